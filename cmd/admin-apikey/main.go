@@ -77,7 +77,7 @@ func defaultAdminAPIKeyDeps() adminAPIKeyDeps {
 
 			userRepo := repositories.NewUserRepository(db)
 			apiKeyRepo := repositories.NewApiKeyRepository(db)
-			apiKeyUsecase := usecases.NewApiKeyUsecase(apiKeyRepo, userRepo, cfg.Security.ApiKeyEncryptionKey)
+			apiKeyUsecase := usecases.NewApiKeyUsecase(apiKeyRepo, userRepo, cfg.Security.ApiKeyEncryptionKey, cfg.Security.ApiKeyPepper)
 			return adminAPIKeyRuntimeImpl{
 				userRepo:   userRepo,
 				apiKeyCase: apiKeyUsecase,
@@ -18,6 +18,7 @@ import (
 	"payment-kita.backend/internal/domain/entities"
 	domainrepo "payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/utils"
 )
 
 func TestParseUserID(t *testing.T) {
@@ -330,7 +331,9 @@ func (s runtimeUserRepoStub) GetByEmail(context.Context, string) (*entities.User
 func (s runtimeUserRepoStub) Update(context.Context, *entities.User) error            { return nil }
 func (s runtimeUserRepoStub) UpdatePassword(context.Context, uuid.UUID, string) error { return nil }
 func (s runtimeUserRepoStub) SoftDelete(context.Context, uuid.UUID) error             { return nil }
-func (s runtimeUserRepoStub) List(context.Context, string) ([]*entities.User, error)  { return nil, nil }
+func (s runtimeUserRepoStub) List(context.Context, domainrepo.UserFilter, utils.PaginationParams) ([]*entities.User, int64, error) {
+	return nil, 0, nil
+}
 
 type runtimeAPIKeyRepoStub struct {
 	createErr error
@@ -354,7 +357,7 @@ func TestAdminRuntimeImpl_WrapperMethods(t *testing.T) {
 	user := &entities.User{ID: userID, Role: entities.UserRoleAdmin}
 
 	var userRepo domainrepo.UserRepository = runtimeUserRepoStub{user: user}
-	apiKeyCase := usecases.NewApiKeyUsecase(runtimeAPIKeyRepoStub{}, userRepo, "0000000000000000000000000000000000000000000000000000000000000000")
+	apiKeyCase := usecases.NewApiKeyUsecase(runtimeAPIKeyRepoStub{}, userRepo, "0000000000000000000000000000000000000000000000000000000000000000", "")
 	rt := adminAPIKeyRuntimeImpl{userRepo: userRepo, apiKeyCase: apiKeyCase}
 
 	got, err := rt.GetUserByID(context.Background(), userID)
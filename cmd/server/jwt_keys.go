@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"payment-kita.backend/internal/config"
+	"payment-kita.backend/pkg/jwt"
+)
+
+var readJWTKeyFile = os.ReadFile
+
+// newJWTService builds the token service from config. HS256 keeps the shared-secret setup;
+// RS256/ES256 load the signing key plus any previous public keys still valid during rotation.
+func newJWTService(cfg config.JWTConfig) (*jwt.JWTService, error) {
+	if cfg.Algorithm == "" || cfg.Algorithm == jwt.AlgorithmHS256 {
+		return jwt.NewJWTService(cfg.Secret, cfg.AccessExpiry, cfg.RefreshExpiry), nil
+	}
+
+	keyPEM := []byte(strings.ReplaceAll(cfg.PrivateKey, `\n`, "\n"))
+	if cfg.PrivateKeyFile != "" {
+		data, err := readJWTKeyFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read JWT private key: %w", err)
+		}
+		keyPEM = data
+	}
+	privateKey, err := jwt.ParsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parse JWT private key: %w", err)
+	}
+
+	svc, err := jwt.NewAsymmetricJWTService(cfg.Algorithm, cfg.KeyID, privateKey, cfg.AccessExpiry, cfg.RefreshExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("init %s JWT service: %w", cfg.Algorithm, err)
+	}
+
+	for _, entry := range cfg.VerificationKeys {
+		kid, path, ok := strings.Cut(entry, "=")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid JWT verification key entry %q (want kid=path)", entry)
+		}
+		data, err := readJWTKeyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read JWT verification key %s: %w", kid, err)
+		}
+		publicKey, err := jwt.ParsePublicKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse JWT verification key %s: %w", kid, err)
+		}
+		if err := svc.AddVerificationKey(kid, publicKey); err != nil {
+			return nil, fmt.Errorf("add JWT verification key %s: %w", kid, err)
+		}
+	}
+	return svc, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"payment-kita.backend/internal/config"
+	"payment-kita.backend/pkg/jwt"
+)
+
+func TestNewJWTService(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, _ := x509.MarshalECPrivateKey(ecKey)
+	privatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}))
+
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldDER, _ := x509.MarshalPKIXPublicKey(&oldKey.PublicKey)
+	oldPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: oldDER})
+
+	origRead := readJWTKeyFile
+	t.Cleanup(func() { readJWTKeyFile = origRead })
+	readJWTKeyFile = func(path string) ([]byte, error) {
+		switch path {
+		case "/keys/old.pem":
+			return oldPEM, nil
+		case "/keys/current.pem":
+			return []byte(privatePEM), nil
+		}
+		return nil, errors.New("missing")
+	}
+
+	base := config.JWTConfig{Secret: "secret", AccessExpiry: time.Minute, RefreshExpiry: time.Hour}
+
+	t.Run("hs256 default", func(t *testing.T) {
+		svc, err := newJWTService(base)
+		if err != nil || svc.Algorithm() != jwt.AlgorithmHS256 {
+			t.Fatalf("expected HS256 service, got %v %v", svc, err)
+		}
+	})
+
+	t.Run("es256 with inline key and rotation key", func(t *testing.T) {
+		cfg := base
+		cfg.Algorithm = jwt.AlgorithmES256
+		cfg.KeyID = "current"
+		// Inline env values commonly carry escaped newlines.
+		cfg.PrivateKey = strings.ReplaceAll(privatePEM, "\n", `\n`)
+		cfg.VerificationKeys = []string{"old=/keys/old.pem"}
+		svc, err := newJWTService(cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := len(svc.JWKS().Keys); got != 2 {
+			t.Fatalf("expected 2 published keys, got %d", got)
+		}
+	})
+
+	t.Run("es256 from key file", func(t *testing.T) {
+		cfg := base
+		cfg.Algorithm = jwt.AlgorithmES256
+		cfg.KeyID = "current"
+		cfg.PrivateKeyFile = "/keys/current.pem"
+		if _, err := newJWTService(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	for name, mutate := range map[string]func(*config.JWTConfig){
+		"missing key":        func(c *config.JWTConfig) {},
+		"missing key file":   func(c *config.JWTConfig) { c.PrivateKeyFile = "/keys/none.pem" },
+		"unsupported alg":    func(c *config.JWTConfig) { c.Algorithm = "PS512"; c.PrivateKey = privatePEM },
+		"missing kid":        func(c *config.JWTConfig) { c.KeyID = ""; c.PrivateKey = privatePEM },
+		"bad rotation entry": func(c *config.JWTConfig) { c.PrivateKey = privatePEM; c.VerificationKeys = []string{"old"} },
+		"missing rotation key": func(c *config.JWTConfig) {
+			c.PrivateKey = privatePEM
+			c.VerificationKeys = []string{"old=/keys/none.pem"}
+		},
+		"bad rotation key": func(c *config.JWTConfig) {
+			c.PrivateKey = privatePEM
+			c.VerificationKeys = []string{"old=/keys/current.pem"}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := base
+			cfg.Algorithm = jwt.AlgorithmES256
+			cfg.KeyID = "current"
+			mutate(&cfg)
+			if _, err := newJWTService(cfg); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"encoding/hex"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -22,11 +24,9 @@ import (
 	"payment-kita.backend/internal/infrastructure/blockchain"
 	"payment-kita.backend/internal/infrastructure/jobs"
 	"payment-kita.backend/internal/infrastructure/repositories"
-	servicesimpl "payment-kita.backend/internal/infrastructure/services"
 	"payment-kita.backend/internal/interfaces/http/handlers"
 	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/usecases"
-	"payment-kita.backend/pkg/jwt"
 	"payment-kita.backend/pkg/logger"
 	"payment-kita.backend/pkg/redis"
 )
@@ -49,7 +49,15 @@ var (
 	getStdDB        = func(db *gorm.DB) (*sql.DB, error) { return db.DB() }
 )
 
+// redisMonitorInterval is how often a server started with REDIS_REQUIRED=false re-checks Redis
+const redisMonitorInterval = 15 * time.Second
+
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s\n\nConfiguration is read from the environment (or .env):\n\n%s", os.Args[0], config.Describe())
+	}
+	flag.Parse()
+
 	if err := runMainProcess(); err != nil {
 		log.Fatal(err)
 	}
@@ -63,6 +71,9 @@ func runMainProcess() error {
 
 	// Load configuration
 	cfg := loadCfg()
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
 
 	// Initialize Logger
 	initLog(cfg.Server.Env)
@@ -70,10 +81,17 @@ func runMainProcess() error {
 
 	// Initialize Redis
 	if err := initRedis(cfg.Redis.URL, cfg.Redis.PASSWORD); err != nil {
-		logger.Error(context.Background(), "Failed to initialize Redis", zap.Error(err))
-		return fmt.Errorf("failed to initialize redis: %w", err)
+		if cfg.Redis.Required {
+			logger.Error(context.Background(), "Failed to initialize Redis", zap.Error(err))
+			return fmt.Errorf("failed to initialize redis: %w", err)
+		}
+		// REDIS_REQUIRED=false: keep serving payments; idempotency, rate limiting and
+		// sessions degrade until the monitor sees Redis again
+		logger.Warn(context.Background(), "Redis unavailable, starting in degraded mode", zap.Error(err))
+		redis.SetAvailable(false)
+	} else {
+		logger.Info(context.Background(), "Redis initialized")
 	}
-	logger.Info(context.Background(), "Redis initialized")
 
 	// Set Gin mode
 	if cfg.Server.Env == "production" {
@@ -86,6 +104,9 @@ func runMainProcess() error {
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
+	if err := repositories.UseQueryTimeout(db, cfg.Database.QueryTimeout); err != nil {
+		return fmt.Errorf("failed to set database query timeout: %w", err)
+	}
 
 	sqlDB, err := getStdDB(db)
 	if err != nil {
@@ -100,11 +121,10 @@ func runMainProcess() error {
 	}
 
 	// Initialize JWT service
-	jwtService := jwt.NewJWTService(
-		cfg.JWT.Secret,
-		cfg.JWT.AccessExpiry,
-		cfg.JWT.RefreshExpiry,
-	)
+	jwtService, err := newJWTService(cfg.JWT)
+	if err != nil {
+		return err
+	}
 
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
@@ -128,6 +148,7 @@ func runMainProcess() error {
 	apiKeyRepo := repositories.NewApiKeyRepository(db)
 	webhookLogRepo := repositories.NewGormWebhookLogRepository(db)
 	auditLogRepo := repositories.NewAuditLogRepository(db)
+	featureFlagRepo := repositories.NewFeatureFlagRepository(db)
 	resolveAuditRepo := repositories.NewResolveAuditRepository(db)
 	uow := repositories.NewUnitOfWork(db)
 
@@ -143,7 +164,6 @@ func runMainProcess() error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize jwe service: %w", err)
 	}
-	hmacService := servicesimpl.NewHMACService()
 	complianceService := services.NewComplianceService(80)
 
 	// Initialize blockchain client factory
@@ -152,14 +172,25 @@ func runMainProcess() error {
 	// Initialize usecases
 	authUsecase := usecases.NewAuthUsecase(userRepo, emailVerifRepo, walletRepo, chainRepo, merchantRepo, uow, jwtService)
 	// ApiKeyUsecase needs Config for Encryption Key
-	apiKeyUsecase := usecases.NewApiKeyUsecase(apiKeyRepo, userRepo, cfg.Security.ApiKeyEncryptionKey)
-	paymentUsecase := usecases.NewPaymentUsecase(paymentRepo, paymentEventRepo, walletRepo, merchantRepo, smartContractRepo, chainRepo, tokenRepo, bridgeConfigRepo, feeConfigRepo, routePolicyRepo, uow, clientFactory)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(apiKeyRepo, userRepo, cfg.Security.ApiKeyEncryptionKey, cfg.Security.ApiKeyPepper)
+	featureFlagUsecase := usecases.NewFeatureFlagUsecase(featureFlagRepo, cfg.Features.Defaults)
+	maintenanceUsecase := usecases.NewMaintenanceUsecase(cfg.Server.Maintenance, cfg.Server.MaintenanceRetryAfter)
+	allowedReceiverRepo := repositories.NewMerchantAllowedReceiverRepository(db)
+	feeRounding, _ := usecases.ParseFeeRoundingMode(cfg.Payments.FeeRounding)
+	paymentUsecase := usecases.NewPaymentUsecaseWithSettings(paymentRepo, paymentEventRepo, walletRepo, merchantRepo, userRepo, smartContractRepo, chainRepo, tokenRepo, bridgeConfigRepo, feeConfigRepo, routePolicyRepo, uow, clientFactory, allowedReceiverRepo, usecases.PaymentSettings{
+		FeeRounding:       feeRounding,
+		GatewayPauseCheck: cfg.Payments.GatewayPauseCheck,
+		ENSRPCURL:         cfg.Blockchain.ENSRPC,
+		ENSTestnetRPCURL:  cfg.Blockchain.ENSTestnetRPC,
+		BridgeOrder:       cfg.Payments.BridgeDefaultOrder,
+	})
 	// PaymentAppUsecase needs PaymentUsecase, UserRepo, WalletRepo, ChainRepo
-	paymentAppUsecase := usecases.NewPaymentAppUsecase(paymentUsecase, userRepo, walletRepo, chainRepo)
+	paymentIntentNonceRepo := repositories.NewPaymentIntentNonceRepository(db)
+	paymentAppUsecase := usecases.NewPaymentAppUsecaseWithSignedIntents(paymentUsecase, userRepo, walletRepo, chainRepo, paymentIntentNonceRepo, cfg.Server.RequireSignedIntent)
 	merchantUsecase := usecases.NewMerchantUsecase(merchantRepo, userRepo)
 	walletUsecase := usecases.NewWalletUsecase(walletRepo, userRepo, chainRepo)
 
-	paymentRequestUsecase := usecases.NewPaymentRequestUsecase(paymentRequestRepo, merchantRepo, walletRepo, chainRepo, smartContractRepo, tokenRepo, jweService)
+	paymentRequestUsecase := usecases.NewPaymentRequestUsecaseWithReceiverAllowlist(paymentRequestRepo, merchantRepo, walletRepo, chainRepo, smartContractRepo, tokenRepo, jweService, allowedReceiverRepo)
 	partnerQuoteUsecase := usecases.NewPartnerQuoteUsecase(paymentQuoteRepo, tokenRepo, chainRepo, paymentUsecase)
 	partnerPaymentSessionUsecase := usecases.NewPartnerPaymentSessionUsecase(
 		paymentQuoteRepo,
@@ -175,7 +206,7 @@ func runMainProcess() error {
 		paymentUsecase,
 		os.Getenv("PARTNER_CHECKOUT_BASE_URL"),
 	)
-	createPaymentUsecase := usecases.NewCreatePaymentUsecase(
+	createPaymentUsecase := usecases.NewCreatePaymentUsecaseWithReceiverAllowlist(
 		merchantRepo,
 		settlementProfileRepo,
 		walletRepo,
@@ -185,31 +216,53 @@ func runMainProcess() error {
 		repositories.NewPartnerPaymentSessionRepository(db),
 		partnerQuoteUsecase,
 		partnerPaymentSessionUsecase,
+		allowedReceiverRepo,
 	)
 	// Step 3: Webhook Delivery Engine
-	webhookDispatcher := usecases.NewWebhookDispatcher(webhookLogRepo, merchantRepo, hmacService)
+	webhookDispatcher := usecases.NewWebhookDispatcher(webhookLogRepo, merchantRepo)
 	webhookJob := jobs.NewWebhookDeliveryJob(webhookLogRepo, webhookDispatcher)
+	jobSupervisor := jobs.NewSupervisor()
+	if cfg.Jobs.LeaderElection {
+		jobSupervisor = jobs.NewSupervisorWithLeaderElection(jobs.NewLeaderElector(jobs.DefaultLeaderLeaseKey, cfg.Jobs.LeaderLeaseTTL))
+	}
 
-	webhookUsecase := usecases.NewWebhookUsecase(paymentRepo, paymentEventRepo, paymentRequestRepo, repositories.NewPartnerPaymentSessionRepository(db), merchantRepo, webhookLogRepo, webhookDispatcher, uow)
-	onchainAdapterUsecase := usecases.NewOnchainAdapterUsecase(chainRepo, smartContractRepo, clientFactory, cfg.Blockchain.OwnerPrivateKey)
+	webhookUsecase := usecases.NewWebhookUsecaseWithConfirmations(paymentRepo, paymentEventRepo, paymentRequestRepo, repositories.NewPartnerPaymentSessionRepository(db), merchantRepo, webhookLogRepo, webhookDispatcher, uow, chainRepo, usecases.NewRPCChainHeadReader(clientFactory))
+	var onchainAdapterUsecase *usecases.OnchainAdapterUsecase
+	if mode := cfg.Blockchain.OwnerSigner; mode != "" && mode != blockchain.SignerModeLocal {
+		ownerSigner, err := blockchain.NewTxSigner(cfg.Blockchain.OwnerSigner, cfg.Blockchain.OwnerPrivateKey, cfg.Blockchain.OwnerSignerURL, cfg.Blockchain.OwnerAddress)
+		if err != nil {
+			return fmt.Errorf("failed to configure owner signer: %w", err)
+		}
+		onchainAdapterUsecase = usecases.NewOnchainAdapterUsecaseWithSigner(chainRepo, smartContractRepo, clientFactory, ownerSigner)
+	} else {
+		// Local mode keeps the raw key path, where a missing key only disables admin txs
+		onchainAdapterUsecase = usecases.NewOnchainAdapterUsecase(chainRepo, smartContractRepo, clientFactory, cfg.Blockchain.OwnerPrivateKey)
+	}
 	contractConfigAuditUsecase := usecases.NewContractConfigAuditUsecase(chainRepo, smartContractRepo, clientFactory)
-	crosschainConfigUsecase := usecases.NewCrosschainConfigUsecase(chainRepo, tokenRepo, smartContractRepo, clientFactory, onchainAdapterUsecase)
+	crosschainConfigUsecase := usecases.NewCrosschainConfigUsecaseWithStatusStore(chainRepo, tokenRepo, smartContractRepo, clientFactory, onchainAdapterUsecase, repositories.NewCrosschainRouteStatusRepository(db))
 	routeErrorUsecase := usecases.NewRouteErrorUsecase(chainRepo, smartContractRepo, clientFactory)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authUsecase, sessionStore)
 	paymentHandler := handlers.NewPaymentHandler(paymentUsecase)
+	paymentApprovalHandler := handlers.NewPaymentApprovalHandler(paymentUsecase)
 	merchantHandler := handlers.NewMerchantHandler(merchantUsecase)
 	walletHandler := handlers.NewWalletHandler(walletUsecase)
-	chainHandler := handlers.NewChainHandler(chainRepo)
+	rpcPingUsecase := usecases.NewRPCPingUsecase()
+	chainHandler := handlers.NewChainHandler(chainRepo, rpcPingUsecase)
 	tokenHandler := handlers.NewTokenHandler(tokenRepo, chainRepo, paymentUsecase)
-	smartContractHandler := handlers.NewSmartContractHandler(smartContractRepo, chainRepo)
-	paymentRequestHandler := handlers.NewPaymentRequestHandler(paymentRequestUsecase)
+	smartContractHandler := handlers.NewSmartContractHandlerWithCache(smartContractRepo, chainRepo, paymentUsecase)
+	paymentRequestHandler := handlers.NewPaymentRequestHandlerWithPublicMetadata(paymentRequestUsecase, cfg.Server.PublicMetadataKeys)
 	webhookHandler := handlers.NewWebhookHandler(webhookUsecase)
-	adminHandler := handlers.NewAdminHandler(userRepo, merchantRepo, paymentRepo, settlementProfileRepo)
+	adminHandler := handlers.NewAdminHandlerWithSessions(userRepo, merchantRepo, paymentRepo, settlementProfileRepo, jobSupervisor, sessionStore)
 	adminMerchantSettlementHandler := handlers.NewAdminMerchantSettlementHandler(merchantRepo, settlementProfileRepo, chainRepo, tokenRepo)
-	merchantSettlementHandler := handlers.NewMerchantSettlementHandler(merchantRepo, settlementProfileRepo, chainRepo, tokenRepo)
+	receiverAllowlistUsecase := usecases.NewReceiverAllowlistUsecase(allowedReceiverRepo, merchantRepo)
+	merchantSettlementHandler := handlers.NewMerchantSettlementHandlerWithReceiverAllowlist(merchantRepo, settlementProfileRepo, chainRepo, tokenRepo, receiverAllowlistUsecase)
+	receiverAllowlistHandler := handlers.NewReceiverAllowlistHandler(receiverAllowlistUsecase)
 	teamHandler := handlers.NewTeamHandler(teamRepo)
+	featureFlagHandler := handlers.NewFeatureFlagHandler(featureFlagUsecase)
+	maintenanceHandler := handlers.NewMaintenanceHandler(maintenanceUsecase)
+	activityHandler := handlers.NewActivityHandler(usecases.NewActivityUsecase(repositories.NewActivityRepository(db), merchantRepo))
 	apiKeyHandler := handlers.NewApiKeyHandler(apiKeyUsecase)             // Added
 	paymentAppHandler := handlers.NewPaymentAppHandler(paymentAppUsecase) // Added
 	paymentResolveHandler := handlers.NewPaymentResolveHandler(jweService, complianceService, resolveAuditRepo, paymentRequestUsecase)
@@ -217,16 +270,22 @@ func runMainProcess() error {
 	partnerQuoteHandler := handlers.NewPartnerQuoteHandler(partnerQuoteUsecase)
 	partnerPaymentSessionHandler := handlers.NewPartnerPaymentSessionHandler(partnerPaymentSessionUsecase, complianceService, resolveAuditRepo)
 	paymentConfigHandler := handlers.NewPaymentConfigHandler(paymentBridgeRepo, bridgeConfigRepo, feeConfigRepo, chainRepo, tokenRepo)
+	bootstrapHandler := handlers.NewBootstrapHandler(usecases.NewBootstrapUsecase(chainRepo, tokenRepo, paymentBridgeRepo, bridgeConfigRepo, routePolicyRepo))
 	onchainAdapterHandler := handlers.NewOnchainAdapterHandler(onchainAdapterUsecase)
 	contractConfigAuditHandler := handlers.NewContractConfigAuditHandler(contractConfigAuditUsecase)
-	crosschainConfigHandler := handlers.NewCrosschainConfigHandler(crosschainConfigUsecase)
-	crosschainPolicyHandler := handlers.NewCrosschainPolicyHandler(routePolicyRepo, stargateConfigRepo, chainRepo)
+	crosschainConfigHandler := handlers.NewCrosschainConfigHandlerWithBulkOptions(crosschainConfigUsecase, handlers.CrosschainBulkOptions{
+		RecheckConcurrency:  cfg.Blockchain.RecheckConcurrency,
+		RecheckRouteTimeout: cfg.Blockchain.RecheckRouteTimeout,
+	})
+	crosschainPolicyHandler := handlers.NewCrosschainPolicyHandlerWithPreflight(routePolicyRepo, stargateConfigRepo, chainRepo, crosschainConfigUsecase)
 	routeErrorHandler := handlers.NewRouteErrorHandler(routeErrorUsecase)
 	rpcHandler := handlers.NewRpcHandler(chainRepo)
+	rpcPingHandler := handlers.NewRPCPingHandler(rpcPingUsecase)
 	gasProfilerHandler := handlers.NewGasProfilerHandler(clientFactory) // Added gas profiler
 
 	// Create dual auth middleware
 	dualAuthMiddleware := middleware.DualAuthMiddleware(jwtService, apiKeyUsecase, merchantRepo, sessionStore)
+	sessionAuthMiddleware := middleware.AuthMiddlewareWithSuspensionCheck(jwtService, sessionStore, apiKeyUsecase)
 	partnerAuthMiddleware := middleware.ApiKeyPartnerMiddleware(apiKeyUsecase, merchantRepo)
 
 	// Create idempotency middleware
@@ -236,23 +295,73 @@ func runMainProcess() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	expiryJob := jobs.NewPaymentRequestExpiryJob(paymentRequestRepo)
-	go expiryJob.Start(ctx)
-	go webhookJob.Run(ctx)
+	expiryJob := jobs.NewPaymentRequestExpiryJobWithOptions(paymentRequestRepo, jobs.PaymentRequestExpiryOptions{
+		Interval:     cfg.Jobs.PaymentRequestExpiryInterval,
+		BatchSize:    cfg.Jobs.PaymentRequestExpiryBatchSize,
+		BatchTimeout: cfg.Jobs.PaymentRequestExpiryBatchTimeout,
+	})
+	jobSupervisor.Register("payment-request-expiry", expiryJob.Start, expiryJob.Stop)
+	jobSupervisor.Register("webhook-delivery", webhookJob.Run, nil)
+	if cfg.Jobs.ConfirmationRecheckInterval > 0 {
+		recheckJob := jobs.NewConfirmationRecheckJob(webhookUsecase, cfg.Jobs.ConfirmationRecheckInterval, cfg.Jobs.ConfirmationRecheckBatchSize)
+		jobSupervisor.Register("confirmation-recheck", recheckJob.Start, recheckJob.Stop)
+	}
+	if cfg.Blockchain.RouteHealthInterval > 0 {
+		routeHealthJob := jobs.NewCrosschainRouteHealthJob(crosschainConfigUsecase, cfg.Blockchain.RouteHealthInterval)
+		if cfg.Blockchain.RouteAlertURL != "" {
+			notifier, err := usecases.NewRouteAlertNotifier(cfg.Blockchain.RouteAlertNotifier, cfg.Blockchain.RouteAlertURL)
+			if err != nil {
+				return fmt.Errorf("failed to configure route alerts: %w", err)
+			}
+			routeHealthJob = jobs.NewCrosschainRouteHealthJobWithAlerts(crosschainConfigUsecase, cfg.Blockchain.RouteHealthInterval, notifier, cfg.Blockchain.RouteAlertDedupeWindow)
+		}
+		jobSupervisor.Register("crosschain-route-health", routeHealthJob.Start, routeHealthJob.Stop)
+	}
+	jobSupervisor.Start(ctx)
+	if !cfg.Redis.Required {
+		go redis.Monitor(ctx, redisMonitorInterval, func(available bool) {
+			if available {
+				logger.Info(ctx, "Redis reachable again, leaving degraded mode")
+			} else {
+				logger.Warn(ctx, "Redis unreachable, entering degraded mode")
+			}
+		})
+	}
 
 	// Initialize router
 	// Initialize router
 	r := gin.New()
+	if err := configureClientIP(r, cfg.Server.TrustedProxies, cfg.Server.RealIPHeaders); err != nil {
+		return fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
 	r.Use(gin.Recovery())
 	r.Use(middleware.RequestIDMiddleware())
 	r.Use(middleware.LoggerMiddleware())
+	r.Use(middleware.Compress())
+	if cfg.Server.LogBodies && !middleware.BodyLoggingAllowed(cfg.Server.Env) {
+		logger.Warn(context.Background(), "HTTP_LOG_BODIES ignored outside staging and development", zap.String("env", cfg.Server.Env))
+	} else if cfg.Server.LogBodies {
+		r.Use(middleware.BodyLoggerMiddleware(cfg.Server.Env, cfg.Server.LogBodyMaxBytes,
+			cfg.Blockchain.OwnerPrivateKey,
+			cfg.JWT.Secret,
+			cfg.JWT.PrivateKey,
+			cfg.Security.ApiKeyEncryptionKey,
+			cfg.Security.ApiKeyPepper,
+			cfg.Security.SessionEncryptionKey,
+			cfg.Security.JweMasterKey,
+			cfg.Security.IndexerFeedToken,
+		))
+	}
+	r.Use(middleware.Maintenance(maintenanceUsecase))
 	r.Use(idempotencyMiddleware) // Add idempotency middleware
 
 	applyCORSMiddleware(r)
 	registerHealthRoute(r)
-	registerAPIV1Routes(r, routeDeps{
+	registerJWKSRoute(r, jwtService)
+	deps := routeDeps{
 		authHandler:                    authHandler,
 		paymentHandler:                 paymentHandler,
+		paymentApprovalHandler:         paymentApprovalHandler,
 		merchantHandler:                merchantHandler,
 		walletHandler:                  walletHandler,
 		chainHandler:                   chainHandler,
@@ -263,6 +372,7 @@ func runMainProcess() error {
 		adminHandler:                   adminHandler,
 		adminMerchantSettlementHandler: adminMerchantSettlementHandler,
 		merchantSettlementHandler:      merchantSettlementHandler,
+		receiverAllowlistHandler:       receiverAllowlistHandler,
 		teamHandler:                    teamHandler,
 		apiKeyHandler:                  apiKeyHandler,
 		paymentAppHandler:              paymentAppHandler,
@@ -273,15 +383,25 @@ func runMainProcess() error {
 		crosschainPolicyHandler:        crosschainPolicyHandler,
 		routeErrorHandler:              routeErrorHandler,
 		rpcHandler:                     rpcHandler,
+		rpcPingHandler:                 rpcPingHandler,
 		paymentResolveHandler:          paymentResolveHandler,
 		createPaymentHandler:           createPaymentHandler,
 		gasProfilerHandler:             gasProfilerHandler, // Added
 		partnerQuoteHandler:            partnerQuoteHandler,
 		partnerPaymentSessionHandler:   partnerPaymentSessionHandler,
+		featureFlagHandler:             featureFlagHandler,
+		maintenanceHandler:             maintenanceHandler,
+		bootstrapHandler:               bootstrapHandler,
+		activityHandler:                activityHandler,
 		auditLogRepo:                   auditLogRepo,
+		featureChecker:                 featureFlagUsecase,
+		indexerFeedToken:               cfg.Security.IndexerFeedToken,
 		dualAuthMiddleware:             dualAuthMiddleware,
+		sessionAuthMiddleware:          sessionAuthMiddleware,
 		partnerAuthMiddleware:          partnerAuthMiddleware,
-	})
+	}
+	registerAPIV1Routes(r, deps)
+	registerAPIV2Routes(r, deps)
 
 	// Print all registered routes for debugging
 	log.Println("📋 Registered Routes:")
@@ -289,13 +409,14 @@ func runMainProcess() error {
 		log.Printf("   %s %s", route.Method, route.Path)
 	}
 
-	// Graceful shutdown
+	// Graceful shutdown; the handler is registered before the server starts so an early signal
+	// is not lost
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
-		quit := make(chan os.Signal, 1)
-		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 		<-quit
 		log.Println("🛑 Shutting down server...")
-		expiryJob.Stop()
+		jobSupervisor.Stop()
 		cancel()
 	}()
 
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"os"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -37,6 +38,7 @@ func withMainHooks(t *testing.T) {
 		newSessionStore = origNewSessionStore
 		runServer = origRunServer
 		getStdDB = origGetStdDB
+		redis.SetAvailable(true)
 	})
 }
 
@@ -57,6 +59,7 @@ func baseTestConfig() *config.Config {
 		Redis: config.RedisConfig{
 			URL:      "redis://localhost:6379",
 			PASSWORD: "",
+			Required: true,
 		},
 		JWT: config.JWTConfig{
 			Secret:        "secret",
@@ -64,7 +67,8 @@ func baseTestConfig() *config.Config {
 			RefreshExpiry: 24 * time.Hour,
 		},
 		Blockchain: config.BlockchainConfig{
-			OwnerPrivateKey: "",
+			OwnerPrivateKey:    "",
+			RecheckConcurrency: 4,
 		},
 		Security: config.SecurityConfig{
 			ApiKeyEncryptionKey:  "0000000000000000000000000000000000000000000000000000000000000000",
@@ -88,6 +92,51 @@ func TestRunMainProcess_RedisInitError(t *testing.T) {
 	}
 }
 
+func TestRunMainProcess_RedisOptionalStartsDegraded(t *testing.T) {
+	withMainHooks(t)
+
+	loadDotenv = func(...string) error { return nil }
+	loadCfg = func() *config.Config {
+		cfg := baseTestConfig()
+		cfg.Redis.Required = false
+		return cfg
+	}
+	initLog = plog.Init
+	initRedis = func(string, string) error { return errors.New("redis down") }
+	openDB = func(string) (*gorm.DB, error) {
+		return gorm.Open(sqlite.Open("file:main_redis_degraded?mode=memory&cache=shared"), &gorm.Config{})
+	}
+	newSessionStore = redis.NewSessionStore
+	runServer = func(*gin.Engine, string) error { return nil }
+
+	if err := runMainProcess(); err != nil {
+		t.Fatalf("expected degraded start, got %v", err)
+	}
+	if redis.Available() {
+		t.Fatal("expected redis to be marked unavailable")
+	}
+}
+
+func TestRunMainProcess_InvalidConfig(t *testing.T) {
+	withMainHooks(t)
+
+	loadDotenv = func(...string) error { return nil }
+	loadCfg = func() *config.Config {
+		cfg := baseTestConfig()
+		cfg.Server.Port = "0"
+		return cfg
+	}
+	initRedis = func(string, string) error {
+		t.Fatal("nothing must start with an invalid configuration")
+		return nil
+	}
+
+	err := runMainProcess()
+	if err == nil || !strings.Contains(err.Error(), "SERVER_PORT") {
+		t.Fatalf("expected SERVER_PORT config error, got %v", err)
+	}
+}
+
 func TestRunMainProcess_DBOpenError(t *testing.T) {
 	withMainHooks(t)
 
@@ -210,6 +259,10 @@ func TestRunMainProcess_ProductionModeAndPingWarnPath(t *testing.T) {
 	loadCfg = func() *config.Config {
 		cfg := baseTestConfig()
 		cfg.Server.Env = "production"
+		// Production refuses the all-zero placeholder keys
+		cfg.Security.ApiKeyEncryptionKey = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+		cfg.Security.SessionEncryptionKey = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+		cfg.Security.JweMasterKey = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
 		return cfg
 	}
 	initLog = plog.Init
@@ -1,6 +1,9 @@
 package main
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"payment-kita.backend/pkg/jwt"
+)
 
 var allowedCORSOrigins = map[string]struct{}{
 	"http://localhost:3000":            {},
@@ -41,3 +44,20 @@ func registerHealthRoute(r *gin.Engine) {
 		})
 	})
 }
+
+// registerJWKSRoute publishes the public token verification keys for other services
+func registerJWKSRoute(r *gin.Engine, jwtService *jwt.JWTService) {
+	r.GET("/.well-known/jwks.json", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(200, jwtService.JWKS())
+	})
+}
+
+// configureClientIP makes c.ClientIP(), used by rate limiting, audit and request logs, the real
+// client address: the first address in headers that is not a trusted proxy, read only when the
+// request itself comes from a trusted proxy. With no trusted proxies it is the peer address.
+func configureClientIP(r *gin.Engine, trustedProxies, headers []string) error {
+	r.ForwardedByClientIP = len(headers) > 0
+	r.RemoteIPHeaders = headers
+	return r.SetTrustedProxies(trustedProxies)
+}
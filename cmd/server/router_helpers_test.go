@@ -5,8 +5,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"payment-kita.backend/pkg/jwt"
 )
 
 func TestApplyCORSMiddleware(t *testing.T) {
@@ -92,3 +94,69 @@ func TestRegisterHealthRoute(t *testing.T) {
 		t.Fatalf("unexpected health payload: %+v", body)
 	}
 }
+
+func TestRegisterJWKSRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	registerJWKSRoute(r, jwt.NewJWTService("secret", time.Minute, time.Hour))
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var body jwt.JWKSet
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if body.Keys == nil || len(body.Keys) != 0 {
+		t.Fatalf("HS256 must publish an empty key set, got %+v", body)
+	}
+}
+
+func TestConfigureClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(trustedProxies []string) *gin.Engine {
+		r := gin.New()
+		if err := configureClientIP(r, trustedProxies, []string{"X-Forwarded-For", "X-Real-IP"}); err != nil {
+			t.Fatalf("configure: %v", err)
+		}
+		r.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+		return r
+	}
+	clientIP := func(r *gin.Engine, remoteAddr string, headers map[string]string) string {
+		req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+		req.RemoteAddr = remoteAddr
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+	forwarded := map[string]string{"X-Forwarded-For": "203.0.113.9, 10.0.0.5"}
+
+	// No trusted proxies: forwarded headers are ignored
+	if got := clientIP(newRouter(nil), "198.51.100.1:4000", forwarded); got != "198.51.100.1" {
+		t.Fatalf("expected peer address, got %s", got)
+	}
+
+	// From a trusted load balancer, the first untrusted hop is the client
+	r := newRouter([]string{"10.0.0.0/8"})
+	if got := clientIP(r, "10.0.0.2:4000", forwarded); got != "203.0.113.9" {
+		t.Fatalf("expected forwarded client, got %s", got)
+	}
+	if got := clientIP(r, "10.0.0.2:4000", map[string]string{"X-Real-IP": "203.0.113.10"}); got != "203.0.113.10" {
+		t.Fatalf("expected X-Real-IP client, got %s", got)
+	}
+	// A client talking to us directly cannot spoof its address
+	if got := clientIP(r, "198.51.100.1:4000", forwarded); got != "198.51.100.1" {
+		t.Fatalf("expected spoofed header to be ignored, got %s", got)
+	}
+
+	if err := configureClientIP(gin.New(), []string{"lb.internal"}, nil); err == nil {
+		t.Fatal("expected an invalid proxy to be rejected")
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"payment-kita.backend/internal/domain"
+	"payment-kita.backend/internal/domain/entities"
 	"payment-kita.backend/internal/interfaces/http/handlers"
 	"payment-kita.backend/internal/interfaces/http/middleware"
 )
@@ -12,6 +13,7 @@ import (
 type routeDeps struct {
 	authHandler                    *handlers.AuthHandler
 	paymentHandler                 *handlers.PaymentHandler
+	paymentApprovalHandler         *handlers.PaymentApprovalHandler
 	merchantHandler                *handlers.MerchantHandler
 	walletHandler                  *handlers.WalletHandler
 	chainHandler                   *handlers.ChainHandler
@@ -22,6 +24,7 @@ type routeDeps struct {
 	adminHandler                   *handlers.AdminHandler
 	adminMerchantSettlementHandler *handlers.AdminMerchantSettlementHandler
 	merchantSettlementHandler      *handlers.MerchantSettlementHandler
+	receiverAllowlistHandler       *handlers.ReceiverAllowlistHandler
 	teamHandler                    *handlers.TeamHandler
 	apiKeyHandler                  *handlers.ApiKeyHandler
 	paymentAppHandler              *handlers.PaymentAppHandler
@@ -32,18 +35,28 @@ type routeDeps struct {
 	crosschainPolicyHandler        *handlers.CrosschainPolicyHandler
 	routeErrorHandler              *handlers.RouteErrorHandler
 	rpcHandler                     *handlers.RpcHandler
+	rpcPingHandler                 *handlers.RPCPingHandler
 	paymentResolveHandler          *handlers.PaymentResolveHandler
 	gasProfilerHandler             *handlers.GasProfilerHandler
 	createPaymentHandler           *handlers.CreatePaymentHandler
 	partnerQuoteHandler            *handlers.PartnerQuoteHandler
 	partnerPaymentSessionHandler   *handlers.PartnerPaymentSessionHandler
+	featureFlagHandler             *handlers.FeatureFlagHandler
+	maintenanceHandler             *handlers.MaintenanceHandler
+	activityHandler                *handlers.ActivityHandler
+	bootstrapHandler               *handlers.BootstrapHandler
 	auditLogRepo                   domain.AuditLogRepository
+	featureChecker                 middleware.FeatureChecker
+	indexerFeedToken               string
 	dualAuthMiddleware             gin.HandlerFunc
-	partnerAuthMiddleware          gin.HandlerFunc
+	// sessionAuthMiddleware only accepts a user's session or JWT, never an API key
+	sessionAuthMiddleware gin.HandlerFunc
+	partnerAuthMiddleware gin.HandlerFunc
 }
 
 func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 	v1 := r.Group("/api/v1")
+	v1.Use(middleware.ImpersonationAuditMiddleware(d.auditLogRepo))
 	{
 		legacyPaymentRequestsDeprecation := middleware.DeprecationMiddleware(middleware.DeprecationOptions{
 			Replacement:    "/api/v1/create-payment",
@@ -63,31 +76,48 @@ func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 			EndpointFamily: "legacy_resolve_code",
 			Mode:           middleware.LegacyModeFromEnv("LEGACY_RESOLVE_PAYMENT_CODE_MODE"),
 		})
+		// Superseded by /api/v2/payments; no sunset is scheduled yet
+		v1PaymentsCreateDeprecation := middleware.DeprecationMiddleware(middleware.DeprecationOptions{
+			Replacement:    "/api/v2/payments",
+			EndpointFamily: "v1_payments_create",
+			Mode:           middleware.LegacyModeFromEnv("LEGACY_V1_PAYMENTS_CREATE_MODE"),
+		})
+		// Config lists change rarely but load with every dashboard; let clients revalidate them
+		configETag := middleware.ETag()
 
 		// Auth routes (public)
 		auth := v1.Group("/auth")
 		{
 			auth.POST("/register", d.authHandler.Register)
+			auth.POST("/wallet-challenge", d.authHandler.WalletChallenge)
 			auth.POST("/login", d.authHandler.Login)
 			auth.POST("/verify-email", d.authHandler.VerifyEmail)
 			auth.POST("/refresh", d.authHandler.RefreshToken)
 			auth.GET("/session-expiry", d.authHandler.GetSessionExpiry)
-			auth.GET("/me", d.dualAuthMiddleware, d.authHandler.GetMe)
-			auth.POST("/change-password", d.dualAuthMiddleware, d.authHandler.ChangePassword)
+			auth.GET("/me", d.sessionAuthMiddleware, d.authHandler.GetMe)
+			auth.POST("/change-password", d.sessionAuthMiddleware, d.authHandler.ChangePassword)
 		}
 
 		// Payment routes (protected)
 		payments := v1.Group("/payments")
 		payments.Use(d.dualAuthMiddleware)
 		{
-			payments.POST("", middleware.IdempotencyMiddleware(), d.paymentHandler.CreatePayment)
+			payments.POST("", v1PaymentsCreateDeprecation, middleware.IdempotencyMiddleware(), d.paymentHandler.CreatePayment)
+			payments.POST("/build-calldata", d.paymentHandler.BuildPaymentCalldata)
+			payments.GET("/approval-target", d.paymentHandler.GetApprovalTarget)
 			payments.GET("/:id", d.paymentHandler.GetPayment)
 			payments.GET("", d.paymentHandler.ListPayments)
 			payments.GET("/:id/events", d.paymentHandler.GetPaymentEvents)
+			payments.GET("/:id/receipt.pdf", d.paymentHandler.GetPaymentReceipt)
 			payments.GET("/:id/privacy-status", d.paymentHandler.GetPaymentPrivacyStatus)
 			payments.POST("/:id/privacy/retry", d.paymentHandler.RetryPrivacyForward)
 			payments.POST("/:id/privacy/claim", d.paymentHandler.ClaimPrivacyEscrow)
-			payments.POST("/:id/privacy/refund", d.paymentHandler.RefundPrivacyEscrow)
+			payments.POST("/:id/privacy/refund", middleware.RequireFeature(d.featureChecker, entities.FeatureRefunds), d.paymentHandler.RefundPrivacyEscrow)
+		}
+
+		// Unified activity feed (protected)
+		if d.activityHandler != nil {
+			v1.GET("/activity", d.dualAuthMiddleware, d.activityHandler.ListActivity)
 		}
 
 		// Payment Request routes (protected for merchants)
@@ -95,8 +125,10 @@ func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 		paymentRequests.Use(d.dualAuthMiddleware, legacyPaymentRequestsDeprecation)
 		{
 			paymentRequests.POST("", middleware.IdempotencyMiddleware(), d.paymentRequestHandler.CreatePaymentRequest)
+			paymentRequests.POST("/batch", middleware.IdempotencyMiddleware(), d.paymentRequestHandler.CreatePaymentRequestBatch)
 			paymentRequests.GET("", d.paymentRequestHandler.ListPaymentRequests)
 			paymentRequests.GET("/:id", d.paymentRequestHandler.GetPaymentRequest)
+			paymentRequests.POST("/:id/cancel", d.paymentRequestHandler.CancelPaymentRequest)
 		}
 
 		// Public payment request route (for payers)
@@ -151,6 +183,7 @@ func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 		wallets := v1.Group("/wallets")
 		wallets.Use(d.dualAuthMiddleware)
 		{
+			wallets.POST("/challenge", d.walletHandler.WalletChallenge)
 			wallets.POST("/connect", d.walletHandler.ConnectWallet)
 			wallets.GET("", d.walletHandler.ListWallets)
 			wallets.PUT("/:id/primary", d.walletHandler.SetPrimaryWallet)
@@ -163,6 +196,7 @@ func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 		{
 			merchants.POST("/apply", d.merchantHandler.ApplyMerchant)
 			merchants.GET("/status", d.merchantHandler.GetMerchantStatus)
+			merchants.POST("/webhooks/:id/test", d.webhookHandler.TestMerchantWebhook)
 			if d.createPaymentHandler != nil {
 				merchants.POST("/create-payment", d.createPaymentHandler.CreatePayment)
 			}
@@ -170,26 +204,34 @@ func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 				merchants.GET("/settlement-profile", d.merchantSettlementHandler.GetMySettlementProfile)
 				merchants.PUT("/settlement-profile", d.merchantSettlementHandler.UpsertMySettlementProfile)
 			}
+			merchants.GET("/allowed-receivers", d.receiverAllowlistHandler.ListMyAllowedReceivers)
+			merchants.POST("/allowed-receivers", d.receiverAllowlistHandler.AddMyAllowedReceiver)
+			merchants.DELETE("/allowed-receivers/:receiverId", d.receiverAllowlistHandler.RemoveMyAllowedReceiver)
 		}
 
+		// Checkout bootstrap (public)
+		v1.GET("/bootstrap", configETag, d.bootstrapHandler.GetBootstrap)
+
 		// Chain routes (public)
 		chains := v1.Group("/chains")
 		{
-			chains.GET("", d.chainHandler.ListChains)
+			chains.GET("", configETag, d.chainHandler.ListChains)
+			chains.GET("/:id/tokens", d.tokenHandler.ListChainTokens)
 		}
 
 		// Token routes (public)
 		tokens := v1.Group("/tokens")
 		{
-			tokens.GET("", d.tokenHandler.ListSupportedTokens)
+			tokens.GET("", configETag, d.tokenHandler.ListSupportedTokens)
 			tokens.GET("/stablecoins", d.tokenHandler.ListStablecoins)
+			tokens.GET("/by-symbol", configETag, d.tokenHandler.ListTokensBySymbol)
 			tokens.GET("/check-pair", d.tokenHandler.CheckPairSupport)
 		}
 
 		// Smart Contract routes (public read, protected write)
 		contracts := v1.Group("/contracts")
 		{
-			contracts.GET("", d.smartContractHandler.ListSmartContracts)
+			contracts.GET("", configETag, d.smartContractHandler.ListSmartContracts)
 			contracts.GET("/lookup", d.smartContractHandler.GetContractByChainAndAddress)
 			contracts.GET("/:id", d.smartContractHandler.GetSmartContract)
 		}
@@ -203,15 +245,15 @@ func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 		// Payment config routes (public read)
 		paymentBridges := v1.Group("/payment-bridges")
 		{
-			paymentBridges.GET("", d.paymentConfigHandler.ListPaymentBridges)
+			paymentBridges.GET("", configETag, d.paymentConfigHandler.ListPaymentBridges)
 		}
 		bridgeConfigs := v1.Group("/bridge-configs")
 		{
-			bridgeConfigs.GET("", d.paymentConfigHandler.ListBridgeConfigs)
+			bridgeConfigs.GET("", configETag, d.paymentConfigHandler.ListBridgeConfigs)
 		}
 		feeConfigs := v1.Group("/fee-configs")
 		{
-			feeConfigs.GET("", d.paymentConfigHandler.ListFeeConfigs)
+			feeConfigs.GET("", configETag, d.paymentConfigHandler.ListFeeConfigs)
 		}
 
 		// Protected smart contract routes (admin only)
@@ -246,58 +288,96 @@ func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 			webhooks.POST("/indexer", d.webhookHandler.HandleIndexerWebhook)
 		}
 
-		// Admin routes (protected)
-		admin := v1.Group("/admin")
-		admin.Use(d.dualAuthMiddleware, middleware.RequireAdmin())
+		// Contract feed for the indexer, on its own service credential rather than an admin's
+		indexer := v1.Group("/indexer")
+		indexer.Use(middleware.ServiceTokenAuth(d.indexerFeedToken))
 		{
-			admin.GET("/users", d.adminHandler.ListUsers)
-			admin.GET("/merchants", d.adminHandler.ListMerchants)
+			indexer.GET("/contracts", d.smartContractHandler.ListContractIndexing)
+		}
+
+		// Admin routes (protected). SUPPORT and FINANCE can read everything, FINANCE also manages
+		// fees and settlement, and every other change is ADMIN only.
+		adminAPI := v1.Group("/admin")
+		adminAPI.Use(d.dualAuthMiddleware)
+		adminRead := adminAPI.Group("", middleware.RequireAdminRead())
+		finance := adminAPI.Group("", middleware.RequireFinance())
+		admin := adminAPI.Group("", middleware.RequireAdmin())
+		{
+			adminRead.GET("/users", d.adminHandler.ListUsers)
+			finance.PUT("/users/:id/fee-exempt", d.adminHandler.UpdateUserFeeExempt)
+			admin.PUT("/users/:id/role", d.adminHandler.UpdateUserRole)
+			admin.PUT("/users/:id/suspended", d.adminHandler.UpdateUserSuspended)
+			admin.POST("/users/:id/impersonate", d.authHandler.Impersonate)
+			adminRead.GET("/merchants", d.adminHandler.ListMerchants)
 			admin.PUT("/merchants/:id/status", d.adminHandler.UpdateMerchantStatus)
+			finance.PUT("/merchants/:id/fee-exempt", d.adminHandler.UpdateMerchantFeeExempt)
+			admin.PUT("/merchants/:id/confirmations", d.adminHandler.UpdateMerchantConfirmations)
 			if d.createPaymentHandler != nil {
 				admin.POST("/merchants/:id/create-payment", d.createPaymentHandler.CreatePaymentAdmin)
 			}
-			admin.GET("/merchants/:id/settlement-profile", d.adminMerchantSettlementHandler.GetSettlementProfile)
-			admin.PUT("/merchants/:id/settlement-profile", d.adminMerchantSettlementHandler.UpsertSettlementProfile)
-			admin.GET("/stats", d.adminHandler.GetStats)
-			admin.GET("/diagnostics/legacy-endpoints", d.adminHandler.GetLegacyEndpointObservability)
-			admin.GET("/diagnostics/settlement-profile-gaps", d.adminHandler.GetSettlementProfileGaps)
-
+			adminRead.GET("/merchants/:id/settlement-profile", d.adminMerchantSettlementHandler.GetSettlementProfile)
+			finance.PUT("/merchants/:id/settlement-profile", d.adminMerchantSettlementHandler.UpsertSettlementProfile)
+			adminRead.GET("/merchants/:id/allowed-receivers", d.receiverAllowlistHandler.ListAllowedReceivers)
+			admin.POST("/merchants/:id/allowed-receivers", d.receiverAllowlistHandler.AddAllowedReceiver)
+			admin.DELETE("/merchants/:id/allowed-receivers/:receiverId", d.receiverAllowlistHandler.RemoveAllowedReceiver)
+			adminRead.GET("/payments/pending-approval", d.paymentApprovalHandler.ListPendingApproval)
+			admin.POST("/payments/:id/approve", d.paymentApprovalHandler.ApprovePayment)
+			admin.POST("/payments/:id/reject", d.paymentApprovalHandler.RejectPayment)
+			adminRead.GET("/stats", d.adminHandler.GetStats)
+			adminRead.GET("/diagnostics/legacy-endpoints", d.adminHandler.GetLegacyEndpointObservability)
+			adminRead.GET("/diagnostics/settlement-profile-gaps", d.adminHandler.GetSettlementProfileGaps)
+			adminRead.GET("/diagnostics/jobs", d.adminHandler.GetJobHealth)
+
+			adminRead.GET("/feature-flags", d.featureFlagHandler.ListFeatureFlags)
+			admin.PUT("/feature-flags/:name", d.featureFlagHandler.SetFeatureFlag)
+			admin.DELETE("/feature-flags/:name", d.featureFlagHandler.DeleteFeatureFlag)
+
+			adminRead.GET("/maintenance", d.maintenanceHandler.GetMaintenance)
+			admin.POST("/maintenance", d.maintenanceHandler.SetMaintenance)
+
+			adminRead.GET("/chains", configETag, d.chainHandler.ListChains)
 			admin.POST("/chains", d.chainHandler.CreateChain)
+			admin.POST("/chains/ping-rpc", d.rpcPingHandler.PingRPC)
 			admin.PUT("/chains/:id", d.chainHandler.UpdateChain)
+			admin.POST("/chains/:id/deactivate", d.chainHandler.DeactivateChain)
 			admin.DELETE("/chains/:id", d.chainHandler.DeleteChain)
 
-			admin.GET("/rpcs", d.rpcHandler.ListRPCs)
+			adminRead.GET("/rpcs", d.rpcHandler.ListRPCs)
 			admin.POST("/rpcs", d.rpcHandler.CreateRPC)
 			admin.PUT("/rpcs/:id", d.rpcHandler.UpdateRPC)
 			admin.DELETE("/rpcs/:id", d.rpcHandler.DeleteRPC)
 			admin.POST("/webhooks/:id/retry", d.webhookHandler.RetryWebhook)
 
-			admin.GET("/tokens", d.tokenHandler.ListSupportedTokens)
+			adminRead.GET("/tokens", configETag, d.tokenHandler.ListSupportedTokens)
 			admin.POST("/tokens", d.tokenHandler.CreateToken)
+			admin.POST("/tokens/bulk-activate", d.tokenHandler.BulkActivateTokens)
+			admin.POST("/tokens/bulk-delete", d.tokenHandler.BulkDeleteTokens)
+			admin.POST("/tokens/:id/deactivate", d.tokenHandler.DeactivateToken)
 			admin.PUT("/tokens/:id", d.tokenHandler.UpdateToken)
 			admin.DELETE("/tokens/:id", d.tokenHandler.DeleteToken)
 
-			admin.GET("/teams", d.teamHandler.ListAdminTeams)
+			adminRead.GET("/teams", d.teamHandler.ListAdminTeams)
 			admin.POST("/teams", d.teamHandler.CreateTeam)
 			admin.PUT("/teams/:id", d.teamHandler.UpdateTeam)
 			admin.DELETE("/teams/:id", d.teamHandler.DeleteTeam)
 
-			admin.GET("/payment-bridges", d.paymentConfigHandler.ListPaymentBridges)
+			adminRead.GET("/payment-bridges", configETag, d.paymentConfigHandler.ListPaymentBridges)
 			admin.POST("/payment-bridges", d.paymentConfigHandler.CreatePaymentBridge)
 			admin.PUT("/payment-bridges/:id", d.paymentConfigHandler.UpdatePaymentBridge)
 			admin.DELETE("/payment-bridges/:id", d.paymentConfigHandler.DeletePaymentBridge)
 
-			admin.GET("/bridge-configs", d.paymentConfigHandler.ListBridgeConfigs)
+			adminRead.GET("/bridge-configs", configETag, d.paymentConfigHandler.ListBridgeConfigs)
 			admin.POST("/bridge-configs", d.paymentConfigHandler.CreateBridgeConfig)
 			admin.PUT("/bridge-configs/:id", d.paymentConfigHandler.UpdateBridgeConfig)
 			admin.DELETE("/bridge-configs/:id", d.paymentConfigHandler.DeleteBridgeConfig)
 
-			admin.GET("/fee-configs", d.paymentConfigHandler.ListFeeConfigs)
-			admin.POST("/fee-configs", d.paymentConfigHandler.CreateFeeConfig)
-			admin.PUT("/fee-configs/:id", d.paymentConfigHandler.UpdateFeeConfig)
-			admin.DELETE("/fee-configs/:id", d.paymentConfigHandler.DeleteFeeConfig)
+			adminRead.GET("/fee-configs", configETag, d.paymentConfigHandler.ListFeeConfigs)
+			finance.POST("/fee-configs", d.paymentConfigHandler.CreateFeeConfig)
+			finance.PUT("/fee-configs/:id", d.paymentConfigHandler.UpdateFeeConfig)
+			finance.DELETE("/fee-configs/:id", d.paymentConfigHandler.DeleteFeeConfig)
 
-			admin.GET("/onchain-adapters/status", d.onchainAdapterHandler.GetStatus)
+			adminRead.GET("/onchain-adapters/status", d.onchainAdapterHandler.GetStatus)
+			adminRead.GET("/onchain-adapters/diagnostics", d.onchainAdapterHandler.Diagnostics)
 			admin.POST("/onchain-adapters/register", d.onchainAdapterHandler.RegisterAdapter)
 			admin.POST("/onchain-adapters/default-bridge", d.onchainAdapterHandler.SetDefaultBridgeType)
 			admin.POST("/onchain-adapters/hyperbridge-config", d.onchainAdapterHandler.SetHyperbridgeConfig)
@@ -305,28 +385,37 @@ func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 			admin.POST("/onchain-adapters/ccip-config", d.onchainAdapterHandler.SetCCIPConfig)
 			admin.POST("/onchain-adapters/stargate-config", d.onchainAdapterHandler.SetStargateConfig)
 			admin.POST("/onchain-adapters/stargate-configure-e2e", d.onchainAdapterHandler.ConfigureStargateE2E)
-			admin.GET("/onchain-adapters/stargate-e2e-status", d.onchainAdapterHandler.GetStargateE2EStatus)
+			adminRead.GET("/onchain-adapters/stargate-e2e-status", d.onchainAdapterHandler.GetStargateE2EStatus)
+			adminRead.GET("/contracts", configETag, d.smartContractHandler.ListSmartContracts)
+			admin.POST("/contracts/bulk-activate", d.smartContractHandler.BulkActivateSmartContracts)
+			admin.POST("/contracts/import-abi", d.smartContractHandler.ImportContractABI)
+			admin.POST("/contracts/:id/activate", d.smartContractHandler.ActivateSmartContract)
 			admin.POST("/contracts/interact", d.onchainAdapterHandler.Interact)
-			admin.GET("/contracts/config-check", d.contractConfigAuditHandler.Check)
-			admin.GET("/contracts/:id/config-check", d.contractConfigAuditHandler.CheckByContract)
-			admin.GET("/crosschain-config/overview", d.crosschainConfigHandler.Overview)
-			admin.GET("/crosschain-config/preflight", d.crosschainConfigHandler.Preflight)
+			adminRead.GET("/contracts/config-check", d.contractConfigAuditHandler.Check)
+			adminRead.GET("/contracts/indexing", d.smartContractHandler.ListContractIndexing)
+			admin.PUT("/contracts/:id/indexing", d.smartContractHandler.UpdateContractIndexing)
+			adminRead.GET("/contracts/:id/config-check", d.contractConfigAuditHandler.CheckByContract)
+			adminRead.GET("/crosschain-config/overview", d.crosschainConfigHandler.Overview)
+			adminRead.GET("/crosschain-config/preflight", d.crosschainConfigHandler.Preflight)
 			admin.POST("/crosschain-config/recheck", d.crosschainConfigHandler.Recheck)
 			admin.POST("/crosschain-config/recheck-bulk", d.crosschainConfigHandler.RecheckBulk)
+			admin.POST("/crosschain-config/recheck-bulk/stream", d.crosschainConfigHandler.RecheckBulkStream)
 			admin.POST("/crosschain-config/auto-fix", d.crosschainConfigHandler.AutoFix)
 			admin.POST("/crosschain-config/auto-fix-bulk", d.crosschainConfigHandler.AutoFixBulk)
+			admin.POST("/crosschain-config/auto-fix-bulk/stream", d.crosschainConfigHandler.AutoFixBulkStream)
 
-			admin.GET("/route-policies", d.crosschainPolicyHandler.ListRoutePolicies)
+			adminRead.GET("/route-policies", d.crosschainPolicyHandler.ListRoutePolicies)
 			admin.POST("/route-policies", d.crosschainPolicyHandler.CreateRoutePolicy)
+			admin.POST("/route-policies/bulk", d.crosschainPolicyHandler.BulkCreateRoutePolicies)
 			admin.PUT("/route-policies/:id", d.crosschainPolicyHandler.UpdateRoutePolicy)
 			admin.DELETE("/route-policies/:id", d.crosschainPolicyHandler.DeleteRoutePolicy)
 
-			admin.GET("/stargate-configs", d.crosschainPolicyHandler.ListStargateConfigs)
+			adminRead.GET("/stargate-configs", d.crosschainPolicyHandler.ListStargateConfigs)
 			admin.POST("/stargate-configs", d.crosschainPolicyHandler.CreateStargateConfig)
 			admin.PUT("/stargate-configs/:id", d.crosschainPolicyHandler.UpdateStargateConfig)
 			admin.DELETE("/stargate-configs/:id", d.crosschainPolicyHandler.DeleteStargateConfig)
 
-			admin.GET("/diagnostics/route-error/:paymentId", d.routeErrorHandler.GetRouteError)
+			adminRead.GET("/diagnostics/route-error/:paymentId", d.routeErrorHandler.GetRouteError)
 		}
 
 		// Gas Profiler routes (public)
@@ -337,3 +426,20 @@ func registerAPIV1Routes(r *gin.Engine, d routeDeps) {
 		}
 	}
 }
+
+// registerAPIV2Routes serves the endpoints whose request or response shape changed in v2. They
+// share usecases with v1 and differ only in handler DTOs; the v1 route they replace answers with
+// Deprecation headers pointing here.
+func registerAPIV2Routes(r *gin.Engine, d routeDeps) {
+	v2 := r.Group("/api/v2")
+	v2.Use(middleware.ImpersonationAuditMiddleware(d.auditLogRepo))
+	{
+		payments := v2.Group("/payments")
+		payments.Use(d.dualAuthMiddleware)
+		{
+			payments.POST("", middleware.IdempotencyMiddleware(), d.paymentHandler.CreatePaymentV2)
+			payments.GET("/:id", d.paymentHandler.GetPayment)
+			payments.GET("", d.paymentHandler.ListPayments)
+		}
+	}
+}
@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
 	"payment-kita.backend/internal/interfaces/http/handlers"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 )
 
 func TestRegisterAPIV1Routes_RegistersKeyRoutes(t *testing.T) {
@@ -16,6 +21,7 @@ func TestRegisterAPIV1Routes_RegistersKeyRoutes(t *testing.T) {
 	registerAPIV1Routes(r, routeDeps{
 		authHandler:                    &handlers.AuthHandler{},
 		paymentHandler:                 &handlers.PaymentHandler{},
+		paymentApprovalHandler:         &handlers.PaymentApprovalHandler{},
 		merchantHandler:                &handlers.MerchantHandler{},
 		walletHandler:                  &handlers.WalletHandler{},
 		chainHandler:                   &handlers.ChainHandler{},
@@ -25,6 +31,7 @@ func TestRegisterAPIV1Routes_RegistersKeyRoutes(t *testing.T) {
 		webhookHandler:                 &handlers.WebhookHandler{},
 		adminHandler:                   &handlers.AdminHandler{},
 		adminMerchantSettlementHandler: &handlers.AdminMerchantSettlementHandler{},
+		receiverAllowlistHandler:       &handlers.ReceiverAllowlistHandler{},
 		teamHandler:                    &handlers.TeamHandler{},
 		apiKeyHandler:                  &handlers.ApiKeyHandler{},
 		paymentAppHandler:              &handlers.PaymentAppHandler{},
@@ -37,9 +44,17 @@ func TestRegisterAPIV1Routes_RegistersKeyRoutes(t *testing.T) {
 		crosschainConfigHandler:        &handlers.CrosschainConfigHandler{},
 		crosschainPolicyHandler:        &handlers.CrosschainPolicyHandler{},
 		rpcHandler:                     &handlers.RpcHandler{},
+		rpcPingHandler:                 &handlers.RPCPingHandler{},
+		featureFlagHandler:             &handlers.FeatureFlagHandler{},
+		maintenanceHandler:             &handlers.MaintenanceHandler{},
+		activityHandler:                &handlers.ActivityHandler{},
+		bootstrapHandler:               &handlers.BootstrapHandler{},
 		dualAuthMiddleware: func(c *gin.Context) {
 			c.Next()
 		},
+		sessionAuthMiddleware: func(c *gin.Context) {
+			c.Next()
+		},
 	})
 
 	routes := r.Routes()
@@ -54,24 +69,69 @@ func TestRegisterAPIV1Routes_RegistersKeyRoutes(t *testing.T) {
 		{"POST", "/api/v1/auth/login"},
 		{"GET", "/api/v1/auth/me"},
 		{"POST", "/api/v1/payments"},
+		{"POST", "/api/v1/payments/build-calldata"},
+		{"GET", "/api/v1/payments/approval-target"},
 		{"GET", "/api/v1/payments/:id"},
+		{"GET", "/api/v1/payments/:id/receipt.pdf"},
+		{"GET", "/api/v1/activity"},
+		{"POST", "/api/v1/payment-requests/batch"},
+		{"POST", "/api/v1/payment-requests/:id/cancel"},
 		{"GET", "/api/v1/pay/:id"},
 		{"POST", "/api/v1/create-payment"},
 		{"POST", "/api/v1/merchants/create-payment"},
+		{"POST", "/api/v1/merchants/webhooks/:id/test"},
 		{"GET", "/api/v1/create-payment/:id"},
 		{"POST", "/api/v1/partner/quotes"},
 		{"POST", "/api/v1/partner/payment-sessions"},
 		{"GET", "/api/v1/partner/payment-sessions/:id"},
 		{"POST", "/api/v1/partner/payment-sessions/resolve-code"},
 		{"POST", "/api/v1/wallets/connect"},
+		{"GET", "/api/v1/chains/:id/tokens"},
+		{"GET", "/api/v1/bootstrap"},
 		{"GET", "/api/v1/admin/stats"},
+		{"GET", "/api/v1/admin/payments/pending-approval"},
+		{"POST", "/api/v1/admin/payments/:id/approve"},
+		{"POST", "/api/v1/admin/payments/:id/reject"},
 		{"POST", "/api/v1/admin/merchants/:id/create-payment"},
 		{"GET", "/api/v1/admin/merchants/:id/settlement-profile"},
 		{"PUT", "/api/v1/admin/merchants/:id/settlement-profile"},
+		{"PUT", "/api/v1/admin/merchants/:id/confirmations"},
+		{"GET", "/api/v1/merchants/allowed-receivers"},
+		{"POST", "/api/v1/merchants/allowed-receivers"},
+		{"DELETE", "/api/v1/merchants/allowed-receivers/:receiverId"},
+		{"GET", "/api/v1/admin/merchants/:id/allowed-receivers"},
+		{"POST", "/api/v1/admin/merchants/:id/allowed-receivers"},
+		{"DELETE", "/api/v1/admin/merchants/:id/allowed-receivers/:receiverId"},
 		{"GET", "/api/v1/admin/diagnostics/legacy-endpoints"},
 		{"GET", "/api/v1/admin/diagnostics/settlement-profile-gaps"},
+		{"GET", "/api/v1/admin/diagnostics/jobs"},
+		{"POST", "/api/v1/admin/users/:id/impersonate"},
+		{"PUT", "/api/v1/admin/users/:id/role"},
+		{"PUT", "/api/v1/admin/users/:id/suspended"},
+		{"GET", "/api/v1/admin/feature-flags"},
+		{"PUT", "/api/v1/admin/feature-flags/:name"},
+		{"DELETE", "/api/v1/admin/feature-flags/:name"},
+		{"GET", "/api/v1/admin/maintenance"},
+		{"POST", "/api/v1/admin/maintenance"},
+		{"GET", "/api/v1/admin/chains"},
+		{"POST", "/api/v1/admin/chains/ping-rpc"},
+		{"POST", "/api/v1/admin/chains/:id/deactivate"},
+		{"GET", "/api/v1/admin/contracts"},
+		{"POST", "/api/v1/admin/tokens/bulk-activate"},
+		{"POST", "/api/v1/admin/tokens/bulk-delete"},
+		{"POST", "/api/v1/admin/tokens/:id/deactivate"},
+		{"POST", "/api/v1/admin/contracts/bulk-activate"},
+		{"POST", "/api/v1/admin/contracts/import-abi"},
+		{"POST", "/api/v1/admin/contracts/:id/activate"},
+		{"GET", "/api/v1/admin/contracts/indexing"},
+		{"GET", "/api/v1/indexer/contracts"},
+		{"PUT", "/api/v1/admin/contracts/:id/indexing"},
+		{"GET", "/api/v1/admin/onchain-adapters/diagnostics"},
 		{"POST", "/api/v1/admin/onchain-adapters/register"},
 		{"POST", "/api/v1/admin/onchain-adapters/hyperbridge-token-gateway-config"},
+		{"POST", "/api/v1/admin/crosschain-config/recheck-bulk/stream"},
+		{"POST", "/api/v1/admin/crosschain-config/auto-fix-bulk/stream"},
+		{"POST", "/api/v1/admin/route-policies/bulk"},
 		{"POST", "/api/v1/admin/stargate-configs"},
 	}
 
@@ -110,3 +170,145 @@ func TestRegisterAPIV1Routes_RouteResponds(t *testing.T) {
 		t.Fatalf("expected 200, got %d", rec.Code)
 	}
 }
+
+type featureCheckerFunc func(name string) bool
+
+func (f featureCheckerFunc) IsFeatureEnabled(_ context.Context, name string, _ *uuid.UUID) bool {
+	return f(name)
+}
+
+func TestRegisterAPIV1Routes_RefundsFeatureFlag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// An invalid payment ID fails in the handler, so a 400 means the gate let it through
+	refund := func(checker middleware.FeatureChecker) int {
+		r := gin.New()
+		registerAPIV1Routes(r, routeDeps{
+			paymentHandler:        &handlers.PaymentHandler{},
+			featureChecker:        checker,
+			dualAuthMiddleware:    func(c *gin.Context) { c.Next() },
+			partnerAuthMiddleware: func(c *gin.Context) { c.Next() },
+		})
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/payments/not-a-uuid/privacy/refund", strings.NewReader("{}")))
+		return rec.Code
+	}
+
+	if code := refund(featureCheckerFunc(func(string) bool { return false })); code != http.StatusNotFound {
+		t.Fatalf("expected 404 with refunds disabled, got %d", code)
+	}
+	if code := refund(featureCheckerFunc(func(name string) bool { return name == entities.FeatureRefunds })); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 with refunds enabled, got %d", code)
+	}
+}
+
+func TestRegisterAPIV1Routes_AdminRoles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	// Handlers are empty here; a request that gets past the role gate fails in them, not with 403
+	r.Use(gin.CustomRecovery(func(c *gin.Context, _ any) { c.AbortWithStatus(http.StatusTeapot) }))
+	registerAPIV1Routes(r, routeDeps{
+		dualAuthMiddleware: func(c *gin.Context) {
+			c.Set(middleware.UserRoleKey, c.GetHeader("X-Test-Role"))
+			c.Next()
+		},
+		partnerAuthMiddleware: func(c *gin.Context) { c.Next() },
+	})
+
+	for _, tc := range []struct {
+		role, method, path string
+		allowed            bool
+	}{
+		{"SUPPORT", http.MethodGet, "/api/v1/admin/fee-configs", true},
+		{"SUPPORT", http.MethodGet, "/api/v1/admin/diagnostics/route-error/0195d4b4-1e2c-7f2f-9aa1-123456789012", true},
+		{"SUPPORT", http.MethodPost, "/api/v1/admin/fee-configs", false},
+		{"SUPPORT", http.MethodPost, "/api/v1/admin/crosschain-config/auto-fix", false},
+		{"FINANCE", http.MethodPost, "/api/v1/admin/fee-configs", true},
+		{"FINANCE", http.MethodPut, "/api/v1/admin/merchants/0195d4b4-1e2c-7f2f-9aa1-123456789012/settlement-profile", true},
+		{"FINANCE", http.MethodPost, "/api/v1/admin/crosschain-config/auto-fix", false},
+		{"ADMIN", http.MethodPost, "/api/v1/admin/crosschain-config/auto-fix", true},
+		{"USER", http.MethodGet, "/api/v1/admin/users", false},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader("{}"))
+		req.Header.Set("X-Test-Role", tc.role)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if got := rec.Code != http.StatusForbidden; got != tc.allowed {
+			t.Fatalf("%s %s %s: expected allowed=%v, got %d", tc.role, tc.method, tc.path, tc.allowed, rec.Code)
+		}
+	}
+}
+
+func TestRegisterAPIV1Routes_IndexerFeedServiceToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	// The handler is empty; a request that gets past the token fails in it, not with 401
+	r.Use(gin.CustomRecovery(func(c *gin.Context, _ any) { c.AbortWithStatus(http.StatusTeapot) }))
+	registerAPIV1Routes(r, routeDeps{
+		indexerFeedToken: "indexer-token",
+		dualAuthMiddleware: func(c *gin.Context) {
+			c.Set(middleware.UserRoleKey, "ADMIN")
+			c.Next()
+		},
+		partnerAuthMiddleware: func(c *gin.Context) { c.Next() },
+	})
+	feed := func(authorization string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/indexer/contracts", nil)
+		req.Header.Set(middleware.AuthorizationHeader, authorization)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := feed("Bearer indexer-token"); code == http.StatusUnauthorized {
+		t.Fatalf("expected the service token to be accepted, got %d", code)
+	}
+	// An admin session is no substitute for the service token
+	if code := feed("Bearer admin-jwt"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without the service token, got %d", code)
+	}
+}
+
+func TestRegisterAPIV2Routes_ServesPaymentsAndDeprecatesV1(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	deps := routeDeps{
+		authHandler:           &handlers.AuthHandler{},
+		paymentHandler:        &handlers.PaymentHandler{},
+		dualAuthMiddleware:    func(c *gin.Context) { c.Next() },
+		partnerAuthMiddleware: func(c *gin.Context) { c.Next() },
+	}
+	registerAPIV1Routes(r, deps)
+	registerAPIV2Routes(r, deps)
+
+	for _, exp := range []struct{ method, path string }{
+		{"POST", "/api/v2/payments"},
+		{"GET", "/api/v2/payments/:id"},
+		{"GET", "/api/v2/payments"},
+	} {
+		found := false
+		for _, route := range r.Routes() {
+			if route.Method == exp.method && route.Path == exp.path {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("route %s %s not registered", exp.method, exp.path)
+		}
+	}
+
+	// A malformed body is rejected before the usecase; only v1 is marked deprecated
+	for path, deprecated := range map[string]bool{"/api/v1/payments": true, "/api/v2/payments": false} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader("{")))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", path, rec.Code)
+		}
+		if got := rec.Header().Get("Deprecation") == "true"; got != deprecated {
+			t.Fatalf("%s: expected deprecated=%v, headers %v", path, deprecated, rec.Header())
+		}
+		if deprecated && rec.Header().Get("X-Deprecated-Replaced-By") != "/api/v2/payments" {
+			t.Fatalf("%s: unexpected replacement %q", path, rec.Header().Get("X-Deprecated-Replaced-By"))
+		}
+	}
+}
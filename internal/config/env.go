@@ -0,0 +1,299 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"payment-kita.backend/pkg/crypto"
+)
+
+// defaultJWTSecret is the HS256 placeholder secret, refused in production
+const defaultJWTSecret = "change-this-in-production"
+
+// envField is one Config leaf bound to an environment variable
+type envField struct {
+	names  []string // the first is canonical; the rest are legacy fallbacks
+	def    string
+	rules  []string
+	desc   string
+	secret bool
+	value  reflect.Value
+}
+
+// envFields lists the tagged fields of cfg in declaration order
+func envFields(cfg *Config) []envField {
+	var fields []envField
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			tag, ok := sf.Tag.Lookup("env")
+			if !ok {
+				if sf.Type.Kind() == reflect.Struct {
+					walk(v.Field(i))
+				}
+				continue
+			}
+			f := envField{
+				names:  strings.Split(tag, ","),
+				def:    sf.Tag.Get("default"),
+				desc:   sf.Tag.Get("desc"),
+				secret: sf.Tag.Get("secret") == "true",
+				value:  v.Field(i),
+			}
+			if rules := sf.Tag.Get("validate"); rules != "" {
+				f.rules = strings.Split(rules, ",")
+			}
+			fields = append(fields, f)
+		}
+	}
+	walk(reflect.ValueOf(cfg).Elem())
+	return fields
+}
+
+// loadEnv sets every tagged field from its environment variable, or its default. A value that
+// does not parse is reported and the default is used instead.
+func loadEnv(cfg *Config) []error {
+	var errs []error
+	for _, f := range envFields(cfg) {
+		raw, name := "", f.names[0]
+		for _, candidate := range f.names {
+			if value := os.Getenv(candidate); value != "" {
+				raw, name = value, candidate
+				break
+			}
+		}
+		if raw == "" {
+			if err := setField(f.value, f.def); err != nil {
+				errs = append(errs, fmt.Errorf("%s: invalid default: %w", name, err))
+			}
+			continue
+		}
+		if err := setField(f.value, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			if f.value.IsZero() {
+				_ = setField(f.value, f.def)
+			}
+		}
+	}
+	return errs
+}
+
+func setField(v reflect.Value, raw string) error {
+	switch v.Interface().(type) {
+	case string:
+		v.SetString(raw)
+	case int:
+		if raw == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		v.SetInt(int64(n))
+	case bool:
+		if raw == "" {
+			return nil
+		}
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		v.SetBool(b)
+	case time.Duration:
+		if raw == "" {
+			return nil
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (use e.g. 15m, 168h)", raw)
+		}
+		v.SetInt(int64(d))
+	case []string:
+		v.Set(reflect.ValueOf(parseList(raw)))
+	case map[string]bool:
+		m, err := parseBoolMap(raw)
+		v.Set(reflect.ValueOf(m))
+		return err
+	default:
+		return fmt.Errorf("unsupported config type %s", v.Type())
+	}
+	return nil
+}
+
+func parseList(raw string) []string {
+	var out []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// parseBoolMap parses "name=true,other=false"; a bare name means true. Entries with an invalid
+// value are skipped and reported.
+func parseBoolMap(raw string) (map[string]bool, error) {
+	out := map[string]bool{}
+	var invalid []string
+	for _, item := range parseList(raw) {
+		name, value, hasValue := strings.Cut(item, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		enabled := true
+		if hasValue {
+			parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				invalid = append(invalid, item)
+				continue
+			}
+			enabled = parsed
+		}
+		out[name] = enabled
+	}
+	if len(invalid) > 0 {
+		return out, fmt.Errorf("invalid entries %q, expected name=true|false", invalid)
+	}
+	return out, nil
+}
+
+// Validate reports values Load could not parse and values that break a field's validate rules.
+// Each error names the environment variable to fix. Rules other than required skip empty values.
+func (c *Config) Validate() error {
+	errs := append([]error(nil), c.loadErrs...)
+	for _, f := range envFields(c) {
+		for _, rule := range f.rules {
+			if err := checkRule(rule, f.value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", f.names[0], err))
+			}
+		}
+	}
+
+	if c.Blockchain.OwnerSigner == "remote" {
+		if c.Blockchain.OwnerSignerURL == "" {
+			errs = append(errs, errors.New("EVM_OWNER_SIGNER_URL: is required when EVM_OWNER_SIGNER=remote"))
+		}
+		if c.Blockchain.OwnerAddress == "" {
+			errs = append(errs, errors.New("EVM_OWNER_ADDRESS: is required when EVM_OWNER_SIGNER=remote"))
+		}
+	}
+	if (c.JWT.Algorithm == "RS256" || c.JWT.Algorithm == "ES256") && c.JWT.PrivateKey == "" && c.JWT.PrivateKeyFile == "" {
+		errs = append(errs, fmt.Errorf("JWT_PRIVATE_KEY_FILE: JWT_PRIVATE_KEY or JWT_PRIVATE_KEY_FILE is required for %s", c.JWT.Algorithm))
+	}
+	if c.Server.Env == "production" {
+		if (c.JWT.Algorithm == "" || c.JWT.Algorithm == "HS256") && crypto.ConstantTimeEqual(c.JWT.Secret, defaultJWTSecret) {
+			errs = append(errs, errors.New("JWT_SECRET: must be changed from its default in production"))
+		}
+		for _, f := range envFields(c) {
+			if f.secret && f.def != "" && strings.Trim(f.def, "0") == "" && f.value.String() == f.def {
+				errs = append(errs, fmt.Errorf("%s: must be changed from its all-zero default in production", f.names[0]))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkRule(rule string, v reflect.Value) error {
+	name, arg, _ := strings.Cut(rule, "=")
+	if name == "required" {
+		if v.IsZero() {
+			return errors.New("is required")
+		}
+		return nil
+	}
+	if v.IsZero() && v.Kind() != reflect.Int {
+		return nil
+	}
+
+	switch name {
+	case "oneof":
+		allowed := strings.Split(arg, "|")
+		values := []string{v.String()}
+		if list, ok := v.Interface().([]string); ok {
+			values = list
+		}
+		for _, value := range values {
+			if !slices.Contains(allowed, value) {
+				return fmt.Errorf("must be one of %s (got %q)", strings.Join(allowed, ", "), value)
+			}
+		}
+	case "min":
+		minimum, _ := strconv.Atoi(arg)
+		if v.Int() < int64(minimum) {
+			return fmt.Errorf("must be at least %d (got %d)", minimum, v.Int())
+		}
+	case "port":
+		port := 0
+		if v.Kind() == reflect.String {
+			port, _ = strconv.Atoi(v.String())
+		} else {
+			port = int(v.Int())
+		}
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("must be a port between 1 and 65535 (got %v)", v.Interface())
+		}
+	case "url":
+		u, err := url.Parse(v.String())
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("must be an absolute URL (got %q)", v.String())
+		}
+	case "cidrs":
+		for _, entry := range v.Interface().([]string) {
+			if net.ParseIP(entry) != nil {
+				continue
+			}
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				return fmt.Errorf("must be IP addresses or CIDRs (got %q)", entry)
+			}
+		}
+	case "hex32":
+		key, err := hex.DecodeString(v.String())
+		if err != nil || len(key) != 32 {
+			return errors.New("must be a 32-byte hex string (64 hex characters)")
+		}
+	default:
+		return fmt.Errorf("unknown validate rule %q", rule)
+	}
+	return nil
+}
+
+// Describe lists every environment variable Config reads, with its default and purpose, for
+// --help output
+func Describe() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VARIABLE\tDEFAULT\tDESCRIPTION")
+	for _, f := range envFields(&Config{}) {
+		def := f.def
+		if f.secret && def != "" {
+			def = "(dev placeholder)"
+		}
+		desc := f.desc
+		if len(f.names) > 1 {
+			desc += " (also read from " + strings.Join(f.names[1:], ", ") + ")"
+		}
+		if f.secret {
+			desc += " [secret]"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.names[0], def, desc)
+	}
+	_ = w.Flush()
+	return b.String()
+}
@@ -8,14 +8,18 @@ import (
 )
 
 type AuditLog struct {
-	ID         uuid.UUID `json:"id"`
-	MerchantID uuid.UUID `json:"merchant_id"`
-	Path       string    `json:"path"`
-	Method     string    `json:"method"`
-	StatusCode int       `json:"status_code"`
-	IPAddress  string    `json:"ip_address"`
-	Duration   float64   `json:"duration"`
-	CreatedAt  time.Time `json:"created_at"`
+	ID         uuid.UUID  `json:"id"`
+	MerchantID *uuid.UUID `json:"merchant_id,omitempty"`
+	// UserID and ImpersonatorID identify who acted when an admin impersonated a user
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
+	Action         string     `json:"action,omitempty"`
+	Path           string     `json:"path"`
+	Method         string     `json:"method"`
+	StatusCode     int        `json:"status_code"`
+	IPAddress      string     `json:"ip_address"`
+	Duration       float64    `json:"duration"`
+	CreatedAt      time.Time  `json:"created_at"`
 }
 
 type AuditLogRepository interface {
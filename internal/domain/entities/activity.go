@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActivityType discriminates the records merged into an activity feed
+type ActivityType string
+
+const (
+	ActivityTypePayment        ActivityType = "payment"
+	ActivityTypePaymentRequest ActivityType = "payment_request"
+)
+
+// ActivityItem is one entry of a user's activity feed. Exactly one of Payment and
+// PaymentRequest is set, matching Type.
+type ActivityItem struct {
+	Type           ActivityType    `json:"type"`
+	ID             uuid.UUID       `json:"id"`
+	Status         string          `json:"status"`
+	Amount         string          `json:"amount"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	Payment        *Payment        `json:"payment,omitempty"`
+	PaymentRequest *PaymentRequest `json:"paymentRequest,omitempty"`
+}
+
+// ActivityPage is one page of an activity feed, newest first. NextCursor is empty on the last page.
+type ActivityPage struct {
+	Items      []*ActivityItem `json:"items"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+	HasMore    bool            `json:"hasMore"`
+}
@@ -30,12 +30,20 @@ type ApiKey struct {
 type CreateApiKeyInput struct {
 	Name        string   `json:"name" binding:"required"`
 	Permissions []string `json:"permissions"`
+	// Mode is "live" (default) or "test". Test keys only transact on testnet chains.
+	Mode PaymentMode `json:"mode" binding:"omitempty,oneof=live test"`
 }
 
 type CreateApiKeyResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	ApiKey    string    `json:"apiKey"`
-	SecretKey string    `json:"secretKey"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID        uuid.UUID   `json:"id"`
+	Name      string      `json:"name"`
+	ApiKey    string      `json:"apiKey"`
+	SecretKey string      `json:"secretKey"`
+	Mode      PaymentMode `json:"mode"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// Mode returns whether the key was issued for test or live payments
+func (k *ApiKey) Mode() PaymentMode {
+	return PaymentModeFromAPIKey(k.KeyPrefix)
 }
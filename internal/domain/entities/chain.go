@@ -17,8 +17,48 @@ const (
 	ChainTypeEVM       ChainType = "EVM"
 	ChainTypeSVM       ChainType = "SVM"
 	ChainTypeSubstrate ChainType = "SUBSTRATE"
+	ChainTypeCosmos    ChainType = "COSMOS"
+	ChainTypeTron      ChainType = "TRON"
 )
 
+// chainTypeNamespaces is the single mapping between chain types and CAIP-2 namespaces.
+// Supporting a new chain family starts here.
+var chainTypeNamespaces = map[ChainType]string{
+	ChainTypeEVM:       "eip155",
+	ChainTypeSVM:       "solana",
+	ChainTypeSubstrate: "substrate",
+	ChainTypeCosmos:    "cosmos",
+	ChainTypeTron:      "tron",
+}
+
+// Namespace returns the CAIP-2 namespace for t, or "" when t is unknown
+func (t ChainType) Namespace() string {
+	return chainTypeNamespaces[t]
+}
+
+// IsEVM reports whether t is an EVM chain
+func (t ChainType) IsEVM() bool {
+	return t == ChainTypeEVM
+}
+
+// IsSVM reports whether t is a Solana VM chain
+func (t ChainType) IsSVM() bool {
+	return t == ChainTypeSVM
+}
+
+// ChainTypeFromCAIP2 returns the chain type of a CAIP-2 ID such as "eip155:8453", or "" when
+// the namespace is not recognised
+func ChainTypeFromCAIP2(caip2 string) ChainType {
+	namespace, _, _ := strings.Cut(strings.TrimSpace(caip2), ":")
+	namespace = strings.ToLower(namespace)
+	for chainType, ns := range chainTypeNamespaces {
+		if ns == namespace {
+			return chainType
+		}
+	}
+	return ""
+}
+
 // Chain represents a blockchain
 type Chain struct {
 	ID             uuid.UUID  `json:"uuid" gorm:"type:uuid;primary_key;default:uuid_generate_v7()"`
@@ -30,6 +70,10 @@ type Chain struct {
 	IsTestnet      bool       `json:"isTestnet"`
 	CurrencySymbol string     `json:"currencySymbol"`
 	ExplorerURL    string     `json:"explorerUrl,omitempty"`
+	// ExplorerAPIURL is the chain's Etherscan-compatible API; empty when the chain has no
+	// contract verification API
+	ExplorerAPIURL string `json:"explorerApiUrl,omitempty"`
+	ExplorerAPIKey string `json:"-"`
 	RPCURL         string     `json:"rpcUrl"` // Main RPC
 	CreatedAt      time.Time  `json:"createdAt"`
 	UpdatedAt      time.Time  `json:"updatedAt"`
@@ -41,6 +85,10 @@ type Chain struct {
 	// Bridge Metadata
 	CCIPChainSelector string `json:"ccipChainSelector" gorm:"type:varchar(255);default:'';column:ccip_chain_selector"`
 	StargateEID      int    `json:"stargateEid" gorm:"type:integer;default:0;column:stargate_eid"`
+
+	// MinConfirmations is how many blocks deep a completion must be before the payment is
+	// marked completed; 0 completes on first sight
+	MinConfirmations int `json:"minConfirmations" gorm:"type:integer;default:0"`
 }
 
 // ChainRPC represents a blockchain RPC endpoint
@@ -86,20 +134,21 @@ func (c *Chain) GetCAIP2ID() string {
 	if strings.Contains(raw, ":") {
 		return raw
 	}
-
-	// Simple heuristic. ideally implementation details should handle this.
-	// For EVM: eip155:ChainID
-	if c.Type == ChainTypeEVM {
-		return fmt.Sprintf("eip155:%s", raw)
-	}
-	// For SVM: solana:ChainID?
-	// This might need refinement based on exact storage of ChainID for Solana.
-	if c.Type == ChainTypeSVM {
-		return fmt.Sprintf("solana:%s", raw)
+	if namespace := c.Type.Namespace(); namespace != "" {
+		return fmt.Sprintf("%s:%s", namespace, raw)
 	}
 	return raw
 }
 
+// ChainType returns the chain's type, falling back to the namespace of a CAIP-2 ChainID
+// when Type is unset
+func (c *Chain) ChainType() ChainType {
+	if c.Type != "" {
+		return c.Type
+	}
+	return ChainTypeFromCAIP2(c.ChainID)
+}
+
 // TokenType represents token type
 type TokenType string
 
@@ -124,8 +173,11 @@ type Token struct {
 	IsActive        bool        `json:"isActive" gorm:"default:true"`
 	IsNative        bool        `json:"isNative" gorm:"default:false"`
 	IsStablecoin    bool        `json:"isStablecoin" gorm:"default:false"`
+	HasTransferFee  bool        `json:"hasTransferFee" gorm:"default:false"` // Fee-on-transfer or rebasing; kept off cross-chain routes
 	MinAmount       string      `json:"minAmount" gorm:"type:decimal(36,18);default:0"`
 	MaxAmount       null.String `json:"maxAmount,omitempty" gorm:"type:decimal(36,18)"`
+	// ApprovalThreshold is the amount above which a cross-chain payment waits for admin approval
+	ApprovalThreshold null.String `json:"approvalThreshold,omitempty" gorm:"type:decimal(36,18)"`
 	CreatedAt       time.Time   `json:"createdAt"`
 	UpdatedAt       time.Time   `json:"updatedAt"`
 	DeletedAt       *time.Time  `json:"deletedAt,omitempty" gorm:"index"`
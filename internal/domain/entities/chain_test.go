@@ -24,8 +24,49 @@ func TestChain_GetCAIP2ID(t *testing.T) {
 	}
 
 	substrate := &Chain{Type: ChainTypeSubstrate, ChainID: "polkadot"}
-	if got := substrate.GetCAIP2ID(); got != "polkadot" {
-		t.Fatalf("expected polkadot got %s", got)
+	if got := substrate.GetCAIP2ID(); got != "substrate:polkadot" {
+		t.Fatalf("expected substrate:polkadot got %s", got)
+	}
+
+	unknown := &Chain{Type: "OTHER", ChainID: "x"}
+	if got := unknown.GetCAIP2ID(); got != "x" {
+		t.Fatalf("expected x got %s", got)
+	}
+}
+
+func TestChainTypeFromCAIP2(t *testing.T) {
+	tests := map[string]ChainType{
+		"eip155:8453":       ChainTypeEVM,
+		"  solana:devnet  ": ChainTypeSVM,
+		"cosmos:osmosis-1":  ChainTypeCosmos,
+		"tron:0x2b6653dc":   ChainTypeTron,
+		"substrate:x":       ChainTypeSubstrate,
+		"bip122:000000":     "",
+		"8453":              "",
+		"":                  "",
+	}
+	for input, want := range tests {
+		if got := ChainTypeFromCAIP2(input); got != want {
+			t.Fatalf("ChainTypeFromCAIP2(%q): expected %q got %q", input, want, got)
+		}
+	}
+
+	for chainType, namespace := range chainTypeNamespaces {
+		if got := ChainTypeFromCAIP2(namespace + ":1"); got != chainType {
+			t.Fatalf("expected %s to round-trip, got %q", chainType, got)
+		}
+	}
+}
+
+func TestChain_ChainTypeFallsBackToCAIP2(t *testing.T) {
+	if got := (&Chain{Type: ChainTypeSVM, ChainID: "eip155:1"}).ChainType(); got != ChainTypeSVM {
+		t.Fatalf("expected stored type to win, got %s", got)
+	}
+	if got := (&Chain{ChainID: "eip155:1"}).ChainType(); got != ChainTypeEVM {
+		t.Fatalf("expected EVM from CAIP-2, got %s", got)
+	}
+	if !ChainTypeEVM.IsEVM() || ChainTypeEVM.IsSVM() || !ChainTypeSVM.IsSVM() {
+		t.Fatal("unexpected IsEVM/IsSVM result")
 	}
 }
 
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// CrosschainRouteStatusRecord is the last recheck result stored for one crosschain route.
+// Snapshot is the full status as JSON; PreviousStatus is the overall status before the last
+// change, empty until the route has changed state once.
+type CrosschainRouteStatusRecord struct {
+	RouteKey        string
+	SourceChainID   string
+	DestChainID     string
+	OverallStatus   string
+	PreviousStatus  string
+	Snapshot        string
+	CheckedAt       time.Time
+	StatusChangedAt time.Time
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Feature names used to gate routes that ship dark
+const (
+	FeatureRefunds   = "refunds"
+	FeatureRecurring = "recurring"
+	FeatureSplits    = "splits"
+)
+
+// FeatureFlag toggles a feature globally, or for one merchant when MerchantID is set.
+// A merchant override wins over the global row.
+type FeatureFlag struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	MerchantID *uuid.UUID `json:"merchantId,omitempty"`
+	Enabled    bool       `json:"enabled"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
@@ -39,6 +39,8 @@ type Merchant struct {
 	BusinessAddress    null.String    `json:"businessAddress,omitempty"`
 	Documents          null.JSON      `json:"documents,omitempty"`
 	FeeDiscountPercent string         `json:"feeDiscountPercent" gorm:"type:decimal(5,2)"` // Changed to string
+	FeeExempt          bool           `json:"feeExempt"`                                   // Internal/test merchants skip the platform fee
+	MinConfirmations   int            `json:"minConfirmations"`                            // Raises, never lowers, the destination chain's threshold
 	CallbackURL        string         `json:"callbackUrl,omitempty"`
 	WebhookSecret      string         `json:"webhookSecret,omitempty"`
 	WebhookIsActive    bool           `json:"webhookIsActive"`
@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MerchantAllowedReceiver is one address a merchant's payments may pay out to. Once a merchant
+// has any, receivers outside the list are refused.
+type MerchantAllowedReceiver struct {
+	ID         uuid.UUID  `json:"id"`
+	MerchantID uuid.UUID  `json:"merchantId"`
+	Address    string     `json:"address"`
+	Label      string     `json:"label,omitempty"`
+	CreatedBy  *uuid.UUID `json:"createdBy,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
@@ -1,6 +1,9 @@
 package entities
 
 import (
+	"encoding/json"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,18 +19,94 @@ const (
 	PaymentStatusCompleted  PaymentStatus = "COMPLETED"
 	PaymentStatusFailed     PaymentStatus = "FAILED"
 	PaymentStatusRefunded   PaymentStatus = "REFUNDED"
+	// PaymentStatusPendingApproval holds a cross-chain payment above its token's approval
+	// threshold until an admin approves (PENDING) or rejects (FAILED) it
+	PaymentStatusPendingApproval PaymentStatus = "PENDING_APPROVAL"
 )
 
+// PaymentMode separates integration traffic from real payments. It follows the API key the
+// payment was created with: pk_test_ keys make test payments, everything else is live.
+type PaymentMode string
+
+const (
+	PaymentModeLive PaymentMode = "live"
+	PaymentModeTest PaymentMode = "test"
+)
+
+// testAPIKeyPrefixes mark keys issued in test mode
+var testAPIKeyPrefixes = []string{"pk_test_", "sk_test_"}
+
+// PaymentModeFromAPIKey returns the mode of an API key from its prefix
+func PaymentModeFromAPIKey(apiKey string) PaymentMode {
+	for _, prefix := range testAPIKeyPrefixes {
+		if strings.HasPrefix(apiKey, prefix) {
+			return PaymentModeTest
+		}
+	}
+	return PaymentModeLive
+}
+
+// ParsePaymentMode parses "test" or "live", case-insensitively
+func ParsePaymentMode(raw string) (PaymentMode, bool) {
+	switch mode := PaymentMode(strings.ToLower(strings.TrimSpace(raw))); mode {
+	case PaymentModeLive, PaymentModeTest:
+		return mode, true
+	}
+	return "", false
+}
+
 // PaymentEventType represents payment event type
 type PaymentEventType string
 
+// Payment event catalog. The first group is written by the backend itself, the indexer group is
+// recorded as received from the indexer webhook.
 const (
-	PaymentEventTypeCreated           PaymentEventType = "CREATED"
-	PaymentEventTypeDestinationTxHash PaymentEventType = "DESTINATION_TX_HASH"
-	PaymentEventTypeCompleted         PaymentEventType = "COMPLETED"
-	PaymentEventTypeFailed            PaymentEventType = "FAILED"
+	PaymentEventTypeCreated               PaymentEventType = "CREATED"
+	PaymentEventTypeDestinationTxHash     PaymentEventType = "DESTINATION_TX_HASH"
+	PaymentEventTypeCompleted             PaymentEventType = "COMPLETED"
+	PaymentEventTypeFailed                PaymentEventType = "FAILED"
+	PaymentEventTypeQuoteSnapshotCaptured PaymentEventType = "QUOTE_SNAPSHOT_CAPTURED"
+	PaymentEventTypeIndexerCreated        PaymentEventType = "PAYMENT_CREATED"
+	PaymentEventTypeIndexerExecuted       PaymentEventType = "PAYMENT_EXECUTED"
+	PaymentEventTypeIndexerCompleted      PaymentEventType = "PAYMENT_COMPLETED"
+	PaymentEventTypeIndexerRefunded       PaymentEventType = "PAYMENT_REFUNDED"
+	PaymentEventTypeIndexerFailed         PaymentEventType = "PAYMENT_FAILED"
+	// Bridge events track a cross-chain payment's message between the source and destination txs
+	PaymentEventTypeBridgeMessageSent      PaymentEventType = "BRIDGE_MESSAGE_SENT"
+	PaymentEventTypeBridgeMessageDelivered PaymentEventType = "BRIDGE_MESSAGE_DELIVERED"
+	// Review events record an admin's decision on a payment held for approval
+	PaymentEventTypeApproved PaymentEventType = "APPROVED"
+	PaymentEventTypeRejected PaymentEventType = "REJECTED"
 )
 
+// PaymentEventTypes lists the catalog in lifecycle order
+var PaymentEventTypes = []PaymentEventType{
+	PaymentEventTypeCreated,
+	PaymentEventTypeApproved,
+	PaymentEventTypeRejected,
+	PaymentEventTypeQuoteSnapshotCaptured,
+	PaymentEventTypeIndexerCreated,
+	PaymentEventTypeBridgeMessageSent,
+	PaymentEventTypeBridgeMessageDelivered,
+	PaymentEventTypeIndexerExecuted,
+	PaymentEventTypeDestinationTxHash,
+	PaymentEventTypeIndexerCompleted,
+	PaymentEventTypeCompleted,
+	PaymentEventTypeIndexerRefunded,
+	PaymentEventTypeIndexerFailed,
+	PaymentEventTypeFailed,
+}
+
+// IsKnown reports whether t is part of the event catalog
+func (t PaymentEventType) IsKnown() bool {
+	for _, known := range PaymentEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
 const (
 	PrivacyLifecycleUnknown               = "privacy_unknown"
 	PrivacyLifecycleNotPrivacy            = "not_privacy"
@@ -68,11 +147,16 @@ type Payment struct {
 	MinDestAmount       null.String   `json:"minDestAmount,omitempty" gorm:"type:decimal(36,18)"`
 	TotalCharged        string        `json:"totalCharged" gorm:"type:decimal(36,18)"`
 	ReceiverAddress     string        `json:"receiverAddress"`
+	ReceiverName        null.String   `json:"receiverName,omitempty"` // ENS/SNS name ReceiverAddress was resolved from
+	ExternalRef         null.String   `json:"externalRef,omitempty"`  // merchant order id
+	Metadata            null.JSON     `json:"metadata,omitempty"`     // integrator JSON, never interpreted
 	Status              PaymentStatus `json:"status"`
+	Mode                PaymentMode   `json:"mode"` // live, or test when created with a test API key
 	SourceTxHash        null.String   `json:"sourceTxHash,omitempty"`
 	DestTxHash          null.String   `json:"destTxHash,omitempty"`
 	RefundTxHash        null.String   `json:"refundTxHash,omitempty"`
-	CrossChainMessageID null.String   `json:"crossChainMessageId,omitempty"`
+	CrossChainMessageID null.String   `json:"crossChainMessageId,omitempty"` // CCIP message ID or Hyperbridge request commitment
+	BridgeExplorerURL   string        `json:"bridgeExplorerUrl,omitempty"`   // filled from Bridge.ExplorerURLTemplate on detail reads
 	FailureReason       null.String   `json:"failureReason,omitempty"`
 	RevertData          null.String   `json:"revertData,omitempty"`
 	ExpiresAt           *time.Time    `json:"expiresAt,omitempty"`
@@ -88,10 +172,32 @@ type Payment struct {
 	Bridge      *PaymentBridge `json:"bridge,omitempty" gorm:"foreignKey:BridgeID"`
 }
 
-// PaymentBridge represents the bridge provider (CCIP, Hyperlane)
+// PaymentBridge represents the bridge provider (CCIP, Hyperbridge, Stargate)
 type PaymentBridge struct {
 	ID   uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v7()"`
 	Name string    `json:"name"`
+	// ExplorerURLTemplate links a payment to the bridge's explorer, e.g.
+	// https://ccip.chain.link/msg/{messageId}. {sourceTxHash} is also filled in.
+	ExplorerURLTemplate string `json:"explorerUrlTemplate,omitempty"`
+}
+
+// ExplorerURL fills the explorer template for one payment. It is empty when there is no
+// template or a placeholder it uses has no value yet.
+func (b *PaymentBridge) ExplorerURL(messageID, sourceTxHash string) string {
+	if b == nil || b.ExplorerURLTemplate == "" {
+		return ""
+	}
+	link := b.ExplorerURLTemplate
+	for placeholder, value := range map[string]string{"{messageId}": messageID, "{sourceTxHash}": sourceTxHash} {
+		if !strings.Contains(link, placeholder) {
+			continue
+		}
+		if value == "" {
+			return ""
+		}
+		link = strings.ReplaceAll(link, placeholder, url.PathEscape(value))
+	}
+	return link
 }
 
 // BridgeConfig represents routing config for a source/destination chain pair.
@@ -123,16 +229,24 @@ type FeeConfig struct {
 
 // CreatePaymentInput represents input for creating a payment
 type CreatePaymentInput struct {
-	SourceChainID      string `json:"sourceChainId" binding:"required"` // UUID or NetworkID? Likely NetworkID in API
-	DestChainID        string `json:"destChainId" binding:"required"`   // Likely NetworkID in API
-	SourceTokenAddress string `json:"sourceTokenAddress" binding:"required"`
-	DestTokenAddress   string `json:"destTokenAddress" binding:"required"`
-	Amount             string `json:"amount" binding:"required"`
-	Decimals           int    `json:"decimals" binding:"required"`
-	ReceiverAddress    string `json:"receiverAddress" binding:"required"`
-	ReceiverMerchantID string `json:"receiverMerchantId,omitempty"`
-	MinAmountOut       string `json:"minAmountOut,omitempty"`
-	SlippageBps        int    `json:"slippageBps,omitempty"` // e.g. 50 = 0.5%
+	SourceChainID      string          `json:"sourceChainId" binding:"required"` // UUID or NetworkID? Likely NetworkID in API
+	DestChainID        string          `json:"destChainId" binding:"required"`   // Likely NetworkID in API
+	SourceTokenAddress string          `json:"sourceTokenAddress" binding:"required"`
+	DestTokenAddress   string          `json:"destTokenAddress" binding:"required"`
+	Amount             string          `json:"amount" binding:"required"`
+	Decimals           int             `json:"decimals" binding:"required"`
+	ReceiverAddress    string          `json:"receiverAddress" binding:"required"`
+	ReceiverMerchantID string          `json:"receiverMerchantId,omitempty"`
+	ExternalRef        string          `json:"externalRef,omitempty" binding:"omitempty,max=128"` // merchant order id
+	Metadata           json.RawMessage `json:"metadata,omitempty"`                                // JSON object, stored as-is
+	MinAmountOut       string          `json:"minAmountOut,omitempty"`
+	SlippageBps        int             `json:"slippageBps,omitempty"` // e.g. 50 = 0.5%
+	// PaymentID is an optional client-generated UUIDv7. Retrying with the same ID returns the
+	// payment already created by the caller instead of a duplicate.
+	PaymentID *uuid.UUID `json:"paymentId,omitempty"`
+	// SimulateFrom is the payer's EVM address. When set, the createPayment call is dry-run with
+	// eth_call from it and the outcome is returned as CreatePaymentResponse.Simulation.
+	SimulateFrom string `json:"simulateFrom,omitempty"`
 
 	// V2 optional request surface.
 	Mode                   *string `json:"mode,omitempty"` // regular | privacy
@@ -144,23 +258,66 @@ type CreatePaymentInput struct {
 	PrivacyStealthReceiver *string `json:"privacyStealthReceiver,omitempty"`
 }
 
+// BuildPaymentCalldataInput is a CreatePaymentInput to preview. Its PaymentID optionally pins
+// the payment ID so Solana calldata for an existing payment can be reproduced.
+type BuildPaymentCalldataInput struct {
+	CreatePaymentInput
+}
+
 // CreatePaymentResponse represents response for payment creation
 type CreatePaymentResponse struct {
-	PaymentID      uuid.UUID     `json:"paymentId"`
-	Status         PaymentStatus `json:"status"`
-	SourceChainID  string        `json:"sourceChainId"` // Network ID
-	DestChainID    string        `json:"destChainId"`   // Network ID
-	SourceAmount   string        `json:"sourceAmount"`
-	SourceDecimals int           `json:"sourceDecimals"`
-	DestAmount     string        `json:"destAmount"`
-	DestDecimals   int           `json:"destDecimals"`
-	FeeAmount      string        `json:"feeAmount"`
-	FeeBreakdown   FeeBreakdown  `json:"feeBreakdown"`
-	BridgeType     string        `json:"bridgeType"`
-	BridgeReason   string        `json:"bridgeReason"`
-	OnchainCost    *OnchainCost  `json:"onchainCost,omitempty"`
-	ExpiresAt      time.Time     `json:"expiresAt"`
-	SignatureData  interface{}   `json:"signatureData"`
+	PaymentID       uuid.UUID       `json:"paymentId"`
+	Status          PaymentStatus   `json:"status"`
+	SourceChainID   string          `json:"sourceChainId"` // Network ID
+	DestChainID     string          `json:"destChainId"`   // Network ID
+	SourceAmount    string          `json:"sourceAmount"`
+	SourceDecimals  int             `json:"sourceDecimals"`
+	ReceiverAddress string          `json:"receiverAddress"`
+	ReceiverName    string          `json:"receiverName,omitempty"`
+	ExternalRef     string          `json:"externalRef,omitempty"`
+	Metadata        null.JSON       `json:"metadata,omitempty"`
+	DestAmount      string          `json:"destAmount"`
+	DestDecimals    int             `json:"destDecimals"`
+	FeeAmount       string          `json:"feeAmount"`
+	FeeBreakdown    FeeBreakdown    `json:"feeBreakdown"`
+	BridgeType      string          `json:"bridgeType"` // same as Bridge.Name, kept for older clients
+	Bridge          *SelectedBridge `json:"bridge,omitempty"`
+	BridgeReason    string          `json:"bridgeReason"`
+	OnchainCost     *OnchainCost    `json:"onchainCost,omitempty"`
+	ExpiresAt       time.Time       `json:"expiresAt"`
+	SignatureData   interface{}     `json:"signatureData"`
+	Replayed        bool            `json:"replayed,omitempty"` // an earlier request with the same paymentId created it
+
+	// Simulation is set when the request had simulateFrom
+	Simulation *PaymentSimulation `json:"simulation,omitempty"`
+}
+
+// PaymentSimulationStatus is the outcome of dry-running a payment before it is signed
+type PaymentSimulationStatus string
+
+const (
+	PaymentSimulationPassed   PaymentSimulationStatus = "PASSED"
+	PaymentSimulationReverted PaymentSimulationStatus = "REVERTED"
+	// PaymentSimulationSkipped means the call could not be simulated yet, e.g. the token
+	// approval is not mined or the RPC is unavailable; Reason says why
+	PaymentSimulationSkipped PaymentSimulationStatus = "SKIPPED"
+)
+
+// PaymentSimulation is the result of running the createPayment call with eth_call from the
+// payer's address. On REVERTED, Reason is the decoded revert reason and RevertData the raw bytes.
+type PaymentSimulation struct {
+	From       string                  `json:"from"`
+	Status     PaymentSimulationStatus `json:"status"`
+	Reason     string                  `json:"reason,omitempty"`
+	ErrorName  string                  `json:"errorName,omitempty"`
+	RevertData string                  `json:"revertData,omitempty"`
+}
+
+// SelectedBridge is the bridge chosen for a cross-chain payment. ID is set when the bridge is
+// the one registered in the route's bridge config; it is also stored as Payment.BridgeID.
+type SelectedBridge struct {
+	Name string     `json:"name"`
+	ID   *uuid.UUID `json:"id,omitempty"`
 }
 
 // OnchainCost represents Track-B style on-chain quote breakdown from gateway.quotePaymentCost.
@@ -177,11 +334,20 @@ type OnchainCost struct {
 
 // FeeBreakdown represents fee breakdown
 type FeeBreakdown struct {
-	PlatformFee string `json:"platformFee"`
-	BridgeFee   string `json:"bridgeFee"`
-	GasFee      string `json:"gasFee"`
-	TotalFee    string `json:"totalFee"`
-	NetAmount   string `json:"netAmount"`
+	PlatformFee       string `json:"platformFee"`
+	BridgeFee         string `json:"bridgeFee"`
+	GasFee            string `json:"gasFee"`
+	TotalFee          string `json:"totalFee"`
+	NetAmount         string `json:"netAmount"`
+	PlatformFeeWaived bool   `json:"platformFeeWaived,omitempty"`
+	// NetInDestUnits is set when NetAmount is denominated in the destination token, i.e. it came
+	// from a swap quote or both sides are the same token
+	NetInDestUnits bool `json:"-"`
+	// FeeSource, BridgeFeeSource and NetAmountSource say which path priced each part, for logs
+	// and metrics
+	FeeSource       string `json:"-"`
+	BridgeFeeSource string `json:"-"`
+	NetAmountSource string `json:"-"`
 }
 
 // PaymentEvent represents a payment event
@@ -192,8 +358,29 @@ type PaymentEvent struct {
 	ChainID     *uuid.UUID       `json:"chainId,omitempty"`
 	TxHash      string           `json:"txHash"`
 	BlockNumber int64            `json:"blockNumber,omitempty"`
-	Metadata    interface{}      `json:"metadata,omitempty" gorm:"type:jsonb"`
-	CreatedAt   time.Time        `json:"createdAt"`
+	// Confirmations is how deep BlockNumber was when the event was reported
+	Confirmations int         `json:"confirmations,omitempty"`
+	Metadata      interface{} `json:"metadata,omitempty" gorm:"type:jsonb"`
+	// Details is the typed, event-specific data; Metadata keeps the raw payload
+	Details   *PaymentEventDetails `json:"details,omitempty"`
+	CreatedAt time.Time            `json:"createdAt"`
+}
+
+// PaymentEventDetails is what an event observed, for debugging and the payment timeline. Every
+// field is optional; an event fills in what it knows.
+type PaymentEventDetails struct {
+	TxHash          string `json:"txHash,omitempty"`
+	DestTxHash      string `json:"destTxHash,omitempty"`
+	BlockNumber     int64  `json:"blockNumber,omitempty"`
+	ObservedAmount  string `json:"observedAmount,omitempty"`
+	BridgeMessageID string `json:"bridgeMessageId,omitempty"`
+	RevertReason    string `json:"revertReason,omitempty"`
+	RevertData      string `json:"revertData,omitempty"`
+}
+
+// IsEmpty reports whether no detail was recorded
+func (d *PaymentEventDetails) IsEmpty() bool {
+	return d == nil || *d == PaymentEventDetails{}
 }
 
 type PaymentPrivacyStatus struct {
@@ -222,6 +409,41 @@ type PaymentPrivacyRecoveryTx struct {
 	Reason           string                `json:"reason,omitempty"`
 }
 
+// CalldataArg is one decoded argument of a gateway call
+type CalldataArg struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// PaymentCalldataPreview is the createPayment calldata the backend would produce for an input
+type PaymentCalldataPreview struct {
+	PaymentID     uuid.UUID     `json:"paymentId"`
+	SourceChainID string        `json:"sourceChainId"`
+	DestChainID   string        `json:"destChainId"`
+	ChainType     string        `json:"chainType"` // eip155 | solana
+	To            string        `json:"to"`
+	Function      string        `json:"function"`
+	Selector      string        `json:"selector"`
+	Data          string        `json:"data"` // 0x-hex for EVM, base58 for Solana
+	Args          []CalldataArg `json:"args"`
+}
+
+// ApprovalTarget is the ERC20 allowance a payer must grant before paying with a token on a
+// chain: the spender the gateway pulls through and, for a given amount, how much to approve
+type ApprovalTarget struct {
+	ChainID          string `json:"chainId"` // CAIP-2
+	TokenAddress     string `json:"tokenAddress"`
+	TokenSymbol      string `json:"tokenSymbol,omitempty"`
+	Decimals         int    `json:"decimals"`
+	ApprovalRequired bool   `json:"approvalRequired"`
+	Reason           string `json:"reason,omitempty"` // why no approval is needed
+	GatewayAddress   string `json:"gatewayAddress,omitempty"`
+	Spender          string `json:"spender,omitempty"`
+	Amount           string `json:"amount,omitempty"`         // smallest unit
+	ApprovalAmount   string `json:"approvalAmount,omitempty"` // smallest unit
+}
+
 type CreatePaymentAppInput struct {
 	SourceChainID       string `json:"sourceChainId" binding:"required"`
 	DestChainID         string `json:"destChainId" binding:"required"`
@@ -243,4 +465,15 @@ type CreatePaymentAppInput struct {
 	MinDestAmountOut       *string `json:"minDestAmountOut,omitempty"`
 	PrivacyIntentID        *string `json:"privacyIntentId,omitempty"`
 	PrivacyStealthReceiver *string `json:"privacyStealthReceiver,omitempty"`
+
+	// Intent is the payer's EIP-712 signature over this payment, binding it to SenderWalletAddress
+	Intent *PaymentIntentSignature `json:"intent,omitempty"`
+}
+
+// PaymentIntentSignature is a signed PaymentIntent. Nonce is a decimal or 0x uint256 the payer
+// may use once; Deadline is a unix timestamp in seconds.
+type PaymentIntentSignature struct {
+	Nonce     string `json:"nonce" binding:"required"`
+	Deadline  int64  `json:"deadline" binding:"required"`
+	Signature string `json:"signature" binding:"required"`
 }
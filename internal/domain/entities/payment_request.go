@@ -4,16 +4,18 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
 )
 
 // PaymentRequestStatus represents the status of a payment request
 type PaymentRequestStatus string
 
 const (
-	PaymentRequestStatusPending   PaymentRequestStatus = "PENDING"
-	PaymentRequestStatusCompleted PaymentRequestStatus = "COMPLETED"
-	PaymentRequestStatusExpired   PaymentRequestStatus = "EXPIRED"
-	PaymentRequestStatusCancelled PaymentRequestStatus = "CANCELLED"
+	PaymentRequestStatusPending    PaymentRequestStatus = "PENDING"
+	PaymentRequestStatusProcessing PaymentRequestStatus = "PROCESSING" // Paid, awaiting the required confirmations
+	PaymentRequestStatusCompleted  PaymentRequestStatus = "COMPLETED"
+	PaymentRequestStatusExpired    PaymentRequestStatus = "EXPIRED"
+	PaymentRequestStatusCancelled  PaymentRequestStatus = "CANCELLED"
 )
 
 // PaymentRequest represents a merchant's payment request
@@ -29,9 +31,11 @@ type PaymentRequest struct {
 	Amount        string               `json:"amount" gorm:"type:decimal(36,18)"`
 	Decimals      int                  `json:"decimals"`
 	Description   string               `json:"description,omitempty"`
+	Metadata      null.JSON            `json:"metadata,omitempty"` // integrator JSON, never interpreted
 	Status        PaymentRequestStatus `json:"status"`
 	ExpiresAt     time.Time            `json:"expiresAt"`
 	TxHash        string               `json:"txHash,omitempty"`
+	BlockNumber   int64                `json:"blockNumber,omitempty"` // Block that included TxHash, when the indexer reported it
 	CompletedAt   *time.Time           `json:"completedAt,omitempty"`
 	CreatedAt     time.Time            `json:"createdAt"`
 	UpdatedAt     time.Time            `json:"updatedAt"`
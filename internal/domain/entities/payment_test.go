@@ -0,0 +1,65 @@
+package entities
+
+import "testing"
+
+func TestPaymentEventType_IsKnown(t *testing.T) {
+	for _, eventType := range PaymentEventTypes {
+		if !eventType.IsKnown() {
+			t.Fatalf("%s should be known", eventType)
+		}
+	}
+	if PaymentEventType("SOMETHING_ELSE").IsKnown() {
+		t.Fatal("unexpected known event type")
+	}
+}
+
+func TestPaymentModeFromAPIKey(t *testing.T) {
+	cases := map[string]PaymentMode{
+		"pk_test_abc": PaymentModeTest,
+		"sk_test_abc": PaymentModeTest,
+		"pk_live_abc": PaymentModeLive,
+		"pk_test":     PaymentModeLive,
+		"":            PaymentModeLive,
+	}
+	for key, want := range cases {
+		if got := PaymentModeFromAPIKey(key); got != want {
+			t.Fatalf("%q: got %s, want %s", key, got, want)
+		}
+	}
+	if mode, ok := ParsePaymentMode(" Test "); !ok || mode != PaymentModeTest {
+		t.Fatalf("unexpected parse %q %v", mode, ok)
+	}
+	if _, ok := ParsePaymentMode("staging"); ok {
+		t.Fatal("staging is not a mode")
+	}
+}
+
+func TestPaymentEventDetails_IsEmpty(t *testing.T) {
+	var nilDetails *PaymentEventDetails
+	if !nilDetails.IsEmpty() || !(&PaymentEventDetails{}).IsEmpty() {
+		t.Fatal("nil and zero details should be empty")
+	}
+	if (&PaymentEventDetails{TxHash: "0x1"}).IsEmpty() {
+		t.Fatal("details with a tx hash are not empty")
+	}
+}
+
+func TestPaymentBridge_ExplorerURL(t *testing.T) {
+	ccip := &PaymentBridge{Name: "CCIP", ExplorerURLTemplate: "https://ccip.chain.link/msg/{messageId}"}
+	if got := ccip.ExplorerURL("0xabc", "0xtx"); got != "https://ccip.chain.link/msg/0xabc" {
+		t.Fatalf("unexpected link %q", got)
+	}
+	if got := ccip.ExplorerURL("", "0xtx"); got != "" {
+		t.Fatalf("a link without its message ID should be empty, got %q", got)
+	}
+
+	layerZero := &PaymentBridge{ExplorerURLTemplate: "https://layerzeroscan.com/tx/{sourceTxHash}"}
+	if got := layerZero.ExplorerURL("", "0xtx"); got != "https://layerzeroscan.com/tx/0xtx" {
+		t.Fatalf("unexpected link %q", got)
+	}
+
+	var none *PaymentBridge
+	if none.ExplorerURL("0xabc", "0xtx") != "" || (&PaymentBridge{}).ExplorerURL("0xabc", "0xtx") != "" {
+		t.Fatal("bridges without a template have no link")
+	}
+}
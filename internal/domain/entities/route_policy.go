@@ -29,6 +29,7 @@ type RoutePolicy struct {
 	OverheadBytes          string             `json:"overheadBytes,omitempty"`
 	MinFee                 string             `json:"minFee,omitempty"`
 	MaxFee                 string             `json:"maxFee,omitempty"`
+	FallbackBridgeFee      string             `json:"fallbackBridgeFee,omitempty"`
 	CreatedAt              time.Time          `json:"createdAt"`
 	UpdatedAt              time.Time          `json:"updatedAt"`
 	DeletedAt              *time.Time         `json:"-"`
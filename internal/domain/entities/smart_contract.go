@@ -47,6 +47,7 @@ type SmartContract struct {
 	HookAddress     null.String       `json:"hookAddress,omitempty"`
 	StartBlock      uint64            `json:"startBlock"` // Block number deployment/indexing start
 	ABI             interface{}       `json:"abi"`
+	IndexedEvents   []string          `json:"indexedEvents"`      // Canonical event signatures the external indexer follows
 	Metadata        null.JSON         `json:"metadata,omitempty"` // Store extra config like gas limits, timeouts
 	IsActive        bool              `json:"isActive"`
 	CreatedAt       time.Time         `json:"createdAt"`
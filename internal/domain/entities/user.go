@@ -14,8 +14,19 @@ const (
 	UserRoleSubAdmin UserRole = "SUB_ADMIN"
 	UserRolePartner  UserRole = "PARTNER"
 	UserRoleUser     UserRole = "USER"
+	UserRoleSupport  UserRole = "SUPPORT" // Read-only access to the admin API
+	UserRoleFinance  UserRole = "FINANCE" // Admin reads plus fee and settlement configuration
 )
 
+// IsStaff reports whether the role belongs to platform staff rather than a customer
+func (r UserRole) IsStaff() bool {
+	switch r {
+	case UserRoleAdmin, UserRoleSubAdmin, UserRoleSupport, UserRoleFinance:
+		return true
+	}
+	return false
+}
+
 // KYCStatus represents KYC verification status
 type KYCStatus string
 
@@ -36,11 +47,18 @@ type User struct {
 	Role          UserRole   `json:"role"`
 	KYCStatus     KYCStatus  `json:"kycStatus"`
 	KYCVerifiedAt *time.Time `json:"kycVerifiedAt,omitempty"`
+	FeeExempt     bool       `json:"feeExempt"`             // Internal/test accounts skip the platform fee
+	SuspendedAt   *time.Time `json:"suspendedAt,omitempty"` // Set by an admin; suspended users cannot sign in or use API keys
 	CreatedAt     time.Time  `json:"createdAt"`
 	UpdatedAt     time.Time  `json:"updatedAt"`
 	DeletedAt     *time.Time `json:"-"`
 }
 
+// IsSuspended reports whether an admin has suspended the account
+func (u *User) IsSuspended() bool {
+	return u != nil && u.SuspendedAt != nil
+}
+
 // CreateUserInput represents input for creating a user
 type CreateUserInput struct {
 	Email           string `json:"email" binding:"required,email"`
@@ -49,6 +67,7 @@ type CreateUserInput struct {
 	WalletAddress   string `json:"walletAddress" binding:"required"`
 	WalletChainID   string `json:"walletChainId" binding:"required"` // The NetworkID (e.g. "84532")
 	WalletSignature string `json:"walletSignature" binding:"required"`
+	WalletNonce     string `json:"walletNonce"` // From POST /auth/wallet-challenge; required to adopt an unowned wallet
 
 	// Merchant fields (unified registration)
 	IsMerchant   bool   `json:"isMerchant"`
@@ -71,6 +90,15 @@ type AuthResponse struct {
 	User         *User  `json:"user"`
 }
 
+// ImpersonationSession is a short-lived, read-mostly session an admin opens as another user
+type ImpersonationSession struct {
+	AccessToken  string    `json:"-"`
+	SessionID    string    `json:"sessionId,omitempty"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	User         *User     `json:"user"`
+	Impersonator *User     `json:"impersonator"`
+}
+
 // ChangePasswordInput represents input for changing user password.
 type ChangePasswordInput struct {
 	CurrentPassword string `json:"currentPassword" binding:"required,min=8"`
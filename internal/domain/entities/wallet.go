@@ -30,5 +30,19 @@ type ConnectWalletInput struct {
 	ChainID   string `json:"chainId" binding:"required"` // The Network ID (e.g. "1")
 	Address   string `json:"address" binding:"required"`
 	Signature string `json:"signature" binding:"required"`
-	Message   string `json:"message" binding:"required"`
+	Nonce     string `json:"nonce"` // From POST /wallets/challenge; required to claim an unowned wallet
+}
+
+// WalletChallengeInput asks for a wallet ownership challenge. Email is only read on the
+// public registration endpoint, where there is no signed-in user to bind the challenge to.
+type WalletChallengeInput struct {
+	Address string `json:"address" binding:"required"`
+	Email   string `json:"email"`
+}
+
+// WalletChallenge is a single-use nonce and the message a wallet signs to prove it holds an address
+type WalletChallenge struct {
+	Nonce     string    `json:"nonce"`
+	Message   string    `json:"message"`
+	ExpiresAt time.Time `json:"expiresAt"`
 }
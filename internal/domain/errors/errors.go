@@ -2,7 +2,10 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 )
 
 // Domain errors
@@ -17,27 +20,69 @@ var (
 	ErrTokenExpired       = errors.New("token expired")
 	ErrEmailNotVerified   = errors.New("email not verified")
 	ErrMerchantNotActive  = errors.New("merchant not active")
+	ErrAccountSuspended   = errors.New("account suspended")
 	ErrPaymentFailed      = errors.New("payment failed")
 	ErrInsufficientFunds  = errors.New("insufficient funds")
 	ErrUnsupportedChain   = errors.New("unsupported chain")
 	ErrUnsupportedToken   = errors.New("unsupported token")
+
+	ErrInvalidReceiverForChain = errors.New("receiver address does not match destination chain")
+	ErrInvalidAddressForChain  = errors.New("address does not match chain type")
+	ErrReceiverNameUnresolved  = errors.New("receiver name could not be resolved")
+	ErrReceiverNotAllowed      = errors.New("receiver address is not on the merchant allow-list")
+	ErrInvalidPaymentIntent    = errors.New("payment intent signature is invalid")
+	ErrSlippageUnsatisfiable   = errors.New("minimum amount out exceeds the quoted amount")
+	ErrChainIDMismatch         = errors.New("rpc serves a different chain than declared")
+	ErrTransferFeeToken        = errors.New("token charges a fee on transfer")
+	ErrApprovalRequired        = errors.New("payment amount requires admin approval")
+	ErrGatewayNotConfigured    = errors.New("gateway contract is not configured")
+	ErrExplorerNotConfigured   = errors.New("chain has no contract verification API")
+	ErrABIUnavailable          = errors.New("no verified ABI available")
+	ErrABIIncomplete           = errors.New("ABI is missing required functions")
+	ErrFallbackNotReady        = errors.New("fallback bridge fails preflight on this route")
+	ErrGatewayPaused           = errors.New("gateway contract is paused")
+	ErrStillReferenced         = errors.New("resource is still referenced")
+	ErrInactive                = errors.New("resource is inactive")
+	ErrChainModeMismatch       = errors.New("chain does not match the payment mode")
 )
 
 // Standard Error Codes
 const (
-	CodeNotFound           = "ERR_NOT_FOUND"
-	CodeAlreadyExists      = "ERR_ALREADY_EXISTS"
-	CodeInvalidInput       = "ERR_INVALID_INPUT"
-	CodeBadRequest         = "ERR_BAD_REQUEST"
-	CodeUnauthorized       = "ERR_UNAUTHORIZED"
-	CodeForbidden          = "ERR_FORBIDDEN"
-	CodeInternalError      = "ERR_INTERNAL_ERROR"
-	CodeInvalidCredentials = "ERR_INVALID_CREDENTIALS"
-	CodeTokenExpired       = "ERR_TOKEN_EXPIRED"
-	CodeEmailNotVerified   = "ERR_EMAIL_NOT_VERIFIED"
-	CodePaymentFailed      = "ERR_PAYMENT_FAILED"
-	CodeInsufficientFunds  = "ERR_INSUFFICIENT_FUNDS"
-	CodeConflict           = "ERR_CONFLICT"
+	CodeNotFound              = "ERR_NOT_FOUND"
+	CodeAlreadyExists         = "ERR_ALREADY_EXISTS"
+	CodeInvalidInput          = "ERR_INVALID_INPUT"
+	CodeBadRequest            = "ERR_BAD_REQUEST"
+	CodeUnauthorized          = "ERR_UNAUTHORIZED"
+	CodeForbidden             = "ERR_FORBIDDEN"
+	CodeInternalError         = "ERR_INTERNAL_ERROR"
+	CodeInvalidCredentials    = "ERR_INVALID_CREDENTIALS"
+	CodeTokenExpired          = "ERR_TOKEN_EXPIRED"
+	CodeEmailNotVerified      = "ERR_EMAIL_NOT_VERIFIED"
+	CodePaymentFailed         = "ERR_PAYMENT_FAILED"
+	CodeInsufficientFunds     = "ERR_INSUFFICIENT_FUNDS"
+	CodeConflict              = "ERR_CONFLICT"
+	CodeSimulationReverted    = "ERR_SIMULATION_REVERTED"
+	CodeNotContractOwner      = "ERR_NOT_CONTRACT_OWNER"
+	CodeAutoFixInProgress     = "ERR_AUTOFIX_IN_PROGRESS"
+	CodeReceiverNotAllowed    = "ERR_RECEIVER_NOT_ALLOWED"
+	CodeInvalidIntent         = "ERR_INVALID_PAYMENT_INTENT"
+	CodeSlippageUnsatisfiable = "ERR_SLIPPAGE_UNSATISFIABLE"
+	CodeMaintenance           = "ERR_MAINTENANCE"
+	CodeInvalidAddress        = "ERR_INVALID_ADDRESS_FOR_CHAIN"
+	CodeChainIDMismatch       = "ERR_CHAIN_ID_MISMATCH"
+	CodeAccountSuspended      = "ERR_ACCOUNT_SUSPENDED"
+	CodeTransferFeeToken      = "ERR_TRANSFER_FEE_TOKEN"
+	CodeApprovalRequired      = "ERR_APPROVAL_REQUIRED"
+	CodeGatewayNotConfigured  = "ERR_GATEWAY_NOT_CONFIGURED"
+	CodeExplorerNotConfigured = "ERR_EXPLORER_NOT_CONFIGURED"
+	CodeABIUnavailable        = "ERR_ABI_UNAVAILABLE"
+	CodeABIIncomplete         = "ERR_ABI_INCOMPLETE"
+	CodeFallbackNotReady      = "ERR_FALLBACK_BRIDGE_NOT_READY"
+	CodeGatewayPaused         = "ERR_GATEWAY_PAUSED"
+	CodeStillReferenced       = "ERR_STILL_REFERENCED"
+	CodeInactive              = "ERR_INACTIVE"
+	CodeChainModeMismatch     = "ERR_CHAIN_MODE_MISMATCH"
+	CodeRPCUnavailable        = "ERR_RPC_UNAVAILABLE"
 )
 
 // AppError represents application error with HTTP status and string code
@@ -65,6 +110,48 @@ func NewAppError(status int, code string, message string, err error) *AppError {
 	}
 }
 
+// AlreadyExistsError is returned by creates that collide with an existing record. ID is the
+// existing record's ID; errors.Is matches ErrAlreadyExists.
+type AlreadyExistsError struct {
+	ID string
+}
+
+func (e *AlreadyExistsError) Error() string {
+	return ErrAlreadyExists.Error() + ": " + e.ID
+}
+
+func (e *AlreadyExistsError) Unwrap() error {
+	return ErrAlreadyExists
+}
+
+// ReferencedError is returned by hard deletes that other records still point at. References
+// counts the blocking rows per "table.column", or holds a constraint name with a count of 0
+// when only the database knew about the reference; errors.Is matches ErrStillReferenced.
+type ReferencedError struct {
+	References map[string]int64
+}
+
+func (e *ReferencedError) Error() string {
+	keys := make([]string, 0, len(e.References))
+	for key := range e.References {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if e.References[key] == 0 {
+			parts = append(parts, key)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", key, e.References[key]))
+	}
+	return ErrStillReferenced.Error() + " by " + strings.Join(parts, ", ")
+}
+
+func (e *ReferencedError) Unwrap() error {
+	return ErrStillReferenced
+}
+
 // Common error constructors
 func NotFound(message string) *AppError {
 	return NewAppError(http.StatusNotFound, CodeNotFound, message, ErrNotFound)
@@ -82,6 +169,11 @@ func Forbidden(message string) *AppError {
 	return NewAppError(http.StatusForbidden, CodeForbidden, message, ErrForbidden)
 }
 
+// AccountSuspended is the 403 returned to a suspended user on every sign-in path
+func AccountSuspended() *AppError {
+	return NewAppError(http.StatusForbidden, CodeAccountSuspended, "Account is suspended", ErrAccountSuspended)
+}
+
 func InternalError(err error) *AppError {
 	return NewAppError(http.StatusInternalServerError, CodeInternalError, "internal server error", err)
 }
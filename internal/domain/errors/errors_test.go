@@ -47,3 +47,9 @@ func TestAppError_Constructors(t *testing.T) {
 	assert.Equal(t, "boom", internalMsg.Message)
 	assert.Equal(t, "boom", internalMsg.Error())
 }
+
+func TestReferencedError(t *testing.T) {
+	err := &ReferencedError{References: map[string]int64{"tokens.chain_id": 4, "payments.source_chain_id": 2}}
+	assert.Equal(t, "resource is still referenced by payments.source_chain_id (2), tokens.chain_id (4)", err.Error())
+	assert.ErrorIs(t, err, ErrStillReferenced)
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+)
+
+// ActivityCursor is the (created_at, id) position of the last feed item a client has seen
+type ActivityCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// ActivityFilter selects whose activity to list: payments the user sent, plus payments to and
+// payment requests of MerchantID. MerchantID is nil for users without a merchant. Only items in
+// Mode are listed; payment requests carry no mode and follow their chain's network.
+type ActivityFilter struct {
+	UserID     uuid.UUID
+	MerchantID *uuid.UUID
+	Mode       entities.PaymentMode
+}
+
+// ActivityRepository reads the merged payments / payment requests feed
+type ActivityRepository interface {
+	// List returns up to limit items strictly after the cursor (from the newest when nil),
+	// ordered by created_at then id, both descending
+	List(ctx context.Context, filter ActivityFilter, after *ActivityCursor, limit int) ([]*entities.ActivityItem, error)
+}
@@ -20,7 +20,13 @@ type ChainRepository interface {
 	UpdateRPC(ctx context.Context, rpc *entities.ChainRPC) error
 	DeleteRPC(ctx context.Context, id uuid.UUID) error
 	GetActive(ctx context.Context, pagination utils.PaginationParams) ([]*entities.Chain, int64, error)
+	// GetPaginated lists active chains, or all chains when includeInactive is set
+	GetPaginated(ctx context.Context, includeInactive bool, pagination utils.PaginationParams) ([]*entities.Chain, int64, error)
 	Create(ctx context.Context, chain *entities.Chain) error
 	Update(ctx context.Context, chain *entities.Chain) error
+	// Delete soft deletes the chain
 	Delete(ctx context.Context, id uuid.UUID) error
+	// HardDelete removes the chain for good. It returns a *errors.ReferencedError while
+	// any other record still references it.
+	HardDelete(ctx context.Context, id uuid.UUID) error
 }
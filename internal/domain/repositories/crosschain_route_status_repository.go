@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"payment-kita.backend/internal/domain/entities"
+)
+
+// CrosschainRouteStatusRepository keeps the latest recheck result per crosschain route
+type CrosschainRouteStatusRepository interface {
+	// Save upserts the record for its RouteKey. When OverallStatus differs from the stored value
+	// the old value moves to PreviousStatus and StatusChangedAt becomes CheckedAt; record is
+	// updated to what was stored.
+	Save(ctx context.Context, record *entities.CrosschainRouteStatusRecord) error
+	// List returns stored routes ordered by route key; empty chain ids do not filter
+	List(ctx context.Context, sourceChainID, destChainID string) ([]*entities.CrosschainRouteStatusRecord, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+)
+
+// FeatureFlagRepository stores global feature flags and per-merchant overrides
+type FeatureFlagRepository interface {
+	List(ctx context.Context) ([]*entities.FeatureFlag, error)
+	// Upsert creates or updates the row for (name, merchantID); a nil merchantID is the global flag
+	Upsert(ctx context.Context, flag *entities.FeatureFlag) error
+	Delete(ctx context.Context, name string, merchantID *uuid.UUID) error
+}
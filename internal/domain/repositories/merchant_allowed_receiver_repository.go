@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+)
+
+// MerchantAllowedReceiverRepository stores merchants' receiver allow-lists
+type MerchantAllowedReceiverRepository interface {
+	ListByMerchantID(ctx context.Context, merchantID uuid.UUID) ([]*entities.MerchantAllowedReceiver, error)
+	// Create returns ErrAlreadyExists when the merchant already allows the address
+	Create(ctx context.Context, receiver *entities.MerchantAllowedReceiver) error
+	// Delete returns ErrNotFound when id is not one of the merchant's entries
+	Delete(ctx context.Context, merchantID, id uuid.UUID) error
+}
@@ -25,5 +25,8 @@ type WalletRepository interface {
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Wallet, error)
 	GetByAddress(ctx context.Context, chainID uuid.UUID, address string) (*entities.Wallet, error)
 	SetPrimary(ctx context.Context, userID, walletID uuid.UUID) error
+	// ClaimUnowned assigns an unowned wallet to userID. Only one concurrent claim can
+	// win; the others get ErrAlreadyExists.
+	ClaimUnowned(ctx context.Context, walletID, userID uuid.UUID, isPrimary bool) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 }
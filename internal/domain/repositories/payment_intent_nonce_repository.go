@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// PaymentIntentNonceRepository records the signed payment intent nonces already used
+type PaymentIntentNonceRepository interface {
+	// Consume marks nonce as used by payer. It returns ErrAlreadyExists when it already was.
+	Consume(ctx context.Context, payer, nonce string, expiresAt time.Time) error
+}
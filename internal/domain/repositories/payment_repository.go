@@ -2,19 +2,32 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/pkg/utils"
 )
 
 // PaymentRepository defines payment data operations
 type PaymentRepository interface {
 	Create(ctx context.Context, payment *entities.Payment) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Payment, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Payment, int, error)
-	GetByMerchantID(ctx context.Context, merchantID uuid.UUID, limit, offset int) ([]*entities.Payment, int, error)
+	// GetByUserID, GetByMerchantID and GetByMerchantExternalRef list payments in mode only, so
+	// test and live payments are never reported together
+	GetByUserID(ctx context.Context, userID uuid.UUID, mode entities.PaymentMode, pagination utils.PaginationParams) ([]*entities.Payment, int64, error)
+	GetByMerchantID(ctx context.Context, merchantID uuid.UUID, mode entities.PaymentMode, pagination utils.PaginationParams) ([]*entities.Payment, int64, error)
+	// GetByMerchantExternalRef returns a page of the merchant's payments created with the given
+	// order id, and how many there are in all
+	GetByMerchantExternalRef(ctx context.Context, merchantID uuid.UUID, mode entities.PaymentMode, ref string, pagination utils.PaginationParams) ([]*entities.Payment, int64, error)
+	// GetByStatus returns payments in status, oldest first
+	GetByStatus(ctx context.Context, status entities.PaymentStatus, limit, offset int) ([]*entities.Payment, int, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status entities.PaymentStatus) error
 	UpdateDestTxHash(ctx context.Context, id uuid.UUID, txHash string) error
 	MarkRefunded(ctx context.Context, id uuid.UUID) error
 	Update(ctx context.Context, payment *entities.Payment) error
+	// ResolvePendingApproval moves a payment out of PENDING_APPROVAL to status, recording
+	// failureReason and the new expiry. ErrNotFound when no payment is awaiting approval under id.
+	ResolvePendingApproval(ctx context.Context, id uuid.UUID, status entities.PaymentStatus, failureReason null.String, expiresAt *time.Time) error
 }
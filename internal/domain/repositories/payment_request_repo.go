@@ -2,18 +2,47 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
 )
 
+// PaymentRequestSortField is a column merchants can sort their payment requests by
+type PaymentRequestSortField string
+
+const (
+	PaymentRequestSortCreatedAt PaymentRequestSortField = "createdAt"
+	PaymentRequestSortExpiresAt PaymentRequestSortField = "expiresAt"
+	PaymentRequestSortAmount    PaymentRequestSortField = "amount"
+	PaymentRequestSortStatus    PaymentRequestSortField = "status"
+)
+
+// PaymentRequestFilter narrows a merchant's payment request listing. Zero values mean
+// "no filter"; the default order is newest first.
+type PaymentRequestFilter struct {
+	Status      entities.PaymentRequestStatus
+	CreatedFrom *time.Time // inclusive
+	CreatedTo   *time.Time // exclusive
+	SortBy      PaymentRequestSortField
+	Ascending   bool
+}
+
 // PaymentRequestRepository interface
 type PaymentRequestRepository interface {
 	Create(ctx context.Context, request *entities.PaymentRequest) error
+	// CreateBatch inserts all requests in one transaction; none are stored on error
+	CreateBatch(ctx context.Context, requests []*entities.PaymentRequest) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.PaymentRequest, error)
-	GetByMerchantID(ctx context.Context, merchantID uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error)
+	GetByMerchantID(ctx context.Context, merchantID uuid.UUID, filter PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status entities.PaymentRequestStatus) error
+	// CancelPending cancels the request only while it is still pending; false means it was not
+	CancelPending(ctx context.Context, id uuid.UUID) (bool, error)
 	MarkCompleted(ctx context.Context, id uuid.UUID, txHash string) error
+	// MarkProcessing records a payment that is not deep enough yet; only a pending request moves
+	MarkProcessing(ctx context.Context, id uuid.UUID, txHash string, blockNumber int64) error
+	// GetProcessing returns the oldest requests awaiting confirmations
+	GetProcessing(ctx context.Context, limit int) ([]*entities.PaymentRequest, error)
 	GetExpiredPending(ctx context.Context, limit int) ([]*entities.PaymentRequest, error)
 	ExpireRequests(ctx context.Context, ids []uuid.UUID) error
 	UpdatePaymentCode(ctx context.Context, id uuid.UUID, code string) error
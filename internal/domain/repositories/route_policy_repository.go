@@ -8,11 +8,22 @@ import (
 	"payment-kita.backend/pkg/utils"
 )
 
+// RoutePolicyFilter narrows a route policy listing. Zero values mean "no filter"; the order is
+// most recently updated first.
+type RoutePolicyFilter struct {
+	SourceChainID     *uuid.UUID
+	DestChainID       *uuid.UUID
+	DefaultBridgeType *uint8
+}
+
 type RoutePolicyRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.RoutePolicy, error)
 	GetByRoute(ctx context.Context, sourceChainID, destChainID uuid.UUID) (*entities.RoutePolicy, error)
-	List(ctx context.Context, sourceChainID, destChainID *uuid.UUID, pagination utils.PaginationParams) ([]*entities.RoutePolicy, int64, error)
+	List(ctx context.Context, filter RoutePolicyFilter, pagination utils.PaginationParams) ([]*entities.RoutePolicy, int64, error)
 	Create(ctx context.Context, policy *entities.RoutePolicy) error
+	// CreateMissing creates, in one transaction, each policy whose route has none yet and reports
+	// per policy whether it was created
+	CreateMissing(ctx context.Context, policies []*entities.RoutePolicy) ([]bool, error)
 	Update(ctx context.Context, policy *entities.RoutePolicy) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
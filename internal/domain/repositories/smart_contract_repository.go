@@ -8,16 +8,40 @@ import (
 	"payment-kita.backend/pkg/utils"
 )
 
-// SmartContractRepository defines smart contract data operations
+// SmartContractFilter narrows a contract listing. Zero values mean "no filter"; the order is
+// newest first.
+type SmartContractFilter struct {
+	ChainID  *uuid.UUID
+	Type     entities.SmartContractType
+	IsActive *bool  // nil lists active and inactive contracts
+	Search   string // name or address substring, case-insensitive
+	// Indexed keeps only contracts with at least one indexed event
+	Indexed bool
+}
+
+// ActiveContracts filters chainID's active contracts; a nil chainID means every chain
+func ActiveContracts(chainID *uuid.UUID) SmartContractFilter {
+	active := true
+	return SmartContractFilter{ChainID: chainID, IsActive: &active}
+}
+
+// SmartContractRepository defines smart contract data operations. Single-contract lookups return
+// domainerrors.ErrNotFound when nothing matches.
 type SmartContractRepository interface {
 	Create(ctx context.Context, contract *entities.SmartContract) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.SmartContract, error)
 	GetByChainAndAddress(ctx context.Context, chainID uuid.UUID, address string) (*entities.SmartContract, error)
 	// GetActiveContract returns the currently active contract of a specific type on a chain
 	GetActiveContract(ctx context.Context, chainID uuid.UUID, contractType entities.SmartContractType) (*entities.SmartContract, error)
-	GetFiltered(ctx context.Context, chainID *uuid.UUID, contractType entities.SmartContractType, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error)
+	GetFiltered(ctx context.Context, filter SmartContractFilter, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error)
 	GetByChain(ctx context.Context, chainID uuid.UUID, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error)
 	GetAll(ctx context.Context, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error)
 	Update(ctx context.Context, contract *entities.SmartContract) error
+	// Activate makes the contract the single active one of its (chain, type), atomically
+	// deactivating the previous one
+	Activate(ctx context.Context, id uuid.UUID) (*entities.SmartContract, error)
+	// BulkSetActive sets the active flag on every existing contract in ids in one transaction and
+	// returns their previous state. IDs absent from the result do not exist.
+	BulkSetActive(ctx context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error)
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 }
@@ -12,6 +12,9 @@ import (
 type TokenRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entities.Token, error)
 	GetBySymbol(ctx context.Context, symbol string, chainID uuid.UUID) (*entities.Token, error)
+	// GetActiveBySymbol returns every active token with exactly this symbol on an active chain,
+	// with Chain set
+	GetActiveBySymbol(ctx context.Context, symbol string) ([]*entities.Token, error)
 	GetByAddress(ctx context.Context, address string, chainID uuid.UUID) (*entities.Token, error)
 	GetAll(ctx context.Context) ([]*entities.Token, error)
 	GetStablecoins(ctx context.Context) ([]*entities.Token, error)
@@ -19,8 +22,14 @@ type TokenRepository interface {
 	// GetTokensByChain replaces GetSupportedByChain
 	GetTokensByChain(ctx context.Context, chainID uuid.UUID, pagination utils.PaginationParams) ([]*entities.Token, int64, error)
 	// GetAllTokens replaces GetAllSupported
-	GetAllTokens(ctx context.Context, chainID *uuid.UUID, search *string, pagination utils.PaginationParams) ([]*entities.Token, int64, error)
+	GetAllTokens(ctx context.Context, chainID *uuid.UUID, search *string, includeInactive bool, pagination utils.PaginationParams) ([]*entities.Token, int64, error)
 	Create(ctx context.Context, token *entities.Token) error
 	Update(ctx context.Context, token *entities.Token) error
+	// BulkSetActive sets the active flag on every existing token in ids in one transaction and
+	// returns their previous state. IDs absent from the result do not exist.
+	BulkSetActive(ctx context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error)
 	SoftDelete(ctx context.Context, id uuid.UUID) error
+	// HardDelete removes the token for good. It returns a *errors.ReferencedError while
+	// any other record still references it.
+	HardDelete(ctx context.Context, id uuid.UUID) error
 }
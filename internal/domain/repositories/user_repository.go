@@ -5,8 +5,18 @@ import (
 
 	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/pkg/utils"
 )
 
+// UserFilter narrows the admin user listing. Zero values mean "no filter"; the default order
+// is newest first.
+type UserFilter struct {
+	Search    string // name or email substring
+	Role      entities.UserRole
+	Verified  *bool // KYC fully verified, or anything short of it
+	Ascending bool  // oldest first
+}
+
 // UserRepository defines user data operations
 type UserRepository interface {
 	Create(ctx context.Context, user *entities.User) error
@@ -15,7 +25,7 @@ type UserRepository interface {
 	Update(ctx context.Context, user *entities.User) error
 	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
 	SoftDelete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, search string) ([]*entities.User, error)
+	List(ctx context.Context, filter UserFilter, pagination utils.PaginationParams) ([]*entities.User, int64, error)
 }
 
 // EmailVerificationRepository defines email verification operations
@@ -2,6 +2,8 @@ package services
 
 import (
 	"strings"
+
+	"payment-kita.backend/internal/domain/entities"
 )
 
 type FinalityService interface {
@@ -23,11 +25,10 @@ func (s *finalityService) GetRequiredConfirmations(networkID string) int {
 		return 12 // Default fallback
 	}
 	
-	namespace := parts[0]
 	reference := parts[1]
 	
-	switch namespace {
-	case "eip155":
+	switch entities.ChainTypeFromCAIP2(networkID) {
+	case entities.ChainTypeEVM:
 		switch reference {
 		case "1": // Ethereum Mainnet
 			return 12
@@ -40,7 +41,7 @@ func (s *finalityService) GetRequiredConfirmations(networkID string) int {
 		default:
 			return 12
 		}
-	case "solana":
+	case entities.ChainTypeSVM:
 		return 32
 	default:
 		return 12
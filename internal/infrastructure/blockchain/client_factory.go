@@ -10,7 +10,7 @@ var beforeGetEVMClientWriteLockHook func(rpcURL string)
 // ClientFactory manages blockchain clients
 type ClientFactory struct {
 	evmClients    map[string]*EVMClient
-	solanaClients map[string]interface{}
+	solanaClients map[string]*SolanaClient
 	mu            sync.RWMutex
 }
 
@@ -18,7 +18,7 @@ type ClientFactory struct {
 func NewClientFactory() *ClientFactory {
 	return &ClientFactory{
 		evmClients:    make(map[string]*EVMClient),
-		solanaClients: make(map[string]interface{}),
+		solanaClients: make(map[string]*SolanaClient),
 	}
 }
 
@@ -59,3 +59,38 @@ func (f *ClientFactory) RegisterEVMClient(rpcURL string, client *EVMClient) {
 	defer f.mu.Unlock()
 	f.evmClients[rpcURL] = client
 }
+
+// GetSolanaClient returns a Solana client for the given RPC URL
+// If a client already exists for the URL, it returns the cached client
+func (f *ClientFactory) GetSolanaClient(rpcURL string) (*SolanaClient, error) {
+	f.mu.RLock()
+	client, ok := f.solanaClients[rpcURL]
+	f.mu.RUnlock()
+	if ok {
+		return client, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Double check
+	if client, ok := f.solanaClients[rpcURL]; ok {
+		return client, nil
+	}
+
+	newClient, err := NewSolanaClient(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Solana client: %w", err)
+	}
+
+	f.solanaClients[rpcURL] = newClient
+	return newClient, nil
+}
+
+// RegisterSolanaClient injects/overrides cached client for a specific rpcURL.
+// Useful for deterministic unit tests.
+func (f *ClientFactory) RegisterSolanaClient(rpcURL string, client *SolanaClient) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.solanaClients[rpcURL] = client
+}
@@ -82,7 +82,7 @@ func TestClientFactory_GetEVMClient_NewClientSuccessPath(t *testing.T) {
 		getClientChainID = origChainID
 	})
 
-	dialEVMClient = func(string) (*ethclient.Client, error) {
+	dialEVMClient = func(context.Context, string) (*ethclient.Client, error) {
 		return &ethclient.Client{}, nil
 	}
 	getClientChainID = func(*ethclient.Client, context.Context) (*big.Int, error) {
@@ -2,6 +2,7 @@ package blockchain
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"os"
 	"strconv"
@@ -10,12 +11,13 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 var (
-	dialEVMClient    = ethclient.Dial
+	dialEVMClient    = ethclient.DialContext
 	getClientChainID = func(client *ethclient.Client, ctx context.Context) (*big.Int, error) {
 		return client.ChainID(ctx)
 	}
@@ -31,32 +33,74 @@ var (
 
 const defaultEVMCallViewTimeout = 1800 * time.Millisecond
 
+type callTimeoutKey struct{}
+
+// WithCallTimeout overrides the client's per-call timeout for RPC calls made with ctx.
+// A zero timeout leaves the call bounded only by ctx itself.
+func WithCallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, callTimeoutKey{}, timeout)
+}
+
 // EVMClient provides EVM blockchain interaction
 type EVMClient struct {
 	client  *ethclient.Client
 	chainID *big.Int
 	rpcURL  string
+	// callTimeout bounds every RPC call; zero uses PAYMENT_EVM_CALL_TIMEOUT_MS (default 1.8s)
+	callTimeout time.Duration
 	// testCallView allows deterministic unit tests without network sockets.
 	testCallView func(ctx context.Context, to string, data []byte) ([]byte, error)
 }
 
 // NewEVMClient creates a new EVM client
 func NewEVMClient(rpcURL string) (*EVMClient, error) {
-	client, err := dialEVMClient(rpcURL)
+	return NewEVMClientContext(context.Background(), rpcURL)
+}
+
+// NewEVMClientContext is NewEVMClient dialing and reading the chain ID within ctx, so a caller's
+// deadline bounds the connection too
+func NewEVMClientContext(ctx context.Context, rpcURL string) (*EVMClient, error) {
+	client, err := dialEVMClient(ctx, rpcURL)
 	if err != nil {
 		return nil, err
 	}
 
-	chainID, err := getClientChainID(client, context.Background())
+	evmClient := &EVMClient{client: client, rpcURL: rpcURL}
+	callCtx, cancel := evmClient.callContext(ctx)
+	defer cancel()
+	chainID, err := getClientChainID(client, callCtx)
 	if err != nil {
 		return nil, err
 	}
+	evmClient.chainID = chainID
+	return evmClient, nil
+}
 
-	return &EVMClient{
-		client:  client,
-		chainID: chainID,
-		rpcURL:  rpcURL,
-	}, nil
+// SetCallTimeout sets the default timeout applied to each RPC call made through c
+func (c *EVMClient) SetCallTimeout(timeout time.Duration) {
+	c.callTimeout = timeout
+}
+
+// callContext bounds ctx by the per-call override, else the client timeout, unless ctx
+// already has an earlier deadline.
+func (c *EVMClient) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout := c.callTimeout
+	if timeout <= 0 {
+		timeout = resolveEVMCallViewTimeout()
+	}
+	if override, ok := ctx.Value(callTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
 }
 
 // NewEVMClientWithCallView creates an EVM client that uses an injected CallView implementation.
@@ -79,6 +123,8 @@ func (c *EVMClient) ChainID() *big.Int {
 // GetBalance gets the native token balance of an address
 func (c *EVMClient) GetBalance(ctx context.Context, address string) (*big.Int, error) {
 	addr := common.HexToAddress(address)
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
 	return c.client.BalanceAt(ctx, addr, nil)
 }
 
@@ -95,6 +141,8 @@ func (c *EVMClient) GetTokenBalance(ctx context.Context, tokenAddress, ownerAddr
 		Data: data,
 	}
 
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
 	result, err := callContract(c.client, ctx, msg)
 	if err != nil {
 		return nil, err
@@ -103,25 +151,178 @@ func (c *EVMClient) GetTokenBalance(ctx context.Context, tokenAddress, ownerAddr
 	return new(big.Int).SetBytes(result), nil
 }
 
+// simulatedCall is one call of an eth_simulateV1 block
+type simulatedCall struct {
+	From  *common.Address `json:"from,omitempty"`
+	To    common.Address  `json:"to"`
+	Value *hexutil.Big    `json:"value,omitempty"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// simulatedCallResult is the outcome of one simulated call
+type simulatedCallResult struct {
+	ReturnData hexutil.Bytes  `json:"returnData"`
+	Status     hexutil.Uint64 `json:"status"`
+	Error      *struct {
+		Message string `json:"message"`
+		Data    string `json:"data,omitempty"`
+	} `json:"error,omitempty"`
+}
+
+// simulateBlock runs calls in one eth_simulateV1 block on top of the latest state and returns
+// each call's outcome
+func (c *EVMClient) simulateBlock(ctx context.Context, calls []simulatedCall) ([]simulatedCallResult, error) {
+	params := map[string]interface{}{
+		"blockStateCalls": []map[string]interface{}{{"calls": calls}},
+	}
+	var blocks []struct {
+		Calls []simulatedCallResult `json:"calls"`
+	}
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
+	if err := c.client.Client().CallContext(ctx, &blocks, "eth_simulateV1", params, "latest"); err != nil {
+		return nil, err
+	}
+	if len(blocks) != 1 || len(blocks[0].Calls) != len(calls) {
+		return nil, fmt.Errorf("unexpected eth_simulateV1 result shape")
+	}
+	return blocks[0].Calls, nil
+}
+
+// BundleCall is one transaction of a bundle passed to SimulateBundle
+type BundleCall struct {
+	To    string
+	Value *big.Int
+	Data  []byte
+}
+
+// BundleCallResult is how one transaction of a simulated bundle ended. A reverted call has the
+// RPC's message and, when the RPC returned it, the revert data.
+type BundleCallResult struct {
+	ReturnData []byte
+	Reverted   bool
+	Message    string
+	RevertData []byte
+}
+
+// SimulateBundle dry-runs calls from one sender, in order, in one eth_simulateV1 block, so a
+// transaction can be checked on top of earlier ones that are not mined yet (an approve, say).
+// Nothing is signed and from needs no gas. RPCs without eth_simulateV1 return an error.
+func (c *EVMClient) SimulateBundle(ctx context.Context, from string, calls []BundleCall) ([]BundleCallResult, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("evm client has no rpc connection")
+	}
+	sender := common.HexToAddress(from)
+	simulated := make([]simulatedCall, 0, len(calls))
+	for _, call := range calls {
+		entry := simulatedCall{From: &sender, To: common.HexToAddress(call.To), Input: call.Data}
+		if call.Value != nil && call.Value.Sign() > 0 {
+			entry.Value = (*hexutil.Big)(call.Value)
+		}
+		simulated = append(simulated, entry)
+	}
+	results, err := c.simulateBlock(ctx, simulated)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]BundleCallResult, len(results))
+	for i, result := range results {
+		out[i].ReturnData = result.ReturnData
+		if result.Status == 1 {
+			continue
+		}
+		out[i].Reverted = true
+		out[i].Message = "execution reverted"
+		if result.Error != nil {
+			if result.Error.Message != "" {
+				out[i].Message = result.Error.Message
+			}
+			if data, err := hexutil.Decode(result.Error.Data); err == nil {
+				out[i].RevertData = data
+			}
+		}
+		if len(out[i].RevertData) == 0 && len(result.ReturnData) > 0 {
+			out[i].RevertData = result.ReturnData
+		}
+	}
+	return out, nil
+}
+
+// SimulateTokenTransfer dry-runs an ERC20 transfer of amount from one address to another and
+// returns how much the recipient's balance actually grew. balanceOf, transfer and balanceOf
+// run in one eth_simulateV1 block, so nothing is signed and from needs the tokens but no gas.
+// RPCs without eth_simulateV1 return an error.
+func (c *EVMClient) SimulateTokenTransfer(ctx context.Context, tokenAddress, from, to string, amount *big.Int) (*big.Int, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("evm client has no rpc connection")
+	}
+	token := common.HexToAddress(tokenAddress)
+	sender := common.HexToAddress(from)
+	recipient := common.HexToAddress(to)
+
+	// balanceOf(address) selector: 0x70a08231
+	balanceOf := append(common.Hex2Bytes("70a08231"), common.LeftPadBytes(recipient.Bytes(), 32)...)
+	// transfer(address,uint256) selector: 0xa9059cbb
+	transfer := append(common.Hex2Bytes("a9059cbb"), common.LeftPadBytes(recipient.Bytes(), 32)...)
+	transfer = append(transfer, common.LeftPadBytes(amount.Bytes(), 32)...)
+
+	calls, err := c.simulateBlock(ctx, []simulatedCall{
+		{To: token, Input: balanceOf},
+		{From: &sender, To: token, Input: transfer},
+		{To: token, Input: balanceOf},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, call := range calls {
+		if call.Status != 1 {
+			reason := "reverted"
+			if call.Error != nil && call.Error.Message != "" {
+				reason = call.Error.Message
+			}
+			return nil, fmt.Errorf("simulated call %d failed: %s", i, reason)
+		}
+	}
+	// Tokens that return false instead of reverting
+	if len(calls[1].ReturnData) == 32 && new(big.Int).SetBytes(calls[1].ReturnData).Sign() == 0 {
+		return nil, fmt.Errorf("simulated transfer returned false")
+	}
+
+	before := new(big.Int).SetBytes(calls[0].ReturnData)
+	after := new(big.Int).SetBytes(calls[2].ReturnData)
+	return after.Sub(after, before), nil
+}
+
 // GetTransaction gets transaction details
 func (c *EVMClient) GetTransaction(ctx context.Context, txHash string) (*types.Transaction, bool, error) {
 	hash := common.HexToHash(txHash)
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
 	return c.client.TransactionByHash(ctx, hash)
 }
 
 // GetTransactionReceipt gets transaction receipt
 func (c *EVMClient) GetTransactionReceipt(ctx context.Context, txHash string) (*types.Receipt, error) {
 	hash := common.HexToHash(txHash)
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
 	return c.client.TransactionReceipt(ctx, hash)
 }
 
 // GetBlockNumber gets the latest block number
 func (c *EVMClient) GetBlockNumber(ctx context.Context) (uint64, error) {
+	if c.testCallView != nil && c.client == nil {
+		return 0, fmt.Errorf("block number is not available on a call-view test client")
+	}
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
 	return c.client.BlockNumber(ctx)
 }
 
 // EstimateGas estimates gas for a transaction
 func (c *EVMClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	ctx, cancel := c.callContext(ctx)
+	defer cancel()
 	return c.client.EstimateGas(ctx, msg)
 }
 
@@ -130,18 +331,8 @@ func (c *EVMClient) CallView(ctx context.Context, to string, data []byte) ([]byt
 	if c.testCallView != nil {
 		return c.testCallView(ctx, to, data)
 	}
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	callCtx := ctx
-	timeout := resolveEVMCallViewTimeout()
-	if timeout > 0 {
-		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > timeout {
-			var cancel context.CancelFunc
-			callCtx, cancel = context.WithTimeout(ctx, timeout)
-			defer cancel()
-		}
-	}
+	callCtx, cancel := c.callContext(ctx)
+	defer cancel()
 	addr := common.HexToAddress(to)
 	msg := ethereum.CallMsg{
 		To:   &addr,
@@ -150,6 +341,24 @@ func (c *EVMClient) CallView(ctx context.Context, to string, data []byte) ([]byt
 	return c.client.CallContract(callCtx, msg, nil)
 }
 
+// CallFrom runs eth_call as from, sending value with data, so a transaction the user is about
+// to sign can be checked for a revert first. Reverts come back as the RPC error.
+func (c *EVMClient) CallFrom(ctx context.Context, from, to string, value *big.Int, data []byte) ([]byte, error) {
+	if c.client == nil {
+		return nil, fmt.Errorf("evm client has no rpc connection")
+	}
+	sender := common.HexToAddress(from)
+	addr := common.HexToAddress(to)
+	callCtx, cancel := c.callContext(ctx)
+	defer cancel()
+	return callContract(c.client, callCtx, ethereum.CallMsg{
+		From:  sender,
+		To:    &addr,
+		Value: value,
+		Data:  data,
+	})
+}
+
 // Close closes the client connection
 func (c *EVMClient) Close() {
 	if c.client != nil {
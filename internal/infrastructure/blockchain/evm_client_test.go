@@ -10,6 +10,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -214,7 +215,7 @@ func TestNewEVMClient_ErrorBranches(t *testing.T) {
 			dialEVMClient = origDial
 			getClientChainID = origChainID
 		})
-		dialEVMClient = func(string) (*ethclient.Client, error) {
+		dialEVMClient = func(context.Context, string) (*ethclient.Client, error) {
 			return nil, errors.New("dial failed")
 		}
 		_, err := NewEVMClient("mock://rpc")
@@ -229,7 +230,7 @@ func TestNewEVMClient_ErrorBranches(t *testing.T) {
 			dialEVMClient = origDial
 			getClientChainID = origChainID
 		})
-		dialEVMClient = func(string) (*ethclient.Client, error) {
+		dialEVMClient = func(context.Context, string) (*ethclient.Client, error) {
 			return &ethclient.Client{}, nil
 		}
 		getClientChainID = func(*ethclient.Client, context.Context) (*big.Int, error) {
@@ -247,7 +248,7 @@ func TestNewEVMClient_ErrorBranches(t *testing.T) {
 			dialEVMClient = origDial
 			getClientChainID = origChainID
 		})
-		dialEVMClient = func(string) (*ethclient.Client, error) {
+		dialEVMClient = func(context.Context, string) (*ethclient.Client, error) {
 			return &ethclient.Client{}, nil
 		}
 		getClientChainID = func(*ethclient.Client, context.Context) (*big.Int, error) {
@@ -316,6 +317,35 @@ func TestEVMClient_GetTokenBalance_SuccessViaCallContractHook(t *testing.T) {
 	require.Equal(t, "1000", bal.String())
 }
 
+func TestEVMClient_CallFrom(t *testing.T) {
+	origCall := callContract
+	defer func() { callContract = origCall }()
+
+	var got ethereum.CallMsg
+	callContract = func(_ *ethclient.Client, _ context.Context, msg ethereum.CallMsg) ([]byte, error) {
+		got = msg
+		return []byte{0x01}, nil
+	}
+	client := &EVMClient{client: &ethclient.Client{}}
+
+	out, err := client.CallFrom(
+		context.Background(),
+		"0x3333333333333333333333333333333333333333",
+		"0x4444444444444444444444444444444444444444",
+		big.NewInt(5),
+		[]byte{0xaa},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, out)
+	require.Equal(t, "0x3333333333333333333333333333333333333333", got.From.Hex())
+	require.Equal(t, "0x4444444444444444444444444444444444444444", got.To.Hex())
+	require.Equal(t, big.NewInt(5), got.Value)
+	require.Equal(t, []byte{0xaa}, got.Data)
+
+	_, err = (&EVMClient{}).CallFrom(context.Background(), "0x3333333333333333333333333333333333333333", "0x4444444444444444444444444444444444444444", nil, nil)
+	require.Error(t, err)
+}
+
 func TestEVMClient_DefaultHookBodies_AreReachable(t *testing.T) {
 	require.Panics(t, func() {
 		_, _ = getClientChainID(&ethclient.Client{}, context.Background())
@@ -324,3 +354,139 @@ func TestEVMClient_DefaultHookBodies_AreReachable(t *testing.T) {
 		closeEVMClient(&ethclient.Client{})
 	})
 }
+
+func TestEVMClient_CallTimeout_BoundsHungRPC(t *testing.T) {
+	srv := newEVMRPCServer(t)
+	defer srv.Close()
+	release := make(chan struct{})
+	hung := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer hung.Close()
+	defer close(release)
+
+	client, err := NewEVMClient(srv.URL)
+	require.NoError(t, err)
+	rpc, err := ethclient.Dial(hung.URL)
+	require.NoError(t, err)
+	client.client = rpc
+	client.SetCallTimeout(100 * time.Millisecond)
+
+	start := time.Now()
+	_, err = client.CallView(context.Background(), "0x4444444444444444444444444444444444444444", []byte{0x12})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+
+	start = time.Now()
+	_, err = client.GetBlockNumber(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+
+	// A per-call override wins over the client default.
+	start = time.Now()
+	_, err = client.GetBalance(WithCallTimeout(context.Background(), 20*time.Millisecond), "0x3333333333333333333333333333333333333333")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestEVMClient_SimulateTokenTransfer(t *testing.T) {
+	word := func(v int64) string {
+		return "0x" + common.Bytes2Hex(common.LeftPadBytes(big.NewInt(v).Bytes(), 32))
+	}
+	var transferResult, transferStatus string
+	var gotParams json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcReq
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		res := rpcResp{JSONRPC: "2.0", ID: req.ID, Result: "0x2105"}
+		if req.Method == "eth_simulateV1" {
+			gotParams = req.Params
+			// The recipient had 100 and receives 1000 less a 2% fee
+			res.Result = []interface{}{map[string]interface{}{"calls": []interface{}{
+				map[string]interface{}{"returnData": word(100), "status": "0x1"},
+				map[string]interface{}{"returnData": transferResult, "status": transferStatus, "error": map[string]interface{}{"message": "execution reverted: paused"}},
+				map[string]interface{}{"returnData": word(1080), "status": "0x1"},
+			}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer srv.Close()
+
+	client, err := NewEVMClient(srv.URL)
+	require.NoError(t, err)
+	defer client.Close()
+	ctx := context.Background()
+	token := "0x4444444444444444444444444444444444444444"
+	holder := "0x3333333333333333333333333333333333333333"
+	recipient := "0x000000000000000000000000000000000000fee1"
+
+	transferResult, transferStatus = word(1), "0x1"
+	received, err := client.SimulateTokenTransfer(ctx, token, holder, recipient, big.NewInt(1000))
+	require.NoError(t, err)
+	require.Equal(t, "980", received.String())
+	params := string(gotParams)
+	require.Contains(t, params, `"from":"`+holder+`"`)
+	require.Contains(t, params, "0xa9059cbb"+strings.Repeat("0", 24)+strings.TrimPrefix(recipient, "0x"))
+	require.Contains(t, params, `"latest"`)
+
+	transferResult = word(0)
+	_, err = client.SimulateTokenTransfer(ctx, token, holder, recipient, big.NewInt(1000))
+	require.EqualError(t, err, "simulated transfer returned false")
+
+	transferResult, transferStatus = "0x", "0x0"
+	_, err = client.SimulateTokenTransfer(ctx, token, holder, recipient, big.NewInt(1000))
+	require.EqualError(t, err, "simulated call 1 failed: execution reverted: paused")
+
+	_, err = (&EVMClient{}).SimulateTokenTransfer(ctx, token, holder, recipient, big.NewInt(1))
+	require.Error(t, err)
+}
+
+func TestEVMClient_SimulateBundle(t *testing.T) {
+	var gotParams json.RawMessage
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcReq
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		res := rpcResp{JSONRPC: "2.0", ID: req.ID, Result: "0x2105"}
+		if req.Method == "eth_simulateV1" {
+			gotParams = req.Params
+			// The approve goes through; createPayment reverts with custom error data
+			res.Result = []interface{}{map[string]interface{}{"calls": []interface{}{
+				map[string]interface{}{"returnData": "0x" + common.Bytes2Hex(common.LeftPadBytes([]byte{1}, 32)), "status": "0x1"},
+				map[string]interface{}{"returnData": "0x", "status": "0x0", "error": map[string]interface{}{"message": "execution reverted", "data": "0xdeadbeef"}},
+			}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer srv.Close()
+
+	client, err := NewEVMClient(srv.URL)
+	require.NoError(t, err)
+	defer client.Close()
+	ctx := context.Background()
+	payer := "0x3333333333333333333333333333333333333333"
+
+	results, err := client.SimulateBundle(ctx, payer, []BundleCall{
+		{To: "0x4444444444444444444444444444444444444444", Data: []byte{0x09, 0x5e, 0xa7, 0xb3}},
+		{To: "0x1111111111111111111111111111111111111111", Value: big.NewInt(100), Data: []byte{0xab}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.False(t, results[0].Reverted)
+	require.True(t, results[1].Reverted)
+	require.Equal(t, "execution reverted", results[1].Message)
+	require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, results[1].RevertData)
+	params := string(gotParams)
+	require.Equal(t, 2, strings.Count(params, `"from":"`+payer+`"`))
+	require.Contains(t, params, `"value":"0x64"`)
+
+	_, err = client.SimulateBundle(ctx, payer, []BundleCall{{To: payer}})
+	require.EqualError(t, err, "unexpected eth_simulateV1 result shape")
+
+	_, err = (&EVMClient{}).SimulateBundle(ctx, payer, nil)
+	require.Error(t, err)
+}
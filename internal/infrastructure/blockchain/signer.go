@@ -0,0 +1,190 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// SignerModeLocal signs with a raw private key held in process memory; meant for development
+	SignerModeLocal = "local"
+	// SignerModeRemote delegates signing to an external signer over JSON-RPC, e.g. Web3Signer
+	// backed by AWS KMS, GCP KMS or an HSM, so the key never enters this process
+	SignerModeRemote = "remote"
+)
+
+// TxSigner signs EVM transactions for a single account
+type TxSigner interface {
+	Address() common.Address
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// NewTxSigner builds the signer selected by mode. An empty mode means local.
+func NewTxSigner(mode, privateKeyHex, remoteURL, remoteAddress string) (TxSigner, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", SignerModeLocal:
+		return NewPrivateKeySigner(privateKeyHex)
+	case SignerModeRemote:
+		return NewRemoteSigner(remoteURL, remoteAddress)
+	default:
+		return nil, fmt.Errorf("unknown signer mode %q", mode)
+	}
+}
+
+type privateKeySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewPrivateKeySigner signs with a hex-encoded secp256k1 private key
+func NewPrivateKeySigner(privateKeyHex string) (TxSigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKeyHex), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return &privateKeySigner{key: key, address: crypto.PubkeyToAddress(key.PublicKey)}, nil
+}
+
+func (s *privateKeySigner) Address() common.Address {
+	return s.address
+}
+
+func (s *privateKeySigner) SignTx(_ context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+type remoteSigner struct {
+	url     string
+	address common.Address
+}
+
+// NewRemoteSigner signs through eth_signTransaction on an external signer that holds the key
+// for address
+func NewRemoteSigner(url, address string) (TxSigner, error) {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return nil, fmt.Errorf("remote signer url is required")
+	}
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("invalid remote signer address %q", address)
+	}
+	return &remoteSigner{url: url, address: common.HexToAddress(address)}, nil
+}
+
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+// remoteSignArgs follows the eth_signTransaction request object
+type remoteSignArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+func (s *remoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := remoteSignArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	client, err := rpc.DialContext(ctx, s.url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer: %w", err)
+	}
+	defer client.Close()
+
+	var result json.RawMessage
+	if err := client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("remote signer rejected transaction: %w", err)
+	}
+	raw, err := decodeSignTransactionResult(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("invalid signed transaction from remote signer: %w", err)
+	}
+	// Never broadcast something other than what was asked for, or signed by another key
+	if !sameTxPayload(tx, signed, chainID) {
+		return nil, fmt.Errorf("remote signer returned a different transaction")
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signature: %w", err)
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("remote signer signed with %s, expected %s", sender.Hex(), s.address.Hex())
+	}
+	return signed, nil
+}
+
+// sameTxPayload reports whether got is want as signed for chainID: same type, chain, pricing
+// and call. Any difference could cost more gas than agreed or replay on another chain.
+func sameTxPayload(want, got *types.Transaction, chainID *big.Int) bool {
+	if want.Type() != got.Type() || got.ChainId().Cmp(chainID) != 0 {
+		return false
+	}
+	if want.Nonce() != got.Nonce() || want.Gas() != got.Gas() || want.Value().Cmp(got.Value()) != 0 {
+		return false
+	}
+	if want.GasPrice().Cmp(got.GasPrice()) != 0 ||
+		want.GasTipCap().Cmp(got.GasTipCap()) != 0 ||
+		want.GasFeeCap().Cmp(got.GasFeeCap()) != 0 {
+		return false
+	}
+	if !bytes.Equal(want.Data(), got.Data()) {
+		return false
+	}
+	if want.To() == nil || got.To() == nil {
+		return want.To() == got.To()
+	}
+	return *want.To() == *got.To()
+}
+
+// decodeSignTransactionResult accepts the raw transaction hex that most signers return, or the
+// {"raw": ..., "tx": ...} object returned by geth-style nodes
+func decodeSignTransactionResult(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var wrapped struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &wrapped); err != nil || len(wrapped.Raw) == 0 {
+		return nil, fmt.Errorf("unexpected eth_signTransaction result")
+	}
+	return wrapped.Raw, nil
+}
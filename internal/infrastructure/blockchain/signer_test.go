@@ -0,0 +1,175 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	signerTestKey  = "0x4c0883a69102937d6231471b5dbb6204fe51296170827931e8f95f6f8d5d2f66"
+	signerOtherKey = "0x8f2a55949038a9610f50fb23b5883af3b4ecb3c3bb792cbcefbd1542c692be63"
+)
+
+func signerTestTx() *types.Transaction {
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(8453),
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      []byte{0xde, 0xad},
+	})
+}
+
+// newRemoteSignerServer answers eth_signTransaction by signing tx with key
+func newRemoteSignerServer(t *testing.T, key string, tx *types.Transaction) *httptest.Server {
+	t.Helper()
+	return newRemoteSignerServerForChain(t, key, tx, big.NewInt(8453))
+}
+
+// newRemoteSignerServerForChain is newRemoteSignerServer signing for chainID
+func newRemoteSignerServerForChain(t *testing.T, key string, tx *types.Transaction, chainID *big.Int) *httptest.Server {
+	t.Helper()
+	local, err := NewPrivateKeySigner(key)
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		res := map[string]interface{}{"jsonrpc": "2.0", "id": req["id"]}
+		signed, err := local.SignTx(r.Context(), tx, chainID)
+		require.NoError(t, err)
+		raw, _ := signed.MarshalBinary()
+		res["result"] = hexutil.Encode(raw)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestNewTxSigner_Modes(t *testing.T) {
+	signer, err := NewTxSigner("", signerTestKey, "", "")
+	require.NoError(t, err)
+	key, _ := crypto.HexToECDSA(signerTestKey[2:])
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	_, err = NewTxSigner(SignerModeLocal, "not-a-key", "", "")
+	require.Error(t, err)
+	_, err = NewTxSigner(SignerModeRemote, "", "", "0x0000000000000000000000000000000000000001")
+	require.Error(t, err)
+	_, err = NewTxSigner(SignerModeRemote, "", "http://signer", "not-an-address")
+	require.Error(t, err)
+	_, err = NewTxSigner("hsm", "", "", "")
+	require.Error(t, err)
+}
+
+func TestPrivateKeySigner_SignTx(t *testing.T) {
+	signer, err := NewPrivateKeySigner(signerTestKey)
+	require.NoError(t, err)
+
+	signed, err := signer.SignTx(context.Background(), signerTestTx(), big.NewInt(8453))
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(8453)), signed)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address(), sender)
+}
+
+func TestRemoteSigner_SignTx(t *testing.T) {
+	local, _ := NewPrivateKeySigner(signerTestKey)
+	tx := signerTestTx()
+	srv := newRemoteSignerServer(t, signerTestKey, tx)
+
+	remote, err := NewRemoteSigner(srv.URL, local.Address().Hex())
+	require.NoError(t, err)
+	signed, err := remote.SignTx(context.Background(), tx, big.NewInt(8453))
+	require.NoError(t, err)
+	require.Equal(t, tx.Nonce(), signed.Nonce())
+	require.Equal(t, tx.Data(), signed.Data())
+}
+
+func TestRemoteSigner_RejectsWrongSenderAndTamperedTx(t *testing.T) {
+	local, _ := NewPrivateKeySigner(signerTestKey)
+	tx := signerTestTx()
+
+	srv := newRemoteSignerServer(t, signerOtherKey, tx)
+	remote, err := NewRemoteSigner(srv.URL, local.Address().Hex())
+	require.NoError(t, err)
+	_, err = remote.SignTx(context.Background(), tx, big.NewInt(8453))
+	require.ErrorContains(t, err, "remote signer signed with")
+
+	srv = newRemoteSignerServer(t, signerTestKey, tx)
+	remote, err = NewRemoteSigner(srv.URL, local.Address().Hex())
+	require.NoError(t, err)
+	other := types.NewTx(&types.DynamicFeeTx{
+		ChainID: big.NewInt(8453), Nonce: 8, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2),
+		Gas: 21000, To: tx.To(), Value: big.NewInt(0), Data: tx.Data(),
+	})
+	_, err = remote.SignTx(context.Background(), other, big.NewInt(8453))
+	require.ErrorContains(t, err, "different transaction")
+}
+
+func TestRemoteSigner_RejectsRepricedOrRetargetedTx(t *testing.T) {
+	local, _ := NewPrivateKeySigner(signerTestKey)
+	tx := signerTestTx()
+	dynamic := func(chainID int64, tip, feeCap int64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID: big.NewInt(chainID), Nonce: tx.Nonce(), GasTipCap: big.NewInt(tip), GasFeeCap: big.NewInt(feeCap),
+			Gas: tx.Gas(), To: tx.To(), Value: tx.Value(), Data: tx.Data(),
+		})
+	}
+	legacy := func(gasPrice int64) *types.Transaction {
+		return types.NewTx(&types.LegacyTx{
+			Nonce: tx.Nonce(), GasPrice: big.NewInt(gasPrice), Gas: tx.Gas(), To: tx.To(), Value: tx.Value(), Data: tx.Data(),
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		requested *types.Transaction
+		returned  *types.Transaction
+		chainID   int64
+	}{
+		"gas tip cap":  {tx, dynamic(8453, 5, 2), 8453},
+		"gas fee cap":  {tx, dynamic(8453, 1, 500), 8453},
+		"tx type":      {tx, legacy(2), 8453},
+		"gas price":    {legacy(2), legacy(900), 8453},
+		"chain id":     {tx, dynamic(1, 1, 2), 1},
+		"legacy chain": {legacy(2), legacy(2), 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := newRemoteSignerServerForChain(t, signerTestKey, tc.returned, big.NewInt(tc.chainID))
+			remote, err := NewRemoteSigner(srv.URL, local.Address().Hex())
+			require.NoError(t, err)
+			_, err = remote.SignTx(context.Background(), tc.requested, big.NewInt(8453))
+			require.ErrorContains(t, err, "different transaction")
+		})
+	}
+
+	// A legacy tx signed as requested still goes through
+	srv := newRemoteSignerServer(t, signerTestKey, legacy(2))
+	remote, err := NewRemoteSigner(srv.URL, local.Address().Hex())
+	require.NoError(t, err)
+	_, err = remote.SignTx(context.Background(), legacy(2), big.NewInt(8453))
+	require.NoError(t, err)
+}
+
+func TestDecodeSignTransactionResult(t *testing.T) {
+	raw, err := decodeSignTransactionResult(json.RawMessage(`{"raw":"0x0102","tx":{}}`))
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2}, raw)
+
+	_, err = decodeSignTransactionResult(json.RawMessage(`{"tx":{}}`))
+	require.Error(t, err)
+}
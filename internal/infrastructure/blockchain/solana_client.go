@@ -0,0 +1,276 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	defaultSolanaCallTimeout = 3 * time.Second
+	// solanaCommitment is the commitment reads and simulations are made at
+	solanaCommitment = "confirmed"
+)
+
+// SolanaClient provides Solana blockchain interaction over JSON-RPC
+type SolanaClient struct {
+	client *rpc.Client
+	rpcURL string
+	// callTimeout bounds every RPC call; zero uses defaultSolanaCallTimeout
+	callTimeout time.Duration
+}
+
+// SolanaAccount is an account's state as returned by getAccountInfo
+type SolanaAccount struct {
+	Lamports   uint64
+	Owner      string
+	Data       []byte
+	Executable bool
+}
+
+// SolanaSimulation is the outcome of simulateTransaction. Err is nil when the transaction would
+// succeed, otherwise the raw transaction error.
+type SolanaSimulation struct {
+	Err           json.RawMessage
+	Logs          []string
+	UnitsConsumed uint64
+}
+
+// SolanaSignatureStatus is a transaction's status as returned by getSignatureStatuses.
+// Confirmations is nil once the transaction is finalized.
+type SolanaSignatureStatus struct {
+	Slot               uint64
+	Confirmations      *uint64
+	ConfirmationStatus string
+	Err                json.RawMessage
+}
+
+// NewSolanaClient creates a new Solana client. No request is made until the first call.
+func NewSolanaClient(rpcURL string) (*SolanaClient, error) {
+	client, err := rpc.DialContext(context.Background(), rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	return &SolanaClient{client: client, rpcURL: rpcURL}, nil
+}
+
+// SetCallTimeout sets the default timeout applied to each RPC call made through c
+func (c *SolanaClient) SetCallTimeout(timeout time.Duration) {
+	c.callTimeout = timeout
+}
+
+// call runs one JSON-RPC method, bounded like EVMClient calls
+func (c *SolanaClient) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout := c.callTimeout
+	if timeout <= 0 {
+		timeout = defaultSolanaCallTimeout
+	}
+	if override, ok := ctx.Value(callTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+	if deadline, ok := ctx.Deadline(); timeout > 0 && (!ok || time.Until(deadline) > timeout) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := c.client.CallContext(ctx, result, method, params...); err != nil {
+		return fmt.Errorf("solana %s failed: %w", method, err)
+	}
+	return nil
+}
+
+// GetBalance gets the lamport balance of an address
+func (c *SolanaClient) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	var out struct {
+		Value uint64 `json:"value"`
+	}
+	if err := c.call(ctx, &out, "getBalance", address, map[string]string{"commitment": solanaCommitment}); err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(out.Value), nil
+}
+
+// GetTokenBalance gets the SPL token balance of an owner, summed over its token accounts for mint
+func (c *SolanaClient) GetTokenBalance(ctx context.Context, mintAddress, ownerAddress string) (*big.Int, error) {
+	var out struct {
+		Value []struct {
+			Account struct {
+				Data struct {
+					Parsed struct {
+						Info struct {
+							TokenAmount struct {
+								Amount string `json:"amount"`
+							} `json:"tokenAmount"`
+						} `json:"info"`
+					} `json:"parsed"`
+				} `json:"data"`
+			} `json:"account"`
+		} `json:"value"`
+	}
+	err := c.call(ctx, &out, "getTokenAccountsByOwner",
+		ownerAddress,
+		map[string]string{"mint": mintAddress},
+		map[string]string{"encoding": "jsonParsed", "commitment": solanaCommitment},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	total := new(big.Int)
+	for _, account := range out.Value {
+		amount, ok := new(big.Int).SetString(account.Account.Data.Parsed.Info.TokenAmount.Amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid token amount %q", account.Account.Data.Parsed.Info.TokenAmount.Amount)
+		}
+		total.Add(total, amount)
+	}
+	return total, nil
+}
+
+// GetAccountInfo reads an account's state, e.g. program data. It returns nil when the account
+// does not exist.
+func (c *SolanaClient) GetAccountInfo(ctx context.Context, address string) (*SolanaAccount, error) {
+	var out struct {
+		Value *struct {
+			Lamports   uint64   `json:"lamports"`
+			Owner      string   `json:"owner"`
+			Data       []string `json:"data"`
+			Executable bool     `json:"executable"`
+		} `json:"value"`
+	}
+	err := c.call(ctx, &out, "getAccountInfo", address, map[string]string{"encoding": "base64", "commitment": solanaCommitment})
+	if err != nil || out.Value == nil {
+		return nil, err
+	}
+
+	account := &SolanaAccount{Lamports: out.Value.Lamports, Owner: out.Value.Owner, Executable: out.Value.Executable}
+	if len(out.Value.Data) > 0 {
+		if account.Data, err = base64.StdEncoding.DecodeString(out.Value.Data[0]); err != nil {
+			return nil, fmt.Errorf("invalid account data: %w", err)
+		}
+	}
+	return account, nil
+}
+
+// GetSlot gets the latest slot, Solana's counterpart of a block number
+func (c *SolanaClient) GetSlot(ctx context.Context) (uint64, error) {
+	var slot uint64
+	err := c.call(ctx, &slot, "getSlot", map[string]string{"commitment": solanaCommitment})
+	return slot, err
+}
+
+// GetGenesisHash gets the cluster's genesis hash. Its first 32 characters are the CAIP-2
+// reference of the cluster (solana:5eykt4Us… for mainnet).
+func (c *SolanaClient) GetGenesisHash(ctx context.Context) (string, error) {
+	var hash string
+	err := c.call(ctx, &hash, "getGenesisHash")
+	return hash, err
+}
+
+// GetLatestBlockhash gets a recent blockhash to build a transaction with, and the last block
+// height at which a transaction using it is still accepted
+func (c *SolanaClient) GetLatestBlockhash(ctx context.Context) (string, uint64, error) {
+	var out struct {
+		Value struct {
+			Blockhash            string `json:"blockhash"`
+			LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+		} `json:"value"`
+	}
+	if err := c.call(ctx, &out, "getLatestBlockhash", map[string]string{"commitment": solanaCommitment}); err != nil {
+		return "", 0, err
+	}
+	return out.Value.Blockhash, out.Value.LastValidBlockHeight, nil
+}
+
+// GetFeeForMessage quotes the lamport fee of a serialized transaction message
+func (c *SolanaClient) GetFeeForMessage(ctx context.Context, message []byte) (uint64, error) {
+	var out struct {
+		Value *uint64 `json:"value"`
+	}
+	err := c.call(ctx, &out, "getFeeForMessage", base64.StdEncoding.EncodeToString(message), map[string]string{"commitment": solanaCommitment})
+	if err != nil {
+		return 0, err
+	}
+	if out.Value == nil {
+		return 0, fmt.Errorf("fee unavailable: the message blockhash has expired")
+	}
+	return *out.Value, nil
+}
+
+// SimulateTransaction dry-runs a serialized transaction, the counterpart of an EVM eth_call.
+// Signatures are not verified and the blockhash is replaced, so unsigned transactions work.
+func (c *SolanaClient) SimulateTransaction(ctx context.Context, tx []byte) (*SolanaSimulation, error) {
+	var out struct {
+		Value struct {
+			Err           json.RawMessage `json:"err"`
+			Logs          []string        `json:"logs"`
+			UnitsConsumed uint64          `json:"unitsConsumed"`
+		} `json:"value"`
+	}
+	err := c.call(ctx, &out, "simulateTransaction", base64.StdEncoding.EncodeToString(tx), map[string]interface{}{
+		"encoding":               "base64",
+		"commitment":             solanaCommitment,
+		"sigVerify":              false,
+		"replaceRecentBlockhash": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	simulation := &SolanaSimulation{Logs: out.Value.Logs, UnitsConsumed: out.Value.UnitsConsumed}
+	if len(out.Value.Err) > 0 && string(out.Value.Err) != "null" {
+		simulation.Err = out.Value.Err
+	}
+	return simulation, nil
+}
+
+// SendTransaction submits a signed, serialized transaction and returns its signature
+func (c *SolanaClient) SendTransaction(ctx context.Context, tx []byte) (string, error) {
+	var signature string
+	err := c.call(ctx, &signature, "sendTransaction", base64.StdEncoding.EncodeToString(tx), map[string]string{
+		"encoding":            "base64",
+		"preflightCommitment": solanaCommitment,
+	})
+	return signature, err
+}
+
+// GetSignatureStatus gets a transaction's status. It returns nil when the signature is unknown.
+func (c *SolanaClient) GetSignatureStatus(ctx context.Context, signature string) (*SolanaSignatureStatus, error) {
+	var out struct {
+		Value []*struct {
+			Slot               uint64          `json:"slot"`
+			Confirmations      *uint64         `json:"confirmations"`
+			ConfirmationStatus string          `json:"confirmationStatus"`
+			Err                json.RawMessage `json:"err"`
+		} `json:"value"`
+	}
+	err := c.call(ctx, &out, "getSignatureStatuses", []string{signature}, map[string]bool{"searchTransactionHistory": true})
+	if err != nil || len(out.Value) == 0 || out.Value[0] == nil {
+		return nil, err
+	}
+
+	status := &SolanaSignatureStatus{
+		Slot:               out.Value[0].Slot,
+		Confirmations:      out.Value[0].Confirmations,
+		ConfirmationStatus: out.Value[0].ConfirmationStatus,
+	}
+	if len(out.Value[0].Err) > 0 && string(out.Value[0].Err) != "null" {
+		status.Err = out.Value[0].Err
+	}
+	return status, nil
+}
+
+// Close closes the client connection
+func (c *SolanaClient) Close() {
+	if c.client != nil {
+		c.client.Close()
+	}
+}
@@ -0,0 +1,175 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newSolanaRPCStub serves results[method] for each JSON-RPC call and records the params seen
+func newSolanaRPCStub(t *testing.T, results map[string]string) (*SolanaClient, map[string][]json.RawMessage) {
+	t.Helper()
+	seen := make(map[string][]json.RawMessage)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		seen[req.Method] = req.Params
+		w.Header().Set("Content-Type", "application/json")
+		result, ok := results[req.Method]
+		if !ok {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"error":{"code":-32601,"message":"Method not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":` + result + `}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewSolanaClient(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client, seen
+}
+
+func TestSolanaClient_Reads(t *testing.T) {
+	programData := base64.StdEncoding.EncodeToString([]byte{0x01, 0x02})
+	client, seen := newSolanaRPCStub(t, map[string]string{
+		"getBalance": `{"context":{"slot":1},"value":1500000000}`,
+		"getTokenAccountsByOwner": `{"context":{"slot":1},"value":[
+			{"pubkey":"a","account":{"data":{"parsed":{"info":{"tokenAmount":{"amount":"1000000"}}}}}},
+			{"pubkey":"b","account":{"data":{"parsed":{"info":{"tokenAmount":{"amount":"250"}}}}}}
+		]}`,
+		"getAccountInfo":     `{"context":{"slot":1},"value":{"lamports":42,"owner":"Prog111","data":["` + programData + `","base64"],"executable":false}}`,
+		"getSlot":            `123456`,
+		"getGenesisHash":     `"5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dw2N9d"`,
+		"getLatestBlockhash": `{"context":{"slot":1},"value":{"blockhash":"Hash111","lastValidBlockHeight":999}}`,
+		"getFeeForMessage":   `{"context":{"slot":1},"value":5000}`,
+	})
+	ctx := context.Background()
+
+	balance, err := client.GetBalance(ctx, "Owner111")
+	require.NoError(t, err)
+	require.Equal(t, "1500000000", balance.String())
+
+	tokenBalance, err := client.GetTokenBalance(ctx, "Mint111", "Owner111")
+	require.NoError(t, err)
+	require.Equal(t, "1000250", tokenBalance.String())
+	require.JSONEq(t, `{"mint":"Mint111"}`, string(seen["getTokenAccountsByOwner"][1]))
+
+	account, err := client.GetAccountInfo(ctx, "State111")
+	require.NoError(t, err)
+	require.Equal(t, &SolanaAccount{Lamports: 42, Owner: "Prog111", Data: []byte{0x01, 0x02}}, account)
+
+	slot, err := client.GetSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(123456), slot)
+
+	genesis, err := client.GetGenesisHash(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdpKuc147dw2N9d", genesis)
+
+	blockhash, lastValid, err := client.GetLatestBlockhash(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Hash111", blockhash)
+	require.Equal(t, uint64(999), lastValid)
+
+	fee, err := client.GetFeeForMessage(ctx, []byte{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5000), fee)
+	require.JSONEq(t, `"`+base64.StdEncoding.EncodeToString([]byte{0xaa})+`"`, string(seen["getFeeForMessage"][0]))
+}
+
+func TestSolanaClient_MissingValues(t *testing.T) {
+	client, _ := newSolanaRPCStub(t, map[string]string{
+		"getAccountInfo":       `{"context":{"slot":1},"value":null}`,
+		"getFeeForMessage":     `{"context":{"slot":1},"value":null}`,
+		"getSignatureStatuses": `{"context":{"slot":1},"value":[null]}`,
+	})
+	ctx := context.Background()
+
+	account, err := client.GetAccountInfo(ctx, "Missing111")
+	require.NoError(t, err)
+	require.Nil(t, account)
+
+	_, err = client.GetFeeForMessage(ctx, []byte{0xaa})
+	require.ErrorContains(t, err, "blockhash has expired")
+
+	status, err := client.GetSignatureStatus(ctx, "Sig111")
+	require.NoError(t, err)
+	require.Nil(t, status)
+
+	_, err = client.GetSlot(ctx)
+	require.ErrorContains(t, err, "solana getSlot failed")
+}
+
+func TestSolanaClient_Transactions(t *testing.T) {
+	client, seen := newSolanaRPCStub(t, map[string]string{
+		"simulateTransaction":  `{"context":{"slot":1},"value":{"err":{"InstructionError":[0,{"Custom":6001}]},"logs":["Program log: slippage"],"unitsConsumed":1400}}`,
+		"sendTransaction":      `"Sig111"`,
+		"getSignatureStatuses": `{"context":{"slot":1},"value":[{"slot":77,"confirmations":10,"confirmationStatus":"confirmed","err":null}]}`,
+	})
+	ctx := context.Background()
+
+	simulation, err := client.SimulateTransaction(ctx, []byte{0x01})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"InstructionError":[0,{"Custom":6001}]}`, string(simulation.Err))
+	require.Equal(t, []string{"Program log: slippage"}, simulation.Logs)
+	require.Equal(t, uint64(1400), simulation.UnitsConsumed)
+
+	signature, err := client.SendTransaction(ctx, []byte{0x01})
+	require.NoError(t, err)
+	require.Equal(t, "Sig111", signature)
+	require.JSONEq(t, `{"encoding":"base64","preflightCommitment":"confirmed"}`, string(seen["sendTransaction"][1]))
+
+	status, err := client.GetSignatureStatus(ctx, signature)
+	require.NoError(t, err)
+	require.Equal(t, uint64(77), status.Slot)
+	require.Equal(t, uint64(10), *status.Confirmations)
+	require.Equal(t, "confirmed", status.ConfirmationStatus)
+	require.Nil(t, status.Err)
+}
+
+func TestSolanaClient_CallTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	client, err := NewSolanaClient(server.URL)
+	require.NoError(t, err)
+	client.SetCallTimeout(20 * time.Millisecond)
+
+	start := time.Now()
+	_, err = client.GetSlot(context.Background())
+	require.Error(t, err)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestClientFactory_GetSolanaClient(t *testing.T) {
+	f := NewClientFactory()
+	_, err := f.GetSolanaClient("://bad-url")
+	require.ErrorContains(t, err, "failed to create Solana client")
+
+	first, err := f.GetSolanaClient("http://127.0.0.1:8899")
+	require.NoError(t, err)
+	second, err := f.GetSolanaClient("http://127.0.0.1:8899")
+	require.NoError(t, err)
+	require.Same(t, first, second)
+
+	injected := &SolanaClient{rpcURL: "mock://solana"}
+	f.RegisterSolanaClient("mock://solana", injected)
+	got, err := f.GetSolanaClient("mock://solana")
+	require.NoError(t, err)
+	require.Same(t, injected, got)
+}
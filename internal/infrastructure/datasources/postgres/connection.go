@@ -20,6 +20,9 @@ func NewConnection(cfg config.DatabaseConfig) (*sql.DB, error) {
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
 	)
+	if cfg.StatementTimeout > 0 {
+		dsn += fmt.Sprintf(" statement_timeout=%d", cfg.StatementTimeout.Milliseconds())
+	}
 
 	db, err := sqlOpen("postgres", dsn)
 	if err != nil {
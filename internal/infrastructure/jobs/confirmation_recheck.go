@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type heldCompletionRechecker interface {
+	RecheckHeldCompletions(ctx context.Context, batchSize int) (int, error)
+}
+
+// ConfirmationRecheckJob finishes payments and payment requests held back for confirmations
+// once their chain has advanced far enough, so completion does not depend on the indexer
+// reporting the same event again
+type ConfirmationRecheckJob struct {
+	rechecker heldCompletionRechecker
+	interval  time.Duration
+	batchSize int
+	stop      chan struct{}
+}
+
+func NewConfirmationRecheckJob(rechecker heldCompletionRechecker, interval time.Duration, batchSize int) *ConfirmationRecheckJob {
+	return &ConfirmationRecheckJob{
+		rechecker: rechecker,
+		interval:  interval,
+		batchSize: batchSize,
+		stop:      make(chan struct{}),
+	}
+}
+
+func (j *ConfirmationRecheckJob) Start(ctx context.Context) {
+	log.Println("🕐 Starting confirmation recheck job...")
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("⏹️ Confirmation recheck job stopped (context cancelled)")
+			return
+		case <-j.stop:
+			log.Println("⏹️ Confirmation recheck job stopped")
+			return
+		case <-ticker.C:
+			j.recheck(ctx)
+		}
+	}
+}
+
+func (j *ConfirmationRecheckJob) Stop() {
+	close(j.stop)
+}
+
+func (j *ConfirmationRecheckJob) recheck(ctx context.Context) {
+	completed, err := j.rechecker.RecheckHeldCompletions(ctx, j.batchSize)
+	if err != nil {
+		log.Printf("❌ Error rechecking held completions: %v", err)
+	}
+	if completed > 0 {
+		log.Printf("✅ Completed %d payments and payment requests after confirmations", completed)
+	}
+}
@@ -0,0 +1,154 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/utils"
+)
+
+type crosschainRouteChecker interface {
+	Overview(ctx context.Context, sourceChainInput, destChainInput string, pagination utils.PaginationParams) (*usecases.CrosschainOverview, error)
+}
+
+// CrosschainRouteHealthJob rechecks every crosschain route on an interval. The usecase saves each
+// result to the route status store, which serves the overview with source=db. With a notifier,
+// a route going from READY to ERROR is alerted.
+type CrosschainRouteHealthJob struct {
+	checker  crosschainRouteChecker
+	interval time.Duration
+	stop     chan struct{}
+
+	notifier     usecases.RouteAlertNotifier
+	dedupeWindow time.Duration
+	lastStatus   map[string]string     // route key -> overall status at the previous check
+	alerted      map[string]routeAlert // route key -> last alert sent
+	now          func() time.Time
+}
+
+// routeAlert remembers an alert so the same failure is not re-alerted while the route flaps
+type routeAlert struct {
+	fingerprint string
+	at          time.Time
+}
+
+func NewCrosschainRouteHealthJob(checker crosschainRouteChecker, interval time.Duration) *CrosschainRouteHealthJob {
+	return &CrosschainRouteHealthJob{
+		checker:    checker,
+		interval:   interval,
+		stop:       make(chan struct{}),
+		lastStatus: map[string]string{},
+		alerted:    map[string]routeAlert{},
+		now:        time.Now,
+	}
+}
+
+// NewCrosschainRouteHealthJobWithAlerts is NewCrosschainRouteHealthJob that sends a notification
+// when a route degrades. The same failure (route and issue codes) is alerted at most once per
+// dedupeWindow, so a flapping route does not flood the channel.
+func NewCrosschainRouteHealthJobWithAlerts(checker crosschainRouteChecker, interval time.Duration, notifier usecases.RouteAlertNotifier, dedupeWindow time.Duration) *CrosschainRouteHealthJob {
+	j := NewCrosschainRouteHealthJob(checker, interval)
+	j.notifier = notifier
+	j.dedupeWindow = dedupeWindow
+	return j
+}
+
+// Start checks once right away, so the store is filled soon after boot, then on every tick
+func (j *CrosschainRouteHealthJob) Start(ctx context.Context) {
+	log.Println("🕐 Starting crosschain route health job...")
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	j.checkRoutes(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("⏹️ Crosschain route health job stopped (context cancelled)")
+			return
+		case <-j.stop:
+			log.Println("⏹️ Crosschain route health job stopped")
+			return
+		case <-ticker.C:
+			j.checkRoutes(ctx)
+		}
+	}
+}
+
+func (j *CrosschainRouteHealthJob) Stop() {
+	close(j.stop)
+}
+
+func (j *CrosschainRouteHealthJob) checkRoutes(ctx context.Context) {
+	overview, err := j.checker.Overview(ctx, "", "", utils.PaginationParams{})
+	if err != nil {
+		log.Printf("❌ Error checking crosschain routes: %v", err)
+		return
+	}
+
+	failing := 0
+	for _, route := range overview.Items {
+		if route.OverallStatus != "READY" {
+			failing++
+		}
+		if j.degraded(route) {
+			j.alert(ctx, route)
+		}
+		j.lastStatus[route.RouteKey] = route.OverallStatus
+	}
+	log.Printf("✅ Checked %d crosschain routes (%d not ready)", len(overview.Items), failing)
+}
+
+// degraded reports whether route went from READY to ERROR since the previous check. For a route
+// the job has not seen yet (e.g. after a restart) the stored history decides: it degraded only if
+// the change happened in this very check.
+func (j *CrosschainRouteHealthJob) degraded(route usecases.CrosschainRouteStatus) bool {
+	if route.OverallStatus != "ERROR" {
+		return false
+	}
+	if previous, seen := j.lastStatus[route.RouteKey]; seen {
+		return previous == "READY"
+	}
+	return route.PreviousStatus == "READY" && route.CheckedAt != nil && route.StatusChangedAt != nil &&
+		route.StatusChangedAt.Equal(*route.CheckedAt)
+}
+
+func (j *CrosschainRouteHealthJob) alert(ctx context.Context, route usecases.CrosschainRouteStatus) {
+	if j.notifier == nil {
+		return
+	}
+	var failing []usecases.ContractConfigCheckItem
+	var codes []string
+	for _, issue := range route.Issues {
+		if issue.Status == "ERROR" {
+			failing = append(failing, issue)
+			codes = append(codes, issue.Code)
+		}
+	}
+	sort.Strings(codes)
+	fingerprint := strings.Join(codes, ",")
+	now := j.now()
+	if last, ok := j.alerted[route.RouteKey]; ok && last.fingerprint == fingerprint && now.Sub(last.at) < j.dedupeWindow {
+		return
+	}
+
+	err := j.notifier.NotifyRouteDegraded(ctx, usecases.RouteDegradedAlert{
+		RouteKey:       route.RouteKey,
+		SourceChainID:  route.SourceChainID,
+		DestChainID:    route.DestChainID,
+		PreviousStatus: "READY",
+		OverallStatus:  route.OverallStatus,
+		Issues:         failing,
+		DetectedAt:     now,
+	})
+	if err != nil {
+		log.Printf("❌ Error sending route degraded alert for %s: %v", route.RouteKey, err)
+		return
+	}
+	j.alerted[route.RouteKey] = routeAlert{fingerprint: fingerprint, at: now}
+	log.Printf("🚨 Alerted route degraded: %s", route.RouteKey)
+}
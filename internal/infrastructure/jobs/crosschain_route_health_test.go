@@ -0,0 +1,127 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/utils"
+)
+
+type crosschainRouteCheckerStub struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (s *crosschainRouteCheckerStub) Overview(_ context.Context, src, dst string, _ utils.PaginationParams) (*usecases.CrosschainOverview, error) {
+	s.calls.Add(1)
+	if src != "" || dst != "" {
+		return nil, errors.New("expected every route")
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &usecases.CrosschainOverview{Items: []usecases.CrosschainRouteStatus{
+		{RouteKey: "a", OverallStatus: "READY"},
+		{RouteKey: "b", OverallStatus: "ERROR"},
+	}}, nil
+}
+
+func TestCrosschainRouteHealthJob_ChecksOnStartAndEveryTick(t *testing.T) {
+	checker := &crosschainRouteCheckerStub{}
+	job := NewCrosschainRouteHealthJob(checker, 5*time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		job.Start(context.Background())
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return checker.calls.Load() >= 3 }, time.Second, time.Millisecond)
+	job.Stop()
+	<-done
+}
+
+func TestCrosschainRouteHealthJob_ErrorDoesNotStopJob(t *testing.T) {
+	checker := &crosschainRouteCheckerStub{err: errors.New("rpc down")}
+	job := NewCrosschainRouteHealthJob(checker, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		job.Start(ctx)
+		close(done)
+	}()
+	require.Eventually(t, func() bool { return checker.calls.Load() >= 2 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
+
+type routeSequenceChecker struct {
+	statuses []usecases.CrosschainRouteStatus
+}
+
+func (s *routeSequenceChecker) Overview(context.Context, string, string, utils.PaginationParams) (*usecases.CrosschainOverview, error) {
+	next := s.statuses[0]
+	s.statuses = s.statuses[1:]
+	return &usecases.CrosschainOverview{Items: []usecases.CrosschainRouteStatus{next}}, nil
+}
+
+type routeAlertRecorder struct {
+	alerts []usecases.RouteDegradedAlert
+}
+
+func (r *routeAlertRecorder) NotifyRouteDegraded(_ context.Context, alert usecases.RouteDegradedAlert) error {
+	r.alerts = append(r.alerts, alert)
+	return nil
+}
+
+func TestCrosschainRouteHealthJob_AlertsOnDegradation(t *testing.T) {
+	ready := usecases.CrosschainRouteStatus{RouteKey: "r", OverallStatus: "READY"}
+	failing := usecases.CrosschainRouteStatus{RouteKey: "r", OverallStatus: "ERROR", Issues: []usecases.ContractConfigCheckItem{
+		{Code: "FEE_QUOTE_FAILED", Status: "ERROR"},
+		{Code: "SOMETHING_OK", Status: "OK"},
+	}}
+	checker := &routeSequenceChecker{statuses: []usecases.CrosschainRouteStatus{
+		ready, failing, failing, // one alert; the persistent failure is not re-alerted
+		ready, failing, // flap within the dedupe window: suppressed
+		ready, failing, // after the window: alerted again
+	}}
+	notifier := &routeAlertRecorder{}
+	job := NewCrosschainRouteHealthJobWithAlerts(checker, time.Minute, notifier, time.Hour)
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	job.now = func() time.Time { return clock }
+
+	for i := 0; i < 5; i++ {
+		job.checkRoutes(context.Background())
+	}
+	require.Len(t, notifier.alerts, 1)
+	require.Equal(t, "r", notifier.alerts[0].RouteKey)
+	require.Equal(t, "READY", notifier.alerts[0].PreviousStatus)
+	require.Len(t, notifier.alerts[0].Issues, 1)
+	require.Equal(t, "FEE_QUOTE_FAILED", notifier.alerts[0].Issues[0].Code)
+
+	clock = clock.Add(2 * time.Hour)
+	job.checkRoutes(context.Background())
+	job.checkRoutes(context.Background())
+	require.Len(t, notifier.alerts, 2)
+}
+
+func TestCrosschainRouteHealthJob_FirstCheckUsesStoredHistory(t *testing.T) {
+	checkedAt := time.Now()
+	earlier := checkedAt.Add(-time.Hour)
+	changedNow := usecases.CrosschainRouteStatus{RouteKey: "a", OverallStatus: "ERROR", PreviousStatus: "READY", CheckedAt: &checkedAt, StatusChangedAt: &checkedAt}
+	changedBefore := usecases.CrosschainRouteStatus{RouteKey: "b", OverallStatus: "ERROR", PreviousStatus: "READY", CheckedAt: &checkedAt, StatusChangedAt: &earlier}
+
+	notifier := &routeAlertRecorder{}
+	job := NewCrosschainRouteHealthJobWithAlerts(&routeSequenceChecker{statuses: []usecases.CrosschainRouteStatus{changedNow}}, time.Minute, notifier, time.Hour)
+	job.checkRoutes(context.Background())
+	require.Len(t, notifier.alerts, 1)
+
+	job = NewCrosschainRouteHealthJobWithAlerts(&routeSequenceChecker{statuses: []usecases.CrosschainRouteStatus{changedBefore}}, time.Minute, notifier, time.Hour)
+	job.checkRoutes(context.Background())
+	require.Len(t, notifier.alerts, 1)
+}
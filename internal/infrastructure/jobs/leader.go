@@ -0,0 +1,156 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/infrastructure/metrics"
+	"payment-kita.backend/pkg/redis"
+)
+
+// DefaultLeaderLeaseKey is the Redis key the replicas compete for
+const DefaultLeaderLeaseKey = "jobs:leader"
+
+// defaultLeaderLeaseTTL is used when NewLeaderElector gets no TTL
+const defaultLeaderLeaseTTL = 30 * time.Second
+
+// leaseStore holds the leader lease. Renew and Release only act while holder still owns key.
+type leaseStore interface {
+	Acquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error)
+	Renew(ctx context.Context, key, holder string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, key, holder string) error
+}
+
+type redisLeaseStore struct{}
+
+func (redisLeaseStore) Acquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	return redis.SetNX(ctx, key, holder, ttl)
+}
+
+func (redisLeaseStore) Renew(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	return redis.ExpireIfValue(ctx, key, holder, ttl)
+}
+
+func (redisLeaseStore) Release(ctx context.Context, key, holder string) error {
+	_, err := redis.DelIfValue(ctx, key, holder)
+	return err
+}
+
+// LeaderElector elects one replica through a Redis lease. The leader renews the lease every
+// third of its TTL; followers retry as often, so when the leader dies another replica takes over
+// within one TTL.
+type LeaderElector struct {
+	store  leaseStore
+	key    string
+	id     string
+	ttl    time.Duration
+	leader atomic.Bool
+	now    func() time.Time
+}
+
+// NewLeaderElector competes for key with a lease of ttl, under an ID unique to this process
+func NewLeaderElector(key string, ttl time.Duration) *LeaderElector {
+	if ttl <= 0 {
+		ttl = defaultLeaderLeaseTTL
+	}
+	host, _ := os.Hostname()
+	return &LeaderElector{
+		store: redisLeaseStore{},
+		key:   key,
+		id:    fmt.Sprintf("%s/%s", host, uuid.NewString()),
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+// IsLeader reports whether this replica holds the lease right now
+func (e *LeaderElector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Run competes for the lease until ctx is done. Each time this replica is elected, lead runs
+// with a context that is cancelled when the lease is lost; Run waits for lead to return before
+// competing again, so two replicas never lead at once within the lease TTL.
+func (e *LeaderElector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	interval := e.ttl / 3
+	for {
+		acquired, err := e.store.Acquire(ctx, e.key, e.id, e.ttl)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("⚠️ Leader election for %s failed: %v", e.key, err)
+		}
+		if acquired {
+			e.lead(ctx, interval, lead)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (e *LeaderElector) lead(ctx context.Context, interval time.Duration, lead func(ctx context.Context)) {
+	log.Printf("👑 %s became job leader", e.id)
+	e.setLeader(true)
+	leaderCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(leaderCtx)
+	}()
+
+	e.hold(leaderCtx, interval)
+	cancel()
+	<-done
+	e.setLeader(false)
+
+	// ctx may already be cancelled on shutdown; the lease must still go so a peer takes over now
+	releaseCtx, cancelRelease := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelRelease()
+	if err := e.store.Release(releaseCtx, e.key, e.id); err != nil {
+		log.Printf("⚠️ Failed to release job leader lease, it expires on its own: %v", err)
+	}
+	log.Printf("👋 %s stepped down as job leader", e.id)
+}
+
+// hold renews the lease until ctx is done or the lease is lost. A renewal error is retried, but
+// the leader steps down before the lease could have expired unrenewed.
+func (e *LeaderElector) hold(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	renewed := e.now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		ok, err := e.store.Renew(ctx, e.key, e.id, e.ttl)
+		switch {
+		case err == nil && !ok:
+			log.Printf("⚠️ Job leader lease %s was taken over", e.key)
+			return
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("⚠️ Failed to renew job leader lease: %v", err)
+			if e.now().Sub(renewed) >= e.ttl-interval {
+				return
+			}
+		default:
+			renewed = e.now()
+		}
+	}
+}
+
+func (e *LeaderElector) setLeader(leader bool) {
+	e.leader.Store(leader)
+	metrics.RecordJobLeader(leader)
+}
@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLeaseStore is a lease shared by electors in one test; failRenew makes renewals error
+type memoryLeaseStore struct {
+	mu        sync.Mutex
+	holder    string
+	expires   time.Time
+	failRenew bool
+}
+
+func (s *memoryLeaseStore) Acquire(_ context.Context, _, holder string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holder != "" && time.Now().Before(s.expires) {
+		return false, nil
+	}
+	s.holder, s.expires = holder, time.Now().Add(ttl)
+	return true, nil
+}
+
+func (s *memoryLeaseStore) Renew(_ context.Context, _, holder string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failRenew {
+		return false, errors.New("redis down")
+	}
+	if s.holder != holder || !time.Now().Before(s.expires) {
+		return false, nil
+	}
+	s.expires = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (s *memoryLeaseStore) Release(_ context.Context, _, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.holder == holder {
+		s.holder = ""
+	}
+	return nil
+}
+
+func (s *memoryLeaseStore) setFailRenew(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failRenew = fail
+}
+
+func newTestElector(store leaseStore, id string) *LeaderElector {
+	e := NewLeaderElector(DefaultLeaderLeaseKey, 30*time.Millisecond)
+	e.store = store
+	e.id = id
+	return e
+}
+
+func TestLeaderElector_OneLeaderAndFailover(t *testing.T) {
+	store := &memoryLeaseStore{}
+	var running atomic.Int32
+	var maxRunning atomic.Int32
+	lead := func(ctx context.Context) {
+		n := running.Add(1)
+		if n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		<-ctx.Done()
+		running.Add(-1)
+	}
+
+	first, second := newTestElector(store, "a"), newTestElector(store, "b")
+	firstCtx, stopFirst := context.WithCancel(context.Background())
+	secondCtx, stopSecond := context.WithCancel(context.Background())
+	defer stopSecond()
+	firstDone := make(chan struct{})
+	go func() {
+		first.Run(firstCtx, lead)
+		close(firstDone)
+	}()
+	require.Eventually(t, first.IsLeader, time.Second, time.Millisecond)
+	go second.Run(secondCtx, lead)
+
+	// The follower keeps waiting while the leader renews
+	time.Sleep(100 * time.Millisecond)
+	require.False(t, second.IsLeader())
+	require.EqualValues(t, 1, maxRunning.Load())
+
+	// The leader goes away; the follower takes over
+	stopFirst()
+	<-firstDone
+	require.False(t, first.IsLeader())
+	require.Eventually(t, second.IsLeader, time.Second, time.Millisecond)
+	require.EqualValues(t, 1, maxRunning.Load())
+}
+
+func TestLeaderElector_StepsDownWhenRenewalsFail(t *testing.T) {
+	store := &memoryLeaseStore{}
+	e := newTestElector(store, "a")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var stepped atomic.Bool
+	go e.Run(ctx, func(leaderCtx context.Context) {
+		<-leaderCtx.Done()
+		stepped.Store(true)
+	})
+	require.Eventually(t, e.IsLeader, time.Second, time.Millisecond)
+
+	store.setFailRenew(true)
+	require.Eventually(t, stepped.Load, time.Second, time.Millisecond)
+	require.False(t, e.IsLeader())
+}
+
+func TestSupervisor_RunsJobsOnlyOnLeader(t *testing.T) {
+	store := &memoryLeaseStore{holder: "other", expires: time.Now().Add(time.Hour)}
+	s := newTestSupervisor()
+	s.elector = newTestElector(store, "a")
+	s.Register("ctx-only", func(ctx context.Context) { <-ctx.Done() }, nil)
+
+	s.Start(context.Background())
+	defer s.Stop()
+	time.Sleep(50 * time.Millisecond)
+	require.False(t, s.Leader())
+	require.Equal(t, JobStatusStandby, s.Health()[0].Status)
+
+	// The other replica's lease runs out
+	store.mu.Lock()
+	store.expires = time.Now()
+	store.mu.Unlock()
+	require.Eventually(t, func() bool {
+		return s.Leader() && s.Health()[0].Status == JobStatusRunning
+	}, time.Second, time.Millisecond)
+}
@@ -2,11 +2,13 @@ package jobs
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/infrastructure/metrics"
 	"payment-kita.backend/internal/infrastructure/repositories"
 )
 
@@ -15,21 +17,53 @@ type paymentRequestExpiryRepo interface {
 	ExpireRequests(ctx context.Context, ids []uuid.UUID) error
 }
 
+// Defaults for PaymentRequestExpiryOptions fields left at zero
+const (
+	defaultPaymentRequestExpiryInterval     = 30 * time.Second
+	defaultPaymentRequestExpiryBatchSize    = 100
+	defaultPaymentRequestExpiryBatchTimeout = 10 * time.Second
+)
+
+// maxPaymentRequestExpiryBatches caps one run; a larger backlog is drained over the next ticks
+const maxPaymentRequestExpiryBatches = 50
+
+// PaymentRequestExpiryOptions tunes the expiry job: how often it runs, how many requests one
+// batch expires and how long one batch may hold the database
+type PaymentRequestExpiryOptions struct {
+	Interval     time.Duration
+	BatchSize    int
+	BatchTimeout time.Duration
+}
+
 // PaymentRequestExpiryJob handles expiring payment requests
 type PaymentRequestExpiryJob struct {
-	repo     paymentRequestExpiryRepo
-	interval time.Duration
-	stop     chan struct{}
+	repo         paymentRequestExpiryRepo
+	interval     time.Duration
+	batchSize    int
+	batchTimeout time.Duration
+	stop         chan struct{}
 }
 
 func NewPaymentRequestExpiryJob(repo *repositories.PaymentRequestRepositoryImpl) *PaymentRequestExpiryJob {
 	return &PaymentRequestExpiryJob{
 		repo:     repo,
-		interval: 30 * time.Second, // Check every 30 seconds
+		interval: defaultPaymentRequestExpiryInterval,
 		stop:     make(chan struct{}),
 	}
 }
 
+// NewPaymentRequestExpiryJobWithOptions is NewPaymentRequestExpiryJob with a configured interval,
+// batch size and per-batch timeout
+func NewPaymentRequestExpiryJobWithOptions(repo *repositories.PaymentRequestRepositoryImpl, opts PaymentRequestExpiryOptions) *PaymentRequestExpiryJob {
+	j := NewPaymentRequestExpiryJob(repo)
+	if opts.Interval > 0 {
+		j.interval = opts.Interval
+	}
+	j.batchSize = opts.BatchSize
+	j.batchTimeout = opts.BatchTimeout
+	return j
+}
+
 func (j *PaymentRequestExpiryJob) Start(ctx context.Context) {
 	log.Println("🕐 Starting payment request expiry job...")
 
@@ -54,31 +88,69 @@ func (j *PaymentRequestExpiryJob) Stop() {
 	close(j.stop)
 }
 
+// processExpiredRequests expires pending requests in batches until a short batch shows the
+// backlog is drained. A failed batch ends the run; the next tick picks up where it stopped.
 func (j *PaymentRequestExpiryJob) processExpiredRequests(ctx context.Context) {
-	// Get pending requests that have expired
-	expired, err := j.repo.GetExpiredPending(ctx, 100)
-	if err != nil {
-		log.Printf("❌ Error fetching expired payment requests: %v", err)
-		return
+	batchSize := j.batchSize
+	if batchSize <= 0 {
+		batchSize = defaultPaymentRequestExpiryBatchSize
 	}
 
-	if len(expired) == 0 {
-		return
+	total := 0
+	defer func() { metrics.RecordPaymentRequestExpiryRun(total) }()
+	for batch := 0; batch < maxPaymentRequestExpiryBatches; batch++ {
+		if ctx.Err() != nil {
+			break
+		}
+		expired, err := j.expireBatch(ctx, batchSize)
+		total += expired
+		if err != nil {
+			log.Printf("❌ Error expiring payment requests: %v", err)
+			break
+		}
+		if expired < batchSize {
+			break
+		}
 	}
 
-	log.Printf("🔄 Processing %d expired payment requests...", len(expired))
+	if total > 0 {
+		log.Printf("✅ Expired %d payment requests", total)
+	}
+}
+
+// expireBatch expires up to limit requests under its own deadline, so one slow batch cannot hold
+// a transaction open indefinitely. A panic is returned as an error to keep the job running.
+func (j *PaymentRequestExpiryJob) expireBatch(ctx context.Context, limit int) (expired int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			expired, err = 0, fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	timeout := j.batchTimeout
+	if timeout <= 0 {
+		timeout = defaultPaymentRequestExpiryBatchTimeout
+	}
+	batchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	// Collect IDs
-	var ids []uuid.UUID
-	for _, req := range expired {
+	// Get pending requests that have expired
+	requests, err := j.repo.GetExpiredPending(batchCtx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("fetch expired: %w", err)
+	}
+	if len(requests) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(requests))
+	for _, req := range requests {
 		ids = append(ids, req.ID)
 	}
 
 	// Mark as expired
-	if err := j.repo.ExpireRequests(ctx, ids); err != nil {
-		log.Printf("❌ Error expiring payment requests: %v", err)
-		return
+	if err := j.repo.ExpireRequests(batchCtx, ids); err != nil {
+		return 0, fmt.Errorf("mark %d expired: %w", len(ids), err)
 	}
-
-	log.Printf("✅ Expired %d payment requests", len(expired))
+	return len(ids), nil
 }
@@ -136,3 +136,66 @@ func TestStartStop_TickerTriggersProcessingBeforeStop(t *testing.T) {
 		t.Fatal("job did not stop on Stop() after ticker")
 	}
 }
+
+func TestNewPaymentRequestExpiryJobWithOptions(t *testing.T) {
+	job := NewPaymentRequestExpiryJobWithOptions(nil, PaymentRequestExpiryOptions{Interval: time.Minute, BatchSize: 10, BatchTimeout: time.Second})
+	require.Equal(t, time.Minute, job.interval)
+	require.Equal(t, 10, job.batchSize)
+	require.Equal(t, time.Second, job.batchTimeout)
+
+	job = NewPaymentRequestExpiryJobWithOptions(nil, PaymentRequestExpiryOptions{})
+	require.Equal(t, 30*time.Second, job.interval)
+}
+
+// batchedExpiryRepoStub hands out pending requests limit at a time, like the real query
+type batchedExpiryRepoStub struct {
+	pending   int
+	limits    []int
+	deadlines []bool
+	panicOn   int
+	expired   int
+}
+
+func (s *batchedExpiryRepoStub) GetExpiredPending(ctx context.Context, limit int) ([]*entities.PaymentRequest, error) {
+	s.limits = append(s.limits, limit)
+	_, hasDeadline := ctx.Deadline()
+	s.deadlines = append(s.deadlines, hasDeadline)
+	if len(s.limits) == s.panicOn {
+		panic("driver bug")
+	}
+	n := min(limit, s.pending)
+	out := make([]*entities.PaymentRequest, n)
+	for i := range out {
+		out[i] = &entities.PaymentRequest{ID: uuid.New()}
+	}
+	return out, nil
+}
+
+func (s *batchedExpiryRepoStub) ExpireRequests(_ context.Context, ids []uuid.UUID) error {
+	s.pending -= len(ids)
+	s.expired += len(ids)
+	return nil
+}
+
+func TestProcessExpiredRequests_DrainsInBatches(t *testing.T) {
+	repo := &batchedExpiryRepoStub{pending: 25}
+	job := &PaymentRequestExpiryJob{repo: repo, batchSize: 10, batchTimeout: time.Second, stop: make(chan struct{})}
+
+	job.processExpiredRequests(context.Background())
+	require.Equal(t, 25, repo.expired)
+	require.Equal(t, []int{10, 10, 10}, repo.limits)
+	require.Equal(t, []bool{true, true, true}, repo.deadlines)
+}
+
+func TestProcessExpiredRequests_RecoversFromPanic(t *testing.T) {
+	repo := &batchedExpiryRepoStub{pending: 25, panicOn: 2}
+	job := &PaymentRequestExpiryJob{repo: repo, batchSize: 10, stop: make(chan struct{})}
+
+	require.NotPanics(t, func() { job.processExpiredRequests(context.Background()) })
+	require.Equal(t, 10, repo.expired)
+
+	// The next run carries on with what is left
+	repo.panicOn = 0
+	job.processExpiredRequests(context.Background())
+	require.Equal(t, 25, repo.expired)
+}
@@ -0,0 +1,228 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"payment-kita.backend/internal/infrastructure/metrics"
+)
+
+// Job states reported by Supervisor.Health
+const (
+	JobStatusPending    = "PENDING"
+	JobStatusRunning    = "RUNNING"
+	JobStatusRestarting = "RESTARTING"
+	JobStatusStopped    = "STOPPED"
+	// JobStatusStandby is a job waiting on a replica that is not the leader
+	JobStatusStandby = "STANDBY"
+)
+
+// Restart backoff after a panic; it doubles per consecutive panic up to the maximum, and starts
+// over once a run has lasted longer than the maximum
+const (
+	defaultSupervisorMinBackoff = time.Second
+	defaultSupervisorMaxBackoff = time.Minute
+)
+
+// JobHealth is the state of one supervised job, as served by the admin diagnostics endpoint
+type JobHealth struct {
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	Restarts    int        `json:"restarts"`
+	LastPanic   string     `json:"lastPanic,omitempty"`
+	LastPanicAt *time.Time `json:"lastPanicAt,omitempty"`
+}
+
+type supervisedJob struct {
+	name   string
+	run    func(ctx context.Context)
+	stop   func()
+	health JobHealth
+}
+
+// Supervisor starts and stops the background jobs together. A job that panics is logged and
+// restarted with backoff instead of silently disappearing; a job that returns is left stopped.
+// With an elector, the jobs only run on the replica that is the leader.
+type Supervisor struct {
+	mu      sync.Mutex
+	jobs    []*supervisedJob
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	elector *LeaderElector
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	now        func() time.Time
+}
+
+func NewSupervisor() *Supervisor {
+	return &Supervisor{
+		minBackoff: defaultSupervisorMinBackoff,
+		maxBackoff: defaultSupervisorMaxBackoff,
+		now:        time.Now,
+	}
+}
+
+// NewSupervisorWithLeaderElection is NewSupervisor for multi-replica deployments: the jobs run
+// only while elector holds the leader lease, and stop when it is lost
+func NewSupervisorWithLeaderElection(elector *LeaderElector) *Supervisor {
+	s := NewSupervisor()
+	s.elector = elector
+	return s
+}
+
+// Register adds a job. run must block until ctx is cancelled or stop is called; stop may be nil
+// for jobs that only watch ctx. Jobs registered after Start are not started.
+func (s *Supervisor) Register(name string, run func(ctx context.Context), stop func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, &supervisedJob{
+		name:   name,
+		run:    run,
+		stop:   stop,
+		health: JobHealth{Name: name, Status: JobStatusPending},
+	})
+}
+
+// Start runs every registered job in its own goroutine until ctx is cancelled or Stop is called
+func (s *Supervisor) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ctx, s.cancel = context.WithCancel(ctx)
+	jobs := append([]*supervisedJob(nil), s.jobs...)
+
+	s.wg.Add(1)
+	if s.elector == nil {
+		go func() {
+			defer s.wg.Done()
+			s.runJobs(ctx, jobs)
+		}()
+		return
+	}
+	for _, job := range jobs {
+		job.health.Status = JobStatusStandby
+	}
+	go func() {
+		defer s.wg.Done()
+		s.elector.Run(ctx, func(leaderCtx context.Context) {
+			s.runJobs(leaderCtx, jobs)
+			if ctx.Err() == nil {
+				for _, job := range jobs {
+					s.update(job, func(h *JobHealth) { h.Status = JobStatusStandby })
+				}
+			}
+		})
+	}()
+}
+
+// Leader reports whether this replica runs the jobs: always without an elector, otherwise while
+// it holds the lease
+func (s *Supervisor) Leader() bool {
+	return s.elector == nil || s.elector.IsLeader()
+}
+
+// runJobs supervises jobs until ctx is done and returns once every one of them has returned
+func (s *Supervisor) runJobs(ctx context.Context, jobs []*supervisedJob) {
+	var wg sync.WaitGroup
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.supervise(ctx, job)
+		}()
+	}
+	wg.Wait()
+}
+
+// Stop asks every job to stop and waits for them to return
+func (s *Supervisor) Stop() {
+	s.mu.Lock()
+	jobs := s.jobs
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	for _, job := range jobs {
+		if job.stop != nil {
+			job.stop()
+		}
+	}
+	if cancel != nil {
+		cancel()
+	}
+	s.wg.Wait()
+}
+
+// Health reports every registered job in registration order
+func (s *Supervisor) Health() []JobHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]JobHealth, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		out = append(out, job.health)
+	}
+	return out
+}
+
+func (s *Supervisor) supervise(ctx context.Context, job *supervisedJob) {
+	backoff := s.minBackoff
+	for {
+		startedAt := s.now()
+		s.update(job, func(h *JobHealth) {
+			h.Status = JobStatusRunning
+			h.StartedAt = &startedAt
+		})
+
+		panicked := s.runOnce(ctx, job)
+		if !panicked || ctx.Err() != nil {
+			s.update(job, func(h *JobHealth) { h.Status = JobStatusStopped })
+			return
+		}
+
+		if s.now().Sub(startedAt) > s.maxBackoff {
+			backoff = s.minBackoff
+		}
+		s.update(job, func(h *JobHealth) {
+			h.Status = JobStatusRestarting
+			h.Restarts++
+		})
+		metrics.RecordJobRestart(job.name)
+		log.Printf("🔁 Restarting job %s in %s", job.name, backoff)
+
+		select {
+		case <-ctx.Done():
+			s.update(job, func(h *JobHealth) { h.Status = JobStatusStopped })
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, s.maxBackoff)
+	}
+}
+
+// runOnce runs the job and reports whether it ended in a panic
+func (s *Supervisor) runOnce(ctx context.Context, job *supervisedJob) (panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+			at := s.now()
+			message := fmt.Sprint(r)
+			s.update(job, func(h *JobHealth) {
+				h.LastPanic = message
+				h.LastPanicAt = &at
+			})
+			log.Printf("❌ Job %s panicked: %v\n%s", job.name, r, debug.Stack())
+		}
+	}()
+	job.run(ctx)
+	return false
+}
+
+func (s *Supervisor) update(job *supervisedJob, fn func(h *JobHealth)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&job.health)
+}
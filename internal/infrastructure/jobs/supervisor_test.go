@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSupervisor() *Supervisor {
+	s := NewSupervisor()
+	s.minBackoff = time.Millisecond
+	s.maxBackoff = 4 * time.Millisecond
+	return s
+}
+
+func TestSupervisor_RestartsPanickingJob(t *testing.T) {
+	s := newTestSupervisor()
+	var runs atomic.Int32
+	s.Register("flaky", func(ctx context.Context) {
+		if runs.Add(1) <= 2 {
+			panic("boom")
+		}
+		<-ctx.Done()
+	}, nil)
+
+	s.Start(context.Background())
+	require.Eventually(t, func() bool {
+		health := s.Health()
+		return health[0].Status == JobStatusRunning && health[0].Restarts == 2
+	}, time.Second, time.Millisecond)
+
+	health := s.Health()[0]
+	require.Equal(t, "flaky", health.Name)
+	require.Equal(t, "boom", health.LastPanic)
+	require.NotNil(t, health.LastPanicAt)
+	require.NotNil(t, health.StartedAt)
+
+	s.Stop()
+	require.Equal(t, JobStatusStopped, s.Health()[0].Status)
+	require.EqualValues(t, 3, runs.Load())
+}
+
+func TestSupervisor_StopStopsEveryJob(t *testing.T) {
+	s := newTestSupervisor()
+	repo := &paymentRequestExpiryRepoStub{}
+	expiry := &PaymentRequestExpiryJob{repo: repo, interval: time.Hour, stop: make(chan struct{})}
+	s.Register("payment-request-expiry", expiry.Start, expiry.Stop)
+	s.Register("ctx-only", func(ctx context.Context) { <-ctx.Done() }, nil)
+	require.Equal(t, JobStatusPending, s.Health()[1].Status)
+
+	s.Start(context.Background())
+	require.Eventually(t, func() bool {
+		for _, health := range s.Health() {
+			if health.Status != JobStatusRunning {
+				return false
+			}
+		}
+		return true
+	}, time.Second, time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("supervisor did not stop its jobs")
+	}
+	for _, health := range s.Health() {
+		require.Equal(t, JobStatusStopped, health.Status)
+		require.Zero(t, health.Restarts)
+	}
+}
+
+func TestSupervisor_ReturnedJobIsNotRestarted(t *testing.T) {
+	s := newTestSupervisor()
+	var runs atomic.Int32
+	s.Register("one-shot", func(context.Context) { runs.Add(1) }, nil)
+
+	s.Start(context.Background())
+	require.Eventually(t, func() bool { return s.Health()[0].Status == JobStatusStopped }, time.Second, time.Millisecond)
+	s.Stop()
+	require.EqualValues(t, 1, runs.Load())
+}
@@ -47,6 +47,32 @@ var (
 		Name: "pk_legacy_endpoint_usage_total",
 		Help: "Total number of legacy endpoint hits",
 	}, []string{"endpoint_family", "merchant_id"})
+
+	PaymentRequestsExpiredPerRun = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "pk_payment_request_expiry_run_expired",
+		Help:    "Payment requests expired by one run of the expiry job",
+		Buckets: []float64{0, 1, 10, 100, 500, 1000, 5000},
+	})
+
+	JobRestartsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pk_job_restarts_total",
+		Help: "Background job restarts after a panic",
+	}, []string{"job"})
+
+	JobLeaderGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "pk_job_leader",
+		Help: "1 while this replica holds the background job leader lease",
+	})
+
+	FeePathTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pk_payment_fee_path_total",
+		Help: "Created payments by the path that priced each part of their fees",
+	}, []string{"fee_source", "bridge_fee_source", "net_amount_source"})
+
+	ApprovalAmountFallbackTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pk_payment_approval_amount_fallback_total",
+		Help: "ERC20 approvals sized from totalCharged because the on-chain quote failed",
+	}, []string{"chain_id"})
 )
 
 func RecordSessionCreated(merchID string, err error) {
@@ -84,3 +110,30 @@ func RecordLegacyEndpointUsage(endpointFamily string, merchantID string) {
 	}
 	LegacyEndpointUsageTotal.WithLabelValues(endpointFamily, merchantID).Inc()
 }
+
+func RecordPaymentRequestExpiryRun(expired int) {
+	PaymentRequestsExpiredPerRun.Observe(float64(expired))
+}
+
+func RecordJobRestart(job string) {
+	JobRestartsTotal.WithLabelValues(job).Inc()
+}
+
+func RecordJobLeader(leader bool) {
+	if leader {
+		JobLeaderGauge.Set(1)
+		return
+	}
+	JobLeaderGauge.Set(0)
+}
+
+func RecordFeePath(feeSource, bridgeFeeSource, netAmountSource string) {
+	if feeSource == "" {
+		feeSource = "unknown"
+	}
+	FeePathTotal.WithLabelValues(feeSource, bridgeFeeSource, netAmountSource).Inc()
+}
+
+func RecordApprovalAmountFallback(chainID string) {
+	ApprovalAmountFallbackTotal.WithLabelValues(chainID).Inc()
+}
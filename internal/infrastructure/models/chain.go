@@ -15,12 +15,16 @@ type Chain struct {
 	ChainType         string `gorm:"type:varchar(50);not null;default:'EVM';column:type"`
 	RPCURL            string `gorm:"type:text;column:rpc_url"`
 	ExplorerURL       string `gorm:"type:text"`
+	ExplorerAPIURL    string `gorm:"type:text;column:explorer_api_url"`
+	ExplorerAPIKey    string `gorm:"type:text;column:explorer_api_key"`
 	Symbol            string `gorm:"type:varchar(20);column:currency_symbol"`
 	LogoURL           string `gorm:"type:text;column:image_url"`
 	IsActive          bool   `gorm:"default:true"`
+	IsTestnet         bool   `gorm:"not null;default:false"`
 	StateMachineID    string `gorm:"type:varchar(100)"`
 	CCIPChainSelector string `gorm:"type:varchar(255);column:ccip_chain_selector"`
 	StargateEID      int    `gorm:"type:integer;column:stargate_eid"`
+	MinConfirmations  int    `gorm:"type:integer;not null;default:0"`
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	DeletedAt         gorm.DeletedAt `gorm:"index"`
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type CrosschainRouteStatus struct {
+	RouteKey        string `gorm:"type:varchar(200);primaryKey"`
+	SourceChainID   string `gorm:"type:varchar(100);not null"`
+	DestChainID     string `gorm:"type:varchar(100);not null"`
+	OverallStatus   string `gorm:"type:varchar(20);not null"`
+	PreviousStatus  string `gorm:"type:varchar(20);not null;default:''"`
+	Snapshot        string `gorm:"type:jsonb;not null"`
+	CheckedAt       time.Time
+	StatusChangedAt time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
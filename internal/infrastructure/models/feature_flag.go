@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FeatureFlag struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
+	Name       string     `gorm:"type:varchar(64);not null"`
+	MerchantID *uuid.UUID `gorm:"type:uuid"`
+	Enabled    bool       `gorm:"not null;default:false"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
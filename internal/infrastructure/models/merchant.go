@@ -18,6 +18,8 @@ type Merchant struct {
 	BusinessAddress    string    `gorm:"type:text"`
 	Documents          string    `gorm:"type:jsonb;default:'{}'"`
 	FeeDiscountPercent string    `gorm:"type:decimal(5,2);default:0"` // Changed to string
+	FeeExempt          bool      `gorm:"type:boolean;not null;default:false"`
+	MinConfirmations   int       `gorm:"type:integer;not null;default:0"`
 	CallbackURL        string    `gorm:"type:text"`
 	WebhookSecret      string    `gorm:"type:varchar(64)"`
 	WebhookIsActive    bool      `gorm:"type:boolean;default:false"`
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MerchantAllowedReceiver struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
+	MerchantID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:uq_merchant_allowed_receivers_address"`
+	Address    string     `gorm:"type:varchar(255);not null;uniqueIndex:uq_merchant_allowed_receivers_address"`
+	Label      string     `gorm:"type:varchar(100);not null;default:''"`
+	CreatedBy  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt  time.Time
+}
@@ -22,7 +22,11 @@ type Payment struct {
 	TotalCharged        string     `gorm:"type:decimal(36,18);default:0"`
 	SenderAddress       string     `gorm:"column:sender_address;type:varchar(255)"`
 	DestAddress         string     `gorm:"column:dest_address;type:varchar(255)"`
+	ReceiverName        *string    `gorm:"column:receiver_name;type:varchar(255)"`
+	ExternalRef         *string    `gorm:"column:external_ref;type:varchar(128)"`
+	Metadata            *string    `gorm:"type:jsonb"`
 	Status              string     `gorm:"type:varchar(50);not null;index"`
+	Mode                string     `gorm:"type:varchar(10);not null;default:'live';index"`
 	SourceTxHash        *string    `gorm:"type:varchar(255);index"`
 	DestTxHash          *string    `gorm:"type:varchar(255);index"`
 	RefundTxHash        *string    `gorm:"type:varchar(255)"`
@@ -44,25 +48,28 @@ type Payment struct {
 }
 
 type PaymentEvent struct {
-	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
-	PaymentID   uuid.UUID  `gorm:"type:uuid;not null;index"`
-	EventType   string     `gorm:"type:varchar(50);not null;index"`
-	ChainID     *uuid.UUID `gorm:"type:uuid;index"`
-	Chain       string     `gorm:"type:varchar(20);column:chain"` // Legacy support
-	TxHash      string     `gorm:"type:varchar(255)"`
-	BlockNumber int64      `gorm:"type:bigint"`
-	Metadata    string     `gorm:"type:jsonb;default:'{}'"`
-	CreatedAt   time.Time
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
+	PaymentID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	EventType     string     `gorm:"type:varchar(50);not null;index"`
+	ChainID       *uuid.UUID `gorm:"type:uuid;index"`
+	Chain         string     `gorm:"type:varchar(20);column:chain"` // Legacy support
+	TxHash        string     `gorm:"type:varchar(255)"`
+	BlockNumber   int64      `gorm:"type:bigint"`
+	Confirmations int        `gorm:"type:integer;not null;default:0"`
+	Metadata      string     `gorm:"type:jsonb;default:'{}'"`
+	Details       *string    `gorm:"type:jsonb"`
+	CreatedAt     time.Time
 
 	Payment Payment `gorm:"foreignKey:PaymentID"`
 }
 
 type PaymentBridge struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
-	Name      string    `gorm:"type:varchar(50);unique;not null"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID                  uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v7()"`
+	Name                string    `gorm:"type:varchar(50);unique;not null"`
+	ExplorerURLTemplate *string   `gorm:"type:varchar(255)"` // {messageId}/{sourceTxHash} placeholders
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           gorm.DeletedAt `gorm:"index"`
 }
 
 func (PaymentBridge) TableName() string {
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type PaymentIntentNonce struct {
+	Payer     string `gorm:"type:varchar(64);primaryKey"`
+	Nonce     string `gorm:"type:varchar(78);primaryKey"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
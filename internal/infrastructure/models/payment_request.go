@@ -16,9 +16,11 @@ type PaymentRequest struct {
 	Amount        string    `gorm:"type:decimal(36,18);not null"`
 	Decimals      int       `gorm:"not null"`
 	Description   string    `gorm:"type:text"`
+	Metadata      *string   `gorm:"type:jsonb"`
 	Status        string    `gorm:"type:varchar(50);not null;index"`
 	ExpiresAt     time.Time `gorm:"not null"`
 	TxHash        string    `gorm:"type:varchar(255)"`
+	BlockNumber   int64     `gorm:"type:bigint"`
 	CompletedAt   *time.Time
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
@@ -23,6 +23,7 @@ type RoutePolicy struct {
 	OverheadBytes          *string   `gorm:"type:numeric(78,0)"`
 	MinFee                 *string   `gorm:"type:numeric(78,0)"`
 	MaxFee                 *string   `gorm:"type:numeric(78,0)"`
+	FallbackBridgeFee      *string   `gorm:"type:numeric(78,0)"`
 	CreatedAt              time.Time
 	UpdatedAt              time.Time
 	DeletedAt              gorm.DeletedAt `gorm:"index"`
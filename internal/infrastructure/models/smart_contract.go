@@ -25,6 +25,7 @@ type SmartContract struct {
 	Metadata        string         `gorm:"type:jsonb;default:'{}'"`
 	IsActive        bool           `gorm:"default:true"`
 	DestinationMap  pq.StringArray `gorm:"type:text[];default:'{}'"`
+	IndexedEvents   pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 	DeletedAt       gorm.DeletedAt `gorm:"index"`
@@ -15,6 +15,8 @@ type User struct {
 	Role          string     `gorm:"type:varchar(50);not null;default:'user'"`
 	KYCStatus     string     `gorm:"type:varchar(50);default:'not_started'"`
 	KYCVerifiedAt *time.Time `gorm:"type:timestamp"`
+	FeeExempt     bool       `gorm:"type:boolean;not null;default:false"`
+	SuspendedAt   *time.Time `gorm:"type:timestamp"`
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	DeletedAt     gorm.DeletedAt `gorm:"index"`
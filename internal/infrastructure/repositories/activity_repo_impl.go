@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"payment-kita.backend/internal/domain/entities"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/infrastructure/models"
+)
+
+// ActivityRepository merges payments and payment requests into one feed. Each table is read
+// with its own keyset query on (created_at, id) and the two sorted pages are merged here, so
+// both sides stay on their owner/created_at indexes.
+type ActivityRepository struct {
+	db       *gorm.DB
+	payments *PaymentRepository
+	requests *PaymentRequestRepositoryImpl
+}
+
+func NewActivityRepository(db *gorm.DB) *ActivityRepository {
+	return &ActivityRepository{
+		db:       db,
+		payments: NewPaymentRepository(db),
+		requests: NewPaymentRequestRepository(db),
+	}
+}
+
+func (r *ActivityRepository) List(ctx context.Context, filter domainrepos.ActivityFilter, after *domainrepos.ActivityCursor, limit int) ([]*entities.ActivityItem, error) {
+	paymentsQuery := r.db.WithContext(ctx).Model(&models.Payment{})
+	if filter.MerchantID != nil {
+		paymentsQuery = paymentsQuery.Where("(sender_id = ? OR merchant_id = ?)", filter.UserID, *filter.MerchantID)
+	} else {
+		paymentsQuery = paymentsQuery.Where("sender_id = ?", filter.UserID)
+	}
+	paymentsQuery = paymentsQuery.Where("mode = ?", filter.Mode)
+
+	var payments []models.Payment
+	if err := activityPage(paymentsQuery, after, limit).
+		Preload("SourceChain", withDeleted).Preload("DestChain", withDeleted).
+		Find(&payments).Error; err != nil {
+		return nil, err
+	}
+
+	var requests []models.PaymentRequest
+	if filter.MerchantID != nil {
+		requestsQuery := r.db.WithContext(ctx).Model(&models.PaymentRequest{}).
+			Where("merchant_id = ?", *filter.MerchantID).
+			Where("chain_id IN (?)", r.db.Model(&models.Chain{}).Select("id").Where("is_testnet = ?", filter.Mode == entities.PaymentModeTest))
+		if err := activityPage(requestsQuery, after, limit).
+			Preload("Chain", withDeleted).Preload("Token", withDeleted).
+			Find(&requests).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]*entities.ActivityItem, 0, limit)
+	i, j := 0, 0
+	for len(items) < limit && (i < len(payments) || j < len(requests)) {
+		if j >= len(requests) || (i < len(payments) && activityNewer(payments[i].CreatedAt, payments[i].ID.String(), requests[j].CreatedAt, requests[j].ID.String())) {
+			p := r.payments.toEntity(&payments[i])
+			items = append(items, &entities.ActivityItem{
+				Type:      entities.ActivityTypePayment,
+				ID:        p.ID,
+				Status:    string(p.Status),
+				Amount:    p.SourceAmount,
+				CreatedAt: p.CreatedAt,
+				Payment:   p,
+			})
+			i++
+			continue
+		}
+		req := r.requests.toEntity(&requests[j])
+		items = append(items, &entities.ActivityItem{
+			Type:           entities.ActivityTypePaymentRequest,
+			ID:             req.ID,
+			Status:         string(req.Status),
+			Amount:         req.Amount,
+			CreatedAt:      req.CreatedAt,
+			PaymentRequest: req,
+		})
+		j++
+	}
+	return items, nil
+}
+
+// activityPage applies the keyset cursor and the shared newest-first order
+func activityPage(q *gorm.DB, after *domainrepos.ActivityCursor, limit int) *gorm.DB {
+	if after != nil {
+		q = q.Where("(created_at < ? OR (created_at = ? AND id < ?))", after.CreatedAt, after.CreatedAt, after.ID)
+	}
+	return q.Order("created_at DESC").Order("id DESC").Limit(limit)
+}
+
+// activityNewer reports whether a sorts before b in the feed, matching activityPage's order
+func activityNewer(aAt time.Time, aID string, bAt time.Time, bID string) bool {
+	if !aAt.Equal(bAt) {
+		return aAt.After(bAt)
+	}
+	return aID > bID
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
+)
+
+func TestActivityRepository_MergesAndPaginates(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentTables(t, db)
+	createPaymentRequestTables(t, db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	merchantID := uuid.New()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mainnet, testnet := uuid.New(), uuid.New()
+	mustExec(t, db, `INSERT INTO chains(id,chain_id,name,type,is_active,is_testnet) VALUES (?,?,?,?,?,?),(?,?,?,?,?,?)`,
+		mainnet.String(), "8453", "Base", "EVM", true, false,
+		testnet.String(), "84532", "Base Sepolia", "EVM", true, true)
+	insertModePayment := func(senderID uuid.UUID, merchant *uuid.UUID, mode entities.PaymentMode, at time.Time) uuid.UUID {
+		id := uuid.New()
+		mustExec(t, db, `INSERT INTO payments(id,sender_id,merchant_id,source_chain_id,dest_chain_id,source_token_id,dest_token_id,source_amount,status,mode,created_at,updated_at)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?)`, id.String(), senderID.String(), merchant, uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString(), "100", "PENDING", string(mode), at, at)
+		return id
+	}
+	insertPayment := func(senderID uuid.UUID, merchant *uuid.UUID, at time.Time) uuid.UUID {
+		return insertModePayment(senderID, merchant, entities.PaymentModeLive, at)
+	}
+	insertChainRequest := func(chainID uuid.UUID, at time.Time) uuid.UUID {
+		id := uuid.New()
+		mustExec(t, db, `INSERT INTO payment_requests(id,merchant_id,chain_id,token_id,wallet_address,amount,decimals,status,expires_at,created_at,updated_at)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?)`, id.String(), merchantID.String(), chainID.String(), uuid.NewString(), "0xwallet", "25", 6, "PENDING", at.Add(time.Hour), at, at)
+		return id
+	}
+	insertRequest := func(at time.Time) uuid.UUID {
+		return insertChainRequest(mainnet, at)
+	}
+
+	sent := insertPayment(userID, nil, base.Add(1*time.Minute))
+	request := insertRequest(base.Add(2 * time.Minute))
+	received := insertPayment(uuid.New(), &merchantID, base.Add(3*time.Minute))
+	sameTime := insertRequest(base.Add(3 * time.Minute))
+	insertPayment(uuid.New(), nil, base.Add(4*time.Minute)) // someone else's
+	testPayment := insertModePayment(userID, nil, entities.PaymentModeTest, base.Add(5*time.Minute))
+	testRequest := insertChainRequest(testnet, base.Add(6*time.Minute))
+
+	// Items created at the same instant are ordered by id, descending
+	newest := []uuid.UUID{received, sameTime}
+	if sameTime.String() > received.String() {
+		newest = []uuid.UUID{sameTime, received}
+	}
+
+	repo := NewActivityRepository(db)
+	filter := domainrepos.ActivityFilter{UserID: userID, MerchantID: &merchantID, Mode: entities.PaymentModeLive}
+	page, err := repo.List(ctx, filter, nil, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, newest, []uuid.UUID{page[0].ID, page[1].ID})
+
+	last := page[1]
+	page, err = repo.List(ctx, filter, &domainrepos.ActivityCursor{CreatedAt: last.CreatedAt, ID: last.ID}, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, request, page[0].ID)
+	require.Equal(t, entities.ActivityTypePaymentRequest, page[0].Type)
+	require.NotNil(t, page[0].PaymentRequest)
+	require.Equal(t, "25", page[0].Amount)
+	require.Equal(t, sent, page[1].ID)
+	require.Equal(t, entities.ActivityTypePayment, page[1].Type)
+	require.NotNil(t, page[1].Payment)
+
+	page, err = repo.List(ctx, filter, &domainrepos.ActivityCursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID}, 2)
+	require.NoError(t, err)
+	require.Empty(t, page)
+
+	// Without a merchant only the user's own payments are listed
+	page, err = repo.List(ctx, domainrepos.ActivityFilter{UserID: userID, Mode: entities.PaymentModeLive}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, sent, page[0].ID)
+
+	// Test mode lists only the test payment and the request on the testnet
+	page, err = repo.List(ctx, domainrepos.ActivityFilter{UserID: userID, MerchantID: &merchantID, Mode: entities.PaymentModeTest}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, []uuid.UUID{testRequest, testPayment}, []uuid.UUID{page[0].ID, page[1].ID})
+}
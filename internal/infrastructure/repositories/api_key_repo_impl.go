@@ -69,7 +69,7 @@ func (r *ApiKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*entitie
 
 func (r *ApiKeyRepository) Update(ctx context.Context, apiKey *entities.ApiKey) error {
 	m := r.toModel(apiKey)
-	result := r.db.WithContext(ctx).Model(&models.ApiKey{}).Where("id = ?", apiKey.ID).Updates(map[string]interface{}{
+	updates := map[string]interface{}{
 		"name":             m.Name,
 		"permissions":      m.Permissions,
 		"is_active":        m.IsActive,
@@ -77,7 +77,12 @@ func (r *ApiKeyRepository) Update(ctx context.Context, apiKey *entities.ApiKey)
 		"updated_at":       time.Now(),
 		"secret_encrypted": m.SecretEncrypted,
 		"secret_masked":    m.SecretMasked,
-	})
+	}
+	// Legacy keys are re-hashed with the pepper on first use
+	if m.KeyHash != "" {
+		updates["key_hash"] = m.KeyHash
+	}
+	result := r.db.WithContext(ctx).Model(&models.ApiKey{}).Where("id = ?", apiKey.ID).Updates(updates)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -143,13 +148,14 @@ func (r *ApiKeyRepository) toEntity(m *models.ApiKey) *entities.ApiKey {
 
 	if m.User.ID != uuid.Nil {
 		e.User = &entities.User{
-			ID:        m.User.ID,
-			Email:     m.User.Email,
-			Name:      m.User.Name,
-			Role:      entities.UserRole(m.User.Role),
-			KYCStatus: entities.KYCStatus(m.User.KYCStatus),
-			CreatedAt: m.User.CreatedAt,
-			UpdatedAt: m.User.UpdatedAt,
+			ID:          m.User.ID,
+			Email:       m.User.Email,
+			Name:        m.User.Name,
+			Role:        entities.UserRole(m.User.Role),
+			KYCStatus:   entities.KYCStatus(m.User.KYCStatus),
+			SuspendedAt: m.User.SuspendedAt,
+			CreatedAt:   m.User.CreatedAt,
+			UpdatedAt:   m.User.UpdatedAt,
 		}
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
@@ -161,10 +162,18 @@ func (r *chainRepo) GetAllRPCs(ctx context.Context, chainID *uuid.UUID, isActive
 
 // GetActive gets all active chains
 func (r *chainRepo) GetActive(ctx context.Context, pagination utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return r.GetPaginated(ctx, false, pagination)
+}
+
+// GetPaginated gets active chains, or all chains when includeInactive is set
+func (r *chainRepo) GetPaginated(ctx context.Context, includeInactive bool, pagination utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	var ms []models.Chain
 	var totalCount int64
 
-	query := r.db.WithContext(ctx).Model(&models.Chain{}).Where("is_active = ?", true)
+	query := r.db.WithContext(ctx).Model(&models.Chain{})
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
 
 	if err := query.Count(&totalCount).Error; err != nil {
 		return nil, 0, err
@@ -199,12 +208,16 @@ func (r *chainRepo) Create(ctx context.Context, chain *entities.Chain) error {
 		ChainType:         string(chain.Type),
 		RPCURL:            chain.RPCURL,
 		ExplorerURL:       chain.ExplorerURL,
+		ExplorerAPIURL:    chain.ExplorerAPIURL,
+		ExplorerAPIKey:    chain.ExplorerAPIKey,
 		Symbol:            chain.CurrencySymbol,
 		LogoURL:           chain.ImageURL,
 		IsActive:          chain.IsActive,
+		IsTestnet:         chain.IsTestnet,
 		StateMachineID:    "", // Entity doesn't have this field
 		CCIPChainSelector: chain.CCIPChainSelector,
 		StargateEID:      chain.StargateEID,
+		MinConfirmations: chain.MinConfirmations,
 		CreatedAt:         chain.CreatedAt,
 	}
 
@@ -224,11 +237,15 @@ func (r *chainRepo) Update(ctx context.Context, chain *entities.Chain) error {
 		"type":                string(chain.Type),
 		"rpc_url":             chain.RPCURL,
 		"explorer_url":        chain.ExplorerURL,
+		"explorer_api_url":    chain.ExplorerAPIURL,
+		"explorer_api_key":    chain.ExplorerAPIKey,
 		"currency_symbol":     chain.CurrencySymbol,
 		"image_url":           chain.ImageURL,
 		"is_active":           chain.IsActive,
+		"is_testnet":          chain.IsTestnet,
 		"ccip_chain_selector": chain.CCIPChainSelector,
 		"stargate_eid":       chain.StargateEID,
+		"min_confirmations": chain.MinConfirmations,
 		// "state_machine_id": chain.StateMachineID, // Removed
 	}
 
@@ -242,7 +259,7 @@ func (r *chainRepo) Update(ctx context.Context, chain *entities.Chain) error {
 	return nil
 }
 
-// Delete deletes a chain
+// Delete soft deletes a chain. Payments and payment requests that reference it still load it.
 func (r *chainRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	result := r.db.WithContext(ctx).Delete(&models.Chain{}, "id = ?", id)
 	if result.Error != nil {
@@ -254,6 +271,31 @@ func (r *chainRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// HardDelete removes a chain row, soft-deleted or not. It fails with a
+// *domainerrors.ReferencedError while payments, payment requests, tokens, contracts, wallets,
+// bridge and fee configs or route policies still reference it.
+func (r *chainRepo) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return GetDB(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Unscoped().Model(&models.Chain{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return domainerrors.ErrNotFound
+		}
+		if err := checkNoReferences(tx, paymentChainReferences, id); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&models.ChainRPC{}, "chain_id = ?", id).Error; err != nil {
+			return err
+		}
+		return referencedError(tx.Unscoped().Delete(&models.Chain{}, "id = ?", id).Error)
+	})
+}
+
 // toEntity converts GORM model to Domain Entity
 func (r *chainRepo) toEntity(m *models.Chain) *entities.Chain {
 	// Logic to pick main RPC URL if legacy is empty?
@@ -274,11 +316,15 @@ func (r *chainRepo) toEntity(m *models.Chain) *entities.Chain {
 		Type:              entities.ChainType(strings.ToUpper(m.ChainType)),
 		RPCURL:            m.RPCURL,
 		ExplorerURL:       m.ExplorerURL,
+		ExplorerAPIURL:    m.ExplorerAPIURL,
+		ExplorerAPIKey:    m.ExplorerAPIKey,
 		CurrencySymbol:    m.Symbol,
 		ImageURL:          m.LogoURL,
 		IsActive:          m.IsActive,
+		IsTestnet:         m.IsTestnet,
 		CCIPChainSelector: m.CCIPChainSelector,
 		StargateEID:      m.StargateEID,
+		MinConfirmations: m.MinConfirmations,
 		CreatedAt:         m.CreatedAt,
 		UpdatedAt:         m.UpdatedAt,
 		// DeletedAt:      &m.DeletedAt.Time, // GORM DeletedAt is struct?
@@ -287,16 +333,10 @@ func (r *chainRepo) toEntity(m *models.Chain) *entities.Chain {
 }
 
 func (r *chainRepo) getNamespace(chainType entities.ChainType) string {
-	switch chainType {
-	case entities.ChainTypeEVM:
-		return "eip155"
-	case entities.ChainTypeSVM:
-		return "solana"
-	case entities.ChainTypeSubstrate:
-		return "substrate"
-	default:
-		return "unknown"
+	if namespace := chainType.Namespace(); namespace != "" {
+		return namespace
 	}
+	return "unknown"
 }
 
 // toRpcEntity converts GORM RPC model to Entity
@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
@@ -301,3 +303,126 @@ func TestChainRepository_GetAllRPCs_FindErrorAfterCount(t *testing.T) {
 	_, _, err := repo.GetAllRPCs(ctx, &id, &isActive, &search, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 }
+
+func TestChainRepository_GetPaginated_IncludeInactive(t *testing.T) {
+	db := newTestDB(t)
+	createChainTables(t, db)
+	repo := NewChainRepository(db)
+	ctx := context.Background()
+
+	seedChain(t, db, uuid.NewString(), "8453", "Base", "EVM", true)
+	seedChain(t, db, uuid.NewString(), "10", "Optimism", "EVM", false)
+	seedChain(t, db, uuid.NewString(), "42161", "Arbitrum", "EVM", true)
+
+	active, total, err := repo.GetPaginated(ctx, false, utils.PaginationParams{Page: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, active, 1)
+	require.Equal(t, "Arbitrum", active[0].Name)
+
+	all, total, err := repo.GetPaginated(ctx, true, utils.PaginationParams{Page: 2, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Len(t, all, 1)
+	require.Equal(t, "Optimism", all[0].Name)
+	require.False(t, all[0].IsActive)
+}
+
+func TestChainRepository_HardDeleteRefusesReferencedChains(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentRequestTables(t, db)
+	createPaymentTables(t, db)
+	createSmartContractTable(t, db)
+	createWalletTable(t, db)
+	createBridgeAndFeeTables(t, db)
+	createRoutePolicyTables(t, db)
+	mustExec(t, db, `CREATE TABLE chain_rpcs (
+		id TEXT PRIMARY KEY,
+		chain_id TEXT NOT NULL,
+		url TEXT NOT NULL,
+		priority INTEGER,
+		is_active BOOLEAN,
+		last_error_at DATETIME,
+		error_count INTEGER,
+		created_at DATETIME,
+		updated_at DATETIME,
+		deleted_at DATETIME
+	);`)
+	repo := NewChainRepository(db)
+	paymentRepo := NewPaymentRepository(db)
+	ctx := context.Background()
+
+	usedID := uuid.New()
+	unusedID := uuid.New()
+	seedChain(t, db, usedID.String(), "8453", "Base", "EVM", true)
+	seedChain(t, db, unusedID.String(), "10", "Optimism", "EVM", false)
+	mustExec(t, db, `INSERT INTO chain_rpcs(id,chain_id,url,priority,is_active) VALUES (?,?,?,?,?)`,
+		uuid.NewString(), unusedID.String(), "https://rpc.optimism", 1, true)
+
+	now := time.Now()
+	paymentID := uuid.New()
+	mustExec(t, db, `INSERT INTO payments(id,sender_id,source_chain_id,dest_chain_id,source_token_id,dest_token_id,source_amount,status,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?)`, paymentID.String(), uuid.NewString(), usedID.String(), usedID.String(), uuid.NewString(), uuid.NewString(), "100", "COMPLETED", now, now)
+	mustExec(t, db, `INSERT INTO tokens(id,chain_id,symbol,decimals,address,is_active,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?)`, uuid.NewString(), usedID.String(), "USDC", 6, "0xusdc", true, now, now)
+	mustExec(t, db, `INSERT INTO fee_configs(id,chain_id,token_id) VALUES (?,?,?)`,
+		uuid.NewString(), unusedID.String(), uuid.NewString())
+	mustExec(t, db, `INSERT INTO route_policies(id,source_chain_id,dest_chain_id) VALUES (?,?,?)`,
+		uuid.NewString(), usedID.String(), unusedID.String())
+
+	err := repo.HardDelete(ctx, usedID)
+	var referenced *domainerrors.ReferencedError
+	require.ErrorAs(t, err, &referenced)
+	require.Equal(t, map[string]int64{
+		"payments.source_chain_id":       1,
+		"payments.dest_chain_id":         1,
+		"tokens.chain_id":                1,
+		"route_policies.source_chain_id": 1,
+	}, referenced.References)
+	require.ErrorAs(t, repo.HardDelete(ctx, unusedID), &referenced)
+	require.Equal(t, map[string]int64{"fee_configs.chain_id": 1, "route_policies.dest_chain_id": 1}, referenced.References)
+	mustExec(t, db, `DELETE FROM fee_configs`)
+	mustExec(t, db, `DELETE FROM route_policies`)
+
+	// A soft-deleted chain is gone from lookups but still shown on the payments made on it
+	require.NoError(t, repo.Delete(ctx, usedID))
+	_, err = repo.GetByID(ctx, usedID)
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
+	payment, err := paymentRepo.GetByID(ctx, paymentID)
+	require.NoError(t, err)
+	require.NotNil(t, payment.SourceChain)
+	require.Equal(t, "Base", payment.SourceChain.Name)
+
+	require.NoError(t, repo.HardDelete(ctx, unusedID))
+	var remaining int64
+	require.NoError(t, db.Table("chain_rpcs").Where("chain_id = ?", unusedID).Count(&remaining).Error)
+	require.Zero(t, remaining)
+	require.ErrorIs(t, repo.HardDelete(ctx, unusedID), domainerrors.ErrNotFound)
+}
+
+func TestChainRepository_HardDeleteMapsForeignKeyViolations(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s_%d?mode=memory&cache=shared&_foreign_keys=1", t.Name(), time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	createPaymentRequestTables(t, db)
+	createPaymentTables(t, db)
+	createSmartContractTable(t, db)
+	createWalletTable(t, db)
+	createBridgeAndFeeTables(t, db)
+	createRoutePolicyTables(t, db)
+	mustExec(t, db, `CREATE TABLE chain_rpcs (id TEXT PRIMARY KEY, chain_id TEXT NOT NULL, url TEXT NOT NULL, deleted_at DATETIME);`)
+	// A table the pre-check does not know about still blocks the delete through its FK
+	mustExec(t, db, `CREATE TABLE chain_notes (id TEXT PRIMARY KEY, chain_id TEXT NOT NULL REFERENCES chains(id));`)
+	repo := NewChainRepository(db)
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	seedChain(t, db, chainID.String(), "8453", "Base", "EVM", true)
+	mustExec(t, db, `INSERT INTO chain_notes(id,chain_id) VALUES (?,?)`, uuid.NewString(), chainID.String())
+
+	err = repo.HardDelete(ctx, chainID)
+	var referenced *domainerrors.ReferencedError
+	require.ErrorAs(t, err, &referenced)
+	require.Equal(t, map[string]int64{"foreign key": 0}, referenced.References)
+	require.Contains(t, err.Error(), "by foreign key")
+}
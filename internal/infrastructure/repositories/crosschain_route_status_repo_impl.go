@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/infrastructure/models"
+)
+
+type CrosschainRouteStatusRepository struct {
+	db *gorm.DB
+}
+
+func NewCrosschainRouteStatusRepository(db *gorm.DB) *CrosschainRouteStatusRepository {
+	return &CrosschainRouteStatusRepository{db: db}
+}
+
+// Save upserts record's route in one statement, so concurrent rechecks cannot lose a state
+// change. previous_status and status_changed_at only move when overall_status differs from the
+// stored one. record is refreshed from the stored row.
+func (r *CrosschainRouteStatusRepository) Save(ctx context.Context, record *entities.CrosschainRouteStatusRecord) error {
+	db := GetDB(ctx, r.db).WithContext(ctx)
+
+	m := models.CrosschainRouteStatus{
+		RouteKey:        record.RouteKey,
+		SourceChainID:   record.SourceChainID,
+		DestChainID:     record.DestChainID,
+		OverallStatus:   record.OverallStatus,
+		Snapshot:        record.Snapshot,
+		CheckedAt:       record.CheckedAt,
+		StatusChangedAt: record.CheckedAt,
+	}
+	if err := db.Clauses(clauseOnConflictCrosschainRouteStatus()).Create(&m).Error; err != nil {
+		return err
+	}
+
+	var stored models.CrosschainRouteStatus
+	if err := db.Where("route_key = ?", record.RouteKey).First(&stored).Error; err != nil {
+		return err
+	}
+	*record = *r.toEntity(&stored)
+	return nil
+}
+
+func clauseOnConflictCrosschainRouteStatus() clause.OnConflict {
+	// SET expressions see the row as stored, so the CASEs compare against the old status
+	changed := "crosschain_route_statuses.overall_status <> EXCLUDED.overall_status"
+	return clause.OnConflict{
+		Columns: []clause.Column{{Name: "route_key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"source_chain_id":   gorm.Expr("EXCLUDED.source_chain_id"),
+			"dest_chain_id":     gorm.Expr("EXCLUDED.dest_chain_id"),
+			"overall_status":    gorm.Expr("EXCLUDED.overall_status"),
+			"previous_status":   gorm.Expr("CASE WHEN " + changed + " THEN crosschain_route_statuses.overall_status ELSE crosschain_route_statuses.previous_status END"),
+			"snapshot":          gorm.Expr("EXCLUDED.snapshot"),
+			"checked_at":        gorm.Expr("EXCLUDED.checked_at"),
+			"status_changed_at": gorm.Expr("CASE WHEN " + changed + " THEN EXCLUDED.checked_at ELSE crosschain_route_statuses.status_changed_at END"),
+			"updated_at":        gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}
+}
+
+func (r *CrosschainRouteStatusRepository) List(ctx context.Context, sourceChainID, destChainID string) ([]*entities.CrosschainRouteStatusRecord, error) {
+	db := GetDB(ctx, r.db).WithContext(ctx)
+	if sourceChainID != "" {
+		db = db.Where("source_chain_id = ?", sourceChainID)
+	}
+	if destChainID != "" {
+		db = db.Where("dest_chain_id = ?", destChainID)
+	}
+
+	var ms []models.CrosschainRouteStatus
+	if err := db.Order("route_key ASC").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	items := make([]*entities.CrosschainRouteStatusRecord, 0, len(ms))
+	for i := range ms {
+		items = append(items, r.toEntity(&ms[i]))
+	}
+	return items, nil
+}
+
+func (r *CrosschainRouteStatusRepository) toEntity(m *models.CrosschainRouteStatus) *entities.CrosschainRouteStatusRecord {
+	return &entities.CrosschainRouteStatusRecord{
+		RouteKey:        m.RouteKey,
+		SourceChainID:   m.SourceChainID,
+		DestChainID:     m.DestChainID,
+		OverallStatus:   m.OverallStatus,
+		PreviousStatus:  m.PreviousStatus,
+		Snapshot:        m.Snapshot,
+		CheckedAt:       m.CheckedAt,
+		StatusChangedAt: m.StatusChangedAt,
+	}
+}
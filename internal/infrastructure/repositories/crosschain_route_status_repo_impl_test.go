@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+)
+
+func TestCrosschainRouteStatusRepository_SaveTracksStateChanges(t *testing.T) {
+	db := newTestDB(t)
+	mustExec(t, db, `CREATE TABLE crosschain_route_statuses (
+		route_key TEXT PRIMARY KEY,
+		source_chain_id TEXT NOT NULL,
+		dest_chain_id TEXT NOT NULL,
+		overall_status TEXT NOT NULL,
+		previous_status TEXT NOT NULL DEFAULT '',
+		snapshot TEXT NOT NULL,
+		checked_at DATETIME,
+		status_changed_at DATETIME,
+		created_at DATETIME,
+		updated_at DATETIME
+	);`)
+	repo := NewCrosschainRouteStatusRepository(db)
+	ctx := context.Background()
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	save := func(key, source, status string, at time.Time) *entities.CrosschainRouteStatusRecord {
+		record := &entities.CrosschainRouteStatusRecord{
+			RouteKey: key, SourceChainID: source, DestChainID: "eip155:42161",
+			OverallStatus: status, Snapshot: `{"overallStatus":"` + status + `"}`, CheckedAt: at,
+		}
+		require.NoError(t, repo.Save(ctx, record))
+		return record
+	}
+
+	first := save("eip155:8453->eip155:42161", "eip155:8453", "READY", t0)
+	require.Empty(t, first.PreviousStatus)
+	require.True(t, first.StatusChangedAt.Equal(t0))
+
+	// Same state: only checked_at moves
+	same := save("eip155:8453->eip155:42161", "eip155:8453", "READY", t0.Add(time.Minute))
+	require.Empty(t, same.PreviousStatus)
+	require.True(t, same.StatusChangedAt.Equal(t0))
+	require.True(t, same.CheckedAt.Equal(t0.Add(time.Minute)))
+
+	changed := save("eip155:8453->eip155:42161", "eip155:8453", "ERROR", t0.Add(2*time.Minute))
+	require.Equal(t, "READY", changed.PreviousStatus)
+	require.True(t, changed.StatusChangedAt.Equal(t0.Add(2*time.Minute)))
+
+	// Still failing: the last change is kept
+	again := save("eip155:8453->eip155:42161", "eip155:8453", "ERROR", t0.Add(3*time.Minute))
+	require.Equal(t, "READY", again.PreviousStatus)
+	require.True(t, again.StatusChangedAt.Equal(t0.Add(2*time.Minute)))
+	require.True(t, again.CheckedAt.Equal(t0.Add(3*time.Minute)))
+
+	save("eip155:137->eip155:42161", "eip155:137", "READY", t0)
+
+	all, err := repo.List(ctx, "", "")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, "eip155:137->eip155:42161", all[0].RouteKey)
+
+	filtered, err := repo.List(ctx, "eip155:8453", "eip155:42161")
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	require.Equal(t, "ERROR", filtered[0].OverallStatus)
+	require.Equal(t, "READY", filtered[0].PreviousStatus)
+	require.Equal(t, `{"overallStatus":"ERROR"}`, filtered[0].Snapshot)
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+const (
+	// pgUniqueViolation is the PostgreSQL SQLSTATE for unique_violation.
+	pgUniqueViolation = "23505"
+	// pgForeignKeyViolation is the PostgreSQL SQLSTATE for foreign_key_violation.
+	pgForeignKeyViolation = "23503"
+)
+
+// isUniqueViolation reports whether err was caused by a unique constraint, regardless of
+// the driver in use (pgx/lib/pq in production, sqlite in tests).
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) && stateErr.SQLState() == pgUniqueViolation {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "UNIQUE constraint failed") || strings.Contains(msg, "duplicate key value")
+}
+
+// isForeignKeyViolation reports whether err was caused by a foreign key constraint, regardless
+// of the driver in use.
+func isForeignKeyViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gorm.ErrForeignKeyViolated) {
+		return true
+	}
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) && stateErr.SQLState() == pgForeignKeyViolation {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "FOREIGN KEY constraint failed") || strings.Contains(msg, "violates foreign key constraint")
+}
+
+// referencedError maps a foreign key violation from a hard delete to a
+// *domainerrors.ReferencedError, so a reference missing from the pre-check list still answers
+// 409. The database does not say how many rows block the delete, so the count is 0.
+func referencedError(err error) error {
+	if !isForeignKeyViolation(err) {
+		return err
+	}
+	constraint := "foreign key"
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName != "" {
+		constraint = pgErr.ConstraintName
+	}
+	return &domainerrors.ReferencedError{References: map[string]int64{constraint: 0}}
+}
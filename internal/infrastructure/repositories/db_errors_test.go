@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type sqlStateErr string
+
+func (e sqlStateErr) Error() string    { return "pg error " + string(e) }
+func (e sqlStateErr) SQLState() string { return string(e) }
+
+func TestIsUniqueViolation(t *testing.T) {
+	require.False(t, isUniqueViolation(nil))
+	require.False(t, isUniqueViolation(errors.New("boom")))
+	require.False(t, isUniqueViolation(sqlStateErr("23503")))
+
+	require.True(t, isUniqueViolation(gorm.ErrDuplicatedKey))
+	require.True(t, isUniqueViolation(fmt.Errorf("wrapped: %w", sqlStateErr(pgUniqueViolation))))
+	require.True(t, isUniqueViolation(errors.New("UNIQUE constraint failed: users.email")))
+}
+
+func TestReferencedError(t *testing.T) {
+	other := errors.New("boom")
+	require.Nil(t, referencedError(nil))
+	require.Equal(t, other, referencedError(other))
+	require.Equal(t, sqlStateErr(pgUniqueViolation), referencedError(sqlStateErr(pgUniqueViolation)))
+
+	var referenced *domainerrors.ReferencedError
+	err := referencedError(fmt.Errorf("delete: %w", &pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: "wallets_chain_id_fkey"}))
+	require.ErrorAs(t, err, &referenced)
+	require.ErrorIs(t, err, domainerrors.ErrStillReferenced)
+	require.Equal(t, map[string]int64{"wallets_chain_id_fkey": 0}, referenced.References)
+	require.Contains(t, err.Error(), "by wallets_chain_id_fkey")
+
+	require.ErrorAs(t, referencedError(sqlStateErr(pgForeignKeyViolation)), &referenced)
+	require.Equal(t, map[string]int64{"foreign key": 0}, referenced.References)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/crypto"
 )
 
 func TestEmailVerificationRepository_CRUDLikeFlow(t *testing.T) {
@@ -63,3 +64,44 @@ func TestEmailVerificationRepository_DBErrorBranches(t *testing.T) {
 	err = repo.MarkVerified(ctx, "token")
 	require.Error(t, err)
 }
+
+func TestEmailVerificationRepository_StoresTokenDigest(t *testing.T) {
+	db := newTestDB(t)
+	createUserTable(t, db)
+	mustExec(t, db, `CREATE TABLE email_verifications (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		token TEXT NOT NULL,
+		expires_at DATETIME NOT NULL,
+		verified_at DATETIME,
+		created_at DATETIME,
+		updated_at DATETIME,
+		deleted_at DATETIME
+	);`)
+
+	repo := NewEmailVerificationRepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	mustExec(t, db, `INSERT INTO users(id,email,name,role,kyc_status,password_hash,is_email_verified,created_at,updated_at)
+		VALUES (?,?,?,?,?,?,?,?,?)`,
+		userID.String(), "digest@paymentkita.io", "User", "USER", "NOT_STARTED", "hash", false, time.Now(), time.Now(),
+	)
+
+	require.NoError(t, repo.Create(ctx, userID, "fresh-token"))
+	var stored string
+	require.NoError(t, db.Raw("SELECT token FROM email_verifications").Scan(&stored).Error)
+	require.Equal(t, crypto.HashToken("fresh-token"), stored)
+
+	// Rows written before hashing hold the raw token and must keep working until they expire.
+	mustExec(t, db, `INSERT INTO email_verifications(id,user_id,token,expires_at,created_at) VALUES (?,?,?,?,?)`,
+		uuid.NewString(), userID.String(), "legacy-token", time.Now().Add(time.Hour), time.Now(),
+	)
+	user, err := repo.GetByToken(ctx, "legacy-token")
+	require.NoError(t, err)
+	require.Equal(t, userID, user.ID)
+	require.NoError(t, repo.MarkVerified(ctx, "legacy-token"))
+
+	// The digest itself is not a usable token.
+	_, err = repo.GetByToken(ctx, stored)
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
+}
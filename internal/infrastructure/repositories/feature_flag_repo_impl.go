@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/models"
+	"payment-kita.backend/pkg/utils"
+)
+
+type FeatureFlagRepository struct {
+	db *gorm.DB
+}
+
+func NewFeatureFlagRepository(db *gorm.DB) *FeatureFlagRepository {
+	return &FeatureFlagRepository{db: db}
+}
+
+func (r *FeatureFlagRepository) List(ctx context.Context) ([]*entities.FeatureFlag, error) {
+	var ms []models.FeatureFlag
+	if err := GetDB(ctx, r.db).WithContext(ctx).Order("name ASC, merchant_id ASC").Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]*entities.FeatureFlag, 0, len(ms))
+	for i := range ms {
+		items = append(items, r.toEntity(&ms[i]))
+	}
+	return items, nil
+}
+
+func (r *FeatureFlagRepository) Upsert(ctx context.Context, flag *entities.FeatureFlag) error {
+	db := GetDB(ctx, r.db).WithContext(ctx)
+
+	var m models.FeatureFlag
+	err := scopeFeatureFlag(db, flag.Name, flag.MerchantID).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		m = models.FeatureFlag{
+			ID:         utils.GenerateUUIDv7(),
+			Name:       flag.Name,
+			MerchantID: flag.MerchantID,
+			Enabled:    flag.Enabled,
+		}
+		if err := db.Create(&m).Error; err != nil {
+			if isUniqueViolation(err) {
+				return domainerrors.ErrAlreadyExists
+			}
+			return err
+		}
+		*flag = *r.toEntity(&m)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := db.Model(&m).Updates(map[string]interface{}{
+		"enabled":    flag.Enabled,
+		"updated_at": time.Now(),
+	}).Error; err != nil {
+		return err
+	}
+	m.Enabled = flag.Enabled
+	*flag = *r.toEntity(&m)
+	return nil
+}
+
+func (r *FeatureFlagRepository) Delete(ctx context.Context, name string, merchantID *uuid.UUID) error {
+	result := scopeFeatureFlag(GetDB(ctx, r.db).WithContext(ctx), name, merchantID).Delete(&models.FeatureFlag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func scopeFeatureFlag(db *gorm.DB, name string, merchantID *uuid.UUID) *gorm.DB {
+	db = db.Where("name = ?", name)
+	if merchantID == nil {
+		return db.Where("merchant_id IS NULL")
+	}
+	return db.Where("merchant_id = ?", *merchantID)
+}
+
+func (r *FeatureFlagRepository) toEntity(m *models.FeatureFlag) *entities.FeatureFlag {
+	return &entities.FeatureFlag{
+		ID:         m.ID,
+		Name:       m.Name,
+		MerchantID: m.MerchantID,
+		Enabled:    m.Enabled,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func createFeatureFlagTable(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	mustExec(t, db, `CREATE TABLE feature_flags (
+		id TEXT PRIMARY KEY,
+		name TEXT NOT NULL,
+		merchant_id TEXT,
+		enabled BOOLEAN NOT NULL DEFAULT false,
+		created_at DATETIME,
+		updated_at DATETIME
+	);`)
+}
+
+func TestFeatureFlagRepository_UpsertListDelete(t *testing.T) {
+	db := newTestDB(t)
+	createFeatureFlagTable(t, db)
+	repo := NewFeatureFlagRepository(db)
+	ctx := context.Background()
+	merchantID := uuid.New()
+
+	global := &entities.FeatureFlag{Name: "refunds", Enabled: false}
+	require.NoError(t, repo.Upsert(ctx, global))
+	require.NotEqual(t, uuid.Nil, global.ID)
+
+	override := &entities.FeatureFlag{Name: "refunds", MerchantID: &merchantID, Enabled: true}
+	require.NoError(t, repo.Upsert(ctx, override))
+	require.NotEqual(t, global.ID, override.ID)
+
+	// Upserting the same scope updates in place.
+	again := &entities.FeatureFlag{Name: "refunds", Enabled: true}
+	require.NoError(t, repo.Upsert(ctx, again))
+	require.Equal(t, global.ID, again.ID)
+
+	items, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	for _, item := range items {
+		require.True(t, item.Enabled)
+	}
+
+	require.NoError(t, repo.Delete(ctx, "refunds", &merchantID))
+	require.ErrorIs(t, repo.Delete(ctx, "refunds", &merchantID), domainerrors.ErrNotFound)
+	items, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Nil(t, items[0].MerchantID)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -55,7 +56,7 @@ func TestRepository_List_FindErrorAfterCount_Branches(t *testing.T) {
 		repo := NewRoutePolicyRepository(db)
 
 		registerFindErrorAfterCount(t, db, "route_policies")
-		_, _, err := repo.List(ctx, nil, nil, utils.PaginationParams{Page: 1, Limit: 10})
+		_, _, err := repo.List(ctx, domainrepos.RoutePolicyFilter{}, utils.PaginationParams{Page: 1, Limit: 10})
 		require.Error(t, err)
 	})
 
@@ -79,7 +80,7 @@ func TestRepository_List_FindErrorAfterCount_Branches(t *testing.T) {
 		seedChain(t, db, chainID.String(), "8453", "Base", "EVM", true)
 
 		registerFindErrorAfterCount(t, db, "tokens")
-		_, _, err := repo.GetAllTokens(ctx, nil, nil, utils.PaginationParams{Page: 1, Limit: 10})
+		_, _, err := repo.GetAllTokens(ctx, nil, nil, true, utils.PaginationParams{Page: 1, Limit: 10})
 		require.Error(t, err)
 	})
 }
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/models"
+	"payment-kita.backend/pkg/utils"
+)
+
+type MerchantAllowedReceiverRepository struct {
+	db *gorm.DB
+}
+
+func NewMerchantAllowedReceiverRepository(db *gorm.DB) *MerchantAllowedReceiverRepository {
+	return &MerchantAllowedReceiverRepository{db: db}
+}
+
+func (r *MerchantAllowedReceiverRepository) ListByMerchantID(ctx context.Context, merchantID uuid.UUID) ([]*entities.MerchantAllowedReceiver, error) {
+	var ms []models.MerchantAllowedReceiver
+	if err := GetDB(ctx, r.db).WithContext(ctx).
+		Where("merchant_id = ?", merchantID).
+		Order("created_at ASC").
+		Find(&ms).Error; err != nil {
+		return nil, err
+	}
+	out := make([]*entities.MerchantAllowedReceiver, 0, len(ms))
+	for i := range ms {
+		out = append(out, r.toEntity(&ms[i]))
+	}
+	return out, nil
+}
+
+func (r *MerchantAllowedReceiverRepository) Create(ctx context.Context, receiver *entities.MerchantAllowedReceiver) error {
+	if receiver.ID == uuid.Nil {
+		receiver.ID = utils.GenerateUUIDv7()
+	}
+	m := &models.MerchantAllowedReceiver{
+		ID:         receiver.ID,
+		MerchantID: receiver.MerchantID,
+		Address:    receiver.Address,
+		Label:      receiver.Label,
+		CreatedBy:  receiver.CreatedBy,
+		CreatedAt:  receiver.CreatedAt,
+	}
+	if err := GetDB(ctx, r.db).WithContext(ctx).Create(m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return domainerrors.ErrAlreadyExists
+		}
+		return err
+	}
+	receiver.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *MerchantAllowedReceiverRepository) Delete(ctx context.Context, merchantID, id uuid.UUID) error {
+	result := GetDB(ctx, r.db).WithContext(ctx).
+		Where("id = ? AND merchant_id = ?", id, merchantID).
+		Delete(&models.MerchantAllowedReceiver{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func (r *MerchantAllowedReceiverRepository) toEntity(m *models.MerchantAllowedReceiver) *entities.MerchantAllowedReceiver {
+	return &entities.MerchantAllowedReceiver{
+		ID:         m.ID,
+		MerchantID: m.MerchantID,
+		Address:    m.Address,
+		Label:      m.Label,
+		CreatedBy:  m.CreatedBy,
+		CreatedAt:  m.CreatedAt,
+	}
+}
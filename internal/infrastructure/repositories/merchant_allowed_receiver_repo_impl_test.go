@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestMerchantAllowedReceiverRepository_Flow(t *testing.T) {
+	db := newTestDB(t)
+	mustExec(t, db, `CREATE TABLE merchant_allowed_receivers (
+		id TEXT PRIMARY KEY,
+		merchant_id TEXT NOT NULL,
+		address TEXT NOT NULL,
+		label TEXT NOT NULL DEFAULT '',
+		created_by TEXT,
+		created_at DATETIME,
+		UNIQUE (merchant_id, address)
+	);`)
+	repo := NewMerchantAllowedReceiverRepository(db)
+	ctx := context.Background()
+	merchantID, otherMerchantID := uuid.New(), uuid.New()
+
+	first := &entities.MerchantAllowedReceiver{MerchantID: merchantID, Address: "0xaaa", Label: "treasury", CreatedAt: time.Now()}
+	require.NoError(t, repo.Create(ctx, first))
+	require.NotEqual(t, uuid.Nil, first.ID)
+	require.NoError(t, repo.Create(ctx, &entities.MerchantAllowedReceiver{MerchantID: merchantID, Address: "0xbbb", CreatedAt: time.Now().Add(time.Second)}))
+	require.NoError(t, repo.Create(ctx, &entities.MerchantAllowedReceiver{MerchantID: otherMerchantID, Address: "0xaaa", CreatedAt: time.Now()}))
+
+	err := repo.Create(ctx, &entities.MerchantAllowedReceiver{MerchantID: merchantID, Address: "0xaaa", CreatedAt: time.Now()})
+	require.ErrorIs(t, err, domainerrors.ErrAlreadyExists)
+
+	list, err := repo.ListByMerchantID(ctx, merchantID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "0xaaa", list[0].Address)
+	require.Equal(t, "treasury", list[0].Label)
+
+	require.ErrorIs(t, repo.Delete(ctx, otherMerchantID, first.ID), domainerrors.ErrNotFound)
+	require.NoError(t, repo.Delete(ctx, merchantID, first.ID))
+	list, err = repo.ListByMerchantID(ctx, merchantID)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+}
@@ -61,6 +61,8 @@ func (r *MerchantRepository) Create(ctx context.Context, merchant *entities.Merc
 		BusinessAddress:    addr,
 		Documents:          docs,
 		FeeDiscountPercent: merchant.FeeDiscountPercent,
+		FeeExempt:          merchant.FeeExempt,
+		MinConfirmations:   merchant.MinConfirmations,
 		CallbackURL:        merchant.CallbackURL,
 		WebhookSecret:      merchant.WebhookSecret,
 		WebhookIsActive:    merchant.WebhookIsActive,
@@ -73,13 +75,20 @@ func (r *MerchantRepository) Create(ctx context.Context, merchant *entities.Merc
 		UpdatedAt:          merchant.UpdatedAt,
 	}
 
-	return r.db.WithContext(ctx).Create(m).Error
+	if err := GetDB(ctx, r.db).WithContext(ctx).Create(m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return domainerrors.ErrAlreadyExists
+		}
+		return err
+	}
+	merchant.ID = m.ID
+	return nil
 }
 
 // GetByID gets a merchant by ID
 func (r *MerchantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Merchant, error) {
 	var m models.Merchant
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
+	if err := GetDB(ctx, r.db).WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
@@ -91,7 +100,7 @@ func (r *MerchantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entiti
 // GetByUserID gets a merchant by user ID
 func (r *MerchantRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.Merchant, error) {
 	var m models.Merchant
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&m).Error; err != nil {
+	if err := GetDB(ctx, r.db).WithContext(ctx).Where("user_id = ?", userID).First(&m).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
@@ -132,6 +141,8 @@ func (r *MerchantRepository) Update(ctx context.Context, merchant *entities.Merc
 		"business_address":     addr,
 		"documents":            docs,
 		"fee_discount_percent": merchant.FeeDiscountPercent,
+		"fee_exempt":           merchant.FeeExempt,
+		"min_confirmations":    merchant.MinConfirmations,
 		"callback_url":         merchant.CallbackURL,
 		"webhook_secret":       merchant.WebhookSecret,
 		"webhook_is_active":    merchant.WebhookIsActive,
@@ -142,7 +153,7 @@ func (r *MerchantRepository) Update(ctx context.Context, merchant *entities.Merc
 		"updated_at":           time.Now(),
 	}
 
-	result := r.db.WithContext(ctx).Model(&models.Merchant{}).
+	result := GetDB(ctx, r.db).WithContext(ctx).Model(&models.Merchant{}).
 		Where("id = ?", merchant.ID).
 		Updates(updates)
 
@@ -166,7 +177,7 @@ func (r *MerchantRepository) UpdateStatus(ctx context.Context, id uuid.UUID, sta
 		updates["verified_at"] = time.Now()
 	}
 
-	result := r.db.WithContext(ctx).Model(&models.Merchant{}).
+	result := GetDB(ctx, r.db).WithContext(ctx).Model(&models.Merchant{}).
 		Where("id = ?", id).
 		Updates(updates)
 
@@ -182,7 +193,7 @@ func (r *MerchantRepository) UpdateStatus(ctx context.Context, id uuid.UUID, sta
 // List lists all merchants
 func (r *MerchantRepository) List(ctx context.Context) ([]*entities.Merchant, error) {
 	var mList []models.Merchant
-	if err := r.db.WithContext(ctx).Order("created_at DESC").Find(&mList).Error; err != nil {
+	if err := GetDB(ctx, r.db).WithContext(ctx).Order("created_at DESC").Find(&mList).Error; err != nil {
 		return nil, err
 	}
 
@@ -196,7 +207,7 @@ func (r *MerchantRepository) List(ctx context.Context) ([]*entities.Merchant, er
 
 // SoftDelete soft deletes a merchant
 func (r *MerchantRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&models.Merchant{}, "id = ?", id)
+	result := GetDB(ctx, r.db).WithContext(ctx).Delete(&models.Merchant{}, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -218,6 +229,8 @@ func (r *MerchantRepository) toEntity(m *models.Merchant) *entities.Merchant {
 		BusinessAddress:    null.StringFrom(m.BusinessAddress),
 		Documents:          null.JSONFrom([]byte(m.Documents)),
 		FeeDiscountPercent: m.FeeDiscountPercent,
+		FeeExempt:          m.FeeExempt,
+		MinConfirmations:   m.MinConfirmations,
 		CallbackURL:        m.CallbackURL,
 		WebhookSecret:      m.WebhookSecret,
 		WebhookIsActive:    m.WebhookIsActive,
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,7 +24,7 @@ func NewMerchantSettlementProfileRepository(db *gorm.DB) *MerchantSettlementProf
 func (r *MerchantSettlementProfileRepositoryImpl) GetByMerchantID(ctx context.Context, merchantID uuid.UUID) (*domainentities.MerchantSettlementProfile, error) {
 	var m models.MerchantSettlementProfile
 	if err := GetDB(ctx, r.db).Where("merchant_id = ?", merchantID).First(&m).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
 		return nil, err
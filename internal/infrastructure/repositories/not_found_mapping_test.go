@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// Every single-row lookup reports a missing row as domainerrors.ErrNotFound, so callers can
+// tell it apart from a database failure
+func TestRepositories_MissingRowIsErrNotFound(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	cases := []struct {
+		name   string
+		tables func(t *testing.T, db *gorm.DB)
+		lookup func(db *gorm.DB) error
+	}{
+		{"api key by id", createAPIKeyTable, func(db *gorm.DB) error {
+			_, err := NewApiKeyRepository(db).FindByID(ctx, id)
+			return err
+		}},
+		{"api key by hash", createAPIKeyTable, func(db *gorm.DB) error {
+			_, err := NewApiKeyRepository(db).FindByKeyHash(ctx, "missing")
+			return err
+		}},
+		{"bridge config by id", createBridgeAndFeeTables, func(db *gorm.DB) error {
+			_, err := NewBridgeConfigRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"active bridge config", createBridgeAndFeeTables, func(db *gorm.DB) error {
+			_, err := NewBridgeConfigRepository(db).GetActive(ctx, id, uuid.New())
+			return err
+		}},
+		{"chain by id", createChainTables, func(db *gorm.DB) error {
+			_, err := NewChainRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"chain by chain id", createChainTables, func(db *gorm.DB) error {
+			_, err := NewChainRepository(db).GetByChainID(ctx, "999999")
+			return err
+		}},
+		{"chain by caip2", createChainTables, func(db *gorm.DB) error {
+			_, err := NewChainRepository(db).GetByCAIP2(ctx, "eip155:999999")
+			return err
+		}},
+		{"chain rpc by id", createChainTables, func(db *gorm.DB) error {
+			_, err := NewChainRepository(db).GetRPCByID(ctx, id)
+			return err
+		}},
+		{"fee config by id", createBridgeAndFeeTables, func(db *gorm.DB) error {
+			_, err := NewFeeConfigRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"fee config by chain and token", createBridgeAndFeeTables, func(db *gorm.DB) error {
+			_, err := NewFeeConfigRepository(db).GetByChainAndToken(ctx, id, uuid.New())
+			return err
+		}},
+		{"merchant by id", createMerchantTable, func(db *gorm.DB) error {
+			_, err := NewMerchantRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"merchant by user", createMerchantTable, func(db *gorm.DB) error {
+			_, err := NewMerchantRepository(db).GetByUserID(ctx, id)
+			return err
+		}},
+		{"settlement profile", createMerchantSettlementProfileTable, func(db *gorm.DB) error {
+			_, err := NewMerchantSettlementProfileRepository(db).GetByMerchantID(ctx, id)
+			return err
+		}},
+		{"payment quote", createPartnerFlowTables, func(db *gorm.DB) error {
+			_, err := NewPaymentQuoteRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"partner session by id", createPartnerFlowTables, func(db *gorm.DB) error {
+			_, err := NewPartnerPaymentSessionRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"partner session by payment request", createPartnerFlowTables, func(db *gorm.DB) error {
+			_, err := NewPartnerPaymentSessionRepository(db).GetByPaymentRequestID(ctx, id)
+			return err
+		}},
+		{"payment bridge by id", createPaymentBridgeTable, func(db *gorm.DB) error {
+			_, err := NewPaymentBridgeRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"payment bridge by name", createPaymentBridgeTable, func(db *gorm.DB) error {
+			_, err := NewPaymentBridgeRepository(db).GetByName(ctx, "missing")
+			return err
+		}},
+		{"latest payment event", createPaymentTables, func(db *gorm.DB) error {
+			_, err := NewPaymentEventRepository(db).GetLatestByPaymentID(ctx, id)
+			return err
+		}},
+		{"payment by id", createPaymentTables, func(db *gorm.DB) error {
+			_, err := NewPaymentRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"payment request by id", createPaymentRequestTables, func(db *gorm.DB) error {
+			_, err := NewPaymentRequestRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"route policy by id", createRoutePolicyTables, func(db *gorm.DB) error {
+			_, err := NewRoutePolicyRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"route policy by route", createRoutePolicyTables, func(db *gorm.DB) error {
+			_, err := NewRoutePolicyRepository(db).GetByRoute(ctx, id, uuid.New())
+			return err
+		}},
+		{"smart contract by id", createSmartContractTable, func(db *gorm.DB) error {
+			_, err := NewSmartContractRepository(db, &stubChainRepo{}).GetByID(ctx, id)
+			return err
+		}},
+		{"smart contract by address", createSmartContractTable, func(db *gorm.DB) error {
+			_, err := NewSmartContractRepository(db, &stubChainRepo{}).GetByChainAndAddress(ctx, id, "0xabc")
+			return err
+		}},
+		{"active smart contract", createSmartContractTable, func(db *gorm.DB) error {
+			_, err := NewSmartContractRepository(db, &stubChainRepo{}).GetActiveContract(ctx, id, entities.ContractTypeGateway)
+			return err
+		}},
+		{"active smart contract address", createSmartContractTable, func(db *gorm.DB) error {
+			_, err := NewSmartContractRepository(db, &stubChainRepo{}).GetActiveContractAddress(ctx, id, entities.ContractTypeGateway)
+			return err
+		}},
+		{"stargate config by id", createRoutePolicyTables, func(db *gorm.DB) error {
+			_, err := NewStargateConfigRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"stargate config by route", createRoutePolicyTables, func(db *gorm.DB) error {
+			_, err := NewStargateConfigRepository(db).GetByRoute(ctx, id, uuid.New())
+			return err
+		}},
+		{"team by id", createTeamTable, func(db *gorm.DB) error {
+			_, err := NewTeamRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"token by id", createTokenTable, func(db *gorm.DB) error {
+			_, err := NewTokenRepository(db, nil).GetByID(ctx, id)
+			return err
+		}},
+		{"token by symbol", createTokenTable, func(db *gorm.DB) error {
+			_, err := NewTokenRepository(db, nil).GetBySymbol(ctx, "USDC", id)
+			return err
+		}},
+		{"token by address", createTokenTable, func(db *gorm.DB) error {
+			_, err := NewTokenRepository(db, nil).GetByAddress(ctx, "0xabc", id)
+			return err
+		}},
+		{"native token", createTokenTable, func(db *gorm.DB) error {
+			_, err := NewTokenRepository(db, nil).GetNative(ctx, id)
+			return err
+		}},
+		{"user by id", createUserTable, func(db *gorm.DB) error {
+			_, err := NewUserRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"user by email", createUserTable, func(db *gorm.DB) error {
+			_, err := NewUserRepository(db).GetByEmail(ctx, "missing@example.com")
+			return err
+		}},
+		{"wallet by id", createWalletTable, func(db *gorm.DB) error {
+			_, err := NewWalletRepository(db).GetByID(ctx, id)
+			return err
+		}},
+		{"wallet by address", createWalletTable, func(db *gorm.DB) error {
+			_, err := NewWalletRepository(db).GetByAddress(ctx, id, "0xabc")
+			return err
+		}},
+		{"webhook delivery by id", func(t *testing.T, db *gorm.DB) {
+			mustExec(t, db, `CREATE TABLE webhook_logs (
+				id TEXT PRIMARY KEY,
+				merchant_id TEXT NOT NULL,
+				payment_id TEXT NOT NULL,
+				event_type TEXT NOT NULL,
+				payload TEXT NOT NULL,
+				delivery_status TEXT,
+				http_status INTEGER,
+				response_body TEXT,
+				retry_count INTEGER,
+				next_retry_at DATETIME,
+				last_attempt_at DATETIME,
+				created_at DATETIME,
+				updated_at DATETIME
+			);`)
+		}, func(db *gorm.DB) error {
+			_, err := NewGormWebhookLogRepository(db).GetByID(ctx, id)
+			return err
+		}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newTestDB(t)
+			tc.tables(t, db)
+			require.ErrorIs(t, tc.lookup(db), domainerrors.ErrNotFound)
+		})
+	}
+}
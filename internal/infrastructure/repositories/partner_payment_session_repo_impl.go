@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -47,7 +48,7 @@ func (r *PaymentQuoteRepositoryImpl) Create(ctx context.Context, quote *domainen
 func (r *PaymentQuoteRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domainentities.PaymentQuote, error) {
 	var m models.PaymentQuote
 	if err := GetDB(ctx, r.db).Where("id = ?", id).First(&m).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
 		return nil, err
@@ -193,7 +194,7 @@ func (r *PartnerPaymentSessionRepositoryImpl) Create(ctx context.Context, sessio
 func (r *PartnerPaymentSessionRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*domainentities.PartnerPaymentSession, error) {
 	var m models.PartnerPaymentSession
 	if err := GetDB(ctx, r.db).Where("id = ?", id).First(&m).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
 		return nil, err
@@ -204,7 +205,7 @@ func (r *PartnerPaymentSessionRepositoryImpl) GetByID(ctx context.Context, id uu
 func (r *PartnerPaymentSessionRepositoryImpl) GetByPaymentRequestID(ctx context.Context, paymentRequestID uuid.UUID) (*domainentities.PartnerPaymentSession, error) {
 	var m models.PartnerPaymentSession
 	if err := GetDB(ctx, r.db).Where("payment_request_id = ?", paymentRequestID).First(&m).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
 		return nil, err
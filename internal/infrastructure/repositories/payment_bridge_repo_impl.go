@@ -31,7 +31,7 @@ func (r *paymentBridgeRepo) GetByID(ctx context.Context, id uuid.UUID) (*entitie
 		}
 		return nil, err
 	}
-	return &entities.PaymentBridge{ID: m.ID, Name: m.Name}, nil
+	return toPaymentBridgeEntity(&m), nil
 }
 
 func (r *paymentBridgeRepo) GetByName(ctx context.Context, name string) (*entities.PaymentBridge, error) {
@@ -42,7 +42,7 @@ func (r *paymentBridgeRepo) GetByName(ctx context.Context, name string) (*entiti
 		}
 		return nil, err
 	}
-	return &entities.PaymentBridge{ID: m.ID, Name: m.Name}, nil
+	return toPaymentBridgeEntity(&m), nil
 }
 
 func (r *paymentBridgeRepo) List(ctx context.Context, pagination utils.PaginationParams) ([]*entities.PaymentBridge, int64, error) {
@@ -64,10 +64,7 @@ func (r *paymentBridgeRepo) List(ctx context.Context, pagination utils.Paginatio
 
 	items := make([]*entities.PaymentBridge, 0, len(rows))
 	for _, row := range rows {
-		items = append(items, &entities.PaymentBridge{
-			ID:   row.ID,
-			Name: row.Name,
-		})
+		items = append(items, toPaymentBridgeEntity(&row))
 	}
 	return items, total, nil
 }
@@ -78,10 +75,11 @@ func (r *paymentBridgeRepo) Create(ctx context.Context, bridge *entities.Payment
 	}
 
 	m := &models.PaymentBridge{
-		ID:        bridge.ID,
-		Name:      strings.TrimSpace(bridge.Name),
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:                  bridge.ID,
+		Name:                strings.TrimSpace(bridge.Name),
+		ExplorerURLTemplate: explorerURLTemplateColumn(bridge.ExplorerURLTemplate),
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
 	}
 	return r.db.WithContext(ctx).Create(m).Error
 }
@@ -90,8 +88,9 @@ func (r *paymentBridgeRepo) Update(ctx context.Context, bridge *entities.Payment
 	result := r.db.WithContext(ctx).Model(&models.PaymentBridge{}).
 		Where("id = ?", bridge.ID).
 		Updates(map[string]interface{}{
-			"name":       strings.TrimSpace(bridge.Name),
-			"updated_at": time.Now(),
+			"name":                  strings.TrimSpace(bridge.Name),
+			"explorer_url_template": explorerURLTemplateColumn(bridge.ExplorerURLTemplate),
+			"updated_at":            time.Now(),
 		})
 
 	if result.Error != nil {
@@ -113,3 +112,20 @@ func (r *paymentBridgeRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+func toPaymentBridgeEntity(m *models.PaymentBridge) *entities.PaymentBridge {
+	bridge := &entities.PaymentBridge{ID: m.ID, Name: m.Name}
+	if m.ExplorerURLTemplate != nil {
+		bridge.ExplorerURLTemplate = *m.ExplorerURLTemplate
+	}
+	return bridge
+}
+
+// explorerURLTemplateColumn stores a blank template as NULL
+func explorerURLTemplateColumn(template string) *string {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		return nil
+	}
+	return &template
+}
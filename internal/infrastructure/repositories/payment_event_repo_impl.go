@@ -42,15 +42,17 @@ func (r *PaymentEventRepository) Create(ctx context.Context, event *entities.Pay
 	_, inTx := ctx.Value(txKey).(*gorm.DB)
 	// Map Entity -> Model
 	m := &models.PaymentEvent{
-		ID:          event.ID,
-		PaymentID:   event.PaymentID,
-		EventType:   string(event.EventType),
-		TxHash:      event.TxHash,
-		Metadata:    meta,
-		CreatedAt:   createdAt,
-		ChainID:     event.ChainID,
-		Chain:       r.resolveLegacyChainValue(event.ChainID),
-		BlockNumber: event.BlockNumber,
+		ID:            event.ID,
+		PaymentID:     event.PaymentID,
+		EventType:     string(event.EventType),
+		TxHash:        event.TxHash,
+		Metadata:      meta,
+		Details:       encodeEventDetails(event.Details),
+		CreatedAt:     createdAt,
+		ChainID:       event.ChainID,
+		Chain:         r.resolveLegacyChainValue(event.ChainID),
+		BlockNumber:   event.BlockNumber,
+		Confirmations: event.Confirmations,
 	}
 
 	// Keep this best-effort write quiet: a single attempt avoids repeated FK spam logs
@@ -129,14 +131,16 @@ func (r *PaymentEventRepository) GetByPaymentID(ctx context.Context, paymentID u
 	var events []*entities.PaymentEvent
 	for _, m := range ms {
 		event := &entities.PaymentEvent{
-			ID:          m.ID,
-			PaymentID:   m.PaymentID,
-			EventType:   entities.PaymentEventType(m.EventType),
-			TxHash:      m.TxHash,
-			ChainID:     m.ChainID,
-			BlockNumber: m.BlockNumber,
-			Metadata:    parseEventMetadataFromStorage(m.Metadata),
-			CreatedAt:   m.CreatedAt,
+			ID:            m.ID,
+			PaymentID:     m.PaymentID,
+			EventType:     entities.PaymentEventType(m.EventType),
+			TxHash:        m.TxHash,
+			ChainID:       m.ChainID,
+			BlockNumber:   m.BlockNumber,
+			Confirmations: m.Confirmations,
+			Metadata:      parseEventMetadataFromStorage(m.Metadata),
+			Details:       decodeEventDetails(m.Details),
+			CreatedAt:     m.CreatedAt,
 		}
 		events = append(events, event)
 	}
@@ -158,14 +162,16 @@ func (r *PaymentEventRepository) GetLatestByPaymentID(ctx context.Context, payme
 	}
 
 	return &entities.PaymentEvent{
-		ID:          m.ID,
-		PaymentID:   m.PaymentID,
-		EventType:   entities.PaymentEventType(m.EventType),
-		TxHash:      m.TxHash,
-		ChainID:     m.ChainID,
-		BlockNumber: m.BlockNumber,
-		Metadata:    parseEventMetadataFromStorage(m.Metadata),
-		CreatedAt:   m.CreatedAt,
+		ID:            m.ID,
+		PaymentID:     m.PaymentID,
+		EventType:     entities.PaymentEventType(m.EventType),
+		TxHash:        m.TxHash,
+		ChainID:       m.ChainID,
+		BlockNumber:   m.BlockNumber,
+		Confirmations: m.Confirmations,
+		Metadata:      parseEventMetadataFromStorage(m.Metadata),
+		Details:       decodeEventDetails(m.Details),
+		CreatedAt:     m.CreatedAt,
 	}, nil
 }
 
@@ -201,3 +207,27 @@ func parseEventMetadataFromStorage(raw string) interface{} {
 	}
 	return decoded
 }
+
+// encodeEventDetails stores empty details as NULL
+func encodeEventDetails(details *entities.PaymentEventDetails) *string {
+	if details.IsEmpty() {
+		return nil
+	}
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return nil
+	}
+	raw := string(encoded)
+	return &raw
+}
+
+func decodeEventDetails(raw *string) *entities.PaymentEventDetails {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return nil
+	}
+	var details entities.PaymentEventDetails
+	if err := json.Unmarshal([]byte(*raw), &details); err != nil || details.IsEmpty() {
+		return nil
+	}
+	return &details
+}
@@ -49,6 +49,46 @@ func TestPaymentEventRepository_CreateAndReads(t *testing.T) {
 	require.Equal(t, eventID, latest.ID)
 }
 
+func TestPaymentEventRepository_Details(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentTables(t, db)
+	repo := NewPaymentEventRepository(db)
+	ctx := context.Background()
+
+	paymentID := uuid.New()
+	now := time.Now()
+	mustExec(t, db, `INSERT INTO payments(
+		id,sender_id,source_chain_id,dest_chain_id,source_token_id,dest_token_id,source_amount,fee_amount,total_charged,status,created_at,updated_at
+	) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)`,
+		paymentID.String(), uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString(),
+		"1", "0", "1", "PENDING", now, now)
+
+	details := &entities.PaymentEventDetails{
+		TxHash:          "0xtx",
+		BlockNumber:     42,
+		ObservedAmount:  "1000000",
+		BridgeMessageID: "0xmsg",
+		RevertReason:    "insufficient output",
+	}
+	require.NoError(t, repo.Create(ctx, &entities.PaymentEvent{
+		ID: uuid.New(), PaymentID: paymentID, EventType: entities.PaymentEventTypeIndexerFailed, Details: details, CreatedAt: now,
+	}))
+	require.NoError(t, repo.Create(ctx, &entities.PaymentEvent{
+		ID: uuid.New(), PaymentID: paymentID, EventType: entities.PaymentEventTypeCreated,
+		Details: &entities.PaymentEventDetails{}, CreatedAt: now.Add(-time.Minute),
+	}))
+
+	events, err := repo.GetByPaymentID(ctx, paymentID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Nil(t, events[0].Details, "empty details are stored as NULL")
+	require.Equal(t, details, events[1].Details)
+
+	latest, err := repo.GetLatestByPaymentID(ctx, paymentID)
+	require.NoError(t, err)
+	require.Equal(t, details, latest.Details)
+}
+
 func TestPaymentEventRepository_NotFoundLatest(t *testing.T) {
 	db := newTestDB(t)
 	createPaymentTables(t, db)
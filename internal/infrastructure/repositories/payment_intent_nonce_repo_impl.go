@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/models"
+)
+
+type PaymentIntentNonceRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentIntentNonceRepository(db *gorm.DB) *PaymentIntentNonceRepository {
+	return &PaymentIntentNonceRepository{db: db}
+}
+
+// Consume relies on the (payer, nonce) primary key, so concurrent uses of one nonce cannot both succeed
+func (r *PaymentIntentNonceRepository) Consume(ctx context.Context, payer, nonce string, expiresAt time.Time) error {
+	m := &models.PaymentIntentNonce{Payer: payer, Nonce: nonce, ExpiresAt: expiresAt, CreatedAt: time.Now().UTC()}
+	if err := GetDB(ctx, r.db).WithContext(ctx).Create(m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return domainerrors.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestPaymentIntentNonceRepository_Consume(t *testing.T) {
+	db := newTestDB(t)
+	mustExec(t, db, `CREATE TABLE payment_intent_nonces (
+		payer TEXT NOT NULL,
+		nonce TEXT NOT NULL,
+		expires_at DATETIME NOT NULL,
+		created_at DATETIME,
+		PRIMARY KEY (payer, nonce)
+	);`)
+	repo := NewPaymentIntentNonceRepository(db)
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, repo.Consume(ctx, "0xaaa", "1", expiresAt))
+	require.ErrorIs(t, repo.Consume(ctx, "0xaaa", "1", expiresAt), domainerrors.ErrAlreadyExists)
+	// Nonces are per payer
+	require.NoError(t, repo.Consume(ctx, "0xbbb", "1", expiresAt))
+	require.NoError(t, repo.Consume(ctx, "0xaaa", "2", expiresAt))
+}
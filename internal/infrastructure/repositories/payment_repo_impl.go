@@ -11,6 +11,7 @@ import (
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/infrastructure/models"
+	"payment-kita.backend/pkg/utils"
 )
 
 // PaymentRepository implements payment data operations
@@ -75,7 +76,14 @@ func (r *PaymentRepository) Create(ctx context.Context, payment *entities.Paymen
 	m.TotalCharged = payment.TotalCharged
 	m.SenderAddress = payment.SenderAddress
 	m.DestAddress = payment.ReceiverAddress
+	m.ReceiverName = payment.ReceiverName.Ptr()
+	m.ExternalRef = payment.ExternalRef.Ptr()
+	m.Metadata = metadataColumn(payment.Metadata)
 	m.Status = string(payment.Status)
+	m.Mode = string(payment.Mode)
+	if m.Mode == "" {
+		m.Mode = string(entities.PaymentModeLive)
+	}
 	m.FailureReason = payment.FailureReason.Ptr()
 	m.RevertData = payment.RevertData.Ptr()
 	m.CreatedAt = payment.CreatedAt
@@ -96,7 +104,7 @@ func (r *PaymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entitie
 	var m models.Payment
 	// Use the transaction-aware DB instance
 	db := GetDB(ctx, r.db)
-	if err := db.WithContext(ctx).Preload("SourceChain").Preload("DestChain").Preload("SourceToken").Preload("DestToken").Where("id = ?", id).First(&m).Error; err != nil {
+	if err := db.WithContext(ctx).Preload("SourceChain", withDeleted).Preload("DestChain", withDeleted).Preload("SourceToken", withDeleted).Preload("DestToken", withDeleted).Preload("Bridge").Where("id = ?", id).First(&m).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
@@ -105,22 +113,23 @@ func (r *PaymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entitie
 	return r.toEntity(&m), nil
 }
 
-// GetByUserID gets payments for a user with pagination
-func (r *PaymentRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Payment, int, error) {
+// GetByUserID gets a user's payments in mode with pagination
+func (r *PaymentRepository) GetByUserID(ctx context.Context, userID uuid.UUID, mode entities.PaymentMode, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
 	var total int64
 	if err := r.db.WithContext(ctx).Model(&models.Payment{}).
-		Where("sender_id = ?", userID).
+		Where("sender_id = ? AND mode = ?", userID, mode).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	query := r.db.WithContext(ctx).
+		Preload("SourceChain", withDeleted).Preload("DestChain", withDeleted).
+		Where("sender_id = ? AND mode = ?", userID, mode)
+	if pagination.Limit > 0 {
+		query = query.Limit(pagination.Limit).Offset(pagination.CalculateOffset())
+	}
 	var ms []models.Payment
-	if err := r.db.WithContext(ctx).
-		Preload("SourceChain").Preload("DestChain").
-		Where("sender_id = ?", userID).
-		Order("created_at DESC").
-		Limit(limit).Offset(offset).
-		Find(&ms).Error; err != nil {
+	if err := query.Order("created_at DESC").Find(&ms).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -130,34 +139,92 @@ func (r *PaymentRepository) GetByUserID(ctx context.Context, userID uuid.UUID, l
 		payments = append(payments, r.toEntity(&model))
 	}
 
-	return payments, int(total), nil
+	return payments, total, nil
 }
 
-// GetByMerchantID gets payments for a merchant
-func (r *PaymentRepository) GetByMerchantID(ctx context.Context, merchantID uuid.UUID, limit, offset int) ([]*entities.Payment, int, error) {
+// GetByMerchantID gets a merchant's payments in mode
+func (r *PaymentRepository) GetByMerchantID(ctx context.Context, merchantID uuid.UUID, mode entities.PaymentMode, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
 	var total int64
 	if err := r.db.WithContext(ctx).Model(&models.Payment{}).
-		Where("merchant_id = ?", merchantID).
+		Where("merchant_id = ? AND mode = ?", merchantID, mode).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := r.db.WithContext(ctx).
+		Preload("SourceChain", withDeleted).Preload("DestChain", withDeleted).
+		Where("merchant_id = ? AND mode = ?", merchantID, mode)
+	if pagination.Limit > 0 {
+		query = query.Limit(pagination.Limit).Offset(pagination.CalculateOffset())
+	}
+	var ms []models.Payment
+	if err := query.Order("created_at DESC").Find(&ms).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var payments []*entities.Payment
+	for _, m := range ms {
+		model := m
+		payments = append(payments, r.toEntity(&model))
+	}
+
+	return payments, total, nil
+}
+
+// GetByMerchantExternalRef gets a merchant's payments in mode carrying the merchant order id ref,
+// newest first. A ref is not unique: a retried order can have several payments.
+func (r *PaymentRepository) GetByMerchantExternalRef(ctx context.Context, merchantID uuid.UUID, mode entities.PaymentMode, ref string, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.Payment{}).
+		Where("merchant_id = ? AND mode = ? AND external_ref = ?", merchantID, mode, ref).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := r.db.WithContext(ctx).
+		Preload("SourceChain", withDeleted).Preload("DestChain", withDeleted).
+		Where("merchant_id = ? AND mode = ? AND external_ref = ?", merchantID, mode, ref)
+	if pagination.Limit > 0 {
+		query = query.Limit(pagination.Limit).Offset(pagination.CalculateOffset())
+	}
+	var ms []models.Payment
+	if err := query.Order("created_at DESC").Find(&ms).Error; err != nil {
+		return nil, 0, err
+	}
+
+	payments := make([]*entities.Payment, 0, len(ms))
+	for _, m := range ms {
+		model := m
+		payments = append(payments, r.toEntity(&model))
+	}
+	return payments, total, nil
+}
+
+// GetByStatus gets payments in status with pagination, oldest first so a review queue is
+// worked in arrival order
+func (r *PaymentRepository) GetByStatus(ctx context.Context, status entities.PaymentStatus, limit, offset int) ([]*entities.Payment, int, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.Payment{}).
+		Where("status = ?", status).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	var ms []models.Payment
 	if err := r.db.WithContext(ctx).
-		Preload("SourceChain").Preload("DestChain").
-		Where("merchant_id = ?", merchantID).
-		Order("created_at DESC").
+		Preload("SourceChain", withDeleted).Preload("DestChain", withDeleted).Preload("SourceToken", withDeleted).Preload("DestToken", withDeleted).
+		Where("status = ?", status).
+		Order("created_at ASC").
 		Limit(limit).Offset(offset).
 		Find(&ms).Error; err != nil {
 		return nil, 0, err
 	}
 
-	var payments []*entities.Payment
+	payments := make([]*entities.Payment, 0, len(ms))
 	for _, m := range ms {
 		model := m
 		payments = append(payments, r.toEntity(&model))
 	}
-
 	return payments, int(total), nil
 }
 
@@ -171,11 +238,12 @@ func (r *PaymentRepository) Update(ctx context.Context, payment *entities.Paymen
 	// Since we introduced FailureReason/RevertData which are nullable strings, we need to be careful.
 
 	updates := map[string]interface{}{
-		"status":         payment.Status,
-		"failure_reason": payment.FailureReason.Ptr(),
-		"revert_data":    payment.RevertData.Ptr(),
-		"dest_tx_hash":   payment.DestTxHash.Ptr(),
-		"updated_at":     time.Now(),
+		"status":                 payment.Status,
+		"failure_reason":         payment.FailureReason.Ptr(),
+		"revert_data":            payment.RevertData.Ptr(),
+		"dest_tx_hash":           payment.DestTxHash.Ptr(),
+		"cross_chain_message_id": payment.CrossChainMessageID.Ptr(),
+		"updated_at":             time.Now(),
 	}
 
 	result := db.WithContext(ctx).Model(&models.Payment{}).
@@ -209,6 +277,28 @@ func (r *PaymentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, stat
 	return nil
 }
 
+// ResolvePendingApproval moves a payment awaiting approval to status. The status guard makes
+// concurrent reviews of the same payment safe: only the first one matches a row.
+func (r *PaymentRepository) ResolvePendingApproval(ctx context.Context, id uuid.UUID, status entities.PaymentStatus, failureReason null.String, expiresAt *time.Time) error {
+	db := GetDB(ctx, r.db)
+	result := db.WithContext(ctx).Model(&models.Payment{}).
+		Where("id = ? AND status = ?", id, entities.PaymentStatusPendingApproval).
+		Updates(map[string]interface{}{
+			"status":         status,
+			"failure_reason": failureReason.Ptr(),
+			"expires_at":     expiresAt,
+			"updated_at":     time.Now(),
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
 func (r *PaymentRepository) UpdateDestTxHash(ctx context.Context, id uuid.UUID, txHash string) error {
 	db := GetDB(ctx, r.db)
 	return db.WithContext(ctx).Model(&models.Payment{}).
@@ -251,11 +341,15 @@ func (r *PaymentRepository) toEntity(m *models.Payment) *entities.Payment {
 		SenderAddress:       m.SenderAddress,
 		DestAddress:         m.DestAddress,
 		ReceiverAddress:     m.DestAddress,
+		ReceiverName:        null.StringFromPtr(m.ReceiverName),
+		ExternalRef:         null.StringFromPtr(m.ExternalRef),
+		Metadata:            metadataFromColumn(m.Metadata),
 		SourceAmount:        m.SourceAmount,
 		DestAmount:          null.StringFromPtr(m.DestAmount),
 		FeeAmount:           m.FeeAmount,
 		TotalCharged:        m.TotalCharged,
 		Status:              entities.PaymentStatus(m.Status),
+		Mode:                entities.PaymentMode(m.Mode),
 		SourceTxHash:        null.StringFromPtr(m.SourceTxHash),
 		DestTxHash:          null.StringFromPtr(m.DestTxHash),
 		RefundTxHash:        null.StringFromPtr(m.RefundTxHash),
@@ -283,6 +377,26 @@ func (r *PaymentRepository) toEntity(m *models.Payment) *entities.Payment {
 			Name:    m.DestChain.Name,
 		}
 	}
+	if m.Bridge != nil && m.Bridge.ID != uuid.Nil {
+		p.Bridge = toPaymentBridgeEntity(m.Bridge)
+		p.BridgeExplorerURL = p.Bridge.ExplorerURL(p.CrossChainMessageID.String, p.SourceTxHash.String)
+	}
 
 	return p
 }
+
+// metadataColumn maps integrator metadata to its nullable jsonb column
+func metadataColumn(metadata null.JSON) *string {
+	if !metadata.Valid || len(metadata.JSON) == 0 {
+		return nil
+	}
+	raw := string(metadata.JSON)
+	return &raw
+}
+
+func metadataFromColumn(raw *string) null.JSON {
+	if raw == nil || *raw == "" {
+		return null.JSON{}
+	}
+	return null.JSONFrom([]byte(*raw))
+}
@@ -11,6 +11,7 @@ import (
 	"gorm.io/gorm"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/utils"
 )
 
 func TestPaymentRepository_BasicFlow(t *testing.T) {
@@ -49,6 +50,7 @@ func TestPaymentRepository_BasicFlow(t *testing.T) {
 		FeeAmount:     "1",
 		TotalCharged:  "101",
 		SenderAddress: "0xsender",
+		ExternalRef:   null.StringFrom("order-42"),
 		Status:        entities.PaymentStatusPending,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
@@ -59,17 +61,48 @@ func TestPaymentRepository_BasicFlow(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, p.ID, got.ID)
 	require.Equal(t, "0xsender", got.SenderAddress)
+	require.Equal(t, entities.PaymentModeLive, got.Mode)
 
-	byUser, totalUser, err := repo.GetByUserID(ctx, userID, 10, 0)
+	byUser, totalUser, err := repo.GetByUserID(ctx, userID, entities.PaymentModeLive, utils.PaginationParams{Page: 1, Limit: 10})
 	require.NoError(t, err)
-	require.Equal(t, 1, totalUser)
+	require.Equal(t, int64(1), totalUser)
 	require.Len(t, byUser, 1)
 
-	byMerchant, totalMerchant, err := repo.GetByMerchantID(ctx, merchantID, 10, 0)
+	byUser, totalUser, err = repo.GetByUserID(ctx, userID, entities.PaymentModeLive, utils.PaginationParams{Page: 2, Limit: 10})
 	require.NoError(t, err)
-	require.Equal(t, 1, totalMerchant)
+	require.Equal(t, int64(1), totalUser)
+	require.Empty(t, byUser)
+
+	byMerchant, totalMerchant, err := repo.GetByMerchantID(ctx, merchantID, entities.PaymentModeLive, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), totalMerchant)
 	require.Len(t, byMerchant, 1)
 
+	// The live payment stays out of test-mode listings
+	byUser, totalUser, err = repo.GetByUserID(ctx, userID, entities.PaymentModeTest, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Zero(t, totalUser)
+	require.Empty(t, byUser)
+	_, totalMerchant, err = repo.GetByMerchantID(ctx, merchantID, entities.PaymentModeTest, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Zero(t, totalMerchant)
+
+	byRef, totalRef, err := repo.GetByMerchantExternalRef(ctx, merchantID, entities.PaymentModeLive, "order-42", utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), totalRef)
+	require.Len(t, byRef, 1)
+	require.Equal(t, "order-42", byRef[0].ExternalRef.String)
+	byRef, totalRef, err = repo.GetByMerchantExternalRef(ctx, merchantID, entities.PaymentModeLive, "order-42", utils.PaginationParams{Page: 2, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), totalRef)
+	require.Empty(t, byRef)
+	testRef, _, err := repo.GetByMerchantExternalRef(ctx, merchantID, entities.PaymentModeTest, "order-42", utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, testRef)
+	otherMerchant, _, err := repo.GetByMerchantExternalRef(ctx, uuid.New(), entities.PaymentModeLive, "order-42", utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, otherMerchant)
+
 	require.NoError(t, repo.UpdateStatus(ctx, p.ID, entities.PaymentStatusProcessing))
 	require.NoError(t, repo.UpdateDestTxHash(ctx, p.ID, "0xdtx"))
 	require.NoError(t, repo.MarkRefunded(ctx, p.ID))
@@ -79,6 +112,115 @@ func TestPaymentRepository_BasicFlow(t *testing.T) {
 	require.Equal(t, entities.PaymentStatusRefunded, updated.Status)
 }
 
+func TestPaymentRepository_PendingApproval(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentTables(t, db)
+	createChainTables(t, db)
+	createTokenTable(t, db)
+	repo := NewPaymentRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	chainID := uuid.New()
+	tokenID := uuid.New()
+	newPayment := func(status entities.PaymentStatus, createdAt time.Time) *entities.Payment {
+		p := &entities.Payment{
+			ID:            uuid.New(),
+			SenderID:      &userID,
+			SourceChainID: chainID,
+			DestChainID:   chainID,
+			SourceTokenID: &tokenID,
+			DestTokenID:   &tokenID,
+			SourceAmount:  "100",
+			FeeAmount:     "1",
+			TotalCharged:  "101",
+			Status:        status,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}
+		require.NoError(t, repo.Create(ctx, p))
+		return p
+	}
+	newer := newPayment(entities.PaymentStatusPendingApproval, time.Now())
+	older := newPayment(entities.PaymentStatusPendingApproval, time.Now().Add(-time.Hour))
+	pending := newPayment(entities.PaymentStatusPending, time.Now())
+
+	// Oldest first, so the queue is worked in arrival order
+	queue, total, err := repo.GetByStatus(ctx, entities.PaymentStatusPendingApproval, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, []uuid.UUID{older.ID, newer.ID}, []uuid.UUID{queue[0].ID, queue[1].ID})
+	page, total, err := repo.GetByStatus(ctx, entities.PaymentStatusPendingApproval, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, page, 1)
+	require.Equal(t, newer.ID, page[0].ID)
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, repo.ResolvePendingApproval(ctx, older.ID, entities.PaymentStatusPending, null.String{}, &expiresAt))
+	approved, err := repo.GetByID(ctx, older.ID)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentStatusPending, approved.Status)
+	require.NotNil(t, approved.ExpiresAt)
+	require.True(t, expiresAt.Equal(*approved.ExpiresAt))
+	// A payment can only be reviewed once, and only while it awaits approval
+	require.ErrorIs(t, repo.ResolvePendingApproval(ctx, older.ID, entities.PaymentStatusFailed, null.StringFrom("late"), nil), domainerrors.ErrNotFound)
+	require.ErrorIs(t, repo.ResolvePendingApproval(ctx, pending.ID, entities.PaymentStatusFailed, null.StringFrom("no"), nil), domainerrors.ErrNotFound)
+
+	require.NoError(t, repo.ResolvePendingApproval(ctx, newer.ID, entities.PaymentStatusFailed, null.StringFrom("rejected"), nil))
+	rejected, err := repo.GetByID(ctx, newer.ID)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentStatusFailed, rejected.Status)
+	require.Equal(t, "rejected", rejected.FailureReason.String)
+	_, total, err = repo.GetByStatus(ctx, entities.PaymentStatusPendingApproval, 10, 0)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
+
+func TestPaymentRepository_GetByID_BridgeExplorerURL(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentTables(t, db)
+	createChainTables(t, db)
+	createTokenTable(t, db)
+	createPaymentBridgeTable(t, db)
+	repo := NewPaymentRepository(db)
+	ctx := context.Background()
+
+	bridgeID := uuid.New()
+	mustExec(t, db, `INSERT INTO payment_bridge(id,name,explorer_url_template,created_at,updated_at) VALUES (?,?,?,?,?)`,
+		bridgeID.String(), "CCIP", "https://ccip.chain.link/msg/{messageId}", time.Now(), time.Now())
+
+	userID := uuid.New()
+	sourceTokenID := uuid.New()
+	destTokenID := uuid.New()
+	p := &entities.Payment{
+		ID:            uuid.New(),
+		SenderID:      &userID,
+		BridgeID:      &bridgeID,
+		SourceChainID: uuid.New(),
+		DestChainID:   uuid.New(),
+		SourceTokenID: &sourceTokenID,
+		DestTokenID:   &destTokenID,
+		SourceAmount:  "100",
+		Status:        entities.PaymentStatusProcessing,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, p))
+
+	// No link until the bridge message ID is known
+	got, err := repo.GetByID(ctx, p.ID)
+	require.NoError(t, err)
+	require.Equal(t, "CCIP", got.Bridge.Name)
+	require.Empty(t, got.BridgeExplorerURL)
+
+	got.CrossChainMessageID = null.StringFrom("0xmsg")
+	require.NoError(t, repo.Update(ctx, got))
+	got, err = repo.GetByID(ctx, p.ID)
+	require.NoError(t, err)
+	require.Equal(t, "https://ccip.chain.link/msg/0xmsg", got.BridgeExplorerURL)
+}
+
 func TestPaymentRepository_NotFoundBranches(t *testing.T) {
 	db := newTestDB(t)
 	createPaymentTables(t, db)
@@ -100,10 +242,10 @@ func TestPaymentRepository_List_DBErrorBranches(t *testing.T) {
 	repo := NewPaymentRepository(db)
 	ctx := context.Background()
 
-	_, _, err := repo.GetByUserID(ctx, uuid.New(), 10, 0)
+	_, _, err := repo.GetByUserID(ctx, uuid.New(), entities.PaymentModeLive, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 
-	_, _, err = repo.GetByMerchantID(ctx, uuid.New(), 10, 0)
+	_, _, err = repo.GetByMerchantID(ctx, uuid.New(), entities.PaymentModeLive, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 }
 
@@ -217,10 +359,10 @@ func TestPaymentRepository_List_FindErrorBranches(t *testing.T) {
 		_ = db.Callback().Query().Remove(cbName)
 	})
 
-	_, _, err := repo.GetByUserID(ctx, uuid.New(), 10, 0)
+	_, _, err := repo.GetByUserID(ctx, uuid.New(), entities.PaymentModeLive, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 
 	queryCount = 0
-	_, _, err = repo.GetByMerchantID(ctx, uuid.New(), 10, 0)
+	_, _, err = repo.GetByMerchantID(ctx, uuid.New(), entities.PaymentModeLive, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 }
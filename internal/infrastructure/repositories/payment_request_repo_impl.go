@@ -3,11 +3,14 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/infrastructure/models"
 )
 
@@ -21,7 +24,25 @@ func NewPaymentRequestRepository(db *gorm.DB) *PaymentRequestRepositoryImpl {
 }
 
 func (r *PaymentRequestRepositoryImpl) Create(ctx context.Context, req *entities.PaymentRequest) error {
-	m := &models.PaymentRequest{
+	return r.db.WithContext(ctx).Create(r.toModel(req, time.Now())).Error
+}
+
+func (r *PaymentRequestRepositoryImpl) CreateBatch(ctx context.Context, reqs []*entities.PaymentRequest) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	ms := make([]*models.PaymentRequest, 0, len(reqs))
+	for _, req := range reqs {
+		ms = append(ms, r.toModel(req, now))
+	}
+	return GetDB(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&ms).Error
+	})
+}
+
+func (r *PaymentRequestRepositoryImpl) toModel(req *entities.PaymentRequest, now time.Time) *models.PaymentRequest {
+	return &models.PaymentRequest{
 		ID:            req.ID,
 		MerchantID:    req.MerchantID,
 		ChainID:       req.ChainID,
@@ -30,40 +51,72 @@ func (r *PaymentRequestRepositoryImpl) Create(ctx context.Context, req *entities
 		Amount:        req.Amount,
 		Decimals:      req.Decimals,
 		Description:   req.Description,
+		Metadata:      metadataColumn(req.Metadata),
 		Status:        string(req.Status),
 		ExpiresAt:     req.ExpiresAt,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		CreatedAt:     now,
+		UpdatedAt:     now,
 	}
-	return r.db.WithContext(ctx).Create(m).Error
 }
 
 func (r *PaymentRequestRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.PaymentRequest, error) {
 	var m models.PaymentRequest
 	if err := r.db.WithContext(ctx).
-		Preload("Chain").
-		Preload("Token").
+		Preload("Chain", withDeleted).
+		Preload("Token", withDeleted).
 		Where("id = ?", id).
 		First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrNotFound
+		}
 		return nil, err
 	}
 	return r.toEntity(&m), nil
 }
 
-func (r *PaymentRequestRepositoryImpl) GetByMerchantID(ctx context.Context, merchantID uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error) {
+// paymentRequestSortColumns whitelists the columns GetByMerchantID may order by
+var paymentRequestSortColumns = map[domainrepos.PaymentRequestSortField]string{
+	domainrepos.PaymentRequestSortCreatedAt: "created_at",
+	domainrepos.PaymentRequestSortExpiresAt: "expires_at",
+	domainrepos.PaymentRequestSortAmount:    "amount",
+	domainrepos.PaymentRequestSortStatus:    "status",
+}
+
+func (r *PaymentRequestRepositoryImpl) GetByMerchantID(ctx context.Context, merchantID uuid.UUID, filter domainrepos.PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error) {
+	scoped := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&models.PaymentRequest{}).Where("merchant_id = ?", merchantID)
+		if filter.Status != "" {
+			q = q.Where("status = ?", string(filter.Status))
+		}
+		if filter.CreatedFrom != nil {
+			q = q.Where("created_at >= ?", *filter.CreatedFrom)
+		}
+		if filter.CreatedTo != nil {
+			q = q.Where("created_at < ?", *filter.CreatedTo)
+		}
+		return q
+	}
+
 	var total int64
-	if err := r.db.WithContext(ctx).Model(&models.PaymentRequest{}).
-		Where("merchant_id = ?", merchantID).
-		Count(&total).Error; err != nil {
+	if err := scoped().Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	column, ok := paymentRequestSortColumns[filter.SortBy]
+	if !ok {
+		column = "created_at"
+	}
+	direction := "DESC"
+	if filter.Ascending {
+		direction = "ASC"
+	}
+
 	var ms []models.PaymentRequest
-	if err := r.db.WithContext(ctx).
-		Preload("Chain").
-		Preload("Token").
-		Where("merchant_id = ?", merchantID).
-		Order("created_at DESC").
+	if err := scoped().
+		Preload("Chain", withDeleted).
+		Preload("Token", withDeleted).
+		Order(column + " " + direction).
+		Order("id " + direction).
 		Limit(limit).Offset(offset).
 		Find(&ms).Error; err != nil {
 		return nil, 0, err
@@ -86,6 +139,19 @@ func (r *PaymentRequestRepositoryImpl) UpdateStatus(ctx context.Context, id uuid
 		}).Error
 }
 
+func (r *PaymentRequestRepositoryImpl) CancelPending(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.PaymentRequest{}).
+		Where("id = ? AND status = ?", id, string(entities.PaymentRequestStatusPending)).
+		Updates(map[string]interface{}{
+			"status":     string(entities.PaymentRequestStatusCancelled),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 func (r *PaymentRequestRepositoryImpl) UpdateTxHash(ctx context.Context, id uuid.UUID, txHash, payerAddress string) error {
 	_ = payerAddress
 	return r.db.WithContext(ctx).Model(&models.PaymentRequest{}).
@@ -109,11 +175,42 @@ func (r *PaymentRequestRepositoryImpl) MarkCompleted(ctx context.Context, id uui
 		}).Error
 }
 
+func (r *PaymentRequestRepositoryImpl) MarkProcessing(ctx context.Context, id uuid.UUID, txHash string, blockNumber int64) error {
+	return r.db.WithContext(ctx).Model(&models.PaymentRequest{}).
+		Where("id = ? AND status = ?", id, entities.PaymentRequestStatusPending).
+		Updates(map[string]interface{}{
+			"status":       entities.PaymentRequestStatusProcessing,
+			"tx_hash":      txHash,
+			"block_number": blockNumber,
+			"updated_at":   time.Now(),
+		}).Error
+}
+
+func (r *PaymentRequestRepositoryImpl) GetProcessing(ctx context.Context, limit int) ([]*entities.PaymentRequest, error) {
+	var ms []models.PaymentRequest
+	if err := r.db.WithContext(ctx).
+		Preload("Chain", withDeleted).
+		Preload("Token", withDeleted).
+		Where("status = ?", entities.PaymentRequestStatusProcessing).
+		Order("updated_at ASC").
+		Limit(limit).
+		Find(&ms).Error; err != nil {
+		return nil, err
+	}
+
+	requests := make([]*entities.PaymentRequest, 0, len(ms))
+	for _, m := range ms {
+		model := m
+		requests = append(requests, r.toEntity(&model))
+	}
+	return requests, nil
+}
+
 func (r *PaymentRequestRepositoryImpl) GetExpiredPending(ctx context.Context, limit int) ([]*entities.PaymentRequest, error) {
 	var ms []models.PaymentRequest
 	if err := r.db.WithContext(ctx).
-		Preload("Chain").
-		Preload("Token").
+		Preload("Chain", withDeleted).
+		Preload("Token", withDeleted).
 		Where("status = ? AND expires_at < ?", entities.PaymentRequestStatusPending, time.Now()).
 		Limit(limit).
 		Find(&ms).Error; err != nil {
@@ -128,12 +225,14 @@ func (r *PaymentRequestRepositoryImpl) GetExpiredPending(ctx context.Context, li
 	return requests, nil
 }
 
+// ExpireRequests expires the requests among ids that are still pending. One paid, cancelled or
+// already processing since it was listed keeps its status.
 func (r *PaymentRequestRepositoryImpl) ExpireRequests(ctx context.Context, ids []uuid.UUID) error {
 	if len(ids) == 0 {
 		return nil
 	}
 	return r.db.WithContext(ctx).Model(&models.PaymentRequest{}).
-		Where("id IN ?", ids).
+		Where("id IN ? AND status = ?", ids, entities.PaymentRequestStatusPending).
 		Updates(map[string]interface{}{
 			"status":     entities.PaymentRequestStatusExpired,
 			"updated_at": time.Now(),
@@ -168,9 +267,11 @@ func (r *PaymentRequestRepositoryImpl) toEntity(m *models.PaymentRequest) *entit
 		Amount:        m.Amount,
 		Decimals:      m.Decimals,
 		Description:   m.Description,
+		Metadata:      metadataFromColumn(m.Metadata),
 		Status:        entities.PaymentRequestStatus(m.Status),
 		ExpiresAt:     m.ExpiresAt,
 		TxHash:        m.TxHash,
+		BlockNumber:   m.BlockNumber,
 		CompletedAt:   m.CompletedAt,
 		CreatedAt:     m.CreatedAt,
 		UpdatedAt:     m.UpdatedAt,
@@ -7,8 +7,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
 	"gorm.io/gorm"
 	"payment-kita.backend/internal/domain/entities"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
 )
 
 func TestPaymentRequestRepository_FullFlow(t *testing.T) {
@@ -32,6 +34,7 @@ func TestPaymentRequestRepository_FullFlow(t *testing.T) {
 		Amount:        "10",
 		Decimals:      6,
 		Description:   "test",
+		Metadata:      null.JSONFrom([]byte(`{"customerId":"cus_1"}`)),
 		Status:        entities.PaymentRequestStatusPending,
 		ExpiresAt:     expires,
 	})
@@ -40,8 +43,9 @@ func TestPaymentRequestRepository_FullFlow(t *testing.T) {
 	got, err := repo.GetByID(ctx, id)
 	require.NoError(t, err)
 	require.Equal(t, merchantID, got.MerchantID)
+	require.JSONEq(t, `{"customerId":"cus_1"}`, string(got.Metadata.JSON))
 
-	items, total, err := repo.GetByMerchantID(ctx, merchantID, 10, 0)
+	items, total, err := repo.GetByMerchantID(ctx, merchantID, domainrepos.PaymentRequestFilter{}, 10, 0)
 	require.NoError(t, err)
 	require.Equal(t, 1, total)
 	require.Len(t, items, 1)
@@ -51,25 +55,189 @@ func TestPaymentRequestRepository_FullFlow(t *testing.T) {
 	require.NoError(t, repo.MarkCompleted(ctx, id, "0xtx2"))
 }
 
-func TestPaymentRequestRepository_ExpiredAndBulkExpire(t *testing.T) {
+func TestPaymentRequestRepository_GetByMerchantID_FiltersAndSorts(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentRequestTables(t, db)
+	repo := NewPaymentRequestRepository(db)
+	ctx := context.Background()
+
+	merchantID := uuid.New()
+	day := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	seed := []struct {
+		status  entities.PaymentRequestStatus
+		amount  string
+		created time.Time
+	}{
+		{entities.PaymentRequestStatusPending, "30", day},
+		{entities.PaymentRequestStatusPending, "10", day.AddDate(0, 0, 1)},
+		{entities.PaymentRequestStatusCompleted, "20", day.AddDate(0, 0, 2)},
+		{entities.PaymentRequestStatusExpired, "40", day.AddDate(0, 0, -5)},
+	}
+	for _, s := range seed {
+		id := uuid.New()
+		require.NoError(t, repo.Create(ctx, &entities.PaymentRequest{
+			ID:            id,
+			MerchantID:    merchantID,
+			ChainID:       uuid.New(),
+			TokenID:       uuid.New(),
+			WalletAddress: "0xwallet",
+			Amount:        s.amount,
+			Decimals:      6,
+			Status:        s.status,
+			ExpiresAt:     s.created.Add(time.Hour),
+		}))
+		require.NoError(t, db.Exec("UPDATE payment_requests SET created_at = ? WHERE id = ?", s.created, id).Error)
+	}
+	// Another merchant's request never leaks into the listing.
+	require.NoError(t, repo.Create(ctx, &entities.PaymentRequest{
+		ID: uuid.New(), MerchantID: uuid.New(), ChainID: uuid.New(), TokenID: uuid.New(),
+		WalletAddress: "0xother", Amount: "1", Status: entities.PaymentRequestStatusPending, ExpiresAt: day,
+	}))
+
+	items, total, err := repo.GetByMerchantID(ctx, merchantID, domainrepos.PaymentRequestFilter{
+		Status: entities.PaymentRequestStatusPending,
+	}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, "10", items[0].Amount, "newest first by default")
+
+	from, to := day, day.AddDate(0, 0, 2)
+	items, total, err = repo.GetByMerchantID(ctx, merchantID, domainrepos.PaymentRequestFilter{
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+		SortBy:      domainrepos.PaymentRequestSortAmount,
+		Ascending:   true,
+	}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Equal(t, []string{"10", "30"}, []string{items[0].Amount, items[1].Amount})
+
+	items, total, err = repo.GetByMerchantID(ctx, merchantID, domainrepos.PaymentRequestFilter{}, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 4, total)
+	require.Len(t, items, 1)
+	require.Equal(t, "10", items[0].Amount)
+}
+
+func TestPaymentRequestRepository_CreateBatch(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentRequestTables(t, db)
+	repo := NewPaymentRequestRepository(db)
+	ctx := context.Background()
+	merchantID := uuid.New()
+
+	newRequest := func(id uuid.UUID) *entities.PaymentRequest {
+		return &entities.PaymentRequest{
+			ID: id, MerchantID: merchantID, ChainID: uuid.New(), TokenID: uuid.New(),
+			WalletAddress: "0xwallet", Amount: "1", Decimals: 6,
+			Status: entities.PaymentRequestStatusPending, ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	require.NoError(t, repo.CreateBatch(ctx, nil))
+	require.NoError(t, repo.CreateBatch(ctx, []*entities.PaymentRequest{newRequest(uuid.New()), newRequest(uuid.New())}))
+
+	// A duplicate primary key rolls back the whole batch.
+	dup := uuid.New()
+	require.Error(t, repo.CreateBatch(ctx, []*entities.PaymentRequest{newRequest(uuid.New()), newRequest(dup), newRequest(dup)}))
+
+	_, total, err := repo.GetByMerchantID(ctx, merchantID, domainrepos.PaymentRequestFilter{}, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+}
+
+func TestPaymentRequestRepository_CancelPending(t *testing.T) {
 	db := newTestDB(t)
 	createPaymentRequestTables(t, db)
 	repo := NewPaymentRequestRepository(db)
 	ctx := context.Background()
 
 	id := uuid.New()
-	mustExec(t, db, `INSERT INTO payment_requests(
-		id,merchant_id,chain_id,token_id,wallet_address,amount,decimals,description,status,expires_at,created_at,updated_at
-	) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)`,
-		id.String(), uuid.NewString(), uuid.NewString(), uuid.NewString(), "0xw", "1", 6, "",
-		string(entities.PaymentRequestStatusPending), time.Now().Add(-time.Hour), time.Now(), time.Now())
+	require.NoError(t, repo.Create(ctx, &entities.PaymentRequest{
+		ID: id, MerchantID: uuid.New(), ChainID: uuid.New(), TokenID: uuid.New(),
+		WalletAddress: "0xwallet", Amount: "1", Decimals: 6,
+		Status: entities.PaymentRequestStatusPending, ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	cancelled, err := repo.CancelPending(ctx, id)
+	require.NoError(t, err)
+	require.True(t, cancelled)
+
+	got, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentRequestStatusCancelled, got.Status)
+
+	// Only pending requests can be cancelled.
+	cancelled, err = repo.CancelPending(ctx, id)
+	require.NoError(t, err)
+	require.False(t, cancelled)
+}
+
+func TestPaymentRequestRepository_MarkProcessingAndGetProcessing(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentRequestTables(t, db)
+	repo := NewPaymentRequestRepository(db)
+	ctx := context.Background()
+
+	id := uuid.New()
+	require.NoError(t, repo.Create(ctx, &entities.PaymentRequest{
+		ID: id, MerchantID: uuid.New(), ChainID: uuid.New(), TokenID: uuid.New(),
+		WalletAddress: "0xwallet", Amount: "1", Decimals: 6,
+		Status: entities.PaymentRequestStatusPending, ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	require.NoError(t, repo.MarkProcessing(ctx, id, "0xtx", 100))
+	processing, err := repo.GetProcessing(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, processing, 1)
+	require.Equal(t, entities.PaymentRequestStatusProcessing, processing[0].Status)
+	require.Equal(t, "0xtx", processing[0].TxHash)
+	require.Equal(t, int64(100), processing[0].BlockNumber)
+
+	// Only a pending request is held; a completed one stays completed
+	require.NoError(t, repo.MarkCompleted(ctx, id, "0xtx"))
+	require.NoError(t, repo.MarkProcessing(ctx, id, "0xother", 200))
+	got, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentRequestStatusCompleted, got.Status)
+	processing, err = repo.GetProcessing(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, processing)
+}
+
+func TestPaymentRequestRepository_ExpiredAndBulkExpire(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentRequestTables(t, db)
+	repo := NewPaymentRequestRepository(db)
+	ctx := context.Background()
+
+	insert := func(status entities.PaymentRequestStatus) uuid.UUID {
+		id := uuid.New()
+		mustExec(t, db, `INSERT INTO payment_requests(
+			id,merchant_id,chain_id,token_id,wallet_address,amount,decimals,description,status,expires_at,created_at,updated_at
+		) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)`,
+			id.String(), uuid.NewString(), uuid.NewString(), uuid.NewString(), "0xw", "1", 6, "",
+			string(status), time.Now().Add(-time.Hour), time.Now(), time.Now())
+		return id
+	}
+	id := insert(entities.PaymentRequestStatusPending)
 
 	expired, err := repo.GetExpiredPending(ctx, 10)
 	require.NoError(t, err)
 	require.Len(t, expired, 1)
 
-	require.NoError(t, repo.ExpireRequests(ctx, []uuid.UUID{id}))
+	// A request paid after it was listed is not expired over its payment
+	paid := insert(entities.PaymentRequestStatusPending)
+	require.NoError(t, repo.MarkCompleted(ctx, paid, "0xtx"))
+
+	require.NoError(t, repo.ExpireRequests(ctx, []uuid.UUID{id, paid}))
 	require.NoError(t, repo.ExpireRequests(ctx, nil))
+	got, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentRequestStatusExpired, got.Status)
+	got, err = repo.GetByID(ctx, paid)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentRequestStatusCompleted, got.Status)
 }
 
 func TestPaymentRequestRepository_GetByID_NotFound(t *testing.T) {
@@ -149,7 +317,7 @@ func TestPaymentRequestRepository_GetByMerchantID_DBError(t *testing.T) {
 	// Intentionally skip table creation.
 	repo := NewPaymentRequestRepository(db)
 
-	_, _, err := repo.GetByMerchantID(context.Background(), uuid.New(), 10, 0)
+	_, _, err := repo.GetByMerchantID(context.Background(), uuid.New(), domainrepos.PaymentRequestFilter{}, 10, 0)
 	require.Error(t, err)
 }
 
@@ -172,7 +340,7 @@ func TestPaymentRequestRepository_GetByMerchantID_FindErrorAfterCount(t *testing
 		_ = db.Callback().Query().Remove(cbName)
 	})
 
-	_, _, err := repo.GetByMerchantID(context.Background(), uuid.New(), 10, 0)
+	_, _, err := repo.GetByMerchantID(context.Background(), uuid.New(), domainrepos.PaymentRequestFilter{}, 10, 0)
 	require.Error(t, err)
 }
 
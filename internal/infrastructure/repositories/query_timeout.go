@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const queryTimeoutSetting = "query_timeout:parent"
+
+// queryTimeoutState is what the before hook leaves for the after hook on the statement
+type queryTimeoutState struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// UseQueryTimeout gives every create, query, update, delete and raw exec on db a deadline of
+// timeout when its context has none, so a caller passing context.Background() cannot hold a
+// pooled connection forever. Contexts that already have a deadline are left alone. Row, Rows
+// and Scan are not covered, since their rows are read after the callbacks return; the
+// connection's statement_timeout still bounds them. A timeout of zero or less does nothing.
+func UseQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+	before := func(tx *gorm.DB) {
+		parent := tx.Statement.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+		if _, ok := parent.Deadline(); ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		tx.Statement.Context = ctx
+		tx.Statement.Settings.Store(queryTimeoutSetting, queryTimeoutState{parent: parent, cancel: cancel})
+	}
+	after := func(tx *gorm.DB) {
+		value, ok := tx.Statement.Settings.LoadAndDelete(queryTimeoutSetting)
+		if !ok {
+			return
+		}
+		state := value.(queryTimeoutState)
+		state.cancel()
+		// A chained *gorm.DB reuses its statement, so the next call must not inherit the
+		// cancelled context
+		tx.Statement.Context = state.parent
+	}
+
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("*").Register("query_timeout:before", before),
+		callbacks.Create().After("*").Register("query_timeout:after", after),
+		callbacks.Query().Before("*").Register("query_timeout:before", before),
+		callbacks.Query().After("*").Register("query_timeout:after", after),
+		callbacks.Update().Before("*").Register("query_timeout:before", before),
+		callbacks.Update().After("*").Register("query_timeout:after", after),
+		callbacks.Delete().Before("*").Register("query_timeout:before", before),
+		callbacks.Delete().After("*").Register("query_timeout:after", after),
+		callbacks.Raw().Before("*").Register("query_timeout:before", before),
+		callbacks.Raw().After("*").Register("query_timeout:after", after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
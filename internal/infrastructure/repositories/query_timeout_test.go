@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestUseQueryTimeout(t *testing.T) {
+	db := newTestDB(t)
+	mustExec(t, db, `CREATE TABLE timeout_rows (id INTEGER PRIMARY KEY, name TEXT)`)
+	require.NoError(t, UseQueryTimeout(db, time.Minute))
+
+	var deadlines []time.Time
+	record := func(tx *gorm.DB) {
+		deadline, _ := tx.Statement.Context.Deadline()
+		deadlines = append(deadlines, deadline)
+	}
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:deadline", record))
+	require.NoError(t, db.Callback().Create().Before("gorm:create").Register("test:deadline", record))
+
+	type timeoutRow struct {
+		ID   int
+		Name string
+	}
+	ctx := context.Background()
+	require.NoError(t, db.WithContext(ctx).Table("timeout_rows").Create(&timeoutRow{ID: 1, Name: "a"}).Error)
+
+	// A chained query reuses its statement: Find must not see the context Count cancelled
+	query := db.WithContext(ctx).Table("timeout_rows").Where("name = ?", "a")
+	var total int64
+	require.NoError(t, query.Count(&total).Error)
+	var rows []timeoutRow
+	require.NoError(t, query.Find(&rows).Error)
+	require.Equal(t, int64(1), total)
+	require.Len(t, rows, 1)
+
+	require.Len(t, deadlines, 3)
+	for _, deadline := range deadlines {
+		require.False(t, deadline.IsZero())
+		require.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+	}
+
+	// A caller's own deadline is kept
+	callerCtx, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+	callerDeadline, _ := callerCtx.Deadline()
+	require.NoError(t, db.WithContext(callerCtx).Table("timeout_rows").Find(&rows).Error)
+	require.Equal(t, callerDeadline, deadlines[len(deadlines)-1])
+
+	plain := newTestDB(t)
+	require.NoError(t, UseQueryTimeout(plain, 0))
+	require.Nil(t, plain.Callback().Query().Get("query_timeout:before"))
+}
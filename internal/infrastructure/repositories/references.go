@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// tableReference is a column in another table that points at a row about to be hard-deleted
+type tableReference struct {
+	table  string
+	column string
+}
+
+// paymentTokenReferences are the columns that keep a token's history and fee config readable
+var paymentTokenReferences = []tableReference{
+	{table: "payments", column: "source_token_id"},
+	{table: "payments", column: "dest_token_id"},
+	{table: "payment_requests", column: "token_id"},
+	{table: "fee_configs", column: "token_id"},
+}
+
+// paymentChainReferences are the columns that keep a chain's history and config readable
+var paymentChainReferences = []tableReference{
+	{table: "payments", column: "source_chain_id"},
+	{table: "payments", column: "dest_chain_id"},
+	{table: "payment_requests", column: "chain_id"},
+	{table: "payment_events", column: "chain_id"},
+	{table: "tokens", column: "chain_id"},
+	{table: "smart_contracts", column: "chain_id"},
+	{table: "wallets", column: "chain_id"},
+	{table: "bridge_configs", column: "source_chain_id"},
+	{table: "bridge_configs", column: "dest_chain_id"},
+	{table: "fee_configs", column: "chain_id"},
+	// Route policies and Stargate configs are ON DELETE CASCADE, so a hard delete would drop a
+	// route's config without a word; they block it like every other reference
+	{table: "route_policies", column: "source_chain_id"},
+	{table: "route_policies", column: "dest_chain_id"},
+	{table: "stargate_configs", column: "source_chain_id"},
+	{table: "stargate_configs", column: "dest_chain_id"},
+}
+
+// checkNoReferences returns a *domainerrors.ReferencedError when any of refs points at id.
+// Soft-deleted referencing rows count too, since a hard delete would orphan them as well.
+func checkNoReferences(tx *gorm.DB, refs []tableReference, id interface{}) error {
+	blocking := make(map[string]int64)
+	for _, ref := range refs {
+		var count int64
+		if err := tx.Table(ref.table).Where(ref.column+" = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			blocking[ref.table+"."+ref.column] = count
+		}
+	}
+	if len(blocking) > 0 {
+		return &domainerrors.ReferencedError{References: blocking}
+	}
+	return nil
+}
+
+// withDeleted preloads an association even when it was soft-deleted, so records that point at a
+// removed token or chain still show it
+func withDeleted(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
@@ -27,6 +27,7 @@ func createPaymentBridgeTable(t *testing.T, db *gorm.DB) {
 	mustExec(t, db, `CREATE TABLE payment_bridge (
 		id TEXT PRIMARY KEY,
 		name TEXT UNIQUE NOT NULL,
+		explorer_url_template TEXT,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -45,6 +46,8 @@ func createMerchantTable(t *testing.T, db *gorm.DB) {
 		business_address TEXT,
 		documents TEXT,
 		fee_discount_percent TEXT,
+		fee_exempt BOOLEAN DEFAULT false,
+		min_confirmations INTEGER NOT NULL DEFAULT 0,
 		callback_url TEXT,
 		webhook_secret TEXT,
 		webhook_is_active BOOLEAN,
@@ -84,6 +87,8 @@ func createUserTable(t *testing.T, db *gorm.DB) {
 		kyc_verified_at DATETIME,
 		password_hash TEXT,
 		is_email_verified BOOLEAN,
+		fee_exempt BOOLEAN DEFAULT false,
+		suspended_at DATETIME,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -120,9 +125,13 @@ func createChainTables(t *testing.T, db *gorm.DB) {
 		currency_symbol TEXT,
 		image_url TEXT,
 		is_active BOOLEAN,
+		is_testnet BOOLEAN NOT NULL DEFAULT FALSE,
 		state_machine_id TEXT,
 		ccip_chain_selector TEXT,
 		stargate_eid INTEGER,
+		min_confirmations INTEGER NOT NULL DEFAULT 0,
+		explorer_api_url TEXT,
+		explorer_api_key TEXT,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -169,8 +178,10 @@ func createTokenTable(t *testing.T, db *gorm.DB) {
 		is_active BOOLEAN,
 		is_native BOOLEAN,
 		is_stablecoin BOOLEAN,
+		has_transfer_fee BOOLEAN NOT NULL DEFAULT FALSE,
 		min_amount TEXT,
 		max_amount TEXT,
+		approval_threshold TEXT,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -222,6 +233,7 @@ func createRoutePolicyTables(t *testing.T, db *gorm.DB) {
 		overhead_bytes TEXT,
 		min_fee TEXT,
 		max_fee TEXT,
+		fallback_bridge_fee TEXT,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -258,7 +270,11 @@ func createPaymentTables(t *testing.T, db *gorm.DB) {
 		total_charged TEXT,
 		sender_address TEXT,
 		dest_address TEXT,
+		receiver_name TEXT,
+		external_ref TEXT,
+		metadata TEXT,
 		status TEXT NOT NULL,
+		mode TEXT NOT NULL DEFAULT 'live',
 		source_tx_hash TEXT,
 		dest_tx_hash TEXT,
 		refund_tx_hash TEXT,
@@ -278,7 +294,9 @@ func createPaymentTables(t *testing.T, db *gorm.DB) {
 		chain TEXT,
 		tx_hash TEXT,
 		block_number INTEGER,
+		confirmations INTEGER NOT NULL DEFAULT 0,
 		metadata TEXT,
+		details TEXT,
 		created_at DATETIME
 	);`)
 }
@@ -367,6 +385,7 @@ func createSmartContractTable(t *testing.T, db *gorm.DB) {
 		metadata TEXT,
 		is_active BOOLEAN,
 		destination_map TEXT,
+		indexed_events TEXT NOT NULL DEFAULT '{}',
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -384,9 +403,13 @@ func createPaymentRequestTables(t *testing.T, db *gorm.DB) {
 		currency_symbol TEXT,
 		image_url TEXT,
 		is_active BOOLEAN,
+		is_testnet BOOLEAN NOT NULL DEFAULT FALSE,
 		state_machine_id TEXT,
 		ccip_chain_selector TEXT,
 		stargate_eid INTEGER,
+		min_confirmations INTEGER NOT NULL DEFAULT 0,
+		explorer_api_url TEXT,
+		explorer_api_key TEXT,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -403,8 +426,10 @@ func createPaymentRequestTables(t *testing.T, db *gorm.DB) {
 		is_active BOOLEAN,
 		is_native BOOLEAN,
 		is_stablecoin BOOLEAN,
+		has_transfer_fee BOOLEAN NOT NULL DEFAULT FALSE,
 		min_amount TEXT,
 		max_amount TEXT,
+		approval_threshold TEXT,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -421,8 +446,10 @@ func createPaymentRequestTables(t *testing.T, db *gorm.DB) {
 		status TEXT NOT NULL,
 		expires_at DATETIME NOT NULL,
 		tx_hash TEXT,
+		block_number INTEGER,
 		payer_address TEXT,
 		payment_code TEXT,
+		metadata TEXT,
 		completed_at DATETIME,
 		created_at DATETIME,
 		updated_at DATETIME,
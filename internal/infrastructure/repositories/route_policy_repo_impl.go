@@ -53,16 +53,19 @@ func (r *routePolicyRepo) GetByRoute(ctx context.Context, sourceChainID, destCha
 	return toRoutePolicyEntity(&row), nil
 }
 
-func (r *routePolicyRepo) List(ctx context.Context, sourceChainID, destChainID *uuid.UUID, pagination utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+func (r *routePolicyRepo) List(ctx context.Context, filter domainrepos.RoutePolicyFilter, pagination utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
 	var rows []models.RoutePolicy
 	var total int64
 
 	query := r.db.WithContext(ctx).Model(&models.RoutePolicy{})
-	if sourceChainID != nil {
-		query = query.Where("source_chain_id = ?", *sourceChainID)
+	if filter.SourceChainID != nil {
+		query = query.Where("source_chain_id = ?", *filter.SourceChainID)
 	}
-	if destChainID != nil {
-		query = query.Where("dest_chain_id = ?", *destChainID)
+	if filter.DestChainID != nil {
+		query = query.Where("dest_chain_id = ?", *filter.DestChainID)
+	}
+	if filter.DefaultBridgeType != nil {
+		query = query.Where("default_bridge_type = ?", int16(*filter.DefaultBridgeType))
 	}
 
 	if err := query.Count(&total).Error; err != nil {
@@ -84,6 +87,41 @@ func (r *routePolicyRepo) List(ctx context.Context, sourceChainID, destChainID *
 }
 
 func (r *routePolicyRepo) Create(ctx context.Context, policy *entities.RoutePolicy) error {
+	return r.db.WithContext(ctx).Create(toRoutePolicyModel(policy)).Error
+}
+
+func (r *routePolicyRepo) CreateMissing(ctx context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+	created := make([]bool, len(policies))
+	err := GetDB(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, policy := range policies {
+			var existing int64
+			if err := tx.Model(&models.RoutePolicy{}).
+				Where("source_chain_id = ? AND dest_chain_id = ?", policy.SourceChainID, policy.DestChainID).
+				Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing > 0 {
+				continue
+			}
+			if err := tx.Create(toRoutePolicyModel(policy)).Error; err != nil {
+				return err
+			}
+			created[i] = true
+		}
+		return nil
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			// A concurrent request created one of the routes first
+			return nil, domainerrors.ErrAlreadyExists
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+// toRoutePolicyModel assigns policy an ID when it has none and returns its row
+func toRoutePolicyModel(policy *entities.RoutePolicy) *models.RoutePolicy {
 	if policy.ID == uuid.Nil {
 		policy.ID = utils.GenerateUUIDv7()
 	}
@@ -94,7 +132,7 @@ func (r *routePolicyRepo) Create(ctx context.Context, policy *entities.RoutePoli
 		mode = string(entities.BridgeFallbackModeStrict)
 	}
 
-	row := &models.RoutePolicy{
+	return &models.RoutePolicy{
 		ID:                     policy.ID,
 		SourceChainID:          policy.SourceChainID,
 		DestChainID:            policy.DestChainID,
@@ -110,10 +148,10 @@ func (r *routePolicyRepo) Create(ctx context.Context, policy *entities.RoutePoli
 		OverheadBytes:          nullableNumeric(policy.OverheadBytes),
 		MinFee:                 nullableNumeric(policy.MinFee),
 		MaxFee:                 nullableNumeric(policy.MaxFee),
+		FallbackBridgeFee:      nullableNumeric(policy.FallbackBridgeFee),
 		CreatedAt:              time.Now(),
 		UpdatedAt:              time.Now(),
 	}
-	return r.db.WithContext(ctx).Create(row).Error
 }
 
 func (r *routePolicyRepo) Update(ctx context.Context, policy *entities.RoutePolicy) error {
@@ -140,6 +178,7 @@ func (r *routePolicyRepo) Update(ctx context.Context, policy *entities.RoutePoli
 			"overhead_bytes":           nullableNumeric(policy.OverheadBytes),
 			"min_fee":                  nullableNumeric(policy.MinFee),
 			"max_fee":                  nullableNumeric(policy.MaxFee),
+			"fallback_bridge_fee":      nullableNumeric(policy.FallbackBridgeFee),
 			"updated_at":               time.Now(),
 		})
 	if result.Error != nil {
@@ -179,6 +218,7 @@ func toRoutePolicyEntity(m *models.RoutePolicy) *entities.RoutePolicy {
 		OverheadBytes:          derefString(m.OverheadBytes),
 		MinFee:                 derefString(m.MinFee),
 		MaxFee:                 derefString(m.MaxFee),
+		FallbackBridgeFee:      derefString(m.FallbackBridgeFee),
 		CreatedAt:              m.CreatedAt,
 		UpdatedAt:              m.UpdatedAt,
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -30,6 +31,7 @@ func TestRoutePolicyRepo_UpdateAndListBranches(t *testing.T) {
 		overhead_bytes TEXT,
 		min_fee TEXT,
 		max_fee TEXT,
+		fallback_bridge_fee TEXT,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -59,6 +61,7 @@ func TestRoutePolicyRepo_UpdateAndListBranches(t *testing.T) {
 	policy.SupportsPrivacyForward = true
 	policy.BridgeToken = "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"
 	policy.Status = "paused"
+	policy.FallbackBridgeFee = "250000000000000"
 	require.NoError(t, repo.Update(ctx, policy))
 
 	got, err := repo.GetByID(ctx, policy.ID)
@@ -71,6 +74,7 @@ func TestRoutePolicyRepo_UpdateAndListBranches(t *testing.T) {
 	require.True(t, got.SupportsPrivacyForward)
 	require.Equal(t, "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913", got.BridgeToken)
 	require.Equal(t, "paused", got.Status)
+	require.Equal(t, "250000000000000", got.FallbackBridgeFee)
 
 	// Update not found branch.
 	missing := &entities.RoutePolicy{
@@ -85,11 +89,20 @@ func TestRoutePolicyRepo_UpdateAndListBranches(t *testing.T) {
 	require.ErrorIs(t, err, domainerrors.ErrNotFound)
 
 	// List with filters and pagination.
-	items, total, err := repo.List(ctx, &sourceID, &destID, utils.PaginationParams{Page: 1, Limit: 10})
+	items, total, err := repo.List(ctx, domainrepos.RoutePolicyFilter{SourceChainID: &sourceID, DestChainID: &destID}, utils.PaginationParams{Page: 1, Limit: 10})
 	require.NoError(t, err)
 	require.Equal(t, int64(1), total)
 	require.Len(t, items, 1)
 
+	bridgeType := uint8(2)
+	_, total, err = repo.List(ctx, domainrepos.RoutePolicyFilter{DefaultBridgeType: &bridgeType}, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	bridgeType = 1
+	_, total, err = repo.List(ctx, domainrepos.RoutePolicyFilter{DefaultBridgeType: &bridgeType}, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), total)
+
 	// Soft delete and not found branch in GetByRoute.
 	mustExec(t, db, `UPDATE route_policies SET deleted_at = ? WHERE id = ?`, time.Now(), policy.ID.String())
 	_, err = repo.GetByRoute(ctx, sourceID, destID)
@@ -131,6 +144,7 @@ func TestRoutePolicyRepo_DeleteAndGetByIDBranches(t *testing.T) {
 		overhead_bytes TEXT,
 		min_fee TEXT,
 		max_fee TEXT,
+		fallback_bridge_fee TEXT,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -167,7 +181,7 @@ func TestRoutePolicyRepo_List_DBErrorBranch(t *testing.T) {
 	repo := NewRoutePolicyRepository(db)
 	ctx := context.Background()
 
-	_, _, err := repo.List(ctx, nil, nil, utils.PaginationParams{Page: 1, Limit: 10})
+	_, _, err := repo.List(ctx, domainrepos.RoutePolicyFilter{}, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 }
 
@@ -189,6 +203,7 @@ func TestRoutePolicyRepo_Create_DefaultsAndDBErrorBranches(t *testing.T) {
 		overhead_bytes TEXT,
 		min_fee TEXT,
 		max_fee TEXT,
+		fallback_bridge_fee TEXT,
 		created_at DATETIME,
 		updated_at DATETIME,
 		deleted_at DATETIME
@@ -227,3 +242,32 @@ func TestRoutePolicyRepo_Create_DefaultsAndDBErrorBranches(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestRoutePolicyRepo_CreateMissing(t *testing.T) {
+	db := newTestDB(t)
+	createRoutePolicyTables(t, db)
+	repo := NewRoutePolicyRepository(db)
+	ctx := context.Background()
+
+	base, arbitrum, polygon := uuid.New(), uuid.New(), uuid.New()
+	existing := &entities.RoutePolicy{SourceChainID: base, DestChainID: arbitrum, DefaultBridgeType: 0, FallbackOrder: []uint8{0}}
+	require.NoError(t, repo.Create(ctx, existing))
+
+	created, err := repo.CreateMissing(ctx, []*entities.RoutePolicy{
+		{SourceChainID: base, DestChainID: arbitrum, DefaultBridgeType: 1, FallbackOrder: []uint8{1}},
+		{SourceChainID: base, DestChainID: polygon, DefaultBridgeType: 1, FallbackOrder: []uint8{1}},
+		{SourceChainID: polygon, DestChainID: base, DefaultBridgeType: 1, FallbackOrder: []uint8{1}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []bool{false, true, true}, created)
+
+	// The existing route keeps its policy
+	got, err := repo.GetByRoute(ctx, base, arbitrum)
+	require.NoError(t, err)
+	require.Equal(t, existing.ID, got.ID)
+	require.Equal(t, uint8(0), got.DefaultBridgeType)
+
+	_, total, err := repo.List(ctx, domainrepos.RoutePolicyFilter{}, utils.PaginationParams{})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+}
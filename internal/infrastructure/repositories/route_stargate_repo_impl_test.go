@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -40,7 +41,7 @@ func TestRoutePolicyRepository_CRUDAndNotFound(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, id, byRoute.ID)
 
-	items, total, err := repo.List(ctx, &sourceID, &destID, utils.PaginationParams{Page: 1, Limit: 10})
+	items, total, err := repo.List(ctx, domainrepos.RoutePolicyFilter{SourceChainID: &sourceID, DestChainID: &destID}, utils.PaginationParams{Page: 1, Limit: 10})
 	require.NoError(t, err)
 	require.Equal(t, int64(1), total)
 	require.Len(t, items, 1)
@@ -144,7 +145,7 @@ func TestRouteAndStargateRepository_DBErrorBranches(t *testing.T) {
 	require.Error(t, err)
 	_, err = routeRepo.GetByRoute(ctx, uuid.New(), uuid.New())
 	require.Error(t, err)
-	_, _, err = routeRepo.List(ctx, nil, nil, utils.PaginationParams{Page: 1, Limit: 10})
+	_, _, err = routeRepo.List(ctx, domainrepos.RoutePolicyFilter{}, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 	err = routeRepo.Delete(ctx, uuid.New())
 	require.Error(t, err)
@@ -0,0 +1,200 @@
+package repositories
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/pkg/utils"
+)
+
+// createSingleActiveContractIndex mirrors migration 000055
+func createSingleActiveContractIndex(t *testing.T, db *gorm.DB) {
+	mustExec(t, db, `CREATE UNIQUE INDEX uq_smart_contracts_single_active ON smart_contracts(chain_id, type)
+	WHERE is_active AND deleted_at IS NULL AND type NOT IN ('POOL', 'DEX_POOL', 'MOCK')`)
+}
+
+func insertContract(t *testing.T, db *gorm.DB, chainID uuid.UUID, contractType string, active bool) uuid.UUID {
+	t.Helper()
+	id := uuid.New()
+	mustExec(t, db, `INSERT INTO smart_contracts (id,name,type,version,chain_id,address,deployer_address,is_active,abi,metadata,start_block,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		id.String(), contractType, contractType, "1.0.0", chainID.String(), "0x"+id.String()[:8], "", active, "[]", "{}", 0, time.Now(), time.Now())
+	return id
+}
+
+func activeContractIDs(t *testing.T, repo *SmartContractRepositoryImpl, chainID uuid.UUID, contractType entities.SmartContractType) []uuid.UUID {
+	t.Helper()
+	filter := domainrepos.ActiveContracts(&chainID)
+	filter.Type = contractType
+	items, _, err := repo.GetFiltered(context.Background(), filter, utils.PaginationParams{})
+	require.NoError(t, err)
+	ids := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestSmartContractRepository_Activate(t *testing.T) {
+	db := newTestDB(t)
+	createSmartContractTable(t, db)
+	createSingleActiveContractIndex(t, db)
+	repo := NewSmartContractRepository(db, &stubChainRepo{})
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	oldGateway := insertContract(t, db, chainID, "GATEWAY", true)
+	newGateway := insertContract(t, db, chainID, "GATEWAY", false)
+	router := insertContract(t, db, chainID, "ROUTER", true)
+
+	activated, err := repo.Activate(ctx, newGateway)
+	require.NoError(t, err)
+	require.True(t, activated.IsActive)
+	require.Equal(t, []uuid.UUID{newGateway}, activeContractIDs(t, repo, chainID, entities.ContractTypeGateway))
+	require.Equal(t, []uuid.UUID{router}, activeContractIDs(t, repo, chainID, entities.ContractTypeRouter))
+
+	old, err := repo.GetByID(ctx, oldGateway)
+	require.NoError(t, err)
+	require.False(t, old.IsActive)
+
+	// Activating the already-active contract is a no-op.
+	_, err = repo.Activate(ctx, newGateway)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{newGateway}, activeContractIDs(t, repo, chainID, entities.ContractTypeGateway))
+
+	_, err = repo.Activate(ctx, uuid.New())
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
+
+	// Pools are not single-active.
+	poolA := insertContract(t, db, chainID, "POOL", true)
+	poolB := insertContract(t, db, chainID, "POOL", false)
+	_, err = repo.Activate(ctx, poolB)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{poolA, poolB}, activeContractIDs(t, repo, chainID, entities.ContractTypePool))
+}
+
+func TestSmartContractRepository_CreateAndUpdateKeepSingleActive(t *testing.T) {
+	db := newTestDB(t)
+	createSmartContractTable(t, db)
+	createSingleActiveContractIndex(t, db)
+	repo := NewSmartContractRepository(db, &stubChainRepo{})
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	first := insertContract(t, db, chainID, "GATEWAY", true)
+
+	second := &entities.SmartContract{
+		ID:              uuid.New(),
+		Name:            "Gateway V2",
+		Type:            entities.ContractTypeGateway,
+		Version:         "2.0.0",
+		ChainUUID:       chainID,
+		ContractAddress: "0xv2",
+		IsActive:        true,
+	}
+	require.NoError(t, repo.Create(ctx, second))
+	require.Equal(t, []uuid.UUID{second.ID}, activeContractIDs(t, repo, chainID, entities.ContractTypeGateway))
+
+	rollback, err := repo.GetByID(ctx, first)
+	require.NoError(t, err)
+	rollback.IsActive = true
+	require.NoError(t, repo.Update(ctx, rollback))
+	require.Equal(t, []uuid.UUID{first}, activeContractIDs(t, repo, chainID, entities.ContractTypeGateway))
+}
+
+func TestSmartContractRepository_BulkSetActive_RejectsTwoActiveInSameGroup(t *testing.T) {
+	db := newTestDB(t)
+	createSmartContractTable(t, db)
+	createSingleActiveContractIndex(t, db)
+	repo := NewSmartContractRepository(db, &stubChainRepo{})
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	current := insertContract(t, db, chainID, "GATEWAY", true)
+	a := insertContract(t, db, chainID, "GATEWAY", false)
+	b := insertContract(t, db, chainID, "GATEWAY", false)
+
+	_, err := repo.BulkSetActive(ctx, []uuid.UUID{a, b}, true)
+	require.ErrorIs(t, err, domainerrors.ErrAlreadyExists)
+	require.Equal(t, []uuid.UUID{current}, activeContractIDs(t, repo, chainID, entities.ContractTypeGateway))
+
+	_, err = repo.BulkSetActive(ctx, []uuid.UUID{a}, true)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{a}, activeContractIDs(t, repo, chainID, entities.ContractTypeGateway))
+}
+
+func TestSmartContractRepository_Activate_ConcurrentActivationsLeaveOneActive(t *testing.T) {
+	// A file database with immediate transactions gives real writer contention, unlike the
+	// shared-cache memory database which fails fast with "table is locked".
+	dsn := filepath.Join(t.TempDir(), "contracts.db") + "?_busy_timeout=10000&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	createSmartContractTable(t, db)
+	createSingleActiveContractIndex(t, db)
+	repo := NewSmartContractRepository(db, &stubChainRepo{})
+
+	chainID := uuid.New()
+	ids := make([]uuid.UUID, 8)
+	for i := range ids {
+		ids[i] = insertContract(t, db, chainID, "GATEWAY", i == 0)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(ids)*3)
+	for round := 0; round < 3; round++ {
+		for _, id := range ids {
+			wg.Add(1)
+			go func(id uuid.UUID) {
+				defer wg.Done()
+				_, err := repo.Activate(context.Background(), id)
+				errs <- err
+			}(id)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Len(t, activeContractIDs(t, repo, chainID, entities.ContractTypeGateway), 1)
+
+	// The index is the backstop for writers that bypass Activate.
+	err = db.Exec(`UPDATE smart_contracts SET is_active = ? WHERE chain_id = ? AND type = ?`, true, chainID.String(), "GATEWAY").Error
+	require.True(t, isUniqueViolation(err))
+}
+
+func TestSmartContractRepository_Create_RejectsDuplicateChainAddressType(t *testing.T) {
+	db := newTestDB(t)
+	createSmartContractTable(t, db)
+	repo := NewSmartContractRepository(db, &stubChainRepo{})
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	newContract := func(contractType entities.SmartContractType, address string) *entities.SmartContract {
+		return &entities.SmartContract{ID: uuid.New(), Name: string(contractType), Type: contractType, Version: "1.0.0", ChainUUID: chainID, ContractAddress: address, IsActive: true}
+	}
+
+	gateway := newContract(entities.ContractTypeGateway, "0x00000000000000000000000000000000000000Aa")
+	require.NoError(t, repo.Create(ctx, gateway))
+
+	err := repo.Create(ctx, newContract(entities.ContractTypeGateway, "0x00000000000000000000000000000000000000aa"))
+	var exists *domainerrors.AlreadyExistsError
+	require.ErrorAs(t, err, &exists)
+	require.Equal(t, gateway.ID.String(), exists.ID)
+	// The rejected create must not have deactivated the existing gateway
+	require.Equal(t, []uuid.UUID{gateway.ID}, activeContractIDs(t, repo, chainID, entities.ContractTypeGateway))
+
+	// The same address under another type is a separate registration
+	require.NoError(t, repo.Create(ctx, newContract(entities.ContractTypeRouter, gateway.ContractAddress)))
+}
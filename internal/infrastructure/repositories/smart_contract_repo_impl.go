@@ -3,12 +3,15 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/volatiletech/null/v8" // Added import
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
@@ -16,6 +19,14 @@ import (
 	"payment-kita.backend/pkg/utils"
 )
 
+// multiActiveContractTypes may have several active rows per chain. Every other type has at most
+// one, enforced by uq_smart_contracts_single_active (migration 000055).
+var multiActiveContractTypes = map[string]bool{
+	string(entities.ContractTypePool): true,
+	"DEX_POOL":                        true,
+	string(entities.ContractTypeMock): true,
+}
+
 type SmartContractRepositoryImpl struct {
 	db        *gorm.DB
 	chainRepo repositories.ChainRepository
@@ -63,21 +74,55 @@ func (r *SmartContractRepositoryImpl) Create(ctx context.Context, contract *enti
 		Metadata:        metadataStr,
 		IsActive:        contract.IsActive,
 		DestinationMap:  pq.StringArray{},
+		IndexedEvents:   indexedEventsArray(contract.IndexedEvents),
 		CreatedAt:       contract.CreatedAt,
 		UpdatedAt:       contract.UpdatedAt,
 	}
 
-	if err := r.db.WithContext(ctx).Create(m).Error; err != nil {
-		return err
+	err = r.inTx(ctx, func(tx *gorm.DB) error {
+		if existing, err := existingContractID(tx, m.ChainID, m.ContractAddress, m.Type); err != nil || existing != nil {
+			if err != nil {
+				return err
+			}
+			return existing
+		}
+		if m.IsActive {
+			if err := deactivateSiblingContracts(tx, m.ChainID, m.Type, m.ID); err != nil {
+				return err
+			}
+		}
+		return tx.Create(m).Error
+	})
+	if err == domainerrors.ErrAlreadyExists {
+		// Lost a race with a concurrent create of the same contract
+		if existing, lookupErr := existingContractID(GetDB(ctx, r.db).WithContext(ctx), m.ChainID, m.ContractAddress, m.Type); lookupErr == nil && existing != nil {
+			return existing
+		}
 	}
-	return nil
+	return err
+}
+
+// existingContractID looks up a live contract with the same (chain, address, type), the key
+// Create refuses to duplicate
+func existingContractID(tx *gorm.DB, chainID uuid.UUID, address, contractType string) (*domainerrors.AlreadyExistsError, error) {
+	var ids []uuid.UUID
+	if err := tx.Model(&models.SmartContract{}).
+		Where("chain_id = ? AND LOWER(address) = ? AND type = ?", chainID, strings.ToLower(strings.TrimSpace(address)), contractType).
+		Limit(1).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return &domainerrors.AlreadyExistsError{ID: ids[0].String()}, nil
 }
 
 func (r *SmartContractRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID) (*entities.SmartContract, error) {
 	var m models.SmartContract
 	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrNotFound
 		}
 		return nil, err
 	}
@@ -87,8 +132,8 @@ func (r *SmartContractRepositoryImpl) GetByID(ctx context.Context, id uuid.UUID)
 func (r *SmartContractRepositoryImpl) GetByChainAndAddress(ctx context.Context, chainID uuid.UUID, address string) (*entities.SmartContract, error) {
 	var m models.SmartContract
 	if err := r.db.WithContext(ctx).Where("chain_id = ? AND address = ?", chainID, address).First(&m).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrNotFound
 		}
 		return nil, err
 	}
@@ -101,8 +146,8 @@ func (r *SmartContractRepositoryImpl) GetActiveContract(ctx context.Context, cha
 		Where("chain_id = ? AND type = ? AND is_active = ?", chainID, contractType, true).
 		Order("updated_at DESC, created_at DESC, version DESC, id DESC").
 		First(&m).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrNotFound
 		}
 		return nil, err
 	}
@@ -121,8 +166,8 @@ func (r *SmartContractRepositoryImpl) GetActiveContractAddress(ctx context.Conte
 		Where("chain_id = ? AND type = ? AND is_active = ?", chainID, contractType, true).
 		Order("updated_at DESC, created_at DESC, version DESC, id DESC").
 		Take(&row).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return "", nil
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", domainerrors.ErrNotFound
 		}
 		return "", err
 	}
@@ -183,23 +228,34 @@ func (r *SmartContractRepositoryImpl) GetAll(ctx context.Context, pagination uti
 	return entitiesList, totalCount, nil
 }
 
-func (r *SmartContractRepositoryImpl) GetFiltered(ctx context.Context, chainID *uuid.UUID, contractType entities.SmartContractType, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+// GetFiltered lists one page of contracts matching filter, with the total count of matches
+func (r *SmartContractRepositoryImpl) GetFiltered(ctx context.Context, filter repositories.SmartContractFilter, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	var ms []models.SmartContract
 	var totalCount int64
 
 	query := r.db.WithContext(ctx).Model(&models.SmartContract{})
 
-	if chainID != nil {
-		query = query.Where("chain_id = ?", *chainID)
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.Indexed {
+		query = query.Where("indexed_events IS NOT NULL AND indexed_events <> ?", "{}")
 	}
-	if contractType != "" {
+	if filter.ChainID != nil {
+		query = query.Where("chain_id = ?", *filter.ChainID)
+	}
+	if filter.Type != "" {
 		// Backward compatibility: older data may still use DEX_POOL.
-		if contractType == entities.ContractTypePool {
+		if filter.Type == entities.ContractTypePool {
 			query = query.Where("type IN ?", []string{string(entities.ContractTypePool), "DEX_POOL"})
 		} else {
-			query = query.Where("type = ?", string(contractType))
+			query = query.Where("type = ?", string(filter.Type))
 		}
 	}
+	if search := strings.ToLower(strings.TrimSpace(filter.Search)); search != "" {
+		searchTerm := "%" + search + "%"
+		query = query.Where("LOWER(name) LIKE ? OR LOWER(address) LIKE ?", searchTerm, searchTerm)
+	}
 
 	if err := query.Count(&totalCount).Error; err != nil {
 		return nil, 0, err
@@ -245,16 +301,121 @@ func (r *SmartContractRepositoryImpl) Update(ctx context.Context, contract *enti
 		"fee_tier":         contract.FeeTier.Int,
 		"hook_address":     contract.HookAddress.String,
 		"start_block":      int64(contract.StartBlock),
+		"indexed_events":   indexedEventsArray(contract.IndexedEvents),
 	}
 
-	result := r.db.WithContext(ctx).Model(&models.SmartContract{}).Where("id = ?", contract.ID).Updates(updates)
-	if result.Error != nil {
-		return result.Error
+	return r.inTx(ctx, func(tx *gorm.DB) error {
+		if contract.IsActive {
+			if err := deactivateSiblingContracts(tx, contract.ChainUUID, string(contract.Type), contract.ID); err != nil {
+				return err
+			}
+		}
+		result := tx.Model(&models.SmartContract{}).Where("id = ?", contract.ID).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domainerrors.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// Activate makes id the only active contract of its (chain, type), deactivating the previous
+// one in the same transaction. Concurrent activations for the same pair are serialized.
+func (r *SmartContractRepositoryImpl) Activate(ctx context.Context, id uuid.UUID) (*entities.SmartContract, error) {
+	var m models.SmartContract
+	err := r.inTx(ctx, func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).First(&m).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainerrors.ErrNotFound
+			}
+			return err
+		}
+		if err := deactivateSiblingContracts(tx, m.ChainID, m.Type, m.ID); err != nil {
+			return err
+		}
+		m.IsActive = true
+		return tx.Model(&models.SmartContract{}).Where("id = ?", id).Update("is_active", true).Error
+	})
+	if err != nil {
+		return nil, err
 	}
-	if result.RowsAffected == 0 {
-		return domainerrors.ErrNotFound
+	return r.toEntity(&m), nil
+}
+
+// inTx runs fn in a transaction (a savepoint when ctx already carries one) and reports unique
+// violations, such as a second active contract for the same (chain, type), as ErrAlreadyExists.
+func (r *SmartContractRepositoryImpl) inTx(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	err := GetDB(ctx, r.db).WithContext(ctx).Transaction(fn)
+	if isUniqueViolation(err) {
+		return domainerrors.ErrAlreadyExists
 	}
-	return nil
+	return err
+}
+
+// deactivateSiblingContracts locks every contract of (chainID, contractType) and deactivates
+// all but exceptID. Taking the row locks first makes racing activations queue up instead of
+// both seeing "nothing active" and tripping the unique index.
+func deactivateSiblingContracts(tx *gorm.DB, chainID uuid.UUID, contractType string, exceptID uuid.UUID) error {
+	if multiActiveContractTypes[contractType] {
+		return nil
+	}
+	var locked []uuid.UUID
+	if err := tx.Model(&models.SmartContract{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("chain_id = ? AND type = ?", chainID, contractType).
+		Pluck("id", &locked).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.SmartContract{}).
+		Where("chain_id = ? AND type = ? AND is_active = ? AND id <> ?", chainID, contractType, true, exceptID).
+		Update("is_active", false).Error
+}
+
+// BulkSetActive sets is_active on the existing contracts in ids within one transaction.
+// Activating two contracts of the same (chain, type) fails with ErrAlreadyExists.
+func (r *SmartContractRepositoryImpl) BulkSetActive(ctx context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error) {
+	previous := make(map[uuid.UUID]bool, len(ids))
+	err := r.inTx(ctx, func(tx *gorm.DB) error {
+		var ms []models.SmartContract
+		if err := tx.Select("id", "chain_id", "type", "is_active").Where("id IN ?", ids).Find(&ms).Error; err != nil {
+			return err
+		}
+		if len(ms) == 0 {
+			return nil
+		}
+		found := make([]uuid.UUID, 0, len(ms))
+		for _, m := range ms {
+			previous[m.ID] = m.IsActive
+			found = append(found, m.ID)
+		}
+		if active {
+			type group struct {
+				chainID      uuid.UUID
+				contractType string
+			}
+			seen := make(map[group]bool, len(ms))
+			for _, m := range ms {
+				if multiActiveContractTypes[m.Type] {
+					continue
+				}
+				key := group{chainID: m.ChainID, contractType: m.Type}
+				if seen[key] {
+					return domainerrors.ErrAlreadyExists
+				}
+				seen[key] = true
+				if err := deactivateSiblingContracts(tx, m.ChainID, m.Type, m.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return tx.Model(&models.SmartContract{}).Where("id IN ?", found).Update("is_active", active).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return previous, nil
 }
 
 func (r *SmartContractRepositoryImpl) SoftDelete(ctx context.Context, id uuid.UUID) error {
@@ -268,6 +429,14 @@ func (r *SmartContractRepositoryImpl) SoftDelete(ctx context.Context, id uuid.UU
 	return nil
 }
 
+// indexedEventsArray stores no events as '{}' rather than NULL, so the column stays comparable
+func indexedEventsArray(events []string) pq.StringArray {
+	if events == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(events)
+}
+
 func (r *SmartContractRepositoryImpl) toEntity(m *models.SmartContract) *entities.SmartContract {
 	var abi interface{}
 	if m.ABI != "" && m.ABI != "null" {
@@ -304,6 +473,7 @@ func (r *SmartContractRepositoryImpl) toEntity(m *models.SmartContract) *entitie
 		HookAddress:     hook,
 		StartBlock:      uint64(m.StartBlock),
 		ABI:             abi,
+		IndexedEvents:   []string(m.IndexedEvents),
 		Metadata:        meta,
 		IsActive:        m.IsActive,
 		CreatedAt:       m.CreatedAt,
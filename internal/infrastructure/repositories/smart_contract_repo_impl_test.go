@@ -12,6 +12,7 @@ import (
 	"gorm.io/gorm"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/infrastructure/models"
 	"payment-kita.backend/pkg/utils"
 )
@@ -40,9 +41,13 @@ func (s *stubChainRepo) GetAllRPCs(context.Context, *uuid.UUID, *bool, *string,
 func (s *stubChainRepo) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *stubChainRepo) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *stubChainRepo) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *stubChainRepo) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *stubChainRepo) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *stubChainRepo) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *stubChainRepo) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *stubChainRepo) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *stubChainRepo) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -98,7 +103,7 @@ func TestSmartContractRepository_CRUDAndFilters(t *testing.T) {
 	require.Equal(t, int64(1), totalByChain)
 	require.Len(t, allByChain, 1)
 
-	filtered, totalFiltered, err := repo.GetFiltered(ctx, &chainID, entities.ContractTypeRouter, utils.PaginationParams{Page: 1, Limit: 10})
+	filtered, totalFiltered, err := repo.GetFiltered(ctx, domainrepos.SmartContractFilter{ChainID: &chainID, Type: entities.ContractTypeRouter}, utils.PaginationParams{Page: 1, Limit: 10})
 	require.NoError(t, err)
 	require.Equal(t, int64(1), totalFiltered)
 	require.Len(t, filtered, 1)
@@ -112,9 +117,8 @@ func TestSmartContractRepository_CRUDAndFilters(t *testing.T) {
 	require.Len(t, all, 1)
 
 	require.NoError(t, repo.SoftDelete(ctx, id))
-	nilByID, err := repo.GetByID(ctx, id)
-	require.NoError(t, err)
-	require.Nil(t, nilByID)
+	_, err = repo.GetByID(ctx, id)
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
 
 	require.ErrorIs(t, repo.Update(ctx, &entities.SmartContract{ID: uuid.New()}), domainerrors.ErrNotFound)
 	require.ErrorIs(t, repo.SoftDelete(ctx, uuid.New()), domainerrors.ErrNotFound)
@@ -133,13 +137,11 @@ func TestSmartContractRepository_NotFoundAndDecodeBranches(t *testing.T) {
 	})
 
 	// Not found branches
-	byAddr, err := repo.GetByChainAndAddress(ctx, chainID, "0xdoesnotexist")
-	require.NoError(t, err)
-	require.Nil(t, byAddr)
+	_, err := repo.GetByChainAndAddress(ctx, chainID, "0xdoesnotexist")
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
 
-	active, err := repo.GetActiveContract(ctx, chainID, entities.ContractTypeRouter)
-	require.NoError(t, err)
-	require.Nil(t, active)
+	_, err = repo.GetActiveContract(ctx, chainID, entities.ContractTypeRouter)
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
 
 	// Insert inactive and malformed ABI row directly to cover decode-safe path.
 	rawID := uuid.New()
@@ -170,9 +172,8 @@ func TestSmartContractRepository_NotFoundAndDecodeBranches(t *testing.T) {
 	require.Nil(t, got.ABI)
 
 	// Inactive row should not be returned by GetActiveContract
-	active, err = repo.GetActiveContract(ctx, chainID, entities.ContractTypeGateway)
-	require.NoError(t, err)
-	require.Nil(t, active)
+	_, err = repo.GetActiveContract(ctx, chainID, entities.ContractTypeGateway)
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
 }
 
 func TestSmartContractRepository_CreateMarshalError(t *testing.T) {
@@ -217,7 +218,7 @@ func TestSmartContractRepository_Query_DBErrorAndFilterCompat(t *testing.T) {
 		_, _, err = repo.GetAll(ctx, utils.PaginationParams{Page: 1, Limit: 10})
 		require.Error(t, err)
 
-		_, _, err = repo.GetFiltered(ctx, &chainID, entities.ContractTypeRouter, utils.PaginationParams{Page: 1, Limit: 10})
+		_, _, err = repo.GetFiltered(ctx, domainrepos.SmartContractFilter{ChainID: &chainID, Type: entities.ContractTypeRouter}, utils.PaginationParams{Page: 1, Limit: 10})
 		require.Error(t, err)
 	})
 
@@ -245,7 +246,7 @@ func TestSmartContractRepository_Query_DBErrorAndFilterCompat(t *testing.T) {
 			"0x2222222222222222222222222222222222222222", "", true, `[]`, `{}`, 0, time.Now(), time.Now(),
 		)
 
-		items, total, err := repo.GetFiltered(ctx, &chainID, entities.ContractTypePool, utils.PaginationParams{Page: 1, Limit: 10})
+		items, total, err := repo.GetFiltered(ctx, domainrepos.SmartContractFilter{ChainID: &chainID, Type: entities.ContractTypePool}, utils.PaginationParams{Page: 1, Limit: 10})
 		require.NoError(t, err)
 		require.Equal(t, int64(2), total)
 		require.Len(t, items, 2)
@@ -307,9 +308,8 @@ func TestSmartContractRepository_GetActiveContractAddress(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, newerAddr, addr)
 
-	addr, err = repo.GetActiveContractAddress(ctx, chainID, entities.ContractTypeRouter)
-	require.NoError(t, err)
-	require.Equal(t, "", addr)
+	_, err = repo.GetActiveContractAddress(ctx, chainID, entities.ContractTypeRouter)
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
 }
 
 func TestSmartContractRepository_DBErrorBranches_OnSingleLookups(t *testing.T) {
@@ -464,7 +464,7 @@ func TestSmartContractRepository_GetFiltered_NoTypeAndNoChainFilter(t *testing.T
 	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)`,
 		uuid.New().String(), "Router", "ROUTER", "1.0.0", chainID.String(), "0x2", "", true, "[]", "{}", 0, time.Now(), time.Now())
 
-	items, total, err := repo.GetFiltered(ctx, nil, "", utils.PaginationParams{Page: 1, Limit: 0})
+	items, total, err := repo.GetFiltered(ctx, domainrepos.SmartContractFilter{}, utils.PaginationParams{Page: 1, Limit: 0})
 	require.NoError(t, err)
 	require.Equal(t, int64(2), total)
 	require.Len(t, items, 2)
@@ -498,6 +498,135 @@ func TestSmartContractRepository_GetFiltered_FindErrorAfterCount(t *testing.T) {
 	}))
 	t.Cleanup(func() { _ = db.Callback().Query().Remove(cbName) })
 
-	_, _, err := repo.GetFiltered(ctx, &chainID, entities.ContractTypeRouter, utils.PaginationParams{Page: 1, Limit: 10})
+	_, _, err := repo.GetFiltered(ctx, domainrepos.SmartContractFilter{ChainID: &chainID, Type: entities.ContractTypeRouter}, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 }
+
+func TestSmartContractRepository_GetFiltered_IncludeInactive(t *testing.T) {
+	db := newTestDB(t)
+	createSmartContractTable(t, db)
+	ctx := context.Background()
+
+	repo := NewSmartContractRepository(db, &stubChainRepo{})
+	chainID := uuid.New()
+
+	mustExec(t, db, `INSERT INTO smart_contracts (id,name,type,version,chain_id,address,deployer_address,is_active,abi,metadata,start_block,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		uuid.New().String(), "Gateway V1", "GATEWAY", "1.0.0", chainID.String(), "0x1", "", false, "[]", "{}", 0, time.Now(), time.Now())
+	mustExec(t, db, `INSERT INTO smart_contracts (id,name,type,version,chain_id,address,deployer_address,is_active,abi,metadata,start_block,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		uuid.New().String(), "Gateway V2", "GATEWAY", "2.0.0", chainID.String(), "0x2", "", true, "[]", "{}", 0, time.Now(), time.Now())
+
+	filter := domainrepos.ActiveContracts(&chainID)
+	filter.Type = entities.ContractTypeGateway
+	items, total, err := repo.GetFiltered(ctx, filter, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Len(t, items, 1)
+	require.Equal(t, "Gateway V2", items[0].Name)
+
+	items, total, err = repo.GetFiltered(ctx, domainrepos.SmartContractFilter{ChainID: &chainID, Type: entities.ContractTypeGateway}, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, items, 2)
+}
+
+func TestSmartContractRepository_GetFiltered_InactiveOnlyAndSearch(t *testing.T) {
+	db := newTestDB(t)
+	createSmartContractTable(t, db)
+	ctx := context.Background()
+
+	repo := NewSmartContractRepository(db, &stubChainRepo{})
+	chainID := uuid.New()
+
+	for _, row := range []struct {
+		name, address string
+		active        bool
+	}{
+		{"Gateway V1", "0xAbC1", false},
+		{"Gateway V2", "0xdef2", true},
+		{"Router", "0xabc3", true},
+	} {
+		mustExec(t, db, `INSERT INTO smart_contracts (id,name,type,version,chain_id,address,deployer_address,is_active,abi,metadata,start_block,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+			uuid.New().String(), row.name, "GATEWAY", "1.0.0", chainID.String(), row.address, "", row.active, "[]", "{}", 0, time.Now(), time.Now())
+	}
+
+	inactive := false
+	items, total, err := repo.GetFiltered(ctx, domainrepos.SmartContractFilter{ChainID: &chainID, IsActive: &inactive}, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, "Gateway V1", items[0].Name)
+
+	// Search matches name or address, ignoring case; the total counts matches, not the page
+	items, total, err = repo.GetFiltered(ctx, domainrepos.SmartContractFilter{Search: "ABC"}, utils.PaginationParams{Page: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, items, 1)
+
+	filter := domainrepos.ActiveContracts(&chainID)
+	filter.Search = "gateway"
+	items, total, err = repo.GetFiltered(ctx, filter, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, "Gateway V2", items[0].Name)
+}
+
+func TestSmartContractRepository_IndexedEventsRoundTripAndFilter(t *testing.T) {
+	db := newTestDB(t)
+	createSmartContractTable(t, db)
+	ctx := context.Background()
+
+	repo := NewSmartContractRepository(db, &stubChainRepo{})
+	chainID := uuid.New()
+	indexed := &entities.SmartContract{ID: uuid.New(), Name: "Gateway", Type: entities.ContractTypeGateway, ChainUUID: chainID, ContractAddress: "0x1", ABI: []interface{}{}, IsActive: true,
+		IndexedEvents: []string{"PaymentCreated(bytes32,address)", "PaymentExecuted(bytes32)"}}
+	plain := &entities.SmartContract{ID: uuid.New(), Name: "Router", Type: entities.ContractTypeRouter, ChainUUID: chainID, ContractAddress: "0x2", ABI: []interface{}{}, IsActive: true}
+	require.NoError(t, repo.Create(ctx, indexed))
+	require.NoError(t, repo.Create(ctx, plain))
+
+	got, err := repo.GetByID(ctx, indexed.ID)
+	require.NoError(t, err)
+	require.Equal(t, indexed.IndexedEvents, got.IndexedEvents)
+
+	filter := domainrepos.ActiveContracts(&chainID)
+	filter.Indexed = true
+	items, total, err := repo.GetFiltered(ctx, filter, utils.PaginationParams{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, indexed.ID, items[0].ID)
+
+	// Clearing the events drops the contract from the indexer's list
+	got.IndexedEvents = nil
+	require.NoError(t, repo.Update(ctx, got))
+	_, total, err = repo.GetFiltered(ctx, filter, utils.PaginationParams{})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), total)
+}
+
+func TestSmartContractRepository_BulkSetActive(t *testing.T) {
+	db := newTestDB(t)
+	createSmartContractTable(t, db)
+	ctx := context.Background()
+
+	repo := NewSmartContractRepository(db, &stubChainRepo{})
+	chainID := uuid.New()
+	gatewayID := uuid.New()
+	routerID := uuid.New()
+
+	mustExec(t, db, `INSERT INTO smart_contracts (id,name,type,version,chain_id,address,deployer_address,is_active,abi,metadata,start_block,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		gatewayID.String(), "Gateway", "GATEWAY", "1.0.0", chainID.String(), "0x1", "", false, "[]", "{}", 0, time.Now(), time.Now())
+	mustExec(t, db, `INSERT INTO smart_contracts (id,name,type,version,chain_id,address,deployer_address,is_active,abi,metadata,start_block,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		routerID.String(), "Router", "ROUTER", "1.0.0", chainID.String(), "0x2", "", false, "[]", "{}", 0, time.Now(), time.Now())
+
+	previous, err := repo.BulkSetActive(ctx, []uuid.UUID{gatewayID, routerID, uuid.New()}, true)
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]bool{gatewayID: false, routerID: false}, previous)
+
+	items, total, err := repo.GetFiltered(ctx, domainrepos.ActiveContracts(&chainID), utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, items, 2)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/volatiletech/null/v8"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
@@ -54,6 +55,44 @@ func (r *TokenRepository) GetBySymbol(ctx context.Context, symbol string, chainI
 	return r.toEntity(&m), nil
 }
 
+// GetActiveBySymbol gets every active token with exactly this symbol on an active chain, reading
+// the chain fields from the same join
+func (r *TokenRepository) GetActiveBySymbol(ctx context.Context, symbol string) ([]*entities.Token, error) {
+	type row struct {
+		models.Token
+		ChainNetworkID string
+		ChainName      string
+		ChainType      string
+		ChainIsTestnet bool
+	}
+	var rows []row
+	if err := r.db.WithContext(ctx).
+		Model(&models.Token{}).
+		Select("tokens.*, chains.chain_id AS chain_network_id, chains.name AS chain_name, chains.type AS chain_type, chains.is_testnet AS chain_is_testnet").
+		Joins("JOIN chains ON chains.id = tokens.chain_id AND chains.is_active = ? AND chains.deleted_at IS NULL", true).
+		Where("tokens.symbol = ? AND tokens.is_active = ?", symbol, true).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*entities.Token, 0, len(rows))
+	for _, row := range rows {
+		model := row.Token
+		token := r.toEntity(&model)
+		token.Chain = &entities.Chain{
+			ID:        row.ChainID,
+			ChainID:   row.ChainNetworkID,
+			Name:      row.ChainName,
+			Type:      entities.ChainType(strings.ToUpper(row.ChainType)),
+			IsActive:  true,
+			IsTestnet: row.ChainIsTestnet,
+		}
+		token.BlockchainID = token.Chain.ChainID
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
 // GetByAddress gets a token by contract address and chain ID
 func (r *TokenRepository) GetByAddress(ctx context.Context, address string, chainID uuid.UUID) (*entities.Token, error) {
 	var m models.Token
@@ -144,13 +183,16 @@ func (r *TokenRepository) GetTokensByChain(ctx context.Context, chainID uuid.UUI
 	return tokens, totalCount, nil
 }
 
-// GetAllTokens gets all tokens with filters
-func (r *TokenRepository) GetAllTokens(ctx context.Context, chainID *uuid.UUID, search *string, pagination utils.PaginationParams) ([]*entities.Token, int64, error) {
+// GetAllTokens gets tokens with filters; inactive tokens are skipped unless includeInactive is set
+func (r *TokenRepository) GetAllTokens(ctx context.Context, chainID *uuid.UUID, search *string, includeInactive bool, pagination utils.PaginationParams) ([]*entities.Token, int64, error) {
 	var ms []models.Token
 	var totalCount int64
 
 	query := r.db.WithContext(ctx).Model(&models.Token{})
 
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
 	if chainID != nil {
 		query = query.Where("chain_id = ?", *chainID)
 	}
@@ -183,22 +225,24 @@ func (r *TokenRepository) GetAllTokens(ctx context.Context, chainID *uuid.UUID,
 
 func (r *TokenRepository) toEntity(m *models.Token) *entities.Token {
 	e := &entities.Token{
-		ID:              m.ID,
-		ChainUUID:       m.ChainID, // Changed ChainID to ChainUUID
-		Symbol:          m.Symbol,
-		Name:            m.Name,
-		Decimals:        m.Decimals,
-		LogoURL:         m.LogoURL,
-		ContractAddress: m.ContractAddress,
-		Type:            entities.TokenType(m.Type),
-		IsActive:        m.IsActive,
-		IsNative:        m.IsNative,
-		IsStablecoin:    m.IsStablecoin,
-		MinAmount:       m.MinAmount,
-		MaxAmount:       null.StringFromPtr(m.MaxAmount), // Added MaxAmount
-		CreatedAt:       m.CreatedAt,
-		UpdatedAt:       m.UpdatedAt,
-		DeletedAt:       &m.DeletedAt.Time, // Added DeletedAt
+		ID:                m.ID,
+		ChainUUID:         m.ChainID, // Changed ChainID to ChainUUID
+		Symbol:            m.Symbol,
+		Name:              m.Name,
+		Decimals:          m.Decimals,
+		LogoURL:           m.LogoURL,
+		ContractAddress:   m.ContractAddress,
+		Type:              entities.TokenType(m.Type),
+		IsActive:          m.IsActive,
+		IsNative:          m.IsNative,
+		IsStablecoin:      m.IsStablecoin,
+		HasTransferFee:    m.HasTransferFee,
+		MinAmount:         m.MinAmount,
+		MaxAmount:         null.StringFromPtr(m.MaxAmount), // Added MaxAmount
+		ApprovalThreshold: null.StringFromPtr(m.ApprovalThreshold),
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+		DeletedAt:         &m.DeletedAt.Time, // Added DeletedAt
 	}
 
 	// Populating BlockchainID from Chain if available
@@ -215,6 +259,7 @@ func (r *TokenRepository) toEntity(m *models.Token) *entities.Token {
 			CurrencySymbol: m.Chain.Symbol,
 			ImageURL:       m.Chain.LogoURL,
 			IsActive:       m.Chain.IsActive,
+			IsTestnet:      m.Chain.IsTestnet,
 			CreatedAt:      m.Chain.CreatedAt,
 			UpdatedAt:      m.Chain.UpdatedAt,
 		}
@@ -230,41 +275,128 @@ func (r *TokenRepository) toEntity(m *models.Token) *entities.Token {
 
 func (r *TokenRepository) toModel(token *entities.Token) *models.Token {
 	return &models.Token{
-		ID:              token.ID,
-		ChainID:         token.ChainUUID,
-		Symbol:          token.Symbol,
-		Name:            token.Name,
-		Decimals:        token.Decimals,
-		ContractAddress: token.ContractAddress,
-		Type:            string(token.Type),
-		LogoURL:         token.LogoURL,
-		IsActive:        token.IsActive,
-		IsNative:        token.IsNative,
-		IsStablecoin:    token.IsStablecoin,
-		MinAmount:       token.MinAmount,
-		MaxAmount:       token.MaxAmount.Ptr(),
-		CreatedAt:       token.CreatedAt,
-		UpdatedAt:       token.UpdatedAt,
+		ID:                token.ID,
+		ChainID:           token.ChainUUID,
+		Symbol:            token.Symbol,
+		Name:              token.Name,
+		Decimals:          token.Decimals,
+		ContractAddress:   token.ContractAddress,
+		Type:              string(token.Type),
+		LogoURL:           token.LogoURL,
+		IsActive:          token.IsActive,
+		IsNative:          token.IsNative,
+		IsStablecoin:      token.IsStablecoin,
+		HasTransferFee:    token.HasTransferFee,
+		MinAmount:         token.MinAmount,
+		MaxAmount:         token.MaxAmount.Ptr(),
+		ApprovalThreshold: token.ApprovalThreshold.Ptr(),
+		CreatedAt:         token.CreatedAt,
+		UpdatedAt:         token.UpdatedAt,
 	}
 }
 
-// Create creates a new token
+// Create creates a new token. If a live token with the same (chain, address) exists it fails
+// with a *domainerrors.AlreadyExistsError carrying that token's ID. Native tokens have no
+// address and are not checked.
 func (r *TokenRepository) Create(ctx context.Context, token *entities.Token) error {
-	m := r.toModel(token)
+	if existing, err := r.existingTokenID(ctx, token.ChainUUID, token.ContractAddress); err != nil || existing != nil {
+		if err != nil {
+			return err
+		}
+		return existing
+	}
 
+	m := r.toModel(token)
 	if err := r.db.WithContext(ctx).Create(m).Error; err != nil {
+		if isUniqueViolation(err) {
+			// Lost a race with a concurrent create of the same token
+			if existing, lookupErr := r.existingTokenID(ctx, token.ChainUUID, token.ContractAddress); lookupErr == nil && existing != nil {
+				return existing
+			}
+			return domainerrors.ErrAlreadyExists
+		}
 		return err
 	}
 	return nil
 }
 
+func (r *TokenRepository) existingTokenID(ctx context.Context, chainID uuid.UUID, address string) (*domainerrors.AlreadyExistsError, error) {
+	address = strings.TrimSpace(strings.ToLower(address))
+	if address == "" {
+		return nil, nil
+	}
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).Model(&models.Token{}).
+		Where("chain_id = ? AND LOWER(address) = ?", chainID, address).
+		Limit(1).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return &domainerrors.AlreadyExistsError{ID: ids[0].String()}, nil
+}
+
 // Update updates an existing token
 func (r *TokenRepository) Update(ctx context.Context, token *entities.Token) error {
 	m := r.toModel(token)
 	return r.db.WithContext(ctx).Save(m).Error
 }
 
-// SoftDelete soft deletes a token
+// BulkSetActive sets is_active on the existing tokens in ids within one transaction
+func (r *TokenRepository) BulkSetActive(ctx context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error) {
+	previous := make(map[uuid.UUID]bool, len(ids))
+	err := GetDB(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ms []models.Token
+		if err := tx.Select("id", "is_active").Where("id IN ?", ids).Find(&ms).Error; err != nil {
+			return err
+		}
+		if len(ms) == 0 {
+			return nil
+		}
+		found := make([]uuid.UUID, 0, len(ms))
+		for _, m := range ms {
+			previous[m.ID] = m.IsActive
+			found = append(found, m.ID)
+		}
+		return tx.Model(&models.Token{}).Where("id IN ?", found).Update("is_active", active).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+// SoftDelete soft deletes a token. Payments and payment requests that reference it still load it.
 func (r *TokenRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&models.Token{}, "id = ?", id).Error
+	result := r.db.WithContext(ctx).Delete(&models.Token{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+// HardDelete removes a token row, soft-deleted or not. It fails with a
+// *domainerrors.ReferencedError while payments, payment requests or fee configs still reference it.
+func (r *TokenRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return GetDB(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Unscoped().Model(&models.Token{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", id).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return domainerrors.ErrNotFound
+		}
+		if err := checkNoReferences(tx, paymentTokenReferences, id); err != nil {
+			return err
+		}
+		return referencedError(tx.Unscoped().Delete(&models.Token{}, "id = ?", id).Error)
+	})
 }
@@ -24,13 +24,13 @@ func TestTokenRepository_GetAllTokens_QueryBranches(t *testing.T) {
 		uuid.New().String(), chainID.String(), "IDRX", "IDRX", 6, "0x2", "ERC20", true, false, false, "0", nil)
 
 	// chain filter branch
-	items, total, err := repo.GetAllTokens(ctx, &chainID, nil, utils.GetPaginationParams(1, 10))
+	items, total, err := repo.GetAllTokens(ctx, &chainID, nil, true, utils.GetPaginationParams(1, 10))
 	require.NoError(t, err)
 	require.Equal(t, int64(2), total)
 	require.Len(t, items, 2)
 
 	// search branch under sqlite (ILIKE unsupported) should hit query error branch.
 	search := "USD"
-	_, _, err = repo.GetAllTokens(ctx, nil, &search, utils.GetPaginationParams(1, 10))
+	_, _, err = repo.GetAllTokens(ctx, nil, &search, true, utils.GetPaginationParams(1, 10))
 	require.Error(t, err)
 }
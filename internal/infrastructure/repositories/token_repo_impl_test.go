@@ -7,7 +7,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
 	"gorm.io/gorm"
+	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/pkg/utils"
 )
@@ -64,13 +66,20 @@ func TestTokenRepository_CRUDAndQueries(t *testing.T) {
 	require.Equal(t, int64(2), total)
 	require.Len(t, byChain, 2)
 
-	allFiltered, totalFiltered, err := repo.GetAllTokens(ctx, &chainID, nil, utils.PaginationParams{Page: 1, Limit: 10})
+	allFiltered, totalFiltered, err := repo.GetAllTokens(ctx, &chainID, nil, true, utils.PaginationParams{Page: 1, Limit: 10})
 	require.NoError(t, err)
 	require.Equal(t, int64(2), totalFiltered)
 	require.Len(t, allFiltered, 2)
 
+	require.False(t, byID.HasTransferFee)
 	byID.Name = "USD Coin Updated"
+	byID.HasTransferFee = true
+	byID.ApprovalThreshold = null.StringFrom("25000")
 	require.NoError(t, repo.Update(ctx, byID))
+	updated, err := repo.GetByID(ctx, byID.ID)
+	require.NoError(t, err)
+	require.True(t, updated.HasTransferFee)
+	require.Equal(t, null.StringFrom("25000"), updated.ApprovalThreshold)
 	require.NoError(t, repo.SoftDelete(ctx, byID.ID))
 
 	_, err = repo.GetByID(ctx, byID.ID)
@@ -121,7 +130,7 @@ func TestTokenRepository_DBErrorBranches(t *testing.T) {
 	_, _, err = repo.GetTokensByChain(ctx, uuid.New(), utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 
-	_, _, err = repo.GetAllTokens(ctx, nil, nil, utils.PaginationParams{Page: 1, Limit: 10})
+	_, _, err = repo.GetAllTokens(ctx, nil, nil, true, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 }
 
@@ -139,7 +148,7 @@ func TestTokenRepository_GetAllTokens_SearchPathSQLiteILikeError(t *testing.T) {
 	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`, uuid.NewString(), chainID.String(), "USDC", "USD Coin", 6, "0x8335", "ERC20", "", true, false, true, "0", nil, now, now)
 
 	search := "USD"
-	_, _, err := repo.GetAllTokens(ctx, &chainID, &search, utils.PaginationParams{Page: 1, Limit: 10})
+	_, _, err := repo.GetAllTokens(ctx, &chainID, &search, true, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 }
 
@@ -168,3 +177,180 @@ func TestTokenRepository_GetTokensByChain_FindErrorAfterCount(t *testing.T) {
 	_, _, err := repo.GetTokensByChain(ctx, chainID, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 }
+
+func TestTokenRepository_GetAllTokens_IncludeInactive(t *testing.T) {
+	db := newTestDB(t)
+	createChainTables(t, db)
+	createTokenTable(t, db)
+	repo := NewTokenRepository(db, nil)
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	seedChain(t, db, chainID.String(), "8453", "Base", "EVM", true)
+	now := time.Now()
+	mustExec(t, db, `INSERT INTO tokens(id,chain_id,symbol,name,decimals,address,type,logo_url,is_active,is_native,is_stablecoin,min_amount,max_amount,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`, uuid.NewString(), chainID.String(), "USDC", "USD Coin", 6, "0x8335", "ERC20", "", true, false, true, "0", nil, now, now)
+	mustExec(t, db, `INSERT INTO tokens(id,chain_id,symbol,name,decimals,address,type,logo_url,is_active,is_native,is_stablecoin,min_amount,max_amount,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`, uuid.NewString(), chainID.String(), "DAI", "Dai", 18, "0x50c5", "ERC20", "", false, false, true, "0", nil, now, now)
+
+	active, total, err := repo.GetAllTokens(ctx, &chainID, nil, false, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Len(t, active, 1)
+	require.Equal(t, "USDC", active[0].Symbol)
+
+	all, total, err := repo.GetAllTokens(ctx, &chainID, nil, true, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	require.Len(t, all, 2)
+}
+
+func TestTokenRepository_GetActiveBySymbol(t *testing.T) {
+	db := newTestDB(t)
+	createChainTables(t, db)
+	createTokenTable(t, db)
+	repo := NewTokenRepository(db, nil)
+	ctx := context.Background()
+
+	baseID, arbID, offID, goneID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	seedChain(t, db, baseID.String(), "8453", "Base", "EVM", true)
+	seedChain(t, db, arbID.String(), "42161", "Arbitrum", "EVM", true)
+	seedChain(t, db, offID.String(), "10", "Optimism", "EVM", false)
+	seedChain(t, db, goneID.String(), "137", "Polygon", "EVM", true)
+	mustExec(t, db, `UPDATE chains SET deleted_at = ? WHERE id = ?`, time.Now(), goneID.String())
+	now := time.Now()
+	insert := func(chainID uuid.UUID, symbol, address string, active bool) uuid.UUID {
+		id := uuid.New()
+		mustExec(t, db, `INSERT INTO tokens(id,chain_id,symbol,name,decimals,address,type,logo_url,is_active,is_native,is_stablecoin,min_amount,max_amount,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`, id.String(), chainID.String(), symbol, symbol, 6, address, "ERC20", "", active, false, true, "0", nil, now, now)
+		return id
+	}
+	baseUSDC := insert(baseID, "USDC", "0x8335", true)
+	insert(arbID, "USDC", "0xaf88", false)
+	insert(arbID, "USDT", "0xfd08", true)
+	insert(offID, "USDC", "0x0b2c", true)
+	insert(goneID, "USDC", "0x3c49", true)
+
+	tokens, err := repo.GetActiveBySymbol(ctx, "USDC")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.Equal(t, baseUSDC, tokens[0].ID)
+	require.NotNil(t, tokens[0].Chain)
+	require.Equal(t, "8453", tokens[0].Chain.ChainID)
+	require.Equal(t, "Base", tokens[0].Chain.Name)
+
+	tokens, err = repo.GetActiveBySymbol(ctx, "DAI")
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+}
+
+func TestTokenRepository_BulkSetActive(t *testing.T) {
+	db := newTestDB(t)
+	createChainTables(t, db)
+	createTokenTable(t, db)
+	repo := NewTokenRepository(db, nil)
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	seedChain(t, db, chainID.String(), "8453", "Base", "EVM", true)
+	now := time.Now()
+	usdcID := uuid.New()
+	daiID := uuid.New()
+	mustExec(t, db, `INSERT INTO tokens(id,chain_id,symbol,name,decimals,address,type,logo_url,is_active,is_native,is_stablecoin,min_amount,max_amount,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`, usdcID.String(), chainID.String(), "USDC", "USD Coin", 6, "0x8335", "ERC20", "", true, false, true, "0", nil, now, now)
+	mustExec(t, db, `INSERT INTO tokens(id,chain_id,symbol,name,decimals,address,type,logo_url,is_active,is_native,is_stablecoin,min_amount,max_amount,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`, daiID.String(), chainID.String(), "DAI", "Dai", 18, "0x50c5", "ERC20", "", false, false, true, "0", nil, now, now)
+
+	previous, err := repo.BulkSetActive(ctx, []uuid.UUID{usdcID, daiID, uuid.New()}, false)
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]bool{usdcID: true, daiID: false}, previous)
+
+	_, total, err := repo.GetAllTokens(ctx, &chainID, nil, false, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Zero(t, total)
+
+	previous, err = repo.BulkSetActive(ctx, []uuid.UUID{uuid.New()}, true)
+	require.NoError(t, err)
+	require.Empty(t, previous)
+}
+
+func TestTokenRepository_Create_RejectsDuplicateChainAddress(t *testing.T) {
+	db := newTestDB(t)
+	createTokenTable(t, db)
+	repo := NewTokenRepository(db, nil)
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	newToken := func(address string) *entities.Token {
+		return &entities.Token{ID: uuid.New(), ChainUUID: chainID, Symbol: "USDC", Name: "USD Coin", Decimals: 6, Type: entities.TokenTypeERC20, ContractAddress: address, MinAmount: "0", IsActive: true}
+	}
+
+	first := newToken("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+	require.NoError(t, repo.Create(ctx, first))
+
+	// Same address in another case: conflict naming the existing token
+	err := repo.Create(ctx, newToken("0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"))
+	require.ErrorIs(t, err, domainerrors.ErrAlreadyExists)
+	var exists *domainerrors.AlreadyExistsError
+	require.ErrorAs(t, err, &exists)
+	require.Equal(t, first.ID.String(), exists.ID)
+
+	// Other chains and native tokens (no address) are not affected
+	other := newToken(first.ContractAddress)
+	other.ChainUUID = uuid.New()
+	require.NoError(t, repo.Create(ctx, other))
+	require.NoError(t, repo.Create(ctx, newToken("")))
+	require.NoError(t, repo.Create(ctx, newToken("")))
+
+	// A deleted token can be registered again
+	require.NoError(t, repo.SoftDelete(ctx, first.ID))
+	require.NoError(t, repo.Create(ctx, newToken(first.ContractAddress)))
+}
+
+func TestTokenRepository_HardDeleteRefusesReferencedTokens(t *testing.T) {
+	db := newTestDB(t)
+	createPaymentRequestTables(t, db)
+	createPaymentTables(t, db)
+	createBridgeAndFeeTables(t, db)
+	repo := NewTokenRepository(db, nil)
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	usedID := uuid.New()
+	unusedID := uuid.New()
+	now := time.Now()
+	for id, address := range map[uuid.UUID]string{usedID: "0xused", unusedID: "0xunused"} {
+		mustExec(t, db, `INSERT INTO tokens(id,chain_id,symbol,name,decimals,address,type,is_active,is_native,is_stablecoin,created_at,updated_at)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?,?)`, id.String(), chainID.String(), "USDC", "USD Coin", 6, address, "ERC20", true, false, true, now, now)
+	}
+	mustExec(t, db, `INSERT INTO payments(id,sender_id,source_chain_id,dest_chain_id,source_token_id,dest_token_id,source_amount,status,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?)`, uuid.NewString(), uuid.NewString(), chainID.String(), chainID.String(), uuid.NewString(), usedID.String(), "100", "COMPLETED", now, now)
+	requestID := uuid.New()
+	mustExec(t, db, `INSERT INTO payment_requests(id,merchant_id,chain_id,token_id,wallet_address,amount,decimals,status,expires_at,created_at,updated_at)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?)`, requestID.String(), uuid.NewString(), chainID.String(), usedID.String(), "0xwallet", "25", 6, "COMPLETED", now.Add(time.Hour), now, now)
+
+	mustExec(t, db, `INSERT INTO fee_configs(id,chain_id,token_id) VALUES (?,?,?)`,
+		uuid.NewString(), chainID.String(), usedID.String())
+
+	err := repo.HardDelete(ctx, usedID)
+	var referenced *domainerrors.ReferencedError
+	require.ErrorAs(t, err, &referenced)
+	require.ErrorIs(t, err, domainerrors.ErrStillReferenced)
+	require.Equal(t, map[string]int64{"payments.dest_token_id": 1, "payment_requests.token_id": 1, "fee_configs.token_id": 1}, referenced.References)
+
+	// A soft-deleted token is gone from lookups but still shown on the requests that used it
+	require.NoError(t, repo.SoftDelete(ctx, usedID))
+	_, err = repo.GetByID(ctx, usedID)
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
+	request, err := NewPaymentRequestRepository(db).GetByID(ctx, requestID)
+	require.NoError(t, err)
+	require.Equal(t, "0xused", request.TokenAddress)
+	require.ErrorIs(t, repo.HardDelete(ctx, usedID), domainerrors.ErrStillReferenced)
+	require.ErrorIs(t, repo.SoftDelete(ctx, usedID), domainerrors.ErrNotFound)
+
+	require.NoError(t, repo.HardDelete(ctx, unusedID))
+	var remaining int64
+	require.NoError(t, db.Table("tokens").Where("id = ?", unusedID).Count(&remaining).Error)
+	require.Zero(t, remaining)
+	require.ErrorIs(t, repo.HardDelete(ctx, unusedID), domainerrors.ErrNotFound)
+}
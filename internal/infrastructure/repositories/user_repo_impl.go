@@ -3,13 +3,16 @@ package repositories
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/infrastructure/models"
+	"payment-kita.backend/pkg/crypto"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -32,21 +35,28 @@ func (r *UserRepository) Create(ctx context.Context, user *entities.User) error
 		PasswordHash: user.PasswordHash,
 		Role:         string(user.Role),
 		KYCStatus:    string(user.KYCStatus),
+		FeeExempt:    user.FeeExempt,
+		SuspendedAt:  user.SuspendedAt,
 		CreatedAt:    user.CreatedAt,
 		UpdatedAt:    user.UpdatedAt,
 	}
 	// Note: DeletedAt handled by GORM
 
-	if err := r.db.WithContext(ctx).Create(m).Error; err != nil {
+	if err := GetDB(ctx, r.db).WithContext(ctx).Create(m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return domainerrors.ErrAlreadyExists
+		}
 		return err
 	}
+	// Ensure caller uses the persisted ID for subsequent FK inserts in the same tx.
+	user.ID = m.ID
 	return nil
 }
 
 // GetByID gets a user by ID
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	var m models.User
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
+	if err := GetDB(ctx, r.db).WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
@@ -58,7 +68,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.U
 // GetByEmail gets a user by email
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var m models.User
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&m).Error; err != nil {
+	if err := GetDB(ctx, r.db).WithContext(ctx).Where("email = ?", email).First(&m).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
@@ -71,16 +81,18 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entitie
 func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
 	// Only update specific fields as per original impl
 	updates := map[string]interface{}{
-		"name":       user.Name,
-		"role":       user.Role,
-		"kyc_status": user.KYCStatus,
-		"updated_at": time.Now(),
+		"name":         user.Name,
+		"role":         user.Role,
+		"kyc_status":   user.KYCStatus,
+		"fee_exempt":   user.FeeExempt,
+		"suspended_at": user.SuspendedAt,
+		"updated_at":   time.Now(),
 	}
 	if user.KYCVerifiedAt != nil {
 		updates["kyc_verified_at"] = *user.KYCVerifiedAt
 	}
 
-	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", user.ID).Updates(updates)
+	result := GetDB(ctx, r.db).WithContext(ctx).Model(&models.User{}).Where("id = ?", user.ID).Updates(updates)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -92,7 +104,7 @@ func (r *UserRepository) Update(ctx context.Context, user *entities.User) error
 
 // UpdatePassword updates user password hash.
 func (r *UserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
-	result := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+	result := GetDB(ctx, r.db).WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
 		"password_hash": passwordHash,
 		"updated_at":    time.Now(),
 	})
@@ -105,31 +117,54 @@ func (r *UserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passw
 	return nil
 }
 
-// List lists users with optional search filter
-func (r *UserRepository) List(ctx context.Context, search string) ([]*entities.User, error) {
+// List lists one page of users matching filter, with the total count of matches
+func (r *UserRepository) List(ctx context.Context, filter domainrepos.UserFilter, pagination utils.PaginationParams) ([]*entities.User, int64, error) {
 	var userModels []models.User
-	query := r.db.WithContext(ctx).Order("created_at DESC")
+	var total int64
+	query := GetDB(ctx, r.db).WithContext(ctx).Model(&models.User{})
 
-	if search != "" {
+	if search := strings.TrimSpace(filter.Search); search != "" {
 		searchTerm := "%" + search + "%"
 		query = query.Where("name ILIKE ? OR email ILIKE ?", searchTerm, searchTerm)
 	}
+	if filter.Role != "" {
+		query = query.Where("UPPER(role) = ?", strings.ToUpper(string(filter.Role)))
+	}
+	if filter.Verified != nil {
+		if *filter.Verified {
+			query = query.Where("UPPER(kyc_status) = ?", string(entities.KYCFullyVerified))
+		} else {
+			query = query.Where("kyc_status IS NULL OR UPPER(kyc_status) <> ?", string(entities.KYCFullyVerified))
+		}
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
 
+	order := "created_at DESC, id DESC"
+	if filter.Ascending {
+		order = "created_at ASC, id ASC"
+	}
+	query = query.Order(order)
+	if pagination.Limit > 0 {
+		query = query.Limit(pagination.Limit).Offset(pagination.CalculateOffset())
+	}
 	if err := query.Find(&userModels).Error; err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	var users []*entities.User
+	users := make([]*entities.User, 0, len(userModels))
 	for _, m := range userModels {
 		model := m
 		users = append(users, r.toEntity(&model))
 	}
-	return users, nil
+	return users, total, nil
 }
 
 // SoftDelete soft deletes a user
 func (r *UserRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&models.User{}, "id = ?", id)
+	result := GetDB(ctx, r.db).WithContext(ctx).Delete(&models.User{}, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -164,6 +199,8 @@ func (r *UserRepository) toEntity(m *models.User) *entities.User {
 		PasswordHash: m.PasswordHash,
 		Role:         entities.UserRole(m.Role),
 		KYCStatus:    entities.KYCStatus(m.KYCStatus),
+		FeeExempt:    m.FeeExempt,
+		SuspendedAt:  m.SuspendedAt,
 		// KYCVerifiedAt: null.TimeFromPtr(m.KYCVerifiedAt), // Need import
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
@@ -181,16 +218,16 @@ func NewEmailVerificationRepository(db *gorm.DB) *EmailVerificationRepository {
 	return &EmailVerificationRepository{db: db}
 }
 
-// Create creates a new email verification
+// Create creates a new email verification. Only the token's digest is stored.
 func (r *EmailVerificationRepository) Create(ctx context.Context, userID uuid.UUID, token string) error {
 	m := &models.EmailVerification{
 		ID:        utils.GenerateUUIDv7(),
 		UserID:    userID,
-		Token:     token,
+		Token:     crypto.HashToken(token),
 		ExpiresAt: time.Now().Add(24 * time.Hour),
 		CreatedAt: time.Now(),
 	}
-	return r.db.WithContext(ctx).Create(m).Error
+	return GetDB(ctx, r.db).WithContext(ctx).Create(m).Error
 }
 
 // GetByToken gets user by verification token
@@ -200,10 +237,10 @@ func (r *EmailVerificationRepository) GetByToken(ctx context.Context, token stri
 
 	var userModel models.User
 
-	err := r.db.WithContext(ctx).
+	err := GetDB(ctx, r.db).WithContext(ctx).
 		Table("users").
 		Joins("JOIN email_verifications ev ON ev.user_id = users.id").
-		Where("ev.token = ? AND ev.expires_at > ? AND ev.verified_at IS NULL AND ev.deleted_at IS NULL", token, time.Now()).
+		Where("ev.token IN ? AND ev.expires_at > ? AND ev.verified_at IS NULL AND ev.deleted_at IS NULL", verificationTokenKeys(token), time.Now()).
 		First(&userModel).Error
 
 	if err != nil {
@@ -225,16 +262,29 @@ func (r *EmailVerificationRepository) GetByToken(ctx context.Context, token stri
 		PasswordHash: userModel.PasswordHash,
 		Role:         entities.UserRole(userModel.Role),
 		KYCStatus:    entities.KYCStatus(userModel.KYCStatus),
+		FeeExempt:    userModel.FeeExempt,
+		SuspendedAt:  userModel.SuspendedAt,
 		CreatedAt:    userModel.CreatedAt,
 		UpdatedAt:    userModel.UpdatedAt,
 	}, nil
 }
 
+// verificationTokenKeys matches the stored digest, plus the raw token for rows written before
+// tokens were hashed (those expire within 24h). Issued tokens are 32 hex chars, so a value the
+// length of a digest is never matched raw; otherwise a leaked digest would work as a token.
+func verificationTokenKeys(token string) []string {
+	digest := crypto.HashToken(token)
+	if len(token) == len(digest) {
+		return []string{digest}
+	}
+	return []string{digest, token}
+}
+
 // MarkVerified marks an email verification as verified
 func (r *EmailVerificationRepository) MarkVerified(ctx context.Context, token string) error {
-	result := r.db.WithContext(ctx).
+	result := GetDB(ctx, r.db).WithContext(ctx).
 		Model(&models.EmailVerification{}).
-		Where("token = ? AND verified_at IS NULL", token).
+		Where("token IN ? AND verified_at IS NULL", verificationTokenKeys(token)).
 		Update("verified_at", time.Now())
 
 	if result.Error != nil {
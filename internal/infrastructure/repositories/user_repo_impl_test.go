@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/pkg/utils"
 )
 
 func TestUserRepository_CRUDAndList(t *testing.T) {
@@ -39,16 +41,22 @@ func TestUserRepository_CRUDAndList(t *testing.T) {
 	require.Equal(t, u.ID, byEmail.ID)
 
 	u.Name = "Alice Updated"
+	u.FeeExempt = true
 	require.NoError(t, repo.Update(ctx, u))
 
+	updated, err := repo.GetByID(ctx, u.ID)
+	require.NoError(t, err)
+	require.True(t, updated.FeeExempt)
+
 	require.NoError(t, repo.UpdatePassword(ctx, u.ID, "hash2"))
 
-	items, err := repo.List(ctx, "")
+	items, total, err := repo.List(ctx, domainrepos.UserFilter{}, utils.PaginationParams{Page: 1, Limit: 10})
 	require.NoError(t, err)
 	require.Len(t, items, 1)
+	require.Equal(t, int64(1), total)
 
 	// SQLite does not support ILIKE, this covers search-query error propagation branch.
-	_, err = repo.List(ctx, "Ali")
+	_, _, err = repo.List(ctx, domainrepos.UserFilter{Search: "Ali"}, utils.PaginationParams{Page: 1, Limit: 10})
 	require.Error(t, err)
 
 	require.NoError(t, repo.SoftDelete(ctx, u.ID))
@@ -56,6 +64,51 @@ func TestUserRepository_CRUDAndList(t *testing.T) {
 	require.ErrorIs(t, err, domainerrors.ErrNotFound)
 }
 
+func TestUserRepository_ListFiltersAndPages(t *testing.T) {
+	db := newTestDB(t)
+	createUserTable(t, db)
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour)
+	for i, user := range []*entities.User{
+		{Email: "a@paymentkita.io", Role: entities.UserRoleUser, KYCStatus: entities.KYCFullyVerified},
+		{Email: "b@paymentkita.io", Role: entities.UserRoleUser, KYCStatus: entities.KYCNotStarted},
+		{Email: "c@paymentkita.io", Role: entities.UserRoleAdmin, KYCStatus: entities.KYCFullyVerified},
+		{Email: "d@paymentkita.io", Role: entities.UserRoleUser, KYCStatus: entities.KYCFaceVerified},
+	} {
+		user.ID = uuid.New()
+		user.Name = user.Email
+		user.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		user.UpdatedAt = user.CreatedAt
+		require.NoError(t, repo.Create(ctx, user))
+	}
+	emails := func(users []*entities.User) []string {
+		out := make([]string, 0, len(users))
+		for _, user := range users {
+			out = append(out, user.Email)
+		}
+		return out
+	}
+
+	// Newest first, one page at a time
+	page, total, err := repo.List(ctx, domainrepos.UserFilter{}, utils.PaginationParams{Page: 2, Limit: 3})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), total)
+	require.Equal(t, []string{"a@paymentkita.io"}, emails(page))
+
+	verified := true
+	page, total, err = repo.List(ctx, domainrepos.UserFilter{Role: entities.UserRoleUser, Verified: &verified}, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	require.Equal(t, []string{"a@paymentkita.io"}, emails(page))
+
+	verified = false
+	page, _, err = repo.List(ctx, domainrepos.UserFilter{Verified: &verified, Ascending: true}, utils.PaginationParams{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b@paymentkita.io", "d@paymentkita.io"}, emails(page))
+}
+
 func TestUserRepository_NotFoundBranches(t *testing.T) {
 	db := newTestDB(t)
 	createUserTable(t, db)
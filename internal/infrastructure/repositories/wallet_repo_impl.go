@@ -39,13 +39,20 @@ func (r *WalletRepository) Create(ctx context.Context, wallet *entities.Wallet)
 		m.MerchantID = wallet.MerchantID
 	}
 
-	return r.db.WithContext(ctx).Create(m).Error
+	if err := GetDB(ctx, r.db).WithContext(ctx).Create(m).Error; err != nil {
+		if isUniqueViolation(err) {
+			return domainerrors.ErrAlreadyExists
+		}
+		return err
+	}
+	wallet.ID = m.ID
+	return nil
 }
 
 // GetByID gets a wallet by ID
 func (r *WalletRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Wallet, error) {
 	var m models.Wallet
-	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
+	if err := GetDB(ctx, r.db).WithContext(ctx).Where("id = ?", id).First(&m).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
@@ -57,7 +64,7 @@ func (r *WalletRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities
 // GetByUserID gets wallets by user ID
 func (r *WalletRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.Wallet, error) {
 	var ms []models.Wallet
-	if err := r.db.WithContext(ctx).
+	if err := GetDB(ctx, r.db).WithContext(ctx).
 		Where("user_id = ?", userID).
 		Order("is_primary DESC, created_at DESC").
 		Find(&ms).Error; err != nil {
@@ -94,7 +101,7 @@ func (r *WalletRepository) GetByAddress(ctx context.Context, chainID uuid.UUID,
 
 	// ChainID is UUID
 	var m models.Wallet
-	if err := r.db.WithContext(ctx).Where("chain_id = ? AND address = ?", chainID, address).First(&m).Error; err != nil {
+	if err := GetDB(ctx, r.db).WithContext(ctx).Where("chain_id = ? AND address = ?", chainID, address).First(&m).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainerrors.ErrNotFound
 		}
@@ -105,7 +112,7 @@ func (r *WalletRepository) GetByAddress(ctx context.Context, chainID uuid.UUID,
 
 // SetPrimary sets a wallet as primary (and unsets others)
 func (r *WalletRepository) SetPrimary(ctx context.Context, userID, walletID uuid.UUID) error {
-	return r.db.Transaction(func(tx *gorm.DB) error {
+	return GetDB(ctx, r.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Unset all
 		if err := tx.Model(&models.Wallet{}).
 			Where("user_id = ?", userID).
@@ -128,9 +135,27 @@ func (r *WalletRepository) SetPrimary(ctx context.Context, userID, walletID uuid
 	})
 }
 
+// ClaimUnowned links a wallet that has no owner yet to userID. The update is conditional
+// on user_id still being NULL so that, of two concurrent claims, only one affects the row.
+func (r *WalletRepository) ClaimUnowned(ctx context.Context, walletID, userID uuid.UUID, isPrimary bool) error {
+	result := GetDB(ctx, r.db).WithContext(ctx).Model(&models.Wallet{}).
+		Where("id = ? AND user_id IS NULL", walletID).
+		Updates(map[string]interface{}{
+			"user_id":    userID,
+			"is_primary": isPrimary,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainerrors.ErrAlreadyExists
+	}
+	return nil
+}
+
 // SoftDelete soft deletes a wallet
 func (r *WalletRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&models.Wallet{}, "id = ?", id)
+	result := GetDB(ctx, r.db).WithContext(ctx).Delete(&models.Wallet{}, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
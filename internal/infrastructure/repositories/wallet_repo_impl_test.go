@@ -51,6 +51,36 @@ func TestWalletRepository_CRUDAndPrimary(t *testing.T) {
 	require.ErrorIs(t, err, domainerrors.ErrNotFound)
 }
 
+func TestWalletRepository_ClaimUnowned(t *testing.T) {
+	db := newTestDB(t)
+	createChainTables(t, db)
+	createWalletTable(t, db)
+	repo := NewWalletRepository(db)
+	ctx := context.Background()
+
+	chainID := uuid.New()
+	seedChain(t, db, chainID.String(), "8453", "Base", "EVM", true)
+	unowned := &entities.Wallet{ID: uuid.New(), ChainID: chainID, Address: "0xunowned", CreatedAt: time.Now()}
+	require.NoError(t, repo.Create(ctx, unowned))
+
+	winner := uuid.New()
+	require.NoError(t, repo.ClaimUnowned(ctx, unowned.ID, winner, true))
+
+	got, err := repo.GetByID(ctx, unowned.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.UserID)
+	require.Equal(t, winner, *got.UserID)
+	require.True(t, got.IsPrimary)
+
+	// A second claim on the now-owned wallet must lose.
+	err = repo.ClaimUnowned(ctx, unowned.ID, uuid.New(), true)
+	require.ErrorIs(t, err, domainerrors.ErrAlreadyExists)
+
+	got, err = repo.GetByID(ctx, unowned.ID)
+	require.NoError(t, err)
+	require.Equal(t, winner, *got.UserID)
+}
+
 func TestWalletRepository_NotFoundBranches(t *testing.T) {
 	db := newTestDB(t)
 	createChainTables(t, db)
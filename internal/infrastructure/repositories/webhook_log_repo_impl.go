@@ -2,12 +2,14 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/volatiletech/null/v8"
 	"gorm.io/gorm"
 	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/infrastructure/models"
 )
 
@@ -77,6 +79,9 @@ func (r *GormWebhookLogRepository) Update(ctx context.Context, log *entities.Web
 func (r *GormWebhookLogRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
 	var m models.WebhookLog
 	if err := r.db.WithContext(ctx).First(&m, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrNotFound
+		}
 		return nil, err
 	}
 	return r.toEntity(&m), nil
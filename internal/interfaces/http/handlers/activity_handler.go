@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/internal/interfaces/http/response"
+)
+
+type ActivityService interface {
+	ListActivity(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*entities.ActivityPage, error)
+}
+
+// ActivityHandler serves the unified activity feed
+type ActivityHandler struct {
+	service ActivityService
+}
+
+func NewActivityHandler(service ActivityService) *ActivityHandler {
+	return &ActivityHandler{service: service}
+}
+
+// ListActivity lists the caller's payments and payment requests, newest first.
+// Pass the returned nextCursor as ?cursor= to fetch the next page.
+// GET /api/v1/activity
+func (h *ActivityHandler) ListActivity(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Error(c, domainerrors.Unauthorized("User not authenticated"))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	page, err := h.service.ListActivity(c.Request.Context(), userID, c.Query("cursor"), limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{
+		"items": page.Items,
+		"pagination": gin.H{
+			"limit":      limit,
+			"nextCursor": page.NextCursor,
+			"hasMore":    page.HasMore,
+		},
+	})
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+)
+
+type activityServiceStub struct {
+	lastCursor string
+	lastLimit  int
+}
+
+func (s *activityServiceStub) ListActivity(_ context.Context, _ uuid.UUID, cursor string, limit int) (*entities.ActivityPage, error) {
+	s.lastCursor, s.lastLimit = cursor, limit
+	if cursor == "bad" {
+		return nil, domainerrors.BadRequest("invalid cursor")
+	}
+	return &entities.ActivityPage{
+		Items:      []*entities.ActivityItem{{Type: entities.ActivityTypePaymentRequest, ID: uuid.New()}},
+		NextCursor: "next",
+		HasMore:    true,
+	}, nil
+}
+
+func TestActivityHandler_ListActivity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := &activityServiceStub{}
+	h := NewActivityHandler(svc)
+	r := gin.New()
+	r.GET("/activity", func(c *gin.Context) {
+		if c.Query("anon") == "" {
+			c.Set(middleware.UserIDKey, uuid.New())
+		}
+		h.ListActivity(c)
+	})
+
+	do := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := do("/activity?cursor=abc&limit=500")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "abc", svc.lastCursor)
+	require.Equal(t, 10, svc.lastLimit)
+	require.Contains(t, w.Body.String(), `"type":"payment_request"`)
+	require.Contains(t, w.Body.String(), `"nextCursor":"next"`)
+	require.Contains(t, w.Body.String(), `"hasMore":true`)
+
+	require.Equal(t, http.StatusBadRequest, do("/activity?cursor=bad").Code)
+	require.Equal(t, http.StatusUnauthorized, do("/activity?anon=1").Code)
+}
@@ -1,22 +1,43 @@
 package handlers
 
 import (
+	"context"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/infrastructure/jobs"
 	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/pkg/utils"
 )
 
+// jobHealthReporter is the part of jobs.Supervisor the job diagnostics need
+type jobHealthReporter interface {
+	Health() []jobs.JobHealth
+	Leader() bool
+}
+
+// userSessionRevoker is the part of redis.SessionStore suspension needs
+type userSessionRevoker interface {
+	DeleteUserSessions(ctx context.Context, userID string) error
+}
+
 // AdminHandler handles admin endpoints
 type AdminHandler struct {
 	userRepo              repositories.UserRepository
 	merchantRepo          repositories.MerchantRepository
 	paymentRepo           repositories.PaymentRepository
 	settlementProfileRepo repositories.MerchantSettlementProfileRepository
+	jobs                  jobHealthReporter
+	sessions              userSessionRevoker
 }
 
 // NewAdminHandler creates a new admin handler
@@ -34,17 +55,99 @@ func NewAdminHandler(
 	}
 }
 
-// ListUsers lists all users
-// GET /api/v1/admin/users
+// NewAdminHandlerWithJobs is NewAdminHandler that also reports background job health
+func NewAdminHandlerWithJobs(
+	userRepo repositories.UserRepository,
+	merchantRepo repositories.MerchantRepository,
+	paymentRepo repositories.PaymentRepository,
+	settlementProfileRepo repositories.MerchantSettlementProfileRepository,
+	jobs jobHealthReporter,
+) *AdminHandler {
+	h := NewAdminHandler(userRepo, merchantRepo, paymentRepo, settlementProfileRepo)
+	h.jobs = jobs
+	return h
+}
+
+// NewAdminHandlerWithSessions is NewAdminHandlerWithJobs that also signs suspended users out
+func NewAdminHandlerWithSessions(
+	userRepo repositories.UserRepository,
+	merchantRepo repositories.MerchantRepository,
+	paymentRepo repositories.PaymentRepository,
+	settlementProfileRepo repositories.MerchantSettlementProfileRepository,
+	jobs jobHealthReporter,
+	sessions userSessionRevoker,
+) *AdminHandler {
+	h := NewAdminHandlerWithJobs(userRepo, merchantRepo, paymentRepo, settlementProfileRepo, jobs)
+	h.sessions = sessions
+	return h
+}
+
+// adminUsersMaxLimit caps one page of GET /admin/users
+const adminUsersMaxLimit = 100
+
+// ListUsers lists users a page at a time, newest first
+// GET /api/v1/admin/users?search=ali&role=USER&verified=true&sortOrder=asc&page=1&limit=20
 func (h *AdminHandler) ListUsers(c *gin.Context) {
-	search := c.Query("search")
-	users, err := h.userRepo.List(c.Request.Context(), search)
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > adminUsersMaxLimit {
+		limit = 20
+	}
+	pagination := utils.GetPaginationParams(page, limit)
+
+	filter, err := parseUserFilter(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	users, total, err := h.userRepo.List(c.Request.Context(), filter, pagination)
 	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	response.Success(c, http.StatusOK, gin.H{"users": users})
+	response.Success(c, http.StatusOK, gin.H{
+		"users": users,
+		"meta":  utils.CalculateMeta(total, pagination.Page, pagination.Limit),
+	})
+}
+
+var adminUserRoles = map[entities.UserRole]bool{
+	entities.UserRoleAdmin:    true,
+	entities.UserRoleSubAdmin: true,
+	entities.UserRolePartner:  true,
+	entities.UserRoleUser:     true,
+	entities.UserRoleSupport:  true,
+	entities.UserRoleFinance:  true,
+}
+
+func parseUserFilter(c *gin.Context) (repositories.UserFilter, error) {
+	filter := repositories.UserFilter{Search: strings.TrimSpace(c.Query("search"))}
+
+	if raw := strings.TrimSpace(c.Query("role")); raw != "" {
+		role := entities.UserRole(strings.ToUpper(raw))
+		if !adminUserRoles[role] {
+			return filter, domainerrors.BadRequest("invalid role")
+		}
+		filter.Role = role
+	}
+	if raw := strings.TrimSpace(c.Query("verified")); raw != "" {
+		verified, err := strconv.ParseBool(raw)
+		if err != nil {
+			return filter, domainerrors.BadRequest("verified must be true or false")
+		}
+		filter.Verified = &verified
+	}
+	switch strings.ToLower(strings.TrimSpace(c.Query("sortOrder"))) {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		return filter, domainerrors.BadRequest("invalid sortOrder")
+	}
+
+	return filter, nil
 }
 
 // ListMerchants lists all merchants
@@ -87,6 +190,8 @@ func (h *AdminHandler) ListMerchants(c *gin.Context) {
 			"callbackUrl":                 merchant.CallbackURL,
 			"supportEmail":                merchant.SupportEmail,
 			"logoUrl":                     merchant.LogoURL,
+			"feeExempt":                   merchant.FeeExempt,
+			"minConfirmations":            merchant.MinConfirmations,
 			"verifiedAt":                  merchant.VerifiedAt,
 			"createdAt":                   merchant.CreatedAt,
 			"updatedAt":                   merchant.UpdatedAt,
@@ -131,6 +236,228 @@ func (h *AdminHandler) UpdateMerchantStatus(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"message": "Merchant status updated", "status": input.Status})
 }
 
+// UpdateUserFeeExempt toggles the platform fee waiver for an internal/test user
+// PUT /api/v1/admin/users/:id/fee-exempt
+func (h *AdminHandler) UpdateUserFeeExempt(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid user ID"))
+		return
+	}
+
+	var input struct {
+		FeeExempt *bool `json:"feeExempt" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+
+	user, err := h.userRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == domainerrors.ErrNotFound {
+			response.Error(c, domainerrors.NotFound("User not found"))
+			return
+		}
+		response.Error(c, err)
+		return
+	}
+
+	user.FeeExempt = *input.FeeExempt
+	if err := h.userRepo.Update(c.Request.Context(), user); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"id": user.ID, "feeExempt": user.FeeExempt})
+}
+
+// UpdateUserRole changes a user's role, e.g. to give staff SUPPORT or FINANCE access. The
+// user's sessions are deleted so the new role applies from their next sign-in.
+// PUT /api/v1/admin/users/:id/role
+func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid user ID"))
+		return
+	}
+
+	var input struct {
+		Role string `json:"role" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+	role := entities.UserRole(strings.ToUpper(strings.TrimSpace(input.Role)))
+	if !adminUserRoles[role] {
+		response.Error(c, domainerrors.BadRequest("invalid role"))
+		return
+	}
+	if adminID, ok := middleware.GetUserID(c); ok && adminID == id {
+		response.Error(c, domainerrors.BadRequest("Cannot change your own role"))
+		return
+	}
+
+	user, err := h.userRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == domainerrors.ErrNotFound {
+			response.Error(c, domainerrors.NotFound("User not found"))
+			return
+		}
+		response.Error(c, err)
+		return
+	}
+	if user.Role == role {
+		response.Success(c, http.StatusOK, gin.H{"id": user.ID, "role": user.Role})
+		return
+	}
+
+	user.Role = role
+	if err := h.userRepo.Update(c.Request.Context(), user); err != nil {
+		response.Error(c, err)
+		return
+	}
+	h.revokeSessions(c.Request.Context(), user.ID)
+
+	response.Success(c, http.StatusOK, gin.H{"id": user.ID, "role": user.Role})
+}
+
+// revokeSessions signs a user out after a change to their access. It is best effort: refresh
+// and signed requests re-read the account anyway.
+func (h *AdminHandler) revokeSessions(ctx context.Context, userID uuid.UUID) {
+	if h.sessions == nil {
+		return
+	}
+	if err := h.sessions.DeleteUserSessions(ctx, userID.String()); err != nil {
+		log.Printf("[AdminHandler] Failed to delete sessions of user %s: %v", userID, err)
+	}
+}
+
+// UpdateUserSuspended suspends or reinstates a user. Suspended users keep their data for audit
+// but cannot sign in, refresh or use their API keys, and their sessions are deleted.
+// PUT /api/v1/admin/users/:id/suspended
+func (h *AdminHandler) UpdateUserSuspended(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid user ID"))
+		return
+	}
+
+	var input struct {
+		Suspended *bool `json:"suspended" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+	if adminID, ok := middleware.GetUserID(c); ok && adminID == id && *input.Suspended {
+		response.Error(c, domainerrors.BadRequest("Cannot suspend your own account"))
+		return
+	}
+
+	user, err := h.userRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == domainerrors.ErrNotFound {
+			response.Error(c, domainerrors.NotFound("User not found"))
+			return
+		}
+		response.Error(c, err)
+		return
+	}
+
+	switch {
+	case *input.Suspended && !user.IsSuspended():
+		now := time.Now()
+		user.SuspendedAt = &now
+	case !*input.Suspended:
+		user.SuspendedAt = nil
+	}
+	if err := h.userRepo.Update(c.Request.Context(), user); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if user.IsSuspended() {
+		h.revokeSessions(c.Request.Context(), user.ID)
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"id": user.ID, "suspended": user.IsSuspended(), "suspendedAt": user.SuspendedAt})
+}
+
+// UpdateMerchantFeeExempt toggles the platform fee waiver for an internal/test merchant
+// PUT /api/v1/admin/merchants/:id/fee-exempt
+func (h *AdminHandler) UpdateMerchantFeeExempt(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid merchant ID"))
+		return
+	}
+
+	var input struct {
+		FeeExempt *bool `json:"feeExempt" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+
+	merchant, err := h.merchantRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == domainerrors.ErrNotFound {
+			response.Error(c, domainerrors.NotFound("Merchant not found"))
+			return
+		}
+		response.Error(c, err)
+		return
+	}
+
+	merchant.FeeExempt = *input.FeeExempt
+	if err := h.merchantRepo.Update(c.Request.Context(), merchant); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"id": merchant.ID, "feeExempt": merchant.FeeExempt})
+}
+
+// UpdateMerchantConfirmations sets how many confirmations the merchant's payments need before
+// they complete, on top of each destination chain's own threshold
+// PUT /api/v1/admin/merchants/:id/confirmations
+func (h *AdminHandler) UpdateMerchantConfirmations(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid merchant ID"))
+		return
+	}
+
+	var input struct {
+		MinConfirmations *int `json:"minConfirmations" binding:"required,min=0,max=1000"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+
+	merchant, err := h.merchantRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == domainerrors.ErrNotFound {
+			response.Error(c, domainerrors.NotFound("Merchant not found"))
+			return
+		}
+		response.Error(c, err)
+		return
+	}
+
+	merchant.MinConfirmations = *input.MinConfirmations
+	if err := h.merchantRepo.Update(c.Request.Context(), merchant); err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"id": merchant.ID, "minConfirmations": merchant.MinConfirmations})
+}
+
 // GetStats returns dashboard stats
 // GET /api/v1/admin/stats
 func (h *AdminHandler) GetStats(c *gin.Context) {
@@ -152,6 +479,24 @@ func (h *AdminHandler) GetLegacyEndpointObservability(c *gin.Context) {
 	response.Success(c, http.StatusOK, middleware.GetLegacyEndpointObservabilitySnapshot())
 }
 
+// GetJobHealth reports every supervised background job; healthy is false while any of them is
+// neither running nor on standby for another leader replica
+// GET /api/v1/admin/diagnostics/jobs
+func (h *AdminHandler) GetJobHealth(c *gin.Context) {
+	if h.jobs == nil {
+		response.Error(c, domainerrors.InternalServerError("job diagnostics not configured"))
+		return
+	}
+	items := h.jobs.Health()
+	healthy := true
+	for _, item := range items {
+		if item.Status != jobs.JobStatusRunning && item.Status != jobs.JobStatusStandby {
+			healthy = false
+		}
+	}
+	response.Success(c, http.StatusOK, gin.H{"healthy": healthy, "leader": h.jobs.Leader(), "jobs": items})
+}
+
 // GetSettlementProfileGaps lists merchants missing dedicated settlement profiles.
 // GET /api/v1/admin/diagnostics/settlement-profile-gaps
 func (h *AdminHandler) GetSettlementProfileGaps(c *gin.Context) {
@@ -11,33 +11,49 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/infrastructure/jobs"
 	"payment-kita.backend/internal/infrastructure/repositories"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/pkg/utils"
 )
 
 type adminUserRepoStub struct {
-	listFn func(ctx context.Context, search string) ([]*entities.User, error)
+	listFn    func(ctx context.Context, filter domainrepos.UserFilter, pagination utils.PaginationParams) ([]*entities.User, int64, error)
+	getByIDFn func(ctx context.Context, id uuid.UUID) (*entities.User, error)
+	updateFn  func(ctx context.Context, user *entities.User) error
 }
 
 func (s *adminUserRepoStub) Create(context.Context, *entities.User) error { return nil }
-func (s *adminUserRepoStub) GetByID(context.Context, uuid.UUID) (*entities.User, error) {
+func (s *adminUserRepoStub) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	if s.getByIDFn != nil {
+		return s.getByIDFn(ctx, id)
+	}
 	return nil, nil
 }
 func (s *adminUserRepoStub) GetByEmail(context.Context, string) (*entities.User, error) {
 	return nil, nil
 }
-func (s *adminUserRepoStub) Update(context.Context, *entities.User) error            { return nil }
+func (s *adminUserRepoStub) Update(ctx context.Context, user *entities.User) error {
+	if s.updateFn != nil {
+		return s.updateFn(ctx, user)
+	}
+	return nil
+}
 func (s *adminUserRepoStub) UpdatePassword(context.Context, uuid.UUID, string) error { return nil }
 func (s *adminUserRepoStub) SoftDelete(context.Context, uuid.UUID) error             { return nil }
-func (s *adminUserRepoStub) List(ctx context.Context, search string) ([]*entities.User, error) {
-	return s.listFn(ctx, search)
+func (s *adminUserRepoStub) List(ctx context.Context, filter domainrepos.UserFilter, pagination utils.PaginationParams) ([]*entities.User, int64, error) {
+	return s.listFn(ctx, filter, pagination)
 }
 
 type adminMerchantRepoStub struct {
-	listFn   func(ctx context.Context) ([]*entities.Merchant, error)
-	getByID  func(ctx context.Context, id uuid.UUID) (*entities.Merchant, error)
-	updateFn func(ctx context.Context, id uuid.UUID, status entities.MerchantStatus) error
+	listFn           func(ctx context.Context) ([]*entities.Merchant, error)
+	getByID          func(ctx context.Context, id uuid.UUID) (*entities.Merchant, error)
+	updateFn         func(ctx context.Context, id uuid.UUID, status entities.MerchantStatus) error
+	updateMerchantFn func(ctx context.Context, merchant *entities.Merchant) error
 }
 
 func (s *adminMerchantRepoStub) Create(context.Context, *entities.Merchant) error { return nil }
@@ -47,7 +63,12 @@ func (s *adminMerchantRepoStub) GetByID(ctx context.Context, id uuid.UUID) (*ent
 func (s *adminMerchantRepoStub) GetByUserID(context.Context, uuid.UUID) (*entities.Merchant, error) {
 	return nil, nil
 }
-func (s *adminMerchantRepoStub) Update(context.Context, *entities.Merchant) error { return nil }
+func (s *adminMerchantRepoStub) Update(ctx context.Context, merchant *entities.Merchant) error {
+	if s.updateMerchantFn != nil {
+		return s.updateMerchantFn(ctx, merchant)
+	}
+	return nil
+}
 func (s *adminMerchantRepoStub) UpdateStatus(ctx context.Context, id uuid.UUID, status entities.MerchantStatus) error {
 	if s.updateFn != nil {
 		return s.updateFn(ctx, id, status)
@@ -65,12 +86,21 @@ func (adminPaymentRepoStub) Create(context.Context, *entities.Payment) error { r
 func (adminPaymentRepoStub) GetByID(context.Context, uuid.UUID) (*entities.Payment, error) {
 	return nil, nil
 }
-func (adminPaymentRepoStub) GetByUserID(context.Context, uuid.UUID, int, int) ([]*entities.Payment, int, error) {
+func (adminPaymentRepoStub) GetByUserID(context.Context, uuid.UUID, entities.PaymentMode, utils.PaginationParams) ([]*entities.Payment, int64, error) {
 	return nil, 0, nil
 }
-func (adminPaymentRepoStub) GetByMerchantID(context.Context, uuid.UUID, int, int) ([]*entities.Payment, int, error) {
+func (adminPaymentRepoStub) GetByMerchantID(context.Context, uuid.UUID, entities.PaymentMode, utils.PaginationParams) ([]*entities.Payment, int64, error) {
 	return nil, 0, nil
 }
+func (adminPaymentRepoStub) GetByMerchantExternalRef(context.Context, uuid.UUID, entities.PaymentMode, string, utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	return nil, 0, nil
+}
+func (adminPaymentRepoStub) GetByStatus(context.Context, entities.PaymentStatus, int, int) ([]*entities.Payment, int, error) {
+	return nil, 0, nil
+}
+func (adminPaymentRepoStub) ResolvePendingApproval(context.Context, uuid.UUID, entities.PaymentStatus, null.String, *time.Time) error {
+	return nil
+}
 func (adminPaymentRepoStub) UpdateStatus(context.Context, uuid.UUID, entities.PaymentStatus) error {
 	return nil
 }
@@ -81,14 +111,17 @@ func (adminPaymentRepoStub) Update(context.Context, *entities.Payment) error
 func TestAdminHandler_ListAndUpdateStatus(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	merchantID := uuid.New()
+	var listedFilter domainrepos.UserFilter
+	var listedPage utils.PaginationParams
 
 	h := NewAdminHandler(
 		&adminUserRepoStub{
-			listFn: func(_ context.Context, search string) ([]*entities.User, error) {
-				if search != "abc" {
-					t.Fatalf("unexpected search %s", search)
+			listFn: func(_ context.Context, filter domainrepos.UserFilter, pagination utils.PaginationParams) ([]*entities.User, int64, error) {
+				if filter.Search != "abc" {
+					t.Fatalf("unexpected search %s", filter.Search)
 				}
-				return []*entities.User{{ID: uuid.New(), Email: "u@paymentkita.io"}}, nil
+				listedFilter, listedPage = filter, pagination
+				return []*entities.User{{ID: uuid.New(), Email: "u@paymentkita.io"}}, 45, nil
 			},
 		},
 		&adminMerchantRepoStub{
@@ -116,6 +149,25 @@ func TestAdminHandler_ListAndUpdateStatus(t *testing.T) {
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 	require.Contains(t, w.Body.String(), "u@paymentkita.io")
+	require.Equal(t, utils.PaginationParams{Page: 1, Limit: 20}, listedPage)
+	require.Nil(t, listedFilter.Verified)
+
+	req = httptest.NewRequest(http.MethodGet, "/users?search=abc&role=sub_admin&verified=true&sortOrder=asc&page=3&limit=10", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"totalCount":45`)
+	require.Equal(t, entities.UserRoleSubAdmin, listedFilter.Role)
+	require.True(t, *listedFilter.Verified)
+	require.True(t, listedFilter.Ascending)
+	require.Equal(t, utils.PaginationParams{Page: 3, Limit: 10}, listedPage)
+
+	for _, query := range []string{"role=owner", "verified=maybe", "sortOrder=sideways"} {
+		req = httptest.NewRequest(http.MethodGet, "/users?search=abc&"+query, nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
 
 	req = httptest.NewRequest(http.MethodGet, "/merchants", nil)
 	w = httptest.NewRecorder()
@@ -137,6 +189,205 @@ func TestAdminHandler_ListAndUpdateStatus(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestAdminHandler_UpdateFeeExempt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	merchantID := uuid.New()
+	var savedUser *entities.User
+	var savedMerchant *entities.Merchant
+
+	h := NewAdminHandler(
+		&adminUserRepoStub{
+			getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.User, error) {
+				if id == userID {
+					return &entities.User{ID: userID}, nil
+				}
+				return nil, domainerrors.ErrNotFound
+			},
+			updateFn: func(_ context.Context, user *entities.User) error {
+				savedUser = user
+				return nil
+			},
+		},
+		&adminMerchantRepoStub{
+			getByID: func(_ context.Context, id uuid.UUID) (*entities.Merchant, error) {
+				if id == merchantID {
+					return &entities.Merchant{ID: merchantID}, nil
+				}
+				return nil, domainerrors.ErrNotFound
+			},
+			updateMerchantFn: func(_ context.Context, merchant *entities.Merchant) error {
+				savedMerchant = merchant
+				return nil
+			},
+		},
+		adminPaymentRepoStub{},
+		nil,
+	)
+
+	r := gin.New()
+	r.PUT("/users/:id/fee-exempt", h.UpdateUserFeeExempt)
+	r.PUT("/merchants/:id/fee-exempt", h.UpdateMerchantFeeExempt)
+	r.PUT("/merchants/:id/confirmations", h.UpdateMerchantConfirmations)
+
+	do := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("/users/"+userID.String()+"/fee-exempt", `{"feeExempt":true}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, savedUser)
+	require.True(t, savedUser.FeeExempt)
+
+	w = do("/merchants/"+merchantID.String()+"/fee-exempt", `{"feeExempt":true}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, savedMerchant)
+	require.True(t, savedMerchant.FeeExempt)
+
+	require.Equal(t, http.StatusBadRequest, do("/users/not-a-uuid/fee-exempt", `{"feeExempt":true}`).Code)
+	require.Equal(t, http.StatusBadRequest, do("/merchants/"+merchantID.String()+"/fee-exempt", `{}`).Code)
+	require.Equal(t, http.StatusNotFound, do("/users/"+uuid.NewString()+"/fee-exempt", `{"feeExempt":false}`).Code)
+	require.Equal(t, http.StatusNotFound, do("/merchants/"+uuid.NewString()+"/fee-exempt", `{"feeExempt":false}`).Code)
+
+	w = do("/merchants/"+merchantID.String()+"/confirmations", `{"minConfirmations":12}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 12, savedMerchant.MinConfirmations)
+	require.Equal(t, http.StatusOK, do("/merchants/"+merchantID.String()+"/confirmations", `{"minConfirmations":0}`).Code)
+	require.Equal(t, 0, savedMerchant.MinConfirmations)
+	require.Equal(t, http.StatusBadRequest, do("/merchants/"+merchantID.String()+"/confirmations", `{}`).Code)
+	require.Equal(t, http.StatusBadRequest, do("/merchants/"+merchantID.String()+"/confirmations", `{"minConfirmations":-1}`).Code)
+	require.Equal(t, http.StatusNotFound, do("/merchants/"+uuid.NewString()+"/confirmations", `{"minConfirmations":1}`).Code)
+}
+
+type sessionRevokerStub struct {
+	revoked []string
+}
+
+func (s *sessionRevokerStub) DeleteUserSessions(_ context.Context, userID string) error {
+	s.revoked = append(s.revoked, userID)
+	return nil
+}
+
+func TestAdminHandler_UpdateUserSuspended(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	adminID := uuid.New()
+	stored := &entities.User{ID: uuid.New()}
+	sessions := &sessionRevokerStub{}
+
+	h := NewAdminHandlerWithSessions(
+		&adminUserRepoStub{
+			getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.User, error) {
+				if id == stored.ID {
+					copied := *stored
+					return &copied, nil
+				}
+				return nil, domainerrors.ErrNotFound
+			},
+			updateFn: func(_ context.Context, user *entities.User) error {
+				stored = user
+				return nil
+			},
+		},
+		&adminMerchantRepoStub{},
+		adminPaymentRepoStub{},
+		nil,
+		nil,
+		sessions,
+	)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(middleware.UserIDKey, adminID) })
+	r.PUT("/users/:id/suspended", h.UpdateUserSuspended)
+	do := func(id, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/users/"+id+"/suspended", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(stored.ID.String(), `{"suspended":true}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, stored.IsSuspended())
+	require.Equal(t, []string{stored.ID.String()}, sessions.revoked)
+	require.Contains(t, w.Body.String(), `"suspended":true`)
+
+	// Suspending again keeps the original timestamp
+	suspendedAt := *stored.SuspendedAt
+	require.Equal(t, http.StatusOK, do(stored.ID.String(), `{"suspended":true}`).Code)
+	require.Equal(t, suspendedAt, *stored.SuspendedAt)
+
+	w = do(stored.ID.String(), `{"suspended":false}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.False(t, stored.IsSuspended())
+	require.Len(t, sessions.revoked, 2)
+
+	require.Equal(t, http.StatusBadRequest, do(adminID.String(), `{"suspended":true}`).Code)
+	require.Equal(t, http.StatusBadRequest, do(stored.ID.String(), `{}`).Code)
+	require.Equal(t, http.StatusBadRequest, do("not-a-uuid", `{"suspended":true}`).Code)
+	require.Equal(t, http.StatusNotFound, do(uuid.NewString(), `{"suspended":true}`).Code)
+}
+
+func TestAdminHandler_UpdateUserRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	adminID := uuid.New()
+	stored := &entities.User{ID: uuid.New(), Role: entities.UserRoleUser}
+	updates := 0
+	sessions := &sessionRevokerStub{}
+
+	h := NewAdminHandlerWithSessions(
+		&adminUserRepoStub{
+			getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.User, error) {
+				if id == stored.ID {
+					copied := *stored
+					return &copied, nil
+				}
+				return nil, domainerrors.ErrNotFound
+			},
+			updateFn: func(_ context.Context, user *entities.User) error {
+				updates++
+				stored = user
+				return nil
+			},
+		},
+		&adminMerchantRepoStub{},
+		adminPaymentRepoStub{},
+		nil,
+		nil,
+		sessions,
+	)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(middleware.UserIDKey, adminID) })
+	r.PUT("/users/:id/role", h.UpdateUserRole)
+	do := func(id, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/users/"+id+"/role", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(stored.ID.String(), `{"role":"support"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, entities.UserRoleSupport, stored.Role)
+	require.Equal(t, []string{stored.ID.String()}, sessions.revoked)
+
+	// The same role again changes nothing
+	require.Equal(t, http.StatusOK, do(stored.ID.String(), `{"role":"SUPPORT"}`).Code)
+	require.Equal(t, 1, updates)
+	require.Len(t, sessions.revoked, 1)
+
+	require.Equal(t, http.StatusBadRequest, do(stored.ID.String(), `{"role":"ROOT"}`).Code)
+	require.Equal(t, http.StatusBadRequest, do(stored.ID.String(), `{}`).Code)
+	require.Equal(t, http.StatusBadRequest, do(adminID.String(), `{"role":"USER"}`).Code)
+	require.Equal(t, http.StatusNotFound, do(uuid.NewString(), `{"role":"FINANCE"}`).Code)
+}
+
 func TestAdminHandler_GetSettlementProfileGaps(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	testDB := repositoriesTestDBForAdminSettlement(t)
@@ -171,3 +422,43 @@ func TestAdminHandler_GetSettlementProfileGaps(t *testing.T) {
 	require.Contains(t, w.Body.String(), "missing@example.com")
 	require.NotContains(t, w.Body.String(), "configured@example.com")
 }
+
+type jobHealthReporterStub []jobs.JobHealth
+
+func (s jobHealthReporterStub) Health() []jobs.JobHealth { return s }
+func (s jobHealthReporterStub) Leader() bool {
+	return len(s) == 0 || s[0].Status != jobs.JobStatusStandby
+}
+
+func TestAdminHandler_GetJobHealth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reporter := jobHealthReporterStub{
+		{Name: "payment-request-expiry", Status: jobs.JobStatusRunning},
+		{Name: "webhook-delivery", Status: jobs.JobStatusRestarting, Restarts: 1, LastPanic: "boom"},
+	}
+
+	get := func(h *AdminHandler) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.GET("/admin/diagnostics/jobs", h.GetJobHealth)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/diagnostics/jobs", nil))
+		return w
+	}
+
+	w := get(NewAdminHandlerWithJobs(nil, nil, nil, nil, reporter))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"healthy":false`)
+	require.Contains(t, w.Body.String(), `"lastPanic":"boom"`)
+
+	w = get(NewAdminHandlerWithJobs(nil, nil, nil, nil, reporter[:1]))
+	require.Contains(t, w.Body.String(), `"healthy":true`)
+	require.Contains(t, w.Body.String(), `"leader":true`)
+
+	// A follower replica is healthy while its jobs wait for the leader
+	w = get(NewAdminHandlerWithJobs(nil, nil, nil, nil, jobHealthReporterStub{{Name: "webhook-delivery", Status: jobs.JobStatusStandby}}))
+	require.Contains(t, w.Body.String(), `"healthy":true`)
+	require.Contains(t, w.Body.String(), `"leader":false`)
+
+	w = get(NewAdminHandler(nil, nil, nil, nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
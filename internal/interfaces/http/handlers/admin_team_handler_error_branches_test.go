@@ -14,7 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/pkg/utils"
 )
 
 func TestAdminHandler_ErrorBranchesAndStats(t *testing.T) {
@@ -24,8 +26,8 @@ func TestAdminHandler_ErrorBranchesAndStats(t *testing.T) {
 
 	h := NewAdminHandler(
 		&adminUserRepoStub{
-			listFn: func(context.Context, string) ([]*entities.User, error) {
-				return nil, errors.New("list users failed")
+			listFn: func(context.Context, domainrepos.UserFilter, utils.PaginationParams) ([]*entities.User, int64, error) {
+				return nil, 0, errors.New("list users failed")
 			},
 		},
 		&adminMerchantRepoStub{
@@ -12,8 +12,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/utils"
 )
 
 type apiKeyRepoStub struct {
@@ -64,7 +66,9 @@ func (apiKeyUserRepoStub) GetByEmail(context.Context, string) (*entities.User, e
 func (apiKeyUserRepoStub) Update(context.Context, *entities.User) error            { return nil }
 func (apiKeyUserRepoStub) UpdatePassword(context.Context, uuid.UUID, string) error { return nil }
 func (apiKeyUserRepoStub) SoftDelete(context.Context, uuid.UUID) error             { return nil }
-func (apiKeyUserRepoStub) List(context.Context, string) ([]*entities.User, error)  { return nil, nil }
+func (apiKeyUserRepoStub) List(context.Context, repositories.UserFilter, utils.PaginationParams) ([]*entities.User, int64, error) {
+	return nil, 0, nil
+}
 
 func TestApiKeyHandler_CreateListRevoke(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -91,6 +95,7 @@ func TestApiKeyHandler_CreateListRevoke(t *testing.T) {
 		repo,
 		apiKeyUserRepoStub{},
 		"00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff",
+		"",
 	)
 	h := NewApiKeyHandler(uc)
 
@@ -131,6 +136,7 @@ func TestApiKeyHandler_ValidationPaths(t *testing.T) {
 		&apiKeyRepoStub{},
 		apiKeyUserRepoStub{},
 		"00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff",
+		"",
 	)
 	h := NewApiKeyHandler(uc)
 
@@ -175,6 +181,7 @@ func TestApiKeyHandler_ErrorPaths(t *testing.T) {
 		repo,
 		apiKeyUserRepoStub{},
 		"00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff",
+		"",
 	)
 	h := NewApiKeyHandler(uc)
 
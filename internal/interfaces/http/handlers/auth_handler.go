@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
@@ -26,6 +27,8 @@ type AuthService interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (*entities.User, error)
 	GetTokenExpiry(token string) (int64, error)
 	ChangePassword(ctx context.Context, userID uuid.UUID, input *entities.ChangePasswordInput) error
+	Impersonate(ctx context.Context, adminID, targetUserID uuid.UUID) (*entities.ImpersonationSession, error)
+	IssueWalletChallenge(ctx context.Context, input *entities.WalletChallengeInput) (*entities.WalletChallenge, error)
 }
 
 type SessionStore interface {
@@ -81,6 +84,29 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	})
 }
 
+// WalletChallenge issues the challenge a wallet signs to be adopted at registration
+// POST /api/v1/auth/wallet-challenge
+func (h *AuthHandler) WalletChallenge(c *gin.Context) {
+	var input entities.WalletChallengeInput
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+	if input.Email == "" {
+		response.Error(c, domainerrors.BadRequest("email is required"))
+		return
+	}
+
+	challenge, err := h.authUsecase.IssueWalletChallenge(c.Request.Context(), &input)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, challenge)
+}
+
 // Login handles user login
 // POST /api/v1/auth/login
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -97,6 +123,10 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			response.Error(c, domainerrors.NewAppError(http.StatusUnauthorized, domainerrors.CodeInvalidCredentials, "Invalid email or password", domainerrors.ErrInvalidCredentials))
 			return
 		}
+		if err == domainerrors.ErrAccountSuspended {
+			response.Error(c, domainerrors.AccountSuspended())
+			return
+		}
 		response.Error(c, err)
 		return
 	}
@@ -109,6 +139,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	sessionData := &redis.SessionData{
 		AccessToken:  authResponse.AccessToken,
 		RefreshToken: authResponse.RefreshToken,
+		UserID:       authResponse.User.ID.String(),
 	}
 	// We need config for expiry? Or use hardcoded defaults matching JWT?
 	// The implementation plan says "Use RefreshToken expiry".
@@ -173,7 +204,7 @@ func (h *AuthHandler) VerifyEmail(c *gin.Context) {
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	log.Printf("[AuthHandler] RefreshToken: Request received. Content-Length: %d", c.Request.ContentLength)
 
-	var refreshToken string
+	var refreshToken, sessionUserID string
 	strictSessionMode := os.Getenv("INTERNAL_PROXY_SECRET") != ""
 
 	// 1. Try to get from Redis session (session_id header/cookie)
@@ -184,6 +215,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	if sessionID != "" && middleware.IsTrustedProxyRequest(c) {
 		if session, sessErr := h.sessionStore.GetSession(c.Request.Context(), sessionID); sessErr == nil && session != nil {
 			refreshToken = session.RefreshToken
+			sessionUserID = session.UserID
 			log.Println("[AuthHandler] RefreshToken: Token loaded from Redis session")
 		}
 	}
@@ -220,6 +252,13 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	}
 
 	tokenPair, err := h.authUsecase.RefreshToken(c.Request.Context(), refreshToken)
+	if err == domainerrors.ErrAccountSuspended {
+		if sessionID != "" {
+			_ = h.sessionStore.DeleteSession(c.Request.Context(), sessionID)
+		}
+		response.Error(c, domainerrors.AccountSuspended())
+		return
+	}
 	if err != nil {
 		response.Error(c, domainerrors.NewAppError(http.StatusUnauthorized, domainerrors.CodeUnauthorized, "Invalid or expired refresh token", err))
 		return
@@ -234,6 +273,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	newData := &redis.SessionData{
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
+		UserID:       sessionUserID,
 	}
 	err = h.sessionStore.CreateSession(c.Request.Context(), sessionID, newData, 7*24*time.Hour)
 	if err != nil {
@@ -348,6 +388,56 @@ func (h *AuthHandler) ChangePassword(c *gin.Context) {
 	})
 }
 
+// Impersonate opens a short-lived, read-only session as another user for support.
+// The admin's own session is left untouched; the returned sessionId is used separately.
+// POST /api/v1/admin/users/:id/impersonate
+func (h *AuthHandler) Impersonate(c *gin.Context) {
+	adminID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Error(c, domainerrors.Unauthorized("Unauthorized"))
+		return
+	}
+	if _, nested := middleware.GetImpersonatorID(c); nested {
+		response.Error(c, domainerrors.Forbidden("Cannot impersonate while impersonating"))
+		return
+	}
+	targetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid user ID"))
+		return
+	}
+
+	session, err := h.authUsecase.Impersonate(c.Request.Context(), adminID, targetID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrNotFound):
+			response.Error(c, domainerrors.NotFound("User not found"))
+		case errors.Is(err, domainerrors.ErrForbidden):
+			response.Error(c, domainerrors.Forbidden("Only admins can impersonate users"))
+		default:
+			response.Error(c, err)
+		}
+		return
+	}
+
+	sessionID := utils.GenerateUUIDv7().String()
+	err = h.sessionStore.CreateSession(c.Request.Context(), sessionID, &redis.SessionData{
+		AccessToken: session.AccessToken,
+		UserID:      targetID.String(),
+	}, time.Until(session.ExpiresAt))
+	if err != nil {
+		response.Error(c, domainerrors.InternalError(err))
+		return
+	}
+	session.SessionID = sessionID
+
+	// Picked up by the impersonation audit middleware.
+	c.Set(middleware.ImpersonationTargetKey, targetID)
+	log.Printf("[AuthHandler] Admin %s started impersonating user %s until %s", adminID, targetID, session.ExpiresAt.Format(time.RFC3339))
+
+	response.Success(c, http.StatusCreated, session)
+}
+
 // GetSessionExpiry returns current access token expiry from Redis session.
 // GET /api/v1/auth/session-expiry
 func (h *AuthHandler) GetSessionExpiry(c *gin.Context) {
@@ -27,6 +27,8 @@ type authServiceStub struct {
 	getUserByIDFn   func(ctx context.Context, id uuid.UUID) (*entities.User, error)
 	getTokenExpFn   func(token string) (int64, error)
 	changePassFn    func(ctx context.Context, userID uuid.UUID, input *entities.ChangePasswordInput) error
+	impersonateFn   func(ctx context.Context, adminID, targetUserID uuid.UUID) (*entities.ImpersonationSession, error)
+	challengeFn     func(ctx context.Context, input *entities.WalletChallengeInput) (*entities.WalletChallenge, error)
 }
 
 func (s authServiceStub) Register(ctx context.Context, input *entities.CreateUserInput) (*entities.User, string, error) {
@@ -50,6 +52,12 @@ func (s authServiceStub) GetTokenExpiry(token string) (int64, error) {
 func (s authServiceStub) ChangePassword(ctx context.Context, userID uuid.UUID, input *entities.ChangePasswordInput) error {
 	return s.changePassFn(ctx, userID, input)
 }
+func (s authServiceStub) Impersonate(ctx context.Context, adminID, targetUserID uuid.UUID) (*entities.ImpersonationSession, error) {
+	return s.impersonateFn(ctx, adminID, targetUserID)
+}
+func (s authServiceStub) IssueWalletChallenge(ctx context.Context, input *entities.WalletChallengeInput) (*entities.WalletChallenge, error) {
+	return s.challengeFn(ctx, input)
+}
 
 type sessionStoreStub struct {
 	createFn func(ctx context.Context, sessionID string, data *redis.SessionData, expiration time.Duration) error
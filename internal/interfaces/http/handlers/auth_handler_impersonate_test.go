@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/pkg/redis"
+)
+
+func TestAuthHandler_Impersonate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	adminID := uuid.New()
+	targetID := uuid.New()
+	var stored *redis.SessionData
+	var storedTTL time.Duration
+	createErr := error(nil)
+
+	h := NewAuthHandler(
+		authServiceStub{
+			impersonateFn: func(_ context.Context, gotAdmin, gotTarget uuid.UUID) (*entities.ImpersonationSession, error) {
+				if gotTarget != targetID {
+					return nil, domainerrors.ErrNotFound
+				}
+				return &entities.ImpersonationSession{
+					AccessToken:  "impersonation-token",
+					ExpiresAt:    time.Now().Add(15 * time.Minute),
+					User:         &entities.User{ID: gotTarget},
+					Impersonator: &entities.User{ID: gotAdmin},
+				}, nil
+			},
+		},
+		sessionStoreStub{
+			createFn: func(_ context.Context, _ string, data *redis.SessionData, expiration time.Duration) error {
+				stored, storedTTL = data, expiration
+				return createErr
+			},
+		},
+	)
+
+	newRouter := func(asImpersonator bool) *gin.Engine {
+		r := gin.New()
+		r.POST("/admin/users/:id/impersonate", func(c *gin.Context) {
+			c.Set(middleware.UserIDKey, adminID)
+			if asImpersonator {
+				c.Set(middleware.ImpersonatorIDKey, uuid.New())
+			}
+			c.Next()
+		}, h.Impersonate)
+		return r
+	}
+	do := func(r *gin.Engine, id string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/users/"+id+"/impersonate", nil))
+		return w
+	}
+
+	w := do(newRouter(false), targetID.String())
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Contains(t, w.Body.String(), `"sessionId"`)
+	require.NotContains(t, w.Body.String(), "impersonation-token")
+	require.Equal(t, "impersonation-token", stored.AccessToken)
+	require.Empty(t, stored.RefreshToken)
+	require.InDelta(t, (15 * time.Minute).Seconds(), storedTTL.Seconds(), 5)
+
+	require.Equal(t, http.StatusBadRequest, do(newRouter(false), "not-a-uuid").Code)
+	require.Equal(t, http.StatusNotFound, do(newRouter(false), uuid.NewString()).Code)
+	require.Equal(t, http.StatusForbidden, do(newRouter(true), targetID.String()).Code)
+
+	createErr = errors.New("redis down")
+	require.Equal(t, http.StatusInternalServerError, do(newRouter(false), targetID.String()).Code)
+}
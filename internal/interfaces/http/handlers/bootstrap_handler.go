@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/internal/usecases"
+)
+
+type bootstrapService interface {
+	Bootstrap(ctx context.Context) (*usecases.Bootstrap, string, error)
+}
+
+// BootstrapHandler serves the checkout UI's startup data in one response
+type BootstrapHandler struct {
+	usecase bootstrapService
+}
+
+// NewBootstrapHandler creates a new bootstrap handler
+func NewBootstrapHandler(usecase bootstrapService) *BootstrapHandler {
+	return &BootstrapHandler{usecase: usecase}
+}
+
+// GetBootstrap returns active chains with their tokens, the bridges and the route matrix, with
+// the cached payload's ETag. Routed behind middleware.ETag, which answers a matching
+// If-None-Match with 304.
+// GET /api/v1/bootstrap
+func (h *BootstrapHandler) GetBootstrap(c *gin.Context) {
+	bootstrap, etag, err := h.usecase.Bootstrap(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=30")
+	response.Success(c, http.StatusOK, bootstrap)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/internal/usecases"
+)
+
+type bootstrapServiceStub struct {
+	bootstrap *usecases.Bootstrap
+	etag      string
+}
+
+func (s *bootstrapServiceStub) Bootstrap(context.Context) (*usecases.Bootstrap, string, error) {
+	return s.bootstrap, s.etag, nil
+}
+
+func TestBootstrapHandler_GetBootstrap(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewBootstrapHandler(&bootstrapServiceStub{
+		bootstrap: &usecases.Bootstrap{Chains: []usecases.BootstrapChain{{CAIP2: "eip155:8453"}}},
+		etag:      `"abc"`,
+	})
+	r := gin.New()
+	r.Use(middleware.Compress())
+	r.GET("/bootstrap", middleware.ETag(), h.GetBootstrap)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/bootstrap", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w.Header().Get("ETag") != `"abc"` {
+		t.Fatalf("expected ETag header, got %q", w.Header().Get("ETag"))
+	}
+	var body usecases.Bootstrap
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Chains) != 1 || body.Chains[0].CAIP2 != "eip155:8453" {
+		t.Fatalf("unexpected body: %s", w.Body.String())
+	}
+
+	for _, header := range []string{`"abc"`, `W/"abc"`, `"old", "abc"`, "*"} {
+		if w := get(header); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+			t.Fatalf("If-None-Match %s: expected empty 304, got %d", header, w.Code)
+		}
+	}
+	if w := get(`"old"`); w.Code != http.StatusOK {
+		t.Fatalf("stale ETag: expected 200, got %d", w.Code)
+	}
+
+	// A client that may get a compressed body gets a weak validator, which still revalidates
+	req := httptest.NewRequest(http.MethodGet, "/bootstrap", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Header().Get("ETag") != `W/"abc"` {
+		t.Fatalf("expected weak ETag with Accept-Encoding, got %q", w.Header().Get("ETag"))
+	}
+	req.Header.Set("If-None-Match", `W/"abc"`)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("weak If-None-Match: expected empty 304, got %d", w.Code)
+	}
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/response"
+)
+
+const maxBulkActivateIDs = 500
+
+const (
+	bulkActivateStatusUpdated   = "UPDATED"
+	bulkActivateStatusUnchanged = "UNCHANGED"
+	bulkActivateStatusNotFound  = "NOT_FOUND"
+)
+
+type bulkActivateRequest struct {
+	IDs      []uuid.UUID `json:"ids" binding:"required,min=1"`
+	IsActive *bool       `json:"isActive" binding:"required"`
+}
+
+type bulkActivateResult struct {
+	ID       uuid.UUID `json:"id"`
+	Status   string    `json:"status"`
+	IsActive *bool     `json:"isActive,omitempty"`
+}
+
+// bindBulkActivateRequest parses the request and removes duplicate IDs, keeping their order
+func bindBulkActivateRequest(c *gin.Context) ([]uuid.UUID, bool, bool) {
+	var req bulkActivateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return nil, false, false
+	}
+	if len(req.IDs) > maxBulkActivateIDs {
+		response.Error(c, domainerrors.BadRequest("too many ids"))
+		return nil, false, false
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(req.IDs))
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, *req.IsActive, true
+}
+
+// bulkActivateResults reports the outcome for each requested ID from the previous states
+// returned by BulkSetActive
+func bulkActivateResults(ids []uuid.UUID, previous map[uuid.UUID]bool, active bool) ([]bulkActivateResult, map[string]int) {
+	summary := map[string]int{
+		bulkActivateStatusUpdated:   0,
+		bulkActivateStatusUnchanged: 0,
+		bulkActivateStatusNotFound:  0,
+	}
+	results := make([]bulkActivateResult, 0, len(ids))
+	for _, id := range ids {
+		wasActive, found := previous[id]
+		result := bulkActivateResult{ID: id}
+		switch {
+		case !found:
+			result.Status = bulkActivateStatusNotFound
+		case wasActive == active:
+			result.Status = bulkActivateStatusUnchanged
+			result.IsActive = &active
+		default:
+			result.Status = bulkActivateStatusUpdated
+			result.IsActive = &active
+		}
+		summary[result.Status]++
+		results = append(results, result)
+	}
+	return results, summary
+}
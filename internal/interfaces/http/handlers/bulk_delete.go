@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/response"
+)
+
+const (
+	bulkDeleteStatusDeleted    = "DELETED"
+	bulkDeleteStatusNotFound   = "NOT_FOUND"
+	bulkDeleteStatusReferenced = "REFERENCED"
+)
+
+type bulkDeleteRequest struct {
+	IDs  []uuid.UUID `json:"ids" binding:"required,min=1"`
+	Hard bool        `json:"hard"`
+}
+
+type bulkDeleteResult struct {
+	ID         uuid.UUID        `json:"id"`
+	Status     string           `json:"status"`
+	References map[string]int64 `json:"references,omitempty"`
+}
+
+// bindBulkDeleteRequest parses the request and removes duplicate IDs, keeping their order
+func bindBulkDeleteRequest(c *gin.Context) ([]uuid.UUID, bool, bool) {
+	var req bulkDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return nil, false, false
+	}
+	if len(req.IDs) > maxBulkActivateIDs {
+		response.Error(c, domainerrors.BadRequest("too many ids"))
+		return nil, false, false
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(req.IDs))
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, req.Hard, true
+}
+
+// bulkDelete deletes each ID on its own, so one referenced or missing record does not stop the
+// rest. Any other error aborts and is returned.
+func bulkDelete(ctx context.Context, ids []uuid.UUID, remove func(context.Context, uuid.UUID) error) ([]bulkDeleteResult, map[string]int, error) {
+	summary := map[string]int{
+		bulkDeleteStatusDeleted:    0,
+		bulkDeleteStatusNotFound:   0,
+		bulkDeleteStatusReferenced: 0,
+	}
+	results := make([]bulkDeleteResult, 0, len(ids))
+	for _, id := range ids {
+		result := bulkDeleteResult{ID: id, Status: bulkDeleteStatusDeleted}
+		var referenced *domainerrors.ReferencedError
+		err := remove(ctx, id)
+		switch {
+		case err == nil:
+		case errors.Is(err, domainerrors.ErrNotFound):
+			result.Status = bulkDeleteStatusNotFound
+		case errors.As(err, &referenced):
+			result.Status = bulkDeleteStatusReferenced
+			result.References = referenced.References
+		default:
+			return nil, nil, err
+		}
+		summary[result.Status]++
+		results = append(results, result)
+	}
+	return results, summary, nil
+}
+
+// parseHardDelete reads the hard query flag. Deletes are soft unless hard=true.
+func parseHardDelete(c *gin.Context) (bool, bool) {
+	hard, err := strconv.ParseBool(c.DefaultQuery("hard", "false"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("hard must be true or false"))
+		return false, false
+	}
+	return hard, true
+}
+
+// deleteError maps a repository delete error for resource: a missing row is 404 and a hard
+// delete blocked by other records is 409 listing them
+func deleteError(resource string, err error) error {
+	var referenced *domainerrors.ReferencedError
+	switch {
+	case errors.Is(err, domainerrors.ErrNotFound):
+		return domainerrors.NotFound(resource + " not found")
+	case errors.As(err, &referenced):
+		return domainerrors.NewAppError(
+			http.StatusConflict,
+			domainerrors.CodeStillReferenced,
+			fmt.Sprintf("%s cannot be hard-deleted: %s; deactivate or soft delete it instead", resource, referenced.Error()),
+			err,
+		)
+	}
+	return domainerrors.InternalError(err)
+}
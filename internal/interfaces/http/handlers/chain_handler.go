@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -15,24 +16,32 @@ import (
 	"payment-kita.backend/pkg/utils"
 )
 
+// ChainIDVerifier checks that an RPC URL serves the chain an admin declared
+type ChainIDVerifier interface {
+	VerifyChainID(ctx context.Context, chainType entities.ChainType, rpcURL, networkID string) error
+}
+
 // ChainHandler handles chain endpoints
 type ChainHandler struct {
-	chainRepo repositories.ChainRepository
+	chainRepo       repositories.ChainRepository
+	chainIDVerifier ChainIDVerifier
 }
 
-// NewChainHandler creates a new chain handler
-func NewChainHandler(chainRepo repositories.ChainRepository) *ChainHandler {
-	return &ChainHandler{chainRepo: chainRepo}
+// NewChainHandler creates a new chain handler. A nil verifier stores chains without asking
+// their RPC for its chain ID.
+func NewChainHandler(chainRepo repositories.ChainRepository, chainIDVerifier ChainIDVerifier) *ChainHandler {
+	return &ChainHandler{chainRepo: chainRepo, chainIDVerifier: chainIDVerifier}
 }
 
-// ListChains lists all active chains
+// ListChains lists active chains; admins may pass includeInactive=true
 // GET /api/v1/chains
+// GET /api/v1/admin/chains
 func (h *ChainHandler) ListChains(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
 	pagination := utils.GetPaginationParams(page, limit)
 
-	chains, totalCount, err := h.chainRepo.GetActive(c.Request.Context(), pagination)
+	chains, totalCount, err := h.chainRepo.GetPaginated(c.Request.Context(), includeInactiveRequested(c), pagination)
 	if err != nil {
 		response.Error(c, domainerrors.InternalError(err))
 		return
@@ -47,11 +56,15 @@ func (h *ChainHandler) ListChains(c *gin.Context) {
 		ChainType         string `json:"chainType"`
 		RPCURL            string `json:"rpcUrl"`
 		ExplorerURL       string `json:"explorerUrl"`
+		ExplorerAPIURL    string `json:"explorerApiUrl"`
+		HasExplorerAPIKey bool   `json:"hasExplorerApiKey"`
 		Symbol            string `json:"symbol"`
 		LogoURL           string `json:"logoUrl"`
 		IsActive          bool   `json:"isActive"`
+		IsTestnet         bool   `json:"isTestnet"`
 		CCIPChainSelector string `json:"ccipChainSelector"`
 		StargateEID      int    `json:"stargateEid"`
+		MinConfirmations  int    `json:"minConfirmations"`
 	}
 
 	var resp []chainResponse
@@ -64,11 +77,15 @@ func (h *ChainHandler) ListChains(c *gin.Context) {
 			ChainType:         string(chain.Type),
 			RPCURL:            chain.RPCURL,
 			ExplorerURL:       chain.ExplorerURL,
+			ExplorerAPIURL:    chain.ExplorerAPIURL,
+			HasExplorerAPIKey: chain.ExplorerAPIKey != "",
 			Symbol:            chain.CurrencySymbol,
 			LogoURL:           chain.ImageURL,
 			IsActive:          chain.IsActive,
+			IsTestnet:         chain.IsTestnet,
 			CCIPChainSelector: chain.CCIPChainSelector,
 			StargateEID:      chain.StargateEID,
+			MinConfirmations:  chain.MinConfirmations,
 		})
 	}
 
@@ -92,16 +109,27 @@ func (h *ChainHandler) CreateChain(c *gin.Context) {
 		ChainType         string `json:"chainType" binding:"required"` // EVM, SVM
 		RPCURL            string `json:"rpcUrl" binding:"required"`
 		ExplorerURL       string `json:"explorerUrl"`
+		ExplorerAPIURL    string `json:"explorerApiUrl"` // Etherscan-compatible API for ABI import
+		ExplorerAPIKey    string `json:"explorerApiKey"`
 		Symbol            string `json:"symbol" binding:"required"`
 		LogoURL           string `json:"logoUrl"`
+		IsTestnet         bool   `json:"isTestnet"` // Test-mode API keys pay only on testnets, live keys only on mainnets
 		CCIPChainSelector string `json:"ccipChainSelector"`
 		StargateEID      int    `json:"stargateEid"`
+		MinConfirmations  int    `json:"minConfirmations" binding:"min=0"` // Completion depth; 0 completes on first sight
+		SkipChainIDCheck  bool   `json:"skipChainIdCheck"`                 // Store without comparing networkId to the RPC's eth_chainId
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		response.Error(c, domainerrors.BadRequest(err.Error()))
 		return
 	}
+	if !input.SkipChainIDCheck {
+		if err := h.verifyChainID(c.Request.Context(), entities.ChainType(input.ChainType), input.RPCURL, input.NetworkID); err != nil {
+			response.Error(c, err)
+			return
+		}
+	}
 
 	chain := &entities.Chain{
 		ID:                utils.GenerateUUIDv7(),
@@ -110,11 +138,15 @@ func (h *ChainHandler) CreateChain(c *gin.Context) {
 		Type:              entities.ChainType(input.ChainType),
 		RPCURL:            input.RPCURL,
 		ExplorerURL:       input.ExplorerURL,
+		ExplorerAPIURL:    input.ExplorerAPIURL,
+		ExplorerAPIKey:    input.ExplorerAPIKey,
 		CurrencySymbol:    input.Symbol,
 		ImageURL:          input.LogoURL,
 		IsActive:          true,
+		IsTestnet:         input.IsTestnet,
 		CCIPChainSelector: input.CCIPChainSelector,
 		StargateEID:      input.StargateEID,
+		MinConfirmations:  input.MinConfirmations,
 		CreatedAt:         time.Now(),
 	}
 
@@ -137,16 +169,21 @@ func (h *ChainHandler) UpdateChain(c *gin.Context) {
 	}
 
 	var input struct {
-		NetworkID         string `json:"networkId" binding:"required"`
-		Name              string `json:"name" binding:"required"`
-		ChainType         string `json:"chainType" binding:"required"`
-		RPCURL            string `json:"rpcUrl" binding:"required"`
-		ExplorerURL       string `json:"explorerUrl"`
-		Symbol            string `json:"symbol"`
-		LogoURL           string `json:"logoUrl"`
-		IsActive          bool   `json:"isActive"`
-		CCIPChainSelector string `json:"ccipChainSelector"`
-		StargateEID      int    `json:"stargateEid"`
+		NetworkID         string  `json:"networkId" binding:"required"`
+		Name              string  `json:"name" binding:"required"`
+		ChainType         string  `json:"chainType" binding:"required"`
+		RPCURL            string  `json:"rpcUrl" binding:"required"`
+		ExplorerURL       string  `json:"explorerUrl"`
+		ExplorerAPIURL    *string `json:"explorerApiUrl"` // Omitted keeps the stored URL, "" clears it
+		ExplorerAPIKey    string  `json:"explorerApiKey"` // Empty keeps the stored key
+		Symbol            string  `json:"symbol"`
+		LogoURL           string  `json:"logoUrl"`
+		IsActive          bool    `json:"isActive"`
+		IsTestnet         *bool   `json:"isTestnet"` // Omitted keeps the stored flag
+		CCIPChainSelector string  `json:"ccipChainSelector"`
+		StargateEID       int     `json:"stargateEid"`
+		MinConfirmations  int     `json:"minConfirmations" binding:"min=0"` // Completion depth; 0 completes on first sight
+		SkipChainIDCheck  bool    `json:"skipChainIdCheck"`                 // Store without comparing networkId to the RPC's eth_chainId
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -154,6 +191,21 @@ func (h *ChainHandler) UpdateChain(c *gin.Context) {
 		response.Error(c, domainerrors.BadRequest(err.Error()))
 		return
 	}
+	if !input.SkipChainIDCheck && h.chainIDVerifier != nil {
+		// Only re-check when something the check depends on changed, so editing a chain whose
+		// RPC is briefly down still works
+		existing, err := h.chainRepo.GetByID(c.Request.Context(), id)
+		if err != nil && err != domainerrors.ErrNotFound {
+			response.Error(c, domainerrors.InternalError(err))
+			return
+		}
+		if existing == nil || existing.RPCURL != input.RPCURL || existing.ChainID != input.NetworkID || string(existing.Type) != input.ChainType {
+			if err := h.verifyChainID(c.Request.Context(), entities.ChainType(input.ChainType), input.RPCURL, input.NetworkID); err != nil {
+				response.Error(c, err)
+				return
+			}
+		}
+	}
 
 	chain := &entities.Chain{
 		ID:                id,
@@ -167,6 +219,29 @@ func (h *ChainHandler) UpdateChain(c *gin.Context) {
 		IsActive:          input.IsActive,
 		CCIPChainSelector: input.CCIPChainSelector,
 		StargateEID:      input.StargateEID,
+		MinConfirmations:  input.MinConfirmations,
+		ExplorerAPIKey:    input.ExplorerAPIKey,
+	}
+	if input.IsTestnet != nil {
+		chain.IsTestnet = *input.IsTestnet
+	}
+	if input.ExplorerAPIURL != nil {
+		chain.ExplorerAPIURL = *input.ExplorerAPIURL
+	}
+	if chain.ExplorerAPIKey == "" || input.IsTestnet == nil || input.ExplorerAPIURL == nil {
+		// The key is never echoed back, so a client resending the chain cannot know it; older
+		// clients do not send isTestnet or explorerApiUrl at all
+		if existing, err := h.chainRepo.GetByID(c.Request.Context(), id); err == nil && existing != nil {
+			if chain.ExplorerAPIKey == "" {
+				chain.ExplorerAPIKey = existing.ExplorerAPIKey
+			}
+			if input.IsTestnet == nil {
+				chain.IsTestnet = existing.IsTestnet
+			}
+			if input.ExplorerAPIURL == nil {
+				chain.ExplorerAPIURL = existing.ExplorerAPIURL
+			}
+		}
 	}
 
 	if err := h.chainRepo.Update(c.Request.Context(), chain); err != nil {
@@ -181,7 +256,16 @@ func (h *ChainHandler) UpdateChain(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"message": "Chain updated", "chain": chain})
 }
 
-// DeleteChain deletes a chain (Admin only)
+func (h *ChainHandler) verifyChainID(ctx context.Context, chainType entities.ChainType, rpcURL, networkID string) error {
+	if h.chainIDVerifier == nil {
+		return nil
+	}
+	return h.chainIDVerifier.VerifyChainID(ctx, chainType, rpcURL, networkID)
+}
+
+// DeleteChain soft deletes a chain (Admin only), so payments on it keep showing it. With
+// hard=true the row is removed instead, which is refused while payments, payment requests,
+// tokens or contracts reference it.
 // DELETE /api/v1/admin/chains/:id
 func (h *ChainHandler) DeleteChain(c *gin.Context) {
 	idStr := c.Param("id")
@@ -190,8 +274,39 @@ func (h *ChainHandler) DeleteChain(c *gin.Context) {
 		response.Error(c, domainerrors.BadRequest("Invalid chain UUID"))
 		return
 	}
+	hard, ok := parseHardDelete(c)
+	if !ok {
+		return
+	}
+
+	remove := h.chainRepo.Delete
+	if hard {
+		remove = h.chainRepo.HardDelete
+	}
+	if err := remove(c.Request.Context(), id); err != nil {
+		response.Error(c, deleteError("Chain", err))
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"message": "Chain deleted"})
+}
+
+// DeactivateChain hides a chain from new payments and chain lists while leaving it, and the
+// payments that reference it, in place (Admin only)
+// POST /api/v1/admin/chains/:id/deactivate
+func (h *ChainHandler) DeactivateChain(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid chain UUID"))
+		return
+	}
 
-	if err := h.chainRepo.Delete(c.Request.Context(), id); err != nil {
+	chain, err := h.chainRepo.GetByID(c.Request.Context(), id)
+	if err == nil && chain.IsActive {
+		chain.IsActive = false
+		err = h.chainRepo.Update(c.Request.Context(), chain)
+	}
+	if err != nil {
 		if err == domainerrors.ErrNotFound {
 			response.Error(c, domainerrors.NotFound("Chain not found"))
 			return
@@ -200,5 +315,5 @@ func (h *ChainHandler) DeleteChain(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, gin.H{"message": "Chain deleted"})
+	response.Success(c, http.StatusOK, gin.H{"id": id, "isActive": false})
 }
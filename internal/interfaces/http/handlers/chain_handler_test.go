@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -26,6 +27,8 @@ type chainHandlerRepoStub struct {
 	getByCAIP2Fn func(ctx context.Context, caip2 string) (*entities.Chain, error)
 	getAllFn     func(ctx context.Context) ([]*entities.Chain, error)
 	getAllRPCsFn func(ctx context.Context, chainID *uuid.UUID, isActive *bool, search *string, pagination utils.PaginationParams) ([]*entities.ChainRPC, int64, error)
+
+	lastIncludeInactive bool
 }
 
 func (s *chainHandlerRepoStub) GetByID(ctx context.Context, id uuid.UUID) (*entities.Chain, error) {
@@ -70,6 +73,14 @@ func (s *chainHandlerRepoStub) GetActive(ctx context.Context, pagination utils.P
 	return []*entities.Chain{}, 0, nil
 }
 
+func (s *chainHandlerRepoStub) GetPaginated(ctx context.Context, includeInactive bool, pagination utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	s.lastIncludeInactive = includeInactive
+	if s.getActiveFn != nil {
+		return s.getActiveFn(ctx, pagination)
+	}
+	return []*entities.Chain{}, 0, nil
+}
+
 func (s *chainHandlerRepoStub) Create(ctx context.Context, chain *entities.Chain) error {
 	if s.createFn != nil {
 		return s.createFn(ctx, chain)
@@ -90,6 +101,7 @@ func (s *chainHandlerRepoStub) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+func (s *chainHandlerRepoStub) HardDelete(context.Context, uuid.UUID) error { return nil }
 
 func (s *chainHandlerRepoStub) CreateRPC(ctx context.Context, rpc *entities.ChainRPC) error {
 	return nil
@@ -126,7 +138,7 @@ func TestChainHandler_ListChains_SuccessAndError(t *testing.T) {
 			}, 1, nil
 		},
 	}
-	h := NewChainHandler(repo)
+	h := NewChainHandler(repo, nil)
 
 	r := gin.New()
 	r.GET("/chains", h.ListChains)
@@ -150,7 +162,7 @@ func TestChainHandler_ListChains_EmptyItemsBranch(t *testing.T) {
 			return nil, 0, nil
 		},
 	}
-	h := NewChainHandler(repo)
+	h := NewChainHandler(repo, nil)
 	r := gin.New()
 	r.GET("/chains", h.ListChains)
 
@@ -161,6 +173,37 @@ func TestChainHandler_ListChains_EmptyItemsBranch(t *testing.T) {
 	require.Contains(t, w.Body.String(), "\"items\":[]")
 }
 
+func TestChainHandler_ListChains_IncludeInactiveAdminOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &chainHandlerRepoStub{}
+	h := NewChainHandler(repo, nil)
+
+	r := gin.New()
+	r.GET("/chains", h.ListChains)
+	admin := r.Group("/admin", func(c *gin.Context) {
+		c.Set(middleware.UserRoleKey, string(entities.UserRoleAdmin))
+	})
+	admin.GET("/chains", h.ListChains)
+
+	req := httptest.NewRequest(http.MethodGet, "/chains?includeInactive=true", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.False(t, repo.lastIncludeInactive)
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/chains", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.False(t, repo.lastIncludeInactive)
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/chains?includeInactive=true", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, repo.lastIncludeInactive)
+}
+
 func TestChainHandler_CreateUpdateDelete(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	chainID := uuid.New()
@@ -192,7 +235,7 @@ func TestChainHandler_CreateUpdateDelete(t *testing.T) {
 			return errors.New("delete failed")
 		},
 	}
-	h := NewChainHandler(repo)
+	h := NewChainHandler(repo, nil)
 
 	r := gin.New()
 	r.POST("/admin/chains", h.CreateChain)
@@ -273,3 +316,162 @@ func TestChainHandler_CreateUpdateDelete(t *testing.T) {
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+type chainIDVerifierStub struct {
+	calls int
+}
+
+func (s *chainIDVerifierStub) VerifyChainID(_ context.Context, chainType entities.ChainType, _ string, networkID string) error {
+	s.calls++
+	if chainType == entities.ChainTypeEVM && networkID != "8453" {
+		return domainerrors.NewAppError(http.StatusBadRequest, domainerrors.CodeChainIDMismatch, "https://rpc serves chain ID 8453, not the declared "+networkID, domainerrors.ErrChainIDMismatch)
+	}
+	return nil
+}
+
+func TestChainHandler_TestnetFlag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	chainID := uuid.New()
+	stored := &entities.Chain{ID: chainID, ChainID: "84532", Name: "Base Sepolia", Type: entities.ChainTypeEVM, IsActive: true, IsTestnet: true}
+
+	var created, updated *entities.Chain
+	repo := &chainHandlerRepoStub{
+		getActiveFn: func(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+			return []*entities.Chain{stored}, 1, nil
+		},
+		getByIDFn: func(context.Context, uuid.UUID) (*entities.Chain, error) { return stored, nil },
+		createFn: func(_ context.Context, chain *entities.Chain) error {
+			created = chain
+			return nil
+		},
+		updateFn: func(_ context.Context, chain *entities.Chain) error {
+			updated = chain
+			return nil
+		},
+	}
+	h := NewChainHandler(repo, nil)
+
+	r := gin.New()
+	r.GET("/chains", h.ListChains)
+	r.POST("/admin/chains", h.CreateChain)
+	r.PUT("/admin/chains/:id", h.UpdateChain)
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodGet, "/chains", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"isTestnet":true`)
+
+	w = send(http.MethodPost, "/admin/chains", `{"networkId":"11155111","name":"Sepolia","chainType":"EVM","rpcUrl":"https://rpc","symbol":"ETH","isTestnet":true}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.True(t, created.IsTestnet)
+
+	// Clients that do not send the flag keep the stored one
+	w = send(http.MethodPut, "/admin/chains/"+chainID.String(), `{"networkId":"84532","name":"Base Sepolia","chainType":"EVM","rpcUrl":"https://rpc","symbol":"ETH","isActive":true}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, updated.IsTestnet)
+
+	w = send(http.MethodPut, "/admin/chains/"+chainID.String(), `{"networkId":"84532","name":"Base Sepolia","chainType":"EVM","rpcUrl":"https://rpc","symbol":"ETH","isActive":true,"isTestnet":false}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.False(t, updated.IsTestnet)
+}
+
+func TestChainHandler_ExplorerAPISettings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	chainID := uuid.New()
+	stored := &entities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: entities.ChainTypeEVM, IsActive: true,
+		ExplorerAPIURL: "https://api.etherscan.io/v2/api?chainid=8453", ExplorerAPIKey: "secret-key"}
+
+	var updated *entities.Chain
+	repo := &chainHandlerRepoStub{
+		getActiveFn: func(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+			return []*entities.Chain{stored}, 1, nil
+		},
+		getByIDFn: func(context.Context, uuid.UUID) (*entities.Chain, error) { return stored, nil },
+		updateFn: func(_ context.Context, chain *entities.Chain) error {
+			updated = chain
+			return nil
+		},
+	}
+	h := NewChainHandler(repo, nil)
+
+	r := gin.New()
+	r.GET("/chains", h.ListChains)
+	r.PUT("/admin/chains/:id", h.UpdateChain)
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodGet, "/chains", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"explorerApiUrl":"https://api.etherscan.io/v2/api?chainid=8453"`)
+	require.Contains(t, w.Body.String(), `"hasExplorerApiKey":true`)
+	require.NotContains(t, w.Body.String(), "secret-key")
+	require.NotContains(t, w.Body.String(), `"explorerApiKey"`)
+
+	// Omitting the URL and key keeps both
+	w = send(http.MethodPut, "/admin/chains/"+chainID.String(), `{"networkId":"8453","name":"Base","chainType":"EVM","rpcUrl":"https://rpc","symbol":"ETH","isActive":true}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, stored.ExplorerAPIURL, updated.ExplorerAPIURL)
+	require.Equal(t, "secret-key", updated.ExplorerAPIKey)
+
+	// An explicit empty URL clears it
+	w = send(http.MethodPut, "/admin/chains/"+chainID.String(), `{"networkId":"8453","name":"Base","chainType":"EVM","rpcUrl":"https://rpc","symbol":"ETH","isActive":true,"explorerApiUrl":""}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, updated.ExplorerAPIURL)
+}
+
+func TestChainHandler_VerifiesChainIDAgainstRPC(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	chainRepo := newChainRepoStub()
+	verifier := &chainIDVerifierStub{}
+	h := NewChainHandler(chainRepo, verifier)
+	r := gin.New()
+	r.POST("/admin/chains", h.CreateChain)
+	r.PUT("/admin/chains/:id", h.UpdateChain)
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+	chainBody := func(networkID, extra string) string {
+		return `{"networkId":"` + networkID + `","name":"Base","chainType":"EVM","rpcUrl":"https://rpc","symbol":"ETH"` + extra + `}`
+	}
+
+	w := do(http.MethodPost, "/admin/chains", chainBody("1", ""))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), domainerrors.CodeChainIDMismatch)
+	require.Empty(t, chainRepo.items)
+
+	// The override stores the chain without asking the RPC
+	calls := verifier.calls
+	require.Equal(t, http.StatusCreated, do(http.MethodPost, "/admin/chains", chainBody("1", `,"skipChainIdCheck":true`)).Code)
+	require.Equal(t, calls, verifier.calls)
+
+	require.Equal(t, http.StatusCreated, do(http.MethodPost, "/admin/chains", chainBody("8453", "")).Code)
+	var base *entities.Chain
+	for _, chain := range chainRepo.items {
+		if chain.ChainID == "8453" {
+			base = chain
+		}
+	}
+	require.NotNil(t, base)
+
+	// Updates only re-check when the RPC, network ID or type changes
+	calls = verifier.calls
+	require.Equal(t, http.StatusOK, do(http.MethodPut, "/admin/chains/"+base.ID.String(), chainBody("8453", `,"name":"Base Mainnet"`)).Code)
+	require.Equal(t, calls, verifier.calls)
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/admin/chains/"+base.ID.String(), chainBody("10", "")).Code)
+	require.Equal(t, calls+1, verifier.calls)
+}
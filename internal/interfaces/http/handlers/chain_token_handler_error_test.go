@@ -31,6 +31,12 @@ func (s *chainRepoErrStub) GetActive(ctx context.Context, p utils.PaginationPara
 	}
 	return s.chainRepoStub.GetActive(ctx, p)
 }
+func (s *chainRepoErrStub) GetPaginated(ctx context.Context, includeInactive bool, p utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	if s.getActiveErr != nil {
+		return nil, 0, s.getActiveErr
+	}
+	return s.chainRepoStub.GetActive(ctx, p)
+}
 func (s *chainRepoErrStub) Create(ctx context.Context, chain *entities.Chain) error {
 	if s.createErr != nil {
 		return s.createErr
@@ -73,11 +79,11 @@ func (s *tokenRepoErrStub) GetTokensByChain(ctx context.Context, chainID uuid.UU
 	}
 	return s.tokenRepoStub.GetTokensByChain(ctx, chainID, p)
 }
-func (s *tokenRepoErrStub) GetAllTokens(ctx context.Context, chainID *uuid.UUID, search *string, p utils.PaginationParams) ([]*entities.Token, int64, error) {
+func (s *tokenRepoErrStub) GetAllTokens(ctx context.Context, chainID *uuid.UUID, search *string, includeInactive bool, p utils.PaginationParams) ([]*entities.Token, int64, error) {
 	if s.getAllErr != nil {
 		return nil, 0, s.getAllErr
 	}
-	return s.tokenRepoStub.GetAllTokens(ctx, chainID, search, p)
+	return s.tokenRepoStub.GetAllTokens(ctx, chainID, search, includeInactive, p)
 }
 func (s *tokenRepoErrStub) GetStablecoins(ctx context.Context) ([]*entities.Token, error) {
 	if s.stableErr != nil {
@@ -113,7 +119,7 @@ func (s *tokenRepoErrStub) SoftDelete(ctx context.Context, id uuid.UUID) error {
 func TestChainHandler_ErrorPaths(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	repo := &chainRepoErrStub{chainRepoStub: newChainRepoStub()}
-	h := NewChainHandler(repo)
+	h := NewChainHandler(repo, nil)
 	r := gin.New()
 	r.GET("/chains", h.ListChains)
 	r.POST("/admin/chains", h.CreateChain)
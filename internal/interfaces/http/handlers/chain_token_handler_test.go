@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -16,7 +17,8 @@ import (
 )
 
 type chainRepoStub struct {
-	items map[uuid.UUID]*entities.Chain
+	items      map[uuid.UUID]*entities.Chain
+	references map[uuid.UUID]map[string]int64
 }
 
 func newChainRepoStub() *chainRepoStub { return &chainRepoStub{items: map[uuid.UUID]*entities.Chain{}} }
@@ -54,6 +56,13 @@ func (s *chainRepoStub) GetActive(_ context.Context, _ utils.PaginationParams) (
 	}
 	return out, int64(len(out)), nil
 }
+func (s *chainRepoStub) GetPaginated(_ context.Context, _ bool, _ utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	out := make([]*entities.Chain, 0, len(s.items))
+	for _, c := range s.items {
+		out = append(out, c)
+	}
+	return out, int64(len(out)), nil
+}
 func (s *chainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *chainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *chainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -78,9 +87,16 @@ func (s *chainRepoStub) Delete(_ context.Context, id uuid.UUID) error {
 	delete(s.items, id)
 	return nil
 }
+func (s *chainRepoStub) HardDelete(ctx context.Context, id uuid.UUID) error {
+	if refs := s.references[id]; len(refs) > 0 {
+		return &domainerrors.ReferencedError{References: refs}
+	}
+	return s.Delete(ctx, id)
+}
 
 type tokenRepoStub struct {
-	items map[uuid.UUID]*entities.Token
+	items      map[uuid.UUID]*entities.Token
+	references map[uuid.UUID]map[string]int64
 }
 
 func newTokenRepoStub() *tokenRepoStub { return &tokenRepoStub{items: map[uuid.UUID]*entities.Token{}} }
@@ -91,9 +107,23 @@ func (s *tokenRepoStub) GetByID(_ context.Context, id uuid.UUID) (*entities.Toke
 	}
 	return nil, domainerrors.ErrNotFound
 }
-func (s *tokenRepoStub) GetBySymbol(_ context.Context, _ string, _ uuid.UUID) (*entities.Token, error) {
+func (s *tokenRepoStub) GetBySymbol(_ context.Context, symbol string, chainID uuid.UUID) (*entities.Token, error) {
+	for _, t := range s.items {
+		if t.Symbol == symbol && t.ChainUUID == chainID {
+			return t, nil
+		}
+	}
 	return nil, domainerrors.ErrNotFound
 }
+func (s *tokenRepoStub) GetActiveBySymbol(_ context.Context, symbol string) ([]*entities.Token, error) {
+	out := make([]*entities.Token, 0)
+	for _, t := range s.items {
+		if t.Symbol == symbol && t.IsActive && t.Chain != nil && t.Chain.IsActive {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
 func (s *tokenRepoStub) GetByAddress(_ context.Context, address string, chainID uuid.UUID) (*entities.Token, error) {
 	for _, t := range s.items {
 		if t.ContractAddress == address && t.ChainUUID == chainID {
@@ -124,11 +154,26 @@ func (s *tokenRepoStub) GetTokensByChain(_ context.Context, chainID uuid.UUID, _
 	}
 	return out, int64(len(out)), nil
 }
-func (s *tokenRepoStub) GetAllTokens(_ context.Context, _ *uuid.UUID, _ *string, _ utils.PaginationParams) ([]*entities.Token, int64, error) {
+func (s *tokenRepoStub) GetAllTokens(_ context.Context, _ *uuid.UUID, _ *string, _ bool, _ utils.PaginationParams) ([]*entities.Token, int64, error) {
 	out, _ := s.GetAll(context.Background())
 	return out, int64(len(out)), nil
 }
+func (s *tokenRepoStub) BulkSetActive(_ context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error) {
+	previous := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		if t, ok := s.items[id]; ok {
+			previous[id] = t.IsActive
+			t.IsActive = active
+		}
+	}
+	return previous, nil
+}
 func (s *tokenRepoStub) Create(_ context.Context, token *entities.Token) error {
+	for _, existing := range s.items {
+		if token.ContractAddress != "" && existing.ChainUUID == token.ChainUUID && strings.EqualFold(existing.ContractAddress, token.ContractAddress) {
+			return &domainerrors.AlreadyExistsError{ID: existing.ID.String()}
+		}
+	}
 	s.items[token.ID] = token
 	return nil
 }
@@ -146,11 +191,17 @@ func (s *tokenRepoStub) SoftDelete(_ context.Context, id uuid.UUID) error {
 	delete(s.items, id)
 	return nil
 }
+func (s *tokenRepoStub) HardDelete(ctx context.Context, id uuid.UUID) error {
+	if refs := s.references[id]; len(refs) > 0 {
+		return &domainerrors.ReferencedError{References: refs}
+	}
+	return s.SoftDelete(ctx, id)
+}
 
 func TestChainHandler_CRUDAndList(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	chainRepo := newChainRepoStub()
-	h := NewChainHandler(chainRepo)
+	h := NewChainHandler(chainRepo, nil)
 	r := gin.New()
 	r.GET("/chains", h.ListChains)
 	r.POST("/admin/chains", h.CreateChain)
@@ -211,7 +262,7 @@ func TestChainHandler_CRUDAndList(t *testing.T) {
 
 func TestChainHandler_InvalidInputs(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	h := NewChainHandler(newChainRepoStub())
+	h := NewChainHandler(newChainRepoStub(), nil)
 	r := gin.New()
 	r.PUT("/admin/chains/:id", h.UpdateChain)
 	r.DELETE("/admin/chains/:id", h.DeleteChain)
@@ -276,7 +327,7 @@ func TestTokenHandler_CoreFlows(t *testing.T) {
 
 	// Create token
 	createReq := map[string]any{
-		"symbol": "IDRX", "name": "IDRX", "decimals": 6, "type": "ERC20", "chainId": chain.ID.String(), "contractAddress": "0xIDRX", "minAmount": "1",
+		"symbol": "IDRX", "name": "IDRX", "decimals": 6, "type": "ERC20", "chainId": chain.ID.String(), "contractAddress": "0x1d70000000000000000000000000000000000001", "minAmount": "1",
 	}
 	b, _ := json.Marshal(createReq)
 	req = httptest.NewRequest(http.MethodPost, "/admin/tokens", bytes.NewReader(b))
@@ -286,6 +337,26 @@ func TestTokenHandler_CoreFlows(t *testing.T) {
 	if rec.Code != http.StatusCreated {
 		t.Fatalf("expected 201 got %d body=%s", rec.Code, rec.Body.String())
 	}
+	var created struct {
+		Token struct {
+			ID string `json:"id"`
+		} `json:"token"`
+	}
+	_ = json.Unmarshal(rec.Body.Bytes(), &created)
+
+	// Creating it again is a conflict naming the existing token
+	req = httptest.NewRequest(http.MethodPost, "/admin/tokens", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 got %d body=%s", rec.Code, rec.Body.String())
+	}
+	var conflict map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &conflict)
+	if created.Token.ID == "" || conflict["existingId"] != created.Token.ID {
+		t.Fatalf("expected existingId %q got body=%s", created.Token.ID, rec.Body.String())
+	}
 
 	// Update existing token
 	upd := map[string]any{"name": "USD Coin Updated", "chainId": "8453"}
@@ -354,7 +425,7 @@ func TestTokenHandler_CreateAndUpdate_LegacyChainFallback(t *testing.T) {
 	r.POST("/admin/tokens", h.CreateToken)
 	r.PUT("/admin/tokens/:id", h.UpdateToken)
 
-	createBody := `{"symbol":"IDRX","name":"IDRX","decimals":6,"type":"ERC20","chainId":"8453","contractAddress":"0xidrx","minAmount":"1","maxAmount":"100"}`
+	createBody := `{"symbol":"IDRX","name":"IDRX","decimals":6,"type":"ERC20","chainId":"8453","contractAddress":"0x1d70000000000000000000000000000000000001","minAmount":"1","maxAmount":"100"}`
 	req := httptest.NewRequest(http.MethodPost, "/admin/tokens", bytes.NewReader([]byte(createBody)))
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
@@ -383,3 +454,293 @@ func TestTokenHandler_CreateAndUpdate_LegacyChainFallback(t *testing.T) {
 		t.Fatalf("expected 200 got %d body=%s", rec.Code, rec.Body.String())
 	}
 }
+
+func TestTokenHandler_BulkActivateTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenRepo := newTokenRepoStub()
+	inactive := &entities.Token{ID: uuid.New(), Symbol: "USDC"}
+	active := &entities.Token{ID: uuid.New(), Symbol: "USDT", IsActive: true}
+	tokenRepo.items[inactive.ID] = inactive
+	tokenRepo.items[active.ID] = active
+	missing := uuid.New()
+
+	h := NewTokenHandler(tokenRepo, newChainRepoStub(), nil)
+	r := gin.New()
+	r.POST("/admin/tokens/bulk-activate", h.BulkActivateTokens)
+
+	b, _ := json.Marshal(map[string]any{
+		"ids":      []string{inactive.ID.String(), active.ID.String(), missing.String(), inactive.ID.String()},
+		"isActive": true,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/admin/tokens/bulk-activate", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	var resp struct {
+		Items []struct {
+			ID     uuid.UUID `json:"id"`
+			Status string    `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	want := []string{"UPDATED", "UNCHANGED", "NOT_FOUND"}
+	if len(resp.Items) != len(want) {
+		t.Fatalf("expected %d results (duplicates removed), got %d", len(want), len(resp.Items))
+	}
+	for i, status := range want {
+		if resp.Items[i].Status != status {
+			t.Fatalf("item %d: expected %s got %s", i, status, resp.Items[i].Status)
+		}
+	}
+	if !inactive.IsActive {
+		t.Fatalf("expected token to be activated")
+	}
+
+	for _, body := range []string{`{"ids":[],"isActive":true}`, `{"ids":["not-a-uuid"],"isActive":true}`, `{"ids":["` + missing.String() + `"]}`} {
+		req = httptest.NewRequest(http.MethodPost, "/admin/tokens/bulk-activate", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %s got %d", body, rec.Code)
+		}
+	}
+}
+
+func TestTokenHandler_ListChainTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	chainRepo := newChainRepoStub()
+	tokenRepo := newTokenRepoStub()
+
+	base := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM, Name: "Base", IsActive: true}
+	disabled := &entities.Chain{ID: uuid.New(), ChainID: "56", Type: entities.ChainTypeEVM, Name: "BSC", IsActive: false}
+	chainRepo.items[base.ID] = base
+	chainRepo.items[disabled.ID] = disabled
+	usdc := &entities.Token{ID: uuid.New(), ChainUUID: base.ID, Symbol: "USDC", IsActive: true}
+	usdt := &entities.Token{ID: uuid.New(), ChainUUID: disabled.ID, Symbol: "USDT", IsActive: true}
+	tokenRepo.items[usdc.ID] = usdc
+	tokenRepo.items[usdt.ID] = usdt
+
+	h := NewTokenHandler(tokenRepo, chainRepo, nil)
+	r := gin.New()
+	r.GET("/chains/:id/tokens", h.ListChainTokens)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	for _, id := range []string{base.ID.String(), "eip155:8453", "8453"} {
+		rec := get("/chains/" + id + "/tokens?limit=500")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200 got %d body=%s", id, rec.Code, rec.Body.String())
+		}
+		var body struct {
+			ChainID string `json:"chainId"`
+			Items   []struct {
+				Symbol string `json:"symbol"`
+			} `json:"items"`
+			Meta utils.PaginationMeta `json:"meta"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if body.ChainID != "eip155:8453" || len(body.Items) != 1 || body.Items[0].Symbol != "USDC" {
+			t.Fatalf("%s: unexpected body %s", id, rec.Body.String())
+		}
+		if body.Meta.Limit != 100 {
+			t.Fatalf("%s: expected limit capped at 100, got %d", id, body.Meta.Limit)
+		}
+	}
+
+	if rec := get("/chains/eip155:56/tokens"); rec.Code != http.StatusNotFound {
+		t.Fatalf("inactive chain: expected 404 got %d", rec.Code)
+	}
+	if rec := get("/chains/999/tokens"); rec.Code != http.StatusNotFound {
+		t.Fatalf("unknown chain: expected 404 got %d", rec.Code)
+	}
+}
+
+func TestTokenHandler_ListTokensBySymbol(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	chainRepo := newChainRepoStub()
+	tokenRepo := newTokenRepoStub()
+
+	base := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM, Name: "Base", IsActive: true}
+	arbitrum := &entities.Chain{ID: uuid.New(), ChainID: "42161", Type: entities.ChainTypeEVM, Name: "Arbitrum", IsActive: true}
+	polygon := &entities.Chain{ID: uuid.New(), ChainID: "137", Type: entities.ChainTypeEVM, Name: "Polygon", IsActive: true}
+	disabled := &entities.Chain{ID: uuid.New(), ChainID: "56", Type: entities.ChainTypeEVM, Name: "BSC", IsActive: false}
+	for _, token := range []*entities.Token{
+		{ID: uuid.New(), ChainUUID: base.ID, Chain: base, Symbol: "USDC", Decimals: 6, ContractAddress: "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913", IsActive: true},
+		{ID: uuid.New(), ChainUUID: arbitrum.ID, Chain: arbitrum, Symbol: "USDC", Decimals: 6, ContractAddress: "0xaf88d065e77c8cC2239327C5EDb3A432268e5831", IsActive: true},
+		{ID: uuid.New(), ChainUUID: polygon.ID, Chain: polygon, Symbol: "USDC", Decimals: 6, ContractAddress: "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359", IsActive: false},
+		{ID: uuid.New(), ChainUUID: disabled.ID, Chain: disabled, Symbol: "USDC", Decimals: 18, ContractAddress: "0x8AC76a51cc950d9822D68b83fE1Ad97B32Cd580d", IsActive: true},
+		{ID: uuid.New(), ChainUUID: base.ID, Chain: base, Symbol: "WETH", Decimals: 18, ContractAddress: "0x4200000000000000000000000000000000000006", IsActive: true},
+	} {
+		tokenRepo.items[token.ID] = token
+	}
+
+	h := NewTokenHandler(tokenRepo, chainRepo, nil)
+	r := gin.New()
+	r.GET("/tokens/by-symbol", h.ListTokensBySymbol)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get("/tokens/by-symbol?symbol=USDC")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d body=%s", rec.Code, rec.Body.String())
+	}
+	var body struct {
+		Symbol string `json:"symbol"`
+		Items  []struct {
+			ChainID         string `json:"chainId"`
+			ContractAddress string `json:"contractAddress"`
+			Decimals        int    `json:"decimals"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Symbol != "USDC" || len(body.Items) != 2 {
+		t.Fatalf("unexpected body %s", rec.Body.String())
+	}
+	if body.Items[0].ChainID != "eip155:42161" || body.Items[0].ContractAddress != "0xaf88d065e77c8cC2239327C5EDb3A432268e5831" ||
+		body.Items[1].ChainID != "eip155:8453" || body.Items[1].Decimals != 6 {
+		t.Fatalf("unexpected items %s", rec.Body.String())
+	}
+
+	rec = get("/tokens/by-symbol?symbol=DAI")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"items":[]`) {
+		t.Fatalf("unknown symbol: expected empty items, got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := get("/tokens/by-symbol?symbol=%20"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("blank symbol: expected 400 got %d", rec.Code)
+	}
+}
+
+func TestTokenHandler_DeleteDeactivateAndBulkDelete(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenRepo := newTokenRepoStub()
+	chainID := uuid.New()
+	used := &entities.Token{ID: uuid.New(), ChainUUID: chainID, Symbol: "USDC", IsActive: true}
+	unused := &entities.Token{ID: uuid.New(), ChainUUID: chainID, Symbol: "IDRX", IsActive: true}
+	spare := &entities.Token{ID: uuid.New(), ChainUUID: chainID, Symbol: "WETH", IsActive: true}
+	for _, token := range []*entities.Token{used, unused, spare} {
+		tokenRepo.items[token.ID] = token
+	}
+	tokenRepo.references = map[uuid.UUID]map[string]int64{used.ID: {"payments.source_token_id": 3}}
+
+	h := NewTokenHandler(tokenRepo, newChainRepoStub(), nil)
+	r := gin.New()
+	r.DELETE("/admin/tokens/:id", h.DeleteToken)
+	r.POST("/admin/tokens/:id/deactivate", h.DeactivateToken)
+	r.POST("/admin/tokens/bulk-delete", h.BulkDeleteTokens)
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A referenced token cannot be hard-deleted, and the response names what holds it
+	rec := send(http.MethodDelete, "/admin/tokens/"+used.ID.String()+"?hard=true", "")
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 got %d body=%s", rec.Code, rec.Body.String())
+	}
+	var conflict struct {
+		Code       string           `json:"code"`
+		References map[string]int64 `json:"references"`
+	}
+	_ = json.Unmarshal(rec.Body.Bytes(), &conflict)
+	if conflict.Code != domainerrors.CodeStillReferenced || conflict.References["payments.source_token_id"] != 3 {
+		t.Fatalf("unexpected conflict body=%s", rec.Body.String())
+	}
+	if rec := send(http.MethodDelete, "/admin/tokens/"+used.ID.String()+"?hard=maybe", ""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid hard flag: expected 400 got %d", rec.Code)
+	}
+
+	// Deactivating keeps the token but hides it from new payments
+	rec = send(http.MethodPost, "/admin/tokens/"+used.ID.String()+"/deactivate", "")
+	if rec.Code != http.StatusOK || used.IsActive || tokenRepo.items[used.ID] == nil {
+		t.Fatalf("deactivate: got %d active=%v body=%s", rec.Code, used.IsActive, rec.Body.String())
+	}
+	if rec := send(http.MethodPost, "/admin/tokens/"+uuid.NewString()+"/deactivate", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("deactivate missing: expected 404 got %d", rec.Code)
+	}
+
+	if rec := send(http.MethodDelete, "/admin/tokens/"+spare.ID.String(), ""); rec.Code != http.StatusOK {
+		t.Fatalf("soft delete: expected 200 got %d body=%s", rec.Code, rec.Body.String())
+	}
+	if rec := send(http.MethodDelete, "/admin/tokens/"+spare.ID.String(), ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("repeat delete: expected 404 got %d", rec.Code)
+	}
+
+	missing := uuid.New()
+	rec = send(http.MethodPost, "/admin/tokens/bulk-delete",
+		`{"hard":true,"ids":["`+used.ID.String()+`","`+unused.ID.String()+`","`+missing.String()+`","`+unused.ID.String()+`"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("bulk delete: expected 200 got %d body=%s", rec.Code, rec.Body.String())
+	}
+	var bulk struct {
+		Items []struct {
+			ID         uuid.UUID        `json:"id"`
+			Status     string           `json:"status"`
+			References map[string]int64 `json:"references"`
+		} `json:"items"`
+		Summary map[string]int `json:"summary"`
+	}
+	_ = json.Unmarshal(rec.Body.Bytes(), &bulk)
+	if len(bulk.Items) != 3 ||
+		bulk.Items[0].Status != "REFERENCED" || bulk.Items[0].References["payments.source_token_id"] != 3 ||
+		bulk.Items[1].Status != "DELETED" || bulk.Items[2].Status != "NOT_FOUND" ||
+		bulk.Summary["DELETED"] != 1 || bulk.Summary["REFERENCED"] != 1 || bulk.Summary["NOT_FOUND"] != 1 {
+		t.Fatalf("unexpected bulk body=%s", rec.Body.String())
+	}
+	if _, ok := tokenRepo.items[used.ID]; !ok {
+		t.Fatal("referenced token must survive a bulk hard delete")
+	}
+	if rec := send(http.MethodPost, "/admin/tokens/bulk-delete", `{"ids":[]}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("empty bulk delete: expected 400 got %d", rec.Code)
+	}
+}
+
+func TestChainHandler_HardDeleteAndDeactivate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	chainRepo := newChainRepoStub()
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM, Name: "Base", IsActive: true}
+	chainRepo.items[chain.ID] = chain
+	chainRepo.references = map[uuid.UUID]map[string]int64{chain.ID: {"payments.dest_chain_id": 2, "tokens.chain_id": 4}}
+
+	h := NewChainHandler(chainRepo, nil)
+	r := gin.New()
+	r.DELETE("/admin/chains/:id", h.DeleteChain)
+	r.POST("/admin/chains/:id/deactivate", h.DeactivateChain)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/chains/"+chain.ID.String()+"?hard=true", nil))
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), `"tokens.chain_id":4`) {
+		t.Fatalf("expected 409 naming references, got %d body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/chains/"+chain.ID.String()+"/deactivate", nil))
+	if rec.Code != http.StatusOK || chain.IsActive {
+		t.Fatalf("deactivate: got %d active=%v body=%s", rec.Code, chain.IsActive, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/chains/"+uuid.NewString()+"/deactivate", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("deactivate missing: expected 404 got %d", rec.Code)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/utils"
 )
@@ -56,9 +57,13 @@ func (s *contractAuditChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bo
 func (s *contractAuditChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *contractAuditChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *contractAuditChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *contractAuditChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *contractAuditChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *contractAuditChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *contractAuditChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *contractAuditChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *contractAuditChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -89,9 +94,15 @@ func (s *contractAuditContractRepoStub) GetByChainAndAddress(context.Context, uu
 func (s *contractAuditContractRepoStub) GetActiveContract(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
 	return nil, domainerrors.ErrNotFound
 }
-func (s *contractAuditContractRepoStub) GetFiltered(context.Context, *uuid.UUID, entities.SmartContractType, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+func (s *contractAuditContractRepoStub) GetFiltered(context.Context, repositories.SmartContractFilter, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return []*entities.SmartContract{}, 0, nil
 }
+func (s *contractAuditContractRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
+func (s *contractAuditContractRepoStub) Activate(context.Context, uuid.UUID) (*entities.SmartContract, error) {
+	return nil, nil
+}
 func (s *contractAuditContractRepoStub) GetByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
@@ -357,6 +357,63 @@ func TestCreatePayment_ExpiresInScenarios_Integration(t *testing.T) {
 	}
 }
 
+func TestCreatePayment_SettlementWalletOffAllowlist_Integration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := newCreatePaymentScenarioDB(t)
+	createPartnerHTTPFlowTables(t, db)
+	mustExecPartnerHTTP(t, db, `CREATE TABLE merchant_allowed_receivers (
+		id TEXT PRIMARY KEY,
+		merchant_id TEXT NOT NULL,
+		address TEXT NOT NULL,
+		label TEXT NOT NULL DEFAULT '',
+		created_by TEXT,
+		created_at DATETIME,
+		UNIQUE (merchant_id, address)
+	);`)
+
+	now := time.Now().UTC()
+	merchantID := uuid.New()
+	baseChainID := uuid.New()
+	mustExecPartnerHTTP(t, db, `INSERT INTO merchants (id, user_id, business_name, business_email, merchant_type, status, tax_id, business_address, documents, fee_discount_percent, callback_url, webhook_secret, webhook_is_active, support_email, logo_url, webhook_metadata, metadata, verified_at, created_at, updated_at, deleted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULL)`, merchantID.String(), uuid.NewString(), "Merchant", "merchant@example.com", "PARTNER", "ACTIVE", "", "", "{}", "0", "", "", false, "", "", "{}", `{}`, now, now, now)
+	// The profile was pointed at a wallet the merchant never allowed
+	mustExecPartnerHTTP(t, db, `INSERT INTO merchant_settlement_profiles (id, merchant_id, invoice_currency, dest_chain, dest_token, dest_wallet, bridge_token_symbol, created_at, updated_at, deleted_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NULL)`, uuid.NewString(), merchantID.String(), "IDRX", "eip155:8453", "0xbaseidrx", "0x2222222222222222222222222222222222222222", "USDC", now, now)
+	mustExecPartnerHTTP(t, db, `INSERT INTO merchant_allowed_receivers (id, merchant_id, address, created_at) VALUES (?, ?, ?, ?)`, uuid.NewString(), merchantID.String(), "0x1111111111111111111111111111111111111111", now)
+	mustExecPartnerHTTP(t, db, `INSERT INTO chains (id, chain_id, name, type, rpc_url, explorer_url, currency_symbol, image_url, is_active, state_machine_id, ccip_chain_selector, stargate_eid, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, baseChainID.String(), "8453", "Base", "EVM", "https://rpc.base.example", "", "ETH", "", true, "", "", 0, now, now)
+	mustExecPartnerHTTP(t, db, `INSERT INTO tokens (id, chain_id, symbol, name, decimals, address, type, logo_url, is_active, is_native, is_stablecoin, min_amount, max_amount, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, uuid.NewString(), baseChainID.String(), "IDRX", "IDRX", 2, "0xbaseidrx", "ERC20", "", true, false, false, "0", nil, now, now)
+	mustExecPartnerHTTP(t, db, `INSERT INTO tokens (id, chain_id, symbol, name, decimals, address, type, logo_url, is_active, is_native, is_stablecoin, min_amount, max_amount, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, uuid.NewString(), baseChainID.String(), "USDC", "USDC", 6, "0xbaseusdc", "ERC20", "", true, false, true, "0", nil, now, now)
+
+	chainRepo := repositories.NewChainRepository(db)
+	tokenRepo := repositories.NewTokenRepository(db, chainRepo)
+	contractRepo := repositories.NewSmartContractRepository(db, chainRepo)
+	quoteRepo := repositories.NewPaymentQuoteRepository(db)
+	sessionRepo := repositories.NewPartnerPaymentSessionRepository(db)
+	paymentRequestRepo := repositories.NewPaymentRequestRepository(db)
+	merchantRepo := repositories.NewMerchantRepository(db)
+	jweService, err := services.NewJWEService([]byte("12345678901234567890123456789012"))
+	require.NoError(t, err)
+
+	quoteUsecase := usecases.NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
+	paymentRequestUsecase := usecases.NewPaymentRequestUsecase(paymentRequestRepo, merchantRepo, nil, chainRepo, contractRepo, tokenRepo, jweService)
+	sessionUsecase := usecases.NewPartnerPaymentSessionUsecase(quoteRepo, sessionRepo, paymentRequestRepo, contractRepo, tokenRepo, chainRepo, merchantRepo, repositories.NewUnitOfWork(db), jweService, paymentRequestUsecase, nil, "https://partner.pay.test/pay")
+	createPaymentUsecase := usecases.NewCreatePaymentUsecaseWithReceiverAllowlist(merchantRepo, repositories.NewMerchantSettlementProfileRepository(db), repositories.NewWalletRepository(db), tokenRepo, chainRepo, quoteRepo, sessionRepo, quoteUsecase, sessionUsecase, repositories.NewMerchantAllowedReceiverRepository(db))
+	handler := NewCreatePaymentHandler(createPaymentUsecase)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.MerchantIDKey, merchantID)
+		c.Next()
+	})
+	router.POST("/api/v1/create-payment", handler.CreatePayment)
+
+	body := `{"chain_id":"eip155:8453","selected_token":"0xbaseusdc","pricing_type":"invoice_currency","requested_amount":"50000"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/create-payment", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), "ERR_RECEIVER_NOT_ALLOWED")
+}
+
 func newCreatePaymentScenarioDB(t *testing.T) *gorm.DB {
 	t.Helper()
 	dsn := fmt.Sprintf("file:%s_%d?mode=memory&cache=shared", t.Name(), time.Now().UnixNano())
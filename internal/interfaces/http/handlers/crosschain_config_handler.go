@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	domainerrors "payment-kita.backend/internal/domain/errors"
@@ -15,24 +19,57 @@ import (
 
 type crosschainConfigService interface {
 	Overview(ctx context.Context, sourceChainInput, destChainInput string, pagination utils.PaginationParams) (*usecases.CrosschainOverview, error)
+	StoredOverview(ctx context.Context, sourceChainInput, destChainInput string, pagination utils.PaginationParams) (*usecases.CrosschainOverview, error)
 	RecheckRoute(ctx context.Context, sourceChainInput, destChainInput string) (*usecases.CrosschainRouteStatus, error)
 	Preflight(ctx context.Context, sourceChainInput, destChainInput string) (*usecases.CrosschainPreflightResult, error)
 	AutoFix(ctx context.Context, req *usecases.AutoFixRequest) (*usecases.AutoFixResult, error)
 }
 
+// Defaults for CrosschainBulkOptions fields left at zero
+const (
+	defaultRecheckConcurrency  = 4
+	defaultRecheckRouteTimeout = 30 * time.Second
+)
+
+// CrosschainBulkOptions bounds recheck-bulk: how many routes are checked at once and how long
+// one route may take before it is reported as timed out
+type CrosschainBulkOptions struct {
+	RecheckConcurrency  int
+	RecheckRouteTimeout time.Duration
+}
+
 type CrosschainConfigHandler struct {
 	usecase crosschainConfigService
+	bulk    CrosschainBulkOptions
 }
 
 func NewCrosschainConfigHandler(usecase *usecases.CrosschainConfigUsecase) *CrosschainConfigHandler {
 	return &CrosschainConfigHandler{usecase: usecase}
 }
 
+// NewCrosschainConfigHandlerWithBulkOptions is NewCrosschainConfigHandler with recheck-bulk limits
+func NewCrosschainConfigHandlerWithBulkOptions(usecase *usecases.CrosschainConfigUsecase, opts CrosschainBulkOptions) *CrosschainConfigHandler {
+	return &CrosschainConfigHandler{usecase: usecase, bulk: opts}
+}
+
+// Overview checks every route live by default; source=db serves the last stored results instead
 func (h *CrosschainConfigHandler) Overview(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
 	pagination := utils.GetPaginationParams(page, limit)
-	result, err := h.usecase.Overview(
+
+	overview := h.usecase.Overview
+	source := strings.ToLower(strings.TrimSpace(c.DefaultQuery("source", "live")))
+	switch source {
+	case "live":
+	case "db":
+		overview = h.usecase.StoredOverview
+	default:
+		response.Error(c, domainerrors.BadRequest("source must be live or db"))
+		return
+	}
+
+	result, err := overview(
 		c.Request.Context(),
 		strings.TrimSpace(c.Query("sourceChainId")),
 		strings.TrimSpace(c.Query("destChainId")),
@@ -42,7 +79,7 @@ func (h *CrosschainConfigHandler) Overview(c *gin.Context) {
 		response.Error(c, err)
 		return
 	}
-	response.Success(c, http.StatusOK, gin.H{"items": result.Items, "meta": result.Meta})
+	response.Success(c, http.StatusOK, gin.H{"items": result.Items, "meta": result.Meta, "source": source})
 }
 
 func (h *CrosschainConfigHandler) Recheck(c *gin.Context) {
@@ -92,68 +129,222 @@ func (h *CrosschainConfigHandler) AutoFix(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"result": result})
 }
 
+// bulkRecheckInput is the body of the recheck-bulk endpoints
+type bulkRecheckInput struct {
+	Routes []struct {
+		SourceChainID string `json:"sourceChainId" binding:"required"`
+		DestChainID   string `json:"destChainId" binding:"required"`
+	} `json:"routes" binding:"required"`
+}
+
+// bulkAutoFixInput is the body of the auto-fix-bulk endpoints
+type bulkAutoFixInput struct {
+	Routes []usecases.AutoFixRequest `json:"routes" binding:"required"`
+}
+
 func (h *CrosschainConfigHandler) RecheckBulk(c *gin.Context) {
-	var input struct {
-		Routes []struct {
-			SourceChainID string `json:"sourceChainId" binding:"required"`
-			DestChainID   string `json:"destChainId" binding:"required"`
-		} `json:"routes" binding:"required"`
-	}
+	var input bulkRecheckInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		response.Error(c, domainerrors.BadRequest(err.Error()))
 		return
 	}
-	results := make([]usecases.CrosschainRouteStatus, 0, len(input.Routes))
-	for _, route := range input.Routes {
-		item, err := h.usecase.RecheckRoute(c.Request.Context(), route.SourceChainID, route.DestChainID)
-		if err != nil {
-			results = append(results, usecases.CrosschainRouteStatus{
-				RouteKey:      route.SourceChainID + "->" + route.DestChainID,
-				SourceChainID: route.SourceChainID,
-				DestChainID:   route.DestChainID,
-				OverallStatus: "ERROR",
-				Issues: []usecases.ContractConfigCheckItem{
-					{
-						Code:    "RECHECK_FAILED",
-						Status:  "ERROR",
-						Message: err.Error(),
-					},
-				},
-			})
-			continue
-		}
-		results = append(results, *item)
-	}
+	results := make([]usecases.CrosschainRouteStatus, len(input.Routes))
+	h.recheckRoutes(c.Request.Context(), input, func(i int, item usecases.CrosschainRouteStatus) bool {
+		results[i] = item
+		return true
+	})
 	response.Success(c, http.StatusOK, gin.H{"items": results})
 }
 
-func (h *CrosschainConfigHandler) AutoFixBulk(c *gin.Context) {
-	var input struct {
-		Routes []usecases.AutoFixRequest `json:"routes" binding:"required"`
+// RecheckBulkStream is RecheckBulk as NDJSON: one line per route as it completes (in completion
+// order, see "index"), then a closing {"done":true} line, so the admin UI can show progress
+func (h *CrosschainConfigHandler) RecheckBulkStream(c *gin.Context) {
+	var input bulkRecheckInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
 	}
+	streamNDJSON(c, len(input.Routes), func(emit func(i int, item interface{}) bool) {
+		h.recheckRoutes(c.Request.Context(), input, func(i int, item usecases.CrosschainRouteStatus) bool {
+			return emit(i, item)
+		})
+	})
+}
+
+func (h *CrosschainConfigHandler) AutoFixBulk(c *gin.Context) {
+	var input bulkAutoFixInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		response.Error(c, domainerrors.BadRequest(err.Error()))
 		return
 	}
 	results := make([]*usecases.AutoFixResult, 0, len(input.Routes))
 	for _, route := range input.Routes {
-		routeCopy := route
-		item, err := h.usecase.AutoFix(c.Request.Context(), &routeCopy)
-		if err != nil {
-			results = append(results, &usecases.AutoFixResult{
-				SourceChainID: route.SourceChainID,
-				DestChainID:   route.DestChainID,
-				Steps: []usecases.AutoFixStep{
-					{
-						Step:    "autoFix",
-						Status:  "FAILED",
-						Message: err.Error(),
-					},
+		results = append(results, h.autoFixOne(c.Request.Context(), route))
+	}
+	response.Success(c, http.StatusOK, gin.H{"items": results})
+}
+
+// AutoFixBulkStream is AutoFixBulk as NDJSON, one line per route
+func (h *CrosschainConfigHandler) AutoFixBulkStream(c *gin.Context) {
+	var input bulkAutoFixInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+	// Auto-fix stays sequential: every step is an owner tx and parallel sends would race on nonces
+	streamNDJSON(c, len(input.Routes), func(emit func(i int, item interface{}) bool) {
+		for i, route := range input.Routes {
+			if !emit(i, h.autoFixOne(c.Request.Context(), route)) {
+				return
+			}
+		}
+	})
+}
+
+// recheckRoutes rechecks every route on a bounded worker pool and passes each result to emit
+// as it completes. emit is never called concurrently; returning false stops the remaining routes.
+func (h *CrosschainConfigHandler) recheckRoutes(ctx context.Context, input bulkRecheckInput, emit func(i int, item usecases.CrosschainRouteStatus) bool) {
+	concurrency := h.bulk.RecheckConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultRecheckConcurrency
+	}
+	timeout := h.bulk.RecheckRouteTimeout
+	if timeout <= 0 {
+		timeout = defaultRecheckRouteTimeout
+	}
+	runBulk(len(input.Routes), concurrency, func(i int) usecases.CrosschainRouteStatus {
+		route := input.Routes[i]
+		return h.recheckOne(ctx, route.SourceChainID, route.DestChainID, timeout)
+	}, emit)
+}
+
+// recheckOne rechecks a route within timeout, reporting a failure or timeout as an ERROR item
+// so one bad route does not fail the batch. A recheck stuck on an RPC that ignores its context
+// is abandoned rather than waited for.
+func (h *CrosschainConfigHandler) recheckOne(ctx context.Context, sourceChainID, destChainID string, timeout time.Duration) usecases.CrosschainRouteStatus {
+	routeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		item *usecases.CrosschainRouteStatus
+		err  error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		item, err := h.usecase.RecheckRoute(routeCtx, sourceChainID, destChainID)
+		done <- outcome{item, err}
+	}()
+
+	var result outcome
+	code := "RECHECK_FAILED"
+	select {
+	case result = <-done:
+	case <-routeCtx.Done():
+		code = "RECHECK_TIMEOUT"
+		result.err = fmt.Errorf("route recheck did not finish within %s: %w", timeout, routeCtx.Err())
+	}
+	if result.err != nil {
+		return usecases.CrosschainRouteStatus{
+			RouteKey:      sourceChainID + "->" + destChainID,
+			SourceChainID: sourceChainID,
+			DestChainID:   destChainID,
+			OverallStatus: "ERROR",
+			Issues: []usecases.ContractConfigCheckItem{
+				{
+					Code:    code,
+					Status:  "ERROR",
+					Message: result.err.Error(),
 				},
-			})
-			continue
+			},
 		}
-		results = append(results, item)
 	}
-	response.Success(c, http.StatusOK, gin.H{"items": results})
+	return *result.item
+}
+
+// runBulk calls work for indexes 0..n-1 on up to concurrency goroutines and hands each result
+// to emit from a single goroutine. Once emit returns false, unstarted items are skipped.
+func runBulk[T any](n, concurrency int, work func(i int) T, emit func(i int, item T) bool) {
+	type indexed struct {
+		i    int
+		item T
+	}
+	jobs := make(chan int)
+	results := make(chan indexed)
+	stop := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results <- indexed{i, work(i)}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := 0; i < n; i++ {
+			select {
+			case jobs <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	stopped := false
+	for r := range results {
+		if !stopped && !emit(r.i, r.item) {
+			stopped = true
+			close(stop)
+		}
+	}
+}
+
+func (h *CrosschainConfigHandler) autoFixOne(ctx context.Context, route usecases.AutoFixRequest) *usecases.AutoFixResult {
+	item, err := h.usecase.AutoFix(ctx, &route)
+	if err != nil {
+		return &usecases.AutoFixResult{
+			SourceChainID: route.SourceChainID,
+			DestChainID:   route.DestChainID,
+			Steps: []usecases.AutoFixStep{
+				{
+					Step:    "autoFix",
+					Status:  "FAILED",
+					Message: err.Error(),
+				},
+			},
+		}
+	}
+	return item
+}
+
+// streamNDJSON writes {"index","total","item"} for each item run emits, flushing after each
+// line, then {"done":true,"total","completed"}. emit returns false once the client is gone.
+func streamNDJSON(c *gin.Context, n int, run func(emit func(i int, item interface{}) bool)) {
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // keep nginx from buffering the stream
+	c.Status(http.StatusOK)
+
+	encoder := json.NewEncoder(c.Writer)
+	completed := 0
+	run(func(i int, item interface{}) bool {
+		if c.Request.Context().Err() != nil {
+			return false
+		}
+		if err := encoder.Encode(gin.H{"index": i, "total": n, "item": item}); err != nil {
+			return false
+		}
+		c.Writer.Flush()
+		completed++
+		return true
+	})
+	_ = encoder.Encode(gin.H{"done": true, "total": n, "completed": completed})
+	c.Writer.Flush()
 }
@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
@@ -48,3 +52,110 @@ func TestCrosschainConfigHandler_BulkSuccessFlows(t *testing.T) {
 	require.Equal(t, http.StatusOK, w.Code)
 	require.Contains(t, w.Body.String(), "SUCCESS")
 }
+
+func TestCrosschainConfigHandler_BulkStreams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &CrosschainConfigHandler{usecase: crosschainConfigServiceStub{
+		recheckFn: func(_ context.Context, src, dst string) (*usecases.CrosschainRouteStatus, error) {
+			if dst == "eip155:10" {
+				return nil, errors.New("rpc down")
+			}
+			return &usecases.CrosschainRouteStatus{RouteKey: src + "->" + dst, OverallStatus: "READY"}, nil
+		},
+		autofixFn: func(_ context.Context, req *usecases.AutoFixRequest) (*usecases.AutoFixResult, error) {
+			return &usecases.AutoFixResult{SourceChainID: req.SourceChainID, DestChainID: req.DestChainID}, nil
+		},
+	}}
+
+	r := gin.New()
+	r.POST("/recheck-bulk/stream", h.RecheckBulkStream)
+	r.POST("/autofix-bulk/stream", h.AutoFixBulkStream)
+
+	recheckBody := `{"routes":[{"sourceChainId":"eip155:8453","destChainId":"eip155:42161"},{"sourceChainId":"eip155:8453","destChainId":"eip155:10"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/recheck-bulk/stream", strings.NewReader(recheckBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+	// Routes are checked concurrently, so lines arrive in completion order
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	require.Len(t, lines, 3)
+	statusByIndex := map[float64]interface{}{}
+	for _, line := range lines[:2] {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		statusByIndex[entry["index"].(float64)] = entry["item"].(map[string]interface{})["overallStatus"]
+	}
+	require.Equal(t, map[float64]interface{}{0: "READY", 1: "ERROR"}, statusByIndex)
+	var done map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &done))
+	require.Equal(t, true, done["done"])
+	require.Equal(t, float64(2), done["completed"])
+
+	req = httptest.NewRequest(http.MethodPost, "/autofix-bulk/stream", strings.NewReader(`{"routes":[{"sourceChainId":"eip155:8453","destChainId":"eip155:42161"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, strings.Split(strings.TrimSpace(w.Body.String()), "\n"), 2)
+
+	req = httptest.NewRequest(http.MethodPost, "/autofix-bulk/stream", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCrosschainConfigHandler_RecheckBulkTimesOutStuckRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	release := make(chan struct{})
+	defer close(release)
+	var inFlight, peak int32
+	h := &CrosschainConfigHandler{
+		usecase: crosschainConfigServiceStub{
+			recheckFn: func(_ context.Context, src, dst string) (*usecases.CrosschainRouteStatus, error) {
+				if dst == "eip155:stuck" {
+					<-release // an RPC that ignores its context
+					return nil, errors.New("released")
+				}
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				return &usecases.CrosschainRouteStatus{RouteKey: src + "->" + dst, OverallStatus: "READY"}, nil
+			},
+		},
+		bulk: CrosschainBulkOptions{RecheckConcurrency: 2, RecheckRouteTimeout: 50 * time.Millisecond},
+	}
+
+	r := gin.New()
+	r.POST("/recheck-bulk", h.RecheckBulk)
+
+	body := `{"routes":[{"sourceChainId":"a","destChainId":"eip155:stuck"},{"sourceChainId":"a","destChainId":"b"},{"sourceChainId":"a","destChainId":"c"},{"sourceChainId":"a","destChainId":"d"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/recheck-bulk", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Items []usecases.CrosschainRouteStatus `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Items, 4)
+	require.Equal(t, "ERROR", resp.Items[0].OverallStatus)
+	require.Equal(t, "RECHECK_TIMEOUT", resp.Items[0].Issues[0].Code)
+	for _, item := range resp.Items[1:] {
+		require.Equal(t, "READY", item.OverallStatus)
+	}
+	require.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
@@ -15,6 +15,7 @@ import (
 
 type crosschainConfigServiceStub struct {
 	overviewFn func(context.Context, string, string, utils.PaginationParams) (*usecases.CrosschainOverview, error)
+	storedFn   func(context.Context, string, string, utils.PaginationParams) (*usecases.CrosschainOverview, error)
 	recheckFn  func(context.Context, string, string) (*usecases.CrosschainRouteStatus, error)
 	preflightFn func(context.Context, string, string) (*usecases.CrosschainPreflightResult, error)
 	autofixFn  func(context.Context, *usecases.AutoFixRequest) (*usecases.AutoFixResult, error)
@@ -26,6 +27,12 @@ func (s crosschainConfigServiceStub) Overview(ctx context.Context, src, dst stri
 	}
 	return &usecases.CrosschainOverview{}, nil
 }
+func (s crosschainConfigServiceStub) StoredOverview(ctx context.Context, src, dst string, p utils.PaginationParams) (*usecases.CrosschainOverview, error) {
+	if s.storedFn != nil {
+		return s.storedFn(ctx, src, dst, p)
+	}
+	return &usecases.CrosschainOverview{}, nil
+}
 func (s crosschainConfigServiceStub) RecheckRoute(ctx context.Context, src, dst string) (*usecases.CrosschainRouteStatus, error) {
 	if s.recheckFn != nil {
 		return s.recheckFn(ctx, src, dst)
@@ -85,3 +92,34 @@ func TestCrosschainConfigHandler_SuccessFlows_Gap(t *testing.T) {
 	require.Equal(t, http.StatusOK, w.Code)
 	require.Contains(t, w.Body.String(), "setDefaultBridge")
 }
+
+func TestCrosschainConfigHandler_OverviewSource(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var used string
+	h := &CrosschainConfigHandler{usecase: crosschainConfigServiceStub{
+		overviewFn: func(context.Context, string, string, utils.PaginationParams) (*usecases.CrosschainOverview, error) {
+			used = "live"
+			return &usecases.CrosschainOverview{}, nil
+		},
+		storedFn: func(_ context.Context, src, _ string, _ utils.PaginationParams) (*usecases.CrosschainOverview, error) {
+			used = "db"
+			require.Equal(t, "eip155:8453", src)
+			return &usecases.CrosschainOverview{}, nil
+		},
+	}}
+	r := gin.New()
+	r.GET("/overview", h.Overview)
+
+	for query, want := range map[string]string{"": "live", "?source=live": "live", "?source=DB&sourceChainId=eip155:8453": "db"} {
+		used = ""
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/overview"+query, nil))
+		require.Equal(t, http.StatusOK, w.Code, query)
+		require.Equal(t, want, used, query)
+		require.Contains(t, w.Body.String(), `"source":"`+want+`"`)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/overview?source=cache", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/utils"
 )
@@ -39,12 +40,20 @@ func (s *cfgChainRepoStub) GetAll(ctx context.Context) ([]*entities.Chain, error
 func (s *cfgChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool, *string, utils.PaginationParams) ([]*entities.ChainRPC, int64, error) {
 	return nil, 0, nil
 }
-func (s *cfgChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+func (s *cfgChainRepoStub) GetActive(ctx context.Context, _ utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	chains, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return chains, int64(len(chains)), nil
+}
+func (s *cfgChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
 func (s *cfgChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *cfgChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *cfgChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *cfgChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *cfgChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *cfgChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *cfgChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -64,6 +73,7 @@ func (cfgTokenRepoStub) GetByAddress(context.Context, string, uuid.UUID) (*entit
 	return nil, domainerrors.ErrNotFound
 }
 func (cfgTokenRepoStub) GetAll(context.Context) ([]*entities.Token, error)         { return nil, nil }
+func (cfgTokenRepoStub) GetActiveBySymbol(context.Context, string) ([]*entities.Token, error) { return nil, nil }
 func (cfgTokenRepoStub) GetStablecoins(context.Context) ([]*entities.Token, error) { return nil, nil }
 func (cfgTokenRepoStub) GetNative(context.Context, uuid.UUID) (*entities.Token, error) {
 	return nil, domainerrors.ErrNotFound
@@ -71,12 +81,16 @@ func (cfgTokenRepoStub) GetNative(context.Context, uuid.UUID) (*entities.Token,
 func (cfgTokenRepoStub) GetTokensByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
-func (cfgTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, utils.PaginationParams) ([]*entities.Token, int64, error) {
+func (cfgTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, bool, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
+func (cfgTokenRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
 func (cfgTokenRepoStub) Create(context.Context, *entities.Token) error { return nil }
 func (cfgTokenRepoStub) Update(context.Context, *entities.Token) error { return nil }
 func (cfgTokenRepoStub) SoftDelete(context.Context, uuid.UUID) error   { return nil }
+func (cfgTokenRepoStub) HardDelete(context.Context, uuid.UUID) error   { return nil }
 
 type cfgContractRepoStub struct{}
 
@@ -90,9 +104,15 @@ func (cfgContractRepoStub) GetByChainAndAddress(context.Context, uuid.UUID, stri
 func (cfgContractRepoStub) GetActiveContract(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
 	return nil, domainerrors.ErrNotFound
 }
-func (cfgContractRepoStub) GetFiltered(context.Context, *uuid.UUID, entities.SmartContractType, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+func (cfgContractRepoStub) GetFiltered(context.Context, repositories.SmartContractFilter, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
+func (cfgContractRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
+func (cfgContractRepoStub) Activate(context.Context, uuid.UUID) (*entities.SmartContract, error) {
+	return nil, nil
+}
 func (cfgContractRepoStub) GetByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -21,9 +22,12 @@ func (routePolicyRepoCreateNoop) GetByID(context.Context, uuid.UUID) (*entities.
 func (routePolicyRepoCreateNoop) GetByRoute(context.Context, uuid.UUID, uuid.UUID) (*entities.RoutePolicy, error) {
 	return nil, nil
 }
-func (routePolicyRepoCreateNoop) List(context.Context, *uuid.UUID, *uuid.UUID, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+func (routePolicyRepoCreateNoop) List(context.Context, repositories.RoutePolicyFilter, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
 	return nil, 0, nil
 }
+func (routePolicyRepoCreateNoop) CreateMissing(_ context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+	return make([]bool, len(policies)), nil
+}
 func (routePolicyRepoCreateNoop) Create(context.Context, *entities.RoutePolicy) error { return nil }
 func (routePolicyRepoCreateNoop) Update(context.Context, *entities.RoutePolicy) error { return nil }
 func (routePolicyRepoCreateNoop) Delete(context.Context, uuid.UUID) error             { return nil }
@@ -79,6 +83,8 @@ func TestCrosschainPolicyHandler_CreateRoutePolicy_ValidationMatrix(t *testing.T
 		`{"sourceChainId":"eip155:8453","destChainId":"eip155:42161","defaultBridgeType":0,"maxFee":"bad"}`,
 		// maxFee lower than minFee
 		`{"sourceChainId":"eip155:8453","destChainId":"eip155:42161","defaultBridgeType":0,"minFee":"100","maxFee":"99"}`,
+		// invalid fallbackBridgeFee (decimal)
+		`{"sourceChainId":"eip155:8453","destChainId":"eip155:42161","defaultBridgeType":0,"fallbackBridgeFee":"0.1"}`,
 		// invalid status
 		`{"sourceChainId":"eip155:8453","destChainId":"eip155:42161","defaultBridgeType":0,"status":"unknown"}`,
 		// invalid bridge token
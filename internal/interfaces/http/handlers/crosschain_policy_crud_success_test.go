@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -27,12 +28,15 @@ func (m *routePolicyRepoMemory) GetByID(context.Context, uuid.UUID) (*entities.R
 func (m *routePolicyRepoMemory) GetByRoute(context.Context, uuid.UUID, uuid.UUID) (*entities.RoutePolicy, error) {
 	return m.item, nil
 }
-func (m *routePolicyRepoMemory) List(context.Context, *uuid.UUID, *uuid.UUID, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+func (m *routePolicyRepoMemory) List(context.Context, repositories.RoutePolicyFilter, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
 	if m.item == nil {
 		return []*entities.RoutePolicy{}, 0, nil
 	}
 	return []*entities.RoutePolicy{m.item}, 1, nil
 }
+func (m *routePolicyRepoMemory) CreateMissing(_ context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+	return make([]bool, len(policies)), nil
+}
 func (m *routePolicyRepoMemory) Create(_ context.Context, p *entities.RoutePolicy) error {
 	m.item = p
 	return nil
@@ -90,7 +94,7 @@ func TestCrosschainPolicyHandler_CRUDSuccessPaths(t *testing.T) {
 	r.POST("/lz", h.CreateStargateConfig)
 	r.PUT("/lz/:id", h.UpdateStargateConfig)
 
-	createRouteBody := `{"sourceChainId":"` + sourceID.String() + `","destChainId":"` + destID.String() + `","defaultBridgeType":1,"fallbackMode":"auto_fallback","fallbackOrder":[1,0],"supportsTokenBridge":true,"supportsDestSwap":true,"supportsPrivacyForward":false,"bridgeToken":"0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913","status":"active","perByteRate":"300","overheadBytes":"256","minFee":"1000","maxFee":"999999"}`
+	createRouteBody := `{"sourceChainId":"` + sourceID.String() + `","destChainId":"` + destID.String() + `","defaultBridgeType":1,"fallbackMode":"auto_fallback","fallbackOrder":[1,0],"supportsTokenBridge":true,"supportsDestSwap":true,"supportsPrivacyForward":false,"bridgeToken":"0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913","status":"active","perByteRate":"300","overheadBytes":"256","minFee":"1000","maxFee":"999999","fallbackBridgeFee":"300000000000000"}`
 	req := httptest.NewRequest(http.MethodPost, "/route", strings.NewReader(createRouteBody))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
@@ -102,6 +106,7 @@ func TestCrosschainPolicyHandler_CRUDSuccessPaths(t *testing.T) {
 	require.Equal(t, "256", routeRepo.item.OverheadBytes)
 	require.Equal(t, "1000", routeRepo.item.MinFee)
 	require.Equal(t, "999999", routeRepo.item.MaxFee)
+	require.Equal(t, "300000000000000", routeRepo.item.FallbackBridgeFee)
 	require.True(t, routeRepo.item.SupportsTokenBridge)
 	require.True(t, routeRepo.item.SupportsDestSwap)
 	require.False(t, routeRepo.item.SupportsPrivacyForward)
@@ -121,6 +126,7 @@ func TestCrosschainPolicyHandler_CRUDSuccessPaths(t *testing.T) {
 	require.Equal(t, "300", routeRepo.item.OverheadBytes)
 	require.Equal(t, "500", routeRepo.item.MinFee)
 	require.Equal(t, "1000", routeRepo.item.MaxFee)
+	require.Empty(t, routeRepo.item.FallbackBridgeFee)
 	require.True(t, routeRepo.item.SupportsTokenBridge)
 	require.True(t, routeRepo.item.SupportsDestSwap)
 	require.True(t, routeRepo.item.SupportsPrivacyForward)
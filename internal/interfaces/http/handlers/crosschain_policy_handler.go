@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
 	"net/http"
 	"strconv"
@@ -14,6 +16,7 @@ import (
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -21,6 +24,12 @@ type CrosschainPolicyHandler struct {
 	routePolicyRepo     repositories.RoutePolicyRepository
 	stargateConfigRepo repositories.StargateConfigRepository
 	chainRepo           repositories.ChainRepository
+	preflight           routePolicyPreflighter // optional; nil skips the fallback bridge check
+}
+
+// routePolicyPreflighter checks whether bridges can carry payments on a route
+type routePolicyPreflighter interface {
+	PreflightBridges(ctx context.Context, sourceChainInput, destChainInput string, bridgeTypes []uint8) ([]usecases.CrosschainBridgePreflight, error)
 }
 
 func NewCrosschainPolicyHandler(
@@ -35,9 +44,30 @@ func NewCrosschainPolicyHandler(
 	}
 }
 
+// NewCrosschainPolicyHandlerWithPreflight is NewCrosschainPolicyHandler that also refuses to save
+// a route policy whose fallback bridges fail preflight, unless the request passes force=true
+func NewCrosschainPolicyHandlerWithPreflight(
+	routePolicyRepo repositories.RoutePolicyRepository,
+	stargateConfigRepo repositories.StargateConfigRepository,
+	chainRepo repositories.ChainRepository,
+	preflight *usecases.CrosschainConfigUsecase,
+) *CrosschainPolicyHandler {
+	h := NewCrosschainPolicyHandler(routePolicyRepo, stargateConfigRepo, chainRepo)
+	if preflight != nil {
+		h.preflight = preflight
+	}
+	return h
+}
+
+// routePoliciesMaxLimit caps one page of GET /admin/route-policies
+const routePoliciesMaxLimit = 100
+
 func (h *CrosschainPolicyHandler) ListRoutePolicies(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > routePoliciesMaxLimit {
+		limit = 20
+	}
 	pagination := utils.GetPaginationParams(page, limit)
 
 	sourceChainID, err := h.parseChainQuery(c.Request.Context(), c.Query("sourceChainId"))
@@ -51,7 +81,18 @@ func (h *CrosschainPolicyHandler) ListRoutePolicies(c *gin.Context) {
 		return
 	}
 
-	items, total, err := h.routePolicyRepo.List(c.Request.Context(), sourceChainID, destChainID, pagination)
+	filter := repositories.RoutePolicyFilter{SourceChainID: sourceChainID, DestChainID: destChainID}
+	if raw := strings.TrimSpace(c.Query("defaultBridgeType")); raw != "" {
+		bridgeType, err := strconv.ParseUint(raw, 10, 8)
+		if err != nil || !isValidBridgeType(uint8(bridgeType)) {
+			response.Error(c, domainerrors.BadRequest("invalid defaultBridgeType"))
+			return
+		}
+		v := uint8(bridgeType)
+		filter.DefaultBridgeType = &v
+	}
+
+	items, total, err := h.routePolicyRepo.List(c.Request.Context(), filter, pagination)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -62,45 +103,62 @@ func (h *CrosschainPolicyHandler) ListRoutePolicies(c *gin.Context) {
 	})
 }
 
+// routePolicyCreateInput is one route policy to create, as POST /admin/route-policies takes it
+type routePolicyCreateInput struct {
+	SourceChainID          string  `json:"sourceChainId" binding:"required"`
+	DestChainID            string  `json:"destChainId" binding:"required"`
+	DefaultBridgeType      *uint8  `json:"defaultBridgeType" binding:"required"`
+	FallbackMode           string  `json:"fallbackMode"`
+	FallbackOrder          []uint8 `json:"fallbackOrder"`
+	SupportsTokenBridge    *bool   `json:"supportsTokenBridge"`
+	SupportsDestSwap       *bool   `json:"supportsDestSwap"`
+	SupportsPrivacyForward *bool   `json:"supportsPrivacyForward"`
+	BridgeToken            *string `json:"bridgeToken"`
+	Status                 *string `json:"status"`
+	PerByteRate            string  `json:"perByteRate"`
+	OverheadBytes          string  `json:"overheadBytes"`
+	MinFee                 string  `json:"minFee"`
+	MaxFee                 string  `json:"maxFee"`
+	FallbackBridgeFee      string  `json:"fallbackBridgeFee"`
+}
+
 func (h *CrosschainPolicyHandler) CreateRoutePolicy(c *gin.Context) {
-	var input struct {
-		SourceChainID          string  `json:"sourceChainId" binding:"required"`
-		DestChainID            string  `json:"destChainId" binding:"required"`
-		DefaultBridgeType      *uint8  `json:"defaultBridgeType" binding:"required"`
-		FallbackMode           string  `json:"fallbackMode"`
-		FallbackOrder          []uint8 `json:"fallbackOrder"`
-		SupportsTokenBridge    *bool   `json:"supportsTokenBridge"`
-		SupportsDestSwap       *bool   `json:"supportsDestSwap"`
-		SupportsPrivacyForward *bool   `json:"supportsPrivacyForward"`
-		BridgeToken            *string `json:"bridgeToken"`
-		Status                 *string `json:"status"`
-		PerByteRate            string  `json:"perByteRate"`
-		OverheadBytes          string  `json:"overheadBytes"`
-		MinFee                 string  `json:"minFee"`
-		MaxFee                 string  `json:"maxFee"`
-	}
+	var input routePolicyCreateInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		response.Error(c, domainerrors.BadRequest(err.Error()))
 		return
 	}
-
-	sourceChainID, err := h.parseChainID(c.Request.Context(), input.SourceChainID)
+	item, err := h.buildRoutePolicy(c.Request.Context(), input)
 	if err != nil {
-		response.Error(c, domainerrors.BadRequest("invalid sourceChainId"))
+		response.Error(c, err)
 		return
 	}
-	destChainID, err := h.parseChainID(c.Request.Context(), input.DestChainID)
-	if err != nil {
-		response.Error(c, domainerrors.BadRequest("invalid destChainId"))
+	if err := h.checkFallbackBridges(c, item); err != nil {
+		response.Error(c, err)
 		return
 	}
-	if sourceChainID == destChainID {
-		response.Error(c, domainerrors.BadRequest("sourceChainId and destChainId must be different"))
+	if err := h.routePolicyRepo.Create(c.Request.Context(), item); err != nil {
+		response.Error(c, err)
 		return
 	}
-	if !isValidBridgeType(*input.DefaultBridgeType) {
-		response.Error(c, domainerrors.BadRequest("invalid defaultBridgeType"))
-		return
+	response.Success(c, http.StatusCreated, gin.H{"policy": item})
+}
+
+// buildRoutePolicy validates input and returns the policy to create
+func (h *CrosschainPolicyHandler) buildRoutePolicy(ctx context.Context, input routePolicyCreateInput) (*entities.RoutePolicy, error) {
+	sourceChainID, err := h.parseChainID(ctx, input.SourceChainID)
+	if err != nil {
+		return nil, domainerrors.BadRequest("invalid sourceChainId")
+	}
+	destChainID, err := h.parseChainID(ctx, input.DestChainID)
+	if err != nil {
+		return nil, domainerrors.BadRequest("invalid destChainId")
+	}
+	if sourceChainID == destChainID {
+		return nil, domainerrors.BadRequest("sourceChainId and destChainId must be different")
+	}
+	if input.DefaultBridgeType == nil || !isValidBridgeType(*input.DefaultBridgeType) {
+		return nil, domainerrors.BadRequest("invalid defaultBridgeType")
 	}
 
 	mode := entities.BridgeFallbackMode(strings.TrimSpace(input.FallbackMode))
@@ -108,56 +166,51 @@ func (h *CrosschainPolicyHandler) CreateRoutePolicy(c *gin.Context) {
 		mode = entities.BridgeFallbackModeStrict
 	}
 	if mode != entities.BridgeFallbackModeStrict && mode != entities.BridgeFallbackModeAutoFallback {
-		response.Error(c, domainerrors.BadRequest("invalid fallbackMode"))
-		return
+		return nil, domainerrors.BadRequest("invalid fallbackMode")
 	}
 	order := input.FallbackOrder
 	if len(order) == 0 {
 		order = []uint8{*input.DefaultBridgeType}
 	}
 	if err := validateBridgeOrder(order); err != nil {
-		response.Error(c, domainerrors.BadRequest(err.Error()))
-		return
+		return nil, domainerrors.BadRequest(err.Error())
 	}
 	perByteRate, err := normalizeUnsignedInteger(input.PerByteRate)
 	if err != nil {
-		response.Error(c, domainerrors.BadRequest("invalid perByteRate"))
-		return
+		return nil, domainerrors.BadRequest("invalid perByteRate")
 	}
 	overheadBytes, err := normalizeUnsignedInteger(input.OverheadBytes)
 	if err != nil {
-		response.Error(c, domainerrors.BadRequest("invalid overheadBytes"))
-		return
+		return nil, domainerrors.BadRequest("invalid overheadBytes")
 	}
 	minFee, err := normalizeUnsignedInteger(input.MinFee)
 	if err != nil {
-		response.Error(c, domainerrors.BadRequest("invalid minFee"))
-		return
+		return nil, domainerrors.BadRequest("invalid minFee")
 	}
 	maxFee, err := normalizeUnsignedInteger(input.MaxFee)
 	if err != nil {
-		response.Error(c, domainerrors.BadRequest("invalid maxFee"))
-		return
+		return nil, domainerrors.BadRequest("invalid maxFee")
 	}
 	if err := validateMinMaxFee(minFee, maxFee); err != nil {
-		response.Error(c, err)
-		return
+		return nil, err
+	}
+	fallbackBridgeFee, err := normalizeUnsignedInteger(input.FallbackBridgeFee)
+	if err != nil {
+		return nil, domainerrors.BadRequest("invalid fallbackBridgeFee")
 	}
 	bridgeToken, err := normalizeBridgeTokenInput(input.BridgeToken)
 	if err != nil {
-		response.Error(c, err)
-		return
+		return nil, err
 	}
 	status, err := normalizeRoutePolicyStatusInput(input.Status)
 	if err != nil {
-		response.Error(c, err)
-		return
+		return nil, err
 	}
 	supportsTokenBridge := input.SupportsTokenBridge != nil && *input.SupportsTokenBridge
 	supportsDestSwap := input.SupportsDestSwap != nil && *input.SupportsDestSwap
 	supportsPrivacyForward := input.SupportsPrivacyForward != nil && *input.SupportsPrivacyForward
 
-	item := &entities.RoutePolicy{
+	return &entities.RoutePolicy{
 		ID:                     utils.GenerateUUIDv7(),
 		SourceChainID:          sourceChainID,
 		DestChainID:            destChainID,
@@ -173,14 +226,164 @@ func (h *CrosschainPolicyHandler) CreateRoutePolicy(c *gin.Context) {
 		OverheadBytes:          overheadBytes,
 		MinFee:                 minFee,
 		MaxFee:                 maxFee,
+		FallbackBridgeFee:      fallbackBridgeFee,
 		CreatedAt:              time.Now(),
 		UpdatedAt:              time.Now(),
+	}, nil
+}
+
+const maxBulkRoutePolicies = 500
+
+const (
+	bulkRoutePolicyStatusCreated = "CREATED"
+	bulkRoutePolicyStatusSkipped = "SKIPPED" // The route already has a policy
+	bulkRoutePolicyStatusInvalid = "INVALID"
+)
+
+// routePolicyConnectInput connects one chain to every other active chain, both ways
+type routePolicyConnectInput struct {
+	ChainID           string  `json:"chainId" binding:"required"`
+	DefaultBridgeType *uint8  `json:"defaultBridgeType" binding:"required"`
+	FallbackMode      string  `json:"fallbackMode"`
+	FallbackOrder     []uint8 `json:"fallbackOrder"`
+	Status            *string `json:"status"`
+}
+
+type bulkRoutePolicyResult struct {
+	SourceChainID string     `json:"sourceChainId"`
+	DestChainID   string     `json:"destChainId"`
+	Status        string     `json:"status"`
+	ID            *uuid.UUID `json:"id,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// BulkCreateRoutePolicies creates many route policies in one transaction, skipping routes that
+// already have one. Invalid entries are reported and do not block the rest. Auto-fallback
+// policies go through the same fallback preflight as a single create; an entry whose fallback
+// bridges are not executable is reported INVALID unless force=true.
+// POST /api/v1/admin/route-policies/bulk
+func (h *CrosschainPolicyHandler) BulkCreateRoutePolicies(c *gin.Context) {
+	var input struct {
+		Policies []routePolicyCreateInput `json:"policies"`
+		Connect  *routePolicyConnectInput `json:"connect"`
 	}
-	if err := h.routePolicyRepo.Create(c.Request.Context(), item); err != nil {
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+	if _, err := strconv.ParseBool(c.DefaultQuery("force", "false")); err != nil {
+		response.Error(c, domainerrors.BadRequest("invalid force"))
+		return
+	}
+	ctx := c.Request.Context()
+
+	requested := input.Policies
+	if input.Connect != nil {
+		expanded, err := h.expandRoutePolicyConnect(ctx, *input.Connect)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+		requested = append(requested, expanded...)
+	}
+	if len(requested) == 0 {
+		response.Error(c, domainerrors.BadRequest("policies or connect is required"))
+		return
+	}
+	if len(requested) > maxBulkRoutePolicies {
+		response.Error(c, domainerrors.BadRequest("too many route policies"))
+		return
+	}
+
+	type routeKey struct{ source, dest uuid.UUID }
+	results := make([]bulkRoutePolicyResult, len(requested))
+	policies := make([]*entities.RoutePolicy, 0, len(requested))
+	positions := make([]int, 0, len(requested))
+	seen := make(map[routeKey]struct{}, len(requested))
+	for i, item := range requested {
+		results[i] = bulkRoutePolicyResult{SourceChainID: item.SourceChainID, DestChainID: item.DestChainID}
+		policy, err := h.buildRoutePolicy(ctx, item)
+		if err == nil {
+			key := routeKey{policy.SourceChainID, policy.DestChainID}
+			if _, dup := seen[key]; dup {
+				err = domainerrors.BadRequest("route is listed more than once")
+			}
+			seen[key] = struct{}{}
+		}
+		if err == nil {
+			err = h.checkFallbackBridges(c, policy)
+		}
+		if err != nil {
+			results[i].Status = bulkRoutePolicyStatusInvalid
+			results[i].Error = err.Error()
+			var appErr *domainerrors.AppError
+			if errors.As(err, &appErr) {
+				results[i].Error = appErr.Message
+			}
+			continue
+		}
+		policies = append(policies, policy)
+		positions = append(positions, i)
+	}
+
+	created, err := h.routePolicyRepo.CreateMissing(ctx, policies)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrAlreadyExists) {
+			response.Error(c, domainerrors.Conflict("route policies changed during the request; retry it"))
+			return
+		}
 		response.Error(c, err)
 		return
 	}
-	response.Success(c, http.StatusCreated, gin.H{"policy": item})
+
+	summary := map[string]int{
+		bulkRoutePolicyStatusCreated: 0,
+		bulkRoutePolicyStatusSkipped: 0,
+		bulkRoutePolicyStatusInvalid: len(requested) - len(policies),
+	}
+	for j, i := range positions {
+		if created[j] {
+			results[i].Status = bulkRoutePolicyStatusCreated
+			results[i].ID = &policies[j].ID
+		} else {
+			results[i].Status = bulkRoutePolicyStatusSkipped
+		}
+		summary[results[i].Status]++
+	}
+	response.Success(c, http.StatusOK, gin.H{
+		"items":   results,
+		"summary": summary,
+	})
+}
+
+// expandRoutePolicyConnect lists the routes between input.ChainID and every other active chain,
+// in both directions
+func (h *CrosschainPolicyHandler) expandRoutePolicyConnect(ctx context.Context, input routePolicyConnectInput) ([]routePolicyCreateInput, error) {
+	chainID, err := h.parseChainID(ctx, input.ChainID)
+	if err != nil {
+		return nil, domainerrors.BadRequest("invalid connect.chainId")
+	}
+	chains, _, err := h.chainRepo.GetActive(ctx, utils.PaginationParams{})
+	if err != nil {
+		return nil, err
+	}
+	routes := make([]routePolicyCreateInput, 0, 2*len(chains))
+	for _, chain := range chains {
+		if chain.ID == chainID {
+			continue
+		}
+		for _, pair := range [][2]uuid.UUID{{chainID, chain.ID}, {chain.ID, chainID}} {
+			routes = append(routes, routePolicyCreateInput{
+				SourceChainID:     pair[0].String(),
+				DestChainID:       pair[1].String(),
+				DefaultBridgeType: input.DefaultBridgeType,
+				FallbackMode:      input.FallbackMode,
+				FallbackOrder:     input.FallbackOrder,
+				Status:            input.Status,
+			})
+		}
+	}
+	return routes, nil
 }
 
 func (h *CrosschainPolicyHandler) UpdateRoutePolicy(c *gin.Context) {
@@ -210,6 +413,7 @@ func (h *CrosschainPolicyHandler) UpdateRoutePolicy(c *gin.Context) {
 		OverheadBytes          string  `json:"overheadBytes"`
 		MinFee                 string  `json:"minFee"`
 		MaxFee                 string  `json:"maxFee"`
+		FallbackBridgeFee      string  `json:"fallbackBridgeFee"`
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
 		response.Error(c, domainerrors.BadRequest(err.Error()))
@@ -275,6 +479,11 @@ func (h *CrosschainPolicyHandler) UpdateRoutePolicy(c *gin.Context) {
 		response.Error(c, err)
 		return
 	}
+	fallbackBridgeFee, err := normalizeUnsignedInteger(input.FallbackBridgeFee)
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("invalid fallbackBridgeFee"))
+		return
+	}
 	bridgeToken := existing.BridgeToken
 	if input.BridgeToken != nil {
 		normalizedBridgeToken, normalizeErr := normalizeBridgeTokenInput(input.BridgeToken)
@@ -317,8 +526,13 @@ func (h *CrosschainPolicyHandler) UpdateRoutePolicy(c *gin.Context) {
 	existing.OverheadBytes = overheadBytes
 	existing.MinFee = minFee
 	existing.MaxFee = maxFee
+	existing.FallbackBridgeFee = fallbackBridgeFee
 	existing.UpdatedAt = time.Now()
 
+	if err := h.checkFallbackBridges(c, existing); err != nil {
+		response.Error(c, err)
+		return
+	}
 	if err := h.routePolicyRepo.Update(c.Request.Context(), existing); err != nil {
 		response.Error(c, err)
 		return
@@ -544,6 +758,48 @@ func isValidBridgeType(v uint8) bool {
 	return v == 0 || v == 1 || v == 2 || v == 3
 }
 
+// checkFallbackBridges rejects an auto-fallback policy when a bridge in its fallback order, other
+// than the default, fails preflight on the route, since a payment falling back to it would fail
+// too. The query parameter force=true saves the policy anyway.
+func (h *CrosschainPolicyHandler) checkFallbackBridges(c *gin.Context, policy *entities.RoutePolicy) error {
+	if h.preflight == nil {
+		return nil
+	}
+	force, err := strconv.ParseBool(c.DefaultQuery("force", "false"))
+	if err != nil {
+		return domainerrors.BadRequest("invalid force")
+	}
+	if force || policy.FallbackMode != entities.BridgeFallbackModeAutoFallback {
+		return nil
+	}
+	fallbacks := make([]uint8, 0, len(policy.FallbackOrder))
+	for _, bridgeType := range policy.FallbackOrder {
+		if bridgeType != policy.DefaultBridgeType {
+			fallbacks = append(fallbacks, bridgeType)
+		}
+	}
+	if len(fallbacks) == 0 {
+		return nil
+	}
+
+	rows, err := h.preflight.PreflightBridges(c.Request.Context(), policy.SourceChainID.String(), policy.DestChainID.String(), fallbacks)
+	if err != nil {
+		return err
+	}
+	failed := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if !row.Ready {
+			failed = append(failed, fmt.Sprintf("%s (%s: %s)", row.BridgeName, row.ErrorCode, row.ErrorMessage))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return domainerrors.NewAppError(http.StatusUnprocessableEntity, domainerrors.CodeFallbackNotReady,
+		"fallback bridges are not executable on this route: "+strings.Join(failed, "; ")+"; pass force=true to save anyway",
+		domainerrors.ErrFallbackNotReady)
+}
+
 func validateBridgeOrder(order []uint8) error {
 	if len(order) == 0 {
 		return domainerrors.BadRequest("fallbackOrder cannot be empty")
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -29,9 +30,12 @@ func (s *routePolicyRepoCreateErrStub) GetByID(_ context.Context, id uuid.UUID)
 func (s *routePolicyRepoCreateErrStub) GetByRoute(context.Context, uuid.UUID, uuid.UUID) (*entities.RoutePolicy, error) {
 	return nil, domainerrors.ErrNotFound
 }
-func (s *routePolicyRepoCreateErrStub) List(context.Context, *uuid.UUID, *uuid.UUID, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+func (s *routePolicyRepoCreateErrStub) List(context.Context, repositories.RoutePolicyFilter, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
 	return nil, 0, nil
 }
+func (s *routePolicyRepoCreateErrStub) CreateMissing(_ context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+	return make([]bool, len(policies)), nil
+}
 func (s *routePolicyRepoCreateErrStub) Create(_ context.Context, item *entities.RoutePolicy) error {
 	if s.createErr != nil {
 		return s.createErr
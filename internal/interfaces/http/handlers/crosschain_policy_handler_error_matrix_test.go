@@ -13,15 +13,19 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/utils"
 )
 
 type routePolicyRepoErrMatrixStub struct {
 	item      *entities.RoutePolicy
 	getByIDFn func(context.Context, uuid.UUID) (*entities.RoutePolicy, error)
-	listFn    func(context.Context, *uuid.UUID, *uuid.UUID, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error)
+	listFn    func(context.Context, repositories.RoutePolicyFilter, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error)
 	updateFn  func(context.Context, *entities.RoutePolicy) error
 	deleteFn  func(context.Context, uuid.UUID) error
+
+	createMissingFn func(context.Context, []*entities.RoutePolicy) ([]bool, error)
 }
 
 func (s *routePolicyRepoErrMatrixStub) GetByID(ctx context.Context, id uuid.UUID) (*entities.RoutePolicy, error) {
@@ -36,15 +40,21 @@ func (s *routePolicyRepoErrMatrixStub) GetByID(ctx context.Context, id uuid.UUID
 func (s *routePolicyRepoErrMatrixStub) GetByRoute(context.Context, uuid.UUID, uuid.UUID) (*entities.RoutePolicy, error) {
 	return nil, domainerrors.ErrNotFound
 }
-func (s *routePolicyRepoErrMatrixStub) List(ctx context.Context, sourceChainID, destChainID *uuid.UUID, p utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+func (s *routePolicyRepoErrMatrixStub) List(ctx context.Context, filter repositories.RoutePolicyFilter, p utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
 	if s.listFn != nil {
-		return s.listFn(ctx, sourceChainID, destChainID, p)
+		return s.listFn(ctx, filter, p)
 	}
 	return []*entities.RoutePolicy{}, 0, nil
 }
 func (s *routePolicyRepoErrMatrixStub) Create(context.Context, *entities.RoutePolicy) error {
 	return nil
 }
+func (s *routePolicyRepoErrMatrixStub) CreateMissing(ctx context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+	if s.createMissingFn != nil {
+		return s.createMissingFn(ctx, policies)
+	}
+	return make([]bool, len(policies)), nil
+}
 func (s *routePolicyRepoErrMatrixStub) Update(ctx context.Context, policy *entities.RoutePolicy) error {
 	if s.updateFn != nil {
 		return s.updateFn(ctx, policy)
@@ -151,7 +161,7 @@ func TestCrosschainPolicyHandler_ErrorMatrix(t *testing.T) {
 	r.PUT("/lz/:id", h.UpdateStargateConfig)
 	r.DELETE("/lz/:id", h.DeleteStargateConfig)
 
-	routeRepo.listFn = func(context.Context, *uuid.UUID, *uuid.UUID, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+	routeRepo.listFn = func(context.Context, repositories.RoutePolicyFilter, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
 		return nil, 0, errors.New("list failed")
 	}
 	req := httptest.NewRequest(http.MethodGet, "/route?sourceChainId=eip155:8453", nil)
@@ -160,6 +170,23 @@ func TestCrosschainPolicyHandler_ErrorMatrix(t *testing.T) {
 	require.Equal(t, http.StatusInternalServerError, w.Code)
 	routeRepo.listFn = nil
 
+	var listed repositories.RoutePolicyFilter
+	routeRepo.listFn = func(_ context.Context, filter repositories.RoutePolicyFilter, _ utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+		listed = filter
+		return []*entities.RoutePolicy{}, 0, nil
+	}
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/route?defaultBridgeType=1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, listed.DefaultBridgeType)
+	require.Equal(t, uint8(1), *listed.DefaultBridgeType)
+	for _, bad := range []string{"9", "-1", "ccip"} {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/route?defaultBridgeType="+bad, nil))
+		require.Equal(t, http.StatusBadRequest, w.Code, bad)
+	}
+	routeRepo.listFn = nil
+
 	updateRouteBody := `{"sourceChainId":"` + sourceID.String() + `","destChainId":"` + sourceID.String() + `","defaultBridgeType":0}`
 	req = httptest.NewRequest(http.MethodPut, "/route/"+routeID.String(), strings.NewReader(updateRouteBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -277,3 +304,227 @@ func TestCrosschainPolicyHandler_ErrorMatrix(t *testing.T) {
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+func TestCrosschainPolicyHandler_BulkCreateRoutePolicies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	baseID := uuid.New()
+	arbID := uuid.New()
+	polygonID := uuid.New()
+
+	chainRepo := &crosschainChainRepoStub{
+		getByChainID: func(context.Context, string) (*entities.Chain, error) {
+			return nil, domainerrors.ErrNotFound
+		},
+		getByCAIP2: func(_ context.Context, caip2 string) (*entities.Chain, error) {
+			if caip2 == "eip155:8453" {
+				return &entities.Chain{ID: baseID}, nil
+			}
+			return nil, domainerrors.ErrNotFound
+		},
+		getActive: func(context.Context) ([]*entities.Chain, error) {
+			return []*entities.Chain{{ID: baseID}, {ID: arbID}, {ID: polygonID}}, nil
+		},
+	}
+	existing := map[[2]uuid.UUID]bool{{baseID, arbID}: true}
+	var received []*entities.RoutePolicy
+	routeRepo := &routePolicyRepoErrMatrixStub{
+		createMissingFn: func(_ context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+			received = policies
+			created := make([]bool, len(policies))
+			for i, policy := range policies {
+				created[i] = !existing[[2]uuid.UUID{policy.SourceChainID, policy.DestChainID}]
+			}
+			return created, nil
+		},
+	}
+	h := NewCrosschainPolicyHandler(routeRepo, &stargateRepoErrMatrixStub{}, chainRepo)
+	r := gin.New()
+	r.POST("/route/bulk", h.BulkCreateRoutePolicies)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/route/bulk", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	body := `{"policies":[` +
+		`{"sourceChainId":"eip155:8453","destChainId":"` + arbID.String() + `","defaultBridgeType":0},` +
+		`{"sourceChainId":"` + arbID.String() + `","destChainId":"` + polygonID.String() + `","defaultBridgeType":1},` +
+		`{"sourceChainId":"` + arbID.String() + `","destChainId":"` + polygonID.String() + `","defaultBridgeType":2},` +
+		`{"sourceChainId":"` + arbID.String() + `","destChainId":"` + arbID.String() + `","defaultBridgeType":0}` +
+		`]}`
+	w := post(body)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, received, 2)
+	require.Contains(t, w.Body.String(), `"CREATED":1`)
+	require.Contains(t, w.Body.String(), `"SKIPPED":1`)
+	require.Contains(t, w.Body.String(), `"INVALID":2`)
+	require.Contains(t, w.Body.String(), "route is listed more than once")
+	require.Contains(t, w.Body.String(), "sourceChainId and destChainId must be different")
+
+	w = post(`{"connect":{"chainId":"eip155:8453","defaultBridgeType":0}}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, received, 4)
+	for _, policy := range received {
+		require.NotEqual(t, policy.SourceChainID, policy.DestChainID)
+		require.True(t, policy.SourceChainID == baseID || policy.DestChainID == baseID)
+	}
+	require.Contains(t, w.Body.String(), `"CREATED":3`)
+	require.Contains(t, w.Body.String(), `"SKIPPED":1`)
+
+	require.Equal(t, http.StatusBadRequest, post(`{}`).Code)
+	require.Equal(t, http.StatusBadRequest, post(`{"connect":{"chainId":"eip155:1","defaultBridgeType":0}}`).Code)
+
+	routeRepo.createMissingFn = func(context.Context, []*entities.RoutePolicy) ([]bool, error) {
+		return nil, domainerrors.ErrAlreadyExists
+	}
+	require.Equal(t, http.StatusConflict, post(body).Code)
+	routeRepo.createMissingFn = func(context.Context, []*entities.RoutePolicy) ([]bool, error) {
+		return nil, errors.New("db down")
+	}
+	require.Equal(t, http.StatusInternalServerError, post(body).Code)
+}
+
+type routePolicyPreflightStub struct {
+	requested []uint8
+	ready     map[uint8]bool
+	err       error
+}
+
+func (s *routePolicyPreflightStub) PreflightBridges(_ context.Context, _, _ string, bridgeTypes []uint8) ([]usecases.CrosschainBridgePreflight, error) {
+	s.requested = bridgeTypes
+	if s.err != nil {
+		return nil, s.err
+	}
+	rows := make([]usecases.CrosschainBridgePreflight, 0, len(bridgeTypes))
+	for _, bt := range bridgeTypes {
+		row := usecases.CrosschainBridgePreflight{BridgeType: bt, BridgeName: "BRIDGE", Ready: s.ready[bt]}
+		if !row.Ready {
+			row.ErrorCode = "ADAPTER_NOT_REGISTERED"
+			row.ErrorMessage = "adapter is not registered for this bridge type"
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func TestCrosschainPolicyHandler_FallbackBridgePreflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sourceID := uuid.New()
+	destID := uuid.New()
+	routeID := uuid.New()
+
+	preflight := &routePolicyPreflightStub{ready: map[uint8]bool{1: true}}
+	routeRepo := &routePolicyRepoErrMatrixStub{item: &entities.RoutePolicy{ID: routeID}}
+	h := NewCrosschainPolicyHandler(routeRepo, &stargateRepoErrMatrixStub{}, &crosschainChainRepoStub{})
+	h.preflight = preflight
+	r := gin.New()
+	r.POST("/route", h.CreateRoutePolicy)
+	r.PUT("/route/:id", h.UpdateRoutePolicy)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	route := `"sourceChainId":"` + sourceID.String() + `","destChainId":"` + destID.String() + `","defaultBridgeType":0`
+
+	w := send(http.MethodPost, "/route", `{`+route+`,"fallbackMode":"auto_fallback","fallbackOrder":[0,1]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.Equal(t, []uint8{1}, preflight.requested)
+
+	w = send(http.MethodPost, "/route", `{`+route+`,"fallbackMode":"auto_fallback","fallbackOrder":[0,1,2]}`)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Contains(t, w.Body.String(), domainerrors.CodeFallbackNotReady)
+	require.Contains(t, w.Body.String(), "ADAPTER_NOT_REGISTERED")
+
+	w = send(http.MethodPut, "/route/"+routeID.String(), `{`+route+`,"fallbackMode":"auto_fallback","fallbackOrder":[0,2]}`)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	w = send(http.MethodPut, "/route/"+routeID.String()+"?force=true", `{`+route+`,"fallbackMode":"auto_fallback","fallbackOrder":[0,2]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, []uint8{0, 2}, routeRepo.item.FallbackOrder)
+	w = send(http.MethodPost, "/route?force=maybe", `{`+route+`,"fallbackMode":"auto_fallback","fallbackOrder":[0,2]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Strict policies never fall back, so their order is not checked
+	preflight.requested = nil
+	w = send(http.MethodPost, "/route", `{`+route+`,"fallbackOrder":[0,2]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.Nil(t, preflight.requested)
+
+	preflight.err = errors.New("rpc down")
+	w = send(http.MethodPost, "/route", `{`+route+`,"fallbackMode":"auto_fallback","fallbackOrder":[0,1]}`)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestCrosschainPolicyHandler_BulkCreateRoutePolicies_FallbackPreflight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	baseID := uuid.New()
+	arbID := uuid.New()
+	polygonID := uuid.New()
+
+	chainRepo := &crosschainChainRepoStub{
+		getByChainID: func(context.Context, string) (*entities.Chain, error) {
+			return nil, domainerrors.ErrNotFound
+		},
+		getByCAIP2: func(context.Context, string) (*entities.Chain, error) {
+			return nil, domainerrors.ErrNotFound
+		},
+		getActive: func(context.Context) ([]*entities.Chain, error) {
+			return []*entities.Chain{{ID: baseID}, {ID: arbID}, {ID: polygonID}}, nil
+		},
+	}
+	var received []*entities.RoutePolicy
+	routeRepo := &routePolicyRepoErrMatrixStub{
+		createMissingFn: func(_ context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+			received = policies
+			created := make([]bool, len(policies))
+			for i := range created {
+				created[i] = true
+			}
+			return created, nil
+		},
+	}
+	h := NewCrosschainPolicyHandler(routeRepo, &stargateRepoErrMatrixStub{}, chainRepo)
+	h.preflight = &routePolicyPreflightStub{ready: map[uint8]bool{1: true}}
+	r := gin.New()
+	r.POST("/route/bulk", h.BulkCreateRoutePolicies)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// An auto-fallback entry with a fallback bridge that fails preflight is reported INVALID
+	body := `{"policies":[` +
+		`{"sourceChainId":"` + baseID.String() + `","destChainId":"` + arbID.String() + `","defaultBridgeType":0,"fallbackMode":"auto_fallback","fallbackOrder":[0,1]},` +
+		`{"sourceChainId":"` + arbID.String() + `","destChainId":"` + polygonID.String() + `","defaultBridgeType":0,"fallbackMode":"auto_fallback","fallbackOrder":[0,2]}` +
+		`]}`
+	w := post("/route/bulk", body)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, received, 1)
+	require.Equal(t, arbID, received[0].DestChainID)
+	require.Contains(t, w.Body.String(), `"INVALID":1`)
+	require.Contains(t, w.Body.String(), "fallback bridges are not executable on this route")
+
+	// The connect shorthand goes through the same check
+	w = post("/route/bulk", `{"connect":{"chainId":"`+baseID.String()+`","defaultBridgeType":0,"fallbackMode":"auto_fallback","fallbackOrder":[0,2]}}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Empty(t, received)
+	require.Contains(t, w.Body.String(), `"INVALID":4`)
+
+	// force=true saves them anyway
+	w = post("/route/bulk?force=true", body)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, received, 2)
+	require.Contains(t, w.Body.String(), `"CREATED":2`)
+
+	require.Equal(t, http.StatusBadRequest, post("/route/bulk?force=maybe", body).Code)
+}
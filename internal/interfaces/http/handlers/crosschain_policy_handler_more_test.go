@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -26,12 +27,15 @@ func (s *routePolicyRepoListDeleteStub) GetByID(context.Context, uuid.UUID) (*en
 func (s *routePolicyRepoListDeleteStub) GetByRoute(context.Context, uuid.UUID, uuid.UUID) (*entities.RoutePolicy, error) {
 	return s.item, nil
 }
-func (s *routePolicyRepoListDeleteStub) List(context.Context, *uuid.UUID, *uuid.UUID, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+func (s *routePolicyRepoListDeleteStub) List(context.Context, repositories.RoutePolicyFilter, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
 	if s.item == nil {
 		s.item = &entities.RoutePolicy{ID: utils.GenerateUUIDv7()}
 	}
 	return []*entities.RoutePolicy{s.item}, 1, nil
 }
+func (s *routePolicyRepoListDeleteStub) CreateMissing(_ context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+	return make([]bool, len(policies)), nil
+}
 func (s *routePolicyRepoListDeleteStub) Create(context.Context, *entities.RoutePolicy) error { return nil }
 func (s *routePolicyRepoListDeleteStub) Update(context.Context, *entities.RoutePolicy) error { return nil }
 func (s *routePolicyRepoListDeleteStub) Delete(context.Context, uuid.UUID) error             { return nil }
@@ -125,3 +129,24 @@ func TestCrosschainPolicyHandler_ListAndDeleteSuccess(t *testing.T) {
 	require.Contains(t, w.Body.String(), "Stargate config deleted")
 }
 
+
+func TestCrosschainPolicyHandler_ListRoutePoliciesCapsLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var got utils.PaginationParams
+	routeRepo := &routePolicyRepoErrMatrixStub{
+		listFn: func(_ context.Context, _ repositories.RoutePolicyFilter, p utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+			got = p
+			return []*entities.RoutePolicy{}, 0, nil
+		},
+	}
+	h := NewCrosschainPolicyHandler(routeRepo, &stargateRepoErrMatrixStub{}, &crosschainChainRepoStub{})
+	r := gin.New()
+	r.GET("/route-policies", h.ListRoutePolicies)
+
+	for query, want := range map[string]int{"limit=50": 50, "limit=100000": 20, "limit=0": 20, "limit=-1": 20} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/route-policies?"+query, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, want, got.Limit, query)
+	}
+}
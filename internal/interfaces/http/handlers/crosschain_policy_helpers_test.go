@@ -12,12 +12,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
 type crosschainChainRepoStub struct {
 	getByChainID func(ctx context.Context, chainID string) (*entities.Chain, error)
 	getByCAIP2   func(ctx context.Context, caip2 string) (*entities.Chain, error)
+	getActive    func(ctx context.Context) ([]*entities.Chain, error)
 }
 
 func (s *crosschainChainRepoStub) GetByID(context.Context, uuid.UUID) (*entities.Chain, error) {
@@ -33,12 +35,20 @@ func (s *crosschainChainRepoStub) GetAll(context.Context) ([]*entities.Chain, er
 func (s *crosschainChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool, *string, utils.PaginationParams) ([]*entities.ChainRPC, int64, error) {
 	return nil, 0, nil
 }
-func (s *crosschainChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+func (s *crosschainChainRepoStub) GetActive(ctx context.Context, _ utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	if s.getActive == nil {
+		return nil, 0, nil
+	}
+	chains, err := s.getActive(ctx)
+	return chains, int64(len(chains)), err
+}
+func (s *crosschainChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
 func (s *crosschainChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *crosschainChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *crosschainChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *crosschainChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *crosschainChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *crosschainChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *crosschainChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -54,9 +64,12 @@ func (routePolicyRepoNoop) GetByID(context.Context, uuid.UUID) (*entities.RouteP
 func (routePolicyRepoNoop) GetByRoute(context.Context, uuid.UUID, uuid.UUID) (*entities.RoutePolicy, error) {
 	return nil, nil
 }
-func (routePolicyRepoNoop) List(context.Context, *uuid.UUID, *uuid.UUID, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+func (routePolicyRepoNoop) List(context.Context, repositories.RoutePolicyFilter, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
 	return nil, 0, nil
 }
+func (routePolicyRepoNoop) CreateMissing(_ context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+	return make([]bool, len(policies)), nil
+}
 func (routePolicyRepoNoop) Create(context.Context, *entities.RoutePolicy) error { return nil }
 func (routePolicyRepoNoop) Update(context.Context, *entities.RoutePolicy) error { return nil }
 func (routePolicyRepoNoop) Delete(context.Context, uuid.UUID) error             { return nil }
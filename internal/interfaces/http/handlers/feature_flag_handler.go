@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/response"
+)
+
+type FeatureFlagService interface {
+	List(ctx context.Context) ([]*entities.FeatureFlag, error)
+	SetFlag(ctx context.Context, name string, merchantID *uuid.UUID, enabled bool) (*entities.FeatureFlag, error)
+	DeleteFlag(ctx context.Context, name string, merchantID *uuid.UUID) error
+}
+
+// FeatureFlagHandler manages feature flags and per-merchant overrides
+type FeatureFlagHandler struct {
+	service FeatureFlagService
+}
+
+func NewFeatureFlagHandler(service FeatureFlagService) *FeatureFlagHandler {
+	return &FeatureFlagHandler{service: service}
+}
+
+// ListFeatureFlags returns global flags and merchant overrides.
+// GET /api/v1/admin/feature-flags
+func (h *FeatureFlagHandler) ListFeatureFlags(c *gin.Context) {
+	items, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, gin.H{"items": items})
+}
+
+// SetFeatureFlag enables or disables a feature globally, or for one merchant.
+// PUT /api/v1/admin/feature-flags/:name
+func (h *FeatureFlagHandler) SetFeatureFlag(c *gin.Context) {
+	var input struct {
+		Enabled    *bool  `json:"enabled" binding:"required"`
+		MerchantID string `json:"merchantId"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+	merchantID, err := featureFlagMerchantScope(input.MerchantID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	flag, err := h.service.SetFlag(c.Request.Context(), strings.TrimSpace(c.Param("name")), merchantID, *input.Enabled)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, flag)
+}
+
+// DeleteFeatureFlag removes a flag or a merchant override (?merchantId=).
+// DELETE /api/v1/admin/feature-flags/:name
+func (h *FeatureFlagHandler) DeleteFeatureFlag(c *gin.Context) {
+	merchantID, err := featureFlagMerchantScope(c.Query("merchantId"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if err := h.service.DeleteFlag(c.Request.Context(), strings.TrimSpace(c.Param("name")), merchantID); err != nil {
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			response.Error(c, domainerrors.NotFound("Feature flag not found"))
+			return
+		}
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, gin.H{"message": "Feature flag deleted"})
+}
+
+// featureFlagMerchantScope maps an optional merchant ID to the override scope (nil = global)
+func featureFlagMerchantScope(raw string) (*uuid.UUID, error) {
+	id, err := parseOptionalMerchantID(raw)
+	if err != nil || id == uuid.Nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type featureFlagServiceStub struct {
+	items   []*entities.FeatureFlag
+	lastSet *entities.FeatureFlag
+}
+
+func (s *featureFlagServiceStub) List(context.Context) ([]*entities.FeatureFlag, error) {
+	return s.items, nil
+}
+
+func (s *featureFlagServiceStub) SetFlag(_ context.Context, name string, merchantID *uuid.UUID, enabled bool) (*entities.FeatureFlag, error) {
+	s.lastSet = &entities.FeatureFlag{Name: name, MerchantID: merchantID, Enabled: enabled}
+	return s.lastSet, nil
+}
+
+func (s *featureFlagServiceStub) DeleteFlag(_ context.Context, name string, _ *uuid.UUID) error {
+	if name != "refunds" {
+		return domainerrors.ErrNotFound
+	}
+	return nil
+}
+
+func TestFeatureFlagHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := &featureFlagServiceStub{items: []*entities.FeatureFlag{{Name: "refunds"}}}
+	h := NewFeatureFlagHandler(svc)
+	r := gin.New()
+	r.GET("/feature-flags", h.ListFeatureFlags)
+	r.PUT("/feature-flags/:name", h.SetFeatureFlag)
+	r.DELETE("/feature-flags/:name", h.DeleteFeatureFlag)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	w := do(http.MethodGet, "/feature-flags", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"refunds"`)
+
+	merchantID := uuid.New()
+	w = do(http.MethodPut, "/feature-flags/refunds", `{"enabled":true,"merchantId":"`+merchantID.String()+`"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, merchantID, *svc.lastSet.MerchantID)
+	require.True(t, svc.lastSet.Enabled)
+
+	w = do(http.MethodPut, "/feature-flags/refunds", `{"enabled":false}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Nil(t, svc.lastSet.MerchantID)
+
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/feature-flags/refunds", `{}`).Code)
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/feature-flags/refunds", `{"enabled":true,"merchantId":"nope"}`).Code)
+
+	require.Equal(t, http.StatusOK, do(http.MethodDelete, "/feature-flags/refunds?merchantId="+merchantID.String(), "").Code)
+	require.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/feature-flags/splits", "").Code)
+	require.Equal(t, http.StatusBadRequest, do(http.MethodDelete, "/feature-flags/refunds?merchantId=nope", "").Code)
+}
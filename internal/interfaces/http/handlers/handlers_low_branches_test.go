@@ -12,12 +12,15 @@ import (
 	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/utils"
 )
 
 func TestChainHandler_UpdateChain_InvalidBodyBranch(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	h := NewChainHandler(&chainRepoErrStub{chainRepoStub: newChainRepoStub()})
+	h := NewChainHandler(&chainRepoErrStub{chainRepoStub: newChainRepoStub()}, nil)
 	r := gin.New()
 	r.PUT("/admin/chains/:id", h.UpdateChain)
 
@@ -39,7 +42,7 @@ func TestPaymentHandler_GetPayment_GenericErrorBranch(t *testing.T) {
 		getFn: func(context.Context, uuid.UUID) (*entities.Payment, error) {
 			return nil, errors.New("boom")
 		},
-		listFn: func(context.Context, uuid.UUID, int, int) ([]*entities.Payment, int, error) {
+		listFn: func(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.Payment, int64, error) {
 			return nil, 0, errors.New("unused")
 		},
 		eventsFn: func(context.Context, uuid.UUID) ([]*entities.PaymentEvent, error) {
@@ -67,7 +70,7 @@ func TestPaymentRequestHandler_GenericErrorAndPaginationBranches(t *testing.T) {
 		getFn: func(context.Context, uuid.UUID) (*entities.PaymentRequest, *entities.PaymentRequestTxData, error) {
 			return nil, nil, errors.New("boom")
 		},
-		listFn: func(_ context.Context, _ uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error) {
+		listFn: func(_ context.Context, _ uuid.UUID, _ repositories.PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error) {
 			if limit != 10 || offset != 0 {
 				return nil, 0, errors.New("unexpected pagination normalization")
 			}
@@ -80,7 +83,7 @@ func TestPaymentRequestHandler_GenericErrorAndPaginationBranches(t *testing.T) {
 
 	r := gin.New()
 	withUser := func(c *gin.Context) {
-		c.Set("userID", userID)
+		c.Set(middleware.UserIDKey, userID)
 		c.Next()
 	}
 	r.GET("/payment-requests/:id", h.GetPaymentRequest)
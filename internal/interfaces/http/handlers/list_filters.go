@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+)
+
+// includeInactiveRequested reports whether ?includeInactive=true was sent by an admin-API reader.
+// List handlers are shared between public and admin routes; public callers only see active rows.
+func includeInactiveRequested(c *gin.Context) bool {
+	includeInactive, err := strconv.ParseBool(c.Query("includeInactive"))
+	if err != nil || !includeInactive {
+		return false
+	}
+	return middleware.CanReadAdmin(c)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/internal/usecases"
+)
+
+type MaintenanceService interface {
+	Status(ctx context.Context) *usecases.MaintenanceStatus
+	SetMaintenance(ctx context.Context, input usecases.SetMaintenanceInput) (*usecases.MaintenanceStatus, error)
+}
+
+// MaintenanceHandler lets admins turn maintenance mode on and off
+type MaintenanceHandler struct {
+	service MaintenanceService
+}
+
+func NewMaintenanceHandler(service MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{service: service}
+}
+
+// GetMaintenance returns the current maintenance state.
+// GET /api/v1/admin/maintenance
+func (h *MaintenanceHandler) GetMaintenance(c *gin.Context) {
+	response.Success(c, http.StatusOK, h.service.Status(c.Request.Context()))
+}
+
+// SetMaintenance turns maintenance mode on or off. While it is on, writes answer 503.
+// POST /api/v1/admin/maintenance
+func (h *MaintenanceHandler) SetMaintenance(c *gin.Context) {
+	var input struct {
+		Enabled           *bool  `json:"enabled" binding:"required"`
+		Message           string `json:"message"`
+		RetryAfterSeconds *int   `json:"retryAfterSeconds"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+
+	status, err := h.service.SetMaintenance(c.Request.Context(), usecases.SetMaintenanceInput{
+		Enabled:           *input.Enabled,
+		Message:           input.Message,
+		RetryAfterSeconds: input.RetryAfterSeconds,
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, status)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/usecases"
+)
+
+type maintenanceServiceStub struct {
+	status usecases.MaintenanceStatus
+}
+
+func (s *maintenanceServiceStub) Status(context.Context) *usecases.MaintenanceStatus {
+	return &s.status
+}
+
+func (s *maintenanceServiceStub) SetMaintenance(_ context.Context, input usecases.SetMaintenanceInput) (*usecases.MaintenanceStatus, error) {
+	if input.RetryAfterSeconds != nil && *input.RetryAfterSeconds < 0 {
+		return nil, domainerrors.BadRequest("retryAfterSeconds must be between 0 and 86400")
+	}
+	s.status = usecases.MaintenanceStatus{Enabled: input.Enabled, Message: input.Message, RetryAfterSeconds: 300}
+	if input.RetryAfterSeconds != nil {
+		s.status.RetryAfterSeconds = *input.RetryAfterSeconds
+	}
+	return &s.status, nil
+}
+
+func TestMaintenanceHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := NewMaintenanceHandler(&maintenanceServiceStub{})
+	r := gin.New()
+	r.GET("/maintenance", h.GetMaintenance)
+	r.POST("/maintenance", h.SetMaintenance)
+
+	do := func(method, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, "/maintenance", bytes.NewBufferString(body)))
+		return w
+	}
+
+	w := do(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"enabled":false,"retryAfterSeconds":0}`, w.Body.String())
+
+	w = do(http.MethodPost, `{"enabled":true,"message":"migrating","retryAfterSeconds":60}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"enabled":true,"message":"migrating","retryAfterSeconds":60}`, w.Body.String())
+	require.Contains(t, do(http.MethodGet, "").Body.String(), `"enabled":true`)
+
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPost, `{}`).Code)
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPost, `{"enabled":true,"retryAfterSeconds":-1}`).Code)
+}
@@ -14,9 +14,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/volatiletech/null/v8"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/utils"
 )
 
 type merchantRepoStub struct {
@@ -92,7 +94,9 @@ func (s merchantUserRepoStub) GetByEmail(context.Context, string) (*entities.Use
 func (s merchantUserRepoStub) Update(context.Context, *entities.User) error                    { return nil }
 func (s merchantUserRepoStub) UpdatePassword(context.Context, uuid.UUID, string) error         { return nil }
 func (s merchantUserRepoStub) SoftDelete(context.Context, uuid.UUID) error                     { return nil }
-func (s merchantUserRepoStub) List(context.Context, string) ([]*entities.User, error)          { return nil, nil }
+func (s merchantUserRepoStub) List(context.Context, repositories.UserFilter, utils.PaginationParams) ([]*entities.User, int64, error) {
+	return nil, 0, nil
+}
 
 func TestMerchantHandler_ApplyAndGetStatus_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
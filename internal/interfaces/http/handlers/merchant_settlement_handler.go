@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
@@ -13,11 +15,17 @@ import (
 	"payment-kita.backend/internal/interfaces/http/response"
 )
 
+// settlementReceiverChecker checks a settlement wallet against the merchant's receiver allow-list
+type settlementReceiverChecker interface {
+	CheckReceiver(ctx context.Context, merchantID uuid.UUID, receiver string) error
+}
+
 type MerchantSettlementHandler struct {
 	merchantRepo          repositories.MerchantRepository
 	settlementProfileRepo repositories.MerchantSettlementProfileRepository
 	chainRepo             repositories.ChainRepository
 	tokenRepo             repositories.TokenRepository
+	receiverAllowlist     settlementReceiverChecker
 }
 
 func NewMerchantSettlementHandler(
@@ -34,6 +42,20 @@ func NewMerchantSettlementHandler(
 	}
 }
 
+// NewMerchantSettlementHandlerWithReceiverAllowlist is NewMerchantSettlementHandler that refuses
+// a dest_wallet missing from the merchant's receiver allow-list
+func NewMerchantSettlementHandlerWithReceiverAllowlist(
+	merchantRepo repositories.MerchantRepository,
+	settlementProfileRepo repositories.MerchantSettlementProfileRepository,
+	chainRepo repositories.ChainRepository,
+	tokenRepo repositories.TokenRepository,
+	receiverAllowlist settlementReceiverChecker,
+) *MerchantSettlementHandler {
+	h := NewMerchantSettlementHandler(merchantRepo, settlementProfileRepo, chainRepo, tokenRepo)
+	h.receiverAllowlist = receiverAllowlist
+	return h
+}
+
 func (h *MerchantSettlementHandler) GetMySettlementProfile(c *gin.Context) {
 	merchant, ok := h.resolveMerchant(c)
 	if !ok {
@@ -67,7 +89,13 @@ func (h *MerchantSettlementHandler) GetMySettlementProfile(c *gin.Context) {
 	})
 }
 
+// UpsertMySettlementProfile changes where the caller's payments settle. Like the receiver
+// allow-list it cannot be changed with an API key, so a leaked key cannot redirect payouts.
 func (h *MerchantSettlementHandler) UpsertMySettlementProfile(c *gin.Context) {
+	if c.GetBool(middleware.APIKeyAuthKey) {
+		response.Error(c, domainerrors.Forbidden("the settlement profile cannot be changed with an API key"))
+		return
+	}
 	merchant, ok := h.resolveMerchant(c)
 	if !ok {
 		return
@@ -107,6 +135,14 @@ func (h *MerchantSettlementHandler) UpsertMySettlementProfile(c *gin.Context) {
 		return
 	}
 
+	destWallet := strings.TrimSpace(req.DestWallet)
+	if h.receiverAllowlist != nil {
+		if err := h.receiverAllowlist.CheckReceiver(c.Request.Context(), merchant.ID, destWallet); err != nil {
+			response.Error(c, err)
+			return
+		}
+	}
+
 	existing, err := h.settlementProfileRepo.GetByMerchantID(c.Request.Context(), merchant.ID)
 	if err != nil && err != domainerrors.ErrNotFound {
 		response.Error(c, err)
@@ -118,7 +154,7 @@ func (h *MerchantSettlementHandler) UpsertMySettlementProfile(c *gin.Context) {
 		InvoiceCurrency:   invoiceCurrency,
 		DestChain:         chain.GetCAIP2ID(),
 		DestToken:         destToken.ContractAddress,
-		DestWallet:        strings.TrimSpace(req.DestWallet),
+		DestWallet:        destWallet,
 		BridgeTokenSymbol: bridgeTokenSymbol,
 		CreatedAt:         time.Now().UTC(),
 	}
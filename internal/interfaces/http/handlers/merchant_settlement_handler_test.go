@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/repositories"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+)
+
+type settlementReceiverCheckerStub struct {
+	allowed string
+}
+
+func (s settlementReceiverCheckerStub) CheckReceiver(_ context.Context, _ uuid.UUID, receiver string) error {
+	if receiver != s.allowed {
+		return domainerrors.NewAppError(http.StatusForbidden, domainerrors.CodeReceiverNotAllowed, "receiver is not on the merchant allow-list", domainerrors.ErrReceiverNotAllowed)
+	}
+	return nil
+}
+
+func TestMerchantSettlementHandler_UpsertMySettlementProfile_Guards(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testDB := repositoriesTestDBForAdminSettlement(t)
+	repositoriesTestCreateAdminSettlementTables(t, testDB)
+	now := time.Now().UTC()
+	userID := uuid.New()
+	chainID := uuid.New()
+	mustExecAdminSettlement(t, testDB, `INSERT INTO merchants (id, user_id, business_name, business_email, merchant_type, status, documents, fee_discount_percent, webhook_metadata, metadata, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, uuid.NewString(), userID.String(), "Merchant", "merchant@example.com", "PARTNER", "ACTIVE", "{}", "0", "{}", "{}", now, now)
+	mustExecAdminSettlement(t, testDB, `INSERT INTO chains (id, chain_id, name, type, rpc_url, explorer_url, currency_symbol, image_url, is_active, state_machine_id, ccip_chain_selector, stargate_eid, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, chainID.String(), "8453", "Base", "EVM", "", "", "ETH", "", true, "", "", 0, now, now)
+	mustExecAdminSettlement(t, testDB, `INSERT INTO tokens (id, chain_id, symbol, name, decimals, address, type, logo_url, is_active, is_native, is_stablecoin, min_amount, max_amount, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, uuid.NewString(), chainID.String(), "IDRX", "IDRX", 2, "0xidrxtoken", "ERC20", "", true, false, false, "0", nil, now, now)
+	mustExecAdminSettlement(t, testDB, `INSERT INTO tokens (id, chain_id, symbol, name, decimals, address, type, logo_url, is_active, is_native, is_stablecoin, min_amount, max_amount, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, uuid.NewString(), chainID.String(), "USDC", "USDC", 6, "0xusdctoken", "ERC20", "", true, false, true, "0", nil, now, now)
+
+	chainRepo := repositories.NewChainRepository(testDB)
+	h := NewMerchantSettlementHandlerWithReceiverAllowlist(
+		repositories.NewMerchantRepository(testDB),
+		repositories.NewMerchantSettlementProfileRepository(testDB),
+		chainRepo,
+		repositories.NewTokenRepository(testDB, chainRepo),
+		settlementReceiverCheckerStub{allowed: "0xallowedwallet"},
+	)
+
+	put := func(apiKey bool, destWallet string) *httptest.ResponseRecorder {
+		r := gin.New()
+		r.PUT("/merchants/settlement-profile", func(c *gin.Context) {
+			c.Set(middleware.UserIDKey, userID)
+			c.Set(middleware.APIKeyAuthKey, apiKey)
+			c.Next()
+		}, h.UpsertMySettlementProfile)
+		req := httptest.NewRequest(http.MethodPut, "/merchants/settlement-profile", bytes.NewBufferString(`{
+			"dest_chain":"eip155:8453",
+			"dest_token":"0xidrxtoken",
+			"dest_wallet":"`+destWallet+`"
+		}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := put(true, "0xallowedwallet")
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), "API key")
+
+	rec = put(false, "0xattackerwallet")
+	require.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), domainerrors.CodeReceiverNotAllowed)
+
+	rec = put(false, "0xallowedwallet")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Contains(t, rec.Body.String(), `"dest_wallet":"0xallowedwallet"`)
+}
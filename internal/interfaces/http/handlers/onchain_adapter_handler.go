@@ -18,6 +18,7 @@ type OnchainAdapterHandler struct {
 }
 type onchainAdapterService interface {
 	GetStatus(ctx context.Context, sourceChainInput, destChainInput string) (*usecases.OnchainAdapterStatus, error)
+	Diagnostics(ctx context.Context) (*usecases.OnchainDiagnostics, error)
 	RegisterAdapter(ctx context.Context, sourceChainInput, destChainInput string, bridgeType uint8, adapterAddress string) (string, error)
 	SetDefaultBridgeType(ctx context.Context, sourceChainInput, destChainInput string, bridgeType uint8) (string, error)
 	SetHyperbridgeConfig(ctx context.Context, sourceChainInput, destChainInput string, stateMachineIDHex, destinationContractHex string) (string, []string, error)
@@ -49,6 +50,16 @@ func (h *OnchainAdapterHandler) GetStatus(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"status": status})
 }
 
+// Diagnostics reports per-chain readiness to send owner transactions
+func (h *OnchainAdapterHandler) Diagnostics(c *gin.Context) {
+	report, err := h.usecase.Diagnostics(c.Request.Context())
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, gin.H{"diagnostics": report})
+}
+
 func (h *OnchainAdapterHandler) RegisterAdapter(c *gin.Context) {
 	var input struct {
 		SourceChainID string `json:"sourceChainId" binding:"required"`
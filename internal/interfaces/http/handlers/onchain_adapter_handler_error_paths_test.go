@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/utils"
 )
@@ -28,6 +29,7 @@ func (onchainHandlerChainRepoStub) GetByCAIP2(context.Context, string) (*entitie
 func (onchainHandlerChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (onchainHandlerChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (onchainHandlerChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (onchainHandlerChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (onchainHandlerChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (onchainHandlerChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (onchainHandlerChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -43,6 +45,9 @@ func (onchainHandlerChainRepoStub) GetByID(context.Context, uuid.UUID) (*entitie
 func (onchainHandlerChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (onchainHandlerChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (onchainHandlerChainRepoStub) GetAll(context.Context) ([]*entities.Chain, error) {
 	return nil, nil
 }
@@ -64,9 +69,15 @@ func (onchainHandlerContractRepoStub) GetByChainAndAddress(context.Context, uuid
 func (onchainHandlerContractRepoStub) GetActiveContract(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
 	return nil, domainerrors.ErrNotFound
 }
-func (onchainHandlerContractRepoStub) GetFiltered(context.Context, *uuid.UUID, entities.SmartContractType, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+func (onchainHandlerContractRepoStub) GetFiltered(context.Context, repositories.SmartContractFilter, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
+func (onchainHandlerContractRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
+func (onchainHandlerContractRepoStub) Activate(context.Context, uuid.UUID) (*entities.SmartContract, error) {
+	return nil, nil
+}
 func (onchainHandlerContractRepoStub) GetByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
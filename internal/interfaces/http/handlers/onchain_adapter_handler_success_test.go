@@ -15,6 +15,7 @@ import (
 
 type onchainAdapterServiceStub struct {
 	getStatus        func(context.Context, string, string) (*usecases.OnchainAdapterStatus, error)
+	diagnostics      func(context.Context) (*usecases.OnchainDiagnostics, error)
 	registerAdapter  func(context.Context, string, string, uint8, string) (string, error)
 	setDefaultBridge func(context.Context, string, string, uint8) (string, error)
 	setHyperbridge   func(context.Context, string, string, string, string) (string, []string, error)
@@ -29,6 +30,9 @@ type onchainAdapterServiceStub struct {
 func (s onchainAdapterServiceStub) GetStatus(ctx context.Context, sourceChainInput, destChainInput string) (*usecases.OnchainAdapterStatus, error) {
 	return s.getStatus(ctx, sourceChainInput, destChainInput)
 }
+func (s onchainAdapterServiceStub) Diagnostics(ctx context.Context) (*usecases.OnchainDiagnostics, error) {
+	return s.diagnostics(ctx)
+}
 func (s onchainAdapterServiceStub) RegisterAdapter(ctx context.Context, sourceChainInput, destChainInput string, bridgeType uint8, adapterAddress string) (string, error) {
 	return s.registerAdapter(ctx, sourceChainInput, destChainInput, bridgeType, adapterAddress)
 }
@@ -74,6 +78,9 @@ func TestOnchainAdapterHandler_SuccessPaths(t *testing.T) {
 			getStatus: func(_ context.Context, _, _ string) (*usecases.OnchainAdapterStatus, error) {
 				return &usecases.OnchainAdapterStatus{DefaultBridgeType: 1}, nil
 			},
+			diagnostics: func(context.Context) (*usecases.OnchainDiagnostics, error) {
+				return &usecases.OnchainDiagnostics{Ready: true, Chains: []usecases.ChainDiagnostics{{ChainID: "eip155:8453", Ready: true}}}, nil
+			},
 			registerAdapter: func(_ context.Context, _, _ string, _ uint8, _ string) (string, error) {
 				return "0xregister", nil
 			},
@@ -97,6 +104,7 @@ func TestOnchainAdapterHandler_SuccessPaths(t *testing.T) {
 
 	r := gin.New()
 	r.GET("/status", h.GetStatus)
+	r.GET("/diagnostics", h.Diagnostics)
 	r.POST("/register", h.RegisterAdapter)
 	r.POST("/default-bridge", h.SetDefaultBridgeType)
 	r.POST("/hyperbridge", h.SetHyperbridgeConfig)
@@ -111,6 +119,11 @@ func TestOnchainAdapterHandler_SuccessPaths(t *testing.T) {
 	r.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusOK, rec.Code)
 
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/diagnostics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"ownerIsGatewayOwner":false`)
+
 	tests := []struct {
 		path string
 		body map[string]interface{}
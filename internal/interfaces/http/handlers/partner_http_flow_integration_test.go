@@ -520,8 +520,10 @@ func createPartnerHTTPFlowTables(t *testing.T, db *gorm.DB) {
 		status TEXT NOT NULL,
 		expires_at DATETIME NOT NULL,
 		tx_hash TEXT,
+		block_number INTEGER,
 		payer_address TEXT,
 		payment_code TEXT,
+		metadata TEXT,
 		completed_at DATETIME,
 		created_at DATETIME,
 		updated_at DATETIME,
@@ -49,6 +49,7 @@ func (h *PartnerQuoteHandler) CreateQuote(c *gin.Context) {
 		SelectedToken   string                 `json:"selected_token" binding:"required"`
 		DestWallet      string                 `json:"dest_wallet" binding:"required"`
 		Metadata        map[string]interface{} `json:"metadata"`
+		SimulateFrom    string                 `json:"simulate_from"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -63,6 +64,7 @@ func (h *PartnerQuoteHandler) CreateQuote(c *gin.Context) {
 		SelectedChain:   req.SelectedChain,
 		SelectedToken:   req.SelectedToken,
 		DestWallet:      req.DestWallet,
+		SimulateFrom:    req.SimulateFrom,
 	})
 	if err != nil {
 		response.Error(c, err)
@@ -4,9 +4,12 @@ import (
 	"context"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/internal/usecases"
 )
 
 type PaymentAppService interface {
@@ -34,9 +37,20 @@ func (h *PaymentAppHandler) CreatePaymentApp(c *gin.Context) {
 	// Note: We don't necessarily need UserID from context because the Usecase logic
 	// resolves User logic based on `SenderWalletAddress` in the input.
 	// DualAuthMiddleware ensures the request is authenticated/authorized to reach here.
+	// The authenticated merchant still binds the payment to its receiver allow-list.
+	ctx := c.Request.Context()
+	if merchantID, ok := c.Get(middleware.MerchantIDKey); ok {
+		if merchantID, ok := merchantID.(uuid.UUID); ok {
+			ctx = usecases.WithMerchantScope(ctx, merchantID)
+		}
+	}
 
-	result, err := h.paymentAppUsecase.CreatePaymentApp(c.Request.Context(), &input)
+	result, err := h.paymentAppUsecase.CreatePaymentApp(ctx, &input)
 	if err != nil {
+		if isReceiverInputError(err) {
+			response.Error(c, domainerrors.BadRequest(err.Error()))
+			return
+		}
 		response.Error(c, err)
 		return
 	}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/pkg/utils"
+)
+
+// PaymentApprovalService is the review queue for cross-chain payments above their token's
+// approval threshold
+type PaymentApprovalService interface {
+	ListPendingApprovalPayments(ctx context.Context, page, limit int) ([]*entities.Payment, int, error)
+	ApprovePayment(ctx context.Context, paymentID, adminID uuid.UUID) (*entities.Payment, error)
+	RejectPayment(ctx context.Context, paymentID, adminID uuid.UUID, reason string) (*entities.Payment, error)
+}
+
+// PaymentApprovalHandler handles the admin payment review endpoints
+type PaymentApprovalHandler struct {
+	approvals PaymentApprovalService
+}
+
+// NewPaymentApprovalHandler creates a new payment approval handler
+func NewPaymentApprovalHandler(approvals PaymentApprovalService) *PaymentApprovalHandler {
+	return &PaymentApprovalHandler{approvals: approvals}
+}
+
+// ListPendingApproval lists payments awaiting review, oldest first
+// GET /api/v1/admin/payments/pending-approval?page=1&limit=20
+func (h *PaymentApprovalHandler) ListPendingApproval(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > adminUsersMaxLimit {
+		limit = 20
+	}
+	pagination := utils.GetPaginationParams(page, limit)
+
+	payments, total, err := h.approvals.ListPendingApprovalPayments(c.Request.Context(), pagination.Page, pagination.Limit)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{
+		"payments": payments,
+		"meta":     utils.CalculateMeta(int64(total), pagination.Page, pagination.Limit),
+	})
+}
+
+// ApprovePayment releases a held payment to the payer
+// POST /api/v1/admin/payments/:id/approve
+func (h *PaymentApprovalHandler) ApprovePayment(c *gin.Context) {
+	paymentID, ok := parsePaymentIDParam(c)
+	if !ok {
+		return
+	}
+	adminID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Error(c, domainerrors.Unauthorized("User not authenticated"))
+		return
+	}
+
+	payment, err := h.approvals.ApprovePayment(c.Request.Context(), paymentID, adminID)
+	if err != nil {
+		respondPaymentReviewError(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, gin.H{"payment": payment})
+}
+
+// RejectPayment fails a held payment
+// POST /api/v1/admin/payments/:id/reject
+func (h *PaymentApprovalHandler) RejectPayment(c *gin.Context) {
+	paymentID, ok := parsePaymentIDParam(c)
+	if !ok {
+		return
+	}
+	adminID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Error(c, domainerrors.Unauthorized("User not authenticated"))
+		return
+	}
+	var input struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+
+	payment, err := h.approvals.RejectPayment(c.Request.Context(), paymentID, adminID, input.Reason)
+	if err != nil {
+		respondPaymentReviewError(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, gin.H{"payment": payment})
+}
+
+func respondPaymentReviewError(c *gin.Context, err error) {
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		response.Error(c, domainerrors.NotFound("Payment not found"))
+		return
+	}
+	response.Error(c, err)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+)
+
+type paymentApprovalServiceStub struct {
+	held     map[uuid.UUID]*entities.Payment
+	reviewer uuid.UUID
+	reason   string
+}
+
+func (s *paymentApprovalServiceStub) ListPendingApprovalPayments(context.Context, int, int) ([]*entities.Payment, int, error) {
+	payments := make([]*entities.Payment, 0, len(s.held))
+	for _, payment := range s.held {
+		payments = append(payments, payment)
+	}
+	return payments, len(payments), nil
+}
+
+func (s *paymentApprovalServiceStub) review(id, adminID uuid.UUID, status entities.PaymentStatus) (*entities.Payment, error) {
+	payment, ok := s.held[id]
+	if !ok {
+		return nil, domainerrors.ErrNotFound
+	}
+	delete(s.held, id)
+	s.reviewer = adminID
+	payment.Status = status
+	return payment, nil
+}
+
+func (s *paymentApprovalServiceStub) ApprovePayment(_ context.Context, id, adminID uuid.UUID) (*entities.Payment, error) {
+	return s.review(id, adminID, entities.PaymentStatusPending)
+}
+
+func (s *paymentApprovalServiceStub) RejectPayment(_ context.Context, id, adminID uuid.UUID, reason string) (*entities.Payment, error) {
+	s.reason = reason
+	return s.review(id, adminID, entities.PaymentStatusFailed)
+}
+
+func TestPaymentApprovalHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	adminID := uuid.New()
+	first := &entities.Payment{ID: uuid.New(), Status: entities.PaymentStatusPendingApproval}
+	second := &entities.Payment{ID: uuid.New(), Status: entities.PaymentStatusPendingApproval}
+	svc := &paymentApprovalServiceStub{held: map[uuid.UUID]*entities.Payment{first.ID: first, second.ID: second}}
+	h := NewPaymentApprovalHandler(svc)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(middleware.UserIDKey, adminID) })
+	r.GET("/payments/pending-approval", h.ListPendingApproval)
+	r.POST("/payments/:id/approve", h.ApprovePayment)
+	r.POST("/payments/:id/reject", h.RejectPayment)
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "/payments/pending-approval?page=1&limit=10", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), first.ID.String())
+	require.Contains(t, w.Body.String(), `"totalCount":2`)
+
+	w = do(http.MethodPost, "/payments/"+first.ID.String()+"/approve", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"PENDING"`)
+	require.Equal(t, adminID, svc.reviewer)
+
+	// Rejecting needs a reason
+	w = do(http.MethodPost, "/payments/"+second.ID.String()+"/reject", `{}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	w = do(http.MethodPost, "/payments/"+second.ID.String()+"/reject", `{"reason":"sanctioned receiver"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"FAILED"`)
+	require.Equal(t, "sanctioned receiver", svc.reason)
+
+	require.Equal(t, http.StatusNotFound, do(http.MethodPost, "/payments/"+first.ID.String()+"/approve", "").Code)
+	require.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/payments/not-a-uuid/approve", "").Code)
+}
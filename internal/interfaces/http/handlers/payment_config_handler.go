@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -61,7 +62,8 @@ func (h *PaymentConfigHandler) ListPaymentBridges(c *gin.Context) {
 
 func (h *PaymentConfigHandler) CreatePaymentBridge(c *gin.Context) {
 	var input struct {
-		Name string `json:"name" binding:"required"`
+		Name                string `json:"name" binding:"required"`
+		ExplorerURLTemplate string `json:"explorerUrlTemplate"`
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
 		response.Error(c, domainerrors.BadRequest(err.Error()))
@@ -69,13 +71,18 @@ func (h *PaymentConfigHandler) CreatePaymentBridge(c *gin.Context) {
 	}
 
 	item := &entities.PaymentBridge{
-		ID:   utils.GenerateUUIDv7(),
-		Name: strings.TrimSpace(input.Name),
+		ID:                  utils.GenerateUUIDv7(),
+		Name:                strings.TrimSpace(input.Name),
+		ExplorerURLTemplate: strings.TrimSpace(input.ExplorerURLTemplate),
 	}
 	if item.Name == "" {
 		response.Error(c, domainerrors.BadRequest("name is required"))
 		return
 	}
+	if err := validateExplorerURLTemplate(item.ExplorerURLTemplate); err != nil {
+		response.Error(c, err)
+		return
+	}
 
 	if err := h.paymentBridgeRepo.Create(c.Request.Context(), item); err != nil {
 		response.Error(c, err)
@@ -98,7 +105,8 @@ func (h *PaymentConfigHandler) UpdatePaymentBridge(c *gin.Context) {
 	}
 
 	var input struct {
-		Name string `json:"name" binding:"required"`
+		Name                string  `json:"name" binding:"required"`
+		ExplorerURLTemplate *string `json:"explorerUrlTemplate"` // omitted keeps the current template
 	}
 	if err := c.ShouldBindJSON(&input); err != nil {
 		response.Error(c, domainerrors.BadRequest(err.Error()))
@@ -110,6 +118,13 @@ func (h *PaymentConfigHandler) UpdatePaymentBridge(c *gin.Context) {
 		response.Error(c, domainerrors.BadRequest("name is required"))
 		return
 	}
+	if input.ExplorerURLTemplate != nil {
+		existing.ExplorerURLTemplate = strings.TrimSpace(*input.ExplorerURLTemplate)
+		if err := validateExplorerURLTemplate(existing.ExplorerURLTemplate); err != nil {
+			response.Error(c, err)
+			return
+		}
+	}
 	if err := h.paymentBridgeRepo.Update(c.Request.Context(), existing); err != nil {
 		response.Error(c, err)
 		return
@@ -117,6 +132,22 @@ func (h *PaymentConfigHandler) UpdatePaymentBridge(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"bridge": existing})
 }
 
+// validateExplorerURLTemplate accepts an empty template (no link) or an http(s) URL using at
+// least one of the {messageId} and {sourceTxHash} placeholders
+func validateExplorerURLTemplate(template string) error {
+	if template == "" {
+		return nil
+	}
+	if !strings.Contains(template, "{messageId}") && !strings.Contains(template, "{sourceTxHash}") {
+		return domainerrors.BadRequest("explorerUrlTemplate must contain {messageId} or {sourceTxHash}")
+	}
+	parsed, err := url.Parse(template)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return domainerrors.BadRequest("explorerUrlTemplate must be an absolute http(s) URL")
+	}
+	return nil
+}
+
 func (h *PaymentConfigHandler) DeletePaymentBridge(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -154,17 +154,24 @@ func (s tokenRepoExistsStub) GetByAddress(context.Context, string, uuid.UUID) (*
 	return nil, domainerrors.ErrNotFound
 }
 func (s tokenRepoExistsStub) GetAll(context.Context) ([]*entities.Token, error)                        { return nil, nil }
+func (s tokenRepoExistsStub) GetActiveBySymbol(context.Context, string) ([]*entities.Token, error) {
+	return nil, nil
+}
 func (s tokenRepoExistsStub) GetStablecoins(context.Context) ([]*entities.Token, error)                 { return nil, nil }
 func (s tokenRepoExistsStub) GetNative(context.Context, uuid.UUID) (*entities.Token, error)             { return nil, domainerrors.ErrNotFound }
 func (s tokenRepoExistsStub) GetTokensByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
-func (s tokenRepoExistsStub) GetAllTokens(context.Context, *uuid.UUID, *string, utils.PaginationParams) ([]*entities.Token, int64, error) {
+func (s tokenRepoExistsStub) GetAllTokens(context.Context, *uuid.UUID, *string, bool, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
+func (s tokenRepoExistsStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
 func (s tokenRepoExistsStub) Create(context.Context, *entities.Token) error  { return nil }
 func (s tokenRepoExistsStub) Update(context.Context, *entities.Token) error  { return nil }
 func (s tokenRepoExistsStub) SoftDelete(context.Context, uuid.UUID) error    { return nil }
+func (s tokenRepoExistsStub) HardDelete(context.Context, uuid.UUID) error    { return nil }
 
 func TestPaymentConfigHandler_BridgeConfigCRUD(t *testing.T) {
 	gin.SetMode(gin.TestMode)
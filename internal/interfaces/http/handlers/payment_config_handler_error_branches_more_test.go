@@ -153,6 +153,9 @@ func (s tokenRepoAlwaysFoundStub) GetByAddress(context.Context, string, uuid.UUI
 	return nil, nil
 }
 func (s tokenRepoAlwaysFoundStub) GetAll(context.Context) ([]*entities.Token, error) { return nil, nil }
+func (s tokenRepoAlwaysFoundStub) GetActiveBySymbol(context.Context, string) ([]*entities.Token, error) {
+	return nil, nil
+}
 func (s tokenRepoAlwaysFoundStub) GetStablecoins(context.Context) ([]*entities.Token, error) {
 	return nil, nil
 }
@@ -160,12 +163,16 @@ func (s tokenRepoAlwaysFoundStub) GetNative(context.Context, uuid.UUID) (*entiti
 func (s tokenRepoAlwaysFoundStub) GetTokensByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
-func (s tokenRepoAlwaysFoundStub) GetAllTokens(context.Context, *uuid.UUID, *string, utils.PaginationParams) ([]*entities.Token, int64, error) {
+func (s tokenRepoAlwaysFoundStub) GetAllTokens(context.Context, *uuid.UUID, *string, bool, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
+func (s tokenRepoAlwaysFoundStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
 func (s tokenRepoAlwaysFoundStub) Create(context.Context, *entities.Token) error { return nil }
 func (s tokenRepoAlwaysFoundStub) Update(context.Context, *entities.Token) error { return nil }
 func (s tokenRepoAlwaysFoundStub) SoftDelete(context.Context, uuid.UUID) error   { return nil }
+func (s tokenRepoAlwaysFoundStub) HardDelete(context.Context, uuid.UUID) error   { return nil }
 
 func TestPaymentConfigHandler_PaymentBridgeErrorBranches(t *testing.T) {
 	gin.SetMode(gin.TestMode)
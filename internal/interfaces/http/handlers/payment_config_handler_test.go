@@ -28,7 +28,8 @@ func (s *paymentBridgeRepoStub) GetByID(_ context.Context, id uuid.UUID) (*entit
 	if !ok {
 		return nil, domainerrors.ErrNotFound
 	}
-	return &entities.PaymentBridge{ID: item.ID, Name: item.Name}, nil
+	copied := *item
+	return &copied, nil
 }
 
 func (s *paymentBridgeRepoStub) GetByName(_ context.Context, _ string) (*entities.PaymentBridge, error) {
@@ -44,7 +45,8 @@ func (s *paymentBridgeRepoStub) List(_ context.Context, _ utils.PaginationParams
 }
 
 func (s *paymentBridgeRepoStub) Create(_ context.Context, bridge *entities.PaymentBridge) error {
-	s.items[bridge.ID] = &entities.PaymentBridge{ID: bridge.ID, Name: bridge.Name}
+	copied := *bridge
+	s.items[bridge.ID] = &copied
 	return nil
 }
 
@@ -52,7 +54,8 @@ func (s *paymentBridgeRepoStub) Update(_ context.Context, bridge *entities.Payme
 	if _, ok := s.items[bridge.ID]; !ok {
 		return domainerrors.ErrNotFound
 	}
-	s.items[bridge.ID] = &entities.PaymentBridge{ID: bridge.ID, Name: bridge.Name}
+	copied := *bridge
+	s.items[bridge.ID] = &copied
 	return nil
 }
 
@@ -105,7 +108,7 @@ func TestPaymentConfigHandler_PaymentBridgeCRUD(t *testing.T) {
 	}
 
 	// Update
-	updateBody := []byte(`{"name":"Hyperbridge"}`)
+	updateBody := []byte(`{"name":"Hyperbridge","explorerUrlTemplate":"https://explorer.hyperbridge.network/messages/{messageId}"}`)
 	req = httptest.NewRequest(http.MethodPut, "/payment-bridges/"+created.Bridge.ID.String(), bytes.NewReader(updateBody))
 	req.Header.Set("Content-Type", "application/json")
 	rec = httptest.NewRecorder()
@@ -113,6 +116,21 @@ func TestPaymentConfigHandler_PaymentBridgeCRUD(t *testing.T) {
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d body=%s", rec.Code, rec.Body.String())
 	}
+	if got := bridgeRepo.items[created.Bridge.ID].ExplorerURLTemplate; got != "https://explorer.hyperbridge.network/messages/{messageId}" {
+		t.Fatalf("expected explorer template to be saved, got %q", got)
+	}
+
+	// Explorer templates need a placeholder and an http(s) URL
+	for _, template := range []string{"https://explorer.example.com", "ftp://explorer.example.com/{messageId}"} {
+		body, _ := json.Marshal(map[string]string{"name": "Stargate", "explorerUrlTemplate": template})
+		req = httptest.NewRequest(http.MethodPost, "/payment-bridges", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %q, got %d body=%s", template, rec.Code, rec.Body.String())
+		}
+	}
 
 	// Delete
 	req = httptest.NewRequest(http.MethodDelete, "/payment-bridges/"+created.Bridge.ID.String(), nil)
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -11,17 +13,23 @@ import (
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/utils"
 )
 
 type PaymentService interface {
 	CreatePayment(ctx context.Context, userID uuid.UUID, input *entities.CreatePaymentInput) (*entities.CreatePaymentResponse, error)
 	GetPayment(ctx context.Context, id uuid.UUID) (*entities.Payment, error)
-	GetPaymentsByUser(ctx context.Context, userID uuid.UUID, page, limit int) ([]*entities.Payment, int, error)
+	GetPaymentsByUser(ctx context.Context, userID uuid.UUID, pagination utils.PaginationParams) ([]*entities.Payment, int64, error)
+	GetPaymentsByExternalRef(ctx context.Context, merchantID uuid.UUID, ref string, pagination utils.PaginationParams) ([]*entities.Payment, int64, error)
 	GetPaymentEvents(ctx context.Context, paymentID uuid.UUID) ([]*entities.PaymentEvent, error)
+	GetPaymentReceipt(ctx context.Context, payment *entities.Payment) ([]byte, error)
 	GetPaymentPrivacyStatus(ctx context.Context, paymentID uuid.UUID) (*entities.PaymentPrivacyStatus, error)
 	BuildRetryPrivacyRecoveryTx(ctx context.Context, paymentID uuid.UUID, onchainPaymentID string) (*entities.PaymentPrivacyRecoveryTx, error)
 	BuildClaimPrivacyRecoveryTx(ctx context.Context, paymentID uuid.UUID, onchainPaymentID string) (*entities.PaymentPrivacyRecoveryTx, error)
 	BuildRefundPrivacyRecoveryTx(ctx context.Context, paymentID uuid.UUID, onchainPaymentID string) (*entities.PaymentPrivacyRecoveryTx, error)
+	BuildPaymentCalldata(ctx context.Context, userID uuid.UUID, input *entities.CreatePaymentInput, paymentID *uuid.UUID) (*entities.PaymentCalldataPreview, error)
+	GetApprovalTarget(ctx context.Context, chain, tokenAddress, amount string) (*entities.ApprovalTarget, error)
 }
 
 // PaymentHandler handles payment endpoints
@@ -37,8 +45,69 @@ func NewPaymentHandler(paymentUsecase PaymentService) *PaymentHandler {
 // CreatePayment creates a new payment
 // POST /api/v1/payments
 func (h *PaymentHandler) CreatePayment(c *gin.Context) {
+	if createResponse, ok := h.createPayment(c); ok {
+		response.Success(c, createPaymentStatus(createResponse), createResponse)
+	}
+}
+
+// CreatePaymentV2 creates a new payment and answers with the v2 body
+// POST /api/v2/payments
+func (h *PaymentHandler) CreatePaymentV2(c *gin.Context) {
+	if createResponse, ok := h.createPayment(c); ok {
+		response.Success(c, createPaymentStatus(createResponse), newCreatePaymentV2Response(createResponse))
+	}
+}
+
+// createPaymentStatus is 201, or 200 when a retry returned a payment created earlier
+func createPaymentStatus(createResponse *entities.CreatePaymentResponse) int {
+	if createResponse.Replayed {
+		return http.StatusOK
+	}
+	return http.StatusCreated
+}
+
+// createPayment binds and creates the payment shared by both API versions. On failure it has
+// already written the error response.
+func (h *PaymentHandler) createPayment(c *gin.Context) (*entities.CreatePaymentResponse, bool) {
 	var input entities.CreatePaymentInput
 
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return nil, false
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Error(c, domainerrors.Unauthorized("User not authenticated"))
+		return nil, false
+	}
+
+	ctx := c.Request.Context()
+	if merchantID, ok := c.Get(middleware.MerchantIDKey); ok {
+		if merchantID, ok := merchantID.(uuid.UUID); ok {
+			ctx = usecases.WithMerchantScope(ctx, merchantID)
+		}
+	}
+	createResponse, err := h.paymentUsecase.CreatePayment(ctx, userID, &input)
+	if err != nil {
+		if err == domainerrors.ErrBadRequest {
+			response.Error(c, domainerrors.BadRequest("Invalid input"))
+			return nil, false
+		}
+		if isReceiverInputError(err) {
+			response.Error(c, domainerrors.BadRequest(err.Error()))
+			return nil, false
+		}
+		response.Error(c, err)
+		return nil, false
+	}
+	return createResponse, true
+}
+
+// BuildPaymentCalldata previews the createPayment calldata for an input without creating a payment
+// POST /api/v1/payments/build-calldata
+func (h *PaymentHandler) BuildPaymentCalldata(c *gin.Context) {
+	var input entities.BuildPaymentCalldataInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		response.Error(c, domainerrors.BadRequest(err.Error()))
 		return
@@ -50,17 +119,39 @@ func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 		return
 	}
 
-	createResponse, err := h.paymentUsecase.CreatePayment(c.Request.Context(), userID, &input)
+	preview, err := h.paymentUsecase.BuildPaymentCalldata(c.Request.Context(), userID, &input.CreatePaymentInput, input.PaymentID)
 	if err != nil {
 		if err == domainerrors.ErrBadRequest {
 			response.Error(c, domainerrors.BadRequest("Invalid input"))
 			return
 		}
+		if isReceiverInputError(err) {
+			response.Error(c, domainerrors.BadRequest(err.Error()))
+			return
+		}
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, preview)
+}
+
+// GetApprovalTarget returns the spender to approve for a token, and the allowance for an amount
+// GET /api/v1/payments/approval-target?chain=eip155:8453&token=0x...&amount=12.5
+func (h *PaymentHandler) GetApprovalTarget(c *gin.Context) {
+	chain := c.Query("chain")
+	if strings.TrimSpace(chain) == "" {
+		response.Error(c, domainerrors.BadRequest("chain is required"))
+		return
+	}
+
+	target, err := h.paymentUsecase.GetApprovalTarget(c.Request.Context(), chain, c.Query("token"), c.Query("amount"))
+	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	response.Success(c, http.StatusCreated, createResponse)
+	response.Success(c, http.StatusOK, target)
 }
 
 // GetPayment gets a payment by ID
@@ -105,21 +196,53 @@ func (h *PaymentHandler) ListPayments(c *gin.Context) {
 		limit = 10
 	}
 
-	payments, total, err := h.paymentUsecase.GetPaymentsByUser(c.Request.Context(), userID, page, limit)
+	if ref := strings.TrimSpace(c.Query("externalRef")); ref != "" {
+		h.listPaymentsByExternalRef(c, ref, page, limit)
+		return
+	}
+
+	payments, total, err := h.paymentUsecase.GetPaymentsByUser(c.Request.Context(), userID, utils.GetPaginationParams(page, limit))
 	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
-	totalPages := (total + limit - 1) / limit
+	meta := utils.CalculateMeta(total, page, limit)
+	response.Success(c, http.StatusOK, gin.H{
+		"payments": payments,
+		"pagination": gin.H{
+			"page":       meta.Page,
+			"limit":      meta.Limit,
+			"total":      meta.TotalCount,
+			"totalPages": meta.TotalPages,
+		},
+	})
+}
+
+// listPaymentsByExternalRef answers GET /api/v1/payments?externalRef=... with the caller's
+// merchant payments for that order id, paged like the plain listing; the lookup is never
+// cross-merchant
+func (h *PaymentHandler) listPaymentsByExternalRef(c *gin.Context, ref string, page, limit int) {
+	merchantID, ok := middleware.GetMerchantID(c)
+	if !ok || merchantID == uuid.Nil {
+		response.Error(c, domainerrors.Forbidden("merchant context required"))
+		return
+	}
+
+	payments, total, err := h.paymentUsecase.GetPaymentsByExternalRef(c.Request.Context(), merchantID, ref, utils.GetPaginationParams(page, limit))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
 
+	meta := utils.CalculateMeta(total, page, limit)
 	response.Success(c, http.StatusOK, gin.H{
 		"payments": payments,
 		"pagination": gin.H{
-			"page":       page,
-			"limit":      limit,
-			"total":      total,
-			"totalPages": totalPages,
+			"page":       meta.Page,
+			"limit":      meta.Limit,
+			"total":      meta.TotalCount,
+			"totalPages": meta.TotalPages,
 		},
 	})
 }
@@ -143,6 +266,53 @@ func (h *PaymentHandler) GetPaymentEvents(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"events": events})
 }
 
+// GetPaymentReceipt downloads a PDF receipt for a payment. Only the payment's sender, its
+// merchant and staff who may read the admin API can download it; anyone else gets a 404 so
+// payment IDs cannot be probed.
+// GET /api/v1/payments/:id/receipt.pdf
+func (h *PaymentHandler) GetPaymentReceipt(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid payment ID"))
+		return
+	}
+
+	payment, err := h.paymentUsecase.GetPayment(c.Request.Context(), id)
+	if err != nil {
+		if err == domainerrors.ErrNotFound {
+			response.Error(c, domainerrors.NotFound("Payment not found"))
+			return
+		}
+		response.Error(c, err)
+		return
+	}
+	if !canViewPayment(c, payment) {
+		response.Error(c, domainerrors.NotFound("Payment not found"))
+		return
+	}
+
+	receipt, err := h.paymentUsecase.GetPaymentReceipt(c.Request.Context(), payment)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="receipt-`+payment.ID.String()+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", receipt)
+}
+
+// canViewPayment reports whether the caller sent the payment, is its merchant, or is staff
+func canViewPayment(c *gin.Context, payment *entities.Payment) bool {
+	if middleware.CanReadAdmin(c) {
+		return true
+	}
+	if userID, ok := middleware.GetUserID(c); ok && payment.SenderID != nil && *payment.SenderID == userID {
+		return true
+	}
+	merchantValue, _ := c.Get(middleware.MerchantIDKey)
+	merchantID, ok := merchantValue.(uuid.UUID)
+	return ok && merchantID != uuid.Nil && payment.MerchantID != nil && *payment.MerchantID == merchantID
+}
+
 // GetPaymentPrivacyStatus gets inferred privacy lifecycle status for a payment
 // GET /api/v1/payments/:id/privacy-status
 func (h *PaymentHandler) GetPaymentPrivacyStatus(c *gin.Context) {
@@ -247,6 +417,11 @@ func (h *PaymentHandler) RefundPrivacyEscrow(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"txData": txData})
 }
 
+// isReceiverInputError reports whether err means the receiver in the request is unusable
+func isReceiverInputError(err error) bool {
+	return errors.Is(err, domainerrors.ErrInvalidReceiverForChain) || errors.Is(err, domainerrors.ErrReceiverNameUnresolved)
+}
+
 func parsePaymentIDParam(c *gin.Context) (uuid.UUID, bool) {
 	idStr := c.Param("id")
 	id, err := uuid.Parse(idStr)
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/pkg/utils"
 )
 
 func TestPaymentHandler_ListPayments_PaginationNormalization(t *testing.T) {
@@ -21,8 +22,8 @@ func TestPaymentHandler_ListPayments_PaginationNormalization(t *testing.T) {
 	h := NewPaymentHandler(paymentServiceStub{
 		createFn: func(context.Context, uuid.UUID, *entities.CreatePaymentInput) (*entities.CreatePaymentResponse, error) { return nil, nil },
 		getFn:    func(context.Context, uuid.UUID) (*entities.Payment, error) { return nil, nil },
-		listFn: func(_ context.Context, _ uuid.UUID, page, limit int) ([]*entities.Payment, int, error) {
-			gotPage, gotLimit = page, limit
+		listFn: func(_ context.Context, _ uuid.UUID, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+			gotPage, gotLimit = pagination.Page, pagination.Limit
 			return []*entities.Payment{}, 0, nil
 		},
 		eventsFn: func(context.Context, uuid.UUID) ([]*entities.PaymentEvent, error) { return nil, nil },
@@ -3,28 +3,37 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/pkg/utils"
 )
 
 type paymentServiceStub struct {
 	createFn        func(ctx context.Context, userID uuid.UUID, input *entities.CreatePaymentInput) (*entities.CreatePaymentResponse, error)
 	getFn           func(ctx context.Context, id uuid.UUID) (*entities.Payment, error)
-	listFn          func(ctx context.Context, userID uuid.UUID, page, limit int) ([]*entities.Payment, int, error)
+	listFn          func(ctx context.Context, userID uuid.UUID, pagination utils.PaginationParams) ([]*entities.Payment, int64, error)
+	externalRefFn   func(ctx context.Context, merchantID uuid.UUID, ref string, pagination utils.PaginationParams) ([]*entities.Payment, int64, error)
 	eventsFn        func(ctx context.Context, paymentID uuid.UUID) ([]*entities.PaymentEvent, error)
+	receiptFn       func(ctx context.Context, payment *entities.Payment) ([]byte, error)
 	privacyFn       func(ctx context.Context, paymentID uuid.UUID) (*entities.PaymentPrivacyStatus, error)
 	retryPrivacyFn  func(ctx context.Context, paymentID uuid.UUID, onchainPaymentID string) (*entities.PaymentPrivacyRecoveryTx, error)
 	claimPrivacyFn  func(ctx context.Context, paymentID uuid.UUID, onchainPaymentID string) (*entities.PaymentPrivacyRecoveryTx, error)
 	refundPrivacyFn func(ctx context.Context, paymentID uuid.UUID, onchainPaymentID string) (*entities.PaymentPrivacyRecoveryTx, error)
+	calldataFn      func(ctx context.Context, userID uuid.UUID, input *entities.CreatePaymentInput, paymentID *uuid.UUID) (*entities.PaymentCalldataPreview, error)
+	approvalFn      func(ctx context.Context, chain, tokenAddress, amount string) (*entities.ApprovalTarget, error)
 }
 
 func (s paymentServiceStub) CreatePayment(ctx context.Context, userID uuid.UUID, input *entities.CreatePaymentInput) (*entities.CreatePaymentResponse, error) {
@@ -33,12 +42,21 @@ func (s paymentServiceStub) CreatePayment(ctx context.Context, userID uuid.UUID,
 func (s paymentServiceStub) GetPayment(ctx context.Context, id uuid.UUID) (*entities.Payment, error) {
 	return s.getFn(ctx, id)
 }
-func (s paymentServiceStub) GetPaymentsByUser(ctx context.Context, userID uuid.UUID, page, limit int) ([]*entities.Payment, int, error) {
-	return s.listFn(ctx, userID, page, limit)
+func (s paymentServiceStub) GetPaymentsByUser(ctx context.Context, userID uuid.UUID, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	return s.listFn(ctx, userID, pagination)
+}
+func (s paymentServiceStub) GetPaymentsByExternalRef(ctx context.Context, merchantID uuid.UUID, ref string, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	return s.externalRefFn(ctx, merchantID, ref, pagination)
 }
 func (s paymentServiceStub) GetPaymentEvents(ctx context.Context, paymentID uuid.UUID) ([]*entities.PaymentEvent, error) {
 	return s.eventsFn(ctx, paymentID)
 }
+func (s paymentServiceStub) GetPaymentReceipt(ctx context.Context, payment *entities.Payment) ([]byte, error) {
+	if s.receiptFn == nil {
+		return nil, errors.New("receipt not implemented")
+	}
+	return s.receiptFn(ctx, payment)
+}
 func (s paymentServiceStub) GetPaymentPrivacyStatus(ctx context.Context, paymentID uuid.UUID) (*entities.PaymentPrivacyStatus, error) {
 	if s.privacyFn == nil {
 		return &entities.PaymentPrivacyStatus{PaymentID: paymentID, Stage: entities.PrivacyLifecycleUnknown}, nil
@@ -63,6 +81,18 @@ func (s paymentServiceStub) BuildRefundPrivacyRecoveryTx(ctx context.Context, pa
 	}
 	return s.refundPrivacyFn(ctx, paymentID, onchainPaymentID)
 }
+func (s paymentServiceStub) BuildPaymentCalldata(ctx context.Context, userID uuid.UUID, input *entities.CreatePaymentInput, paymentID *uuid.UUID) (*entities.PaymentCalldataPreview, error) {
+	if s.calldataFn == nil {
+		return nil, errors.New("calldata not implemented")
+	}
+	return s.calldataFn(ctx, userID, input, paymentID)
+}
+func (s paymentServiceStub) GetApprovalTarget(ctx context.Context, chain, tokenAddress, amount string) (*entities.ApprovalTarget, error) {
+	if s.approvalFn == nil {
+		return nil, errors.New("approval target not implemented")
+	}
+	return s.approvalFn(ctx, chain, tokenAddress, amount)
+}
 
 func TestPaymentHandler_SuccessAndErrorMappings(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -85,8 +115,8 @@ func TestPaymentHandler_SuccessAndErrorMappings(t *testing.T) {
 			}
 			return nil, domainerrors.ErrNotFound
 		},
-		listFn: func(_ context.Context, gotUserID uuid.UUID, page, limit int) ([]*entities.Payment, int, error) {
-			if page == 9 {
+		listFn: func(_ context.Context, gotUserID uuid.UUID, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+			if pagination.Page == 9 {
 				return nil, 0, errors.New("list boom")
 			}
 			return []*entities.Payment{{ID: paymentID, Status: entities.PaymentStatusPending}}, 1, nil
@@ -269,3 +299,296 @@ func TestPaymentHandler_SuccessAndErrorMappings(t *testing.T) {
 		t.Fatalf("expected 400, got %d body=%s", w.Code, w.Body.String())
 	}
 }
+
+func TestPaymentHandler_BuildPaymentCalldata(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	pinnedID := uuid.New()
+
+	var gotPaymentID *uuid.UUID
+	service := paymentServiceStub{
+		calldataFn: func(_ context.Context, gotUserID uuid.UUID, input *entities.CreatePaymentInput, paymentID *uuid.UUID) (*entities.PaymentCalldataPreview, error) {
+			if gotUserID != userID {
+				t.Fatalf("unexpected user %s", gotUserID)
+			}
+			if input.Amount == "bad" {
+				return nil, domainerrors.ErrBadRequest
+			}
+			if input.Amount == "2" {
+				return nil, fmt.Errorf("%w: solana:devnet expects a base58 32-byte public key", domainerrors.ErrInvalidReceiverForChain)
+			}
+			if input.Amount == "3" {
+				return nil, fmt.Errorf("%w: alice.eth on eip155:1: name has no resolver", domainerrors.ErrReceiverNameUnresolved)
+			}
+			gotPaymentID = paymentID
+			return &entities.PaymentCalldataPreview{Function: "createPayment", Data: "0xabcd"}, nil
+		},
+	}
+	h := NewPaymentHandler(service)
+	r := gin.New()
+	r.POST("/payments/build-calldata", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, userID)
+		c.Next()
+	}, h.BuildPaymentCalldata)
+	r.POST("/anonymous/build-calldata", h.BuildPaymentCalldata)
+
+	body := func(amount string) []byte {
+		return []byte(`{"sourceChainId":"eip155:8453","destChainId":"eip155:8453","sourceTokenAddress":"0x1","destTokenAddress":"0x1","amount":"` + amount + `","decimals":6,"receiverAddress":"0x2","paymentId":"` + pinnedID.String() + `"}`)
+	}
+	cases := []struct {
+		path string
+		body []byte
+		code int
+	}{
+		{"/payments/build-calldata", body("1"), http.StatusOK},
+		{"/payments/build-calldata", body("bad"), http.StatusBadRequest},
+		{"/payments/build-calldata", body("2"), http.StatusBadRequest},
+		{"/payments/build-calldata", body("3"), http.StatusBadRequest},
+		{"/payments/build-calldata", []byte(`{"amount":"1"}`), http.StatusBadRequest},
+		{"/anonymous/build-calldata", body("1"), http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, tc.path, bytes.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.code {
+			t.Fatalf("%s: expected %d, got %d body=%s", tc.path, tc.code, w.Code, w.Body.String())
+		}
+	}
+	if gotPaymentID == nil || *gotPaymentID != pinnedID {
+		t.Fatalf("expected pinned payment id to be passed through, got %v", gotPaymentID)
+	}
+}
+
+func TestPaymentHandler_GetApprovalTarget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var gotChain, gotToken, gotAmount string
+	h := NewPaymentHandler(paymentServiceStub{
+		approvalFn: func(_ context.Context, chain, tokenAddress, amount string) (*entities.ApprovalTarget, error) {
+			gotChain, gotToken, gotAmount = chain, tokenAddress, amount
+			if tokenAddress == "0xunknown" {
+				return nil, domainerrors.NotFound("token 0xunknown is not supported on eip155:8453")
+			}
+			return &entities.ApprovalTarget{ChainID: chain, TokenAddress: tokenAddress, ApprovalRequired: true, Spender: "0xvault", ApprovalAmount: "12510000"}, nil
+		},
+	})
+	r := gin.New()
+	r.GET("/payments/approval-target", h.GetApprovalTarget)
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/payments/approval-target"+query, nil))
+		return w
+	}
+
+	w := get("?chain=eip155:8453&token=0xusdc&amount=12.5")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if gotChain != "eip155:8453" || gotToken != "0xusdc" || gotAmount != "12.5" {
+		t.Fatalf("unexpected query passed through: %q %q %q", gotChain, gotToken, gotAmount)
+	}
+	if !strings.Contains(w.Body.String(), `"spender":"0xvault"`) || !strings.Contains(w.Body.String(), `"approvalAmount":"12510000"`) {
+		t.Fatalf("unexpected body %s", w.Body.String())
+	}
+	if w := get("?token=0xusdc"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without chain, got %d", w.Code)
+	}
+	if w := get("?chain=eip155:8453&token=0xunknown"); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown token, got %d", w.Code)
+	}
+}
+
+func TestPaymentHandler_ListPaymentsByExternalRef(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	merchantID := uuid.New()
+	paymentID := uuid.New()
+
+	h := NewPaymentHandler(paymentServiceStub{
+		externalRefFn: func(_ context.Context, gotMerchantID uuid.UUID, ref string, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+			if gotMerchantID != merchantID {
+				t.Fatalf("unexpected merchant %s", gotMerchantID)
+			}
+			if ref == "boom" {
+				return nil, 0, errors.New("lookup boom")
+			}
+			if pagination.Page != 2 || pagination.Limit != 1 {
+				t.Fatalf("unexpected pagination %+v", pagination)
+			}
+			return []*entities.Payment{{ID: paymentID, ExternalRef: null.StringFrom(ref)}}, 3, nil
+		},
+		listFn: func(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.Payment, int64, error) {
+			t.Fatal("externalRef lookups must not list the user's payments")
+			return nil, 0, nil
+		},
+	})
+	r := gin.New()
+	withUser := func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uuid.New())
+		c.Next()
+	}
+	withMerchant := func(c *gin.Context) {
+		c.Set(middleware.MerchantIDKey, merchantID)
+		c.Next()
+	}
+	r.GET("/merchant/payments", withUser, withMerchant, h.ListPayments)
+	r.GET("/user/payments", withUser, h.ListPayments)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/merchant/payments?externalRef=order-42&page=2&limit=1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"externalRef":"order-42"`) || !strings.Contains(w.Body.String(), paymentID.String()) {
+		t.Fatalf("unexpected body %s", w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"total":3`) || !strings.Contains(w.Body.String(), `"totalPages":3`) {
+		t.Fatalf("expected the full count in pagination, got %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/user/payments?externalRef=order-42", nil))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 without merchant context, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/merchant/payments?externalRef=boom", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestPaymentHandler_GetPaymentReceipt(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	senderID := uuid.New()
+	merchantID := uuid.New()
+	paymentID := uuid.New()
+
+	h := NewPaymentHandler(paymentServiceStub{
+		getFn: func(_ context.Context, id uuid.UUID) (*entities.Payment, error) {
+			if id != paymentID {
+				return nil, domainerrors.ErrNotFound
+			}
+			return &entities.Payment{ID: id, SenderID: &senderID, MerchantID: &merchantID}, nil
+		},
+		receiptFn: func(_ context.Context, payment *entities.Payment) ([]byte, error) {
+			return []byte("%PDF-1.4 " + payment.ID.String()), nil
+		},
+	})
+	r := gin.New()
+	r.GET("/payments/:id/receipt.pdf", func(c *gin.Context) {
+		if user := c.GetHeader("X-User"); user != "" {
+			c.Set(middleware.UserIDKey, uuid.MustParse(user))
+		}
+		if merchant := c.GetHeader("X-Merchant"); merchant != "" {
+			c.Set(middleware.MerchantIDKey, uuid.MustParse(merchant))
+		}
+		if role := c.GetHeader("X-Role"); role != "" {
+			c.Set(middleware.UserRoleKey, role)
+		}
+		c.Next()
+	}, h.GetPaymentReceipt)
+	do := func(id string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/payments/"+id+"/receipt.pdf", nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(paymentID.String(), map[string]string{"X-User": senderID.String(), "X-Role": "USER"})
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/pdf" {
+		t.Fatalf("expected PDF for the sender, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	if want := `attachment; filename="receipt-` + paymentID.String() + `.pdf"`; w.Header().Get("Content-Disposition") != want {
+		t.Fatalf("unexpected Content-Disposition %q", w.Header().Get("Content-Disposition"))
+	}
+	if !strings.HasPrefix(w.Body.String(), "%PDF-") {
+		t.Fatalf("unexpected body %q", w.Body.String())
+	}
+
+	for name, headers := range map[string]map[string]string{
+		"merchant": {"X-User": uuid.NewString(), "X-Merchant": merchantID.String(), "X-Role": "MERCHANT"},
+		"support":  {"X-User": uuid.NewString(), "X-Role": "SUPPORT"},
+	} {
+		if w := do(paymentID.String(), headers); w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", name, w.Code)
+		}
+	}
+	for name, headers := range map[string]map[string]string{
+		"other user":     {"X-User": uuid.NewString(), "X-Role": "USER"},
+		"other merchant": {"X-User": uuid.NewString(), "X-Merchant": uuid.NewString(), "X-Role": "MERCHANT"},
+	} {
+		if w := do(paymentID.String(), headers); w.Code != http.StatusNotFound {
+			t.Fatalf("%s: expected 404, got %d", name, w.Code)
+		}
+	}
+	if w := do(uuid.NewString(), map[string]string{"X-Role": "ADMIN"}); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown payment, got %d", w.Code)
+	}
+	if w := do("not-a-uuid", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad id, got %d", w.Code)
+	}
+}
+
+func TestPaymentHandler_CreatePaymentV2_ReportsBridgeOnce(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	bridgeID := uuid.New()
+	responses := map[string]*entities.CreatePaymentResponse{
+		"eip155:42161": {PaymentID: uuid.New(), BridgeType: "CCIP", Bridge: &entities.SelectedBridge{Name: "CCIP", ID: &bridgeID}},
+		"eip155:8453":  {PaymentID: uuid.New()},
+	}
+	h := NewPaymentHandler(paymentServiceStub{
+		createFn: func(_ context.Context, _ uuid.UUID, input *entities.CreatePaymentInput) (*entities.CreatePaymentResponse, error) {
+			if input.Amount == "bad" {
+				return nil, domainerrors.ErrBadRequest
+			}
+			return responses[input.DestChainID], nil
+		},
+	})
+	r := gin.New()
+	r.POST("/v2/payments", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, userID)
+		c.Next()
+	}, h.CreatePaymentV2)
+
+	create := func(destChainID, amount string) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"sourceChainId":"eip155:8453","destChainId":%q,"sourceTokenAddress":"0xabc","destTokenAddress":"0xdef","amount":%q,"decimals":6,"receiverAddress":"0x123"}`, destChainID, amount)
+		req := httptest.NewRequest(http.MethodPost, "/v2/payments", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := create("eip155:42161", "1")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d body=%s", w.Code, w.Body.String())
+	}
+	var crossChain map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &crossChain); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := crossChain["bridgeType"]; ok {
+		t.Fatalf("v2 should not send bridgeType: %s", w.Body.String())
+	}
+	if _, ok := crossChain["bridgeReason"]; ok {
+		t.Fatalf("v2 should not send bridgeReason: %s", w.Body.String())
+	}
+	if bridge, _ := crossChain["bridge"].(map[string]interface{}); bridge["name"] != "CCIP" || bridge["id"] != bridgeID.String() {
+		t.Fatalf("unexpected bridge: %s", w.Body.String())
+	}
+
+	w = create("eip155:8453", "1")
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"bridge":null`) {
+		t.Fatalf("expected a null bridge on same-chain, got %d body=%s", w.Code, w.Body.String())
+	}
+
+	if w = create("eip155:8453", "bad"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d body=%s", w.Code, w.Body.String())
+	}
+}
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
+	"strings"
+	"time"
 
 	"net/http"
 
@@ -10,37 +13,60 @@ import (
 	"github.com/google/uuid"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/interfaces/http/response"
 	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/utils"
 )
 
 type PaymentRequestHandler struct {
 	usecase PaymentRequestService
+	// publicMetadataKeys are the metadata keys GetPublicPaymentRequest may show payers
+	publicMetadataKeys map[string]bool
 }
 
 type PaymentRequestService interface {
 	CreatePaymentRequest(ctx context.Context, input usecases.CreatePaymentRequestInput) (*usecases.CreatePaymentRequestOutput, error)
+	CreatePaymentRequestBatch(ctx context.Context, input usecases.CreatePaymentRequestBatchInput) (*usecases.CreatePaymentRequestBatchOutput, error)
 	GetPaymentRequest(ctx context.Context, requestID uuid.UUID) (*entities.PaymentRequest, *entities.PaymentRequestTxData, error)
 	ResolvePaymentRequest(ctx context.Context, requestID uuid.UUID) (*usecases.ResolvePaymentRequestOutput, error)
-	ListPaymentRequests(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error)
+	CancelPaymentRequest(ctx context.Context, userID, requestID uuid.UUID) (*entities.PaymentRequest, error)
+	ListPaymentRequests(ctx context.Context, userID uuid.UUID, filter repositories.PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error)
 }
 
 func NewPaymentRequestHandler(usecase PaymentRequestService) *PaymentRequestHandler {
 	return &PaymentRequestHandler{usecase: usecase}
 }
 
+// NewPaymentRequestHandlerWithPublicMetadata is NewPaymentRequestHandler exposing the given
+// metadata keys on the public /pay/:id response. Metadata is merchant-only by default.
+func NewPaymentRequestHandlerWithPublicMetadata(usecase PaymentRequestService, publicKeys []string) *PaymentRequestHandler {
+	h := NewPaymentRequestHandler(usecase)
+	if len(publicKeys) > 0 {
+		h.publicMetadataKeys = make(map[string]bool, len(publicKeys))
+		for _, key := range publicKeys {
+			h.publicMetadataKeys[key] = true
+		}
+	}
+	return h
+}
+
 type CreatePaymentRequestRequest struct {
 	ChainID      string `json:"chainId" binding:"required"`
 	TokenAddress string `json:"tokenAddress" binding:"required"`
 	Amount       string `json:"amount" binding:"required"`
 	Decimals     int    `json:"decimals" binding:"required"`
 	Description  string `json:"description"`
+	// Metadata is a JSON object of at most usecases.MaxMetadataBytes, returned to the merchant
+	// as-is and hidden from payers
+	Metadata json.RawMessage `json:"metadata,omitempty"`
 }
 
 // CreatePaymentRequest creates a new payment request
 // POST /api/v1/payment-requests
 func (h *PaymentRequestHandler) CreatePaymentRequest(c *gin.Context) {
-	userID, exists := c.Get("userID")
+	userID, exists := c.Get(middleware.UserIDKey)
 	if !exists {
 		response.Error(c, domainerrors.Unauthorized("unauthorized"))
 		return
@@ -59,6 +85,7 @@ func (h *PaymentRequestHandler) CreatePaymentRequest(c *gin.Context) {
 		Amount:       req.Amount,
 		Decimals:     req.Decimals,
 		Description:  req.Description,
+		Metadata:     req.Metadata,
 	}
 
 	result, err := h.usecase.CreatePaymentRequest(c.Request.Context(), input)
@@ -70,6 +97,65 @@ func (h *PaymentRequestHandler) CreatePaymentRequest(c *gin.Context) {
 	response.Success(c, http.StatusCreated, result)
 }
 
+// CreatePaymentRequestBatchRequest carries up to usecases.MaxPaymentRequestBatchSize items.
+// Items are validated one by one rather than through binding so a bad entry is reported
+// without rejecting the batch.
+type CreatePaymentRequestBatchRequest struct {
+	Items        []CreatePaymentRequestRequest `json:"items" binding:"required,min=1"`
+	AllOrNothing bool                          `json:"allOrNothing"`
+}
+
+// CreatePaymentRequestBatch creates many payment requests in one transaction
+// POST /api/v1/payment-requests/batch
+func (h *PaymentRequestHandler) CreatePaymentRequestBatch(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		response.Error(c, domainerrors.Unauthorized("unauthorized"))
+		return
+	}
+
+	var req CreatePaymentRequestBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+	if len(req.Items) > usecases.MaxPaymentRequestBatchSize {
+		response.Error(c, domainerrors.BadRequest("too many items"))
+		return
+	}
+
+	input := usecases.CreatePaymentRequestBatchInput{
+		UserID:       userID.(uuid.UUID),
+		Items:        make([]usecases.CreatePaymentRequestInput, 0, len(req.Items)),
+		AllOrNothing: req.AllOrNothing,
+	}
+	for _, item := range req.Items {
+		input.Items = append(input.Items, usecases.CreatePaymentRequestInput{
+			ChainID:      item.ChainID,
+			TokenAddress: item.TokenAddress,
+			Amount:       item.Amount,
+			Decimals:     item.Decimals,
+			Description:  item.Description,
+			Metadata:     item.Metadata,
+		})
+	}
+
+	result, err := h.usecase.CreatePaymentRequestBatch(c.Request.Context(), input)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	status := http.StatusCreated
+	switch {
+	case result.Created == 0:
+		status = http.StatusUnprocessableEntity
+	case result.Failed > 0:
+		status = http.StatusMultiStatus
+	}
+	response.Success(c, status, result)
+}
+
 // GetPaymentRequest gets a payment request by ID with transaction data
 // GET /api/v1/payment-requests/:id
 func (h *PaymentRequestHandler) GetPaymentRequest(c *gin.Context) {
@@ -97,9 +183,9 @@ func (h *PaymentRequestHandler) GetPaymentRequest(c *gin.Context) {
 }
 
 // ListPaymentRequests lists payment requests for the authenticated merchant
-// GET /api/v1/payment-requests
+// GET /api/v1/payment-requests?status=PENDING&from=2024-01-01&to=2024-01-31&sortBy=expiresAt&sortOrder=asc
 func (h *PaymentRequestHandler) ListPaymentRequests(c *gin.Context) {
-	userID, exists := c.Get("userID")
+	userID, exists := c.Get(middleware.UserIDKey)
 	if !exists {
 		response.Error(c, domainerrors.Unauthorized("unauthorized"))
 		return
@@ -116,23 +202,130 @@ func (h *PaymentRequestHandler) ListPaymentRequests(c *gin.Context) {
 	}
 	offset := (page - 1) * limit
 
-	requests, total, err := h.usecase.ListPaymentRequests(c.Request.Context(), userID.(uuid.UUID), limit, offset)
+	filter, err := parsePaymentRequestFilter(c)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	requests, total, err := h.usecase.ListPaymentRequests(c.Request.Context(), userID.(uuid.UUID), filter, limit, offset)
 	if err != nil {
 		response.Error(c, err)
 		return
 	}
 
+	// pagination is the shape existing clients read; meta matches the other list endpoints
+	meta := utils.CalculateMeta(int64(total), page, limit)
 	response.Success(c, http.StatusOK, gin.H{
 		"paymentRequests": requests,
 		"pagination": gin.H{
-			"page":       page,
-			"limit":      limit,
-			"total":      total,
-			"totalPages": (total + limit - 1) / limit,
+			"page":       meta.Page,
+			"limit":      meta.Limit,
+			"total":      meta.TotalCount,
+			"totalPages": meta.TotalPages,
 		},
+		"meta": meta,
 	})
 }
 
+var paymentRequestStatuses = map[entities.PaymentRequestStatus]bool{
+	entities.PaymentRequestStatusPending:   true,
+	entities.PaymentRequestStatusCompleted: true,
+	entities.PaymentRequestStatusExpired:   true,
+	entities.PaymentRequestStatusCancelled: true,
+}
+
+var paymentRequestSortFields = map[repositories.PaymentRequestSortField]bool{
+	repositories.PaymentRequestSortCreatedAt: true,
+	repositories.PaymentRequestSortExpiresAt: true,
+	repositories.PaymentRequestSortAmount:    true,
+	repositories.PaymentRequestSortStatus:    true,
+}
+
+// parsePaymentRequestFilter reads status, from/to (RFC3339 or YYYY-MM-DD) and sortBy/sortOrder.
+// A date-only "to" covers that whole day.
+func parsePaymentRequestFilter(c *gin.Context) (repositories.PaymentRequestFilter, error) {
+	var filter repositories.PaymentRequestFilter
+
+	if raw := strings.TrimSpace(c.Query("status")); raw != "" {
+		status := entities.PaymentRequestStatus(strings.ToUpper(raw))
+		if !paymentRequestStatuses[status] {
+			return filter, domainerrors.BadRequest("invalid status")
+		}
+		filter.Status = status
+	}
+
+	if raw := strings.TrimSpace(c.Query("from")); raw != "" {
+		from, _, err := parseListDate(raw)
+		if err != nil {
+			return filter, domainerrors.BadRequest("invalid from date")
+		}
+		filter.CreatedFrom = &from
+	}
+	if raw := strings.TrimSpace(c.Query("to")); raw != "" {
+		to, dateOnly, err := parseListDate(raw)
+		if err != nil {
+			return filter, domainerrors.BadRequest("invalid to date")
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.CreatedTo = &to
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return filter, domainerrors.BadRequest("from must be before to")
+	}
+
+	if raw := strings.TrimSpace(c.Query("sortBy")); raw != "" {
+		sortBy := repositories.PaymentRequestSortField(raw)
+		if !paymentRequestSortFields[sortBy] {
+			return filter, domainerrors.BadRequest("invalid sortBy")
+		}
+		filter.SortBy = sortBy
+	}
+	switch strings.ToLower(strings.TrimSpace(c.Query("sortOrder"))) {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		return filter, domainerrors.BadRequest("invalid sortOrder")
+	}
+
+	return filter, nil
+}
+
+func parseListDate(raw string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	return t, false, err
+}
+
+// CancelPaymentRequest cancels a pending payment request owned by the authenticated merchant
+// POST /api/v1/payment-requests/:id/cancel
+func (h *PaymentRequestHandler) CancelPaymentRequest(c *gin.Context) {
+	userID, exists := c.Get(middleware.UserIDKey)
+	if !exists {
+		response.Error(c, domainerrors.Unauthorized("unauthorized"))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("invalid request ID"))
+		return
+	}
+
+	request, err := h.usecase.CancelPaymentRequest(c.Request.Context(), userID.(uuid.UUID), id)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"request": request})
+}
+
 // GetPublicPaymentRequest gets a payment request by ID for payers (public)
 // GET /api/v1/pay/:id
 func (h *PaymentRequestHandler) GetPublicPaymentRequest(c *gin.Context) {
@@ -154,24 +347,52 @@ func (h *PaymentRequestHandler) GetPublicPaymentRequest(c *gin.Context) {
 	}
 
 	// Only return public info
-	response.Success(c, http.StatusOK, gin.H{
-		"requestId":       request.ID,
-		"chainId":         request.NetworkID,
-		"amount":          request.Amount,
-		"decimals":        request.Decimals,
-		"walletAddress":   request.WalletAddress,
-		"description":     request.Description,
-		"status":          request.Status,
-		"expiresAt":       request.ExpiresAt,
-		"contractAddress": txData.ContractAddress,
-		"txData": gin.H{
+	body := gin.H{
+		"requestId":     request.ID,
+		"chainId":       request.NetworkID,
+		"amount":        request.Amount,
+		"decimals":      request.Decimals,
+		"walletAddress": request.WalletAddress,
+		"description":   request.Description,
+		"status":        request.Status,
+		"expiresAt":     request.ExpiresAt,
+	}
+	if metadata := h.publicMetadata(request); metadata != nil {
+		body["metadata"] = metadata
+	}
+	// Cancelled requests come back without tx data so payers cannot submit them
+	if txData != nil {
+		body["contractAddress"] = txData.ContractAddress
+		body["txData"] = gin.H{
 			"to":        txData.To,
 			"programId": txData.ProgramID,
 			"hex":       txData.Hex,
 			"base58":    txData.Base58,
 			"base64":    txData.Base64,
-		},
-	})
+		}
+	}
+	response.Success(c, http.StatusOK, body)
+}
+
+// publicMetadata picks the whitelisted keys out of the request metadata, or nil when none apply
+func (h *PaymentRequestHandler) publicMetadata(request *entities.PaymentRequest) map[string]json.RawMessage {
+	if len(h.publicMetadataKeys) == 0 || !request.Metadata.Valid {
+		return nil
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(request.Metadata.JSON, &all); err != nil {
+		return nil
+	}
+	public := map[string]json.RawMessage{}
+	for key, value := range all {
+		if h.publicMetadataKeys[key] {
+			public[key] = value
+		}
+	}
+	if len(public) == 0 {
+		return nil
+	}
+	return public
 }
 
 // ResolvePaymentRequest resolves a payment request for the partner flow
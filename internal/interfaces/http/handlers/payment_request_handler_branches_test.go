@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/usecases"
 )
 
@@ -54,7 +57,7 @@ func TestPaymentRequestHandler_ExtraBranches(t *testing.T) {
 			}
 			return nil, nil, domainerrors.ErrNotFound
 		},
-		listFn: func(_ context.Context, _ uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error) {
+		listFn: func(_ context.Context, _ uuid.UUID, _ repositories.PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error) {
 			gotListLimit = limit
 			gotListOffset = offset
 			return []*entities.PaymentRequest{}, 0, nil
@@ -81,7 +84,7 @@ func TestPaymentRequestHandler_ExtraBranches(t *testing.T) {
 	// Create bad payload bind error.
 	rWithUser := gin.New()
 	rWithUser.Use(func(c *gin.Context) {
-		c.Set("userID", userID)
+		c.Set(middleware.UserIDKey, userID)
 		c.Next()
 	})
 	rWithUser.POST("/payment-requests", h.CreatePaymentRequest)
@@ -144,3 +147,153 @@ func TestPaymentRequestHandler_ExtraBranches(t *testing.T) {
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+func TestPaymentRequestHandler_ListPaymentRequests_Filters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var gotFilter repositories.PaymentRequestFilter
+	h := NewPaymentRequestHandler(paymentRequestServiceStub{
+		listFn: func(_ context.Context, _ uuid.UUID, filter repositories.PaymentRequestFilter, _, _ int) ([]*entities.PaymentRequest, int, error) {
+			gotFilter = filter
+			return []*entities.PaymentRequest{}, 25, nil
+		},
+	})
+	r := gin.New()
+	r.GET("/payment-requests", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uuid.New())
+		c.Next()
+	}, h.ListPaymentRequests)
+
+	req := httptest.NewRequest(http.MethodGet, "/payment-requests?status=pending&from=2024-01-01&to=2024-01-31&sortBy=expiresAt&sortOrder=asc&page=2&limit=10", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, entities.PaymentRequestStatusPending, gotFilter.Status)
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *gotFilter.CreatedFrom)
+	require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), *gotFilter.CreatedTo)
+	require.Equal(t, repositories.PaymentRequestSortExpiresAt, gotFilter.SortBy)
+	require.True(t, gotFilter.Ascending)
+	require.Contains(t, w.Body.String(), `"meta":{"page":2,"limit":10,"totalCount":25,"totalPages":3}`)
+	require.Contains(t, w.Body.String(), `"pagination":{"limit":10,"page":2,"total":25,"totalPages":3}`)
+
+	for _, query := range []string{
+		"status=paid",
+		"from=yesterday",
+		"to=2024-13-01",
+		"from=2024-02-01&to=2024-01-01",
+		"sortBy=merchant_id",
+		"sortOrder=sideways",
+	} {
+		req = httptest.NewRequest(http.MethodGet, "/payment-requests?"+query, nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestPaymentRequestHandler_CreatePaymentRequestBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var gotInput usecases.CreatePaymentRequestBatchInput
+	result := &usecases.CreatePaymentRequestBatchOutput{}
+	var resultErr error
+	h := NewPaymentRequestHandler(paymentRequestServiceStub{
+		batchFn: func(_ context.Context, input usecases.CreatePaymentRequestBatchInput) (*usecases.CreatePaymentRequestBatchOutput, error) {
+			gotInput = input
+			return result, resultErr
+		},
+	})
+	userID := uuid.New()
+	r := gin.New()
+	r.POST("/payment-requests/batch", h.CreatePaymentRequestBatch)
+	rWithUser := gin.New()
+	rWithUser.POST("/payment-requests/batch", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, userID)
+		c.Next()
+	}, h.CreatePaymentRequestBatch)
+
+	post := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/payment-requests/batch", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	body := `{"allOrNothing":true,"items":[{"chainId":"eip155:8453","tokenAddress":"0xusdc","amount":"1"},{"chainId":"eip155:8453"}]}`
+
+	require.Equal(t, http.StatusUnauthorized, post(r, body).Code)
+	require.Equal(t, http.StatusBadRequest, post(rWithUser, `{"items":[]}`).Code)
+
+	items := make([]string, usecases.MaxPaymentRequestBatchSize+1)
+	for i := range items {
+		items[i] = `{}`
+	}
+	require.Equal(t, http.StatusBadRequest, post(rWithUser, `{"items":[`+strings.Join(items, ",")+`]}`).Code)
+
+	result = &usecases.CreatePaymentRequestBatchOutput{Created: 2}
+	require.Equal(t, http.StatusCreated, post(rWithUser, body).Code)
+	require.Equal(t, userID, gotInput.UserID)
+	require.True(t, gotInput.AllOrNothing)
+	require.Len(t, gotInput.Items, 2)
+	require.Equal(t, "0xusdc", gotInput.Items[0].TokenAddress)
+
+	result = &usecases.CreatePaymentRequestBatchOutput{Created: 1, Failed: 1}
+	require.Equal(t, http.StatusMultiStatus, post(rWithUser, body).Code)
+
+	result = &usecases.CreatePaymentRequestBatchOutput{Failed: 1}
+	require.Equal(t, http.StatusUnprocessableEntity, post(rWithUser, body).Code)
+
+	resultErr = domainerrors.NotFound("merchant not found")
+	require.Equal(t, http.StatusNotFound, post(rWithUser, body).Code)
+}
+
+func TestPaymentRequestHandler_CancelPaymentRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	requestID := uuid.New()
+	var cancelErr error
+	h := NewPaymentRequestHandler(paymentRequestServiceStub{
+		cancelFn: func(_ context.Context, gotUser, gotRequest uuid.UUID) (*entities.PaymentRequest, error) {
+			require.Equal(t, userID, gotUser)
+			require.Equal(t, requestID, gotRequest)
+			if cancelErr != nil {
+				return nil, cancelErr
+			}
+			return &entities.PaymentRequest{ID: requestID, Status: entities.PaymentRequestStatusCancelled}, nil
+		},
+		getFn: func(context.Context, uuid.UUID) (*entities.PaymentRequest, *entities.PaymentRequestTxData, error) {
+			return &entities.PaymentRequest{ID: requestID, Status: entities.PaymentRequestStatusCancelled}, nil, nil
+		},
+	})
+	r := gin.New()
+	r.POST("/payment-requests/:id/cancel", h.CancelPaymentRequest)
+	rWithUser := gin.New()
+	rWithUser.POST("/payment-requests/:id/cancel", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, userID)
+		c.Next()
+	}, h.CancelPaymentRequest)
+	rWithUser.GET("/pay/:id", h.GetPublicPaymentRequest)
+
+	serve := func(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	require.Equal(t, http.StatusUnauthorized, serve(r, http.MethodPost, "/payment-requests/"+requestID.String()+"/cancel").Code)
+	require.Equal(t, http.StatusBadRequest, serve(rWithUser, http.MethodPost, "/payment-requests/not-uuid/cancel").Code)
+
+	w := serve(rWithUser, http.MethodPost, "/payment-requests/"+requestID.String()+"/cancel")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"CANCELLED"`)
+
+	cancelErr = domainerrors.Conflict("payment request is COMPLETED and cannot be cancelled")
+	require.Equal(t, http.StatusConflict, serve(rWithUser, http.MethodPost, "/payment-requests/"+requestID.String()+"/cancel").Code)
+	cancelErr = domainerrors.Forbidden("payment request does not belong to merchant")
+	require.Equal(t, http.StatusForbidden, serve(rWithUser, http.MethodPost, "/payment-requests/"+requestID.String()+"/cancel").Code)
+
+	// The public view of a cancelled request shows the status but nothing payable.
+	w = serve(rWithUser, http.MethodGet, "/pay/"+requestID.String())
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"CANCELLED"`)
+	require.NotContains(t, w.Body.String(), "txData")
+	require.NotContains(t, w.Body.String(), "contractAddress")
+}
@@ -6,13 +6,17 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/usecases"
 )
 
@@ -20,20 +24,28 @@ type paymentRequestServiceStub struct {
 	createFn  func(ctx context.Context, input usecases.CreatePaymentRequestInput) (*usecases.CreatePaymentRequestOutput, error)
 	getFn     func(ctx context.Context, id uuid.UUID) (*entities.PaymentRequest, *entities.PaymentRequestTxData, error)
 	resolveFn func(ctx context.Context, id uuid.UUID) (*usecases.ResolvePaymentRequestOutput, error)
-	listFn    func(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error)
+	listFn    func(ctx context.Context, userID uuid.UUID, filter repositories.PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error)
+	cancelFn  func(ctx context.Context, userID, requestID uuid.UUID) (*entities.PaymentRequest, error)
+	batchFn   func(ctx context.Context, input usecases.CreatePaymentRequestBatchInput) (*usecases.CreatePaymentRequestBatchOutput, error)
 }
 
 func (s paymentRequestServiceStub) CreatePaymentRequest(ctx context.Context, input usecases.CreatePaymentRequestInput) (*usecases.CreatePaymentRequestOutput, error) {
 	return s.createFn(ctx, input)
 }
+func (s paymentRequestServiceStub) CreatePaymentRequestBatch(ctx context.Context, input usecases.CreatePaymentRequestBatchInput) (*usecases.CreatePaymentRequestBatchOutput, error) {
+	return s.batchFn(ctx, input)
+}
+func (s paymentRequestServiceStub) CancelPaymentRequest(ctx context.Context, userID, requestID uuid.UUID) (*entities.PaymentRequest, error) {
+	return s.cancelFn(ctx, userID, requestID)
+}
 func (s paymentRequestServiceStub) GetPaymentRequest(ctx context.Context, id uuid.UUID) (*entities.PaymentRequest, *entities.PaymentRequestTxData, error) {
 	return s.getFn(ctx, id)
 }
 func (s paymentRequestServiceStub) ResolvePaymentRequest(ctx context.Context, id uuid.UUID) (*usecases.ResolvePaymentRequestOutput, error) {
 	return s.resolveFn(ctx, id)
 }
-func (s paymentRequestServiceStub) ListPaymentRequests(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error) {
-	return s.listFn(ctx, userID, limit, offset)
+func (s paymentRequestServiceStub) ListPaymentRequests(ctx context.Context, userID uuid.UUID, filter repositories.PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error) {
+	return s.listFn(ctx, userID, filter, limit, offset)
 }
 
 func TestPaymentRequestHandler_SuccessAndErrorMappings(t *testing.T) {
@@ -79,7 +91,7 @@ func TestPaymentRequestHandler_SuccessAndErrorMappings(t *testing.T) {
 					Hex:             "0xabc",
 				}, nil
 		},
-		listFn: func(_ context.Context, userID uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error) {
+		listFn: func(_ context.Context, userID uuid.UUID, _ repositories.PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error) {
 			if offset > 0 {
 				return nil, 0, errors.New("list boom")
 			}
@@ -100,7 +112,7 @@ func TestPaymentRequestHandler_SuccessAndErrorMappings(t *testing.T) {
 	h := NewPaymentRequestHandler(service)
 	r := gin.New()
 	withUser := func(c *gin.Context) {
-		c.Set("userID", userID)
+		c.Set(middleware.UserIDKey, userID)
 		c.Next()
 	}
 	r.POST("/payment-requests", withUser, h.CreatePaymentRequest)
@@ -176,3 +188,58 @@ func TestPaymentRequestHandler_SuccessAndErrorMappings(t *testing.T) {
 		t.Fatalf("expected 404, got %d body=%s", w.Code, w.Body.String())
 	}
 }
+
+func TestPaymentRequestHandler_PublicMetadataWhitelist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	requestID := uuid.New()
+	var created usecases.CreatePaymentRequestInput
+	service := paymentRequestServiceStub{
+		createFn: func(_ context.Context, input usecases.CreatePaymentRequestInput) (*usecases.CreatePaymentRequestOutput, error) {
+			created = input
+			return &usecases.CreatePaymentRequestOutput{RequestID: requestID.String()}, nil
+		},
+		getFn: func(context.Context, uuid.UUID) (*entities.PaymentRequest, *entities.PaymentRequestTxData, error) {
+			return &entities.PaymentRequest{
+				ID:       requestID,
+				Status:   entities.PaymentRequestStatusPending,
+				Metadata: null.JSONFrom([]byte(`{"orderLabel":"Order #7","customerId":"cus_123"}`)),
+			}, nil, nil
+		},
+	}
+	withUser := func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, uuid.New())
+		c.Next()
+	}
+
+	r := gin.New()
+	r.POST("/payment-requests", withUser, NewPaymentRequestHandler(service).CreatePaymentRequest)
+	r.GET("/pay/:id", NewPaymentRequestHandler(service).GetPublicPaymentRequest)
+	r.GET("/whitelisted/pay/:id", NewPaymentRequestHandlerWithPublicMetadata(service, []string{"orderLabel"}).GetPublicPaymentRequest)
+
+	body := []byte(`{"chainId":"eip155:8453","tokenAddress":"0xusdc","amount":"1","decimals":6,"metadata":{"customerId":"cus_123"}}`)
+	req := httptest.NewRequest(http.MethodPost, "/payment-requests", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d body=%s", w.Code, w.Body.String())
+	}
+	if string(created.Metadata) != `{"customerId":"cus_123"}` {
+		t.Fatalf("metadata not passed through: %s", created.Metadata)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pay/"+requestID.String(), nil))
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "metadata") {
+		t.Fatalf("metadata must be hidden by default, got %d body=%s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/whitelisted/pay/"+requestID.String(), nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"metadata":{"orderLabel":"Order #7"}`) || strings.Contains(w.Body.String(), "cus_123") {
+		t.Fatalf("only whitelisted keys may be public, got %s", w.Body.String())
+	}
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
+	"payment-kita.backend/internal/domain/entities"
+)
+
+// CreatePaymentV2Response is the /api/v2 create-payment body. It reports the selected bridge
+// once, as bridge (null on same-chain payments), where v1 also sent bridgeType and an always
+// empty bridgeReason.
+type CreatePaymentV2Response struct {
+	PaymentID       uuid.UUID                `json:"paymentId"`
+	Status          entities.PaymentStatus   `json:"status"`
+	SourceChainID   string                   `json:"sourceChainId"`
+	DestChainID     string                   `json:"destChainId"`
+	SourceAmount    string                   `json:"sourceAmount"`
+	SourceDecimals  int                      `json:"sourceDecimals"`
+	ReceiverAddress string                   `json:"receiverAddress"`
+	ReceiverName    string                   `json:"receiverName,omitempty"`
+	ExternalRef     string                   `json:"externalRef,omitempty"`
+	Metadata        null.JSON                `json:"metadata,omitempty"`
+	DestAmount      string                   `json:"destAmount"`
+	DestDecimals    int                      `json:"destDecimals"`
+	FeeAmount       string                   `json:"feeAmount"`
+	FeeBreakdown    entities.FeeBreakdown    `json:"feeBreakdown"`
+	Bridge          *entities.SelectedBridge `json:"bridge"`
+	OnchainCost     *entities.OnchainCost    `json:"onchainCost,omitempty"`
+	ExpiresAt       time.Time                `json:"expiresAt"`
+	SignatureData   interface{}              `json:"signatureData"`
+	Replayed        bool                     `json:"replayed,omitempty"`
+}
+
+func newCreatePaymentV2Response(r *entities.CreatePaymentResponse) *CreatePaymentV2Response {
+	return &CreatePaymentV2Response{
+		PaymentID:       r.PaymentID,
+		Status:          r.Status,
+		SourceChainID:   r.SourceChainID,
+		DestChainID:     r.DestChainID,
+		SourceAmount:    r.SourceAmount,
+		SourceDecimals:  r.SourceDecimals,
+		ReceiverAddress: r.ReceiverAddress,
+		ReceiverName:    r.ReceiverName,
+		ExternalRef:     r.ExternalRef,
+		Metadata:        r.Metadata,
+		DestAmount:      r.DestAmount,
+		DestDecimals:    r.DestDecimals,
+		FeeAmount:       r.FeeAmount,
+		FeeBreakdown:    r.FeeBreakdown,
+		Bridge:          r.Bridge,
+		OnchainCost:     r.OnchainCost,
+		ExpiresAt:       r.ExpiresAt,
+		SignatureData:   r.SignatureData,
+		Replayed:        r.Replayed,
+	}
+}
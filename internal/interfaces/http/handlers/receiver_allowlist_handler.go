@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/internal/interfaces/http/response"
+)
+
+type ReceiverAllowlistService interface {
+	MerchantIDForUser(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
+	List(ctx context.Context, merchantID uuid.UUID) ([]*entities.MerchantAllowedReceiver, error)
+	Add(ctx context.Context, merchantID uuid.UUID, address, label string, createdBy *uuid.UUID) (*entities.MerchantAllowedReceiver, error)
+	Remove(ctx context.Context, merchantID, id uuid.UUID) error
+}
+
+// ReceiverAllowlistHandler manages merchants' receiver allow-lists, for the merchant itself
+// and for admins. Merchants can only change their list from a user session, never with an API
+// key, so a leaked key cannot allow its own address.
+type ReceiverAllowlistHandler struct {
+	service ReceiverAllowlistService
+}
+
+func NewReceiverAllowlistHandler(service ReceiverAllowlistService) *ReceiverAllowlistHandler {
+	return &ReceiverAllowlistHandler{service: service}
+}
+
+type addAllowedReceiverRequest struct {
+	Address string `json:"address" binding:"required"`
+	Label   string `json:"label"`
+}
+
+// ListMyAllowedReceivers lists the caller's allowed receivers
+// GET /api/v1/merchants/allowed-receivers
+func (h *ReceiverAllowlistHandler) ListMyAllowedReceivers(c *gin.Context) {
+	merchantID, ok := h.callerMerchantID(c, false)
+	if !ok {
+		return
+	}
+	h.list(c, merchantID)
+}
+
+// AddMyAllowedReceiver adds an address to the caller's allow-list
+// POST /api/v1/merchants/allowed-receivers
+func (h *ReceiverAllowlistHandler) AddMyAllowedReceiver(c *gin.Context) {
+	merchantID, ok := h.callerMerchantID(c, true)
+	if !ok {
+		return
+	}
+	h.add(c, merchantID)
+}
+
+// RemoveMyAllowedReceiver removes an entry from the caller's allow-list
+// DELETE /api/v1/merchants/allowed-receivers/:receiverId
+func (h *ReceiverAllowlistHandler) RemoveMyAllowedReceiver(c *gin.Context) {
+	merchantID, ok := h.callerMerchantID(c, true)
+	if !ok {
+		return
+	}
+	h.remove(c, merchantID)
+}
+
+// ListAllowedReceivers lists a merchant's allowed receivers
+// GET /api/v1/admin/merchants/:id/allowed-receivers
+func (h *ReceiverAllowlistHandler) ListAllowedReceivers(c *gin.Context) {
+	merchantID, ok := parseMerchantIDParam(c)
+	if !ok {
+		return
+	}
+	h.list(c, merchantID)
+}
+
+// AddAllowedReceiver adds an address to a merchant's allow-list
+// POST /api/v1/admin/merchants/:id/allowed-receivers
+func (h *ReceiverAllowlistHandler) AddAllowedReceiver(c *gin.Context) {
+	merchantID, ok := parseMerchantIDParam(c)
+	if !ok {
+		return
+	}
+	h.add(c, merchantID)
+}
+
+// RemoveAllowedReceiver removes an entry from a merchant's allow-list
+// DELETE /api/v1/admin/merchants/:id/allowed-receivers/:receiverId
+func (h *ReceiverAllowlistHandler) RemoveAllowedReceiver(c *gin.Context) {
+	merchantID, ok := parseMerchantIDParam(c)
+	if !ok {
+		return
+	}
+	h.remove(c, merchantID)
+}
+
+func (h *ReceiverAllowlistHandler) list(c *gin.Context, merchantID uuid.UUID) {
+	items, err := h.service.List(c.Request.Context(), merchantID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, gin.H{
+		"merchantId": merchantID,
+		"enforced":   len(items) > 0,
+		"items":      items,
+	})
+}
+
+func (h *ReceiverAllowlistHandler) add(c *gin.Context, merchantID uuid.UUID) {
+	var req addAllowedReceiverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+	var createdBy *uuid.UUID
+	if userID, ok := middleware.GetUserID(c); ok {
+		createdBy = &userID
+	}
+	receiver, err := h.service.Add(c.Request.Context(), merchantID, req.Address, req.Label, createdBy)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusCreated, receiver)
+}
+
+func (h *ReceiverAllowlistHandler) remove(c *gin.Context, merchantID uuid.UUID) {
+	id, err := uuid.Parse(c.Param("receiverId"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("invalid receiver ID"))
+		return
+	}
+	if err := h.service.Remove(c.Request.Context(), merchantID, id); err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, gin.H{"message": "Allowed receiver removed"})
+}
+
+// callerMerchantID resolves the caller's merchant. Changes are refused for API-key requests.
+func (h *ReceiverAllowlistHandler) callerMerchantID(c *gin.Context, modifying bool) (uuid.UUID, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Error(c, domainerrors.Unauthorized("User not authenticated"))
+		return uuid.Nil, false
+	}
+	if modifying && c.GetBool(middleware.APIKeyAuthKey) {
+		response.Error(c, domainerrors.Forbidden("the receiver allow-list cannot be changed with an API key"))
+		return uuid.Nil, false
+	}
+	merchantID, err := h.service.MerchantIDForUser(c.Request.Context(), userID)
+	if err != nil {
+		response.Error(c, err)
+		return uuid.Nil, false
+	}
+	return merchantID, true
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+)
+
+type receiverAllowlistServiceStub struct {
+	merchantID uuid.UUID
+	items      []*entities.MerchantAllowedReceiver
+	added      *entities.MerchantAllowedReceiver
+}
+
+func (s *receiverAllowlistServiceStub) MerchantIDForUser(context.Context, uuid.UUID) (uuid.UUID, error) {
+	return s.merchantID, nil
+}
+
+func (s *receiverAllowlistServiceStub) List(context.Context, uuid.UUID) ([]*entities.MerchantAllowedReceiver, error) {
+	return s.items, nil
+}
+
+func (s *receiverAllowlistServiceStub) Add(_ context.Context, merchantID uuid.UUID, address, label string, createdBy *uuid.UUID) (*entities.MerchantAllowedReceiver, error) {
+	s.added = &entities.MerchantAllowedReceiver{ID: uuid.New(), MerchantID: merchantID, Address: address, Label: label, CreatedBy: createdBy}
+	return s.added, nil
+}
+
+func (s *receiverAllowlistServiceStub) Remove(_ context.Context, _, id uuid.UUID) error {
+	if s.added == nil || s.added.ID != id {
+		return domainerrors.NotFound("allowed receiver not found")
+	}
+	return nil
+}
+
+func TestReceiverAllowlistHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	merchantID, userID := uuid.New(), uuid.New()
+	svc := &receiverAllowlistServiceStub{merchantID: merchantID}
+	h := NewReceiverAllowlistHandler(svc)
+
+	apiKey := false
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, userID)
+		if apiKey {
+			c.Set(middleware.APIKeyAuthKey, true)
+		}
+		c.Next()
+	})
+	r.GET("/merchants/allowed-receivers", h.ListMyAllowedReceivers)
+	r.POST("/merchants/allowed-receivers", h.AddMyAllowedReceiver)
+	r.DELETE("/merchants/allowed-receivers/:receiverId", h.RemoveMyAllowedReceiver)
+	r.GET("/admin/merchants/:id/allowed-receivers", h.ListAllowedReceivers)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, bytes.NewBufferString(body)))
+		return w
+	}
+
+	w := do(http.MethodGet, "/merchants/allowed-receivers", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"enforced":false`)
+
+	w = do(http.MethodPost, "/merchants/allowed-receivers", `{"address":"0x1111111111111111111111111111111111111111","label":"treasury"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, merchantID, svc.added.MerchantID)
+	require.Equal(t, userID, *svc.added.CreatedBy)
+
+	w = do(http.MethodPost, "/merchants/allowed-receivers", `{}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// A leaked API key can read the list but not change it
+	apiKey = true
+	w = do(http.MethodGet, "/merchants/allowed-receivers", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = do(http.MethodPost, "/merchants/allowed-receivers", `{"address":"0x2222222222222222222222222222222222222222"}`)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	w = do(http.MethodDelete, "/merchants/allowed-receivers/"+svc.added.ID.String(), "")
+	require.Equal(t, http.StatusForbidden, w.Code)
+	apiKey = false
+
+	w = do(http.MethodDelete, "/merchants/allowed-receivers/"+svc.added.ID.String(), "")
+	require.Equal(t, http.StatusOK, w.Code)
+	w = do(http.MethodDelete, "/merchants/allowed-receivers/not-a-uuid", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	svc.items = []*entities.MerchantAllowedReceiver{svc.added}
+	w = do(http.MethodGet, "/admin/merchants/"+merchantID.String()+"/allowed-receivers", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"enforced":true`)
+}
@@ -31,9 +31,13 @@ func (s *rpcChainRepoStub) GetAll(context.Context) ([]*entities.Chain, error) {
 func (s *rpcChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *rpcChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *rpcChainRepoStub) Create(context.Context, *entities.Chain) error { return nil }
 func (s *rpcChainRepoStub) Update(context.Context, *entities.Chain) error { return nil }
 func (s *rpcChainRepoStub) Delete(context.Context, uuid.UUID) error       { return nil }
+func (s *rpcChainRepoStub) HardDelete(context.Context, uuid.UUID) error   { return nil }
 func (s *rpcChainRepoStub) GetAllRPCs(ctx context.Context, chainID *uuid.UUID, isActive *bool, search *string, pagination utils.PaginationParams) ([]*entities.ChainRPC, int64, error) {
 	return s.getAllRPCsFn(ctx, chainID, isActive, search, pagination)
 }
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/internal/usecases"
+)
+
+type RPCPingService interface {
+	PingRPC(ctx context.Context, input usecases.PingRPCInput) (*usecases.RPCPingResult, error)
+}
+
+// RPCPingHandler lets admins try an RPC URL before saving it
+type RPCPingHandler struct {
+	service RPCPingService
+}
+
+func NewRPCPingHandler(service RPCPingService) *RPCPingHandler {
+	return &RPCPingHandler{service: service}
+}
+
+// PingRPC reports the chain ID, latest block and latency of an RPC URL, or why it failed.
+// Nothing is saved.
+// POST /api/v1/admin/chains/ping-rpc
+func (h *RPCPingHandler) PingRPC(c *gin.Context) {
+	var input struct {
+		URL       string `json:"url" binding:"required"`
+		ChainType string `json:"chainType"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.service.PingRPC(c.Request.Context(), usecases.PingRPCInput{
+		URL:       input.URL,
+		ChainType: entities.ChainType(input.ChainType),
+	})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, result)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/usecases"
+)
+
+type rpcPingServiceStub struct {
+	input usecases.PingRPCInput
+}
+
+func (s *rpcPingServiceStub) PingRPC(_ context.Context, input usecases.PingRPCInput) (*usecases.RPCPingResult, error) {
+	s.input = input
+	if input.URL == "bad" {
+		return nil, domainerrors.BadRequest("url must be an absolute http(s) or ws(s) URL")
+	}
+	return &usecases.RPCPingResult{URL: input.URL, ChainType: entities.ChainTypeEVM, Reachable: true, ChainID: "8453", CAIP2: "eip155:8453", LatestBlock: 42, LatencyMs: 12}, nil
+}
+
+func TestRPCPingHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := &rpcPingServiceStub{}
+	r := gin.New()
+	r.POST("/chains/ping-rpc", NewRPCPingHandler(service).PingRPC)
+	do := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/chains/ping-rpc", bytes.NewBufferString(body)))
+		return w
+	}
+
+	w := do(`{"url":"https://mainnet.base.org","chainType":"EVM"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"url":"https://mainnet.base.org","chainType":"EVM","reachable":true,"chainId":"8453","caip2":"eip155:8453","latestBlock":42,"latencyMs":12}`, w.Body.String())
+	require.Equal(t, entities.ChainTypeEVM, service.input.ChainType)
+
+	require.Equal(t, http.StatusBadRequest, do(`{}`).Code)
+	require.Equal(t, http.StatusBadRequest, do(`{"url":"bad"}`).Code)
+}
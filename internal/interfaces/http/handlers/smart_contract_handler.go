@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,21 +14,47 @@ import (
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/utils"
 )
 
+// ContractCacheInvalidator drops state derived from contract records, such as the vault each
+// gateway reports
+type ContractCacheInvalidator interface {
+	InvalidateVaultAddresses(ctx context.Context)
+}
+
 // SmartContractHandler handles smart contract endpoints
 type SmartContractHandler struct {
 	repo      repositories.SmartContractRepository
 	chainRepo repositories.ChainRepository
+	// caches is told about every contract write; nil when nothing caches contract state
+	caches      ContractCacheInvalidator
+	abiImporter *usecases.ContractABIImporter
 }
 
 // NewSmartContractHandler creates a new smart contract handler
 func NewSmartContractHandler(repo repositories.SmartContractRepository, chainRepo repositories.ChainRepository) *SmartContractHandler {
 	return &SmartContractHandler{
-		repo:      repo,
-		chainRepo: chainRepo,
+		repo:        repo,
+		chainRepo:   chainRepo,
+		abiImporter: usecases.NewContractABIImporter(),
+	}
+}
+
+// NewSmartContractHandlerWithCache is NewSmartContractHandler invalidating caches after every
+// contract create, update, (de)activation or delete
+func NewSmartContractHandlerWithCache(repo repositories.SmartContractRepository, chainRepo repositories.ChainRepository, caches ContractCacheInvalidator) *SmartContractHandler {
+	h := NewSmartContractHandler(repo, chainRepo)
+	h.caches = caches
+	return h
+}
+
+func (h *SmartContractHandler) contractsChanged(c *gin.Context) {
+	if h.caches != nil {
+		h.caches.InvalidateVaultAddresses(c.Request.Context())
 	}
 }
 
@@ -40,22 +68,26 @@ func (h *SmartContractHandler) CreateSmartContract(c *gin.Context) {
 		return
 	}
 
-	chainUUID, err := uuid.Parse(input.ChainID)
+	chain, err := usecases.NewChainResolver(h.chainRepo).ResolveChain(c.Request.Context(), input.ChainID)
 	if err != nil {
-		// Try lookup by legacy blockchain ID
-		chain, err := h.chainRepo.GetByChainID(c.Request.Context(), input.ChainID)
-		if err != nil {
-			response.Error(c, domainerrors.BadRequest("Invalid chain ID"))
+		response.Error(c, domainerrors.BadRequest("Invalid chain ID"))
+		return
+	}
+	for _, address := range []string{input.ContractAddress, input.DeployerAddress} {
+		if address == "" {
+			continue
+		}
+		if err := usecases.ValidateAddressForChain(chain, address); err != nil {
+			response.Error(c, err)
 			return
 		}
-		chainUUID = chain.ID
 	}
 
 	contract := &entities.SmartContract{
 		Name:            input.Name,
 		Type:            input.Type,
 		Version:         input.Version,
-		ChainUUID:       chainUUID,
+		ChainUUID:       chain.ID,
 		ContractAddress: input.ContractAddress,
 		DeployerAddress: null.NewString(input.DeployerAddress, input.DeployerAddress != ""),
 		Token0Address:   null.NewString(input.Token0Address, input.Token0Address != ""),
@@ -77,9 +109,10 @@ func (h *SmartContractHandler) CreateSmartContract(c *gin.Context) {
 	}
 
 	if err := h.repo.Create(c.Request.Context(), contract); err != nil {
-		response.Error(c, err)
+		response.Error(c, contractWriteError(err))
 		return
 	}
+	h.contractsChanged(c)
 
 	response.Success(c, http.StatusCreated, gin.H{
 		"contract": contract,
@@ -109,8 +142,10 @@ func (h *SmartContractHandler) GetSmartContract(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"contract": contract})
 }
 
-// ListSmartContracts lists all smart contracts
+// ListSmartContracts lists active smart contracts; admins may pass includeInactive=true, or
+// isActive=true|false for one state only. search matches name or address.
 // GET /api/v1/contracts
+// GET /api/v1/admin/contracts
 func (h *SmartContractHandler) ListSmartContracts(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
@@ -132,16 +167,22 @@ func (h *SmartContractHandler) ListSmartContracts(c *gin.Context) {
 		}
 	}
 
-	var contracts []*entities.SmartContract
-	var totalCount int64
-	var err error
-	contracts, totalCount, err = h.repo.GetFiltered(
-		c.Request.Context(),
-		chainUUID,
-		entities.SmartContractType(typeStr),
-		pagination,
-	)
+	filter := repositories.ActiveContracts(chainUUID)
+	filter.Type = entities.SmartContractType(typeStr)
+	filter.Search = strings.TrimSpace(c.Query("search"))
+	if includeInactiveRequested(c) {
+		filter.IsActive = nil
+	}
+	if raw := strings.TrimSpace(c.Query("isActive")); raw != "" && middleware.CanReadAdmin(c) {
+		isActive, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.Error(c, domainerrors.BadRequest("isActive must be true or false"))
+			return
+		}
+		filter.IsActive = &isActive
+	}
 
+	contracts, totalCount, err := h.repo.GetFiltered(c.Request.Context(), filter, pagination)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -189,6 +230,62 @@ func (h *SmartContractHandler) GetContractByChainAndAddress(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"contract": contract})
 }
 
+// BulkActivateSmartContracts sets the active flag on many contracts in one transaction
+// POST /api/v1/admin/contracts/bulk-activate
+func (h *SmartContractHandler) BulkActivateSmartContracts(c *gin.Context) {
+	ids, active, ok := bindBulkActivateRequest(c)
+	if !ok {
+		return
+	}
+
+	previous, err := h.repo.BulkSetActive(c.Request.Context(), ids, active)
+	if err != nil {
+		response.Error(c, contractWriteError(err))
+		return
+	}
+	h.contractsChanged(c)
+
+	results, summary := bulkActivateResults(ids, previous, active)
+	response.Success(c, http.StatusOK, gin.H{
+		"items":   results,
+		"summary": summary,
+	})
+}
+
+// ActivateSmartContract makes a contract the active one for its chain and type
+// POST /api/v1/admin/contracts/:id/activate
+func (h *SmartContractHandler) ActivateSmartContract(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid contract ID"))
+		return
+	}
+
+	contract, err := h.repo.Activate(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			response.Error(c, domainerrors.NotFound("Contract not found"))
+			return
+		}
+		response.Error(c, contractWriteError(err))
+		return
+	}
+	h.contractsChanged(c)
+
+	response.Success(c, http.StatusOK, gin.H{"contract": contract})
+}
+
+// contractWriteError maps repository conflicts (duplicate address, or a second active
+// contract for the same chain and type) to 409
+func contractWriteError(err error) error {
+	if errors.Is(err, domainerrors.ErrAlreadyExists) {
+		conflict := domainerrors.Conflict("Contract conflicts with an existing contract")
+		conflict.Err = err // keeps the existing contract's ID for the response
+		return conflict
+	}
+	return err
+}
+
 // DeleteSmartContract soft deletes a smart contract
 // DELETE /api/v1/contracts/:id
 func (h *SmartContractHandler) DeleteSmartContract(c *gin.Context) {
@@ -207,6 +304,7 @@ func (h *SmartContractHandler) DeleteSmartContract(c *gin.Context) {
 		response.Error(c, err)
 		return
 	}
+	h.contractsChanged(c)
 
 	response.Success(c, http.StatusOK, gin.H{"message": "Contract deleted successfully"})
 }
@@ -276,6 +374,13 @@ func (h *SmartContractHandler) UpdateSmartContract(c *gin.Context) {
 		contract.StartBlock = *input.StartBlock
 	}
 	if input.ABI != nil {
+		if len(contract.IndexedEvents) > 0 {
+			if _, err := usecases.ResolveIndexedEvents(input.ABI, contract.IndexedEvents); err != nil {
+				// The indexer would otherwise keep following events the new ABI no longer has
+				response.Error(c, err)
+				return
+			}
+		}
 		contract.ABI = input.ABI
 	}
 	if input.IsActive != nil {
@@ -288,9 +393,211 @@ func (h *SmartContractHandler) UpdateSmartContract(c *gin.Context) {
 	}
 
 	if err := h.repo.Update(c.Request.Context(), contract); err != nil {
-		response.Error(c, err)
+		response.Error(c, contractWriteError(err))
 		return
 	}
+	h.contractsChanged(c)
 
 	response.Success(c, http.StatusOK, gin.H{"message": "Contract updated", "contract": contract})
 }
+
+// ImportContractABI fetches a contract's verified ABI from the chain's block explorer for review,
+// and stores it on the contract when save is set
+// POST /api/v1/admin/contracts/import-abi
+func (h *SmartContractHandler) ImportContractABI(c *gin.Context) {
+	var input struct {
+		ChainID    string                     `json:"chainId"`
+		Address    string                     `json:"address"`
+		Type       entities.SmartContractType `json:"type"`
+		ContractID string                     `json:"contractId"` // Defaults chainId, address and type from the contract
+		Save       bool                       `json:"save"`       // Store the ABI on contractId; refused unless complete
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+	ctx := c.Request.Context()
+
+	var contract *entities.SmartContract
+	if input.ContractID != "" {
+		id, err := uuid.Parse(input.ContractID)
+		if err != nil {
+			response.Error(c, domainerrors.BadRequest("Invalid contract ID"))
+			return
+		}
+		contract, err = h.repo.GetByID(ctx, id)
+		if err != nil {
+			response.Error(c, domainerrors.NotFound("Contract not found"))
+			return
+		}
+		if input.Address == "" {
+			input.Address = contract.ContractAddress
+		} else if !strings.EqualFold(input.Address, contract.ContractAddress) {
+			response.Error(c, domainerrors.BadRequest("address does not match the contract"))
+			return
+		}
+		if input.Type == "" {
+			input.Type = contract.Type
+		}
+	} else if input.Save {
+		response.Error(c, domainerrors.BadRequest("contractId is required to save the ABI"))
+		return
+	}
+	if input.Address == "" {
+		response.Error(c, domainerrors.BadRequest("address or contractId is required"))
+		return
+	}
+
+	var (
+		chain *entities.Chain
+		err   error
+	)
+	if input.ChainID != "" {
+		chain, err = usecases.NewChainResolver(h.chainRepo).ResolveChain(ctx, input.ChainID)
+		if err == nil && contract != nil && chain.ID != contract.ChainUUID {
+			response.Error(c, domainerrors.BadRequest("chainId does not match the contract"))
+			return
+		}
+	} else if contract != nil {
+		chain, err = h.chainRepo.GetByID(ctx, contract.ChainUUID)
+	} else {
+		response.Error(c, domainerrors.BadRequest("chainId or contractId is required"))
+		return
+	}
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid chain ID"))
+		return
+	}
+
+	imported, err := h.abiImporter.ImportABI(ctx, chain, input.Address, input.Type)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	if input.Save {
+		if !imported.Complete() {
+			response.Error(c, domainerrors.NewAppError(http.StatusUnprocessableEntity, domainerrors.CodeABIIncomplete,
+				"required functions missing from ABI: "+strings.Join(imported.MissingFunctions, ", "), domainerrors.ErrABIIncomplete))
+			return
+		}
+		contract.ABI = imported.ABI
+		if err := h.repo.Update(ctx, contract); err != nil {
+			response.Error(c, contractWriteError(err))
+			return
+		}
+		h.contractsChanged(c)
+	}
+
+	response.Success(c, http.StatusOK, gin.H{
+		"import": imported,
+		"saved":  input.Save,
+	})
+}
+
+// contractIndexingConfig is what the external indexer needs to follow one contract
+type contractIndexingConfig struct {
+	ContractID uuid.UUID                       `json:"contractId"`
+	Name       string                          `json:"name"`
+	Type       entities.SmartContractType      `json:"type"`
+	ChainID    string                          `json:"chainId"` // CAIP-2
+	Address    string                          `json:"address"`
+	StartBlock uint64                          `json:"startBlock"`
+	Events     []usecases.ContractIndexedEvent `json:"events"`
+}
+
+func newContractIndexingConfig(contract *entities.SmartContract, caip2 string) contractIndexingConfig {
+	return contractIndexingConfig{
+		ContractID: contract.ID,
+		Name:       contract.Name,
+		Type:       contract.Type,
+		ChainID:    caip2,
+		Address:    contract.ContractAddress,
+		StartBlock: contract.StartBlock,
+		Events:     usecases.DescribeIndexedEvents(contract.IndexedEvents),
+	}
+}
+
+// UpdateContractIndexing sets the events the external indexer follows on a contract, and
+// optionally where it starts
+// PUT /api/v1/admin/contracts/:id/indexing
+func (h *SmartContractHandler) UpdateContractIndexing(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid contract ID"))
+		return
+	}
+	var input struct {
+		Events     []string `json:"events" binding:"required"` // Names or signatures; [] stops indexing
+		StartBlock *uint64  `json:"startBlock"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+
+	ctx := c.Request.Context()
+	contract, err := h.repo.GetByID(ctx, id)
+	if err != nil {
+		response.Error(c, domainerrors.NotFound("Contract not found"))
+		return
+	}
+	signatures, err := usecases.ResolveIndexedEvents(contract.ABI, input.Events)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	contract.IndexedEvents = signatures
+	if input.StartBlock != nil {
+		contract.StartBlock = *input.StartBlock
+	}
+	if err := h.repo.Update(ctx, contract); err != nil {
+		response.Error(c, contractWriteError(err))
+		return
+	}
+	h.contractsChanged(c)
+
+	caip2 := ""
+	if chain, err := h.chainRepo.GetByID(ctx, contract.ChainUUID); err == nil {
+		caip2 = chain.GetCAIP2ID()
+	}
+	response.Success(c, http.StatusOK, gin.H{"indexing": newContractIndexingConfig(contract, caip2)})
+}
+
+// ListContractIndexing lists every active contract with indexed events, for the external
+// indexer. chainId narrows it to one chain.
+// GET /api/v1/indexer/contracts (service token) · GET /api/v1/admin/contracts/indexing
+func (h *SmartContractHandler) ListContractIndexing(c *gin.Context) {
+	ctx := c.Request.Context()
+	var chainUUID *uuid.UUID
+	if raw := strings.TrimSpace(c.Query("chainId")); raw != "" {
+		chain, err := usecases.NewChainResolver(h.chainRepo).ResolveChain(ctx, raw)
+		if err != nil {
+			response.Error(c, domainerrors.BadRequest("Invalid chain ID"))
+			return
+		}
+		chainUUID = &chain.ID
+	}
+
+	filter := repositories.ActiveContracts(chainUUID)
+	filter.Indexed = true
+	contracts, _, err := h.repo.GetFiltered(ctx, filter, utils.PaginationParams{})
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	caip2ByChain := make(map[uuid.UUID]string)
+	items := make([]contractIndexingConfig, 0, len(contracts))
+	for _, contract := range contracts {
+		caip2, ok := caip2ByChain[contract.ChainUUID]
+		if !ok {
+			if chain, err := h.chainRepo.GetByID(ctx, contract.ChainUUID); err == nil {
+				caip2 = chain.GetCAIP2ID()
+			}
+			caip2ByChain[contract.ChainUUID] = caip2
+		}
+		items = append(items, newContractIndexingConfig(contract, caip2))
+	}
+	response.Success(c, http.StatusOK, gin.H{"items": items})
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -48,8 +50,8 @@ func TestSmartContractHandler_ListAndLookup_AdditionalErrorBranches(t *testing.T
 	chainUUID := uuid.New()
 
 	repo := &smartContractRepoStub{
-		getFilteredFn: func(_ context.Context, chainID *uuid.UUID, _ entities.SmartContractType, _ utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
-			require.Nil(t, chainID, "invalid chainId that cannot be resolved should keep chain filter nil")
+		getFilteredFn: func(_ context.Context, filter repositories.SmartContractFilter, _ utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+			require.Nil(t, filter.ChainID, "invalid chainId that cannot be resolved should keep chain filter nil")
 			return []*entities.SmartContract{}, 0, nil
 		},
 		getByChainAddressFn: func(context.Context, uuid.UUID, string) (*entities.SmartContract, error) {
@@ -86,10 +88,10 @@ func TestSmartContractHandler_ListSmartContracts_UUIDChainFilterAndEmptyType(t *
 	chainUUID := uuid.New()
 
 	repo := &smartContractRepoStub{
-		getFilteredFn: func(_ context.Context, chainID *uuid.UUID, contractType entities.SmartContractType, _ utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
-			require.NotNil(t, chainID)
-			require.Equal(t, chainUUID, *chainID)
-			require.Equal(t, entities.SmartContractType(""), contractType)
+		getFilteredFn: func(_ context.Context, filter repositories.SmartContractFilter, _ utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+			require.NotNil(t, filter.ChainID)
+			require.Equal(t, chainUUID, *filter.ChainID)
+			require.Equal(t, entities.SmartContractType(""), filter.Type)
 			return []*entities.SmartContract{}, 0, nil
 		},
 	}
@@ -102,3 +104,41 @@ func TestSmartContractHandler_ListSmartContracts_UUIDChainFilterAndEmptyType(t *
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestSmartContractHandler_ListSmartContracts_SearchAndActiveFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var got repositories.SmartContractFilter
+	repo := &smartContractRepoStub{
+		getFilteredFn: func(_ context.Context, filter repositories.SmartContractFilter, _ utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+			got = filter
+			return []*entities.SmartContract{}, 0, nil
+		},
+	}
+	h := NewSmartContractHandler(repo, &smartContractChainRepoStub{})
+	r := gin.New()
+	r.GET("/contracts", h.ListSmartContracts)
+	admin := r.Group("/admin", func(c *gin.Context) {
+		c.Set(middleware.UserRoleKey, string(entities.UserRoleAdmin))
+	})
+	admin.GET("/contracts", h.ListSmartContracts)
+	get := func(target string) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w.Code
+	}
+
+	// Public callers only see active contracts, whatever they ask for
+	require.Equal(t, http.StatusOK, get("/contracts?search=%20gateway%20&isActive=false"))
+	require.Equal(t, "gateway", got.Search)
+	require.NotNil(t, got.IsActive)
+	require.True(t, *got.IsActive)
+
+	require.Equal(t, http.StatusOK, get("/admin/contracts?includeInactive=true"))
+	require.Nil(t, got.IsActive)
+
+	require.Equal(t, http.StatusOK, get("/admin/contracts?isActive=false"))
+	require.NotNil(t, got.IsActive)
+	require.False(t, *got.IsActive)
+
+	require.Equal(t, http.StatusBadRequest, get("/admin/contracts?isActive=maybe"))
+}
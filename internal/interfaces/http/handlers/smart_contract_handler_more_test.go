@@ -154,7 +154,11 @@ func TestSmartContractHandler_Create_WithOptionalFieldsAndUUIDChain(t *testing.T
 			return nil
 		},
 	}
-	h := NewSmartContractHandler(repo, &smartContractChainRepoStub{})
+	h := NewSmartContractHandler(repo, &smartContractChainRepoStub{
+		getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.Chain, error) {
+			return &entities.Chain{ID: id, ChainID: "8453", Type: entities.ChainTypeEVM}, nil
+		},
+	})
 	r := gin.New()
 	r.POST("/contracts", h.CreateSmartContract)
 
@@ -163,8 +167,8 @@ func TestSmartContractHandler_Create_WithOptionalFieldsAndUUIDChain(t *testing.T
 		"type":"ROUTER",
 		"version":"2.0.0",
 		"chainId":"` + chainUUID.String() + `",
-		"contractAddress":"0xrouter",
-		"deployerAddress":"0xdeployer",
+		"contractAddress":"0x7000000000000000000000000000000000000001",
+		"deployerAddress":"0xde00000000000000000000000000000000000001",
 		"token0Address":"0xt0",
 		"token1Address":"0xt1",
 		"feeTier":500,
@@ -13,16 +13,19 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
 type smartContractRepoStub struct {
 	createFn            func(ctx context.Context, contract *entities.SmartContract) error
 	getByIDFn           func(ctx context.Context, id uuid.UUID) (*entities.SmartContract, error)
-	getFilteredFn       func(ctx context.Context, chainID *uuid.UUID, contractType entities.SmartContractType, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error)
+	getFilteredFn       func(ctx context.Context, filter repositories.SmartContractFilter, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error)
 	getByChainAddressFn func(ctx context.Context, chainID uuid.UUID, address string) (*entities.SmartContract, error)
 	updateFn            func(ctx context.Context, contract *entities.SmartContract) error
 	softDeleteFn        func(ctx context.Context, id uuid.UUID) error
+	bulkSetActiveFn     func(ctx context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error)
+	activateFn          func(ctx context.Context, id uuid.UUID) (*entities.SmartContract, error)
 }
 
 func (s *smartContractRepoStub) Create(ctx context.Context, contract *entities.SmartContract) error {
@@ -50,13 +53,27 @@ func (s *smartContractRepoStub) GetActiveContract(context.Context, uuid.UUID, en
 	return nil, domainerrors.ErrNotFound
 }
 
-func (s *smartContractRepoStub) GetFiltered(ctx context.Context, chainID *uuid.UUID, contractType entities.SmartContractType, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+func (s *smartContractRepoStub) GetFiltered(ctx context.Context, filter repositories.SmartContractFilter, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	if s.getFilteredFn != nil {
-		return s.getFilteredFn(ctx, chainID, contractType, pagination)
+		return s.getFilteredFn(ctx, filter, pagination)
 	}
 	return []*entities.SmartContract{}, 0, nil
 }
 
+func (s *smartContractRepoStub) BulkSetActive(ctx context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error) {
+	if s.bulkSetActiveFn != nil {
+		return s.bulkSetActiveFn(ctx, ids, active)
+	}
+	return map[uuid.UUID]bool{}, nil
+}
+
+func (s *smartContractRepoStub) Activate(ctx context.Context, id uuid.UUID) (*entities.SmartContract, error) {
+	if s.activateFn != nil {
+		return s.activateFn(ctx, id)
+	}
+	return nil, nil
+}
+
 func (s *smartContractRepoStub) GetByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return []*entities.SmartContract{}, 0, nil
 }
@@ -80,10 +97,14 @@ func (s *smartContractRepoStub) SoftDelete(ctx context.Context, id uuid.UUID) er
 }
 
 type smartContractChainRepoStub struct {
+	getByIDFn      func(ctx context.Context, id uuid.UUID) (*entities.Chain, error)
 	getByChainIDFn func(ctx context.Context, chainID string) (*entities.Chain, error)
 }
 
-func (s *smartContractChainRepoStub) GetByID(context.Context, uuid.UUID) (*entities.Chain, error) {
+func (s *smartContractChainRepoStub) GetByID(ctx context.Context, id uuid.UUID) (*entities.Chain, error) {
+	if s.getByIDFn != nil {
+		return s.getByIDFn(ctx, id)
+	}
 	return nil, domainerrors.ErrNotFound
 }
 func (s *smartContractChainRepoStub) GetByChainID(ctx context.Context, chainID string) (*entities.Chain, error) {
@@ -104,9 +125,13 @@ func (s *smartContractChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bo
 func (s *smartContractChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *smartContractChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *smartContractChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *smartContractChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *smartContractChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *smartContractChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *smartContractChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *smartContractChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *smartContractChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -114,6 +139,10 @@ func (s *smartContractChainRepoStub) GetRPCByID(context.Context, uuid.UUID) (*en
 	return nil, domainerrors.ErrNotFound
 }
 
+type contractCacheCounter struct{ invalidations int }
+
+func (c *contractCacheCounter) InvalidateVaultAddresses(context.Context) { c.invalidations++ }
+
 func TestSmartContractHandler_CRUDAndLookup(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	chainID := uuid.New()
@@ -130,10 +159,12 @@ func TestSmartContractHandler_CRUDAndLookup(t *testing.T) {
 			}
 			return &entities.SmartContract{ID: contractID, Name: "Gateway", ChainUUID: chainID}, nil
 		},
-		getFilteredFn: func(_ context.Context, chainUUID *uuid.UUID, contractType entities.SmartContractType, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
-			require.NotNil(t, chainUUID)
-			require.Equal(t, chainID, *chainUUID)
-			require.Equal(t, entities.ContractTypeGateway, contractType)
+		getFilteredFn: func(_ context.Context, filter repositories.SmartContractFilter, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+			require.NotNil(t, filter.ChainID)
+			require.Equal(t, chainID, *filter.ChainID)
+			require.Equal(t, entities.ContractTypeGateway, filter.Type)
+			require.NotNil(t, filter.IsActive)
+			require.True(t, *filter.IsActive)
 			require.Equal(t, 1, pagination.Page)
 			require.Equal(t, 10, pagination.Limit)
 			return []*entities.SmartContract{{ID: contractID, Name: "Gateway"}}, 1, nil
@@ -161,7 +192,8 @@ func TestSmartContractHandler_CRUDAndLookup(t *testing.T) {
 			return nil, domainerrors.ErrNotFound
 		},
 	}
-	h := NewSmartContractHandler(repo, chainRepo)
+	caches := &contractCacheCounter{}
+	h := NewSmartContractHandlerWithCache(repo, chainRepo, caches)
 
 	r := gin.New()
 	r.POST("/contracts", h.CreateSmartContract)
@@ -209,6 +241,8 @@ func TestSmartContractHandler_CRUDAndLookup(t *testing.T) {
 	w = httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusOK, w.Code)
+	// Create, update and delete each drop cached gateway vaults; reads do not
+	require.Equal(t, 3, caches.invalidations)
 }
 
 func TestSmartContractHandler_ValidationAndErrorBranches(t *testing.T) {
@@ -220,7 +254,7 @@ func TestSmartContractHandler_ValidationAndErrorBranches(t *testing.T) {
 		getByIDFn: func(context.Context, uuid.UUID) (*entities.SmartContract, error) {
 			return nil, domainerrors.ErrNotFound
 		},
-		getFilteredFn: func(context.Context, *uuid.UUID, entities.SmartContractType, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+		getFilteredFn: func(context.Context, repositories.SmartContractFilter, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 			return nil, 0, errors.New("list failed")
 		},
 		getByChainAddressFn: func(context.Context, uuid.UUID, string) (*entities.SmartContract, error) {
@@ -301,3 +335,219 @@ func TestSmartContractHandler_ValidationAndErrorBranches(t *testing.T) {
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusNotFound, w.Code)
 }
+
+func TestSmartContractHandler_BulkActivateSmartContracts(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	contractID := uuid.New()
+	var gotActive bool
+	repo := &smartContractRepoStub{
+		bulkSetActiveFn: func(_ context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error) {
+			gotActive = active
+			if len(ids) > 1 {
+				return nil, errors.New("tx failed")
+			}
+			return map[uuid.UUID]bool{contractID: true}, nil
+		},
+	}
+	h := NewSmartContractHandler(repo, &smartContractChainRepoStub{})
+	r := gin.New()
+	r.POST("/admin/contracts/bulk-activate", h.BulkActivateSmartContracts)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/contracts/bulk-activate", strings.NewReader(`{"ids":["`+contractID.String()+`"],"isActive":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.False(t, gotActive)
+	require.Contains(t, w.Body.String(), `"status":"UPDATED"`)
+	require.Contains(t, w.Body.String(), `"isActive":false`)
+
+	req = httptest.NewRequest(http.MethodPost, "/admin/contracts/bulk-activate", strings.NewReader(`{"ids":["`+contractID.String()+`","`+uuid.NewString()+`"],"isActive":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestSmartContractHandler_ActivateSmartContract(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	contractID := uuid.New()
+	conflictID := uuid.New()
+	repo := &smartContractRepoStub{
+		activateFn: func(_ context.Context, id uuid.UUID) (*entities.SmartContract, error) {
+			switch id {
+			case contractID:
+				return &entities.SmartContract{ID: id, IsActive: true}, nil
+			case conflictID:
+				return nil, domainerrors.ErrAlreadyExists
+			}
+			return nil, domainerrors.ErrNotFound
+		},
+	}
+	caches := &contractCacheCounter{}
+	h := NewSmartContractHandlerWithCache(repo, &smartContractChainRepoStub{}, caches)
+	r := gin.New()
+	r.POST("/admin/contracts/:id/activate", h.ActivateSmartContract)
+
+	cases := []struct {
+		id   string
+		code int
+	}{
+		{contractID.String(), http.StatusOK},
+		{conflictID.String(), http.StatusConflict},
+		{uuid.NewString(), http.StatusNotFound},
+		{"not-a-uuid", http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/contracts/"+tc.id+"/activate", nil))
+		require.Equal(t, tc.code, w.Code, tc.id)
+	}
+	require.Equal(t, 1, caches.invalidations)
+}
+
+func TestSmartContractHandler_ImportContractABI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	explorer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":"[{\"type\":\"function\",\"name\":\"isSupportedToken\",\"inputs\":[],\"outputs\":[]}]"}`))
+	}))
+	defer explorer.Close()
+
+	chainID := uuid.New()
+	bareChainID := uuid.New()
+	registryID := uuid.New()
+	swapperID := uuid.New()
+	address := "0x1111111111111111111111111111111111111111"
+	contracts := map[uuid.UUID]*entities.SmartContract{
+		registryID: {ID: registryID, ChainUUID: chainID, ContractAddress: address, Type: entities.ContractTypeTokenRegistry},
+		swapperID:  {ID: swapperID, ChainUUID: chainID, ContractAddress: address, Type: entities.ContractTypeTokenSwapper},
+	}
+	var saved *entities.SmartContract
+	repo := &smartContractRepoStub{
+		getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.SmartContract, error) {
+			if contract, ok := contracts[id]; ok {
+				return contract, nil
+			}
+			return nil, domainerrors.ErrNotFound
+		},
+		updateFn: func(_ context.Context, contract *entities.SmartContract) error {
+			saved = contract
+			return nil
+		},
+	}
+	chains := &smartContractChainRepoStub{
+		getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.Chain, error) {
+			switch id {
+			case chainID:
+				return &entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, ExplorerAPIURL: explorer.URL}, nil
+			case bareChainID:
+				return &entities.Chain{ID: bareChainID, ChainID: "31337", Type: entities.ChainTypeEVM}, nil
+			}
+			return nil, domainerrors.ErrNotFound
+		},
+	}
+	caches := &contractCacheCounter{}
+	h := NewSmartContractHandlerWithCache(repo, chains, caches)
+	r := gin.New()
+	r.POST("/admin/contracts/import-abi", h.ImportContractABI)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/admin/contracts/import-abi", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"chainId":"` + chainID.String() + `","address":"` + address + `","type":"TOKEN_SWAPPER"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"missingFunctions":["swap"]`)
+	require.Contains(t, w.Body.String(), `"saved":false`)
+
+	w = post(`{"contractId":"` + registryID.String() + `","save":true}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"saved":true`)
+	require.NotNil(t, saved)
+	require.NotNil(t, saved.ABI)
+	require.Equal(t, 1, caches.invalidations)
+
+	w = post(`{"contractId":"` + swapperID.String() + `","save":true}`)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Contains(t, w.Body.String(), domainerrors.CodeABIIncomplete)
+
+	w = post(`{"chainId":"` + bareChainID.String() + `","address":"` + address + `"}`)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.Contains(t, w.Body.String(), domainerrors.CodeExplorerNotConfigured)
+
+	require.Equal(t, http.StatusBadRequest, post(`{"chainId":"`+chainID.String()+`","address":"`+address+`","save":true}`).Code)
+	require.Equal(t, http.StatusBadRequest, post(`{"contractId":"`+registryID.String()+`","address":"0x2222222222222222222222222222222222222222"}`).Code)
+	require.Equal(t, http.StatusNotFound, post(`{"contractId":"`+uuid.NewString()+`"}`).Code)
+	require.Equal(t, 1, caches.invalidations)
+}
+
+func TestSmartContractHandler_ContractIndexing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	chainID := uuid.New()
+	contractID := uuid.New()
+	contract := &entities.SmartContract{
+		ID: contractID, Name: "Gateway", Type: entities.ContractTypeGateway, ChainUUID: chainID, ContractAddress: "0x1111111111111111111111111111111111111111", IsActive: true,
+		ABI: []interface{}{map[string]interface{}{"type": "event", "name": "PaymentCreated", "inputs": []interface{}{map[string]interface{}{"name": "id", "type": "bytes32", "indexed": true}}}},
+	}
+	var listed repositories.SmartContractFilter
+	repo := &smartContractRepoStub{
+		getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.SmartContract, error) {
+			if id == contractID {
+				return contract, nil
+			}
+			return nil, domainerrors.ErrNotFound
+		},
+		updateFn: func(_ context.Context, updated *entities.SmartContract) error {
+			contract = updated
+			return nil
+		},
+		getFilteredFn: func(_ context.Context, filter repositories.SmartContractFilter, _ utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+			listed = filter
+			return []*entities.SmartContract{contract}, 1, nil
+		},
+	}
+	chains := &smartContractChainRepoStub{
+		getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.Chain, error) {
+			return &entities.Chain{ID: id, ChainID: "8453", Type: entities.ChainTypeEVM}, nil
+		},
+	}
+	h := NewSmartContractHandler(repo, chains)
+	r := gin.New()
+	r.PUT("/admin/contracts/:id/indexing", h.UpdateContractIndexing)
+	r.GET("/admin/contracts/indexing", h.ListContractIndexing)
+	r.PUT("/admin/contracts/:id", h.UpdateSmartContract)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodPut, "/admin/contracts/"+contractID.String()+"/indexing", `{"events":["PaymentCreated"],"startBlock":1200}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"PaymentCreated(bytes32)"}, contract.IndexedEvents)
+	require.Equal(t, uint64(1200), contract.StartBlock)
+	require.Contains(t, w.Body.String(), `"chainId":"eip155:8453"`)
+
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPut, "/admin/contracts/"+contractID.String()+"/indexing", `{"events":["Refunded"]}`).Code)
+	require.Equal(t, http.StatusBadRequest, send(http.MethodPut, "/admin/contracts/"+contractID.String()+"/indexing", `{}`).Code)
+	require.Equal(t, http.StatusNotFound, send(http.MethodPut, "/admin/contracts/"+uuid.NewString()+"/indexing", `{"events":[]}`).Code)
+
+	w = send(http.MethodGet, "/admin/contracts/indexing", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, listed.Indexed)
+	require.NotNil(t, listed.IsActive)
+	require.Contains(t, w.Body.String(), `"signature":"PaymentCreated(bytes32)"`)
+	require.Contains(t, w.Body.String(), `"startBlock":1200`)
+
+	// An ABI update may not drop an indexed event
+	w = send(http.MethodPut, "/admin/contracts/"+contractID.String(), `{"abi":[]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "PaymentCreated(bytes32)")
+}
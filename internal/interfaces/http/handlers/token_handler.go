@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"math/big"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/volatiletech/null/v8"
 
@@ -32,8 +36,10 @@ func NewTokenHandler(tokenRepo repositories.TokenRepository, chainRepo repositor
 	}
 }
 
-// ListSupportedTokens lists tokens, optionally filtered by chain
+// ListSupportedTokens lists active tokens, optionally filtered by chain.
+// Admins may pass includeInactive=true.
 // GET /api/v1/tokens
+// GET /api/v1/admin/tokens
 func (h *TokenHandler) ListSupportedTokens(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "0"))
@@ -41,9 +47,10 @@ func (h *TokenHandler) ListSupportedTokens(c *gin.Context) {
 
 	chainIDStr := c.Query("chainId")
 	search := c.Query("search")
+	includeInactive := includeInactiveRequested(c)
 
+	var chainIDPtr *uuid.UUID
 	if chainIDStr != "" {
-		// Get tokens supported on specific chain
 		chainID, err := uuid.Parse(chainIDStr)
 		if err != nil {
 			// Try lookup by legacy blockchain ID
@@ -54,8 +61,12 @@ func (h *TokenHandler) ListSupportedTokens(c *gin.Context) {
 			}
 			chainID = chain.ID
 		}
+		chainIDPtr = &chainID
+	}
 
-		tokens, totalCount, err := h.tokenRepo.GetTokensByChain(c.Request.Context(), chainID, pagination)
+	if chainIDPtr != nil && !includeInactive {
+		// Get tokens supported on specific chain
+		tokens, totalCount, err := h.tokenRepo.GetTokensByChain(c.Request.Context(), *chainIDPtr, pagination)
 		if err != nil {
 			response.Error(c, err)
 			return
@@ -75,7 +86,7 @@ func (h *TokenHandler) ListSupportedTokens(c *gin.Context) {
 		searchPtr = &search
 	}
 
-	tokens, totalCount, err := h.tokenRepo.GetAllTokens(c.Request.Context(), nil, searchPtr, pagination)
+	tokens, totalCount, err := h.tokenRepo.GetAllTokens(c.Request.Context(), chainIDPtr, searchPtr, includeInactive, pagination)
 	if err != nil {
 		response.Error(c, err)
 		return
@@ -88,6 +99,69 @@ func (h *TokenHandler) ListSupportedTokens(c *gin.Context) {
 	})
 }
 
+// chainTokensMaxLimit caps one page of GET /chains/:id/tokens
+const chainTokensMaxLimit = 100
+
+// ListChainTokens lists the active tokens of one active chain, for per-chain token pickers.
+// :id is the chain UUID, its CAIP-2 ID or its numeric chain ID.
+// GET /api/v1/chains/:id/tokens
+func (h *TokenHandler) ListChainTokens(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit <= 0 || limit > chainTokensMaxLimit {
+		limit = chainTokensMaxLimit
+	}
+	pagination := utils.GetPaginationParams(page, limit)
+
+	chain, err := h.lookupChain(c, c.Param("id"))
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	tokens, totalCount, err := h.tokenRepo.GetTokensByChain(c.Request.Context(), chain.ID, pagination)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	if tokens == nil {
+		tokens = []*entities.Token{}
+	}
+
+	response.Success(c, http.StatusOK, gin.H{
+		"chainId": chain.GetCAIP2ID(),
+		"items":   tokens,
+		"meta":    utils.CalculateMeta(totalCount, pagination.Page, pagination.Limit),
+	})
+}
+
+// lookupChain resolves a chain path parameter; unknown and inactive chains are not found
+func (h *TokenHandler) lookupChain(c *gin.Context, raw string) (*entities.Chain, error) {
+	ctx := c.Request.Context()
+	raw = strings.TrimSpace(raw)
+	var (
+		chain *entities.Chain
+		err   error
+	)
+	if id, parseErr := uuid.Parse(raw); parseErr == nil {
+		chain, err = h.chainRepo.GetByID(ctx, id)
+	} else if strings.Contains(raw, ":") {
+		chain, err = h.chainRepo.GetByCAIP2(ctx, raw)
+	} else {
+		chain, err = h.chainRepo.GetByChainID(ctx, raw)
+	}
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			return nil, domainerrors.NotFound("chain not found")
+		}
+		return nil, err
+	}
+	if chain == nil || !chain.IsActive {
+		return nil, domainerrors.NotFound("chain not found")
+	}
+	return chain, nil
+}
+
 // ListStablecoins lists only stablecoin tokens
 // GET /api/v1/tokens/stablecoins
 func (h *TokenHandler) ListStablecoins(c *gin.Context) {
@@ -100,6 +174,58 @@ func (h *TokenHandler) ListStablecoins(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"tokens": tokens})
 }
 
+// tokenChainAddress is one chain's registration of a token symbol
+type tokenChainAddress struct {
+	ChainID         string `json:"chainId"`
+	ChainName       string `json:"chainName"`
+	TokenID         string `json:"tokenId"`
+	Name            string `json:"name"`
+	ContractAddress string `json:"contractAddress"`
+	Decimals        int    `json:"decimals"`
+	IsNative        bool   `json:"isNative"`
+	LogoURL         string `json:"logoUrl,omitempty"`
+}
+
+// ListTokensBySymbol resolves a token symbol to its address on every active chain that has an
+// active token with exactly that symbol, sorted by CAIP-2 chain ID
+// GET /api/v1/tokens/by-symbol?symbol=USDC
+func (h *TokenHandler) ListTokensBySymbol(c *gin.Context) {
+	ctx := c.Request.Context()
+	symbol := strings.TrimSpace(c.Query("symbol"))
+	if symbol == "" {
+		response.Error(c, domainerrors.BadRequest("symbol is required"))
+		return
+	}
+
+	tokens, err := h.tokenRepo.GetActiveBySymbol(ctx, symbol)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	items := make([]tokenChainAddress, 0, len(tokens))
+	for _, token := range tokens {
+		if token == nil || token.Chain == nil {
+			continue
+		}
+		items = append(items, tokenChainAddress{
+			ChainID:         token.Chain.GetCAIP2ID(),
+			ChainName:       token.Chain.Name,
+			TokenID:         token.ID.String(),
+			Name:            token.Name,
+			ContractAddress: token.ContractAddress,
+			Decimals:        token.Decimals,
+			IsNative:        token.IsNative,
+			LogoURL:         token.LogoURL,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ChainID < items[j].ChainID })
+
+	response.Success(c, http.StatusOK, gin.H{
+		"symbol": symbol,
+		"items":  items,
+	})
+}
+
 // CreateToken creates a new token
 // POST /api/v1/admin/tokens
 func (h *TokenHandler) CreateToken(c *gin.Context) {
@@ -113,6 +239,12 @@ func (h *TokenHandler) CreateToken(c *gin.Context) {
 		ContractAddress string  `json:"contractAddress"`
 		MinAmount       string  `json:"minAmount"`
 		MaxAmount       *string `json:"maxAmount"`
+		HasTransferFee  bool    `json:"hasTransferFee"`
+		// ApprovalThreshold holds cross-chain payments above it for admin approval
+		ApprovalThreshold *string `json:"approvalThreshold"`
+		// TransferProbeHolder is an address holding the token; when set, a transfer from it is
+		// simulated to detect a transfer fee
+		TransferProbeHolder string `json:"transferProbeHolder"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -129,38 +261,73 @@ func (h *TokenHandler) CreateToken(c *gin.Context) {
 	if req.MaxAmount != nil && *req.MaxAmount == "" {
 		req.MaxAmount = nil
 	}
+	approvalThreshold, err := parseApprovalThreshold(req.ApprovalThreshold)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
 
-	chainID, err := uuid.Parse(req.ChainID)
+	chain, err := usecases.NewChainResolver(h.chainRepo).ResolveChain(c.Request.Context(), req.ChainID)
 	if err != nil {
-		// Try lookup by legacy blockchain ID
-		chain, err := h.chainRepo.GetByChainID(c.Request.Context(), req.ChainID)
-		if err != nil {
-			response.Error(c, domainerrors.BadRequest("Invalid chainId"))
+		response.Error(c, domainerrors.BadRequest("Invalid chainId"))
+		return
+	}
+	if req.ContractAddress != "" {
+		if err := usecases.ValidateAddressForChain(chain, req.ContractAddress); err != nil {
+			response.Error(c, err)
+			return
+		}
+	}
+	if req.TransferProbeHolder != "" {
+		if req.ContractAddress == "" {
+			response.Error(c, domainerrors.BadRequest("transferProbeHolder needs a token contractAddress"))
+			return
+		}
+		if err := usecases.ValidateAddressForChain(chain, req.TransferProbeHolder); err != nil {
+			response.Error(c, err)
 			return
 		}
-		chainID = chain.ID
 	}
 
 	token := &entities.Token{
-		ID:              utils.GenerateUUIDv7(),
-		Symbol:          req.Symbol,
-		Name:            req.Name,
-		Decimals:        req.Decimals,
-		LogoURL:         req.LogoURL,
-		Type:            entities.TokenType(req.Type),
-		ChainUUID:       chainID,
-		ContractAddress: req.ContractAddress,
-		MinAmount:       req.MinAmount,
-		MaxAmount:       null.StringFromPtr(req.MaxAmount),
-		IsActive:        true,
+		ID:                utils.GenerateUUIDv7(),
+		Symbol:            req.Symbol,
+		Name:              req.Name,
+		Decimals:          req.Decimals,
+		LogoURL:           req.LogoURL,
+		Type:              entities.TokenType(req.Type),
+		ChainUUID:         chain.ID,
+		ContractAddress:   req.ContractAddress,
+		MinAmount:         req.MinAmount,
+		MaxAmount:         null.StringFromPtr(req.MaxAmount),
+		IsActive:          true,
+		HasTransferFee:    req.HasTransferFee,
+		ApprovalThreshold: approvalThreshold,
+	}
+
+	// The probe only ever raises the flag: a failed simulation is reported, not fatal
+	result := gin.H{"token": token}
+	if req.TransferProbeHolder != "" && h.paymentUseCase != nil {
+		probe, err := h.paymentUseCase.ProbeTransferFee(c.Request.Context(), chain, req.ContractAddress, req.TransferProbeHolder)
+		if err != nil {
+			result["transferFeeProbeError"] = err.Error()
+		} else {
+			result["transferFeeProbe"] = probe
+			token.HasTransferFee = token.HasTransferFee || probe.HasTransferFee
+		}
 	}
 
 	if err := h.tokenRepo.Create(c.Request.Context(), token); err != nil {
+		if errors.Is(err, domainerrors.ErrAlreadyExists) {
+			conflict := domainerrors.Conflict("Token already exists for this chain and contract address")
+			conflict.Err = err // keeps the existing token's ID for the response
+			err = conflict
+		}
 		response.Error(c, err)
 		return
 	}
 
-	response.Success(c, http.StatusCreated, gin.H{"token": token})
+	response.Success(c, http.StatusCreated, result)
 }
 
 // UpdateToken updates an existing token
@@ -183,6 +350,9 @@ func (h *TokenHandler) UpdateToken(c *gin.Context) {
 		ChainID         string  `json:"chainId"`
 		MinAmount       string  `json:"minAmount"`
 		MaxAmount       *string `json:"maxAmount"` // Use pointer to distinguish between missing field and explicit null/empty
+		HasTransferFee  *bool   `json:"hasTransferFee"`
+		// ApprovalThreshold is left alone when missing and cleared by an empty string
+		ApprovalThreshold *string `json:"approvalThreshold"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -217,6 +387,17 @@ func (h *TokenHandler) UpdateToken(c *gin.Context) {
 	if req.MinAmount != "" {
 		token.MinAmount = req.MinAmount
 	}
+	if req.HasTransferFee != nil {
+		token.HasTransferFee = *req.HasTransferFee
+	}
+	if req.ApprovalThreshold != nil {
+		threshold, err := parseApprovalThreshold(req.ApprovalThreshold)
+		if err != nil {
+			response.Error(c, err)
+			return
+		}
+		token.ApprovalThreshold = threshold
+	}
 
 	// Handle MaxAmount
 	if req.MaxAmount != nil {
@@ -250,7 +431,29 @@ func (h *TokenHandler) UpdateToken(c *gin.Context) {
 	response.Success(c, http.StatusOK, gin.H{"token": token})
 }
 
-// DeleteToken soft deletes a token
+// BulkActivateTokens sets the active flag on many tokens in one transaction
+// POST /api/v1/admin/tokens/bulk-activate
+func (h *TokenHandler) BulkActivateTokens(c *gin.Context) {
+	ids, active, ok := bindBulkActivateRequest(c)
+	if !ok {
+		return
+	}
+
+	previous, err := h.tokenRepo.BulkSetActive(c.Request.Context(), ids, active)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	results, summary := bulkActivateResults(ids, previous, active)
+	response.Success(c, http.StatusOK, gin.H{
+		"items":   results,
+		"summary": summary,
+	})
+}
+
+// DeleteToken soft deletes a token, so payments that used it keep showing it. With hard=true
+// the row is removed instead, which is refused while payments or payment requests reference it.
 // DELETE /api/v1/admin/tokens/:id
 func (h *TokenHandler) DeleteToken(c *gin.Context) {
 	idStr := c.Param("id")
@@ -259,15 +462,70 @@ func (h *TokenHandler) DeleteToken(c *gin.Context) {
 		response.Error(c, domainerrors.BadRequest("Invalid token ID"))
 		return
 	}
+	hard, ok := parseHardDelete(c)
+	if !ok {
+		return
+	}
 
-	if err := h.tokenRepo.SoftDelete(c.Request.Context(), id); err != nil {
-		response.Error(c, err)
+	remove := h.tokenRepo.SoftDelete
+	if hard {
+		remove = h.tokenRepo.HardDelete
+	}
+	if err := remove(c.Request.Context(), id); err != nil {
+		response.Error(c, deleteError("Token", err))
 		return
 	}
 
 	response.Success(c, http.StatusOK, gin.H{"message": "Token deleted successfully"})
 }
 
+// DeactivateToken hides a token from new payments and token lists while leaving it, and the
+// payments that reference it, in place
+// POST /api/v1/admin/tokens/:id/deactivate
+func (h *TokenHandler) DeactivateToken(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("Invalid token ID"))
+		return
+	}
+
+	previous, err := h.tokenRepo.BulkSetActive(c.Request.Context(), []uuid.UUID{id}, false)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	if _, found := previous[id]; !found {
+		response.Error(c, domainerrors.NotFound("Token not found"))
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"id": id, "isActive": false})
+}
+
+// BulkDeleteTokens deletes many tokens, soft unless hard is set. Each token is deleted on its
+// own and reported as DELETED, NOT_FOUND or REFERENCED with the blocking references.
+// POST /api/v1/admin/tokens/bulk-delete
+func (h *TokenHandler) BulkDeleteTokens(c *gin.Context) {
+	ids, hard, ok := bindBulkDeleteRequest(c)
+	if !ok {
+		return
+	}
+
+	remove := h.tokenRepo.SoftDelete
+	if hard {
+		remove = h.tokenRepo.HardDelete
+	}
+	results, summary, err := bulkDelete(c.Request.Context(), ids, remove)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+	response.Success(c, http.StatusOK, gin.H{
+		"items":   results,
+		"summary": summary,
+	})
+}
+
 // CheckPairSupport checks if a swap route exists for a token pair on-chain
 // GET /api/v1/tokens/check-pair?chainId=...&tokenIn=...&tokenOut=...
 func (h *TokenHandler) CheckPairSupport(c *gin.Context) {
@@ -307,3 +565,17 @@ func (h *TokenHandler) CheckPairSupport(c *gin.Context) {
 		"universalRouter": status.UniversalV4,
 	})
 }
+
+// parseApprovalThreshold reads a token's cross-chain approval threshold, a positive amount in
+// whole tokens. Nil or empty means none.
+func parseApprovalThreshold(raw *string) (null.String, error) {
+	if raw == nil || strings.TrimSpace(*raw) == "" {
+		return null.String{}, nil
+	}
+	value := strings.TrimSpace(*raw)
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok || amount.Sign() <= 0 || strings.ContainsAny(value, "eE/") {
+		return null.String{}, domainerrors.BadRequest("approvalThreshold must be a positive decimal amount")
+	}
+	return null.StringFrom(value), nil
+}
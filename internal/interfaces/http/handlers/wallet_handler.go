@@ -18,6 +18,7 @@ type walletService interface {
 	GetWallets(ctx context.Context, userID uuid.UUID) ([]*entities.Wallet, error)
 	SetPrimaryWallet(ctx context.Context, userID, walletID uuid.UUID) error
 	DisconnectWallet(ctx context.Context, userID, walletID uuid.UUID) error
+	IssueWalletChallenge(ctx context.Context, userID uuid.UUID, input *entities.WalletChallengeInput) (*entities.WalletChallenge, error)
 }
 
 // WalletHandler handles wallet endpoints
@@ -66,6 +67,31 @@ func (h *WalletHandler) ConnectWallet(c *gin.Context) {
 	})
 }
 
+// WalletChallenge issues the challenge a wallet signs to be claimed by the current user
+// POST /api/v1/wallets/challenge
+func (h *WalletHandler) WalletChallenge(c *gin.Context) {
+	var input entities.WalletChallengeInput
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, domainerrors.BadRequest(err.Error()))
+		return
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Error(c, domainerrors.Unauthorized("User not authenticated"))
+		return
+	}
+
+	challenge, err := h.walletUsecase.IssueWalletChallenge(c.Request.Context(), userID, &input)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, challenge)
+}
+
 // ListWallets lists wallets for the current user
 // GET /api/v1/wallets
 func (h *WalletHandler) ListWallets(c *gin.Context) {
@@ -21,6 +21,7 @@ type walletServiceStub struct {
 	listFn       func(context.Context, uuid.UUID) ([]*entities.Wallet, error)
 	setPrimaryFn func(context.Context, uuid.UUID, uuid.UUID) error
 	disconnectFn func(context.Context, uuid.UUID, uuid.UUID) error
+	challengeFn  func(context.Context, uuid.UUID, *entities.WalletChallengeInput) (*entities.WalletChallenge, error)
 }
 
 func (s walletServiceStub) ConnectWallet(ctx context.Context, userID uuid.UUID, input *entities.ConnectWalletInput) (*entities.Wallet, error) {
@@ -47,6 +48,12 @@ func (s walletServiceStub) DisconnectWallet(ctx context.Context, userID, walletI
 	}
 	return nil
 }
+func (s walletServiceStub) IssueWalletChallenge(ctx context.Context, userID uuid.UUID, input *entities.WalletChallengeInput) (*entities.WalletChallenge, error) {
+	if s.challengeFn != nil {
+		return s.challengeFn(ctx, userID, input)
+	}
+	return &entities.WalletChallenge{}, nil
+}
 
 func TestWalletHandler_ConnectWallet_ErrorMapping_Gap(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/utils"
@@ -92,6 +93,17 @@ func (s *walletRepoStub) SetPrimary(_ context.Context, userID, walletID uuid.UUI
 	return nil
 }
 
+func (s *walletRepoStub) ClaimUnowned(_ context.Context, walletID, userID uuid.UUID, isPrimary bool) error {
+	item, ok := s.items[walletID]
+	if !ok || item.UserID != nil {
+		return domainerrors.ErrAlreadyExists
+	}
+	uid := userID
+	item.UserID = &uid
+	item.IsPrimary = isPrimary
+	return nil
+}
+
 func (s *walletRepoStub) SoftDelete(_ context.Context, id uuid.UUID) error {
 	if _, ok := s.items[id]; !ok {
 		return domainerrors.ErrNotFound
@@ -117,7 +129,9 @@ func (s walletUserRepoStub) GetByEmail(context.Context, string) (*entities.User,
 func (s walletUserRepoStub) Update(context.Context, *entities.User) error            { return nil }
 func (s walletUserRepoStub) UpdatePassword(context.Context, uuid.UUID, string) error { return nil }
 func (s walletUserRepoStub) SoftDelete(context.Context, uuid.UUID) error             { return nil }
-func (s walletUserRepoStub) List(context.Context, string) ([]*entities.User, error)  { return nil, nil }
+func (s walletUserRepoStub) List(context.Context, repositories.UserFilter, utils.PaginationParams) ([]*entities.User, int64, error) {
+	return nil, 0, nil
+}
 
 type walletChainRepoStub struct {
 	chain *entities.Chain
@@ -142,9 +156,13 @@ func (s walletChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool, *str
 func (s walletChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s walletChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s walletChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s walletChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s walletChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s walletChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s walletChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s walletChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s walletChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -175,7 +193,7 @@ func TestWalletHandler_SuccessFlow(t *testing.T) {
 	r.PUT("/wallets/:id/primary", withUser, h.SetPrimaryWallet)
 	r.DELETE("/wallets/:id", withUser, h.DisconnectWallet)
 
-	connectBody := []byte(`{"chainId":"` + chainID.String() + `","address":"0xabc","signature":"sig","message":"msg"}`)
+	connectBody := []byte(`{"chainId":"` + chainID.String() + `","address":"0xabc0000000000000000000000000000000000000","signature":"sig","message":"msg"}`)
 	req := httptest.NewRequest(http.MethodPost, "/wallets/connect", bytes.NewReader(connectBody))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
@@ -272,9 +290,9 @@ func TestWalletHandler_ConnectWallet_ErrorPaths(t *testing.T) {
 		ID:      seedID,
 		UserID:  &otherUserID,
 		ChainID: chainID,
-		Address: "0xdup",
+		Address: "0xd0b0000000000000000000000000000000000000",
 	}
-	repo.addressToID[walletKey(chainID, "0xdup")] = seedID
+	repo.addressToID[walletKey(chainID, "0xd0b0000000000000000000000000000000000000")] = seedID
 
 	uc := usecases.NewWalletUsecase(
 		repo,
@@ -300,7 +318,7 @@ func TestWalletHandler_ConnectWallet_ErrorPaths(t *testing.T) {
 
 	noAuthRouter := gin.New()
 	noAuthRouter.POST("/wallets/connect", h.ConnectWallet)
-	connectBody := []byte(`{"chainId":"` + chainID.String() + `","address":"0xabc","signature":"sig","message":"msg"}`)
+	connectBody := []byte(`{"chainId":"` + chainID.String() + `","address":"0xabc0000000000000000000000000000000000000","signature":"sig","message":"msg"}`)
 	req = httptest.NewRequest(http.MethodPost, "/wallets/connect", bytes.NewReader(connectBody))
 	req.Header.Set("Content-Type", "application/json")
 	w = httptest.NewRecorder()
@@ -309,7 +327,7 @@ func TestWalletHandler_ConnectWallet_ErrorPaths(t *testing.T) {
 		t.Fatalf("expected 401 for missing auth, got %d body=%s", w.Code, w.Body.String())
 	}
 
-	connectDupBody := []byte(`{"chainId":"` + chainID.String() + `","address":"0xdup","signature":"sig","message":"msg"}`)
+	connectDupBody := []byte(`{"chainId":"` + chainID.String() + `","address":"0xd0b0000000000000000000000000000000000000","signature":"sig","message":"msg"}`)
 	req = httptest.NewRequest(http.MethodPost, "/wallets/connect", bytes.NewReader(connectDupBody))
 	req.Header.Set("Content-Type", "application/json")
 	w = httptest.NewRecorder()
@@ -370,7 +388,7 @@ func TestWalletHandler_ListAndDisconnect_ErrorBranches(t *testing.T) {
 	walletID := utils.GenerateUUIDv7()
 
 	baseRepo := newWalletRepoStub()
-	baseRepo.items[walletID] = &entities.Wallet{ID: walletID, UserID: &userID, ChainID: chainID, Address: "0xabc"}
+	baseRepo.items[walletID] = &entities.Wallet{ID: walletID, UserID: &userID, ChainID: chainID, Address: "0xabc0000000000000000000000000000000000000"}
 
 	repo := &walletRepoErrorPathStub{walletRepoStub: baseRepo}
 	uc := usecases.NewWalletUsecase(
@@ -11,12 +11,15 @@ import (
 	"github.com/google/uuid"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/infrastructure/metrics"
+	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/interfaces/http/response"
+	"payment-kita.backend/internal/usecases"
 )
 
 type WebhookService interface {
 	ProcessIndexerWebhook(ctx context.Context, eventType string, data json.RawMessage) error
 	ManualRetry(ctx context.Context, deliveryID uuid.UUID) error
+	SendTestWebhook(ctx context.Context, userID, merchantID uuid.UUID) (*usecases.WebhookTestResult, error)
 }
 
 // WebhookHandler handles webhook endpoints
@@ -79,3 +82,27 @@ func (h *WebhookHandler) RetryWebhook(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, gin.H{"sent": true})
 }
+
+// TestMerchantWebhook sends a sample signed payment.completed event to the merchant's endpoint
+// POST /api/v1/merchants/webhooks/:id/test
+func (h *WebhookHandler) TestMerchantWebhook(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		response.Error(c, domainerrors.Unauthorized("User not authenticated"))
+		return
+	}
+
+	merchantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, domainerrors.BadRequest("invalid merchant id"))
+		return
+	}
+
+	result, err := h.webhookUsecase.SendTestWebhook(c.Request.Context(), userID, merchantID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, result)
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/internal/usecases"
 )
 
 type webhookServiceStub struct {
 	processFn func(ctx context.Context, eventType string, data json.RawMessage) error
+	testFn    func(ctx context.Context, userID, merchantID uuid.UUID) (*usecases.WebhookTestResult, error)
 }
 
 func (s webhookServiceStub) ProcessIndexerWebhook(ctx context.Context, eventType string, data json.RawMessage) error {
@@ -25,6 +28,10 @@ func (s webhookServiceStub) ManualRetry(ctx context.Context, webhookLogID uuid.U
 	return nil
 }
 
+func (s webhookServiceStub) SendTestWebhook(ctx context.Context, userID, merchantID uuid.UUID) (*usecases.WebhookTestResult, error) {
+	return s.testFn(ctx, userID, merchantID)
+}
+
 func TestWebhookHandler_HandleIndexerWebhook(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -97,3 +104,68 @@ func TestWebhookHandler_HandleIndexerWebhook(t *testing.T) {
 		}
 	})
 }
+
+func TestWebhookHandler_TestMerchantWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	merchantID := uuid.New()
+
+	newRouter := func(stub webhookServiceStub, authenticated bool) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			if authenticated {
+				c.Set(middleware.UserIDKey, userID)
+			}
+			c.Next()
+		})
+		r.POST("/merchants/webhooks/:id/test", NewWebhookHandler(stub).TestMerchantWebhook)
+		return r
+	}
+	send := func(r *gin.Engine, id string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/merchants/webhooks/"+id+"/test", nil))
+		return w
+	}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		w := send(newRouter(webhookServiceStub{}, false), merchantID.String())
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", w.Code)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		w := send(newRouter(webhookServiceStub{}, true), "not-a-uuid")
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		w := send(newRouter(webhookServiceStub{
+			testFn: func(context.Context, uuid.UUID, uuid.UUID) (*usecases.WebhookTestResult, error) {
+				return nil, domainerrors.Forbidden("webhook does not belong to merchant")
+			},
+		}, true), uuid.NewString())
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		w := send(newRouter(webhookServiceStub{
+			testFn: func(_ context.Context, gotUser, gotMerchant uuid.UUID) (*usecases.WebhookTestResult, error) {
+				if gotUser != userID || gotMerchant != merchantID {
+					t.Fatalf("unexpected ids: %s %s", gotUser, gotMerchant)
+				}
+				return &usecases.WebhookTestResult{EventType: usecases.WebhookTestEventType, Delivered: true, HttpStatus: 204, LatencyMs: 12}, nil
+			},
+		}, true), merchantID.String())
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+		}
+		if !bytes.Contains(w.Body.Bytes(), []byte(`"httpStatus":204`)) || !bytes.Contains(w.Body.Bytes(), []byte(`"latencyMs":12`)) {
+			t.Fatalf("expected status and latency, body=%s", w.Body.String())
+		}
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"payment-kita.backend/internal/domain/entities"
 	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/usecases"
 )
@@ -56,6 +57,9 @@ func ApiKeyPartnerMiddleware(apiKeyUsecase *usecases.ApiKeyUsecase, merchantRepo
 		)
 		if err != nil {
 			log.Printf("[ApiKeyPartnerMiddleware] auth failed: %v", err)
+			if abortIfSuspended(c, err) {
+				return
+			}
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error": "Invalid partner API key or signature",
 			})
@@ -76,6 +80,8 @@ func ApiKeyPartnerMiddleware(apiKeyUsecase *usecases.ApiKeyUsecase, merchantRepo
 		c.Set(UserRoleKey, string(user.Role))
 		c.Set(MerchantIDKey, merchant.ID)
 		c.Set(IsMerchantAuthenticatedKey, true)
+		c.Set(APIKeyAuthKey, true)
+		setPaymentMode(c, entities.PaymentModeFromAPIKey(apiKey))
 		c.Next()
 	}
 }
@@ -26,7 +26,7 @@ func TestApiKeyPartnerMiddleware_HappyPath(t *testing.T) {
 	mockMerchantRepo := new(MockMerchantRepository)
 
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	userID := uuid.New()
 	merchantID := uuid.New()
@@ -112,7 +112,7 @@ func TestApiKeyPartnerMiddleware_RequiresMerchant(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockMerchantRepo := new(MockMerchantRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	userID := uuid.New()
 	apiKey := "pk_partner_test"
@@ -169,7 +169,7 @@ func TestApiKeyPartnerMiddleware_RejectsExpiredTimestamp(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockMerchantRepo := new(MockMerchantRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	userID := uuid.New()
 	apiKey := "pk_partner_test"
@@ -219,7 +219,7 @@ func TestApiKeyPartnerMiddleware_RejectsInvalidSignature(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockMerchantRepo := new(MockMerchantRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	userID := uuid.New()
 	apiKey := "pk_partner_test"
@@ -266,7 +266,7 @@ func TestApiKeyPartnerMiddleware_RejectsTamperedBody(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockMerchantRepo := new(MockMerchantRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	userID := uuid.New()
 	apiKey := "pk_partner_test"
@@ -31,7 +31,7 @@ func AuditMiddleware(repo domain.AuditLogRepository) gin.HandlerFunc {
 
 		log := &domain.AuditLog{
 			ID:         uuid.New(),
-			MerchantID: merchantID,
+			MerchantID: &merchantID,
 			Path:       c.Request.URL.Path,
 			Method:     c.Request.Method,
 			StatusCode: c.Writer.Status(),
@@ -2,13 +2,18 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/crypto"
 	"payment-kita.backend/pkg/jwt"
 	"payment-kita.backend/pkg/redis"
 )
@@ -28,14 +33,31 @@ const (
 	MerchantIDKey = "merchantId"
 	// IsMerchantAuthenticatedKey is the context key for merchant auth status
 	IsMerchantAuthenticatedKey = "isMerchantAuthenticated"
+	// APIKeyAuthKey is set to true when the request authenticated with an API key
+	APIKeyAuthKey = "apiKeyAuth"
+	// PaymentModeKey is the context key for the request's payment mode (test or live)
+	PaymentModeKey = "paymentMode"
 )
 
 var loadSessionFromStore = func(ctx context.Context, store *redis.SessionStore, sessionID string) (*redis.SessionData, error) {
 	return store.GetSession(ctx, sessionID)
 }
 
-// AuthMiddleware creates a new authentication middleware
+// SuspensionChecker reports a suspended account as domainerrors.ErrAccountSuspended. JWTs stay
+// valid until they expire, so every JWT-authenticated request asks it about the token's user.
+type SuspensionChecker interface {
+	EnsureNotSuspended(ctx context.Context, userID uuid.UUID) error
+}
+
+// AuthMiddleware creates a new authentication middleware. It does not look users up, so
+// suspended users keep access until their token expires; use AuthMiddlewareWithSuspensionCheck
+// wherever a user repository is available.
 func AuthMiddleware(jwtService *jwt.JWTService, sessionStore *redis.SessionStore) gin.HandlerFunc {
+	return AuthMiddlewareWithSuspensionCheck(jwtService, sessionStore, nil)
+}
+
+// AuthMiddlewareWithSuspensionCheck is AuthMiddleware that also rejects tokens of suspended users
+func AuthMiddlewareWithSuspensionCheck(jwtService *jwt.JWTService, sessionStore *redis.SessionStore, suspensions SuspensionChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenString := ""
 		strictSessionMode := os.Getenv("INTERNAL_PROXY_SECRET") != ""
@@ -49,8 +71,9 @@ func AuthMiddleware(jwtService *jwt.JWTService, sessionStore *redis.SessionStore
 				}
 			}
 
-		// 2. Legacy fallback to Authorization header when strict mode is disabled.
-		if tokenString == "" && !strictSessionMode {
+		// 2. Legacy fallback to Authorization header when strict mode is disabled, or when
+		// Redis is down and sessions cannot be resolved (JWT-only degraded mode).
+		if tokenString == "" && (!strictSessionMode || !redis.Available()) {
 			authHeader := c.GetHeader(AuthorizationHeader)
 			if authHeader != "" && strings.HasPrefix(authHeader, BearerPrefix) {
 				tokenString = strings.TrimPrefix(authHeader, BearerPrefix)
@@ -80,23 +103,62 @@ func AuthMiddleware(jwtService *jwt.JWTService, sessionStore *redis.SessionStore
 			return
 		}
 
+		if abortIfTokenUserSuspended(c, suspensions, claims) {
+			return
+		}
+
 		log.Printf("[AuthMiddleware] Authenticated user %s with role %s for %s", claims.Email, claims.Role, c.Request.URL.Path)
 
 		// Set user info in context
 		c.Set(UserIDKey, claims.UserID)
 		c.Set(UserEmailKey, claims.Email)
 		c.Set(UserRoleKey, claims.Role)
+		if !applyImpersonation(c, claims) {
+			return
+		}
 
 		c.Next()
 	}
 }
 
+// abortIfTokenUserSuspended answers 403 when the token's user, or the admin impersonating them,
+// has been suspended since the token was issued, and 401 when the user is gone. When the user
+// cannot be looked up it answers 503: the token may be fine, so it is not called invalid, but
+// the request is not let through unchecked either. A nil checker checks nothing.
+func abortIfTokenUserSuspended(c *gin.Context, suspensions SuspensionChecker, claims *jwt.Claims) bool {
+	if suspensions == nil {
+		return false
+	}
+	userIDs := []uuid.UUID{claims.UserID}
+	if claims.IsImpersonation() {
+		userIDs = append(userIDs, *claims.ImpersonatorID)
+	}
+	for _, userID := range userIDs {
+		err := suspensions.EnsureNotSuspended(c.Request.Context(), userID)
+		if err == nil {
+			continue
+		}
+		log.Printf("[Auth] Rejected token of user %s: %v", userID, err)
+		if abortIfSuspended(c, err) {
+			return true
+		}
+		var appErr *domainerrors.AppError
+		if errors.As(err, &appErr) && appErr.Status == http.StatusUnauthorized {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			return true
+		}
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "Account status could not be checked, try again"})
+		return true
+	}
+	return false
+}
+
 func IsTrustedProxyRequest(c *gin.Context) bool {
 	secret := os.Getenv("INTERNAL_PROXY_SECRET")
 	if secret == "" {
 		return true // backward compatible for local/dev without configured secret
 	}
-	return c.GetHeader("X-Internal-Proxy-Secret") == secret
+	return crypto.ConstantTimeEqual(c.GetHeader("X-Internal-Proxy-Secret"), secret)
 }
 
 // GetUserID gets the user ID from context
@@ -108,6 +170,16 @@ func GetUserID(c *gin.Context) (uuid.UUID, bool) {
 	return userID.(uuid.UUID), true
 }
 
+// GetMerchantID gets the authenticated merchant's ID from context
+func GetMerchantID(c *gin.Context) (uuid.UUID, bool) {
+	merchantID, exists := c.Get(MerchantIDKey)
+	if !exists {
+		return uuid.Nil, false
+	}
+	id, ok := merchantID.(uuid.UUID)
+	return id, ok
+}
+
 // GetUserEmail gets the user email from context
 func GetUserEmail(c *gin.Context) (string, bool) {
 	email, exists := c.Get(UserEmailKey)
@@ -126,6 +198,16 @@ func GetUserRole(c *gin.Context) (string, bool) {
 	return role.(string), true
 }
 
+// GetPaymentMode gets the request's payment mode from context: the API key's, or the one a JWT
+// session asked for with ?mode (live by default). false before authentication.
+func GetPaymentMode(c *gin.Context) (entities.PaymentMode, bool) {
+	mode, exists := c.Get(PaymentModeKey)
+	if !exists {
+		return "", false
+	}
+	return mode.(entities.PaymentMode), true
+}
+
 // RequireRole creates a middleware that requires a specific role
 func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -155,6 +237,26 @@ func RequireAdmin() gin.HandlerFunc {
 	return RequireRole("ADMIN")
 }
 
+// adminReadRoles may read the admin API. Only ADMIN may change anything beyond fees and
+// settlement (see RequireFinance).
+var adminReadRoles = []string{"ADMIN", "SUPPORT", "FINANCE"}
+
+// RequireAdminRead lets admins, support and finance through; use it on read-only admin routes
+func RequireAdminRead() gin.HandlerFunc {
+	return RequireRole(adminReadRoles...)
+}
+
+// CanReadAdmin reports whether the caller's role may read the admin API
+func CanReadAdmin(c *gin.Context) bool {
+	role, ok := GetUserRole(c)
+	return ok && slices.Contains(adminReadRoles, role)
+}
+
+// RequireFinance lets admins and finance through, for fee and settlement configuration
+func RequireFinance() gin.HandlerFunc {
+	return RequireRole("ADMIN", "FINANCE")
+}
+
 // RequireAdminOrSubAdmin creates a middleware that requires admin or sub_admin role
 func RequireAdminOrSubAdmin() gin.HandlerFunc {
 	return RequireRole("ADMIN", "SUB_ADMIN")
@@ -17,9 +17,12 @@ import (
 	goredis "github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/jwt"
 	"payment-kita.backend/pkg/redis"
+	"payment-kita.backend/pkg/utils"
 )
 
 type internalApiKeyRepoStub struct{}
@@ -41,7 +44,7 @@ type internalUserRepoStub struct{}
 
 func (internalUserRepoStub) Create(context.Context, *entities.User) error { return nil }
 func (internalUserRepoStub) GetByID(context.Context, uuid.UUID) (*entities.User, error) {
-	return nil, errors.New("not found")
+	return nil, domainerrors.ErrNotFound
 }
 func (internalUserRepoStub) GetByEmail(context.Context, string) (*entities.User, error) {
 	return nil, errors.New("not found")
@@ -49,7 +52,9 @@ func (internalUserRepoStub) GetByEmail(context.Context, string) (*entities.User,
 func (internalUserRepoStub) Update(context.Context, *entities.User) error            { return nil }
 func (internalUserRepoStub) UpdatePassword(context.Context, uuid.UUID, string) error { return nil }
 func (internalUserRepoStub) SoftDelete(context.Context, uuid.UUID) error             { return nil }
-func (internalUserRepoStub) List(context.Context, string) ([]*entities.User, error)  { return nil, nil }
+func (internalUserRepoStub) List(context.Context, repositories.UserFilter, utils.PaginationParams) ([]*entities.User, int64, error) {
+	return nil, 0, nil
+}
 
 type internalMerchantRepoStub struct{}
 
@@ -138,6 +143,7 @@ func TestAuthAndDualMiddleware_InternalCoveragePaths(t *testing.T) {
 			internalApiKeyRepoStub{},
 			internalUserRepoStub{},
 			"00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff",
+			"",
 		)
 
 		r := gin.New()
@@ -218,7 +224,8 @@ func TestAuthAndDualMiddleware_InternalCoveragePaths_StrictBranchAssertions(t *t
 	t.Run("dual auth session with optional signature verification", func(t *testing.T) {
 		_ = os.Setenv("INTERNAL_PROXY_SECRET", "proxy-secret")
 		j := jwt.NewJWTService("secret", time.Hour, time.Hour)
-		pair, err := j.GenerateTokenPair(uuid.New(), "dual2@paymentkita.io", "USER")
+		userID := uuid.New()
+		pair, err := j.GenerateTokenPair(userID, "dual2@paymentkita.io", "USER")
 		require.NoError(t, err)
 		require.NoError(t, sessionStore.CreateSession(context.Background(), "sid-internal-dual-2", &redis.SessionData{
 			AccessToken:  pair.AccessToken,
@@ -227,8 +234,9 @@ func TestAuthAndDualMiddleware_InternalCoveragePaths_StrictBranchAssertions(t *t
 
 		apiKeyUsecase := usecases.NewApiKeyUsecase(
 			internalApiKeyRepoStub{},
-			internalUserRepoStub{},
+			newKnownUserRepoStub(userID),
 			"00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff",
+			"",
 		)
 
 		r := gin.New()
@@ -255,3 +263,20 @@ func TestAuthAndDualMiddleware_InternalCoveragePaths_StrictBranchAssertions(t *t
 		require.Contains(t, w.Body.String(), "Invalid Signature for JWT user")
 	})
 }
+
+func TestShouldResolveMerchantContext_AnyAPIVersion(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/api/v1/payments":                 true,
+		"/api/v2/payments":                 true,
+		"/API/V2/Payments/abc/receipt.pdf": true,
+		"/api/v2/merchants/me":             true,
+		"/api/v1/create-payment":           true,
+		"/api/v2/payments/abc":             false,
+		"/api/v2x/payments":                false,
+		"/api/payments":                    false,
+		"/payments":                        false,
+		"":                                 false,
+	} {
+		require.Equal(t, want, shouldResolveMerchantContext(path), path)
+	}
+}
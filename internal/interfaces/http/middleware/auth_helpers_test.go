@@ -84,6 +84,38 @@ func TestRequireRolePaths(t *testing.T) {
 	})
 }
 
+func TestAdminRoleGates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	serve := func(gate gin.HandlerFunc, role string) int {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Set(UserRoleKey, role)
+			c.Next()
+		})
+		r.Use(gate)
+		r.GET("/x", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+		return w.Code
+	}
+
+	for role, want := range map[string][2]int{
+		"ADMIN":     {http.StatusNoContent, http.StatusNoContent},
+		"FINANCE":   {http.StatusNoContent, http.StatusNoContent},
+		"SUPPORT":   {http.StatusNoContent, http.StatusForbidden},
+		"SUB_ADMIN": {http.StatusForbidden, http.StatusForbidden},
+		"USER":      {http.StatusForbidden, http.StatusForbidden},
+	} {
+		require.Equal(t, want[0], serve(RequireAdminRead(), role), role)
+		require.Equal(t, want[1], serve(RequireFinance(), role), role)
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	require.False(t, CanReadAdmin(c))
+	c.Set(UserRoleKey, "SUPPORT")
+	require.True(t, CanReadAdmin(c))
+}
+
 func TestIsTrustedProxyRequest(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
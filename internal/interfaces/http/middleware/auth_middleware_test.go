@@ -78,6 +78,13 @@ func TestAuthMiddleware_StrictModeBlocksBearerFallback(t *testing.T) {
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// Sessions cannot be resolved while Redis is down, so the bearer token is accepted
+	redis.SetAvailable(false)
+	t.Cleanup(func() { redis.SetAvailable(true) })
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
 }
 
 func TestAuthMiddleware_StrictModeSessionFlowAndExpiredToken(t *testing.T) {
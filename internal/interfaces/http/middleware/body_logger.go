@@ -0,0 +1,194 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"payment-kita.backend/pkg/logger"
+)
+
+const (
+	redactedValue = "[REDACTED]"
+	// bodyCaptureLimit bounds how much of a body is buffered for logging; larger bodies are not logged
+	bodyCaptureLimit = 64 * 1024
+	// DefaultBodyLogMaxBytes is the logged size cap when none is configured
+	DefaultBodyLogMaxBytes = 4096
+)
+
+// sensitiveKeyFragments redact any JSON key containing them, compared lowercase with _ and - removed
+var sensitiveKeyFragments = []string{
+	"password", "secret", "signature", "privatekey", "authorization", "apikey", "mnemonic", "seedphrase",
+}
+
+// sensitiveKeys redact JSON keys that only match exactly, so e.g. sourceTokenAddress stays visible
+var sensitiveKeys = map[string]bool{
+	"token": true, "accesstoken": true, "refreshtoken": true, "sessiontoken": true, "idtoken": true,
+	"otp": true, "pin": true,
+}
+
+// BodyLoggingAllowed reports whether env may log bodies at all. Production never does.
+func BodyLoggingAllowed(env string) bool {
+	switch strings.ToLower(strings.TrimSpace(env)) {
+	case "staging", "development":
+		return true
+	}
+	return false
+}
+
+// BodyLoggerMiddleware logs redacted JSON request and response bodies for debugging. It is a
+// no-op unless env allows body logging. Values listed in secrets (owner key, signing secrets)
+// are scrubbed from the output wherever they appear; headers are never logged.
+func BodyLoggerMiddleware(env string, maxBytes int, secrets ...string) gin.HandlerFunc {
+	if !BodyLoggingAllowed(env) {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultBodyLogMaxBytes
+	}
+	scrub := secretScrubber(secrets)
+
+	return func(c *gin.Context) {
+		if c.Request.Method == "OPTIONS" {
+			c.Next()
+			return
+		}
+
+		var reqBody []byte
+		reqComplete := true
+		if c.Request.Body != nil {
+			reqBody, _ = io.ReadAll(io.LimitReader(c.Request.Body, bodyCaptureLimit+1))
+			reqComplete = len(reqBody) <= bodyCaptureLimit
+			c.Request.Body = readCloser{io.MultiReader(bytes.NewReader(reqBody), c.Request.Body), c.Request.Body}
+		}
+
+		writer := &bodyCaptureWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		logger.Info(c.Request.Context(), "HTTP Body",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", writer.Status()),
+			zap.String("request_body", formatLoggedBody(reqBody, reqComplete, c.ContentType(), maxBytes, scrub)),
+			zap.String("response_body", formatLoggedBody(writer.body.Bytes(), !writer.overflow, writer.Header().Get("Content-Type"), maxBytes, scrub)),
+		)
+	}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// bodyCaptureWriter tees the response into a bounded buffer
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	w.capture(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCaptureWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *bodyCaptureWriter) capture(b []byte) {
+	if w.overflow {
+		return
+	}
+	if w.body.Len()+len(b) > bodyCaptureLimit {
+		w.overflow = true
+		w.body.Reset()
+		return
+	}
+	w.body.Write(b)
+}
+
+// formatLoggedBody redacts and caps body. Only JSON is logged: other content types could carry
+// credentials in shapes we cannot redact reliably.
+func formatLoggedBody(body []byte, complete bool, contentType string, maxBytes int, scrub func(string) string) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if !complete {
+		return "[body too large to log]"
+	}
+	if !strings.Contains(strings.ToLower(contentType), "json") {
+		return "[non-JSON body omitted]"
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return "[malformed JSON body omitted]"
+	}
+	redacted, err := json.Marshal(redactJSON(decoded))
+	if err != nil {
+		return "[unloggable body omitted]"
+	}
+	out := scrub(string(redacted))
+	if len(out) > maxBytes {
+		out = out[:maxBytes] + "...[truncated]"
+	}
+	return out
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			if isSensitiveKey(key) {
+				value[key] = redactedValue
+				continue
+			}
+			value[key] = redactJSON(nested)
+		}
+	case []interface{}:
+		for i, nested := range value {
+			value[i] = redactJSON(nested)
+		}
+	}
+	return v
+}
+
+func isSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	if sensitiveKeys[normalized] {
+		return true
+	}
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(normalized, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// secretScrubber replaces any configured secret value, with or without a 0x prefix
+func secretScrubber(secrets []string) func(string) string {
+	var pairs []string
+	for _, secret := range secrets {
+		secret = strings.TrimSpace(secret)
+		// Short or placeholder values (all zeros) would redact unrelated text and are not secrets.
+		if len(secret) < 8 || strings.Trim(secret, secret[:1]) == "" {
+			continue
+		}
+		pairs = append(pairs, secret, redactedValue)
+		if trimmed := strings.TrimPrefix(secret, "0x"); trimmed != secret && len(trimmed) >= 8 {
+			pairs = append(pairs, trimmed, redactedValue)
+		}
+	}
+	if len(pairs) == 0 {
+		return func(s string) string { return s }
+	}
+	return strings.NewReplacer(pairs...).Replace
+}
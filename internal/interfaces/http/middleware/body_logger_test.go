@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatLoggedBody_RedactsSensitiveFieldsAndSecrets(t *testing.T) {
+	ownerKey := "0xabcdef0123456789abcdef0123456789abcdef0123456789abcdef0123456789"
+	scrub := secretScrubber([]string{ownerKey, "0000000000000000", "short"})
+
+	body := `{"email":"a@b.c","password":"hunter2","nested":{"secret_key":"sk_live","SIGNATURE":"0xsig"},` +
+		`"items":[{"privateKey":"pk"}],"token":"t","sourceTokenAddress":"0x1111","note":"` + strings.TrimPrefix(ownerKey, "0x") + `"}`
+	out := formatLoggedBody([]byte(body), true, "application/json", 4096, scrub)
+
+	require.NotContains(t, out, "hunter2")
+	require.NotContains(t, out, "sk_live")
+	require.NotContains(t, out, "0xsig")
+	require.NotContains(t, out, `"pk"`)
+	require.NotContains(t, out, "abcdef0123456789")
+	require.Contains(t, out, `"token":"[REDACTED]"`)
+	require.Contains(t, out, `"sourceTokenAddress":"0x1111"`)
+	require.Contains(t, out, `"email":"a@b.c"`)
+}
+
+func TestFormatLoggedBody_CapsAndSkipsUnsafeBodies(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	out := formatLoggedBody([]byte(`{"memo":"`+strings.Repeat("x", 100)+`"}`), true, "application/json", 20, identity)
+	require.Equal(t, `{"memo":"xxxxxxxxxxx...[truncated]`, out)
+
+	require.Equal(t, "[non-JSON body omitted]", formatLoggedBody([]byte("password=hunter2"), true, "application/x-www-form-urlencoded", 100, identity))
+	require.Equal(t, "[malformed JSON body omitted]", formatLoggedBody([]byte(`{"password":"hunter2"`), true, "application/json", 100, identity))
+	require.Equal(t, "[body too large to log]", formatLoggedBody([]byte(`{}`), false, "application/json", 100, identity))
+}
+
+func TestBodyLoggerMiddleware_PassesBodiesThrough(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	require.False(t, BodyLoggingAllowed("production"))
+	require.True(t, BodyLoggingAllowed("staging"))
+
+	for _, env := range []string{"staging", "production"} {
+		r := gin.New()
+		r.Use(BodyLoggerMiddleware(env, 16))
+		r.POST("/echo", func(c *gin.Context) {
+			raw, err := io.ReadAll(c.Request.Body)
+			require.NoError(t, err)
+			c.Data(http.StatusOK, "application/json", raw)
+		})
+
+		payload := `{"amount":"` + strings.Repeat("9", bodyCaptureLimit) + `"}`
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, env)
+		require.Equal(t, payload, w.Body.String(), env)
+	}
+}
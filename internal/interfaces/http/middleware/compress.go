@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultCompressMinSize is the smallest response body Compress encodes; below it the encoding
+// overhead outweighs the saving
+const DefaultCompressMinSize = 1024
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// incompressibleContentTypes are already compressed (e.g. a QR code PNG) and are sent as they are
+var incompressibleContentTypes = []string{
+	"image/", "video/", "audio/", "font/woff",
+	"application/zip", "application/gzip", "application/x-gzip", "application/pdf", "application/octet-stream",
+}
+
+var (
+	gzipWriters = sync.Pool{New: func() interface{} { return gzip.NewWriter(io.Discard) }}
+	zlibWriters = sync.Pool{New: func() interface{} { return zlib.NewWriter(io.Discard) }}
+)
+
+// Compress encodes responses with gzip or deflate, as the client's Accept-Encoding allows, once
+// the body reaches DefaultCompressMinSize
+func Compress() gin.HandlerFunc {
+	return CompressWithMinSize(DefaultCompressMinSize)
+}
+
+// CompressWithMinSize is Compress with a custom minimum body size
+func CompressWithMinSize(minSize int) gin.HandlerFunc {
+	if minSize < 0 {
+		minSize = 0
+	}
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, minSize: minSize}
+		c.Writer = writer
+		defer func() {
+			writer.close()
+			c.Writer = writer.ResponseWriter
+		}()
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip over deflate among the encodings accept allows, or "" for none
+func negotiateEncoding(accept string) string {
+	allowed := make(map[string]bool)
+	star := false
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if name == "*" {
+			star = q > 0
+			continue
+		}
+		allowed[name] = q > 0
+	}
+	// An encoding named explicitly, even with q=0, overrides *
+	for _, encoding := range []string{encodingGzip, encodingDeflate} {
+		if ok, named := allowed[encoding]; ok || (!named && star) {
+			return encoding
+		}
+	}
+	return ""
+}
+
+func compressibleContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, prefix := range incompressibleContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// flushWriter is an encoder that can push out what it has buffered, as gzip and zlib writers do
+type flushWriter interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// compressWriter buffers the body until it reaches minSize, then decides once whether to encode
+// it. Headers are held back until that decision so Content-Encoding can still be set.
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+	buf      bytes.Buffer
+	decided  bool
+	written  bool
+	encoder  flushWriter // nil when the body is sent as is
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	w.written = true
+	if !w.decided {
+		w.buf.Write(b)
+		if w.buf.Len() >= w.minSize {
+			if err := w.decide(); err != nil {
+				return 0, err
+			}
+		}
+		return len(b), nil
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow is deferred until the encoding is decided; the status is already recorded
+func (w *compressWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *compressWriter) Written() bool {
+	return w.written || w.ResponseWriter.Written()
+}
+
+// Flush sends what is buffered so far, e.g. for streamed NDJSON. A stream flushed before it
+// reaches minSize is sent unencoded.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide picks the encoding from the headers and buffered body, then writes the buffer out
+func (w *compressWriter) decide() error {
+	w.decided = true
+	header := w.Header()
+	if header.Get("Content-Type") == "" && w.buf.Len() > 0 {
+		// Sniff now: net/http would otherwise sniff the encoded bytes
+		header.Set("Content-Type", http.DetectContentType(w.buf.Bytes()))
+	}
+	if compressibleContentType(header.Get("Content-Type")) && header.Get("Content-Encoding") == "" {
+		header.Add("Vary", "Accept-Encoding")
+		if w.buf.Len() >= w.minSize && w.buf.Len() > 0 && bodyAllowedForStatus(w.Status()) {
+			header.Set("Content-Encoding", w.encoding)
+			header.Del("Content-Length")
+			w.encoder = w.newEncoder()
+		}
+	}
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(w.buf.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+	}
+	w.buf.Reset()
+	return err
+}
+
+func (w *compressWriter) newEncoder() flushWriter {
+	if w.encoding == encodingGzip {
+		gz := gzipWriters.Get().(*gzip.Writer)
+		gz.Reset(w.ResponseWriter)
+		return gz
+	}
+	zw := zlibWriters.Get().(*zlib.Writer)
+	zw.Reset(w.ResponseWriter)
+	return zw
+}
+
+// close writes out anything still buffered and finishes the encoded stream
+func (w *compressWriter) close() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.encoder == nil {
+		if w.written {
+			w.ResponseWriter.WriteHeaderNow()
+		}
+		return
+	}
+	_ = w.encoder.Close()
+	switch encoder := w.encoder.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(encoder)
+	case *zlib.Writer:
+		zlibWriters.Put(encoder)
+	}
+	w.encoder = nil
+}
+
+func bodyAllowedForStatus(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
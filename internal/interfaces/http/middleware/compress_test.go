@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompressRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Compress())
+	large := strings.Repeat(`{"id":"payment","status":"pending"},`, 100)
+	r.GET("/large", func(c *gin.Context) { c.String(http.StatusOK, "["+large+"{}]") })
+	r.GET("/small", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	r.GET("/qr.png", func(c *gin.Context) { c.Data(http.StatusOK, "image/png", []byte(large)) })
+	r.GET("/empty", func(c *gin.Context) { c.AbortWithStatus(http.StatusNoContent) })
+	r.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "application/x-ndjson")
+		for i := 0; i < 3; i++ {
+			_, _ = c.Writer.WriteString(large + "\n")
+			c.Writer.Flush()
+		}
+	})
+	return r
+}
+
+func serveCompressed(r *gin.Engine, method, path, acceptEncoding string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCompress_EncodesLargeResponses(t *testing.T) {
+	r := newCompressRouter()
+	plain := serveCompressed(r, http.MethodGet, "/large", "")
+	require.Empty(t, plain.Header().Get("Content-Encoding"))
+
+	w := serveCompressed(r, http.MethodGet, "/large", "br, gzip;q=0.8, deflate")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Less(t, w.Body.Len(), plain.Body.Len())
+	gz, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, plain.Body.String(), string(body))
+
+	w = serveCompressed(r, http.MethodGet, "/large", "gzip;q=0, deflate")
+	assert.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+	zr, err := zlib.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err = io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, plain.Body.String(), string(body))
+
+	// Streamed responses stay readable as they are flushed
+	w = serveCompressed(r, http.MethodGet, "/stream", "gzip")
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	gz, err = gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err = io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(body), "\n"))
+}
+
+func TestCompress_SkipsSmallAndCompressedResponses(t *testing.T) {
+	r := newCompressRouter()
+
+	w := serveCompressed(r, http.MethodGet, "/small", "gzip")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.JSONEq(t, `{"ok":true}`, w.Body.String())
+
+	w = serveCompressed(r, http.MethodGet, "/qr.png", "gzip")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+
+	w = serveCompressed(r, http.MethodGet, "/empty", "gzip")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+
+	w = serveCompressed(r, http.MethodGet, "/large", "identity, gzip;q=0")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	assert.Equal(t, "gzip", negotiateEncoding("deflate, gzip"))
+	assert.Equal(t, "gzip", negotiateEncoding("*"))
+	assert.Equal(t, "deflate", negotiateEncoding("*, gzip;q=0"))
+	assert.Equal(t, "deflate", negotiateEncoding("gzip;q=0, *"))
+	assert.Equal(t, "", negotiateEncoding("br"))
+	assert.Equal(t, "", negotiateEncoding(""))
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type DeprecationOptions struct {
-	Replacement    string
+	Replacement string
+	// Sunset is when the endpoint goes away; zero while no date is set, and no Sunset header is sent
 	Sunset         time.Time
 	EndpointFamily string
 	Mode           string
@@ -18,7 +19,10 @@ type DeprecationOptions struct {
 func DeprecationMiddleware(opts DeprecationOptions) gin.HandlerFunc {
 	replacement := opts.Replacement
 	endpointFamily := opts.EndpointFamily
-	sunset := opts.Sunset.UTC().Format(time.RFC1123)
+	sunset := ""
+	if !opts.Sunset.IsZero() {
+		sunset = opts.Sunset.UTC().Format(time.RFC1123)
+	}
 	mode := NormalizeLegacyMode(opts.Mode)
 
 	return func(c *gin.Context) {
@@ -33,7 +37,9 @@ func DeprecationMiddleware(opts DeprecationOptions) gin.HandlerFunc {
 		recordLegacyEndpointHit(endpointFamily, replacement, opts.Sunset.UTC(), mode, merchantID)
 
 		c.Header("Deprecation", "true")
-		c.Header("Sunset", sunset)
+		if sunset != "" {
+			c.Header("Sunset", sunset)
+		}
 		c.Header("Link", "<"+replacement+">; rel=\"successor-version\"")
 		c.Header("X-Deprecated-Replaced-By", replacement)
 		c.Header("X-Legacy-Endpoint-Mode", mode)
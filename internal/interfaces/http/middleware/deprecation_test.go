@@ -78,3 +78,28 @@ func TestDeprecationMiddleware_DisabledModeBlocksRoute(t *testing.T) {
 		t.Fatalf("expected disabled mode header")
 	}
 }
+
+func TestDeprecationMiddleware_OmitsSunsetUntilScheduled(t *testing.T) {
+	resetLegacyEndpointObservabilityForTests()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/v1/payments", DeprecationMiddleware(DeprecationOptions{
+		Replacement:    "/api/v2/payments",
+		EndpointFamily: "v1_payments_create",
+	}), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/payments", nil))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rec.Code)
+	}
+	if rec.Header().Get("Deprecation") != "true" || rec.Header().Get("Link") != `</api/v2/payments>; rel="successor-version"` {
+		t.Fatalf("unexpected deprecation headers: %v", rec.Header())
+	}
+	if _, ok := rec.Header()["Sunset"]; ok {
+		t.Fatalf("expected no sunset header, got %q", rec.Header().Get("Sunset"))
+	}
+}
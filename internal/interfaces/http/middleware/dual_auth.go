@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"io"
 	"log"
 	"net/http"
@@ -11,6 +12,8 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/jwt"
@@ -54,6 +57,9 @@ func DualAuthMiddleware(jwtService *jwt.JWTService, apiKeyUsecase *usecases.ApiK
 
 			if err != nil {
 				log.Printf("[DualAuth] API Key validation failed: %v", err)
+				if abortIfSuspended(c, err) {
+					return
+				}
 				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API Key or Signature"})
 				return
 			}
@@ -69,6 +75,8 @@ func DualAuthMiddleware(jwtService *jwt.JWTService, apiKeyUsecase *usecases.ApiK
 			c.Set(UserIDKey, user.ID)
 			c.Set(UserEmailKey, user.Email)
 			c.Set(UserRoleKey, string(user.Role))
+			c.Set(APIKeyAuthKey, true)
+			setPaymentMode(c, entities.PaymentModeFromAPIKey(apiKey))
 			c.Next()
 			return
 		}
@@ -88,8 +96,8 @@ func DualAuthMiddleware(jwtService *jwt.JWTService, apiKeyUsecase *usecases.ApiK
 			}
 		}
 
-		// Legacy fallback (non-strict mode only)
-		if tokenString == "" && !strictSessionMode && authHeader != "" && strings.HasPrefix(authHeader, BearerPrefix) {
+		// Legacy fallback (non-strict mode, or JWT-only while Redis is down)
+		if tokenString == "" && (!strictSessionMode || !redis.Available()) && authHeader != "" && strings.HasPrefix(authHeader, BearerPrefix) {
 			tokenString = strings.TrimPrefix(authHeader, BearerPrefix)
 		}
 
@@ -106,6 +114,11 @@ func DualAuthMiddleware(jwtService *jwt.JWTService, apiKeyUsecase *usecases.ApiK
 				return
 			}
 
+			// The signature checks below look the user up too, but session requests may skip them
+			if apiKeyUsecase != nil && abortIfTokenUserSuspended(c, apiKeyUsecase, claims) {
+				return
+			}
+
 			// Signature is required for direct JWT requests, but optional for trusted
 			// proxy session flow (session_id -> Redis access token).
 			if !tokenFromTrustedSession {
@@ -125,6 +138,9 @@ func DualAuthMiddleware(jwtService *jwt.JWTService, apiKeyUsecase *usecases.ApiK
 				)
 				if err != nil {
 					log.Printf("[DualAuth] JWT Signature validation failed: %v", err)
+					if abortIfSuspended(c, err) {
+						return
+					}
 					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Signature for JWT user"})
 					return
 				}
@@ -141,6 +157,9 @@ func DualAuthMiddleware(jwtService *jwt.JWTService, apiKeyUsecase *usecases.ApiK
 				)
 				if err != nil {
 					log.Printf("[DualAuth] JWT Signature validation failed (session flow): %v", err)
+					if abortIfSuspended(c, err) {
+						return
+					}
 					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Signature for JWT user"})
 					return
 				}
@@ -150,6 +169,15 @@ func DualAuthMiddleware(jwtService *jwt.JWTService, apiKeyUsecase *usecases.ApiK
 			c.Set(UserIDKey, claims.UserID)
 			c.Set(UserEmailKey, claims.Email)
 			c.Set(UserRoleKey, claims.Role)
+			if !applyImpersonation(c, claims) {
+				return
+			}
+			mode, ok := sessionPaymentMode(c)
+			if !ok {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "mode must be live or test"})
+				return
+			}
+			setPaymentMode(c, mode)
 			if merchantRepo != nil && shouldResolveMerchantContext(c.Request.URL.Path) {
 				merchant, mErr := merchantRepo.GetByUserID(c.Request.Context(), claims.UserID)
 				if mErr == nil && merchant != nil {
@@ -168,18 +196,76 @@ func DualAuthMiddleware(jwtService *jwt.JWTService, apiKeyUsecase *usecases.ApiK
 	}
 }
 
+// abortIfSuspended answers 403 when err says the account is suspended, so clients can tell
+// it apart from a bad key or signature
+func abortIfSuspended(c *gin.Context, err error) bool {
+	if !errors.Is(err, domainerrors.ErrAccountSuspended) {
+		return false
+	}
+	suspended := domainerrors.AccountSuspended()
+	c.AbortWithStatusJSON(suspended.Status, gin.H{
+		"code":    suspended.Code,
+		"message": suspended.Message,
+		"error":   suspended.Message,
+	})
+	return true
+}
+
+// setPaymentMode records the request's payment mode for handlers and on the request context,
+// where the payment usecases read it, so every route behind the middleware gets the same mode
+func setPaymentMode(c *gin.Context, mode entities.PaymentMode) {
+	c.Set(PaymentModeKey, mode)
+	c.Request = c.Request.WithContext(usecases.WithPaymentMode(c.Request.Context(), mode))
+}
+
+// sessionPaymentMode picks the mode of a JWT request. Sessions have no key prefix, so the
+// dashboard asks for test mode with ?mode=test; without it the request is live. It returns
+// false for any other value.
+func sessionPaymentMode(c *gin.Context) (entities.PaymentMode, bool) {
+	raw := c.Query("mode")
+	if raw == "" {
+		return entities.PaymentModeLive, true
+	}
+	return entities.ParsePaymentMode(raw)
+}
+
 func sha256Hex(data []byte) string {
 	hash := sha256.Sum256(data)
 	return hex.EncodeToString(hash[:])
 }
 
+// shouldResolveMerchantContext reports whether path serves merchants, under any API version
 func shouldResolveMerchantContext(path string) bool {
-	normalized := strings.ToLower(strings.TrimSpace(path))
-	if normalized == "" {
+	normalized, ok := unversionedAPIPath(strings.ToLower(strings.TrimSpace(path)))
+	if !ok {
 		return false
 	}
-	if strings.HasPrefix(normalized, "/api/v1/merchants/") {
+	if strings.HasPrefix(normalized, "/merchants/") {
 		return true
 	}
-	return strings.HasPrefix(normalized, "/api/v1/create-payment")
+	// The payment list looks up payments by merchant order id
+	if normalized == "/payments" {
+		return true
+	}
+	// Merchants may download receipts for payments made to them
+	if strings.HasPrefix(normalized, "/payments/") && strings.HasSuffix(normalized, "/receipt.pdf") {
+		return true
+	}
+	return strings.HasPrefix(normalized, "/create-payment")
+}
+
+// unversionedAPIPath strips the /api/v<n> prefix from path, reporting whether it had one
+func unversionedAPIPath(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, "/api/v")
+	if !ok {
+		return "", false
+	}
+	digits := 0
+	for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+		digits++
+	}
+	if digits == 0 || (digits < len(rest) && rest[digits] != '/') {
+		return "", false
+	}
+	return rest[digits:], true
 }
@@ -29,7 +29,7 @@ func TestDualAuthMiddleware_ExtraBranches(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 	jwtService := jwt.NewJWTService("secret", time.Hour, time.Hour*24)
 
 	srv, err := miniredis.Run()
@@ -275,7 +275,7 @@ func TestDualAuthMiddleware_TrustedSession_WithBodyAndOptionalSignatureValidatio
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 	jwtService := jwt.NewJWTService("secret", time.Hour, time.Hour*24)
 
 	srv, err := miniredis.Run()
@@ -28,7 +28,7 @@ func TestDualAuthMiddleware_TrustedSession_WithOptionalSignatureValidationSucces
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 	jwtService := jwt.NewJWTService("secret", time.Hour, time.Hour*24)
 
 	mr, err := miniredis.Run()
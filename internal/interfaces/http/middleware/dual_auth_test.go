@@ -25,10 +25,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/interfaces/http/middleware"
 	"payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/jwt"
 	redispkg "payment-kita.backend/pkg/redis"
+	"payment-kita.backend/pkg/utils"
 )
 
 type errorReadCloser struct{}
@@ -81,8 +84,8 @@ func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, p
 	return nil
 }
 func (m *MockUserRepository) SoftDelete(ctx context.Context, id uuid.UUID) error { return nil }
-func (m *MockUserRepository) List(ctx context.Context, s string) ([]*entities.User, error) {
-	return nil, nil
+func (m *MockUserRepository) List(ctx context.Context, filter repositories.UserFilter, pagination utils.PaginationParams) ([]*entities.User, int64, error) {
+	return nil, 0, nil
 }
 
 type MockMerchantRepository struct{ mock.Mock }
@@ -128,7 +131,7 @@ func TestDualAuthMiddleware_ApiKey(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	// 32 bytes for AES-256
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	jwtService := jwt.NewJWTService("secret", time.Hour, time.Hour*24)
 	// sessionStore would need redis, let's keep it nil for now if the middleware handles it
@@ -140,10 +143,12 @@ func TestDualAuthMiddleware_ApiKey(t *testing.T) {
 		userID, _ := c.Get(middleware.UserIDKey)
 		merchantID, _ := c.Get(middleware.MerchantIDKey)
 		isMerchant, _ := c.Get(middleware.IsMerchantAuthenticatedKey)
+		mode, _ := middleware.GetPaymentMode(c)
 		c.JSON(http.StatusOK, gin.H{
 			"userId":     userID,
 			"merchantId": merchantID,
 			"isMerchant": isMerchant,
+			"mode":       mode,
 		})
 	})
 
@@ -192,6 +197,16 @@ func TestDualAuthMiddleware_ApiKey(t *testing.T) {
 	assert.Equal(t, userID.String(), resp["userId"])
 	assert.Equal(t, merchantID.String(), resp["merchantId"])
 	assert.True(t, resp["isMerchant"].(bool))
+	// Only a pk_test_ prefix makes a test-mode key
+	assert.Equal(t, "live", resp["mode"])
+
+	// Once the owner is suspended the same key is refused with its own code
+	suspendedAt := time.Now()
+	keyEntity.User.SuspendedAt = &suspendedAt
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), domainerrors.CodeAccountSuspended)
 }
 
 func TestDualAuthMiddleware_JWT(t *testing.T) {
@@ -230,7 +245,7 @@ func TestDualAuthMiddleware_JWTWithSignature(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	jwtService := jwt.NewJWTService("secret", time.Hour, time.Hour*24)
 
@@ -291,6 +306,55 @@ func TestDualAuthMiddleware_JWTWithSignature(t *testing.T) {
 	assert.True(t, resp["isMerchant"].(bool))
 }
 
+func TestDualAuthMiddleware_JWTPaymentMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("INTERNAL_PROXY_SECRET", "")
+
+	mockApiKeyRepo := new(MockApiKeyRepository)
+	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, new(MockUserRepository), encryptionKey, "")
+	jwtService := jwt.NewJWTService("secret", time.Hour, time.Hour*24)
+
+	r := gin.New()
+	r.Use(middleware.DualAuthMiddleware(jwtService, apiKeyUsecase, new(MockMerchantRepository), nil))
+	r.GET("/test", func(c *gin.Context) {
+		mode, _ := middleware.GetPaymentMode(c)
+		c.JSON(http.StatusOK, gin.H{"mode": mode})
+	})
+
+	userID := uuid.New()
+	secretKey := "sk_live_secret"
+	tokens, _ := jwtService.GenerateTokenPair(userID, "test@example.com", "USER")
+	encryptedSecret, _ := encryptTest(secretKey, encryptionKey)
+	mockApiKeyRepo.On("FindByUserID", mock.Anything, userID).Return([]*entities.ApiKey{{ID: uuid.New(), UserID: userID, SecretEncrypted: encryptedSecret, IsActive: true}}, nil)
+	mockApiKeyRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	send := func(uri string) *httptest.ResponseRecorder {
+		timestamp := fmt.Sprintf("%d", time.Now().Unix())
+		signature := hmacSha256Hex(secretKey, timestamp+"GET"+uri+sha256Hex([]byte("")))
+		req, _ := http.NewRequest("GET", uri, nil)
+		req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		req.Header.Set("X-Signature", signature)
+		req.Header.Set("X-Timestamp", timestamp)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Sessions are live unless they ask for test mode
+	w := send("/test")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"mode":"live"}`, w.Body.String())
+
+	w = send("/test?mode=TEST")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"mode":"test"}`, w.Body.String())
+
+	w = send("/test?mode=staging")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "mode must be live or test")
+}
+
 func TestDualAuthMiddleware_RequestBodyReadError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	jwtService := jwt.NewJWTService("secret", time.Hour, time.Hour*24)
@@ -315,7 +379,7 @@ func TestDualAuthMiddleware_ApiKeyInvalid(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 	jwtService := jwt.NewJWTService("secret", time.Hour, time.Hour*24)
 
 	r := gin.New()
@@ -406,7 +470,7 @@ func TestDualAuthMiddleware_JWTInvalidSignature(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 	jwtService := jwt.NewJWTService("secret", time.Hour, time.Hour*24)
 
 	r := gin.New()
@@ -472,7 +536,7 @@ func TestDualAuthMiddleware_TrustedSessionFlow_AndOptionalSignature(t *testing.T
 	// case 2: trusted session + provided bad signature should be rejected
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	apiKeyUsecase := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 	mockApiKeyRepo.On("FindByUserID", mock.Anything, userID).Return([]*entities.ApiKey{}, nil).Once()
 
 	r2 := gin.New()
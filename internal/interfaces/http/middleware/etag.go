@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag adds an ETag, a hash of the response body, to successful GETs and answers 304 without a
+// body when If-None-Match already carries it. Meant for config reads that rarely change: the
+// handler still runs, but an unchanged result costs the client no transfer. A handler that
+// knows its version cheaper than a hash can set the ETag header itself and it is used as is.
+// The ETag is made weak whenever the client accepts an encoding Compress may apply, since the
+// bytes sent then differ from the representation the ETag names.
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		writer := &etagWriter{ResponseWriter: c.Writer, weak: negotiateEncoding(c.GetHeader("Accept-Encoding")) != ""}
+		c.Writer = writer
+		defer func() { c.Writer = writer.ResponseWriter }()
+		c.Next()
+		writer.finish(c.GetHeader("If-None-Match"))
+	}
+}
+
+// etagWriter holds the whole body back so its ETag can be sent before it
+type etagWriter struct {
+	gin.ResponseWriter
+	body    bytes.Buffer
+	written bool
+	// weak forces a weak validator, for responses that may be compressed
+	weak bool
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	w.written = true
+	return w.body.Write(b)
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+// WriteHeaderNow is deferred until finish; the status is already recorded
+func (w *etagWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *etagWriter) Written() bool {
+	return w.written || w.ResponseWriter.Written()
+}
+
+// Flush is deferred until finish as well: the ETag covers the whole body
+func (w *etagWriter) Flush() {}
+
+func (w *etagWriter) finish(ifNoneMatch string) {
+	if w.Status() != http.StatusOK {
+		w.writeBody()
+		return
+	}
+	header := w.Header()
+	etag := header.Get("ETag")
+	if etag == "" {
+		sum := sha256.Sum256(w.body.Bytes())
+		etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	} else if w.weak && !strings.HasPrefix(etag, "W/") {
+		etag = "W/" + etag
+	}
+	header.Set("ETag", etag)
+	if header.Get("Cache-Control") == "" {
+		header.Set("Cache-Control", "no-cache")
+	}
+	if !ETagMatches(ifNoneMatch, etag) {
+		w.writeBody()
+		return
+	}
+	header.Del("Content-Type")
+	header.Del("Content-Length")
+	w.ResponseWriter.WriteHeader(http.StatusNotModified)
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *etagWriter) writeBody() {
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	} else if w.written {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// ETagMatches reports whether an If-None-Match header lists etag. The comparison is weak, as
+// RFC 9110 requires for If-None-Match, so W/"x" and "x" match each other.
+func ETagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETag_RevalidatesUnchangedResponses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	calls := 0
+	chains := gin.H{"items": []string{"eip155:8453"}}
+	r := gin.New()
+	r.Use(ETag())
+	r.GET("/chains", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusOK, chains)
+	})
+	r.GET("/missing", func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{"error": "missing"}) })
+	get := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/chains", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"items":["eip155:8453"]}`, w.Body.String())
+
+	w = get("/chains", `"other", `+etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Equal(t, 2, calls)
+
+	chains["items"] = []string{"eip155:8453", "eip155:1"}
+	w = get("/chains", etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	w = get("/missing", "*")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), "missing")
+}
+
+func TestETagMatches(t *testing.T) {
+	assert.True(t, ETagMatches(`"abc"`, `W/"abc"`))
+	assert.True(t, ETagMatches(`W/"abc"`, `W/"abc"`))
+	assert.True(t, ETagMatches(`*`, `W/"abc"`))
+	assert.False(t, ETagMatches(`"abd"`, `W/"abc"`))
+	assert.False(t, ETagMatches(``, `W/"abc"`))
+}
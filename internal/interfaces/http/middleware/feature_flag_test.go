@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+type featureCheckerStub map[string]bool
+
+func (s featureCheckerStub) IsFeatureEnabled(_ context.Context, name string, merchantID *uuid.UUID) bool {
+	if merchantID != nil {
+		if enabled, ok := s[name+":"+merchantID.String()]; ok {
+			return enabled
+		}
+	}
+	return s[name]
+}
+
+func TestRequireFeature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	merchantID := uuid.New()
+
+	newRouter := func(checker FeatureChecker, withMerchant bool) *gin.Engine {
+		r := gin.New()
+		r.GET("/refunds", func(c *gin.Context) {
+			if withMerchant {
+				c.Set(MerchantIDKey, merchantID)
+			}
+			c.Next()
+		}, RequireFeature(checker, "refunds"), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+		return r
+	}
+	do := func(r *gin.Engine) int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/refunds", nil))
+		return w.Code
+	}
+
+	// No checker configured: routes stay dark.
+	require.Equal(t, http.StatusNotFound, do(newRouter(nil, false)))
+
+	overridden := featureCheckerStub{"refunds": false, "refunds:" + merchantID.String(): true}
+	require.Equal(t, http.StatusNotFound, do(newRouter(overridden, false)))
+	require.Equal(t, http.StatusNoContent, do(newRouter(overridden, true)))
+
+	require.Equal(t, http.StatusNoContent, do(newRouter(featureCheckerStub{"refunds": true}, false)))
+}
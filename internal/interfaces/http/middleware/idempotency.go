@@ -1,13 +1,19 @@
 package middleware
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"payment-kita.backend/pkg/logger"
 	"payment-kita.backend/pkg/redis"
 )
 
@@ -18,6 +24,12 @@ const (
 	IdempotencyTTL = 24 * time.Hour
 	// MaxIdempotencyKeyLength is the maximum allowed length for idempotency key
 	MaxIdempotencyKeyLength = 256
+
+	// IdempotencyReplayedHeader marks a response served from the idempotency cache
+	IdempotencyReplayedHeader = "Idempotency-Replayed"
+
+	// IdempotencyOriginalTimestampHeader carries when the replayed request was first received
+	IdempotencyOriginalTimestampHeader = "Idempotency-Original-Timestamp"
 )
 
 // IdempotencyMiddleware creates a middleware that prevents duplicate requests
@@ -36,19 +48,43 @@ func IdempotencyMiddleware() gin.HandlerFunc {
 		// Validate key length
 		if len(idempotencyKey) > MaxIdempotencyKeyLength {
 			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Idempotency key too long",
+				"error":      "Idempotency key too long",
 				"max_length": MaxIdempotencyKeyLength,
 			})
 			c.Abort()
 			return
 		}
 
+		// Without Redis the key cannot be tracked; serve the request rather than refuse it
+		if !redis.Available() {
+			logger.Warn(c.Request.Context(), "Redis unavailable, idempotency key not enforced",
+				zap.String("path", c.Request.URL.Path))
+			c.Next()
+			return
+		}
+
 		// Generate fingerprint from request
 		fingerprint := generateRequestFingerprint(c, idempotencyKey)
-
-		// Try to acquire lock in Redis
+		bodyHash := requestBodyHash(c)
+		cacheKey := "idem:cache:" + fingerprint
 		lockKey := "idem:lock:" + fingerprint
-		acquired, err := redis.SetNX(c.Request.Context(), lockKey, "1", IdempotencyTTL)
+
+		// Replay a completed request, unless the key is being reused for a different body
+		if cached, err := redis.Get(c.Request.Context(), cacheKey); err == nil && cached != "" {
+			record := decodeIdempotencyRecord(cached)
+			if record.BodyHash != "" && record.BodyHash != bodyHash {
+				abortIdempotencyMismatch(c, idempotencyKey, record.CreatedAt)
+				return
+			}
+			replayIdempotencyRecord(c, record)
+			return
+		}
+
+		// Try to acquire lock in Redis. The lock remembers which body holds the key so a
+		// concurrent request with a different body is reported as a mismatch, not in-flight.
+		receivedAt := time.Now().UTC().Format(time.RFC3339)
+		lockValue := bodyHash + "|" + receivedAt
+		acquired, err := redis.SetNX(c.Request.Context(), lockKey, lockValue, IdempotencyTTL)
 		if err != nil {
 			// Redis error, fail open to avoid blocking legitimate requests
 			c.Next()
@@ -56,21 +92,17 @@ func IdempotencyMiddleware() gin.HandlerFunc {
 		}
 
 		if !acquired {
-			// Request is being processed or was recently processed
-			// Try to get cached response
-			cacheKey := "idem:cache:" + fingerprint
-			cachedResponse, err := redis.Get(c.Request.Context(), cacheKey)
-			if err == nil && cachedResponse != "" {
-				// Return cached response
-				c.Data(http.StatusOK, "application/json", []byte(cachedResponse))
-				c.Abort()
-				return
+			if held, err := redis.Get(c.Request.Context(), lockKey); err == nil {
+				heldHash, heldAt, _ := strings.Cut(held, "|")
+				if heldHash != bodyHash && heldAt != "" {
+					abortIdempotencyMismatch(c, idempotencyKey, heldAt)
+					return
+				}
 			}
-
 			// Return conflict error
 			c.JSON(http.StatusConflict, gin.H{
-				"error": "Duplicate request detected",
-				"message": "A request with this idempotency key is already being processed",
+				"error":       "Duplicate request detected",
+				"message":     "A request with this idempotency key is already being processed",
 				"retry_after": 5, // seconds
 			})
 			c.Abort()
@@ -90,10 +122,18 @@ func IdempotencyMiddleware() gin.HandlerFunc {
 		// Cache the response for future duplicate requests
 		if writer.status >= 200 && writer.status < 300 {
 			// Only cache successful responses
-			cacheKey := "idem:cache:" + fingerprint
 			if len(writer.body) > 0 {
-				// Cache response for TTL duration
-				redis.SetEX(c.Request.Context(), cacheKey, string(writer.body), IdempotencyTTL)
+				record := idempotencyRecord{
+					Status:      writer.status,
+					ContentType: writer.Header().Get("Content-Type"),
+					Body:        string(writer.body),
+					BodyHash:    bodyHash,
+					CreatedAt:   receivedAt,
+				}
+				if encoded, err := json.Marshal(record); err == nil {
+					// Cache response for TTL duration
+					redis.SetEX(c.Request.Context(), cacheKey, string(encoded), IdempotencyTTL)
+				}
 			}
 		}
 
@@ -102,17 +142,71 @@ func IdempotencyMiddleware() gin.HandlerFunc {
 	}
 }
 
+// idempotencyRecord is the cached outcome of the first request made with a key
+type idempotencyRecord struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body"`
+	BodyHash    string `json:"bodyHash"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// decodeIdempotencyRecord reads a cache entry. Entries written before records existed hold the
+// raw response body and replay as a 200 without a mismatch check.
+func decodeIdempotencyRecord(cached string) idempotencyRecord {
+	var record idempotencyRecord
+	if err := json.Unmarshal([]byte(cached), &record); err != nil || record.Status == 0 {
+		return idempotencyRecord{Status: http.StatusOK, Body: cached}
+	}
+	return record
+}
+
+func replayIdempotencyRecord(c *gin.Context, record idempotencyRecord) {
+	contentType := record.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	c.Header(IdempotencyReplayedHeader, "true")
+	if record.CreatedAt != "" {
+		c.Header(IdempotencyOriginalTimestampHeader, record.CreatedAt)
+	}
+	c.Data(record.Status, contentType, []byte(record.Body))
+	c.Abort()
+}
+
+func abortIdempotencyMismatch(c *gin.Context, idempotencyKey, originalAt string) {
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"error":               "Idempotency key reused",
+		"code":                "IDEMPOTENCY_KEY_MISMATCH",
+		"message":             "This idempotency key was already used with a different request body; use a new key for a new request",
+		"idempotency_key":     idempotencyKey,
+		"original_request_at": originalAt,
+	})
+	c.Abort()
+}
+
+// requestBodyHash hashes the request body and restores it for the handler
+func requestBodyHash(c *gin.Context) string {
+	var body []byte
+	if c.Request.Body != nil {
+		body, _ = io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:])
+}
+
 // generateRequestFingerprint creates a unique fingerprint for the request
 func generateRequestFingerprint(c *gin.Context, idempotencyKey string) string {
 	// Combine idempotency key with request path and merchant ID (if available)
 	merchantID := ""
-	if val, exists := c.Get("MerchantID"); exists {
-		merchantID = val.(string)
+	if id, ok := GetMerchantID(c); ok {
+		merchantID = id.String()
 	}
 
 	userID := ""
-	if val, exists := c.Get("UserID"); exists {
-		userID = val.(string)
+	if id, ok := GetUserID(c); ok {
+		userID = id.String()
 	}
 
 	// Create fingerprint from: idempotency_key + path + merchant_id + user_id
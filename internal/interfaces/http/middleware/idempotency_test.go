@@ -10,6 +10,7 @@ import (
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"payment-kita.backend/pkg/redis"
 )
@@ -155,6 +156,25 @@ func TestIdempotencyMiddleware_RejectsTooLongKey(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestIdempotencyMiddleware_RedisUnavailablePassesThrough(t *testing.T) {
+	redis.SetAvailable(false)
+	t.Cleanup(func() { redis.SetAvailable(true) })
+
+	router := setupTestRouter()
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("POST", "/test-payment", bytes.NewBuffer([]byte(`{"amount": "100.00"}`)))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(IdempotencyKeyHeader, "idem_degraded")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Neither request is replayed nor reported as in flight
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Empty(t, w.Header().Get(IdempotencyReplayedHeader))
+	}
+}
+
 func TestValidateIdempotencyKey(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -197,3 +217,101 @@ func TestGenerateIdempotencyKey(t *testing.T) {
 	assert.NoError(t, ValidateIdempotencyKey(key1))
 	assert.NoError(t, ValidateIdempotencyKey(key2))
 }
+
+func TestIdempotencyMiddleware_ReplayHeadersAndBodyMismatch(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.NoError(t, err)
+	defer mr.Close()
+	assert.NoError(t, redis.Init("redis://"+mr.Addr(), ""))
+
+	calls := 0
+	router := gin.New()
+	router.Use(IdempotencyMiddleware())
+	router.POST("/test-payment", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"payment_id": "pay_123", "call": calls})
+	})
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", "/test-payment", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(IdempotencyKeyHeader, "idem_replay")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := send(`{"amount": "100.00"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotencyReplayedHeader))
+
+	replay := send(`{"amount": "100.00"}`)
+	assert.Equal(t, http.StatusCreated, replay.Code)
+	assert.Equal(t, "true", replay.Header().Get(IdempotencyReplayedHeader))
+	_, err = time.Parse(time.RFC3339, replay.Header().Get(IdempotencyOriginalTimestampHeader))
+	assert.NoError(t, err)
+	assert.Equal(t, first.Body.String(), replay.Body.String())
+	assert.Equal(t, 1, calls)
+
+	mismatch := send(`{"amount": "999.00"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, mismatch.Code)
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(mismatch.Body.Bytes(), &body))
+	assert.Equal(t, "IDEMPOTENCY_KEY_MISMATCH", body["code"])
+	assert.Equal(t, "idem_replay", body["idempotency_key"])
+	assert.Equal(t, replay.Header().Get(IdempotencyOriginalTimestampHeader), body["original_request_at"])
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotencyMiddleware_InFlightMismatchAndLegacyCache(t *testing.T) {
+	mr, err := miniredis.Run()
+	assert.NoError(t, err)
+	defer mr.Close()
+	assert.NoError(t, redis.Init("redis://"+mr.Addr(), ""))
+
+	router := setupTestRouter()
+	newRequest := func(key, body string) *http.Request {
+		req, _ := http.NewRequest("POST", "/test-payment", bytes.NewBufferString(body))
+		req.Header.Set(IdempotencyKeyHeader, key)
+		return req
+	}
+	fingerprintOf := func(req *http.Request) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = req
+		return generateRequestFingerprint(c, req.Header.Get(IdempotencyKeyHeader))
+	}
+
+	// A request still holding the lock with another body is a mismatch, not a retry-later
+	inflight := newRequest("idem_inflight", `{"amount": "1"}`)
+	assert.NoError(t, mr.Set("idem:lock:"+fingerprintOf(inflight), "otherhash|2026-01-01T00:00:00Z"))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, inflight)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// Entries cached before replay records existed still replay
+	legacy := newRequest("idem_legacy", `{"amount": "1"}`)
+	assert.NoError(t, mr.Set("idem:cache:"+fingerprintOf(legacy), `{"payment_id":"pay_old"}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, legacy)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get(IdempotencyReplayedHeader))
+	assert.JSONEq(t, `{"payment_id":"pay_old"}`, w.Body.String())
+}
+
+func TestGenerateRequestFingerprint_ScopedToCaller(t *testing.T) {
+	fingerprint := func(set func(c *gin.Context)) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest("POST", "/test-payment", nil)
+		set(c)
+		return generateRequestFingerprint(c, "idem_shared")
+	}
+
+	merchantA := fingerprint(func(c *gin.Context) { c.Set(MerchantIDKey, uuid.New()) })
+	merchantB := fingerprint(func(c *gin.Context) { c.Set(MerchantIDKey, uuid.New()) })
+	user := fingerprint(func(c *gin.Context) { c.Set(UserIDKey, uuid.New()) })
+	anonymous := fingerprint(func(*gin.Context) {})
+
+	assert.NotEqual(t, merchantA, merchantB)
+	assert.NotEqual(t, merchantA, anonymous)
+	assert.NotEqual(t, user, anonymous)
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"payment-kita.backend/internal/domain"
+	"payment-kita.backend/pkg/jwt"
+	"payment-kita.backend/pkg/logger"
+)
+
+const (
+	// ImpersonatorIDKey is the context key for the admin acting as the authenticated user
+	ImpersonatorIDKey = "impersonatorId"
+	// ImpersonatorEmailKey is the context key for the impersonating admin's email
+	ImpersonatorEmailKey = "impersonatorEmail"
+	// ImpersonationTargetKey is set by the handler that starts an impersonation session
+	ImpersonationTargetKey = "impersonationTarget"
+
+	auditActionImpersonationStarted = "IMPERSONATION_STARTED"
+	auditActionImpersonatedRequest  = "IMPERSONATED_REQUEST"
+)
+
+// applyImpersonation marks the request as impersonated and enforces read-mostly access.
+// It returns false when the request was aborted.
+func applyImpersonation(c *gin.Context, claims *jwt.Claims) bool {
+	if !claims.IsImpersonation() {
+		return true
+	}
+
+	c.Set(ImpersonatorIDKey, *claims.ImpersonatorID)
+	c.Set(ImpersonatorEmailKey, claims.ImpersonatorEmail)
+	logger.Warn(c.Request.Context(), "Request under impersonation",
+		zap.String("impersonator_id", claims.ImpersonatorID.String()),
+		zap.String("impersonator_email", claims.ImpersonatorEmail),
+		zap.String("user_id", claims.UserID.String()),
+		zap.String("method", c.Request.Method),
+		zap.String("path", c.Request.URL.Path),
+	)
+
+	if isSafeMethod(c.Request.Method) {
+		return true
+	}
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error": "This action is not allowed while impersonating a user",
+	})
+	return false
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// GetImpersonatorID gets the impersonating admin's ID, if the request is impersonated
+func GetImpersonatorID(c *gin.Context) (uuid.UUID, bool) {
+	id, exists := c.Get(ImpersonatorIDKey)
+	if !exists {
+		return uuid.Nil, false
+	}
+	return id.(uuid.UUID), true
+}
+
+// ImpersonationAuditMiddleware writes an audit log row for every request made under
+// impersonation, including blocked ones, and for every impersonation session started.
+func ImpersonationAuditMiddleware(repo domain.AuditLogRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		var entry *domain.AuditLog
+		userID, _ := GetUserID(c)
+		if impersonatorID, ok := GetImpersonatorID(c); ok {
+			entry = &domain.AuditLog{UserID: &userID, ImpersonatorID: &impersonatorID, Action: auditActionImpersonatedRequest}
+		} else if raw, ok := c.Get(ImpersonationTargetKey); ok {
+			target := raw.(uuid.UUID)
+			entry = &domain.AuditLog{UserID: &target, ImpersonatorID: &userID, Action: auditActionImpersonationStarted}
+		}
+		if entry == nil || repo == nil {
+			return
+		}
+
+		entry.ID = uuid.New()
+		if merchantID, ok := c.Get(MerchantIDKey); ok {
+			if id, ok := merchantID.(uuid.UUID); ok {
+				entry.MerchantID = &id
+			}
+		}
+		entry.Path = c.Request.URL.Path
+		entry.Method = c.Request.Method
+		entry.StatusCode = c.Writer.Status()
+		entry.IPAddress = c.ClientIP()
+		entry.Duration = time.Since(start).Seconds()
+		entry.CreatedAt = time.Now()
+
+		if err := repo.Create(c.Request.Context(), entry); err != nil {
+			logger.Error(c.Request.Context(), "Failed to write impersonation audit log", zap.Error(err))
+		}
+	}
+}
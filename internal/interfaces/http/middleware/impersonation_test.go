@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain"
+	"payment-kita.backend/pkg/jwt"
+)
+
+type auditLogRepoStub struct {
+	logs []*domain.AuditLog
+}
+
+func (s *auditLogRepoStub) Create(_ context.Context, log *domain.AuditLog) error {
+	s.logs = append(s.logs, log)
+	return nil
+}
+
+func TestAuthMiddleware_ImpersonationIsReadOnlyAndAudited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtService := jwt.NewJWTService("secret", time.Minute, time.Hour)
+
+	prev := os.Getenv("INTERNAL_PROXY_SECRET")
+	t.Cleanup(func() {
+		_ = os.Setenv("INTERNAL_PROXY_SECRET", prev)
+	})
+	_ = os.Setenv("INTERNAL_PROXY_SECRET", "")
+
+	repo := &auditLogRepoStub{}
+	r := gin.New()
+	r.Use(ImpersonationAuditMiddleware(repo), AuthMiddleware(jwtService, nil))
+	r.GET("/me", func(c *gin.Context) {
+		impersonatorID, ok := GetImpersonatorID(c)
+		if !ok {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		c.String(http.StatusOK, impersonatorID.String())
+	})
+	r.POST("/me", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	userID := uuid.New()
+	adminID := uuid.New()
+	token, err := jwtService.GenerateImpersonationToken(userID, "u@paymentkita.io", "USER", adminID, "admin@paymentkita.io", time.Minute)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, adminID.String(), w.Body.String())
+
+	req = httptest.NewRequest(http.MethodPost, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	require.Len(t, repo.logs, 2)
+	for _, entry := range repo.logs {
+		require.Equal(t, auditActionImpersonatedRequest, entry.Action)
+		require.Equal(t, userID, *entry.UserID)
+		require.Equal(t, adminID, *entry.ImpersonatorID)
+	}
+	require.Equal(t, http.MethodPost, repo.logs[1].Method)
+	require.Equal(t, http.StatusForbidden, repo.logs[1].StatusCode)
+
+	// Regular sessions are neither restricted nor audited here.
+	pair, err := jwtService.GenerateTokenPair(userID, "u@paymentkita.io", "USER")
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPost, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+pair.AccessToken)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Len(t, repo.logs, 2)
+}
+
+func TestImpersonationAuditMiddleware_RecordsSessionStart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &auditLogRepoStub{}
+	adminID := uuid.New()
+	targetID := uuid.New()
+
+	r := gin.New()
+	r.Use(ImpersonationAuditMiddleware(repo))
+	r.POST("/impersonate", func(c *gin.Context) {
+		c.Set(UserIDKey, adminID)
+		c.Set(ImpersonationTargetKey, targetID)
+		c.Status(http.StatusCreated)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/impersonate", nil))
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Len(t, repo.logs, 1)
+	require.Equal(t, auditActionImpersonationStarted, repo.logs[0].Action)
+	require.Equal(t, targetID, *repo.logs[0].UserID)
+	require.Equal(t, adminID, *repo.logs[0].ImpersonatorID)
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+const defaultMaintenanceMessage = "Service is under maintenance, please retry later"
+
+// MaintenanceChecker reports whether maintenance mode is on, the Retry-After to announce and an
+// optional message for clients
+type MaintenanceChecker interface {
+	MaintenanceState(ctx context.Context) (enabled bool, retryAfter time.Duration, message string)
+}
+
+// maintenanceExemptRoutes stay writable during maintenance so an admin can sign in and turn it
+// off again
+var maintenanceExemptRoutes = map[string]bool{
+	"/api/v1/auth/login":        true,
+	"/api/v1/auth/refresh":      true,
+	"/api/v1/admin/maintenance": true,
+}
+
+// Maintenance answers 503 with Retry-After to writes (POST, PUT, PATCH, DELETE) while
+// maintenance mode is on. Reads, health checks and the routes needed to end maintenance are
+// still served. A nil checker never enters maintenance.
+func Maintenance(checker MaintenanceChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if maintenanceExemptRoutes[c.FullPath()] {
+			c.Next()
+			return
+		}
+		if checker == nil {
+			c.Next()
+			return
+		}
+		enabled, retryAfter, message := checker.MaintenanceState(c.Request.Context())
+		if !enabled {
+			c.Next()
+			return
+		}
+
+		if retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second)/time.Second)))
+		}
+		if message == "" {
+			message = defaultMaintenanceMessage
+		}
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"code":    domainerrors.CodeMaintenance,
+			"message": message,
+			"error":   message,
+		})
+	}
+}
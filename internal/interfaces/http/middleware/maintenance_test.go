@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type maintenanceCheckerStub struct {
+	enabled    bool
+	retryAfter time.Duration
+	message    string
+}
+
+func (s *maintenanceCheckerStub) MaintenanceState(context.Context) (bool, time.Duration, string) {
+	return s.enabled, s.retryAfter, s.message
+}
+
+func TestMaintenance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(checker MaintenanceChecker) *gin.Engine {
+		r := gin.New()
+		r.Use(Maintenance(checker))
+		ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+		r.GET("/health", ok)
+		r.POST("/api/v1/payments", ok)
+		r.DELETE("/api/v1/admin/chains/:id", ok)
+		r.POST("/api/v1/admin/maintenance", ok)
+		return r
+	}
+	checker := &maintenanceCheckerStub{}
+	r := newRouter(checker)
+	do := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+
+	// No checker configured, or maintenance off: everything is served
+	w := httptest.NewRecorder()
+	newRouter(nil).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/payments", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, http.StatusNoContent, do(http.MethodPost, "/api/v1/payments").Code)
+
+	checker.enabled, checker.retryAfter = true, 90*time.Second
+	w = do(http.MethodPost, "/api/v1/payments")
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Equal(t, "90", w.Header().Get("Retry-After"))
+	require.JSONEq(t, `{"code":"ERR_MAINTENANCE","message":"`+defaultMaintenanceMessage+`","error":"`+defaultMaintenanceMessage+`"}`, w.Body.String())
+
+	checker.message = "Database migration until 14:00 UTC"
+	w = do(http.MethodDelete, "/api/v1/admin/chains/1")
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Contains(t, w.Body.String(), checker.message)
+
+	// Reads and the way out of maintenance stay open
+	require.Equal(t, http.StatusNoContent, do(http.MethodGet, "/health").Code)
+	require.Equal(t, http.StatusNoContent, do(http.MethodPost, "/api/v1/admin/maintenance").Code)
+}
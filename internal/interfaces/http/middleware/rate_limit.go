@@ -16,7 +16,7 @@ import (
 func RateLimitMiddleware(identifier func(*gin.Context) string, limit int64, period time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := identifier(c)
-		if id == "" {
+		if id == "" || !redis.Available() {
 			c.Next()
 			return
 		}
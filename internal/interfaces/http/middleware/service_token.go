@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"payment-kita.backend/pkg/crypto"
+)
+
+// ServiceTokenAuth admits callers presenting token as a bearer credential. It authenticates a
+// backend service, such as the indexer, on the few routes it needs, without a user session or
+// admin role. An empty token disables the routes: every request gets 401.
+func ServiceTokenAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		presented := strings.TrimPrefix(c.GetHeader(AuthorizationHeader), BearerPrefix)
+		if token == "" || presented == "" || !crypto.ConstantTimeEqual(presented, token) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Invalid service token",
+			})
+			return
+		}
+		c.Next()
+	}
+}
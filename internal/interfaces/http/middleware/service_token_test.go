@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceTokenAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	do := func(token, authorization string) int {
+		r := gin.New()
+		r.GET("/feed", ServiceTokenAuth(token), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+		req := httptest.NewRequest(http.MethodGet, "/feed", nil)
+		if authorization != "" {
+			req.Header.Set(AuthorizationHeader, authorization)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	require.Equal(t, http.StatusNoContent, do("indexer-token", "Bearer indexer-token"))
+	require.Equal(t, http.StatusUnauthorized, do("indexer-token", "Bearer other-token"))
+	require.Equal(t, http.StatusUnauthorized, do("indexer-token", ""))
+	// Unconfigured: the route stays closed, even to an empty bearer
+	require.Equal(t, http.StatusUnauthorized, do("", "Bearer "))
+	require.Equal(t, http.StatusUnauthorized, do("", ""))
+}
@@ -75,7 +75,8 @@ func TestDualAuthMiddleware_SessionHookAndOptionalSignatureBranch(t *testing.T)
 	})
 
 	j := jwt.NewJWTService("secret", time.Hour, time.Hour)
-	pair, err := j.GenerateTokenPair(uuid.New(), "dual-session-hook@paymentkita.io", "USER")
+	userID := uuid.New()
+	pair, err := j.GenerateTokenPair(userID, "dual-session-hook@paymentkita.io", "USER")
 	require.NoError(t, err)
 
 	loadSessionFromStore = func(context.Context, *redis.SessionStore, string) (*redis.SessionData, error) {
@@ -84,8 +85,9 @@ func TestDualAuthMiddleware_SessionHookAndOptionalSignatureBranch(t *testing.T)
 
 	apiKeyUsecase := usecases.NewApiKeyUsecase(
 		internalApiKeyRepoStub{},
-		internalUserRepoStub{},
+		newKnownUserRepoStub(userID),
 		"00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff",
+		"",
 	)
 
 	_ = os.Setenv("INTERNAL_PROXY_SECRET", "proxy-secret")
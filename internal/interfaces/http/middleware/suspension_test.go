@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/jwt"
+	"payment-kita.backend/pkg/redis"
+)
+
+// knownUserRepoStub is internalUserRepoStub that knows some users and counts lookups
+type knownUserRepoStub struct {
+	internalUserRepoStub
+	mu      sync.Mutex
+	users   map[uuid.UUID]*entities.User
+	lookups int
+	err     error
+}
+
+func (s *knownUserRepoStub) GetByID(_ context.Context, id uuid.UUID) (*entities.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lookups++
+	if s.err != nil {
+		return nil, s.err
+	}
+	if user, ok := s.users[id]; ok {
+		copied := *user
+		return &copied, nil
+	}
+	return nil, domainerrors.ErrNotFound
+}
+
+func (s *knownUserRepoStub) suspend(id uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.users[id].SuspendedAt = &now
+}
+
+func newKnownUserRepoStub(ids ...uuid.UUID) *knownUserRepoStub {
+	repo := &knownUserRepoStub{users: make(map[uuid.UUID]*entities.User)}
+	for _, id := range ids {
+		repo.users[id] = &entities.User{ID: id}
+	}
+	return repo
+}
+
+func TestAuthMiddlewareWithSuspensionCheck_RejectsSuspendedBearer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("INTERNAL_PROXY_SECRET", "")
+
+	j := jwt.NewJWTService("secret", time.Hour, time.Hour)
+	userID, goneID := uuid.New(), uuid.New()
+	users := newKnownUserRepoStub(userID)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(internalApiKeyRepoStub{}, users, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
+
+	r := gin.New()
+	r.Use(AuthMiddlewareWithSuspensionCheck(j, nil, apiKeyUsecase))
+	r.GET("/me", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	get := func(id uuid.UUID) *httptest.ResponseRecorder {
+		pair, err := j.GenerateTokenPair(id, "bearer@paymentkita.io", "USER")
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer "+pair.AccessToken)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusNoContent, get(userID).Code)
+	require.Equal(t, http.StatusUnauthorized, get(goneID).Code)
+
+	// A token issued before the suspension stops working: this user was never looked up
+	suspendedID := uuid.New()
+	users.users[suspendedID] = &entities.User{ID: suspendedID}
+	users.suspend(suspendedID)
+	w := get(suspendedID)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), domainerrors.CodeAccountSuspended)
+
+	// A failed lookup is not an invalid token, and the request is not let through either
+	users.err = errors.New("db down")
+	require.Equal(t, http.StatusServiceUnavailable, get(uuid.New()).Code)
+}
+
+func TestDualAuthMiddleware_RejectsSuspendedSessionWithoutSignature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	origLoadSession := loadSessionFromStore
+	t.Cleanup(func() { loadSessionFromStore = origLoadSession })
+	_ = os.Setenv("INTERNAL_PROXY_SECRET", "proxy-secret")
+	t.Cleanup(func() { _ = os.Unsetenv("INTERNAL_PROXY_SECRET") })
+
+	j := jwt.NewJWTService("secret", time.Hour, time.Hour)
+	userID := uuid.New()
+	users := newKnownUserRepoStub(userID)
+	users.suspend(userID)
+	pair, err := j.GenerateTokenPair(userID, "suspended@paymentkita.io", "USER")
+	require.NoError(t, err)
+	loadSessionFromStore = func(context.Context, *redis.SessionStore, string) (*redis.SessionData, error) {
+		return &redis.SessionData{AccessToken: pair.AccessToken}, nil
+	}
+
+	apiKeyUsecase := usecases.NewApiKeyUsecase(internalApiKeyRepoStub{}, users, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
+	r := gin.New()
+	r.Use(DualAuthMiddleware(j, apiKeyUsecase, internalMerchantRepoStub{}, &redis.SessionStore{}))
+	r.GET("/dual", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	req := httptest.NewRequest(http.MethodGet, "/dual", nil)
+	req.Header.Set("x-session-id", "sid-suspended")
+	req.Header.Set("X-Internal-Proxy-Secret", "proxy-secret")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), domainerrors.CodeAccountSuspended)
+}
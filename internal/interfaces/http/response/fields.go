@@ -0,0 +1,97 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldsQueryParam names the sparse-fieldset query parameter, e.g. ?fields=id,status,destAmount
+const FieldsQueryParam = "fields"
+
+// requestedFields returns the top-level fields a GET asked for with ?fields=, or nil for the
+// full response
+func requestedFields(c *gin.Context) map[string]bool {
+	if c.Request == nil || c.Request.Method != http.MethodGet {
+		return nil
+	}
+	raw := strings.TrimSpace(c.Query(FieldsQueryParam))
+	if raw == "" {
+		return nil
+	}
+	fields := make(map[string]bool)
+	for _, field := range strings.Split(raw, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields[field] = true
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
+
+// selectFields trims a JSON body to the requested fields of its resources: the object itself,
+// each element of an array, or each element of the lists in a paginated envelope (an object with
+// "pagination" or "meta"), whose other keys are kept as they are
+func selectFields(body json.RawMessage, fields map[string]bool) json.RawMessage {
+	if list, ok := selectListFields(body, fields); ok {
+		return list
+	}
+	var envelope map[string]json.RawMessage
+	if json.Unmarshal(body, &envelope) != nil {
+		return body
+	}
+	_, paginated := envelope["pagination"]
+	_, hasMeta := envelope["meta"]
+	if !paginated && !hasMeta {
+		return selectObjectFields(body, fields)
+	}
+	for key, value := range envelope {
+		if list, ok := selectListFields(value, fields); ok {
+			envelope[key] = list
+		}
+	}
+	out, err := json.Marshal(envelope)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// selectListFields filters each object of a JSON array; ok is false when body is not an array
+func selectListFields(body json.RawMessage, fields map[string]bool) (json.RawMessage, bool) {
+	var items []json.RawMessage
+	if json.Unmarshal(body, &items) != nil {
+		return body, false
+	}
+	for i := range items {
+		items[i] = selectObjectFields(items[i], fields)
+	}
+	out, err := json.Marshal(items)
+	if err != nil {
+		return body, false
+	}
+	return out, true
+}
+
+// selectObjectFields keeps only the requested keys of a JSON object; anything else is returned
+// unchanged
+func selectObjectFields(body json.RawMessage, fields map[string]bool) json.RawMessage {
+	var object map[string]json.RawMessage
+	if json.Unmarshal(body, &object) != nil || object == nil {
+		return body
+	}
+	for key := range object {
+		if !fields[key] {
+			delete(object, key)
+		}
+	}
+	out, err := json.Marshal(object)
+	if err != nil {
+		return body
+	}
+	return out
+}
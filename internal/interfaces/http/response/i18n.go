@@ -0,0 +1,114 @@
+package response
+
+import (
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// supportedLanguages lists the locales error messages are translated into. English comes first
+// so it is the fallback; English responses keep the original, more specific message.
+var supportedLanguages = []language.Tag{language.English, language.Indonesian, language.Spanish}
+
+var languageMatcher = language.NewMatcher(supportedLanguages)
+
+// errorMessages holds the localized human message for each error code. Codes without an
+// entry keep their English message.
+var errorMessages = map[language.Tag]map[string]string{
+	language.Indonesian: {
+		domainerrors.CodeNotFound:              "Data tidak ditemukan",
+		domainerrors.CodeAlreadyExists:         "Data sudah ada",
+		domainerrors.CodeInvalidInput:          "Input tidak valid",
+		domainerrors.CodeBadRequest:            "Permintaan tidak valid",
+		domainerrors.CodeUnauthorized:          "Autentikasi diperlukan",
+		domainerrors.CodeForbidden:             "Anda tidak memiliki akses ke data ini",
+		domainerrors.CodeInternalError:         "Terjadi kesalahan pada server",
+		domainerrors.CodeInvalidCredentials:    "Email atau kata sandi salah",
+		domainerrors.CodeTokenExpired:          "Token sudah kedaluwarsa",
+		domainerrors.CodeEmailNotVerified:      "Email belum diverifikasi",
+		domainerrors.CodePaymentFailed:         "Pembayaran gagal",
+		domainerrors.CodeInsufficientFunds:     "Saldo tidak mencukupi",
+		domainerrors.CodeConflict:              "Permintaan bertentangan dengan status data saat ini",
+		domainerrors.CodeSimulationReverted:    "Simulasi transaksi gagal",
+		domainerrors.CodeNotContractOwner:      "Kunci owner tidak berwenang pada kontrak",
+		domainerrors.CodeAutoFixInProgress:     "Perbaikan otomatis untuk rute ini sedang berjalan",
+		domainerrors.CodeReceiverNotAllowed:    "Alamat penerima tidak ada dalam daftar yang diizinkan merchant",
+		domainerrors.CodeInvalidIntent:         "Tanda tangan niat pembayaran tidak valid",
+		domainerrors.CodeSlippageUnsatisfiable: "Jumlah minimum yang diterima melebihi jumlah kuotasi",
+		domainerrors.CodeAccountSuspended:      "Akun ditangguhkan",
+		domainerrors.CodeTransferFeeToken:      "Token ini memotong biaya saat transfer dan tidak dapat dipakai untuk pembayaran",
+		domainerrors.CodeApprovalRequired:      "Jumlah pembayaran lintas chain ini memerlukan persetujuan admin",
+		domainerrors.CodeGatewayNotConfigured:  "Chain ini belum memiliki kontrak gateway aktif",
+		domainerrors.CodeExplorerNotConfigured: "Chain ini tidak memiliki API verifikasi kontrak",
+		domainerrors.CodeABIUnavailable:        "ABI terverifikasi untuk kontrak ini tidak tersedia",
+		domainerrors.CodeABIIncomplete:         "ABI tidak memuat semua fungsi yang dibutuhkan tipe kontrak",
+		domainerrors.CodeFallbackNotReady:      "Bridge cadangan belum dapat dijalankan pada rute ini",
+		domainerrors.CodeGatewayPaused:         "Gateway pembayaran sedang dijeda; coba lagi nanti",
+		domainerrors.CodeStillReferenced:       "Data masih dirujuk oleh data lain dan tidak dapat dihapus permanen",
+		domainerrors.CodeInactive:              "Token atau jaringan ini tidak aktif",
+		domainerrors.CodeChainModeMismatch:     "Jaringan ini tidak sesuai dengan mode pembayaran (test atau live)",
+	},
+	language.Spanish: {
+		domainerrors.CodeNotFound:              "Recurso no encontrado",
+		domainerrors.CodeAlreadyExists:         "El recurso ya existe",
+		domainerrors.CodeInvalidInput:          "Datos de entrada no válidos",
+		domainerrors.CodeBadRequest:            "Solicitud no válida",
+		domainerrors.CodeUnauthorized:          "Se requiere autenticación",
+		domainerrors.CodeForbidden:             "No tiene acceso a este recurso",
+		domainerrors.CodeInternalError:         "Error interno del servidor",
+		domainerrors.CodeInvalidCredentials:    "Correo electrónico o contraseña incorrectos",
+		domainerrors.CodeTokenExpired:          "El token ha caducado",
+		domainerrors.CodeEmailNotVerified:      "Correo electrónico no verificado",
+		domainerrors.CodePaymentFailed:         "El pago ha fallado",
+		domainerrors.CodeInsufficientFunds:     "Fondos insuficientes",
+		domainerrors.CodeConflict:              "La solicitud entra en conflicto con el estado actual del recurso",
+		domainerrors.CodeSimulationReverted:    "La simulación de la transacción ha fallado",
+		domainerrors.CodeNotContractOwner:      "La clave de propietario no está autorizada en el contrato",
+		domainerrors.CodeAutoFixInProgress:     "Ya hay una corrección automática en curso para esta ruta",
+		domainerrors.CodeReceiverNotAllowed:    "La dirección receptora no está en la lista permitida del comercio",
+		domainerrors.CodeInvalidIntent:         "La firma de la intención de pago no es válida",
+		domainerrors.CodeSlippageUnsatisfiable: "El importe mínimo a recibir supera el importe cotizado",
+		domainerrors.CodeAccountSuspended:      "La cuenta está suspendida",
+		domainerrors.CodeTransferFeeToken:      "El token cobra una comisión por transferencia y no se admite en pagos",
+		domainerrors.CodeApprovalRequired:      "El importe de este pago entre cadenas requiere la aprobación de un administrador",
+		domainerrors.CodeGatewayNotConfigured:  "Esta cadena no tiene un contrato gateway activo",
+		domainerrors.CodeExplorerNotConfigured: "Esta cadena no tiene una API de verificación de contratos",
+		domainerrors.CodeABIUnavailable:        "No hay un ABI verificado disponible para este contrato",
+		domainerrors.CodeABIIncomplete:         "El ABI no incluye todas las funciones que requiere el tipo de contrato",
+		domainerrors.CodeFallbackNotReady:      "Un bridge de respaldo no puede ejecutarse en esta ruta",
+		domainerrors.CodeGatewayPaused:         "La pasarela de pagos está en pausa; inténtelo más tarde",
+		domainerrors.CodeStillReferenced:       "El recurso sigue referenciado y no puede eliminarse definitivamente",
+		domainerrors.CodeInactive:              "Este token o red no está activo",
+		domainerrors.CodeChainModeMismatch:     "Esta red no corresponde al modo del pago (test o live)",
+	},
+}
+
+// requestLanguage picks the best supported language for the Accept-Language header
+func requestLanguage(c *gin.Context) language.Tag {
+	if c.Request == nil {
+		return language.English
+	}
+	header := c.GetHeader("Accept-Language")
+	if header == "" {
+		return language.English
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return language.English
+	}
+	_, index, confidence := languageMatcher.Match(tags...)
+	if confidence == language.No {
+		return language.English
+	}
+	return supportedLanguages[index]
+}
+
+// localizedMessage returns the message for code in the request's language, and whether a
+// translation was used
+func localizedMessage(c *gin.Context, code, message string) (string, language.Tag, bool) {
+	lang := requestLanguage(c)
+	if translated, ok := errorMessages[lang][code]; ok {
+		return translated, lang, true
+	}
+	return message, language.English, false
+}
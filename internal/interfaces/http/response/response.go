@@ -1,12 +1,23 @@
 package response
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 )
 
-// Success sends a success response
+// Success sends a success response. A GET with ?fields= gets only those fields of the returned
+// resources (see selectFields).
 func Success(c *gin.Context, status int, data interface{}) {
+	if fields := requestedFields(c); fields != nil && status < http.StatusMultipleChoices {
+		if body, err := json.Marshal(data); err == nil {
+			c.Data(status, "application/json; charset=utf-8", selectFields(body, fields))
+			return
+		}
+	}
 	c.JSON(status, data)
 }
 
@@ -20,17 +31,45 @@ func Error(c *gin.Context, err error) {
 		appErr = domainerrors.InternalError(err)
 	}
 
-	c.JSON(appErr.Status, gin.H{
+	body := gin.H{
 		"code":    appErr.Code,
 		"message": appErr.Message,
 		"error":   appErr.Message, // Backward compatibility
-	})
+	}
+	var exists *domainerrors.AlreadyExistsError
+	if errors.As(appErr.Err, &exists) {
+		body["existingId"] = exists.ID
+	}
+	var referenced *domainerrors.ReferencedError
+	if errors.As(appErr.Err, &referenced) {
+		body["references"] = referenced.References
+	}
+	localizeError(c, body, appErr.Code, appErr.Message)
+	c.JSON(appErr.Status, body)
 }
 
 // ErrorWithStatus sends an error response with a specific status and message
 func ErrorWithError(c *gin.Context, status int, code string, message string) {
-	c.JSON(status, gin.H{
+	body := gin.H{
 		"code":    code,
 		"message": message,
-	})
+	}
+	localizeError(c, body, code, message)
+	c.JSON(status, body)
+}
+
+// localizeError swaps the human message for its Accept-Language translation. The code never
+// changes, and the original English message is kept in "detail".
+func localizeError(c *gin.Context, body gin.H, code, message string) {
+	c.Header("Vary", "Accept-Language")
+	translated, lang, ok := localizedMessage(c, code, message)
+	c.Header("Content-Language", lang.String())
+	if !ok {
+		return
+	}
+	body["message"] = translated
+	if _, hasError := body["error"]; hasError {
+		body["error"] = translated
+	}
+	body["detail"] = message
 }
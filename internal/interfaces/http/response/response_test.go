@@ -21,6 +21,37 @@ func TestSuccess(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"ok":true`)
 }
 
+func TestSuccess_SelectsRequestedFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	send := func(method, target string, data interface{}) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(method, target, nil)
+		Success(c, http.StatusOK, data)
+		return w
+	}
+	payment := gin.H{"id": "p1", "status": "pending", "destAmount": "1000000000000000000000", "sourceChainId": "eip155:1"}
+
+	w := send(http.MethodGet, "/payments/p1?fields=id,%20destAmount", payment)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":"p1","destAmount":"1000000000000000000000"}`, w.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	// Paginated envelopes keep their metadata; bare arrays are filtered element by element
+	w = send(http.MethodGet, "/payments?fields=id,status", gin.H{"payments": []gin.H{payment}, "pagination": gin.H{"total": 1}})
+	assert.JSONEq(t, `{"payments":[{"id":"p1","status":"pending"}],"pagination":{"total":1}}`, w.Body.String())
+	w = send(http.MethodGet, "/chains?fields=id", gin.H{"items": []gin.H{payment}, "meta": gin.H{"page": 1}})
+	assert.JSONEq(t, `{"items":[{"id":"p1"}],"meta":{"page":1}}`, w.Body.String())
+	w = send(http.MethodGet, "/payments?fields=status", []gin.H{payment, payment})
+	assert.JSONEq(t, `[{"status":"pending"},{"status":"pending"}]`, w.Body.String())
+
+	// No filter without ?fields= or on writes
+	w = send(http.MethodGet, "/payments/p1?fields=", payment)
+	assert.Contains(t, w.Body.String(), `"sourceChainId"`)
+	w = send(http.MethodPost, "/payments?fields=id", payment)
+	assert.Contains(t, w.Body.String(), `"sourceChainId"`)
+}
+
 func TestError_AppError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
@@ -33,6 +64,18 @@ func TestError_AppError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "missing")
 }
 
+func TestError_ConflictCarriesExistingID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	conflict := domainerrors.Conflict("Token already exists")
+	conflict.Err = &domainerrors.AlreadyExistsError{ID: "0190a0b0-0000-7000-8000-000000000001"}
+	Error(c, conflict)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"existingId":"0190a0b0-0000-7000-8000-000000000001"`)
+}
+
 func TestError_GenericError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
@@ -52,3 +95,38 @@ func TestErrorWithError(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 	assert.Contains(t, w.Body.String(), `"code":"ERR_X"`)
 }
+
+func TestError_LocalizedByAcceptLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	send := func(acceptLanguage string, err error) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if acceptLanguage != "" {
+			c.Request.Header.Set("Accept-Language", acceptLanguage)
+		}
+		Error(c, err)
+		return w
+	}
+
+	w := send("id-ID,id;q=0.9,en;q=0.8", domainerrors.NotFound("payment not found"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "id", w.Header().Get("Content-Language"))
+	assert.Contains(t, w.Body.String(), `"code":"ERR_NOT_FOUND"`)
+	assert.Contains(t, w.Body.String(), `"message":"Data tidak ditemukan"`)
+	assert.Contains(t, w.Body.String(), `"detail":"payment not found"`)
+
+	w = send("es", domainerrors.Forbidden("nope"))
+	assert.Equal(t, "es", w.Header().Get("Content-Language"))
+	assert.Contains(t, w.Body.String(), `"message":"No tiene acceso a este recurso"`)
+
+	// Unsupported languages, missing headers and untranslated codes fall back to English
+	for _, lang := range []string{"fr-FR", "", "not a header;;"} {
+		w = send(lang, domainerrors.NotFound("payment not found"))
+		assert.Equal(t, "en", w.Header().Get("Content-Language"))
+		assert.Contains(t, w.Body.String(), `"message":"payment not found"`)
+		assert.NotContains(t, w.Body.String(), `"detail"`)
+	}
+	w = send("id", domainerrors.NewAppError(http.StatusTeapot, "ERR_CUSTOM", "custom", nil))
+	assert.Contains(t, w.Body.String(), `"message":"custom"`)
+}
@@ -0,0 +1,103 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/models"
+	"payment-kita.backend/internal/infrastructure/repositories"
+	"payment-kita.backend/internal/interfaces/http/handlers"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/jwt"
+)
+
+// TestPaymentApp_MerchantKeyRespectsAllowlist sends payment-app payments with a merchant's API key,
+// so the allow-list follows the key even though the sender wallet belongs to someone else
+func TestPaymentApp_MerchantKeyRespectsAllowlist(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("INTERNAL_PROXY_SECRET", "")
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	chainRepo := repositories.NewChainRepository(db)
+	walletRepo := repositories.NewWalletRepository(db)
+	merchantRepo := repositories.NewMerchantRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	allowlistRepo := repositories.NewMerchantAllowedReceiverRepository(db)
+
+	paymentUsecase := usecases.NewPaymentUsecaseWithReceiverAllowlist(
+		repositories.NewPaymentRepository(db),
+		repositories.NewPaymentEventRepository(db),
+		walletRepo,
+		merchantRepo,
+		userRepo,
+		repositories.NewSmartContractRepository(db, chainRepo),
+		chainRepo,
+		repositories.NewTokenRepository(db, chainRepo),
+		repositories.NewBridgeConfigRepository(db),
+		repositories.NewFeeConfigRepository(db),
+		repositories.NewRoutePolicyRepository(db),
+		repositories.NewUnitOfWork(db),
+		nil,
+		allowlistRepo,
+	)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(repositories.NewApiKeyRepository(db), userRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
+
+	ownerID, merchantID, chainID := uuid.New(), uuid.New(), uuid.New()
+	treasury := "0x000000000000000000000000000000000000bEEF"
+	db.Exec("INSERT INTO users (id, email, name, password_hash, role) VALUES (?, ?, ?, ?, ?)", ownerID, "owner@merchant.com", "Owner", "hash", "USER")
+	db.Exec("INSERT INTO merchants (id, user_id, business_name, business_email, status, merchant_type) VALUES (?, ?, ?, ?, ?, ?)", merchantID, ownerID, "Test Merchant", "test@merchant.com", "ACTIVE", "individual")
+	db.Exec("INSERT INTO chains (id, chain_id, name, type, is_active) VALUES (?, ?, ?, ?, ?)", chainID, "8453", "Base", "EVM", true)
+	db.Exec("INSERT INTO tokens (id, chain_id, symbol, name, address, decimals, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)", uuid.New(), chainID, "USDC", "USD Coin", "0xUSDC", 6, true)
+	db.Exec("INSERT INTO smart_contracts (id, chain_id, name, type, address, version, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)", uuid.New(), chainID, "Gateway", "GATEWAY", "0x1111111111111111111111111111111111111111", 1, true)
+	db.Exec("INSERT INTO smart_contracts (id, chain_id, name, type, address, version, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)", uuid.New(), chainID, "Vault", "VAULT", "0x2222222222222222222222222222222222222222", 1, true)
+	require.NoError(t, allowlistRepo.Create(ctx, &entities.MerchantAllowedReceiver{ID: uuid.New(), MerchantID: merchantID, Address: "0x000000000000000000000000000000000000beef", CreatedAt: time.Now()}))
+
+	key, err := apiKeyUsecase.CreateApiKey(ctx, ownerID, &entities.CreateApiKeyInput{Name: "integration"})
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(middleware.DualAuthMiddleware(jwt.NewJWTService("secret", time.Hour, 24*time.Hour), apiKeyUsecase, merchantRepo, nil))
+	r.POST("/api/v1/payment-app", handlers.NewPaymentAppHandler(usecases.NewPaymentAppUsecase(paymentUsecase, userRepo, walletRepo, chainRepo)).CreatePaymentApp)
+
+	send := func(receiver string) *httptest.ResponseRecorder {
+		path := "/api/v1/payment-app"
+		body := fmt.Sprintf(`{"sourceChainId":"eip155:8453","destChainId":"eip155:8453","sourceTokenAddress":"0xUSDC","destTokenAddress":"0xUSDC","amount":"1","decimals":6,"senderWalletAddress":"0x000000000000000000000000000000000000aBcD","receiverAddress":"%s"}`, receiver)
+		timestamp := fmt.Sprintf("%d", time.Now().Unix())
+		bodyHash := sha256.Sum256([]byte(body))
+		mac := hmac.New(sha256.New, []byte(key.SecretKey))
+		mac.Write([]byte(timestamp + http.MethodPost + path + hex.EncodeToString(bodyHash[:])))
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Api-Key", key.ApiKey)
+		req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+		req.Header.Set("X-Timestamp", timestamp)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("0x000000000000000000000000000000000000dEaD")
+	require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), domainerrors.CodeReceiverNotAllowed)
+	var n int64
+	require.NoError(t, db.Model(&models.Payment{}).Count(&n).Error)
+	require.Zero(t, n)
+
+	w = send(treasury)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
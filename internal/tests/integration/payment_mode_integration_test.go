@@ -0,0 +1,163 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/models"
+	"payment-kita.backend/internal/infrastructure/repositories"
+	"payment-kita.backend/internal/interfaces/http/handlers"
+	"payment-kita.backend/internal/interfaces/http/middleware"
+	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/jwt"
+)
+
+// TestPaymentMode_TestKeyStaysOnTestnets drives the payment-app and payment-request routes
+// through the real DualAuth middleware, so a test key's mode reaches every usecase that
+// creates payments
+func TestPaymentMode_TestKeyStaysOnTestnets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Setenv("INTERNAL_PROXY_SECRET", "")
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	chainRepo := repositories.NewChainRepository(db)
+	tokenRepo := repositories.NewTokenRepository(db, chainRepo)
+	paymentRepo := repositories.NewPaymentRepository(db)
+	walletRepo := repositories.NewWalletRepository(db)
+	merchantRepo := repositories.NewMerchantRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	contractRepo := repositories.NewSmartContractRepository(db, chainRepo)
+
+	paymentUsecase := usecases.NewPaymentUsecase(
+		paymentRepo,
+		repositories.NewPaymentEventRepository(db),
+		walletRepo,
+		merchantRepo,
+		userRepo,
+		contractRepo,
+		chainRepo,
+		tokenRepo,
+		repositories.NewBridgeConfigRepository(db),
+		repositories.NewFeeConfigRepository(db),
+		repositories.NewRoutePolicyRepository(db),
+		repositories.NewUnitOfWork(db),
+		nil,
+	)
+	paymentRequestUsecase := usecases.NewPaymentRequestUsecase(repositories.NewPaymentRequestRepository(db), merchantRepo, walletRepo, chainRepo, contractRepo, tokenRepo, nil)
+	apiKeyUsecase := usecases.NewApiKeyUsecase(repositories.NewApiKeyRepository(db), userRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
+
+	ownerID, merchantID := uuid.New(), uuid.New()
+	mainnet, testnet := uuid.New(), uuid.New()
+	db.Exec("INSERT INTO users (id, email, name, password_hash, role) VALUES (?, ?, ?, ?, ?)", ownerID, "owner@merchant.com", "Owner", "hash", "USER")
+	db.Exec("INSERT INTO merchants (id, user_id, business_name, business_email, status, merchant_type) VALUES (?, ?, ?, ?, ?, ?)", merchantID, ownerID, "Test Merchant", "test@merchant.com", "ACTIVE", "individual")
+	for _, chain := range []struct {
+		id        uuid.UUID
+		chainID   string
+		isTestnet bool
+	}{{mainnet, "8453", false}, {testnet, "84532", true}} {
+		db.Exec("INSERT INTO chains (id, chain_id, name, type, is_active, is_testnet) VALUES (?, ?, ?, ?, ?, ?)", chain.id, chain.chainID, "Base "+chain.chainID, "EVM", true, chain.isTestnet)
+		db.Exec("INSERT INTO tokens (id, chain_id, symbol, name, address, decimals, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)", uuid.New(), chain.id, "USDC", "USD Coin", "0xUSDC", 6, true)
+		db.Exec("INSERT INTO smart_contracts (id, chain_id, name, type, address, version, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)", uuid.New(), chain.id, "Gateway", "GATEWAY", "0x1111111111111111111111111111111111111111", 1, true)
+		db.Exec("INSERT INTO smart_contracts (id, chain_id, name, type, address, version, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)", uuid.New(), chain.id, "Vault", "VAULT", "0x2222222222222222222222222222222222222222", 1, true)
+	}
+	db.Exec("INSERT INTO wallets (id, user_id, chain_id, address, is_primary) VALUES (?, ?, ?, ?, ?)", uuid.New(), ownerID, mainnet, "0x000000000000000000000000000000000000bEEF", true)
+
+	key, err := apiKeyUsecase.CreateApiKey(ctx, ownerID, &entities.CreateApiKeyInput{Name: "integration", Mode: entities.PaymentModeTest})
+	require.NoError(t, err)
+
+	r := gin.New()
+	jwtService := jwt.NewJWTService("secret", time.Hour, 24*time.Hour)
+	r.Use(middleware.DualAuthMiddleware(jwtService, apiKeyUsecase, merchantRepo, nil))
+	r.POST("/api/v1/payment-app", handlers.NewPaymentAppHandler(usecases.NewPaymentAppUsecase(paymentUsecase, userRepo, walletRepo, chainRepo)).CreatePaymentApp)
+	r.POST("/api/v1/payment-requests", handlers.NewPaymentRequestHandler(paymentRequestUsecase).CreatePaymentRequest)
+
+	// Sessions sign with the same secret as the key, so both callers share the signing
+	signed := func(path, body string) *http.Request {
+		timestamp := fmt.Sprintf("%d", time.Now().Unix())
+		bodyHash := sha256.Sum256([]byte(body))
+		mac := hmac.New(sha256.New, []byte(key.SecretKey))
+		mac.Write([]byte(timestamp + http.MethodPost + path + hex.EncodeToString(bodyHash[:])))
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+		req.Header.Set("X-Timestamp", timestamp)
+		return req
+	}
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	send := func(path, body string) *httptest.ResponseRecorder {
+		req := signed(path, body)
+		req.Header.Set("X-Api-Key", key.ApiKey)
+		return serve(req)
+	}
+	tokens, err := jwtService.GenerateTokenPair(ownerID, "owner@merchant.com", "USER")
+	require.NoError(t, err)
+	sendSession := func(path, body string) *httptest.ResponseRecorder {
+		req := signed(path, body)
+		req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		return serve(req)
+	}
+	paymentApp := func(chainID string) string {
+		return fmt.Sprintf(`{"sourceChainId":"eip155:%[1]s","destChainId":"eip155:%[1]s","sourceTokenAddress":"0xUSDC","destTokenAddress":"0xUSDC","amount":"1","decimals":6,"senderWalletAddress":"0x000000000000000000000000000000000000aBcD","receiverAddress":"0x000000000000000000000000000000000000dEaD"}`, chainID)
+	}
+	paymentRequest := func(chainID string) string {
+		return fmt.Sprintf(`{"chainId":"eip155:%s","tokenAddress":"0xUSDC","amount":"1","decimals":6}`, chainID)
+	}
+	count := func(model interface{}) int64 {
+		var n int64
+		require.NoError(t, db.Model(model).Count(&n).Error)
+		return n
+	}
+
+	// A test key cannot create live payments or payment requests on a mainnet
+	w := send("/api/v1/payment-app", paymentApp("8453"))
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), domainerrors.CodeChainModeMismatch)
+	require.Zero(t, count(&models.Payment{}))
+
+	w = send("/api/v1/payment-requests", paymentRequest("8453"))
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), domainerrors.CodeChainModeMismatch)
+	require.Zero(t, count(&models.PaymentRequest{}))
+
+	// On a testnet it creates test payments
+	w = send("/api/v1/payment-app", paymentApp("84532"))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var payment models.Payment
+	require.NoError(t, db.First(&payment).Error)
+	require.Equal(t, string(entities.PaymentModeTest), payment.Mode)
+
+	w = send("/api/v1/payment-requests", paymentRequest("84532"))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.Equal(t, int64(1), count(&models.PaymentRequest{}))
+
+	// A dashboard session is live unless it asks for test mode
+	w = sendSession("/api/v1/payment-app", paymentApp("84532"))
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), domainerrors.CodeChainModeMismatch)
+
+	w = sendSession("/api/v1/payment-requests", paymentRequest("84532"))
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	require.Contains(t, w.Body.String(), domainerrors.CodeChainModeMismatch)
+
+	w = sendSession("/api/v1/payment-requests?mode=test", paymentRequest("84532"))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.Equal(t, int64(2), count(&models.PaymentRequest{}))
+}
@@ -31,13 +31,18 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	queries := []string{
 		`CREATE TABLE users (
 			id TEXT PRIMARY KEY, email TEXT, name TEXT, password_hash TEXT, role TEXT, 
-			kyc_status TEXT, kyc_verified_at DATETIME, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
+			kyc_status TEXT, kyc_verified_at DATETIME, fee_exempt BOOLEAN DEFAULT FALSE, suspended_at DATETIME,
+			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
 		)`,
 		`CREATE TABLE merchants (
 			id TEXT PRIMARY KEY, user_id TEXT, business_name TEXT, business_email TEXT, status TEXT, 
 			merchant_type TEXT, callback_url TEXT, webhook_secret TEXT, webhook_is_active BOOLEAN, 
 			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
 		)`,
+		`CREATE TABLE wallets (
+			id TEXT PRIMARY KEY, user_id TEXT, merchant_id TEXT, chain_id TEXT, address TEXT,
+			is_primary BOOLEAN, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
+		)`,
 		`CREATE TABLE payments (
 			id TEXT PRIMARY KEY, 
 			sender_id TEXT, 
@@ -53,7 +58,11 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			total_charged TEXT,
 			sender_address TEXT,
 			dest_address TEXT,
+			receiver_name TEXT,
+			external_ref TEXT,
+			metadata TEXT,
 			status TEXT, 
+			mode TEXT DEFAULT 'live',
 			source_tx_hash TEXT,
 			dest_tx_hash TEXT,
 			refund_tx_hash TEXT,
@@ -72,7 +81,8 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		)`,
 		`CREATE TABLE chains (
 			id TEXT PRIMARY KEY, chain_id TEXT, name TEXT, currency_symbol TEXT, image_url TEXT, 
-			rpc_url TEXT, type TEXT, is_active BOOLEAN, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
+			rpc_url TEXT, type TEXT, is_active BOOLEAN, is_testnet BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
 		)`,
 		`CREATE TABLE chain_rpcs (
 			id TEXT PRIMARY KEY, chain_id TEXT, url TEXT, priority INTEGER, is_active BOOLEAN, 
@@ -85,10 +95,20 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		)`,
 		`CREATE TABLE payment_events (
 			id TEXT PRIMARY KEY, payment_id TEXT, event_type TEXT, chain_id TEXT, chain TEXT,
-			tx_hash TEXT, block_number BIGINT, metadata TEXT, created_at DATETIME
+			tx_hash TEXT, block_number BIGINT, confirmations INTEGER DEFAULT 0, metadata TEXT, details TEXT, created_at DATETIME
 		)`,
 		`CREATE TABLE api_keys (
-			id TEXT PRIMARY KEY, user_id TEXT, name TEXT, key_hash TEXT, is_active BOOLEAN, 
+			id TEXT PRIMARY KEY, user_id TEXT, name TEXT, key_prefix TEXT, key_hash TEXT, secret_encrypted TEXT,
+			secret_masked TEXT, permissions TEXT, is_active BOOLEAN, last_used_at DATETIME, expires_at DATETIME,
+			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
+		)`,
+		`CREATE TABLE merchant_allowed_receivers (
+			id TEXT PRIMARY KEY, merchant_id TEXT, address TEXT, label TEXT, created_by TEXT, created_at DATETIME
+		)`,
+		`CREATE TABLE payment_requests (
+			id TEXT PRIMARY KEY, merchant_id TEXT, chain_id TEXT, token_id TEXT, wallet_address TEXT,
+			amount TEXT, decimals INTEGER, description TEXT, status TEXT, expires_at DATETIME, tx_hash TEXT, block_number INTEGER,
+			payer_address TEXT, payment_code TEXT, metadata TEXT, completed_at DATETIME,
 			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
 		)`,
 		`CREATE TABLE smart_contracts (
@@ -96,7 +116,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			version INTEGER, is_active BOOLEAN, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
 		)`,
 		`CREATE TABLE payment_bridge (
-			id TEXT PRIMARY KEY, name TEXT, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
+			id TEXT PRIMARY KEY, name TEXT, explorer_url_template TEXT, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
 		)`,
 		`CREATE TABLE bridge_configs (
 			id TEXT PRIMARY KEY, bridge_id TEXT, source_chain_id TEXT, dest_chain_id TEXT, 
@@ -104,7 +124,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
 		)`,
 		`CREATE TABLE fee_configs (
-			id TEXT PRIMARY KEY, chain_id TEXT, token_id TEXT, platform_fee_percent TEXT, 
+			id TEXT PRIMARY KEY, chain_id TEXT, token_id TEXT, dest_chain_id TEXT, dest_token_id TEXT, platform_fee_percent TEXT, 
 			fixed_base_fee TEXT, min_fee TEXT, max_fee TEXT, 
 			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
 		)`,
@@ -149,6 +169,7 @@ func TestPaymentAttribution(t *testing.T) {
 		paymentEventRepo,
 		walletRepo,
 		merchantRepo,
+		nil,
 		contractRepo,
 		chainRepo,
 		tokenRepo,
@@ -161,43 +182,54 @@ func TestPaymentAttribution(t *testing.T) {
 
 	userID := uuid.New()
 	merchantID := uuid.New()
+	const merchantWallet = "0x000000000000000000000000000000000000bEEF"
 
 	// Create required data for attribution
 	db.Exec("INSERT INTO users (id, email, name, password_hash, role) VALUES (?, ?, ?, ?, ?)", userID, "test@user.com", "Test", "hash", "user")
-	db.Exec("INSERT INTO merchants (id, user_id, business_name, business_email, status, merchant_type) VALUES (?, ?, ?, ?, ?, ?)", merchantID, userID, "Test Merchant", "test@merchant.com", "active", "individual")
+	db.Exec("INSERT INTO merchants (id, user_id, business_name, business_email, status, merchant_type) VALUES (?, ?, ?, ?, ?, ?)", merchantID, userID, "Test Merchant", "test@merchant.com", "ACTIVE", "individual")
 
 	chainUUID := uuid.New()
 	db.Exec("INSERT INTO chains (id, chain_id, name, type, is_active) VALUES (?, ?, ?, ?, ?)", chainUUID, "eip155:1", "Ethereum", "evm", true)
 	db.Exec("INSERT INTO tokens (id, chain_id, symbol, name, address, decimals, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)", uuid.New(), chainUUID, "USDC", "USD Coin", "0xUSDC", 6, true)
-
-	ctx := context.WithValue(context.Background(), "MerchantID", merchantID)
-
-	input := &entities.CreatePaymentInput{
-		Amount:             "100",
-		SourceChainID:      "eip155:1",
-		DestChainID:        "eip155:1",
-		SourceTokenAddress: "0xUSDC",
-		DestTokenAddress:   "0xUSDC",
-		ReceiverAddress:    "0xReceiver",
-		Decimals:           6,
+	db.Exec("INSERT INTO wallets (id, user_id, chain_id, address, is_primary) VALUES (?, ?, ?, ?, ?)", uuid.New(), userID, chainUUID, merchantWallet, true)
+	db.Exec("INSERT INTO smart_contracts (id, chain_id, name, type, address, version, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)", uuid.New(), chainUUID, "Gateway", "GATEWAY", "0x1111111111111111111111111111111111111111", 1, true)
+	db.Exec("INSERT INTO smart_contracts (id, chain_id, name, type, address, version, is_active) VALUES (?, ?, ?, ?, ?, ?, ?)", uuid.New(), chainUUID, "Vault", "VAULT", "0x2222222222222222222222222222222222222222", 1, true)
+
+	ctx := usecases.WithMerchantScope(context.Background(), merchantID)
+
+	newInput := func(receiver string) *entities.CreatePaymentInput {
+		return &entities.CreatePaymentInput{
+			Amount:             "100",
+			SourceChainID:      "eip155:1",
+			DestChainID:        "eip155:1",
+			SourceTokenAddress: "0xUSDC",
+			DestTokenAddress:   "0xUSDC",
+			ReceiverAddress:    receiver,
+			Decimals:           6,
+		}
+	}
+	merchantOf := func(paymentID uuid.UUID) *uuid.UUID {
+		var dbPayment models.Payment
+		require.NoError(t, db.First(&dbPayment, "id = ?", paymentID).Error)
+		return dbPayment.MerchantID
 	}
 
-	result, err := uc.CreatePayment(ctx, userID, input)
+	// Inbound to the merchant's own wallet: attributed
+	result, err := uc.CreatePayment(ctx, userID, newInput(merchantWallet))
 	require.NoError(t, err)
+	require.NotNil(t, merchantOf(result.PaymentID))
+	assert.Equal(t, merchantID, *merchantOf(result.PaymentID))
 
-	// Verify in DB
-	var dbPayment models.Payment
-	err = db.First(&dbPayment, "id = ?", result.PaymentID).Error
+	// The merchant paying someone else is not merchant revenue
+	result, err = uc.CreatePayment(ctx, userID, newInput("0x000000000000000000000000000000000000dEaD"))
 	require.NoError(t, err)
-	assert.NotNil(t, dbPayment.MerchantID)
-	assert.Equal(t, merchantID, *dbPayment.MerchantID)
+	assert.Nil(t, merchantOf(result.PaymentID))
 }
 
 func TestWebhookSignature(t *testing.T) {
 	db := setupTestDB(t)
 	merchantRepo := repositories.NewMerchantRepository(db)
 	webhookRepo := repositories.NewGormWebhookLogRepository(db)
-	hmacService := servicesimpl.NewHMACService()
 
 	secret := "test-secret-123"
 	merchantID := uuid.New()
@@ -220,7 +252,7 @@ func TestWebhookSignature(t *testing.T) {
 	// Update merchant with mock server URL
 	db.Exec("UPDATE merchants SET callback_url = ? WHERE id = ?", server.URL, merchantID)
 
-	dispatcher := usecases.NewWebhookDispatcher(webhookRepo, merchantRepo, hmacService)
+	dispatcher := usecases.NewWebhookDispatcher(webhookRepo, merchantRepo)
 
 	payload := map[string]interface{}{"status": "success"}
 	payloadBytes, _ := json.Marshal(payload)
@@ -266,7 +298,6 @@ func TestWebhookRetryOnFailure(t *testing.T) {
 	db := setupTestDB(t)
 	merchantRepo := repositories.NewMerchantRepository(db)
 	webhookRepo := repositories.NewGormWebhookLogRepository(db)
-	hmacService := servicesimpl.NewHMACService()
 
 	merchantID := uuid.New()
 	db.Exec("INSERT INTO merchants (id, user_id, business_name, business_email, webhook_is_active, merchant_type) VALUES (?, ?, ?, ?, ?, ?)",
@@ -279,7 +310,7 @@ func TestWebhookRetryOnFailure(t *testing.T) {
 
 	db.Exec("UPDATE merchants SET callback_url = ? WHERE id = ?", server.URL, merchantID)
 
-	dispatcher := usecases.NewWebhookDispatcher(webhookRepo, merchantRepo, hmacService)
+	dispatcher := usecases.NewWebhookDispatcher(webhookRepo, merchantRepo)
 
 	payload := map[string]interface{}{"status": "fail"}
 	payloadBytes, _ := json.Marshal(payload)
@@ -9,8 +9,10 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"payment-kita.backend/internal/domain/entities"
 	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/pkg/logger"
 )
 
 // ABIResolverMixin provides common ABI resolution logic
@@ -109,19 +111,40 @@ func (u *ABIResolverMixin) ResolveABIWithFallback(ctx context.Context, chainID u
 			// Unified adapter can be either a basic sender or a token gateway sender
 			isValid = hasSetStateMachine || hasSetSettlementExecutor
 			if !isValid {
-				u.logResolverfOnce("invalid:"+fmt.Sprintf("%s:%s", chainID.String(), contractType), "[ResolveABI] ABI for %s has %d methods but missing Hyperbridge methods. Using fallback.\n", contractType, len(parsed.Methods))
+				if u.firstResolverLog("invalid:" + fmt.Sprintf("%s:%s", chainID.String(), contractType)) {
+					logger.Warn(ctx, "ABI missing expected admin methods, using fallback",
+						zap.String("chain_id", chainID.String()),
+						zap.String("contract_type", string(contractType)),
+						zap.Int("methods", len(parsed.Methods)),
+						zap.String("missing", "setStateMachineId/setRouteSettlementExecutor"),
+					)
+				}
 			}
 		case entities.ContractTypeAdapterCCIP:
 			_, hasSetChainSelector := parsed.Methods["setChainSelector"]
 			_, hasSetChainConfig := parsed.Methods["setChainConfig"]
 			isValid = hasSetChainSelector || hasSetChainConfig
 			if !isValid {
-				u.logResolverfOnce("invalid:"+fmt.Sprintf("%s:%s", chainID.String(), contractType), "[ResolveABI] ABI for %s has %d methods but missing 'setChainSelector'/'setChainConfig'. Using fallback.\n", contractType, len(parsed.Methods))
+				if u.firstResolverLog("invalid:" + fmt.Sprintf("%s:%s", chainID.String(), contractType)) {
+					logger.Warn(ctx, "ABI missing expected admin methods, using fallback",
+						zap.String("chain_id", chainID.String()),
+						zap.String("contract_type", string(contractType)),
+						zap.Int("methods", len(parsed.Methods)),
+						zap.String("missing", "setChainSelector/setChainConfig"),
+					)
+				}
 			}
 		case entities.ContractTypeAdapterStargate:
 			_, isValid = parsed.Methods["setRoute"]
 			if !isValid {
-				u.logResolverfOnce("invalid:"+fmt.Sprintf("%s:%s", chainID.String(), contractType), "[ResolveABI] ABI for %s has %d methods but missing 'setRoute'. Using fallback.\n", contractType, len(parsed.Methods))
+				if u.firstResolverLog("invalid:" + fmt.Sprintf("%s:%s", chainID.String(), contractType)) {
+					logger.Warn(ctx, "ABI missing expected admin methods, using fallback",
+						zap.String("chain_id", chainID.String()),
+						zap.String("contract_type", string(contractType)),
+						zap.Int("methods", len(parsed.Methods)),
+						zap.String("missing", "setRoute"),
+					)
+				}
 			}
 		default:
 			// For others (or if we don't need strict validation), checks length
@@ -129,11 +152,23 @@ func (u *ABIResolverMixin) ResolveABIWithFallback(ctx context.Context, chainID u
 		}
 
 		if isValid {
-			u.logResolverfOnce("valid:"+fmt.Sprintf("%s:%s", chainID.String(), contractType), "[ResolveABI] Found validated ABI for %s on %s. Methods: %d\n", contractType, chainID, len(parsed.Methods))
+			if u.firstResolverLog("valid:" + fmt.Sprintf("%s:%s", chainID.String(), contractType)) {
+				logger.Info(ctx, "Resolved validated ABI",
+					zap.String("chain_id", chainID.String()),
+					zap.String("contract_type", string(contractType)),
+					zap.Int("methods", len(parsed.Methods)),
+				)
+			}
 			return *parsed, nil
 		}
 	} else if err != nil {
-		u.logResolverfOnce("fallback:"+fmt.Sprintf("%s:%s", chainID.String(), contractType), "[ResolveABI] Failed to resolve from DB for %s on %s: %v. Falling back.\n", contractType, chainID, err)
+		if u.firstResolverLog("fallback:" + fmt.Sprintf("%s:%s", chainID.String(), contractType)) {
+			logger.Warn(ctx, "Failed to resolve ABI from DB, using fallback",
+				zap.String("chain_id", chainID.String()),
+				zap.String("contract_type", string(contractType)),
+				zap.Error(err),
+			)
+		}
 	}
 
 	// Fallback logic
@@ -161,13 +196,11 @@ func (u *ABIResolverMixin) ResolveABIWithFallback(ctx context.Context, chainID u
 	return abi.ABI{}, fmt.Errorf("no ABI found for %s", contractType)
 }
 
-func (u *ABIResolverMixin) logResolverfOnce(key string, format string, args ...interface{}) {
+// firstResolverLog reports whether key is being logged for the first time
+func (u *ABIResolverMixin) firstResolverLog(key string) bool {
 	if key == "" {
-		fmt.Printf(format, args...)
-		return
-	}
-	if _, loaded := u.logOnce.LoadOrStore(key, struct{}{}); loaded {
-		return
+		return true
 	}
-	fmt.Printf(format, args...)
+	_, loaded := u.logOnce.LoadOrStore(key, struct{}{})
+	return !loaded
 }
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+)
+
+// ActivityUsecase serves the combined payments / payment requests feed
+type ActivityUsecase struct {
+	activityRepo repositories.ActivityRepository
+	merchantRepo repositories.MerchantRepository
+}
+
+// NewActivityUsecase creates a new activity usecase
+func NewActivityUsecase(activityRepo repositories.ActivityRepository, merchantRepo repositories.MerchantRepository) *ActivityUsecase {
+	return &ActivityUsecase{activityRepo: activityRepo, merchantRepo: merchantRepo}
+}
+
+// ListActivity returns one page of the user's activity in the request's mode: payments they sent
+// and, when they own a merchant, payments to it and its payment requests. cursor is the
+// NextCursor of the previous page, or empty for the first page.
+func (u *ActivityUsecase) ListActivity(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*entities.ActivityPage, error) {
+	after, err := decodeActivityCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repositories.ActivityFilter{UserID: userID, Mode: paymentMode(ctx)}
+	merchant, err := u.merchantRepo.GetByUserID(ctx, userID)
+	switch {
+	case err == nil:
+		filter.MerchantID = &merchant.ID
+	case !errors.Is(err, domainerrors.ErrNotFound):
+		return nil, err
+	}
+
+	// One extra row tells whether another page exists
+	items, err := u.activityRepo.List(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &entities.ActivityPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+		page.NextCursor = encodeActivityCursor(page.Items[limit-1])
+	}
+	if page.Items == nil {
+		page.Items = []*entities.ActivityItem{}
+	}
+	return page, nil
+}
+
+// encodeActivityCursor makes an opaque cursor from the feed position of item
+func encodeActivityCursor(item *entities.ActivityItem) string {
+	raw := item.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + item.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeActivityCursor(cursor string) (*repositories.ActivityCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domainerrors.BadRequest("invalid cursor")
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, domainerrors.BadRequest("invalid cursor")
+	}
+	at, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, domainerrors.BadRequest("invalid cursor")
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domainerrors.BadRequest("invalid cursor")
+	}
+	return &repositories.ActivityCursor{CreatedAt: at, ID: parsedID}, nil
+}
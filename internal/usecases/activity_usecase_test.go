@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	domainrepos "payment-kita.backend/internal/domain/repositories"
+)
+
+type activityRepoStub struct {
+	items      []*entities.ActivityItem
+	lastFilter domainrepos.ActivityFilter
+	lastAfter  *domainrepos.ActivityCursor
+	lastLimit  int
+}
+
+func (s *activityRepoStub) List(_ context.Context, filter domainrepos.ActivityFilter, after *domainrepos.ActivityCursor, limit int) ([]*entities.ActivityItem, error) {
+	s.lastFilter, s.lastAfter, s.lastLimit = filter, after, limit
+	if len(s.items) > limit {
+		return s.items[:limit], nil
+	}
+	return s.items, nil
+}
+
+type activityMerchantRepoStub struct {
+	fakeMerchantRepo
+	err error
+}
+
+func (s *activityMerchantRepoStub) GetByUserID(context.Context, uuid.UUID) (*entities.Merchant, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.merchant, nil
+}
+
+func TestActivityUsecase_ListActivity_PagesWithCursor(t *testing.T) {
+	merchant := &entities.Merchant{ID: uuid.New()}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &activityRepoStub{}
+	for i := 0; i < 3; i++ {
+		repo.items = append(repo.items, &entities.ActivityItem{Type: entities.ActivityTypePayment, ID: uuid.New(), CreatedAt: base.Add(-time.Duration(i) * time.Minute)})
+	}
+	u := NewActivityUsecase(repo, &activityMerchantRepoStub{fakeMerchantRepo: fakeMerchantRepo{merchant: merchant}})
+	userID := uuid.New()
+
+	page, err := u.ListActivity(context.Background(), userID, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, repo.lastLimit)
+	require.Equal(t, userID, repo.lastFilter.UserID)
+	require.Equal(t, merchant.ID, *repo.lastFilter.MerchantID)
+	require.Nil(t, repo.lastAfter)
+	require.Len(t, page.Items, 2)
+	require.True(t, page.HasMore)
+	require.NotEmpty(t, page.NextCursor)
+
+	repo.items = repo.items[2:]
+	page, err = u.ListActivity(context.Background(), userID, page.NextCursor, 2)
+	require.NoError(t, err)
+	require.Equal(t, base.Add(-time.Minute), repo.lastAfter.CreatedAt)
+	require.False(t, page.HasMore)
+	require.Empty(t, page.NextCursor)
+	require.Len(t, page.Items, 1)
+}
+
+func TestActivityUsecase_ListActivity_UserWithoutMerchantAndBadCursor(t *testing.T) {
+	repo := &activityRepoStub{}
+	u := NewActivityUsecase(repo, &activityMerchantRepoStub{err: domainerrors.ErrNotFound})
+
+	page, err := u.ListActivity(context.Background(), uuid.New(), "", 10)
+	require.NoError(t, err)
+	require.Nil(t, repo.lastFilter.MerchantID)
+	require.NotNil(t, page.Items)
+	require.Empty(t, page.Items)
+
+	_, err = u.ListActivity(context.Background(), uuid.New(), "not-a-cursor", 10)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+}
@@ -19,6 +19,7 @@ import (
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/pkg/crypto"
 )
 
 var (
@@ -32,12 +33,20 @@ type ApiKeyUsecase struct {
 	apiKeyRepo    repositories.ApiKeyRepository
 	userRepo      repositories.UserRepository
 	encryptionKey []byte // 32 bytes for AES-256
+	// pepper keys the API key lookup hash. It lives only in config, so the stored hashes
+	// cannot be recomputed or forged from a database dump alone.
+	pepper []byte
+	// suspensions caches EnsureNotSuspended answers
+	suspensions *userSuspensionCache
 }
 
+// NewApiKeyUsecase creates the API key usecase. An empty pepper keeps the legacy unkeyed
+// SHA-256 lookup hash.
 func NewApiKeyUsecase(
 	apiKeyRepo repositories.ApiKeyRepository,
 	userRepo repositories.UserRepository,
 	encryptionKeyHex string,
+	pepper string,
 ) *ApiKeyUsecase {
 	key, err := hex.DecodeString(encryptionKeyHex)
 	if err != nil || len(key) != 32 {
@@ -53,27 +62,39 @@ func NewApiKeyUsecase(
 		apiKeyRepo:    apiKeyRepo,
 		userRepo:      userRepo,
 		encryptionKey: key,
+		pepper:        []byte(pepper),
+		suspensions:   newUserSuspensionCache(),
 	}
 }
 
 func (u *ApiKeyUsecase) CreateApiKey(ctx context.Context, userID uuid.UUID, input *entities.CreateApiKeyInput) (*entities.CreateApiKeyResponse, error) {
 	// Generate Key and Secret
-	// pk_live_<32 hex chars>
-	// sk_live_<32 hex chars>
+	// pk_<mode>_<32 hex chars>
+	// sk_<mode>_<32 hex chars>
+	mode := entities.PaymentModeLive
+	if input.Mode != "" {
+		parsed, ok := entities.ParsePaymentMode(string(input.Mode))
+		if !ok {
+			return nil, domainerrors.BadRequest("mode must be live or test")
+		}
+		mode = parsed
+	}
+	keyPrefix := "pk_" + string(mode) + "_"
+
 	apiKeyRaw, err := generateRandomHex(32)
 	if err != nil {
 		return nil, domainerrors.InternalServerError("failed to generate key")
 	}
-	apiKey := "pk_live_" + apiKeyRaw
+	apiKey := keyPrefix + apiKeyRaw
 
 	secretKeyRaw, err := generateRandomHex(32)
 	if err != nil {
 		return nil, domainerrors.InternalServerError("failed to generate secret")
 	}
-	secretKey := "sk_live_" + secretKeyRaw
+	secretKey := "sk_" + string(mode) + "_" + secretKeyRaw
 
-	// Hash Key (SHA256)
-	keyHash := sha256Hex([]byte(apiKey))
+	// Hash Key (HMAC-SHA256 with the pepper, plain SHA256 without one)
+	keyHash := u.hashAPIKey(apiKey)
 
 	// Encrypt Secret (AES-GCM)
 	secretEncrypted, err := u.encrypt(secretKey)
@@ -87,7 +108,7 @@ func (u *ApiKeyUsecase) CreateApiKey(ctx context.Context, userID uuid.UUID, inpu
 	entity := &entities.ApiKey{
 		UserID:          userID,
 		Name:            input.Name,
-		KeyPrefix:       "pk_live_",
+		KeyPrefix:       keyPrefix,
 		KeyHash:         keyHash,
 		SecretEncrypted: secretEncrypted,
 		SecretMasked:    secretMasked,
@@ -106,6 +127,7 @@ func (u *ApiKeyUsecase) CreateApiKey(ctx context.Context, userID uuid.UUID, inpu
 		Name:      entity.Name,
 		ApiKey:    apiKey,    // Shown once
 		SecretKey: secretKey, // Shown once
+		Mode:      mode,
 		CreatedAt: entity.CreatedAt,
 	}, nil
 }
@@ -155,8 +177,7 @@ func (u *ApiKeyUsecase) validateAPIKeyWithCanonicalString(
 	}
 
 	// 2. Lookup API Key
-	keyHash := sha256Hex([]byte(apiKey))
-	keyEntity, err := u.apiKeyRepo.FindByKeyHash(ctx, keyHash)
+	keyEntity, err := u.findAPIKey(ctx, apiKey)
 	if err != nil {
 		return nil, domainerrors.Unauthorized("invalid api key")
 	}
@@ -176,30 +197,36 @@ func (u *ApiKeyUsecase) validateAPIKeyWithCanonicalString(
 	stringToSign := stringToSignBuilder(timestamp, method, path, bodyHash)
 	expectedSignature := hmacSha256Hex(secretKey, stringToSign)
 
-	if !hmac.Equal([]byte(expectedSignature), []byte(signature)) { // Constant time compare? Hex strings vs hex strings is usually fine if length constant, but hmac.Equal expects []byte
+	if !crypto.ConstantTimeEqual(expectedSignature, signature) {
 		return nil, domainerrors.Unauthorized("invalid signature")
 	}
 
-	// 5. Update LastUsedAt (Async/Fire-and-forget ideally, but sync is fine for now)
+	// 5. Update LastUsedAt (Async/Fire-and-forget ideally, but sync is fine for now).
+	// findAPIKey already swapped a legacy hash for the peppered one, so this also migrates it.
 	nowTime := time.Now()
 	keyEntity.LastUsedAt = &nowTime
 	_ = u.apiKeyRepo.Update(ctx, keyEntity)
 
 	// 6. Return User
-	if keyEntity.User == nil {
+	user := keyEntity.User
+	if user == nil {
 		// Should have been preloaded, if not, fetch
-		user, err := u.userRepo.GetByID(ctx, keyEntity.UserID)
+		user, err = u.userRepo.GetByID(ctx, keyEntity.UserID)
 		if err != nil {
 			return nil, domainerrors.InternalServerError("api key owner not found")
 		}
-		return user, nil
+	}
+	// A suspended user's keys stay on file but stop working
+	if user.IsSuspended() {
+		return nil, domainerrors.ErrAccountSuspended
 	}
 
-	return keyEntity.User, nil
+	return user, nil
 }
 
 // ValidateSignatureForJWT verifies signature using USER'S active API keys
-// Returns nil if signature valid for ANY active key, else error
+// Returns nil if signature valid for ANY active key, else error. A suspended user gets
+// domainerrors.ErrAccountSuspended even with a valid signature.
 func (u *ApiKeyUsecase) ValidateSignatureForJWT(
 	ctx context.Context,
 	userID uuid.UUID,
@@ -241,8 +268,15 @@ func (u *ApiKeyUsecase) ValidateSignatureForJWT(
 		}
 
 		expected := hmacSha256Hex(secret, stringToSign)
-		if hmac.Equal([]byte(expected), []byte(signature)) {
-			// Valid!
+		if crypto.ConstantTimeEqual(expected, signature) {
+			// Valid, unless the account has been suspended since the JWT was issued
+			user, err := u.userRepo.GetByID(ctx, userID)
+			if err != nil {
+				return domainerrors.Unauthorized("user not found")
+			}
+			if user.IsSuspended() {
+				return domainerrors.ErrAccountSuspended
+			}
 			now := time.Now()
 			k.LastUsedAt = &now
 			_ = u.apiKeyRepo.Update(ctx, k)
@@ -277,6 +311,43 @@ func (u *ApiKeyUsecase) RevokeApiKey(ctx context.Context, userID uuid.UUID, id u
 	return u.apiKeyRepo.Delete(ctx, id)
 }
 
+// hashAPIKey derives the stored lookup hash of a public API key
+func (u *ApiKeyUsecase) hashAPIKey(apiKey string) string {
+	if len(u.pepper) == 0 {
+		return sha256Hex([]byte(apiKey))
+	}
+	return hmacSha256Hex(string(u.pepper), apiKey)
+}
+
+// findAPIKey looks a key up by its peppered hash, falling back to the legacy unkeyed hash for
+// keys created before the pepper was configured. A legacy match gets its KeyHash replaced so the
+// caller's next Update stores the peppered hash. The stored hash is re-checked in constant time
+// rather than trusting the database's string match.
+func (u *ApiKeyUsecase) findAPIKey(ctx context.Context, apiKey string) (*entities.ApiKey, error) {
+	keyHash := u.hashAPIKey(apiKey)
+	keyEntity, err := u.apiKeyRepo.FindByKeyHash(ctx, keyHash)
+	if err == nil && crypto.ConstantTimeEqual(keyEntity.KeyHash, keyHash) {
+		return keyEntity, nil
+	}
+	if len(u.pepper) == 0 {
+		if err == nil {
+			err = domainerrors.ErrNotFound
+		}
+		return nil, err
+	}
+
+	legacyHash := sha256Hex([]byte(apiKey))
+	keyEntity, err = u.apiKeyRepo.FindByKeyHash(ctx, legacyHash)
+	if err != nil {
+		return nil, err
+	}
+	if !crypto.ConstantTimeEqual(keyEntity.KeyHash, legacyHash) {
+		return nil, domainerrors.ErrNotFound
+	}
+	keyEntity.KeyHash = keyHash
+	return keyEntity, nil
+}
+
 // Helpers
 
 func generateRandomHex(n int) (string, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/usecases"
 )
 
@@ -20,7 +21,7 @@ func TestApiKeyUsecase_NewAndCreate_Branches(t *testing.T) {
 		mockApiKeyRepo := new(MockApiKeyRepository)
 		mockUserRepo := new(MockUserRepository)
 		raw32 := "abcdefghijklmnopqrstuvwxyzABCDEF" // 32 chars
-		uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, raw32)
+		uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, raw32, "")
 
 		userID := uuid.New()
 		input := &entities.CreateApiKeyInput{Name: "Raw Key", Permissions: []string{"read"}}
@@ -33,7 +34,7 @@ func TestApiKeyUsecase_NewAndCreate_Branches(t *testing.T) {
 	t.Run("create fails when encryption key invalid", func(t *testing.T) {
 		mockApiKeyRepo := new(MockApiKeyRepository)
 		mockUserRepo := new(MockUserRepository)
-		uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "bad-key")
+		uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "bad-key", "")
 		_, err := uc.CreateApiKey(context.Background(), uuid.New(), &entities.CreateApiKeyInput{Name: "x"})
 		require.Error(t, err)
 	})
@@ -43,7 +44,7 @@ func TestApiKeyUsecase_ValidateApiKey_ErrorBranches(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 	ctx := context.Background()
 
 	t.Run("invalid timestamp", func(t *testing.T) {
@@ -109,6 +110,7 @@ func TestApiKeyUsecase_ValidateApiKey_ErrorBranches(t *testing.T) {
 		userID := uuid.New()
 
 		mockApiKeyRepo.On("FindByKeyHash", ctx, sha256Hex([]byte(apiKey))).Return(&entities.ApiKey{
+			KeyHash:         sha256Hex([]byte(apiKey)),
 			IsActive:        true,
 			SecretEncrypted: enc,
 			UserID:          userID,
@@ -134,6 +136,7 @@ func TestApiKeyUsecase_ValidateApiKey_ErrorBranches(t *testing.T) {
 		user := &entities.User{ID: userID, Email: "ok@paymentkita.io"}
 
 		mockApiKeyRepo.On("FindByKeyHash", ctx, sha256Hex([]byte(apiKey))).Return(&entities.ApiKey{
+			KeyHash:         sha256Hex([]byte(apiKey)),
 			IsActive:        true,
 			SecretEncrypted: enc,
 			UserID:          userID,
@@ -147,13 +150,36 @@ func TestApiKeyUsecase_ValidateApiKey_ErrorBranches(t *testing.T) {
 		require.NotNil(t, resolvedUser)
 		require.Equal(t, userID, resolvedUser.ID)
 	})
+
+	t.Run("owner suspended", func(t *testing.T) {
+		apiKey := "pk_live_user_suspended"
+		secretKey := "sk_live_user_suspended"
+		enc, _ := encryptSecret(secretKey, encryptionKey)
+		ts := fmt.Sprintf("%d", time.Now().Unix())
+		method := "POST"
+		path := "/v1/payments"
+		bodyHash := sha256Hex([]byte(`{"x":3}`))
+		signature := hmacSha256Hex(secretKey, fmt.Sprintf("%s%s%s%s", ts, method, path, bodyHash))
+		suspendedAt := time.Now()
+
+		mockApiKeyRepo.On("FindByKeyHash", ctx, sha256Hex([]byte(apiKey))).Return(&entities.ApiKey{
+			KeyHash:         sha256Hex([]byte(apiKey)),
+			IsActive:        true,
+			SecretEncrypted: enc,
+			User:            &entities.User{ID: uuid.New(), SuspendedAt: &suspendedAt},
+		}, nil).Once()
+		mockApiKeyRepo.On("Update", ctx, mock.AnythingOfType("*entities.ApiKey")).Return(nil).Once()
+
+		_, err := uc.ValidateApiKey(ctx, apiKey, signature, ts, method, path, bodyHash)
+		require.ErrorIs(t, err, domainerrors.ErrAccountSuspended)
+	})
 }
 
 func TestApiKeyUsecase_ValidateSignatureForJWT_ErrorBranches(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 	ctx := context.Background()
 	userID := uuid.New()
 
@@ -187,7 +213,7 @@ func TestApiKeyUsecase_ValidateSignatureForJWT_ErrorBranches(t *testing.T) {
 func TestApiKeyUsecase_RevokeApiKey_Branches(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 	ctx := context.Background()
 	userID := uuid.New()
 	keyID := uuid.New()
@@ -12,6 +12,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/pkg/utils"
 )
 
 type apiKeyRepoMiniStub struct {
@@ -68,12 +70,14 @@ func (userRepoMiniStub) GetByEmail(context.Context, string) (*entities.User, err
 func (userRepoMiniStub) Update(context.Context, *entities.User) error                    { return nil }
 func (userRepoMiniStub) UpdatePassword(context.Context, uuid.UUID, string) error         { return nil }
 func (userRepoMiniStub) SoftDelete(context.Context, uuid.UUID) error                     { return nil }
-func (userRepoMiniStub) List(context.Context, string) ([]*entities.User, error)          { return nil, nil }
+func (userRepoMiniStub) List(context.Context, repositories.UserFilter, utils.PaginationParams) ([]*entities.User, int64, error) {
+	return nil, 0, nil
+}
 
 func TestApiKeyUsecase_CreateApiKey_RandomFailureBranches(t *testing.T) {
 	validKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
 	repo := &apiKeyRepoMiniStub{}
-	uc := NewApiKeyUsecase(repo, userRepoMiniStub{}, validKey)
+	uc := NewApiKeyUsecase(repo, userRepoMiniStub{}, validKey, "")
 
 	orig := apiKeyRandRead
 	t.Cleanup(func() { apiKeyRandRead = orig })
@@ -107,12 +111,13 @@ func TestApiKeyUsecase_ValidateApiKey_DecryptInvalidCipherKeyBranch(t *testing.T
 	// invalid key on constructor path => decrypt hits aes.NewCipher key-size error branch
 	uc := NewApiKeyUsecase(
 		&apiKeyRepoMiniStub{
-			findByKeyHashFn: func(context.Context, string) (*entities.ApiKey, error) {
-				return &entities.ApiKey{ID: uuid.New(), UserID: uuid.New(), IsActive: true, SecretEncrypted: "00"}, nil
+			findByKeyHashFn: func(_ context.Context, keyHash string) (*entities.ApiKey, error) {
+				return &entities.ApiKey{ID: uuid.New(), UserID: uuid.New(), KeyHash: keyHash, IsActive: true, SecretEncrypted: "00"}, nil
 			},
 		},
 		userRepoMiniStub{},
 		"invalid-encryption-key",
+		"",
 	)
 
 	timestamp := fmt.Sprintf("%d", time.Now().Unix())
@@ -127,7 +132,7 @@ func (errNonceReader) Read(_ []byte) (int, error) { return 0, io.ErrUnexpectedEO
 
 func TestApiKeyUsecase_EncryptDecrypt_ErrorBranches(t *testing.T) {
 	validKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	uc := NewApiKeyUsecase(&apiKeyRepoMiniStub{}, userRepoMiniStub{}, validKey)
+	uc := NewApiKeyUsecase(&apiKeyRepoMiniStub{}, userRepoMiniStub{}, validKey, "")
 
 	t.Run("encrypt nonce read error", func(t *testing.T) {
 		origReader := apiKeyRandReader
@@ -148,7 +153,7 @@ func TestApiKeyUsecase_EncryptDecrypt_ErrorBranches(t *testing.T) {
 		enc, err := uc.encrypt("hello")
 		require.NoError(t, err)
 
-		ucOther := NewApiKeyUsecase(&apiKeyRepoMiniStub{}, userRepoMiniStub{}, "ffeeddccbbaa99887766554433221100ffeeddccbbaa99887766554433221100")
+		ucOther := NewApiKeyUsecase(&apiKeyRepoMiniStub{}, userRepoMiniStub{}, "ffeeddccbbaa99887766554433221100ffeeddccbbaa99887766554433221100", "")
 		_, err = ucOther.decrypt(enc)
 		require.Error(t, err)
 	})
@@ -162,7 +167,7 @@ func TestApiKeyUsecase_EncryptDecrypt_ErrorBranches(t *testing.T) {
 	})
 
 	t.Run("encrypt invalid key size", func(t *testing.T) {
-		ucBad := NewApiKeyUsecase(&apiKeyRepoMiniStub{}, userRepoMiniStub{}, "bad-key")
+		ucBad := NewApiKeyUsecase(&apiKeyRepoMiniStub{}, userRepoMiniStub{}, "bad-key", "")
 		_, err := ucBad.encrypt("plain")
 		require.Error(t, err)
 	})
@@ -185,7 +190,7 @@ func TestApiKeyUsecase_EncryptDecrypt_ErrorBranches(t *testing.T) {
 	})
 
 	t.Run("decrypt invalid key size", func(t *testing.T) {
-		ucBad := NewApiKeyUsecase(&apiKeyRepoMiniStub{}, userRepoMiniStub{}, "bad-key")
+		ucBad := NewApiKeyUsecase(&apiKeyRepoMiniStub{}, userRepoMiniStub{}, "bad-key", "")
 		_, err := ucBad.decrypt("00")
 		require.Error(t, err)
 	})
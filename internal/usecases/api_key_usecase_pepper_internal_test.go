@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestApiKeyUsecase_PepperedHashAndLegacyMigration(t *testing.T) {
+	encKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+	stored := map[string]*entities.ApiKey{}
+	repo := &apiKeyRepoMiniStub{
+		createFn: func(_ context.Context, k *entities.ApiKey) error {
+			k.ID = uuid.New()
+			stored[k.KeyHash] = k
+			return nil
+		},
+		findByKeyHashFn: func(_ context.Context, hash string) (*entities.ApiKey, error) {
+			k, ok := stored[hash]
+			if !ok {
+				return nil, domainerrors.ErrNotFound
+			}
+			copied := *k
+			return &copied, nil
+		},
+		updateFn: func(_ context.Context, k *entities.ApiKey) error {
+			for hash, existing := range stored {
+				if existing.ID == k.ID {
+					delete(stored, hash)
+				}
+			}
+			stored[k.KeyHash] = k
+			return nil
+		},
+	}
+
+	// A key created before the pepper existed is stored under the plain SHA-256 hash.
+	legacy := NewApiKeyUsecase(repo, userRepoMiniStub{}, encKey, "")
+	created, err := legacy.CreateApiKey(context.Background(), uuid.New(), &entities.CreateApiKeyInput{Name: "legacy"})
+	require.NoError(t, err)
+	legacyHash := sha256Hex([]byte(created.ApiKey))
+	require.Contains(t, stored, legacyHash)
+
+	peppered := NewApiKeyUsecase(repo, userRepoMiniStub{}, encKey, "pepper-from-config")
+	pepperedHash := hmacSha256Hex("pepper-from-config", created.ApiKey)
+	require.NotEqual(t, legacyHash, pepperedHash)
+
+	sign := func() (string, string) {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		return ts, hmacSha256Hex(created.SecretKey, buildPartnerAPIKeyStringToSign(ts, "GET", "/v1/x", ""))
+	}
+
+	// A bad signature finds the legacy key but does not migrate it.
+	ts, _ := sign()
+	_, err = peppered.ValidatePartnerApiKey(context.Background(), created.ApiKey, "bad", ts, "GET", "/v1/x", "")
+	require.Error(t, err)
+	require.Contains(t, stored, legacyHash)
+
+	// The first verified request re-hashes the key with the pepper.
+	ts, sig := sign()
+	_, err = peppered.ValidatePartnerApiKey(context.Background(), created.ApiKey, sig, ts, "GET", "/v1/x", "")
+	require.NoError(t, err)
+	require.Contains(t, stored, pepperedHash)
+	require.NotContains(t, stored, legacyHash)
+
+	ts, sig = sign()
+	_, err = peppered.ValidatePartnerApiKey(context.Background(), created.ApiKey, sig, ts, "GET", "/v1/x", "")
+	require.NoError(t, err)
+
+	// New keys are stored only under the peppered hash.
+	fresh, err := peppered.CreateApiKey(context.Background(), uuid.New(), &entities.CreateApiKeyInput{Name: "fresh"})
+	require.NoError(t, err)
+	require.Contains(t, stored, hmacSha256Hex("pepper-from-config", fresh.ApiKey))
+	require.NotContains(t, stored, sha256Hex([]byte(fresh.ApiKey)))
+
+	// Without the pepper a dump of the stored hashes no longer matches the key.
+	_, err = legacy.findAPIKey(context.Background(), fresh.ApiKey)
+	require.Error(t, err)
+}
+
+func TestApiKeyUsecase_FindAPIKey_RejectsMismatchedStoredHash(t *testing.T) {
+	repo := &apiKeyRepoMiniStub{
+		findByKeyHashFn: func(context.Context, string) (*entities.ApiKey, error) {
+			return &entities.ApiKey{KeyHash: "something-else"}, nil
+		},
+	}
+	for _, pepper := range []string{"", "pepper"} {
+		uc := NewApiKeyUsecase(repo, userRepoMiniStub{}, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", pepper)
+		_, err := uc.findAPIKey(context.Background(), "pk_live_x")
+		require.ErrorIs(t, err, domainerrors.ErrNotFound, "pepper=%q", pepper)
+	}
+}
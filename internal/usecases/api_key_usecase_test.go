@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/usecases"
 )
 
@@ -26,7 +28,7 @@ func TestApiKeyUsecase_CreateApiKey(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
 
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	userID := uuid.New()
 	input := &entities.CreateApiKeyInput{
@@ -49,12 +51,37 @@ func TestApiKeyUsecase_CreateApiKey(t *testing.T) {
 	mockApiKeyRepo.AssertExpectations(t)
 }
 
+func TestApiKeyUsecase_CreateApiKey_TestMode(t *testing.T) {
+	mockApiKeyRepo := new(MockApiKeyRepository)
+	mockUserRepo := new(MockUserRepository)
+	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
+	ctx := context.Background()
+
+	var stored *entities.ApiKey
+	mockApiKeyRepo.On("Create", ctx, mock.AnythingOfType("*entities.ApiKey")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*entities.ApiKey) }).
+		Return(nil)
+
+	resp, err := uc.CreateApiKey(ctx, uuid.New(), &entities.CreateApiKeyInput{Name: "Sandbox", Mode: entities.PaymentModeTest})
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PaymentModeTest, resp.Mode)
+	assert.True(t, strings.HasPrefix(resp.ApiKey, "pk_test_"))
+	assert.True(t, strings.HasPrefix(resp.SecretKey, "sk_test_"))
+	assert.Equal(t, "pk_test_", stored.KeyPrefix)
+	assert.Equal(t, entities.PaymentModeTest, stored.Mode())
+
+	_, err = uc.CreateApiKey(ctx, uuid.New(), &entities.CreateApiKeyInput{Name: "Bad", Mode: "staging"})
+	assert.Error(t, err)
+}
+
 func TestApiKeyUsecase_ValidateApiKey(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
 
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -106,7 +133,7 @@ func TestApiKeyUsecase_ValidatePartnerApiKey(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
 
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -154,7 +181,7 @@ func TestApiKeyUsecase_ValidateSignatureForJWT(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
 
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -181,18 +208,25 @@ func TestApiKeyUsecase_ValidateSignatureForJWT(t *testing.T) {
 
 	mockApiKeyRepo.On("FindByUserID", ctx, userID).Return(activeKeys, nil)
 	mockApiKeyRepo.On("Update", ctx, mock.AnythingOfType("*entities.ApiKey")).Return(nil)
+	mockUserRepo.On("GetByID", ctx, userID).Return(&entities.User{ID: userID}, nil).Once()
 
 	err := uc.ValidateSignatureForJWT(ctx, userID, signature, timestamp, method, path, bodyHash)
 
 	assert.NoError(t, err)
 
 	mockApiKeyRepo.AssertExpectations(t)
+
+	// A valid signature no longer works once the account is suspended
+	suspendedAt := time.Now()
+	mockUserRepo.On("GetByID", ctx, userID).Return(&entities.User{ID: userID, SuspendedAt: &suspendedAt}, nil).Once()
+	err = uc.ValidateSignatureForJWT(ctx, userID, signature, timestamp, method, path, bodyHash)
+	assert.ErrorIs(t, err, domainerrors.ErrAccountSuspended)
 }
 
 func TestApiKeyUsecase_ListApiKeys(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -216,7 +250,7 @@ func TestApiKeyUsecase_ListApiKeys(t *testing.T) {
 func TestApiKeyUsecase_RevokeApiKey(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -240,7 +274,7 @@ func TestApiKeyUsecase_RevokeApiKey(t *testing.T) {
 func TestApiKeyUsecase_CreateApiKey_CreateError(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -257,7 +291,7 @@ func TestApiKeyUsecase_CreateApiKey_CreateError(t *testing.T) {
 func TestApiKeyUsecase_CreateApiKey_InvalidEncryptionKey(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "invalid-short-key")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "invalid-short-key", "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -272,7 +306,7 @@ func TestApiKeyUsecase_CreateApiKey_InvalidEncryptionKey(t *testing.T) {
 func TestApiKeyUsecase_ValidateApiKey_InvalidTimestamp(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	_, err := uc.ValidateApiKey(context.Background(), "pk", "sig", "not-a-number", "GET", "/v1/test", "")
 	assert.Error(t, err)
@@ -282,7 +316,7 @@ func TestApiKeyUsecase_ValidateApiKey_InvalidTimestamp(t *testing.T) {
 func TestApiKeyUsecase_ValidateApiKey_ExpiredTimestamp(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	oldTS := fmt.Sprintf("%d", time.Now().Add(-10*time.Minute).Unix())
 	_, err := uc.ValidateApiKey(context.Background(), "pk", "sig", oldTS, "GET", "/v1/test", "")
@@ -293,7 +327,7 @@ func TestApiKeyUsecase_ValidateApiKey_ExpiredTimestamp(t *testing.T) {
 func TestApiKeyUsecase_ValidateApiKey_KeyInactive(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	ctx := context.Background()
 	apiKey := "pk_live_inactive"
@@ -315,7 +349,7 @@ func TestApiKeyUsecase_ValidateApiKey_UserFallbackError(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
 	encryptionKey := "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey)
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, encryptionKey, "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -349,7 +383,7 @@ func TestApiKeyUsecase_ValidateApiKey_UserFallbackError(t *testing.T) {
 func TestApiKeyUsecase_ValidateSignatureForJWT_NoKeys(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -364,7 +398,7 @@ func TestApiKeyUsecase_ValidateSignatureForJWT_NoKeys(t *testing.T) {
 func TestApiKeyUsecase_ValidateSignatureForJWT_RepoError(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -379,7 +413,7 @@ func TestApiKeyUsecase_ValidateSignatureForJWT_RepoError(t *testing.T) {
 func TestApiKeyUsecase_RevokeApiKey_NotOwner(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	ctx := context.Background()
 	caller := uuid.New()
@@ -395,7 +429,7 @@ func TestApiKeyUsecase_RevokeApiKey_NotOwner(t *testing.T) {
 func TestApiKeyUsecase_RevokeApiKey_FindError(t *testing.T) {
 	mockApiKeyRepo := new(MockApiKeyRepository)
 	mockUserRepo := new(MockUserRepository)
-	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff")
+	uc := usecases.NewApiKeyUsecase(mockApiKeyRepo, mockUserRepo, "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff", "")
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -8,12 +8,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
 
 	"payment-kita.backend/pkg/crypto"
 	"payment-kita.backend/pkg/jwt"
+	"payment-kita.backend/pkg/logger"
 	"payment-kita.backend/pkg/redis"
 	"payment-kita.backend/pkg/utils"
 )
@@ -23,6 +25,7 @@ var (
 	authGenerateVerificationToken = crypto.GenerateVerificationToken
 	authJSONMarshal               = json.Marshal
 	authRedisSet                  = redis.Set
+	authRedisAvailable            = redis.Available
 	authGenerateTokenPair         = func(s *jwt.JWTService, userID uuid.UUID, email, role string) (*jwt.TokenPair, error) {
 		return s.GenerateTokenPair(userID, email, role)
 	}
@@ -69,13 +72,6 @@ func (u *AuthUsecase) Register(ctx context.Context, input *entities.CreateUserIn
 		return nil, "", domainerrors.ErrBadRequest
 	}
 
-	// TODO: Verify wallet signature
-	// This would involve:
-	// 1. Recover signer from signature
-	// 2. Compare recovered address with input.WalletAddress
-	// 3. Verify message format and timestamp
-	// For now, we accept any valid-looking signature
-
 	// Check if email already exists
 	_, err := u.userRepo.GetByEmail(ctx, input.Email)
 	if err == nil {
@@ -86,17 +82,24 @@ func (u *AuthUsecase) Register(ctx context.Context, input *entities.CreateUserIn
 	}
 
 	// Check if wallet already registered to another user
-	chainUUID, _, err := u.chainResolver.ResolveFromAny(ctx, input.WalletChainID)
+	chain, err := u.chainResolver.ResolveChain(ctx, input.WalletChainID)
 	if err != nil {
 		return nil, "", domainerrors.ErrInvalidInput
 	}
-	existingWallet, err := u.walletRepo.GetByAddress(ctx, chainUUID, input.WalletAddress)
+	existingWallet, err := u.walletRepo.GetByAddress(ctx, chain.ID, input.WalletAddress)
 	if err != nil && !errors.Is(err, domainerrors.ErrNotFound) {
 		return nil, "", err
 	}
 	if existingWallet != nil && existingWallet.UserID != nil {
 		return nil, "", domainerrors.NewError("wallet already registered to another user", domainerrors.ErrAlreadyExists)
 	}
+	// Adopting an unowned wallet needs the wallet's signature over a challenge issued to this
+	// email, as in ConnectWallet; the address alone proves nothing.
+	if existingWallet != nil {
+		if err := verifyWalletOwnership(ctx, chain, input.Email, input.WalletAddress, input.WalletNonce, input.WalletSignature); err != nil {
+			return nil, "", err
+		}
+	}
 
 	// Hash password
 	passwordHash, err := authHashPassword(input.Password)
@@ -107,7 +110,8 @@ func (u *AuthUsecase) Register(ctx context.Context, input *entities.CreateUserIn
 	var user *entities.User
 	var token string
 
-	// Execute User + Wallet + Merchant creation in a transaction
+	// Execute User + Wallet + Merchant + verification creation in one transaction so a
+	// failure mid-way never leaves an orphaned user or wallet behind for the retry to trip on.
 	err = u.uow.Do(ctx, func(txCtx context.Context) error {
 		userRole := entities.UserRoleUser
 		if input.IsMerchant {
@@ -116,6 +120,7 @@ func (u *AuthUsecase) Register(ctx context.Context, input *entities.CreateUserIn
 
 		// Create user
 		user = &entities.User{
+			ID:           utils.GenerateUUIDv7(),
 			Email:        input.Email,
 			Name:         input.Name,
 			PasswordHash: passwordHash,
@@ -127,16 +132,25 @@ func (u *AuthUsecase) Register(ctx context.Context, input *entities.CreateUserIn
 			return err
 		}
 
-		// Create wallet linked to user (as primary)
-		wallet := &entities.Wallet{
-			UserID:    &user.ID,
-			ChainID:   chainUUID,
-			Address:   input.WalletAddress,
-			IsPrimary: true,
-		}
+		// Adopt an existing unowned wallet, or create one linked to the user (as primary).
+		// The claim only succeeds while user_id is still NULL, so a concurrent registration
+		// adopting the same wallet rolls back with a conflict instead of double-linking it.
+		if existingWallet != nil {
+			if err := u.walletRepo.ClaimUnowned(txCtx, existingWallet.ID, user.ID, true); err != nil {
+				return err
+			}
+		} else {
+			wallet := &entities.Wallet{
+				ID:        utils.GenerateUUIDv7(),
+				UserID:    &user.ID,
+				ChainID:   chain.ID,
+				Address:   input.WalletAddress,
+				IsPrimary: true,
+			}
 
-		if err := u.walletRepo.Create(txCtx, wallet); err != nil {
-			return err
+			if err := u.walletRepo.Create(txCtx, wallet); err != nil {
+				return err
+			}
 		}
 
 		// Create Merchant record if applicable
@@ -169,12 +183,23 @@ func (u *AuthUsecase) Register(ctx context.Context, input *entities.CreateUserIn
 	})
 
 	if err != nil {
+		// A concurrent or retried registration lost the race on a unique constraint;
+		// the transaction was rolled back, so report a clean conflict.
+		if errors.Is(err, domainerrors.ErrAlreadyExists) {
+			return nil, "", domainerrors.ErrAlreadyExists
+		}
 		return nil, "", err
 	}
 
 	return user, token, nil
 }
 
+// IssueWalletChallenge returns a single-use challenge for input.Address, bound to input.Email,
+// that the wallet signs to prove ownership in Register
+func (u *AuthUsecase) IssueWalletChallenge(ctx context.Context, input *entities.WalletChallengeInput) (*entities.WalletChallenge, error) {
+	return issueWalletChallenge(ctx, input.Email, input.Address)
+}
+
 // Login authenticates a user and returns tokens
 func (u *AuthUsecase) Login(ctx context.Context, input *entities.LoginInput) (*entities.AuthResponse, error) {
 	// Get user by email
@@ -190,6 +215,10 @@ func (u *AuthUsecase) Login(ctx context.Context, input *entities.LoginInput) (*e
 	if !crypto.CheckPassword(input.Password, user.PasswordHash) {
 		return nil, domainerrors.ErrInvalidCredentials
 	}
+	// Only said after the password matched, so suspension does not reveal which emails exist
+	if user.IsSuspended() {
+		return nil, domainerrors.ErrAccountSuspended
+	}
 
 	// Generate tokens
 	tokenPair, err := authGenerateTokenPair(u.jwtService, user.ID, user.Email, string(user.Role))
@@ -197,8 +226,12 @@ func (u *AuthUsecase) Login(ctx context.Context, input *entities.LoginInput) (*e
 		return nil, err
 	}
 
-	// Handle Session Request
-	if input.UseSession {
+	// Handle Session Request. Without Redis there is nowhere to keep the session, so the
+	// client gets the token pair instead (JWT-only degraded mode).
+	if input.UseSession && !authRedisAvailable() {
+		logger.Warn(ctx, "Redis unavailable, issuing tokens instead of a session", zap.String("userId", user.ID.String()))
+	}
+	if input.UseSession && authRedisAvailable() {
 		sessionID := utils.GenerateUUIDv7().String()
 		sessionKey := fmt.Sprintf("session:%s", sessionID)
 
@@ -261,17 +294,64 @@ func (u *AuthUsecase) RefreshToken(ctx context.Context, refreshToken string) (*j
 	if err != nil {
 		return nil, err
 	}
+	// Impersonation sessions are not renewable; they would otherwise turn into a full session.
+	if claims.IsImpersonation() {
+		return nil, domainerrors.ErrUnauthorized
+	}
 
 	// Get current user to ensure still valid
 	user, err := u.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
 		return nil, err
 	}
+	if user.IsSuspended() {
+		return nil, domainerrors.ErrAccountSuspended
+	}
 
 	// Generate new token pair
 	return authGenerateTokenPair(u.jwtService, user.ID, user.Email, string(user.Role))
 }
 
+// ImpersonationTTL bounds how long an admin can act as another user per session
+const ImpersonationTTL = 15 * time.Minute
+
+// Impersonate mints a short-lived token that lets an admin act as targetUserID. Privileged
+// accounts cannot be impersonated, and an impersonation token cannot start another one.
+func (u *AuthUsecase) Impersonate(ctx context.Context, adminID, targetUserID uuid.UUID) (*entities.ImpersonationSession, error) {
+	if adminID == targetUserID {
+		return nil, domainerrors.BadRequest("cannot impersonate yourself")
+	}
+
+	admin, err := u.userRepo.GetByID(ctx, adminID)
+	if err != nil {
+		return nil, err
+	}
+	if admin.Role != entities.UserRoleAdmin {
+		return nil, domainerrors.ErrForbidden
+	}
+
+	target, err := u.userRepo.GetByID(ctx, targetUserID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Role.IsStaff() {
+		return nil, domainerrors.Forbidden("staff accounts cannot be impersonated")
+	}
+
+	expiresAt := time.Now().Add(ImpersonationTTL)
+	token, err := u.jwtService.GenerateImpersonationToken(target.ID, target.Email, string(target.Role), admin.ID, admin.Email, ImpersonationTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ImpersonationSession{
+		AccessToken:  token,
+		ExpiresAt:    expiresAt,
+		User:         target,
+		Impersonator: admin,
+	}, nil
+}
+
 // GetUserByID gets a user by ID
 func (u *AuthUsecase) GetUserByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
 	return u.userRepo.GetByID(ctx, id)
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/crypto"
 	"payment-kita.backend/pkg/jwt"
 	"payment-kita.backend/pkg/utils"
@@ -48,8 +49,10 @@ func (s *authUserRepoStub) UpdatePassword(ctx context.Context, id uuid.UUID, pas
 	}
 	return nil
 }
-func (s *authUserRepoStub) SoftDelete(context.Context, uuid.UUID) error            { return nil }
-func (s *authUserRepoStub) List(context.Context, string) ([]*entities.User, error) { return nil, nil }
+func (s *authUserRepoStub) SoftDelete(context.Context, uuid.UUID) error { return nil }
+func (s *authUserRepoStub) List(context.Context, repositories.UserFilter, utils.PaginationParams) ([]*entities.User, int64, error) {
+	return nil, 0, nil
+}
 
 type authEmailRepoStub struct {
 	createFn     func(context.Context, uuid.UUID, string) error
@@ -107,6 +110,9 @@ func (s *authWalletRepoStub) Update(context.Context, *entities.Wallet) error
 func (s *authWalletRepoStub) Delete(context.Context, uuid.UUID) error                { return nil }
 func (s *authWalletRepoStub) SetPrimary(context.Context, uuid.UUID, uuid.UUID) error { return nil }
 func (s *authWalletRepoStub) SoftDelete(context.Context, uuid.UUID) error            { return nil }
+func (s *authWalletRepoStub) ClaimUnowned(context.Context, uuid.UUID, uuid.UUID, bool) error {
+	return nil
+}
 
 type authChainRepoStub struct {
 	chain *entities.Chain
@@ -134,9 +140,13 @@ func (s *authChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool, *stri
 func (s *authChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *authChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *authChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *authChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *authChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *authChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *authChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *authChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *authChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -145,7 +155,8 @@ func (s *authChainRepoStub) GetRPCByID(context.Context, uuid.UUID) (*entities.Ch
 }
 
 type authMerchantRepoStub struct {
-	createFn func(context.Context, *entities.Merchant) error
+	createFn  func(context.Context, *entities.Merchant) error
+	getByIDFn func(context.Context, uuid.UUID) (*entities.Merchant, error)
 }
 
 func (s *authMerchantRepoStub) Create(ctx context.Context, merchant *entities.Merchant) error {
@@ -154,7 +165,10 @@ func (s *authMerchantRepoStub) Create(ctx context.Context, merchant *entities.Me
 	}
 	return nil
 }
-func (s *authMerchantRepoStub) GetByID(context.Context, uuid.UUID) (*entities.Merchant, error) {
+func (s *authMerchantRepoStub) GetByID(ctx context.Context, id uuid.UUID) (*entities.Merchant, error) {
+	if s.getByIDFn != nil {
+		return s.getByIDFn(ctx, id)
+	}
 	return nil, nil
 }
 func (s *authMerchantRepoStub) GetByUserID(context.Context, uuid.UUID) (*entities.Merchant, error) {
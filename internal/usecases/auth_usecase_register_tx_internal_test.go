@@ -0,0 +1,247 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/repositories"
+	"payment-kita.backend/pkg/jwt"
+)
+
+func createAuthRegisterTables(t *testing.T, db *gorm.DB, withVerifications bool) {
+	t.Helper()
+	mustExecIntegration(t, db, `CREATE TABLE users (
+		id TEXT PRIMARY KEY,
+		email TEXT UNIQUE,
+		name TEXT,
+		role TEXT,
+		kyc_status TEXT,
+		kyc_verified_at DATETIME,
+		password_hash TEXT,
+		fee_exempt BOOLEAN DEFAULT false,
+		suspended_at DATETIME,
+		created_at DATETIME,
+		updated_at DATETIME,
+		deleted_at DATETIME
+	);`)
+	mustExecIntegration(t, db, `CREATE TABLE wallets (
+		id TEXT PRIMARY KEY,
+		user_id TEXT,
+		merchant_id TEXT,
+		chain_id TEXT NOT NULL,
+		address TEXT NOT NULL,
+		is_primary BOOLEAN,
+		created_at DATETIME,
+		updated_at DATETIME,
+		deleted_at DATETIME,
+		UNIQUE (chain_id, address)
+	);`)
+	if withVerifications {
+		mustExecIntegration(t, db, `CREATE TABLE email_verifications (
+			id TEXT PRIMARY KEY,
+			user_id TEXT NOT NULL,
+			token TEXT NOT NULL,
+			expires_at DATETIME NOT NULL,
+			verified_at DATETIME,
+			created_at DATETIME,
+			updated_at DATETIME,
+			deleted_at DATETIME
+		);`)
+	}
+}
+
+func newAuthRegisterTxUsecase(db *gorm.DB, chain *entities.Chain) *AuthUsecase {
+	return NewAuthUsecase(
+		repositories.NewUserRepository(db),
+		repositories.NewEmailVerificationRepository(db),
+		repositories.NewWalletRepository(db),
+		&authChainRepoStub{chain: chain},
+		repositories.NewMerchantRepository(db),
+		repositories.NewUnitOfWork(db),
+		jwt.NewJWTService("test-secret", 15*time.Minute, 24*time.Hour),
+	)
+}
+
+// stubWalletChallenges keeps wallet challenges in memory for the test instead of Redis
+func stubWalletChallenges(t *testing.T) {
+	t.Helper()
+	store := map[string]string{}
+	prevSet, prevConsume := walletChallengeRedisSet, walletChallengeRedisConsume
+	t.Cleanup(func() { walletChallengeRedisSet, walletChallengeRedisConsume = prevSet, prevConsume })
+	walletChallengeRedisSet = func(_ context.Context, key string, value interface{}, _ time.Duration) error {
+		store[key] = value.(string)
+		return nil
+	}
+	walletChallengeRedisConsume = func(_ context.Context, key, value string) (bool, error) {
+		if store[key] != value {
+			return false, nil
+		}
+		delete(store, key)
+		return true, nil
+	}
+}
+
+// signedWalletRegistration is a registration for email whose wallet signed its challenge
+func signedWalletRegistration(t *testing.T, uc *AuthUsecase, email string) *entities.CreateUserInput {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	challenge, err := uc.IssueWalletChallenge(context.Background(), &entities.WalletChallengeInput{Address: address, Email: email})
+	require.NoError(t, err)
+	signature, err := crypto.Sign(accounts.TextHash([]byte(challenge.Message)), key)
+	require.NoError(t, err)
+	return &entities.CreateUserInput{
+		Email:           email,
+		Password:        "password123",
+		WalletAddress:   address,
+		WalletChainID:   "eip155:8453",
+		WalletSignature: hexutil.Encode(signature),
+		WalletNonce:     challenge.Nonce,
+	}
+}
+
+func countRows(t *testing.T, db *gorm.DB, table string) int64 {
+	t.Helper()
+	var n int64
+	require.NoError(t, db.Table(table).Count(&n).Error)
+	return n
+}
+
+func TestAuthUsecase_Register_RollsBackOnVerificationFailure(t *testing.T) {
+	db := newPartnerFlowIntegrationDB(t)
+	// No email_verifications table: the last insert in the transaction fails.
+	createAuthRegisterTables(t, db, false)
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM}
+	uc := newAuthRegisterTxUsecase(db, chain)
+
+	input := &entities.CreateUserInput{
+		Email:           "rollback@paymentkita.io",
+		Name:            "Rollback",
+		Password:        "password123",
+		WalletAddress:   "0x1111111111111111111111111111111111111111",
+		WalletChainID:   "eip155:8453",
+		WalletSignature: "sig",
+	}
+	_, _, err := uc.Register(context.Background(), input)
+	require.Error(t, err)
+	require.Zero(t, countRows(t, db, "users"))
+	require.Zero(t, countRows(t, db, "wallets"))
+
+	// Once the table exists the same input registers cleanly: no orphan blocks the retry.
+	mustExecIntegration(t, db, `CREATE TABLE email_verifications (
+		id TEXT PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		token TEXT NOT NULL,
+		expires_at DATETIME NOT NULL,
+		verified_at DATETIME,
+		created_at DATETIME,
+		updated_at DATETIME,
+		deleted_at DATETIME
+	);`)
+	user, token, err := uc.Register(context.Background(), input)
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, user.ID)
+	require.NotEmpty(t, token)
+	require.EqualValues(t, 1, countRows(t, db, "users"))
+	require.EqualValues(t, 1, countRows(t, db, "wallets"))
+}
+
+func TestAuthUsecase_Register_UniqueViolationReturnsAlreadyExists(t *testing.T) {
+	db := newPartnerFlowIntegrationDB(t)
+	createAuthRegisterTables(t, db, true)
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM}
+
+	// Simulate a concurrent registration that committed between our pre-checks and insert.
+	uc := newAuthRegisterTxUsecase(db, chain)
+	uc.userRepo = &authUserRepoStub{
+		getByEmailFn: func(context.Context, string) (*entities.User, error) { return nil, domainerrors.ErrNotFound },
+		createFn: func(ctx context.Context, user *entities.User) error {
+			return repositories.NewUserRepository(db).Create(ctx, user)
+		},
+	}
+	mustExecIntegration(t, db, `INSERT INTO users (id, email, name, role, kyc_status, password_hash) VALUES (?, ?, ?, ?, ?, ?)`,
+		uuid.NewString(), "race@paymentkita.io", "Other", "USER", "NOT_STARTED", "hash")
+
+	_, _, err := uc.Register(context.Background(), &entities.CreateUserInput{
+		Email:           "race@paymentkita.io",
+		Name:            "Race",
+		Password:        "password123",
+		WalletAddress:   "0x2222222222222222222222222222222222222222",
+		WalletChainID:   "eip155:8453",
+		WalletSignature: "sig",
+	})
+	require.ErrorIs(t, err, domainerrors.ErrAlreadyExists)
+	require.EqualValues(t, 1, countRows(t, db, "users"))
+	require.Zero(t, countRows(t, db, "wallets"))
+}
+
+// staleWalletLookupRepo reports a wallet as unowned even after another request claimed it,
+// reproducing the window between the pre-check and the claim.
+type staleWalletLookupRepo struct {
+	*repositories.WalletRepository
+	stale *entities.Wallet
+}
+
+func (r *staleWalletLookupRepo) GetByAddress(context.Context, uuid.UUID, string) (*entities.Wallet, error) {
+	cpy := *r.stale
+	return &cpy, nil
+}
+
+func TestAuthUsecase_Register_AdoptsUnownedWallet(t *testing.T) {
+	stubWalletChallenges(t)
+	db := newPartnerFlowIntegrationDB(t)
+	createAuthRegisterTables(t, db, true)
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM}
+	uc := newAuthRegisterTxUsecase(db, chain)
+
+	input := signedWalletRegistration(t, uc, "adopt@paymentkita.io")
+	input.Name = "Adopt"
+	walletID := uuid.NewString()
+	mustExecIntegration(t, db, `INSERT INTO wallets (id, chain_id, address, is_primary) VALUES (?, ?, ?, ?)`,
+		walletID, chain.ID.String(), input.WalletAddress, false)
+
+	user, _, err := uc.Register(context.Background(), input)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, countRows(t, db, "wallets"))
+
+	var owner string
+	require.NoError(t, db.Table("wallets").Select("user_id").Where("id = ?", walletID).Scan(&owner).Error)
+	require.Equal(t, user.ID.String(), owner)
+
+	// The challenge is spent: replaying the same signature for another account fails
+	input.Email = "replay@paymentkita.io"
+	_, _, err = uc.Register(context.Background(), input)
+	require.Error(t, err)
+}
+
+func TestAuthUsecase_Register_LosingWalletClaimRollsBack(t *testing.T) {
+	stubWalletChallenges(t)
+	db := newPartnerFlowIntegrationDB(t)
+	createAuthRegisterTables(t, db, true)
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM}
+	uc := newAuthRegisterTxUsecase(db, chain)
+
+	input := signedWalletRegistration(t, uc, "loser@paymentkita.io")
+	input.Name = "Loser"
+	walletID := uuid.New()
+	mustExecIntegration(t, db, `INSERT INTO wallets (id, user_id, chain_id, address, is_primary) VALUES (?, ?, ?, ?, ?)`,
+		walletID.String(), uuid.NewString(), chain.ID.String(), input.WalletAddress, true)
+	uc.walletRepo = &staleWalletLookupRepo{
+		WalletRepository: repositories.NewWalletRepository(db),
+		stale:            &entities.Wallet{ID: walletID, ChainID: chain.ID, Address: input.WalletAddress},
+	}
+
+	_, _, err := uc.Register(context.Background(), input)
+	require.ErrorIs(t, err, domainerrors.ErrAlreadyExists)
+	require.Zero(t, countRows(t, db, "users"))
+}
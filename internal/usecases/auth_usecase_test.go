@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/alicebob/miniredis/v2"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	gojwt "github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	redisv9 "github.com/redis/go-redis/v9"
@@ -156,6 +157,35 @@ func TestAuthUsecase_Login_SuccessNoSession(t *testing.T) {
 	assert.Equal(t, user.ID, resp.User.ID)
 }
 
+func TestAuthUsecase_SuspendedUser(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	uc := newAuthUsecaseForTest(userRepo, new(MockEmailVerificationRepository), new(MockWalletRepository), new(MockChainRepository), new(MockMerchantRepository), new(MockUnitOfWork))
+
+	hashed, _ := crypto.HashPassword("correct-password")
+	suspendedAt := time.Now()
+	user := &entities.User{
+		ID:           uuid.New(),
+		Email:        "suspended@mail.com",
+		PasswordHash: hashed,
+		Role:         entities.UserRoleUser,
+		SuspendedAt:  &suspendedAt,
+	}
+	userRepo.On("GetByEmail", context.Background(), user.Email).Return(user, nil).Twice()
+
+	// A wrong password still reads as bad credentials, so suspension leaks nothing
+	_, err := uc.Login(context.Background(), &entities.LoginInput{Email: user.Email, Password: "wrong-password"})
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidCredentials)
+	_, err = uc.Login(context.Background(), &entities.LoginInput{Email: user.Email, Password: "correct-password"})
+	assert.ErrorIs(t, err, domainerrors.ErrAccountSuspended)
+
+	jwtSvc := jwt.NewJWTService("test-secret", 15*time.Minute, 24*time.Hour)
+	pair, genErr := jwtSvc.GenerateTokenPair(user.ID, user.Email, string(user.Role))
+	assert.NoError(t, genErr)
+	userRepo.On("GetByID", context.Background(), user.ID).Return(user, nil).Once()
+	_, err = uc.RefreshToken(context.Background(), pair.RefreshToken)
+	assert.ErrorIs(t, err, domainerrors.ErrAccountSuspended)
+}
+
 func TestAuthUsecase_VerifyEmail(t *testing.T) {
 	userRepo := new(MockUserRepository)
 	emailRepo := new(MockEmailVerificationRepository)
@@ -393,6 +423,33 @@ func TestAuthUsecase_Login_UseSessionSuccess(t *testing.T) {
 	assert.Empty(t, resp.AccessToken)
 }
 
+func TestAuthUsecase_Login_UseSessionWithoutRedisIssuesTokens(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	uc := newAuthUsecaseForTest(userRepo, new(MockEmailVerificationRepository), new(MockWalletRepository), new(MockChainRepository), new(MockMerchantRepository), new(MockUnitOfWork))
+
+	redispkg.SetAvailable(false)
+	t.Cleanup(func() { redispkg.SetAvailable(true) })
+
+	hashed, _ := crypto.HashPassword("correct-password")
+	user := &entities.User{
+		ID:           uuid.New(),
+		Email:        "session-degraded@mail.com",
+		PasswordHash: hashed,
+		Role:         entities.UserRoleUser,
+	}
+	userRepo.On("GetByEmail", context.Background(), user.Email).Return(user, nil).Once()
+
+	resp, err := uc.Login(context.Background(), &entities.LoginInput{
+		Email:      user.Email,
+		Password:   "correct-password",
+		UseSession: true,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.SessionID)
+	assert.NotEmpty(t, resp.AccessToken)
+	assert.NotEmpty(t, resp.RefreshToken)
+}
+
 func TestAuthUsecase_Register_ErrorBranches(t *testing.T) {
 	t.Run("user repo email lookup error", func(t *testing.T) {
 		userRepo := new(MockUserRepository)
@@ -490,12 +547,19 @@ func TestAuthUsecase_Register_ErrorBranches(t *testing.T) {
 	})
 
 	t.Run("existing wallet without user continues and then user create fails", func(t *testing.T) {
+		useWalletChallengeRedis(t)
 		userRepo := new(MockUserRepository)
 		walletRepo := new(MockWalletRepository)
 		chainRepo := new(MockChainRepository)
 		uow := new(MockUnitOfWork)
 		uc := newAuthUsecaseForTest(userRepo, new(MockEmailVerificationRepository), walletRepo, chainRepo, new(MockMerchantRepository), uow)
 
+		key, err := ethcrypto.GenerateKey()
+		assert.NoError(t, err)
+		address := ethcrypto.PubkeyToAddress(key.PublicKey).Hex()
+		challenge, err := uc.IssueWalletChallenge(context.Background(), &entities.WalletChallengeInput{Address: address, Email: "create-fail@mail.com"})
+		assert.NoError(t, err)
+
 		chainUUID := uuid.New()
 		userRepo.On("GetByEmail", context.Background(), "create-fail@mail.com").Return(nil, domainerrors.ErrNotFound).Once()
 		chainRepo.On("GetByCAIP2", context.Background(), "eip155:8453").Return(&entities.Chain{
@@ -503,26 +567,67 @@ func TestAuthUsecase_Register_ErrorBranches(t *testing.T) {
 			Type:    entities.ChainTypeEVM,
 			ChainID: "8453",
 		}, nil).Once()
-		walletRepo.On("GetByAddress", context.Background(), chainUUID, "0xabc").Return(&entities.Wallet{
+		walletRepo.On("GetByAddress", context.Background(), chainUUID, address).Return(&entities.Wallet{
 			ID:      uuid.New(),
 			UserID:  nil,
 			ChainID: chainUUID,
-			Address: "0xabc",
+			Address: address,
 		}, nil).Once()
 		uow.On("Do", context.Background(), mock.Anything).Return(errors.New("create user failed")).Once()
 		userRepo.On("Create", context.Background(), mock.AnythingOfType("*entities.User")).Return(errors.New("create user failed")).Once()
 
-		_, _, err := uc.Register(context.Background(), &entities.CreateUserInput{
+		_, _, err = uc.Register(context.Background(), &entities.CreateUserInput{
 			Email:           "create-fail@mail.com",
 			Name:            "Create Fail",
 			Password:        "Password123!",
-			WalletAddress:   "0xabc",
+			WalletAddress:   address,
 			WalletChainID:   "eip155:8453",
-			WalletSignature: "sig",
+			WalletSignature: signWalletChallenge(t, key, challenge.Message),
+			WalletNonce:     challenge.Nonce,
 		})
 		assert.EqualError(t, err, "create user failed")
 	})
 
+	t.Run("existing wallet without user needs the wallet's signature", func(t *testing.T) {
+		useWalletChallengeRedis(t)
+		userRepo := new(MockUserRepository)
+		walletRepo := new(MockWalletRepository)
+		chainRepo := new(MockChainRepository)
+		uow := new(MockUnitOfWork)
+		uc := newAuthUsecaseForTest(userRepo, new(MockEmailVerificationRepository), walletRepo, chainRepo, new(MockMerchantRepository), uow)
+
+		key, err := ethcrypto.GenerateKey()
+		assert.NoError(t, err)
+		address := ethcrypto.PubkeyToAddress(key.PublicKey).Hex()
+		// The victim's own challenge and signature, replayed by an attacker registering another email
+		challenge, err := uc.IssueWalletChallenge(context.Background(), &entities.WalletChallengeInput{Address: address, Email: "victim@mail.com"})
+		assert.NoError(t, err)
+
+		chainUUID := uuid.New()
+		userRepo.On("GetByEmail", context.Background(), "attacker@mail.com").Return(nil, domainerrors.ErrNotFound)
+		chainRepo.On("GetByCAIP2", context.Background(), "eip155:8453").Return(&entities.Chain{
+			ID:      chainUUID,
+			Type:    entities.ChainTypeEVM,
+			ChainID: "8453",
+		}, nil)
+		walletRepo.On("GetByAddress", context.Background(), chainUUID, address).Return(&entities.Wallet{
+			ID:      uuid.New(),
+			ChainID: chainUUID,
+			Address: address,
+		}, nil)
+
+		for name, input := range map[string]*entities.CreateUserInput{
+			"no challenge":        {WalletSignature: "sig"},
+			"another's challenge": {WalletSignature: signWalletChallenge(t, key, challenge.Message), WalletNonce: challenge.Nonce},
+		} {
+			input.Email, input.Name, input.Password = "attacker@mail.com", "Attacker", "Password123!"
+			input.WalletAddress, input.WalletChainID = address, "eip155:8453"
+			_, _, err := uc.Register(context.Background(), input)
+			assert.Error(t, err, name)
+		}
+		uow.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+	})
+
 	t.Run("wallet create fails after user create", func(t *testing.T) {
 		userRepo := new(MockUserRepository)
 		walletRepo := new(MockWalletRepository)
@@ -624,3 +729,65 @@ func TestAuthUsecase_RefreshToken_UserLookupError(t *testing.T) {
 	_, err := uc.RefreshToken(context.Background(), pair.RefreshToken)
 	assert.EqualError(t, err, "user lookup failed")
 }
+
+func TestAuthUsecase_Impersonate(t *testing.T) {
+	admin := &entities.User{ID: uuid.New(), Email: "admin@mail.com", Role: entities.UserRoleAdmin}
+	target := &entities.User{ID: uuid.New(), Email: "user@mail.com", Role: entities.UserRoleUser}
+	otherAdmin := &entities.User{ID: uuid.New(), Email: "sub@mail.com", Role: entities.UserRoleSubAdmin}
+	partner := &entities.User{ID: uuid.New(), Email: "partner@mail.com", Role: entities.UserRolePartner}
+
+	newUC := func() (*usecases.AuthUsecase, *MockUserRepository) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("GetByID", mock.Anything, admin.ID).Return(admin, nil)
+		userRepo.On("GetByID", mock.Anything, target.ID).Return(target, nil)
+		userRepo.On("GetByID", mock.Anything, otherAdmin.ID).Return(otherAdmin, nil)
+		userRepo.On("GetByID", mock.Anything, partner.ID).Return(partner, nil)
+		return newAuthUsecaseForTest(userRepo, new(MockEmailVerificationRepository), new(MockWalletRepository), new(MockChainRepository), new(MockMerchantRepository), new(MockUnitOfWork)), userRepo
+	}
+
+	t.Run("success", func(t *testing.T) {
+		uc, _ := newUC()
+		session, err := uc.Impersonate(context.Background(), admin.ID, target.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, target.ID, session.User.ID)
+		assert.Equal(t, admin.ID, session.Impersonator.ID)
+		assert.WithinDuration(t, time.Now().Add(usecases.ImpersonationTTL), session.ExpiresAt, 5*time.Second)
+
+		claims, err := jwt.NewJWTService("test-secret", time.Minute, time.Hour).ValidateToken(session.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, target.ID, claims.UserID)
+		assert.Equal(t, admin.ID, *claims.ImpersonatorID)
+
+		// The impersonation token must not be exchangeable for a regular session.
+		_, err = uc.RefreshToken(context.Background(), session.AccessToken)
+		assert.ErrorIs(t, err, domainerrors.ErrUnauthorized)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		uc, _ := newUC()
+		_, err := uc.Impersonate(context.Background(), admin.ID, admin.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("caller not admin", func(t *testing.T) {
+		uc, _ := newUC()
+		_, err := uc.Impersonate(context.Background(), partner.ID, target.ID)
+		assert.ErrorIs(t, err, domainerrors.ErrForbidden)
+	})
+
+	t.Run("privileged target", func(t *testing.T) {
+		uc, _ := newUC()
+		_, err := uc.Impersonate(context.Background(), admin.ID, otherAdmin.ID)
+		var appErr *domainerrors.AppError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, domainerrors.CodeForbidden, appErr.Code)
+	})
+
+	t.Run("target missing", func(t *testing.T) {
+		uc, userRepo := newUC()
+		missing := uuid.New()
+		userRepo.On("GetByID", mock.Anything, missing).Return(nil, domainerrors.ErrNotFound)
+		_, err := uc.Impersonate(context.Background(), admin.ID, missing)
+		assert.ErrorIs(t, err, domainerrors.ErrNotFound)
+	})
+}
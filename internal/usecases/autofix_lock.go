@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/logger"
+	"payment-kita.backend/pkg/redis"
+)
+
+// autoFixLockTTL bounds how long a crashed auto-fix can keep its route locked
+const autoFixLockTTL = 10 * time.Minute
+
+// localAutoFixLocks holds route locks while Redis is not usable, so one instance still never
+// runs two fixes of the same route at once
+var localAutoFixLocks sync.Map
+
+// acquireAutoFixLock takes key for token, in Redis or in process when Redis is not usable. It
+// reports false when someone else holds the lock; release frees it only while token holds it.
+var acquireAutoFixLock = func(ctx context.Context, key, token string) (release func(), acquired bool, err error) {
+	if redis.GetClient() == nil || !redis.Available() {
+		if _, held := localAutoFixLocks.LoadOrStore(key, token); held {
+			return nil, false, nil
+		}
+		return func() { localAutoFixLocks.CompareAndDelete(key, token) }, true, nil
+	}
+
+	acquired, err = redis.SetNX(ctx, key, token, autoFixLockTTL)
+	if err != nil || !acquired {
+		return nil, false, err
+	}
+	return func() {
+		// The request context may already be cancelled; the lock must still go
+		if _, err := redis.DelIfValue(context.WithoutCancel(ctx), key, token); err != nil {
+			logger.Warn(ctx, "failed to release auto-fix lock, it expires on its own",
+				zap.String("key", key), zap.Error(err))
+		}
+	}, true, nil
+}
+
+// lockAutoFix serializes auto-fixes of one (source, dest, bridgeType) route across instances,
+// so two admins cannot send competing owner txs. A second caller gets a 409.
+func (u *CrosschainConfigUsecase) lockAutoFix(ctx context.Context, sourceChainInput, destChainInput string, bridgeType uint8) (func(), error) {
+	key := fmt.Sprintf("autofix:lock:%s:%s:%d", u.routeChainKey(ctx, sourceChainInput), u.routeChainKey(ctx, destChainInput), bridgeType)
+	release, acquired, err := acquireAutoFixLock(ctx, key, uuid.NewString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire auto-fix lock: %w", err)
+	}
+	if !acquired {
+		return nil, domainerrors.NewAppError(http.StatusConflict, domainerrors.CodeAutoFixInProgress,
+			fmt.Sprintf("an auto-fix for route %s -> %s (bridge type %d) is already in progress", sourceChainInput, destChainInput, bridgeType), nil)
+	}
+	return release, nil
+}
+
+// routeChainKey normalizes a chain input to CAIP-2 so "8453" and "eip155:8453" share a lock
+func (u *CrosschainConfigUsecase) routeChainKey(ctx context.Context, input string) string {
+	if u.chainResolver != nil {
+		if _, caip2, err := u.chainResolver.ResolveFromAny(ctx, input); err == nil {
+			return caip2
+		}
+	}
+	return strings.TrimSpace(input)
+}
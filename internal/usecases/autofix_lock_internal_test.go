@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	redisv9 "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	redispkg "payment-kita.backend/pkg/redis"
+)
+
+func TestAutoFix_SecondCallerGetsInProgress(t *testing.T) {
+	// Without Redis the lock is held in process; earlier tests may leave a client behind
+	previous := redispkg.GetClient()
+	t.Cleanup(func() { redispkg.SetClient(previous) })
+	redispkg.SetClient(nil)
+
+	source, dest, chainRepo := newMatrixChains()
+	calls := 0
+	ccip := uint8(1)
+	var u *CrosschainConfigUsecase
+	adapter := &crosschainAdapterStub{
+		statusFn: func(context.Context, string, string) (*OnchainAdapterStatus, error) {
+			return &OnchainAdapterStatus{DefaultBridgeType: 2, AdapterType1: "0x1111111111111111111111111111111111111111"}, nil
+		},
+		setDefaultBridgeFn: func(ctx context.Context, _, _ string, _ uint8) (string, error) {
+			calls++
+			// A concurrent fix of the same route, named by chain id instead of CAIP-2
+			_, err := u.AutoFix(ctx, &AutoFixRequest{SourceChainID: source.ChainID, DestChainID: dest.ChainID, BridgeType: &ccip})
+			var appErr *domainerrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			require.Equal(t, http.StatusConflict, appErr.Status)
+			require.Equal(t, domainerrors.CodeAutoFixInProgress, appErr.Code)
+
+			// Another bridge type of the same route is a different lock
+			other := uint8(0)
+			release, err := u.lockAutoFix(ctx, source.GetCAIP2ID(), dest.GetCAIP2ID(), other)
+			require.NoError(t, err)
+			release()
+			return "0xdefault", nil
+		},
+	}
+	u = NewCrosschainConfigUsecase(chainRepo, &ccTokenRepoStub{}, &ccContractRepoStub{}, nil, adapter)
+
+	_, err := u.AutoFix(context.Background(), &AutoFixRequest{SourceChainID: source.GetCAIP2ID(), DestChainID: dest.GetCAIP2ID(), BridgeType: &ccip})
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+
+	// Released once the first fix returned
+	release, err := u.lockAutoFix(context.Background(), source.GetCAIP2ID(), dest.GetCAIP2ID(), ccip)
+	require.NoError(t, err)
+	release()
+}
+
+func TestAcquireAutoFixLock_Redis(t *testing.T) {
+	srv, err := miniredis.Run()
+	if err != nil {
+		t.Skipf("skip: miniredis unavailable: %v", err)
+	}
+	defer srv.Close()
+	t.Cleanup(func() { redispkg.SetClient(nil) })
+	redispkg.SetClient(redisv9.NewClient(&redisv9.Options{Addr: srv.Addr()}))
+
+	ctx := context.Background()
+	release, acquired, err := acquireAutoFixLock(ctx, "autofix:lock:test", "a")
+	require.NoError(t, err)
+	require.True(t, acquired)
+	require.True(t, srv.Exists("autofix:lock:test"))
+	require.Equal(t, autoFixLockTTL, srv.TTL("autofix:lock:test"))
+
+	_, acquired, err = acquireAutoFixLock(ctx, "autofix:lock:test", "b")
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	release()
+	require.False(t, srv.Exists("autofix:lock:test"))
+}
@@ -0,0 +1,267 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/pkg/utils"
+)
+
+// bootstrapCacheTTL is how long one bootstrap snapshot is served before it is rebuilt
+const bootstrapCacheTTL = 30 * time.Second
+
+// Bootstrap is everything a checkout UI needs at startup: the active chains with their tokens,
+// the bridges and which bridges serve each route
+type Bootstrap struct {
+	Chains  []BootstrapChain          `json:"chains"`
+	Bridges []*entities.PaymentBridge `json:"bridges"`
+	Routes  []BootstrapRoute          `json:"routes"`
+}
+
+// BootstrapChain is one active chain, shaped like GET /chains items, with its active tokens
+type BootstrapChain struct {
+	ID                string            `json:"id"`
+	NetworkID         string            `json:"networkId"`
+	CAIP2             string            `json:"caip2"`
+	Name              string            `json:"name"`
+	ChainType         string            `json:"chainType"`
+	RPCURL            string            `json:"rpcUrl"`
+	ExplorerURL       string            `json:"explorerUrl"`
+	Symbol            string            `json:"symbol"`
+	LogoURL           string            `json:"logoUrl"`
+	IsActive          bool              `json:"isActive"`
+	CCIPChainSelector string            `json:"ccipChainSelector"`
+	StargateEID       int               `json:"stargateEid"`
+	Tokens            []*entities.Token `json:"tokens"`
+}
+
+// BootstrapRoute is one configured source -> dest route between active chains. Bridges lists the
+// bridges that may serve it, the route policy's fallback order first.
+type BootstrapRoute struct {
+	SourceChainID string   `json:"sourceChainId"`
+	DestChainID   string   `json:"destChainId"`
+	DefaultBridge string   `json:"defaultBridge"`
+	Bridges       []string `json:"bridges"`
+	FallbackMode  string   `json:"fallbackMode,omitempty"`
+}
+
+// BootstrapUsecase builds the bootstrap payload and caches it briefly, with an ETag over its
+// content so clients can revalidate without downloading it again
+type BootstrapUsecase struct {
+	chainRepo         repositories.ChainRepository
+	tokenRepo         repositories.TokenRepository
+	paymentBridgeRepo repositories.PaymentBridgeRepository
+	bridgeConfigRepo  repositories.BridgeConfigRepository
+	routePolicyRepo   repositories.RoutePolicyRepository
+	now               func() time.Time
+
+	mu       sync.Mutex
+	snapshot *Bootstrap
+	etag     string
+	loadedAt time.Time
+}
+
+// NewBootstrapUsecase creates a new bootstrap usecase
+func NewBootstrapUsecase(
+	chainRepo repositories.ChainRepository,
+	tokenRepo repositories.TokenRepository,
+	paymentBridgeRepo repositories.PaymentBridgeRepository,
+	bridgeConfigRepo repositories.BridgeConfigRepository,
+	routePolicyRepo repositories.RoutePolicyRepository,
+) *BootstrapUsecase {
+	return &BootstrapUsecase{
+		chainRepo:         chainRepo,
+		tokenRepo:         tokenRepo,
+		paymentBridgeRepo: paymentBridgeRepo,
+		bridgeConfigRepo:  bridgeConfigRepo,
+		routePolicyRepo:   routePolicyRepo,
+		now:               time.Now,
+	}
+}
+
+// Bootstrap returns the cached payload and its ETag, rebuilding it once the cache is stale. If a
+// rebuild fails, the last payload keeps being served.
+func (u *BootstrapUsecase) Bootstrap(ctx context.Context) (*Bootstrap, string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.snapshot != nil && u.now().Sub(u.loadedAt) < bootstrapCacheTTL {
+		return u.snapshot, u.etag, nil
+	}
+
+	snapshot, err := u.build(ctx)
+	if err == nil {
+		var body []byte
+		body, err = json.Marshal(snapshot)
+		if err == nil {
+			sum := sha256.Sum256(body)
+			u.snapshot, u.etag, u.loadedAt = snapshot, `"`+hex.EncodeToString(sum[:16])+`"`, u.now()
+		}
+	}
+	if err != nil {
+		if u.snapshot != nil {
+			return u.snapshot, u.etag, nil
+		}
+		return nil, "", err
+	}
+	return u.snapshot, u.etag, nil
+}
+
+func (u *BootstrapUsecase) build(ctx context.Context) (*Bootstrap, error) {
+	all := utils.PaginationParams{Page: 1}
+	chains, _, err := u.chainRepo.GetActive(ctx, all)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chains: %w", err)
+	}
+	tokens, _, err := u.tokenRepo.GetAllTokens(ctx, nil, nil, false, all)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokens: %w", err)
+	}
+	bridges, _, err := u.paymentBridgeRepo.List(ctx, all)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bridges: %w", err)
+	}
+	configs, _, err := u.bridgeConfigRepo.List(ctx, nil, nil, nil, all)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bridge configs: %w", err)
+	}
+	var policies []*entities.RoutePolicy
+	if u.routePolicyRepo != nil {
+		if policies, _, err = u.routePolicyRepo.List(ctx, repositories.RoutePolicyFilter{}, all); err != nil {
+			return nil, fmt.Errorf("failed to load route policies: %w", err)
+		}
+	}
+
+	tokensByChain := make(map[uuid.UUID][]*entities.Token)
+	for _, token := range tokens {
+		if token != nil && token.IsActive {
+			tokensByChain[token.ChainUUID] = append(tokensByChain[token.ChainUUID], token)
+		}
+	}
+	caip2ByChain := make(map[uuid.UUID]string, len(chains))
+	out := &Bootstrap{Chains: make([]BootstrapChain, 0, len(chains)), Bridges: bridges, Routes: []BootstrapRoute{}}
+	for _, chain := range chains {
+		if chain == nil || !chain.IsActive {
+			continue
+		}
+		caip2ByChain[chain.ID] = chain.GetCAIP2ID()
+		chainTokens := tokensByChain[chain.ID]
+		if chainTokens == nil {
+			chainTokens = []*entities.Token{}
+		}
+		out.Chains = append(out.Chains, BootstrapChain{
+			ID:                chain.ID.String(),
+			NetworkID:         chain.ChainID,
+			CAIP2:             chain.GetCAIP2ID(),
+			Name:              chain.Name,
+			ChainType:         string(chain.Type),
+			RPCURL:            chain.RPCURL,
+			ExplorerURL:       chain.ExplorerURL,
+			Symbol:            chain.CurrencySymbol,
+			LogoURL:           chain.ImageURL,
+			IsActive:          chain.IsActive,
+			CCIPChainSelector: chain.CCIPChainSelector,
+			StargateEID:       chain.StargateEID,
+			Tokens:            chainTokens,
+		})
+	}
+	if out.Bridges == nil {
+		out.Bridges = []*entities.PaymentBridge{}
+	}
+	out.Routes = bootstrapRoutes(caip2ByChain, bridges, configs, policies)
+	return out, nil
+}
+
+// bootstrapRoutes merges route policies and active bridge configs into one entry per route whose
+// chains are both active
+func bootstrapRoutes(caip2ByChain map[uuid.UUID]string, bridges []*entities.PaymentBridge, configs []*entities.BridgeConfig, policies []*entities.RoutePolicy) []BootstrapRoute {
+	bridgeNames := make(map[uuid.UUID]string, len(bridges))
+	for _, bridge := range bridges {
+		if bridge != nil {
+			bridgeNames[bridge.ID] = bridge.Name
+		}
+	}
+
+	type routeKey struct{ source, dest uuid.UUID }
+	routes := make(map[routeKey]*BootstrapRoute)
+	route := func(source, dest uuid.UUID) *BootstrapRoute {
+		sourceCAIP2, sourceOK := caip2ByChain[source]
+		destCAIP2, destOK := caip2ByChain[dest]
+		if !sourceOK || !destOK {
+			return nil
+		}
+		key := routeKey{source, dest}
+		if routes[key] == nil {
+			routes[key] = &BootstrapRoute{SourceChainID: sourceCAIP2, DestChainID: destCAIP2, Bridges: []string{}}
+		}
+		return routes[key]
+	}
+	addBridge := func(r *BootstrapRoute, name string) {
+		if name == "" {
+			return
+		}
+		for _, existing := range r.Bridges {
+			if existing == name {
+				return
+			}
+		}
+		r.Bridges = append(r.Bridges, name)
+	}
+
+	for _, policy := range policies {
+		if policy == nil {
+			continue
+		}
+		r := route(policy.SourceChainID, policy.DestChainID)
+		if r == nil {
+			continue
+		}
+		r.DefaultBridge = bridgeTypeToName(policy.DefaultBridgeType)
+		r.FallbackMode = string(policy.FallbackMode)
+		addBridge(r, r.DefaultBridge)
+		for _, bridgeType := range policy.FallbackOrder {
+			addBridge(r, bridgeTypeToName(bridgeType))
+		}
+	}
+	for _, cfg := range configs {
+		if cfg == nil || !cfg.IsActive {
+			continue
+		}
+		r := route(cfg.SourceChainID, cfg.DestChainID)
+		if r == nil {
+			continue
+		}
+		name := bridgeNames[cfg.BridgeID]
+		if cfg.Bridge != nil && cfg.Bridge.Name != "" {
+			name = cfg.Bridge.Name
+		}
+		// Config names like "STARGATE" are reported under the same name as the policy's types
+		if bridgeType, ok := lookupBridgeType(name); ok {
+			name = bridgeTypeToName(bridgeType)
+		}
+		addBridge(r, name)
+		if r.DefaultBridge == "" {
+			r.DefaultBridge = name
+		}
+	}
+
+	out := make([]BootstrapRoute, 0, len(routes))
+	for _, r := range routes {
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].SourceChainID != out[j].SourceChainID {
+			return out[i].SourceChainID < out[j].SourceChainID
+		}
+		return out[i].DestChainID < out[j].DestChainID
+	})
+	return out
+}
@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/pkg/utils"
+)
+
+type bootstrapChainRepoStub struct {
+	quoteChainRepoStub
+	chains []*entities.Chain
+	calls  int
+	err    error
+}
+
+func (s *bootstrapChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, 0, s.err
+	}
+	return s.chains, int64(len(s.chains)), nil
+}
+
+type bootstrapTokenRepoStub struct {
+	quoteTokenRepoStub
+	tokens []*entities.Token
+}
+
+func (s *bootstrapTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, bool, utils.PaginationParams) ([]*entities.Token, int64, error) {
+	return s.tokens, int64(len(s.tokens)), nil
+}
+
+type bootstrapBridgeRepoStub struct {
+	bridges []*entities.PaymentBridge
+}
+
+func (s *bootstrapBridgeRepoStub) GetByID(context.Context, uuid.UUID) (*entities.PaymentBridge, error) {
+	return nil, domainerrors.ErrNotFound
+}
+func (s *bootstrapBridgeRepoStub) GetByName(context.Context, string) (*entities.PaymentBridge, error) {
+	return nil, domainerrors.ErrNotFound
+}
+func (s *bootstrapBridgeRepoStub) List(context.Context, utils.PaginationParams) ([]*entities.PaymentBridge, int64, error) {
+	return s.bridges, int64(len(s.bridges)), nil
+}
+func (s *bootstrapBridgeRepoStub) Create(context.Context, *entities.PaymentBridge) error { return nil }
+func (s *bootstrapBridgeRepoStub) Update(context.Context, *entities.PaymentBridge) error { return nil }
+func (s *bootstrapBridgeRepoStub) Delete(context.Context, uuid.UUID) error               { return nil }
+
+type bootstrapBridgeConfigRepoStub struct {
+	bridgeConfigRepoStub
+	configs []*entities.BridgeConfig
+}
+
+func (s *bootstrapBridgeConfigRepoStub) List(context.Context, *uuid.UUID, *uuid.UUID, *uuid.UUID, utils.PaginationParams) ([]*entities.BridgeConfig, int64, error) {
+	return s.configs, int64(len(s.configs)), nil
+}
+
+type bootstrapRoutePolicyRepoStub struct {
+	routePolicyRepoStub
+	policies []*entities.RoutePolicy
+}
+
+func (s *bootstrapRoutePolicyRepoStub) List(context.Context, repositories.RoutePolicyFilter, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+	return s.policies, int64(len(s.policies)), nil
+}
+func (s *bootstrapRoutePolicyRepoStub) CreateMissing(_ context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+	return make([]bool, len(policies)), nil
+}
+
+func TestBootstrapUsecase(t *testing.T) {
+	base := &entities.Chain{ID: uuid.New(), ChainID: "8453", Name: "Base", Type: entities.ChainTypeEVM, IsActive: true}
+	arbitrum := &entities.Chain{ID: uuid.New(), ChainID: "42161", Name: "Arbitrum", Type: entities.ChainTypeEVM, IsActive: true}
+	disabled := uuid.New()
+	usdc := &entities.Token{ID: uuid.New(), ChainUUID: base.ID, Symbol: "USDC", IsActive: true}
+	stargate := &entities.PaymentBridge{ID: uuid.New(), Name: "STARGATE"}
+	ccip := &entities.PaymentBridge{ID: uuid.New(), Name: "CCIP"}
+
+	chainRepo := &bootstrapChainRepoStub{chains: []*entities.Chain{base, arbitrum}}
+	u := NewBootstrapUsecase(
+		chainRepo,
+		&bootstrapTokenRepoStub{tokens: []*entities.Token{usdc}},
+		&bootstrapBridgeRepoStub{bridges: []*entities.PaymentBridge{stargate, ccip}},
+		&bootstrapBridgeConfigRepoStub{configs: []*entities.BridgeConfig{
+			{SourceChainID: base.ID, DestChainID: arbitrum.ID, BridgeID: stargate.ID, IsActive: true},
+			{SourceChainID: arbitrum.ID, DestChainID: base.ID, BridgeID: stargate.ID, IsActive: false},
+			{SourceChainID: base.ID, DestChainID: disabled, BridgeID: ccip.ID, IsActive: true},
+		}},
+		&bootstrapRoutePolicyRepoStub{policies: []*entities.RoutePolicy{
+			{SourceChainID: base.ID, DestChainID: arbitrum.ID, DefaultBridgeType: 1, FallbackMode: entities.BridgeFallbackModeAutoFallback, FallbackOrder: []uint8{1, 2}},
+		}},
+	)
+	now := time.Unix(1_800_000_000, 0)
+	u.now = func() time.Time { return now }
+
+	bootstrap, etag, err := u.Bootstrap(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, etag)
+	require.Len(t, bootstrap.Chains, 2)
+	require.Equal(t, "eip155:8453", bootstrap.Chains[0].CAIP2)
+	require.Equal(t, []*entities.Token{usdc}, bootstrap.Chains[0].Tokens)
+	require.Empty(t, bootstrap.Chains[1].Tokens)
+	require.NotNil(t, bootstrap.Chains[1].Tokens)
+	require.Len(t, bootstrap.Bridges, 2)
+
+	// Inactive configs and routes to inactive chains are left out; policy order comes first
+	require.Equal(t, []BootstrapRoute{{
+		SourceChainID: "eip155:8453",
+		DestChainID:   "eip155:42161",
+		DefaultBridge: "CCIP",
+		Bridges:       []string{"CCIP", "Stargate"},
+		FallbackMode:  "auto_fallback",
+	}}, bootstrap.Routes)
+
+	// Served from cache, with the same ETag, until the TTL passes
+	_, cachedETag, err := u.Bootstrap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, etag, cachedETag)
+	require.Equal(t, 1, chainRepo.calls)
+
+	// A content change changes the ETag once the cache expires
+	now = now.Add(bootstrapCacheTTL)
+	chainRepo.chains = []*entities.Chain{base}
+	_, changedETag, err := u.Bootstrap(context.Background())
+	require.NoError(t, err)
+	require.NotEqual(t, etag, changedETag)
+
+	// A failing rebuild keeps serving the last payload
+	now = now.Add(bootstrapCacheTTL)
+	chainRepo.err = errors.New("db down")
+	stale, staleETag, err := u.Bootstrap(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, changedETag, staleETag)
+	require.Len(t, stale.Chains, 1)
+}
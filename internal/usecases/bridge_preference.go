@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/pkg/logger"
+)
+
+const (
+	bridgeTypeHyperbridge             uint8 = 0
+	bridgeTypeCCIP                    uint8 = 1
+	bridgeTypeStargate                uint8 = 2
+	bridgeTypeHyperbridgeTokenGateway uint8 = 3
+)
+
+// Bridge names as reported in payment responses and stored on payment bridges
+const (
+	bridgeNameHyperbridge             = "Hyperbridge"
+	bridgeNameCCIP                    = "CCIP"
+	bridgeNameStargate                = "Stargate"
+	bridgeNameHyperbridgeTokenGateway = "HyperbridgeTokenGateway"
+)
+
+// defaultBridgeOrder is used when no route policy or bridge config picks a bridge
+var defaultBridgeOrder = []uint8{bridgeTypeCCIP, bridgeTypeHyperbridge, bridgeTypeStargate}
+
+// parseBridgeOrder reads a list of bridge names, e.g. the configured default order. Unknown
+// names are dropped; an empty result means the default order.
+func parseBridgeOrder(names []string) []uint8 {
+	var order []uint8
+	seen := map[uint8]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		bridgeType, ok := lookupBridgeType(name)
+		if !ok {
+			logger.Warn(context.Background(), "Ignoring unknown bridge in default order",
+				zap.String("bridge", name),
+			)
+			continue
+		}
+		if !seen[bridgeType] {
+			seen[bridgeType] = true
+			order = append(order, bridgeType)
+		}
+	}
+	return order
+}
+
+// bridgeSupportsRoute reports whether a bridge can carry a payment between the two chain types.
+// Only Hyperbridge reaches non-EVM chains.
+func bridgeSupportsRoute(bridgeType uint8, source, dest entities.ChainType) bool {
+	switch bridgeType {
+	case bridgeTypeHyperbridge:
+		return true
+	case bridgeTypeCCIP, bridgeTypeStargate, bridgeTypeHyperbridgeTokenGateway:
+		return source.IsEVM() && dest.IsEVM()
+	}
+	return false
+}
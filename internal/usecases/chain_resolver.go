@@ -23,8 +23,17 @@ func NewChainResolver(chainRepo repositories.ChainRepository) *ChainResolver {
 // ResolveFromAny takes a chain identifier (UUID string or CAIP-2 string)
 // and returns the internal UUID and the canonical CAIP-2 string.
 func (r *ChainResolver) ResolveFromAny(ctx context.Context, input string) (uuid.UUID, string, error) {
+	chain, err := r.ResolveChain(ctx, input)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	return chain.ID, chain.GetCAIP2ID(), nil
+}
+
+// ResolveChain is ResolveFromAny returning the chain itself, for callers that need its type
+func (r *ChainResolver) ResolveChain(ctx context.Context, input string) (*entities.Chain, error) {
 	if input == "" {
-		return uuid.Nil, "", fmt.Errorf("chain identifier cannot be empty")
+		return nil, fmt.Errorf("chain identifier cannot be empty")
 	}
 	value := strings.TrimSpace(input)
 
@@ -32,15 +41,15 @@ func (r *ChainResolver) ResolveFromAny(ctx context.Context, input string) (uuid.
 	if id, err := uuid.Parse(value); err == nil {
 		chain, err := r.chainRepo.GetByID(ctx, id)
 		if err != nil {
-			return uuid.Nil, "", fmt.Errorf("failed to get chain by ID %s: %w", id, err)
+			return nil, fmt.Errorf("failed to get chain by ID %s: %w", id, err)
 		}
-		return chain.ID, chain.GetCAIP2ID(), nil
+		return chain, nil
 	}
 
 	// 2. Try direct lookup by full input via GetByCAIP2 (optimized for namespace:ref)
 	if strings.Contains(value, ":") {
 		if chain, err := r.chainRepo.GetByCAIP2(ctx, value); err == nil {
-			return chain.ID, chain.GetCAIP2ID(), nil
+			return chain, nil
 		}
 	}
 
@@ -48,7 +57,7 @@ func (r *ChainResolver) ResolveFromAny(ctx context.Context, input string) (uuid.
 	normalized := entities.NormalizeChainID(value)
 	chain, err := r.chainRepo.GetByChainID(ctx, normalized)
 	if err == nil {
-		return chain.ID, chain.GetCAIP2ID(), nil
+		return chain, nil
 	}
 
 	// 4. Legacy Fallback: Try stripping namespace (e.g. eip155:8453 -> 8453)
@@ -58,7 +67,7 @@ func (r *ChainResolver) ResolveFromAny(ctx context.Context, input string) (uuid.
 		if len(parts) == 2 {
 			legacyID := parts[1]
 			if legacyChain, err := r.chainRepo.GetByChainID(ctx, legacyID); err == nil {
-				return legacyChain.ID, legacyChain.GetCAIP2ID(), nil
+				return legacyChain, nil
 			}
 		}
 	}
@@ -68,9 +77,9 @@ func (r *ChainResolver) ResolveFromAny(ctx context.Context, input string) (uuid.
 	if value != normalized {
 		chain, err = r.chainRepo.GetByChainID(ctx, value)
 		if err == nil {
-			return chain.ID, chain.GetCAIP2ID(), nil
+			return chain, nil
 		}
 	}
 
-	return uuid.Nil, "", fmt.Errorf("failed to find chain for %s: %w", value, err)
+	return nil, fmt.Errorf("failed to find chain for %s: %w", value, err)
 }
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+// EVMClient is the part of an EVM RPC client usecases call. *blockchain.EVMClient implements it;
+// tests can supply a mock instead of a JSON-RPC stub.
+type EVMClient interface {
+	CallView(ctx context.Context, to string, data []byte) ([]byte, error)
+	GetBlockNumber(ctx context.Context) (uint64, error)
+	Close()
+}
+
+// ClientFactory hands out EVM clients by RPC URL
+type ClientFactory interface {
+	GetEVMClient(rpcURL string) (EVMClient, error)
+}
+
+// blockchainClientFactory adapts *blockchain.ClientFactory to ClientFactory
+type blockchainClientFactory struct {
+	factory *blockchain.ClientFactory
+}
+
+// NewEVMClientFactory wraps the shared blockchain client factory. A nil factory yields a nil
+// ClientFactory so "no RPC configured" checks keep working.
+func NewEVMClientFactory(factory *blockchain.ClientFactory) ClientFactory {
+	if factory == nil {
+		return nil
+	}
+	return &blockchainClientFactory{factory: factory}
+}
+
+func (f *blockchainClientFactory) GetEVMClient(rpcURL string) (EVMClient, error) {
+	client, err := f.factory.GetEVMClient(rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// SolanaClient is the part of a Solana RPC client usecases call. *blockchain.SolanaClient
+// implements it.
+type SolanaClient interface {
+	GetAccountInfo(ctx context.Context, address string) (*blockchain.SolanaAccount, error)
+}
+
+// SolanaClientFactory hands out Solana clients by RPC URL
+type SolanaClientFactory interface {
+	GetSolanaClient(rpcURL string) (SolanaClient, error)
+}
+
+// NewSolanaClientFactory wraps the shared blockchain client factory; like NewEVMClientFactory, a
+// nil factory yields nil
+func NewSolanaClientFactory(factory *blockchain.ClientFactory) SolanaClientFactory {
+	if factory == nil {
+		return nil
+	}
+	return &blockchainClientFactory{factory: factory}
+}
+
+func (f *blockchainClientFactory) GetSolanaClient(rpcURL string) (SolanaClient, error) {
+	client, err := f.factory.GetSolanaClient(rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+type evmClientMock struct {
+	callView func(ctx context.Context, to string, data []byte) ([]byte, error)
+	block    uint64
+	closed   bool
+}
+
+func (m *evmClientMock) CallView(ctx context.Context, to string, data []byte) ([]byte, error) {
+	return m.callView(ctx, to, data)
+}
+
+func (m *evmClientMock) GetBlockNumber(context.Context) (uint64, error) { return m.block, nil }
+
+func (m *evmClientMock) Close() { m.closed = true }
+
+type clientFactoryMock struct {
+	clients map[string]EVMClient
+	err     error
+}
+
+func (m *clientFactoryMock) GetEVMClient(rpcURL string) (EVMClient, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	client, ok := m.clients[rpcURL]
+	if !ok {
+		return nil, errors.New("no client for " + rpcURL)
+	}
+	return client, nil
+}
+
+func TestNewEVMClientFactory_WrapsBlockchainFactory(t *testing.T) {
+	require.Nil(t, NewEVMClientFactory(nil))
+
+	concrete := blockchain.NewClientFactory()
+	registered := blockchain.NewEVMClientWithCallView(big.NewInt(8453), func(context.Context, string, []byte) ([]byte, error) {
+		return []byte{0x01}, nil
+	})
+	concrete.RegisterEVMClient("https://rpc.example", registered)
+
+	client, err := NewEVMClientFactory(concrete).GetEVMClient("https://rpc.example")
+	require.NoError(t, err)
+	require.Same(t, registered, client)
+
+	out, err := client.CallView(context.Background(), "0x1111111111111111111111111111111111111111", nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, out)
+}
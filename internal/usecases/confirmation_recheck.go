@@ -0,0 +1,234 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+	"payment-kita.backend/pkg/logger"
+)
+
+// ChainHeadReader tells how deep a transaction is, so completions held for confirmations can be
+// finished without the indexer reporting them again
+type ChainHeadReader interface {
+	// LatestBlock is the chain's latest block number (slot on Solana)
+	LatestBlock(ctx context.Context, chain *entities.Chain) (uint64, error)
+	// TxBlock is the block that included txHash, for events reported without a block number
+	TxBlock(ctx context.Context, chain *entities.Chain, txHash string) (uint64, error)
+}
+
+// rpcChainHeadReader reads chain heads over the chain's RPC
+type rpcChainHeadReader struct {
+	factory *blockchain.ClientFactory
+}
+
+// NewRPCChainHeadReader reads chain heads through the shared client factory. A nil factory
+// yields nil, which leaves held completions to the indexer.
+func NewRPCChainHeadReader(factory *blockchain.ClientFactory) ChainHeadReader {
+	if factory == nil {
+		return nil
+	}
+	return &rpcChainHeadReader{factory: factory}
+}
+
+func (r *rpcChainHeadReader) LatestBlock(ctx context.Context, chain *entities.Chain) (uint64, error) {
+	rpcURL := resolveRPCURL(chain)
+	if rpcURL == "" {
+		return 0, fmt.Errorf("no active rpc url for chain %s", chain.GetCAIP2ID())
+	}
+	switch chain.ChainType() {
+	case entities.ChainTypeEVM:
+		client, err := r.factory.GetEVMClient(rpcURL)
+		if err != nil {
+			return 0, err
+		}
+		return client.GetBlockNumber(ctx)
+	case entities.ChainTypeSVM:
+		client, err := r.factory.GetSolanaClient(rpcURL)
+		if err != nil {
+			return 0, err
+		}
+		return client.GetSlot(ctx)
+	default:
+		return 0, fmt.Errorf("confirmation tracking is not supported on %s chains", chain.ChainType())
+	}
+}
+
+func (r *rpcChainHeadReader) TxBlock(ctx context.Context, chain *entities.Chain, txHash string) (uint64, error) {
+	if chain.ChainType() != entities.ChainTypeEVM {
+		return 0, fmt.Errorf("transaction lookup is not supported on %s chains", chain.ChainType())
+	}
+	rpcURL := resolveRPCURL(chain)
+	if rpcURL == "" {
+		return 0, fmt.Errorf("no active rpc url for chain %s", chain.GetCAIP2ID())
+	}
+	client, err := r.factory.GetEVMClient(rpcURL)
+	if err != nil {
+		return 0, err
+	}
+	receipt, err := client.GetTransactionReceipt(ctx, txHash)
+	if err != nil {
+		return 0, err
+	}
+	return receipt.BlockNumber.Uint64(), nil
+}
+
+// RecheckHeldCompletions completes payments and payment requests that were held back for
+// confirmations once the chain has advanced far enough. Held payments are replayed through
+// ProcessIndexerWebhook with the observed depth, so they complete and notify the merchant exactly
+// as if the indexer had reported the event again. It returns how many were completed.
+func (u *WebhookUsecase) RecheckHeldCompletions(ctx context.Context, batchSize int) (int, error) {
+	if u.chainRepo == nil || u.headReader == nil {
+		return 0, nil
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	completed := 0
+	for offset := 0; ; offset += batchSize {
+		payments, total, err := u.paymentRepo.GetByStatus(ctx, entities.PaymentStatusProcessing, batchSize, offset)
+		if err != nil {
+			return completed, err
+		}
+		for _, payment := range payments {
+			if u.recheckHeldPayment(ctx, payment) {
+				completed++
+			}
+		}
+		if len(payments) < batchSize || offset+batchSize >= total {
+			break
+		}
+	}
+
+	requests, err := u.paymentRequestRepo.GetProcessing(ctx, batchSize)
+	if err != nil {
+		return completed, err
+	}
+	for _, request := range requests {
+		merchantID := request.MerchantID
+		required := u.confirmationThreshold(ctx, request.ChainID, &merchantID)
+		if u.observedConfirmations(ctx, request.ChainID, request.BlockNumber, request.TxHash) < required {
+			continue
+		}
+		u.completePaymentRequest(ctx, request.ID, request.TxHash)
+		completed++
+	}
+	return completed, nil
+}
+
+// recheckHeldPayment replays a payment's held completion event once it is deep enough. A
+// processing payment without a completion event is still in flight and is left alone.
+func (u *WebhookUsecase) recheckHeldPayment(ctx context.Context, payment *entities.Payment) bool {
+	events, err := u.paymentEventRepo.GetByPaymentID(ctx, payment.ID)
+	if err != nil {
+		logger.Warn(ctx, "Failed to load payment events for confirmation recheck",
+			zap.String("payment_id", payment.ID.String()),
+			zap.Error(err),
+		)
+		return false
+	}
+	var held *entities.PaymentEvent
+	for _, event := range events {
+		if event.EventType == entities.PaymentEventTypeIndexerCompleted {
+			held = event
+		}
+	}
+	if held == nil {
+		return false
+	}
+
+	txHash := held.TxHash
+	blockNumber := held.BlockNumber
+	if held.Details != nil {
+		if held.Details.DestTxHash != "" {
+			txHash = held.Details.DestTxHash
+		}
+		if blockNumber == 0 {
+			blockNumber = held.Details.BlockNumber
+		}
+	}
+	confirmations := u.observedConfirmations(ctx, payment.DestChainID, blockNumber, txHash)
+	if confirmations < u.requiredConfirmations(ctx, payment) {
+		return false
+	}
+
+	payload := heldEventPayload(held.Metadata)
+	if payload == nil {
+		return false
+	}
+	payload["confirmations"] = confirmations
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return false
+	}
+	if err := u.ProcessIndexerWebhook(ctx, string(held.EventType), data); err != nil {
+		return false
+	}
+	return true
+}
+
+// heldEventPayload reads the raw indexer payload stored on an event, whichever form the
+// repository returned it in
+func heldEventPayload(metadata interface{}) map[string]any {
+	var raw []byte
+	switch value := metadata.(type) {
+	case string:
+		raw = []byte(value)
+	case []byte:
+		raw = value
+	case nil:
+		return nil
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+		raw = encoded
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil
+	}
+	return payload
+}
+
+// observedConfirmations is how deep blockNumber is on chainID, counting the block itself. Without
+// a block number the transaction is looked up; 0 means the depth is unknown.
+func (u *WebhookUsecase) observedConfirmations(ctx context.Context, chainID uuid.UUID, blockNumber int64, txHash string) int {
+	chain, err := u.chainRepo.GetByID(ctx, chainID)
+	if err != nil || chain == nil {
+		return 0
+	}
+	if blockNumber <= 0 {
+		if txHash == "" {
+			return 0
+		}
+		block, err := u.headReader.TxBlock(ctx, chain, txHash)
+		if err != nil {
+			logger.Warn(ctx, "Failed to look up transaction block for confirmation recheck",
+				zap.String("chain_id", chain.GetCAIP2ID()),
+				zap.String("tx_hash", txHash),
+				zap.Error(err),
+			)
+			return 0
+		}
+		blockNumber = int64(block)
+	}
+	head, err := u.headReader.LatestBlock(ctx, chain)
+	if err != nil {
+		logger.Warn(ctx, "Failed to read chain head for confirmation recheck",
+			zap.String("chain_id", chain.GetCAIP2ID()),
+			zap.Error(err),
+		)
+		return 0
+	}
+	if int64(head) < blockNumber {
+		return 0
+	}
+	return int(int64(head)-blockNumber) + 1
+}
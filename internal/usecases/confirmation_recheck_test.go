@@ -0,0 +1,139 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/usecases"
+)
+
+type stubChainHeadReader struct {
+	head     uint64
+	txBlocks map[string]uint64
+}
+
+func (s *stubChainHeadReader) LatestBlock(context.Context, *entities.Chain) (uint64, error) {
+	return s.head, nil
+}
+
+func (s *stubChainHeadReader) TxBlock(_ context.Context, _ *entities.Chain, txHash string) (uint64, error) {
+	return s.txBlocks[txHash], nil
+}
+
+func TestWebhookUsecase_RequestPaymentReceived_WaitsForConfirmations(t *testing.T) {
+	requestRepo := new(MockPaymentRequestRepository)
+	sessionRepo := new(MockPartnerPaymentSessionRepository)
+	merchantRepo := new(MockMerchantRepository)
+	chainRepo := new(MockChainRepository)
+	uc := usecases.NewWebhookUsecaseWithConfirmations(
+		new(MockPaymentRepository),
+		new(MockPaymentEventRepository),
+		requestRepo,
+		sessionRepo,
+		merchantRepo,
+		new(MockWebhookLogRepository),
+		nil, // WebhookDispatcher
+		new(MockUnitOfWork),
+		chainRepo,
+		nil, // ChainHeadReader
+	)
+
+	ctx := context.Background()
+	request := &entities.PaymentRequest{ID: uuid.New(), MerchantID: uuid.New(), ChainID: uuid.New(), Status: entities.PaymentRequestStatusPending}
+	requestRepo.On("GetByID", mock.Anything, request.ID).Return(request, nil)
+	chainRepo.On("GetByID", mock.Anything, request.ChainID).Return(&entities.Chain{ID: request.ChainID, MinConfirmations: 3}, nil)
+	merchantRepo.On("GetByID", mock.Anything, request.MerchantID).Return(&entities.Merchant{ID: request.MerchantID}, nil)
+
+	received := func(confirmations int) json.RawMessage {
+		data, _ := json.Marshal(map[string]any{
+			"id":            request.ID.String(),
+			"txHash":        "0xTx",
+			"blockNumber":   "0x64",
+			"confirmations": confirmations,
+		})
+		return data
+	}
+
+	// Too shallow: the request is held as processing, with the block to measure from
+	requestRepo.On("MarkProcessing", mock.Anything, request.ID, "0xTx", int64(100)).Return(nil).Once()
+	require.NoError(t, uc.ProcessIndexerWebhook(ctx, "REQUEST_PAYMENT_RECEIVED", received(1)))
+	requestRepo.AssertNotCalled(t, "MarkCompleted", mock.Anything, mock.Anything, mock.Anything)
+	sessionRepo.AssertNotCalled(t, "GetByPaymentRequestID", mock.Anything, mock.Anything)
+
+	// Deep enough: the request and its session complete
+	sessionID := uuid.New()
+	requestRepo.On("MarkCompleted", mock.Anything, request.ID, "0xTx").Return(nil).Once()
+	sessionRepo.On("GetByPaymentRequestID", mock.Anything, request.ID).Return(&entities.PartnerPaymentSession{ID: sessionID}, nil).Once()
+	sessionRepo.On("MarkCompleted", mock.Anything, sessionID, "0xTx").Return(nil).Once()
+	require.NoError(t, uc.ProcessIndexerWebhook(ctx, "REQUEST_PAYMENT_RECEIVED", received(3)))
+
+	requestRepo.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestWebhookUsecase_RecheckHeldCompletions(t *testing.T) {
+	paymentRepo := new(MockPaymentRepository)
+	eventRepo := new(MockPaymentEventRepository)
+	requestRepo := new(MockPaymentRequestRepository)
+	sessionRepo := new(MockPartnerPaymentSessionRepository)
+	merchantRepo := new(MockMerchantRepository)
+	webhookRepo := new(MockWebhookLogRepository)
+	chainRepo := new(MockChainRepository)
+	uow := new(MockUnitOfWork)
+	heads := &stubChainHeadReader{head: 104, txBlocks: map[string]uint64{"0xshallow": 103}}
+	uc := usecases.NewWebhookUsecaseWithConfirmations(paymentRepo, eventRepo, requestRepo, sessionRepo, merchantRepo, webhookRepo, nil, uow, chainRepo, heads)
+
+	ctx := context.Background()
+	chainID := uuid.New()
+	merchantID := uuid.New()
+	chainRepo.On("GetByID", mock.Anything, chainID).Return(&entities.Chain{ID: chainID, Type: entities.ChainTypeEVM, MinConfirmations: 5}, nil)
+	merchantRepo.On("GetByID", mock.Anything, merchantID).Return(&entities.Merchant{ID: merchantID}, nil)
+
+	// A payment held at block 100 is 5 deep at head 104; one still in flight has no completion
+	held := &entities.Payment{ID: uuid.New(), DestChainID: chainID, MerchantID: &merchantID, Status: entities.PaymentStatusProcessing}
+	inFlight := &entities.Payment{ID: uuid.New(), DestChainID: chainID, MerchantID: &merchantID, Status: entities.PaymentStatusProcessing}
+	paymentRepo.On("GetByStatus", mock.Anything, entities.PaymentStatusProcessing, 10, 0).Return([]*entities.Payment{held, inFlight}, 2, nil)
+	heldPayload, _ := json.Marshal(map[string]any{"paymentId": held.ID.String(), "status": "completed", "sourceTxHash": "0xsrc", "blockNumber": 100, "confirmations": 1})
+	eventRepo.On("GetByPaymentID", mock.Anything, held.ID).Return([]*entities.PaymentEvent{
+		{PaymentID: held.ID, EventType: entities.PaymentEventTypeIndexerCompleted, TxHash: "0xsrc", BlockNumber: 100, Confirmations: 1, Metadata: string(heldPayload)},
+	}, nil)
+	eventRepo.On("GetByPaymentID", mock.Anything, inFlight.ID).Return([]*entities.PaymentEvent{
+		{PaymentID: inFlight.ID, EventType: entities.PaymentEventTypeIndexerCreated},
+	}, nil)
+
+	uow.On("Do", ctx, mock.Anything).Return(nil)
+	uow.On("WithLock", ctx).Return(ctx)
+	paymentRepo.On("GetByID", mock.Anything, held.ID).Return(held, nil)
+	paymentRepo.On("UpdateStatus", mock.Anything, held.ID, entities.PaymentStatusCompleted).Return(nil).Once()
+	var replayed *entities.PaymentEvent
+	eventRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		replayed = args.Get(1).(*entities.PaymentEvent)
+	}).Return(nil).Once()
+	webhookRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+	// A request at block 100 completes; one at block 103 is only 2 deep
+	deep := &entities.PaymentRequest{ID: uuid.New(), MerchantID: merchantID, ChainID: chainID, Status: entities.PaymentRequestStatusProcessing, TxHash: "0xdeep", BlockNumber: 100}
+	shallow := &entities.PaymentRequest{ID: uuid.New(), MerchantID: merchantID, ChainID: chainID, Status: entities.PaymentRequestStatusProcessing, TxHash: "0xshallow"}
+	requestRepo.On("GetProcessing", mock.Anything, 10).Return([]*entities.PaymentRequest{deep, shallow}, nil)
+	requestRepo.On("MarkCompleted", mock.Anything, deep.ID, "0xdeep").Return(nil).Once()
+	sessionRepo.On("GetByPaymentRequestID", mock.Anything, deep.ID).Return(nil, nil).Once()
+
+	completed, err := uc.RecheckHeldCompletions(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, completed)
+
+	paymentRepo.AssertExpectations(t)
+	requestRepo.AssertExpectations(t)
+	webhookRepo.AssertExpectations(t)
+	requestRepo.AssertNotCalled(t, "MarkCompleted", mock.Anything, shallow.ID, mock.Anything)
+	if assert.NotNil(t, replayed) {
+		assert.Equal(t, 5, replayed.Confirmations)
+		assert.Equal(t, int64(100), replayed.BlockNumber)
+	}
+}
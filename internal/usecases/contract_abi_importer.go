@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+const contractABIImportTimeout = 15 * time.Second
+
+// ImportedContractABI is a verified ABI fetched from a chain's block explorer, with the
+// functions it declares checked against what the contract type needs
+type ImportedContractABI struct {
+	ChainID           string      `json:"chainId"`
+	Address           string      `json:"address"`
+	ABI               interface{} `json:"abi"`
+	Functions         []string    `json:"functions"`
+	RequiredFunctions []string    `json:"requiredFunctions"`
+	MissingFunctions  []string    `json:"missingFunctions"`
+}
+
+// Complete reports whether the ABI declares every function its contract type needs
+func (i *ImportedContractABI) Complete() bool {
+	return len(i.MissingFunctions) == 0
+}
+
+// ContractABIImporter fetches verified contract ABIs from Etherscan-compatible explorer APIs
+type ContractABIImporter struct {
+	client *http.Client
+}
+
+// NewContractABIImporter creates an importer with a bounded request timeout
+func NewContractABIImporter() *ContractABIImporter {
+	return &ContractABIImporter{client: &http.Client{Timeout: contractABIImportTimeout}}
+}
+
+// ImportABI fetches the verified ABI of address from chain's explorer API and checks it against
+// the functions contractType requires. An empty contractType skips the check.
+func (i *ContractABIImporter) ImportABI(ctx context.Context, chain *entities.Chain, address string, contractType entities.SmartContractType) (*ImportedContractABI, error) {
+	caip2 := chain.GetCAIP2ID()
+	if chain.ChainType() != entities.ChainTypeEVM || strings.TrimSpace(chain.ExplorerAPIURL) == "" {
+		return nil, domainerrors.NewAppError(http.StatusUnprocessableEntity, domainerrors.CodeExplorerNotConfigured,
+			fmt.Sprintf("%s has no contract verification API configured", caip2), domainerrors.ErrExplorerNotConfigured)
+	}
+	if !common.IsHexAddress(address) {
+		return nil, domainerrors.BadRequest("address must be an EVM address")
+	}
+	address = common.HexToAddress(address).Hex()
+
+	rawABI, err := i.fetchABI(ctx, chain, address)
+	if err != nil {
+		return nil, domainerrors.NewAppError(http.StatusUnprocessableEntity, domainerrors.CodeABIUnavailable,
+			fmt.Sprintf("no verified ABI for %s on %s: %v", address, caip2, err), domainerrors.ErrABIUnavailable)
+	}
+	if _, err := abi.JSON(strings.NewReader(rawABI)); err != nil {
+		return nil, domainerrors.NewAppError(http.StatusUnprocessableEntity, domainerrors.CodeABIUnavailable,
+			fmt.Sprintf("explorer returned an invalid ABI for %s on %s: %v", address, caip2, err), domainerrors.ErrABIUnavailable)
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(rawABI), &parsed); err != nil {
+		return nil, domainerrors.NewAppError(http.StatusUnprocessableEntity, domainerrors.CodeABIUnavailable,
+			fmt.Sprintf("explorer returned an invalid ABI for %s on %s: %v", address, caip2, err), domainerrors.ErrABIUnavailable)
+	}
+
+	functions := extractFunctionNames(parsed)
+	existing := make(map[string]struct{}, len(functions))
+	for _, fn := range functions {
+		existing[strings.ToLower(fn)] = struct{}{}
+	}
+	required := requiredFunctions(contractType)
+	missing := make([]string, 0)
+	for _, req := range required {
+		if _, ok := existing[strings.ToLower(req)]; !ok {
+			missing = append(missing, req)
+		}
+	}
+	sort.Strings(functions)
+	sort.Strings(required)
+	sort.Strings(missing)
+
+	return &ImportedContractABI{
+		ChainID:           caip2,
+		Address:           address,
+		ABI:               parsed,
+		Functions:         functions,
+		RequiredFunctions: required,
+		MissingFunctions:  missing,
+	}, nil
+}
+
+// fetchABI calls module=contract&action=getabi, keeping any query parameters already on the
+// configured URL (such as Etherscan v2's chainid)
+func (i *ContractABIImporter) fetchABI(ctx context.Context, chain *entities.Chain, address string) (string, error) {
+	endpoint, err := url.Parse(strings.TrimSpace(chain.ExplorerAPIURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid explorer API URL: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("module", "contract")
+	query.Set("action", "getabi")
+	query.Set("address", address)
+	if chain.ExplorerAPIKey != "" {
+		query.Set("apikey", chain.ExplorerAPIKey)
+	}
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return "", redactExplorerAPIKey(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("explorer answered %s", resp.Status)
+	}
+
+	var body struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Result  string `json:"result"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 8<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("unreadable explorer response: %w", err)
+	}
+	if body.Status != "1" {
+		reason := strings.TrimSpace(body.Result)
+		if reason == "" {
+			reason = body.Message
+		}
+		return "", errors.New(reason)
+	}
+	return body.Result, nil
+}
+
+// redactExplorerAPIKey strips the apikey query parameter from the URL a failed request reports,
+// since ImportABI surfaces the error to the caller and the key is never exposed otherwise
+func redactExplorerAPIKey(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	if parsed, parseErr := url.Parse(urlErr.URL); parseErr == nil {
+		query := parsed.Query()
+		if query.Has("apikey") {
+			query.Set("apikey", "REDACTED")
+			parsed.RawQuery = query.Encode()
+		}
+		urlErr.URL = parsed.String()
+	} else {
+		urlErr.URL = "<explorer API URL>"
+	}
+	return urlErr
+}
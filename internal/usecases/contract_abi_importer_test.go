@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestContractABIImporter_ImportABI(t *testing.T) {
+	const tokenRegistryABI = `[{"type":"function","name":"isSupportedToken","inputs":[{"name":"token","type":"address"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"view"}]`
+	var query map[string][]string
+	result := map[string]string{"status": "1", "message": "OK", "result": tokenRegistryABI}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		require.NoError(t, json.NewEncoder(w).Encode(result))
+	}))
+	defer srv.Close()
+
+	chain := &entities.Chain{ChainID: "8453", Type: entities.ChainTypeEVM, ExplorerAPIURL: srv.URL + "/v2/api?chainid=8453", ExplorerAPIKey: "key"}
+	address := "0x1111111111111111111111111111111111111111"
+	importer := NewContractABIImporter()
+
+	imported, err := importer.ImportABI(context.Background(), chain, address, entities.ContractTypeTokenRegistry)
+	require.NoError(t, err)
+	require.True(t, imported.Complete())
+	require.Equal(t, []string{"isSupportedToken"}, imported.Functions)
+	require.Equal(t, "getabi", query["action"][0])
+	require.Equal(t, "8453", query["chainid"][0])
+	require.Equal(t, "key", query["apikey"][0])
+
+	imported, err = importer.ImportABI(context.Background(), chain, address, entities.ContractTypeTokenSwapper)
+	require.NoError(t, err)
+	require.False(t, imported.Complete())
+	require.Equal(t, []string{"swap"}, imported.MissingFunctions)
+
+	var appErr *domainerrors.AppError
+	result = map[string]string{"status": "0", "message": "NOTOK", "result": "Contract source code not verified"}
+	_, err = importer.ImportABI(context.Background(), chain, address, "")
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeABIUnavailable, appErr.Code)
+	require.Contains(t, appErr.Message, "not verified")
+
+	result = map[string]string{"status": "1", "message": "OK", "result": `[{"type":"function","name":`}
+	_, err = importer.ImportABI(context.Background(), chain, address, "")
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeABIUnavailable, appErr.Code)
+
+	_, err = importer.ImportABI(context.Background(), chain, "not-an-address", "")
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+
+	_, err = importer.ImportABI(context.Background(), &entities.Chain{ChainID: "1", Type: entities.ChainTypeEVM}, address, "")
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeExplorerNotConfigured, appErr.Code)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+}
+
+func TestContractABIImporter_TransportErrorRedactsAPIKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	explorerURL := srv.URL + "/api"
+	srv.Close()
+
+	chain := &entities.Chain{ChainID: "8453", Type: entities.ChainTypeEVM, ExplorerAPIURL: explorerURL, ExplorerAPIKey: "very-secret-key"}
+	_, err := NewContractABIImporter().ImportABI(context.Background(), chain, "0x1111111111111111111111111111111111111111", "")
+
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeABIUnavailable, appErr.Code)
+	require.NotContains(t, appErr.Message, "very-secret-key")
+	require.NotContains(t, err.Error(), "very-secret-key")
+	require.Contains(t, appErr.Message, "apikey=REDACTED")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
 	uc "payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/utils"
 )
@@ -25,7 +26,7 @@ func TestContractConfigAuditUsecase_Check_DestinationInvalidStillReturnsReport(t
 	chainRepo.On("GetByCAIP2", mock.Anything, "eip155:bad").Return((*entities.Chain)(nil), errors.New("not found"))
 	chainRepo.On("GetByChainID", mock.Anything, mock.AnythingOfType("string")).Return((*entities.Chain)(nil), errors.New("not found")).Maybe()
 
-	contractRepo.On("GetFiltered", mock.Anything, &sourceID, entities.SmartContractType(""), utils.PaginationParams{Page: 1, Limit: 0}).
+	contractRepo.On("GetFiltered", mock.Anything, repositories.ActiveContracts(&sourceID), utils.PaginationParams{Page: 1, Limit: 0}).
 		Return([]*entities.SmartContract{}, int64(0), nil)
 
 	u := uc.NewContractConfigAuditUsecase(chainRepo, contractRepo, nil)
@@ -44,7 +45,7 @@ func TestContractConfigAuditUsecase_Check_ContractListError(t *testing.T) {
 	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
 	chainRepo.On("GetByCAIP2", mock.Anything, "eip155:8453").Return(source, nil)
 	chainRepo.On("GetByID", mock.Anything, sourceID).Return(source, nil)
-	contractRepo.On("GetFiltered", mock.Anything, &sourceID, entities.SmartContractType(""), utils.PaginationParams{Page: 1, Limit: 0}).
+	contractRepo.On("GetFiltered", mock.Anything, repositories.ActiveContracts(&sourceID), utils.PaginationParams{Page: 1, Limit: 0}).
 		Return(nil, int64(0), errors.New("db down"))
 
 	u := uc.NewContractConfigAuditUsecase(chainRepo, contractRepo, nil)
@@ -103,7 +104,7 @@ func TestContractConfigAuditUsecase_Check_EvmDestRunsOnchainChecksWithRpcMissing
 	chainRepo.On("GetByCAIP2", mock.Anything, "eip155:8453").Return(source, nil)
 	chainRepo.On("GetByID", mock.Anything, sourceID).Return(source, nil)
 	chainRepo.On("GetByCAIP2", mock.Anything, "eip155:42161").Return(dest, nil)
-	contractRepo.On("GetFiltered", mock.Anything, &sourceID, entities.SmartContractType(""), utils.PaginationParams{Page: 1, Limit: 0}).
+	contractRepo.On("GetFiltered", mock.Anything, repositories.ActiveContracts(&sourceID), utils.PaginationParams{Page: 1, Limit: 0}).
 		Return([]*entities.SmartContract{gateway, router}, int64(2), nil)
 
 	u := uc.NewContractConfigAuditUsecase(chainRepo, contractRepo, nil)
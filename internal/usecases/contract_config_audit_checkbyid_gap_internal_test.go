@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -35,9 +36,13 @@ func (s *ccasChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool, *stri
 func (s *ccasChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *ccasChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *ccasChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *ccasChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *ccasChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *ccasChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *ccasChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *ccasChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *ccasChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -60,9 +65,15 @@ func (s *ccasContractRepoStub) GetByChainAndAddress(context.Context, uuid.UUID,
 func (s *ccasContractRepoStub) GetActiveContract(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
 	return nil, errors.New("not found")
 }
-func (s *ccasContractRepoStub) GetFiltered(context.Context, *uuid.UUID, entities.SmartContractType, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+func (s *ccasContractRepoStub) GetFiltered(context.Context, repositories.SmartContractFilter, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return s.filtered, int64(len(s.filtered)), nil
 }
+func (s *ccasContractRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
+func (s *ccasContractRepoStub) Activate(context.Context, uuid.UUID) (*entities.SmartContract, error) {
+	return nil, nil
+}
 func (s *ccasContractRepoStub) GetByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
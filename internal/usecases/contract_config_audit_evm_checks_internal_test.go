@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"math/big"
@@ -21,7 +22,11 @@ type evmCallStep struct {
 func newAuditUsecaseWithMockEVM(rpcURL string, steps []evmCallStep) *ContractConfigAuditUsecase {
 	clientFactory := blockchain.NewClientFactory()
 	idx := 0
-	clientFactory.RegisterEVMClient(rpcURL, blockchain.NewEVMClientWithCallView(big.NewInt(8453), func(context.Context, string, []byte) ([]byte, error) {
+	clientFactory.RegisterEVMClient(rpcURL, blockchain.NewEVMClientWithCallView(big.NewInt(8453), func(_ context.Context, _ string, data []byte) ([]byte, error) {
+		// The gateway pause read comes first and is answered "not paused" outside the steps
+		if bytes.HasPrefix(data, gatewayPausedSelector) {
+			return make([]byte, 32), nil
+		}
 		if idx >= len(steps) {
 			return nil, errors.New("unexpected call")
 		}
@@ -29,7 +34,7 @@ func newAuditUsecaseWithMockEVM(rpcURL string, steps []evmCallStep) *ContractCon
 		idx++
 		return step.out, step.err
 	}))
-	return &ContractConfigAuditUsecase{clientFactory: clientFactory}
+	return &ContractConfigAuditUsecase{clientFactory: NewEVMClientFactory(clientFactory)}
 }
 
 func auditContracts(sourceID uuid.UUID) []*entities.SmartContract {
@@ -43,7 +48,7 @@ func TestRunEVMOnchainChecks_CoreAndReadFailureBranches(t *testing.T) {
 	sourceID := uuid.New()
 	source := &entities.Chain{ID: sourceID, Type: entities.ChainTypeEVM}
 
-	u := &ContractConfigAuditUsecase{clientFactory: blockchain.NewClientFactory()}
+	u := &ContractConfigAuditUsecase{clientFactory: NewEVMClientFactory(blockchain.NewClientFactory())}
 	checks := u.runEVMOnchainChecks(context.Background(), source, nil, "eip155:42161")
 	require.Equal(t, "RPC_MISSING", checks[0].Code)
 
@@ -143,3 +148,35 @@ func TestRunEVMOnchainChecks_CCIPBranches(t *testing.T) {
 		require.Equal(t, "CCIP_DEST_ADAPTER_MISSING", checks[len(checks)-1].Code)
 	})
 }
+
+func TestRunEVMOnchainChecks_GatewayPauseState(t *testing.T) {
+	sourceID := uuid.New()
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: "mock://paused"}
+	contracts := auditContracts(sourceID)[:1]
+	contracts[0].Name = "PaymentKitaGateway"
+
+	run := func(paused []byte, err error) []ContractConfigCheckItem {
+		clientFactory := blockchain.NewClientFactory()
+		clientFactory.RegisterEVMClient(source.RPCURL, blockchain.NewEVMClientWithCallView(big.NewInt(8453), func(context.Context, string, []byte) ([]byte, error) {
+			return paused, err
+		}))
+		u := &ContractConfigAuditUsecase{clientFactory: NewEVMClientFactory(clientFactory)}
+		return u.runEVMOnchainChecks(context.Background(), source, contracts, "eip155:42161")
+	}
+	codes := func(checks []ContractConfigCheckItem) []string {
+		out := make([]string, 0, len(checks))
+		for _, check := range checks {
+			out = append(out, check.Code)
+		}
+		return out
+	}
+
+	checks := run(common.LeftPadBytes([]byte{1}, 32), nil)
+	require.Equal(t, []string{"ROUTER_MISSING", "GATEWAY_PAUSED"}, codes(checks))
+	require.Equal(t, "WARN", checks[1].Status)
+	require.Equal(t, "PaymentKitaGateway", checks[1].Contract)
+
+	require.Equal(t, []string{"ROUTER_MISSING"}, codes(run(make([]byte, 32), nil)))
+	require.Equal(t, []string{"ROUTER_MISSING"}, codes(run(nil, errors.New("execution reverted"))))
+	require.Equal(t, []string{"ROUTER_MISSING", "GATEWAY_PAUSED_READ_FAILED"}, codes(run(nil, errors.New("connection refused"))))
+}
@@ -155,7 +155,7 @@ func TestRunEVMOnchainChecks_RPCMissing(t *testing.T) {
 
 func TestRunEVMOnchainChecks_RPCConnectFailed(t *testing.T) {
 	u := &ContractConfigAuditUsecase{
-		clientFactory: blockchain.NewClientFactory(),
+		clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 	}
 	source := &entities.Chain{
 		ID:      uuid.New(),
@@ -186,7 +186,7 @@ func TestRunEVMOnchainChecks_GatewayRouterMissing(t *testing.T) {
 	defer srv.Close()
 
 	u := &ContractConfigAuditUsecase{
-		clientFactory: blockchain.NewClientFactory(),
+		clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 	}
 	source := &entities.Chain{
 		ID:      uuid.New(),
@@ -220,7 +220,7 @@ func TestRunEVMOnchainChecks_DefaultBridgeReadFailed(t *testing.T) {
 
 	sourceID := uuid.New()
 	u := &ContractConfigAuditUsecase{
-		clientFactory: blockchain.NewClientFactory(),
+		clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 	}
 	source := &entities.Chain{
 		ID:      sourceID,
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -31,6 +32,13 @@ func encodeMethodOutput(t *testing.T, rawABI, method string, values ...interface
 	return "0x" + hex.EncodeToString(out)
 }
 
+// isGatewayPausedRPCCall reports whether an eth_call reads paused(), which the audit checks
+// before the route reads these servers answer in order
+func isGatewayPausedRPCCall(params interface{}) bool {
+	raw, _ := json.Marshal(params)
+	return strings.Contains(string(raw), "0x"+hex.EncodeToString(gatewayPausedSelector))
+}
+
 func TestRunEVMOnchainChecks_HyperbridgeConfiguredPath(t *testing.T) {
 	defaultBridgeABI := `[{"inputs":[{"internalType":"string","name":"destChainId","type":"string"}],"name":"defaultBridgeTypes","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"}]`
 	hasAdapterABI := `[{"inputs":[{"internalType":"string","name":"destChainId","type":"string"},{"internalType":"uint8","name":"bridgeType","type":"uint8"}],"name":"hasAdapter","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"}]`
@@ -54,7 +62,9 @@ func TestRunEVMOnchainChecks_HyperbridgeConfiguredPath(t *testing.T) {
 		if req.Method == "eth_chainId" {
 			res["result"] = "0x2105"
 		} else if req.Method == "eth_call" {
-			if callIdx < len(callResults) {
+			if isGatewayPausedRPCCall(req.Params) {
+				res["result"] = "0x" + hex.EncodeToString(make([]byte, 32))
+			} else if callIdx < len(callResults) {
 				res["result"] = callResults[callIdx]
 				callIdx++
 			} else {
@@ -69,7 +79,7 @@ func TestRunEVMOnchainChecks_HyperbridgeConfiguredPath(t *testing.T) {
 	defer srv.Close()
 
 	sourceID := uuid.New()
-	usecase := &ContractConfigAuditUsecase{clientFactory: blockchain.NewClientFactory()}
+	usecase := &ContractConfigAuditUsecase{clientFactory: NewEVMClientFactory(blockchain.NewClientFactory())}
 	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: srv.URL}
 	contracts := []*entities.SmartContract{
 		{ID: uuid.New(), ChainUUID: sourceID, Type: entities.ContractTypeGateway, ContractAddress: "0x00000000000000000000000000000000000000a1", IsActive: true},
@@ -112,7 +122,9 @@ func TestRunEVMOnchainChecks_CcipConfiguredPath(t *testing.T) {
 		if req.Method == "eth_chainId" {
 			res["result"] = "0x2105"
 		} else if req.Method == "eth_call" {
-			if callIdx < len(callResults) {
+			if isGatewayPausedRPCCall(req.Params) {
+				res["result"] = "0x" + hex.EncodeToString(make([]byte, 32))
+			} else if callIdx < len(callResults) {
 				res["result"] = callResults[callIdx]
 				callIdx++
 			} else {
@@ -127,7 +139,7 @@ func TestRunEVMOnchainChecks_CcipConfiguredPath(t *testing.T) {
 	defer srv.Close()
 
 	sourceID := uuid.New()
-	usecase := &ContractConfigAuditUsecase{clientFactory: blockchain.NewClientFactory()}
+	usecase := &ContractConfigAuditUsecase{clientFactory: NewEVMClientFactory(blockchain.NewClientFactory())}
 	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: srv.URL}
 	contracts := []*entities.SmartContract{
 		{ID: uuid.New(), ChainUUID: sourceID, Type: entities.ContractTypeGateway, ContractAddress: "0x00000000000000000000000000000000000000a1", IsActive: true},
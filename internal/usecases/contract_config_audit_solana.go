@@ -0,0 +1,475 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"payment-kita.backend/internal/domain/entities"
+)
+
+// The gateway program keeps its settings in two kinds of Anchor accounts, found at PDAs of the
+// program (the active gateway contract's address):
+//
+//	GatewayConfig ["config"]:                     authority, fee_recipient (32 bytes each),
+//	                                              platform_fee_bps u16, paused bool
+//	BridgeRoute   ["route", sha256(destCAIP2)]:   dest_chain_id string, bridge_type u8,
+//	                                              destination_adapter bytes, enabled bool
+//
+// Strings and byte vectors are Borsh-encoded: a little-endian u32 length, then the bytes.
+// The program is not built from this repository, so these layouts are not assumed: they are
+// checked against the Anchor IDL recorded as the gateway contract's abi, and the audit cites
+// the IDL's program name and version. A program whose IDL declares other fields is reported
+// rather than misread.
+const (
+	solanaGatewayConfigSeed    = "config"
+	solanaBridgeRouteSeed      = "route"
+	solanaGatewayConfigAccount = "GatewayConfig"
+	solanaBridgeRouteAccount   = "BridgeRoute"
+	solanaMaxPlatformFeeBps    = 10000
+)
+
+// solanaIDLField is one field of an account as an Anchor IDL declares it
+type solanaIDLField struct {
+	Name string
+	Type string
+}
+
+// solanaAccountLayouts are the account layouts the decoders below read, in IDL terms
+var solanaAccountLayouts = map[string][]solanaIDLField{
+	solanaGatewayConfigAccount: {
+		{Name: "authority", Type: "pubkey"},
+		{Name: "fee_recipient", Type: "pubkey"},
+		{Name: "platform_fee_bps", Type: "u16"},
+		{Name: "paused", Type: "bool"},
+	},
+	solanaBridgeRouteAccount: {
+		{Name: "dest_chain_id", Type: "string"},
+		{Name: "bridge_type", Type: "u8"},
+		{Name: "destination_adapter", Type: "bytes"},
+		{Name: "enabled", Type: "bool"},
+	},
+}
+
+// anchorIDL is the part of an Anchor IDL the audit reads. Anchor 0.30+ IDLs carry the version
+// in metadata and declare account fields under types; older ones have a top-level version and
+// declare the fields on the account itself.
+type anchorIDL struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Metadata struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"metadata"`
+	Accounts []anchorIDLTypeDef `json:"accounts"`
+	Types    []anchorIDLTypeDef `json:"types"`
+}
+
+type anchorIDLTypeDef struct {
+	Name          string `json:"name"`
+	Discriminator []int  `json:"discriminator"`
+	Type          *struct {
+		Kind   string `json:"kind"`
+		Fields []struct {
+			Name string          `json:"name"`
+			Type json.RawMessage `json:"type"`
+		} `json:"fields"`
+	} `json:"type"`
+}
+
+// label names the program the IDL describes, e.g. "payment_kita_gateway 0.3.0"
+func (idl *anchorIDL) label() string {
+	name, version := idl.Metadata.Name, idl.Metadata.Version
+	if name == "" {
+		name = idl.Name
+	}
+	if version == "" {
+		version = idl.Version
+	}
+	return strings.TrimSpace(name + " " + version)
+}
+
+// accountFields lists the fields the IDL declares for account, with types in 0.30 spelling
+func (idl *anchorIDL) accountFields(account string) ([]solanaIDLField, error) {
+	var def *anchorIDLTypeDef
+	for i := range idl.Accounts {
+		if idl.Accounts[i].Name != account {
+			continue
+		}
+		def = &idl.Accounts[i]
+		if len(def.Discriminator) > 0 {
+			want := anchorAccountDiscriminator(account)
+			if len(def.Discriminator) != len(want) {
+				return nil, fmt.Errorf("%s discriminator is %v", account, def.Discriminator)
+			}
+			for j, b := range want {
+				if def.Discriminator[j] != int(b) {
+					return nil, fmt.Errorf("%s discriminator is %v", account, def.Discriminator)
+				}
+			}
+		}
+	}
+	if def == nil {
+		return nil, fmt.Errorf("IDL has no %s account", account)
+	}
+	if def.Type == nil {
+		for i := range idl.Types {
+			if idl.Types[i].Name == account {
+				def = &idl.Types[i]
+			}
+		}
+	}
+	if def.Type == nil || def.Type.Kind != "struct" {
+		return nil, fmt.Errorf("IDL does not declare the %s fields", account)
+	}
+
+	fields := make([]solanaIDLField, 0, len(def.Type.Fields))
+	for _, field := range def.Type.Fields {
+		fields = append(fields, solanaIDLField{Name: normalizeIDLName(field.Name), Type: normalizeIDLType(field.Type)})
+	}
+	return fields, nil
+}
+
+// normalizeIDLName spells camelCase (pre-0.30) field names in snake_case
+func normalizeIDLName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// normalizeIDLType spells an IDL field type the way solanaAccountLayouts does. Vec<u8> is
+// Borsh-encoded exactly like bytes.
+func normalizeIDLType(raw json.RawMessage) string {
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		if name == "publicKey" {
+			return "pubkey"
+		}
+		return name
+	}
+	var vec struct {
+		Vec string `json:"vec"`
+	}
+	if err := json.Unmarshal(raw, &vec); err == nil && vec.Vec == "u8" {
+		return "bytes"
+	}
+	return string(raw)
+}
+
+// solanaGatewayIDLCheck checks the IDL recorded on the gateway contract declares the account
+// layouts the audit decodes. ok is false when the accounts must not be decoded.
+func solanaGatewayIDLCheck(gateway *entities.SmartContract) (check ContractConfigCheckItem, ok bool) {
+	if gateway.ABI == nil {
+		return ContractConfigCheckItem{
+			Code:     "PROGRAM_IDL_MISSING",
+			Status:   "WARN",
+			Message:  "gateway program IDL is not recorded on the contract; its accounts cannot be audited",
+			Contract: gateway.Name,
+		}, false
+	}
+	var idl anchorIDL
+	raw, err := json.Marshal(gateway.ABI)
+	if err == nil {
+		if text, isText := gateway.ABI.(string); isText {
+			raw = []byte(text)
+		}
+		err = json.Unmarshal(raw, &idl)
+	}
+	if err != nil {
+		return ContractConfigCheckItem{Code: "PROGRAM_IDL_INVALID", Status: "ERROR", Message: "gateway program IDL is not an Anchor IDL", Contract: gateway.Name}, false
+	}
+
+	label := idl.label()
+	if label == "" {
+		label = gateway.Version
+	}
+	for _, account := range []string{solanaGatewayConfigAccount, solanaBridgeRouteAccount} {
+		fields, err := idl.accountFields(account)
+		if err == nil && !slices.Equal(fields, solanaAccountLayouts[account]) {
+			err = fmt.Errorf("%s fields are %v, expected %v", account, fields, solanaAccountLayouts[account])
+		}
+		if err != nil {
+			return ContractConfigCheckItem{
+				Code:     "PROGRAM_IDL_MISMATCH",
+				Status:   "ERROR",
+				Message:  fmt.Sprintf("gateway program IDL %s does not match the audited layout: %v", label, err),
+				Contract: gateway.Name,
+			}, false
+		}
+	}
+	return ContractConfigCheckItem{Code: "PROGRAM_IDL_MATCHED", Status: "OK", Message: "gateway program IDL " + label + " matches the audited layout", Contract: gateway.Name}, true
+}
+
+// solanaGatewayConfig is a decoded GatewayConfig account
+type solanaGatewayConfig struct {
+	Authority      []byte
+	FeeRecipient   []byte
+	PlatformFeeBps uint16
+	Paused         bool
+}
+
+// solanaBridgeRoute is a decoded BridgeRoute account
+type solanaBridgeRoute struct {
+	DestChainID        string
+	BridgeType         uint8
+	DestinationAdapter []byte
+	Enabled            bool
+}
+
+// runSolanaOnchainChecks is runEVMOnchainChecks for an SVM source chain: it reads the gateway
+// program's config and its route to destCAIP2 and reports them with the same check codes where
+// they mean the same thing
+func (u *ContractConfigAuditUsecase) runSolanaOnchainChecks(
+	ctx context.Context,
+	sourceChain *entities.Chain,
+	contracts []*entities.SmartContract,
+	destCAIP2 string,
+) []ContractConfigCheckItem {
+	checks := make([]ContractConfigCheckItem, 0)
+	rpcURL := resolveRPCURL(sourceChain)
+	if rpcURL == "" {
+		return append(checks, ContractConfigCheckItem{
+			Code:    "RPC_MISSING",
+			Status:  "ERROR",
+			Message: "source chain has no active RPC URL",
+		})
+	}
+	if u.solanaClients == nil {
+		return append(checks, ContractConfigCheckItem{
+			Code:    "RPC_CONNECT_FAILED",
+			Status:  "ERROR",
+			Message: "failed to connect source chain RPC",
+		})
+	}
+	client, err := u.solanaClients.GetSolanaClient(rpcURL)
+	if err != nil {
+		return append(checks, ContractConfigCheckItem{
+			Code:    "RPC_CONNECT_FAILED",
+			Status:  "ERROR",
+			Message: "failed to connect source chain RPC",
+		})
+	}
+
+	gateway := findActiveContractByType(contracts, entities.ContractTypeGateway)
+	if gateway == nil {
+		return append(checks, ContractConfigCheckItem{Code: "GATEWAY_MISSING", Status: "ERROR", Message: "active gateway contract is missing"})
+	}
+	programID := base58Decode(gateway.ContractAddress)
+	if len(programID) != 32 {
+		return append(checks, ContractConfigCheckItem{
+			Code:     "PROGRAM_ADDRESS_INVALID",
+			Status:   "ERROR",
+			Message:  "gateway program address is not a base58 public key",
+			Contract: gateway.Name,
+		})
+	}
+
+	program, err := client.GetAccountInfo(ctx, gateway.ContractAddress)
+	switch {
+	case err != nil:
+		return append(checks, ContractConfigCheckItem{Code: "PROGRAM_READ_FAILED", Status: "ERROR", Message: "failed to read gateway program account", Contract: gateway.Name})
+	case program == nil || !program.Executable:
+		return append(checks, ContractConfigCheckItem{Code: "PROGRAM_NOT_DEPLOYED", Status: "ERROR", Message: "gateway program is not deployed at " + gateway.ContractAddress, Contract: gateway.Name})
+	}
+	checks = append(checks, ContractConfigCheckItem{Code: "PROGRAM_DEPLOYED", Status: "OK", Message: "gateway program is deployed", Contract: gateway.Name})
+
+	idlCheck, ok := solanaGatewayIDLCheck(gateway)
+	checks = append(checks, idlCheck)
+	if !ok {
+		return checks
+	}
+
+	checks = append(checks, u.solanaConfigChecks(ctx, client, programID, gateway.Name)...)
+	return append(checks, u.solanaRouteChecks(ctx, client, programID, gateway.Name, destCAIP2)...)
+}
+
+// solanaConfigChecks audits the program's fee parameters
+func (u *ContractConfigAuditUsecase) solanaConfigChecks(ctx context.Context, client SolanaClient, programID []byte, contractName string) []ContractConfigCheckItem {
+	address, err := findProgramAddress([][]byte{[]byte(solanaGatewayConfigSeed)}, programID)
+	if err != nil {
+		return []ContractConfigCheckItem{{Code: "CONFIG_READ_FAILED", Status: "ERROR", Message: "failed to derive gateway config account", Contract: contractName}}
+	}
+	account, err := client.GetAccountInfo(ctx, base58Encode(address[:]))
+	if err != nil {
+		return []ContractConfigCheckItem{{Code: "CONFIG_READ_FAILED", Status: "ERROR", Message: "failed to read gateway config account", Contract: contractName}}
+	}
+	if account == nil {
+		return []ContractConfigCheckItem{{Code: "CONFIG_NOT_INITIALIZED", Status: "ERROR", Message: "gateway config account is not initialized", Contract: contractName}}
+	}
+	config, err := decodeSolanaGatewayConfig(account.Data)
+	if err != nil {
+		return []ContractConfigCheckItem{{Code: "CONFIG_INVALID", Status: "ERROR", Message: "gateway config account is invalid: " + err.Error(), Contract: contractName}}
+	}
+
+	checks := make([]ContractConfigCheckItem, 0, 3)
+	switch {
+	case isZeroBytes(config.FeeRecipient):
+		checks = append(checks, ContractConfigCheckItem{Code: "FEE_RECIPIENT_ZERO", Status: "ERROR", Message: "gateway fee recipient is not set", Contract: contractName})
+	case config.PlatformFeeBps > solanaMaxPlatformFeeBps:
+		checks = append(checks, ContractConfigCheckItem{Code: "FEE_BPS_INVALID", Status: "ERROR", Message: fmt.Sprintf("gateway platform fee %d bps exceeds 100%%", config.PlatformFeeBps), Contract: contractName})
+	default:
+		checks = append(checks, ContractConfigCheckItem{
+			Code:     "FEE_CONFIG_OK",
+			Status:   "OK",
+			Message:  fmt.Sprintf("platform fee %d bps to %s", config.PlatformFeeBps, base58Encode(config.FeeRecipient)),
+			Contract: contractName,
+		})
+	}
+	if config.Paused {
+		checks = append(checks, ContractConfigCheckItem{Code: "GATEWAY_PAUSED", Status: "WARN", Message: "gateway program is paused", Contract: contractName})
+	}
+	return checks
+}
+
+// solanaRouteChecks audits the program's bridge route to destCAIP2
+func (u *ContractConfigAuditUsecase) solanaRouteChecks(ctx context.Context, client SolanaClient, programID []byte, contractName, destCAIP2 string) []ContractConfigCheckItem {
+	destHash := sha256.Sum256([]byte(destCAIP2))
+	address, err := findProgramAddress([][]byte{[]byte(solanaBridgeRouteSeed), destHash[:]}, programID)
+	if err != nil {
+		return []ContractConfigCheckItem{{Code: "DEFAULT_BRIDGE_READ_FAILED", Status: "ERROR", Message: "failed to derive bridge route account"}}
+	}
+	account, err := client.GetAccountInfo(ctx, base58Encode(address[:]))
+	if err != nil {
+		return []ContractConfigCheckItem{{Code: "DEFAULT_BRIDGE_READ_FAILED", Status: "ERROR", Message: "failed to read default bridge type for destination chain"}}
+	}
+	if account == nil {
+		return []ContractConfigCheckItem{{Code: "ROUTE_NOT_CONFIGURED", Status: "ERROR", Message: "gateway program has no route to " + destCAIP2}}
+	}
+	route, err := decodeSolanaBridgeRoute(account.Data)
+	if err != nil {
+		return []ContractConfigCheckItem{{Code: "ROUTE_INVALID", Status: "ERROR", Message: "bridge route account is invalid: " + err.Error()}}
+	}
+	if route.DestChainID != destCAIP2 {
+		return []ContractConfigCheckItem{{Code: "ROUTE_INVALID", Status: "ERROR", Message: fmt.Sprintf("bridge route account is for %s, not %s", route.DestChainID, destCAIP2)}}
+	}
+
+	checks := []ContractConfigCheckItem{{
+		Code:    "DEFAULT_BRIDGE_TYPE",
+		Status:  "OK",
+		Message: fmt.Sprintf("default bridge type for %s is %d", destCAIP2, route.BridgeType),
+	}}
+	if !route.Enabled {
+		checks = append(checks, ContractConfigCheckItem{Code: "ROUTE_DISABLED", Status: "ERROR", Message: "gateway route to " + destCAIP2 + " is disabled"})
+	}
+	if len(route.DestinationAdapter) == 0 || isZeroBytes(route.DestinationAdapter) {
+		checks = append(checks, ContractConfigCheckItem{
+			Code:    "ADAPTER_NOT_REGISTERED",
+			Status:  "ERROR",
+			Message: "gateway route has no destination adapter for destination/default bridge type",
+		})
+	} else {
+		checks = append(checks, ContractConfigCheckItem{
+			Code:    "ADAPTER_REGISTERED",
+			Status:  "OK",
+			Message: fmt.Sprintf("gateway route destination adapter: 0x%x", route.DestinationAdapter),
+		})
+	}
+	return checks
+}
+
+// anchorAccountDiscriminator is the 8-byte prefix Anchor writes on accounts of type name
+func anchorAccountDiscriminator(name string) [8]byte {
+	hash := sha256.Sum256([]byte("account:" + name))
+	var out [8]byte
+	copy(out[:], hash[:8])
+	return out
+}
+
+// borshReader reads Borsh-encoded fields in order; the first short read sticks as err
+type borshReader struct {
+	data []byte
+	err  error
+}
+
+func (r *borshReader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || len(r.data) < n {
+		r.err = fmt.Errorf("account data is truncated")
+		return nil
+	}
+	out := r.data[:n]
+	r.data = r.data[n:]
+	return out
+}
+
+func (r *borshReader) u8() uint8 {
+	if b := r.take(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *borshReader) u16() uint16 {
+	if b := r.take(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *borshReader) bytes() []byte {
+	if b := r.take(4); b != nil {
+		return r.take(int(binary.LittleEndian.Uint32(b)))
+	}
+	return nil
+}
+
+func newAnchorAccountReader(data []byte, account string) (*borshReader, error) {
+	discriminator := anchorAccountDiscriminator(account)
+	if len(data) < 8 || !bytes.Equal(data[:8], discriminator[:]) {
+		return nil, fmt.Errorf("not a %s account", account)
+	}
+	return &borshReader{data: data[8:]}, nil
+}
+
+func decodeSolanaGatewayConfig(data []byte) (*solanaGatewayConfig, error) {
+	r, err := newAnchorAccountReader(data, solanaGatewayConfigAccount)
+	if err != nil {
+		return nil, err
+	}
+	config := &solanaGatewayConfig{
+		Authority:      r.take(32),
+		FeeRecipient:   r.take(32),
+		PlatformFeeBps: r.u16(),
+		Paused:         r.u8() != 0,
+	}
+	return config, r.err
+}
+
+func decodeSolanaBridgeRoute(data []byte) (*solanaBridgeRoute, error) {
+	r, err := newAnchorAccountReader(data, solanaBridgeRouteAccount)
+	if err != nil {
+		return nil, err
+	}
+	route := &solanaBridgeRoute{
+		DestChainID:        string(r.bytes()),
+		BridgeType:         r.u8(),
+		DestinationAdapter: r.bytes(),
+		Enabled:            r.u8() != 0,
+	}
+	return route, r.err
+}
+
+func isZeroBytes(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,208 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+// solanaGatewayIDLFixture is an Anchor 0.30 IDL of the gateway program, as recorded on the
+// gateway contract's abi
+const solanaGatewayIDLFixture = `{
+  "address": "PKGw1111111111111111111111111111111111111111",
+  "metadata": {"name": "payment_kita_gateway", "version": "0.3.0", "spec": "0.1.0"},
+  "instructions": [],
+  "accounts": [
+    {"name": "BridgeRoute", "discriminator": [77, 85, 110, 3, 253, 67, 52, 25]},
+    {"name": "GatewayConfig", "discriminator": [91, 247, 66, 27, 24, 1, 48, 176]}
+  ],
+  "types": [
+    {"name": "BridgeRoute", "type": {"kind": "struct", "fields": [
+      {"name": "dest_chain_id", "type": "string"},
+      {"name": "bridge_type", "type": "u8"},
+      {"name": "destination_adapter", "type": "bytes"},
+      {"name": "enabled", "type": "bool"}
+    ]}},
+    {"name": "GatewayConfig", "type": {"kind": "struct", "fields": [
+      {"name": "authority", "type": "pubkey"},
+      {"name": "fee_recipient", "type": "pubkey"},
+      {"name": "platform_fee_bps", "type": "u16"},
+      {"name": "paused", "type": "bool"}
+    ]}}
+  ]
+}`
+
+type solanaAccountsStub struct {
+	accounts map[string]*blockchain.SolanaAccount
+	err      error
+}
+
+func (s *solanaAccountsStub) GetSolanaClient(string) (SolanaClient, error) { return s, nil }
+
+func (s *solanaAccountsStub) GetAccountInfo(_ context.Context, address string) (*blockchain.SolanaAccount, error) {
+	return s.accounts[address], s.err
+}
+
+func anchorAccountData(account string, fields ...[]byte) []byte {
+	discriminator := anchorAccountDiscriminator(account)
+	data := discriminator[:]
+	for _, field := range fields {
+		data = append(data, field...)
+	}
+	return data
+}
+
+func borshBytes(b []byte) []byte {
+	out := binary.LittleEndian.AppendUint32(nil, uint32(len(b)))
+	return append(out, b...)
+}
+
+func solanaPDA(t *testing.T, programID []byte, seeds ...[]byte) string {
+	t.Helper()
+	address, err := findProgramAddress(seeds, programID)
+	require.NoError(t, err)
+	return base58Encode(address[:])
+}
+
+func checkCodes(checks []ContractConfigCheckItem) []string {
+	codes := make([]string, 0, len(checks))
+	for _, check := range checks {
+		codes = append(codes, check.Code)
+	}
+	return codes
+}
+
+func TestRunSolanaOnchainChecks(t *testing.T) {
+	ctx := context.Background()
+	const dest = "eip155:8453"
+	programID := sha256.Sum256([]byte("gateway program"))
+	program := base58Encode(programID[:])
+	feeRecipient := sha256.Sum256([]byte("fee recipient"))
+	destHash := sha256.Sum256([]byte(dest))
+	configPDA := solanaPDA(t, programID[:], []byte("config"))
+	routePDA := solanaPDA(t, programID[:], []byte("route"), destHash[:])
+
+	source := &entities.Chain{ID: uuid.New(), Type: entities.ChainTypeSVM, RPCURL: "mock://solana"}
+	contracts := []*entities.SmartContract{{Name: "Gateway", Type: entities.ContractTypeGateway, ContractAddress: program, ABI: solanaGatewayIDLFixture, IsActive: true}}
+	configData := func(feeBps uint16, paused bool) []byte {
+		pausedByte := []byte{0}
+		if paused {
+			pausedByte = []byte{1}
+		}
+		return anchorAccountData("GatewayConfig", make([]byte, 32), feeRecipient[:], binary.LittleEndian.AppendUint16(nil, feeBps), pausedByte)
+	}
+	routeData := func(destChainID string, adapter []byte, enabled byte) []byte {
+		return anchorAccountData("BridgeRoute", borshBytes([]byte(destChainID)), []byte{1}, borshBytes(adapter), []byte{enabled})
+	}
+	stub := &solanaAccountsStub{accounts: map[string]*blockchain.SolanaAccount{
+		program:   {Executable: true},
+		configPDA: {Data: configData(30, false)},
+		routePDA:  {Data: routeData(dest, []byte{0xab, 0xcd}, 1)},
+	}}
+	u := &ContractConfigAuditUsecase{solanaClients: stub}
+
+	checks := u.runSolanaOnchainChecks(ctx, source, contracts, dest)
+	require.Equal(t, []string{"PROGRAM_DEPLOYED", "PROGRAM_IDL_MATCHED", "FEE_CONFIG_OK", "DEFAULT_BRIDGE_TYPE", "ADAPTER_REGISTERED"}, checkCodes(checks))
+	require.Equal(t, "platform fee 30 bps to "+base58Encode(feeRecipient[:]), checks[2].Message)
+	require.Equal(t, "default bridge type for eip155:8453 is 1", checks[3].Message)
+
+	// Misconfigured: paused, fee above 100%, route disabled without an adapter
+	stub.accounts[configPDA] = &blockchain.SolanaAccount{Data: configData(10001, true)}
+	stub.accounts[routePDA] = &blockchain.SolanaAccount{Data: routeData(dest, nil, 0)}
+	checks = u.runSolanaOnchainChecks(ctx, source, contracts, dest)
+	require.Equal(t, []string{"PROGRAM_DEPLOYED", "PROGRAM_IDL_MATCHED", "FEE_BPS_INVALID", "GATEWAY_PAUSED", "DEFAULT_BRIDGE_TYPE", "ROUTE_DISABLED", "ADAPTER_NOT_REGISTERED"}, checkCodes(checks))
+
+	// Accounts that are missing, truncated or of the wrong type
+	delete(stub.accounts, routePDA)
+	stub.accounts[configPDA] = &blockchain.SolanaAccount{Data: configData(30, false)[:40]}
+	checks = u.runSolanaOnchainChecks(ctx, source, contracts, dest)
+	require.Equal(t, []string{"PROGRAM_DEPLOYED", "PROGRAM_IDL_MATCHED", "CONFIG_INVALID", "ROUTE_NOT_CONFIGURED"}, checkCodes(checks))
+	stub.accounts[routePDA] = &blockchain.SolanaAccount{Data: configData(30, false)}
+	stub.accounts[configPDA] = &blockchain.SolanaAccount{Data: routeData("eip155:1", []byte{1}, 1)}
+	checks = u.runSolanaOnchainChecks(ctx, source, contracts, dest)
+	require.Equal(t, []string{"PROGRAM_DEPLOYED", "PROGRAM_IDL_MATCHED", "CONFIG_INVALID", "ROUTE_INVALID"}, checkCodes(checks))
+
+	// Program not deployed, unreadable, or not configured at all
+	stub.accounts[program] = &blockchain.SolanaAccount{Executable: false}
+	require.Equal(t, []string{"PROGRAM_NOT_DEPLOYED"}, checkCodes(u.runSolanaOnchainChecks(ctx, source, contracts, dest)))
+	stub.err = errors.New("rpc down")
+	require.Equal(t, []string{"PROGRAM_READ_FAILED"}, checkCodes(u.runSolanaOnchainChecks(ctx, source, contracts, dest)))
+	require.Equal(t, []string{"GATEWAY_MISSING"}, checkCodes(u.runSolanaOnchainChecks(ctx, source, nil, dest)))
+	invalid := []*entities.SmartContract{{Type: entities.ContractTypeGateway, ContractAddress: "0xabc", IsActive: true}}
+	require.Equal(t, []string{"PROGRAM_ADDRESS_INVALID"}, checkCodes(u.runSolanaOnchainChecks(ctx, source, invalid, dest)))
+	require.Equal(t, []string{"RPC_CONNECT_FAILED"}, checkCodes((&ContractConfigAuditUsecase{}).runSolanaOnchainChecks(ctx, source, contracts, dest)))
+	require.Equal(t, []string{"RPC_MISSING"}, checkCodes(u.runSolanaOnchainChecks(ctx, &entities.Chain{Type: entities.ChainTypeSVM}, contracts, dest)))
+}
+
+func TestRunSolanaOnchainChecks_AccountFixtures(t *testing.T) {
+	ctx := context.Background()
+	const dest = "eip155:8453"
+	programID := sha256.Sum256([]byte("gateway program"))
+	program := base58Encode(programID[:])
+	destHash := sha256.Sum256([]byte(dest))
+
+	// getAccountInfo data (base64) of a config charging 50 bps and a route to Base over bridge type 1
+	fixture := func(b64 string) *blockchain.SolanaAccount {
+		data, err := base64.StdEncoding.DecodeString(b64)
+		require.NoError(t, err)
+		return &blockchain.SolanaAccount{Owner: program, Data: data}
+	}
+	stub := &solanaAccountsStub{accounts: map[string]*blockchain.SolanaAccount{
+		program: {Executable: true},
+		solanaPDA(t, programID[:], []byte("config")):             fixture("W/dCGxgBMLA6O+jGVTji1MU2UzHBk26xWZ2nLvl+bGdRCMh34kTahixwyMNZ9vT8M3Xf9yVFaKAYMAolbXyS/XcL37tcLhEOMgAA"),
+		solanaPDA(t, programID[:], []byte("route"), destHash[:]): fixture("TVVuA/1DNBkLAAAAZWlwMTU1Ojg0NTMBFAAAAF4aikzEuwah7A2cL7taTYoNLh88AQ=="),
+	}}
+	u := &ContractConfigAuditUsecase{solanaClients: stub}
+	source := &entities.Chain{ID: uuid.New(), Type: entities.ChainTypeSVM, RPCURL: "mock://solana"}
+	gateway := func(idl interface{}) []*entities.SmartContract {
+		return []*entities.SmartContract{{Name: "Gateway", Type: entities.ContractTypeGateway, Version: "0.3.0", ContractAddress: program, ABI: idl, IsActive: true}}
+	}
+
+	checks := u.runSolanaOnchainChecks(ctx, source, gateway(solanaGatewayIDLFixture), dest)
+	require.Equal(t, []string{"PROGRAM_DEPLOYED", "PROGRAM_IDL_MATCHED", "FEE_CONFIG_OK", "DEFAULT_BRIDGE_TYPE", "ADAPTER_REGISTERED"}, checkCodes(checks))
+	require.Equal(t, "gateway program IDL payment_kita_gateway 0.3.0 matches the audited layout", checks[1].Message)
+	require.Contains(t, checks[2].Message, "platform fee 50 bps")
+	require.Equal(t, "gateway route destination adapter: 0x5e1a8a4cc4bb06a1ec0d9c2fbb5a4d8a0d2e1f3c", checks[4].Message)
+
+	// A pre-0.30 IDL spells the same layout in camelCase with publicKey
+	legacy := map[string]interface{}{
+		"version": "0.2.1",
+		"name":    "payment_kita_gateway",
+		"accounts": []interface{}{
+			map[string]interface{}{"name": "GatewayConfig", "type": map[string]interface{}{"kind": "struct", "fields": []interface{}{
+				map[string]interface{}{"name": "authority", "type": "publicKey"},
+				map[string]interface{}{"name": "feeRecipient", "type": "publicKey"},
+				map[string]interface{}{"name": "platformFeeBps", "type": "u16"},
+				map[string]interface{}{"name": "paused", "type": "bool"},
+			}}},
+			map[string]interface{}{"name": "BridgeRoute", "type": map[string]interface{}{"kind": "struct", "fields": []interface{}{
+				map[string]interface{}{"name": "destChainId", "type": "string"},
+				map[string]interface{}{"name": "bridgeType", "type": "u8"},
+				map[string]interface{}{"name": "destinationAdapter", "type": map[string]interface{}{"vec": "u8"}},
+				map[string]interface{}{"name": "enabled", "type": "bool"},
+			}}},
+		},
+	}
+	checks = u.runSolanaOnchainChecks(ctx, source, gateway(legacy), dest)
+	require.Equal(t, "PROGRAM_IDL_MATCHED", checks[1].Code)
+	require.Equal(t, "gateway program IDL payment_kita_gateway 0.2.1 matches the audited layout", checks[1].Message)
+
+	// A program whose IDL declares another layout, or none, is not decoded
+	changed := strings.Replace(solanaGatewayIDLFixture, `{"name": "platform_fee_bps", "type": "u16"}`, `{"name": "platform_fee_bps", "type": "u32"}`, 1)
+	checks = u.runSolanaOnchainChecks(ctx, source, gateway(changed), dest)
+	require.Equal(t, []string{"PROGRAM_DEPLOYED", "PROGRAM_IDL_MISMATCH"}, checkCodes(checks))
+	require.Contains(t, checks[1].Message, "payment_kita_gateway 0.3.0")
+	checks = u.runSolanaOnchainChecks(ctx, source, gateway(nil), dest)
+	require.Equal(t, []string{"PROGRAM_DEPLOYED", "PROGRAM_IDL_MISSING"}, checkCodes(checks))
+	checks = u.runSolanaOnchainChecks(ctx, source, gateway([]interface{}{map[string]interface{}{"type": "function"}}), dest)
+	require.Equal(t, []string{"PROGRAM_DEPLOYED", "PROGRAM_IDL_INVALID"}, checkCodes(checks))
+}
@@ -66,7 +66,8 @@ type ContractDetailAuditResult struct {
 type ContractConfigAuditUsecase struct {
 	chainRepo     repositories.ChainRepository
 	contractRepo  repositories.SmartContractRepository
-	clientFactory *blockchain.ClientFactory
+	clientFactory ClientFactory
+	solanaClients SolanaClientFactory
 	chainResolver *ChainResolver
 }
 
@@ -78,7 +79,8 @@ func NewContractConfigAuditUsecase(
 	return &ContractConfigAuditUsecase{
 		chainRepo:     chainRepo,
 		contractRepo:  contractRepo,
-		clientFactory: clientFactory,
+		clientFactory: NewEVMClientFactory(clientFactory),
+		solanaClients: NewSolanaClientFactory(clientFactory),
 		chainResolver: NewChainResolver(chainRepo),
 	}
 }
@@ -119,23 +121,11 @@ func (u *ContractConfigAuditUsecase) Check(ctx context.Context, sourceChainInput
 		}
 	}
 
-	contracts, _, err := u.contractRepo.GetFiltered(
-		ctx,
-		&sourceChainUUID,
-		entities.SmartContractType(""),
-		utils.PaginationParams{Page: 1, Limit: 0},
-	)
+	activeContracts, _, err := u.contractRepo.GetFiltered(ctx, repositories.ActiveContracts(&sourceChainUUID), utils.PaginationParams{Page: 1, Limit: 0})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list contracts: %w", err)
 	}
 
-	activeContracts := make([]*entities.SmartContract, 0, len(contracts))
-	for _, c := range contracts {
-		if c != nil && c.IsActive {
-			activeContracts = append(activeContracts, c)
-		}
-	}
-
 	if len(activeContracts) == 0 {
 		result.GlobalChecks = append(result.GlobalChecks, ContractConfigCheckItem{
 			Code:    "NO_ACTIVE_CONTRACTS",
@@ -151,10 +141,11 @@ func (u *ContractConfigAuditUsecase) Check(ctx context.Context, sourceChainInput
 		result.Contracts = append(result.Contracts, report)
 	}
 
-	if sourceChain.Type == entities.ChainTypeEVM && destCAIP2 != "" {
-		onchainChecks := u.runEVMOnchainChecks(ctx, sourceChain, activeContracts, destCAIP2)
-		result.GlobalChecks = append(result.GlobalChecks, onchainChecks...)
-		mergeSummary(result.Summary, onchainChecks)
+	if destCAIP2 != "" {
+		if onchainChecks, ok := u.runOnchainChecks(ctx, sourceChain, activeContracts, destCAIP2); ok {
+			result.GlobalChecks = append(result.GlobalChecks, onchainChecks...)
+			mergeSummary(result.Summary, onchainChecks)
+		}
 	}
 
 	result.OverallStatus = deriveOverallStatus(result.Summary)
@@ -194,13 +185,9 @@ func (u *ContractConfigAuditUsecase) CheckByContractID(ctx context.Context, cont
 		return nil, fmt.Errorf("failed to list chains: %w", err)
 	}
 
-	var activeContracts []*entities.SmartContract
-	if sourceContracts, _, listErr := u.contractRepo.GetFiltered(ctx, &contract.ChainUUID, entities.SmartContractType(""), utils.PaginationParams{Page: 1, Limit: 0}); listErr == nil {
-		for _, c := range sourceContracts {
-			if c != nil && c.IsActive {
-				activeContracts = append(activeContracts, c)
-			}
-		}
+	activeContracts, _, listErr := u.contractRepo.GetFiltered(ctx, repositories.ActiveContracts(&contract.ChainUUID), utils.PaginationParams{Page: 1, Limit: 0})
+	if listErr != nil {
+		activeContracts = nil
 	}
 
 	for _, ch := range chains {
@@ -218,14 +205,14 @@ func (u *ContractConfigAuditUsecase) CheckByContractID(ctx context.Context, cont
 			},
 		}
 
-		if sourceChain.Type != entities.ChainTypeEVM {
+		if onchainChecks, ok := u.runOnchainChecks(ctx, sourceChain, activeContracts, destCAIP2); ok {
+			destAudit.Checks = append(destAudit.Checks, onchainChecks...)
+		} else {
 			destAudit.Checks = append(destAudit.Checks, ContractConfigCheckItem{
 				Code:    "ONCHAIN_AUDIT_SKIPPED",
 				Status:  "WARN",
-				Message: "on-chain route audit currently supports EVM source chain only",
+				Message: "on-chain route audit currently supports EVM and Solana source chains only",
 			})
-		} else {
-			destAudit.Checks = append(destAudit.Checks, u.runEVMOnchainChecks(ctx, sourceChain, activeContracts, destCAIP2)...)
 		}
 		mergeSummary(destAudit.Summary, destAudit.Checks)
 		destAudit.OverallStatus = deriveOverallStatus(destAudit.Summary)
@@ -325,6 +312,24 @@ func (u *ContractConfigAuditUsecase) buildContractReport(contract *entities.Smar
 	}
 }
 
+// runOnchainChecks audits the source chain's route to destCAIP2 on-chain. ok is false for chain
+// types that have no on-chain audit.
+func (u *ContractConfigAuditUsecase) runOnchainChecks(
+	ctx context.Context,
+	sourceChain *entities.Chain,
+	contracts []*entities.SmartContract,
+	destCAIP2 string,
+) (checks []ContractConfigCheckItem, ok bool) {
+	switch sourceChain.Type {
+	case entities.ChainTypeEVM:
+		return u.runEVMOnchainChecks(ctx, sourceChain, contracts, destCAIP2), true
+	case entities.ChainTypeSVM:
+		return u.runSolanaOnchainChecks(ctx, sourceChain, contracts, destCAIP2), true
+	default:
+		return nil, false
+	}
+}
+
 func (u *ContractConfigAuditUsecase) runEVMOnchainChecks(
 	ctx context.Context,
 	sourceChain *entities.Chain,
@@ -362,6 +367,16 @@ func (u *ContractConfigAuditUsecase) runEVMOnchainChecks(
 	if router == nil {
 		checks = append(checks, ContractConfigCheckItem{Code: "ROUTER_MISSING", Status: "ERROR", Message: "active router contract is missing"})
 	}
+	if gateway != nil {
+		// Gateways without an emergency stop revert on paused(); there is nothing to report then
+		paused, err := readGatewayPaused(ctx, client, gateway.ContractAddress)
+		switch {
+		case err == nil && paused:
+			checks = append(checks, ContractConfigCheckItem{Code: "GATEWAY_PAUSED", Status: "WARN", Message: "gateway contract is paused", Contract: gateway.Name})
+		case err != nil && !isExecutionFailure(err):
+			checks = append(checks, ContractConfigCheckItem{Code: "GATEWAY_PAUSED_READ_FAILED", Status: "WARN", Message: "failed to read gateway pause state", Contract: gateway.Name})
+		}
+	}
 	if gateway == nil || router == nil {
 		return checks
 	}
@@ -640,7 +655,7 @@ func parseABI(raw string) (abi.ABI, error) {
 	return abi.JSON(strings.NewReader(raw))
 }
 
-func callBoolView(ctx context.Context, client *blockchain.EVMClient, contractAddress, rawABI, method string, args ...interface{}) (bool, error) {
+func callBoolView(ctx context.Context, client EVMClient, contractAddress, rawABI, method string, args ...interface{}) (bool, error) {
 	parsed, err := parseABI(rawABI)
 	if err != nil {
 		return false, err
@@ -664,7 +679,7 @@ func callBoolView(ctx context.Context, client *blockchain.EVMClient, contractAdd
 	return value, nil
 }
 
-func callUint8View(ctx context.Context, client *blockchain.EVMClient, contractAddress, rawABI, method string, args ...interface{}) (uint8, error) {
+func callUint8View(ctx context.Context, client EVMClient, contractAddress, rawABI, method string, args ...interface{}) (uint8, error) {
 	parsed, err := parseABI(rawABI)
 	if err != nil {
 		return 0, err
@@ -688,7 +703,7 @@ func callUint8View(ctx context.Context, client *blockchain.EVMClient, contractAd
 	return value, nil
 }
 
-func callUint64View(ctx context.Context, client *blockchain.EVMClient, contractAddress, rawABI, method string, args ...interface{}) (uint64, error) {
+func callUint64View(ctx context.Context, client EVMClient, contractAddress, rawABI, method string, args ...interface{}) (uint64, error) {
 	parsed, err := parseABI(rawABI)
 	if err != nil {
 		return 0, err
@@ -712,7 +727,7 @@ func callUint64View(ctx context.Context, client *blockchain.EVMClient, contractA
 	return value, nil
 }
 
-func callAddressView(ctx context.Context, client *blockchain.EVMClient, contractAddress, rawABI, method string, args ...interface{}) (common.Address, error) {
+func callAddressView(ctx context.Context, client EVMClient, contractAddress, rawABI, method string, args ...interface{}) (common.Address, error) {
 	parsed, err := parseABI(rawABI)
 	if err != nil {
 		return common.Address{}, err
@@ -736,7 +751,7 @@ func callAddressView(ctx context.Context, client *blockchain.EVMClient, contract
 	return value, nil
 }
 
-func callBytesView(ctx context.Context, client *blockchain.EVMClient, contractAddress, rawABI, method string, args ...interface{}) ([]byte, error) {
+func callBytesView(ctx context.Context, client EVMClient, contractAddress, rawABI, method string, args ...interface{}) ([]byte, error) {
 	parsed, err := parseABI(rawABI)
 	if err != nil {
 		return nil, err
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/repositories"
 	uc "payment-kita.backend/internal/usecases"
 	"payment-kita.backend/pkg/utils"
 )
@@ -47,7 +48,7 @@ func TestContractConfigAuditUsecase_Check_NoActiveContractWarn(t *testing.T) {
 
 	chainRepo.On("GetByCAIP2", mock.Anything, "eip155:8453").Return(chain, nil)
 	chainRepo.On("GetByID", mock.Anything, id).Return(chain, nil)
-	contractRepo.On("GetFiltered", mock.Anything, &id, entities.SmartContractType(""), utils.PaginationParams{Page: 1, Limit: 0}).Return([]*entities.SmartContract{}, int64(0), nil)
+	contractRepo.On("GetFiltered", mock.Anything, repositories.ActiveContracts(&id), utils.PaginationParams{Page: 1, Limit: 0}).Return([]*entities.SmartContract{}, int64(0), nil)
 
 	u := uc.NewContractConfigAuditUsecase(chainRepo, contractRepo, nil)
 	res, err := u.Check(context.Background(), "eip155:8453", "")
@@ -60,21 +61,21 @@ func TestContractConfigAuditUsecase_Check_NoActiveContractWarn(t *testing.T) {
 	contractRepo.AssertExpectations(t)
 }
 
-func TestContractConfigAuditUsecase_CheckByContractID_NonEvmSkipsOnchain(t *testing.T) {
+func TestContractConfigAuditUsecase_CheckByContractID_UnsupportedChainSkipsOnchain(t *testing.T) {
 	chainRepo := new(MockChainRepository)
 	contractRepo := new(MockSmartContractRepository)
 
 	sourceID := uuid.New()
 	destID := uuid.New()
 	contractID := uuid.New()
-	source := &entities.Chain{ID: sourceID, ChainID: "mainnet", Type: entities.ChainTypeSVM, IsActive: true, Name: "Solana"}
+	source := &entities.Chain{ID: sourceID, ChainID: "polkadot", Type: entities.ChainTypeSubstrate, IsActive: true, Name: "Polkadot"}
 	dest := &entities.Chain{ID: destID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true, Name: "Base"}
 	contract := &entities.SmartContract{ID: contractID, Name: "Gateway", Type: entities.ContractTypeGateway, ChainUUID: sourceID, ContractAddress: "0xabc", IsActive: true}
 
 	contractRepo.On("GetByID", mock.Anything, contractID).Return(contract, nil)
 	chainRepo.On("GetByID", mock.Anything, sourceID).Return(source, nil)
 	chainRepo.On("GetAll", mock.Anything).Return([]*entities.Chain{source, dest}, nil)
-	contractRepo.On("GetFiltered", mock.Anything, &sourceID, entities.SmartContractType(""), utils.PaginationParams{Page: 1, Limit: 0}).Return([]*entities.SmartContract{contract}, int64(1), nil)
+	contractRepo.On("GetFiltered", mock.Anything, repositories.ActiveContracts(&sourceID), utils.PaginationParams{Page: 1, Limit: 0}).Return([]*entities.SmartContract{contract}, int64(1), nil)
 
 	u := uc.NewContractConfigAuditUsecase(chainRepo, contractRepo, nil)
 	res, err := u.CheckByContractID(context.Background(), contractID)
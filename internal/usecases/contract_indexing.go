@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/crypto"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// ContractIndexedEvent is one event the external indexer follows on a contract
+type ContractIndexedEvent struct {
+	Name      string `json:"name"`
+	Signature string `json:"signature"`
+	Topic0    string `json:"topic0"`
+}
+
+// ResolveIndexedEvents checks every requested event against rawABI and returns their canonical
+// signatures (e.g. Transfer(address,address,uint256)) in request order, without duplicates. An
+// event may be named by signature, or by name when the ABI has one event of that name.
+func ResolveIndexedEvents(rawABI interface{}, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return []string{}, nil
+	}
+	raw, err := json.Marshal(rawABI)
+	if err != nil {
+		return nil, domainerrors.BadRequest("contract ABI is invalid")
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(raw)))
+	if err != nil {
+		return nil, domainerrors.BadRequest("contract ABI is invalid: " + err.Error())
+	}
+
+	bySignature := make(map[string]string, len(parsed.Events))
+	byName := make(map[string][]string, len(parsed.Events))
+	for _, event := range parsed.Events {
+		if event.Anonymous {
+			// Anonymous events have no topic0 to filter on
+			continue
+		}
+		bySignature[event.Sig] = event.Sig
+		byName[event.RawName] = append(byName[event.RawName], event.Sig)
+	}
+
+	signatures := make([]string, 0, len(requested))
+	seen := make(map[string]struct{}, len(requested))
+	var unknown, ambiguous []string
+	for _, item := range requested {
+		item = strings.Join(strings.Fields(item), "")
+		if item == "" {
+			continue
+		}
+		var signature string
+		if strings.Contains(item, "(") {
+			signature = bySignature[item]
+		} else if matches := byName[item]; len(matches) == 1 {
+			signature = matches[0]
+		} else if len(matches) > 1 {
+			sort.Strings(matches)
+			ambiguous = append(ambiguous, fmt.Sprintf("%s (%s)", item, strings.Join(matches, ", ")))
+			continue
+		}
+		if signature == "" {
+			unknown = append(unknown, item)
+			continue
+		}
+		if _, dup := seen[signature]; dup {
+			continue
+		}
+		seen[signature] = struct{}{}
+		signatures = append(signatures, signature)
+	}
+	if len(unknown) > 0 {
+		return nil, domainerrors.BadRequest("events not in the contract ABI: " + strings.Join(unknown, ", "))
+	}
+	if len(ambiguous) > 0 {
+		return nil, domainerrors.BadRequest("overloaded events must be given by signature: " + strings.Join(ambiguous, "; "))
+	}
+	return signatures, nil
+}
+
+// DescribeIndexedEvents expands canonical event signatures with their name and topic0
+func DescribeIndexedEvents(signatures []string) []ContractIndexedEvent {
+	events := make([]ContractIndexedEvent, 0, len(signatures))
+	for _, signature := range signatures {
+		name := signature
+		if i := strings.Index(signature, "("); i >= 0 {
+			name = signature[:i]
+		}
+		events = append(events, ContractIndexedEvent{
+			Name:      name,
+			Signature: signature,
+			Topic0:    crypto.Keccak256Hash([]byte(signature)).Hex(),
+		})
+	}
+	return events
+}
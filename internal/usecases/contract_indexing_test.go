@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestResolveIndexedEvents(t *testing.T) {
+	var contractABI interface{}
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"type":"event","name":"PaymentCreated","inputs":[{"name":"id","type":"bytes32","indexed":true},{"name":"payer","type":"address","indexed":false}]},
+		{"type":"event","name":"Settled","inputs":[{"name":"id","type":"bytes32","indexed":true}]},
+		{"type":"event","name":"Settled","inputs":[{"name":"id","type":"bytes32","indexed":true},{"name":"amount","type":"uint256","indexed":false}]},
+		{"type":"function","name":"pay","inputs":[],"outputs":[]}
+	]`), &contractABI))
+
+	signatures, err := ResolveIndexedEvents(contractABI, []string{"PaymentCreated", "Settled(bytes32, uint256)", "PaymentCreated(bytes32,address)"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"PaymentCreated(bytes32,address)", "Settled(bytes32,uint256)"}, signatures)
+
+	var appErr *domainerrors.AppError
+	_, err = ResolveIndexedEvents(contractABI, []string{"Settled"})
+	require.ErrorAs(t, err, &appErr)
+	require.Contains(t, appErr.Message, "by signature")
+
+	_, err = ResolveIndexedEvents(contractABI, []string{"pay", "Refunded(bytes32)"})
+	require.ErrorAs(t, err, &appErr)
+	require.Contains(t, appErr.Message, "pay, Refunded(bytes32)")
+
+	signatures, err = ResolveIndexedEvents(nil, []string{})
+	require.NoError(t, err)
+	require.Empty(t, signatures)
+
+	events := DescribeIndexedEvents([]string{"Transfer(address,address,uint256)"})
+	require.Equal(t, "Transfer", events[0].Name)
+	require.Equal(t, crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")).Hex(), events[0].Topic0)
+}
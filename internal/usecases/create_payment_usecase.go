@@ -113,6 +113,8 @@ type CreatePaymentUsecase struct {
 	quoteUC        createPaymentQuoteEngine
 	sessionUC      createPaymentSessionEngine
 	chainResolver  *ChainResolver
+	// receiverAllowlist, when set, restricts the wallet payments settle to (see checkReceiverAllowed)
+	receiverAllowlist domainrepos.MerchantAllowedReceiverRepository
 }
 
 type merchantCreatePaymentConfig struct {
@@ -157,6 +159,25 @@ func NewCreatePaymentUsecase(
 	}
 }
 
+// NewCreatePaymentUsecaseWithReceiverAllowlist is NewCreatePaymentUsecase that refuses to settle
+// to a wallet missing from the merchant's receiver allow-list
+func NewCreatePaymentUsecaseWithReceiverAllowlist(
+	merchantRepo domainrepos.MerchantRepository,
+	settlementRepo domainrepos.MerchantSettlementProfileRepository,
+	walletRepo domainrepos.WalletRepository,
+	tokenRepo domainrepos.TokenRepository,
+	chainRepo domainrepos.ChainRepository,
+	quoteRepo domainrepos.PaymentQuoteRepository,
+	sessionRepo domainrepos.PartnerPaymentSessionRepository,
+	quoteUC createPaymentQuoteEngine,
+	sessionUC createPaymentSessionEngine,
+	receiverAllowlist domainrepos.MerchantAllowedReceiverRepository,
+) *CreatePaymentUsecase {
+	u := NewCreatePaymentUsecase(merchantRepo, settlementRepo, walletRepo, tokenRepo, chainRepo, quoteRepo, sessionRepo, quoteUC, sessionUC)
+	u.receiverAllowlist = receiverAllowlist
+	return u
+}
+
 func (u *CreatePaymentUsecase) CreatePayment(ctx context.Context, input *CreatePaymentInput) (*CreatePaymentOutput, error) {
 	startedAt := time.Now()
 	ctx = withQuoteRequestCache(ctx)
@@ -225,6 +246,9 @@ func (u *CreatePaymentUsecase) CreatePayment(ctx context.Context, input *CreateP
 	if err != nil {
 		return nil, domainerrors.BadRequest(fmt.Sprintf("invalid chain_id: %v", err))
 	}
+	if err := u.checkPaymentChainsActive(ctx, chainUUID, settlement.DestChainID); err != nil {
+		return nil, err
+	}
 	selectedToken, err := u.tokenRepo.GetByAddress(ctx, strings.TrimSpace(input.SelectedToken), chainUUID)
 	if err != nil || selectedToken == nil || !selectedToken.IsActive {
 		return nil, domainerrors.BadRequest("selected_token not supported on chain_id")
@@ -233,6 +257,11 @@ func (u *CreatePaymentUsecase) CreatePayment(ctx context.Context, input *CreateP
 	if err != nil {
 		return nil, err
 	}
+	// The settlement profile can be rewritten after the allow-list was set up, so check the
+	// wallet the payment will actually settle to
+	if err := checkReceiverAllowed(ctx, u.receiverAllowlist, &merchant.ID, walletAddress); err != nil {
+		return nil, err
+	}
 	now := time.Now().UTC()
 	expiresAt, isUnlimitedExpiry, err := resolveCreatePaymentExpiresAt(strings.TrimSpace(input.ExpiresIn), now)
 	if err != nil {
@@ -524,7 +553,7 @@ func readInstructionProgramID(session *entities.PartnerPaymentSession) string {
 	if session == nil {
 		return ""
 	}
-	if strings.HasPrefix(strings.ToLower(session.DestChain), "solana:") {
+	if entities.ChainTypeFromCAIP2(session.DestChain).IsSVM() {
 		if strings.TrimSpace(session.InstructionTo) != "" {
 			return strings.TrimSpace(session.InstructionTo)
 		}
@@ -1211,3 +1240,18 @@ func quoteRateFromAtomicAmounts(quotedAmount string, quotedDecimals int, invoice
 	}
 	return formatNormalizedTokenRatio(quoted, quotedDecimals, invoice, invoiceDecimals, 18)
 }
+
+// checkPaymentChainsActive refuses a payment whose source chain or settlement chain was
+// deactivated, before a quote or session is created for it
+func (u *CreatePaymentUsecase) checkPaymentChainsActive(ctx context.Context, chainIDs ...uuid.UUID) error {
+	for _, chainID := range chainIDs {
+		chain, err := u.chainRepo.GetByID(ctx, chainID)
+		if err != nil {
+			return domainerrors.BadRequest(fmt.Sprintf("invalid chain_id: %v", err))
+		}
+		if err := checkChainsActive(chain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -65,7 +65,7 @@ func TestCrosschainConfigUsecase_CheckFeeQuoteHealth_Matrix(t *testing.T) {
 	u := &CrosschainConfigUsecase{
 		contractRepo:  contractRepo,
 		tokenRepo:     tokenRepo,
-		clientFactory: factory,
+		clientFactory: NewEVMClientFactory(factory),
 	}
 
 	require.False(t, u.checkFeeQuoteHealth(context.Background(), nil, dest, 0))
@@ -76,7 +76,7 @@ func TestCrosschainConfigUsecase_CheckFeeQuoteHealth_Matrix(t *testing.T) {
 	uNoRouter := &CrosschainConfigUsecase{
 		contractRepo:  contractRepoNoRouter,
 		tokenRepo:     tokenRepo,
-		clientFactory: factory,
+		clientFactory: NewEVMClientFactory(factory),
 	}
 	require.False(t, uNoRouter.checkFeeQuoteHealth(context.Background(), source, dest, 0))
 
@@ -90,7 +90,7 @@ func TestCrosschainConfigUsecase_CheckFeeQuoteHealth_Matrix(t *testing.T) {
 	uErr := &CrosschainConfigUsecase{
 		contractRepo:  contractRepo,
 		tokenRepo:     tokenRepo,
-		clientFactory: factoryErr,
+		clientFactory: NewEVMClientFactory(factoryErr),
 	}
 	require.False(t, uErr.checkFeeQuoteHealth(context.Background(), source, dest, 0))
 
@@ -104,7 +104,7 @@ func TestCrosschainConfigUsecase_CheckFeeQuoteHealth_Matrix(t *testing.T) {
 	uEmpty := &CrosschainConfigUsecase{
 		contractRepo:  contractRepo,
 		tokenRepo:     tokenRepo,
-		clientFactory: factoryEmpty,
+		clientFactory: NewEVMClientFactory(factoryEmpty),
 	}
 	require.False(t, uEmpty.checkFeeQuoteHealth(context.Background(), source, dest, 0))
 }
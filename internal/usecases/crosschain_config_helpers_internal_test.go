@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -34,9 +35,13 @@ func (s *ccfgChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool, *stri
 func (s *ccfgChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *ccfgChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *ccfgChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *ccfgChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *ccfgChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *ccfgChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *ccfgChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *ccfgChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *ccfgChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -61,9 +66,15 @@ func (s *ccfgContractRepoStub) GetActiveContract(ctx context.Context, chainID uu
 	}
 	return nil, domainerrors.ErrNotFound
 }
-func (s *ccfgContractRepoStub) GetFiltered(context.Context, *uuid.UUID, entities.SmartContractType, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+func (s *ccfgContractRepoStub) GetFiltered(context.Context, repositories.SmartContractFilter, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
+func (s *ccfgContractRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
+func (s *ccfgContractRepoStub) Activate(context.Context, uuid.UUID) (*entities.SmartContract, error) {
+	return nil, nil
+}
 func (s *ccfgContractRepoStub) GetByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
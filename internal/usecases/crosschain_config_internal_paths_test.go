@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/infrastructure/blockchain"
 	"payment-kita.backend/pkg/utils"
 )
@@ -48,11 +49,21 @@ func (s *ccChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool, *string
 	return nil, 0, nil
 }
 func (s *ccChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	var active []*entities.Chain
+	for _, ch := range s.allChain {
+		if ch.IsActive {
+			active = append(active, ch)
+		}
+	}
+	return active, int64(len(active)), nil
+}
+func (s *ccChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return s.allChain, int64(len(s.allChain)), nil
 }
 func (s *ccChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *ccChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *ccChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *ccChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *ccChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *ccChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *ccChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -74,6 +85,7 @@ func (s *ccTokenRepoStub) GetByAddress(context.Context, string, uuid.UUID) (*ent
 	return nil, domainerrors.ErrNotFound
 }
 func (s *ccTokenRepoStub) GetAll(context.Context) ([]*entities.Token, error)         { return nil, nil }
+func (s *ccTokenRepoStub) GetActiveBySymbol(context.Context, string) ([]*entities.Token, error) { return nil, nil }
 func (s *ccTokenRepoStub) GetStablecoins(context.Context) ([]*entities.Token, error) { return nil, nil }
 func (s *ccTokenRepoStub) GetNative(context.Context, uuid.UUID) (*entities.Token, error) {
 	return nil, domainerrors.ErrNotFound
@@ -82,12 +94,16 @@ func (s *ccTokenRepoStub) GetTokensByChain(_ context.Context, chainID uuid.UUID,
 	items := s.byChain[chainID]
 	return items, int64(len(items)), nil
 }
-func (s *ccTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, utils.PaginationParams) ([]*entities.Token, int64, error) {
+func (s *ccTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, bool, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
+func (s *ccTokenRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
 func (s *ccTokenRepoStub) Create(context.Context, *entities.Token) error { return nil }
 func (s *ccTokenRepoStub) Update(context.Context, *entities.Token) error { return nil }
 func (s *ccTokenRepoStub) SoftDelete(context.Context, uuid.UUID) error   { return nil }
+func (s *ccTokenRepoStub) HardDelete(context.Context, uuid.UUID) error   { return nil }
 
 type ccContractRepoStub struct {
 	active map[string]*entities.SmartContract
@@ -110,9 +126,15 @@ func (s *ccContractRepoStub) GetActiveContract(_ context.Context, chainID uuid.U
 	}
 	return nil, domainerrors.ErrNotFound
 }
-func (s *ccContractRepoStub) GetFiltered(context.Context, *uuid.UUID, entities.SmartContractType, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+func (s *ccContractRepoStub) GetFiltered(context.Context, repositories.SmartContractFilter, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
+func (s *ccContractRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
+func (s *ccContractRepoStub) Activate(context.Context, uuid.UUID) (*entities.SmartContract, error) {
+	return nil, nil
+}
 func (s *ccContractRepoStub) GetByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
@@ -97,6 +97,52 @@ func TestCrosschainConfigUsecase_Preflight_WithAdapterStub(t *testing.T) {
 	require.Equal(t, "CCIP_NOT_CONFIGURED", res.Bridges[1].ErrorCode)
 }
 
+func TestCrosschainConfigUsecase_PreflightBridges(t *testing.T) {
+	sourceID := uuid.New()
+	destID := uuid.New()
+	srcChain := &entities.Chain{ID: sourceID, ChainID: "8453", Name: "Base", Type: entities.ChainTypeEVM, IsActive: true}
+	dstChain := &entities.Chain{ID: destID, ChainID: "42161", Name: "Arbitrum", Type: entities.ChainTypeEVM, IsActive: true}
+	chainRepo := &ccChainRepoStub{
+		byID:     map[uuid.UUID]*entities.Chain{sourceID: srcChain, destID: dstChain},
+		byChain:  map[string]*entities.Chain{},
+		byCAIP2:  map[string]*entities.Chain{},
+		allChain: []*entities.Chain{srcChain, dstChain},
+	}
+	adapter := &crosschainAdapterStub{
+		statusFn: func(context.Context, string, string) (*OnchainAdapterStatus, error) {
+			return &OnchainAdapterStatus{
+				DefaultBridgeType:                 0,
+				HasAdapterType1:                   true,
+				AdapterType1:                      "0x2222222222222222222222222222222222222222",
+				HasAdapterType3:                   true,
+				AdapterType3:                      "0x3333333333333333333333333333333333333333",
+				HyperbridgeTokenGatewayConfigured: false,
+			}, nil
+		},
+	}
+	u := NewCrosschainConfigUsecase(chainRepo, &ccTokenRepoStub{byChain: map[uuid.UUID][]*entities.Token{}}, &ccContractRepoStub{active: map[string]*entities.SmartContract{}}, nil, adapter)
+
+	rows, err := u.PreflightBridges(context.Background(), sourceID.String(), destID.String(), []uint8{3, 2, 1})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, []uint8{3, 2, 1}, []uint8{rows[0].BridgeType, rows[1].BridgeType, rows[2].BridgeType})
+	require.Equal(t, "HYPERBRIDGE_TOKEN_GATEWAY_NOT_CONFIGURED", rows[0].ErrorCode)
+	require.Equal(t, "ADAPTER_NOT_REGISTERED", rows[1].ErrorCode)
+	require.Equal(t, "CCIP_NOT_CONFIGURED", rows[2].ErrorCode)
+	for _, row := range rows {
+		require.False(t, row.Ready)
+	}
+
+	_, err = u.PreflightBridges(context.Background(), uuid.NewString(), destID.String(), []uint8{1})
+	require.Error(t, err)
+
+	adapter.statusFn = func(context.Context, string, string) (*OnchainAdapterStatus, error) {
+		return nil, errors.New("rpc down")
+	}
+	_, err = u.PreflightBridges(context.Background(), sourceID.String(), destID.String(), []uint8{1})
+	require.Error(t, err)
+}
+
 func TestCrosschainConfigUsecase_AutoFix_WithAdapterStub(t *testing.T) {
 	sourceID := uuid.New()
 	destID := uuid.New()
@@ -409,7 +409,7 @@ func TestCrosschainConfigUsecase_Overview_RecheckFailurePath(t *testing.T) {
 	chainRepo := new(MockChainRepository)
 	tokenRepo := new(MockTokenRepository)
 	contractRepo := new(MockSmartContractRepository)
-	chainRepo.On("GetAll", mock.Anything).Return([]*entities.Chain{source, dest}, nil)
+	chainRepo.On("GetActive", mock.Anything, utils.PaginationParams{}).Return([]*entities.Chain{source, dest}, int64(2), nil)
 	chainRepo.On("GetByCAIP2", mock.Anything, "eip155:8453").Return(source, nil)
 	chainRepo.On("GetByCAIP2", mock.Anything, "eip155:42161").Return(dest, nil)
 	chainRepo.On("GetByID", mock.Anything, source.ID).Return(source, nil)
@@ -127,7 +127,7 @@ func TestCrosschainConfigUsecase_RecheckRoute_StatusAndFeeQuoteIssue(t *testing.
 	chainRepo.On("GetByCAIP2", mock.Anything, "eip155:42161").Return(dest, nil)
 	chainRepo.On("GetByID", mock.Anything, sourceID).Return(source, nil)
 	chainRepo.On("GetByID", mock.Anything, destID).Return(dest, nil)
-	chainRepo.On("GetAll", mock.Anything).Return([]*entities.Chain{source, dest}, nil)
+	chainRepo.On("GetActive", mock.Anything, utils.PaginationParams{}).Return([]*entities.Chain{source, dest}, int64(2), nil)
 
 	contractRepo.On("GetActiveContract", mock.Anything, sourceID, entities.ContractTypeGateway).Return(gateway, nil)
 	contractRepo.On("GetActiveContract", mock.Anything, sourceID, entities.ContractTypeRouter).Return(router, nil)
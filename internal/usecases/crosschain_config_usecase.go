@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -35,6 +36,11 @@ type CrosschainRouteStatus struct {
 	QuoteFailureReason    string                    `json:"quoteFailureReason,omitempty"`
 	OverallStatus         string                    `json:"overallStatus"`
 	Issues                []ContractConfigCheckItem `json:"issues"`
+	// Set from the status store: the overall status before the last change and when the route
+	// was last checked / last changed state
+	PreviousStatus  string     `json:"previousStatus,omitempty"`
+	CheckedAt       *time.Time `json:"checkedAt,omitempty"`
+	StatusChangedAt *time.Time `json:"statusChangedAt,omitempty"`
 }
 
 type CrosschainBridgePreflight struct {
@@ -89,10 +95,11 @@ type CrosschainConfigUsecase struct {
 	chainRepo      repositories.ChainRepository
 	tokenRepo      repositories.TokenRepository
 	contractRepo   repositories.SmartContractRepository
-	clientFactory  *blockchain.ClientFactory
+	clientFactory  ClientFactory
 	chainResolver  *ChainResolver
 	adapterUsecase CrosschainAdapterUsecase
 	feeQuoteHealth func(ctx context.Context, sourceChain, destChain *entities.Chain, bridgeType uint8) bool
+	statusRepo     repositories.CrosschainRouteStatusRepository // optional; nil disables persistence
 }
 
 type CrosschainAdapterUsecase interface {
@@ -117,7 +124,7 @@ func NewCrosschainConfigUsecase(
 		chainRepo:      chainRepo,
 		tokenRepo:      tokenRepo,
 		contractRepo:   contractRepo,
-		clientFactory:  clientFactory,
+		clientFactory:  NewEVMClientFactory(clientFactory),
 		chainResolver:  NewChainResolver(chainRepo),
 		adapterUsecase: adapterUsecase,
 	}
@@ -128,7 +135,7 @@ func (u *CrosschainConfigUsecase) Overview(
 	sourceChainInput, destChainInput string,
 	pagination utils.PaginationParams,
 ) (*CrosschainOverview, error) {
-	chains, err := u.chainRepo.GetAll(ctx)
+	chains, _, err := u.chainRepo.GetActive(ctx, utils.PaginationParams{})
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +155,7 @@ func (u *CrosschainConfigUsecase) Overview(
 		sourceChains = append(sourceChains, sourceChain)
 	} else {
 		for _, ch := range chains {
-			if ch != nil && ch.IsActive && ch.Type == entities.ChainTypeEVM {
+			if ch != nil && ch.Type == entities.ChainTypeEVM {
 				sourceChains = append(sourceChains, ch)
 			}
 		}
@@ -166,7 +173,7 @@ func (u *CrosschainConfigUsecase) Overview(
 		destChains = append(destChains, destChain)
 	} else {
 		for _, ch := range chains {
-			if ch != nil && ch.IsActive {
+			if ch != nil {
 				destChains = append(destChains, ch)
 			}
 		}
@@ -180,7 +187,7 @@ func (u *CrosschainConfigUsecase) Overview(
 			}
 			status, statusErr := u.RecheckRoute(ctx, source.GetCAIP2ID(), dest.GetCAIP2ID())
 			if statusErr != nil {
-				routes = append(routes, CrosschainRouteStatus{
+				failed := CrosschainRouteStatus{
 					RouteKey:        source.GetCAIP2ID() + "->" + dest.GetCAIP2ID(),
 					SourceChainID:   source.GetCAIP2ID(),
 					SourceChainName: source.Name,
@@ -190,13 +197,19 @@ func (u *CrosschainConfigUsecase) Overview(
 					Issues: []ContractConfigCheckItem{
 						{Code: "RECHECK_FAILED", Status: "ERROR", Message: statusErr.Error()},
 					},
-				})
+				}
+				u.recordRouteStatus(ctx, &failed)
+				routes = append(routes, failed)
 				continue
 			}
 			routes = append(routes, *status)
 		}
 	}
 
+	return paginateRouteStatuses(routes, pagination), nil
+}
+
+func paginateRouteStatuses(routes []CrosschainRouteStatus, pagination utils.PaginationParams) *CrosschainOverview {
 	total := int64(len(routes))
 	start := pagination.CalculateOffset()
 	if start > len(routes) {
@@ -210,7 +223,7 @@ func (u *CrosschainConfigUsecase) Overview(
 	return &CrosschainOverview{
 		Items: routes[start:end],
 		Meta:  utils.CalculateMeta(total, pagination.Page, pagination.Limit),
-	}, nil
+	}
 }
 
 func (u *CrosschainConfigUsecase) RecheckRoute(ctx context.Context, sourceChainInput, destChainInput string) (*CrosschainRouteStatus, error) {
@@ -296,7 +309,7 @@ func (u *CrosschainConfigUsecase) RecheckRoute(ctx context.Context, sourceChainI
 		overall = "ERROR"
 	}
 
-	return &CrosschainRouteStatus{
+	result := &CrosschainRouteStatus{
 		RouteKey:              sourceCAIP2 + "->" + destCAIP2,
 		SourceChainID:         sourceCAIP2,
 		SourceChainName:       sourceChain.Name,
@@ -314,7 +327,9 @@ func (u *CrosschainConfigUsecase) RecheckRoute(ctx context.Context, sourceChainI
 		QuoteFailureReason:    strings.TrimSpace(feeQuoteReason),
 		OverallStatus:         overall,
 		Issues:                issues,
-	}, nil
+	}
+	u.recordRouteStatus(ctx, result)
+	return result, nil
 }
 
 func (u *CrosschainConfigUsecase) Preflight(ctx context.Context, sourceChainInput, destChainInput string) (*CrosschainPreflightResult, error) {
@@ -368,6 +383,29 @@ func (u *CrosschainConfigUsecase) Preflight(ctx context.Context, sourceChainInpu
 	}, nil
 }
 
+// PreflightBridges runs the preflight checks for the given bridge types only, in the order given,
+// so a route policy can be checked before it is saved
+func (u *CrosschainConfigUsecase) PreflightBridges(ctx context.Context, sourceChainInput, destChainInput string, bridgeTypes []uint8) ([]CrosschainBridgePreflight, error) {
+	sourceChain, err := u.chainResolver.ResolveChain(ctx, sourceChainInput)
+	if err != nil {
+		return nil, domainerrors.BadRequest("invalid sourceChainId")
+	}
+	destChain, err := u.chainResolver.ResolveChain(ctx, destChainInput)
+	if err != nil {
+		return nil, domainerrors.BadRequest("invalid destChainId")
+	}
+	status, err := u.adapterUsecase.GetStatus(ctx, sourceChainInput, destChainInput)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]CrosschainBridgePreflight, 0, len(bridgeTypes))
+	for _, bt := range bridgeTypes {
+		rows = append(rows, u.buildPreflightRow(ctx, sourceChain, destChain, status, bt))
+	}
+	return rows, nil
+}
+
 func (u *CrosschainConfigUsecase) buildPreflightRow(
 	ctx context.Context,
 	sourceChain, destChain *entities.Chain,
@@ -395,6 +433,9 @@ func (u *CrosschainConfigUsecase) buildPreflightRow(
 	case 2:
 		hasAdapter = status.HasAdapterType2 && status.AdapterType2 != "" && status.AdapterType2 != "0x0000000000000000000000000000000000000000"
 		row.Checks["routeConfigured"] = status.StargateConfigured
+	case 3:
+		hasAdapter = status.HasAdapterType3 && status.AdapterType3 != "" && status.AdapterType3 != "0x0000000000000000000000000000000000000000"
+		row.Checks["routeConfigured"] = status.HyperbridgeTokenGatewayConfigured
 	}
 	row.Checks["adapterRegistered"] = hasAdapter
 	feeQuoteReason := ""
@@ -425,6 +466,9 @@ func (u *CrosschainConfigUsecase) buildPreflightRow(
 		case 2:
 			row.ErrorCode = "STARGATE_NOT_CONFIGURED"
 			row.ErrorMessage = "missing dstEid or peer"
+		case 3:
+			row.ErrorCode = "HYPERBRIDGE_TOKEN_GATEWAY_NOT_CONFIGURED"
+			row.ErrorMessage = "missing state machine ID or settlement executor"
 		}
 		return row
 	}
@@ -447,6 +491,11 @@ func (u *CrosschainConfigUsecase) AutoFix(ctx context.Context, req *AutoFixReque
 	if req.BridgeType != nil {
 		bridgeType = *req.BridgeType
 	}
+	release, err := u.lockAutoFix(ctx, req.SourceChainID, req.DestChainID, bridgeType)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
 	result := &AutoFixResult{
 		SourceChainID: req.SourceChainID,
@@ -694,7 +743,7 @@ func (u *CrosschainConfigUsecase) checkFeeQuoteHealthWithReason(
 
 func (u *CrosschainConfigUsecase) checkFeeQuoteHealthWithReasonOnClient(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	sourceChain, destChain *entities.Chain,
 	bridgeType uint8,
 	routerAddress string,
@@ -1137,7 +1186,7 @@ func summarizeFeeQuoteRPCFailures(errors []string) string {
 
 func (u *CrosschainConfigUsecase) checkAdapterRuntimeReadiness(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	sourceChain *entities.Chain,
 	destCAIP2 string,
 	routerAddress string,
@@ -1193,7 +1242,7 @@ func (u *CrosschainConfigUsecase) checkAdapterRuntimeReadiness(
 
 func (u *CrosschainConfigUsecase) callRouterAdapter(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	routerAddress, destCAIP2 string,
 	bridgeType uint8,
 ) (common.Address, error) {
@@ -1226,7 +1275,7 @@ func (u *CrosschainConfigUsecase) callRouterAdapter(
 
 func (u *CrosschainConfigUsecase) callAddressView(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	contractAddress, signature string,
 ) (common.Address, error) {
 	methodID := crypto.Keccak256([]byte(signature))[:4]
@@ -1242,7 +1291,7 @@ func (u *CrosschainConfigUsecase) callAddressView(
 
 func (u *CrosschainConfigUsecase) callVaultAuthorizedSpender(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	vaultAddress string,
 	spender common.Address,
 ) (bool, error) {
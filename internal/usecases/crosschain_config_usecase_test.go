@@ -17,7 +17,7 @@ func TestCrosschainConfigUsecase_Overview_GetAllError(t *testing.T) {
 	chainRepo := new(MockChainRepository)
 	tokenRepo := new(MockTokenRepository)
 	contractRepo := new(MockSmartContractRepository)
-	chainRepo.On("GetAll", mock.Anything).Return(([]*entities.Chain)(nil), errors.New("db failed"))
+	chainRepo.On("GetActive", mock.Anything, utils.PaginationParams{}).Return(([]*entities.Chain)(nil), int64(0), errors.New("db failed"))
 
 	u := uc.NewCrosschainConfigUsecase(chainRepo, tokenRepo, contractRepo, nil, &uc.OnchainAdapterUsecase{})
 	_, err := u.Overview(context.Background(), "", "", utils.PaginationParams{Page: 1, Limit: 20})
@@ -29,7 +29,7 @@ func TestCrosschainConfigUsecase_Overview_InvalidSource(t *testing.T) {
 	chainRepo := new(MockChainRepository)
 	tokenRepo := new(MockTokenRepository)
 	contractRepo := new(MockSmartContractRepository)
-	chainRepo.On("GetAll", mock.Anything).Return([]*entities.Chain{}, nil)
+	chainRepo.On("GetActive", mock.Anything, utils.PaginationParams{}).Return([]*entities.Chain{}, int64(0), nil)
 
 	chainRepo.On("GetByChainID", mock.Anything, "invalid").Return((*entities.Chain)(nil), errors.New("not found")).Twice()
 
@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+	"payment-kita.backend/pkg/logger"
+	"payment-kita.backend/pkg/utils"
+)
+
+// NewCrosschainConfigUsecaseWithStatusStore is NewCrosschainConfigUsecase that also saves every
+// route recheck to statusRepo, so StoredOverview can serve the overview without RPC calls
+func NewCrosschainConfigUsecaseWithStatusStore(
+	chainRepo repositories.ChainRepository,
+	tokenRepo repositories.TokenRepository,
+	contractRepo repositories.SmartContractRepository,
+	clientFactory *blockchain.ClientFactory,
+	adapterUsecase CrosschainAdapterUsecase,
+	statusRepo repositories.CrosschainRouteStatusRepository,
+) *CrosschainConfigUsecase {
+	u := NewCrosschainConfigUsecase(chainRepo, tokenRepo, contractRepo, clientFactory, adapterUsecase)
+	u.statusRepo = statusRepo
+	return u
+}
+
+// StoredOverview is Overview read from the status store: the last result of every route, as
+// saved by rechecks and the route health job, with no RPC calls. Routes never checked are absent.
+func (u *CrosschainConfigUsecase) StoredOverview(
+	ctx context.Context,
+	sourceChainInput, destChainInput string,
+	pagination utils.PaginationParams,
+) (*CrosschainOverview, error) {
+	if u.statusRepo == nil {
+		return nil, domainerrors.BadRequest("crosschain route status store is not configured")
+	}
+	sourceCAIP2, destCAIP2 := "", ""
+	if strings.TrimSpace(sourceChainInput) != "" {
+		_, caip2, err := u.chainResolver.ResolveFromAny(ctx, sourceChainInput)
+		if err != nil {
+			return nil, domainerrors.BadRequest("invalid sourceChainId")
+		}
+		sourceCAIP2 = caip2
+	}
+	if strings.TrimSpace(destChainInput) != "" {
+		_, caip2, err := u.chainResolver.ResolveFromAny(ctx, destChainInput)
+		if err != nil {
+			return nil, domainerrors.BadRequest("invalid destChainId")
+		}
+		destCAIP2 = caip2
+	}
+
+	records, err := u.statusRepo.List(ctx, sourceCAIP2, destCAIP2)
+	if err != nil {
+		return nil, err
+	}
+	routes := make([]CrosschainRouteStatus, 0, len(records))
+	for _, record := range records {
+		var status CrosschainRouteStatus
+		if err := json.Unmarshal([]byte(record.Snapshot), &status); err != nil {
+			logger.Warn(ctx, "skipping unreadable crosschain route status", zap.String("route", record.RouteKey), zap.Error(err))
+			continue
+		}
+		applyStatusRecord(&status, record)
+		routes = append(routes, status)
+	}
+	return paginateRouteStatuses(routes, pagination), nil
+}
+
+// recordRouteStatus saves status to the status store and fills in its change history. A store
+// failure is logged, never returned: the live result is still correct.
+func (u *CrosschainConfigUsecase) recordRouteStatus(ctx context.Context, status *CrosschainRouteStatus) {
+	if u.statusRepo == nil {
+		return
+	}
+	snapshot := *status
+	snapshot.PreviousStatus, snapshot.CheckedAt, snapshot.StatusChangedAt = "", nil, nil
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		logger.Warn(ctx, "failed to encode crosschain route status", zap.String("route", status.RouteKey), zap.Error(err))
+		return
+	}
+	record := &entities.CrosschainRouteStatusRecord{
+		RouteKey:      status.RouteKey,
+		SourceChainID: status.SourceChainID,
+		DestChainID:   status.DestChainID,
+		OverallStatus: status.OverallStatus,
+		Snapshot:      string(raw),
+		CheckedAt:     time.Now().UTC(),
+	}
+	if err := u.statusRepo.Save(ctx, record); err != nil {
+		logger.Warn(ctx, "failed to save crosschain route status", zap.String("route", status.RouteKey), zap.Error(err))
+		return
+	}
+	applyStatusRecord(status, record)
+}
+
+func applyStatusRecord(status *CrosschainRouteStatus, record *entities.CrosschainRouteStatusRecord) {
+	checkedAt, changedAt := record.CheckedAt, record.StatusChangedAt
+	status.PreviousStatus = record.PreviousStatus
+	status.CheckedAt = &checkedAt
+	status.StatusChangedAt = &changedAt
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/pkg/utils"
+)
+
+type routeStatusRepoStub struct {
+	records map[string]*entities.CrosschainRouteStatusRecord
+	saveErr error
+}
+
+func (s *routeStatusRepoStub) Save(_ context.Context, record *entities.CrosschainRouteStatusRecord) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	if prev, ok := s.records[record.RouteKey]; ok {
+		record.PreviousStatus, record.StatusChangedAt = prev.PreviousStatus, prev.StatusChangedAt
+		if prev.OverallStatus != record.OverallStatus {
+			record.PreviousStatus, record.StatusChangedAt = prev.OverallStatus, record.CheckedAt
+		}
+	} else {
+		record.StatusChangedAt = record.CheckedAt
+	}
+	stored := *record
+	s.records[record.RouteKey] = &stored
+	return nil
+}
+
+func (s *routeStatusRepoStub) List(_ context.Context, source, dest string) ([]*entities.CrosschainRouteStatusRecord, error) {
+	var out []*entities.CrosschainRouteStatusRecord
+	for _, record := range s.records {
+		if (source == "" || record.SourceChainID == source) && (dest == "" || record.DestChainID == dest) {
+			out = append(out, record)
+		}
+	}
+	return out, nil
+}
+
+func TestCrosschainConfigUsecase_RouteStatusStore(t *testing.T) {
+	source, dest, chainRepo := newMatrixChains()
+	statusErr := errors.New("rpc down")
+	adapter := &crosschainAdapterStub{
+		statusFn: func(context.Context, string, string) (*OnchainAdapterStatus, error) {
+			if statusErr != nil {
+				return nil, statusErr
+			}
+			return &OnchainAdapterStatus{DefaultBridgeType: 0, HyperbridgeConfigured: true}, nil
+		},
+	}
+	repo := &routeStatusRepoStub{records: map[string]*entities.CrosschainRouteStatusRecord{}}
+	u := NewCrosschainConfigUsecaseWithStatusStore(chainRepo, &ccTokenRepoStub{}, &ccContractRepoStub{}, nil, adapter, repo)
+	ctx := context.Background()
+	routeKey := source.GetCAIP2ID() + "->" + dest.GetCAIP2ID()
+
+	// A failed recheck in the live overview is stored as ERROR
+	live, err := u.Overview(ctx, source.GetCAIP2ID(), dest.GetCAIP2ID(), utils.PaginationParams{})
+	require.NoError(t, err)
+	require.Len(t, live.Items, 1)
+	require.Equal(t, "ERROR", repo.records[routeKey].OverallStatus)
+	require.NotNil(t, live.Items[0].CheckedAt)
+	require.Empty(t, live.Items[0].PreviousStatus)
+
+	statusErr = nil
+	route, err := u.RecheckRoute(ctx, source.GetCAIP2ID(), dest.GetCAIP2ID())
+	require.NoError(t, err)
+	require.Equal(t, "ERROR", route.OverallStatus)
+	require.True(t, hasIssueCode(route.Issues, "ADAPTER_NOT_REGISTERED"))
+	require.NotNil(t, route.StatusChangedAt)
+
+	stored, err := u.StoredOverview(ctx, "eip155:8453", "", utils.PaginationParams{})
+	require.NoError(t, err)
+	require.Len(t, stored.Items, 1)
+	require.Equal(t, routeKey, stored.Items[0].RouteKey)
+	require.True(t, hasIssueCode(stored.Items[0].Issues, "ADAPTER_NOT_REGISTERED"))
+	require.Equal(t, *route.CheckedAt, *stored.Items[0].CheckedAt)
+
+	stored, err = u.StoredOverview(ctx, "eip155:42161", "", utils.PaginationParams{})
+	require.NoError(t, err)
+	require.Empty(t, stored.Items)
+
+	// A store failure never fails the recheck itself
+	repo.saveErr = errors.New("db down")
+	route, err = u.RecheckRoute(ctx, source.GetCAIP2ID(), dest.GetCAIP2ID())
+	require.NoError(t, err)
+	require.Nil(t, route.CheckedAt)
+
+	_, err = NewCrosschainConfigUsecase(chainRepo, &ccTokenRepoStub{}, &ccContractRepoStub{}, nil, adapter).
+		StoredOverview(ctx, "", "", utils.PaginationParams{})
+	require.Error(t, err)
+}
@@ -26,6 +26,7 @@ type evmAdminGetAdapterFn func(ctx context.Context, sourceChainID uuid.UUID, rou
 type evmAdminSendTxFn func(ctx context.Context, sourceChainID uuid.UUID, contractAddress string, parsedABI abi.ABI, method string, args ...interface{}) (string, error)
 type evmAdminResolveABIFn func(ctx context.Context, sourceChainID uuid.UUID, contractType entities.SmartContractType) (abi.ABI, error)
 type evmAdminReadViewFn func(ctx context.Context, sourceChainID uuid.UUID, contractAddress string, parsedABI abi.ABI, method string, args ...interface{}) ([]interface{}, error)
+type evmAdminVerifyOwnerFn func(ctx context.Context, sourceChainID uuid.UUID, contractAddress string) error
 
 type evmAdminOpsService struct {
 	resolveContext evmAdminResolveFn
@@ -33,6 +34,9 @@ type evmAdminOpsService struct {
 	sendTx         evmAdminSendTxFn
 	resolveABI     evmAdminResolveABIFn
 	readView       evmAdminReadViewFn
+	// verifyOwner, when set, runs before every tx so a key that does not administer the
+	// contract fails fast instead of reverting on-chain
+	verifyOwner evmAdminVerifyOwnerFn
 }
 
 func newEVMAdminOpsService(
@@ -55,6 +59,23 @@ func newEVMAdminOpsService(
 	}
 }
 
+// send submits an admin tx after checking the owner key is authorized on contractAddress
+func (s *evmAdminOpsService) send(
+	ctx context.Context,
+	sourceChainID uuid.UUID,
+	contractAddress string,
+	parsedABI abi.ABI,
+	method string,
+	args ...interface{},
+) (string, error) {
+	if s.verifyOwner != nil {
+		if err := s.verifyOwner(ctx, sourceChainID, contractAddress); err != nil {
+			return "", err
+		}
+	}
+	return s.sendTx(ctx, sourceChainID, contractAddress, parsedABI, method, args...)
+}
+
 type StargateE2EStepStatus string
 
 const (
@@ -176,7 +197,7 @@ func (s *evmAdminOpsService) RegisterAdapter(
 		return "", err
 	}
 
-	return s.sendTx(
+	return s.send(
 		ctx,
 		resolved.sourceChainID,
 		resolved.routerAddress,
@@ -203,7 +224,7 @@ func (s *evmAdminOpsService) SetDefaultBridgeType(
 		return "", err
 	}
 
-	return s.sendTx(
+	return s.send(
 		ctx,
 		resolved.sourceChainID,
 		resolved.gatewayAddress,
@@ -239,7 +260,7 @@ func (s *evmAdminOpsService) SetHyperbridgeConfig(
 	var txHashes []string
 	target := normalizeHexInput(stateMachineIDHex)
 	if target != "" {
-		txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, adapter, parsedABI, "setStateMachineId", resolved.destCAIP2, common.FromHex("0x"+target))
+		txHash, txErr := s.send(ctx, resolved.sourceChainID, adapter, parsedABI, "setStateMachineId", resolved.destCAIP2, common.FromHex("0x"+target))
 		if txErr != nil {
 			return "", txHashes, wrapAdminTxError("setStateMachineId", txErr)
 		}
@@ -248,7 +269,7 @@ func (s *evmAdminOpsService) SetHyperbridgeConfig(
 
 	dest := normalizeHexInput(destinationContractHex)
 	if dest != "" {
-		txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, adapter, parsedABI, "setDestinationContract", resolved.destCAIP2, common.FromHex("0x"+dest))
+		txHash, txErr := s.send(ctx, resolved.sourceChainID, adapter, parsedABI, "setDestinationContract", resolved.destCAIP2, common.FromHex("0x"+dest))
 		if txErr != nil {
 			return "", txHashes, wrapAdminTxError("setDestinationContract", txErr)
 		}
@@ -285,7 +306,7 @@ func (s *evmAdminOpsService) SetHyperbridgeTokenGatewayConfig(
 
 	stateMachine := normalizeHexInput(input.StateMachineIDHex)
 	if stateMachine != "" {
-		txHash, txErr := s.sendTx(
+		txHash, txErr := s.send(
 			ctx,
 			resolved.sourceChainID,
 			adapter,
@@ -305,7 +326,7 @@ func (s *evmAdminOpsService) SetHyperbridgeTokenGatewayConfig(
 		if !isValidAdapterAddress(settlementExecutor) {
 			return "", nil, domainerrors.BadRequest("invalid settlementExecutorAddress")
 		}
-		txHash, txErr := s.sendTx(
+		txHash, txErr := s.send(
 			ctx,
 			resolved.sourceChainID,
 			adapter,
@@ -323,7 +344,7 @@ func (s *evmAdminOpsService) SetHyperbridgeTokenGatewayConfig(
 	if nativeCost, parseErr := parseOptionalBigInt(input.NativeCost); parseErr != nil {
 		return "", nil, domainerrors.BadRequest("invalid nativeCost")
 	} else if nativeCost != nil {
-		txHash, txErr := s.sendTx(
+		txHash, txErr := s.send(
 			ctx,
 			resolved.sourceChainID,
 			adapter,
@@ -341,7 +362,7 @@ func (s *evmAdminOpsService) SetHyperbridgeTokenGatewayConfig(
 	if relayerFee, parseErr := parseOptionalBigInt(input.RelayerFee); parseErr != nil {
 		return "", nil, domainerrors.BadRequest("invalid relayerFee")
 	} else if relayerFee != nil {
-		txHash, txErr := s.sendTx(
+		txHash, txErr := s.send(
 			ctx,
 			resolved.sourceChainID,
 			adapter,
@@ -389,7 +410,7 @@ func (s *evmAdminOpsService) SetCCIPConfig(ctx context.Context, input CCIPConfig
 	usedSetChainConfig := false
 	if input.ChainSelector != nil && dest != "" && hasSetChainConfig {
 		if destAddress, parseErr := parseAdapterAddressHex("0x" + dest); parseErr == nil {
-			txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, adapter, parsedABI, "setChainConfig", resolved.destCAIP2, *input.ChainSelector, destAddress)
+			txHash, txErr := s.send(ctx, resolved.sourceChainID, adapter, parsedABI, "setChainConfig", resolved.destCAIP2, *input.ChainSelector, destAddress)
 			if txErr != nil {
 				return "", txHashes, wrapAdminTxError("setChainConfig", txErr)
 			}
@@ -399,14 +420,14 @@ func (s *evmAdminOpsService) SetCCIPConfig(ctx context.Context, input CCIPConfig
 	}
 	if !usedSetChainConfig {
 		if input.ChainSelector != nil {
-			txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, adapter, parsedABI, "setChainSelector", resolved.destCAIP2, *input.ChainSelector)
+			txHash, txErr := s.send(ctx, resolved.sourceChainID, adapter, parsedABI, "setChainSelector", resolved.destCAIP2, *input.ChainSelector)
 			if txErr != nil {
 				return "", txHashes, wrapAdminTxError("setChainSelector", txErr)
 			}
 			txHashes = append(txHashes, txHash)
 		}
 		if dest != "" {
-			txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, adapter, parsedABI, "setDestinationAdapter", resolved.destCAIP2, common.FromHex("0x"+dest))
+			txHash, txErr := s.send(ctx, resolved.sourceChainID, adapter, parsedABI, "setDestinationAdapter", resolved.destCAIP2, common.FromHex("0x"+dest))
 			if txErr != nil {
 				return "", txHashes, wrapAdminTxError("setDestinationAdapter", txErr)
 			}
@@ -415,7 +436,7 @@ func (s *evmAdminOpsService) SetCCIPConfig(ctx context.Context, input CCIPConfig
 	}
 	if input.DestinationGasLimit != nil {
 		gasLimit := new(big.Int).SetUint64(*input.DestinationGasLimit)
-		txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, adapter, parsedABI, "setDestinationGasLimit", resolved.destCAIP2, gasLimit)
+		txHash, txErr := s.send(ctx, resolved.sourceChainID, adapter, parsedABI, "setDestinationGasLimit", resolved.destCAIP2, gasLimit)
 		if txErr != nil {
 			return "", txHashes, wrapAdminTxError("setDestinationGasLimit", txErr)
 		}
@@ -423,7 +444,7 @@ func (s *evmAdminOpsService) SetCCIPConfig(ctx context.Context, input CCIPConfig
 	}
 	extra := normalizeHexInput(input.DestinationExtraArgsHex)
 	if extra != "" {
-		txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, adapter, parsedABI, "setDestinationExtraArgs", resolved.destCAIP2, common.FromHex("0x"+extra))
+		txHash, txErr := s.send(ctx, resolved.sourceChainID, adapter, parsedABI, "setDestinationExtraArgs", resolved.destCAIP2, common.FromHex("0x"+extra))
 		if txErr != nil {
 			return "", txHashes, wrapAdminTxError("setDestinationExtraArgs", txErr)
 		}
@@ -433,7 +454,7 @@ func (s *evmAdminOpsService) SetCCIPConfig(ctx context.Context, input CCIPConfig
 		if !common.IsHexAddress(input.DestinationFeeToken) {
 			return "", nil, domainerrors.BadRequest("invalid destinationFeeTokenAddress")
 		}
-		txHash, txErr := s.sendTx(
+		txHash, txErr := s.send(
 			ctx,
 			resolved.sourceChainID,
 			adapter,
@@ -471,7 +492,7 @@ func (s *evmAdminOpsService) SetCCIPConfig(ctx context.Context, input CCIPConfig
 
 		trustedSender := normalizeHexInput(input.TrustedSenderHex)
 		if trustedSender != "" {
-			txHash, txErr := s.sendTx(
+			txHash, txErr := s.send(
 				ctx,
 				destinationCtx.sourceChainID,
 				input.DestinationReceiver,
@@ -486,7 +507,7 @@ func (s *evmAdminOpsService) SetCCIPConfig(ctx context.Context, input CCIPConfig
 			txHashes = append(txHashes, txHash)
 		}
 		if input.AllowSourceChain != nil {
-			txHash, txErr := s.sendTx(
+			txHash, txErr := s.send(
 				ctx,
 				destinationCtx.sourceChainID,
 				input.DestinationReceiver,
@@ -541,7 +562,7 @@ func (s *evmAdminOpsService) SetStargateConfig(
 		if parseErr != nil {
 			return "", nil, domainerrors.BadRequest("invalid peerHex")
 		}
-		txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, adapter, parsedABI, "setRoute", resolved.destCAIP2, *dstEid, peer32)
+		txHash, txErr := s.send(ctx, resolved.sourceChainID, adapter, parsedABI, "setRoute", resolved.destCAIP2, *dstEid, peer32)
 		if txErr != nil {
 			return "", txHashes, wrapAdminTxError("setRoute", txErr)
 		}
@@ -554,7 +575,7 @@ func (s *evmAdminOpsService) SetStargateConfig(
 			trimmedOptions = "0x" + trimmedOptions
 		}
 		optionsMethod := stargateOptionsSetterMethod(parsedABI)
-		txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, adapter, parsedABI, optionsMethod, resolved.destCAIP2, common.FromHex(trimmedOptions))
+		txHash, txErr := s.send(ctx, resolved.sourceChainID, adapter, parsedABI, optionsMethod, resolved.destCAIP2, common.FromHex(trimmedOptions))
 		if txErr != nil {
 			return "", txHashes, wrapAdminTxError(optionsMethod, txErr)
 		}
@@ -646,7 +667,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 		if !isValidAdapterAddress(sourceSender) {
 			return nil, domainerrors.BadRequest("senderAddress is required when adapter type 2 is missing")
 		}
-		txHash, txErr := s.sendTx(
+		txHash, txErr := s.send(
 			ctx,
 			resolved.sourceChainID,
 			resolved.routerAddress,
@@ -661,7 +682,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 		}
 		addSuccess("registerAdapter", txHash)
 	} else if input.Source.RegisterAdapterIfMissing && isValidAdapterAddress(sourceSender) && !strings.EqualFold(currentSender, sourceSender) {
-		txHash, txErr := s.sendTx(
+		txHash, txErr := s.send(
 			ctx,
 			resolved.sourceChainID,
 			resolved.routerAddress,
@@ -688,7 +709,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 		if readErr == nil && defaultBridge == 2 {
 			addSkipped("setDefaultBridgeType", "already-configured")
 		} else {
-			txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, resolved.gatewayAddress, gatewayABI, "setDefaultBridgeType", resolved.destCAIP2, uint8(2))
+			txHash, txErr := s.send(ctx, resolved.sourceChainID, resolved.gatewayAddress, gatewayABI, "setDefaultBridgeType", resolved.destCAIP2, uint8(2))
 			if txErr != nil {
 				return nil, wrapAdminTxError("setDefaultBridgeType", txErr)
 			}
@@ -709,7 +730,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 	if dstReadErr == nil && peerReadErr == nil && currentDstEID == input.Source.DstEID && currentPeer == dstPeer {
 		addSkipped("setRoute", "already-configured")
 	} else {
-		txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, sourceSender, senderABI, "setRoute", resolved.destCAIP2, input.Source.DstEID, dstPeer)
+		txHash, txErr := s.send(ctx, resolved.sourceChainID, sourceSender, senderABI, "setRoute", resolved.destCAIP2, input.Source.DstEID, dstPeer)
 		if txErr != nil {
 			return nil, wrapAdminTxError("setRoute", txErr)
 		}
@@ -727,7 +748,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 		if optsReadErr == nil && strings.EqualFold("0x"+common.Bytes2Hex(currentOptions), options) {
 			addSkipped(optionsMethod, "already-configured")
 		} else {
-			txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, sourceSender, senderABI, optionsMethod, resolved.destCAIP2, common.FromHex(options))
+			txHash, txErr := s.send(ctx, resolved.sourceChainID, sourceSender, senderABI, optionsMethod, resolved.destCAIP2, common.FromHex(options))
 			if txErr != nil {
 				return nil, wrapAdminTxError(optionsMethod, txErr)
 			}
@@ -742,7 +763,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 		if gasReadErr == nil && currentComposeGas == input.Source.ComposeGasLimit {
 			addSkipped("setDestinationComposeGasLimit", "already-configured")
 		} else if _, ok := senderABI.Methods["setDestinationComposeGasLimit"]; ok {
-			txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, sourceSender, senderABI, "setDestinationComposeGasLimit", resolved.destCAIP2, input.Source.ComposeGasLimit)
+			txHash, txErr := s.send(ctx, resolved.sourceChainID, sourceSender, senderABI, "setDestinationComposeGasLimit", resolved.destCAIP2, input.Source.ComposeGasLimit)
 			if txErr != nil {
 				return nil, wrapAdminTxError("setDestinationComposeGasLimit", txErr)
 			}
@@ -751,7 +772,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 	}
 
 	if input.Source.RegisterDelegate {
-		txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, sourceSender, senderABI, "registerDelegate")
+		txHash, txErr := s.send(ctx, resolved.sourceChainID, sourceSender, senderABI, "registerDelegate")
 		if txErr != nil {
 			return nil, wrapAdminTxError("registerDelegate", txErr)
 		}
@@ -766,7 +787,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 			if authReadErr == nil && authorized {
 				addSkipped("sourceVault.setAuthorizedSpender", "already-configured")
 			} else {
-				txHash, txErr := s.sendTx(ctx, resolved.sourceChainID, resolved.vaultAddress, sourceVaultABI, "setAuthorizedSpender", common.HexToAddress(sourceSender), true)
+				txHash, txErr := s.send(ctx, resolved.sourceChainID, resolved.vaultAddress, sourceVaultABI, "setAuthorizedSpender", common.HexToAddress(sourceSender), true)
 				if txErr != nil {
 					return nil, wrapAdminTxError("source setAuthorizedSpender", txErr)
 				}
@@ -794,7 +815,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 		if pathReadErr == nil && peerConfigured && trusted {
 			addSkipped("destinationReceiver.setPeer", "already-configured")
 		} else {
-			txHash, txErr := s.sendTx(ctx, input.Destination.ChainID, input.Destination.ReceiverAddress, destinationReceiverABI, "setPeer", input.Destination.SrcEID, srcSender)
+			txHash, txErr := s.send(ctx, input.Destination.ChainID, input.Destination.ReceiverAddress, destinationReceiverABI, "setPeer", input.Destination.SrcEID, srcSender)
 			if txErr != nil {
 				return nil, wrapAdminTxError("destination setPeer", txErr)
 			}
@@ -816,7 +837,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 			if authReadErr == nil && authorized {
 				addSkipped("destinationVault.setAuthorizedSpender", "already-configured")
 			} else {
-				txHash, txErr := s.sendTx(
+				txHash, txErr := s.send(
 					ctx,
 					input.Destination.ChainID,
 					input.Destination.VaultAddress,
@@ -847,7 +868,7 @@ func (s *evmAdminOpsService) ConfigureStargateE2E(
 			if authReadErr == nil && authorized {
 				addSkipped("destinationGateway.setAuthorizedAdapter", "already-configured")
 			} else {
-				txHash, txErr := s.sendTx(
+				txHash, txErr := s.send(
 					ctx,
 					input.Destination.ChainID,
 					input.Destination.GatewayAddress,
@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+)
+
+const (
+	featureFlagCacheTTL = 30 * time.Second
+	// featureFlagRetryTTL spaces out reloads while the repo is failing, so an outage does
+	// not turn every gated request into a DB round trip.
+	featureFlagRetryTTL = 5 * time.Second
+)
+
+var featureFlagNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+
+type featureFlagKey struct {
+	name       string
+	merchantID uuid.UUID
+}
+
+// FeatureFlagUsecase resolves feature flags: merchant override, then global row, then the
+// configured default. Rows are cached briefly so gated routes do not hit the DB per request.
+type FeatureFlagUsecase struct {
+	repo     repositories.FeatureFlagRepository
+	defaults map[string]bool
+	now      func() time.Time
+
+	// reloadMu lets a single request refresh the snapshot; the rest wait for its result.
+	reloadMu sync.Mutex
+
+	mu       sync.RWMutex
+	snapshot map[featureFlagKey]bool
+	loadedAt time.Time
+	ttl      time.Duration
+}
+
+// NewFeatureFlagUsecase creates a new feature flag usecase
+func NewFeatureFlagUsecase(repo repositories.FeatureFlagRepository, defaults map[string]bool) *FeatureFlagUsecase {
+	return &FeatureFlagUsecase{
+		repo:     repo,
+		defaults: defaults,
+		now:      time.Now,
+	}
+}
+
+// IsFeatureEnabled reports whether name is enabled for merchantID (nil for no tenant)
+func (u *FeatureFlagUsecase) IsFeatureEnabled(ctx context.Context, name string, merchantID *uuid.UUID) bool {
+	snapshot := u.load(ctx)
+	if merchantID != nil {
+		if enabled, ok := snapshot[featureFlagKey{name: name, merchantID: *merchantID}]; ok {
+			return enabled
+		}
+	}
+	if enabled, ok := snapshot[featureFlagKey{name: name}]; ok {
+		return enabled
+	}
+	return u.defaults[name]
+}
+
+// List returns all stored flags and overrides
+func (u *FeatureFlagUsecase) List(ctx context.Context) ([]*entities.FeatureFlag, error) {
+	return u.repo.List(ctx)
+}
+
+// SetFlag enables or disables a feature globally, or for one merchant
+func (u *FeatureFlagUsecase) SetFlag(ctx context.Context, name string, merchantID *uuid.UUID, enabled bool) (*entities.FeatureFlag, error) {
+	if !featureFlagNamePattern.MatchString(name) {
+		return nil, domainerrors.BadRequest("invalid feature name")
+	}
+	flag := &entities.FeatureFlag{Name: name, MerchantID: merchantID, Enabled: enabled}
+	if err := u.repo.Upsert(ctx, flag); err != nil {
+		return nil, err
+	}
+	u.invalidate()
+	return flag, nil
+}
+
+// DeleteFlag removes a stored flag so resolution falls back to the next level
+func (u *FeatureFlagUsecase) DeleteFlag(ctx context.Context, name string, merchantID *uuid.UUID) error {
+	if err := u.repo.Delete(ctx, name, merchantID); err != nil {
+		return err
+	}
+	u.invalidate()
+	return nil
+}
+
+func (u *FeatureFlagUsecase) load(ctx context.Context) map[featureFlagKey]bool {
+	if snapshot, fresh := u.cached(); fresh {
+		return snapshot
+	}
+
+	u.reloadMu.Lock()
+	defer u.reloadMu.Unlock()
+	// Another request may have reloaded while this one waited.
+	if snapshot, fresh := u.cached(); fresh {
+		return snapshot
+	}
+
+	flags, err := u.repo.List(ctx)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.loadedAt = u.now()
+	if err != nil {
+		// Keep serving the last known state (or the config defaults if nothing loaded yet)
+		// rather than flapping gated routes on a DB blip, and retry after a short backoff.
+		u.ttl = featureFlagRetryTTL
+		return u.snapshot
+	}
+	snapshot := make(map[featureFlagKey]bool, len(flags))
+	for _, f := range flags {
+		key := featureFlagKey{name: f.Name}
+		if f.MerchantID != nil {
+			key.merchantID = *f.MerchantID
+		}
+		snapshot[key] = f.Enabled
+	}
+	u.snapshot = snapshot
+	u.ttl = featureFlagCacheTTL
+	return snapshot
+}
+
+func (u *FeatureFlagUsecase) cached() (map[featureFlagKey]bool, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.snapshot, !u.loadedAt.IsZero() && u.now().Sub(u.loadedAt) < u.ttl
+}
+
+// invalidate forces the next lookup to reload, keeping the snapshot as the fallback.
+func (u *FeatureFlagUsecase) invalidate() {
+	u.mu.Lock()
+	u.loadedAt = time.Time{}
+	u.mu.Unlock()
+}
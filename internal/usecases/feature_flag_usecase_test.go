@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type featureFlagRepoStub struct {
+	flags     []*entities.FeatureFlag
+	listErr   error
+	listCalls int
+}
+
+func (s *featureFlagRepoStub) List(context.Context) ([]*entities.FeatureFlag, error) {
+	s.listCalls++
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	return s.flags, nil
+}
+
+func (s *featureFlagRepoStub) Upsert(_ context.Context, flag *entities.FeatureFlag) error {
+	for _, f := range s.flags {
+		if f.Name == flag.Name && sameMerchant(f.MerchantID, flag.MerchantID) {
+			f.Enabled = flag.Enabled
+			return nil
+		}
+	}
+	cpy := *flag
+	s.flags = append(s.flags, &cpy)
+	return nil
+}
+
+func (s *featureFlagRepoStub) Delete(_ context.Context, name string, merchantID *uuid.UUID) error {
+	for i, f := range s.flags {
+		if f.Name == name && sameMerchant(f.MerchantID, merchantID) {
+			s.flags = append(s.flags[:i], s.flags[i+1:]...)
+			return nil
+		}
+	}
+	return domainerrors.ErrNotFound
+}
+
+func sameMerchant(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func TestFeatureFlagUsecase_Resolution(t *testing.T) {
+	ctx := context.Background()
+	merchantID := uuid.New()
+	otherMerchant := uuid.New()
+	repo := &featureFlagRepoStub{}
+	uc := NewFeatureFlagUsecase(repo, map[string]bool{entities.FeatureSplits: true})
+
+	// Config default applies when nothing is stored.
+	assert.True(t, uc.IsFeatureEnabled(ctx, entities.FeatureSplits, nil))
+	assert.False(t, uc.IsFeatureEnabled(ctx, entities.FeatureRefunds, &merchantID))
+
+	// Per-merchant override enables a feature that is globally off.
+	_, err := uc.SetFlag(ctx, entities.FeatureRefunds, nil, false)
+	require.NoError(t, err)
+	_, err = uc.SetFlag(ctx, entities.FeatureRefunds, &merchantID, true)
+	require.NoError(t, err)
+	assert.True(t, uc.IsFeatureEnabled(ctx, entities.FeatureRefunds, &merchantID))
+	assert.False(t, uc.IsFeatureEnabled(ctx, entities.FeatureRefunds, &otherMerchant))
+	assert.False(t, uc.IsFeatureEnabled(ctx, entities.FeatureRefunds, nil))
+
+	// The global DB row wins over the config default.
+	_, err = uc.SetFlag(ctx, entities.FeatureSplits, nil, false)
+	require.NoError(t, err)
+	assert.False(t, uc.IsFeatureEnabled(ctx, entities.FeatureSplits, nil))
+
+	// Removing the override falls back to the global row.
+	require.NoError(t, uc.DeleteFlag(ctx, entities.FeatureRefunds, &merchantID))
+	assert.False(t, uc.IsFeatureEnabled(ctx, entities.FeatureRefunds, &merchantID))
+	assert.ErrorIs(t, uc.DeleteFlag(ctx, entities.FeatureRefunds, &merchantID), domainerrors.ErrNotFound)
+
+	_, err = uc.SetFlag(ctx, "Bad Name!", nil, true)
+	assert.Error(t, err)
+}
+
+func TestFeatureFlagUsecase_CachesAndSurvivesRepoErrors(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	repo := &featureFlagRepoStub{flags: []*entities.FeatureFlag{{Name: entities.FeatureRecurring, Enabled: true}}}
+	uc := NewFeatureFlagUsecase(repo, nil)
+	uc.now = func() time.Time { return now }
+
+	assert.True(t, uc.IsFeatureEnabled(ctx, entities.FeatureRecurring, nil))
+	assert.True(t, uc.IsFeatureEnabled(ctx, entities.FeatureRecurring, nil))
+	assert.Equal(t, 1, repo.listCalls)
+
+	// After the TTL a failing reload keeps serving the last snapshot.
+	now = now.Add(featureFlagCacheTTL + time.Second)
+	repo.listErr = errors.New("db down")
+	assert.True(t, uc.IsFeatureEnabled(ctx, entities.FeatureRecurring, nil))
+	assert.Equal(t, 2, repo.listCalls)
+
+	// The failure is remembered: requests inside the retry window do not hit the DB again.
+	assert.True(t, uc.IsFeatureEnabled(ctx, entities.FeatureRecurring, nil))
+	assert.Equal(t, 2, repo.listCalls)
+
+	// Once the DB recovers the next reload after the retry window picks up changes.
+	now = now.Add(featureFlagRetryTTL + time.Second)
+	repo.listErr = nil
+	repo.flags[0].Enabled = false
+	assert.False(t, uc.IsFeatureEnabled(ctx, entities.FeatureRecurring, nil))
+	assert.Equal(t, 3, repo.listCalls)
+}
+
+func TestFeatureFlagUsecase_ColdStartFailureFallsBackToDefaults(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	repo := &featureFlagRepoStub{listErr: errors.New("db down")}
+	uc := NewFeatureFlagUsecase(repo, map[string]bool{entities.FeatureRefunds: true})
+	uc.now = func() time.Time { return now }
+
+	assert.True(t, uc.IsFeatureEnabled(ctx, entities.FeatureRefunds, nil))
+	assert.True(t, uc.IsFeatureEnabled(ctx, entities.FeatureRefunds, nil))
+	assert.Equal(t, 1, repo.listCalls)
+
+	now = now.Add(featureFlagRetryTTL + time.Second)
+	repo.listErr = nil
+	repo.flags = []*entities.FeatureFlag{{Name: entities.FeatureRefunds, Enabled: false}}
+	assert.False(t, uc.IsFeatureEnabled(ctx, entities.FeatureRefunds, nil))
+	assert.Equal(t, 2, repo.listCalls)
+}
+
+type blockingFeatureFlagRepo struct {
+	featureFlagRepoStub
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (r *blockingFeatureFlagRepo) List(context.Context) ([]*entities.FeatureFlag, error) {
+	r.calls.Add(1)
+	<-r.release
+	return []*entities.FeatureFlag{{Name: entities.FeatureRecurring, Enabled: true}}, nil
+}
+
+func TestFeatureFlagUsecase_CoalescesConcurrentReloads(t *testing.T) {
+	repo := &blockingFeatureFlagRepo{release: make(chan struct{})}
+	uc := NewFeatureFlagUsecase(repo, nil)
+
+	const callers = 20
+	var wg sync.WaitGroup
+	results := make([]bool, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = uc.IsFeatureEnabled(context.Background(), entities.FeatureRecurring, nil)
+		}(i)
+	}
+	require.Eventually(t, func() bool { return repo.calls.Load() == 1 }, time.Second, time.Millisecond)
+	close(repo.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), repo.calls.Load())
+	for _, enabled := range results {
+		assert.True(t, enabled)
+	}
+}
@@ -0,0 +1,326 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+)
+
+// PlatformFeeSource says which rule priced a platform fee
+type PlatformFeeSource string
+
+const (
+	// PlatformFeeSourceGatewayQuote is the gateway's own quoteTotalAmount, exactly what it pulls
+	PlatformFeeSourceGatewayQuote PlatformFeeSource = "GATEWAY_QUOTE"
+	// PlatformFeeSourceGatewayRates is FIXED_BASE_FEE / FEE_RATE_BPS read from the gateway, for
+	// gateways without quoteTotalAmount
+	PlatformFeeSourceGatewayRates PlatformFeeSource = "GATEWAY_RATES"
+	// PlatformFeeSourceFeeConfig is the chain/token FeeConfig row, used when the gateway cannot
+	// be asked
+	PlatformFeeSourceFeeConfig PlatformFeeSource = "FEE_CONFIG"
+	// PlatformFeeSourceDefault is the built-in DefaultFeeConfig, when no FeeConfig row exists
+	PlatformFeeSourceDefault PlatformFeeSource = "DEFAULT"
+)
+
+// errNoFeeToken means the gateway could not be asked and the source token is unknown, so
+// there is no FeeConfig to fall back on
+var errNoFeeToken = errors.New("source token unknown for fee config fallback")
+
+// platformFeeRequest is what the fee engine prices. GatewayAddress empty means the source
+// chain's active gateway; SourceTokenID nil means the FeeConfig fallback is unavailable.
+type platformFeeRequest struct {
+	SourceChainID    uuid.UUID
+	SourceTokenID    *uuid.UUID
+	GatewayAddress   string
+	Amount           *big.Int
+	Decimals         int
+	MerchantDiscount float64
+}
+
+// platformFeeQuote is a platform fee in smallest units of the source token, before any
+// off-chain waiver
+type platformFeeQuote struct {
+	Fee    *big.Int
+	Source PlatformFeeSource
+}
+
+// quotePlatformFee is the single source of truth for the platform fee, shared by the fee
+// breakdown (CalculateFees) and the ERC20 approval (CalculateOnchainApprovalAmount). It asks
+// the gateway first, since that is what the payment will actually be charged, and only prices
+// from FeeConfig (or the defaults) when the gateway cannot be reached. A gateway-priced fee
+// deliberately ignores the merchant discount and the FeeConfig min/max: the gateway charges its
+// own rate on-chain, so a discounted or clamped fee would only misstate what the payer pays.
+func (u *PaymentUsecase) quotePlatformFee(ctx context.Context, req platformFeeRequest) (*platformFeeQuote, error) {
+	gatewayAddress := strings.TrimSpace(req.GatewayAddress)
+	if gatewayAddress == "" {
+		gatewayAddress = u.activeGatewayAddress(ctx, req.SourceChainID)
+	}
+
+	gatewayErr := errors.New("no active gateway")
+	if gatewayAddress != "" {
+		var quote *platformFeeQuote
+		if quote, gatewayErr = u.gatewayPlatformFee(ctx, req.SourceChainID, gatewayAddress, req.Amount); gatewayErr == nil {
+			return quote, nil
+		}
+	}
+	if req.SourceTokenID == nil {
+		return nil, gatewayErr
+	}
+	return u.configuredPlatformFee(ctx, req), nil
+}
+
+// activeGatewayAddress is the source chain's active EVM gateway, or "" when there is none or
+// the chain is not EVM
+func (u *PaymentUsecase) activeGatewayAddress(ctx context.Context, chainID uuid.UUID) string {
+	if u.contractRepo == nil || u.chainRepo == nil {
+		return ""
+	}
+	chain, err := u.chainRepo.GetByID(ctx, chainID)
+	if err != nil || chain == nil || !chain.ChainType().IsEVM() {
+		return ""
+	}
+	contract, err := u.contractRepo.GetActiveContract(ctx, chainID, entities.ContractTypeGateway)
+	if err != nil || contract == nil {
+		return ""
+	}
+	return strings.TrimSpace(contract.ContractAddress)
+}
+
+// gatewayFeeKey identifies a gateway fee answer: the gateway's fee settings can only change
+// from one block to the next, so an answer holds for the whole block it was read at
+type gatewayFeeKey struct {
+	chainID uuid.UUID
+	gateway string
+}
+
+type gatewayFeeBlock struct {
+	block uint64
+	fees  map[string]*big.Int
+	// sources records whether each fee came from quoteTotalAmount or the gateway's rates
+	sources map[string]PlatformFeeSource
+}
+
+// gatewayFeeCache remembers the platform fee each gateway quoted per amount at its latest block
+// seen, so the breakdown and the approval of one payment, and payments in the same block, read
+// the gateway once. A newer block replaces the older answers. A nil cache caches nothing.
+type gatewayFeeCache struct {
+	mu      sync.Mutex
+	entries map[gatewayFeeKey]*gatewayFeeBlock
+}
+
+func newGatewayFeeCache() *gatewayFeeCache {
+	return &gatewayFeeCache{entries: make(map[gatewayFeeKey]*gatewayFeeBlock)}
+}
+
+func (c *gatewayFeeCache) get(chainID uuid.UUID, gateway string, block uint64, amount *big.Int) (*platformFeeQuote, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[gatewayFeeKey{chainID: chainID, gateway: strings.ToLower(gateway)}]
+	if !ok || entry.block != block {
+		return nil, false
+	}
+	fee, ok := entry.fees[amount.String()]
+	if !ok {
+		return nil, false
+	}
+	return &platformFeeQuote{Fee: new(big.Int).Set(fee), Source: entry.sources[amount.String()]}, true
+}
+
+func (c *gatewayFeeCache) put(chainID uuid.UUID, gateway string, block uint64, amount *big.Int, quote *platformFeeQuote) {
+	if c == nil {
+		return
+	}
+	key := gatewayFeeKey{chainID: chainID, gateway: strings.ToLower(gateway)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if ok && entry.block > block {
+		return
+	}
+	if !ok || entry.block < block {
+		entry = &gatewayFeeBlock{block: block, fees: make(map[string]*big.Int), sources: make(map[string]PlatformFeeSource)}
+		c.entries[key] = entry
+	}
+	entry.fees[amount.String()] = new(big.Int).Set(quote.Fee)
+	entry.sources[amount.String()] = quote.Source
+}
+
+// gatewayPlatformFee asks the gateway what it will charge on top of amount: quoteTotalAmount
+// when the gateway has it, otherwise min(amount * FEE_RATE_BPS / 10000, FIXED_BASE_FEE).
+// Answers are cached per chain and block; when the block cannot be read the gateway is asked
+// uncached.
+func (u *PaymentUsecase) gatewayPlatformFee(ctx context.Context, chainID uuid.UUID, gatewayAddress string, amount *big.Int) (*platformFeeQuote, error) {
+	if u.chainRepo == nil || u.clientFactory == nil {
+		return nil, fmt.Errorf("gateway fee quote unavailable")
+	}
+	chain, err := u.chainRepo.GetByID(ctx, chainID)
+	if err != nil || chain == nil {
+		return nil, fmt.Errorf("failed to resolve source chain")
+	}
+	rpcURL := strings.TrimSpace(chain.RPCURL)
+	if rpcURL == "" {
+		for _, rpc := range chain.RPCs {
+			if rpc.IsActive && strings.TrimSpace(rpc.URL) != "" {
+				rpcURL = rpc.URL
+				break
+			}
+		}
+	}
+	if rpcURL == "" {
+		return nil, fmt.Errorf("no active source chain rpc url")
+	}
+	client, err := u.clientFactory.GetEVMClient(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create evm client for approval quote: %w", err)
+	}
+
+	block, blockErr := client.GetBlockNumber(ctx)
+	if blockErr == nil {
+		if quote, ok := u.gatewayFees.get(chainID, gatewayAddress, block, amount); ok {
+			return quote, nil
+		}
+	}
+	quote, err := u.readGatewayPlatformFee(ctx, client, chainID, gatewayAddress, amount)
+	if err != nil {
+		return nil, err
+	}
+	if blockErr == nil {
+		u.gatewayFees.put(chainID, gatewayAddress, block, amount, quote)
+	}
+	return quote, nil
+}
+
+// readGatewayPlatformFee reads the fee for amount from the gateway's views
+func (u *PaymentUsecase) readGatewayPlatformFee(ctx context.Context, client EVMClient, chainID uuid.UUID, gatewayAddress string, amount *big.Int) (*platformFeeQuote, error) {
+	feeABI := FallbackPaymentKitaGatewayABI
+	if u.ABIResolverMixin != nil {
+		resolvedABI, abiErr := u.ResolveABIWithFallback(ctx, chainID, entities.ContractTypeGateway)
+		if abiErr != nil {
+			return nil, fmt.Errorf("failed to resolve gateway ABI: %w", abiErr)
+		}
+		// Some DB ABI rows are stale and can miss Track-B methods.
+		// Prefer resolved ABI only when it contains the method we need.
+		if _, ok := resolvedABI.Methods["quoteTotalAmount"]; ok {
+			feeABI = resolvedABI
+		}
+	}
+
+	// Preferred path: ask contract directly for exact total amount
+	if quoteCall, quoteErr := feeABI.Pack("quoteTotalAmount", amount); quoteErr == nil {
+		if quoteRaw, callErr := client.CallView(ctx, gatewayAddress, quoteCall); callErr == nil {
+			quoteVals, unpackErr := feeABI.Unpack("quoteTotalAmount", quoteRaw)
+			if unpackErr == nil && len(quoteVals) >= 1 {
+				if quotedTotal, ok := quoteVals[0].(*big.Int); ok && quotedTotal != nil {
+					fee := new(big.Int).Sub(quotedTotal, amount)
+					if fee.Sign() < 0 {
+						fee.SetInt64(0)
+					}
+					return &platformFeeQuote{Fee: fee, Source: PlatformFeeSourceGatewayQuote}, nil
+				}
+			}
+		}
+	}
+
+	fixedFeeCall, _ := feeABI.Pack("FIXED_BASE_FEE")
+	fixedFeeRaw, err := client.CallView(ctx, gatewayAddress, fixedFeeCall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call FIXED_BASE_FEE: %w", err)
+	}
+	fixedVals, err := feeABI.Unpack("FIXED_BASE_FEE", fixedFeeRaw)
+	if err != nil || len(fixedVals) == 0 {
+		return nil, fmt.Errorf("failed to decode FIXED_BASE_FEE")
+	}
+	fixedFee := fixedVals[0].(*big.Int)
+
+	bpsCall, _ := feeABI.Pack("FEE_RATE_BPS")
+	bpsRaw, err := client.CallView(ctx, gatewayAddress, bpsCall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call FEE_RATE_BPS: %w", err)
+	}
+	bpsVals, err := feeABI.Unpack("FEE_RATE_BPS", bpsRaw)
+	if err != nil || len(bpsVals) == 0 {
+		return nil, fmt.Errorf("failed to decode FEE_RATE_BPS")
+	}
+	feeBps := bpsVals[0].(*big.Int)
+
+	// Integer division truncates like the contract; FeeConfig pricing floors by default to match
+	percentageFee := new(big.Int).Mul(amount, feeBps)
+	percentageFee.Div(percentageFee, big.NewInt(10000))
+	// fixedFee is now the CAP
+	if percentageFee.Cmp(fixedFee) > 0 {
+		percentageFee = new(big.Int).Set(fixedFee)
+	}
+	return &platformFeeQuote{Fee: percentageFee, Source: PlatformFeeSourceGatewayRates}, nil
+}
+
+// configuredPlatformFee prices the fee from the chain/token FeeConfig, or DefaultFeeConfig when
+// there is no row: min(amount * percentage, fixed cap), less the merchant discount, clamped to
+// the min/max fee and rounded with u.feeRounding
+func (u *PaymentUsecase) configuredPlatformFee(ctx context.Context, req platformFeeRequest) *platformFeeQuote {
+	config := DefaultFeeConfig()
+	source := PlatformFeeSourceDefault
+	percentage := ratFromFloat(config.PercentageFee)
+	capTokens := ratFromFloat(config.BaseFeeToken)
+	minFeeTokens := new(big.Rat)
+	var maxFeeTokens *big.Rat
+	if u.feeConfigRepo != nil {
+		if feeCfg, err := u.feeConfigRepo.GetByChainAndToken(ctx, req.SourceChainID, *req.SourceTokenID); err == nil && feeCfg != nil {
+			source = PlatformFeeSourceFeeConfig
+			if v, ok := parseFeeRat(feeCfg.FixedBaseFee); ok {
+				capTokens = v
+			}
+			if v, ok := parseFeeRat(feeCfg.PlatformFeePercent); ok {
+				percentage = v
+			}
+			if v, ok := parseFeeRat(feeCfg.MinFee); ok {
+				minFeeTokens = v
+			}
+			if feeCfg.MaxFee != nil && *feeCfg.MaxFee != "" {
+				if v, ok := parseFeeRat(*feeCfg.MaxFee); ok {
+					maxFeeTokens = v
+				}
+			}
+		}
+	}
+
+	// Fees are worked out exactly in smallest units and rounded once, with u.feeRounding
+	// Platform fee: min(amount * percentage, baseFee)
+	platformValue := new(big.Rat).Mul(new(big.Rat).SetInt(req.Amount), percentage)
+	// config.BaseFeeToken is now treated as the Fixed Cap
+	if feeCap := tokenUnits(capTokens, req.Decimals); platformValue.Cmp(feeCap) > 0 {
+		platformValue = feeCap
+	}
+
+	// Apply merchant discount
+	if req.MerchantDiscount > 0 {
+		platformValue.Mul(platformValue, new(big.Rat).Sub(big.NewRat(1, 1), ratFromFloat(req.MerchantDiscount)))
+	}
+	if minFee := tokenUnits(minFeeTokens, req.Decimals); platformValue.Cmp(minFee) < 0 {
+		platformValue = minFee
+	}
+	// maxFeeTokens is still respected if set, but mostlyredundant with our new cap logic
+	if maxFeeTokens != nil && maxFeeTokens.Sign() >= 0 {
+		if maxFee := tokenUnits(maxFeeTokens, req.Decimals); platformValue.Cmp(maxFee) > 0 {
+			platformValue = maxFee
+		}
+	}
+	return &platformFeeQuote{Fee: u.feeRounding.round(platformValue), Source: source}
+}
+
+// parseFeeRat reads a decimal fee setting such as "0.003" exactly
+func parseFeeRat(raw string) (*big.Rat, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, false
+	}
+	return new(big.Rat).SetString(raw)
+}
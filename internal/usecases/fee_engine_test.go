@@ -0,0 +1,235 @@
+package usecases
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+const feeEngineGateway = "0x1111111111111111111111111111111111111111"
+
+// feeEngineGatewayRPC answers the gateway fee views by selector. A nil handler makes the call
+// return nothing, which fails to decode.
+func feeEngineGatewayRPC(t *testing.T, quote func(amount *big.Int) (*big.Int, bool), fixedFee, bps *big.Int) string {
+	selector := func(method string) string {
+		return "0x" + hex.EncodeToString(FallbackPaymentKitaGatewayABI.Methods[method].ID)
+	}
+	srv := newPaymentRPCServer(t, func(_ int, data string) string {
+		switch {
+		case strings.HasPrefix(data, selector("quoteTotalAmount")):
+			amount, _ := new(big.Int).SetString(data[10:], 16)
+			if total, ok := quote(amount); ok {
+				return mustPackOutputs(t, []string{"uint256", "uint256"}, total, new(big.Int).Sub(total, amount))
+			}
+		case strings.HasPrefix(data, selector("FIXED_BASE_FEE")) && fixedFee != nil:
+			return mustPackOutputs(t, []string{"uint256"}, fixedFee)
+		case strings.HasPrefix(data, selector("FEE_RATE_BPS")) && bps != nil:
+			return mustPackOutputs(t, []string{"uint256"}, bps)
+		}
+		return "0x"
+	})
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestPlatformFeeEngine_BreakdownAndApprovalAgree(t *testing.T) {
+	ctx := context.Background()
+	chainID := uuid.New()
+	tokenID := uuid.New()
+	const decimals = 6
+
+	// FeeConfig charges 1% capped at 5 tokens; the gateway charges 0.53% or 0.3% below
+	feeConfigs := &feeConfigRepoStub{getByChainAndTokenFn: func(context.Context, uuid.UUID, uuid.UUID) (*entities.FeeConfig, error) {
+		return &entities.FeeConfig{FixedBaseFee: "5", PlatformFeePercent: "0.01", MinFee: "0"}, nil
+	}}
+	newUsecase := func(rpcURL string) *PaymentUsecase {
+		scRepo := &scRepoStub{getActiveFn: func(_ context.Context, _ uuid.UUID, typ entities.SmartContractType) (*entities.SmartContract, error) {
+			return &entities.SmartContract{ContractAddress: feeEngineGateway, Type: typ}, nil
+		}}
+		return &PaymentUsecase{
+			contractRepo:     scRepo,
+			chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: rpcURL}},
+			clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
+			feeConfigRepo:    feeConfigs,
+			ABIResolverMixin: NewABIResolverMixin(scRepo),
+		}
+	}
+	buffered := func(total *big.Int) string {
+		buffer := new(big.Int).Div(total, big.NewInt(100))
+		if buffer.Cmp(big.NewInt(1000)) < 0 {
+			buffer = big.NewInt(1000)
+		}
+		return new(big.Int).Add(total, buffer).String()
+	}
+
+	cases := []struct {
+		name     string
+		rpcURL   string
+		fee      func(amount *big.Int) *big.Int
+		buffered bool
+	}{
+		{
+			name: "gateway quote",
+			rpcURL: feeEngineGatewayRPC(t, func(amount *big.Int) (*big.Int, bool) {
+				fee := new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(53)), big.NewInt(10000))
+				return new(big.Int).Add(amount, fee), true
+			}, nil, nil),
+			fee: func(amount *big.Int) *big.Int {
+				return new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(53)), big.NewInt(10000))
+			},
+		},
+		{
+			name: "gateway rates",
+			rpcURL: feeEngineGatewayRPC(t, func(*big.Int) (*big.Int, bool) { return nil, false },
+				big.NewInt(50_000), big.NewInt(30)),
+			fee: func(amount *big.Int) *big.Int {
+				fee := new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(30)), big.NewInt(10000))
+				if fee.Cmp(big.NewInt(50_000)) > 0 {
+					return big.NewInt(50_000)
+				}
+				return fee
+			},
+			buffered: true,
+		},
+		{
+			name:   "fee config when the gateway cannot be reached",
+			rpcURL: "",
+			fee: func(amount *big.Int) *big.Int {
+				fee := new(big.Int).Div(amount, big.NewInt(100))
+				if fee.Cmp(big.NewInt(5_000_000)) > 0 {
+					return big.NewInt(5_000_000)
+				}
+				return fee
+			},
+			buffered: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			u := newUsecase(tc.rpcURL)
+			for _, amount := range []*big.Int{big.NewInt(999), big.NewInt(1_000_000), big.NewInt(12_345_678), big.NewInt(2_500_000_000)} {
+				breakdown := u.CalculateFees(ctx, amount, decimals, "eip155:8453", "eip155:8453",
+					chainID, chainID, tokenID, "native", "native", decimals, 0)
+				require.Equal(t, tc.fee(amount).String(), breakdown.PlatformFee, "amount %s", amount)
+
+				totalCharged, err := addDecimalStrings(amount.String(), breakdown.TotalFee)
+				require.NoError(t, err)
+				payment := &entities.Payment{
+					SourceChainID: chainID,
+					SourceTokenID: &tokenID,
+					SourceToken:   &entities.Token{ID: tokenID, Decimals: decimals},
+					SourceAmount:  amount.String(),
+					TotalCharged:  totalCharged,
+				}
+				approval, err := u.CalculateOnchainApprovalAmount(payment, feeEngineGateway)
+				require.NoError(t, err)
+
+				want := totalCharged
+				if tc.buffered {
+					total, _ := new(big.Int).SetString(totalCharged, 10)
+					want = buffered(total)
+				}
+				require.Equal(t, want, approval, "amount %s", amount)
+			}
+		})
+	}
+}
+
+func TestPlatformFeeEngine_Sources(t *testing.T) {
+	ctx := context.Background()
+	chainID := uuid.New()
+	tokenID := uuid.New()
+	amount := big.NewInt(1_000_000)
+
+	// Without a gateway or token the engine has nothing to price from
+	u := &PaymentUsecase{}
+	_, err := u.quotePlatformFee(ctx, platformFeeRequest{SourceChainID: chainID, Amount: amount, Decimals: 6})
+	require.Error(t, err)
+
+	quote, err := u.quotePlatformFee(ctx, platformFeeRequest{SourceChainID: chainID, SourceTokenID: &tokenID, Amount: amount, Decimals: 6})
+	require.NoError(t, err)
+	require.Equal(t, PlatformFeeSourceDefault, quote.Source)
+	require.Equal(t, "3000", quote.Fee.String())
+
+	u.feeConfigRepo = &feeConfigRepoStub{getByChainAndTokenFn: func(context.Context, uuid.UUID, uuid.UUID) (*entities.FeeConfig, error) {
+		return &entities.FeeConfig{FixedBaseFee: "1", PlatformFeePercent: "0.02", MinFee: "0"}, nil
+	}}
+	quote, err = u.quotePlatformFee(ctx, platformFeeRequest{SourceChainID: chainID, SourceTokenID: &tokenID, Amount: amount, Decimals: 6, MerchantDiscount: 0.5})
+	require.NoError(t, err)
+	require.Equal(t, PlatformFeeSourceFeeConfig, quote.Source)
+	require.Equal(t, "10000", quote.Fee.String())
+
+	// A gateway quote wins over FeeConfig and ignores the discount, which the contract cannot apply
+	scRepo := &scRepoStub{}
+	u.contractRepo = scRepo
+	u.chainRepo = &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM,
+		RPCURL: feeEngineGatewayRPC(t, func(amount *big.Int) (*big.Int, bool) { return new(big.Int).Add(amount, big.NewInt(4321)), true }, nil, nil)}}
+	u.clientFactory = NewEVMClientFactory(blockchain.NewClientFactory())
+	u.ABIResolverMixin = NewABIResolverMixin(scRepo)
+	quote, err = u.quotePlatformFee(ctx, platformFeeRequest{SourceChainID: chainID, SourceTokenID: &tokenID, GatewayAddress: feeEngineGateway, Amount: amount, Decimals: 6, MerchantDiscount: 0.5})
+	require.NoError(t, err)
+	require.Equal(t, PlatformFeeSourceGatewayQuote, quote.Source)
+	require.Equal(t, "4321", quote.Fee.String())
+
+	// Non-EVM chains have no gateway to ask
+	u.chainRepo = &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, ChainID: "solana:devnet", Type: entities.ChainTypeSVM}}
+	scRepo.getActiveFn = func(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
+		t.Fatal("the gateway must not be looked up on a non-EVM chain")
+		return nil, nil
+	}
+	require.Empty(t, u.activeGatewayAddress(ctx, chainID))
+}
+
+func TestPlatformFeeEngine_GatewayQuoteSkipsFeeConfigClampsAndIsCachedPerBlock(t *testing.T) {
+	ctx := context.Background()
+	chainID := uuid.New()
+	tokenID := uuid.New()
+	amount := big.NewInt(1_000_000)
+
+	quotes := 0
+	scRepo := &scRepoStub{}
+	u := &PaymentUsecase{
+		contractRepo: scRepo,
+		chainRepo: &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM,
+			RPCURL: feeEngineGatewayRPC(t, func(amount *big.Int) (*big.Int, bool) {
+				quotes++
+				return new(big.Int).Add(amount, big.NewInt(4321)), true
+			}, nil, nil)}},
+		clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
+		ABIResolverMixin: NewABIResolverMixin(scRepo),
+		// A FeeConfig fee would be raised to the 10 token minimum
+		feeConfigRepo: &feeConfigRepoStub{getByChainAndTokenFn: func(context.Context, uuid.UUID, uuid.UUID) (*entities.FeeConfig, error) {
+			return &entities.FeeConfig{FixedBaseFee: "1", PlatformFeePercent: "0.02", MinFee: "10"}, nil
+		}},
+		gatewayFees: newGatewayFeeCache(),
+	}
+	req := platformFeeRequest{SourceChainID: chainID, SourceTokenID: &tokenID, GatewayAddress: feeEngineGateway, Amount: amount, Decimals: 6}
+
+	quote, err := u.quotePlatformFee(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, PlatformFeeSourceGatewayQuote, quote.Source)
+	require.Equal(t, "4321", quote.Fee.String())
+
+	// The test chain never leaves block 0, so the second quote is served from the cache
+	quote, err = u.quotePlatformFee(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "4321", quote.Fee.String())
+	require.Equal(t, 1, quotes)
+
+	// A newer block replaces the cached answers; an older one never overwrites them
+	u.gatewayFees.put(chainID, feeEngineGateway, 1, amount, &platformFeeQuote{Fee: big.NewInt(99), Source: PlatformFeeSourceGatewayQuote})
+	_, ok := u.gatewayFees.get(chainID, feeEngineGateway, 0, amount)
+	require.False(t, ok)
+	u.gatewayFees.put(chainID, feeEngineGateway, 0, amount, quote)
+	cached, ok := u.gatewayFees.get(chainID, strings.ToUpper(feeEngineGateway), 1, amount)
+	require.True(t, ok)
+	require.Equal(t, "99", cached.Fee.String())
+}
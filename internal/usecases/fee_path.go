@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/infrastructure/metrics"
+	"payment-kita.backend/pkg/logger"
+)
+
+// Bridge fee sources recorded on FeeBreakdown.BridgeFeeSource
+const (
+	// BridgeFeeSourceNone means no bridge fee is in the breakdown: a same-chain payment, or an
+	// ERC20 source whose bridge fee is paid as native tx value
+	BridgeFeeSourceNone = "none"
+	// BridgeFeeSourceQuote is the bridge's own fee quote
+	BridgeFeeSourceQuote = "quote"
+	// BridgeFeeSourceRouteFallback is the route policy's fallbackBridgeFee, used when the quote failed
+	BridgeFeeSourceRouteFallback = "route_fallback"
+	// BridgeFeeSourceFlatFallback is the global flat fee, used when the quote failed and the
+	// route sets no fallback
+	BridgeFeeSourceFlatFallback = "flat_fallback"
+)
+
+// Net amount sources recorded on FeeBreakdown.NetAmountSource
+const (
+	// NetAmountSourceDirect is the amount minus fees, in source token units
+	NetAmountSourceDirect = "direct"
+	// NetAmountSourceSwapQuote is a swap quote into the destination token
+	NetAmountSourceSwapQuote = "swap_quote"
+)
+
+// recordFeePath logs and counts which paths priced a created payment's fees, so operators can
+// see how often fees come from estimates rather than quotes.
+func recordFeePath(ctx context.Context, paymentID uuid.UUID, fees *entities.FeeBreakdown) {
+	if fees == nil {
+		return
+	}
+	metrics.RecordFeePath(fees.FeeSource, fees.BridgeFeeSource, fees.NetAmountSource)
+	logger.Info(ctx, "Payment fees priced",
+		zap.String("payment_id", paymentID.String()),
+		zap.String("fee_source", fees.FeeSource),
+		zap.String("bridge_fee_source", fees.BridgeFeeSource),
+		zap.String("net_amount_source", fees.NetAmountSource),
+		zap.Bool("platform_fee_waived", fees.PlatformFeeWaived),
+	)
+}
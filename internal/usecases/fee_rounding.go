@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// FeeRoundingMode is how CalculateFees turns a fee into whole smallest units. The net amount is
+// the amount minus the rounded fees, so it rounds the opposite way.
+type FeeRoundingMode string
+
+const (
+	// FeeRoundingFloor truncates, exactly like the gateway's amount * FEE_RATE_BPS / 10000.
+	// The zero value means floor.
+	FeeRoundingFloor FeeRoundingMode = "floor"
+	// FeeRoundingCeil rounds fees up, so the platform never collects less than the rate
+	FeeRoundingCeil FeeRoundingMode = "ceil"
+	// FeeRoundingNearest rounds half a unit away from zero
+	FeeRoundingNearest FeeRoundingMode = "nearest"
+)
+
+// ParseFeeRoundingMode reads floor, ceil or nearest (case-insensitive). Empty means floor.
+func ParseFeeRoundingMode(raw string) (FeeRoundingMode, bool) {
+	switch mode := FeeRoundingMode(strings.ToLower(strings.TrimSpace(raw))); mode {
+	case "", FeeRoundingFloor:
+		return FeeRoundingFloor, true
+	case FeeRoundingCeil, FeeRoundingNearest:
+		return mode, true
+	}
+	return FeeRoundingFloor, false
+}
+
+// round turns r into an integer. Negative values round symmetrically to positive ones.
+func (m FeeRoundingMode) round(r *big.Rat) *big.Int {
+	num := new(big.Int).Abs(r.Num())
+	quo, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		switch m {
+		case FeeRoundingCeil:
+			quo.Add(quo, big.NewInt(1))
+		case FeeRoundingNearest:
+			if new(big.Int).Lsh(rem, 1).Cmp(r.Denom()) >= 0 {
+				quo.Add(quo, big.NewInt(1))
+			}
+		}
+	}
+	if r.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	return quo
+}
+
+// ratFromFloat converts a configured fee value through its shortest decimal form, so 0.003 is
+// exactly 3/1000 rather than the nearest binary fraction, which would round ceil up a unit.
+func ratFromFloat(f float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	return r
+}
+
+// tokenUnits scales a token amount to smallest units, leaving it unrounded
+func tokenUnits(tokens *big.Rat, decimals int) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Rat).Mul(tokens, new(big.Rat).SetInt(scale))
+}
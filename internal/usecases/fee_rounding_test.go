@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+)
+
+func TestFeeRoundingMode_Round(t *testing.T) {
+	cases := []struct {
+		value                string
+		floor, ceil, nearest int64
+	}{
+		{"3", 3, 3, 3},
+		{"3.003", 3, 4, 3},
+		{"3.5", 3, 4, 4},
+		{"3.501", 3, 4, 4},
+		{"-3.5", -3, -4, -4},
+	}
+	for _, tc := range cases {
+		r, ok := new(big.Rat).SetString(tc.value)
+		require.True(t, ok)
+		require.Equal(t, tc.floor, FeeRoundingFloor.round(r).Int64(), tc.value)
+		require.Equal(t, tc.floor, FeeRoundingMode("").round(r).Int64(), tc.value)
+		require.Equal(t, tc.ceil, FeeRoundingCeil.round(r).Int64(), tc.value)
+		require.Equal(t, tc.nearest, FeeRoundingNearest.round(r).Int64(), tc.value)
+	}
+
+	// 0.003 must not pick up binary noise that ceil would round up
+	require.Equal(t, int64(3000), FeeRoundingCeil.round(new(big.Rat).Mul(ratFromFloat(0.003), big.NewRat(1000000, 1))).Int64())
+}
+
+func TestParseFeeRoundingMode(t *testing.T) {
+	for raw, want := range map[string]FeeRoundingMode{"": FeeRoundingFloor, "FLOOR": FeeRoundingFloor, " ceil ": FeeRoundingCeil, "Nearest": FeeRoundingNearest} {
+		mode, ok := ParseFeeRoundingMode(raw)
+		require.True(t, ok, raw)
+		require.Equal(t, want, mode)
+	}
+	mode, ok := ParseFeeRoundingMode("banker")
+	require.False(t, ok)
+	require.Equal(t, FeeRoundingFloor, mode)
+}
+
+func TestPaymentUsecase_CalculateFees_Rounding(t *testing.T) {
+	ctx := context.Background()
+	chainID := uuid.New()
+	calculate := func(mode FeeRoundingMode, amount int64, discount float64) *entities.FeeBreakdown {
+		u := &PaymentUsecase{feeRounding: mode, feeConfigRepo: &feeConfigRepoStub{}}
+		return u.CalculateFees(ctx, big.NewInt(amount), 2, "eip155:8453", "eip155:8453",
+			chainID, chainID, uuid.New(), "native", "native", 2, discount)
+	}
+
+	// 0.3% of 11.67 is 3.501 units
+	for mode, want := range map[FeeRoundingMode]string{FeeRoundingFloor: "3", FeeRoundingCeil: "4", FeeRoundingNearest: "4"} {
+		fees := calculate(mode, 1167, 0)
+		require.Equal(t, want, fees.PlatformFee, mode)
+		require.Equal(t, want, fees.TotalFee, mode)
+		net, _ := new(big.Int).SetString(fees.NetAmount, 10)
+		fee, _ := new(big.Int).SetString(fees.TotalFee, 10)
+		require.Equal(t, int64(1167), new(big.Int).Add(net, fee).Int64(), "net + fee must equal the amount")
+	}
+
+	// Floor matches the gateway's amount * FEE_RATE_BPS / 10000 for every amount
+	for amount := int64(1); amount <= 5000; amount += 7 {
+		onchain := amount * 30 / 10000
+		require.Equal(t, big.NewInt(onchain).String(), calculate(FeeRoundingFloor, amount, 0).PlatformFee, amount)
+	}
+
+	// A discount is applied before rounding: 3.501 * 0.5 = 1.7505
+	require.Equal(t, "1", calculate(FeeRoundingFloor, 1167, 0.5).PlatformFee)
+	require.Equal(t, "2", calculate(FeeRoundingNearest, 1167, 0.5).PlatformFee)
+}
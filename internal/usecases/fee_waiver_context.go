@@ -0,0 +1,27 @@
+package usecases
+
+import "context"
+
+type platformFeeWaiverKeyType struct{}
+
+var platformFeeWaiverKey = platformFeeWaiverKeyType{}
+
+// withPlatformFeeWaived marks the context so CalculateFees skips the platform fee.
+// Bridge fees are unaffected and still apply on cross-chain routes.
+func withPlatformFeeWaived(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if platformFeeWaived(ctx) {
+		return ctx
+	}
+	return context.WithValue(ctx, platformFeeWaiverKey, true)
+}
+
+func platformFeeWaived(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	waived, _ := ctx.Value(platformFeeWaiverKey).(bool)
+	return waived
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/logger"
+)
+
+// gatewayPauseCacheTTL bounds how long a gateway's paused() answer is reused. It is short so
+// payments resume soon after the owner unpauses.
+const gatewayPauseCacheTTL = 15 * time.Second
+
+// paused() selector
+var gatewayPausedSelector = common.Hex2Bytes("5c975abb")
+
+// readGatewayPaused calls paused() on an EVM gateway. Gateways without an emergency stop revert,
+// which callers can tell apart from RPC failures with isExecutionFailure.
+func readGatewayPaused(ctx context.Context, client EVMClient, gatewayAddress string) (bool, error) {
+	out, err := client.CallView(ctx, gatewayAddress, gatewayPausedSelector)
+	if err != nil {
+		return false, err
+	}
+	if len(out) != 32 {
+		return false, fmt.Errorf("unexpected paused() result length %d", len(out))
+	}
+	return out[31] != 0, nil
+}
+
+type gatewayPauseKey struct {
+	chainID uuid.UUID
+	gateway string
+}
+
+type gatewayPauseEntry struct {
+	paused    bool
+	checkedAt time.Time
+}
+
+// gatewayPauseCache remembers each gateway's paused() answer for gatewayPauseCacheTTL. A nil
+// cache caches nothing.
+type gatewayPauseCache struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[gatewayPauseKey]gatewayPauseEntry
+}
+
+func newGatewayPauseCache() *gatewayPauseCache {
+	return &gatewayPauseCache{
+		now:     time.Now,
+		entries: make(map[gatewayPauseKey]gatewayPauseEntry),
+	}
+}
+
+func (c *gatewayPauseCache) get(chainID uuid.UUID, gateway string) (paused, ok bool) {
+	if c == nil {
+		return false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[gatewayPauseKey{chainID: chainID, gateway: strings.ToLower(gateway)}]
+	if !ok || c.now().Sub(entry.checkedAt) >= gatewayPauseCacheTTL {
+		return false, false
+	}
+	return entry.paused, true
+}
+
+func (c *gatewayPauseCache) put(chainID uuid.UUID, gateway string, paused bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.entries[gatewayPauseKey{chainID: chainID, gateway: strings.ToLower(gateway)}] = gatewayPauseEntry{paused: paused, checkedAt: c.now()}
+	c.mu.Unlock()
+}
+
+// checkGatewayPaused refuses a payment whose EVM gateway reports paused(), since the signed
+// transaction could only revert. It is a no-op unless the pause check is on. Gateways without
+// paused() count as running, and an RPC failure lets the payment through uncached.
+func (u *PaymentUsecase) checkGatewayPaused(ctx context.Context, chain *entities.Chain, gateway *entities.SmartContract) error {
+	if u.gatewayPause == nil || gateway == nil || chain == nil || !chain.ChainType().IsEVM() || u.clientFactory == nil {
+		return nil
+	}
+	paused, ok := u.gatewayPause.get(chain.ID, gateway.ContractAddress)
+	if !ok {
+		rpcURL := resolveChainRPCURL(chain)
+		if rpcURL == "" {
+			return nil
+		}
+		client, err := u.clientFactory.GetEVMClient(rpcURL)
+		if err != nil {
+			return nil
+		}
+		paused, err = readGatewayPaused(ctx, client, gateway.ContractAddress)
+		if err != nil && !isExecutionFailure(err) {
+			logger.WarnSampled(ctx, "gateway_pause_read|"+chain.ID.String(), "Failed to read gateway pause state",
+				zap.String("chain_id", chain.GetCAIP2ID()),
+				zap.String("gateway", gateway.ContractAddress),
+				zap.Error(err),
+			)
+			return nil
+		}
+		u.gatewayPause.put(chain.ID, gateway.ContractAddress, paused)
+	}
+	if !paused {
+		return nil
+	}
+	return domainerrors.NewAppError(
+		http.StatusServiceUnavailable,
+		domainerrors.CodeGatewayPaused,
+		fmt.Sprintf("the payment gateway on %s is paused; try again later", chain.GetCAIP2ID()),
+		domainerrors.ErrGatewayPaused,
+	)
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestNewPaymentUsecaseWithSettings_GatewayPauseCheck(t *testing.T) {
+	newUsecase := func(check bool) *PaymentUsecase {
+		return NewPaymentUsecaseWithSettings(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, PaymentSettings{GatewayPauseCheck: check})
+	}
+	require.Nil(t, newUsecase(false).gatewayPause)
+	require.NotNil(t, newUsecase(true).gatewayPause)
+}
+
+func TestPaymentUsecase_CheckGatewayPaused(t *testing.T) {
+	ctx := context.Background()
+	const rpcURL = "https://rpc.example"
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: rpcURL}
+	gateway := &entities.SmartContract{ContractAddress: "0x1111111111111111111111111111111111111111"}
+
+	calls := 0
+	answer := func() ([]byte, error) { return make([]byte, 32), nil }
+	client := &evmClientMock{callView: func(_ context.Context, to string, data []byte) ([]byte, error) {
+		calls++
+		require.Equal(t, gateway.ContractAddress, to)
+		require.True(t, bytes.Equal(gatewayPausedSelector, data))
+		return answer()
+	}}
+	now := time.Unix(1_700_000_000, 0)
+	cache := newGatewayPauseCache()
+	cache.now = func() time.Time { return now }
+	u := &PaymentUsecase{
+		clientFactory: &clientFactoryMock{clients: map[string]EVMClient{rpcURL: client}},
+		gatewayPause:  cache,
+	}
+
+	require.NoError(t, u.checkGatewayPaused(ctx, chain, gateway))
+	require.NoError(t, u.checkGatewayPaused(ctx, chain, gateway))
+	require.Equal(t, 1, calls)
+
+	// Once the TTL runs out the gateway is read again
+	answer = func() ([]byte, error) {
+		out := make([]byte, 32)
+		out[31] = 1
+		return out, nil
+	}
+	now = now.Add(gatewayPauseCacheTTL)
+	err := u.checkGatewayPaused(ctx, chain, gateway)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeGatewayPaused, appErr.Code)
+	require.Equal(t, domainerrors.ErrGatewayPaused, appErr.Err)
+	require.Equal(t, 503, appErr.Status)
+	require.Equal(t, 2, calls)
+
+	// A gateway without paused() counts as running
+	answer = func() ([]byte, error) { return nil, errors.New("execution reverted") }
+	now = now.Add(gatewayPauseCacheTTL)
+	require.NoError(t, u.checkGatewayPaused(ctx, chain, gateway))
+	require.NoError(t, u.checkGatewayPaused(ctx, chain, gateway))
+	require.Equal(t, 3, calls)
+
+	// RPC failures let the payment through and are not cached
+	answer = func() ([]byte, error) { return nil, errors.New("connection refused") }
+	now = now.Add(gatewayPauseCacheTTL)
+	require.NoError(t, u.checkGatewayPaused(ctx, chain, gateway))
+	require.NoError(t, u.checkGatewayPaused(ctx, chain, gateway))
+	require.Equal(t, 5, calls)
+
+	// Off, or on a non-EVM chain, nothing is read
+	require.NoError(t, u.checkGatewayPaused(ctx, &entities.Chain{ID: uuid.New(), ChainID: "devnet", Type: entities.ChainTypeSVM, RPCURL: rpcURL}, gateway))
+	u.gatewayPause = nil
+	require.NoError(t, u.checkGatewayPaused(ctx, chain, gateway))
+	require.Equal(t, 5, calls)
+}
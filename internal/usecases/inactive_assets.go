@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"fmt"
+	"net/http"
+
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// checkChainsActive refuses new payments on a deactivated chain. Payments already made on it
+// keep referencing it.
+func checkChainsActive(chains ...*entities.Chain) error {
+	for _, chain := range chains {
+		if chain == nil || chain.IsActive {
+			continue
+		}
+		return domainerrors.NewAppError(
+			http.StatusUnprocessableEntity,
+			domainerrors.CodeInactive,
+			fmt.Sprintf("chain %s is deactivated and cannot be used for new payments", chain.GetCAIP2ID()),
+			domainerrors.ErrInactive,
+		)
+	}
+	return nil
+}
+
+// checkTokensActive refuses new payments in a deactivated token. Payments already made in it
+// keep referencing it.
+func checkTokensActive(tokens ...*entities.Token) error {
+	for _, token := range tokens {
+		if token == nil || token.IsActive {
+			continue
+		}
+		return domainerrors.NewAppError(
+			http.StatusUnprocessableEntity,
+			domainerrors.CodeInactive,
+			fmt.Sprintf("token %s is deactivated and cannot be used for new payments", token.Symbol),
+			domainerrors.ErrInactive,
+		)
+	}
+	return nil
+}
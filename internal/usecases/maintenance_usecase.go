@@ -0,0 +1,157 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/logger"
+	"payment-kita.backend/pkg/redis"
+)
+
+const (
+	// maintenanceKey holds the admin-set MaintenanceStatus, shared by every replica
+	maintenanceKey = "maintenance:mode"
+	// maintenanceCacheTTL bounds how long a replica takes to notice a toggle made on another
+	maintenanceCacheTTL = 5 * time.Second
+	// maintenanceMaxRetryAfter caps the Retry-After an admin may announce
+	maintenanceMaxRetryAfter = 24 * time.Hour
+)
+
+var (
+	maintenanceRedisGet       = redis.Get
+	maintenanceRedisSet       = redis.Set
+	maintenanceRedisAvailable = func() bool { return redis.GetClient() != nil && redis.Available() }
+)
+
+// MaintenanceStatus is whether writes are being rejected for maintenance
+type MaintenanceStatus struct {
+	Enabled           bool       `json:"enabled"`
+	Message           string     `json:"message,omitempty"`
+	RetryAfterSeconds int        `json:"retryAfterSeconds"`
+	UpdatedAt         *time.Time `json:"updatedAt,omitempty"`
+}
+
+// SetMaintenanceInput toggles maintenance mode. RetryAfterSeconds nil keeps the configured one.
+type SetMaintenanceInput struct {
+	Enabled           bool
+	Message           string
+	RetryAfterSeconds *int
+}
+
+// MaintenanceUsecase resolves maintenance mode: the state an admin stored in Redis, else the
+// configured default. It is read on every write request, so the Redis value is cached briefly.
+// Without Redis a toggle only applies to the replica that received it, and only until Redis
+// is back.
+type MaintenanceUsecase struct {
+	defaults MaintenanceStatus
+	now      func() time.Time
+
+	mu       sync.RWMutex
+	cached   *MaintenanceStatus
+	loadedAt time.Time
+}
+
+// NewMaintenanceUsecase creates a new maintenance usecase starting from the configured state
+func NewMaintenanceUsecase(enabled bool, retryAfter time.Duration) *MaintenanceUsecase {
+	return &MaintenanceUsecase{
+		defaults: MaintenanceStatus{Enabled: enabled, RetryAfterSeconds: int(retryAfter / time.Second)},
+		now:      time.Now,
+	}
+}
+
+// Status returns the current maintenance state
+func (u *MaintenanceUsecase) Status(ctx context.Context) *MaintenanceStatus {
+	u.mu.RLock()
+	if u.cached != nil && u.now().Sub(u.loadedAt) < maintenanceCacheTTL {
+		defer u.mu.RUnlock()
+		return u.cached
+	}
+	u.mu.RUnlock()
+
+	status := u.load(ctx)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.cached, u.loadedAt = status, u.now()
+	return status
+}
+
+// MaintenanceState reports whether writes should be rejected, for middleware.Maintenance
+func (u *MaintenanceUsecase) MaintenanceState(ctx context.Context) (bool, time.Duration, string) {
+	status := u.Status(ctx)
+	return status.Enabled, time.Duration(status.RetryAfterSeconds) * time.Second, status.Message
+}
+
+// SetMaintenance turns maintenance mode on or off for every replica
+func (u *MaintenanceUsecase) SetMaintenance(ctx context.Context, input SetMaintenanceInput) (*MaintenanceStatus, error) {
+	status := &MaintenanceStatus{
+		Enabled:           input.Enabled,
+		Message:           strings.TrimSpace(input.Message),
+		RetryAfterSeconds: u.defaults.RetryAfterSeconds,
+	}
+	if input.RetryAfterSeconds != nil {
+		if *input.RetryAfterSeconds < 0 || time.Duration(*input.RetryAfterSeconds)*time.Second > maintenanceMaxRetryAfter {
+			return nil, domainerrors.BadRequest("retryAfterSeconds must be between 0 and 86400")
+		}
+		status.RetryAfterSeconds = *input.RetryAfterSeconds
+	}
+	now := u.now().UTC()
+	status.UpdatedAt = &now
+
+	shared := maintenanceRedisAvailable()
+	if shared {
+		raw, err := json.Marshal(status)
+		if err != nil {
+			return nil, err
+		}
+		if err := maintenanceRedisSet(ctx, maintenanceKey, string(raw), 0); err != nil {
+			return nil, err
+		}
+	} else {
+		logger.Warn(ctx, "Redis unavailable, maintenance mode applies to this replica only",
+			zap.Bool("enabled", status.Enabled))
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.cached, u.loadedAt = status, u.now()
+	return status, nil
+}
+
+// load reads the shared state. A Redis error keeps the last known state so a blip neither
+// starts nor ends maintenance.
+func (u *MaintenanceUsecase) load(ctx context.Context) *MaintenanceStatus {
+	u.mu.RLock()
+	fallback := u.cached
+	if fallback == nil {
+		defaults := u.defaults
+		fallback = &defaults
+	}
+	u.mu.RUnlock()
+
+	if !maintenanceRedisAvailable() {
+		return fallback
+	}
+	raw, err := maintenanceRedisGet(ctx, maintenanceKey)
+	if errors.Is(err, goredis.Nil) {
+		defaults := u.defaults
+		return &defaults
+	}
+	if err != nil {
+		logger.Warn(ctx, "failed to read maintenance mode, keeping the last known state", zap.Error(err))
+		return fallback
+	}
+	var status MaintenanceStatus
+	if err := json.Unmarshal([]byte(raw), &status); err != nil {
+		logger.Warn(ctx, "invalid maintenance mode state, keeping the last known state", zap.Error(err))
+		return fallback
+	}
+	return &status
+}
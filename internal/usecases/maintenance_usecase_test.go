@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	redisv9 "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	redispkg "payment-kita.backend/pkg/redis"
+)
+
+func TestMaintenanceUsecase_SharedAcrossReplicas(t *testing.T) {
+	srv, err := miniredis.Run()
+	if err != nil {
+		t.Skipf("skip: miniredis unavailable: %v", err)
+	}
+	defer srv.Close()
+	t.Cleanup(func() { redispkg.SetClient(nil) })
+	redispkg.SetClient(redisv9.NewClient(&redisv9.Options{Addr: srv.Addr()}))
+	ctx := context.Background()
+
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	admin := NewMaintenanceUsecase(false, 5*time.Minute)
+	replica := NewMaintenanceUsecase(false, 5*time.Minute)
+	replica.now = func() time.Time { return now }
+
+	// Nothing stored: the configured default
+	require.False(t, replica.Status(ctx).Enabled)
+	require.Equal(t, 300, replica.Status(ctx).RetryAfterSeconds)
+
+	retryAfter := 120
+	status, err := admin.SetMaintenance(ctx, SetMaintenanceInput{Enabled: true, Message: " migrating ", RetryAfterSeconds: &retryAfter})
+	require.NoError(t, err)
+	require.Equal(t, "migrating", status.Message)
+	require.True(t, srv.Exists(maintenanceKey))
+	require.True(t, admin.Status(ctx).Enabled)
+
+	// Other replicas notice once their cache expires
+	require.False(t, replica.Status(ctx).Enabled)
+	now = now.Add(maintenanceCacheTTL)
+	enabled, wait, message := replica.MaintenanceState(ctx)
+	require.True(t, enabled)
+	require.Equal(t, 2*time.Minute, wait)
+	require.Equal(t, "migrating", message)
+
+	tooLong := 86401
+	_, err = admin.SetMaintenance(ctx, SetMaintenanceInput{Enabled: true, RetryAfterSeconds: &tooLong})
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+
+	// A Redis blip keeps the last known state
+	srv.SetError("LOADING Redis is loading the dataset in memory")
+	now = now.Add(maintenanceCacheTTL)
+	require.True(t, replica.Status(ctx).Enabled)
+}
+
+func TestMaintenanceUsecase_WithoutRedis(t *testing.T) {
+	prevAvailable, prevGet := maintenanceRedisAvailable, maintenanceRedisGet
+	t.Cleanup(func() { maintenanceRedisAvailable, maintenanceRedisGet = prevAvailable, prevGet })
+	maintenanceRedisAvailable = func() bool { return false }
+	maintenanceRedisGet = func(context.Context, string) (string, error) {
+		return "", errors.New("must not be called")
+	}
+	ctx := context.Background()
+
+	u := NewMaintenanceUsecase(true, time.Minute)
+	require.True(t, u.Status(ctx).Enabled)
+
+	// The toggle applies to this replica
+	status, err := u.SetMaintenance(ctx, SetMaintenanceInput{Enabled: false})
+	require.NoError(t, err)
+	require.Equal(t, 60, status.RetryAfterSeconds)
+	u.loadedAt = time.Time{}
+	require.False(t, u.Status(ctx).Enabled)
+}
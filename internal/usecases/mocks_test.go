@@ -2,11 +2,14 @@ package usecases_test
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
+	"github.com/volatiletech/null/v8"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -56,9 +59,9 @@ func (m *MockPaymentRepository) UpdateStatus(ctx context.Context, id uuid.UUID,
 	return args.Error(0)
 }
 
-func (m *MockPaymentRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.Payment, int, error) {
-	args := m.Called(ctx, userID, limit, offset)
-	return args.Get(0).([]*entities.Payment), args.Int(1), args.Error(2)
+func (m *MockPaymentRepository) GetByUserID(ctx context.Context, userID uuid.UUID, mode entities.PaymentMode, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	args := m.Called(ctx, userID, mode, pagination)
+	return args.Get(0).([]*entities.Payment), args.Get(1).(int64), args.Error(2)
 }
 
 func (m *MockPaymentRepository) List(ctx context.Context, limit, offset int) ([]*entities.Payment, int, error) {
@@ -76,14 +79,35 @@ func (m *MockPaymentRepository) MarkRefunded(ctx context.Context, id uuid.UUID)
 	return args.Error(0)
 }
 
-func (m *MockPaymentRepository) GetByMerchantID(ctx context.Context, merchantID uuid.UUID, limit, offset int) ([]*entities.Payment, int, error) {
-	args := m.Called(ctx, merchantID, limit, offset)
+func (m *MockPaymentRepository) GetByMerchantID(ctx context.Context, merchantID uuid.UUID, mode entities.PaymentMode, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	args := m.Called(ctx, merchantID, mode, pagination)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entities.Payment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPaymentRepository) GetByStatus(ctx context.Context, status entities.PaymentStatus, limit, offset int) ([]*entities.Payment, int, error) {
+	args := m.Called(ctx, status, limit, offset)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]*entities.Payment), args.Get(1).(int), args.Error(2)
 }
 
+func (m *MockPaymentRepository) ResolvePendingApproval(ctx context.Context, id uuid.UUID, status entities.PaymentStatus, failureReason null.String, expiresAt *time.Time) error {
+	args := m.Called(ctx, id, status, failureReason, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) GetByMerchantExternalRef(ctx context.Context, merchantID uuid.UUID, mode entities.PaymentMode, ref string, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	args := m.Called(ctx, merchantID, mode, ref, pagination)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entities.Payment), args.Get(1).(int64), args.Error(2)
+}
+
 // Mock PaymentEventRepository
 type MockPaymentEventRepository struct {
 	mock.Mock
@@ -172,6 +196,9 @@ func (m *MockChainRepository) Update(ctx context.Context, chain *entities.Chain)
 func (m *MockChainRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
 }
+func (m *MockChainRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
 func (m *MockChainRepository) List(ctx context.Context) ([]*entities.Chain, error) {
 	args := m.Called(ctx)
 	return args.Get(0).([]*entities.Chain), args.Error(1)
@@ -192,6 +219,14 @@ func (m *MockChainRepository) GetActive(ctx context.Context, pagination utils.Pa
 	return args.Get(0).([]*entities.Chain), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockChainRepository) GetPaginated(ctx context.Context, includeInactive bool, pagination utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	args := m.Called(ctx, includeInactive, pagination)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entities.Chain), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockChainRepository) GetAll(ctx context.Context) ([]*entities.Chain, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -280,6 +315,14 @@ func (m *MockTokenRepository) GetBySymbol(ctx context.Context, symbol string, ch
 	return args.Get(0).(*entities.Token), args.Error(1)
 }
 
+func (m *MockTokenRepository) GetActiveBySymbol(ctx context.Context, symbol string) ([]*entities.Token, error) {
+	args := m.Called(ctx, symbol)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.Token), args.Error(1)
+}
+
 func (m *MockTokenRepository) GetStablecoins(ctx context.Context) ([]*entities.Token, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -321,18 +364,30 @@ func (m *MockTokenRepository) GetAll(ctx context.Context) ([]*entities.Token, er
 	return args.Get(0).([]*entities.Token), args.Error(1)
 }
 
-func (m *MockTokenRepository) GetAllTokens(ctx context.Context, chainID *uuid.UUID, search *string, pagination utils.PaginationParams) ([]*entities.Token, int64, error) {
-	args := m.Called(ctx, chainID, search, pagination)
+func (m *MockTokenRepository) GetAllTokens(ctx context.Context, chainID *uuid.UUID, search *string, includeInactive bool, pagination utils.PaginationParams) ([]*entities.Token, int64, error) {
+	args := m.Called(ctx, chainID, search, includeInactive, pagination)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
 	return args.Get(0).([]*entities.Token), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockTokenRepository) BulkSetActive(ctx context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error) {
+	args := m.Called(ctx, ids, active)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]bool), args.Error(1)
+}
+
 func (m *MockTokenRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
 }
 
+func (m *MockTokenRepository) HardDelete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
 // Mock SmartContractRepository
 type MockSmartContractRepository struct {
 	mock.Mock
@@ -377,8 +432,8 @@ func (m *MockSmartContractRepository) GetByChain(ctx context.Context, chainID uu
 	return args.Get(0).([]*entities.SmartContract), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockSmartContractRepository) GetFiltered(ctx context.Context, chainID *uuid.UUID, contractType entities.SmartContractType, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
-	args := m.Called(ctx, chainID, contractType, pagination)
+func (m *MockSmartContractRepository) GetFiltered(ctx context.Context, filter repositories.SmartContractFilter, pagination utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+	args := m.Called(ctx, filter, pagination)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
@@ -395,6 +450,22 @@ func (m *MockSmartContractRepository) Delete(ctx context.Context, id uuid.UUID)
 	return m.Called(ctx, id).Error(0)
 }
 
+func (m *MockSmartContractRepository) BulkSetActive(ctx context.Context, ids []uuid.UUID, active bool) (map[uuid.UUID]bool, error) {
+	args := m.Called(ctx, ids, active)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]bool), args.Error(1)
+}
+
+func (m *MockSmartContractRepository) Activate(ctx context.Context, id uuid.UUID) (*entities.SmartContract, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.SmartContract), args.Error(1)
+}
+
 func (m *MockSmartContractRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
 }
@@ -475,6 +546,9 @@ func (m *MockWalletRepository) Delete(ctx context.Context, id uuid.UUID) error {
 func (m *MockWalletRepository) SetPrimary(ctx context.Context, userID, walletID uuid.UUID) error {
 	return m.Called(ctx, userID, walletID).Error(0)
 }
+func (m *MockWalletRepository) ClaimUnowned(ctx context.Context, walletID, userID uuid.UUID, isPrimary bool) error {
+	return m.Called(ctx, walletID, userID, isPrimary).Error(0)
+}
 
 func (m *MockWalletRepository) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
@@ -488,6 +562,9 @@ type MockPaymentRequestRepository struct {
 func (m *MockPaymentRequestRepository) Create(ctx context.Context, request *entities.PaymentRequest) error {
 	return m.Called(ctx, request).Error(0)
 }
+func (m *MockPaymentRequestRepository) CreateBatch(ctx context.Context, requests []*entities.PaymentRequest) error {
+	return m.Called(ctx, requests).Error(0)
+}
 func (m *MockPaymentRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.PaymentRequest, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -495,16 +572,27 @@ func (m *MockPaymentRequestRepository) GetByID(ctx context.Context, id uuid.UUID
 	}
 	return args.Get(0).(*entities.PaymentRequest), args.Error(1)
 }
-func (m *MockPaymentRequestRepository) GetByMerchantID(ctx context.Context, merchantID uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error) {
-	args := m.Called(ctx, merchantID, limit, offset)
+func (m *MockPaymentRequestRepository) GetByMerchantID(ctx context.Context, merchantID uuid.UUID, filter repositories.PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error) {
+	args := m.Called(ctx, merchantID, filter, limit, offset)
 	return args.Get(0).([]*entities.PaymentRequest), args.Int(1), args.Error(2)
 }
 func (m *MockPaymentRequestRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entities.PaymentRequestStatus) error {
 	return m.Called(ctx, id, status).Error(0)
 }
+func (m *MockPaymentRequestRepository) CancelPending(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
 func (m *MockPaymentRequestRepository) MarkCompleted(ctx context.Context, id uuid.UUID, txHash string) error {
 	return m.Called(ctx, id, txHash).Error(0)
 }
+func (m *MockPaymentRequestRepository) MarkProcessing(ctx context.Context, id uuid.UUID, txHash string, blockNumber int64) error {
+	return m.Called(ctx, id, txHash, blockNumber).Error(0)
+}
+func (m *MockPaymentRequestRepository) GetProcessing(ctx context.Context, limit int) ([]*entities.PaymentRequest, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]*entities.PaymentRequest), args.Error(1)
+}
 func (m *MockPaymentRequestRepository) GetExpiredPending(ctx context.Context, limit int) ([]*entities.PaymentRequest, error) {
 	args := m.Called(ctx, limit)
 	return args.Get(0).([]*entities.PaymentRequest), args.Error(1)
@@ -553,9 +641,9 @@ func (m *MockUserRepository) SoftDelete(ctx context.Context, id uuid.UUID) error
 	return m.Called(ctx, id).Error(0)
 }
 
-func (m *MockUserRepository) List(ctx context.Context, search string) ([]*entities.User, error) {
-	args := m.Called(ctx, search)
-	return args.Get(0).([]*entities.User), args.Error(1)
+func (m *MockUserRepository) List(ctx context.Context, filter repositories.UserFilter, pagination utils.PaginationParams) ([]*entities.User, int64, error) {
+	args := m.Called(ctx, filter, pagination)
+	return args.Get(0).([]*entities.User), args.Get(1).(int64), args.Error(2)
 }
 
 // Mock ApiKeyRepository
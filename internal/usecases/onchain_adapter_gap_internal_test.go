@@ -146,7 +146,7 @@ func TestOnchainAdapterUsecase_ExecuteOnchainTx_Errors(t *testing.T) {
 		_, err := executeOnchainTx(
 			context.Background(),
 			"http://127.0.0.1:0",
-			"0x4c0883a69102937d6231471b5dbb6204fe51296170827931e8f95f6f8d5d2f66",
+			mustOwnerSigner(t, "0x4c0883a69102937d6231471b5dbb6204fe51296170827931e8f95f6f8d5d2f66"),
 			"0x0000000000000000000000000000000000000001",
 			parseABIForOnchainGapTest(t, `[]`),
 			"noop",
@@ -155,26 +155,9 @@ func TestOnchainAdapterUsecase_ExecuteOnchainTx_Errors(t *testing.T) {
 	})
 
 	t.Run("invalid owner private key", func(t *testing.T) {
-		srv := newSafeHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			defer r.Body.Close()
-			var req map[string]interface{}
-			_ = json.NewDecoder(r.Body).Decode(&req)
-			resp := map[string]interface{}{"jsonrpc": "2.0", "id": req["id"], "result": "0x1"}
-			w.Header().Set("Content-Type", "application/json")
-			_ = json.NewEncoder(w).Encode(resp)
-		}))
-		defer srv.Close()
-
-		_, err := executeOnchainTx(
-			context.Background(),
-			srv.URL,
-			"not-a-private-key",
-			"0x0000000000000000000000000000000000000001",
-			parseABIForOnchainGapTest(t, `[]`),
-			"noop",
-		)
+		_, err := blockchain.NewPrivateKeySigner("not-a-private-key")
 		require.Error(t, err)
-		require.Contains(t, strings.ToLower(err.Error()), "invalid input")
+		require.Contains(t, strings.ToLower(err.Error()), "invalid private key")
 	})
 
 	t.Run("chain id rpc error", func(t *testing.T) {
@@ -196,7 +179,7 @@ func TestOnchainAdapterUsecase_ExecuteOnchainTx_Errors(t *testing.T) {
 		_, err := executeOnchainTx(
 			context.Background(),
 			srv.URL,
-			"0x4c0883a69102937d6231471b5dbb6204fe51296170827931e8f95f6f8d5d2f66",
+			mustOwnerSigner(t, "0x4c0883a69102937d6231471b5dbb6204fe51296170827931e8f95f6f8d5d2f66"),
 			common.HexToAddress("0x0000000000000000000000000000000000000001").Hex(),
 			parseABIForOnchainGapTest(t, `[]`),
 			"noop",
@@ -66,7 +66,7 @@ func TestOnchainAdapterUsecase_RegisterAdapter_InvalidAddress(t *testing.T) {
 }
 
 func TestOnchainAdapterUsecase_SendTx_OwnerKeyMissing(t *testing.T) {
-	u := &OnchainAdapterUsecase{ownerPrivateKey: ""}
+	u := &OnchainAdapterUsecase{}
 	_, err := u.sendTx(context.Background(), uuid.New(), "0x0000000000000000000000000000000000000001", abi.ABI{}, "set", "arg")
 	require.Error(t, err)
 	require.Equal(t, "invalid input", err.Error())
@@ -85,8 +85,8 @@ func TestOnchainAdapterUsecase_SendTx_Branches(t *testing.T) {
 
 	t.Run("source chain not found", func(t *testing.T) {
 		u := &OnchainAdapterUsecase{
-			ownerPrivateKey: "0xabc123",
-			chainRepo:       &quoteChainRepoStub{},
+			ownerSigner: mustOwnerSigner(t, validKey),
+			chainRepo:   &quoteChainRepoStub{},
 		}
 		_, err := u.sendTx(context.Background(), uuid.New(), "0x0000000000000000000000000000000000000001", abi.ABI{}, "set", "arg")
 		require.Error(t, err)
@@ -95,7 +95,7 @@ func TestOnchainAdapterUsecase_SendTx_Branches(t *testing.T) {
 	t.Run("no active rpc", func(t *testing.T) {
 		chainID := uuid.New()
 		u := &OnchainAdapterUsecase{
-			ownerPrivateKey: "0xabc123",
+			ownerSigner: mustOwnerSigner(t, validKey),
 			chainRepo: &quoteChainRepoStub{
 				byID: map[uuid.UUID]*entities.Chain{
 					chainID: {ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM},
@@ -124,8 +124,9 @@ func TestOnchainAdapterUsecase_SendTx_Branches(t *testing.T) {
 		defer srv.Close()
 
 		chainID := uuid.New()
+		_, keyErr := blockchain.NewPrivateKeySigner("not-a-private-key")
 		u := &OnchainAdapterUsecase{
-			ownerPrivateKey: "not-a-private-key",
+			ownerSignerErr: keyErr,
 			chainRepo: &quoteChainRepoStub{
 				byID: map[uuid.UUID]*entities.Chain{
 					chainID: {ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: srv.URL},
@@ -155,7 +156,7 @@ func TestOnchainAdapterUsecase_SendTx_Branches(t *testing.T) {
 
 		chainID := uuid.New()
 		u := &OnchainAdapterUsecase{
-			ownerPrivateKey: validKey,
+			ownerSigner: mustOwnerSigner(t, validKey),
 			chainRepo: &quoteChainRepoStub{
 				byID: map[uuid.UUID]*entities.Chain{
 					chainID: {ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: srv.URL},
@@ -186,7 +187,7 @@ func TestOnchainAdapterUsecase_SendTx_Branches(t *testing.T) {
 
 		chainID := uuid.New()
 		u := &OnchainAdapterUsecase{
-			ownerPrivateKey: validKey,
+			ownerSigner: mustOwnerSigner(t, validKey),
 			chainRepo: &quoteChainRepoStub{
 				byID: map[uuid.UUID]*entities.Chain{
 					chainID: {ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: srv.URL},
@@ -223,7 +224,7 @@ func TestOnchainAdapterUsecase_SendTx_Branches(t *testing.T) {
 
 		chainID := uuid.New()
 		u := &OnchainAdapterUsecase{
-			ownerPrivateKey: validKey,
+			ownerSigner: mustOwnerSigner(t, validKey),
 			chainRepo: &quoteChainRepoStub{
 				byID: map[uuid.UUID]*entities.Chain{
 					chainID: {ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: srv.URL},
@@ -242,7 +243,7 @@ func TestOnchainAdapterUsecase_SendTx_Branches(t *testing.T) {
 
 		chainID := uuid.New()
 		u := &OnchainAdapterUsecase{
-			ownerPrivateKey: validKey,
+			ownerSigner: mustOwnerSigner(t, validKey),
 			chainRepo: &quoteChainRepoStub{
 				byID: map[uuid.UUID]*entities.Chain{
 					chainID: {ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: "mock://chain"},
@@ -251,14 +252,14 @@ func TestOnchainAdapterUsecase_SendTx_Branches(t *testing.T) {
 		}
 		parsed := mustParseABI(`[{"inputs":[{"internalType":"uint256","name":"x","type":"uint256"}],"name":"setValue","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
 
-		executeOnchainTx = func(context.Context, string, string, string, abi.ABI, string, ...interface{}) (string, error) {
+		executeOnchainTx = func(context.Context, string, blockchain.TxSigner, string, abi.ABI, string, ...interface{}) (string, error) {
 			return "", errors.New("tx failed")
 		}
 		_, err := u.sendTx(context.Background(), chainID, "0x0000000000000000000000000000000000000001", parsed, "setValue", 1)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "tx failed")
 
-		executeOnchainTx = func(context.Context, string, string, string, abi.ABI, string, ...interface{}) (string, error) {
+		executeOnchainTx = func(context.Context, string, blockchain.TxSigner, string, abi.ABI, string, ...interface{}) (string, error) {
 			return "0xabc", nil
 		}
 		tx, err := u.sendTx(context.Background(), chainID, "0x0000000000000000000000000000000000000001", parsed, "setValue", 1)
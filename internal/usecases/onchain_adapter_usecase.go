@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
@@ -10,7 +11,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -102,7 +102,7 @@ var (
 		}
 		return tx.Hash().Hex(), nil
 	}
-	executeOnchainTx = func(ctx context.Context, rpcURL string, ownerPrivateKey string, contractAddress string, parsedABI abi.ABI, method string, args ...interface{}) (string, error) {
+	executeOnchainTx = func(ctx context.Context, rpcURL string, signer blockchain.TxSigner, contractAddress string, parsedABI abi.ABI, method string, args ...interface{}) (string, error) {
 		client, err := ethclient.DialContext(ctx, rpcURL)
 		if err != nil {
 			logger.Error(ctx, "failed to connect to RPC", zap.String("rpc_url", rpcURL), zap.Error(err))
@@ -110,12 +110,6 @@ var (
 		}
 		defer client.Close()
 
-		privateKeyHex := strings.TrimPrefix(ownerPrivateKey, "0x")
-		privateKey, err := crypto.HexToECDSA(privateKeyHex)
-		if err != nil {
-			return "", domainerrors.BadRequest("invalid owner private key format")
-		}
-
 		chainID, err := client.ChainID(ctx)
 		if err != nil {
 			logger.Error(ctx, "failed to get chain ID", zap.Error(err))
@@ -124,11 +118,17 @@ var (
 		if chainID == nil {
 			return "", domainerrors.NewError("chain id is nil from RPC", nil)
 		}
-		auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
-		if err != nil {
-			return "", domainerrors.NewError("failed to create transactor: "+err.Error(), err)
+		auth := newSignerTransactor(ctx, signer, chainID)
+
+		if data, packErr := parsedABI.Pack(method, args...); packErr == nil {
+			if err := simulateContractCall(ctx, client, auth.From, contractAddress, data); err != nil {
+				logger.Warn(ctx, "on-chain transaction simulation failed", zap.String("method", method), zap.Error(err))
+				return "", simulationRevertError(method, parsedABI, err)
+			}
+			if err := checkOwnerGas(ctx, client, auth.From, contractAddress, data); err != nil {
+				return "", err
+			}
 		}
-		auth.Context = ctx
 
 		txHash, err := performContractTransact(client, contractAddress, parsedABI, auth, method, args...)
 		if err != nil {
@@ -173,16 +173,20 @@ type OnchainAdapterStatus struct {
 	StargatePeer                  string `json:"stargatePeer"`
 	StargateOptionsHex            string `json:"stargateOptionsHex"`
 	StargateComposeGasLimit       string `json:"stargateComposeGasLimit"`
+	OwnerAddress                  string            `json:"ownerAddress,omitempty"`
+	OwnerGasBalances              []OwnerGasBalance `json:"ownerGasBalances,omitempty"`
 }
 
 type OnchainAdapterUsecase struct {
 	*ABIResolverMixin
 	chainRepo       repositories.ChainRepository
 	contractRepo    repositories.SmartContractRepository
-	clientFactory   *blockchain.ClientFactory
+	clientFactory   ClientFactory
 	chainResolver   *ChainResolver
-	ownerPrivateKey string
-	adminOps        *evmAdminOpsService
+	ownerSigner     blockchain.TxSigner
+	// ownerSignerErr is reported when an admin tx is attempted with an unusable owner key
+	ownerSignerErr error
+	adminOps       *evmAdminOpsService
 }
 
 func NewOnchainAdapterUsecase(
@@ -195,9 +199,11 @@ func NewOnchainAdapterUsecase(
 		ABIResolverMixin: NewABIResolverMixin(contractRepo),
 		chainRepo:        chainRepo,
 		contractRepo:     contractRepo,
-		clientFactory:    clientFactory,
+		clientFactory:    NewEVMClientFactory(clientFactory),
 		chainResolver:    NewChainResolver(chainRepo),
-		ownerPrivateKey:  strings.TrimSpace(ownerPrivateKey),
+	}
+	if key := strings.TrimSpace(ownerPrivateKey); key != "" {
+		u.ownerSigner, u.ownerSignerErr = blockchain.NewPrivateKeySigner(key)
 	}
 
 	u.adminOps = newEVMAdminOpsService(
@@ -273,6 +279,7 @@ func NewOnchainAdapterUsecase(
 			return parsedABI.Unpack(method, out)
 		},
 	)
+	u.adminOps.verifyOwner = u.verifyContractOwner
 
 	return u
 }
@@ -390,6 +397,8 @@ func (u *OnchainAdapterUsecase) GetStatus(ctx context.Context, sourceChainInput,
 		}
 	}
 
+	ownerAddress, ownerBalances := u.ownerGasBalances(ctx)
+
 	return &OnchainAdapterStatus{
 		SourceChainID:                  sourceChain.GetCAIP2ID(),
 		DestChainID:                    destCAIP2,
@@ -424,6 +433,8 @@ func (u *OnchainAdapterUsecase) GetStatus(ctx context.Context, sourceChainInput,
 		StargatePeer:                  stargatePeer,
 		StargateOptionsHex:            stargateOptionsHex,
 		StargateComposeGasLimit:       stargateComposeGasLimit,
+		OwnerAddress:                  ownerAddress,
+		OwnerGasBalances:              ownerBalances,
 	}, nil
 }
 
@@ -737,7 +748,7 @@ func convertArg(t abi.Type, val interface{}) (interface{}, error) {
 func (u *OnchainAdapterUsecase) resolveEVMContext(
 	ctx context.Context,
 	sourceChainInput, destChainInput string,
-) (*entities.Chain, uuid.UUID, string, *entities.SmartContract, *entities.SmartContract, *blockchain.EVMClient, error) {
+) (*entities.Chain, uuid.UUID, string, *entities.SmartContract, *entities.SmartContract, EVMClient, error) {
 	sourceChain, sourceChainID, destCAIP2, gateway, router, err := u.resolveEVMContextCore(ctx, sourceChainInput, destChainInput)
 	if err != nil {
 		return nil, uuid.Nil, "", nil, nil, nil, err
@@ -794,8 +805,11 @@ func (u *OnchainAdapterUsecase) sendTx(
 	method string,
 	args ...interface{},
 ) (string, error) {
-	if u.ownerPrivateKey == "" {
-		return "", domainerrors.BadRequest("owner private key is not configured")
+	if u.ownerSignerErr != nil {
+		return "", domainerrors.BadRequest("invalid owner private key format")
+	}
+	if u.ownerSigner == nil {
+		return "", domainerrors.BadRequest("owner signer is not configured")
 	}
 	chain, err := u.chainRepo.GetByID(ctx, sourceChainID)
 	if err != nil {
@@ -809,10 +823,16 @@ func (u *OnchainAdapterUsecase) sendTx(
 	const maxAttempts = 4
 	var lastErr error
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		txHash, err := executeOnchainTx(ctx, rpcURL, u.ownerPrivateKey, contractAddress, parsedABI, method, args...)
+		txHash, err := executeOnchainTx(ctx, rpcURL, u.ownerSigner, contractAddress, parsedABI, method, args...)
 		if err == nil {
+			// Any owner tx may repoint a gateway's vault, so cached vault() answers go
+			invalidateVaultAddresses(ctx)
 			return txHash, nil
 		}
+		var gasErr *ErrInsufficientOwnerGas
+		if errors.As(err, &gasErr) {
+			return "", insufficientOwnerGasError(gasErr, chain)
+		}
 		lastErr = err
 		if !isRetriableNonceError(err) || attempt == maxAttempts {
 			break
@@ -838,15 +858,15 @@ func isRetriableNonceError(err error) bool {
 		strings.Contains(msg, "replacement transaction underpriced")
 }
 
-func (u *OnchainAdapterUsecase) callDefaultBridgeType(ctx context.Context, client *blockchain.EVMClient, gatewayAddress string, parsedABI abi.ABI, destCAIP2 string) (uint8, error) {
+func (u *OnchainAdapterUsecase) callDefaultBridgeType(ctx context.Context, client EVMClient, gatewayAddress string, parsedABI abi.ABI, destCAIP2 string) (uint8, error) {
 	return callTypedView[uint8](ctx, client, gatewayAddress, parsedABI, "defaultBridgeTypes", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callHasAdapter(ctx context.Context, client *blockchain.EVMClient, routerAddress string, parsedABI abi.ABI, destCAIP2 string, bridgeType uint8) (bool, error) {
+func (u *OnchainAdapterUsecase) callHasAdapter(ctx context.Context, client EVMClient, routerAddress string, parsedABI abi.ABI, destCAIP2 string, bridgeType uint8) (bool, error) {
 	return callTypedView[bool](ctx, client, routerAddress, parsedABI, "hasAdapter", destCAIP2, bridgeType)
 }
 
-func (u *OnchainAdapterUsecase) callGetAdapter(ctx context.Context, client *blockchain.EVMClient, routerAddress string, parsedABI abi.ABI, destCAIP2 string, bridgeType uint8) (string, error) {
+func (u *OnchainAdapterUsecase) callGetAdapter(ctx context.Context, client EVMClient, routerAddress string, parsedABI abi.ABI, destCAIP2 string, bridgeType uint8) (string, error) {
 	value, err := callTypedView[common.Address](ctx, client, routerAddress, parsedABI, "getAdapter", destCAIP2, bridgeType)
 	if err != nil {
 		return "", err
@@ -854,59 +874,59 @@ func (u *OnchainAdapterUsecase) callGetAdapter(ctx context.Context, client *bloc
 	return value.Hex(), nil
 }
 
-func (u *OnchainAdapterUsecase) callHyperbridgeConfigured(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (bool, error) {
+func (u *OnchainAdapterUsecase) callHyperbridgeConfigured(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (bool, error) {
 	return callTypedView[bool](ctx, client, adapterAddress, parsedABI, "isChainConfigured", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callHyperbridgeBytes(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, method, destCAIP2 string) ([]byte, error) {
+func (u *OnchainAdapterUsecase) callHyperbridgeBytes(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, method, destCAIP2 string) ([]byte, error) {
 	return callTypedView[[]byte](ctx, client, adapterAddress, parsedABI, method, destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callCCIPSelector(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (uint64, error) {
+func (u *OnchainAdapterUsecase) callCCIPSelector(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (uint64, error) {
 	return callTypedView[uint64](ctx, client, adapterAddress, parsedABI, "chainSelectors", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callCCIPDestinationAdapter(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) ([]byte, error) {
+func (u *OnchainAdapterUsecase) callCCIPDestinationAdapter(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) ([]byte, error) {
 	return callTypedView[[]byte](ctx, client, adapterAddress, parsedABI, "destinationAdapters", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callCCIPDestinationGasLimit(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (*big.Int, error) {
+func (u *OnchainAdapterUsecase) callCCIPDestinationGasLimit(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (*big.Int, error) {
 	return callTypedView[*big.Int](ctx, client, adapterAddress, parsedABI, "destinationGasLimits", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callCCIPDestinationExtraArgs(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) ([]byte, error) {
+func (u *OnchainAdapterUsecase) callCCIPDestinationExtraArgs(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) ([]byte, error) {
 	return callTypedView[[]byte](ctx, client, adapterAddress, parsedABI, "destinationExtraArgs", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callCCIPDestinationFeeToken(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (common.Address, error) {
+func (u *OnchainAdapterUsecase) callCCIPDestinationFeeToken(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (common.Address, error) {
 	return callTypedView[common.Address](ctx, client, adapterAddress, parsedABI, "destinationFeeTokens", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callStargateConfigured(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (bool, error) {
+func (u *OnchainAdapterUsecase) callStargateConfigured(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (bool, error) {
 	return callTypedView[bool](ctx, client, adapterAddress, parsedABI, "isRouteConfigured", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callTokenGatewayConfigured(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (bool, error) {
+func (u *OnchainAdapterUsecase) callTokenGatewayConfigured(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (bool, error) {
 	return callTypedView[bool](ctx, client, adapterAddress, parsedABI, "isRouteConfigured", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callTokenGatewaySettlementExecutor(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (common.Address, error) {
+func (u *OnchainAdapterUsecase) callTokenGatewaySettlementExecutor(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (common.Address, error) {
 	return callTypedView[common.Address](ctx, client, adapterAddress, parsedABI, "settlementExecutors", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callTokenGatewayNativeCost(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (*big.Int, error) {
+func (u *OnchainAdapterUsecase) callTokenGatewayNativeCost(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (*big.Int, error) {
 	return callTypedView[*big.Int](ctx, client, adapterAddress, parsedABI, "nativeCosts", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callTokenGatewayRelayerFee(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (*big.Int, error) {
+func (u *OnchainAdapterUsecase) callTokenGatewayRelayerFee(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (*big.Int, error) {
 	return callTypedView[*big.Int](ctx, client, adapterAddress, parsedABI, "relayerFees", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callStargateDstEid(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (uint32, error) {
+func (u *OnchainAdapterUsecase) callStargateDstEid(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (uint32, error) {
 	return callTypedView[uint32](ctx, client, adapterAddress, parsedABI, "dstEids", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callStargatePeer(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (common.Hash, error) {
+func (u *OnchainAdapterUsecase) callStargatePeer(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (common.Hash, error) {
 	value, err := callTypedView[[32]byte](ctx, client, adapterAddress, parsedABI, "peers", destCAIP2)
 	if err != nil {
 		return common.Hash{}, err
@@ -914,14 +934,14 @@ func (u *OnchainAdapterUsecase) callStargatePeer(ctx context.Context, client *bl
 	return common.BytesToHash(value[:]), nil
 }
 
-func (u *OnchainAdapterUsecase) callStargateOptions(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) ([]byte, error) {
+func (u *OnchainAdapterUsecase) callStargateOptions(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) ([]byte, error) {
 	if _, ok := parsedABI.Methods["destinationExtraOptions"]; ok {
 		return callTypedView[[]byte](ctx, client, adapterAddress, parsedABI, "destinationExtraOptions", destCAIP2)
 	}
 	return callTypedView[[]byte](ctx, client, adapterAddress, parsedABI, "enforcedOptions", destCAIP2)
 }
 
-func (u *OnchainAdapterUsecase) callStargateComposeGasLimit(ctx context.Context, client *blockchain.EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (*big.Int, error) {
+func (u *OnchainAdapterUsecase) callStargateComposeGasLimit(ctx context.Context, client EVMClient, adapterAddress string, parsedABI abi.ABI, destCAIP2 string) (*big.Int, error) {
 	if _, ok := parsedABI.Methods["destinationComposeGasLimits"]; ok {
 		return callTypedView[*big.Int](ctx, client, adapterAddress, parsedABI, "destinationComposeGasLimits", destCAIP2)
 	}
@@ -930,7 +950,7 @@ func (u *OnchainAdapterUsecase) callStargateComposeGasLimit(ctx context.Context,
 
 func callTypedView[T any](
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	contractAddress string,
 	parsedABI abi.ABI,
 	method string,
@@ -0,0 +1,174 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// diagnosticsChainTimeout bounds all RPC work for one chain in the diagnostics report
+const diagnosticsChainTimeout = 10 * time.Second
+
+// FallbackOwnableABI covers the Ownable / AccessControl views the admin contracts expose
+var FallbackOwnableABI = mustParseABI(`[
+	{"inputs":[],"name":"owner","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"bytes32","name":"role","type":"bytes32"},{"internalType":"address","name":"account","type":"address"}],"name":"hasRole","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"}
+]`)
+
+// probeRPC checks that rpcURL answers by asking for its chain id
+var probeRPC = func(ctx context.Context, rpcURL string) error {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	_, err = client.ChainID(ctx)
+	return err
+}
+
+// OnchainDiagnostics answers "can we actually transact?" for every active EVM chain
+type OnchainDiagnostics struct {
+	OwnerAddress  string             `json:"ownerAddress,omitempty"`
+	OwnerKeyError string             `json:"ownerKeyError,omitempty"`
+	Ready         bool               `json:"ready"`
+	Chains        []ChainDiagnostics `json:"chains"`
+}
+
+// ChainDiagnostics is the readiness of one chain. Ready means every check passed; the *Error
+// fields say why a check could not run.
+type ChainDiagnostics struct {
+	ChainID             string   `json:"chainId"`
+	Name                string   `json:"name"`
+	RPCReachable        bool     `json:"rpcReachable"`
+	RPCError            string   `json:"rpcError,omitempty"`
+	OwnerBalance        string   `json:"ownerBalance,omitempty"` // wei
+	OwnerBalanceError   string   `json:"ownerBalanceError,omitempty"`
+	GatewayAddress      string   `json:"gatewayAddress,omitempty"`
+	RouterAddress       string   `json:"routerAddress,omitempty"`
+	VaultAddress        string   `json:"vaultAddress,omitempty"`
+	MissingContracts    []string `json:"missingContracts,omitempty"`
+	GatewayOwner        string   `json:"gatewayOwner,omitempty"`
+	OwnerIsGatewayOwner bool     `json:"ownerIsGatewayOwner"`
+	GatewayOwnerError   string   `json:"gatewayOwnerError,omitempty"`
+	Ready               bool     `json:"ready"`
+}
+
+// Diagnostics runs the pre-flight checks operators otherwise do by hand before an auto-fix:
+// RPC reachability, owner gas, active gateway/router/vault, and gateway ownership. Chains are
+// checked concurrently; a failing check is reported on its chain rather than as an error.
+func (u *OnchainAdapterUsecase) Diagnostics(ctx context.Context) (*OnchainDiagnostics, error) {
+	report := &OnchainDiagnostics{Chains: []ChainDiagnostics{}}
+	var owner common.Address
+	switch {
+	case u.ownerSignerErr != nil:
+		report.OwnerKeyError = "invalid owner private key format"
+	case u.ownerSigner == nil:
+		report.OwnerKeyError = "owner signer is not configured"
+	default:
+		owner = u.ownerSigner.Address()
+		report.OwnerAddress = owner.Hex()
+	}
+
+	chains, err := u.chainRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var targets []*entities.Chain
+	for _, chain := range chains {
+		if chain != nil && chain.IsActive && chain.Type == entities.ChainTypeEVM {
+			targets = append(targets, chain)
+		}
+	}
+
+	report.Chains = make([]ChainDiagnostics, len(targets))
+	var wg sync.WaitGroup
+	for i, chain := range targets {
+		wg.Add(1)
+		go func(i int, chain *entities.Chain) {
+			defer wg.Done()
+			chainCtx, cancel := context.WithTimeout(ctx, diagnosticsChainTimeout)
+			defer cancel()
+			report.Chains[i] = u.diagnoseChain(chainCtx, chain, owner, report.OwnerAddress != "")
+		}(i, chain)
+	}
+	wg.Wait()
+
+	report.Ready = report.OwnerKeyError == "" && len(report.Chains) > 0
+	for _, chain := range report.Chains {
+		report.Ready = report.Ready && chain.Ready
+	}
+	return report, nil
+}
+
+func (u *OnchainAdapterUsecase) diagnoseChain(ctx context.Context, chain *entities.Chain, owner common.Address, hasOwner bool) ChainDiagnostics {
+	d := ChainDiagnostics{ChainID: chain.GetCAIP2ID(), Name: chain.Name}
+
+	for _, contractType := range []entities.SmartContractType{entities.ContractTypeGateway, entities.ContractTypeRouter, entities.ContractTypeVault} {
+		contract, err := u.contractRepo.GetActiveContract(ctx, chain.ID, contractType)
+		if err != nil || contract == nil || contract.ContractAddress == "" {
+			d.MissingContracts = append(d.MissingContracts, string(contractType))
+			continue
+		}
+		switch contractType {
+		case entities.ContractTypeGateway:
+			d.GatewayAddress = contract.ContractAddress
+		case entities.ContractTypeRouter:
+			d.RouterAddress = contract.ContractAddress
+		case entities.ContractTypeVault:
+			d.VaultAddress = contract.ContractAddress
+		}
+	}
+
+	rpcURL := resolveRPCURL(chain)
+	if rpcURL == "" {
+		d.RPCError = "no active rpc url"
+		return d
+	}
+	if err := probeRPC(ctx, rpcURL); err != nil {
+		d.RPCError = err.Error()
+		return d
+	}
+	d.RPCReachable = true
+
+	var funded bool
+	if hasOwner {
+		balance, err := fetchNativeBalance(ctx, rpcURL, owner)
+		if err != nil {
+			d.OwnerBalanceError = err.Error()
+		} else {
+			d.OwnerBalance = balance.String()
+			funded = balance.Sign() > 0
+		}
+	}
+
+	if d.GatewayAddress != "" {
+		gatewayOwner, err := u.readContractOwner(ctx, rpcURL, d.GatewayAddress)
+		if err != nil {
+			d.GatewayOwnerError = err.Error()
+		} else {
+			d.GatewayOwner = gatewayOwner.Hex()
+			d.OwnerIsGatewayOwner = hasOwner && gatewayOwner == owner
+		}
+	}
+
+	d.Ready = d.RPCReachable && funded && len(d.MissingContracts) == 0 && d.OwnerIsGatewayOwner
+	return d
+}
+
+// readContractOwner reads owner() from an Ownable contract
+func (u *OnchainAdapterUsecase) readContractOwner(ctx context.Context, rpcURL, contractAddress string) (common.Address, error) {
+	if u.clientFactory == nil {
+		return common.Address{}, domainerrors.BadRequest("evm client factory is not configured")
+	}
+	client, err := u.clientFactory.GetEVMClient(rpcURL)
+	if err != nil {
+		return common.Address{}, err
+	}
+	defer client.Close()
+	return callTypedView[common.Address](ctx, client, contractAddress, FallbackOwnableABI, "owner")
+}
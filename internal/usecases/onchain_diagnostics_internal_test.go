@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+)
+
+type diagnosticsContractRepoStub struct {
+	*quoteContractRepoStub
+	byChain map[uuid.UUID]map[entities.SmartContractType]string
+}
+
+func (s *diagnosticsContractRepoStub) GetActiveContract(_ context.Context, chainID uuid.UUID, typ entities.SmartContractType) (*entities.SmartContract, error) {
+	if address, ok := s.byChain[chainID][typ]; ok {
+		return &entities.SmartContract{ChainUUID: chainID, Type: typ, ContractAddress: address}, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestDiagnostics_ReportsPerChainReadiness(t *testing.T) {
+	origProbe, origBalance := probeRPC, fetchNativeBalance
+	t.Cleanup(func() { probeRPC, fetchNativeBalance = origProbe, origBalance })
+	probeRPC = func(_ context.Context, rpcURL string) error {
+		if rpcURL == "https://down.example" {
+			return errors.New("dial failed")
+		}
+		return nil
+	}
+	fetchNativeBalance = func(context.Context, string, common.Address) (*big.Int, error) {
+		return big.NewInt(1e18), nil
+	}
+
+	signer := mustOwnerSigner(t, ownerGasTestKey)
+	stranger := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	ownerOf := map[string]common.Address{
+		"0x00000000000000000000000000000000000000a1": signer.Address(),
+		"0x00000000000000000000000000000000000000b1": stranger,
+	}
+	client := &evmClientMock{callView: func(_ context.Context, to string, _ []byte) ([]byte, error) {
+		return FallbackOwnableABI.Methods["owner"].Outputs.Pack(ownerOf[to])
+	}}
+
+	base, polygon, arbitrum := uuid.New(), uuid.New(), uuid.New()
+	full := func(gateway string) map[entities.SmartContractType]string {
+		return map[entities.SmartContractType]string{
+			entities.ContractTypeGateway: gateway,
+			entities.ContractTypeRouter:  "0x00000000000000000000000000000000000000c1",
+			entities.ContractTypeVault:   "0x00000000000000000000000000000000000000d1",
+		}
+	}
+	u := &OnchainAdapterUsecase{
+		ownerSigner: signer,
+		chainRepo: &ownerGasChainRepoStub{quoteChainRepoStub: &quoteChainRepoStub{}, chains: []*entities.Chain{
+			{ID: base, ChainID: "8453", Name: "Base", Type: entities.ChainTypeEVM, IsActive: true, RPCURL: "https://base.example"},
+			{ID: polygon, ChainID: "137", Name: "Polygon", Type: entities.ChainTypeEVM, IsActive: true, RPCURL: "https://polygon.example"},
+			{ID: arbitrum, ChainID: "42161", Name: "Arbitrum", Type: entities.ChainTypeEVM, IsActive: true, RPCURL: "https://down.example"},
+			{ChainID: "mainnet", Name: "Solana", Type: entities.ChainTypeSVM, IsActive: true, RPCURL: "https://sol.example"},
+		}},
+		contractRepo: &diagnosticsContractRepoStub{quoteContractRepoStub: &quoteContractRepoStub{}, byChain: map[uuid.UUID]map[entities.SmartContractType]string{
+			base:    full("0x00000000000000000000000000000000000000a1"),
+			polygon: {entities.ContractTypeGateway: "0x00000000000000000000000000000000000000b1"},
+		}},
+		clientFactory: &clientFactoryMock{clients: map[string]EVMClient{
+			"https://base.example":    client,
+			"https://polygon.example": client,
+		}},
+	}
+
+	report, err := u.Diagnostics(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, signer.Address().Hex(), report.OwnerAddress)
+	require.False(t, report.Ready)
+	require.Len(t, report.Chains, 3)
+
+	baseReport := report.Chains[0]
+	require.True(t, baseReport.Ready)
+	require.True(t, baseReport.OwnerIsGatewayOwner)
+	require.Equal(t, "1000000000000000000", baseReport.OwnerBalance)
+	require.Empty(t, baseReport.MissingContracts)
+
+	polygonReport := report.Chains[1]
+	require.False(t, polygonReport.Ready)
+	require.Equal(t, stranger.Hex(), polygonReport.GatewayOwner)
+	require.False(t, polygonReport.OwnerIsGatewayOwner)
+	require.Equal(t, []string{"ROUTER", "VAULT"}, polygonReport.MissingContracts)
+
+	arbitrumReport := report.Chains[2]
+	require.False(t, arbitrumReport.RPCReachable)
+	require.Equal(t, "dial failed", arbitrumReport.RPCError)
+
+	u.ownerSigner = nil
+	report, err = u.Diagnostics(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "owner signer is not configured", report.OwnerKeyError)
+	require.False(t, report.Chains[0].Ready)
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/logger"
+)
+
+// defaultAdminRole is AccessControl's DEFAULT_ADMIN_ROLE (bytes32(0))
+var defaultAdminRole [32]byte
+
+// ErrNotContractOwner means the owner key neither is owner() of Contract nor holds its
+// DEFAULT_ADMIN_ROLE, so an admin tx would revert. Owner is empty for role-based contracts.
+type ErrNotContractOwner struct {
+	Contract string
+	Signer   string
+	Owner    string
+}
+
+func (e *ErrNotContractOwner) Error() string {
+	if e.Owner == "" {
+		return fmt.Sprintf("owner key %s does not hold the admin role on contract %s", e.Signer, e.Contract)
+	}
+	return fmt.Sprintf("owner key %s is not the owner of contract %s (owner is %s)", e.Signer, e.Contract, e.Owner)
+}
+
+// verifyContractOwner checks the configured owner key may administer contractAddress. Ownable
+// contracts are checked with owner(), others with hasRole(DEFAULT_ADMIN_ROLE). When neither view
+// answers the check is skipped and the tx simulation has the last word.
+func (u *OnchainAdapterUsecase) verifyContractOwner(ctx context.Context, sourceChainID uuid.UUID, contractAddress string) error {
+	// Missing or invalid keys are reported by sendTx
+	if u.ownerSignerErr != nil || u.ownerSigner == nil || u.adminOps == nil || u.adminOps.readView == nil {
+		return nil
+	}
+	signer := u.ownerSigner.Address()
+
+	out, ownerErr := u.adminOps.readView(ctx, sourceChainID, contractAddress, FallbackOwnableABI, "owner")
+	if ownerErr == nil && len(out) > 0 {
+		if owner, ok := out[0].(common.Address); ok {
+			if owner == signer {
+				return nil
+			}
+			return notContractOwnerError(&ErrNotContractOwner{Contract: contractAddress, Signer: signer.Hex(), Owner: owner.Hex()})
+		}
+	}
+
+	out, roleErr := u.adminOps.readView(ctx, sourceChainID, contractAddress, FallbackOwnableABI, "hasRole", defaultAdminRole, signer)
+	if roleErr == nil && len(out) > 0 {
+		if hasRole, ok := out[0].(bool); ok {
+			if hasRole {
+				return nil
+			}
+			return notContractOwnerError(&ErrNotContractOwner{Contract: contractAddress, Signer: signer.Hex()})
+		}
+	}
+
+	logger.Warn(ctx, "could not verify owner key on contract, relying on tx simulation",
+		zap.String("contract", contractAddress),
+		zap.NamedError("owner_error", ownerErr),
+		zap.NamedError("role_error", roleErr),
+	)
+	return nil
+}
+
+func notContractOwnerError(err *ErrNotContractOwner) error {
+	return domainerrors.NewAppError(http.StatusUnprocessableEntity, domainerrors.CodeNotContractOwner, err.Error(), err)
+}
@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestVerifyContractOwner(t *testing.T) {
+	signer := mustOwnerSigner(t, ownerGasTestKey)
+	stranger := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	const contract = "0x00000000000000000000000000000000000000a1"
+
+	newUsecase := func(readView evmAdminReadViewFn) *OnchainAdapterUsecase {
+		u := &OnchainAdapterUsecase{ownerSigner: signer}
+		u.adminOps = &evmAdminOpsService{readView: readView}
+		return u
+	}
+	ownable := func(owner common.Address) evmAdminReadViewFn {
+		return func(_ context.Context, _ uuid.UUID, _ string, _ abi.ABI, method string, _ ...interface{}) ([]interface{}, error) {
+			if method != "owner" {
+				return nil, errors.New("unexpected " + method)
+			}
+			return []interface{}{owner}, nil
+		}
+	}
+	roleBased := func(hasRole bool) evmAdminReadViewFn {
+		return func(_ context.Context, _ uuid.UUID, _ string, _ abi.ABI, method string, args ...interface{}) ([]interface{}, error) {
+			if method == "owner" {
+				return nil, errors.New("execution reverted")
+			}
+			require.Equal(t, signer.Address(), args[1])
+			return []interface{}{hasRole}, nil
+		}
+	}
+
+	require.NoError(t, newUsecase(ownable(signer.Address())).verifyContractOwner(context.Background(), uuid.New(), contract))
+	require.NoError(t, newUsecase(roleBased(true)).verifyContractOwner(context.Background(), uuid.New(), contract))
+
+	err := newUsecase(ownable(stranger)).verifyContractOwner(context.Background(), uuid.New(), contract)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Equal(t, domainerrors.CodeNotContractOwner, appErr.Code)
+	var ownerErr *ErrNotContractOwner
+	require.ErrorAs(t, appErr.Err, &ownerErr)
+	require.Equal(t, stranger.Hex(), ownerErr.Owner)
+
+	err = newUsecase(roleBased(false)).verifyContractOwner(context.Background(), uuid.New(), contract)
+	require.ErrorAs(t, err, &appErr)
+	require.Contains(t, appErr.Message, "does not hold the admin role")
+
+	// Neither view answers: leave it to the tx simulation
+	unknown := func(context.Context, uuid.UUID, string, abi.ABI, string, ...interface{}) ([]interface{}, error) {
+		return nil, errors.New("no such method")
+	}
+	require.NoError(t, newUsecase(unknown).verifyContractOwner(context.Background(), uuid.New(), contract))
+}
+
+func TestEVMAdminOps_NotOwnerStopsBeforeSendTx(t *testing.T) {
+	sourceID := uuid.New()
+	sent := false
+	svc := newEVMAdminOpsService(
+		func(context.Context, string, string) (*evmAdminContext, error) {
+			return &evmAdminContext{sourceChainID: sourceID, destCAIP2: "eip155:42161", gatewayAddress: "0x00000000000000000000000000000000000000a1"}, nil
+		},
+		nil,
+		func(context.Context, uuid.UUID, string, abi.ABI, string, ...interface{}) (string, error) {
+			sent = true
+			return "0xtx", nil
+		},
+		func(context.Context, uuid.UUID, entities.SmartContractType) (abi.ABI, error) {
+			return FallbackPaymentKitaGatewayABI, nil
+		},
+	)
+	svc.verifyOwner = func(_ context.Context, chainID uuid.UUID, contract string) error {
+		require.Equal(t, sourceID, chainID)
+		return notContractOwnerError(&ErrNotContractOwner{Contract: contract, Signer: "0xsigner", Owner: "0xowner"})
+	}
+
+	_, err := svc.SetDefaultBridgeType(context.Background(), "eip155:8453", "eip155:42161", 1)
+	require.Error(t, err)
+	require.False(t, sent)
+
+	svc.verifyOwner = func(context.Context, uuid.UUID, string) error { return nil }
+	txHash, err := svc.SetDefaultBridgeType(context.Background(), "eip155:8453", "eip155:42161", 1)
+	require.NoError(t, err)
+	require.Equal(t, "0xtx", txHash)
+}
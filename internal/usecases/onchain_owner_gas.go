@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// ownerGasFallbackLimit prices an admin tx whose gas estimate failed
+const ownerGasFallbackLimit uint64 = 300_000
+
+// ownerBalanceTimeout bounds each per-chain balance lookup in the status endpoint
+const ownerBalanceTimeout = 5 * time.Second
+
+// ErrInsufficientOwnerGas means the owner wallet cannot pay for an admin transaction on Chain.
+// Balance and Needed are in wei; Needed is the estimated gas limit times the current gas price.
+type ErrInsufficientOwnerGas struct {
+	Chain   string
+	Owner   string
+	Balance *big.Int
+	Needed  *big.Int
+}
+
+func (e *ErrInsufficientOwnerGas) Error() string {
+	chain := e.Chain
+	if chain == "" {
+		chain = "source chain"
+	}
+	return fmt.Sprintf("owner wallet %s has insufficient native gas on %s: balance %s wei, needed %s wei",
+		e.Owner, chain, e.Balance.String(), e.Needed.String())
+}
+
+// OwnerGasBalance is the owner wallet's native balance on one active EVM chain
+type OwnerGasBalance struct {
+	ChainID string `json:"chainId"`
+	Name    string `json:"name"`
+	Symbol  string `json:"symbol,omitempty"`
+	Balance string `json:"balance,omitempty"` // wei
+	Error   string `json:"error,omitempty"`
+}
+
+var (
+	// checkOwnerGas runs before an admin tx is submitted. Lookup failures are not reported here;
+	// the submission itself surfaces RPC problems.
+	checkOwnerGas = func(ctx context.Context, client *ethclient.Client, owner common.Address, contractAddress string, data []byte) error {
+		balance, err := client.BalanceAt(ctx, owner, nil)
+		if err != nil {
+			return nil
+		}
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil
+		}
+		to := common.HexToAddress(contractAddress)
+		gasLimit, err := client.EstimateGas(ctx, ethereum.CallMsg{From: owner, To: &to, Data: data})
+		if err != nil || gasLimit == 0 {
+			gasLimit = ownerGasFallbackLimit
+		}
+		needed := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice)
+		if balance.Cmp(needed) < 0 {
+			return &ErrInsufficientOwnerGas{Owner: owner.Hex(), Balance: balance, Needed: needed}
+		}
+		return nil
+	}
+
+	fetchNativeBalance = func(ctx context.Context, rpcURL string, owner common.Address) (*big.Int, error) {
+		client, err := ethclient.DialContext(ctx, rpcURL)
+		if err != nil {
+			return nil, err
+		}
+		defer client.Close()
+		return client.BalanceAt(ctx, owner, nil)
+	}
+)
+
+// insufficientOwnerGasError labels a gas shortfall with the chain and turns it into a 422
+func insufficientOwnerGasError(gasErr *ErrInsufficientOwnerGas, chain *entities.Chain) error {
+	gasErr.Chain = chain.GetCAIP2ID()
+	return domainerrors.NewAppError(http.StatusUnprocessableEntity, domainerrors.CodeInsufficientFunds, gasErr.Error(), gasErr)
+}
+
+// ownerGasBalances reads the owner wallet's native balance on every active EVM chain so
+// operators can top it up before admin txs start failing
+func (u *OnchainAdapterUsecase) ownerGasBalances(ctx context.Context) (string, []OwnerGasBalance) {
+	if u.ownerSigner == nil {
+		return "", nil
+	}
+	owner := u.ownerSigner.Address()
+	chains, err := u.chainRepo.GetAll(ctx)
+	if err != nil {
+		return owner.Hex(), nil
+	}
+
+	var targets []*entities.Chain
+	for _, chain := range chains {
+		if chain != nil && chain.IsActive && chain.Type == entities.ChainTypeEVM {
+			targets = append(targets, chain)
+		}
+	}
+
+	balances := make([]OwnerGasBalance, len(targets))
+	var wg sync.WaitGroup
+	for i, chain := range targets {
+		balances[i] = OwnerGasBalance{ChainID: chain.GetCAIP2ID(), Name: chain.Name, Symbol: chain.CurrencySymbol}
+		rpcURL := resolveRPCURL(chain)
+		if rpcURL == "" {
+			balances[i].Error = "no active rpc url"
+			continue
+		}
+		wg.Add(1)
+		go func(i int, rpcURL string) {
+			defer wg.Done()
+			callCtx, cancel := context.WithTimeout(ctx, ownerBalanceTimeout)
+			defer cancel()
+			balance, err := fetchNativeBalance(callCtx, rpcURL, owner)
+			if err != nil {
+				balances[i].Error = err.Error()
+				return
+			}
+			balances[i].Balance = balance.String()
+		}(i, rpcURL)
+	}
+	wg.Wait()
+	return owner.Hex(), balances
+}
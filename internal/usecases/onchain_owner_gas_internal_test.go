@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+const ownerGasTestKey = "0x4c0883a69102937d6231471b5dbb6204fe51296170827931e8f95f6f8d5d2f66"
+
+func mustOwnerSigner(t *testing.T, key string) blockchain.TxSigner {
+	t.Helper()
+	signer, err := blockchain.NewPrivateKeySigner(key)
+	require.NoError(t, err)
+	return signer
+}
+
+type ownerGasChainRepoStub struct {
+	*quoteChainRepoStub
+	chains []*entities.Chain
+}
+
+func (s *ownerGasChainRepoStub) GetAll(context.Context) ([]*entities.Chain, error) {
+	return s.chains, nil
+}
+
+func TestExecuteOnchainTx_RejectsWhenOwnerLacksGas(t *testing.T) {
+	srv := newSafeHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		var req map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		res := map[string]interface{}{"jsonrpc": "2.0", "id": req["id"]}
+		switch req["method"] {
+		case "eth_chainId":
+			res["result"] = "0x1"
+		case "eth_getBalance":
+			res["result"] = "0x64" // 100 wei
+		case "eth_gasPrice":
+			res["result"] = "0xa" // 10 wei
+		case "eth_estimateGas":
+			res["result"] = "0x5208" // 21000
+		case "eth_call":
+			res["result"] = "0x"
+		default:
+			res["result"] = "0x0"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer srv.Close()
+
+	orig := performContractTransact
+	t.Cleanup(func() { performContractTransact = orig })
+	performContractTransact = func(*ethclient.Client, string, abi.ABI, *bind.TransactOpts, string, ...interface{}) (string, error) {
+		t.Fatal("transaction must not be submitted without gas")
+		return "", nil
+	}
+
+	parsed := mustParseABI(`[{"inputs":[{"internalType":"uint256","name":"x","type":"uint256"}],"name":"setValue","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
+	signer := mustOwnerSigner(t, ownerGasTestKey)
+	_, err := executeOnchainTx(context.Background(), srv.URL, signer, "0x0000000000000000000000000000000000000001", parsed, "setValue", big.NewInt(1))
+
+	var gasErr *ErrInsufficientOwnerGas
+	require.ErrorAs(t, err, &gasErr)
+	require.Equal(t, "100", gasErr.Balance.String())
+	require.Equal(t, "210000", gasErr.Needed.String())
+	require.Equal(t, signer.Address().Hex(), gasErr.Owner)
+}
+
+func TestSendTx_InsufficientOwnerGasIsLabelledAndNotRetried(t *testing.T) {
+	origExec := executeOnchainTx
+	t.Cleanup(func() { executeOnchainTx = origExec })
+	calls := 0
+	executeOnchainTx = func(context.Context, string, blockchain.TxSigner, string, abi.ABI, string, ...interface{}) (string, error) {
+		calls++
+		return "", &ErrInsufficientOwnerGas{Owner: "0xowner", Balance: big.NewInt(1), Needed: big.NewInt(2)}
+	}
+
+	chainID := uuid.New()
+	u := &OnchainAdapterUsecase{
+		ownerSigner: mustOwnerSigner(t, ownerGasTestKey),
+		chainRepo: &quoteChainRepoStub{byID: map[uuid.UUID]*entities.Chain{
+			chainID: {ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: "mock://chain"},
+		}},
+	}
+	_, err := u.sendTx(context.Background(), chainID, "0x0000000000000000000000000000000000000001", abi.ABI{}, "setValue")
+
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Contains(t, appErr.Message, "eip155:8453")
+	require.Contains(t, appErr.Message, "balance 1 wei, needed 2 wei")
+	require.Equal(t, "eip155:8453", appErr.Err.(*ErrInsufficientOwnerGas).Chain)
+	require.Equal(t, 1, calls)
+}
+
+func TestOwnerGasBalances_ListsActiveEVMChains(t *testing.T) {
+	orig := fetchNativeBalance
+	t.Cleanup(func() { fetchNativeBalance = orig })
+	fetchNativeBalance = func(_ context.Context, rpcURL string, _ common.Address) (*big.Int, error) {
+		if rpcURL == "https://down.example" {
+			return nil, errors.New("dial failed")
+		}
+		return big.NewInt(5e17), nil
+	}
+
+	u := &OnchainAdapterUsecase{
+		ownerSigner: mustOwnerSigner(t, ownerGasTestKey),
+		chainRepo: &ownerGasChainRepoStub{quoteChainRepoStub: &quoteChainRepoStub{}, chains: []*entities.Chain{
+			{ChainID: "8453", Name: "Base", Type: entities.ChainTypeEVM, IsActive: true, CurrencySymbol: "ETH", RPCURL: "https://base.example"},
+			{ChainID: "137", Name: "Polygon", Type: entities.ChainTypeEVM, IsActive: true, RPCURL: "https://down.example"},
+			{ChainID: "42161", Name: "Arbitrum", Type: entities.ChainTypeEVM, IsActive: false, RPCURL: "https://arb.example"},
+			{ChainID: "mainnet", Name: "Solana", Type: entities.ChainTypeSVM, IsActive: true, RPCURL: "https://sol.example"},
+		}},
+	}
+
+	owner, balances := u.ownerGasBalances(context.Background())
+	require.Equal(t, u.ownerSigner.Address().Hex(), owner)
+	require.Len(t, balances, 2)
+	require.Equal(t, OwnerGasBalance{ChainID: "eip155:8453", Name: "Base", Symbol: "ETH", Balance: "500000000000000000"}, balances[0])
+	require.Equal(t, "dial failed", balances[1].Error)
+
+	u.ownerSigner = nil
+	owner, balances = u.ownerGasBalances(context.Background())
+	require.Empty(t, owner)
+	require.Nil(t, balances)
+}
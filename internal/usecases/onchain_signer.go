@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"payment-kita.backend/internal/domain/repositories"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+// NewOnchainAdapterUsecaseWithSigner is NewOnchainAdapterUsecase for an owner key held by a
+// TxSigner, such as a remote KMS-backed signer, instead of a raw key in config
+func NewOnchainAdapterUsecaseWithSigner(
+	chainRepo repositories.ChainRepository,
+	contractRepo repositories.SmartContractRepository,
+	clientFactory *blockchain.ClientFactory,
+	signer blockchain.TxSigner,
+) *OnchainAdapterUsecase {
+	u := NewOnchainAdapterUsecase(chainRepo, contractRepo, clientFactory, "")
+	u.ownerSigner = signer
+	return u
+}
+
+// newSignerTransactor is bind.NewKeyedTransactorWithChainID for a TxSigner
+func newSignerTransactor(ctx context.Context, signer blockchain.TxSigner, chainID *big.Int) *bind.TransactOpts {
+	from := signer.Address()
+	return &bind.TransactOpts{
+		From:    from,
+		Context: ctx,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(ctx, tx, chainID)
+		},
+	}
+}
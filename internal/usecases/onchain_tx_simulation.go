@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// simulateContractCall dry-runs an admin tx with eth_call from the owner address, so a revert
+// (not owner, bad arguments) is caught before any gas is spent
+var simulateContractCall = func(ctx context.Context, client *ethclient.Client, from common.Address, contractAddress string, data []byte) error {
+	to := common.HexToAddress(contractAddress)
+	_, err := client.CallContract(ctx, ethereum.CallMsg{From: from, To: &to, Data: data}, nil)
+	return err
+}
+
+// simulationRevertError describes a failed dry run. The revert payload is decoded with the
+// known route errors first, then with the custom errors declared in the contract ABI. Only an
+// actual execution failure is a 422; a dry run the RPC could not answer says nothing about the
+// tx, so it is reported as the RPC's failure instead.
+func simulationRevertError(method string, parsedABI abi.ABI, err error) error {
+	decoded, hasRevertData := decodeRevertDataFromError(err)
+	if !hasRevertData && !isExecutionFailure(err) {
+		return simulationRPCError(method, err)
+	}
+	reason := err.Error()
+	if hasRevertData {
+		reason = decoded.Message
+		if decoded.Name == "" {
+			if name := abiErrorName(parsedABI, decoded.RawHex); name != "" {
+				reason = name
+			}
+		}
+	}
+	return domainerrors.NewAppError(
+		http.StatusUnprocessableEntity,
+		domainerrors.CodeSimulationReverted,
+		"simulation of "+method+" reverted: "+reason,
+		err,
+	)
+}
+
+// simulationRPCError is a dry run that never reached the contract: timeouts and rate limits
+// are worth retrying (503), anything else is a bad upstream answer (502)
+func simulationRPCError(method string, err error) error {
+	status := http.StatusBadGateway
+	message := strings.ToLower(err.Error())
+	if errors.Is(err, context.DeadlineExceeded) ||
+		strings.Contains(message, "timeout") ||
+		strings.Contains(message, "429") ||
+		strings.Contains(message, "rate limit") ||
+		strings.Contains(message, "too many requests") {
+		status = http.StatusServiceUnavailable
+	}
+	return domainerrors.NewAppError(
+		status,
+		domainerrors.CodeRPCUnavailable,
+		"simulation of "+method+" could not be run: "+err.Error(),
+		err,
+	)
+}
+
+func abiErrorName(parsedABI abi.ABI, rawHex string) string {
+	data, ok := parseHexBytes(rawHex)
+	if !ok || len(data) < 4 {
+		return ""
+	}
+	for name, abiErr := range parsedABI.Errors {
+		if bytes.Equal(abiErr.ID[:4], data[:4]) {
+			return name
+		}
+	}
+	return ""
+}
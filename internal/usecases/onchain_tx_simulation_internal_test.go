@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type revertDataError struct{ data string }
+
+func (e revertDataError) Error() string          { return "execution reverted" }
+func (e revertDataError) ErrorData() interface{} { return e.data }
+
+func errorStringRevert(t *testing.T, reason string) string {
+	t.Helper()
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	packed, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return "0x08c379a0" + hex.EncodeToString(packed)
+}
+
+func TestExecuteOnchainTx_AbortsWhenSimulationReverts(t *testing.T) {
+	revertData := errorStringRevert(t, "Ownable: caller is not the owner")
+	srv := newSafeHTTPServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		var req map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		res := map[string]interface{}{"jsonrpc": "2.0", "id": req["id"]}
+		switch req["method"] {
+		case "eth_chainId":
+			res["result"] = "0x1"
+		case "eth_call":
+			res["error"] = map[string]interface{}{"code": 3, "message": "execution reverted", "data": revertData}
+		default:
+			res["result"] = "0x0"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer srv.Close()
+
+	orig := performContractTransact
+	t.Cleanup(func() { performContractTransact = orig })
+	performContractTransact = func(*ethclient.Client, string, abi.ABI, *bind.TransactOpts, string, ...interface{}) (string, error) {
+		t.Fatal("a reverting transaction must not be submitted")
+		return "", nil
+	}
+
+	parsed := mustParseABI(`[{"inputs":[{"internalType":"uint256","name":"x","type":"uint256"}],"name":"setValue","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
+	_, err := executeOnchainTx(context.Background(), srv.URL, mustOwnerSigner(t, ownerGasTestKey), "0x0000000000000000000000000000000000000001", parsed, "setValue", big.NewInt(1))
+
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Equal(t, domainerrors.CodeSimulationReverted, appErr.Code)
+	require.Equal(t, "simulation of setValue reverted: Ownable: caller is not the owner", appErr.Message)
+}
+
+func TestSimulationRevertError_DecodesCustomErrors(t *testing.T) {
+	parsed := mustParseABI(`[
+		{"inputs":[],"name":"setValue","outputs":[],"stateMutability":"nonpayable","type":"function"},
+		{"inputs":[],"name":"InvalidBridgeType","type":"error"}
+	]`)
+
+	owner := "0x" + hex.EncodeToString(make([]byte, 12)) + "00000000000000000000000000000000000000aa"
+	err := simulationRevertError("setValue", parsed, revertDataError{data: selectorHex("OwnableUnauthorizedAccount(address)") + owner[2:]})
+	require.Contains(t, err.(*domainerrors.AppError).Message, "is not the contract owner")
+
+	err = simulationRevertError("setValue", parsed, revertDataError{data: "0x" + hex.EncodeToString(parsed.Errors["InvalidBridgeType"].ID.Bytes()[:4])})
+	require.Equal(t, "simulation of setValue reverted: InvalidBridgeType", err.(*domainerrors.AppError).Message)
+
+	err = simulationRevertError("setValue", parsed, errors.New("execution reverted"))
+	require.Equal(t, http.StatusUnprocessableEntity, err.(*domainerrors.AppError).Status)
+	require.Equal(t, "simulation of setValue reverted: execution reverted", err.(*domainerrors.AppError).Message)
+}
+
+func TestSimulationRevertError_TransportFailuresAreNotReverts(t *testing.T) {
+	parsed := mustParseABI(`[{"inputs":[],"name":"setValue","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
+
+	for _, tc := range []struct {
+		err    error
+		status int
+	}{
+		{errors.New("dial tcp 10.0.0.1:8545: connect: connection refused"), http.StatusBadGateway},
+		{errors.New("connection reset"), http.StatusBadGateway},
+		{context.DeadlineExceeded, http.StatusServiceUnavailable},
+		{errors.New("Post \"https://rpc\": net/http: request canceled (Client.Timeout exceeded)"), http.StatusServiceUnavailable},
+		{errors.New("429 Too Many Requests: rate limit exceeded"), http.StatusServiceUnavailable},
+	} {
+		var appErr *domainerrors.AppError
+		require.ErrorAs(t, simulationRevertError("setValue", parsed, tc.err), &appErr, tc.err.Error())
+		require.Equal(t, tc.status, appErr.Status, tc.err.Error())
+		require.Equal(t, domainerrors.CodeRPCUnavailable, appErr.Code)
+		require.Contains(t, appErr.Message, "simulation of setValue could not be run")
+	}
+}
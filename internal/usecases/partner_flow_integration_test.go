@@ -77,6 +77,7 @@ func TestPartnerFlow_QuoteSessionHostedReadResolveWebhookSync_Integration(t *tes
 
 	merchantRepo := infrarepos.NewMerchantRepository(db)
 	paymentRequestUsecase := NewPaymentRequestUsecase(paymentRequestRepo, merchantRepo, nil, chainRepo, contractRepo, tokenRepo, jweService)
+	clientFactory := blockchain.NewClientFactory()
 	sessionUsecase := NewPartnerPaymentSessionUsecase(
 		quoteRepo,
 		sessionRepo,
@@ -89,13 +90,13 @@ func TestPartnerFlow_QuoteSessionHostedReadResolveWebhookSync_Integration(t *tes
 		jweService,
 		paymentRequestUsecase,
 		NewPaymentUsecase(
-			nil, nil, nil, merchantRepo, contractRepo, chainRepo, tokenRepo, nil, nil, nil, uow,
-			blockchain.NewClientFactory(),
+			nil, nil, nil, merchantRepo, nil, contractRepo, chainRepo, tokenRepo, nil, nil, nil, uow,
+			clientFactory,
 		), // paymentUC
 		"https://partner.pay.test/pay",
 	)
 	// Register a fake EVM client for the test RPC to avoid dial errors
-	sessionUsecase.paymentUC.clientFactory.RegisterEVMClient("https://rpc.base.example", blockchain.NewEVMClientWithCallView(big.NewInt(8453), func(ctx context.Context, to string, data []byte) ([]byte, error) {
+	clientFactory.RegisterEVMClient("https://rpc.base.example", blockchain.NewEVMClientWithCallView(big.NewInt(8453), func(ctx context.Context, to string, data []byte) ([]byte, error) {
 		return []byte{}, nil // Default empty response for calls
 	}))
 	webhookUsecase := NewWebhookUsecase(nil, nil, paymentRequestRepo, sessionRepo, nil, nil, nil, nil)
@@ -221,7 +222,7 @@ func TestPartnerFlow_NativeToken_NoApproval(t *testing.T) {
 	jweService, err := services.NewJWEService([]byte("12345678901234567890123456789012"))
 	require.NoError(t, err)
 
-	paymentUC := NewPaymentUsecase(nil, nil, nil, merchantRepo, contractRepo, chainRepo, tokenRepo, nil, nil, nil, uow, blockchain.NewClientFactory())
+	paymentUC := NewPaymentUsecase(nil, nil, nil, merchantRepo, nil, contractRepo, chainRepo, tokenRepo, nil, nil, nil, uow, blockchain.NewClientFactory())
 	quoteUsecase := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
 	quoteUsecase.RouteSupportFnForTest(func(ctx context.Context, chainID uuid.UUID, tokenIn, tokenOut string) (*TokenRouteSupportStatus, error) {
 		return &TokenRouteSupportStatus{Exists: true, Executable: true}, nil
@@ -296,7 +297,7 @@ func TestPartnerFlow_NonEVM_NoApproval(t *testing.T) {
 	jweService, err := services.NewJWEService([]byte("12345678901234567890123456789012"))
 	require.NoError(t, err)
 
-	paymentUC := NewPaymentUsecase(nil, nil, nil, merchantRepo, contractRepo, chainRepo, tokenRepo, nil, nil, nil, uow, blockchain.NewClientFactory())
+	paymentUC := NewPaymentUsecase(nil, nil, nil, merchantRepo, nil, contractRepo, chainRepo, tokenRepo, nil, nil, nil, uow, blockchain.NewClientFactory())
 	quoteUsecase := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
 	quoteUsecase.RouteSupportFnForTest(func(ctx context.Context, chainID uuid.UUID, tokenIn, tokenOut string) (*TokenRouteSupportStatus, error) {
 		return &TokenRouteSupportStatus{Exists: true, Executable: true}, nil
@@ -437,8 +438,10 @@ func createPartnerFlowIntegrationTables(t *testing.T, db *gorm.DB) {
 		status TEXT NOT NULL,
 		expires_at DATETIME NOT NULL,
 		tx_hash TEXT,
+		block_number INTEGER,
 		payer_address TEXT,
 		payment_code TEXT,
+		metadata TEXT,
 		completed_at DATETIME,
 		created_at DATETIME,
 		updated_at DATETIME,
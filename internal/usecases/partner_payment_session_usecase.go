@@ -257,10 +257,17 @@ func (u *PartnerPaymentSessionUsecase) CreateSession(ctx context.Context, input
 			return domainerrors.BadRequest("quote has expired")
 		}
 
-		selectedChainID, selectedChainCAIP2, err := u.chainResolver.ResolveFromAny(txCtx, quote.SelectedChainID)
+		selectedChain, err := u.chainResolver.ResolveChain(txCtx, quote.SelectedChainID)
 		if err != nil {
 			return domainerrors.BadRequest(fmt.Sprintf("invalid selected chain on quote: %v", err))
 		}
+		if err := checkChainsActive(selectedChain); err != nil {
+			return err
+		}
+		if err := checkChainsForMode(paymentMode(txCtx), selectedChain); err != nil {
+			return err
+		}
+		selectedChainID, selectedChainCAIP2 := selectedChain.ID, selectedChain.GetCAIP2ID()
 		selectedToken, err := u.tokenRepo.GetByAddress(txCtx, quote.SelectedTokenAddress, selectedChainID)
 		if err != nil || selectedToken == nil {
 			return domainerrors.BadRequest("quoted token no longer supported")
@@ -307,6 +314,15 @@ func (u *PartnerPaymentSessionUsecase) CreateSession(ctx context.Context, input
 		if err != nil {
 			return domainerrors.BadRequest(fmt.Sprintf("invalid destination chain: %v", err))
 		}
+		if destChainID != selectedChainID {
+			destChain, err := u.chainRepo.GetByID(txCtx, destChainID)
+			if err != nil {
+				return domainerrors.BadRequest(fmt.Sprintf("invalid destination chain: %v", err))
+			}
+			if err := checkChainsActive(destChain); err != nil {
+				return err
+			}
+		}
 		destTokenAddress := coalesceString(strings.TrimSpace(input.DestTokenOverride), quote.SelectedTokenAddress)
 		createPaymentTraceDebug(txCtx, "partner_session.destination_resolved",
 			zap.String("dest_chain_caip2", destChainCAIP2),
@@ -356,22 +372,7 @@ func (u *PartnerPaymentSessionUsecase) CreateSession(ctx context.Context, input
 		amountInSource.SetString(paymentRequest.Amount, 10)
 
 		if contract != nil {
-			addrType, _ := abi.NewType("address", "", nil)
-			receiverPacked, _ := abi.Arguments{{Type: addrType}}.Pack(common.HexToAddress(normalizeEvmAddress(paymentRequest.WalletAddress)))
-
-			v2Args := PaymentRequestV2Args{
-				DestChainIDBytes:   []byte(destChainCAIP2),
-				ReceiverBytes:      receiverPacked,
-				SourceToken:        common.HexToAddress(normalizeEvmAddress(quote.SelectedTokenAddress)),
-				BridgeTokenSource:  common.Address{}, // Default
-				DestToken:          common.HexToAddress(normalizeEvmAddress(destTokenAddress)),
-				AmountInSource:     amountInSource,
-				MinBridgeAmountOut: big.NewInt(0),
-				MinDestAmountOut:   big.NewInt(0),
-				Mode:               0, // Standard
-				BridgeOption:       1, // Default Bridge
-			}
-
+			v2Args := partnerSessionPaymentArgs(destChainCAIP2, paymentRequest.WalletAddress, quote.SelectedTokenAddress, destTokenAddress, amountInSource)
 			dataHex, err := packCreatePaymentDefaultBridgeV2Calldata(v2Args)
 			if err == nil {
 				dataBytes, _ := hex.DecodeString(strings.TrimPrefix(dataHex, "0x"))
@@ -436,7 +437,7 @@ func (u *PartnerPaymentSessionUsecase) CreateSession(ctx context.Context, input
 		}
 
 		// ERC20 Approval logic for EVM
-		if strings.HasPrefix(selectedChainCAIP2, "eip155:") && contract != nil {
+		if domainentities.ChainTypeFromCAIP2(selectedChainCAIP2).IsEVM() && contract != nil {
 			normalizedToken := normalizeEvmAddress(quote.SelectedTokenAddress)
 			if normalizedToken != "0x0000000000000000000000000000000000000000" {
 				session.InstructionApprovalTo = normalizedToken
@@ -668,6 +669,25 @@ func buildPartnerPaymentSessionReadModel(session *domainentities.PartnerPaymentS
 	return out
 }
 
+// partnerSessionPaymentArgs are the createPayment arguments of a session's payment instruction:
+// the default bridge, no minimum outputs
+func partnerSessionPaymentArgs(destCAIP2, receiver, sourceToken, destToken string, amount *big.Int) PaymentRequestV2Args {
+	addrType, _ := abi.NewType("address", "", nil)
+	receiverPacked, _ := abi.Arguments{{Type: addrType}}.Pack(common.HexToAddress(normalizeEvmAddress(receiver)))
+	return PaymentRequestV2Args{
+		DestChainIDBytes:   []byte(destCAIP2),
+		ReceiverBytes:      receiverPacked,
+		SourceToken:        common.HexToAddress(normalizeEvmAddress(sourceToken)),
+		BridgeTokenSource:  common.Address{}, // Default
+		DestToken:          common.HexToAddress(normalizeEvmAddress(destToken)),
+		AmountInSource:     amount,
+		MinBridgeAmountOut: big.NewInt(0),
+		MinDestAmountOut:   big.NewInt(0),
+		Mode:               0, // Standard
+		BridgeOption:       1, // Default Bridge
+	}
+}
+
 func instructionToAddress(session *domainentities.PartnerPaymentSession) string {
 	if strings.TrimSpace(session.InstructionDataHex) != "" {
 		return session.InstructionTo
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	domainentities "payment-kita.backend/internal/domain/entities"
@@ -30,6 +31,9 @@ type CreatePartnerQuoteInput struct {
 	SelectedToken     string
 	DestWallet        string
 	ExpiresAtOverride *time.Time
+	// SimulateFrom is the payer's EVM address. When set, the approve and createPayment a session
+	// for this quote would carry are dry-run from it; see CreatePaymentInput.SimulateFrom.
+	SimulateFrom string
 }
 
 type CreatePartnerQuoteOutput struct {
@@ -47,6 +51,8 @@ type CreatePartnerQuoteOutput struct {
 	SlippageBps         int       `json:"slippage_bps"`
 	RateTimestamp       time.Time `json:"rate_timestamp"`
 	QuoteExpiresAt      time.Time `json:"quote_expires_at"`
+	// Simulation is set when the request had SimulateFrom
+	Simulation *domainentities.PaymentSimulation `json:"simulation,omitempty"`
 }
 
 type PreviewRequiredInputForOutputInput struct {
@@ -73,6 +79,7 @@ type PartnerQuoteUsecase struct {
 	accurateQuoteFn         func(context.Context, uuid.UUID, string, string, *big.Int) (*AccurateSwapQuoteResult, error)
 	accurateRequiredInputFn func(context.Context, uuid.UUID, string, string, *big.Int) (*AccurateSwapRequiredInputResult, error)
 	simulatorQuoteFn        func(context.Context, uuid.UUID, string, string, *big.Int) (*AccurateSwapQuoteResult, error)
+	simulatePaymentFn       func(ctx context.Context, chain *domainentities.Chain, tokenAddress, amount, receiver, from string) *domainentities.PaymentSimulation
 }
 
 func NewPartnerQuoteUsecase(
@@ -93,6 +100,7 @@ func NewPartnerQuoteUsecase(
 		uc.accurateQuoteFn = paymentUsecase.getAccuratePartnerQuote
 		uc.accurateRequiredInputFn = paymentUsecase.getAccuratePartnerRequiredInput
 		uc.simulatorQuoteFn = paymentUsecase.getSimulatorBackedPartnerQuote
+		uc.simulatePaymentFn = paymentUsecase.simulateQuotedPayment
 	}
 	return uc
 }
@@ -145,6 +153,11 @@ func (u *PartnerQuoteUsecase) PreviewRequiredInputForOutput(ctx context.Context,
 	if err != nil {
 		return nil, domainerrors.BadRequest(fmt.Sprintf("invalid selected_chain: %v", err))
 	}
+	if chain, err := u.getCachedChainByID(ctx, chainID); err == nil {
+		if err := checkChainsActive(chain); err != nil {
+			return nil, err
+		}
+	}
 	inputToken, err := u.getCachedTokenByAddress(ctx, chainID, strings.TrimSpace(input.InputToken))
 	if err != nil || inputToken == nil || !inputToken.IsActive {
 		return nil, domainerrors.BadRequest("input_token not supported on selected_chain")
@@ -241,6 +254,12 @@ func (u *PartnerQuoteUsecase) createQuoteCore(ctx context.Context, input *Create
 	if err != nil {
 		return nil, domainerrors.BadRequest("selected_chain not found")
 	}
+	if err := checkChainsActive(chain); err != nil {
+		return nil, err
+	}
+	if err := validateSimulateFrom(chain, input.SimulateFrom); err != nil {
+		return nil, err
+	}
 
 	selectedToken, err := u.getCachedTokenByAddress(ctx, chainID, strings.TrimSpace(input.SelectedToken))
 	if err != nil || selectedToken == nil || !selectedToken.IsActive {
@@ -467,6 +486,17 @@ func (u *PartnerQuoteUsecase) createQuoteCore(ctx context.Context, input *Create
 		RateTimestamp:       now,
 		QuoteExpiresAt:      expiresAt,
 	}
+	if from := strings.TrimSpace(input.SimulateFrom); from != "" {
+		if u.simulatePaymentFn != nil {
+			output.Simulation = u.simulatePaymentFn(ctx, chain, selectedToken.ContractAddress, output.QuotedAmount, strings.TrimSpace(input.DestWallet), from)
+		} else {
+			output.Simulation = &domainentities.PaymentSimulation{
+				From:   common.HexToAddress(from).Hex(),
+				Status: domainentities.PaymentSimulationSkipped,
+				Reason: "payment simulation is not configured",
+			}
+		}
+	}
 	if !persist {
 		createPaymentTraceInfo(ctx, "partner_quote.preview_success",
 			zap.String("selected_chain", output.SelectedChain),
@@ -58,6 +58,9 @@ func (s *partnerQuoteTokenRepoStub) GetByAddress(ctx context.Context, address st
 func (s *partnerQuoteTokenRepoStub) GetAll(context.Context) ([]*domainentities.Token, error) {
 	return nil, nil
 }
+func (s *partnerQuoteTokenRepoStub) GetActiveBySymbol(context.Context, string) ([]*domainentities.Token, error) {
+	return nil, nil
+}
 func (s *partnerQuoteTokenRepoStub) GetStablecoins(context.Context) ([]*domainentities.Token, error) {
 	return nil, nil
 }
@@ -67,12 +70,16 @@ func (s *partnerQuoteTokenRepoStub) GetNative(context.Context, uuid.UUID) (*doma
 func (s *partnerQuoteTokenRepoStub) GetTokensByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*domainentities.Token, int64, error) {
 	return nil, 0, nil
 }
-func (s *partnerQuoteTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, utils.PaginationParams) ([]*domainentities.Token, int64, error) {
+func (s *partnerQuoteTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, bool, utils.PaginationParams) ([]*domainentities.Token, int64, error) {
 	return nil, 0, nil
 }
+func (s *partnerQuoteTokenRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
 func (s *partnerQuoteTokenRepoStub) Create(context.Context, *domainentities.Token) error { return nil }
 func (s *partnerQuoteTokenRepoStub) Update(context.Context, *domainentities.Token) error { return nil }
 func (s *partnerQuoteTokenRepoStub) SoftDelete(context.Context, uuid.UUID) error         { return nil }
+func (s *partnerQuoteTokenRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 
 type partnerQuoteChainRepoStub struct {
 	chain *domainentities.Chain
@@ -106,9 +113,13 @@ func (s *partnerQuoteChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error
 func (s *partnerQuoteChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*domainentities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *partnerQuoteChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*domainentities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *partnerQuoteChainRepoStub) Create(context.Context, *domainentities.Chain) error { return nil }
 func (s *partnerQuoteChainRepoStub) Update(context.Context, *domainentities.Chain) error { return nil }
 func (s *partnerQuoteChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *partnerQuoteChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 
 func TestPartnerQuoteUsecase_CreateQuote_SupportedPair(t *testing.T) {
 	chainID := uuid.New()
@@ -124,7 +135,7 @@ func TestPartnerQuoteUsecase_CreateQuote_SupportedPair(t *testing.T) {
 		},
 	}
 	chainRepo := &partnerQuoteChainRepoStub{
-		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM},
+		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM, IsActive: true},
 	}
 
 	uc := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
@@ -161,6 +172,58 @@ func TestPartnerQuoteUsecase_CreateQuote_SupportedPair(t *testing.T) {
 	require.Equal(t, domainentities.PaymentQuoteStatusActive, quoteRepo.created.Status)
 }
 
+func TestPartnerQuoteUsecase_CreateQuote_SimulatesFromPayer(t *testing.T) {
+	chainID := uuid.New()
+	usdc := &domainentities.Token{ChainUUID: chainID, ContractAddress: "0xusdc", Symbol: "USDC", Decimals: 6, IsActive: true}
+	tokenRepo := &partnerQuoteTokenRepoStub{
+		byAddress: map[string]*domainentities.Token{"0xusdc": usdc},
+		bySymbol:  map[string]*domainentities.Token{"USDC": usdc},
+	}
+	chainRepo := &partnerQuoteChainRepoStub{
+		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM, IsActive: true},
+	}
+	uc := NewPartnerQuoteUsecase(&partnerQuoteRepoStub{}, tokenRepo, chainRepo, nil)
+	uc.routeSupportFn = func(context.Context, uuid.UUID, string, string) (*TokenRouteSupportStatus, error) {
+		return &TokenRouteSupportStatus{Exists: true, IsDirect: true, Executable: true}, nil
+	}
+	uc.swapQuoteFn = func(_ context.Context, _ uuid.UUID, _, _ string, amountIn *big.Int) (*big.Int, error) {
+		return amountIn, nil
+	}
+	const payer = "0x3333333333333333333333333333333333333333"
+	var simulated []string
+	uc.simulatePaymentFn = func(_ context.Context, chain *domainentities.Chain, token, amount, receiver, from string) *domainentities.PaymentSimulation {
+		require.Equal(t, chainID, chain.ID)
+		simulated = append(simulated, token, amount, receiver, from)
+		return &domainentities.PaymentSimulation{From: from, Status: domainentities.PaymentSimulationReverted, Reason: "amount below minimum"}
+	}
+	input := &CreatePartnerQuoteInput{
+		MerchantID:      uuid.New(),
+		InvoiceCurrency: "USDC",
+		InvoiceAmount:   "5000000",
+		SelectedChain:   "eip155:8453",
+		SelectedToken:   "0xusdc",
+		DestWallet:      "0xmerchant",
+		SimulateFrom:    payer,
+	}
+
+	out, err := uc.CreateQuote(context.Background(), input)
+	require.NoError(t, err)
+	require.Equal(t, []string{"0xusdc", "5000000", "0xmerchant", payer}, simulated)
+	require.NotNil(t, out.Simulation)
+	require.Equal(t, domainentities.PaymentSimulationReverted, out.Simulation.Status)
+
+	// Without simulateFrom nothing is simulated
+	input.SimulateFrom = ""
+	out, err = uc.CreateQuote(context.Background(), input)
+	require.NoError(t, err)
+	require.Nil(t, out.Simulation)
+	require.Len(t, simulated, 4)
+
+	input.SimulateFrom = "payer"
+	_, err = uc.CreateQuote(context.Background(), input)
+	require.Error(t, err)
+}
+
 func TestPartnerQuoteUsecase_CreateQuote_UsesExpiresAtOverride(t *testing.T) {
 	chainID := uuid.New()
 	quoteRepo := &partnerQuoteRepoStub{}
@@ -175,7 +238,7 @@ func TestPartnerQuoteUsecase_CreateQuote_UsesExpiresAtOverride(t *testing.T) {
 		},
 	}
 	chainRepo := &partnerQuoteChainRepoStub{
-		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM},
+		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM, IsActive: true},
 	}
 
 	uc := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
@@ -224,7 +287,7 @@ func TestPartnerQuoteUsecase_CreateQuote_UnsupportedPair(t *testing.T) {
 		},
 	}
 	chainRepo := &partnerQuoteChainRepoStub{
-		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM},
+		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM, IsActive: true},
 	}
 
 	uc := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
@@ -250,6 +313,38 @@ func TestPartnerQuoteUsecase_CreateQuote_UnsupportedPair(t *testing.T) {
 	require.Equal(t, 400, appErr.Status)
 }
 
+func TestPartnerQuoteUsecase_CreateQuote_DeactivatedChain(t *testing.T) {
+	chainID := uuid.New()
+	tokenRepo := &partnerQuoteTokenRepoStub{
+		byAddress: map[string]*domainentities.Token{
+			"0xusdc": {ChainUUID: chainID, ContractAddress: "0xusdc", Symbol: "USDC", Decimals: 6, IsActive: true},
+		},
+	}
+	chainRepo := &partnerQuoteChainRepoStub{
+		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM},
+	}
+
+	uc := NewPartnerQuoteUsecase(&partnerQuoteRepoStub{}, tokenRepo, chainRepo, nil)
+	uc.routeSupportFn = func(ctx context.Context, chainID uuid.UUID, tokenIn string, tokenOut string) (*TokenRouteSupportStatus, error) {
+		return nil, errors.New("should not be called")
+	}
+	uc.swapQuoteFn = func(ctx context.Context, chainID uuid.UUID, tokenIn string, tokenOut string, amountIn *big.Int) (*big.Int, error) {
+		return nil, errors.New("should not be called")
+	}
+
+	_, err := uc.CreateQuote(context.Background(), &CreatePartnerQuoteInput{
+		MerchantID:      uuid.New(),
+		InvoiceCurrency: "USDC",
+		InvoiceAmount:   "5000000",
+		SelectedChain:   "eip155:8453",
+		SelectedToken:   "0xusdc",
+		DestWallet:      "0xmerchant",
+	})
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeInactive, appErr.Code)
+}
+
 func TestPartnerQuoteUsecase_CreateQuote_AccurateQuoteFallbacksToSwapQuote(t *testing.T) {
 	chainID := uuid.New()
 	quoteRepo := &partnerQuoteRepoStub{}
@@ -264,7 +359,7 @@ func TestPartnerQuoteUsecase_CreateQuote_AccurateQuoteFallbacksToSwapQuote(t *te
 		},
 	}
 	chainRepo := &partnerQuoteChainRepoStub{
-		chain: &domainentities.Chain{ID: chainID, ChainID: "137", Name: "Polygon", Type: domainentities.ChainTypeEVM},
+		chain: &domainentities.Chain{ID: chainID, ChainID: "137", Name: "Polygon", Type: domainentities.ChainTypeEVM, IsActive: true},
 	}
 
 	uc := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
@@ -313,7 +408,7 @@ func TestPartnerQuoteUsecase_CreateQuote_UsesExplicitInvoiceTokenAddress(t *test
 		},
 	}
 	chainRepo := &partnerQuoteChainRepoStub{
-		chain: &domainentities.Chain{ID: chainID, ChainID: "137", Name: "Polygon", Type: domainentities.ChainTypeEVM},
+		chain: &domainentities.Chain{ID: chainID, ChainID: "137", Name: "Polygon", Type: domainentities.ChainTypeEVM, IsActive: true},
 	}
 
 	uc := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
@@ -356,7 +451,7 @@ func TestPartnerQuoteUsecase_CreateQuote_PlaceholderFallsBackToSimulator(t *test
 		},
 	}
 	chainRepo := &partnerQuoteChainRepoStub{
-		chain: &domainentities.Chain{ID: chainID, ChainID: "137", Name: "Polygon", Type: domainentities.ChainTypeEVM},
+		chain: &domainentities.Chain{ID: chainID, ChainID: "137", Name: "Polygon", Type: domainentities.ChainTypeEVM, IsActive: true},
 	}
 
 	uc := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
@@ -412,7 +507,7 @@ func TestPartnerQuoteUsecase_CreateQuote_PlaceholderRejectedWithoutSimulator(t *
 		},
 	}
 	chainRepo := &partnerQuoteChainRepoStub{
-		chain: &domainentities.Chain{ID: chainID, ChainID: "137", Name: "Polygon", Type: domainentities.ChainTypeEVM},
+		chain: &domainentities.Chain{ID: chainID, ChainID: "137", Name: "Polygon", Type: domainentities.ChainTypeEVM, IsActive: true},
 	}
 
 	uc := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
@@ -457,7 +552,7 @@ func TestPartnerQuoteUsecase_PreviewRequiredInputForOutput_SupportedPair(t *test
 		},
 	}
 	chainRepo := &partnerQuoteChainRepoStub{
-		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM},
+		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM, IsActive: true},
 	}
 
 	uc := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
@@ -504,7 +599,7 @@ func TestPartnerQuoteUsecase_PreviewRequiredInputForOutput_UnsupportedWhenExactO
 		},
 	}
 	chainRepo := &partnerQuoteChainRepoStub{
-		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM},
+		chain: &domainentities.Chain{ID: chainID, ChainID: "8453", Name: "Base", Type: domainentities.ChainTypeEVM, IsActive: true},
 	}
 
 	uc := NewPartnerQuoteUsecase(quoteRepo, tokenRepo, chainRepo, nil)
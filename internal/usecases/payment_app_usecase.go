@@ -23,6 +23,11 @@ type PaymentAppUsecase struct {
 	walletRepo     repositories.WalletRepository
 	chainRepo      repositories.ChainRepository
 	chainResolver  *ChainResolver
+
+	// intentNonces and requireSignedIntent configure signed payment intents (see verifyPaymentIntent)
+	intentNonces        repositories.PaymentIntentNonceRepository
+	requireSignedIntent bool
+	now                 func() time.Time
 }
 
 var predictEscrowStealthAddressFn = tryPredictEscrowStealthAddress
@@ -39,9 +44,26 @@ func NewPaymentAppUsecase(
 		walletRepo:     walletRepo,
 		chainRepo:      chainRepo,
 		chainResolver:  NewChainResolver(chainRepo),
+		now:            time.Now,
 	}
 }
 
+// NewPaymentAppUsecaseWithSignedIntents also verifies signed payment intents. With
+// requireSignedIntent, payments from an EVM source chain are refused without one.
+func NewPaymentAppUsecaseWithSignedIntents(
+	paymentUsecase *PaymentUsecase,
+	userRepo repositories.UserRepository,
+	walletRepo repositories.WalletRepository,
+	chainRepo repositories.ChainRepository,
+	intentNonces repositories.PaymentIntentNonceRepository,
+	requireSignedIntent bool,
+) *PaymentAppUsecase {
+	u := NewPaymentAppUsecase(paymentUsecase, userRepo, walletRepo, chainRepo)
+	u.intentNonces = intentNonces
+	u.requireSignedIntent = requireSignedIntent
+	return u
+}
+
 func (u *PaymentAppUsecase) CreatePaymentApp(ctx context.Context, input *entities.CreatePaymentAppInput) (*entities.CreatePaymentResponse, error) {
 	mode := normalizePaymentMode(input.Mode)
 	if _, err := normalizeBridgeOption(input.BridgeOption); err != nil {
@@ -58,6 +80,14 @@ func (u *PaymentAppUsecase) CreatePaymentApp(ctx context.Context, input *entitie
 	if err != nil {
 		return nil, fmt.Errorf("invalid destination chain: %w", err)
 	}
+	// Verified before the sender's user and wallet are looked up or auto-created
+	if input.Intent != nil {
+		if err := u.verifyPaymentIntent(ctx, input, sourceCAIP2, destCAIP2); err != nil {
+			return nil, err
+		}
+	} else if u.requireSignedIntent && entities.ChainTypeFromCAIP2(sourceCAIP2).IsEVM() {
+		return nil, invalidPaymentIntent("a signed payment intent is required")
+	}
 	if mode == PaymentModePrivacy {
 		intentID, stealthReceiver, err := u.preparePrivacyRoutingWithDB(ctx, sourceChainID, input)
 		if err != nil {
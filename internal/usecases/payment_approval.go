@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/volatiletech/null/v8"
+	"go.uber.org/zap"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/logger"
+	"payment-kita.backend/pkg/utils"
+)
+
+// exceedsApprovalThreshold reports whether amount, in the token's smallest unit, is above the
+// token's cross-chain approval threshold. Tokens without a threshold never need approval.
+func exceedsApprovalThreshold(token *entities.Token, amount *big.Int) bool {
+	if token == nil || !token.ApprovalThreshold.Valid || amount == nil {
+		return false
+	}
+	raw, err := convertToSmallestUnit(token.ApprovalThreshold.String, token.Decimals)
+	if err != nil {
+		return false
+	}
+	threshold, ok := new(big.Int).SetString(raw, 10)
+	return ok && amount.Cmp(threshold) > 0
+}
+
+// calldataWithheld reports whether a payment in status must not be handed calldata: a held
+// payment gets none until approved, and a failed or rejected one never does again
+func calldataWithheld(status entities.PaymentStatus) bool {
+	return status == entities.PaymentStatusPendingApproval || status == entities.PaymentStatusFailed
+}
+
+// errApprovalRequired refuses to preview calldata for a payment that would be held for review
+func errApprovalRequired(token *entities.Token) error {
+	return domainerrors.NewAppError(
+		http.StatusUnprocessableEntity,
+		domainerrors.CodeApprovalRequired,
+		fmt.Sprintf("cross-chain payments above %s %s need admin approval; create the payment and retry it with its paymentId once approved", token.ApprovalThreshold.String, token.Symbol),
+		domainerrors.ErrApprovalRequired,
+	)
+}
+
+// ListPendingApprovalPayments returns the payments held for admin review, oldest first
+func (u *PaymentUsecase) ListPendingApprovalPayments(ctx context.Context, page, limit int) ([]*entities.Payment, int, error) {
+	offset := (page - 1) * limit
+	return u.paymentRepo.GetByStatus(ctx, entities.PaymentStatusPendingApproval, limit, offset)
+}
+
+// ApprovePayment releases a held payment. It becomes PENDING with a fresh expiry, and the payer
+// gets its calldata by retrying CreatePayment with the payment's ID.
+func (u *PaymentUsecase) ApprovePayment(ctx context.Context, paymentID, adminID uuid.UUID) (*entities.Payment, error) {
+	expiresAt := time.Now().Add(PaymentExpiryDuration)
+	return u.reviewPayment(ctx, paymentID, adminID, entities.PaymentStatusPending, null.String{}, &expiresAt, entities.PaymentEventTypeApproved)
+}
+
+// RejectPayment fails a held payment; no calldata is ever handed out for it
+func (u *PaymentUsecase) RejectPayment(ctx context.Context, paymentID, adminID uuid.UUID, reason string) (*entities.Payment, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, domainerrors.BadRequest("reason is required")
+	}
+	return u.reviewPayment(ctx, paymentID, adminID, entities.PaymentStatusFailed, null.StringFrom("rejected in admin review: "+reason), nil, entities.PaymentEventTypeRejected)
+}
+
+func (u *PaymentUsecase) reviewPayment(
+	ctx context.Context,
+	paymentID, adminID uuid.UUID,
+	status entities.PaymentStatus,
+	failureReason null.String,
+	expiresAt *time.Time,
+	eventType entities.PaymentEventType,
+) (*entities.Payment, error) {
+	payment, err := u.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != entities.PaymentStatusPendingApproval {
+		return nil, domainerrors.Conflict(fmt.Sprintf("payment is %s, not awaiting approval", payment.Status))
+	}
+	if err := u.paymentRepo.ResolvePendingApproval(ctx, paymentID, status, failureReason, expiresAt); err != nil {
+		// Another admin reviewed it between the read and the update
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			return nil, domainerrors.Conflict("payment was already reviewed")
+		}
+		return nil, err
+	}
+
+	metadata := map[string]interface{}{"reviewedBy": adminID.String()}
+	if failureReason.Valid {
+		metadata["reason"] = failureReason.String
+	}
+	event := &entities.PaymentEvent{
+		ID:        utils.GenerateUUIDv7(),
+		PaymentID: paymentID,
+		EventType: eventType,
+		ChainID:   &payment.SourceChainID,
+		Metadata:  metadata,
+		CreatedAt: time.Now(),
+	}
+	if err := u.paymentEventRepo.Create(ctx, event); err != nil {
+		logger.Warn(ctx, "Failed to record payment review event",
+			zap.String("payment_id", paymentID.String()),
+			zap.String("event_type", string(eventType)),
+			zap.Error(err),
+		)
+	}
+
+	payment.Status = status
+	payment.FailureReason = failureReason
+	payment.ExpiresAt = expiresAt
+	return payment, nil
+}
@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// GetApprovalTarget returns the spender a payer must approve before paying with tokenAddress on
+// chain, worked out the way CreatePayment does: the active vault contract, else the gateway's
+// vault(). amount is in whole tokens and optional; with it the response also carries the
+// allowance CreatePayment would ask for on a same-chain payment of that amount. Bridge fees on
+// cross-chain ERC20 payments are paid as native value, so they do not change the allowance.
+func (u *PaymentUsecase) GetApprovalTarget(ctx context.Context, chain, tokenAddress, amount string) (*entities.ApprovalTarget, error) {
+	chain = strings.TrimSpace(chain)
+	tokenAddress = strings.TrimSpace(tokenAddress)
+	if chain == "" {
+		return nil, domainerrors.BadRequest("chain is required")
+	}
+	chainUUID, caip2, err := u.chainResolver.ResolveFromAny(ctx, chain)
+	if err != nil {
+		return nil, domainerrors.BadRequest(fmt.Sprintf("invalid chain: %s", chain))
+	}
+	sourceChain, err := u.chainRepo.GetByID(ctx, chainUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &entities.ApprovalTarget{ChainID: caip2, TokenAddress: tokenAddress}
+	if !u.shouldRequireEvmApproval(tokenAddress) {
+		// Native payments carry the amount as tx value; there is nothing to approve
+		target.Reason = "native token is sent as transaction value"
+		if native, err := u.tokenRepo.GetNative(ctx, chainUUID); err == nil && native != nil {
+			target.TokenSymbol = native.Symbol
+			target.Decimals = native.Decimals
+		}
+		return target, nil
+	}
+
+	token, err := u.resolveToken(ctx, tokenAddress, chainUUID)
+	if err != nil {
+		return nil, domainerrors.NotFound(fmt.Sprintf("token %s is not supported on %s", tokenAddress, caip2))
+	}
+	target.TokenAddress = token.ContractAddress
+	target.TokenSymbol = token.Symbol
+	target.Decimals = token.Decimals
+
+	var rawAmount string
+	if strings.TrimSpace(amount) != "" {
+		rawAmount, err = convertToSmallestUnit(amount, token.Decimals)
+		if err != nil {
+			return nil, domainerrors.BadRequest(fmt.Sprintf("invalid amount: %v", err))
+		}
+		if value, ok := new(big.Int).SetString(rawAmount, 10); !ok || value.Sign() <= 0 {
+			return nil, domainerrors.BadRequest("amount must be greater than zero")
+		}
+		target.Amount = rawAmount
+	}
+
+	if !sourceChain.ChainType().IsEVM() {
+		target.Reason = "token allowances only apply to EVM chains"
+		return target, nil
+	}
+
+	gateway, err := u.contractRepo.GetActiveContract(ctx, chainUUID, entities.ContractTypeGateway)
+	if err != nil || gateway == nil {
+		return nil, errGatewayNotConfigured(caip2)
+	}
+	target.ApprovalRequired = true
+	target.GatewayAddress = gateway.ContractAddress
+	target.Spender = u.ResolveVaultAddressForApproval(chainUUID, gateway.ContractAddress)
+	if target.Spender == "" {
+		return nil, domainerrors.NotFound(fmt.Sprintf("vault contract address is not configured for %s", caip2))
+	}
+
+	if rawAmount != "" {
+		tokenID := token.ID
+		payment := &entities.Payment{
+			SourceChainID:      chainUUID,
+			DestChainID:        chainUUID,
+			SourceTokenID:      &tokenID,
+			DestTokenID:        &tokenID,
+			SourceToken:        token,
+			SourceTokenAddress: token.ContractAddress,
+			DestTokenAddress:   token.ContractAddress,
+			SourceAmount:       rawAmount,
+			TotalCharged:       rawAmount,
+		}
+		target.ApprovalAmount, err = u.CalculateOnchainApprovalAmount(payment, gateway.ContractAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return target, nil
+}
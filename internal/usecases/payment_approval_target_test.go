@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestPaymentUsecase_GetApprovalTarget(t *testing.T) {
+	ctx := context.Background()
+	baseID := uuid.New()
+	solanaID := uuid.New()
+	base := &entities.Chain{ID: baseID, ChainID: "8453", Type: entities.ChainTypeEVM}
+	solana := &entities.Chain{ID: solanaID, ChainID: "devnet", Type: entities.ChainTypeSVM}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{baseID: base, solanaID: solana},
+		byCAIP2: map[string]*entities.Chain{"eip155:8453": base, "solana:devnet": solana},
+	}
+	usdc := &entities.Token{ID: uuid.New(), Symbol: "USDC", Decimals: 6, ContractAddress: "0xusdc", ChainUUID: baseID}
+	splUSDC := &entities.Token{ID: uuid.New(), Symbol: "USDC", Decimals: 6, ContractAddress: "EPjFWdd5", ChainUUID: solanaID}
+	const vault = "0x2222222222222222222222222222222222222222"
+	contracts := map[entities.SmartContractType]string{entities.ContractTypeGateway: feeEngineGateway, entities.ContractTypeVault: vault}
+	u := &PaymentUsecase{
+		chainRepo:     chainRepo,
+		chainResolver: NewChainResolver(chainRepo),
+		tokenRepo: &createPaymentTokenRepoStub{
+			byAddress: map[string]*entities.Token{baseID.String() + "|0xusdc": usdc, solanaID.String() + "|EPjFWdd5": splUSDC},
+			native:    &entities.Token{Symbol: "ETH", Decimals: 18},
+		},
+		contractRepo: &scRepoStub{getActiveFn: func(_ context.Context, _ uuid.UUID, typ entities.SmartContractType) (*entities.SmartContract, error) {
+			if address, ok := contracts[typ]; ok {
+				return &entities.SmartContract{ContractAddress: address, Type: typ}, nil
+			}
+			return nil, domainerrors.ErrNotFound
+		}},
+		// 1% capped at 5 tokens; the gateway has no RPC so FeeConfig prices the fee
+		feeConfigRepo: &feeConfigRepoStub{getByChainAndTokenFn: func(context.Context, uuid.UUID, uuid.UUID) (*entities.FeeConfig, error) {
+			return &entities.FeeConfig{FixedBaseFee: "5", PlatformFeePercent: "0.01", MinFee: "0"}, nil
+		}},
+	}
+
+	// Spender only
+	target, err := u.GetApprovalTarget(ctx, "eip155:8453", "0xusdc", "")
+	require.NoError(t, err)
+	require.True(t, target.ApprovalRequired)
+	require.Equal(t, vault, target.Spender)
+	require.Equal(t, feeEngineGateway, target.GatewayAddress)
+	require.Empty(t, target.ApprovalAmount)
+
+	// 12.5 USDC + 1% fee, plus the 1% buffer a FeeConfig-priced fee gets
+	target, err = u.GetApprovalTarget(ctx, "eip155:8453", "0xusdc", "12.5")
+	require.NoError(t, err)
+	require.Equal(t, "12500000", target.Amount)
+	require.Equal(t, "12751250", target.ApprovalAmount)
+
+	for _, native := range []string{"", "native", "0x0000000000000000000000000000000000000000"} {
+		target, err = u.GetApprovalTarget(ctx, "eip155:8453", native, "1")
+		require.NoError(t, err)
+		require.False(t, target.ApprovalRequired)
+		require.Empty(t, target.Spender)
+		require.Equal(t, "ETH", target.TokenSymbol)
+		require.NotEmpty(t, target.Reason)
+	}
+
+	target, err = u.GetApprovalTarget(ctx, "solana:devnet", "EPjFWdd5", "1")
+	require.NoError(t, err)
+	require.False(t, target.ApprovalRequired)
+	require.Empty(t, target.Spender)
+
+	var appErr *domainerrors.AppError
+	_, err = u.GetApprovalTarget(ctx, "eip155:8453", "0xunknown", "")
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusNotFound, appErr.Status)
+	_, err = u.GetApprovalTarget(ctx, "eip155:1", "0xusdc", "")
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+	_, err = u.GetApprovalTarget(ctx, "eip155:8453", "0xusdc", "0")
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+
+	delete(contracts, entities.ContractTypeGateway)
+	_, err = u.GetApprovalTarget(ctx, "eip155:8453", "0xusdc", "")
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeGatewayNotConfigured, appErr.Code)
+}
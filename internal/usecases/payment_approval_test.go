@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/utils"
+)
+
+func TestExceedsApprovalThreshold(t *testing.T) {
+	token := &entities.Token{Decimals: 6, ApprovalThreshold: null.StringFrom("1000.5")}
+	require.False(t, exceedsApprovalThreshold(token, big.NewInt(1000_500000)))
+	require.True(t, exceedsApprovalThreshold(token, big.NewInt(1000_500001)))
+	require.False(t, exceedsApprovalThreshold(&entities.Token{Decimals: 6}, big.NewInt(1<<60)))
+	require.False(t, exceedsApprovalThreshold(nil, big.NewInt(1)))
+}
+
+func TestPaymentUsecase_CrossChainApprovalQueue(t *testing.T) {
+	ctx := context.Background()
+	sourceID := uuid.New()
+	destID := uuid.New()
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	dest := &entities.Chain{ID: destID, ChainID: "42161", Type: entities.ChainTypeEVM, IsActive: true}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{sourceID: source, destID: dest},
+		byCAIP2: map[string]*entities.Chain{"eip155:8453": source, "eip155:42161": dest},
+	}
+	srcTok := &entities.Token{ID: uuid.New(), Symbol: "USDC", Decimals: 6, ContractAddress: "0xusdc", ChainUUID: sourceID, ApprovalThreshold: null.StringFrom("1000"), IsActive: true}
+	destTok := &entities.Token{ID: uuid.New(), Symbol: "USDC", Decimals: 6, ContractAddress: "0xusdc", ChainUUID: destID, IsActive: true}
+	paymentRepo := &createPaymentRepoStub{byID: map[uuid.UUID]*entities.Payment{}}
+	eventRepo := &createPaymentEventRepoStub{}
+	u := &PaymentUsecase{
+		paymentRepo:      paymentRepo,
+		paymentEventRepo: eventRepo,
+		chainRepo:        chainRepo,
+		chainResolver:    NewChainResolver(chainRepo),
+		tokenRepo: &createPaymentTokenRepoStub{byAddress: map[string]*entities.Token{
+			sourceID.String() + "|0xusdc": srcTok,
+			destID.String() + "|0xusdc":   destTok,
+		}},
+		contractRepo: &scRepoStub{getActiveFn: func(_ context.Context, _ uuid.UUID, typ entities.SmartContractType) (*entities.SmartContract, error) {
+			if typ != entities.ContractTypeGateway {
+				return nil, domainerrors.ErrNotFound
+			}
+			return &entities.SmartContract{ContractAddress: feeEngineGateway, Type: typ}, nil
+		}},
+		uow: &createPaymentUOWStub{},
+	}
+	userID := uuid.New()
+	adminID := uuid.New()
+	newInput := func(amount, destChain, destToken string) *entities.CreatePaymentInput {
+		paymentID := utils.GenerateUUIDv7()
+		return &entities.CreatePaymentInput{
+			SourceChainID:      "eip155:8453",
+			DestChainID:        destChain,
+			SourceTokenAddress: "0xusdc",
+			DestTokenAddress:   destToken,
+			ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
+			Amount:             amount,
+			PaymentID:          &paymentID,
+		}
+	}
+	create := func(input *entities.CreatePaymentInput) (*entities.CreatePaymentResponse, error) {
+		resp, err := u.CreatePayment(ctx, userID, input)
+		if paymentRepo.created != nil {
+			paymentRepo.byID[paymentRepo.created.ID] = paymentRepo.created
+			paymentRepo.created = nil
+		}
+		return resp, err
+	}
+	// The route has no router, so building its calldata fails after the payment is saved; that
+	// error is how these cases see calldata being built
+	requireBuildAttempted := func(err error) {
+		t.Helper()
+		var appErr *domainerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		require.Contains(t, appErr.Message, "failed to resolve bridge fee quote")
+	}
+
+	// At the threshold nothing changes
+	_, err := create(newInput("1000", "eip155:42161", "0xusdc"))
+	requireBuildAttempted(err)
+
+	// Above it the payment is held and no calldata is built, on creation or on a retry
+	held := newInput("1000.01", "eip155:42161", "0xusdc")
+	resp, err := create(held)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentStatusPendingApproval, resp.Status)
+	require.Nil(t, resp.SignatureData)
+	resp, err = create(held)
+	require.NoError(t, err)
+	require.True(t, resp.Replayed)
+	require.Nil(t, resp.SignatureData)
+
+	_, err = u.BuildPaymentCalldata(ctx, userID, newInput("1000.01", "eip155:42161", "0xusdc"), nil)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Equal(t, domainerrors.CodeApprovalRequired, appErr.Code)
+
+	queue, total, err := u.ListPendingApprovalPayments(ctx, 1, 20)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, *held.PaymentID, queue[0].ID)
+
+	// Approval releases it: the payer's retry now gets calldata
+	approved, err := u.ApprovePayment(ctx, *held.PaymentID, adminID)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentStatusPending, approved.Status)
+	require.NotNil(t, approved.ExpiresAt)
+	require.Equal(t, entities.PaymentEventTypeApproved, eventRepo.events[len(eventRepo.events)-1].EventType)
+	_, err = create(held)
+	requireBuildAttempted(err)
+
+	_, err = u.ApprovePayment(ctx, *held.PaymentID, adminID)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusConflict, appErr.Status)
+	_, err = u.ApprovePayment(ctx, uuid.New(), adminID)
+	require.ErrorIs(t, err, domainerrors.ErrNotFound)
+
+	// A rejected payment fails and never gets calldata
+	rejected := newInput("2000", "eip155:42161", "0xusdc")
+	_, err = create(rejected)
+	require.NoError(t, err)
+	_, err = u.RejectPayment(ctx, *rejected.PaymentID, adminID, " ")
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+	payment, err := u.RejectPayment(ctx, *rejected.PaymentID, adminID, "receiver not verified")
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentStatusFailed, payment.Status)
+	require.Equal(t, "rejected in admin review: receiver not verified", payment.FailureReason.String)
+	require.Equal(t, entities.PaymentEventTypeRejected, eventRepo.events[len(eventRepo.events)-1].EventType)
+	resp, err = create(rejected)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentStatusFailed, resp.Status)
+	require.Nil(t, resp.SignatureData)
+
+	_, total, err = u.ListPendingApprovalPayments(ctx, 1, 20)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
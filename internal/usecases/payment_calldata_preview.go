@@ -0,0 +1,157 @@
+package usecases
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+var createPaymentFunctionsBySelector = map[string]string{
+	CreatePaymentSelector:              "createPayment",
+	CreatePaymentDefaultBridgeSelector: "createPaymentDefaultBridge",
+	CreatePaymentPrivateSelector:       "createPaymentPrivate",
+}
+
+// BuildPaymentCalldata returns the createPayment calldata CreatePayment would hand out for
+// input, decoded back into its arguments. Nothing is persisted. paymentID pins the ID that
+// Solana calldata embeds; a fresh one is used when nil.
+func (u *PaymentUsecase) BuildPaymentCalldata(ctx context.Context, userID uuid.UUID, input *entities.CreatePaymentInput, paymentID *uuid.UUID) (*entities.PaymentCalldataPreview, error) {
+	draft, err := u.preparePayment(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+	payment := draft.payment
+	if payment.Status == entities.PaymentStatusPendingApproval {
+		return nil, errApprovalRequired(draft.sourceToken)
+	}
+	if paymentID != nil {
+		payment.ID = *paymentID
+	}
+
+	preview := &entities.PaymentCalldataPreview{
+		PaymentID:     payment.ID,
+		SourceChainID: draft.sourceCAIP2,
+		DestChainID:   draft.destCAIP2,
+		ChainType:     draft.sourceChain.ChainType().Namespace(),
+	}
+	if draft.contract != nil {
+		preview.To = draft.contract.ContractAddress
+	}
+
+	switch draft.sourceChain.ChainType() {
+	case entities.ChainTypeEVM:
+		minDestAmount := big.NewInt(0)
+		if payment.MinDestAmount.Valid {
+			minDestAmount.SetString(payment.MinDestAmount.String, 10)
+		}
+		data := u.buildEvmPaymentHexWithInput(payment, draft.destCAIP2, minDestAmount, input)
+		if data == "" {
+			return nil, domainerrors.BadRequest("failed to build gateway calldata")
+		}
+		preview.Data = data
+		preview.Selector = data[:10]
+		preview.Function, preview.Args, err = decodeEvmCreatePaymentCalldata(data)
+	case entities.ChainTypeSVM:
+		preview.Data = u.buildSvmPaymentBase58(payment)
+		preview.Function = "create_payment"
+		discriminator := anchorDiscriminator("create_payment")
+		preview.Selector = "0x" + hex.EncodeToString(discriminator[:])
+		preview.Args, err = decodeSvmCreatePaymentData(preview.Data)
+	default:
+		return nil, domainerrors.BadRequest("unsupported source chain type")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// decodeEvmCreatePaymentCalldata unpacks gateway calldata so callers see what the bytes
+// actually encode, not what we meant to encode.
+func decodeEvmCreatePaymentCalldata(data string) (string, []entities.CalldataArg, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil || len(raw) < 4 {
+		return "", nil, fmt.Errorf("decode createPayment calldata: invalid hex")
+	}
+	selector := "0x" + hex.EncodeToString(raw[:4])
+	function, ok := createPaymentFunctionsBySelector[selector]
+	if !ok {
+		return "", nil, fmt.Errorf("decode createPayment calldata: unknown selector %s", selector)
+	}
+
+	args := abi.Arguments{{Name: "request", Type: paymentRequestV2TupleType}}
+	if function == "createPaymentPrivate" {
+		args = append(args, abi.Argument{Name: "privacy", Type: privateRoutingTupleType})
+	}
+	values, err := args.Unpack(raw[4:])
+	if err != nil {
+		return "", nil, fmt.Errorf("decode createPayment calldata: %w", err)
+	}
+
+	req := abi.ConvertType(values[0], new(paymentRequestV2TupleValue)).(*paymentRequestV2TupleValue)
+	out := []entities.CalldataArg{
+		{Name: "request.destChainIdBytes", Type: "bytes", Value: prefixedHex(req.DestChainIdBytes)},
+		{Name: "request.receiverBytes", Type: "bytes", Value: prefixedHex(req.ReceiverBytes)},
+		{Name: "request.sourceToken", Type: "address", Value: req.SourceToken.Hex()},
+		{Name: "request.bridgeTokenSource", Type: "address", Value: req.BridgeTokenSource.Hex()},
+		{Name: "request.destToken", Type: "address", Value: req.DestToken.Hex()},
+		{Name: "request.amountInSource", Type: "uint256", Value: req.AmountInSource.String()},
+		{Name: "request.minBridgeAmountOut", Type: "uint256", Value: req.MinBridgeAmountOut.String()},
+		{Name: "request.minDestAmountOut", Type: "uint256", Value: req.MinDestAmountOut.String()},
+		{Name: "request.mode", Type: "uint8", Value: fmt.Sprint(req.Mode)},
+		{Name: "request.bridgeOption", Type: "uint8", Value: fmt.Sprint(req.BridgeOption)},
+	}
+	if len(values) > 1 {
+		privacy := abi.ConvertType(values[1], new(privateRoutingTupleValue)).(*privateRoutingTupleValue)
+		out = append(out,
+			entities.CalldataArg{Name: "privacy.intentId", Type: "bytes32", Value: prefixedHex(privacy.IntentId[:])},
+			entities.CalldataArg{Name: "privacy.stealthReceiver", Type: "address", Value: privacy.StealthReceiver.Hex()},
+		)
+	}
+	return function, out, nil
+}
+
+// decodeSvmCreatePaymentData splits the Anchor create_payment instruction data built by
+// buildSvmPaymentBase58 back into its fields.
+func decodeSvmCreatePaymentData(data string) ([]entities.CalldataArg, error) {
+	raw := base58Decode(data)
+	// discriminator(8) + paymentId(32) + string length prefix(4)
+	if len(raw) < 44 {
+		return nil, fmt.Errorf("decode create_payment data: too short")
+	}
+	offset := 8
+	paymentID := raw[offset : offset+32]
+	offset += 32
+	destChainLen := int(binary.LittleEndian.Uint32(raw[offset : offset+4]))
+	offset += 4
+	if len(raw) != offset+destChainLen+32+8+32 {
+		return nil, fmt.Errorf("decode create_payment data: unexpected length")
+	}
+	destChainID := string(raw[offset : offset+destChainLen])
+	offset += destChainLen
+	destToken := raw[offset : offset+32]
+	offset += 32
+	amount := binary.LittleEndian.Uint64(raw[offset : offset+8])
+	offset += 8
+	receiver := raw[offset : offset+32]
+
+	return []entities.CalldataArg{
+		{Name: "paymentId", Type: "[u8;32]", Value: prefixedHex(paymentID)},
+		{Name: "destChainId", Type: "string", Value: destChainID},
+		{Name: "destToken", Type: "[u8;32]", Value: prefixedHex(destToken)},
+		{Name: "amount", Type: "u64", Value: fmt.Sprint(amount)},
+		{Name: "receiver", Type: "[u8;32]", Value: prefixedHex(receiver)},
+	}, nil
+}
+
+func prefixedHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
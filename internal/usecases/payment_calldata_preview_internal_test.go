@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func newCalldataPreviewUsecase(chain *entities.Chain, contract *entities.SmartContract) (*PaymentUsecase, *createPaymentRepoStub) {
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{chain.ID: chain},
+		byCAIP2: map[string]*entities.Chain{chain.GetCAIP2ID(): chain},
+	}
+	// Same token on both sides keeps CalculateFees off the swap-quote RPC path.
+	token := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0x1111111111111111111111111111111111111111", ChainUUID: chain.ID, IsActive: true}
+	paymentRepo := &createPaymentRepoStub{}
+	return &PaymentUsecase{
+		paymentRepo:      paymentRepo,
+		paymentEventRepo: &createPaymentEventRepoStub{},
+		chainRepo:        chainRepo,
+		chainResolver:    NewChainResolver(chainRepo),
+		tokenRepo: &createPaymentTokenRepoStub{byAddress: map[string]*entities.Token{
+			chain.ID.String() + "|" + token.ContractAddress: token,
+		}},
+		contractRepo: &scRepoStub{getActiveFn: func(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
+			if contract == nil {
+				return nil, domainerrors.ErrNotFound
+			}
+			return contract, nil
+		}},
+		uow: &createPaymentUOWStub{},
+	}, paymentRepo
+}
+
+func TestPaymentUsecase_BuildPaymentCalldata_EVMMatchesCreatePaymentBytes(t *testing.T) {
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	gateway := &entities.SmartContract{ContractAddress: "0x3333333333333333333333333333333333333333"}
+	u, paymentRepo := newCalldataPreviewUsecase(chain, gateway)
+
+	bridgeOption := uint8(1)
+	input := &entities.CreatePaymentInput{
+		SourceChainID:      "eip155:8453",
+		DestChainID:        "eip155:8453",
+		SourceTokenAddress: "0x1111111111111111111111111111111111111111",
+		DestTokenAddress:   "0x1111111111111111111111111111111111111111",
+		ReceiverAddress:    "0x4444444444444444444444444444444444444444",
+		Amount:             "1.5",
+		Decimals:           6,
+		BridgeOption:       &bridgeOption,
+	}
+
+	preview, err := u.BuildPaymentCalldata(context.Background(), uuid.New(), input, nil)
+	require.NoError(t, err)
+	require.Nil(t, paymentRepo.created, "preview must not persist a payment")
+	require.Equal(t, "eip155", preview.ChainType)
+	require.Equal(t, gateway.ContractAddress, preview.To)
+	require.Equal(t, "createPayment", preview.Function)
+	require.Equal(t, CreatePaymentSelector, preview.Selector)
+
+	expected, err := packCreatePaymentV2Calldata(PaymentRequestV2Args{
+		DestChainIDBytes:   []byte("eip155:8453"),
+		ReceiverBytes:      common.LeftPadBytes(common.FromHex("0x4444444444444444444444444444444444444444"), 32),
+		SourceToken:        common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		DestToken:          common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		AmountInSource:     big.NewInt(1_500_000),
+		MinBridgeAmountOut: big.NewInt(0),
+		MinDestAmountOut:   big.NewInt(0),
+		BridgeOption:       1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected, preview.Data)
+
+	args := map[string]string{}
+	for _, arg := range preview.Args {
+		args[arg.Name] = arg.Value
+	}
+	require.Equal(t, "1500000", args["request.amountInSource"])
+	require.Equal(t, "1", args["request.bridgeOption"])
+	require.Equal(t, "0x"+hex.EncodeToString([]byte("eip155:8453")), args["request.destChainIdBytes"])
+
+	// Without an explicit bridge option the gateway default-bridge entrypoint is used.
+	input.BridgeOption = nil
+	preview, err = u.BuildPaymentCalldata(context.Background(), uuid.New(), input, nil)
+	require.NoError(t, err)
+	require.Equal(t, "createPaymentDefaultBridge", preview.Function)
+	require.Equal(t, CreatePaymentDefaultBridgeSelector, preview.Selector)
+}
+
+func TestPaymentUsecase_BuildPaymentCalldata_SolanaPinsPaymentID(t *testing.T) {
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "devnet", Type: entities.ChainTypeSVM, IsActive: true}
+	u, _ := newCalldataPreviewUsecase(chain, &entities.SmartContract{ContractAddress: "Program1111"})
+
+	paymentID := uuid.New()
+	input := &entities.CreatePaymentInput{
+		SourceChainID:      "solana:devnet",
+		DestChainID:        "solana:devnet",
+		SourceTokenAddress: "0x1111111111111111111111111111111111111111",
+		DestTokenAddress:   "0x1111111111111111111111111111111111111111",
+		ReceiverAddress:    "So11111111111111111111111111111111111111112",
+		Amount:             "2",
+		Decimals:           6,
+	}
+
+	preview, err := u.BuildPaymentCalldata(context.Background(), uuid.New(), input, &paymentID)
+	require.NoError(t, err)
+	require.Equal(t, paymentID, preview.PaymentID)
+	require.Equal(t, "create_payment", preview.Function)
+	require.Equal(t, u.buildSvmPaymentBase58(&entities.Payment{
+		ID:               paymentID,
+		DestChain:        chain,
+		DestTokenAddress: input.DestTokenAddress,
+		ReceiverAddress:  input.ReceiverAddress,
+		SourceAmount:     "2000000",
+	}), preview.Data)
+
+	pinned := uuidToBytes32(paymentID)
+	require.Equal(t, []entities.CalldataArg{
+		{Name: "paymentId", Type: "[u8;32]", Value: "0x" + hex.EncodeToString(pinned[:])},
+		{Name: "destChainId", Type: "string", Value: "solana:devnet"},
+		{Name: "destToken", Type: "[u8;32]", Value: "0x" + hex.EncodeToString(common.LeftPadBytes(common.FromHex("0x1111111111111111111111111111111111111111"), 32))},
+		{Name: "amount", Type: "u64", Value: "2000000"},
+		{Name: "receiver", Type: "[u8;32]", Value: "0x" + hex.EncodeToString(base58Decode("So11111111111111111111111111111111111111112"))},
+	}, preview.Args)
+}
+
+func TestDecodeEvmCreatePaymentCalldata_RejectsUnknownSelector(t *testing.T) {
+	_, _, err := decodeEvmCreatePaymentCalldata("0xdeadbeef")
+	require.Error(t, err)
+	_, err = decodeSvmCreatePaymentData("1111")
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// maxPaymentIntentLifetime bounds how far ahead an intent deadline may be, which in turn bounds
+// how long used nonces must be kept
+const maxPaymentIntentLifetime = time.Hour
+
+// paymentIntentTypes is the EIP-712 schema the payer signs. Chains, tokens and the receiver are
+// strings so Solana destinations can be signed too; amount is the decimal amount as submitted.
+var paymentIntentTypes = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+	},
+	"PaymentIntent": {
+		{Name: "payer", Type: "address"},
+		{Name: "receiver", Type: "string"},
+		{Name: "sourceChainId", Type: "string"},
+		{Name: "destChainId", Type: "string"},
+		{Name: "sourceToken", Type: "string"},
+		{Name: "destToken", Type: "string"},
+		{Name: "amount", Type: "string"},
+		{Name: "decimals", Type: "uint8"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "deadline", Type: "uint256"},
+	},
+}
+
+// paymentIntentTypedData builds the EIP-712 message for input on the EVM source chain sourceCAIP2.
+// The domain chainId is the source chain, so a signature cannot be replayed on another chain.
+func paymentIntentTypedData(input *entities.CreatePaymentAppInput, sourceCAIP2, destCAIP2 string, nonce *big.Int) (apitypes.TypedData, error) {
+	_, reference, _ := strings.Cut(sourceCAIP2, ":")
+	chainID, ok := new(big.Int).SetString(reference, 10)
+	if !entities.ChainTypeFromCAIP2(sourceCAIP2).IsEVM() || !ok {
+		return apitypes.TypedData{}, domainerrors.BadRequest("signed payment intents require an EVM source chain")
+	}
+	return apitypes.TypedData{
+		Types:       paymentIntentTypes,
+		PrimaryType: "PaymentIntent",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "PaymentKita",
+			Version: "1",
+			ChainId: (*math.HexOrDecimal256)(chainID),
+		},
+		Message: apitypes.TypedDataMessage{
+			"payer":         strings.TrimSpace(input.SenderWalletAddress),
+			"receiver":      strings.TrimSpace(input.ReceiverAddress),
+			"sourceChainId": sourceCAIP2,
+			"destChainId":   destCAIP2,
+			"sourceToken":   strings.TrimSpace(input.SourceTokenAddress),
+			"destToken":     strings.TrimSpace(input.DestTokenAddress),
+			"amount":        strings.TrimSpace(input.Amount),
+			"decimals":      strconv.Itoa(input.Decimals),
+			"nonce":         nonce.String(),
+			"deadline":      strconv.FormatInt(input.Intent.Deadline, 10),
+		},
+	}, nil
+}
+
+// verifyPaymentIntent checks input.Intent was signed by the sender wallet for exactly this
+// payment and burns its nonce. sourceCAIP2 and destCAIP2 are the resolved chain IDs, so the
+// signature covers them whatever form the caller submitted.
+func (u *PaymentAppUsecase) verifyPaymentIntent(ctx context.Context, input *entities.CreatePaymentAppInput, sourceCAIP2, destCAIP2 string) error {
+	intent := input.Intent
+	payer := strings.TrimSpace(input.SenderWalletAddress)
+	if !common.IsHexAddress(payer) {
+		return domainerrors.BadRequest("senderWalletAddress must be an EVM address to sign a payment intent")
+	}
+	nonce, ok := math.ParseBig256(strings.TrimSpace(intent.Nonce))
+	if !ok {
+		return domainerrors.BadRequest("intent.nonce must be a uint256")
+	}
+	signature, err := hexutil.Decode(strings.TrimSpace(intent.Signature))
+	if err != nil || len(signature) != crypto.SignatureLength {
+		return domainerrors.BadRequest("intent.signature must be a 65-byte hex signature")
+	}
+
+	now := u.now()
+	deadline := time.Unix(intent.Deadline, 0)
+	if !deadline.After(now) {
+		return invalidPaymentIntent("payment intent has expired")
+	}
+	if deadline.After(now.Add(maxPaymentIntentLifetime)) {
+		return invalidPaymentIntent(fmt.Sprintf("payment intent deadline must be within %s", maxPaymentIntentLifetime))
+	}
+
+	typed, err := paymentIntentTypedData(input, sourceCAIP2, destCAIP2, nonce)
+	if err != nil {
+		return err
+	}
+	digest, _, err := apitypes.TypedDataAndHash(typed)
+	if err != nil {
+		return domainerrors.BadRequest("payment intent cannot be encoded: " + err.Error())
+	}
+	// Wallets produce v as 27/28; SigToPub wants 0/1
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(digest, signature)
+	if err != nil {
+		return invalidPaymentIntent("payment intent signature cannot be recovered")
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != common.HexToAddress(payer) {
+		return invalidPaymentIntent("payment intent was not signed by senderWalletAddress")
+	}
+
+	if u.intentNonces == nil {
+		return domainerrors.InternalError(fmt.Errorf("payment intent nonce store is not configured"))
+	}
+	if err := u.intentNonces.Consume(ctx, strings.ToLower(payer), nonce.String(), deadline); err != nil {
+		if err == domainerrors.ErrAlreadyExists {
+			return invalidPaymentIntent("payment intent nonce has already been used")
+		}
+		return domainerrors.InternalError(err)
+	}
+	return nil
+}
+
+func invalidPaymentIntent(message string) error {
+	return domainerrors.NewAppError(http.StatusForbidden, domainerrors.CodeInvalidIntent, message, domainerrors.ErrInvalidPaymentIntent)
+}
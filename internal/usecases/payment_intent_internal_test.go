@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type intentNonceRepoStub struct {
+	used map[string]bool
+}
+
+func (s *intentNonceRepoStub) Consume(_ context.Context, payer, nonce string, _ time.Time) error {
+	if s.used[payer+"/"+nonce] {
+		return domainerrors.ErrAlreadyExists
+	}
+	s.used[payer+"/"+nonce] = true
+	return nil
+}
+
+func signPaymentIntent(t *testing.T, key *ecdsa.PrivateKey, input *entities.CreatePaymentAppInput, sourceCAIP2, destCAIP2 string, nonce int64) string {
+	t.Helper()
+	typed, err := paymentIntentTypedData(input, sourceCAIP2, destCAIP2, big.NewInt(nonce))
+	require.NoError(t, err)
+	digest, _, err := apitypes.TypedDataAndHash(typed)
+	require.NoError(t, err)
+	sig, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+	sig[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(sig)
+}
+
+func TestVerifyPaymentIntent(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	now := time.Unix(1_800_000_000, 0)
+	const source, dest = "eip155:8453", "eip155:42161"
+
+	u := NewPaymentAppUsecaseWithSignedIntents(nil, nil, nil, nil, &intentNonceRepoStub{used: map[string]bool{}}, false)
+	u.now = func() time.Time { return now }
+
+	newInput := func() *entities.CreatePaymentAppInput {
+		return &entities.CreatePaymentAppInput{
+			SourceChainID:       source,
+			DestChainID:         dest,
+			SourceTokenAddress:  "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913",
+			DestTokenAddress:    "0xaf88d065e77c8cC2239327C5EDb3A432268e5831",
+			Amount:              "10.5",
+			Decimals:            6,
+			SenderWalletAddress: crypto.PubkeyToAddress(key.PublicKey).Hex(),
+			ReceiverAddress:     "0x1111111111111111111111111111111111111111",
+			Intent:              &entities.PaymentIntentSignature{Nonce: "7", Deadline: now.Add(10 * time.Minute).Unix()},
+		}
+	}
+	requireInvalid := func(err error, message string) {
+		t.Helper()
+		var appErr *domainerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, http.StatusForbidden, appErr.Status)
+		require.Equal(t, domainerrors.CodeInvalidIntent, appErr.Code)
+		require.Contains(t, appErr.Message, message)
+	}
+
+	input := newInput()
+	input.Intent.Signature = signPaymentIntent(t, key, input, source, dest, 7)
+	require.NoError(t, u.verifyPaymentIntent(context.Background(), input, source, dest))
+
+	// The same signature cannot be used twice
+	requireInvalid(u.verifyPaymentIntent(context.Background(), input, source, dest), "already been used")
+
+	// Any change to the signed fields breaks the signature
+	tampered := newInput()
+	tampered.Intent.Nonce = "8"
+	tampered.Intent.Signature = signPaymentIntent(t, key, tampered, source, dest, 8)
+	tampered.Amount = "1000"
+	requireInvalid(u.verifyPaymentIntent(context.Background(), tampered, source, dest), "not signed by senderWalletAddress")
+
+	// Signed by a different wallet than the sender
+	forged := newInput()
+	forged.Intent.Nonce = "9"
+	forged.Intent.Signature = signPaymentIntent(t, other, forged, source, dest, 9)
+	requireInvalid(u.verifyPaymentIntent(context.Background(), forged, source, dest), "not signed by senderWalletAddress")
+
+	expired := newInput()
+	expired.Intent.Deadline = now.Add(-time.Second).Unix()
+	expired.Intent.Signature = signPaymentIntent(t, key, expired, source, dest, 7)
+	requireInvalid(u.verifyPaymentIntent(context.Background(), expired, source, dest), "expired")
+
+	tooLong := newInput()
+	tooLong.Intent.Deadline = now.Add(2 * time.Hour).Unix()
+	tooLong.Intent.Signature = signPaymentIntent(t, key, tooLong, source, dest, 7)
+	requireInvalid(u.verifyPaymentIntent(context.Background(), tooLong, source, dest), "deadline must be within")
+
+	malformed := newInput()
+	malformed.Intent.Signature = "0x1234"
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, u.verifyPaymentIntent(context.Background(), malformed, source, dest), &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+
+	solana := newInput()
+	solana.Intent.Signature = signPaymentIntent(t, key, solana, source, dest, 7)
+	require.ErrorAs(t, u.verifyPaymentIntent(context.Background(), solana, "solana:mainnet", dest), &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+}
+
+func TestCreatePaymentApp_RequiresSignedIntent(t *testing.T) {
+	base := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM}
+	arbitrum := &entities.Chain{ID: uuid.New(), ChainID: "42161", Type: entities.ChainTypeEVM}
+	chainRepo := &quoteChainRepoStub{byCAIP2: map[string]*entities.Chain{"eip155:8453": base, "eip155:42161": arbitrum}}
+	u := NewPaymentAppUsecaseWithSignedIntents(nil, nil, nil, chainRepo, &intentNonceRepoStub{used: map[string]bool{}}, true)
+
+	_, err := u.CreatePaymentApp(context.Background(), &entities.CreatePaymentAppInput{
+		SourceChainID:       "eip155:8453",
+		DestChainID:         "eip155:42161",
+		Amount:              "1",
+		SenderWalletAddress: "0x2222222222222222222222222222222222222222",
+		ReceiverAddress:     "0x1111111111111111111111111111111111111111",
+	})
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeInvalidIntent, appErr.Code)
+	require.Contains(t, appErr.Message, "signed payment intent is required")
+}
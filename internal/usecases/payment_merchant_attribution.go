@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type merchantScopeKeyType struct{}
+
+var merchantScopeKey = merchantScopeKeyType{}
+
+// WithMerchantScope records the merchant the request is authenticated as, e.g. through a
+// merchant API key. CreatePayment then attributes to it instead of looking the caller up.
+func WithMerchantScope(ctx context.Context, merchantID uuid.UUID) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, merchantScopeKey, merchantID)
+}
+
+func merchantScope(ctx context.Context) (uuid.UUID, bool) {
+	if ctx == nil {
+		return uuid.Nil, false
+	}
+	merchantID, ok := ctx.Value(merchantScopeKey).(uuid.UUID)
+	return merchantID, ok && merchantID != uuid.Nil
+}
+
+// callerMerchant returns the merchant the request is authenticated as: the scoped one for
+// merchant API keys, else the one owned by userID. It is nil when the caller is not a merchant.
+// Request input never picks it, so it is what allow-lists and fee waivers are decided on.
+func (u *PaymentUsecase) callerMerchant(ctx context.Context, userID uuid.UUID) (*entities.Merchant, error) {
+	if u.merchantRepo == nil {
+		return nil, nil
+	}
+
+	var merchant *entities.Merchant
+	var err error
+	if scoped, ok := merchantScope(ctx); ok {
+		merchant, err = u.merchantRepo.GetByID(ctx, scoped)
+	} else if userID != uuid.Nil {
+		merchant, err = u.merchantRepo.GetByUserID(ctx, userID)
+	} else {
+		return nil, nil
+	}
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to resolve caller merchant: %w", err)
+	}
+	return merchant, nil
+}
+
+// attributePaymentMerchant decides which merchant a payment is reported under. An explicit
+// receiverMerchantID is only accepted for an active merchant that is the caller or owns
+// receiver; anything else is refused, since the payment would show up in that merchant's
+// settlement reports and order lookups. Without one, the caller's merchant (see callerMerchant)
+// is used when it is active and the payment is inbound to it, i.e. receiver is one of its
+// addresses. A merchant user paying someone else stays a plain user payment.
+func (u *PaymentUsecase) attributePaymentMerchant(ctx context.Context, caller *entities.Merchant, receiverMerchantID, receiver string) (*uuid.UUID, error) {
+	if receiverMerchantID != "" {
+		return u.explicitPaymentMerchant(ctx, caller, receiverMerchantID, receiver)
+	}
+	if caller == nil || caller.Status != entities.MerchantStatusActive {
+		return nil, nil
+	}
+
+	inbound, err := u.isMerchantReceiver(ctx, caller, receiver)
+	if err != nil || !inbound {
+		return nil, err
+	}
+	return &caller.ID, nil
+}
+
+// explicitPaymentMerchant checks a client-supplied receiverMerchantID (see attributePaymentMerchant)
+func (u *PaymentUsecase) explicitPaymentMerchant(ctx context.Context, caller *entities.Merchant, receiverMerchantID, receiver string) (*uuid.UUID, error) {
+	refused := domainerrors.BadRequest("receiverMerchantId must be an active merchant that is the caller or owns receiverAddress")
+	merchantID, err := uuid.Parse(strings.TrimSpace(receiverMerchantID))
+	if err != nil || u.merchantRepo == nil {
+		return nil, refused
+	}
+	merchant := caller
+	if merchant == nil || merchant.ID != merchantID {
+		merchant, err = u.merchantRepo.GetByID(ctx, merchantID)
+		if err != nil {
+			if errors.Is(err, domainerrors.ErrNotFound) {
+				return nil, refused
+			}
+			return nil, fmt.Errorf("failed to resolve receiver merchant: %w", err)
+		}
+	}
+	if merchant == nil || merchant.Status != entities.MerchantStatusActive {
+		return nil, refused
+	}
+	if caller != nil && caller.ID == merchant.ID {
+		return &merchant.ID, nil
+	}
+	owns, err := u.isMerchantReceiver(ctx, merchant, receiver)
+	if err != nil {
+		return nil, err
+	}
+	if !owns {
+		return nil, refused
+	}
+	return &merchant.ID, nil
+}
+
+// isMerchantReceiver reports whether receiver is one of the merchant's own addresses: a wallet
+// of its owner or an entry of its receiver allow-list
+func (u *PaymentUsecase) isMerchantReceiver(ctx context.Context, merchant *entities.Merchant, receiver string) (bool, error) {
+	want := normalizeReceiverAddress(receiver)
+	if u.walletRepo != nil {
+		wallets, err := u.walletRepo.GetByUserID(ctx, merchant.UserID)
+		if err != nil && !errors.Is(err, domainerrors.ErrNotFound) {
+			return false, fmt.Errorf("failed to load merchant wallets: %w", err)
+		}
+		for _, wallet := range wallets {
+			if wallet != nil && normalizeReceiverAddress(wallet.Address) == want {
+				return true, nil
+			}
+		}
+	}
+	if u.receiverAllowlist != nil {
+		allowed, err := u.receiverAllowlist.ListByMerchantID(ctx, merchant.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to load merchant allowed receivers: %w", err)
+		}
+		for _, entry := range allowed {
+			if entry.Address == want {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
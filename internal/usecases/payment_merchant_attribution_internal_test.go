@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type attributionMerchantRepoStub struct {
+	fakeMerchantRepo
+	byUser map[uuid.UUID]*entities.Merchant
+	err    error
+}
+
+func (s *attributionMerchantRepoStub) GetByID(_ context.Context, id uuid.UUID) (*entities.Merchant, error) {
+	for _, merchant := range s.byUser {
+		if merchant.ID == id {
+			return merchant, nil
+		}
+	}
+	return nil, domainerrors.ErrNotFound
+}
+
+func (s *attributionMerchantRepoStub) GetByUserID(_ context.Context, userID uuid.UUID) (*entities.Merchant, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if merchant, ok := s.byUser[userID]; ok {
+		return merchant, nil
+	}
+	return nil, domainerrors.ErrNotFound
+}
+
+func TestAttributePaymentMerchant(t *testing.T) {
+	ctx := context.Background()
+	plainUser, merchantUser, apiKeyUser := uuid.New(), uuid.New(), uuid.New()
+	merchant := &entities.Merchant{ID: uuid.New(), UserID: merchantUser, Status: entities.MerchantStatusActive}
+	apiMerchant := &entities.Merchant{ID: uuid.New(), UserID: apiKeyUser, Status: entities.MerchantStatusActive}
+	const merchantWallet = "0x000000000000000000000000000000000000bEEF"
+	const apiMerchantTreasury = "0x000000000000000000000000000000000000cafe"
+	const stranger = "0x000000000000000000000000000000000000dEaD"
+
+	merchantRepo := &attributionMerchantRepoStub{byUser: map[uuid.UUID]*entities.Merchant{merchantUser: merchant, apiKeyUser: apiMerchant}}
+	allowlist := &allowedReceiverRepoStub{items: []*entities.MerchantAllowedReceiver{{MerchantID: apiMerchant.ID, Address: apiMerchantTreasury}}}
+	u := &PaymentUsecase{
+		merchantRepo: merchantRepo,
+		walletRepo: &authWalletRepoStub{getByUserIDFn: func(_ context.Context, userID uuid.UUID) ([]*entities.Wallet, error) {
+			if userID == merchantUser {
+				return []*entities.Wallet{{UserID: &merchantUser, Address: merchantWallet}}, nil
+			}
+			return nil, nil
+		}},
+		receiverAllowlist: allowlist,
+	}
+	attribute := func(ctx context.Context, userID uuid.UUID, receiverMerchantID, receiver string) (*uuid.UUID, error) {
+		caller, err := u.callerMerchant(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return u.attributePaymentMerchant(ctx, caller, receiverMerchantID, receiver)
+	}
+
+	// User-only: nothing to attribute
+	merchantID, err := attribute(ctx, plainUser, "", stranger)
+	require.NoError(t, err)
+	require.Nil(t, merchantID)
+
+	// Merchant user receiving into their own wallet, whatever the casing
+	merchantID, err = attribute(ctx, merchantUser, "", "0x000000000000000000000000000000000000beef")
+	require.NoError(t, err)
+	require.Equal(t, merchant.ID, *merchantID)
+
+	// Merchant user paying someone else
+	merchantID, err = attribute(ctx, merchantUser, "", stranger)
+	require.NoError(t, err)
+	require.Nil(t, merchantID)
+
+	// API-key scoped merchant, receiving into an allow-listed treasury
+	scoped := WithMerchantScope(ctx, apiMerchant.ID)
+	merchantID, err = attribute(scoped, apiKeyUser, "", apiMerchantTreasury)
+	require.NoError(t, err)
+	require.Equal(t, apiMerchant.ID, *merchantID)
+	merchantID, err = attribute(scoped, apiKeyUser, "", merchantWallet)
+	require.NoError(t, err)
+	require.Nil(t, merchantID)
+
+	// An explicit receiver merchant is accepted when it owns the receiver, whoever pays
+	merchantID, err = attribute(ctx, plainUser, merchant.ID.String(), merchantWallet)
+	require.NoError(t, err)
+	require.Equal(t, merchant.ID, *merchantID)
+	merchantID, err = attribute(ctx, plainUser, apiMerchant.ID.String(), apiMerchantTreasury)
+	require.NoError(t, err)
+	require.Equal(t, apiMerchant.ID, *merchantID)
+
+	// or when the caller is that merchant, even for a receiver it does not own
+	merchantID, err = attribute(scoped, apiKeyUser, apiMerchant.ID.String(), stranger)
+	require.NoError(t, err)
+	require.Equal(t, apiMerchant.ID, *merchantID)
+
+	// Attributing to another merchant is refused: the API-key merchant cannot report a payment
+	// to its own treasury, or to a stranger, under the other merchant
+	var appErr *domainerrors.AppError
+	for _, receiver := range []string{apiMerchantTreasury, stranger} {
+		_, err = attribute(scoped, apiKeyUser, merchant.ID.String(), receiver)
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, http.StatusBadRequest, appErr.Status)
+	}
+	// as are unknown merchants and IDs that do not parse
+	for _, explicit := range []string{uuid.NewString(), "not-a-uuid"} {
+		_, err = attribute(ctx, plainUser, explicit, stranger)
+		require.ErrorAs(t, err, &appErr)
+		require.Equal(t, http.StatusBadRequest, appErr.Status)
+	}
+
+	// Suspended merchants are not attributed
+	merchant.Status = entities.MerchantStatusSuspended
+	merchantID, err = attribute(ctx, merchantUser, "", merchantWallet)
+	require.NoError(t, err)
+	require.Nil(t, merchantID)
+	_, err = attribute(ctx, plainUser, merchant.ID.String(), merchantWallet)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+
+	// A failed lookup fails the payment rather than misreporting it
+	merchantRepo.err = errors.New("db down")
+	_, err = attribute(ctx, plainUser, "", stranger)
+	require.ErrorContains(t, err, "db down")
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/volatiletech/null/v8"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// MaxMetadataBytes caps integrator metadata on payments and payment requests, measured after
+// compaction
+const MaxMetadataBytes = 4096
+
+// normalizeMetadata checks raw is a JSON object within MaxMetadataBytes and compacts it. Empty
+// input and a JSON null mean no metadata. The content itself is never interpreted.
+func normalizeMetadata(raw json.RawMessage) (null.JSON, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return null.JSON{}, nil
+	}
+	if trimmed[0] != '{' {
+		return null.JSON{}, domainerrors.BadRequest("metadata must be a JSON object")
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, trimmed); err != nil {
+		return null.JSON{}, domainerrors.BadRequest("metadata must be valid JSON")
+	}
+	if compacted.Len() > MaxMetadataBytes {
+		return null.JSON{}, domainerrors.BadRequest(fmt.Sprintf("metadata must be at most %d bytes", MaxMetadataBytes))
+	}
+	return null.JSONFrom(compacted.Bytes()), nil
+}
@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestNormalizeMetadata(t *testing.T) {
+	metadata, err := normalizeMetadata(json.RawMessage(`{ "cart": [1, 2], "customerId": "cus_1" }`))
+	require.NoError(t, err)
+	require.True(t, metadata.Valid)
+	require.Equal(t, `{"cart":[1,2],"customerId":"cus_1"}`, string(metadata.JSON))
+
+	for _, empty := range []string{"", "  ", "null"} {
+		metadata, err = normalizeMetadata(json.RawMessage(empty))
+		require.NoError(t, err)
+		require.False(t, metadata.Valid)
+	}
+
+	for _, invalid := range []string{`"text"`, `[1,2]`, `{"open":`} {
+		_, err = normalizeMetadata(json.RawMessage(invalid))
+		require.Error(t, err, invalid)
+	}
+
+	tooLarge := `{"blob":"` + strings.Repeat("x", MaxMetadataBytes) + `"}`
+	_, err = normalizeMetadata(json.RawMessage(tooLarge))
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+	require.Contains(t, appErr.Message, "at most")
+}
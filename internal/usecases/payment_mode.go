@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type paymentModeKeyType struct{}
+
+var paymentModeKey = paymentModeKeyType{}
+
+// WithPaymentMode records the request's payment mode: the API key's, or the one a dashboard
+// session asked for. Payments created under it carry the mode, are held to chains of the
+// matching network, and listings only return payments of that mode.
+func WithPaymentMode(ctx context.Context, mode entities.PaymentMode) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, paymentModeKey, mode)
+}
+
+// paymentMode returns the request's mode. Requests without one are live, and held to mainnet
+// chains like any live key.
+func paymentMode(ctx context.Context) entities.PaymentMode {
+	if ctx == nil {
+		return entities.PaymentModeLive
+	}
+	if mode, ok := ctx.Value(paymentModeKey).(entities.PaymentMode); ok && mode != "" {
+		return mode
+	}
+	return entities.PaymentModeLive
+}
+
+// checkChainsForMode keeps test-mode payments on testnets and live-mode payments on mainnets,
+// so integration tests never move real funds and live keys never take testnet tokens
+func checkChainsForMode(mode entities.PaymentMode, chains ...*entities.Chain) error {
+	for _, chain := range chains {
+		if chain == nil {
+			continue
+		}
+		var message string
+		switch {
+		case mode == entities.PaymentModeTest && !chain.IsTestnet:
+			message = fmt.Sprintf("test-mode payments can only use testnet chains; %s is a mainnet", chain.GetCAIP2ID())
+		case mode == entities.PaymentModeLive && chain.IsTestnet:
+			message = fmt.Sprintf("live-mode payments can only use mainnet chains; %s is a testnet", chain.GetCAIP2ID())
+		default:
+			continue
+		}
+		return domainerrors.NewAppError(
+			http.StatusUnprocessableEntity,
+			domainerrors.CodeChainModeMismatch,
+			message,
+			domainerrors.ErrChainModeMismatch,
+		)
+	}
+	return nil
+}
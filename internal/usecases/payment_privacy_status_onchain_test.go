@@ -27,6 +27,7 @@ func TestPaymentUsecase_GetPaymentPrivacyStatus_UsesOnchainForwardCompletionFall
 		eventRepo,
 		new(MockWalletRepository),
 		new(MockMerchantRepository),
+		nil,
 		contractRepo,
 		chainRepo,
 		new(MockTokenRepository),
@@ -111,6 +112,7 @@ func TestPaymentUsecase_GetPaymentPrivacyStatus_UsesOnchainRetryFallbackWhenEven
 		eventRepo,
 		new(MockWalletRepository),
 		new(MockMerchantRepository),
+		nil,
 		contractRepo,
 		chainRepo,
 		new(MockTokenRepository),
@@ -9,7 +9,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
-	"payment-kita.backend/internal/infrastructure/blockchain"
 )
 
 func (u *PaymentUsecase) getCachedActiveContract(
@@ -70,7 +69,7 @@ func (u *PaymentUsecase) getCachedResolvedABI(
 
 func (u *PaymentUsecase) getCachedRoutePath(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	chainID uuid.UUID,
 	swapperAddress string,
 	swapperABI abi.ABI,
@@ -101,7 +100,7 @@ func (u *PaymentUsecase) getCachedRoutePath(
 
 func (u *PaymentUsecase) getCachedQuoterV3(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	chainID uuid.UUID,
 	chain *entities.Chain,
 	swapperAddress string,
@@ -139,7 +138,7 @@ func (u *PaymentUsecase) getCachedQuoterV3(
 
 func (u *PaymentUsecase) getCachedV3PoolConfig(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	chainID uuid.UUID,
 	swapperAddress, tokenIn, tokenOut string,
 ) (bool, uint32, error) {
@@ -17,7 +17,6 @@ import (
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"payment-kita.backend/internal/domain/entities"
-	"payment-kita.backend/internal/infrastructure/blockchain"
 )
 
 const (
@@ -290,7 +289,7 @@ func (u *PaymentUsecase) getRPCDryRunPartnerQuote(
 
 func quoteHopV3WithDryRunFees(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	quoterAddress string,
 	tokenIn, tokenOut string,
 	amountIn *big.Int,
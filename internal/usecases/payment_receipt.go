@@ -0,0 +1,231 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/pkg/pdf"
+)
+
+const (
+	receiptMargin     = 48.0
+	receiptLabelWidth = 150.0
+	receiptLineHeight = 16.0
+	receiptFontSize   = 10.0
+)
+
+// GetPaymentReceipt renders a payment loaded with GetPayment as a PDF receipt. The caller has
+// already checked the payment may be shown to the requester.
+func (u *PaymentUsecase) GetPaymentReceipt(ctx context.Context, payment *entities.Payment) ([]byte, error) {
+	events, err := u.paymentEventRepo.GetByPaymentID(ctx, payment.ID)
+	if err != nil {
+		return nil, err
+	}
+	return RenderPaymentReceipt(payment, events, time.Now().UTC())
+}
+
+// RenderPaymentReceipt lays out amounts, fees, route, transactions and the event timeline of a
+// payment on A4 pages. Amounts are shown in token units when the token's decimals are known.
+func RenderPaymentReceipt(payment *entities.Payment, events []*entities.PaymentEvent, generatedAt time.Time) ([]byte, error) {
+	r := &receiptWriter{doc: pdf.New()}
+	r.newPage()
+
+	r.doc.Text(receiptMargin, r.y, pdf.SansBold, 20, "Payment Receipt")
+	r.y += 14
+	r.doc.Line(receiptMargin, r.y, pdf.PageWidth-receiptMargin, r.y)
+	r.y += 10
+
+	r.section("Payment")
+	r.row("Payment ID", payment.ID.String())
+	r.row("Status", string(payment.Status))
+	if payment.ExternalRef.Valid {
+		r.row("Reference", payment.ExternalRef.String)
+	}
+	r.row("Created", receiptTime(payment.CreatedAt))
+	r.row("Updated", receiptTime(payment.UpdatedAt))
+	if payment.FailureReason.Valid {
+		r.row("Failure reason", payment.FailureReason.String)
+	}
+
+	r.section("Amounts")
+	r.row("Amount", receiptAmount(payment.SourceAmount, payment.SourceToken))
+	fees := receiptFees(payment, events)
+	platformFee := receiptAmount(fees.PlatformFee, payment.SourceToken)
+	if fees.PlatformFeeWaived {
+		platformFee += " (waived)"
+	}
+	r.row("Platform fee", platformFee)
+	r.row("Bridge fee", receiptAmount(fees.BridgeFee, payment.SourceToken))
+	if fees.GasFee != "" && fees.GasFee != "0" {
+		r.row("Gas fee", receiptAmount(fees.GasFee, payment.SourceToken))
+	}
+	r.row("Total fee", receiptAmount(payment.FeeAmount, payment.SourceToken))
+	r.row("Total charged", receiptAmount(payment.TotalCharged, payment.SourceToken))
+	if payment.DestAmount.Valid {
+		r.row("Amount received", receiptAmount(payment.DestAmount.String, payment.DestToken))
+	}
+
+	r.section("Route")
+	r.row("Source chain", receiptChain(payment.SourceChain))
+	r.row("Source token", receiptToken(payment.SourceToken, payment.SourceTokenAddress))
+	r.row("Destination chain", receiptChain(payment.DestChain))
+	r.row("Destination token", receiptToken(payment.DestToken, payment.DestTokenAddress))
+	if payment.Bridge != nil && payment.Bridge.Name != "" {
+		r.row("Bridge", payment.Bridge.Name)
+	}
+	r.row("Sender", payment.SenderAddress)
+	receiver := payment.ReceiverAddress
+	if payment.ReceiverName.Valid && payment.ReceiverName.String != "" {
+		receiver = payment.ReceiverName.String + " (" + receiver + ")"
+	}
+	r.row("Receiver", receiver)
+
+	r.section("Transactions")
+	r.row("Source tx", receiptOptional(payment.SourceTxHash.String))
+	r.row("Destination tx", receiptOptional(payment.DestTxHash.String))
+	if payment.RefundTxHash.Valid {
+		r.row("Refund tx", payment.RefundTxHash.String)
+	}
+	if payment.CrossChainMessageID.Valid {
+		r.row("Bridge message", payment.CrossChainMessageID.String)
+	}
+	if payment.BridgeExplorerURL != "" {
+		r.row("Bridge explorer", payment.BridgeExplorerURL)
+	}
+
+	if len(events) > 0 {
+		r.section("Timeline")
+		for _, event := range events {
+			value := string(event.EventType)
+			if event.TxHash != "" {
+				value += "  " + event.TxHash
+			}
+			r.row(receiptTime(event.CreatedAt), value)
+		}
+	}
+
+	r.y += receiptLineHeight
+	r.fit(receiptLineHeight)
+	r.doc.Text(receiptMargin, r.y, pdf.Sans, 8, "Generated "+receiptTime(generatedAt)+". Amounts in smallest units when the token is unknown.")
+	return r.doc.Bytes()
+}
+
+// receiptFees is the fee split priced when the payment was created, stored on its CREATED
+// event. Payments created before the split was stored fall back to storedFeeBreakdown, which
+// only knows the split of same-chain payments.
+func receiptFees(payment *entities.Payment, events []*entities.PaymentEvent) entities.FeeBreakdown {
+	for _, event := range events {
+		if event.EventType != entities.PaymentEventTypeCreated {
+			continue
+		}
+		payload := heldEventPayload(event.Metadata)
+		raw, ok := payload[paymentEventFeeBreakdownKey]
+		if !ok {
+			break
+		}
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			break
+		}
+		var fees entities.FeeBreakdown
+		if err := json.Unmarshal(encoded, &fees); err != nil {
+			break
+		}
+		return fees
+	}
+	return storedFeeBreakdown(payment)
+}
+
+type receiptWriter struct {
+	doc *pdf.Document
+	y   float64
+}
+
+func (r *receiptWriter) newPage() {
+	r.doc.AddPage()
+	r.y = receiptMargin + 20
+}
+
+// fit starts a new page when height more points would run into the bottom margin
+func (r *receiptWriter) fit(height float64) {
+	if r.y+height > pdf.PageHeight-receiptMargin {
+		r.newPage()
+	}
+}
+
+func (r *receiptWriter) section(title string) {
+	r.fit(3 * receiptLineHeight)
+	r.y += receiptLineHeight
+	r.doc.Text(receiptMargin, r.y, pdf.SansBold, 12, title)
+	r.y += receiptLineHeight + 2
+}
+
+func (r *receiptWriter) row(label, value string) {
+	r.fit(receiptLineHeight)
+	maxWidth := pdf.PageWidth - 2*receiptMargin - receiptLabelWidth
+	r.doc.Text(receiptMargin, r.y, pdf.SansBold, receiptFontSize, label)
+	r.doc.Text(receiptMargin+receiptLabelWidth, r.y, pdf.Sans, receiptFontSize, r.truncateToWidth(value, maxWidth))
+	r.y += receiptLineHeight
+}
+
+// truncateToWidth cuts s on a character boundary so it fits width with an ellipsis
+func (r *receiptWriter) truncateToWidth(s string, width float64) string {
+	if r.doc.TextWidth(pdf.Sans, receiptFontSize, s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for keep := len(runes) - 1; keep > 0; keep-- {
+		if cut := string(runes[:keep]) + "…"; r.doc.TextWidth(pdf.Sans, receiptFontSize, cut) <= width {
+			return cut
+		}
+	}
+	return "…"
+}
+
+func receiptTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+func receiptOptional(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
+
+func receiptAmount(amount string, token *entities.Token) string {
+	if strings.TrimSpace(amount) == "" {
+		return "-"
+	}
+	if token == nil {
+		return amount
+	}
+	return strings.TrimSpace(smallestUnitToDecimalString(amount, token.Decimals) + " " + token.Symbol)
+}
+
+func receiptChain(chain *entities.Chain) string {
+	if chain == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s)", chain.Name, chain.GetCAIP2ID())
+}
+
+func receiptToken(token *entities.Token, address string) string {
+	if token == nil {
+		return receiptOptional(address)
+	}
+	if address == "" {
+		address = token.ContractAddress
+	}
+	if address == "" {
+		return token.Symbol
+	}
+	return token.Symbol + " " + address
+}
@@ -0,0 +1,123 @@
+package usecases_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/pdf/pdftest"
+)
+
+func TestRenderPaymentReceipt(t *testing.T) {
+	created := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	payment := &entities.Payment{
+		ID:                  uuid.MustParse("0195f0a2-7c3e-7b1a-9d4e-2f6a8b0c1d2e"),
+		Status:              entities.PaymentStatusCompleted,
+		SourceChainID:       uuid.New(),
+		DestChainID:         uuid.New(),
+		SourceAmount:        "1500000",
+		FeeAmount:           "2500",
+		TotalCharged:        "1502500",
+		DestAmount:          null.StringFrom("1499000"),
+		SenderAddress:       "0xsender",
+		ReceiverAddress:     "0xreceiver",
+		ReceiverName:        null.StringFrom("café-zürich.eth"),
+		SourceTxHash:        null.StringFrom("0xsourcetx"),
+		DestTxHash:          null.StringFrom("0xdesttx"),
+		CrossChainMessageID: null.StringFrom("0xmessage"),
+		BridgeExplorerURL:   "https://ccip.chain.link/msg/0xmessage",
+		CreatedAt:           created,
+		UpdatedAt:           created.Add(time.Minute),
+		SourceChain:         &entities.Chain{Name: "Base", ChainID: "8453", Type: entities.ChainTypeEVM},
+		DestChain:           &entities.Chain{Name: "Polygon", ChainID: "eip155:137"},
+		SourceToken:         &entities.Token{Symbol: "USDC", Decimals: 6},
+		DestToken:           &entities.Token{Symbol: "USDC", Decimals: 6, ContractAddress: "0xdesttoken"},
+		Bridge:              &entities.PaymentBridge{Name: "CCIP"},
+	}
+	events := []*entities.PaymentEvent{
+		{EventType: entities.PaymentEventTypeCreated, CreatedAt: created, Metadata: map[string]interface{}{
+			"feeBreakdown": map[string]interface{}{"platformFee": "1500", "bridgeFee": "1000", "gasFee": "0", "totalFee": "2500"},
+		}},
+		{EventType: entities.PaymentEventTypeCompleted, TxHash: "0xdesttx", CreatedAt: created.Add(time.Minute)},
+	}
+
+	out, err := usecases.RenderPaymentReceipt(payment, events, created.Add(time.Hour))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+	body := pdftest.Text(t, out)
+	for _, want := range []string{
+		"0195f0a2-7c3e-7b1a-9d4e-2f6a8b0c1d2e",
+		"COMPLETED",
+		"Amount\n1.5 USDC",
+		"Platform fee\n0.0015 USDC",
+		"Bridge fee\n0.001 USDC",
+		"Total fee\n0.0025 USDC",
+		"Total charged\n1.5025 USDC",
+		"1.499 USDC",
+		"Base (eip155:8453)",
+		"Polygon (eip155:137)",
+		"USDC 0xdesttoken",
+		"CCIP",
+		"café-zürich.eth (0xreceiver)",
+		"0xsourcetx",
+		"https://ccip.chain.link/msg/0xmessage",
+		"2026-03-02 10:01:00 UTC",
+		"Generated 2026-03-02 11:00:00 UTC",
+	} {
+		require.Contains(t, body, want)
+	}
+	require.NotContains(t, body, "Refund tx")
+	require.NotContains(t, body, "Gas fee")
+
+	// Without a stored split, a cross-chain payment's fee cannot be split
+	out, err = usecases.RenderPaymentReceipt(payment, events[1:], created)
+	require.NoError(t, err)
+	body = pdftest.Text(t, out)
+	require.Contains(t, body, "Platform fee\n-")
+	require.Contains(t, body, "Bridge fee\n-")
+	require.Contains(t, body, "Total fee\n0.0025 USDC")
+
+	// A long timeline flows onto more pages
+	for i := 0; i < 40; i++ {
+		events = append(events, &entities.PaymentEvent{EventType: entities.PaymentEventTypeDestinationTxHash, TxHash: strings.Repeat("ab", 40), CreatedAt: created})
+	}
+	out, err = usecases.RenderPaymentReceipt(payment, events, created)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "/Count 2")
+	body = pdftest.Text(t, out)
+	require.NotContains(t, body, strings.Repeat("ab", 40))
+	require.Contains(t, body, strings.Repeat("ab", 15))
+	require.Contains(t, body, "…")
+}
+
+func TestPaymentUsecase_GetPaymentReceipt(t *testing.T) {
+	eventRepo := new(MockPaymentEventRepository)
+	uc := usecases.NewPaymentUsecase(new(MockPaymentRepository), eventRepo, new(MockWalletRepository), new(MockMerchantRepository), nil,
+		new(MockSmartContractRepository), new(MockChainRepository), new(MockTokenRepository), nil, nil, nil, new(MockUnitOfWork), nil)
+
+	ctx := context.Background()
+	paymentID := uuid.New()
+	payment := &entities.Payment{ID: paymentID, Status: entities.PaymentStatusPending}
+	eventRepo.On("GetByPaymentID", ctx, paymentID).Return([]*entities.PaymentEvent{
+		{EventType: entities.PaymentEventTypeCreated, CreatedAt: time.Now()},
+	}, nil).Once()
+
+	out, err := uc.GetPaymentReceipt(ctx, payment)
+	require.NoError(t, err)
+	body := pdftest.Text(t, out)
+	require.Contains(t, body, paymentID.String())
+	require.Contains(t, body, "\nCREATED")
+
+	eventRepo.On("GetByPaymentID", ctx, paymentID).Return([]*entities.PaymentEvent(nil), errors.New("events down")).Once()
+	_, err = uc.GetPaymentReceipt(ctx, payment)
+	require.EqualError(t, err, "events down")
+	eventRepo.AssertExpectations(t)
+}
@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/domain/errors"
+)
+
+// MaxPaymentRequestBatchSize bounds a single batch so one call cannot hold a long transaction
+const MaxPaymentRequestBatchSize = 100
+
+const (
+	PaymentRequestBatchItemCreated = "CREATED"
+	PaymentRequestBatchItemFailed  = "FAILED"
+	// PaymentRequestBatchItemSkipped marks a valid item that was not created because an
+	// all-or-nothing batch had failures
+	PaymentRequestBatchItemSkipped = "SKIPPED"
+)
+
+type CreatePaymentRequestBatchInput struct {
+	UserID uuid.UUID
+	Items  []CreatePaymentRequestInput
+	// AllOrNothing creates nothing when any item fails validation
+	AllOrNothing bool
+}
+
+type PaymentRequestBatchItemResult struct {
+	Index  int    `json:"index"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	*CreatePaymentRequestOutput
+}
+
+type CreatePaymentRequestBatchOutput struct {
+	Items   []PaymentRequestBatchItemResult `json:"items"`
+	Created int                             `json:"created"`
+	Failed  int                             `json:"failed"`
+}
+
+type preparedPaymentRequest struct {
+	index    int
+	request  *entities.PaymentRequest
+	contract *entities.SmartContract
+	amount   string
+}
+
+// CreatePaymentRequestBatch validates each item independently and stores the valid ones in a
+// single transaction. Invalid items are reported per index; with AllOrNothing set, any invalid
+// item leaves the whole batch uncreated.
+func (uc *PaymentRequestUsecase) CreatePaymentRequestBatch(ctx context.Context, input CreatePaymentRequestBatchInput) (*CreatePaymentRequestBatchOutput, error) {
+	if len(input.Items) == 0 {
+		return nil, errors.BadRequest("items are required")
+	}
+	if len(input.Items) > MaxPaymentRequestBatchSize {
+		return nil, errors.BadRequest("too many items")
+	}
+
+	merchant, wallet, err := uc.resolveMerchantWallet(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CreatePaymentRequestBatchOutput{
+		Items: make([]PaymentRequestBatchItemResult, len(input.Items)),
+	}
+	prepared := make([]preparedPaymentRequest, 0, len(input.Items))
+	for i, item := range input.Items {
+		item.UserID = input.UserID
+		output.Items[i].Index = i
+
+		request, contract, err := uc.prepareBatchItem(ctx, merchant, wallet, item)
+		if err != nil {
+			output.Items[i].Status = PaymentRequestBatchItemFailed
+			output.Items[i].Error = batchItemError(err)
+			output.Failed++
+			continue
+		}
+		prepared = append(prepared, preparedPaymentRequest{index: i, request: request, contract: contract, amount: item.Amount})
+	}
+
+	if input.AllOrNothing && output.Failed > 0 {
+		for _, p := range prepared {
+			output.Items[p.index].Status = PaymentRequestBatchItemSkipped
+		}
+		return output, nil
+	}
+	if len(prepared) == 0 {
+		return output, nil
+	}
+
+	requests := make([]*entities.PaymentRequest, 0, len(prepared))
+	for _, p := range prepared {
+		requests = append(requests, p.request)
+	}
+	if err := uc.paymentRequestRepo.CreateBatch(ctx, requests); err != nil {
+		return nil, errors.InternalError(err)
+	}
+
+	for _, p := range prepared {
+		output.Items[p.index].Status = PaymentRequestBatchItemCreated
+		output.Items[p.index].CreatePaymentRequestOutput = uc.finishPaymentRequest(ctx, p.request, p.contract, p.amount)
+		output.Created++
+	}
+	return output, nil
+}
+
+func (uc *PaymentRequestUsecase) prepareBatchItem(
+	ctx context.Context,
+	merchant *entities.Merchant,
+	wallet *entities.Wallet,
+	item CreatePaymentRequestInput,
+) (*entities.PaymentRequest, *entities.SmartContract, error) {
+	// The single-create endpoint enforces these through request binding; a batch checks them
+	// per item so one bad entry does not reject the rest.
+	if strings.TrimSpace(item.ChainID) == "" {
+		return nil, nil, errors.BadRequest("chainId is required")
+	}
+	if strings.TrimSpace(item.TokenAddress) == "" {
+		return nil, nil, errors.BadRequest("tokenAddress is required")
+	}
+	if strings.TrimSpace(item.Amount) == "" {
+		return nil, nil, errors.BadRequest("amount is required")
+	}
+	return uc.newPaymentRequest(ctx, merchant, wallet, item)
+}
+
+func batchItemError(err error) string {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return appErr.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,118 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/usecases"
+)
+
+func setupPaymentRequestBatchUC(t *testing.T) (*usecases.PaymentRequestUsecase, *MockPaymentRequestRepository, uuid.UUID) {
+	t.Helper()
+	pr := new(MockPaymentRequestRepository)
+	mr := new(MockMerchantRepository)
+	wr := new(MockWalletRepository)
+	cr := new(MockChainRepository)
+	sr := new(MockSmartContractRepository)
+	tr := new(MockTokenRepository)
+	uc := newPaymentRequestUC(pr, mr, wr, cr, sr, tr, nil)
+
+	userID := uuid.New()
+	chainID := uuid.New()
+	mr.On("GetByUserID", mock.Anything, userID).Return(&entities.Merchant{
+		ID:     uuid.New(),
+		UserID: userID,
+		Status: entities.MerchantStatusActive,
+	}, nil)
+	wr.On("GetByUserID", mock.Anything, userID).Return([]*entities.Wallet{
+		{ID: uuid.New(), Address: "0xMerchant", IsPrimary: true},
+	}, nil)
+	cr.On("GetByCAIP2", mock.Anything, "eip155:8453").Return(&entities.Chain{
+		ID:       chainID,
+		Type:     entities.ChainTypeEVM,
+		ChainID:  "8453",
+		IsActive: true,
+	}, nil)
+	tr.On("GetByAddress", mock.Anything, "0xToken", chainID).Return(&entities.Token{ID: uuid.New(), Decimals: 6, IsActive: true}, nil)
+	sr.On("GetActiveContract", mock.Anything, chainID, entities.ContractTypeGateway).Return(&entities.SmartContract{
+		ID:              uuid.New(),
+		ContractAddress: "0xGateway",
+	}, nil)
+	return uc, pr, userID
+}
+
+func TestPaymentRequestUsecase_CreatePaymentRequestBatch_PartialFailure(t *testing.T) {
+	uc, pr, userID := setupPaymentRequestBatchUC(t)
+	pr.On("CreateBatch", mock.Anything, mock.MatchedBy(func(reqs []*entities.PaymentRequest) bool {
+		return len(reqs) == 2
+	})).Return(nil).Once()
+
+	out, err := uc.CreatePaymentRequestBatch(context.Background(), usecases.CreatePaymentRequestBatchInput{
+		UserID: userID,
+		Items: []usecases.CreatePaymentRequestInput{
+			{ChainID: "eip155:8453", TokenAddress: "0xToken", Amount: "1.5", Decimals: 6},
+			{ChainID: "eip155:8453", TokenAddress: "0xToken", Amount: "", Decimals: 6},
+			{ChainID: "eip155:8453", TokenAddress: "0xToken", Amount: "2", Decimals: 18},
+			{ChainID: "eip155:8453", TokenAddress: "0xToken", Amount: "3"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, out.Created)
+	assert.Equal(t, 2, out.Failed)
+	require.Len(t, out.Items, 4)
+
+	assert.Equal(t, usecases.PaymentRequestBatchItemCreated, out.Items[0].Status)
+	require.NotNil(t, out.Items[0].CreatePaymentRequestOutput)
+	assert.NotEmpty(t, out.Items[0].RequestID)
+	assert.NotNil(t, out.Items[0].TxData)
+	assert.Equal(t, usecases.PaymentRequestBatchItemFailed, out.Items[1].Status)
+	assert.Equal(t, "amount is required", out.Items[1].Error)
+	assert.Equal(t, "token decimals mismatch", out.Items[2].Error)
+	assert.Equal(t, usecases.PaymentRequestBatchItemCreated, out.Items[3].Status)
+	assert.NotEqual(t, out.Items[0].RequestID, out.Items[3].RequestID)
+	pr.AssertExpectations(t)
+}
+
+func TestPaymentRequestUsecase_CreatePaymentRequestBatch_AllOrNothing(t *testing.T) {
+	uc, pr, userID := setupPaymentRequestBatchUC(t)
+
+	out, err := uc.CreatePaymentRequestBatch(context.Background(), usecases.CreatePaymentRequestBatchInput{
+		UserID:       userID,
+		AllOrNothing: true,
+		Items: []usecases.CreatePaymentRequestInput{
+			{ChainID: "eip155:8453", TokenAddress: "0xToken", Amount: "1"},
+			{ChainID: "", TokenAddress: "0xToken", Amount: "1"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, out.Created)
+	assert.Equal(t, usecases.PaymentRequestBatchItemSkipped, out.Items[0].Status)
+	assert.Nil(t, out.Items[0].CreatePaymentRequestOutput)
+	assert.Equal(t, "chainId is required", out.Items[1].Error)
+	pr.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+}
+
+func TestPaymentRequestUsecase_CreatePaymentRequestBatch_Errors(t *testing.T) {
+	uc, pr, userID := setupPaymentRequestBatchUC(t)
+
+	_, err := uc.CreatePaymentRequestBatch(context.Background(), usecases.CreatePaymentRequestBatchInput{UserID: userID})
+	assert.Error(t, err)
+
+	_, err = uc.CreatePaymentRequestBatch(context.Background(), usecases.CreatePaymentRequestBatchInput{
+		UserID: userID,
+		Items:  make([]usecases.CreatePaymentRequestInput, usecases.MaxPaymentRequestBatchSize+1),
+	})
+	assert.Error(t, err)
+
+	pr.On("CreateBatch", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	_, err = uc.CreatePaymentRequestBatch(context.Background(), usecases.CreatePaymentRequestBatchInput{
+		UserID: userID,
+		Items:  []usecases.CreatePaymentRequestInput{{ChainID: "eip155:8453", TokenAddress: "0xToken", Amount: "1"}},
+	})
+	assert.Error(t, err)
+}
@@ -2,6 +2,8 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"time"
 
@@ -26,6 +28,8 @@ type PaymentRequestUsecase struct {
 	tokenRepo          domainRepos.TokenRepository
 	chainResolver      *ChainResolver
 	jweService         services.JWEService
+	// receiverAllowlist restricts which wallets requests may pay into; nil means unrestricted
+	receiverAllowlist domainRepos.MerchantAllowedReceiverRepository
 }
 
 func NewPaymentRequestUsecase(
@@ -49,6 +53,23 @@ func NewPaymentRequestUsecase(
 	}
 }
 
+// NewPaymentRequestUsecaseWithReceiverAllowlist is NewPaymentRequestUsecase refusing to create
+// requests that pay into a wallet outside the merchant's allow-list
+func NewPaymentRequestUsecaseWithReceiverAllowlist(
+	paymentRequestRepo domainRepos.PaymentRequestRepository,
+	merchantRepo domainRepos.MerchantRepository,
+	walletRepo domainRepos.WalletRepository,
+	chainRepo domainRepos.ChainRepository,
+	contractRepo domainRepos.SmartContractRepository,
+	tokenRepo domainRepos.TokenRepository,
+	jweService services.JWEService,
+	receiverAllowlist domainRepos.MerchantAllowedReceiverRepository,
+) *PaymentRequestUsecase {
+	uc := NewPaymentRequestUsecase(paymentRequestRepo, merchantRepo, walletRepo, chainRepo, contractRepo, tokenRepo, jweService)
+	uc.receiverAllowlist = receiverAllowlist
+	return uc
+}
+
 type CreatePaymentRequestInput struct {
 	UserID       uuid.UUID
 	ChainID      string // CAIP-2 format
@@ -56,6 +77,7 @@ type CreatePaymentRequestInput struct {
 	Amount       string // Human readable amount (e.g., "100.00")
 	Decimals     int
 	Description  string
+	Metadata     json.RawMessage // JSON object, see MaxMetadataBytes
 }
 
 type CreatePaymentRequestOutput struct {
@@ -65,37 +87,61 @@ type CreatePaymentRequestOutput struct {
 	ExpiresInSecs int                            `json:"expiresInSeconds"`
 }
 
+// ResolvePaymentRequestOutput is what the partner flow shows for a payment request. Requests
+// that can no longer be paid (completed, expired, cancelled) only carry their IDs and status.
 type ResolvePaymentRequestOutput struct {
-	PaymentID          string `json:"payment_id"`
-	MerchantID         string `json:"merchant_id"`
-	Amount             string `json:"amount"`
-	DestChain          string `json:"dest_chain"`
-	DestToken          string `json:"dest_token"`
-	DestWallet         string `json:"dest_wallet"`
-	ExpireTime         int64  `json:"expire_time"`
-	PaymentCode        string `json:"payment_code"`
-	PaymentInstruction struct {
-		QRData   string `json:"qr_data"`
-		DeepLink string `json:"deep_link"`
-	} `json:"payment_instruction"`
-	Status entities.PaymentRequestStatus `json:"status"`
+	PaymentID          string                        `json:"payment_id"`
+	MerchantID         string                        `json:"merchant_id"`
+	Amount             string                        `json:"amount,omitempty"`
+	DestChain          string                        `json:"dest_chain,omitempty"`
+	DestToken          string                        `json:"dest_token,omitempty"`
+	DestWallet         string                        `json:"dest_wallet,omitempty"`
+	ExpireTime         int64                         `json:"expire_time,omitempty"`
+	PaymentCode        string                        `json:"payment_code,omitempty"`
+	PaymentInstruction *PaymentRequestInstruction    `json:"payment_instruction,omitempty"`
+	Status             entities.PaymentRequestStatus `json:"status"`
+}
+
+// PaymentRequestInstruction is how a payer's wallet opens a pending payment request
+type PaymentRequestInstruction struct {
+	QRData   string `json:"qr_data"`
+	DeepLink string `json:"deep_link"`
 }
 
 func (uc *PaymentRequestUsecase) CreatePaymentRequest(ctx context.Context, input CreatePaymentRequestInput) (*CreatePaymentRequestOutput, error) {
+	merchant, wallet, err := uc.resolveMerchantWallet(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentRequest, contract, err := uc.newPaymentRequest(ctx, merchant, wallet, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.paymentRequestRepo.Create(ctx, paymentRequest); err != nil {
+		return nil, errors.InternalError(err)
+	}
+
+	return uc.finishPaymentRequest(ctx, paymentRequest, contract, input.Amount), nil
+}
+
+// resolveMerchantWallet loads the caller's active merchant and the wallet that receives payments
+func (uc *PaymentRequestUsecase) resolveMerchantWallet(ctx context.Context, userID uuid.UUID) (*entities.Merchant, *entities.Wallet, error) {
 	// Get merchant by user ID
-	merchant, err := uc.merchantRepo.GetByUserID(ctx, input.UserID)
+	merchant, err := uc.merchantRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		return nil, errors.NotFound("merchant not found, please apply as merchant first")
+		return nil, nil, errors.NotFound("merchant not found, please apply as merchant first")
 	}
 
 	if merchant.Status != entities.MerchantStatusActive {
-		return nil, errors.BadRequest("merchant account is not active")
+		return nil, nil, errors.BadRequest("merchant account is not active")
 	}
 
 	// Get merchant's wallet - prefer primary wallet
-	wallets, err := uc.walletRepo.GetByUserID(ctx, input.UserID)
+	wallets, err := uc.walletRepo.GetByUserID(ctx, userID)
 	if err != nil || len(wallets) == 0 {
-		return nil, errors.NotFound("no wallet found for this merchant")
+		return nil, nil, errors.NotFound("no wallet found for this merchant")
 	}
 
 	// Use primary wallet or first available
@@ -109,57 +155,86 @@ func (uc *PaymentRequestUsecase) CreatePaymentRequest(ctx context.Context, input
 	if targetWallet == nil {
 		targetWallet = wallets[0]
 	}
+	return merchant, targetWallet, nil
+}
 
-	chainUUID, caip2ID, err := uc.chainResolver.ResolveFromAny(ctx, input.ChainID)
+// newPaymentRequest validates input against the chain and token registry and builds the
+// pending request without persisting it
+func (uc *PaymentRequestUsecase) newPaymentRequest(
+	ctx context.Context,
+	merchant *entities.Merchant,
+	wallet *entities.Wallet,
+	input CreatePaymentRequestInput,
+) (*entities.PaymentRequest, *entities.SmartContract, error) {
+	metadata, err := normalizeMetadata(input.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkReceiverAllowed(ctx, uc.receiverAllowlist, &merchant.ID, wallet.Address); err != nil {
+		return nil, nil, err
+	}
+	chain, err := uc.chainResolver.ResolveChain(ctx, input.ChainID)
 	if err != nil {
-		return nil, errors.BadRequest("invalid chain id format")
+		return nil, nil, errors.BadRequest("invalid chain id format")
+	}
+	if err := checkChainsActive(chain); err != nil {
+		return nil, nil, err
 	}
+	if err := checkChainsForMode(paymentMode(ctx), chain); err != nil {
+		return nil, nil, err
+	}
+	chainUUID, caip2ID := chain.ID, chain.GetCAIP2ID()
 	token, err := uc.tokenRepo.GetByAddress(ctx, input.TokenAddress, chainUUID)
 	if err != nil {
 		if input.TokenAddress == "" || input.TokenAddress == "0x0000000000000000000000000000000000000000" || input.TokenAddress == "native" {
 			token, err = uc.tokenRepo.GetNative(ctx, chainUUID)
 		}
 		if err != nil {
-			return nil, errors.BadRequest("invalid token for selected chain")
+			return nil, nil, errors.BadRequest("invalid token for selected chain")
 		}
 	}
+	if err := checkTokensActive(token); err != nil {
+		return nil, nil, err
+	}
 
 	contract, _ := uc.contractRepo.GetActiveContract(ctx, chainUUID, entities.ContractTypeGateway)
 
 	decimals := token.Decimals
 	if input.Decimals > 0 && input.Decimals != decimals {
-		return nil, errors.BadRequest("token decimals mismatch")
+		return nil, nil, errors.BadRequest("token decimals mismatch")
 	}
 
 	// Convert human readable amount to smallest unit
 	amountInSmallestUnit, convErr := convertToSmallestUnit(input.Amount, decimals)
 	if convErr != nil {
-		return nil, errors.BadRequest(convErr.Error())
+		return nil, nil, errors.BadRequest(convErr.Error())
 	}
 
-	// Create payment request
-	requestID := utils.GenerateUUIDv7()
-	expiresAt := time.Now().Add(PaymentRequestExpiryMinutes * time.Minute)
-
-	paymentRequest := &entities.PaymentRequest{
-		ID:            requestID,
+	return &entities.PaymentRequest{
+		ID:            utils.GenerateUUIDv7(),
 		MerchantID:    merchant.ID,
 		ChainID:       chainUUID,
 		NetworkID:     caip2ID,
 		TokenID:       token.ID,
 		TokenAddress:  input.TokenAddress,
-		WalletAddress: targetWallet.Address,
+		WalletAddress: wallet.Address,
 		Amount:        amountInSmallestUnit,
 		Decimals:      decimals,
 		Description:   input.Description,
+		Metadata:      metadata,
 		Status:        entities.PaymentRequestStatusPending,
-		ExpiresAt:     expiresAt,
-	}
-
-	if err := uc.paymentRequestRepo.Create(ctx, paymentRequest); err != nil {
-		return nil, errors.InternalError(err)
-	}
+		ExpiresAt:     time.Now().Add(PaymentRequestExpiryMinutes * time.Minute),
+	}, contract, nil
+}
 
+// finishPaymentRequest attaches the partner-flow payment code to a stored request and builds
+// its transaction data
+func (uc *PaymentRequestUsecase) finishPaymentRequest(
+	ctx context.Context,
+	paymentRequest *entities.PaymentRequest,
+	contract *entities.SmartContract,
+	humanAmount string,
+) *CreatePaymentRequestOutput {
 	// ---------------------------------------------------------
 	// NEW: Generate JWE Payment Code for Partner Flow
 	// ---------------------------------------------------------
@@ -167,10 +242,10 @@ func (uc *PaymentRequestUsecase) CreatePaymentRequest(ctx context.Context, input
 	if uc.jweService != nil {
 		jwePayload := services.JWEPayload{
 			SessionID:  paymentRequest.ID.String(),
-			Amount:     input.Amount,
-			MerchantID: merchant.ID.String(),
-			Currency:   caip2ID,
-			ExpiresAt:  expiresAt.Unix(),
+			Amount:     humanAmount,
+			MerchantID: paymentRequest.MerchantID.String(),
+			Currency:   paymentRequest.NetworkID,
+			ExpiresAt:  paymentRequest.ExpiresAt.Unix(),
 		}
 		paymentCode, err := uc.jweService.Encrypt(jwePayload)
 		if err == nil {
@@ -186,11 +261,11 @@ func (uc *PaymentRequestUsecase) CreatePaymentRequest(ctx context.Context, input
 	txData := uc.buildTransactionData(paymentRequest, contract)
 
 	return &CreatePaymentRequestOutput{
-		RequestID:     requestID.String(),
+		RequestID:     paymentRequest.ID.String(),
 		TxData:        txData,
-		ExpiresAt:     expiresAt,
+		ExpiresAt:     paymentRequest.ExpiresAt,
 		ExpiresInSecs: PaymentRequestExpiryMinutes * 60,
-	}, nil
+	}
 }
 
 func (uc *PaymentRequestUsecase) buildTransactionData(
@@ -210,13 +285,10 @@ func (uc *PaymentRequestUsecase) buildTransactionData(
 		txData.ProgramID = contract.ContractAddress
 	}
 
-	// Determine chain type from CAIP-2
-	chainType := getChainTypeFromCAIP2(request.NetworkID)
-
-	switch chainType {
-	case "eip155": // EVM
+	switch entities.ChainTypeFromCAIP2(request.NetworkID) {
+	case entities.ChainTypeEVM:
 		txData.Hex = uc.buildEvmTransactionHex(request)
-	case "solana": // SVM
+	case entities.ChainTypeSVM:
 		txData.Base58 = uc.buildSvmTransactionBase58(request)
 	}
 
@@ -255,7 +327,10 @@ func (uc *PaymentRequestUsecase) buildSvmTransactionBase58(
 func (uc *PaymentRequestUsecase) GetPaymentRequest(ctx context.Context, requestID uuid.UUID) (*entities.PaymentRequest, *entities.PaymentRequestTxData, error) {
 	request, err := uc.paymentRequestRepo.GetByID(ctx, requestID)
 	if err != nil {
-		return nil, nil, errors.NotFound("payment request not found")
+		if stderrors.Is(err, errors.ErrNotFound) {
+			return nil, nil, errors.NotFound("payment request not found")
+		}
+		return nil, nil, err
 	}
 
 	// Check if expired
@@ -277,6 +352,11 @@ func (uc *PaymentRequestUsecase) GetPaymentRequest(ctx context.Context, requestI
 			request.NetworkID = chain.GetCAIP2ID()
 		}
 	}
+	// A cancelled request must not be payable, so it carries no transaction data
+	if request.Status == entities.PaymentRequestStatusCancelled {
+		return request, nil, nil
+	}
+
 	contract, _ := uc.contractRepo.GetActiveContract(ctx, chainID, entities.ContractTypeGateway)
 
 	txData := uc.buildTransactionData(request, contract)
@@ -284,10 +364,52 @@ func (uc *PaymentRequestUsecase) GetPaymentRequest(ctx context.Context, requestI
 	return request, txData, nil
 }
 
+// CancelPaymentRequest cancels one of the caller's pending payment requests
+func (uc *PaymentRequestUsecase) CancelPaymentRequest(ctx context.Context, userID, requestID uuid.UUID) (*entities.PaymentRequest, error) {
+	merchant, err := uc.merchantRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.NotFound("merchant not found")
+	}
+
+	request, err := uc.paymentRequestRepo.GetByID(ctx, requestID)
+	if err != nil {
+		if stderrors.Is(err, errors.ErrNotFound) {
+			return nil, errors.NotFound("payment request not found")
+		}
+		return nil, err
+	}
+	if request.MerchantID != merchant.ID {
+		return nil, errors.Forbidden("payment request does not belong to merchant")
+	}
+
+	if request.Status == entities.PaymentRequestStatusPending && time.Now().After(request.ExpiresAt) {
+		request.Status = entities.PaymentRequestStatusExpired
+		_ = uc.paymentRequestRepo.UpdateStatus(ctx, requestID, entities.PaymentRequestStatusExpired)
+	}
+	if request.Status != entities.PaymentRequestStatusPending {
+		return nil, errors.Conflict(fmt.Sprintf("payment request is %s and cannot be cancelled", request.Status))
+	}
+
+	cancelled, err := uc.paymentRequestRepo.CancelPending(ctx, requestID)
+	if err != nil {
+		return nil, errors.InternalError(err)
+	}
+	if !cancelled {
+		// Paid or expired between the read and the update
+		return nil, errors.Conflict("payment request is no longer pending")
+	}
+
+	request.Status = entities.PaymentRequestStatusCancelled
+	return request, nil
+}
+
 func (uc *PaymentRequestUsecase) ResolvePaymentRequest(ctx context.Context, requestID uuid.UUID) (*ResolvePaymentRequestOutput, error) {
 	request, err := uc.paymentRequestRepo.GetByID(ctx, requestID)
 	if err != nil {
-		return nil, errors.NotFound("payment request not found")
+		if stderrors.Is(err, errors.ErrNotFound) {
+			return nil, errors.NotFound("payment request not found")
+		}
+		return nil, err
 	}
 
 	// Check if expired
@@ -296,6 +418,15 @@ func (uc *PaymentRequestUsecase) ResolvePaymentRequest(ctx context.Context, requ
 		_ = uc.paymentRequestRepo.UpdateStatus(ctx, requestID, entities.PaymentRequestStatusExpired)
 	}
 
+	// Only a pending request may be paid; anything else gets its status without instructions
+	if request.Status != entities.PaymentRequestStatusPending {
+		return &ResolvePaymentRequestOutput{
+			PaymentID:  request.ID.String(),
+			MerchantID: request.MerchantID.String(),
+			Status:     request.Status,
+		}, nil
+	}
+
 	// Enrich with chain info if missing
 	chainID := request.ChainID
 	if chainID == uuid.Nil && request.NetworkID != "" {
@@ -326,19 +457,19 @@ func (uc *PaymentRequestUsecase) ResolvePaymentRequest(ctx context.Context, requ
 		PaymentCode: request.PaymentCode,
 		Status:      request.Status,
 	}
-	output.PaymentInstruction.QRData = qrData
-	output.PaymentInstruction.DeepLink = deepLink
+	output.PaymentInstruction = &PaymentRequestInstruction{QRData: qrData, DeepLink: deepLink}
 
 	return output, nil
 }
 
-func (uc *PaymentRequestUsecase) ListPaymentRequests(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entities.PaymentRequest, int, error) {
+// ListPaymentRequests returns the caller's payment requests matching filter
+func (uc *PaymentRequestUsecase) ListPaymentRequests(ctx context.Context, userID uuid.UUID, filter domainRepos.PaymentRequestFilter, limit, offset int) ([]*entities.PaymentRequest, int, error) {
 	merchant, err := uc.merchantRepo.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, 0, errors.NotFound("merchant not found")
 	}
 
-	return uc.paymentRequestRepo.GetByMerchantID(ctx, merchant.ID, limit, offset)
+	return uc.paymentRequestRepo.GetByMerchantID(ctx, merchant.ID, filter, limit, offset)
 }
 
 func (uc *PaymentRequestUsecase) MarkPaymentCompleted(ctx context.Context, requestID uuid.UUID, txHash string) error {
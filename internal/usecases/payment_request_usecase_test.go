@@ -2,13 +2,17 @@ package usecases_test
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
 	domainRepos "payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/domain/services"
 	"payment-kita.backend/internal/usecases"
@@ -106,13 +110,15 @@ func TestPaymentRequestUsecase_CreatePaymentRequest_SuccessEVM(t *testing.T) {
 		{ID: uuid.New(), Address: "0xMerchant", IsPrimary: true},
 	}, nil).Once()
 	cr.On("GetByCAIP2", context.Background(), input.ChainID).Return(&entities.Chain{
-		ID:      chainID,
-		Type:    entities.ChainTypeEVM,
-		ChainID: "8453",
+		ID:       chainID,
+		Type:     entities.ChainTypeEVM,
+		ChainID:  "8453",
+		IsActive: true,
 	}, nil).Once()
 	tr.On("GetByAddress", context.Background(), input.TokenAddress, chainID).Return(&entities.Token{
 		ID:       tokenID,
 		Decimals: 6,
+		IsActive: true,
 	}, nil).Once()
 	sr.On("GetActiveContract", context.Background(), chainID, entities.ContractTypeGateway).Return(&entities.SmartContract{
 		ID:              uuid.New(),
@@ -130,6 +136,48 @@ func TestPaymentRequestUsecase_CreatePaymentRequest_SuccessEVM(t *testing.T) {
 	assert.Contains(t, out.TxData.Hex, usecases.PayRequestSelector)
 }
 
+func TestPaymentRequestUsecase_CreatePaymentRequest_InactiveChain(t *testing.T) {
+	pr := new(MockPaymentRequestRepository)
+	mr := new(MockMerchantRepository)
+	wr := new(MockWalletRepository)
+	cr := new(MockChainRepository)
+	sr := new(MockSmartContractRepository)
+	tr := new(MockTokenRepository)
+	uc := newPaymentRequestUC(pr, mr, wr, cr, sr, tr, nil)
+
+	userID := uuid.New()
+	mr.On("GetByUserID", context.Background(), userID).Return(&entities.Merchant{
+		ID:     uuid.New(),
+		UserID: userID,
+		Status: entities.MerchantStatusActive,
+	}, nil).Once()
+	wr.On("GetByUserID", context.Background(), userID).Return([]*entities.Wallet{
+		{ID: uuid.New(), Address: "0xMerchant", IsPrimary: true},
+	}, nil).Once()
+	// ResolveChain does not filter deactivated chains, so the usecase must refuse them itself
+	cr.On("GetByCAIP2", context.Background(), "eip155:8453").Return(&entities.Chain{
+		ID:       uuid.New(),
+		Type:     entities.ChainTypeEVM,
+		ChainID:  "8453",
+		IsActive: false,
+	}, nil).Once()
+
+	_, err := uc.CreatePaymentRequest(context.Background(), usecases.CreatePaymentRequestInput{
+		UserID:       userID,
+		ChainID:      "eip155:8453",
+		TokenAddress: "0xToken",
+		Amount:       "1",
+		Decimals:     6,
+	})
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Equal(t, domainerrors.CodeInactive, appErr.Code)
+	require.Contains(t, appErr.Message, "eip155:8453")
+	tr.AssertNotCalled(t, "GetByAddress", mock.Anything, mock.Anything, mock.Anything)
+	pr.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestPaymentRequestUsecase_CreatePaymentRequest_DecimalsMismatch(t *testing.T) {
 	pr := new(MockPaymentRequestRepository)
 	mr := new(MockMerchantRepository)
@@ -159,12 +207,14 @@ func TestPaymentRequestUsecase_CreatePaymentRequest_DecimalsMismatch(t *testing.
 		{ID: uuid.New(), Address: "0xMerchant", IsPrimary: true},
 	}, nil).Once()
 	cr.On("GetByCAIP2", context.Background(), input.ChainID).Return(&entities.Chain{
-		ID:      chainID,
-		Type:    entities.ChainTypeEVM,
-		ChainID: "8453",
+		ID:       chainID,
+		Type:     entities.ChainTypeEVM,
+		ChainID:  "8453",
+		IsActive: true,
 	}, nil).Once()
 	tr.On("GetByAddress", context.Background(), input.TokenAddress, chainID).Return(&entities.Token{
 		ID:       uuid.New(),
+		IsActive: true,
 		Decimals: 6,
 	}, nil).Once()
 	sr.On("GetActiveContract", context.Background(), chainID, entities.ContractTypeGateway).Return(nil, nil).Once()
@@ -206,13 +256,15 @@ func TestPaymentRequestUsecase_CreatePaymentRequest_NoPrimaryWallet_UsesFirstWal
 		{ID: uuid.New(), Address: "0xSecondWallet", IsPrimary: false},
 	}, nil).Once()
 	cr.On("GetByCAIP2", context.Background(), input.ChainID).Return(&entities.Chain{
-		ID:      chainID,
-		Type:    entities.ChainTypeEVM,
-		ChainID: "8453",
+		ID:       chainID,
+		Type:     entities.ChainTypeEVM,
+		ChainID:  "8453",
+		IsActive: true,
 	}, nil).Once()
 	tr.On("GetByAddress", context.Background(), input.TokenAddress, chainID).Return(&entities.Token{
 		ID:       tokenID,
 		Decimals: 6,
+		IsActive: true,
 	}, nil).Once()
 	sr.On("GetActiveContract", context.Background(), chainID, entities.ContractTypeGateway).Return(nil, nil).Once()
 	pr.On("Create", context.Background(), mock.MatchedBy(func(req *entities.PaymentRequest) bool {
@@ -271,9 +323,9 @@ func TestPaymentRequestUsecase_ListAndMarkCompleted(t *testing.T) {
 	userID := uuid.New()
 	merchantID := uuid.New()
 	mr.On("GetByUserID", context.Background(), userID).Return(&entities.Merchant{ID: merchantID}, nil).Once()
-	pr.On("GetByMerchantID", context.Background(), merchantID, 10, 0).Return([]*entities.PaymentRequest{}, 0, nil).Once()
+	pr.On("GetByMerchantID", context.Background(), merchantID, domainRepos.PaymentRequestFilter{}, 10, 0).Return([]*entities.PaymentRequest{}, 0, nil).Once()
 
-	items, total, err := uc.ListPaymentRequests(context.Background(), userID, 10, 0)
+	items, total, err := uc.ListPaymentRequests(context.Background(), userID, domainRepos.PaymentRequestFilter{}, 10, 0)
 	assert.NoError(t, err)
 	assert.Len(t, items, 0)
 	assert.Equal(t, 0, total)
@@ -385,7 +437,7 @@ func TestPaymentRequestUsecase_CreatePaymentRequest_ErrorBranches(t *testing.T)
 			Status: entities.MerchantStatusActive,
 		}, nil).Once()
 		wr.On("GetByUserID", context.Background(), userID).Return([]*entities.Wallet{{Address: "0xM", IsPrimary: true}}, nil).Once()
-		cr.On("GetByCAIP2", context.Background(), "eip155:8453").Return(&entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM}, nil).Once()
+		cr.On("GetByCAIP2", context.Background(), "eip155:8453").Return(&entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}, nil).Once()
 		tr.On("GetByAddress", context.Background(), "native", chainID).Return(nil, assert.AnError).Once()
 		tr.On("GetNative", context.Background(), chainID).Return(nil, assert.AnError).Once()
 
@@ -416,8 +468,8 @@ func TestPaymentRequestUsecase_CreatePaymentRequest_ErrorBranches(t *testing.T)
 			Status: entities.MerchantStatusActive,
 		}, nil).Once()
 		wr.On("GetByUserID", context.Background(), userID).Return([]*entities.Wallet{{Address: "0xM", IsPrimary: true}}, nil).Once()
-		cr.On("GetByCAIP2", context.Background(), "eip155:8453").Return(&entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM}, nil).Once()
-		tr.On("GetByAddress", context.Background(), "0xToken", chainID).Return(&entities.Token{ID: uuid.New(), Decimals: 6}, nil).Once()
+		cr.On("GetByCAIP2", context.Background(), "eip155:8453").Return(&entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}, nil).Once()
+		tr.On("GetByAddress", context.Background(), "0xToken", chainID).Return(&entities.Token{ID: uuid.New(), Decimals: 6, IsActive: true}, nil).Once()
 		sr.On("GetActiveContract", context.Background(), chainID, entities.ContractTypeGateway).Return(nil, nil).Once()
 
 		_, err := uc.CreatePaymentRequest(context.Background(), usecases.CreatePaymentRequestInput{
@@ -448,8 +500,8 @@ func TestPaymentRequestUsecase_CreatePaymentRequest_ErrorBranches(t *testing.T)
 			Status: entities.MerchantStatusActive,
 		}, nil).Once()
 		wr.On("GetByUserID", context.Background(), userID).Return([]*entities.Wallet{{Address: "0xM", IsPrimary: true}}, nil).Once()
-		cr.On("GetByCAIP2", context.Background(), "eip155:8453").Return(&entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM}, nil).Once()
-		tr.On("GetByAddress", context.Background(), "0xToken", chainID).Return(&entities.Token{ID: tokenID, Decimals: 6}, nil).Once()
+		cr.On("GetByCAIP2", context.Background(), "eip155:8453").Return(&entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}, nil).Once()
+		tr.On("GetByAddress", context.Background(), "0xToken", chainID).Return(&entities.Token{ID: tokenID, Decimals: 6, IsActive: true}, nil).Once()
 		sr.On("GetActiveContract", context.Background(), chainID, entities.ContractTypeGateway).Return(nil, nil).Once()
 		pr.On("Create", context.Background(), mock.AnythingOfType("*entities.PaymentRequest")).Return(assert.AnError).Once()
 
@@ -475,6 +527,18 @@ func TestPaymentRequestUsecase_GetAndList_ErrorBranches(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("get payment request wrapped not found is a 404", func(t *testing.T) {
+		pr := new(MockPaymentRequestRepository)
+		uc := newPaymentRequestUC(pr, new(MockMerchantRepository), new(MockWalletRepository), new(MockChainRepository), new(MockSmartContractRepository), new(MockTokenRepository), nil)
+		requestID := uuid.New()
+		pr.On("GetByID", context.Background(), requestID).Return(nil, fmt.Errorf("load request: %w", domainerrors.ErrNotFound)).Once()
+
+		_, _, err := uc.GetPaymentRequest(context.Background(), requestID)
+		var appErr *domainerrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.Status)
+	})
+
 	t.Run("list payment requests merchant not found", func(t *testing.T) {
 		pr := new(MockPaymentRequestRepository)
 		mr := new(MockMerchantRepository)
@@ -482,7 +546,150 @@ func TestPaymentRequestUsecase_GetAndList_ErrorBranches(t *testing.T) {
 		userID := uuid.New()
 		mr.On("GetByUserID", context.Background(), userID).Return(nil, assert.AnError).Once()
 
-		_, _, err := uc.ListPaymentRequests(context.Background(), userID, 10, 0)
+		_, _, err := uc.ListPaymentRequests(context.Background(), userID, domainRepos.PaymentRequestFilter{}, 10, 0)
 		assert.Error(t, err)
 	})
 }
+
+func TestPaymentRequestUsecase_CancelPaymentRequest(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	merchantID := uuid.New()
+	requestID := uuid.New()
+
+	setup := func(request *entities.PaymentRequest) (*usecases.PaymentRequestUsecase, *MockPaymentRequestRepository) {
+		pr := new(MockPaymentRequestRepository)
+		mr := new(MockMerchantRepository)
+		mr.On("GetByUserID", ctx, userID).Return(&entities.Merchant{ID: merchantID}, nil)
+		if request != nil {
+			pr.On("GetByID", ctx, requestID).Return(request, nil)
+		} else {
+			pr.On("GetByID", ctx, requestID).Return(nil, domainerrors.ErrNotFound)
+		}
+		return newPaymentRequestUC(pr, mr, new(MockWalletRepository), new(MockChainRepository), new(MockSmartContractRepository), new(MockTokenRepository), nil), pr
+	}
+	pending := func() *entities.PaymentRequest {
+		return &entities.PaymentRequest{
+			ID:         requestID,
+			MerchantID: merchantID,
+			Status:     entities.PaymentRequestStatusPending,
+			ExpiresAt:  time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("cancels pending request", func(t *testing.T) {
+		uc, pr := setup(pending())
+		pr.On("CancelPending", ctx, requestID).Return(true, nil).Once()
+		got, err := uc.CancelPaymentRequest(ctx, userID, requestID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.PaymentRequestStatusCancelled, got.Status)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		uc, _ := setup(nil)
+		_, err := uc.CancelPaymentRequest(ctx, userID, requestID)
+		assert.Equal(t, http.StatusNotFound, err.(*domainerrors.AppError).Status)
+	})
+
+	t.Run("other merchant", func(t *testing.T) {
+		request := pending()
+		request.MerchantID = uuid.New()
+		uc, pr := setup(request)
+		_, err := uc.CancelPaymentRequest(ctx, userID, requestID)
+		assert.Equal(t, http.StatusForbidden, err.(*domainerrors.AppError).Status)
+		pr.AssertNotCalled(t, "CancelPending", mock.Anything, mock.Anything)
+	})
+
+	t.Run("already completed", func(t *testing.T) {
+		request := pending()
+		request.Status = entities.PaymentRequestStatusCompleted
+		uc, _ := setup(request)
+		_, err := uc.CancelPaymentRequest(ctx, userID, requestID)
+		assert.Equal(t, http.StatusConflict, err.(*domainerrors.AppError).Status)
+	})
+
+	t.Run("expired while pending", func(t *testing.T) {
+		request := pending()
+		request.ExpiresAt = time.Now().Add(-time.Minute)
+		uc, pr := setup(request)
+		pr.On("UpdateStatus", ctx, requestID, entities.PaymentRequestStatusExpired).Return(nil).Once()
+		_, err := uc.CancelPaymentRequest(ctx, userID, requestID)
+		assert.Equal(t, http.StatusConflict, err.(*domainerrors.AppError).Status)
+	})
+
+	t.Run("lost race", func(t *testing.T) {
+		uc, pr := setup(pending())
+		pr.On("CancelPending", ctx, requestID).Return(false, nil).Once()
+		_, err := uc.CancelPaymentRequest(ctx, userID, requestID)
+		assert.Equal(t, http.StatusConflict, err.(*domainerrors.AppError).Status)
+	})
+}
+
+func TestPaymentRequestUsecase_GetPaymentRequest_CancelledHasNoTxData(t *testing.T) {
+	pr := new(MockPaymentRequestRepository)
+	sr := new(MockSmartContractRepository)
+	uc := newPaymentRequestUC(pr, new(MockMerchantRepository), new(MockWalletRepository), new(MockChainRepository), sr, new(MockTokenRepository), nil)
+
+	requestID := uuid.New()
+	pr.On("GetByID", context.Background(), requestID).Return(&entities.PaymentRequest{
+		ID:        requestID,
+		ChainID:   uuid.New(),
+		NetworkID: "eip155:8453",
+		Status:    entities.PaymentRequestStatusCancelled,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil).Once()
+
+	request, txData, err := uc.GetPaymentRequest(context.Background(), requestID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.PaymentRequestStatusCancelled, request.Status)
+	assert.Nil(t, txData)
+	sr.AssertNotCalled(t, "GetActiveContract", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPaymentRequestUsecase_ResolvePaymentRequest_OnlyPendingIsPayable(t *testing.T) {
+	ctx := context.Background()
+	requestID := uuid.New()
+	merchantID := uuid.New()
+	resolve := func(status entities.PaymentRequestStatus, expiresAt time.Time) *usecases.ResolvePaymentRequestOutput {
+		pr := new(MockPaymentRequestRepository)
+		pr.On("GetByID", ctx, requestID).Return(&entities.PaymentRequest{
+			ID:            requestID,
+			MerchantID:    merchantID,
+			ChainID:       uuid.New(),
+			NetworkID:     "eip155:8453",
+			WalletAddress: "0xwallet",
+			Amount:        "25",
+			PaymentCode:   "jwe-code",
+			Status:        status,
+			ExpiresAt:     expiresAt,
+		}, nil).Once()
+		pr.On("UpdateStatus", ctx, requestID, entities.PaymentRequestStatusExpired).Return(nil).Maybe()
+		uc := newPaymentRequestUC(pr, new(MockMerchantRepository), new(MockWalletRepository), new(MockChainRepository), new(MockSmartContractRepository), new(MockTokenRepository), nil)
+		out, err := uc.ResolvePaymentRequest(ctx, requestID)
+		require.NoError(t, err)
+		return out
+	}
+
+	out := resolve(entities.PaymentRequestStatusPending, time.Now().Add(time.Hour))
+	require.NotNil(t, out.PaymentInstruction)
+	assert.Equal(t, "jwe-code", out.PaymentInstruction.QRData)
+	assert.Equal(t, "jwe-code", out.PaymentCode)
+
+	for status, expiresAt := range map[entities.PaymentRequestStatus]time.Time{
+		entities.PaymentRequestStatusCancelled: time.Now().Add(time.Hour),
+		entities.PaymentRequestStatusCompleted: time.Now().Add(time.Hour),
+		entities.PaymentRequestStatusExpired:   time.Now().Add(-time.Hour),
+	} {
+		out := resolve(status, expiresAt)
+		assert.Equal(t, status, out.Status)
+		assert.Equal(t, merchantID.String(), out.MerchantID)
+		assert.Nil(t, out.PaymentInstruction, status)
+		assert.Empty(t, out.PaymentCode, status)
+		assert.Empty(t, out.DestWallet, status)
+	}
+
+	// A pending request past its expiry is expired, not payable
+	out = resolve(entities.PaymentRequestStatusPending, time.Now().Add(-time.Minute))
+	assert.Equal(t, entities.PaymentRequestStatusExpired, out.Status)
+	assert.Nil(t, out.PaymentInstruction)
+}
@@ -0,0 +1,287 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+// paymentCallSimulator is implemented by EVM clients that can eth_call as a given sender with
+// value. *blockchain.EVMClient implements it.
+type paymentCallSimulator interface {
+	CallFrom(ctx context.Context, from, to string, value *big.Int, data []byte) ([]byte, error)
+}
+
+// validateSimulateFrom checks CreatePaymentInput.SimulateFrom before the payment is created
+func validateSimulateFrom(sourceChain *entities.Chain, from string) error {
+	if strings.TrimSpace(from) == "" {
+		return nil
+	}
+	if sourceChain == nil || !sourceChain.ChainType().IsEVM() {
+		return domainerrors.BadRequest("simulateFrom is only supported on EVM source chains")
+	}
+	if !common.IsHexAddress(strings.TrimSpace(from)) {
+		return domainerrors.BadRequest("simulateFrom must be an EVM address")
+	}
+	return nil
+}
+
+// paymentBundleSimulator is implemented by EVM clients that can dry-run several transactions
+// from one sender in order. *blockchain.EVMClient implements it.
+type paymentBundleSimulator interface {
+	SimulateBundle(ctx context.Context, from string, calls []blockchain.BundleCall) ([]blockchain.BundleCallResult, error)
+}
+
+// simulatePayment dry-runs the createPayment transaction in signatureData from the payer, so a
+// revert is reported before the user signs. With the payer's allowance already in place it is
+// one eth_call against the latest state. While an approve it depends on is not mined yet, the
+// approves and createPayment run as one eth_simulateV1 bundle instead; an RPC without
+// eth_simulateV1 then gives SKIPPED, as does any other transaction listed before createPayment.
+// Reverts are decoded with the known route errors, then the gateway ABI's custom errors.
+func (u *PaymentUsecase) simulatePayment(ctx context.Context, sourceChain *entities.Chain, gateway *entities.SmartContract, signatureData interface{}, from string) *entities.PaymentSimulation {
+	payer := common.HexToAddress(strings.TrimSpace(from)).Hex()
+	result := &entities.PaymentSimulation{From: payer, Status: entities.PaymentSimulationSkipped}
+
+	txData, ok := signatureData.(map[string]interface{})
+	if !ok {
+		result.Reason = "no EVM transaction to simulate"
+		return result
+	}
+	to, _ := txData["to"].(string)
+	data, err := hexutil.Decode(fmt.Sprint(txData["data"]))
+	if to == "" || err != nil {
+		result.Reason = "no EVM transaction to simulate"
+		return result
+	}
+	value, err := hexutil.DecodeBig(fmt.Sprint(txData["value"]))
+	if err != nil {
+		value = big.NewInt(0)
+	}
+
+	if u.clientFactory == nil {
+		result.Reason = "no RPC client configured"
+		return result
+	}
+	rpcURL := resolveChainRPCURL(sourceChain)
+	if rpcURL == "" {
+		result.Reason = "no RPC URL for " + sourceChain.GetCAIP2ID()
+		return result
+	}
+	client, err := u.clientFactory.GetEVMClient(rpcURL)
+	if err != nil {
+		result.Reason = "RPC unavailable: " + err.Error()
+		return result
+	}
+
+	txs, _ := txData["transactions"].([]map[string]string)
+	var pendingApprovals []blockchain.BundleCall
+	for _, tx := range txs {
+		switch tx["kind"] {
+		case "createPayment":
+		case "approve":
+			approved, err := hasTokenAllowance(ctx, client, tx["to"], payer, tx["spender"], tx["amount"])
+			if err != nil {
+				result.Reason = "failed to read token allowance: " + err.Error()
+				return result
+			}
+			if approved {
+				continue
+			}
+			approveData := tx["data"]
+			if approveData == "" {
+				approveData = u.buildErc20ApproveHex(tx["spender"], tx["amount"])
+			}
+			call, err := hexutil.Decode(approveData)
+			if err != nil {
+				result.Reason = "invalid approve calldata"
+				return result
+			}
+			pendingApprovals = append(pendingApprovals, blockchain.BundleCall{To: tx["to"], Data: call})
+		default:
+			result.Reason = fmt.Sprintf("the %s transaction must be mined first", tx["kind"])
+			return result
+		}
+	}
+
+	if len(pendingApprovals) > 0 {
+		return u.simulatePaymentBundle(ctx, client, result, gateway, append(pendingApprovals, blockchain.BundleCall{To: to, Value: value, Data: data}))
+	}
+
+	simulator, ok := client.(paymentCallSimulator)
+	if !ok {
+		result.Reason = "RPC client cannot simulate calls"
+		return result
+	}
+	_, err = simulator.CallFrom(ctx, payer, to, value, data)
+	if err == nil {
+		result.Status = entities.PaymentSimulationPassed
+		return result
+	}
+	decoded, decodedOK := decodeRevertDataFromError(err)
+	if !decodedOK && !isExecutionFailure(err) {
+		result.Reason = "simulation failed: " + err.Error()
+		return result
+	}
+	result.Status = entities.PaymentSimulationReverted
+	result.Reason = err.Error()
+	if decodedOK {
+		applyDecodedRevert(result, gateway, decoded)
+	}
+	return result
+}
+
+// simulatePaymentBundle runs the unmined approves and createPayment, the last of calls, as one
+// bundle from the payer
+func (u *PaymentUsecase) simulatePaymentBundle(ctx context.Context, client EVMClient, result *entities.PaymentSimulation, gateway *entities.SmartContract, calls []blockchain.BundleCall) *entities.PaymentSimulation {
+	bundler, ok := client.(paymentBundleSimulator)
+	if !ok {
+		result.Reason = "approval pending and the RPC client cannot simulate it with the payment: simulate again once the approve transaction is mined"
+		return result
+	}
+	outcomes, err := bundler.SimulateBundle(ctx, result.From, calls)
+	if err != nil {
+		result.Reason = "approval pending and the RPC cannot simulate it with the payment (" + err.Error() + "): simulate again once the approve transaction is mined"
+		return result
+	}
+	for i, outcome := range outcomes {
+		if !outcome.Reverted {
+			continue
+		}
+		result.Status = entities.PaymentSimulationReverted
+		if i < len(outcomes)-1 {
+			result.Reason = "approve reverted: " + outcome.Message
+			return result
+		}
+		result.Reason = outcome.Message
+		if len(outcome.RevertData) > 0 {
+			applyDecodedRevert(result, gateway, decodeRouteErrorData(outcome.RevertData))
+		}
+		return result
+	}
+	result.Status = entities.PaymentSimulationPassed
+	return result
+}
+
+// applyDecodedRevert fills result from decoded revert data, naming custom errors from the
+// gateway ABI when the known route errors do not
+func applyDecodedRevert(result *entities.PaymentSimulation, gateway *entities.SmartContract, decoded RouteErrorDecoded) {
+	result.Reason = decoded.Message
+	result.ErrorName = decoded.Name
+	result.RevertData = decoded.RawHex
+	if decoded.Name != "" || gateway == nil {
+		return
+	}
+	raw, err := json.Marshal(gateway.ABI)
+	if err != nil {
+		return
+	}
+	parsed, err := parseABI(string(raw))
+	if err != nil {
+		return
+	}
+	if name := abiErrorName(parsed, decoded.RawHex); name != "" {
+		result.ErrorName = name
+		result.Reason = name
+	}
+}
+
+// simulateQuotedPayment dry-runs what a partner session for a quote would ask the payer to sign:
+// approving and paying amount of tokenAddress on chain to receiver on the same chain, with the
+// calldata CreateSession builds
+func (u *PaymentUsecase) simulateQuotedPayment(ctx context.Context, chain *entities.Chain, tokenAddress, amount, receiver, from string) *entities.PaymentSimulation {
+	gateway, err := u.contractRepo.GetActiveContract(ctx, chain.ID, entities.ContractTypeGateway)
+	if err != nil || gateway == nil {
+		return &entities.PaymentSimulation{
+			From:   common.HexToAddress(strings.TrimSpace(from)).Hex(),
+			Status: entities.PaymentSimulationSkipped,
+			Reason: "no active gateway on " + chain.GetCAIP2ID(),
+		}
+	}
+	amountIn, ok := new(big.Int).SetString(strings.TrimSpace(amount), 10)
+	if !ok {
+		amountIn = big.NewInt(0)
+	}
+	caip2 := chain.GetCAIP2ID()
+	createData, err := packCreatePaymentDefaultBridgeV2Calldata(partnerSessionPaymentArgs(caip2, receiver, tokenAddress, tokenAddress, amountIn))
+	if err != nil {
+		return &entities.PaymentSimulation{
+			From:   common.HexToAddress(strings.TrimSpace(from)).Hex(),
+			Status: entities.PaymentSimulationSkipped,
+			Reason: "failed to build createPayment calldata: " + err.Error(),
+		}
+	}
+
+	payment := &entities.Payment{
+		SourceChainID:      chain.ID,
+		DestChainID:        chain.ID,
+		SourceTokenAddress: tokenAddress,
+		DestTokenAddress:   tokenAddress,
+		SourceAmount:       amountIn.String(),
+		TotalCharged:       amountIn.String(),
+		ReceiverAddress:    receiver,
+	}
+	value := big.NewInt(0)
+	if cost, err := u.quoteGatewayPaymentCost(ctx, payment, gateway.ContractAddress, nil); err == nil && cost != nil {
+		if fee, ok := new(big.Int).SetString(cost.BridgeFeeNative, 10); ok {
+			value = fee
+		}
+	}
+	createTx := map[string]string{"kind": "createPayment", "to": gateway.ContractAddress, "data": createData, "value": hexutil.EncodeBig(value)}
+	txs := []map[string]string{createTx}
+	if u.shouldRequireEvmApproval(tokenAddress) {
+		spender := u.ResolveVaultAddressForApproval(chain.ID, gateway.ContractAddress)
+		if spender == "" {
+			spender = gateway.ContractAddress
+		}
+		approvalAmount, err := u.CalculateOnchainApprovalAmount(payment, gateway.ContractAddress)
+		if err != nil {
+			approvalAmount = amountIn.String()
+		}
+		approveTx := map[string]string{
+			"kind":    "approve",
+			"to":      tokenAddress,
+			"data":    u.buildErc20ApproveHex(spender, approvalAmount),
+			"spender": spender,
+			"amount":  approvalAmount,
+		}
+		txs = []map[string]string{approveTx, createTx}
+	}
+	return u.simulatePayment(ctx, chain, gateway, map[string]interface{}{
+		"to":           createTx["to"],
+		"data":         createTx["data"],
+		"value":        createTx["value"],
+		"transactions": txs,
+	}, from)
+}
+
+// isExecutionFailure tells an eth_call that ran and failed apart from one the RPC never ran
+func isExecutionFailure(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "execution reverted") ||
+		strings.Contains(message, "insufficient funds") ||
+		strings.Contains(message, "out of gas")
+}
+
+// hasTokenAllowance reports whether owner has approved spender for at least amount of token
+func hasTokenAllowance(ctx context.Context, client EVMClient, tokenAddress, owner, spender, amount string) (bool, error) {
+	required, ok := new(big.Int).SetString(strings.TrimSpace(amount), 10)
+	if !ok {
+		return false, fmt.Errorf("invalid approval amount %q", amount)
+	}
+	// allowance(address,address) selector: 0xdd62ed3e
+	call := append(common.Hex2Bytes("dd62ed3e"), common.LeftPadBytes(common.HexToAddress(owner).Bytes(), 32)...)
+	call = append(call, common.LeftPadBytes(common.HexToAddress(spender).Bytes(), 32)...)
+	raw, err := client.CallView(ctx, tokenAddress, call)
+	if err != nil {
+		return false, err
+	}
+	return new(big.Int).SetBytes(raw).Cmp(required) >= 0, nil
+}
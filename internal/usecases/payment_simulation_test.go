@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+type paymentCallSimulatorMock struct {
+	evmClientMock
+	callFrom func(from, to string, value *big.Int, data []byte) ([]byte, error)
+}
+
+func (m *paymentCallSimulatorMock) CallFrom(_ context.Context, from, to string, value *big.Int, data []byte) ([]byte, error) {
+	return m.callFrom(from, to, value, data)
+}
+
+type paymentBundleSimulatorMock struct {
+	paymentCallSimulatorMock
+	bundle func(from string, calls []blockchain.BundleCall) ([]blockchain.BundleCallResult, error)
+}
+
+func (m *paymentBundleSimulatorMock) SimulateBundle(_ context.Context, from string, calls []blockchain.BundleCall) ([]blockchain.BundleCallResult, error) {
+	return m.bundle(from, calls)
+}
+
+func TestValidateSimulateFrom(t *testing.T) {
+	evm := &entities.Chain{ChainID: "8453", Type: entities.ChainTypeEVM}
+	require.NoError(t, validateSimulateFrom(evm, ""))
+	require.NoError(t, validateSimulateFrom(evm, "0x3333333333333333333333333333333333333333"))
+	require.Error(t, validateSimulateFrom(evm, "payer"))
+	require.Error(t, validateSimulateFrom(&entities.Chain{ChainID: "devnet", Type: entities.ChainTypeSVM}, "0x3333333333333333333333333333333333333333"))
+}
+
+func TestPaymentUsecase_SimulatePayment(t *testing.T) {
+	ctx := context.Background()
+	const (
+		rpcURL  = "https://rpc.example"
+		gateway = "0x1111111111111111111111111111111111111111"
+		token   = "0x4444444444444444444444444444444444444444"
+		vault   = "0x5555555555555555555555555555555555555555"
+		payer   = "0x3333333333333333333333333333333333333333"
+	)
+	chain := &entities.Chain{ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: rpcURL}
+	contract := &entities.SmartContract{ABI: []interface{}{
+		map[string]interface{}{"inputs": []interface{}{}, "name": "PaymentExpired", "type": "error"},
+	}}
+	createTx := map[string]string{"kind": "createPayment", "to": gateway, "data": "0xabcdef01", "value": "0x64"}
+	approveTx := map[string]string{"kind": "approve", "to": token, "spender": vault, "amount": "1000"}
+	signatureData := func(txs ...map[string]string) map[string]interface{} {
+		return map[string]interface{}{"to": gateway, "data": "0xabcdef01", "value": "0x64", "transactions": txs}
+	}
+
+	allowance := big.NewInt(0)
+	client := &paymentCallSimulatorMock{
+		evmClientMock: evmClientMock{callView: func(_ context.Context, to string, data []byte) ([]byte, error) {
+			require.Equal(t, token, to)
+			require.Equal(t, "dd62ed3e", hex.EncodeToString(data[:4]))
+			return common.LeftPadBytes(allowance.Bytes(), 32), nil
+		}},
+	}
+	client.callFrom = func(from, to string, value *big.Int, data []byte) ([]byte, error) {
+		require.Equal(t, common.HexToAddress(payer).Hex(), from)
+		require.Equal(t, gateway, to)
+		require.Equal(t, big.NewInt(100), value)
+		require.Equal(t, []byte{0xab, 0xcd, 0xef, 0x01}, data)
+		return nil, nil
+	}
+	u := &PaymentUsecase{clientFactory: &clientFactoryMock{clients: map[string]EVMClient{rpcURL: client}}}
+
+	sim := u.simulatePayment(ctx, chain, contract, signatureData(createTx), payer)
+	require.Equal(t, entities.PaymentSimulationPassed, sim.Status)
+
+	// The approve is not mined yet, so createPayment would revert on the allowance alone
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(approveTx, createTx), payer)
+	require.Equal(t, entities.PaymentSimulationSkipped, sim.Status)
+	require.Contains(t, sim.Reason, "approval pending")
+	allowance = big.NewInt(1000)
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(approveTx, createTx), payer)
+	require.Equal(t, entities.PaymentSimulationPassed, sim.Status)
+
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(map[string]string{"kind": "deployEscrow"}, createTx), payer)
+	require.Equal(t, entities.PaymentSimulationSkipped, sim.Status)
+	require.Contains(t, sim.Reason, "deployEscrow")
+
+	client.callFrom = func(string, string, *big.Int, []byte) ([]byte, error) {
+		return nil, revertDataError{data: errorStringRevert(t, "amount below minimum")}
+	}
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(createTx), payer)
+	require.Equal(t, entities.PaymentSimulationReverted, sim.Status)
+	require.Equal(t, "amount below minimum", sim.Reason)
+	require.NotEmpty(t, sim.RevertData)
+
+	// Custom errors fall back to the gateway ABI
+	client.callFrom = func(string, string, *big.Int, []byte) ([]byte, error) {
+		return nil, revertDataError{data: hex.EncodeToString(crypto.Keccak256([]byte("PaymentExpired()"))[:4])}
+	}
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(createTx), payer)
+	require.Equal(t, entities.PaymentSimulationReverted, sim.Status)
+	require.Equal(t, "PaymentExpired", sim.ErrorName)
+
+	client.callFrom = func(string, string, *big.Int, []byte) ([]byte, error) {
+		return nil, errors.New("insufficient funds for gas * price + value")
+	}
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(createTx), payer)
+	require.Equal(t, entities.PaymentSimulationReverted, sim.Status)
+
+	// An RPC that never ran the call is not a revert
+	client.callFrom = func(string, string, *big.Int, []byte) ([]byte, error) {
+		return nil, errors.New("context deadline exceeded")
+	}
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(createTx), payer)
+	require.Equal(t, entities.PaymentSimulationSkipped, sim.Status)
+
+	// With an RPC that runs bundles, an unmined approve is simulated together with the payment
+	allowance = big.NewInt(0)
+	bundler := &paymentBundleSimulatorMock{paymentCallSimulatorMock: *client}
+	var bundled []blockchain.BundleCall
+	bundler.bundle = func(from string, calls []blockchain.BundleCall) ([]blockchain.BundleCallResult, error) {
+		require.Equal(t, common.HexToAddress(payer).Hex(), from)
+		bundled = calls
+		return []blockchain.BundleCallResult{{}, {}}, nil
+	}
+	u.clientFactory = &clientFactoryMock{clients: map[string]EVMClient{rpcURL: bundler}}
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(approveTx, createTx), payer)
+	require.Equal(t, entities.PaymentSimulationPassed, sim.Status)
+	require.Len(t, bundled, 2)
+	require.Equal(t, token, bundled[0].To)
+	require.Equal(t, "095ea7b3", hex.EncodeToString(bundled[0].Data[:4]))
+	require.Equal(t, gateway, bundled[1].To)
+	require.Equal(t, big.NewInt(100), bundled[1].Value)
+
+	bundler.bundle = func(string, []blockchain.BundleCall) ([]blockchain.BundleCallResult, error) {
+		return []blockchain.BundleCallResult{{}, {Reverted: true, Message: "execution reverted", RevertData: crypto.Keccak256([]byte("PaymentExpired()"))[:4]}}, nil
+	}
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(approveTx, createTx), payer)
+	require.Equal(t, entities.PaymentSimulationReverted, sim.Status)
+	require.Equal(t, "PaymentExpired", sim.ErrorName)
+
+	bundler.bundle = func(string, []blockchain.BundleCall) ([]blockchain.BundleCallResult, error) {
+		return []blockchain.BundleCallResult{{Reverted: true, Message: "execution reverted: paused"}, {}}, nil
+	}
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(approveTx, createTx), payer)
+	require.Equal(t, entities.PaymentSimulationReverted, sim.Status)
+	require.Equal(t, "approve reverted: execution reverted: paused", sim.Reason)
+
+	// An RPC without eth_simulateV1 leaves it to after the approve is mined
+	bundler.bundle = func(string, []blockchain.BundleCall) ([]blockchain.BundleCallResult, error) {
+		return nil, errors.New("the method eth_simulateV1 does not exist")
+	}
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(approveTx, createTx), payer)
+	require.Equal(t, entities.PaymentSimulationSkipped, sim.Status)
+	require.Contains(t, sim.Reason, "approval pending")
+
+	u.clientFactory = &clientFactoryMock{clients: map[string]EVMClient{rpcURL: &client.evmClientMock}}
+	sim = u.simulatePayment(ctx, chain, contract, signatureData(createTx), payer)
+	require.Equal(t, entities.PaymentSimulationSkipped, sim.Status)
+	sim = u.simulatePayment(ctx, chain, contract, map[string]string{"programId": "x"}, payer)
+	require.Equal(t, entities.PaymentSimulationSkipped, sim.Status)
+}
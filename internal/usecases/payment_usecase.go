@@ -7,9 +7,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
 	"math/big"
-	"strconv"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -20,11 +19,13 @@ import (
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 	"github.com/volatiletech/null/v8"
+	"go.uber.org/zap"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/infrastructure/blockchain"
 	"payment-kita.backend/internal/infrastructure/metrics"
+	"payment-kita.backend/pkg/logger"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -49,6 +50,7 @@ type PaymentUsecase struct {
 	paymentEventRepo repositories.PaymentEventRepository
 	walletRepo       repositories.WalletRepository
 	merchantRepo     repositories.MerchantRepository
+	userRepo         repositories.UserRepository
 	contractRepo     repositories.SmartContractRepository
 	chainRepo        repositories.ChainRepository
 	tokenRepo        repositories.TokenRepository
@@ -56,8 +58,21 @@ type PaymentUsecase struct {
 	feeConfigRepo    repositories.FeeConfigRepository
 	routePolicyRepo  repositories.RoutePolicyRepository
 	uow              repositories.UnitOfWork
-	clientFactory    *blockchain.ClientFactory
+	clientFactory    ClientFactory
 	chainResolver    *ChainResolver
+	receiverNames    ReceiverNameResolver
+	// receiverAllowlist restricts where merchant payments may pay out; nil means unrestricted
+	receiverAllowlist repositories.MerchantAllowedReceiverRepository
+	// bridgeOrder is the fallback bridge preference; empty means defaultBridgeOrder
+	bridgeOrder []uint8
+	// feeRounding rounds fees to whole smallest units; the zero value is floor
+	feeRounding FeeRoundingMode
+	// vaultAddresses caches gateway vault() answers; nil disables caching
+	vaultAddresses *vaultAddressCache
+	// gatewayPause caches gateway paused() answers; nil disables the pre-create pause check
+	gatewayPause *gatewayPauseCache
+	// gatewayFees caches gateway platform fee answers per block; nil disables caching
+	gatewayFees *gatewayFeeCache
 	*ABIResolverMixin
 }
 
@@ -67,6 +82,7 @@ func NewPaymentUsecase(
 	paymentEventRepo repositories.PaymentEventRepository,
 	walletRepo repositories.WalletRepository,
 	merchantRepo repositories.MerchantRepository,
+	userRepo repositories.UserRepository,
 	contractRepo repositories.SmartContractRepository,
 	chainRepo repositories.ChainRepository,
 	tokenRepo repositories.TokenRepository,
@@ -76,11 +92,12 @@ func NewPaymentUsecase(
 	uow repositories.UnitOfWork,
 	clientFactory *blockchain.ClientFactory,
 ) *PaymentUsecase {
-	return &PaymentUsecase{
+	u := &PaymentUsecase{
 		paymentRepo:      paymentRepo,
 		paymentEventRepo: paymentEventRepo,
 		walletRepo:       walletRepo,
 		merchantRepo:     merchantRepo,
+		userRepo:         userRepo,
 		contractRepo:     contractRepo,
 		chainRepo:        chainRepo,
 		tokenRepo:        tokenRepo,
@@ -88,10 +105,81 @@ func NewPaymentUsecase(
 		feeConfigRepo:    feeConfigRepo,
 		routePolicyRepo:  routePolicyRepo,
 		uow:              uow,
-		clientFactory:    clientFactory,
+		clientFactory:    NewEVMClientFactory(clientFactory),
 		chainResolver:    NewChainResolver(chainRepo),
+		receiverNames:    NewReceiverNameResolver(NewEVMClientFactory(clientFactory), "", ""),
+		vaultAddresses:   newVaultAddressCache(),
+		gatewayFees:      newGatewayFeeCache(),
 		ABIResolverMixin: NewABIResolverMixin(contractRepo),
 	}
+	return u
+}
+
+// NewPaymentUsecaseWithReceiverAllowlist is NewPaymentUsecase refusing receivers outside the
+// merchant's allow-list
+func NewPaymentUsecaseWithReceiverAllowlist(
+	paymentRepo repositories.PaymentRepository,
+	paymentEventRepo repositories.PaymentEventRepository,
+	walletRepo repositories.WalletRepository,
+	merchantRepo repositories.MerchantRepository,
+	userRepo repositories.UserRepository,
+	contractRepo repositories.SmartContractRepository,
+	chainRepo repositories.ChainRepository,
+	tokenRepo repositories.TokenRepository,
+	bridgeConfigRepo repositories.BridgeConfigRepository,
+	feeConfigRepo repositories.FeeConfigRepository,
+	routePolicyRepo repositories.RoutePolicyRepository,
+	uow repositories.UnitOfWork,
+	clientFactory *blockchain.ClientFactory,
+	receiverAllowlist repositories.MerchantAllowedReceiverRepository,
+) *PaymentUsecase {
+	u := NewPaymentUsecase(paymentRepo, paymentEventRepo, walletRepo, merchantRepo, userRepo, contractRepo, chainRepo, tokenRepo, bridgeConfigRepo, feeConfigRepo, routePolicyRepo, uow, clientFactory)
+	u.receiverAllowlist = receiverAllowlist
+	return u
+}
+
+// PaymentSettings are the deployment settings payment creation is configured with
+type PaymentSettings struct {
+	// FeeRounding rounds fees to whole smallest units; the zero value is floor
+	FeeRounding FeeRoundingMode
+	// GatewayPauseCheck reads the EVM gateway's paused() before a payment is created. It is off
+	// by default since it adds an RPC read to payment creation every gatewayPauseCacheTTL.
+	GatewayPauseCheck bool
+	// ENSRPCURL and ENSTestnetRPCURL are the Ethereum mainnet and Sepolia RPCs ENS receiver
+	// names are resolved on, whatever EVM chain is paid to
+	ENSRPCURL        string
+	ENSTestnetRPCURL string
+	// BridgeOrder names the bridges tried, in order, when no route policy or bridge config picks
+	// one; empty means defaultBridgeOrder
+	BridgeOrder []string
+}
+
+// NewPaymentUsecaseWithSettings is NewPaymentUsecaseWithReceiverAllowlist configured by settings
+func NewPaymentUsecaseWithSettings(
+	paymentRepo repositories.PaymentRepository,
+	paymentEventRepo repositories.PaymentEventRepository,
+	walletRepo repositories.WalletRepository,
+	merchantRepo repositories.MerchantRepository,
+	userRepo repositories.UserRepository,
+	contractRepo repositories.SmartContractRepository,
+	chainRepo repositories.ChainRepository,
+	tokenRepo repositories.TokenRepository,
+	bridgeConfigRepo repositories.BridgeConfigRepository,
+	feeConfigRepo repositories.FeeConfigRepository,
+	routePolicyRepo repositories.RoutePolicyRepository,
+	uow repositories.UnitOfWork,
+	clientFactory *blockchain.ClientFactory,
+	receiverAllowlist repositories.MerchantAllowedReceiverRepository,
+	settings PaymentSettings,
+) *PaymentUsecase {
+	u := NewPaymentUsecaseWithReceiverAllowlist(paymentRepo, paymentEventRepo, walletRepo, merchantRepo, userRepo, contractRepo, chainRepo, tokenRepo, bridgeConfigRepo, feeConfigRepo, routePolicyRepo, uow, clientFactory, receiverAllowlist)
+	u.feeRounding = settings.FeeRounding
+	u.bridgeOrder = parseBridgeOrder(settings.BridgeOrder)
+	u.receiverNames = NewReceiverNameResolver(NewEVMClientFactory(clientFactory), settings.ENSRPCURL, settings.ENSTestnetRPCURL)
+	if settings.GatewayPauseCheck {
+		u.gatewayPause = newGatewayPauseCache()
+	}
+	return u
 }
 
 // FeeConfig holds fee configuration
@@ -111,7 +199,11 @@ func DefaultFeeConfig() *FeeConfig {
 	}
 }
 
-// CalculateFees calculates fees for a payment
+// CalculateFees calculates fees for a payment, in smallest units of the source token. The
+// platform fee comes from quotePlatformFee, the same engine the ERC20 approval uses. Each fee
+// is rounded to a whole unit with the configured FeeRoundingMode and the net amount is what is
+// left of amount, so PlatformFee + BridgeFee + NetAmount equals amount unless NetAmount came
+// from a swap quote.
 func (u *PaymentUsecase) CalculateFees(
 	ctx context.Context,
 	amount *big.Int,
@@ -125,119 +217,547 @@ func (u *PaymentUsecase) CalculateFees(
 	destTokenDecimals int,
 	merchantDiscount float64,
 ) *entities.FeeBreakdown {
-	// Convert amount to float for calculation
-	divisor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
-	dist := new(big.Float).SetInt(amount)
-	amountFloat, _ := new(big.Float).Quo(dist, divisor).Float64()
-
 	config := DefaultFeeConfig()
-	minFeeToken := 0.0
-	maxFeeToken := -1.0
-	if u.feeConfigRepo != nil {
-		if feeCfg, err := u.feeConfigRepo.GetByChainAndToken(ctx, sourceChainUUID, sourceTokenID); err == nil && feeCfg != nil {
-			if v, parseErr := strconv.ParseFloat(feeCfg.FixedBaseFee, 64); parseErr == nil {
-				config.BaseFeeToken = v
-			}
-			if v, parseErr := strconv.ParseFloat(feeCfg.PlatformFeePercent, 64); parseErr == nil {
-				config.PercentageFee = v
-			}
-			if v, parseErr := strconv.ParseFloat(feeCfg.MinFee, 64); parseErr == nil {
-				minFeeToken = v
-			}
-			if feeCfg.MaxFee != nil && *feeCfg.MaxFee != "" {
-				if v, parseErr := strconv.ParseFloat(*feeCfg.MaxFee, 64); parseErr == nil {
-					maxFeeToken = v
-				}
-			}
-		}
-	}
-
-	// Platform fee: min(amount * percentage, baseFee)
-	platformValue := amountFloat * config.PercentageFee
-	// config.BaseFeeToken is now treated as the Fixed Cap
-	if platformValue > config.BaseFeeToken {
-		platformValue = config.BaseFeeToken
-	}
-
-	platformFee := platformValue
-
-	// Apply merchant discount
-	if merchantDiscount > 0 {
-		platformFee = platformFee * (1 - merchantDiscount)
-	}
-	if platformFee < minFeeToken {
-		platformFee = minFeeToken
-	}
-	// maxFeeToken is still respected if set, but mostlyredundant with our new cap logic
-	if maxFeeToken >= 0 && platformFee > maxFeeToken {
-		platformFee = maxFeeToken
+	feeReq := platformFeeRequest{
+		SourceChainID:    sourceChainUUID,
+		SourceTokenID:    &sourceTokenID,
+		Amount:           amount,
+		Decimals:         decimals,
+		MerchantDiscount: merchantDiscount,
+	}
+	quote, err := u.quotePlatformFee(ctx, feeReq)
+	if err != nil || quote == nil {
+		// With the token known the engine falls back to FeeConfig, so this is not expected
+		logger.Warn(ctx, "Platform fee engine failed, pricing from fee config",
+			zap.String("source_chain_id", sourceChainID),
+			zap.Error(err),
+		)
+		quote = u.configuredPlatformFee(ctx, feeReq)
+	}
+	platformFee := quote.Fee
+
+	// Fee-exempt (internal/test) accounts skip the platform fee entirely, including min fee.
+	feeWaived := platformFeeWaived(ctx)
+	if feeWaived {
+		platformFee = big.NewInt(0)
 	}
 
 	// Bridge fee (only for cross-chain)
 	isCrossChain := sourceChainID != destChainID // Defined here
-	bridgeFeeToken := 0.0
+	bridgeFee := big.NewInt(0)
+	bridgeFeeSource := BridgeFeeSourceNone
 	if isCrossChain {
 		// Bridge quote is native-gas-denominated and is paid via tx value on EVM path.
 		// Do not add it to token-denominated fee for ERC20 source payments.
 		isSourceNative := !u.shouldRequireEvmApproval(sourceTokenAddress)
 		if isSourceNative {
 			if quotedBridgeFeeWei, err := u.getBridgeFeeQuote(ctx, sourceChainID, destChainID, sourceTokenAddress, destTokenAddress, amount, big.NewInt(0)); err == nil && quotedBridgeFeeWei != nil {
-				bridgeFeeFloat := new(big.Float).SetInt(quotedBridgeFeeWei)
-				divisor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
-				feeTokens, _ := new(big.Float).Quo(bridgeFeeFloat, divisor).Float64()
-				bridgeFeeToken = feeTokens
+				bridgeFee = new(big.Int).Set(quotedBridgeFeeWei)
+				bridgeFeeSource = BridgeFeeSourceQuote
 			} else {
-				bridgeFeeToken = config.BridgeFeeFlat
+				bridgeFee, bridgeFeeSource = u.fallbackBridgeFee(ctx, sourceChainUUID, destChainUUID, decimals, config)
 			}
 		}
 	}
 
 	// Total Fee in Token (Platform Fee + bridge flat fee)
-	totalFeeToken := platformFee + bridgeFeeToken
+	totalFee := new(big.Int).Add(platformFee, bridgeFee)
 
-	netAmount := amountFloat - totalFeeToken
-	netAmountStr := formatAmount(netAmount, decimals)
+	netAmountStr := new(big.Int).Sub(amount, totalFee).String()
+	// Without a swap quote the net amount is still in source units. That only counts as
+	// destination units when both sides are the same token; two tokens sharing decimals is not
+	// enough, their price differs.
+	netInDestUnits := strings.EqualFold(strings.TrimSpace(sourceTokenAddress), strings.TrimSpace(destTokenAddress))
+	netAmountSource := NetAmountSourceDirect
 
 	// If tokens are different, we need a price-aware net amount in destination token units.
 	if sourceTokenAddress != destTokenAddress && sourceTokenAddress != "" && destTokenAddress != "" {
 		// Calculate net amount in source token first (after platform fees)
-		netAmountSourceToken := new(big.Int).Sub(amount, new(big.Int).SetInt64(int64(platformFee*math.Pow10(decimals))))
+		netAmountSourceToken := new(big.Int).Sub(amount, platformFee)
 		if quote, err := u.getSwapQuote(ctx, sourceChainUUID, sourceTokenAddress, destTokenAddress, netAmountSourceToken); err == nil && quote != nil {
 			netAmountStr = quote.String() // Return in smallest unit of dest token
+			netInDestUnits = true
+			netAmountSource = NetAmountSourceSwapQuote
 		}
 	}
 
 	return &entities.FeeBreakdown{
-		PlatformFee: formatAmount(platformFee, decimals),
-		BridgeFee:   formatAmount(bridgeFeeToken, decimals),
-		GasFee:      "0", // Gas is handled separately
-		TotalFee:    formatAmount(totalFeeToken, decimals),
-		NetAmount:   netAmountStr,
+		PlatformFee:       platformFee.String(),
+		BridgeFee:         bridgeFee.String(),
+		GasFee:            "0", // Gas is handled separately
+		TotalFee:          totalFee.String(),
+		NetAmount:         netAmountStr,
+		PlatformFeeWaived: feeWaived,
+		NetInDestUnits:    netInDestUnits,
+		FeeSource:         strings.ToLower(string(quote.Source)),
+		BridgeFeeSource:   bridgeFeeSource,
+		NetAmountSource:   netAmountSource,
+	}
+}
+
+// fallbackBridgeFee estimates the bridge fee when no quote is available: the route policy's
+// fallbackBridgeFee (native smallest units) when set, else the global flat fee.
+func (u *PaymentUsecase) fallbackBridgeFee(ctx context.Context, sourceChainUUID, destChainUUID uuid.UUID, decimals int, config *FeeConfig) (*big.Int, string) {
+	if u.routePolicyRepo != nil {
+		if policy, err := u.routePolicyRepo.GetByRoute(ctx, sourceChainUUID, destChainUUID); err == nil && policy != nil {
+			if fee, ok := new(big.Int).SetString(strings.TrimSpace(policy.FallbackBridgeFee), 10); ok && fee.Sign() >= 0 {
+				return fee, BridgeFeeSourceRouteFallback
+			}
+		}
+	}
+	return u.feeRounding.round(tokenUnits(ratFromFloat(config.BridgeFeeFlat), decimals)), BridgeFeeSourceFlatFallback
+}
+
+// isPlatformFeeExempt reports whether the caller, the user or the merchant they are
+// authenticated as (see callerMerchant), is flagged as fee-exempt by an admin. Lookup failures
+// are treated as "not exempt".
+func (u *PaymentUsecase) isPlatformFeeExempt(ctx context.Context, userID uuid.UUID, caller *entities.Merchant) bool {
+	if caller != nil && caller.FeeExempt {
+		return true
+	}
+	if userID != uuid.Nil && u.userRepo != nil {
+		if user, err := u.userRepo.GetByID(ctx, userID); err == nil && user != nil && user.FeeExempt {
+			return true
+		}
 	}
+	return false
 }
 
-// SelectBridge selects the best bridge for cross-chain transfer
+// SelectBridge picks the first bridge in the configured default order that can carry the route,
+// falling back to Hyperbridge, which reaches every chain type.
 func (u *PaymentUsecase) SelectBridge(sourceChainID, destChainID string) string {
-	// Bridge selection logic based on chain types
-	// Priority: CCIP > Hyperlane > Hyperbridge
+	sourceType := entities.ChainTypeFromCAIP2(sourceChainID)
+	destType := entities.ChainTypeFromCAIP2(destChainID)
 
-	// For EVM chains, prefer CCIP
-	if isEVMChain(sourceChainID) && isEVMChain(destChainID) {
-		return "CCIP"
+	order := u.bridgeOrder
+	if len(order) == 0 {
+		order = defaultBridgeOrder
 	}
-
-	// For Solana <-> EVM, use Hyperlane
-	if (isSolanaChain(sourceChainID) && isEVMChain(destChainID)) ||
-		(isEVMChain(sourceChainID) && isSolanaChain(destChainID)) {
-		return "Hyperlane"
+	for _, bridgeType := range order {
+		if bridgeSupportsRoute(bridgeType, sourceType, destType) {
+			return bridgeTypeToName(bridgeType)
+		}
 	}
-
-	// Default to Hyperbridge
-	return "Hyperbridge"
+	return bridgeTypeToName(bridgeTypeHyperbridge)
 }
 
 // CreatePayment creates a new payment
 func (u *PaymentUsecase) CreatePayment(ctx context.Context, userID uuid.UUID, input *entities.CreatePaymentInput) (*entities.CreatePaymentResponse, error) {
+	existing, err := u.clientPaymentReplay(ctx, userID, input.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	// A retry answers with the stored payment even after its chain was deactivated, its gateway
+	// paused or its fees changed, so it is matched before any of that is checked again
+	if existing != nil {
+		retried, err := u.replayIdentity(ctx, existing, input)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkReplayMatches(existing, retried); err != nil {
+			return nil, err
+		}
+		return u.replayCreatePayment(ctx, existing, input)
+	}
+	draft, err := u.preparePayment(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSimulateFrom(draft.sourceChain, input.SimulateFrom); err != nil {
+		return nil, err
+	}
+	if !calldataWithheld(draft.payment.Status) {
+		if err := u.checkGatewayPaused(ctx, draft.sourceChain, draft.contract); err != nil {
+			return nil, err
+		}
+	}
+	payment := draft.payment
+	if input.PaymentID != nil {
+		payment.ID = *input.PaymentID
+	}
+	contract := draft.contract
+	sourceChain := draft.sourceChain
+	sourceCAIP2 := draft.sourceCAIP2
+	destCAIP2 := draft.destCAIP2
+	merchantID := draft.merchantID
+
+	// Save payment in transaction.
+	if err = u.uow.Do(ctx, func(txCtx context.Context) error {
+		if err := u.paymentRepo.Create(txCtx, payment); err != nil {
+			return err
+		}
+		return nil
+	}); err != nil {
+		// A concurrent retry with the same client payment ID got there first
+		if input.PaymentID != nil {
+			raced, replayErr := u.clientPaymentReplay(ctx, userID, input.PaymentID)
+			if replayErr != nil {
+				return nil, replayErr
+			}
+			if raced != nil {
+				if err := checkReplayMatches(raced, draft.payment); err != nil {
+					return nil, err
+				}
+				return u.replayCreatePayment(ctx, raced, input)
+			}
+		}
+		return nil, err
+	}
+
+	// Create initial event as best-effort after payment commit.
+	// Never fail payment creation when event table has FK/schema timing issues.
+	// The payment only stores the total fee, so the event keeps the split for the receipt.
+	event := &entities.PaymentEvent{
+		ID:        utils.GenerateUUIDv7(),
+		PaymentID: payment.ID,
+		EventType: entities.PaymentEventTypeCreated,
+		ChainID:   &sourceChain.ID,
+		Metadata:  map[string]interface{}{paymentEventFeeBreakdownKey: draft.feeBreakdown},
+		CreatedAt: time.Now(),
+	}
+	if err := u.paymentEventRepo.Create(ctx, event); err != nil {
+		constraint := dbConstraintName(err)
+		logger.WarnSampled(ctx, "payment_event_create|"+constraint, "Failed to create payment event",
+			zap.String("payment_id", payment.ID.String()),
+			zap.String("event_type", string(event.EventType)),
+			zap.String("constraint", constraint),
+			zap.Error(err),
+		)
+	}
+
+	// A payment held for approval gets its calldata once approved, when the payer retries with
+	// its paymentId
+	var signatureData interface{}
+	if !calldataWithheld(payment.Status) {
+		built, sigErr := u.buildTransactionDataWithInput(payment, contract, input)
+		if sigErr != nil {
+			return nil, sigErr
+		}
+		signatureData = built
+	}
+
+	var simulation *entities.PaymentSimulation
+	if signatureData != nil && strings.TrimSpace(input.SimulateFrom) != "" {
+		simulation = u.simulatePayment(ctx, sourceChain, contract, signatureData, input.SimulateFrom)
+	}
+
+	// Phase 3 (Track-B): expose gateway quotePaymentCost breakdown when available.
+	var onchainCost *entities.OnchainCost
+	if signatureData != nil && contract != nil && sourceChain.ChainType().IsEVM() {
+		if quoted, qErr := u.quoteGatewayPaymentCost(ctx, payment, contract.ContractAddress, input); qErr == nil {
+			onchainCost = quoted
+		}
+	}
+	if snapshotMetadata := buildPaymentQuoteSnapshotMetadata(signatureData, onchainCost); snapshotMetadata != nil {
+		snapshotEvent := &entities.PaymentEvent{
+			ID:        utils.GenerateUUIDv7(),
+			PaymentID: payment.ID,
+			EventType: entities.PaymentEventTypeQuoteSnapshotCaptured,
+			ChainID:   &sourceChain.ID,
+			Metadata:  snapshotMetadata,
+			CreatedAt: time.Now(),
+		}
+		if err := u.paymentEventRepo.Create(ctx, snapshotEvent); err != nil {
+			constraint := dbConstraintName(err)
+			logger.WarnSampled(ctx, "payment_event_create|"+constraint, "Failed to create payment event",
+				zap.String("payment_id", payment.ID.String()),
+				zap.String("event_type", string(snapshotEvent.EventType)),
+				zap.String("constraint", constraint),
+				zap.Error(err),
+			)
+		}
+	}
+
+	// Record metrics
+	merchantIDStr := "anonymous"
+	if merchantID != nil {
+		merchantIDStr = merchantID.String()
+	}
+	metrics.RecordSessionCreated(merchantIDStr, nil)
+	recordFeePath(ctx, payment.ID, draft.feeBreakdown)
+
+	return &entities.CreatePaymentResponse{
+		PaymentID:       payment.ID,
+		Status:          payment.Status,
+		SourceChainID:   sourceCAIP2,
+		DestChainID:     destCAIP2,
+		SourceAmount:    payment.SourceAmount,
+		SourceDecimals:  draft.decimals,
+		ReceiverAddress: payment.ReceiverAddress,
+		ReceiverName:    payment.ReceiverName.String,
+		ExternalRef:     payment.ExternalRef.String,
+		Metadata:        payment.Metadata,
+		DestAmount:      payment.DestAmount.String,
+		FeeAmount:       payment.FeeAmount,
+		BridgeType:      draft.bridgeType,
+		Bridge:          selectedBridge(draft.bridgeType, payment.BridgeID),
+		FeeBreakdown:    *draft.feeBreakdown,
+		OnchainCost:     onchainCost,
+		ExpiresAt:       time.Now().Add(PaymentExpiryDuration),
+		SignatureData:   signatureData,
+		Simulation:      simulation,
+	}, nil
+}
+
+// clientPaymentReplay checks a client-supplied payment ID. It must be a UUIDv7; when a payment
+// with it already exists it is returned if userID created it, and refused with a 409 otherwise.
+// A nil ID, or one not used yet, returns nil.
+func (u *PaymentUsecase) clientPaymentReplay(ctx context.Context, userID uuid.UUID, paymentID *uuid.UUID) (*entities.Payment, error) {
+	if paymentID == nil {
+		return nil, nil
+	}
+	if paymentID.Version() != 7 || paymentID.Variant() != uuid.RFC4122 {
+		return nil, domainerrors.BadRequest("paymentId must be a UUIDv7")
+	}
+	existing, err := u.paymentRepo.GetByID(ctx, *paymentID)
+	if errors.Is(err, domainerrors.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if existing.SenderID == nil || *existing.SenderID != userID {
+		return nil, domainerrors.Conflict("paymentId is already in use")
+	}
+	return existing, nil
+}
+
+// checkReplayMatches refuses a retry that describes a different payment than the one stored
+// under its payment ID, so a reused ID can never answer for other chains, tokens, amount or
+// receiver
+func checkReplayMatches(existing, retried *entities.Payment) error {
+	if existing.SourceChainID == retried.SourceChainID &&
+		existing.DestChainID == retried.DestChainID &&
+		sameUUID(existing.SourceTokenID, retried.SourceTokenID) &&
+		sameUUID(existing.DestTokenID, retried.DestTokenID) &&
+		sameDecimal(existing.SourceAmount, retried.SourceAmount) &&
+		normalizeReceiverAddress(existing.ReceiverAddress) == normalizeReceiverAddress(retried.ReceiverAddress) {
+		return nil
+	}
+	return domainerrors.Conflict("paymentId is already used by a payment with different chains, tokens, amount or receiver")
+}
+
+// replayIdentity resolves only the fields checkReplayMatches compares: the chains, tokens, amount
+// in smallest units and receiver a retried input names. A receiver name the stored payment was
+// created with is matched by name, without resolving it again.
+func (u *PaymentUsecase) replayIdentity(ctx context.Context, existing *entities.Payment, input *entities.CreatePaymentInput) (*entities.Payment, error) {
+	sourceChainUUID, _, err := u.chainResolver.ResolveFromAny(ctx, input.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source chain: %w", err)
+	}
+	destChainUUID, destCAIP2, err := u.chainResolver.ResolveFromAny(ctx, input.DestChainID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dest chain: %w", err)
+	}
+	srcToken, err := u.resolveToken(ctx, input.SourceTokenAddress, sourceChainUUID)
+	if err != nil || srcToken == nil {
+		return nil, fmt.Errorf("source token not found for address %s on chain %s", input.SourceTokenAddress, input.SourceChainID)
+	}
+	destToken, err := u.resolveToken(ctx, input.DestTokenAddress, destChainUUID)
+	if err != nil || destToken == nil {
+		return nil, fmt.Errorf("dest token not found for address %s on chain %s", input.DestTokenAddress, input.DestChainID)
+	}
+	amount, err := convertToSmallestUnit(input.Amount, srcToken.Decimals)
+	if err != nil {
+		return nil, domainerrors.ErrBadRequest
+	}
+
+	receiver := strings.TrimSpace(input.ReceiverAddress)
+	if existing.ReceiverName.Valid && strings.EqualFold(receiver, existing.ReceiverName.String) {
+		receiver = existing.ReceiverAddress
+	} else {
+		destChain, err := u.chainRepo.GetByID(ctx, destChainUUID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching dest chain: %w", err)
+		}
+		if receiver, _, err = u.resolveReceiver(ctx, destChain, destCAIP2, receiver); err != nil {
+			return nil, err
+		}
+	}
+
+	return &entities.Payment{
+		SourceChainID:   sourceChainUUID,
+		DestChainID:     destChainUUID,
+		SourceTokenID:   &srcToken.ID,
+		DestTokenID:     &destToken.ID,
+		SourceAmount:    amount,
+		ReceiverAddress: receiver,
+	}, nil
+}
+
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameDecimal compares decimal strings by value, since the stored amount may carry the column's
+// trailing zeros
+func sameDecimal(a, b string) bool {
+	x, okX := new(big.Rat).SetString(strings.TrimSpace(a))
+	y, okY := new(big.Rat).SetString(strings.TrimSpace(b))
+	return okX && okY && x.Cmp(y) == 0
+}
+
+// replayCreatePayment answers a retried CreatePayment with the payment stored under the client's
+// payment ID instead of creating another. The response and calldata are rebuilt from the stored
+// payment, its chains and its source chain's gateway; calldata is withheld while it awaits
+// approval or after it failed. Nothing is written.
+func (u *PaymentUsecase) replayCreatePayment(ctx context.Context, existing *entities.Payment, input *entities.CreatePaymentInput) (*entities.CreatePaymentResponse, error) {
+	sourceChain, err := u.chainRepo.GetByID(ctx, existing.SourceChainID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching source chain: %w", err)
+	}
+	destChain, err := u.chainRepo.GetByID(ctx, existing.DestChainID)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching dest chain: %w", err)
+	}
+	var decimals int
+	if existing.SourceTokenID != nil {
+		sourceToken, err := u.tokenRepo.GetByID(ctx, *existing.SourceTokenID)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching source token: %w", err)
+		}
+		decimals = sourceToken.Decimals
+	}
+	sourceCAIP2, destCAIP2 := sourceChain.GetCAIP2ID(), destChain.GetCAIP2ID()
+	contract, err := u.sourceGateway(ctx, sourceChain, sourceCAIP2)
+	if err != nil {
+		return nil, err
+	}
+
+	// The repository only maps a chain's name and ID, so the calldata gets the full chains
+	stored := *existing
+	stored.SourceChain, stored.DestChain = sourceChain, destChain
+	var signatureData interface{}
+	if !calldataWithheld(stored.Status) {
+		built, err := u.buildTransactionDataWithInput(&stored, contract, input)
+		if err != nil {
+			return nil, err
+		}
+		signatureData = built
+	}
+	var simulation *entities.PaymentSimulation
+	if signatureData != nil && strings.TrimSpace(input.SimulateFrom) != "" {
+		if err := validateSimulateFrom(sourceChain, input.SimulateFrom); err != nil {
+			return nil, err
+		}
+		simulation = u.simulatePayment(ctx, sourceChain, contract, signatureData, input.SimulateFrom)
+	}
+	bridgeType := ""
+	if stored.Bridge != nil {
+		bridgeType = stored.Bridge.Name
+	} else if sourceCAIP2 != destCAIP2 {
+		bridgeType, _ = u.decideBridge(ctx, stored.SourceChainID, stored.DestChainID, sourceCAIP2, destCAIP2)
+	}
+	expiresAt := stored.CreatedAt.Add(PaymentExpiryDuration)
+	if stored.ExpiresAt != nil {
+		expiresAt = *stored.ExpiresAt
+	}
+	return &entities.CreatePaymentResponse{
+		PaymentID:       stored.ID,
+		Status:          stored.Status,
+		SourceChainID:   sourceCAIP2,
+		DestChainID:     destCAIP2,
+		SourceAmount:    stored.SourceAmount,
+		SourceDecimals:  decimals,
+		ReceiverAddress: stored.ReceiverAddress,
+		ReceiverName:    stored.ReceiverName.String,
+		ExternalRef:     stored.ExternalRef.String,
+		Metadata:        stored.Metadata,
+		DestAmount:      stored.DestAmount.String,
+		FeeAmount:       stored.FeeAmount,
+		BridgeType:      bridgeType,
+		Bridge:          selectedBridge(bridgeType, stored.BridgeID),
+		FeeBreakdown:    storedFeeBreakdown(&stored),
+		ExpiresAt:       expiresAt,
+		SignatureData:   signatureData,
+		Simulation:      simulation,
+		Replayed:        true,
+	}, nil
+}
+
+// paymentEventFeeBreakdownKey holds the fee split in the metadata of a CREATED event
+const paymentEventFeeBreakdownKey = "feeBreakdown"
+
+// storedFeeBreakdown restates the fees of a stored payment. Only the total is stored, so the
+// split is known for same-chain payments, whose whole fee is the platform's, and left empty for
+// cross-chain ones.
+func storedFeeBreakdown(payment *entities.Payment) entities.FeeBreakdown {
+	fees := entities.FeeBreakdown{
+		GasFee:    "0",
+		TotalFee:  payment.FeeAmount,
+		NetAmount: payment.DestAmount.String,
+	}
+	if payment.SourceChainID == payment.DestChainID {
+		fees.PlatformFee = payment.FeeAmount
+		fees.BridgeFee = "0"
+	}
+	return fees
+}
+
+// selectedBridge is the response view of the bridge picked for a payment; nil on same-chain
+// payments, which use no bridge
+func selectedBridge(name string, id *uuid.UUID) *entities.SelectedBridge {
+	if name == "" {
+		return nil
+	}
+	return &entities.SelectedBridge{Name: name, ID: id}
+}
+
+// MaxSlippageBps caps the slippage a payment may request; anything above 50% is almost certainly
+// a units mistake and would leave the payment with a meaningless minimum amount out
+const MaxSlippageBps = 5000
+
+// validateSlippageBps rejects slippage outside 0..MaxSlippageBps, where 0 means none was requested
+func validateSlippageBps(slippageBps int) error {
+	if slippageBps < 0 || slippageBps > MaxSlippageBps {
+		return domainerrors.BadRequest(fmt.Sprintf("slippageBps must be between 0 and %d", MaxSlippageBps))
+	}
+	return nil
+}
+
+// checkMinAmountOut rejects a client minimum the payment could never meet: one above the quoted
+// net amount makes the transaction revert on-chain after the user has signed it. The check is
+// skipped when the net amount is not in destination token units.
+func checkMinAmountOut(minAmountOut string, fees *entities.FeeBreakdown) error {
+	minOut, ok := new(big.Int).SetString(strings.TrimSpace(minAmountOut), 10)
+	if !ok || minOut.Sign() < 0 {
+		return domainerrors.BadRequest("minAmountOut must be a non-negative integer in the destination token's smallest unit")
+	}
+	if !fees.NetInDestUnits {
+		return nil
+	}
+	quoted, ok := new(big.Int).SetString(fees.NetAmount, 10)
+	if !ok || minOut.Cmp(quoted) <= 0 {
+		return nil
+	}
+	return domainerrors.NewAppError(
+		http.StatusUnprocessableEntity,
+		domainerrors.CodeSlippageUnsatisfiable,
+		fmt.Sprintf("minAmountOut %s exceeds the quoted amount %s; the payment would revert on-chain", minOut, quoted),
+		domainerrors.ErrSlippageUnsatisfiable,
+	)
+}
+
+// paymentDraft is an unsaved payment together with the context resolved while building it
+type paymentDraft struct {
+	payment      *entities.Payment
+	contract     *entities.SmartContract
+	sourceChain  *entities.Chain
+	sourceToken  *entities.Token
+	sourceCAIP2  string
+	destCAIP2    string
+	bridgeType   string
+	decimals     int
+	merchantID   *uuid.UUID
+	feeBreakdown *entities.FeeBreakdown
+}
+
+// preparePayment resolves chains, tokens, fees and the gateway for input without persisting
+// anything, so CreatePayment and the calldata preview build from the same payment.
+func (u *PaymentUsecase) preparePayment(ctx context.Context, userID uuid.UUID, input *entities.CreatePaymentInput) (*paymentDraft, error) {
 	// Validate input
 	if input.SourceChainID == "" || input.DestChainID == "" {
 		return nil, domainerrors.ErrBadRequest
@@ -245,6 +765,13 @@ func (u *PaymentUsecase) CreatePayment(ctx context.Context, userID uuid.UUID, in
 	if input.ReceiverAddress == "" {
 		return nil, domainerrors.ErrBadRequest
 	}
+	if err := validateSlippageBps(input.SlippageBps); err != nil {
+		return nil, err
+	}
+	metadata, err := normalizeMetadata(input.Metadata)
+	if err != nil {
+		return nil, err
+	}
 
 	sourceChainUUID, sourceCAIP2, err := u.chainResolver.ResolveFromAny(ctx, input.SourceChainID)
 	if err != nil {
@@ -263,6 +790,20 @@ func (u *PaymentUsecase) CreatePayment(ctx context.Context, userID uuid.UUID, in
 	if err != nil {
 		return nil, fmt.Errorf("error fetching dest chain: %w", err)
 	}
+	if err := checkChainsActive(sourceChain, destChain); err != nil {
+		return nil, err
+	}
+	mode := paymentMode(ctx)
+	if err := checkChainsForMode(mode, sourceChain, destChain); err != nil {
+		return nil, err
+	}
+	receiverAddress, receiverName, err := u.resolveReceiver(ctx, destChain, destCAIP2, input.ReceiverAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateReceiverForChain(receiverAddress, destChain); err != nil {
+		return nil, err
+	}
 
 	// Select bridge
 	bridgeType := ""
@@ -272,12 +813,6 @@ func (u *PaymentUsecase) CreatePayment(ctx context.Context, userID uuid.UUID, in
 		bridgeType, bridgeID = u.decideBridge(ctx, sourceChainUUID, destChainUUID, sourceCAIP2, destCAIP2)
 	}
 
-	// Get specific gateway contract for source chain using UUID
-	contract, err := u.contractRepo.GetActiveContract(ctx, sourceChain.ID, entities.ContractTypeGateway)
-	if err != nil {
-		fmt.Printf("Warning: Active Gateway contract not found for chain %s: %v\n", input.SourceChainID, err)
-	}
-
 	// Resolve Token UUIDs?
 	// Input provides `SourceTokenAddress`.
 	// We need to find the Token Entity ID for `Payment` record.
@@ -309,6 +844,12 @@ func (u *PaymentUsecase) CreatePayment(ctx context.Context, userID uuid.UUID, in
 	} else {
 		return nil, fmt.Errorf("dest token not found for address %s on chain %s", input.DestTokenAddress, input.DestChainID)
 	}
+	if err := checkTokensActive(srcToken, destToken); err != nil {
+		return nil, err
+	}
+	if err := checkTransferFeeTokens(srcToken, destToken); err != nil {
+		return nil, err
+	}
 
 	decimals := srcToken.Decimals
 	if input.Decimals > 0 && input.Decimals != decimals {
@@ -323,9 +864,46 @@ func (u *PaymentUsecase) CreatePayment(ctx context.Context, userID uuid.UUID, in
 	amount := new(big.Int)
 	amount.SetString(amountSmallestUnit, 10)
 
+	contract, err := u.sourceGateway(ctx, sourceChain, sourceCAIP2)
+	if err != nil {
+		return nil, err
+	}
+
+	status := entities.PaymentStatusPending
+	if isCrossChain && exceedsApprovalThreshold(srcToken, amount) {
+		status = entities.PaymentStatusPendingApproval
+	}
+
+	caller, err := u.callerMerchant(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	merchantID, err := u.attributePaymentMerchant(ctx, caller, input.ReceiverMerchantID, receiverAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	// The allow-list binds whoever holds the merchant's credentials, so it is checked against the
+	// authenticated merchant rather than the attributed one, which is nil for foreign receivers.
+	if caller != nil {
+		if err := checkReceiverAllowed(ctx, u.receiverAllowlist, &caller.ID, receiverAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	// The waiver is off-chain only. The gateway charges its platform fee on-chain and has no
+	// per-account exemption, and EVM and SVM sources always pay through one (sourceGateway refuses
+	// them otherwise), so only sources paid without a gateway (Substrate, Cosmos, Tron) can be
+	// waived. Anywhere else the breakdown would show no fee while the payer still approves and
+	// pays it.
+	feeCtx := ctx
+	if contract == nil && u.isPlatformFeeExempt(ctx, userID, caller) {
+		feeCtx = withPlatformFeeWaived(ctx)
+	}
+
 	// Calculate fees after token is resolved so chain/token-specific fee_configs can be applied.
 	feeBreakdown := u.CalculateFees(
-		ctx,
+		feeCtx,
 		amount,
 		decimals,
 		sourceCAIP2,
@@ -351,17 +929,10 @@ func (u *PaymentUsecase) CreatePayment(ctx context.Context, userID uuid.UUID, in
 			minDestAmountStr = null.StringFrom(minDest.String())
 		}
 	} else if input.MinAmountOut != "" {
-		minDestAmountStr = null.StringFrom(input.MinAmountOut)
-	}
-
-	// Merchant Attribution
-	var merchantID *uuid.UUID
-	if mID, ok := ctx.Value("MerchantID").(uuid.UUID); ok {
-		merchantID = &mID
-	} else if input.ReceiverMerchantID != "" {
-		if mID, err := uuid.Parse(input.ReceiverMerchantID); err == nil {
-			merchantID = &mID
+		if err := checkMinAmountOut(input.MinAmountOut, feeBreakdown); err != nil {
+			return nil, err
 		}
+		minDestAmountStr = null.StringFrom(input.MinAmountOut)
 	}
 
 	// Create payment entity
@@ -395,13 +966,17 @@ func (u *PaymentUsecase) CreatePayment(ctx context.Context, userID uuid.UUID, in
 		// `BridgeID *uuid.UUID`
 		// It does NOT have `BridgeType` string field in the struct snippet.
 
-		ReceiverAddress: input.ReceiverAddress,
+		ReceiverAddress: receiverAddress,
+		ReceiverName:    receiverName,
+		ExternalRef:     null.NewString(strings.TrimSpace(input.ExternalRef), strings.TrimSpace(input.ExternalRef) != ""),
+		Metadata:        metadata,
 		// Decimals:           input.Decimals, // Entity `payment.go` REMOVED Decimals field?
 		// Step 15817 snippet: `SourceAmount`, `DestAmount`..., `Status`.
 		// Does NOT show `Decimals`.
 		// I should check `payment.go` again to be safe.
 
-		Status:    entities.PaymentStatusPending,
+		Status:    status,
+		Mode:      mode,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -417,78 +992,56 @@ func (u *PaymentUsecase) CreatePayment(ctx context.Context, userID uuid.UUID, in
 		payment.DestAmount = null.StringFrom(feeBreakdown.NetAmount)
 	}
 
-	// Save payment in transaction.
-	if err = u.uow.Do(ctx, func(txCtx context.Context) error {
-		if err := u.paymentRepo.Create(txCtx, payment); err != nil {
-			return err
-		}
-		return nil
-	}); err != nil {
-		return nil, err
-	}
+	return &paymentDraft{
+		payment:      payment,
+		contract:     contract,
+		sourceChain:  sourceChain,
+		sourceToken:  srcToken,
+		sourceCAIP2:  sourceCAIP2,
+		destCAIP2:    destCAIP2,
+		bridgeType:   bridgeType,
+		decimals:     decimals,
+		merchantID:   merchantID,
+		feeBreakdown: feeBreakdown,
+	}, nil
+}
 
-	// Create initial event as best-effort after payment commit.
-	// Never fail payment creation when event table has FK/schema timing issues.
-	event := &entities.PaymentEvent{
-		ID:        utils.GenerateUUIDv7(),
-		PaymentID: payment.ID,
-		EventType: entities.PaymentEventTypeCreated,
-		ChainID:   &sourceChain.ID,
-		CreatedAt: time.Now(),
+// sourceGateway returns the active gateway payments on chain are sent to. Without one the payment
+// could never be signed, so chains paid on-chain refuse it; other chains get nil.
+func (u *PaymentUsecase) sourceGateway(ctx context.Context, chain *entities.Chain, caip2 string) (*entities.SmartContract, error) {
+	contract, err := u.contractRepo.GetActiveContract(ctx, chain.ID, entities.ContractTypeGateway)
+	if err != nil && !errors.Is(err, domainerrors.ErrNotFound) {
+		return nil, fmt.Errorf("error fetching gateway contract: %w", err)
 	}
-	if err := u.paymentEventRepo.Create(ctx, event); err != nil {
-		fmt.Printf("Warning: failed to create payment event for payment %s: %v\n", payment.ID, err)
-	}
-
-	// Build transaction data using metadata from DB
-	signatureData, sigErr := u.buildTransactionDataWithInput(payment, contract, input)
-	if sigErr != nil {
-		return nil, sigErr
+	if contract == nil || err != nil {
+		logger.WarnSampled(ctx, "gateway_missing|"+caip2, "Active gateway contract not found",
+			zap.String("chain_id", caip2),
+			zap.Error(err),
+		)
+		if requiresGateway(chain.ChainType()) {
+			return nil, errGatewayNotConfigured(caip2)
+		}
+		return nil, nil
 	}
+	return contract, nil
+}
 
-	// Phase 3 (Track-B): expose gateway quotePaymentCost breakdown when available.
-	var onchainCost *entities.OnchainCost
-	if contract != nil && getChainTypeFromCAIP2(sourceCAIP2) == "eip155" {
-		if quoted, qErr := u.quoteGatewayPaymentCost(ctx, payment, contract.ContractAddress, input); qErr == nil {
-			onchainCost = quoted
-		}
+// resolveReceiver resolves an ENS/SNS receiver name to its address. Raw addresses pass through
+// with a null name.
+func (u *PaymentUsecase) resolveReceiver(ctx context.Context, destChain *entities.Chain, destCAIP2, receiver string) (string, null.String, error) {
+	receiver = strings.TrimSpace(receiver)
+	if !isReceiverName(receiver, destChain.ChainType()) {
+		return receiver, null.String{}, nil
 	}
-	if snapshotMetadata := buildPaymentQuoteSnapshotMetadata(signatureData, onchainCost); snapshotMetadata != nil {
-		snapshotEvent := &entities.PaymentEvent{
-			ID:        utils.GenerateUUIDv7(),
-			PaymentID: payment.ID,
-			EventType: entities.PaymentEventType("QUOTE_SNAPSHOT_CAPTURED"),
-			ChainID:   &sourceChain.ID,
-			Metadata:  snapshotMetadata,
-			CreatedAt: time.Now(),
-		}
-		if err := u.paymentEventRepo.Create(ctx, snapshotEvent); err != nil {
-			fmt.Printf("Warning: failed to create quote snapshot event for payment %s: %v\n", payment.ID, err)
-		}
+	if u.receiverNames == nil {
+		return "", null.String{}, fmt.Errorf("%w: %s", domainerrors.ErrReceiverNameUnresolved, receiver)
 	}
-
-	// Record metrics
-	merchantIDStr := "anonymous"
-	if merchantID != nil {
-		merchantIDStr = merchantID.String()
+	name := strings.ToLower(receiver)
+	address, err := u.receiverNames.ResolveReceiverName(ctx, destChain, name)
+	if err != nil {
+		return "", null.String{}, err
 	}
-	metrics.RecordSessionCreated(merchantIDStr, nil)
-
-	return &entities.CreatePaymentResponse{
-		PaymentID:      payment.ID,
-		Status:         payment.Status,
-		SourceChainID:  sourceCAIP2,
-		DestChainID:    destCAIP2,
-		SourceAmount:   payment.SourceAmount,
-		SourceDecimals: decimals,
-		DestAmount:     payment.DestAmount.String,
-		FeeAmount:      payment.FeeAmount,
-		BridgeType:     bridgeType,
-		FeeBreakdown:   *feeBreakdown,
-		OnchainCost:    onchainCost,
-		ExpiresAt:      time.Now().Add(PaymentExpiryDuration),
-		SignatureData:  signatureData,
-	}, nil
+	return address, null.StringFrom(name), nil
 }
 
 func (u *PaymentUsecase) decideBridge(
@@ -499,7 +1052,8 @@ func (u *PaymentUsecase) decideBridge(
 	// Priority 1: explicit route policy (default bridge type)
 	if u.routePolicyRepo != nil {
 		if policy, err := u.routePolicyRepo.GetByRoute(ctx, sourceChainUUID, destChainUUID); err == nil && policy != nil {
-			return bridgeTypeToName(policy.DefaultBridgeType), nil
+			name := bridgeTypeToName(policy.DefaultBridgeType)
+			return name, u.configuredBridgeID(ctx, sourceChainUUID, destChainUUID, name)
 		}
 		// Phase 4.3: Auto-bootstrap disabled to prevent unwanted defaults.
 		// else if errors.Is(err, domainerrors.ErrNotFound) {
@@ -521,6 +1075,24 @@ func (u *PaymentUsecase) decideBridge(
 	return u.SelectBridge(sourceCAIP2, destCAIP2), nil
 }
 
+// configuredBridgeID returns the ID of the route's active bridge config bridge when it is the
+// bridge named name, so a policy-selected bridge is still linked to its registered row
+func (u *PaymentUsecase) configuredBridgeID(ctx context.Context, sourceChainUUID, destChainUUID uuid.UUID, name string) *uuid.UUID {
+	if u.bridgeConfigRepo == nil {
+		return nil
+	}
+	cfg, err := u.bridgeConfigRepo.GetActive(ctx, sourceChainUUID, destChainUUID)
+	if err != nil || cfg == nil || cfg.Bridge == nil || cfg.Bridge.ID == uuid.Nil {
+		return nil
+	}
+	configured, ok := lookupBridgeType(cfg.Bridge.Name)
+	if !ok || bridgeTypeToName(configured) != name {
+		return nil
+	}
+	id := cfg.Bridge.ID
+	return &id
+}
+
 func isForeignKeyViolation(err error, constraint string) bool {
 	if err == nil {
 		return false
@@ -537,6 +1109,31 @@ func isForeignKeyViolation(err error, constraint string) bool {
 	return false
 }
 
+// dbConstraintName returns the violated constraint of a Postgres error, or "" when unknown
+func dbConstraintName(err error) string {
+	var pgErr *pq.Error
+	if errors.As(err, &pgErr) {
+		return pgErr.Constraint
+	}
+	return ""
+}
+
+// requiresGateway reports whether payments from chainType are made through the gateway
+// contract, and so cannot be created on a chain without an active one
+func requiresGateway(chainType entities.ChainType) bool {
+	return chainType == entities.ChainTypeEVM || chainType == entities.ChainTypeSVM
+}
+
+// errGatewayNotConfigured tells the client the source chain is not set up for payments yet
+func errGatewayNotConfigured(caip2 string) error {
+	return domainerrors.NewAppError(
+		http.StatusUnprocessableEntity,
+		domainerrors.CodeGatewayNotConfigured,
+		fmt.Sprintf("no active gateway contract is configured on %s", caip2),
+		domainerrors.ErrGatewayNotConfigured,
+	)
+}
+
 // Helper to resolve token
 func (u *PaymentUsecase) resolveToken(ctx context.Context, address string, chainID uuid.UUID) (*entities.Token, error) {
 	// If address is "0x000..." or "native", handle native token logic
@@ -570,19 +1167,16 @@ func (u *PaymentUsecase) buildTransactionDataWithInput(
 		return nil, nil
 	}
 
-	// Determine chain type using SourceChain relation if available, else standard EVM default or fetch
-	// For now, assuming standard EVM if not Sol
-	// But better: use SourceChain.ChainID (string/CAIP-2) if preloaded.
-	sourceChainID := ""
+	// Determine chain type from the SourceChain relation if preloaded, else fetch it.
+	var chainType entities.ChainType
 	if payment.SourceChain != nil {
-		sourceChainID = payment.SourceChain.GetCAIP2ID()
+		chainType = payment.SourceChain.ChainType()
 	} else if chain, err := u.chainRepo.GetByID(context.Background(), payment.SourceChainID); err == nil && chain != nil {
-		sourceChainID = chain.GetCAIP2ID()
+		chainType = chain.ChainType()
 	}
-	chainType := getChainTypeFromCAIP2(sourceChainID)
 
 	switch chainType {
-	case "eip155":
+	case entities.ChainTypeEVM:
 		destChainID := payment.DestChainID.String()
 		if payment.DestChain != nil {
 			destChainID = payment.DestChain.GetCAIP2ID()
@@ -700,7 +1294,12 @@ func (u *PaymentUsecase) buildTransactionDataWithInput(
 					if approvalAmount == "" || approvalAmount == "0" {
 						return nil, approvalErr
 					}
-					fmt.Printf("Warning: using fallback approval amount for payment %s: %v\n", payment.ID, approvalErr)
+					metrics.RecordApprovalAmountFallback(payment.SourceChainID.String())
+					logger.WarnSampled(context.Background(), "approval_fallback|"+payment.SourceChainID.String(), "Using fallback approval amount",
+						zap.String("payment_id", payment.ID.String()),
+						zap.String("chain_id", payment.SourceChainID.String()),
+						zap.Error(approvalErr),
+					)
 				} else {
 					approvalAmount = approvalAmountResolved
 				}
@@ -720,7 +1319,7 @@ func (u *PaymentUsecase) buildTransactionDataWithInput(
 		result["transactions"] = txs
 
 		return result, nil
-	case "solana":
+	case entities.ChainTypeSVM:
 		return map[string]string{
 			"programId": contract.ContractAddress,
 			"data":      u.buildSvmPaymentBase58(payment),
@@ -849,6 +1448,9 @@ func (u *PaymentUsecase) ResolveVaultAddressForApproval(sourceChainID uuid.UUID,
 	if gatewayAddress == "" {
 		return ""
 	}
+	if cached, ok := u.vaultAddresses.get(context.Background(), sourceChainID, gatewayAddress); ok {
+		return cached
+	}
 	chain, err := u.chainRepo.GetByID(context.Background(), sourceChainID)
 	if err != nil || chain == nil {
 		return ""
@@ -885,8 +1487,12 @@ func (u *PaymentUsecase) ResolveVaultAddressForApproval(sourceChainID uuid.UUID,
 	if err != nil || len(out) < 32 {
 		return ""
 	}
-	vaultAddress := common.BytesToAddress(out[12:32]).Hex()
-	return vaultAddress
+	vault := common.BytesToAddress(out[12:32])
+	if vault != (common.Address{}) {
+		// An unset vault may be configured any moment; only a real one is worth remembering
+		u.vaultAddresses.put(sourceChainID, gatewayAddress, vault.Hex())
+	}
+	return vault.Hex()
 }
 
 func (u *PaymentUsecase) buildErc20ApproveHex(spender, amount string) string {
@@ -996,6 +1602,14 @@ func (u *PaymentUsecase) buildDeployEscrowHex(salt [32]byte, owner common.Addres
 	return "0x" + hex.EncodeToString(append(common.FromHex(DeployEscrowSelector), packed...))
 }
 
+// CalculateOnchainApprovalAmount returns the ERC20 allowance the payer must grant the gateway.
+//
+// The amount follows what the gateway contract will actually pull: quotePaymentCost when the
+// gateway has it, otherwise amount plus the platform fee from quotePlatformFee, the same engine
+// behind CalculateFees. Only an exact quoteTotalAmount is used as is; fees worked out from the
+// gateway's rates or from FeeConfig get a safety buffer. Fee-exempt accounts are never waived
+// on payments through a gateway, since it has no per-account exemption, so the approval always
+// covers the fee the breakdown shows.
 func (u *PaymentUsecase) CalculateOnchainApprovalAmount(payment *entities.Payment, gatewayAddress string) (string, error) {
 	if payment == nil || gatewayAddress == "" {
 		return "", fmt.Errorf("invalid payment or gateway address")
@@ -1021,90 +1635,35 @@ func (u *PaymentUsecase) CalculateOnchainApprovalAmount(payment *entities.Paymen
 		}
 	}
 
-	chain, err := u.chainRepo.GetByID(context.Background(), payment.SourceChainID)
-	if err != nil || chain == nil {
-		return "", fmt.Errorf("failed to resolve source chain")
-	}
-	rpcURL := strings.TrimSpace(chain.RPCURL)
-	if rpcURL == "" {
-		for _, rpc := range chain.RPCs {
-			if rpc.IsActive && strings.TrimSpace(rpc.URL) != "" {
-				rpcURL = rpc.URL
-				break
-			}
-		}
-	}
-	if rpcURL == "" {
-		return "", fmt.Errorf("no active source chain rpc url")
-	}
-	client, err := u.clientFactory.GetEVMClient(rpcURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to create evm client for approval quote: %w", err)
-	}
-
-	feeABI := FallbackPaymentKitaGatewayABI
-	if u.ABIResolverMixin != nil {
-		resolvedABI, abiErr := u.ResolveABIWithFallback(context.Background(), payment.SourceChainID, entities.ContractTypeGateway)
-		if abiErr != nil {
-			return "", fmt.Errorf("failed to resolve gateway ABI: %w", abiErr)
-		}
-		// Some DB ABI rows are stale and can miss Track-B methods.
-		// Prefer resolved ABI only when it contains the method we need.
-		if _, ok := resolvedABI.Methods["quoteTotalAmount"]; ok {
-			feeABI = resolvedABI
-		}
+	req := platformFeeRequest{
+		SourceChainID:  payment.SourceChainID,
+		SourceTokenID:  payment.SourceTokenID,
+		GatewayAddress: gatewayAddress,
+		Amount:         amount,
+		Decimals:       -1,
 	}
-
-	// Preferred path: ask contract directly for exact total amount
-	quoteCall, quoteErr := feeABI.Pack("quoteTotalAmount", amount)
-	if quoteErr == nil {
-		quoteRaw, callErr := client.CallView(context.Background(), gatewayAddress, quoteCall)
-		if callErr == nil {
-			quoteVals, unpackErr := feeABI.Unpack("quoteTotalAmount", quoteRaw)
-			if unpackErr == nil && len(quoteVals) >= 1 {
-				if quotedTotal, ok := quoteVals[0].(*big.Int); ok && quotedTotal != nil {
-					if quotedTotal.Cmp(totalCharged) < 0 {
-						return totalCharged.String(), nil
-					}
-					return quotedTotal.String(), nil
-				}
-			}
+	if payment.SourceToken != nil {
+		req.Decimals = payment.SourceToken.Decimals
+	} else if payment.SourceTokenID != nil && u.tokenRepo != nil {
+		if token, err := u.tokenRepo.GetByID(context.Background(), *payment.SourceTokenID); err == nil && token != nil {
+			req.Decimals = token.Decimals
 		}
 	}
-
-	fixedFeeCall, _ := feeABI.Pack("FIXED_BASE_FEE")
-	fixedFeeRaw, err := client.CallView(context.Background(), gatewayAddress, fixedFeeCall)
-	if err != nil {
-		return "", fmt.Errorf("failed to call FIXED_BASE_FEE: %w", err)
-	}
-	fixedVals, err := feeABI.Unpack("FIXED_BASE_FEE", fixedFeeRaw)
-	if err != nil || len(fixedVals) == 0 {
-		return "", fmt.Errorf("failed to decode FIXED_BASE_FEE")
+	if req.Decimals < 0 {
+		// FeeConfig amounts are in whole tokens, so without decimals the engine may only ask the gateway
+		req.SourceTokenID = nil
 	}
-	fixedFee := fixedVals[0].(*big.Int)
-
-	bpsCall, _ := feeABI.Pack("FEE_RATE_BPS")
-	bpsRaw, err := client.CallView(context.Background(), gatewayAddress, bpsCall)
+	quote, err := u.quotePlatformFee(context.Background(), req)
 	if err != nil {
-		return "", fmt.Errorf("failed to call FEE_RATE_BPS: %w", err)
-	}
-	bpsVals, err := feeABI.Unpack("FEE_RATE_BPS", bpsRaw)
-	if err != nil || len(bpsVals) == 0 {
-		return "", fmt.Errorf("failed to decode FEE_RATE_BPS")
-	}
-	feeBps := bpsVals[0].(*big.Int)
-
-	percentageFee := new(big.Int).Mul(amount, feeBps)
-	percentageFee.Div(percentageFee, big.NewInt(10000))
-	// fixedFee is now the CAP
-	platformFee := new(big.Int).Set(percentageFee)
-	if platformFee.Cmp(fixedFee) > 0 {
-		platformFee = fixedFee
+		return "", err
 	}
-	onchainTotal := new(big.Int).Add(amount, platformFee)
+	onchainTotal := new(big.Int).Add(amount, quote.Fee)
 	if onchainTotal.Cmp(totalCharged) < 0 {
 		onchainTotal = totalCharged
 	}
+	if quote.Source == PlatformFeeSourceGatewayQuote {
+		return onchainTotal.String(), nil
+	}
 
 	// Add 1% safety buffer (standard practice for bridges/DEXs to handle minor fee fluctuations)
 	buffer := new(big.Int).Div(onchainTotal, big.NewInt(100))
@@ -1639,10 +2198,20 @@ func (u *PaymentUsecase) GetPayment(ctx context.Context, paymentID uuid.UUID) (*
 	return u.paymentRepo.GetByID(ctx, paymentID)
 }
 
-// GetPaymentsByUser gets payments for a user
-func (u *PaymentUsecase) GetPaymentsByUser(ctx context.Context, userID uuid.UUID, page, limit int) ([]*entities.Payment, int, error) {
-	offset := (page - 1) * limit
-	return u.paymentRepo.GetByUserID(ctx, userID, limit, offset)
+// GetPaymentsByUser gets a user's payments in the request's mode, so test payments stay out of
+// live listings
+func (u *PaymentUsecase) GetPaymentsByUser(ctx context.Context, userID uuid.UUID, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	return u.paymentRepo.GetByUserID(ctx, userID, paymentMode(ctx), pagination)
+}
+
+// GetPaymentsByExternalRef gets the merchant's payments in the request's mode created with the
+// merchant order id ref, a page at a time
+func (u *PaymentUsecase) GetPaymentsByExternalRef(ctx context.Context, merchantID uuid.UUID, ref string, pagination utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, 0, domainerrors.BadRequest("externalRef is required")
+	}
+	return u.paymentRepo.GetByMerchantExternalRef(ctx, merchantID, paymentMode(ctx), ref, pagination)
 }
 
 // GetPaymentEvents gets events for a payment
@@ -1991,7 +2560,7 @@ func (u *PaymentUsecase) deriveGatewayPrivacyLifecycle(
 
 func fetchGatewayPrivacyState(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	gatewayAddress string,
 	paymentID [32]byte,
 ) (*gatewayPrivacyState, error) {
@@ -2032,7 +2601,7 @@ func fetchGatewayPrivacyState(
 
 func callGatewayBytes32Getter(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	gatewayAddress string,
 	methodSig string,
 	paymentID [32]byte,
@@ -2066,7 +2635,7 @@ func callGatewayBytes32Getter(
 
 func callGatewayAddressGetter(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	gatewayAddress string,
 	methodSig string,
 	paymentID [32]byte,
@@ -2095,7 +2664,7 @@ func callGatewayAddressGetter(
 
 func callGatewayBoolGetter(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	gatewayAddress string,
 	methodSig string,
 	paymentID [32]byte,
@@ -2124,7 +2693,7 @@ func callGatewayBoolGetter(
 
 func callGatewayUint8Getter(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	gatewayAddress string,
 	methodSig string,
 	paymentID [32]byte,
@@ -2156,7 +2725,7 @@ func callGatewayUint8Getter(
 
 func callGatewayUint256Getter(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	gatewayAddress string,
 	methodSig string,
 	paymentID [32]byte,
@@ -2188,7 +2757,7 @@ func callGatewayUint256Getter(
 
 func callGatewayPaymentGetter(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	gatewayAddress string,
 	methodSig string,
 	paymentID [32]byte,
@@ -2387,7 +2956,7 @@ func (u *PaymentUsecase) getBridgeFeeQuote(
 	routerAddress := router.ContractAddress
 
 	// 3. Get RPC Client
-	var client *blockchain.EVMClient
+	var client EVMClient
 	var clientErr error
 
 	// Use RPCs if available, fallback to legacy RPCURL
@@ -2448,7 +3017,7 @@ func (u *PaymentUsecase) getBridgeFeeQuote(
 
 func (u *PaymentUsecase) readGatewayRouterAddress(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	gatewayAddress string,
 ) (string, error) {
 	selector := crypto.Keccak256([]byte("router()"))[:4]
@@ -2468,7 +3037,7 @@ func (u *PaymentUsecase) readGatewayRouterAddress(
 
 func (u *PaymentUsecase) readGatewayDefaultBridgeType(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	gatewayAddress string,
 	destCAIP2 string,
 ) (uint8, error) {
@@ -2550,7 +3119,7 @@ func buildBridgeOrderFromPolicy(policy *entities.RoutePolicy) []uint8 {
 
 func (u *PaymentUsecase) quoteBridgeFeeByType(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	routerAddress string,
 	destCAIP2 string,
 	bridgeType uint8,
@@ -2730,7 +3299,7 @@ func (u *PaymentUsecase) quoteBridgeFeeByType(
 
 func (u *PaymentUsecase) checkRouterHasAdapter(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	routerAddress string,
 	destCAIP2 string,
 	bridgeType uint8,
@@ -2763,7 +3332,7 @@ func (u *PaymentUsecase) checkRouterHasAdapter(
 
 func (u *PaymentUsecase) checkRouterRouteConfigured(
 	ctx context.Context,
-	client *blockchain.EVMClient,
+	client EVMClient,
 	routerAddress string,
 	destCAIP2 string,
 	bridgeType uint8,
@@ -2796,29 +3365,35 @@ func (u *PaymentUsecase) checkRouterRouteConfigured(
 
 func bridgeTypeToName(bridgeType uint8) string {
 	switch bridgeType {
-	case 0:
-		return "Hyperbridge"
-	case 1:
-		return "CCIP"
-	case 2:
-		return "Stargate"
-	case 3:
-		return "HyperbridgeTokenGateway"
+	case bridgeTypeCCIP:
+		return bridgeNameCCIP
+	case bridgeTypeStargate:
+		return bridgeNameStargate
+	case bridgeTypeHyperbridgeTokenGateway:
+		return bridgeNameHyperbridgeTokenGateway
 	default:
-		return "Hyperbridge"
+		return bridgeNameHyperbridge
 	}
 }
 
 func bridgeNameToType(bridgeName string) uint8 {
+	bridgeType, _ := lookupBridgeType(bridgeName)
+	return bridgeType
+}
+
+// lookupBridgeType maps a bridge name to its on-chain type, reporting whether the name is known
+func lookupBridgeType(bridgeName string) (uint8, bool) {
 	switch strings.ToUpper(strings.TrimSpace(bridgeName)) {
+	case "HYPERBRIDGE":
+		return bridgeTypeHyperbridge, true
 	case "CCIP":
-		return 1
-	case "STARGATE":
-		return 2
+		return bridgeTypeCCIP, true
+	case "STARGATE", "LAYERZERO":
+		return bridgeTypeStargate, true
 	case "HYPERBRIDGE_TOKEN_GATEWAY", "HYPERBRIDGETOKENGATEWAY", "HBTOKENGATEWAY":
-		return 3
+		return bridgeTypeHyperbridgeTokenGateway, true
 	default:
-		return 0
+		return bridgeTypeHyperbridge, false
 	}
 }
 
@@ -2912,7 +3487,7 @@ type AccurateSwapRequiredInputResult struct {
 
 type accurateQuoteContext struct {
 	chain      *entities.Chain
-	client     *blockchain.EVMClient
+	client     EVMClient
 	swapper    *entities.SmartContract
 	swapperABI abi.ABI
 	routePath  []string
@@ -3276,7 +3851,7 @@ func (u *PaymentUsecase) CheckRouteSupportDetailed(
 	return status, nil
 }
 
-func callAddressBySignature(ctx context.Context, client *blockchain.EVMClient, contractAddress, signature string) (common.Address, error) {
+func callAddressBySignature(ctx context.Context, client EVMClient, contractAddress, signature string) (common.Address, error) {
 	selector := crypto.Keccak256([]byte(signature))[:4]
 	out, err := client.CallView(ctx, contractAddress, selector)
 	if err != nil {
@@ -3288,7 +3863,7 @@ func callAddressBySignature(ctx context.Context, client *blockchain.EVMClient, c
 	return common.BytesToAddress(out[len(out)-20:]), nil
 }
 
-func readRoutePath(ctx context.Context, client *blockchain.EVMClient, swapperAddress string, swapperABI abi.ABI, tokenIn, tokenOut string) ([]string, error) {
+func readRoutePath(ctx context.Context, client EVMClient, swapperAddress string, swapperABI abi.ABI, tokenIn, tokenOut string) ([]string, error) {
 	findRouteCall, err := swapperABI.Pack("findRoute", common.HexToAddress(tokenIn), common.HexToAddress(tokenOut))
 	if err != nil {
 		return nil, err
@@ -3320,7 +3895,7 @@ func readRoutePath(ctx context.Context, client *blockchain.EVMClient, swapperAdd
 	return path, nil
 }
 
-func readV3PoolConfig(ctx context.Context, client *blockchain.EVMClient, contractAddress, tokenIn, tokenOut string) (bool, uint32, error) {
+func readV3PoolConfig(ctx context.Context, client EVMClient, contractAddress, tokenIn, tokenOut string) (bool, uint32, error) {
 	bytes32Type, _ := abi.NewType("bytes32", "", nil)
 	args := abi.Arguments{{Type: bytes32Type}}
 	callData, err := args.Pack(computePairKey(tokenIn, tokenOut))
@@ -3373,7 +3948,7 @@ func readV3PoolConfig(ctx context.Context, client *blockchain.EVMClient, contrac
 	return active, feeTier, nil
 }
 
-func callQuoterV3ExactInputSingle(ctx context.Context, client *blockchain.EVMClient, quoterAddress, tokenIn, tokenOut string, amountIn *big.Int, feeTier uint32) (*big.Int, error) {
+func callQuoterV3ExactInputSingle(ctx context.Context, client EVMClient, quoterAddress, tokenIn, tokenOut string, amountIn *big.Int, feeTier uint32) (*big.Int, error) {
 	uint256Type, _ := abi.NewType("uint256", "", nil)
 	uint160Type, _ := abi.NewType("uint160", "", nil)
 	tupleType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
@@ -3428,7 +4003,7 @@ func callQuoterV3ExactInputSingle(ctx context.Context, client *blockchain.EVMCli
 	return amountOut, nil
 }
 
-func callQuoterV3ExactOutputSingle(ctx context.Context, client *blockchain.EVMClient, quoterAddress, tokenIn, tokenOut string, amountOut *big.Int, feeTier uint32) (*big.Int, error) {
+func callQuoterV3ExactOutputSingle(ctx context.Context, client EVMClient, quoterAddress, tokenIn, tokenOut string, amountOut *big.Int, feeTier uint32) (*big.Int, error) {
 	uint256Type, _ := abi.NewType("uint256", "", nil)
 	uint160Type, _ := abi.NewType("uint160", "", nil)
 	tupleType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
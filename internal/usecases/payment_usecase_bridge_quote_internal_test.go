@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/infrastructure/blockchain"
 	"payment-kita.backend/pkg/utils"
 )
@@ -45,9 +46,13 @@ func (s *quoteChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool, *str
 func (s *quoteChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *quoteChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *quoteChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *quoteChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *quoteChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *quoteChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *quoteChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *quoteChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *quoteChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -78,9 +83,15 @@ func (s *quoteContractRepoStub) GetActiveContract(_ context.Context, _ uuid.UUID
 	}
 	return nil, errors.New("not found")
 }
-func (s *quoteContractRepoStub) GetFiltered(context.Context, *uuid.UUID, entities.SmartContractType, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
+func (s *quoteContractRepoStub) GetFiltered(context.Context, repositories.SmartContractFilter, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
+func (s *quoteContractRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
+func (s *quoteContractRepoStub) Activate(context.Context, uuid.UUID) (*entities.SmartContract, error) {
+	return nil, nil
+}
 func (s *quoteContractRepoStub) GetByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.SmartContract, int64, error) {
 	return nil, 0, nil
 }
@@ -102,6 +113,7 @@ func (quoteTokenRepoStub) GetByAddress(context.Context, string, uuid.UUID) (*ent
 	return nil, domainerrors.ErrNotFound
 }
 func (quoteTokenRepoStub) GetAll(context.Context) ([]*entities.Token, error)         { return nil, nil }
+func (quoteTokenRepoStub) GetActiveBySymbol(context.Context, string) ([]*entities.Token, error) { return nil, nil }
 func (quoteTokenRepoStub) GetStablecoins(context.Context) ([]*entities.Token, error) { return nil, nil }
 func (quoteTokenRepoStub) GetNative(context.Context, uuid.UUID) (*entities.Token, error) {
 	return nil, domainerrors.ErrNotFound
@@ -109,12 +121,16 @@ func (quoteTokenRepoStub) GetNative(context.Context, uuid.UUID) (*entities.Token
 func (quoteTokenRepoStub) GetTokensByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return []*entities.Token{}, 0, nil
 }
-func (quoteTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, utils.PaginationParams) ([]*entities.Token, int64, error) {
+func (quoteTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, bool, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
+func (quoteTokenRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
 func (quoteTokenRepoStub) Create(context.Context, *entities.Token) error { return nil }
 func (quoteTokenRepoStub) Update(context.Context, *entities.Token) error { return nil }
 func (quoteTokenRepoStub) SoftDelete(context.Context, uuid.UUID) error   { return nil }
+func (quoteTokenRepoStub) HardDelete(context.Context, uuid.UUID) error   { return nil }
 
 func TestPaymentUsecase_GetBridgeFeeQuote_ErrorBranches(t *testing.T) {
 	sourceID := uuid.New()
@@ -162,7 +178,7 @@ func TestPaymentUsecase_GetBridgeFeeQuote_ErrorBranches(t *testing.T) {
 			chainRepo:     repo,
 			chainResolver: NewChainResolver(repo),
 			contractRepo:  &quoteContractRepoStub{router: router},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 			tokenRepo:     quoteTokenRepoStub{},
 		}
 		_, err := u.getBridgeFeeQuote(context.Background(), "eip155:8453", "eip155:42161", "0x1", "0x2", big.NewInt(1), big.NewInt(0))
@@ -180,7 +196,7 @@ func TestPaymentUsecase_GetBridgeFeeQuote_ErrorBranches(t *testing.T) {
 			chainRepo:       repo,
 			chainResolver:   NewChainResolver(repo),
 			contractRepo:    &quoteContractRepoStub{router: router},
-			clientFactory:   blockchain.NewClientFactory(),
+			clientFactory:   NewEVMClientFactory(blockchain.NewClientFactory()),
 			tokenRepo:       quoteTokenRepoStub{},
 			routePolicyRepo: nil,
 		}
@@ -198,7 +214,7 @@ func TestPaymentUsecase_GetBridgeFeeQuote_ErrorBranches(t *testing.T) {
 			chainRepo:     repo,
 			chainResolver: NewChainResolver(repo),
 			contractRepo:  &quoteContractRepoStub{router: router},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		}
 		_, err := u.getBridgeFeeQuote(context.Background(), "eip155:8453", "eip155:42161", "0x1", "0x2", big.NewInt(1), big.NewInt(0))
 		require.Error(t, err)
@@ -222,7 +238,7 @@ func TestPaymentUsecase_GetBridgeFeeQuote_ErrorBranches(t *testing.T) {
 			chainRepo:       repo,
 			chainResolver:   NewChainResolver(repo),
 			contractRepo:    &quoteContractRepoStub{router: router},
-			clientFactory:   factory,
+			clientFactory:   NewEVMClientFactory(factory),
 			routePolicyRepo: nil,
 		}
 		_, err := u.getBridgeFeeQuote(context.Background(), "eip155:8453", "eip155:42161", "0x1", "0x2", big.NewInt(100), big.NewInt(0))
@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 	"math/big"
+	"strings"
 	"testing"
 
 	"payment-kita.backend/internal/infrastructure/blockchain"
@@ -72,6 +73,9 @@ func TestPaymentUsecase_CalculateFees_ConfigAndFallback(t *testing.T) {
 		require.Equal(t, "0", fees.BridgeFee)
 		require.Equal(t, "300", fees.TotalFee)
 		require.Equal(t, "700", fees.NetAmount)
+		require.Equal(t, "fee_config", fees.FeeSource)
+		require.Equal(t, BridgeFeeSourceNone, fees.BridgeFeeSource)
+		require.Equal(t, NetAmountSourceDirect, fees.NetAmountSource)
 	})
 
 	t.Run("cross-chain quote failure uses flat bridge fee fallback", func(t *testing.T) {
@@ -122,6 +126,93 @@ func TestPaymentUsecase_CalculateFees_ConfigAndFallback(t *testing.T) {
 		require.Equal(t, "10", fees.BridgeFee)
 		require.Equal(t, "13", fees.TotalFee)
 		require.Equal(t, "987", fees.NetAmount)
+		require.Equal(t, "default", fees.FeeSource)
+		require.Equal(t, BridgeFeeSourceFlatFallback, fees.BridgeFeeSource)
+	})
+
+	t.Run("cross-chain quote failure uses the route's fallback bridge fee", func(t *testing.T) {
+		sourceID := uuid.New()
+		destID := uuid.New()
+		sourceChain := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM}
+		destChain := &entities.Chain{ID: destID, ChainID: "42161", Type: entities.ChainTypeEVM}
+		chainRepo := &quoteChainRepoStub{
+			byCAIP2: map[string]*entities.Chain{
+				"eip155:8453":  sourceChain,
+				"eip155:42161": destChain,
+			},
+			byID: map[uuid.UUID]*entities.Chain{
+				sourceID: sourceChain,
+				destID:   destChain,
+			},
+		}
+		fallbackFee := "25"
+		u := &PaymentUsecase{
+			feeConfigRepo: &feeConfigRepoStub{},
+			chainRepo:     chainRepo,
+			chainResolver: NewChainResolver(chainRepo),
+			contractRepo: &quoteContractRepoStub{
+				router: &entities.SmartContract{
+					ContractAddress: "0x1111111111111111111111111111111111111111",
+					Type:            entities.ContractTypeRouter,
+				},
+			},
+			routePolicyRepo: &routePolicyRepoStub{
+				getByRouteFn: func(_ context.Context, src, dst uuid.UUID) (*entities.RoutePolicy, error) {
+					require.Equal(t, sourceID, src)
+					require.Equal(t, destID, dst)
+					return &entities.RoutePolicy{SourceChainID: src, DestChainID: dst, FallbackBridgeFee: fallbackFee}, nil
+				},
+			},
+		}
+		calculate := func() *entities.FeeBreakdown {
+			return u.CalculateFees(ctx, big.NewInt(1000), 2, "eip155:8453", "eip155:42161", sourceID, destID, sourceTokenID, "native", "native", 2, 0)
+		}
+
+		fees := calculate()
+		require.Equal(t, "3", fees.PlatformFee)
+		require.Equal(t, "25", fees.BridgeFee)
+		require.Equal(t, "28", fees.TotalFee)
+		require.Equal(t, "972", fees.NetAmount)
+		require.Equal(t, BridgeFeeSourceRouteFallback, fees.BridgeFeeSource)
+
+		// A policy without the fee keeps the global flat fallback
+		fallbackFee = ""
+		fees = calculate()
+		require.Equal(t, "10", fees.BridgeFee)
+		require.Equal(t, BridgeFeeSourceFlatFallback, fees.BridgeFeeSource)
+	})
+
+	t.Run("fee exempt context skips platform fee including min fee", func(t *testing.T) {
+		u := &PaymentUsecase{
+			feeConfigRepo: &feeConfigRepoStub{
+				getByChainAndTokenFn: func(context.Context, uuid.UUID, uuid.UUID) (*entities.FeeConfig, error) {
+					return &entities.FeeConfig{
+						FixedBaseFee:       "1",
+						PlatformFeePercent: "0.1",
+						MinFee:             "3",
+					}, nil
+				},
+			},
+		}
+
+		fees := u.CalculateFees(
+			withPlatformFeeWaived(ctx),
+			big.NewInt(1000), // 10.00 token
+			2,
+			"eip155:8453",
+			"eip155:8453",
+			sourceChainUUID,
+			sourceChainUUID,
+			sourceTokenID,
+			"native",
+			"native",
+			2,
+			0,
+		)
+		require.Equal(t, "0", fees.PlatformFee)
+		require.Equal(t, "0", fees.TotalFee)
+		require.Equal(t, "1000", fees.NetAmount)
+		require.True(t, fees.PlatformFeeWaived)
 	})
 
 	t.Run("max fee clamp is applied", func(t *testing.T) {
@@ -224,7 +315,7 @@ func TestPaymentUsecase_CalculateFees_ConfigAndFallback(t *testing.T) {
 					Type:            entities.ContractTypeRouter,
 				},
 			},
-			clientFactory: factory,
+			clientFactory: NewEVMClientFactory(factory),
 		}
 
 		fees := u.CalculateFees(
@@ -245,5 +336,55 @@ func TestPaymentUsecase_CalculateFees_ConfigAndFallback(t *testing.T) {
 		require.Equal(t, "20", fees.BridgeFee)
 		require.Equal(t, "23", fees.TotalFee)
 		require.Equal(t, "977", fees.NetAmount)
+		require.Equal(t, BridgeFeeSourceQuote, fees.BridgeFeeSource)
 	})
 }
+
+func TestPaymentUsecase_CalculateFees_NetUnitsWithoutSwapQuote(t *testing.T) {
+	ctx := context.Background()
+	chainID := uuid.New()
+	chain := &entities.Chain{ID: chainID, ChainID: "8453", Type: entities.ChainTypeEVM}
+	chainRepo := &quoteChainRepoStub{
+		byCAIP2: map[string]*entities.Chain{"eip155:8453": chain},
+		byID:    map[uuid.UUID]*entities.Chain{chainID: chain},
+	}
+	// No swapper is registered, so the swap quote fails
+	u := &PaymentUsecase{
+		feeConfigRepo: &feeConfigRepoStub{},
+		chainRepo:     chainRepo,
+		chainResolver: NewChainResolver(chainRepo),
+		contractRepo:  &quoteContractRepoStub{},
+	}
+	const usdc, usdt = "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913", "0xfde4c96c8593536e31f229ea8f37b2ada2699bb2"
+
+	// Different tokens that share 6 decimals: the net amount is still USDC, not USDT
+	fees := u.CalculateFees(ctx, big.NewInt(1_000_000), 6, "eip155:8453", "eip155:8453", chainID, chainID, uuid.New(), usdc, usdt, 6, 0)
+	require.False(t, fees.NetInDestUnits)
+	require.Equal(t, NetAmountSourceDirect, fees.NetAmountSource)
+	// so a minimum cannot be judged against it either way
+	require.NoError(t, checkMinAmountOut("999999999", fees))
+
+	// The same token on both sides needs no conversion
+	fees = u.CalculateFees(ctx, big.NewInt(1_000_000), 6, "eip155:8453", "eip155:8453", chainID, chainID, uuid.New(), usdc, "0x"+strings.ToUpper(usdc[2:]), 6, 0)
+	require.True(t, fees.NetInDestUnits)
+}
+
+func TestPaymentUsecase_IsPlatformFeeExempt(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	u := &PaymentUsecase{}
+	require.True(t, u.isPlatformFeeExempt(ctx, userID, &entities.Merchant{ID: uuid.New(), FeeExempt: true}))
+	require.False(t, u.isPlatformFeeExempt(ctx, userID, &entities.Merchant{ID: uuid.New()}))
+	require.False(t, u.isPlatformFeeExempt(ctx, userID, nil))
+
+	u = &PaymentUsecase{userRepo: &authUserRepoStub{
+		getByIDFn: func(context.Context, uuid.UUID) (*entities.User, error) {
+			return &entities.User{ID: userID, FeeExempt: true}, nil
+		},
+	}}
+	require.True(t, u.isPlatformFeeExempt(ctx, userID, nil))
+
+	u = &PaymentUsecase{userRepo: &authUserRepoStub{}}
+	require.False(t, u.isPlatformFeeExempt(ctx, userID, nil))
+}
@@ -3,10 +3,13 @@ package usecases
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/infrastructure/blockchain"
@@ -22,7 +25,15 @@ func (s *createPaymentTokenRepoStub) key(chainID uuid.UUID, addr string) string
 	return chainID.String() + "|" + addr
 }
 
-func (s *createPaymentTokenRepoStub) GetByID(context.Context, uuid.UUID) (*entities.Token, error) {
+func (s *createPaymentTokenRepoStub) GetByID(_ context.Context, id uuid.UUID) (*entities.Token, error) {
+	for _, tok := range s.byAddress {
+		if tok.ID == id {
+			return tok, nil
+		}
+	}
+	if s.native != nil && s.native.ID == id {
+		return s.native, nil
+	}
 	return nil, domainerrors.ErrNotFound
 }
 func (s *createPaymentTokenRepoStub) GetBySymbol(context.Context, string, uuid.UUID) (*entities.Token, error) {
@@ -40,6 +51,9 @@ func (s *createPaymentTokenRepoStub) GetByAddress(_ context.Context, address str
 func (s *createPaymentTokenRepoStub) GetAll(context.Context) ([]*entities.Token, error) {
 	return nil, nil
 }
+func (s *createPaymentTokenRepoStub) GetActiveBySymbol(context.Context, string) ([]*entities.Token, error) {
+	return nil, nil
+}
 func (s *createPaymentTokenRepoStub) GetStablecoins(context.Context) ([]*entities.Token, error) {
 	return nil, nil
 }
@@ -52,31 +66,61 @@ func (s *createPaymentTokenRepoStub) GetNative(context.Context, uuid.UUID) (*ent
 func (s *createPaymentTokenRepoStub) GetTokensByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
-func (s *createPaymentTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, utils.PaginationParams) ([]*entities.Token, int64, error) {
+func (s *createPaymentTokenRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, bool, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
+func (s *createPaymentTokenRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
 func (s *createPaymentTokenRepoStub) Create(context.Context, *entities.Token) error { return nil }
 func (s *createPaymentTokenRepoStub) Update(context.Context, *entities.Token) error { return nil }
 func (s *createPaymentTokenRepoStub) SoftDelete(context.Context, uuid.UUID) error   { return nil }
+func (s *createPaymentTokenRepoStub) HardDelete(context.Context, uuid.UUID) error   { return nil }
 
 type createPaymentRepoStub struct {
 	createErr error
 	created   *entities.Payment
+	byID      map[uuid.UUID]*entities.Payment
 }
 
 func (s *createPaymentRepoStub) Create(_ context.Context, payment *entities.Payment) error {
 	s.created = payment
 	return s.createErr
 }
-func (s *createPaymentRepoStub) GetByID(context.Context, uuid.UUID) (*entities.Payment, error) {
+func (s *createPaymentRepoStub) GetByID(_ context.Context, id uuid.UUID) (*entities.Payment, error) {
+	if payment, ok := s.byID[id]; ok {
+		return payment, nil
+	}
 	return nil, domainerrors.ErrNotFound
 }
-func (s *createPaymentRepoStub) GetByUserID(context.Context, uuid.UUID, int, int) ([]*entities.Payment, int, error) {
+func (s *createPaymentRepoStub) GetByUserID(context.Context, uuid.UUID, entities.PaymentMode, utils.PaginationParams) ([]*entities.Payment, int64, error) {
+	return nil, 0, nil
+}
+func (s *createPaymentRepoStub) GetByMerchantID(context.Context, uuid.UUID, entities.PaymentMode, utils.PaginationParams) ([]*entities.Payment, int64, error) {
 	return nil, 0, nil
 }
-func (s *createPaymentRepoStub) GetByMerchantID(context.Context, uuid.UUID, int, int) ([]*entities.Payment, int, error) {
+func (s *createPaymentRepoStub) GetByMerchantExternalRef(context.Context, uuid.UUID, entities.PaymentMode, string, utils.PaginationParams) ([]*entities.Payment, int64, error) {
 	return nil, 0, nil
 }
+func (s *createPaymentRepoStub) GetByStatus(_ context.Context, status entities.PaymentStatus, _, _ int) ([]*entities.Payment, int, error) {
+	var payments []*entities.Payment
+	for _, payment := range s.byID {
+		if payment.Status == status {
+			payments = append(payments, payment)
+		}
+	}
+	return payments, len(payments), nil
+}
+func (s *createPaymentRepoStub) ResolvePendingApproval(_ context.Context, id uuid.UUID, status entities.PaymentStatus, failureReason null.String, expiresAt *time.Time) error {
+	payment, ok := s.byID[id]
+	if !ok || payment.Status != entities.PaymentStatusPendingApproval {
+		return domainerrors.ErrNotFound
+	}
+	payment.Status = status
+	payment.FailureReason = failureReason
+	payment.ExpiresAt = expiresAt
+	return nil
+}
 func (s *createPaymentRepoStub) UpdateStatus(context.Context, uuid.UUID, entities.PaymentStatus) error {
 	return nil
 }
@@ -145,8 +189,8 @@ func TestPaymentUsecase_CreatePayment_ValidationAndResolutionErrors(t *testing.T
 func TestPaymentUsecase_CreatePayment_TokenAndAmountErrors(t *testing.T) {
 	sourceID := uuid.New()
 	destID := uuid.New()
-	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM}
-	dest := &entities.Chain{ID: destID, ChainID: "42161", Type: entities.ChainTypeEVM}
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	dest := &entities.Chain{ID: destID, ChainID: "42161", Type: entities.ChainTypeEVM, IsActive: true}
 	chainRepo := &quoteChainRepoStub{
 		byID: map[uuid.UUID]*entities.Chain{sourceID: source, destID: dest},
 		byCAIP2: map[string]*entities.Chain{
@@ -168,13 +212,13 @@ func TestPaymentUsecase_CreatePayment_TokenAndAmountErrors(t *testing.T) {
 		DestChainID:        "eip155:42161",
 		SourceTokenAddress: "0xsource",
 		DestTokenAddress:   "0xdest",
-		ReceiverAddress:    "0xreceiver",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 		Amount:             "1",
 	})
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "source token not found")
 
-	srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID}
+	srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID, IsActive: true}
 	tokenRepo := &createPaymentTokenRepoStub{
 		byAddress: map[string]*entities.Token{
 			sourceID.String() + "|0xsource": srcTok,
@@ -186,13 +230,13 @@ func TestPaymentUsecase_CreatePayment_TokenAndAmountErrors(t *testing.T) {
 		DestChainID:        "eip155:42161",
 		SourceTokenAddress: "0xsource",
 		DestTokenAddress:   "0xdest",
-		ReceiverAddress:    "0xreceiver",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 		Amount:             "1",
 	})
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "dest token not found")
 
-	dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: destID}
+	dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: destID, IsActive: true}
 	tokenRepo.byAddress[destID.String()+"|0xdest"] = dstTok
 
 	_, err = u.CreatePayment(context.Background(), uuid.New(), &entities.CreatePaymentInput{
@@ -200,7 +244,7 @@ func TestPaymentUsecase_CreatePayment_TokenAndAmountErrors(t *testing.T) {
 		DestChainID:        "eip155:42161",
 		SourceTokenAddress: "0xsource",
 		DestTokenAddress:   "0xdest",
-		ReceiverAddress:    "0xreceiver",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 		Amount:             "1",
 		Decimals:           18,
 	})
@@ -212,24 +256,94 @@ func TestPaymentUsecase_CreatePayment_TokenAndAmountErrors(t *testing.T) {
 		DestChainID:        "eip155:42161",
 		SourceTokenAddress: "0xsource",
 		DestTokenAddress:   "0xdest",
-		ReceiverAddress:    "0xreceiver",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 		Amount:             "invalid-number",
 		Decimals:           6,
 	})
 	require.ErrorIs(t, err, domainerrors.ErrBadRequest)
 }
 
+// gatewayContractRepoStub has an active gateway and vault on every chain, which CreatePayment
+// needs before it will create a payment
+func gatewayContractRepoStub() *scRepoStub {
+	return &scRepoStub{getActiveFn: func(_ context.Context, _ uuid.UUID, typ entities.SmartContractType) (*entities.SmartContract, error) {
+		switch typ {
+		case entities.ContractTypeGateway:
+			return &entities.SmartContract{ContractAddress: "0x1111111111111111111111111111111111111111", Type: typ}, nil
+		case entities.ContractTypeVault:
+			return &entities.SmartContract{ContractAddress: "0x2222222222222222222222222222222222222222", Type: typ}, nil
+		}
+		return nil, domainerrors.ErrNotFound
+	}}
+}
+
+func TestPaymentUsecase_CreatePayment_RequiresGateway(t *testing.T) {
+	sourceID := uuid.New()
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{sourceID: source},
+		byCAIP2: map[string]*entities.Chain{"eip155:8453": source},
+	}
+	tokenRepo := &createPaymentTokenRepoStub{
+		byAddress: map[string]*entities.Token{
+			sourceID.String() + "|0xsource": {ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID, IsActive: true},
+			sourceID.String() + "|0xdest":   {ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: sourceID, IsActive: true},
+		},
+	}
+	gatewayErr := domainerrors.ErrNotFound
+	paymentRepo := &createPaymentRepoStub{}
+	u := &PaymentUsecase{
+		paymentRepo:      paymentRepo,
+		paymentEventRepo: &createPaymentEventRepoStub{},
+		chainRepo:        chainRepo,
+		chainResolver:    NewChainResolver(chainRepo),
+		tokenRepo:        tokenRepo,
+		contractRepo: &scRepoStub{getActiveFn: func(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
+			return nil, gatewayErr
+		}},
+		uow: &createPaymentUOWStub{},
+	}
+	input := &entities.CreatePaymentInput{
+		SourceChainID:      "eip155:8453",
+		DestChainID:        "eip155:8453",
+		SourceTokenAddress: "0xsource",
+		DestTokenAddress:   "0xdest",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
+		Amount:             "1",
+		Decimals:           6,
+	}
+
+	// No unsignable payment is created, and the preview says why too
+	_, err := u.CreatePayment(context.Background(), uuid.New(), input)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Equal(t, domainerrors.CodeGatewayNotConfigured, appErr.Code)
+	require.Contains(t, appErr.Message, "eip155:8453")
+	require.Nil(t, paymentRepo.created)
+	_, err = u.BuildPaymentCalldata(context.Background(), uuid.New(), input, nil)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeGatewayNotConfigured, appErr.Code)
+
+	// A failed lookup is not mistaken for a missing gateway
+	gatewayErr = errors.New("connection reset")
+	_, err = u.CreatePayment(context.Background(), uuid.New(), input)
+	require.ErrorContains(t, err, "connection reset")
+	require.False(t, errors.As(err, &appErr))
+	require.Nil(t, paymentRepo.created)
+}
+
 func TestPaymentUsecase_CreatePayment_UOWAndEventBranches(t *testing.T) {
 	sourceID := uuid.New()
-	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM}
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
 	chainRepo := &quoteChainRepoStub{
 		byID: map[uuid.UUID]*entities.Chain{sourceID: source},
 		byCAIP2: map[string]*entities.Chain{
 			"eip155:8453": source,
 		},
 	}
-	srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID}
-	dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: sourceID}
+	srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID, IsActive: true}
+	dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: sourceID, IsActive: true}
 	tokenRepo := &createPaymentTokenRepoStub{
 		byAddress: map[string]*entities.Token{
 			sourceID.String() + "|0xsource": srcTok,
@@ -246,10 +360,8 @@ func TestPaymentUsecase_CreatePayment_UOWAndEventBranches(t *testing.T) {
 		chainRepo:        chainRepo,
 		chainResolver:    NewChainResolver(chainRepo),
 		tokenRepo:        tokenRepo,
-		contractRepo: &scRepoStub{getActiveFn: func(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
-			return nil, domainerrors.ErrNotFound
-		}},
-		uow: uow,
+		contractRepo:     gatewayContractRepoStub(),
+		uow:              uow,
 	}
 
 	req := &entities.CreatePaymentInput{
@@ -257,7 +369,7 @@ func TestPaymentUsecase_CreatePayment_UOWAndEventBranches(t *testing.T) {
 		DestChainID:        "eip155:8453",
 		SourceTokenAddress: "0xsource",
 		DestTokenAddress:   "0xdest",
-		ReceiverAddress:    "0xreceiver",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 		Amount:             "1",
 		Decimals:           6,
 	}
@@ -274,6 +386,105 @@ func TestPaymentUsecase_CreatePayment_UOWAndEventBranches(t *testing.T) {
 	require.NotNil(t, eventRepo.created)
 }
 
+func TestPaymentUsecase_CreatePayment_ClientPaymentID(t *testing.T) {
+	sourceID := uuid.New()
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{sourceID: source},
+		byCAIP2: map[string]*entities.Chain{"eip155:8453": source},
+	}
+	tokenRepo := &createPaymentTokenRepoStub{
+		byAddress: map[string]*entities.Token{
+			sourceID.String() + "|0xsource": {ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID, IsActive: true},
+			sourceID.String() + "|0xdest":   {ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: sourceID, IsActive: true},
+		},
+	}
+	paymentRepo := &createPaymentRepoStub{byID: map[uuid.UUID]*entities.Payment{}}
+	eventRepo := &createPaymentEventRepoStub{}
+	u := &PaymentUsecase{
+		paymentRepo:      paymentRepo,
+		paymentEventRepo: eventRepo,
+		chainRepo:        chainRepo,
+		chainResolver:    NewChainResolver(chainRepo),
+		tokenRepo:        tokenRepo,
+		contractRepo:     gatewayContractRepoStub(),
+		uow:              &createPaymentUOWStub{},
+	}
+
+	userID := uuid.New()
+	paymentID := utils.GenerateUUIDv7()
+	req := &entities.CreatePaymentInput{
+		SourceChainID:      "eip155:8453",
+		DestChainID:        "eip155:8453",
+		SourceTokenAddress: "0xsource",
+		DestTokenAddress:   "0xdest",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
+		Amount:             "1",
+		Decimals:           6,
+		PaymentID:          &paymentID,
+	}
+
+	resp, err := u.CreatePayment(context.Background(), userID, req)
+	require.NoError(t, err)
+	require.Equal(t, paymentID, resp.PaymentID)
+	require.False(t, resp.Replayed)
+	require.Equal(t, paymentID, paymentRepo.created.ID)
+	paymentRepo.byID[paymentID] = paymentRepo.created
+	recordedEvents := len(eventRepo.events)
+
+	// A retry by the same caller returns the stored payment without writing anything
+	paymentRepo.created = nil
+	resp, err = u.CreatePayment(context.Background(), userID, req)
+	require.NoError(t, err)
+	require.True(t, resp.Replayed)
+	require.Equal(t, paymentID, resp.PaymentID)
+	require.Nil(t, paymentRepo.created)
+	require.Len(t, eventRepo.events, recordedEvents)
+	// The response restates the stored payment
+	stored := paymentRepo.byID[paymentID]
+	require.Equal(t, "eip155:8453", resp.SourceChainID)
+	require.Equal(t, 6, resp.SourceDecimals)
+	require.Equal(t, stored.FeeAmount, resp.FeeBreakdown.TotalFee)
+	require.Equal(t, stored.DestAmount.String, resp.FeeBreakdown.NetAmount)
+
+	// A retry still replays after the chain was deactivated, which would refuse a new payment
+	source.IsActive = false
+	resp, err = u.CreatePayment(context.Background(), userID, req)
+	require.NoError(t, err)
+	require.True(t, resp.Replayed)
+	require.Nil(t, paymentRepo.created)
+	source.IsActive = true
+
+	// A retry that describes another payment is refused rather than answered with this one
+	var appErr *domainerrors.AppError
+	for name, change := range map[string]func(in *entities.CreatePaymentInput){
+		"amount": func(in *entities.CreatePaymentInput) { in.Amount = "2" },
+		"receiver": func(in *entities.CreatePaymentInput) {
+			in.ReceiverAddress = "0x000000000000000000000000000000000000bEEF"
+		},
+		"token": func(in *entities.CreatePaymentInput) { in.DestTokenAddress = "0xsource" },
+	} {
+		changed := *req
+		change(&changed)
+		_, err = u.CreatePayment(context.Background(), userID, &changed)
+		require.ErrorAs(t, err, &appErr, name)
+		require.Equal(t, http.StatusConflict, appErr.Status, name)
+	}
+	require.Nil(t, paymentRepo.created)
+
+	// Someone else's payment ID is refused
+	_, err = u.CreatePayment(context.Background(), uuid.New(), req)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusConflict, appErr.Status)
+
+	// Only UUIDv7 is accepted
+	v4 := uuid.New()
+	req.PaymentID = &v4
+	_, err = u.CreatePayment(context.Background(), userID, req)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+}
+
 func TestBuildPaymentQuoteSnapshotMetadata_CombinesPreviewAndQuote(t *testing.T) {
 	signatureData := map[string]interface{}{
 		"value": "0x1234",
@@ -315,8 +526,8 @@ func TestBuildPaymentQuoteSnapshotMetadata_CombinesPreviewAndQuote(t *testing.T)
 func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testing.T) {
 	sourceID := uuid.New()
 	destID := uuid.New()
-	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM}
-	dest := &entities.Chain{ID: destID, ChainID: "42161", Type: entities.ChainTypeEVM}
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	dest := &entities.Chain{ID: destID, ChainID: "42161", Type: entities.ChainTypeEVM, IsActive: true}
 	userID := uuid.New()
 
 	t.Run("invalid dest chain resolve", func(t *testing.T) {
@@ -330,7 +541,7 @@ func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testi
 		_, err := u.CreatePayment(context.Background(), userID, &entities.CreatePaymentInput{
 			SourceChainID:      "eip155:8453",
 			DestChainID:        "eip155:42161",
-			ReceiverAddress:    "0xreceiver",
+			ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 			SourceTokenAddress: "0xsource",
 			DestTokenAddress:   "0xdest",
 			Amount:             "1",
@@ -353,7 +564,7 @@ func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testi
 		_, err := u.CreatePayment(context.Background(), userID, &entities.CreatePaymentInput{
 			SourceChainID:      "eip155:8453",
 			DestChainID:        "eip155:42161",
-			ReceiverAddress:    "0xreceiver",
+			ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 			SourceTokenAddress: "0xsource",
 			DestTokenAddress:   "0xdest",
 			Amount:             "1",
@@ -376,7 +587,7 @@ func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testi
 		_, err := u.CreatePayment(context.Background(), userID, &entities.CreatePaymentInput{
 			SourceChainID:      "eip155:8453",
 			DestChainID:        "eip155:42161",
-			ReceiverAddress:    "0xreceiver",
+			ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 			SourceTokenAddress: "0xsource",
 			DestTokenAddress:   "0xdest",
 			Amount:             "1",
@@ -386,8 +597,8 @@ func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testi
 	})
 
 	t.Run("payment repo create error inside uow", func(t *testing.T) {
-		srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID}
-		dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: sourceID}
+		srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID, IsActive: true}
+		dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: sourceID, IsActive: true}
 		chainRepo := &quoteChainRepoStub{
 			byID: map[uuid.UUID]*entities.Chain{
 				sourceID: source,
@@ -409,17 +620,15 @@ func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testi
 			chainRepo:        chainRepo,
 			chainResolver:    NewChainResolver(chainRepo),
 			tokenRepo:        tokenRepo,
-			contractRepo: &scRepoStub{getActiveFn: func(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
-				return nil, domainerrors.ErrNotFound
-			}},
-			uow: &createPaymentUOWStub{},
+			contractRepo:     gatewayContractRepoStub(),
+			uow:              &createPaymentUOWStub{},
 		}
 		_, err := u.CreatePayment(context.Background(), userID, &entities.CreatePaymentInput{
 			SourceChainID:      "eip155:8453",
 			DestChainID:        "eip155:8453",
 			SourceTokenAddress: "0xsource",
 			DestTokenAddress:   "0xdest",
-			ReceiverAddress:    "0xreceiver",
+			ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 			Amount:             "1",
 			Decimals:           6,
 		})
@@ -428,8 +637,8 @@ func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testi
 	})
 
 	t.Run("build transaction data error after persistence", func(t *testing.T) {
-		srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID}
-		dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: destID}
+		srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID, IsActive: true}
+		dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: destID, IsActive: true}
 		chainRepo := &quoteChainRepoStub{
 			byID: map[uuid.UUID]*entities.Chain{
 				sourceID: source,
@@ -458,7 +667,7 @@ func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testi
 				}
 				return nil, errors.New("router missing")
 			}},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 			uow:           &createPaymentUOWStub{},
 		}
 		_, err := u.CreatePayment(context.Background(), userID, &entities.CreatePaymentInput{
@@ -466,7 +675,7 @@ func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testi
 			DestChainID:        "eip155:42161",
 			SourceTokenAddress: "0xsource",
 			DestTokenAddress:   "0xdest",
-			ReceiverAddress:    "0xreceiver",
+			ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 			Amount:             "1",
 			Decimals:           6,
 		})
@@ -476,3 +685,321 @@ func TestPaymentUsecase_CreatePayment_ChainLookupAndPersistenceBranches(t *testi
 		require.Contains(t, appErr.Message, "failed to resolve bridge fee quote")
 	})
 }
+
+func TestPaymentUsecase_CreatePayment_RejectsReceiverForWrongChainType(t *testing.T) {
+	sourceID := uuid.New()
+	destID := uuid.New()
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	dest := &entities.Chain{ID: destID, ChainID: "devnet", Type: entities.ChainTypeSVM, IsActive: true}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{sourceID: source, destID: dest},
+		byCAIP2: map[string]*entities.Chain{"eip155:8453": source, "solana:devnet": dest},
+	}
+	paymentRepo := &createPaymentRepoStub{}
+	u := &PaymentUsecase{paymentRepo: paymentRepo, chainRepo: chainRepo, chainResolver: NewChainResolver(chainRepo)}
+
+	_, err := u.CreatePayment(context.Background(), uuid.New(), &entities.CreatePaymentInput{
+		SourceChainID:      "eip155:8453",
+		DestChainID:        "solana:devnet",
+		SourceTokenAddress: "0x1",
+		DestTokenAddress:   "So11111111111111111111111111111111111111112",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
+		Amount:             "1",
+		Decimals:           6,
+	})
+	require.ErrorIs(t, err, domainerrors.ErrInvalidReceiverForChain)
+	require.Nil(t, paymentRepo.created)
+}
+
+func TestPaymentUsecase_CreatePayment_RejectsDeactivatedTokenAndChain(t *testing.T) {
+	sourceID := uuid.New()
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{sourceID: source},
+		byCAIP2: map[string]*entities.Chain{"eip155:8453": source},
+	}
+	srcTok := &entities.Token{ID: uuid.New(), Symbol: "USDC", Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID}
+	dstTok := &entities.Token{ID: uuid.New(), Symbol: "IDRX", Decimals: 6, ContractAddress: "0xdest", ChainUUID: sourceID, IsActive: true}
+	tokenRepo := &createPaymentTokenRepoStub{
+		byAddress: map[string]*entities.Token{
+			sourceID.String() + "|0xsource": srcTok,
+			sourceID.String() + "|0xdest":   dstTok,
+		},
+	}
+	paymentRepo := &createPaymentRepoStub{}
+	u := &PaymentUsecase{paymentRepo: paymentRepo, chainRepo: chainRepo, chainResolver: NewChainResolver(chainRepo), tokenRepo: tokenRepo}
+	input := &entities.CreatePaymentInput{
+		SourceChainID:      "eip155:8453",
+		DestChainID:        "eip155:8453",
+		SourceTokenAddress: "0xsource",
+		DestTokenAddress:   "0xdest",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
+		Amount:             "1",
+		Decimals:           6,
+	}
+
+	_, err := u.CreatePayment(context.Background(), uuid.New(), input)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Equal(t, domainerrors.CodeInactive, appErr.Code)
+	require.Contains(t, appErr.Message, "USDC")
+
+	source.IsActive = false
+	srcTok.IsActive = true
+	_, err = u.CreatePayment(context.Background(), uuid.New(), input)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeInactive, appErr.Code)
+	require.Contains(t, appErr.Message, "eip155:8453")
+	require.Nil(t, paymentRepo.created)
+}
+
+func TestPaymentUsecase_CreatePayment_KeyModeMatchesChainNetwork(t *testing.T) {
+	sourceID := uuid.New()
+	source := &entities.Chain{ID: sourceID, ChainID: "84532", Type: entities.ChainTypeEVM, IsActive: true}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{sourceID: source},
+		byCAIP2: map[string]*entities.Chain{"eip155:84532": source},
+	}
+	srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID, IsActive: true}
+	dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: sourceID, IsActive: true}
+	tokenRepo := &createPaymentTokenRepoStub{
+		byAddress: map[string]*entities.Token{
+			sourceID.String() + "|0xsource": srcTok,
+			sourceID.String() + "|0xdest":   dstTok,
+		},
+	}
+	paymentRepo := &createPaymentRepoStub{}
+	u := &PaymentUsecase{
+		paymentRepo:      paymentRepo,
+		paymentEventRepo: &createPaymentEventRepoStub{},
+		chainRepo:        chainRepo,
+		chainResolver:    NewChainResolver(chainRepo),
+		tokenRepo:        tokenRepo,
+		contractRepo:     gatewayContractRepoStub(),
+		uow:              &createPaymentUOWStub{},
+	}
+	input := &entities.CreatePaymentInput{
+		SourceChainID:      "eip155:84532",
+		DestChainID:        "eip155:84532",
+		SourceTokenAddress: "0xsource",
+		DestTokenAddress:   "0xdest",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
+		Amount:             "1",
+		Decimals:           6,
+	}
+	testCtx := WithPaymentMode(context.Background(), entities.PaymentModeTest)
+
+	_, err := u.CreatePayment(testCtx, uuid.New(), input)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Equal(t, domainerrors.CodeChainModeMismatch, appErr.Code)
+	require.Contains(t, appErr.Message, "eip155:84532")
+	require.Nil(t, paymentRepo.created)
+
+	source.IsTestnet = true
+	_, err = u.CreatePayment(testCtx, uuid.New(), input)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentModeTest, paymentRepo.created.Mode)
+
+	// A live key cannot pay on the testnet
+	paymentRepo.created = nil
+	_, err = u.CreatePayment(WithPaymentMode(context.Background(), entities.PaymentModeLive), uuid.New(), input)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeChainModeMismatch, appErr.Code)
+	require.Contains(t, appErr.Message, "live-mode")
+	require.Nil(t, paymentRepo.created)
+
+	// Requests without a mode are live, and kept off testnets like a live key
+	_, err = u.CreatePayment(context.Background(), uuid.New(), input)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeChainModeMismatch, appErr.Code)
+	require.Nil(t, paymentRepo.created)
+
+	source.IsTestnet = false
+	_, err = u.CreatePayment(context.Background(), uuid.New(), input)
+	require.NoError(t, err)
+	require.Equal(t, entities.PaymentModeLive, paymentRepo.created.Mode)
+}
+
+func TestPaymentUsecase_CreatePayment_MerchantKeyRespectsAllowlist(t *testing.T) {
+	sourceID := uuid.New()
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{sourceID: source},
+		byCAIP2: map[string]*entities.Chain{"eip155:8453": source},
+	}
+	srcTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: sourceID, IsActive: true}
+	dstTok := &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xdest", ChainUUID: sourceID, IsActive: true}
+	tokenRepo := &createPaymentTokenRepoStub{
+		byAddress: map[string]*entities.Token{
+			sourceID.String() + "|0xsource": srcTok,
+			sourceID.String() + "|0xdest":   dstTok,
+		},
+	}
+	ownerID := uuid.New()
+	merchant := &entities.Merchant{ID: uuid.New(), UserID: ownerID, Status: entities.MerchantStatusActive}
+	const treasury = "0x000000000000000000000000000000000000cafe"
+	paymentRepo := &createPaymentRepoStub{}
+	u := &PaymentUsecase{
+		paymentRepo:       paymentRepo,
+		paymentEventRepo:  &createPaymentEventRepoStub{},
+		chainRepo:         chainRepo,
+		chainResolver:     NewChainResolver(chainRepo),
+		tokenRepo:         tokenRepo,
+		contractRepo:      gatewayContractRepoStub(),
+		uow:               &createPaymentUOWStub{},
+		merchantRepo:      &attributionMerchantRepoStub{byUser: map[uuid.UUID]*entities.Merchant{ownerID: merchant}},
+		receiverAllowlist: &allowedReceiverRepoStub{items: []*entities.MerchantAllowedReceiver{{MerchantID: merchant.ID, Address: treasury}}},
+	}
+	input := &entities.CreatePaymentInput{
+		SourceChainID:      "eip155:8453",
+		DestChainID:        "eip155:8453",
+		SourceTokenAddress: "0xsource",
+		DestTokenAddress:   "0xdest",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
+		Amount:             "1",
+		Decimals:           6,
+	}
+
+	// A merchant API key paying out to an address off its allow-list is refused, even though the
+	// payment would not be attributed to the merchant
+	keyCtx := WithMerchantScope(context.Background(), merchant.ID)
+	_, err := u.CreatePayment(keyCtx, ownerID, input)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusForbidden, appErr.Status)
+	require.Equal(t, domainerrors.CodeReceiverNotAllowed, appErr.Code)
+	require.Nil(t, paymentRepo.created)
+
+	// So is the merchant owner's dashboard session
+	_, err = u.CreatePayment(context.Background(), ownerID, input)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeReceiverNotAllowed, appErr.Code)
+
+	input.ReceiverAddress = treasury
+	_, err = u.CreatePayment(keyCtx, ownerID, input)
+	require.NoError(t, err)
+	require.NotNil(t, paymentRepo.created)
+
+	// Users without a merchant are not bound by anyone's list
+	paymentRepo.created = nil
+	input.ReceiverAddress = "0x000000000000000000000000000000000000dEaD"
+	_, err = u.CreatePayment(context.Background(), uuid.New(), input)
+	require.NoError(t, err)
+	require.NotNil(t, paymentRepo.created)
+}
+
+func TestPaymentUsecase_CreatePayment_SlippageBounds(t *testing.T) {
+	require.NoError(t, validateSlippageBps(0))
+	require.NoError(t, validateSlippageBps(MaxSlippageBps))
+
+	for _, slippage := range []int{MaxSlippageBps + 1, 10001, -1} {
+		var appErr *domainerrors.AppError
+		require.ErrorAs(t, validateSlippageBps(slippage), &appErr)
+		require.Equal(t, http.StatusBadRequest, appErr.Status)
+	}
+
+	// Rejected before any chain lookup
+	_, err := (&PaymentUsecase{}).CreatePayment(context.Background(), uuid.New(), &entities.CreatePaymentInput{
+		SourceChainID:   "eip155:8453",
+		DestChainID:     "eip155:42161",
+		ReceiverAddress: "0x000000000000000000000000000000000000dEaD",
+		SlippageBps:     MaxSlippageBps + 1,
+	})
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, "slippageBps must be between 0 and 5000", appErr.Message)
+}
+
+func TestCheckMinAmountOut(t *testing.T) {
+	quoted := &entities.FeeBreakdown{NetAmount: "950000", NetInDestUnits: true}
+	require.NoError(t, checkMinAmountOut("950000", quoted))
+	require.NoError(t, checkMinAmountOut("900000", quoted))
+
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, checkMinAmountOut("950001", quoted), &appErr)
+	require.Equal(t, domainerrors.ErrSlippageUnsatisfiable, appErr.Err)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Equal(t, domainerrors.CodeSlippageUnsatisfiable, appErr.Code)
+	require.Contains(t, appErr.Message, "quoted amount 950000")
+
+	// A net amount still in source units cannot be compared
+	require.NoError(t, checkMinAmountOut("950001", &entities.FeeBreakdown{NetAmount: "950000"}))
+
+	for _, invalid := range []string{"abc", "-1", "1.5"} {
+		require.ErrorAs(t, checkMinAmountOut(invalid, quoted), &appErr)
+		require.Equal(t, http.StatusBadRequest, appErr.Status)
+	}
+}
+
+func TestPaymentUsecase_CreatePayment_FeeWaiverOnlyWithoutGateway(t *testing.T) {
+	evmID, svmID, cosmosID := uuid.New(), uuid.New(), uuid.New()
+	evm := &entities.Chain{ID: evmID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	svm := &entities.Chain{ID: svmID, ChainID: "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp", Type: entities.ChainTypeSVM, IsActive: true}
+	cosmos := &entities.Chain{ID: cosmosID, ChainID: "cosmoshub-4", Type: entities.ChainTypeCosmos, IsActive: true}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{evmID: evm, svmID: svm, cosmosID: cosmos},
+		byCAIP2: map[string]*entities.Chain{evm.GetCAIP2ID(): evm, svm.GetCAIP2ID(): svm, cosmos.GetCAIP2ID(): cosmos},
+	}
+	tokenRepo := &createPaymentTokenRepoStub{byAddress: map[string]*entities.Token{}}
+	for _, chainID := range []uuid.UUID{evmID, svmID, cosmosID} {
+		tokenRepo.byAddress[chainID.String()+"|0xsource"] = &entities.Token{ID: uuid.New(), Decimals: 6, ContractAddress: "0xsource", ChainUUID: chainID, IsActive: true}
+	}
+	ownerID := uuid.New()
+	exempt := &entities.Merchant{ID: uuid.New(), UserID: ownerID, Status: entities.MerchantStatusActive, FeeExempt: true}
+	paymentRepo := &createPaymentRepoStub{}
+	u := &PaymentUsecase{
+		paymentRepo:      paymentRepo,
+		paymentEventRepo: &createPaymentEventRepoStub{},
+		chainRepo:        chainRepo,
+		chainResolver:    NewChainResolver(chainRepo),
+		tokenRepo:        tokenRepo,
+		contractRepo: &scRepoStub{getActiveFn: func(ctx context.Context, chainID uuid.UUID, typ entities.SmartContractType) (*entities.SmartContract, error) {
+			if chainID == cosmosID {
+				return nil, domainerrors.ErrNotFound
+			}
+			return gatewayContractRepoStub().getActiveFn(ctx, chainID, typ)
+		}},
+		uow:          &createPaymentUOWStub{},
+		merchantRepo: &attributionMerchantRepoStub{byUser: map[uuid.UUID]*entities.Merchant{ownerID: exempt}},
+	}
+	input := func(caip2, receiver string) *entities.CreatePaymentInput {
+		return &entities.CreatePaymentInput{
+			SourceChainID:      caip2,
+			DestChainID:        caip2,
+			SourceTokenAddress: "0xsource",
+			DestTokenAddress:   "0xsource",
+			ReceiverAddress:    receiver,
+			Amount:             "100",
+			Decimals:           6,
+		}
+	}
+
+	// The gateway charges the fee regardless, so an exempt merchant is shown and approves it
+	resp, err := u.CreatePayment(context.Background(), ownerID, input(evm.GetCAIP2ID(), "0x000000000000000000000000000000000000dEaD"))
+	require.NoError(t, err)
+	require.False(t, resp.FeeBreakdown.PlatformFeeWaived)
+	require.NotEqual(t, "0", resp.FeeBreakdown.PlatformFee)
+
+	// The same goes for SVM, which is never paid without its gateway either
+	resp, err = u.CreatePayment(context.Background(), ownerID, input(svm.GetCAIP2ID(), "9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin"))
+	require.NoError(t, err)
+	require.False(t, resp.FeeBreakdown.PlatformFeeWaived)
+
+	// Without a gateway nothing charges it on-chain, so it is waived
+	resp, err = u.CreatePayment(context.Background(), ownerID, input(cosmos.GetCAIP2ID(), "cosmos1receiver"))
+	require.NoError(t, err)
+	require.True(t, resp.FeeBreakdown.PlatformFeeWaived)
+	require.Equal(t, "0", resp.FeeBreakdown.PlatformFee)
+
+	// Naming an exempt merchant that does not own the receiver cannot borrow its waiver
+	other := uuid.New()
+	naming := input(cosmos.GetCAIP2ID(), "cosmos1receiver")
+	naming.ReceiverMerchantID = exempt.ID.String()
+	_, err = u.CreatePayment(context.Background(), other, naming)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+}
@@ -41,7 +41,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_MoreErrorBranches(t *test
 		u := &PaymentUsecase{
 			contractRepo:     scRepo,
 			chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
-			clientFactory:    blockchain.NewClientFactory(),
+			clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
 			ABIResolverMixin: NewABIResolverMixin(scRepo),
 		}
 		_, err := u.CalculateOnchainApprovalAmount(&entities.Payment{
@@ -72,7 +72,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_MoreErrorBranches(t *test
 		u := &PaymentUsecase{
 			contractRepo:     scRepo,
 			chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
-			clientFactory:    blockchain.NewClientFactory(),
+			clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
 			ABIResolverMixin: NewABIResolverMixin(scRepo),
 		}
 		_, err := u.CalculateOnchainApprovalAmount(&entities.Payment{
@@ -125,7 +125,7 @@ func TestPaymentUsecase_GetBridgeFeeQuote_FirstConnectedRPCCallError(t *testing.
 		chainRepo:        chainRepo,
 		chainResolver:    NewChainResolver(chainRepo),
 		contractRepo:     scRepo,
-		clientFactory:    factory,
+		clientFactory:    NewEVMClientFactory(factory),
 		routePolicyRepo:  nil,
 		ABIResolverMixin: NewABIResolverMixin(scRepo),
 	}
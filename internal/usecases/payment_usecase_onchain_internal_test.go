@@ -44,9 +44,13 @@ func (s *approvalChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool, *
 func (s *approvalChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *approvalChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *approvalChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *approvalChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *approvalChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *approvalChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *approvalChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *approvalChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *approvalChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -83,11 +87,15 @@ func newPaymentRPCServer(t *testing.T, callHandler func(callIndex int, data stri
 			res.Result = "0x2105"
 		case "eth_call":
 			var payload struct {
-				Data string `json:"data"`
+				Data  string `json:"data"`
+				Input string `json:"input"`
 			}
 			if len(req.Params) > 0 {
 				_ = json.Unmarshal(req.Params[0], &payload)
 			}
+			if payload.Data == "" {
+				payload.Data = payload.Input
+			}
 			callMu.Lock()
 			callIx++
 			idx := callIx
@@ -130,7 +138,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_QuoteSuccess(t *testing.T
 	u := &PaymentUsecase{
 		contractRepo:     scRepo,
 		chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
-		clientFactory:    blockchain.NewClientFactory(),
+		clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
 		ABIResolverMixin: NewABIResolverMixin(scRepo),
 	}
 
@@ -162,7 +170,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_FallbackFeePath(t *testin
 	u := &PaymentUsecase{
 		contractRepo:     scRepo,
 		chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
-		clientFactory:    blockchain.NewClientFactory(),
+		clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
 		ABIResolverMixin: NewABIResolverMixin(scRepo),
 	}
 
@@ -191,7 +199,7 @@ func TestPaymentUsecase_ResolveVaultAddressForApproval_FromGatewayView(t *testin
 	u := &PaymentUsecase{
 		contractRepo:     scRepo,
 		chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
-		clientFactory:    blockchain.NewClientFactory(),
+		clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
 		ABIResolverMixin: NewABIResolverMixin(scRepo),
 	}
 
@@ -206,7 +214,7 @@ func TestPaymentUsecase_ResolveVaultAddressForApproval_FallbackBranches(t *testi
 				return nil, domainerrors.ErrNotFound
 			}},
 			chainRepo:     &approvalChainRepoStub{chain: nil},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		}
 		got := u.ResolveVaultAddressForApproval(uuid.New(), "0x1111111111111111111111111111111111111111")
 		require.Equal(t, "", got)
@@ -223,7 +231,7 @@ func TestPaymentUsecase_ResolveVaultAddressForApproval_FallbackBranches(t *testi
 				RPCURL: "",
 				RPCs:   []entities.ChainRPC{{URL: "://bad", IsActive: true}},
 			}},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		}
 		got := u.ResolveVaultAddressForApproval(chainID, "0x1111111111111111111111111111111111111111")
 		require.Equal(t, "", got)
@@ -242,7 +250,7 @@ func TestPaymentUsecase_ResolveVaultAddressForApproval_FallbackBranches(t *testi
 				ID:     chainID,
 				RPCURL: srv.URL,
 			}},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		}
 		got := u.ResolveVaultAddressForApproval(chainID, "0x1111111111111111111111111111111111111111")
 		require.Equal(t, "", got)
@@ -269,7 +277,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_ErrorBranches(t *testing.
 	t.Run("source chain missing", func(t *testing.T) {
 		u := &PaymentUsecase{
 			chainRepo:     &approvalChainRepoStub{chain: nil},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		}
 		_, err := u.CalculateOnchainApprovalAmount(&entities.Payment{
 			SourceChainID: uuid.New(),
@@ -287,7 +295,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_ErrorBranches(t *testing.
 				ID:     chainID,
 				RPCURL: "",
 			}},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		}
 		_, err := u.CalculateOnchainApprovalAmount(&entities.Payment{
 			SourceChainID: chainID,
@@ -318,7 +326,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_ErrorBranches(t *testing.
 		u := &PaymentUsecase{
 			contractRepo:     scRepo,
 			chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
-			clientFactory:    blockchain.NewClientFactory(),
+			clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
 			ABIResolverMixin: NewABIResolverMixin(scRepo),
 		}
 		_, err := u.CalculateOnchainApprovalAmount(&entities.Payment{
@@ -352,7 +360,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_ErrorBranches(t *testing.
 		u := &PaymentUsecase{
 			contractRepo:     scRepo,
 			chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
-			clientFactory:    blockchain.NewClientFactory(),
+			clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
 			ABIResolverMixin: NewABIResolverMixin(scRepo),
 		}
 		_, err := u.CalculateOnchainApprovalAmount(&entities.Payment{
@@ -386,7 +394,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_ErrorBranches(t *testing.
 		u := &PaymentUsecase{
 			contractRepo:     scRepo,
 			chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
-			clientFactory:    blockchain.NewClientFactory(),
+			clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
 			ABIResolverMixin: NewABIResolverMixin(scRepo),
 		}
 		amount, err := u.CalculateOnchainApprovalAmount(&entities.Payment{
@@ -19,18 +19,18 @@ type approvalCallStep struct {
 	err error
 }
 
-func mockApprovalFactory(rpcURL string, steps []approvalCallStep) *blockchain.ClientFactory {
-	factory := blockchain.NewClientFactory()
+func mockApprovalFactory(rpcURL string, steps []approvalCallStep) ClientFactory {
 	idx := 0
-	factory.RegisterEVMClient(rpcURL, blockchain.NewEVMClientWithCallView(big.NewInt(8453), func(context.Context, string, []byte) ([]byte, error) {
-		if idx >= len(steps) {
-			return nil, errors.New("unexpected call")
-		}
-		step := steps[idx]
-		idx++
-		return step.out, step.err
-	}))
-	return factory
+	return &clientFactoryMock{clients: map[string]EVMClient{
+		rpcURL: &evmClientMock{callView: func(context.Context, string, []byte) ([]byte, error) {
+			if idx >= len(steps) {
+				return nil, errors.New("unexpected call")
+			}
+			step := steps[idx]
+			idx++
+			return step.out, step.err
+		}},
+	}}
 }
 
 func TestPaymentUsecase_CalculateOnchainApprovalAmount_MockClientBranches(t *testing.T) {
@@ -152,7 +152,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_MockClientBranches(t *tes
 
 		u := &PaymentUsecase{
 			chainRepo:        chainRepoRPCList,
-			clientFactory:    factory,
+			clientFactory:    NewEVMClientFactory(factory),
 			contractRepo:     scRepo,
 			routePolicyRepo:  nil,
 			ABIResolverMixin: NewABIResolverMixin(scRepo),
@@ -559,7 +559,7 @@ func TestPaymentUsecase_GetBridgeFeeQuote_UsesFallbackOrder(t *testing.T) {
 				Type:            entities.ContractTypeRouter,
 			},
 		},
-		clientFactory: blockchain.NewClientFactory(),
+		clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		tokenRepo:     quoteTokenRepoStub{},
 		routePolicyRepo: &routePolicyRepoStub{
 			getByRouteFn: func(context.Context, uuid.UUID, uuid.UUID) (*entities.RoutePolicy, error) {
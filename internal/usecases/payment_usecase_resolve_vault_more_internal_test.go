@@ -36,9 +36,13 @@ func (s *approvalNilChainRepoStub) GetAllRPCs(context.Context, *uuid.UUID, *bool
 func (s *approvalNilChainRepoStub) GetActive(context.Context, utils.PaginationParams) ([]*entities.Chain, int64, error) {
 	return nil, 0, nil
 }
+func (s *approvalNilChainRepoStub) GetPaginated(context.Context, bool, utils.PaginationParams) ([]*entities.Chain, int64, error) {
+	return nil, 0, nil
+}
 func (s *approvalNilChainRepoStub) Create(context.Context, *entities.Chain) error       { return nil }
 func (s *approvalNilChainRepoStub) Update(context.Context, *entities.Chain) error       { return nil }
 func (s *approvalNilChainRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
+func (s *approvalNilChainRepoStub) HardDelete(context.Context, uuid.UUID) error         { return nil }
 func (s *approvalNilChainRepoStub) CreateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *approvalNilChainRepoStub) UpdateRPC(context.Context, *entities.ChainRPC) error { return nil }
 func (s *approvalNilChainRepoStub) DeleteRPC(context.Context, uuid.UUID) error          { return nil }
@@ -73,7 +77,7 @@ func TestPaymentUsecase_ResolveVaultAddressForApproval_MoreBranches(t *testing.T
 				ID:   chainID,
 				RPCs: []entities.ChainRPC{{URL: srv.URL, IsActive: false}},
 			}},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		}
 		got := u.ResolveVaultAddressForApproval(chainID, "0x1111111111111111111111111111111111111111")
 		require.Equal(t, "0xaAaAaAaaAaAaAaaAaAAAAAAAAaaaAaAaAaaAaaAa", got)
@@ -95,7 +99,7 @@ func TestPaymentUsecase_ResolveVaultAddressForApproval_MoreBranches(t *testing.T
 				return nil, errors.New("not found")
 			}},
 			chainRepo:     &approvalNilChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: rpcURL}},
-			clientFactory: factory,
+			clientFactory: NewEVMClientFactory(factory),
 		}
 		got := u.ResolveVaultAddressForApproval(chainID, "0x1111111111111111111111111111111111111111")
 		require.Equal(t, "0xaAaAaAaaAaAaAaaAaAAAAAAAAaaaAaAaAaaAaaAa", got)
@@ -122,7 +126,7 @@ func TestPaymentUsecase_ResolveVaultAddressForApproval_MoreBranches(t *testing.T
 				return nil, errors.New("not found")
 			}},
 			chainRepo:     &approvalNilChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: rpcURL}},
-			clientFactory: factory,
+			clientFactory: NewEVMClientFactory(factory),
 		}
 		got := u.ResolveVaultAddressForApproval(chainID, "0x1111111111111111111111111111111111111111")
 		require.Equal(t, "", got)
@@ -134,7 +138,7 @@ func TestPaymentUsecase_ResolveVaultAddressForApproval_MoreBranches(t *testing.T
 				return nil, errors.New("not found")
 			}},
 			chainRepo:     &approvalNilChainRepoStub{chain: &entities.Chain{ID: chainID}},
-			clientFactory: blockchain.NewClientFactory(),
+			clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		}
 		got := u.ResolveVaultAddressForApproval(chainID, "0x1111111111111111111111111111111111111111")
 		require.Equal(t, "", got)
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/pkg/utils"
 )
 
@@ -25,9 +26,12 @@ func (s *routePolicyRepoStub) GetByRoute(ctx context.Context, sourceChainID, des
 	}
 	return nil, domainerrors.ErrNotFound
 }
-func (s *routePolicyRepoStub) List(context.Context, *uuid.UUID, *uuid.UUID, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
+func (s *routePolicyRepoStub) List(context.Context, repositories.RoutePolicyFilter, utils.PaginationParams) ([]*entities.RoutePolicy, int64, error) {
 	return nil, 0, nil
 }
+func (s *routePolicyRepoStub) CreateMissing(_ context.Context, policies []*entities.RoutePolicy) ([]bool, error) {
+	return make([]bool, len(policies)), nil
+}
 func (s *routePolicyRepoStub) Create(context.Context, *entities.RoutePolicy) error { return nil }
 func (s *routePolicyRepoStub) Update(context.Context, *entities.RoutePolicy) error { return nil }
 func (s *routePolicyRepoStub) Delete(context.Context, uuid.UUID) error             { return nil }
@@ -72,6 +76,9 @@ func (s *tokenResolveRepoStub) GetByAddress(ctx context.Context, address string,
 func (s *tokenResolveRepoStub) GetAll(context.Context) ([]*entities.Token, error) {
 	return nil, nil
 }
+func (s *tokenResolveRepoStub) GetActiveBySymbol(context.Context, string) ([]*entities.Token, error) {
+	return nil, nil
+}
 func (s *tokenResolveRepoStub) GetStablecoins(context.Context) ([]*entities.Token, error) {
 	return nil, nil
 }
@@ -84,12 +91,16 @@ func (s *tokenResolveRepoStub) GetNative(ctx context.Context, chainID uuid.UUID)
 func (s *tokenResolveRepoStub) GetTokensByChain(context.Context, uuid.UUID, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
-func (s *tokenResolveRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, utils.PaginationParams) ([]*entities.Token, int64, error) {
+func (s *tokenResolveRepoStub) GetAllTokens(context.Context, *uuid.UUID, *string, bool, utils.PaginationParams) ([]*entities.Token, int64, error) {
 	return nil, 0, nil
 }
+func (s *tokenResolveRepoStub) BulkSetActive(context.Context, []uuid.UUID, bool) (map[uuid.UUID]bool, error) {
+	return nil, nil
+}
 func (s *tokenResolveRepoStub) Create(context.Context, *entities.Token) error { return nil }
 func (s *tokenResolveRepoStub) Update(context.Context, *entities.Token) error { return nil }
 func (s *tokenResolveRepoStub) SoftDelete(context.Context, uuid.UUID) error   { return nil }
+func (s *tokenResolveRepoStub) HardDelete(context.Context, uuid.UUID) error   { return nil }
 
 func TestPaymentUsecase_DecideBridge_Priority(t *testing.T) {
 	sourceID := uuid.New()
@@ -115,6 +126,33 @@ func TestPaymentUsecase_DecideBridge_Priority(t *testing.T) {
 		require.Nil(t, bridgeID)
 	})
 
+	t.Run("route policy keeps the id of the matching configured bridge", func(t *testing.T) {
+		cfgBridgeID := uuid.New()
+		u := &PaymentUsecase{
+			routePolicyRepo: &routePolicyRepoStub{
+				getByRouteFn: func(context.Context, uuid.UUID, uuid.UUID) (*entities.RoutePolicy, error) {
+					return &entities.RoutePolicy{DefaultBridgeType: 2}, nil
+				},
+			},
+			bridgeConfigRepo: &bridgeConfigRepoStub{
+				getActiveFn: func(context.Context, uuid.UUID, uuid.UUID) (*entities.BridgeConfig, error) {
+					return &entities.BridgeConfig{
+						Bridge: &entities.PaymentBridge{ID: cfgBridgeID, Name: "LAYERZERO"},
+					}, nil
+				},
+			},
+		}
+		bridgeName, bridgeID := u.decideBridge(context.Background(), sourceID, destID, "eip155:8453", "eip155:42161")
+		require.Equal(t, "Stargate", bridgeName)
+		require.NotNil(t, bridgeID)
+		require.Equal(t, cfgBridgeID, *bridgeID)
+
+		selected := selectedBridge(bridgeName, bridgeID)
+		require.Equal(t, "Stargate", selected.Name)
+		require.Equal(t, cfgBridgeID, *selected.ID)
+		require.Nil(t, selectedBridge("", nil), "same-chain payments have no bridge")
+	})
+
 	t.Run("bridge config used when policy missing", func(t *testing.T) {
 		cfgBridgeID := uuid.New()
 		u := &PaymentUsecase{
@@ -236,18 +274,45 @@ func TestPaymentUsecase_SelectBridge_Branches(t *testing.T) {
 		require.Equal(t, "CCIP", got)
 	})
 
-	t.Run("solana to evm selects hyperlane", func(t *testing.T) {
+	t.Run("solana to evm selects hyperbridge", func(t *testing.T) {
 		got := u.SelectBridge("solana:mainnet", "eip155:8453")
-		require.Equal(t, "Hyperlane", got)
+		require.Equal(t, "Hyperbridge", got)
 	})
 
-	t.Run("evm to solana selects hyperlane", func(t *testing.T) {
+	t.Run("evm to solana selects hyperbridge", func(t *testing.T) {
 		got := u.SelectBridge("eip155:8453", "solana:mainnet")
-		require.Equal(t, "Hyperlane", got)
+		require.Equal(t, "Hyperbridge", got)
 	})
 
 	t.Run("non-evm non-solana falls back to hyperbridge", func(t *testing.T) {
 		got := u.SelectBridge("cosmos:osmosis-1", "cosmos:cosmoshub-4")
 		require.Equal(t, "Hyperbridge", got)
 	})
+
+	t.Run("configured order is honoured where the bridge supports the route", func(t *testing.T) {
+		u := &PaymentUsecase{bridgeOrder: parseBridgeOrder([]string{"LayerZero", " ccip", "bogus", "Stargate"})}
+		require.Equal(t, []uint8{bridgeTypeStargate, bridgeTypeCCIP}, u.bridgeOrder)
+		require.Equal(t, "Stargate", u.SelectBridge("eip155:8453", "eip155:42161"))
+		require.Equal(t, "Hyperbridge", u.SelectBridge("eip155:8453", "solana:mainnet"))
+	})
+}
+
+func TestPaymentUsecase_SelectBridge_AlwaysReturnsKnownBridge(t *testing.T) {
+	caip2s := []string{"eip155:8453", "eip155:42161", "solana:mainnet", "cosmos:osmosis-1", "tron:0x2b6653dc", "unknown:1", ""}
+	orders := [][]uint8{nil, defaultBridgeOrder, {bridgeTypeStargate}, {bridgeTypeHyperbridgeTokenGateway, bridgeTypeCCIP}, {bridgeTypeHyperbridge}}
+
+	for _, order := range orders {
+		u := &PaymentUsecase{bridgeOrder: order}
+		for _, source := range caip2s {
+			for _, dest := range caip2s {
+				name := u.SelectBridge(source, dest)
+				bridgeType, ok := lookupBridgeType(name)
+				require.True(t, ok, "SelectBridge(%q, %q) returned unrecognised bridge %q", source, dest, name)
+				require.Equal(t, name, bridgeTypeToName(bridgeType))
+				if !entities.ChainTypeFromCAIP2(source).IsEVM() || !entities.ChainTypeFromCAIP2(dest).IsEVM() {
+					require.Equal(t, "Hyperbridge", name, "only hyperbridge reaches non-EVM chains")
+				}
+			}
+		}
+	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/infrastructure/blockchain"
 	"payment-kita.backend/internal/usecases"
 )
@@ -34,6 +35,7 @@ func TestCreatePayment_Success(t *testing.T) {
 		mockEventRepo,
 		mockWalletRepo,
 		mockMerchantRepo,
+		nil,
 		mockContractRepo,
 		mockChainRepo,
 		mockTokenRepo,
@@ -45,17 +47,19 @@ func TestCreatePayment_Success(t *testing.T) {
 	)
 
 	srcChainID := uuid.New()
-	token := &entities.Token{ID: uuid.New(), Symbol: "USDC", Decimals: 6}
+	token := &entities.Token{ID: uuid.New(), Symbol: "USDC", Decimals: 6, IsActive: true}
 	srcChain := &entities.Chain{
-		ID:      srcChainID,
-		ChainID: "1",
+		ID:       srcChainID,
+		IsActive: true,
+		ChainID:  "1",
 		RPCs: []entities.ChainRPC{
 			{URL: "https://eth.llama.rpc.com"},
 		},
 	}
 	destChain := &entities.Chain{
-		ID:      uuid.New(), // destination chain ID
-		ChainID: "137",
+		ID:       uuid.New(), // destination chain ID
+		IsActive: true,
+		ChainID:  "137",
 		RPCs: []entities.ChainRPC{
 			{URL: "https://polygon-rpc.com"},
 		},
@@ -68,7 +72,7 @@ func TestCreatePayment_Success(t *testing.T) {
 		DestTokenAddress:   "0x456",
 		Amount:             "1",
 		Decimals:           6,
-		ReceiverAddress:    "0xReceiver",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
 	}
 
 	// Mocks setup
@@ -81,6 +85,8 @@ func TestCreatePayment_Success(t *testing.T) {
 	mockChainRepo.On("GetByID", mock.Anything, destChain.ID).Return(destChain, nil)
 	mockTokenRepo.On("GetByAddress", mock.Anything, "0x123", srcChain.ID).Return(token, nil)
 	mockTokenRepo.On("GetByAddress", mock.Anything, "0x456", destChain.ID).Return(token, nil)
+	// Plain user: no merchant to attribute the payment to
+	mockMerchantRepo.On("GetByUserID", mock.Anything, mock.Anything).Return(nil, domainerrors.ErrNotFound)
 
 	// Mock Gateway for source chain
 	mockContractRepo.On("GetActiveContract", mock.Anything, srcChain.ID, entities.ContractTypeGateway).Return(&entities.SmartContract{
@@ -259,7 +259,7 @@ func TestPaymentUsecase_BuildTransactionData_Branches(t *testing.T) {
 		u := &PaymentUsecase{
 			contractRepo:     scRepo,
 			chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
-			clientFactory:    blockchain.NewClientFactory(),
+			clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
 			ABIResolverMixin: NewABIResolverMixin(scRepo),
 		}
 
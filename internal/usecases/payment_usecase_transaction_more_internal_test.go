@@ -36,7 +36,7 @@ func TestPaymentUsecase_BuildTransactionData_MoreBranches(t *testing.T) {
 			contractRepo: &quoteContractRepoStub{
 				router: &entities.SmartContract{ContractAddress: "0x1111111111111111111111111111111111111111"},
 			},
-			clientFactory: factory,
+			clientFactory: NewEVMClientFactory(factory),
 		}
 
 		payment := &entities.Payment{
@@ -77,7 +77,7 @@ func TestPaymentUsecase_BuildTransactionData_MoreBranches(t *testing.T) {
 			contractRepo: &quoteContractRepoStub{
 				router: &entities.SmartContract{ContractAddress: "0x1111111111111111111111111111111111111111"},
 			},
-			clientFactory: factory,
+			clientFactory: NewEVMClientFactory(factory),
 		}
 
 		payment := &entities.Payment{
@@ -196,7 +196,7 @@ func TestPaymentUsecase_BuildTransactionData_MoreBranches(t *testing.T) {
 				}
 				return nil, errors.New("not found")
 			}},
-			clientFactory: factory,
+			clientFactory: NewEVMClientFactory(factory),
 			ABIResolverMixin: NewABIResolverMixin(&scRepoStub{getActiveFn: func(_ context.Context, _ uuid.UUID, typ entities.SmartContractType) (*entities.SmartContract, error) {
 				if typ == entities.ContractTypeVault {
 					return &entities.SmartContract{ContractAddress: vaultAddr}, nil
@@ -255,7 +255,7 @@ func TestPaymentUsecase_ResolveVaultAddressForApproval_UsesActiveRPCList(t *test
 			RPCURL: "",
 			RPCs:   []entities.ChainRPC{{URL: srv.URL, IsActive: true}},
 		}},
-		clientFactory: blockchain.NewClientFactory(),
+		clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 	}
 
 	got := u.ResolveVaultAddressForApproval(chainID, "0x1111111111111111111111111111111111111111")
@@ -269,7 +269,7 @@ func TestPaymentUsecase_CalculateOnchainApprovalAmount_ClientCreateError(t *test
 			ID:     chainID,
 			RPCURL: "://bad-rpc-url",
 		}},
-		clientFactory: blockchain.NewClientFactory(),
+		clientFactory: NewEVMClientFactory(blockchain.NewClientFactory()),
 		contractRepo: &scRepoStub{getActiveFn: func(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
 			return nil, errors.New("not found")
 		}},
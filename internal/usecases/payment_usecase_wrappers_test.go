@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"payment-kita.backend/internal/domain/entities"
 	"payment-kita.backend/internal/usecases"
+	"payment-kita.backend/pkg/utils"
 )
 
 func TestPaymentUsecase_ReadWrappers(t *testing.T) {
@@ -19,6 +20,7 @@ func TestPaymentUsecase_ReadWrappers(t *testing.T) {
 		eventRepo,
 		new(MockWalletRepository),
 		new(MockMerchantRepository),
+		nil,
 		new(MockSmartContractRepository),
 		new(MockChainRepository),
 		new(MockTokenRepository),
@@ -39,12 +41,20 @@ func TestPaymentUsecase_ReadWrappers(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, paymentID, got.ID)
 
-	paymentRepo.On("GetByUserID", context.Background(), userID, 5, 5).Return([]*entities.Payment{p}, 1, nil).Once()
-	items, total, err := uc.GetPaymentsByUser(context.Background(), userID, 2, 5)
+	pagination := utils.PaginationParams{Page: 2, Limit: 5}
+	paymentRepo.On("GetByUserID", context.Background(), userID, entities.PaymentModeLive, pagination).Return([]*entities.Payment{p}, int64(1), nil).Once()
+	items, total, err := uc.GetPaymentsByUser(context.Background(), userID, pagination)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, total)
+	assert.Equal(t, int64(1), total)
 	assert.Len(t, items, 1)
 
+	// A test-mode key only sees test payments
+	testCtx := usecases.WithPaymentMode(context.Background(), entities.PaymentModeTest)
+	paymentRepo.On("GetByUserID", testCtx, userID, entities.PaymentModeTest, pagination).Return([]*entities.Payment{}, int64(0), nil).Once()
+	_, total, err = uc.GetPaymentsByUser(testCtx, userID, pagination)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
 	eventRepo.On("GetByPaymentID", context.Background(), paymentID).Return(evs, nil).Once()
 	gotEvents, err := uc.GetPaymentEvents(context.Background(), paymentID)
 	assert.NoError(t, err)
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/domain/repositories"
+)
+
+// maxAllowedReceiverLabel caps the free-text label on an allow-list entry
+const maxAllowedReceiverLabel = 100
+
+// ReceiverAllowlistUsecase manages the receiver addresses each merchant may pay out to
+type ReceiverAllowlistUsecase struct {
+	repo         repositories.MerchantAllowedReceiverRepository
+	merchantRepo repositories.MerchantRepository
+}
+
+func NewReceiverAllowlistUsecase(repo repositories.MerchantAllowedReceiverRepository, merchantRepo repositories.MerchantRepository) *ReceiverAllowlistUsecase {
+	return &ReceiverAllowlistUsecase{repo: repo, merchantRepo: merchantRepo}
+}
+
+// MerchantIDForUser returns the merchant owned by userID
+func (u *ReceiverAllowlistUsecase) MerchantIDForUser(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	merchant, err := u.merchantRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if err == domainerrors.ErrNotFound {
+			return uuid.Nil, domainerrors.Forbidden("merchant account required")
+		}
+		return uuid.Nil, err
+	}
+	return merchant.ID, nil
+}
+
+// List returns the merchant's allowed receivers; empty means any receiver is accepted
+func (u *ReceiverAllowlistUsecase) List(ctx context.Context, merchantID uuid.UUID) ([]*entities.MerchantAllowedReceiver, error) {
+	if err := u.ensureMerchant(ctx, merchantID); err != nil {
+		return nil, err
+	}
+	return u.repo.ListByMerchantID(ctx, merchantID)
+}
+
+// Add allows address for the merchant. address must be an EVM or Solana address; names are
+// refused because what they resolve to can change.
+func (u *ReceiverAllowlistUsecase) Add(ctx context.Context, merchantID uuid.UUID, address, label string, createdBy *uuid.UUID) (*entities.MerchantAllowedReceiver, error) {
+	if err := u.ensureMerchant(ctx, merchantID); err != nil {
+		return nil, err
+	}
+	address = strings.TrimSpace(address)
+	if !isReceiverAddress(address) {
+		return nil, domainerrors.BadRequest("address must be a 0x EVM address or a base58 Solana public key")
+	}
+	label = strings.TrimSpace(label)
+	if len(label) > maxAllowedReceiverLabel {
+		return nil, domainerrors.BadRequest(fmt.Sprintf("label must be at most %d characters", maxAllowedReceiverLabel))
+	}
+
+	receiver := &entities.MerchantAllowedReceiver{
+		MerchantID: merchantID,
+		Address:    normalizeReceiverAddress(address),
+		Label:      label,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := u.repo.Create(ctx, receiver); err != nil {
+		if err == domainerrors.ErrAlreadyExists {
+			return nil, domainerrors.Conflict("address is already on the allow-list")
+		}
+		return nil, err
+	}
+	return receiver, nil
+}
+
+// Remove deletes one of the merchant's allow-list entries
+func (u *ReceiverAllowlistUsecase) Remove(ctx context.Context, merchantID, id uuid.UUID) error {
+	if err := u.repo.Delete(ctx, merchantID, id); err != nil {
+		if err == domainerrors.ErrNotFound {
+			return domainerrors.NotFound("allowed receiver not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// CheckReceiver refuses receiver when the merchant's allow-list is non-empty and lacks it
+func (u *ReceiverAllowlistUsecase) CheckReceiver(ctx context.Context, merchantID uuid.UUID, receiver string) error {
+	return checkReceiverAllowed(ctx, u.repo, &merchantID, receiver)
+}
+
+func (u *ReceiverAllowlistUsecase) ensureMerchant(ctx context.Context, merchantID uuid.UUID) error {
+	if _, err := u.merchantRepo.GetByID(ctx, merchantID); err != nil {
+		if err == domainerrors.ErrNotFound {
+			return domainerrors.NotFound("merchant not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// checkReceiverAllowed refuses receiver when the merchant has a non-empty allow-list without
+// it. No repo or no merchant means no restriction; a failed lookup refuses the receiver.
+func checkReceiverAllowed(ctx context.Context, repo repositories.MerchantAllowedReceiverRepository, merchantID *uuid.UUID, receiver string) error {
+	if repo == nil || merchantID == nil {
+		return nil
+	}
+	allowed, err := repo.ListByMerchantID(ctx, *merchantID)
+	if err != nil {
+		return domainerrors.InternalError(err)
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	want := normalizeReceiverAddress(receiver)
+	for _, entry := range allowed {
+		if entry.Address == want {
+			return nil
+		}
+	}
+	return domainerrors.NewAppError(
+		http.StatusForbidden,
+		domainerrors.CodeReceiverNotAllowed,
+		fmt.Sprintf("receiver %s is not on the merchant allow-list", strings.TrimSpace(receiver)),
+		domainerrors.ErrReceiverNotAllowed,
+	)
+}
+
+// isReceiverAddress reports whether address is a raw EVM or Solana address
+func isReceiverAddress(address string) bool {
+	return addressMatchesChainType(entities.ChainTypeEVM, address) || addressMatchesChainType(entities.ChainTypeSVM, address)
+}
+
+// normalizeReceiverAddress lowercases EVM addresses so checksum casing does not matter. Solana
+// addresses are case-sensitive and kept as they are.
+func normalizeReceiverAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(address, "0x") {
+		return strings.ToLower(address)
+	}
+	return address
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type allowedReceiverRepoStub struct {
+	items   []*entities.MerchantAllowedReceiver
+	listErr error
+}
+
+func (s *allowedReceiverRepoStub) ListByMerchantID(_ context.Context, merchantID uuid.UUID) ([]*entities.MerchantAllowedReceiver, error) {
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	var out []*entities.MerchantAllowedReceiver
+	for _, item := range s.items {
+		if item.MerchantID == merchantID {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+func (s *allowedReceiverRepoStub) Create(_ context.Context, receiver *entities.MerchantAllowedReceiver) error {
+	for _, item := range s.items {
+		if item.MerchantID == receiver.MerchantID && item.Address == receiver.Address {
+			return domainerrors.ErrAlreadyExists
+		}
+	}
+	receiver.ID = uuid.New()
+	s.items = append(s.items, receiver)
+	return nil
+}
+
+func (s *allowedReceiverRepoStub) Delete(_ context.Context, merchantID, id uuid.UUID) error {
+	for i, item := range s.items {
+		if item.MerchantID == merchantID && item.ID == id {
+			s.items = append(s.items[:i], s.items[i+1:]...)
+			return nil
+		}
+	}
+	return domainerrors.ErrNotFound
+}
+
+type allowlistMerchantRepoStub struct {
+	fakeMerchantRepo
+}
+
+func (s *allowlistMerchantRepoStub) GetByID(_ context.Context, id uuid.UUID) (*entities.Merchant, error) {
+	if s.merchant == nil || s.merchant.ID != id {
+		return nil, domainerrors.ErrNotFound
+	}
+	return s.merchant, nil
+}
+
+func TestCheckReceiverAllowed(t *testing.T) {
+	ctx := context.Background()
+	merchantID := uuid.New()
+	const listed = "0xAbCdEf0123456789aBcDeF0123456789AbCdEf01"
+	repo := &allowedReceiverRepoStub{}
+
+	// No list, no merchant or no repo: anything goes
+	require.NoError(t, checkReceiverAllowed(ctx, repo, &merchantID, "0x1111111111111111111111111111111111111111"))
+	require.NoError(t, checkReceiverAllowed(ctx, repo, nil, "0x1111111111111111111111111111111111111111"))
+	require.NoError(t, checkReceiverAllowed(ctx, nil, &merchantID, "0x1111111111111111111111111111111111111111"))
+
+	repo.items = []*entities.MerchantAllowedReceiver{{MerchantID: merchantID, Address: normalizeReceiverAddress(listed)}}
+	require.NoError(t, checkReceiverAllowed(ctx, repo, &merchantID, listed))
+	require.NoError(t, checkReceiverAllowed(ctx, repo, &merchantID, " 0xabcdef0123456789abcdef0123456789abcdef01 "))
+
+	err := checkReceiverAllowed(ctx, repo, &merchantID, "0x1111111111111111111111111111111111111111")
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusForbidden, appErr.Status)
+	require.Equal(t, domainerrors.CodeReceiverNotAllowed, appErr.Code)
+	require.ErrorIs(t, appErr.Err, domainerrors.ErrReceiverNotAllowed)
+
+	// Another merchant's list does not apply
+	other := uuid.New()
+	require.NoError(t, checkReceiverAllowed(ctx, repo, &other, "0x1111111111111111111111111111111111111111"))
+
+	// A failed lookup refuses rather than waving the payment through
+	repo.listErr = errors.New("db down")
+	err = checkReceiverAllowed(ctx, repo, &merchantID, listed)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusInternalServerError, appErr.Status)
+}
+
+func TestReceiverAllowlistUsecase_AddAndRemove(t *testing.T) {
+	ctx := context.Background()
+	merchantID := uuid.New()
+	repo := &allowedReceiverRepoStub{}
+	u := NewReceiverAllowlistUsecase(repo, &allowlistMerchantRepoStub{fakeMerchantRepo{merchant: &entities.Merchant{ID: merchantID}}})
+
+	added, err := u.Add(ctx, merchantID, "0xAbCdEf0123456789aBcDeF0123456789AbCdEf01", " treasury ", nil)
+	require.NoError(t, err)
+	require.Equal(t, "0xabcdef0123456789abcdef0123456789abcdef01", added.Address)
+	require.Equal(t, "treasury", added.Label)
+
+	_, err = u.Add(ctx, merchantID, "0xabcdef0123456789abcdef0123456789abcdef01", "", nil)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusConflict, appErr.Status)
+
+	_, err = u.Add(ctx, merchantID, "alice.eth", "", nil)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusBadRequest, appErr.Status)
+
+	_, err = u.Add(ctx, uuid.New(), "0x1111111111111111111111111111111111111111", "", nil)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusNotFound, appErr.Status)
+
+	items, err := u.List(ctx, merchantID)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	require.NoError(t, u.Remove(ctx, merchantID, added.ID))
+	err = u.Remove(ctx, merchantID, added.ID)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusNotFound, appErr.Status)
+}
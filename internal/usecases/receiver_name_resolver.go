@@ -0,0 +1,342 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+const receiverNameCacheTTL = 5 * time.Minute
+
+const (
+	// ensRegistryAddress is the ENS registry, deployed at the same address on mainnet and testnets
+	ensRegistryAddress = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+	// ensMainnetID and ensSepoliaID are the L1s ENS names are resolved on. The registry only
+	// exists there, so names paid to on L2s are looked up on L1.
+	ensMainnetID = "1"
+	ensSepoliaID = "11155111"
+
+	snsNameProgramID   = "namesLPneVptA9Z5rqUDD9tMTWEJwofgaYwp8cawRkX"
+	snsSolTLDAuthority = "58PwtjSDuFHuUkYjH9BYnnQKHfwo9reZhC2zMJv9JPkx"
+	snsHashPrefix      = "SPL Name Service"
+	// snsHeaderLen is parent(32) + owner(32) + class(32)
+	snsHeaderLen = 96
+)
+
+var (
+	ensResolverSelector = crypto.Keccak256([]byte("resolver(bytes32)"))[:4]
+	ensAddrSelector     = crypto.Keccak256([]byte("addr(bytes32)"))[:4]
+)
+
+// ReceiverNameResolver resolves a human-readable receiver name (alice.eth, alice.sol) to an address
+type ReceiverNameResolver interface {
+	ResolveReceiverName(ctx context.Context, chain *entities.Chain, name string) (string, error)
+}
+
+// isReceiverName reports whether receiver is a name rather than a raw address for the chain type
+func isReceiverName(receiver string, chainType entities.ChainType) bool {
+	receiver = strings.TrimSpace(receiver)
+	switch chainType {
+	case entities.ChainTypeEVM:
+		return strings.Contains(receiver, ".") && !strings.HasPrefix(receiver, "0x")
+	case entities.ChainTypeSVM:
+		return strings.HasSuffix(strings.ToLower(receiver), ".sol")
+	}
+	return false
+}
+
+type receiverNameEntry struct {
+	address    string
+	resolvedAt time.Time
+}
+
+// CachedReceiverNameResolver resolves ENS names for EVM chains on Ethereum L1, and SNS names on
+// Solana through the chain's own RPC. Successful lookups are cached briefly; failures are not.
+type CachedReceiverNameResolver struct {
+	ens *ENSResolver
+	sns *SNSResolver
+	now func() time.Time
+
+	ensRPCURL        string
+	ensTestnetRPCURL string
+
+	mu      sync.Mutex
+	entries map[string]receiverNameEntry
+}
+
+// NewReceiverNameResolver creates a resolver backed by the shared client factory, resolving ENS
+// through the Ethereum mainnet and Sepolia RPCs ensRPCURL and ensTestnetRPCURL
+func NewReceiverNameResolver(clientFactory ClientFactory, ensRPCURL, ensTestnetRPCURL string) *CachedReceiverNameResolver {
+	return &CachedReceiverNameResolver{
+		ens:              &ENSResolver{clientFactory: clientFactory},
+		sns:              &SNSResolver{httpClient: &http.Client{Timeout: 10 * time.Second}},
+		now:              time.Now,
+		ensRPCURL:        strings.TrimSpace(ensRPCURL),
+		ensTestnetRPCURL: strings.TrimSpace(ensTestnetRPCURL),
+		entries:          make(map[string]receiverNameEntry),
+	}
+}
+
+// ensL1 returns the L1 ENS names paid to on chain resolve on, Sepolia for testnets and Ethereum
+// mainnet otherwise, and its RPC. Without one configured, only the L1 itself resolves, through
+// its own RPC.
+func (r *CachedReceiverNameResolver) ensL1(chain *entities.Chain) (string, string) {
+	l1, rpcURL := ensMainnetID, r.ensRPCURL
+	if chain.IsTestnet {
+		l1, rpcURL = ensSepoliaID, r.ensTestnetRPCURL
+	}
+	if rpcURL == "" && chain.ChainID == l1 {
+		rpcURL = chain.RPCURL
+	}
+	return "eip155:" + l1, rpcURL
+}
+
+// ResolveReceiverName resolves name on chain, wrapping any failure in ErrReceiverNameUnresolved
+func (r *CachedReceiverNameResolver) ResolveReceiverName(ctx context.Context, chain *entities.Chain, name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	caip2 := chain.GetCAIP2ID()
+	// ENS names resolve the same on every chain of a network, so they are cached per L1
+	lookupChain, ensRPCURL := caip2, ""
+	if chain.ChainType() == entities.ChainTypeEVM {
+		lookupChain, ensRPCURL = r.ensL1(chain)
+	}
+	key := lookupChain + "|" + name
+
+	r.mu.Lock()
+	if entry, ok := r.entries[key]; ok && r.now().Sub(entry.resolvedAt) < receiverNameCacheTTL {
+		r.mu.Unlock()
+		return entry.address, nil
+	}
+	r.mu.Unlock()
+
+	var (
+		address string
+		err     error
+	)
+	switch chain.ChainType() {
+	case entities.ChainTypeEVM:
+		address, err = r.ens.Resolve(ctx, ensRPCURL, name)
+	case entities.ChainTypeSVM:
+		address, err = r.sns.Resolve(ctx, chain.RPCURL, name)
+	default:
+		err = fmt.Errorf("names are not supported on %s", caip2)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %s on %s: %v", domainerrors.ErrReceiverNameUnresolved, name, lookupChain, err)
+	}
+
+	r.mu.Lock()
+	r.entries[key] = receiverNameEntry{address: address, resolvedAt: r.now()}
+	r.mu.Unlock()
+	return address, nil
+}
+
+// ENSResolver resolves ENS names with eth_call against the ENS registry and the name's resolver
+type ENSResolver struct {
+	clientFactory ClientFactory
+}
+
+// Resolve returns the checksummed address name points to
+func (r *ENSResolver) Resolve(ctx context.Context, rpcURL, name string) (string, error) {
+	if r.clientFactory == nil || strings.TrimSpace(rpcURL) == "" {
+		return "", fmt.Errorf("no rpc configured")
+	}
+	client, err := r.clientFactory.GetEVMClient(rpcURL)
+	if err != nil {
+		return "", err
+	}
+
+	node := ensNamehash(name)
+	resolver, err := ensCallAddress(ctx, client, ensRegistryAddress, ensResolverSelector, node)
+	if err != nil {
+		return "", fmt.Errorf("registry lookup failed: %w", err)
+	}
+	if resolver == (common.Address{}) {
+		return "", fmt.Errorf("name has no resolver")
+	}
+	addr, err := ensCallAddress(ctx, client, resolver.Hex(), ensAddrSelector, node)
+	if err != nil {
+		return "", fmt.Errorf("resolver lookup failed: %w", err)
+	}
+	if addr == (common.Address{}) {
+		return "", fmt.Errorf("name has no address record")
+	}
+	return addr.Hex(), nil
+}
+
+func ensCallAddress(ctx context.Context, client EVMClient, to string, selector []byte, node [32]byte) (common.Address, error) {
+	out, err := client.CallView(ctx, to, append(append([]byte{}, selector...), node[:]...))
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(out) < 32 {
+		return common.Address{}, nil
+	}
+	return common.BytesToAddress(out[12:32]), nil
+}
+
+// ensNamehash implements EIP-137 namehash. Names are lowercased; full UTS-46 normalisation is
+// left to the client.
+func ensNamehash(name string) [32]byte {
+	var node [32]byte
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := crypto.Keccak256([]byte(labels[i]))
+		copy(node[:], crypto.Keccak256(node[:], labelHash))
+	}
+	return node
+}
+
+// SNSResolver resolves .sol names with getAccountInfo on the Solana Name Service account.
+// It returns the domain owner, which is what wallets pay to when no SOL record is set.
+type SNSResolver struct {
+	httpClient *http.Client
+}
+
+// Resolve returns the base58 owner of name
+func (r *SNSResolver) Resolve(ctx context.Context, rpcURL, name string) (string, error) {
+	if strings.TrimSpace(rpcURL) == "" {
+		return "", fmt.Errorf("no rpc configured")
+	}
+	account, err := snsDomainKey(name)
+	if err != nil {
+		return "", err
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "getAccountInfo",
+		"params":  []interface{}{base58Encode(account[:]), map[string]string{"encoding": "base64"}},
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("rpc returned status %d", resp.StatusCode)
+	}
+
+	var decoded struct {
+		Result *struct {
+			Value *struct {
+				Data []string `json:"data"`
+			} `json:"value"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return "", fmt.Errorf("invalid rpc response: %w", err)
+	}
+	if decoded.Error != nil {
+		return "", fmt.Errorf("rpc error: %s", decoded.Error.Message)
+	}
+	if decoded.Result == nil || decoded.Result.Value == nil || len(decoded.Result.Value.Data) == 0 {
+		return "", fmt.Errorf("name is not registered")
+	}
+	data, err := base64.StdEncoding.DecodeString(decoded.Result.Value.Data[0])
+	if err != nil || len(data) < snsHeaderLen {
+		return "", fmt.Errorf("invalid name account data")
+	}
+	owner := data[32:64]
+	if bytes.Equal(owner, make([]byte, 32)) {
+		return "", fmt.Errorf("name has no owner")
+	}
+	return base58Encode(owner), nil
+}
+
+// snsDomainKey derives the name account for a second-level .sol domain
+func snsDomainKey(name string) ([32]byte, error) {
+	label := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".sol")
+	if label == "" || strings.Contains(label, ".") {
+		return [32]byte{}, fmt.Errorf("only second-level .sol names are supported")
+	}
+	hashed := sha256.Sum256([]byte(snsHashPrefix + label))
+	return findProgramAddress(
+		[][]byte{hashed[:], make([]byte, 32), base58Decode(snsSolTLDAuthority)},
+		base58Decode(snsNameProgramID),
+	)
+}
+
+// findProgramAddress mirrors Solana's Pubkey::find_program_address
+func findProgramAddress(seeds [][]byte, programID []byte) ([32]byte, error) {
+	for bump := 255; bump >= 0; bump-- {
+		h := sha256.New()
+		for _, seed := range seeds {
+			h.Write(seed)
+		}
+		h.Write([]byte{byte(bump)})
+		h.Write(programID)
+		h.Write([]byte("ProgramDerivedAddress"))
+		var candidate [32]byte
+		copy(candidate[:], h.Sum(nil))
+		if !isOnEd25519Curve(candidate) {
+			return candidate, nil
+		}
+	}
+	return [32]byte{}, fmt.Errorf("no viable program address")
+}
+
+var (
+	ed25519P = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	ed25519D = func() *big.Int {
+		// d = -121665 / 121666 mod p
+		d := new(big.Int).ModInverse(big.NewInt(121666), ed25519P)
+		d.Mul(d, big.NewInt(-121665))
+		return d.Mod(d, ed25519P)
+	}()
+)
+
+// isOnEd25519Curve reports whether b decompresses to an ed25519 point, i.e. whether
+// x^2 = (y^2 - 1) / (d*y^2 + 1) has a square root mod p.
+func isOnEd25519Curve(b [32]byte) bool {
+	le := b
+	le[31] &= 0x7f
+	for i, j := 0, len(le)-1; i < j; i, j = i+1, j-1 {
+		le[i], le[j] = le[j], le[i]
+	}
+	y := new(big.Int).SetBytes(le[:])
+	y.Mod(y, ed25519P)
+
+	y2 := new(big.Int).Mul(y, y)
+	u := new(big.Int).Sub(y2, big.NewInt(1))
+	u.Mod(u, ed25519P)
+	v := new(big.Int).Mul(ed25519D, y2)
+	v.Add(v, big.NewInt(1))
+	v.Mod(v, ed25519P)
+	if v.Sign() == 0 {
+		return u.Sign() == 0
+	}
+	x2 := new(big.Int).Mul(u, new(big.Int).ModInverse(v, ed25519P))
+	x2.Mod(x2, ed25519P)
+	if x2.Sign() == 0 {
+		return true
+	}
+	return new(big.Int).ModSqrt(x2, ed25519P) != nil
+}
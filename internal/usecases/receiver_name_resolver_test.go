@@ -0,0 +1,214 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+func TestEnsNamehash_KnownVectors(t *testing.T) {
+	// Vectors from EIP-137.
+	require.Equal(t, strings.Repeat("0", 64), hex.EncodeToString(func() []byte { n := ensNamehash(""); return n[:] }()))
+	eth := ensNamehash("eth")
+	require.Equal(t, "93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae", hex.EncodeToString(eth[:]))
+	foo := ensNamehash("foo.eth")
+	require.Equal(t, "de9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f", hex.EncodeToString(foo[:]))
+}
+
+func TestSnsDomainKey_MatchesNameService(t *testing.T) {
+	key, err := snsDomainKey("bonfida.sol")
+	require.NoError(t, err)
+	require.Equal(t, "Crf8hzfthWGbGbLTVCiqRqV5MVnbpHB1L9KQMd6gsinb", base58Encode(key[:]))
+
+	_, err = snsDomainKey("sub.bonfida.sol")
+	require.Error(t, err)
+}
+
+func TestIsReceiverName(t *testing.T) {
+	require.True(t, isReceiverName("alice.eth", entities.ChainTypeEVM))
+	require.True(t, isReceiverName("pay.alice.xyz", entities.ChainTypeEVM))
+	require.False(t, isReceiverName("0x000000000000000000000000000000000000dEaD", entities.ChainTypeEVM))
+	require.True(t, isReceiverName("Alice.SOL", entities.ChainTypeSVM))
+	require.False(t, isReceiverName("So11111111111111111111111111111111111111112", entities.ChainTypeSVM))
+	require.False(t, isReceiverName("alice.eth", entities.ChainTypeSVM))
+}
+
+func TestCachedReceiverNameResolver_ENS(t *testing.T) {
+	const rpcURL = "http://ens.test"
+	resolverAddr := common.HexToAddress("0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41")
+	owner := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	node := ensNamehash("alice.eth")
+
+	var calls int32
+	factory := blockchain.NewClientFactory()
+	factory.RegisterEVMClient(rpcURL, blockchain.NewEVMClientWithCallView(big.NewInt(1), func(_ context.Context, to string, data []byte) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		if string(data[4:]) != string(node[:]) {
+			return make([]byte, 32), nil
+		}
+		switch {
+		case strings.EqualFold(to, ensRegistryAddress):
+			return common.LeftPadBytes(resolverAddr.Bytes(), 32), nil
+		case strings.EqualFold(to, resolverAddr.Hex()):
+			return common.LeftPadBytes(owner.Bytes(), 32), nil
+		}
+		return make([]byte, 32), nil
+	}))
+
+	// Mainnet itself falls back to its own RPC
+	r := NewReceiverNameResolver(NewEVMClientFactory(factory), "", "")
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "1", Type: entities.ChainTypeEVM, RPCURL: rpcURL, IsActive: true}
+
+	addr, err := r.ResolveReceiverName(context.Background(), chain, "Alice.eth")
+	require.NoError(t, err)
+	require.Equal(t, owner.Hex(), addr)
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+
+	// Cached within the TTL.
+	_, err = r.ResolveReceiverName(context.Background(), chain, "alice.eth")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+
+	// Expired entries are looked up again.
+	r.now = func() time.Time { return time.Now().Add(receiverNameCacheTTL + time.Second) }
+	_, err = r.ResolveReceiverName(context.Background(), chain, "alice.eth")
+	require.NoError(t, err)
+	require.EqualValues(t, 4, atomic.LoadInt32(&calls))
+
+	_, err = r.ResolveReceiverName(context.Background(), chain, "nobody.eth")
+	require.ErrorIs(t, err, domainerrors.ErrReceiverNameUnresolved)
+}
+
+func TestCachedReceiverNameResolver_ENSOnL1(t *testing.T) {
+	const l1RPC, l2RPC, sepoliaRPC = "http://mainnet.test", "http://base.test", "http://sepolia.test"
+	resolverAddr := common.HexToAddress("0x4976fb03C32e5B8cfe2b6cCB31c09Ba78EBaBa41")
+	owner := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	registry := func(_ context.Context, to string, _ []byte) ([]byte, error) {
+		switch {
+		case strings.EqualFold(to, ensRegistryAddress):
+			return common.LeftPadBytes(resolverAddr.Bytes(), 32), nil
+		case strings.EqualFold(to, resolverAddr.Hex()):
+			return common.LeftPadBytes(owner.Bytes(), 32), nil
+		}
+		return make([]byte, 32), nil
+	}
+	var l2Calls int32
+	factory := blockchain.NewClientFactory()
+	factory.RegisterEVMClient(l1RPC, blockchain.NewEVMClientWithCallView(big.NewInt(1), registry))
+	factory.RegisterEVMClient(sepoliaRPC, blockchain.NewEVMClientWithCallView(big.NewInt(11155111), registry))
+	// Base has no ENS registry of its own
+	factory.RegisterEVMClient(l2RPC, blockchain.NewEVMClientWithCallView(big.NewInt(8453), func(context.Context, string, []byte) ([]byte, error) {
+		atomic.AddInt32(&l2Calls, 1)
+		return make([]byte, 32), nil
+	}))
+	base := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: l2RPC, IsActive: true}
+	baseSepolia := &entities.Chain{ID: uuid.New(), ChainID: "84532", Type: entities.ChainTypeEVM, RPCURL: l2RPC, IsActive: true, IsTestnet: true}
+
+	// Names paid to on an L2 are resolved on L1, never through the L2's RPC
+	r := NewReceiverNameResolver(NewEVMClientFactory(factory), l1RPC, sepoliaRPC)
+	addr, err := r.ResolveReceiverName(context.Background(), base, "alice.eth")
+	require.NoError(t, err)
+	require.Equal(t, owner.Hex(), addr)
+	addr, err = r.ResolveReceiverName(context.Background(), baseSepolia, "alice.eth")
+	require.NoError(t, err)
+	require.Equal(t, owner.Hex(), addr)
+	require.Zero(t, atomic.LoadInt32(&l2Calls))
+
+	// Without an L1 RPC an L2 cannot resolve names
+	r = NewReceiverNameResolver(NewEVMClientFactory(factory), "", sepoliaRPC)
+	_, err = r.ResolveReceiverName(context.Background(), base, "alice.eth")
+	require.ErrorIs(t, err, domainerrors.ErrReceiverNameUnresolved)
+	require.Zero(t, atomic.LoadInt32(&l2Calls))
+}
+
+func TestCachedReceiverNameResolver_SNS(t *testing.T) {
+	owner := base58Decode("So11111111111111111111111111111111111111112")
+	expectedAccount, err := snsDomainKey("bonfida.sol")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			Method string        `json:"method"`
+			Params []interface{} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		require.Equal(t, "getAccountInfo", body.Method)
+		if body.Params[0] != base58Encode(expectedAccount[:]) {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"value":null}}`))
+			return
+		}
+		data := make([]byte, snsHeaderLen)
+		copy(data[32:64], owner)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  map[string]interface{}{"value": map[string]interface{}{"data": []string{base64.StdEncoding.EncodeToString(data), "base64"}}},
+		})
+	}))
+	defer srv.Close()
+
+	r := NewReceiverNameResolver(nil, "", "")
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "mainnet", Type: entities.ChainTypeSVM, RPCURL: srv.URL, IsActive: true}
+
+	addr, err := r.ResolveReceiverName(context.Background(), chain, "bonfida.sol")
+	require.NoError(t, err)
+	require.Equal(t, "So11111111111111111111111111111111111111112", addr)
+
+	_, err = r.ResolveReceiverName(context.Background(), chain, "unregistered.sol")
+	require.ErrorIs(t, err, domainerrors.ErrReceiverNameUnresolved)
+}
+
+type receiverNameResolverStub struct {
+	addresses map[string]string
+}
+
+func (s receiverNameResolverStub) ResolveReceiverName(_ context.Context, _ *entities.Chain, name string) (string, error) {
+	if addr, ok := s.addresses[name]; ok {
+		return addr, nil
+	}
+	return "", errors.Join(domainerrors.ErrReceiverNameUnresolved, errors.New("not found"))
+}
+
+func TestPaymentUsecase_CreatePayment_ResolvesReceiverName(t *testing.T) {
+	chain := &entities.Chain{ID: uuid.New(), ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	u, paymentRepo := newCalldataPreviewUsecase(chain, &entities.SmartContract{ContractAddress: "0x3333333333333333333333333333333333333333"})
+	u.receiverNames = receiverNameResolverStub{addresses: map[string]string{"alice.eth": "0x000000000000000000000000000000000000dEaD"}}
+
+	input := &entities.CreatePaymentInput{
+		SourceChainID:      "eip155:8453",
+		DestChainID:        "eip155:8453",
+		SourceTokenAddress: "0x1111111111111111111111111111111111111111",
+		DestTokenAddress:   "0x1111111111111111111111111111111111111111",
+		ReceiverAddress:    "Alice.eth",
+		Amount:             "1",
+		Decimals:           6,
+	}
+	resp, err := u.CreatePayment(context.Background(), uuid.New(), input)
+	require.NoError(t, err)
+	require.Equal(t, "0x000000000000000000000000000000000000dEaD", resp.ReceiverAddress)
+	require.Equal(t, "alice.eth", resp.ReceiverName)
+	require.Equal(t, "0x000000000000000000000000000000000000dEaD", paymentRepo.created.ReceiverAddress)
+	require.Equal(t, "alice.eth", paymentRepo.created.ReceiverName.String)
+
+	paymentRepo.created = nil
+	input.ReceiverAddress = "bob.eth"
+	_, err = u.CreatePayment(context.Background(), uuid.New(), input)
+	require.ErrorIs(t, err, domainerrors.ErrReceiverNameUnresolved)
+	require.Nil(t, paymentRepo.created)
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RouteDegradedEvent is the event name of a route degradation alert
+const RouteDegradedEvent = "crosschain.route.degraded"
+
+// Route alert notifier kinds, selected by CROSSCHAIN_ROUTE_ALERT_NOTIFIER
+const (
+	RouteAlertNotifierWebhook = "webhook"
+	RouteAlertNotifierSlack   = "slack"
+)
+
+const routeAlertTimeout = 10 * time.Second
+
+// RouteDegradedAlert reports a crosschain route that went from READY to ERROR, with the issues
+// that failed it
+type RouteDegradedAlert struct {
+	RouteKey       string                    `json:"routeKey"`
+	SourceChainID  string                    `json:"sourceChainId"`
+	DestChainID    string                    `json:"destChainId"`
+	PreviousStatus string                    `json:"previousStatus"`
+	OverallStatus  string                    `json:"overallStatus"`
+	Issues         []ContractConfigCheckItem `json:"issues"`
+	DetectedAt     time.Time                 `json:"detectedAt"`
+}
+
+// RouteAlertNotifier delivers route alerts to operators
+type RouteAlertNotifier interface {
+	NotifyRouteDegraded(ctx context.Context, alert RouteDegradedAlert) error
+}
+
+// NewRouteAlertNotifier returns the notifier of the given kind posting to url
+func NewRouteAlertNotifier(kind, url string) (RouteAlertNotifier, error) {
+	client := &http.Client{Timeout: routeAlertTimeout}
+	switch kind {
+	case "", RouteAlertNotifierWebhook:
+		return &webhookRouteAlertNotifier{url: url, client: client}, nil
+	case RouteAlertNotifierSlack:
+		return &slackRouteAlertNotifier{url: url, client: client}, nil
+	default:
+		return nil, fmt.Errorf("unknown route alert notifier %q", kind)
+	}
+}
+
+// webhookRouteAlertNotifier posts {"event": ..., "alert": {...}} as JSON
+type webhookRouteAlertNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *webhookRouteAlertNotifier) NotifyRouteDegraded(ctx context.Context, alert RouteDegradedAlert) error {
+	return postRouteAlert(ctx, n.client, n.url, map[string]interface{}{
+		"event": RouteDegradedEvent,
+		"alert": alert,
+	})
+}
+
+// slackRouteAlertNotifier posts a message to a Slack incoming webhook
+type slackRouteAlertNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (n *slackRouteAlertNotifier) NotifyRouteDegraded(ctx context.Context, alert RouteDegradedAlert) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, ":rotating_light: Crosschain route *%s* degraded: %s -> %s", alert.RouteKey, alert.PreviousStatus, alert.OverallStatus)
+	for _, issue := range alert.Issues {
+		fmt.Fprintf(&b, "\n• `%s` %s", issue.Code, issue.Message)
+	}
+	return postRouteAlert(ctx, n.client, n.url, map[string]string{"text": b.String()})
+}
+
+func postRouteAlert(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal route alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create route alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PaymentKita-Ops-Alerts/1.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("route alert endpoint returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteAlertNotifiers(t *testing.T) {
+	var got map[string]interface{}
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		got = nil
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	alert := RouteDegradedAlert{
+		RouteKey:       "eip155:8453->eip155:42161",
+		PreviousStatus: "READY",
+		OverallStatus:  "ERROR",
+		Issues:         []ContractConfigCheckItem{{Code: "FEE_QUOTE_FAILED", Status: "ERROR", Message: "fee quote call failed"}},
+		DetectedAt:     time.Now(),
+	}
+
+	webhook, err := NewRouteAlertNotifier(RouteAlertNotifierWebhook, srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, webhook.NotifyRouteDegraded(context.Background(), alert))
+	require.Equal(t, RouteDegradedEvent, got["event"])
+	require.Equal(t, alert.RouteKey, got["alert"].(map[string]interface{})["routeKey"])
+
+	slack, err := NewRouteAlertNotifier(RouteAlertNotifierSlack, srv.URL)
+	require.NoError(t, err)
+	require.NoError(t, slack.NotifyRouteDegraded(context.Background(), alert))
+	require.Contains(t, got["text"], "eip155:8453->eip155:42161")
+	require.Contains(t, got["text"], "FEE_QUOTE_FAILED")
+
+	status = http.StatusInternalServerError
+	require.ErrorContains(t, slack.NotifyRouteDegraded(context.Background(), alert), "HTTP 500")
+
+	_, err = NewRouteAlertNotifier("pagerduty", srv.URL)
+	require.Error(t, err)
+}
@@ -34,7 +34,7 @@ type RouteErrorDiagnostics struct {
 type RouteErrorUsecase struct {
 	chainRepo     repositories.ChainRepository
 	contractRepo  repositories.SmartContractRepository
-	clientFactory *blockchain.ClientFactory
+	clientFactory ClientFactory
 	chainResolver *ChainResolver
 }
 
@@ -46,7 +46,7 @@ func NewRouteErrorUsecase(
 	return &RouteErrorUsecase{
 		chainRepo:     chainRepo,
 		contractRepo:  contractRepo,
-		clientFactory: clientFactory,
+		clientFactory: NewEVMClientFactory(clientFactory),
 		chainResolver: NewChainResolver(chainRepo),
 	}
 }
@@ -234,6 +234,8 @@ func knownSelectorName(selector string) string {
 		return "UntrustedSource"
 	case selectorHex("UntrustedPeer(uint32,bytes32)"):
 		return "UntrustedPeer"
+	case selectorHex("OwnableUnauthorizedAccount(address)"):
+		return "OwnableUnauthorizedAccount"
 	default:
 		return ""
 	}
@@ -317,6 +319,16 @@ func decodeKnownCustomErrorPayload(selector string, payload []byte) (map[string]
 			"srcEid": eid,
 			"peer":   "0x" + hex.EncodeToString(peer[:]),
 		}, true
+	case selectorHex("OwnableUnauthorizedAccount(address)"):
+		values, ok := unpackCustomErrorArgs(payload, []string{"address"})
+		if !ok || len(values) != 1 {
+			return nil, false
+		}
+		account, okAccount := values[0].(common.Address)
+		if !okAccount {
+			return nil, false
+		}
+		return map[string]any{"account": account.Hex()}, true
 	case selectorHex("FeeQuoteFailed(uint256,address[])"):
 		values, ok := unpackCustomErrorArgs(payload, []string{"uint256", "address[]"})
 		if !ok || len(values) != 2 {
@@ -390,6 +402,10 @@ func buildDetailMessage(name string, details map[string]any) string {
 			return fmt.Sprintf("fee quote failed (required fee: %s native)", required)
 		}
 		return "fee quote failed"
+	case "OwnableUnauthorizedAccount":
+		if v, ok := details["account"].(string); ok && v != "" {
+			return "caller " + v + " is not the contract owner"
+		}
 	}
 	return ""
 }
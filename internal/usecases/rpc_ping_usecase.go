@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+const rpcPingTimeout = 5 * time.Second
+
+// PingRPCInput names an RPC endpoint to probe. ChainType defaults to EVM.
+type PingRPCInput struct {
+	URL       string
+	ChainType entities.ChainType
+}
+
+// RPCPingResult is what an RPC endpoint answered. When Reachable is false, Error says why and
+// the chain fields are empty.
+type RPCPingResult struct {
+	URL         string             `json:"url"`
+	ChainType   entities.ChainType `json:"chainType"`
+	Reachable   bool               `json:"reachable"`
+	ChainID     string             `json:"chainId,omitempty"`
+	CAIP2       string             `json:"caip2,omitempty"`
+	LatestBlock uint64             `json:"latestBlock,omitempty"`
+	LatencyMs   int64              `json:"latencyMs"`
+	Error       string             `json:"error,omitempty"`
+}
+
+// RPCPingUsecase checks that an RPC URL answers before it is saved as a ChainRPC. It dials a
+// throwaway client rather than the shared client factory, so unsaved URLs are never cached.
+type RPCPingUsecase struct {
+	timeout time.Duration
+}
+
+func NewRPCPingUsecase() *RPCPingUsecase {
+	return &RPCPingUsecase{timeout: rpcPingTimeout}
+}
+
+// PingRPC asks the endpoint for its chain ID and latest block (slot on Solana). An endpoint that
+// cannot be reached is not an error: the result reports it with Reachable false.
+func (u *RPCPingUsecase) PingRPC(ctx context.Context, input PingRPCInput) (*RPCPingResult, error) {
+	rpcURL := strings.TrimSpace(input.URL)
+	parsed, err := url.Parse(rpcURL)
+	if err != nil || parsed.Host == "" {
+		return nil, domainerrors.BadRequest("url must be an absolute http(s) or ws(s) URL")
+	}
+	switch parsed.Scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		return nil, domainerrors.BadRequest("url must be an absolute http(s) or ws(s) URL")
+	}
+
+	chainType := entities.ChainType(strings.ToUpper(strings.TrimSpace(string(input.ChainType))))
+	if chainType == "" {
+		chainType = entities.ChainTypeEVM
+	}
+	if chainType != entities.ChainTypeEVM && chainType != entities.ChainTypeSVM {
+		return nil, domainerrors.BadRequest(fmt.Sprintf("chainType must be %s or %s", entities.ChainTypeEVM, entities.ChainTypeSVM))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	result := &RPCPingResult{URL: rpcURL, ChainType: chainType}
+	started := time.Now()
+	if chainType == entities.ChainTypeSVM {
+		err = pingSolanaRPC(ctx, rpcURL, result)
+	} else {
+		err = pingEVMRPC(ctx, rpcURL, result)
+	}
+	result.LatencyMs = time.Since(started).Milliseconds()
+	if err != nil {
+		return &RPCPingResult{URL: rpcURL, ChainType: chainType, LatencyMs: result.LatencyMs, Error: err.Error()}, nil
+	}
+	result.Reachable = true
+	return result, nil
+}
+
+// VerifyChainID checks that rpcURL serves the EVM chain declared as networkID ("8453" or
+// "eip155:8453") by calling eth_chainId. A mismatch, or an RPC that cannot answer, is a 400
+// wrapping domainerrors.ErrChainIDMismatch. Non-EVM chains are not checked.
+func (u *RPCPingUsecase) VerifyChainID(ctx context.Context, chainType entities.ChainType, rpcURL, networkID string) error {
+	if chainType != entities.ChainTypeEVM {
+		return nil
+	}
+	declared := strings.TrimPrefix(strings.TrimSpace(networkID), "eip155:")
+	if _, ok := new(big.Int).SetString(declared, 10); !ok {
+		return domainerrors.BadRequest(fmt.Sprintf("networkId %q is not a numeric EVM chain ID", networkID))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+	var result RPCPingResult
+	if err := pingEVMRPC(ctx, strings.TrimSpace(rpcURL), &result); err != nil {
+		return domainerrors.NewAppError(http.StatusBadRequest, domainerrors.CodeChainIDMismatch,
+			fmt.Sprintf("could not read the chain ID from %s: %v", rpcURL, err), domainerrors.ErrChainIDMismatch)
+	}
+	if result.ChainID != declared {
+		return domainerrors.NewAppError(http.StatusBadRequest, domainerrors.CodeChainIDMismatch,
+			fmt.Sprintf("%s serves chain ID %s, not the declared %s", rpcURL, result.ChainID, declared), domainerrors.ErrChainIDMismatch)
+	}
+	return nil
+}
+
+func pingEVMRPC(ctx context.Context, rpcURL string, result *RPCPingResult) error {
+	client, err := blockchain.NewEVMClientContext(ctx, rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	block, err := client.GetBlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	result.ChainID = client.ChainID().String()
+	result.CAIP2 = "eip155:" + result.ChainID
+	result.LatestBlock = block
+	return nil
+}
+
+func pingSolanaRPC(ctx context.Context, rpcURL string, result *RPCPingResult) error {
+	client, err := blockchain.NewSolanaClient(rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	genesis, err := client.GetGenesisHash(ctx)
+	if err != nil {
+		return err
+	}
+	slot, err := client.GetSlot(ctx)
+	if err != nil {
+		return err
+	}
+	result.ChainID = genesis
+	if len(genesis) > 32 {
+		genesis = genesis[:32]
+	}
+	result.CAIP2 = "solana:" + genesis
+	result.LatestBlock = slot
+	return nil
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// newRPCPingStub answers each JSON-RPC method with results[method]
+func newRPCPingStub(t *testing.T, results map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		result, ok := results[req.Method]
+		if !ok {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"error":{"code":-32601,"message":"Method not found"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,"result":` + result + `}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRPCPingUsecase_PingRPC(t *testing.T) {
+	u := NewRPCPingUsecase()
+	ctx := context.Background()
+
+	evm := newRPCPingStub(t, map[string]string{"eth_chainId": `"0x2105"`, "eth_blockNumber": `"0x1a2b3c"`})
+	result, err := u.PingRPC(ctx, PingRPCInput{URL: evm.URL})
+	require.NoError(t, err)
+	require.True(t, result.Reachable, result.Error)
+	require.Equal(t, entities.ChainTypeEVM, result.ChainType)
+	require.Equal(t, "8453", result.ChainID)
+	require.Equal(t, "eip155:8453", result.CAIP2)
+	require.Equal(t, uint64(0x1a2b3c), result.LatestBlock)
+
+	solana := newRPCPingStub(t, map[string]string{"getGenesisHash": `"EtWTRABZaYq6iMfeYKouRu166VU2xqa1wcaWoxPkrZBG"`, "getSlot": `321`})
+	result, err = u.PingRPC(ctx, PingRPCInput{URL: solana.URL, ChainType: "svm"})
+	require.NoError(t, err)
+	require.True(t, result.Reachable, result.Error)
+	require.Equal(t, "solana:EtWTRABZaYq6iMfeYKouRu166VU2xqa1", result.CAIP2)
+	require.Equal(t, uint64(321), result.LatestBlock)
+
+	// An endpoint that answers errors is reported, not returned as a failure
+	broken := newRPCPingStub(t, map[string]string{"eth_chainId": `"0x1"`})
+	result, err = u.PingRPC(ctx, PingRPCInput{URL: broken.URL})
+	require.NoError(t, err)
+	require.False(t, result.Reachable)
+	require.Contains(t, result.Error, "Method not found")
+	require.Empty(t, result.ChainID)
+
+	for _, input := range []PingRPCInput{
+		{URL: "not a url"},
+		{URL: "ftp://rpc.example.com"},
+		{URL: evm.URL, ChainType: entities.ChainTypeCosmos},
+	} {
+		_, err = u.PingRPC(ctx, input)
+		var appErr *domainerrors.AppError
+		require.ErrorAs(t, err, &appErr, input.URL)
+		require.Equal(t, http.StatusBadRequest, appErr.Status)
+	}
+}
+
+func TestRPCPingUsecase_PingRPC_TimeoutCoversDial(t *testing.T) {
+	// The client reads the chain ID while it is created; a stalled answer must not outlive the ping
+	release := make(chan struct{})
+	stalled := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { <-release }))
+	t.Cleanup(func() {
+		close(release)
+		stalled.Close()
+	})
+	u := &RPCPingUsecase{timeout: 50 * time.Millisecond}
+
+	started := time.Now()
+	result, err := u.PingRPC(context.Background(), PingRPCInput{URL: stalled.URL})
+	require.NoError(t, err)
+	require.False(t, result.Reachable)
+	require.Contains(t, result.Error, "deadline exceeded")
+	require.Less(t, time.Since(started), time.Second)
+}
+
+func TestRPCPingUsecase_VerifyChainID(t *testing.T) {
+	u := NewRPCPingUsecase()
+	ctx := context.Background()
+	base := newRPCPingStub(t, map[string]string{"eth_chainId": `"0x2105"`, "eth_blockNumber": `"0x1"`})
+
+	require.NoError(t, u.VerifyChainID(ctx, entities.ChainTypeEVM, base.URL, "8453"))
+	require.NoError(t, u.VerifyChainID(ctx, entities.ChainTypeEVM, base.URL, "eip155:8453"))
+	// Non-EVM chains are not checked, whatever the RPC serves
+	require.NoError(t, u.VerifyChainID(ctx, entities.ChainTypeSVM, "http://127.0.0.1:1", "solana:devnet"))
+
+	err := u.VerifyChainID(ctx, entities.ChainTypeEVM, base.URL, "1")
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeChainIDMismatch, appErr.Code)
+	require.Contains(t, appErr.Message, "serves chain ID 8453, not the declared 1")
+
+	broken := newRPCPingStub(t, map[string]string{})
+	require.ErrorAs(t, u.VerifyChainID(ctx, entities.ChainTypeEVM, broken.URL, "8453"), &appErr)
+	require.Equal(t, domainerrors.CodeChainIDMismatch, appErr.Code)
+
+	require.ErrorAs(t, u.VerifyChainID(ctx, entities.ChainTypeEVM, base.URL, "base"), &appErr)
+	require.Equal(t, domainerrors.CodeInvalidInput, appErr.Code)
+}
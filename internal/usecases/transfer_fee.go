@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// transferFeeProbeRecipient receives the simulated transfer. It holds nothing and is on no
+// token's fee-exempt list, so what arrives is what any payer's transfer would deliver.
+const transferFeeProbeRecipient = "0x000000000000000000000000000000000000fee1"
+
+// errTransferFeeProbeUnsupported means the chain or its RPC client cannot simulate a transfer
+var errTransferFeeProbeUnsupported = errors.New("transfer fee probe needs an EVM chain whose RPC supports eth_simulateV1")
+
+// tokenTransferSimulator is implemented by EVM clients that can dry-run an ERC20 transfer.
+// *blockchain.EVMClient implements it.
+type tokenTransferSimulator interface {
+	SimulateTokenTransfer(ctx context.Context, tokenAddress, from, to string, amount *big.Int) (*big.Int, error)
+}
+
+// TransferFeeProbe is the outcome of simulating a holder sending its whole balance of a token.
+// HasTransferFee is set when the recipient's balance grew by anything other than Sent.
+type TransferFeeProbe struct {
+	Holder         string `json:"holder"`
+	Sent           string `json:"sent"`
+	Received       string `json:"received"`
+	HasTransferFee bool   `json:"hasTransferFee"`
+}
+
+// ProbeTransferFee simulates holder transferring its whole balance of tokenAddress and compares
+// what arrives with what was sent, to spot fee-on-transfer and rebasing tokens at import.
+// Nothing is sent on-chain. holder must hold some of the token.
+func (u *PaymentUsecase) ProbeTransferFee(ctx context.Context, chain *entities.Chain, tokenAddress, holder string) (*TransferFeeProbe, error) {
+	if chain == nil || !chain.ChainType().IsEVM() || u.clientFactory == nil {
+		return nil, errTransferFeeProbeUnsupported
+	}
+	rpcURL := resolveChainRPCURL(chain)
+	if rpcURL == "" {
+		return nil, fmt.Errorf("no rpc url for chain %s", chain.GetCAIP2ID())
+	}
+	client, err := u.clientFactory.GetEVMClient(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create evm client for transfer fee probe: %w", err)
+	}
+	simulator, ok := client.(tokenTransferSimulator)
+	if !ok {
+		return nil, errTransferFeeProbeUnsupported
+	}
+
+	// balanceOf(address) selector: 0x70a08231
+	balanceCall := append(common.Hex2Bytes("70a08231"), common.LeftPadBytes(common.HexToAddress(holder).Bytes(), 32)...)
+	raw, err := client.CallView(ctx, tokenAddress, balanceCall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read holder balance: %w", err)
+	}
+	balance := new(big.Int).SetBytes(raw)
+	if balance.Sign() == 0 {
+		return nil, fmt.Errorf("holder %s has no balance of %s to simulate a transfer with", holder, tokenAddress)
+	}
+
+	received, err := simulator.SimulateTokenTransfer(ctx, tokenAddress, holder, transferFeeProbeRecipient, balance)
+	if err != nil {
+		return nil, fmt.Errorf("transfer simulation failed: %w", err)
+	}
+	return &TransferFeeProbe{
+		Holder:         holder,
+		Sent:           balance.String(),
+		Received:       received.String(),
+		HasTransferFee: received.Cmp(balance) != 0,
+	}, nil
+}
+
+// checkTransferFeeTokens refuses payments in tokens flagged HasTransferFee, on any route. The
+// gateway, and on cross-chain routes the bridge, would be handed less than the payment amount
+// and revert on-chain without a reason.
+func checkTransferFeeTokens(tokens ...*entities.Token) error {
+	for _, token := range tokens {
+		if token == nil || !token.HasTransferFee {
+			continue
+		}
+		return domainerrors.NewAppError(
+			http.StatusUnprocessableEntity,
+			domainerrors.CodeTransferFeeToken,
+			fmt.Sprintf("%s charges a fee on transfer and cannot be used for payments; pay with another token", token.Symbol),
+			domainerrors.ErrTransferFeeToken,
+		)
+	}
+	return nil
+}
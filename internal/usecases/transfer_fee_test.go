@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+type transferSimulatorMock struct {
+	evmClientMock
+	simulate func(tokenAddress, from, to string, amount *big.Int) (*big.Int, error)
+}
+
+func (m *transferSimulatorMock) SimulateTokenTransfer(_ context.Context, tokenAddress, from, to string, amount *big.Int) (*big.Int, error) {
+	return m.simulate(tokenAddress, from, to, amount)
+}
+
+func TestPaymentUsecase_ProbeTransferFee(t *testing.T) {
+	ctx := context.Background()
+	const (
+		rpcURL = "https://rpc.example"
+		token  = "0x4444444444444444444444444444444444444444"
+		holder = "0x3333333333333333333333333333333333333333"
+	)
+	chain := &entities.Chain{ChainID: "8453", Type: entities.ChainTypeEVM, RPCURL: rpcURL}
+	balance := big.NewInt(1_000_000)
+	client := &transferSimulatorMock{
+		evmClientMock: evmClientMock{callView: func(_ context.Context, to string, data []byte) ([]byte, error) {
+			require.Equal(t, token, to)
+			require.True(t, strings.HasSuffix(common.Bytes2Hex(data), strings.TrimPrefix(holder, "0x")))
+			return common.LeftPadBytes(balance.Bytes(), 32), nil
+		}},
+	}
+	u := &PaymentUsecase{clientFactory: &clientFactoryMock{clients: map[string]EVMClient{rpcURL: client}}}
+
+	// A 1% fee on transfer
+	client.simulate = func(tokenAddress, from, to string, amount *big.Int) (*big.Int, error) {
+		require.Equal(t, token, tokenAddress)
+		require.Equal(t, holder, from)
+		require.Equal(t, transferFeeProbeRecipient, to)
+		return new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(99)), big.NewInt(100)), nil
+	}
+	probe, err := u.ProbeTransferFee(ctx, chain, token, holder)
+	require.NoError(t, err)
+	require.Equal(t, &TransferFeeProbe{Holder: holder, Sent: "1000000", Received: "990000", HasTransferFee: true}, probe)
+
+	client.simulate = func(_, _, _ string, amount *big.Int) (*big.Int, error) { return amount, nil }
+	probe, err = u.ProbeTransferFee(ctx, chain, token, holder)
+	require.NoError(t, err)
+	require.False(t, probe.HasTransferFee)
+
+	client.simulate = func(string, string, string, *big.Int) (*big.Int, error) { return nil, errors.New("method not found") }
+	_, err = u.ProbeTransferFee(ctx, chain, token, holder)
+	require.ErrorContains(t, err, "transfer simulation failed: method not found")
+
+	balance = big.NewInt(0)
+	_, err = u.ProbeTransferFee(ctx, chain, token, holder)
+	require.ErrorContains(t, err, "has no balance")
+
+	// Clients that cannot simulate, and non-EVM chains, are unsupported
+	u.clientFactory = &clientFactoryMock{clients: map[string]EVMClient{rpcURL: &client.evmClientMock}}
+	_, err = u.ProbeTransferFee(ctx, chain, token, holder)
+	require.ErrorIs(t, err, errTransferFeeProbeUnsupported)
+	_, err = u.ProbeTransferFee(ctx, &entities.Chain{ChainID: "solana:devnet", Type: entities.ChainTypeSVM, RPCURL: rpcURL}, token, holder)
+	require.ErrorIs(t, err, errTransferFeeProbeUnsupported)
+}
+
+func TestPaymentUsecase_CreatePayment_RejectsTransferFeeTokensCrossChain(t *testing.T) {
+	sourceID := uuid.New()
+	destID := uuid.New()
+	source := &entities.Chain{ID: sourceID, ChainID: "8453", Type: entities.ChainTypeEVM, IsActive: true}
+	dest := &entities.Chain{ID: destID, ChainID: "42161", Type: entities.ChainTypeEVM, IsActive: true}
+	chainRepo := &quoteChainRepoStub{
+		byID:    map[uuid.UUID]*entities.Chain{sourceID: source, destID: dest},
+		byCAIP2: map[string]*entities.Chain{"eip155:8453": source, "eip155:42161": dest},
+	}
+	taxed := &entities.Token{ID: uuid.New(), Symbol: "TAX", Decimals: 6, ContractAddress: "0xtaxed", ChainUUID: sourceID, HasTransferFee: true, IsActive: true}
+	sameChainDest := &entities.Token{ID: uuid.New(), Symbol: "USDC", Decimals: 6, ContractAddress: "0xusdc", ChainUUID: sourceID, IsActive: true}
+	destTok := &entities.Token{ID: uuid.New(), Symbol: "USDC", Decimals: 6, ContractAddress: "0xdest", ChainUUID: destID, IsActive: true}
+	u := &PaymentUsecase{
+		chainRepo:     chainRepo,
+		chainResolver: NewChainResolver(chainRepo),
+		tokenRepo: &createPaymentTokenRepoStub{byAddress: map[string]*entities.Token{
+			sourceID.String() + "|0xtaxed": taxed,
+			sourceID.String() + "|0xusdc":  sameChainDest,
+			destID.String() + "|0xdest":    destTok,
+		}},
+		contractRepo: &scRepoStub{getActiveFn: func(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
+			return nil, domainerrors.ErrNotFound
+		}},
+	}
+	input := &entities.CreatePaymentInput{
+		SourceChainID:      "eip155:8453",
+		DestChainID:        "eip155:42161",
+		SourceTokenAddress: "0xtaxed",
+		DestTokenAddress:   "0xdest",
+		ReceiverAddress:    "0x000000000000000000000000000000000000dEaD",
+		Amount:             "invalid-number",
+	}
+
+	_, err := u.CreatePayment(context.Background(), uuid.New(), input)
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, http.StatusUnprocessableEntity, appErr.Status)
+	require.Equal(t, domainerrors.CodeTransferFeeToken, appErr.Code)
+	require.Contains(t, appErr.Message, "TAX")
+
+	// A flagged destination token is refused too
+	taxed.HasTransferFee = false
+	destTok.HasTransferFee = true
+	_, err = u.CreatePayment(context.Background(), uuid.New(), input)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeTransferFeeToken, appErr.Code)
+	require.Contains(t, appErr.Message, "USDC")
+
+	// Same-chain payments go through the gateway and are refused as well
+	taxed.HasTransferFee = true
+	destTok.HasTransferFee = false
+	input.DestChainID = "eip155:8453"
+	input.DestTokenAddress = "0xusdc"
+	_, err = u.CreatePayment(context.Background(), uuid.New(), input)
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, domainerrors.CodeTransferFeeToken, appErr.Code)
+
+	// Unflagged tokens go on to the next check
+	taxed.HasTransferFee = false
+	_, err = u.CreatePayment(context.Background(), uuid.New(), input)
+	require.ErrorIs(t, err, domainerrors.ErrBadRequest)
+}
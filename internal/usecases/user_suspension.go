@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+// userSuspensionCacheTTL bounds how long a user's suspended state is reused by
+// EnsureNotSuspended. It is short so a suspension takes effect on live JWTs within seconds,
+// while a burst of requests from one user costs a single user lookup.
+const userSuspensionCacheTTL = 15 * time.Second
+
+// userSuspensionCacheMaxEntries bounds the cache. A full cache first drops expired entries, then
+// arbitrary ones, which only costs those users one more lookup.
+const userSuspensionCacheMaxEntries = 10000
+
+type userSuspensionEntry struct {
+	suspended bool
+	checkedAt time.Time
+}
+
+// userSuspensionCache remembers each user's suspended state for userSuspensionCacheTTL, for at
+// most userSuspensionCacheMaxEntries users. A nil cache caches nothing.
+type userSuspensionCache struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[uuid.UUID]userSuspensionEntry
+}
+
+func newUserSuspensionCache() *userSuspensionCache {
+	return &userSuspensionCache{
+		now:     time.Now,
+		entries: make(map[uuid.UUID]userSuspensionEntry),
+	}
+}
+
+func (c *userSuspensionCache) get(userID uuid.UUID) (suspended, ok bool) {
+	if c == nil {
+		return false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[userID]
+	if !ok || c.now().Sub(entry.checkedAt) >= userSuspensionCacheTTL {
+		return false, false
+	}
+	return entry.suspended, true
+}
+
+func (c *userSuspensionCache) put(userID uuid.UUID, suspended bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if _, cached := c.entries[userID]; !cached && len(c.entries) >= userSuspensionCacheMaxEntries {
+		for id, entry := range c.entries {
+			if now.Sub(entry.checkedAt) >= userSuspensionCacheTTL {
+				delete(c.entries, id)
+			}
+		}
+		for id := range c.entries {
+			if len(c.entries) < userSuspensionCacheMaxEntries {
+				break
+			}
+			delete(c.entries, id)
+		}
+	}
+	c.entries[userID] = userSuspensionEntry{suspended: suspended, checkedAt: now}
+}
+
+// EnsureNotSuspended returns domainerrors.ErrAccountSuspended when the user has been suspended,
+// so a JWT issued before the suspension stops working within userSuspensionCacheTTL instead of
+// at its expiry. Users that no longer exist are unauthorized; failed lookups are not cached.
+func (u *ApiKeyUsecase) EnsureNotSuspended(ctx context.Context, userID uuid.UUID) error {
+	suspended, ok := u.suspensions.get(userID)
+	if !ok {
+		user, err := u.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, domainerrors.ErrNotFound) {
+				return domainerrors.Unauthorized("user not found")
+			}
+			return err
+		}
+		suspended = user.IsSuspended()
+		u.suspensions.put(userID, suspended)
+	}
+	if suspended {
+		return domainerrors.ErrAccountSuspended
+	}
+	return nil
+}
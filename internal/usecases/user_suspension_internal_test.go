@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+)
+
+func TestApiKeyUsecase_EnsureNotSuspended(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	var suspendedAt *time.Time
+	lookups := 0
+	lookupErr := error(nil)
+	users := &authUserRepoStub{getByIDFn: func(_ context.Context, id uuid.UUID) (*entities.User, error) {
+		lookups++
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		if id != userID {
+			return nil, domainerrors.ErrNotFound
+		}
+		return &entities.User{ID: id, SuspendedAt: suspendedAt}, nil
+	}}
+	now := time.Now()
+	u := &ApiKeyUsecase{userRepo: users, suspensions: newUserSuspensionCache()}
+	u.suspensions.now = func() time.Time { return now }
+
+	require.NoError(t, u.EnsureNotSuspended(ctx, userID))
+
+	// The answer is reused until the TTL passes, then the suspension shows
+	suspended := now
+	suspendedAt = &suspended
+	require.NoError(t, u.EnsureNotSuspended(ctx, userID))
+	require.Equal(t, 1, lookups)
+	now = now.Add(userSuspensionCacheTTL)
+	require.ErrorIs(t, u.EnsureNotSuspended(ctx, userID), domainerrors.ErrAccountSuspended)
+	require.Equal(t, 2, lookups)
+
+	// Unknown users are unauthorized, and failed lookups are retried rather than cached
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, u.EnsureNotSuspended(ctx, uuid.New()), &appErr)
+	require.Equal(t, http.StatusUnauthorized, appErr.Status)
+	lookupErr = errors.New("db down")
+	otherID := uuid.New()
+	require.ErrorIs(t, u.EnsureNotSuspended(ctx, otherID), lookupErr)
+	require.ErrorIs(t, u.EnsureNotSuspended(ctx, otherID), lookupErr)
+	require.Equal(t, 5, lookups)
+}
+
+func TestUserSuspensionCache_Bounded(t *testing.T) {
+	now := time.Now()
+	cache := newUserSuspensionCache()
+	cache.now = func() time.Time { return now }
+
+	stale := uuid.New()
+	cache.put(stale, true)
+	now = now.Add(userSuspensionCacheTTL)
+	for len(cache.entries) < userSuspensionCacheMaxEntries {
+		cache.put(uuid.New(), false)
+	}
+
+	// A full cache drops the expired entry first, then arbitrary ones, and never grows
+	fresh := uuid.New()
+	cache.put(fresh, true)
+	require.Len(t, cache.entries, userSuspensionCacheMaxEntries)
+	require.NotContains(t, cache.entries, stale)
+	suspended, ok := cache.get(fresh)
+	require.True(t, ok)
+	require.True(t, suspended)
+
+	// Refreshing a cached user evicts nobody
+	before := len(cache.entries)
+	cache.put(fresh, false)
+	require.Len(t, cache.entries, before)
+	cache.put(uuid.New(), false)
+	require.Len(t, cache.entries, userSuspensionCacheMaxEntries)
+}
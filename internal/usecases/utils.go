@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net/http"
 	"strings"
 	"unicode"
 
 	"github.com/google/uuid"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
 )
 
 func padLeft(s string, length int) string {
@@ -27,27 +30,50 @@ func padRight(s string, length int) string {
 	return s + strings.Repeat("0", length-len(s))
 }
 
-func getChainTypeFromCAIP2(caip2 string) string {
-	trimmed := strings.TrimSpace(caip2)
-	if trimmed == "" {
-		return ""
+// validateReceiverForChain checks that receiver is encoded for the destination chain: a 20-byte
+// 0x-hex address on EVM, a 32-byte base58 public key on Solana, going by the chain's own type.
+// Other chain types are not checked.
+func validateReceiverForChain(receiver string, destChain *entities.Chain) error {
+	if destChain == nil || addressMatchesChainType(destChain.Type, receiver) {
+		return nil
 	}
-	parts := strings.SplitN(trimmed, ":", 2)
-	return parts[0]
+	return fmt.Errorf("%w: %s expects %s", domainerrors.ErrInvalidReceiverForChain, destChain.GetCAIP2ID(), addressFormat(destChain.Type))
 }
 
-func isEVMChain(chainID string) bool {
-	return len(chainID) > 6 && chainID[:6] == "eip155"
+// ValidateAddressForChain checks that address is encoded for chain's type, as
+// validateReceiverForChain does. A mismatch is a 400 naming the format the chain expects; it
+// wraps domainerrors.ErrInvalidAddressForChain. Other chain types are not checked.
+func ValidateAddressForChain(chain *entities.Chain, address string) error {
+	if chain == nil || addressMatchesChainType(chain.Type, address) {
+		return nil
+	}
+	return domainerrors.NewAppError(http.StatusBadRequest, domainerrors.CodeInvalidAddress,
+		fmt.Sprintf("%q is not a valid address on %s: %s chains expect %s", strings.TrimSpace(address), chain.GetCAIP2ID(), chain.Type, addressFormat(chain.Type)),
+		domainerrors.ErrInvalidAddressForChain)
 }
 
-func isSolanaChain(chainID string) bool {
-	return len(chainID) > 6 && chainID[:6] == "solana"
+// addressMatchesChainType reports whether address is a 20-byte 0x-hex address on EVM or a
+// 32-byte base58 ed25519 public key on Solana. Solana PDAs, being off-curve, pass as well.
+func addressMatchesChainType(chainType entities.ChainType, address string) bool {
+	address = strings.TrimSpace(address)
+	switch chainType {
+	case entities.ChainTypeEVM:
+		if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+			return false
+		}
+		_, err := hex.DecodeString(address[2:])
+		return err == nil
+	case entities.ChainTypeSVM:
+		return len(base58Decode(address)) == 32
+	}
+	return true
 }
 
-func formatAmount(amount float64, decimals int) string {
-	// Use rounding to avoid truncation errors (e.g. 0.03 * 100 becoming 2.99... -> 2)
-	multiplier := amount * math.Pow(10, float64(decimals))
-	return fmt.Sprintf("%.0f", math.Round(multiplier))
+func addressFormat(chainType entities.ChainType) string {
+	if chainType == entities.ChainTypeSVM {
+		return "a base58 32-byte public key"
+	}
+	return "a 0x-prefixed 20-byte address"
 }
 
 func convertToSmallestUnit(amount string, decimals int) (string, error) {
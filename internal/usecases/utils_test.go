@@ -3,10 +3,13 @@ package usecases
 import (
 	"encoding/hex"
 	"math"
+	"net/http"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
 )
 
 func TestPadHelpers(t *testing.T) {
@@ -16,16 +19,6 @@ func TestPadHelpers(t *testing.T) {
 	assert.Equal(t, "abcdef", padRight("abcdef", 3))
 }
 
-func TestChainTypeHelpers(t *testing.T) {
-	assert.Equal(t, "eip155", getChainTypeFromCAIP2("eip155:8453"))
-	assert.Equal(t, "eip155", getChainTypeFromCAIP2("  eip155:8453  "))
-	assert.Equal(t, "", getChainTypeFromCAIP2(""))
-	assert.True(t, isEVMChain("eip155:8453"))
-	assert.False(t, isEVMChain("solana:devnet"))
-	assert.True(t, isSolanaChain("solana:devnet"))
-	assert.False(t, isSolanaChain("eip155:8453"))
-}
-
 func TestConvertToSmallestUnit(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -146,3 +139,45 @@ func TestUUIDAndAnchorHelpers(t *testing.T) {
 	d := anchorDiscriminator("create_payment")
 	assert.Len(t, d, 8)
 }
+
+func TestValidateReceiverForChain(t *testing.T) {
+	evmReceiver := "0x000000000000000000000000000000000000dEaD"
+	solReceiver := "So11111111111111111111111111111111111111112"
+
+	evmChain := &entities.Chain{ChainID: "8453", Type: entities.ChainTypeEVM}
+	solChain := &entities.Chain{ChainID: "devnet", Type: entities.ChainTypeSVM}
+
+	assert.NoError(t, validateReceiverForChain(evmReceiver, evmChain))
+	assert.NoError(t, validateReceiverForChain(solReceiver, solChain))
+	assert.ErrorIs(t, validateReceiverForChain(solReceiver, evmChain), domainerrors.ErrInvalidReceiverForChain)
+	assert.ErrorIs(t, validateReceiverForChain(evmReceiver, solChain), domainerrors.ErrInvalidReceiverForChain)
+	assert.ErrorIs(t, validateReceiverForChain("0xabc", evmChain), domainerrors.ErrInvalidReceiverForChain)
+	assert.ErrorIs(t, validateReceiverForChain("0x000000000000000000000000000000000000zzzz", evmChain), domainerrors.ErrInvalidReceiverForChain)
+	assert.ErrorIs(t, validateReceiverForChain("1111", solChain), domainerrors.ErrInvalidReceiverForChain)
+	assert.NoError(t, validateReceiverForChain("anything", &entities.Chain{ChainID: "abc", Type: entities.ChainTypeSubstrate}))
+
+	// The chain's type decides, not a guess from its CAIP-2 namespace
+	assert.ErrorIs(t, validateReceiverForChain(solReceiver, &entities.Chain{ChainID: "devnet", Type: entities.ChainTypeEVM}), domainerrors.ErrInvalidReceiverForChain)
+}
+
+func TestValidateAddressForChain(t *testing.T) {
+	evmChain := &entities.Chain{ChainID: "8453", Type: entities.ChainTypeEVM}
+	solChain := &entities.Chain{ChainID: "devnet", Type: entities.ChainTypeSVM}
+	evmAddress := "0x000000000000000000000000000000000000dEaD"
+	solAddress := "So11111111111111111111111111111111111111112"
+
+	assert.NoError(t, ValidateAddressForChain(evmChain, evmAddress))
+	assert.NoError(t, ValidateAddressForChain(solChain, solAddress))
+	assert.NoError(t, ValidateAddressForChain(nil, "anything"))
+
+	err := ValidateAddressForChain(evmChain, solAddress)
+	var appErr *domainerrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Status)
+	assert.Equal(t, domainerrors.CodeInvalidAddress, appErr.Code)
+	assert.Contains(t, appErr.Message, "0x-prefixed 20-byte address")
+
+	assert.ErrorAs(t, ValidateAddressForChain(solChain, evmAddress), &appErr)
+	assert.Contains(t, appErr.Message, "base58 32-byte public key")
+	assert.Error(t, ValidateAddressForChain(solChain, "0OIl"))
+}
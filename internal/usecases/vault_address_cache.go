@@ -0,0 +1,151 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"payment-kita.backend/pkg/logger"
+	"payment-kita.backend/pkg/redis"
+)
+
+const (
+	// vaultAddressCacheTTL bounds how long a gateway's vault() answer is reused. Writes made
+	// through this service invalidate it on every replica; the TTL covers a gateway repointed
+	// on-chain by other means, and a repoint still pending when the cache was refilled.
+	vaultAddressCacheTTL = time.Minute
+	// vaultAddressVersionKey is bumped on every invalidation, so other replicas drop their
+	// entries too
+	vaultAddressVersionKey = "vault:addresses:version"
+	// vaultAddressVersionCheckInterval bounds how long another replica takes to notice a bump
+	vaultAddressVersionCheckInterval = 5 * time.Second
+)
+
+var (
+	vaultAddressRedisGet       = redis.Get
+	vaultAddressRedisIncr      = redis.Incr
+	vaultAddressRedisAvailable = func() bool { return redis.GetClient() != nil && redis.Available() }
+
+	// vaultAddressGeneration is bumped on every invalidation in this process, so every cache
+	// here drops its entries at once, with or without Redis
+	vaultAddressGeneration atomic.Uint64
+)
+
+type vaultAddressKey struct {
+	chainID uuid.UUID
+	gateway string
+}
+
+type vaultAddressEntry struct {
+	address    string
+	resolvedAt time.Time
+}
+
+// vaultAddressCache remembers the vault each gateway reports, so ERC20 payments on chains
+// without an active vault contract skip the vault() round trip. A nil cache caches nothing.
+type vaultAddressCache struct {
+	now func() time.Time
+
+	mu         sync.Mutex
+	entries    map[vaultAddressKey]vaultAddressEntry
+	generation uint64
+	// version is the last shared version seen, checked at most every
+	// vaultAddressVersionCheckInterval
+	version          string
+	versionCheckedAt time.Time
+}
+
+func newVaultAddressCache() *vaultAddressCache {
+	return &vaultAddressCache{
+		now:        time.Now,
+		entries:    make(map[vaultAddressKey]vaultAddressEntry),
+		generation: vaultAddressGeneration.Load(),
+	}
+}
+
+func (c *vaultAddressCache) get(ctx context.Context, chainID uuid.UUID, gateway string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.syncVersion(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncGeneration()
+	entry, ok := c.entries[vaultAddressKey{chainID: chainID, gateway: strings.ToLower(gateway)}]
+	if !ok || c.now().Sub(entry.resolvedAt) >= vaultAddressCacheTTL {
+		return "", false
+	}
+	return entry.address, true
+}
+
+func (c *vaultAddressCache) put(chainID uuid.UUID, gateway, address string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.syncGeneration()
+	c.entries[vaultAddressKey{chainID: chainID, gateway: strings.ToLower(gateway)}] = vaultAddressEntry{address: address, resolvedAt: c.now()}
+}
+
+// syncGeneration drops every entry after an invalidation in this process. c.mu must be held.
+func (c *vaultAddressCache) syncGeneration() {
+	if generation := vaultAddressGeneration.Load(); generation != c.generation {
+		c.entries = make(map[vaultAddressKey]vaultAddressEntry)
+		c.generation = generation
+	}
+}
+
+// syncVersion drops every entry once the shared version has moved, that is after an
+// invalidation on another replica. Without Redis only the TTL covers those.
+func (c *vaultAddressCache) syncVersion(ctx context.Context) {
+	if !vaultAddressRedisAvailable() {
+		return
+	}
+	c.mu.Lock()
+	now := c.now()
+	due := now.Sub(c.versionCheckedAt) >= vaultAddressVersionCheckInterval
+	if due {
+		c.versionCheckedAt = now
+	}
+	c.mu.Unlock()
+	if !due {
+		return
+	}
+
+	version, err := vaultAddressRedisGet(ctx, vaultAddressVersionKey)
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		logger.Warn(ctx, "Failed to read vault address cache version", zap.Error(err))
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if version != c.version {
+		c.entries = make(map[vaultAddressKey]vaultAddressEntry)
+		c.version = version
+	}
+}
+
+// invalidateVaultAddresses makes every vault address cache, here and on other replicas, resolve
+// again. A failed Redis bump leaves other replicas on their TTL.
+func invalidateVaultAddresses(ctx context.Context) {
+	vaultAddressGeneration.Add(1)
+	if !vaultAddressRedisAvailable() {
+		return
+	}
+	if _, err := vaultAddressRedisIncr(ctx, vaultAddressVersionKey); err != nil {
+		logger.Warn(ctx, "Failed to publish vault address cache invalidation", zap.Error(err))
+	}
+}
+
+// InvalidateVaultAddresses forgets every cached gateway vault, on every replica. Call it after
+// contracts change so the next ERC20 payment resolves the spender again.
+func (u *PaymentUsecase) InvalidateVaultAddresses(ctx context.Context) {
+	invalidateVaultAddresses(ctx)
+}
@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/internal/infrastructure/blockchain"
+)
+
+func TestPaymentUsecase_ResolveVaultAddressForApproval_Cached(t *testing.T) {
+	prevAvailable := vaultAddressRedisAvailable
+	t.Cleanup(func() { vaultAddressRedisAvailable = prevAvailable })
+	vaultAddressRedisAvailable = func() bool { return false }
+
+	vault := common.HexToAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	answer := vault
+	calls := 0
+	srv := newPaymentRPCServer(t, func(_ int, data string) string {
+		require.Equal(t, "0xfbfa77cf", data)
+		calls++
+		return "0x" + hex.EncodeToString(common.LeftPadBytes(answer.Bytes(), 32))
+	})
+	defer srv.Close()
+
+	chainID := uuid.New()
+	now := time.Unix(1_700_000_000, 0)
+	cache := newVaultAddressCache()
+	cache.now = func() time.Time { return now }
+	scRepo := &scRepoStub{getActiveFn: func(context.Context, uuid.UUID, entities.SmartContractType) (*entities.SmartContract, error) {
+		return nil, domainerrors.ErrNotFound
+	}}
+	u := &PaymentUsecase{
+		contractRepo:     scRepo,
+		chainRepo:        &approvalChainRepoStub{chain: &entities.Chain{ID: chainID, RPCURL: srv.URL}},
+		clientFactory:    NewEVMClientFactory(blockchain.NewClientFactory()),
+		vaultAddresses:   cache,
+		ABIResolverMixin: NewABIResolverMixin(scRepo),
+	}
+	const gateway = "0x1111111111111111111111111111111111111111"
+
+	require.Equal(t, vault.Hex(), u.ResolveVaultAddressForApproval(chainID, gateway))
+	require.Equal(t, vault.Hex(), u.ResolveVaultAddressForApproval(chainID, "0x1111111111111111111111111111111111111111"))
+	require.Equal(t, 1, calls)
+
+	// Another gateway on the chain is its own entry
+	require.Equal(t, vault.Hex(), u.ResolveVaultAddressForApproval(chainID, "0x3333333333333333333333333333333333333333"))
+	require.Equal(t, 2, calls)
+
+	// A contract edit forgets everything
+	answer = common.HexToAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	u.InvalidateVaultAddresses(context.Background())
+	require.Equal(t, answer.Hex(), u.ResolveVaultAddressForApproval(chainID, gateway))
+	require.Equal(t, 3, calls)
+
+	// So does time, for a vault repointed on-chain
+	now = now.Add(vaultAddressCacheTTL)
+	require.Equal(t, answer.Hex(), u.ResolveVaultAddressForApproval(chainID, gateway))
+	require.Equal(t, 4, calls)
+
+	// An unset vault is not remembered
+	answer = common.Address{}
+	u.InvalidateVaultAddresses(context.Background())
+	u.ResolveVaultAddressForApproval(chainID, gateway)
+	u.ResolveVaultAddressForApproval(chainID, gateway)
+	require.Equal(t, 6, calls)
+}
+
+func TestVaultAddressCache_InvalidatedAcrossReplicas(t *testing.T) {
+	prevAvailable, prevGet, prevIncr := vaultAddressRedisAvailable, vaultAddressRedisGet, vaultAddressRedisIncr
+	t.Cleanup(func() {
+		vaultAddressRedisAvailable, vaultAddressRedisGet, vaultAddressRedisIncr = prevAvailable, prevGet, prevIncr
+	})
+	shared := ""
+	vaultAddressRedisAvailable = func() bool { return true }
+	vaultAddressRedisGet = func(context.Context, string) (string, error) {
+		if shared == "" {
+			return "", goredis.Nil
+		}
+		return shared, nil
+	}
+	vaultAddressRedisIncr = func(context.Context, string) (int64, error) {
+		t.Fatal("a replica that does not write must not bump the version")
+		return 0, nil
+	}
+
+	ctx := context.Background()
+	chainID := uuid.New()
+	const gateway = "0x1111111111111111111111111111111111111111"
+	now := time.Unix(1_700_000_000, 0)
+	cache := newVaultAddressCache()
+	cache.now = func() time.Time { return now }
+	cache.put(chainID, gateway, "0xaaaa")
+	_, ok := cache.get(ctx, chainID, gateway)
+	require.True(t, ok)
+
+	// Another replica bumps the version; this one notices on its next check
+	shared = "1"
+	_, ok = cache.get(ctx, chainID, gateway)
+	require.True(t, ok, "the version is not read again before the check interval")
+	now = now.Add(vaultAddressVersionCheckInterval)
+	_, ok = cache.get(ctx, chainID, gateway)
+	require.False(t, ok)
+
+	// A write here clears this process at once and bumps the shared version
+	cache.put(chainID, gateway, "0xbbbb")
+	bumped := false
+	vaultAddressRedisIncr = func(_ context.Context, key string) (int64, error) {
+		require.Equal(t, vaultAddressVersionKey, key)
+		bumped = true
+		return 2, nil
+	}
+	invalidateVaultAddresses(ctx)
+	require.True(t, bumped)
+	_, ok = cache.get(ctx, chainID, gateway)
+	require.False(t, ok)
+}
@@ -0,0 +1,100 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/redis"
+	"payment-kita.backend/pkg/utils"
+)
+
+// WalletChallengeTTL bounds how long an issued wallet challenge can be signed and redeemed
+const WalletChallengeTTL = 5 * time.Minute
+
+var (
+	walletChallengeRedisSet     = redis.Set
+	walletChallengeRedisConsume = redis.DelIfValue
+)
+
+func walletChallengeKey(nonce string) string {
+	return "wallet_challenge:" + nonce
+}
+
+// walletChallengeBinding is what a challenge is stored under, so it can only be redeemed for
+// the account and address it was issued to
+func walletChallengeBinding(account, address string) string {
+	return strings.ToLower(strings.TrimSpace(account)) + "|" + strings.ToLower(strings.TrimSpace(address))
+}
+
+// walletChallengeMessage is the text the wallet signs with personal_sign (EIP-191)
+func walletChallengeMessage(account, address, nonce string) string {
+	return fmt.Sprintf(
+		"Payment-Kita wallet ownership\n\nAccount: %s\nAddress: %s\nNonce: %s",
+		strings.ToLower(strings.TrimSpace(account)), strings.TrimSpace(address), nonce,
+	)
+}
+
+// issueWalletChallenge stores a single-use nonce bound to account (the user's email or ID) and
+// address, and returns the message the wallet has to sign to prove it holds the address.
+func issueWalletChallenge(ctx context.Context, account, address string) (*entities.WalletChallenge, error) {
+	if strings.TrimSpace(account) == "" || strings.TrimSpace(address) == "" {
+		return nil, domainerrors.ErrBadRequest
+	}
+	nonce := utils.GenerateUUIDv7().String()
+	if err := walletChallengeRedisSet(ctx, walletChallengeKey(nonce), walletChallengeBinding(account, address), WalletChallengeTTL); err != nil {
+		return nil, walletChallengeUnavailable(err)
+	}
+	return &entities.WalletChallenge{
+		Nonce:     nonce,
+		Message:   walletChallengeMessage(account, address, nonce),
+		ExpiresAt: time.Now().Add(WalletChallengeTTL),
+	}, nil
+}
+
+// verifyWalletOwnership checks that signature is a personal_sign of the challenge issued for
+// account and address under nonce, then burns the nonce so the signature cannot be replayed.
+// Only EVM signatures can be checked so far, so ownership on other chains cannot be proven.
+func verifyWalletOwnership(ctx context.Context, chain *entities.Chain, account, address, nonce, signatureHex string) error {
+	if !chain.Type.IsEVM() {
+		return domainerrors.Forbidden("wallet ownership cannot be verified on this chain, so the wallet cannot be claimed")
+	}
+	nonce = strings.TrimSpace(nonce)
+	signature, err := hexutil.Decode(strings.TrimSpace(signatureHex))
+	if err != nil || len(signature) != crypto.SignatureLength || nonce == "" {
+		return domainerrors.BadRequest("signature must be a 65-byte hex personal_sign of the wallet challenge for nonce")
+	}
+	// Wallets produce v as 27/28; SigToPub wants 0/1
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	message := walletChallengeMessage(account, address, nonce)
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(message)), signature)
+	if err != nil || crypto.PubkeyToAddress(*pub) != common.HexToAddress(address) {
+		return domainerrors.Forbidden("signature was not made by the wallet's key")
+	}
+	consumed, err := walletChallengeRedisConsume(ctx, walletChallengeKey(nonce), walletChallengeBinding(account, address))
+	if err != nil {
+		return walletChallengeUnavailable(err)
+	}
+	if !consumed {
+		return domainerrors.Forbidden("wallet challenge is unknown, expired, already used or issued for another account")
+	}
+	return nil
+}
+
+func walletChallengeUnavailable(err error) error {
+	if errors.Is(err, redis.ErrUnavailable) {
+		return domainerrors.NewAppError(http.StatusServiceUnavailable, domainerrors.CodeInternalError, "wallet challenges are unavailable, try again later", err)
+	}
+	return err
+}
@@ -62,41 +62,46 @@ func (u *WalletUsecase) ConnectWallet(ctx context.Context, userID uuid.UUID, inp
 		}
 	}
 
-	// TODO: Verify signature
-	// This would involve:
-	// 1. Recover signer from signature
-	// 2. Compare recovered address with input.Address
-	// 3. Verify message format and timestamp
-
 	// Check if wallet already exists
-	checkChainID, _, err := u.resolver.ResolveFromAny(ctx, input.ChainID)
+	chain, err := u.resolver.ResolveChain(ctx, input.ChainID)
 	if err != nil {
 		return nil, domainerrors.ErrInvalidInput
 	}
-	existingWallet, err := u.walletRepo.GetByAddress(ctx, checkChainID, input.Address)
+	if err := ValidateAddressForChain(chain, input.Address); err != nil {
+		return nil, err
+	}
+	existingWallet, err := u.walletRepo.GetByAddress(ctx, chain.ID, input.Address)
 	if err != nil && !errors.Is(err, domainerrors.ErrNotFound) {
 		return nil, err
 	}
+	// First wallet is set as primary
+	isPrimary := len(existingWallets) == 0
+
 	if existingWallet != nil {
 		if existingWallet.UserID != nil && *existingWallet.UserID == userID {
 			return existingWallet, nil // Already connected
 		}
-		return nil, domainerrors.ErrAlreadyExists // Wallet belongs to another user
-	}
-
-	// First wallet is set as primary
-	isPrimary := len(existingWallets) == 0
-
-	// Parse chain ID to uuid
-	chainID, _, err := u.resolver.ResolveFromAny(ctx, input.ChainID)
-	if err != nil {
-		return nil, domainerrors.ErrInvalidInput
+		if existingWallet.UserID != nil {
+			return nil, domainerrors.ErrAlreadyExists // Wallet belongs to another user
+		}
+		// Unowned wallet: knowing the address proves nothing, so the claim needs the wallet's
+		// signature over a challenge issued to this user. The conditional update then makes
+		// sure that, if another request adopts it concurrently, only one wins.
+		if err := verifyWalletOwnership(ctx, chain, userID.String(), input.Address, input.Nonce, input.Signature); err != nil {
+			return nil, err
+		}
+		if err := u.walletRepo.ClaimUnowned(ctx, existingWallet.ID, userID, isPrimary); err != nil {
+			return nil, err
+		}
+		existingWallet.UserID = &userID
+		existingWallet.IsPrimary = isPrimary
+		return existingWallet, nil
 	}
 
 	// Create wallet with null.String for UserID
 	wallet := &entities.Wallet{
 		UserID:    &userID,
-		ChainID:   chainID,
+		ChainID:   chain.ID,
 		Address:   input.Address,
 		IsPrimary: isPrimary,
 	}
@@ -131,3 +136,9 @@ func (u *WalletUsecase) DisconnectWallet(ctx context.Context, userID, walletID u
 
 	return u.walletRepo.SoftDelete(ctx, walletID)
 }
+
+// IssueWalletChallenge returns a single-use challenge for address, bound to userID, that the
+// wallet signs to prove ownership in ConnectWallet
+func (u *WalletUsecase) IssueWalletChallenge(ctx context.Context, userID uuid.UUID, input *entities.WalletChallengeInput) (*entities.WalletChallenge, error) {
+	return issueWalletChallenge(ctx, userID.String(), input.Address)
+}
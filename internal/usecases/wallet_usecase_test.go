@@ -2,15 +2,24 @@ package usecases_test
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
+	"net/http"
 	"testing"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
+	redisv9 "github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"payment-kita.backend/internal/domain/entities"
 	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/usecases"
+	redispkg "payment-kita.backend/pkg/redis"
 )
 
 func TestWalletUsecase_ConnectWallet_BadInput(t *testing.T) {
@@ -33,7 +42,7 @@ func TestWalletUsecase_ConnectWallet_ExistingWalletSameUser(t *testing.T) {
 	chainUUID := uuid.New()
 	input := &entities.ConnectWalletInput{
 		ChainID: "eip155:8453",
-		Address: "0xabc",
+		Address: "0xabc0000000000000000000000000000000000000",
 	}
 	user := &entities.User{ID: userID, Role: entities.UserRoleUser, KYCStatus: entities.KYCFullyVerified}
 	existing := &entities.Wallet{ID: uuid.New(), UserID: &userID, ChainID: chainUUID, Address: input.Address}
@@ -52,6 +61,132 @@ func TestWalletUsecase_ConnectWallet_ExistingWalletSameUser(t *testing.T) {
 	assert.Equal(t, existing.ID, got.ID)
 }
 
+// useWalletChallengeRedis points the redis package at a fresh miniredis for wallet challenges
+func useWalletChallengeRedis(t *testing.T) {
+	t.Helper()
+	srv, err := miniredis.Run()
+	if err != nil {
+		t.Skipf("skip: miniredis unavailable: %v", err)
+	}
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { redispkg.SetClient(nil) })
+	redispkg.SetClient(redisv9.NewClient(&redisv9.Options{Addr: srv.Addr()}))
+}
+
+// signWalletChallenge is the personal_sign a wallet produces over message
+func signWalletChallenge(t *testing.T, key *ecdsa.PrivateKey, message string) string {
+	t.Helper()
+	signature, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	require.NoError(t, err)
+	signature[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(signature)
+}
+
+func requireAppStatus(t *testing.T, err error, status int) {
+	t.Helper()
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, status, appErr.Status)
+}
+
+func TestWalletUsecase_ConnectWallet_ClaimsUnownedWallet(t *testing.T) {
+	useWalletChallengeRedis(t)
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	userID := uuid.New()
+	chainUUID := uuid.New()
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	user := &entities.User{ID: userID, Role: entities.UserRoleUser}
+	chain := &entities.Chain{ID: chainUUID, Type: entities.ChainTypeEVM, ChainID: "8453"}
+
+	setup := func(chain *entities.Chain, claimErr error) (*usecases.WalletUsecase, *MockWalletRepository, *entities.Wallet) {
+		mockWalletRepo := new(MockWalletRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockChainRepo := new(MockChainRepository)
+		unowned := &entities.Wallet{ID: uuid.New(), ChainID: chainUUID, Address: address}
+
+		mockUserRepo.On("GetByID", context.Background(), userID).Return(user, nil)
+		mockWalletRepo.On("GetByUserID", context.Background(), userID).Return([]*entities.Wallet{}, nil)
+		mockChainRepo.On("GetByCAIP2", context.Background(), mock.Anything).Return(chain, nil)
+		mockWalletRepo.On("GetByAddress", context.Background(), chainUUID, mock.Anything).Return(unowned, nil)
+		mockWalletRepo.On("ClaimUnowned", context.Background(), unowned.ID, userID, true).Return(claimErr).Once()
+		return usecases.NewWalletUsecase(mockWalletRepo, mockUserRepo, mockChainRepo), mockWalletRepo, unowned
+	}
+	// signedInput asks uc for a challenge bound to forUser and has key sign it
+	signedInput := func(uc *usecases.WalletUsecase, forUser uuid.UUID, key *ecdsa.PrivateKey) *entities.ConnectWalletInput {
+		challenge, err := uc.IssueWalletChallenge(context.Background(), forUser, &entities.WalletChallengeInput{Address: address})
+		require.NoError(t, err)
+		return &entities.ConnectWalletInput{
+			ChainID:   "eip155:8453",
+			Address:   address,
+			Nonce:     challenge.Nonce,
+			Signature: signWalletChallenge(t, key, challenge.Message),
+		}
+	}
+
+	t.Run("claim wins", func(t *testing.T) {
+		uc, walletRepo, unowned := setup(chain, nil)
+		got, err := uc.ConnectWallet(context.Background(), userID, signedInput(uc, userID, key))
+		assert.NoError(t, err)
+		assert.Equal(t, unowned.ID, got.ID)
+		assert.Equal(t, userID, *got.UserID)
+		assert.True(t, got.IsPrimary)
+		walletRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("concurrent claim loses", func(t *testing.T) {
+		uc, walletRepo, _ := setup(chain, domainerrors.ErrAlreadyExists)
+		_, err := uc.ConnectWallet(context.Background(), userID, signedInput(uc, userID, key))
+		assert.ErrorIs(t, err, domainerrors.ErrAlreadyExists)
+		walletRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("replayed signature is refused", func(t *testing.T) {
+		uc, walletRepo, _ := setup(chain, domainerrors.ErrAlreadyExists)
+		input := signedInput(uc, userID, key)
+		_, err := uc.ConnectWallet(context.Background(), userID, input)
+		require.ErrorIs(t, err, domainerrors.ErrAlreadyExists)
+		_, err = uc.ConnectWallet(context.Background(), userID, input)
+		requireAppStatus(t, err, http.StatusForbidden)
+		walletRepo.AssertNumberOfCalls(t, "ClaimUnowned", 1)
+	})
+
+	t.Run("challenge issued to another user is refused", func(t *testing.T) {
+		uc, walletRepo, _ := setup(chain, nil)
+		_, err := uc.ConnectWallet(context.Background(), userID, signedInput(uc, uuid.New(), key))
+		requireAppStatus(t, err, http.StatusForbidden)
+		walletRepo.AssertNotCalled(t, "ClaimUnowned", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("signature by another key is refused", func(t *testing.T) {
+		other, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		uc, walletRepo, _ := setup(chain, nil)
+		_, err = uc.ConnectWallet(context.Background(), userID, signedInput(uc, userID, other))
+		requireAppStatus(t, err, http.StatusForbidden)
+		walletRepo.AssertNotCalled(t, "ClaimUnowned", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("malformed signature is refused", func(t *testing.T) {
+		uc, walletRepo, _ := setup(chain, nil)
+		malformed := signedInput(uc, userID, key)
+		malformed.Signature = "0x1234"
+		_, err := uc.ConnectWallet(context.Background(), userID, malformed)
+		requireAppStatus(t, err, http.StatusBadRequest)
+		walletRepo.AssertNotCalled(t, "ClaimUnowned", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unverifiable chain is refused", func(t *testing.T) {
+		uc, walletRepo, _ := setup(&entities.Chain{ID: chainUUID, Type: entities.ChainTypeSVM, ChainID: "5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp"}, nil)
+		solana := signedInput(uc, userID, key)
+		solana.ChainID = "solana:5eykt4UsFv8P8NJdTREpY1vzqKqZKvdp"
+		solana.Address = "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+		_, err := uc.ConnectWallet(context.Background(), userID, solana)
+		requireAppStatus(t, err, http.StatusForbidden)
+		walletRepo.AssertNotCalled(t, "ClaimUnowned", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func TestWalletUsecase_ConnectWallet_KYCRequiredForAdditionalWallet(t *testing.T) {
 	mockWalletRepo := new(MockWalletRepository)
 	mockUserRepo := new(MockUserRepository)
@@ -66,7 +201,7 @@ func TestWalletUsecase_ConnectWallet_KYCRequiredForAdditionalWallet(t *testing.T
 
 	_, err := uc.ConnectWallet(context.Background(), userID, &entities.ConnectWalletInput{
 		ChainID: "eip155:8453",
-		Address: "0xabc",
+		Address: "0xabc0000000000000000000000000000000000000",
 	})
 	assert.Error(t, err)
 	assert.Equal(t, domainerrors.ErrForbidden.Error(), err.Error())
@@ -117,7 +252,7 @@ func TestWalletUsecase_ConnectWallet_ErrorBranches(t *testing.T) {
 
 		_, err := uc.ConnectWallet(context.Background(), userID, &entities.ConnectWalletInput{
 			ChainID: "eip155:8453",
-			Address: "0xabc",
+			Address: "0xabc0000000000000000000000000000000000000",
 		})
 		assert.EqualError(t, err, "user repo down")
 	})
@@ -134,7 +269,7 @@ func TestWalletUsecase_ConnectWallet_ErrorBranches(t *testing.T) {
 
 		_, err := uc.ConnectWallet(context.Background(), userID, &entities.ConnectWalletInput{
 			ChainID: "eip155:8453",
-			Address: "0xabc",
+			Address: "0xabc0000000000000000000000000000000000000",
 		})
 		assert.EqualError(t, err, "wallet repo down")
 	})
@@ -155,7 +290,7 @@ func TestWalletUsecase_ConnectWallet_ErrorBranches(t *testing.T) {
 
 		_, err := uc.ConnectWallet(context.Background(), userID, &entities.ConnectWalletInput{
 			ChainID: "bad-chain",
-			Address: "0xabc",
+			Address: "0xabc0000000000000000000000000000000000000",
 		})
 		assert.ErrorIs(t, err, domainerrors.ErrInvalidInput)
 	})
@@ -169,7 +304,7 @@ func TestWalletUsecase_ConnectWallet_ErrorBranches(t *testing.T) {
 		userID := uuid.New()
 		otherUserID := uuid.New()
 		chainUUID := uuid.New()
-		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "0xdup"}
+		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "0xd0b0000000000000000000000000000000000000"}
 		user := &entities.User{ID: userID, Role: entities.UserRoleUser, KYCStatus: entities.KYCFullyVerified}
 		existing := &entities.Wallet{ID: uuid.New(), UserID: &otherUserID, ChainID: chainUUID, Address: input.Address}
 
@@ -196,7 +331,7 @@ func TestWalletUsecase_ConnectWallet_CreateBranches(t *testing.T) {
 
 		userID := uuid.New()
 		chainUUID := uuid.New()
-		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "0xnew"}
+		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "0x0e10000000000000000000000000000000000000"}
 		user := &entities.User{ID: userID, Role: entities.UserRoleUser, KYCStatus: entities.KYCFullyVerified}
 
 		mockUserRepo.On("GetByID", context.Background(), userID).Return(user, nil).Once()
@@ -221,7 +356,7 @@ func TestWalletUsecase_ConnectWallet_CreateBranches(t *testing.T) {
 
 		userID := uuid.New()
 		chainUUID := uuid.New()
-		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "0xadmin"}
+		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "0xad00000000000000000000000000000000000000"}
 		user := &entities.User{ID: userID, Role: entities.UserRoleAdmin, KYCStatus: entities.KYCNotStarted}
 
 		mockUserRepo.On("GetByID", context.Background(), userID).Return(user, nil).Once()
@@ -272,7 +407,7 @@ func TestWalletUsecase_GetWallets_And_SetPrimary(t *testing.T) {
 	assert.NoError(t, err)
 }
 
-func TestWalletUsecase_ConnectWallet_GetByAddressErrorAndSecondResolveFail(t *testing.T) {
+func TestWalletUsecase_ConnectWallet_GetByAddressErrorAndAddressMismatch(t *testing.T) {
 	t.Run("get by address returns unexpected error", func(t *testing.T) {
 		mockWalletRepo := new(MockWalletRepository)
 		mockUserRepo := new(MockUserRepository)
@@ -281,7 +416,7 @@ func TestWalletUsecase_ConnectWallet_GetByAddressErrorAndSecondResolveFail(t *te
 
 		userID := uuid.New()
 		chainUUID := uuid.New()
-		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "0xerr"}
+		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "0xe0e0000000000000000000000000000000000000"}
 		user := &entities.User{ID: userID, Role: entities.UserRoleUser, KYCStatus: entities.KYCFullyVerified}
 
 		mockUserRepo.On("GetByID", context.Background(), userID).Return(user, nil).Once()
@@ -297,32 +432,28 @@ func TestWalletUsecase_ConnectWallet_GetByAddressErrorAndSecondResolveFail(t *te
 		assert.EqualError(t, err, "lookup fail")
 	})
 
-	t.Run("second resolver call fails after first success", func(t *testing.T) {
+	t.Run("address does not match chain type", func(t *testing.T) {
 		mockWalletRepo := new(MockWalletRepository)
 		mockUserRepo := new(MockUserRepository)
 		mockChainRepo := new(MockChainRepository)
 		uc := usecases.NewWalletUsecase(mockWalletRepo, mockUserRepo, mockChainRepo)
 
 		userID := uuid.New()
-		chainUUID := uuid.New()
-		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "0xnewfail"}
+		input := &entities.ConnectWalletInput{ChainID: "eip155:8453", Address: "11111111111111111111111111111111"}
 		user := &entities.User{ID: userID, Role: entities.UserRoleUser, KYCStatus: entities.KYCFullyVerified}
 
 		mockUserRepo.On("GetByID", context.Background(), userID).Return(user, nil).Once()
 		mockWalletRepo.On("GetByUserID", context.Background(), userID).Return([]*entities.Wallet{}, nil).Once()
-		// first ResolveFromAny -> success
 		mockChainRepo.On("GetByCAIP2", context.Background(), input.ChainID).Return(&entities.Chain{
-			ID:      chainUUID,
+			ID:      uuid.New(),
 			Type:    entities.ChainTypeEVM,
 			ChainID: "8453",
 		}, nil).Once()
-		mockWalletRepo.On("GetByAddress", context.Background(), chainUUID, input.Address).Return(nil, domainerrors.ErrNotFound).Once()
-		// second ResolveFromAny -> fail
-		mockChainRepo.On("GetByCAIP2", context.Background(), input.ChainID).Return(nil, domainerrors.ErrNotFound).Once()
-		mockChainRepo.On("GetByChainID", context.Background(), input.ChainID).Return(nil, domainerrors.ErrNotFound).Once()
-		mockChainRepo.On("GetByChainID", context.Background(), "8453").Return(nil, domainerrors.ErrNotFound).Once()
 
 		_, err := uc.ConnectWallet(context.Background(), userID, input)
-		assert.ErrorIs(t, err, domainerrors.ErrInvalidInput)
+		var appErr *domainerrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, domainerrors.CodeInvalidAddress, appErr.Code)
+		mockWalletRepo.AssertNotCalled(t, "GetByAddress", mock.Anything, mock.Anything, mock.Anything)
 	})
 }
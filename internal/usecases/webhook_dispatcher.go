@@ -7,18 +7,35 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"payment-kita.backend/internal/domain/entities"
 	"payment-kita.backend/internal/domain/repositories"
-	"payment-kita.backend/internal/domain/services"
 	"payment-kita.backend/internal/infrastructure/metrics"
+	"payment-kita.backend/pkg/webhook"
 )
 
 const (
 	webhookMaxRetries = 10
 )
 
+// WebhookTestEventType is the event sent by the merchant "send test webhook" action
+const WebhookTestEventType = "payment.completed"
+
+// WebhookTestResult is what a merchant endpoint answered to a test delivery. The response
+// body is never echoed back and Error is a short category, not the raw transport error.
+type WebhookTestResult struct {
+	DeliveryID string `json:"deliveryId"`
+	EventType  string `json:"eventType"`
+	URL        string `json:"url"`
+	Delivered  bool   `json:"delivered"`
+	HttpStatus int    `json:"httpStatus,omitempty"`
+	LatencyMs  int64  `json:"latencyMs"`
+	Error      string `json:"error,omitempty"`
+}
+
 var webhookRetrySchedule = []time.Duration{
 	1 * time.Minute,
 	2 * time.Minute,
@@ -39,22 +56,22 @@ func WebhookMaxRetries() int {
 type WebhookDispatcher struct {
 	webhookLogRepo repositories.WebhookLogRepository
 	merchantRepo   repositories.MerchantRepository
-	hmacService    services.HMACService
 	httpClient     *http.Client
+	// testClient serves merchant-triggered test deliveries and only reaches public addresses
+	testClient *http.Client
 }
 
 func NewWebhookDispatcher(
 	webhookLogRepo repositories.WebhookLogRepository,
 	merchantRepo repositories.MerchantRepository,
-	hmacService services.HMACService,
 ) *WebhookDispatcher {
 	return &WebhookDispatcher{
 		webhookLogRepo: webhookLogRepo,
 		merchantRepo:   merchantRepo,
-		hmacService:    hmacService,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		testClient: newWebhookTestClient(),
 	}
 }
 
@@ -78,26 +95,12 @@ func (d *WebhookDispatcher) Dispatch(ctx context.Context, delivery *entities.Web
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	// 3. Generate HMAC Signature
-	timestamp := fmt.Sprintf("%d", time.Now().Unix())
-	signaturePayload := timestamp + "." + string(payloadBytes)
-	signature := d.hmacService.Generate(signaturePayload, merchant.WebhookSecret)
-	legacySignature := d.hmacService.Generate(timestamp+string(payloadBytes), merchant.WebhookSecret)
-
-	// 4. Send Request
-	req, err := http.NewRequestWithContext(ctx, "POST", merchant.CallbackURL, bytes.NewBuffer(payloadBytes))
+	// 3. Sign and build the request
+	req, err := d.newSignedRequest(ctx, merchant, delivery.EventType, delivery.ID.String(), payloadBytes)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-Webhook-Signature", signature)
-	req.Header.Set("X-Webhook-Signature-Legacy", legacySignature)
-	req.Header.Set("X-Webhook-Timestamp", timestamp)
-	req.Header.Set("X-Webhook-Event", delivery.EventType)
-	req.Header.Set("X-Webhook-Delivery-Id", delivery.ID.String())
-	req.Header.Set("User-Agent", "PaymentKita-Webhook-Dispatcher/1.0")
-
 	now := time.Now()
 	delivery.LastAttemptAt = &now
 	delivery.NextRetryAt = nil
@@ -116,7 +119,7 @@ func (d *WebhookDispatcher) Dispatch(ctx context.Context, delivery *entities.Web
 	}
 	defer resp.Body.Close()
 
-	// 5. Update Status
+	// 4. Update Status
 	delivery.HttpStatus = resp.StatusCode
 	body, _ := io.ReadAll(resp.Body)
 	delivery.ResponseBody = string(body)
@@ -135,6 +138,86 @@ func (d *WebhookDispatcher) Dispatch(ctx context.Context, delivery *entities.Web
 	return d.webhookLogRepo.Update(ctx, delivery)
 }
 
+// newSignedRequest builds a webhook POST signed with the merchant secret by package webhook, the
+// same code receivers verify with. The canonical signature covers "timestamp.payload"; the
+// legacy one covers "timestamp+payload" for older receivers.
+func (d *WebhookDispatcher) newSignedRequest(ctx context.Context, merchant *entities.Merchant, eventType, deliveryID string, payloadBytes []byte) (*http.Request, error) {
+	timestamp := fmt.Sprintf("%d", time.Now().Unix())
+	signature := webhook.Sign(merchant.WebhookSecret, timestamp, payloadBytes)
+	legacySignature := webhook.SignLegacy(merchant.WebhookSecret, timestamp, payloadBytes)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", merchant.CallbackURL, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.SignatureHeader, signature)
+	req.Header.Set(webhook.LegacySignatureHeader, legacySignature)
+	req.Header.Set(webhook.TimestampHeader, timestamp)
+	req.Header.Set(webhook.EventHeader, eventType)
+	req.Header.Set(webhook.DeliveryHeader, deliveryID)
+	req.Header.Set("User-Agent", "PaymentKita-Webhook-Dispatcher/1.0")
+	return req, nil
+}
+
+// SendTest posts a sample signed event to the merchant callback URL and reports what the
+// endpoint answered. Nothing is persisted and the result is returned even when the endpoint
+// is unreachable, so merchants can debug their receiver. The callback must resolve to a public
+// address and redirects are not followed, so the action cannot probe internal services.
+func (d *WebhookDispatcher) SendTest(ctx context.Context, merchant *entities.Merchant) (*WebhookTestResult, error) {
+	deliveryID := uuid.New()
+	payloadBytes, err := json.Marshal(sampleWebhookTestPayload(merchant, deliveryID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := d.newSignedRequest(ctx, merchant, WebhookTestEventType, deliveryID.String(), payloadBytes)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Webhook-Test", "true")
+
+	result := &WebhookTestResult{
+		DeliveryID: deliveryID.String(),
+		EventType:  WebhookTestEventType,
+		URL:        merchant.CallbackURL,
+	}
+	start := time.Now()
+	resp, err := d.testClient.Do(req)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = describeWebhookTestError(err)
+		return result, nil
+	}
+	defer resp.Body.Close()
+
+	result.HttpStatus = resp.StatusCode
+	result.Delivered = resp.StatusCode >= 200 && resp.StatusCode < 300
+	return result, nil
+}
+
+func sampleWebhookTestPayload(merchant *entities.Merchant, deliveryID uuid.UUID) map[string]interface{} {
+	now := time.Now().UTC()
+	return map[string]interface{}{
+		"event":     WebhookTestEventType,
+		"test":      true,
+		"createdAt": now.Format(time.RFC3339),
+		"data": map[string]interface{}{
+			"paymentId":     deliveryID.String(),
+			"merchantId":    merchant.ID.String(),
+			"status":        string(entities.PaymentStatusCompleted),
+			"sourceChainId": "eip155:8453",
+			"destChainId":   "eip155:8453",
+			"tokenSymbol":   "USDC",
+			"amount":        "1000000",
+			"decimals":      6,
+			"txHash":        "0x" + strings.Repeat("0", 64),
+			"completedAt":   now.Format(time.RFC3339),
+		},
+	}
+}
+
 func setNextRetryAt(delivery *entities.WebhookDelivery) {
 	if delivery == nil {
 		return
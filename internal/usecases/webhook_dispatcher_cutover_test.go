@@ -12,7 +12,6 @@ import (
 	"github.com/volatiletech/null/v8"
 	"payment-kita.backend/internal/domain/entities"
 	domainrepos "payment-kita.backend/internal/domain/repositories"
-	servicesimpl "payment-kita.backend/internal/infrastructure/services"
 )
 
 type fakeMerchantRepo struct {
@@ -24,7 +23,7 @@ func (f *fakeMerchantRepo) GetByID(ctx context.Context, id uuid.UUID) (*entities
 	return f.merchant, nil
 }
 func (f *fakeMerchantRepo) GetByUserID(ctx context.Context, userID uuid.UUID) (*entities.Merchant, error) {
-	return nil, nil
+	return f.merchant, nil
 }
 func (f *fakeMerchantRepo) Update(ctx context.Context, merchant *entities.Merchant) error { return nil }
 func (f *fakeMerchantRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status entities.MerchantStatus) error {
@@ -89,7 +88,7 @@ func TestWebhookDispatcher_CutoverHeadersAndLegacyCompatibility(t *testing.T) {
 		WebhookIsActive: true,
 	}
 	webhookRepo := &fakeWebhookLogRepo{}
-	dispatcher := NewWebhookDispatcher(webhookRepo, &fakeMerchantRepo{merchant: merchant}, servicesimpl.NewHMACService())
+	dispatcher := NewWebhookDispatcher(webhookRepo, &fakeMerchantRepo{merchant: merchant})
 	transport := &captureRoundTripper{statusCode: http.StatusOK}
 	dispatcher.httpClient = &http.Client{Transport: transport}
 
@@ -136,7 +135,7 @@ func TestWebhookDispatcher_SetsNextRetryAtOnFailure(t *testing.T) {
 		WebhookIsActive: true,
 	}
 	webhookRepo := &fakeWebhookLogRepo{}
-	dispatcher := NewWebhookDispatcher(webhookRepo, &fakeMerchantRepo{merchant: merchant}, servicesimpl.NewHMACService())
+	dispatcher := NewWebhookDispatcher(webhookRepo, &fakeMerchantRepo{merchant: merchant})
 	transport := &captureRoundTripper{statusCode: http.StatusInternalServerError}
 	dispatcher.httpClient = &http.Client{Transport: transport}
 
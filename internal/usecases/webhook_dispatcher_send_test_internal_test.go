@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
+	"payment-kita.backend/pkg/crypto"
+	"payment-kita.backend/pkg/webhook"
+)
+
+type failingRoundTripper struct{}
+
+func (failingRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestWebhookDispatcher_SendTest_SignsSamplePaymentCompleted(t *testing.T) {
+	merchant := &entities.Merchant{
+		ID:            uuid.New(),
+		CallbackURL:   "https://merchant.example/webhook",
+		WebhookSecret: "super-secret",
+	}
+	webhookRepo := &fakeWebhookLogRepo{}
+	dispatcher := NewWebhookDispatcher(webhookRepo, &fakeMerchantRepo{merchant: merchant})
+	transport := &captureRoundTripper{statusCode: http.StatusAccepted}
+	dispatcher.testClient = &http.Client{Transport: transport}
+
+	result, err := dispatcher.SendTest(context.Background(), merchant)
+	require.NoError(t, err)
+	assert.True(t, result.Delivered)
+	assert.Equal(t, http.StatusAccepted, result.HttpStatus)
+	assert.GreaterOrEqual(t, result.LatencyMs, int64(0))
+
+	req := transport.lastRequest
+	require.NotNil(t, req)
+	assert.Equal(t, WebhookTestEventType, req.Header.Get("X-Webhook-Event"))
+	assert.Equal(t, "true", req.Header.Get("X-Webhook-Test"))
+	assert.Equal(t, result.DeliveryID, req.Header.Get("X-Webhook-Delivery-Id"))
+	signed := req.Header.Get("X-Webhook-Timestamp") + "." + transport.lastBody
+	assert.True(t, crypto.VerifyHMAC(signed, merchant.WebhookSecret, req.Header.Get("X-Webhook-Signature")))
+	assert.NoError(t, webhook.Verify(merchant.WebhookSecret, []byte(transport.lastBody), req.Header.Get("X-Webhook-Timestamp"), req.Header.Get("X-Webhook-Signature"), 0))
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(transport.lastBody), &payload))
+	assert.Equal(t, "payment.completed", payload["event"])
+	assert.Equal(t, true, payload["test"])
+
+	// A test delivery never touches the delivery log
+	assert.Empty(t, webhookRepo.updated)
+}
+
+func TestWebhookDispatcher_SendTest_ReportsTransportAndStatusFailures(t *testing.T) {
+	merchant := &entities.Merchant{ID: uuid.New(), CallbackURL: "https://merchant.example/webhook", WebhookSecret: "s"}
+	dispatcher := NewWebhookDispatcher(&fakeWebhookLogRepo{}, &fakeMerchantRepo{merchant: merchant})
+
+	dispatcher.testClient = &http.Client{Transport: failingRoundTripper{}}
+	result, err := dispatcher.SendTest(context.Background(), merchant)
+	require.NoError(t, err)
+	assert.False(t, result.Delivered)
+	assert.Zero(t, result.HttpStatus)
+	assert.Equal(t, "could not reach the callback URL", result.Error)
+	assert.NotContains(t, result.Error, "connection refused")
+
+	dispatcher.testClient = &http.Client{Transport: &captureRoundTripper{statusCode: http.StatusUnauthorized}}
+	result, err = dispatcher.SendTest(context.Background(), merchant)
+	require.NoError(t, err)
+	assert.False(t, result.Delivered)
+	assert.Equal(t, http.StatusUnauthorized, result.HttpStatus)
+}
+
+type redirectRoundTripper struct {
+	calls atomic.Int32
+}
+
+func (r *redirectRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	r.calls.Add(1)
+	header := make(http.Header)
+	header.Set("Location", "http://169.254.169.254/latest/meta-data/")
+	return &http.Response{
+		StatusCode: http.StatusFound,
+		Body:       io.NopCloser(strings.NewReader("")),
+		Header:     header,
+	}, nil
+}
+
+func TestWebhookDispatcher_SendTest_RefusesNonPublicAddresses(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		_, _ = w.Write([]byte("internal secret"))
+	}))
+	defer server.Close()
+
+	merchant := &entities.Merchant{ID: uuid.New(), CallbackURL: server.URL + "/hook", WebhookSecret: "s"}
+	dispatcher := NewWebhookDispatcher(&fakeWebhookLogRepo{}, &fakeMerchantRepo{merchant: merchant})
+
+	result, err := dispatcher.SendTest(context.Background(), merchant)
+	require.NoError(t, err)
+	assert.False(t, result.Delivered)
+	assert.Zero(t, result.HttpStatus)
+	assert.Equal(t, "callback URL resolves to a non-public address", result.Error)
+	assert.Zero(t, hits.Load())
+}
+
+func TestWebhookDispatcher_SendTest_DoesNotFollowRedirects(t *testing.T) {
+	merchant := &entities.Merchant{ID: uuid.New(), CallbackURL: "https://merchant.example/webhook", WebhookSecret: "s"}
+	dispatcher := NewWebhookDispatcher(&fakeWebhookLogRepo{}, &fakeMerchantRepo{merchant: merchant})
+	transport := &redirectRoundTripper{}
+	dispatcher.testClient.Transport = transport
+
+	result, err := dispatcher.SendTest(context.Background(), merchant)
+	require.NoError(t, err)
+	assert.False(t, result.Delivered)
+	assert.Equal(t, http.StatusFound, result.HttpStatus)
+	assert.Equal(t, int32(1), transport.calls.Load())
+}
+
+func TestIsPublicWebhookAddr(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"8.8.8.8":          true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		assert.Equal(t, public, isPublicWebhookAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestWebhookUsecase_SendTestWebhook_Ownership(t *testing.T) {
+	merchant := &entities.Merchant{ID: uuid.New(), CallbackURL: "https://merchant.example/webhook", WebhookSecret: "s"}
+	merchantRepo := &fakeMerchantRepo{merchant: merchant}
+	dispatcher := NewWebhookDispatcher(&fakeWebhookLogRepo{}, merchantRepo)
+	dispatcher.testClient = &http.Client{Transport: &captureRoundTripper{statusCode: http.StatusOK}}
+	uc := NewWebhookUsecase(nil, nil, nil, nil, merchantRepo, nil, dispatcher, nil)
+
+	result, err := uc.SendTestWebhook(context.Background(), uuid.New(), merchant.ID)
+	require.NoError(t, err)
+	assert.True(t, result.Delivered)
+
+	_, err = uc.SendTestWebhook(context.Background(), uuid.New(), uuid.New())
+	var appErr *domainerrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusForbidden, appErr.Status)
+
+	merchant.CallbackURL = ""
+	_, err = uc.SendTestWebhook(context.Background(), uuid.New(), merchant.ID)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Status)
+
+	merchantRepo.merchant = nil
+	_, err = uc.SendTestWebhook(context.Background(), uuid.New(), merchant.ID)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Status)
+}
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// errWebhookAddressBlocked is returned when a merchant callback resolves to an address the
+// server must never call on a merchant's behalf (loopback, private, link-local, ...)
+var errWebhookAddressBlocked = errors.New("webhook callback resolves to a non-public address")
+
+// cgnatPrefix is the shared address space (RFC 6598), which netip does not flag as private
+var cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+
+// isPublicWebhookAddr reports whether ip is routable on the public internet
+func isPublicWebhookAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() &&
+		!ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified() &&
+		!cgnatPrefix.Contains(ip)
+}
+
+// publicOnlyDialControl runs after DNS resolution, so it checks the address actually dialed and
+// a rebinding resolver cannot swap in an internal one between check and connect
+func publicOnlyDialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errWebhookAddressBlocked, address)
+	}
+	if !isPublicWebhookAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errWebhookAddressBlocked, addrPort.Addr())
+	}
+	return nil
+}
+
+// newWebhookTestClient builds the client for merchant-triggered test deliveries: it only dials
+// public addresses, ignores proxy settings and never follows redirects
+func newWebhookTestClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: publicOnlyDialControl,
+	}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   5 * time.Second,
+			ResponseHeaderTimeout: 5 * time.Second,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// describeWebhookTestError turns a transport failure into a short category so the raw error,
+// which can echo resolved addresses or internal hostnames, never reaches the merchant
+func describeWebhookTestError(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, errWebhookAddressBlocked):
+		return "callback URL resolves to a non-public address"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timed out waiting for the callback URL"
+	default:
+		return "could not reach the callback URL"
+	}
+}
@@ -1,19 +1,24 @@
 package usecases
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"log"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/volatiletech/null/v8"
+	"go.uber.org/zap"
 	"payment-kita.backend/internal/domain/entities"
+	domainerrors "payment-kita.backend/internal/domain/errors"
 	"payment-kita.backend/internal/domain/repositories"
 	"payment-kita.backend/internal/infrastructure/metrics"
+	"payment-kita.backend/pkg/logger"
 )
 
 // WebhookUsecase handles incoming notifications from the indexer
@@ -26,6 +31,8 @@ type WebhookUsecase struct {
 	webhookLogRepo     repositories.WebhookLogRepository
 	dispatcher         *WebhookDispatcher
 	uow                repositories.UnitOfWork
+	chainRepo          repositories.ChainRepository
+	headReader         ChainHeadReader
 }
 
 // NewWebhookUsecase creates a new webhook usecase
@@ -51,6 +58,27 @@ func NewWebhookUsecase(
 	}
 }
 
+// NewWebhookUsecaseWithConfirmations is NewWebhookUsecase holding completions back until they
+// are as deep as the destination chain and the merchant require. With a head reader,
+// RecheckHeldCompletions finishes them once the chain has advanced far enough.
+func NewWebhookUsecaseWithConfirmations(
+	paymentRepo repositories.PaymentRepository,
+	paymentEventRepo repositories.PaymentEventRepository,
+	paymentRequestRepo repositories.PaymentRequestRepository,
+	sessionRepo repositories.PartnerPaymentSessionRepository,
+	merchantRepo repositories.MerchantRepository,
+	webhookLogRepo repositories.WebhookLogRepository,
+	dispatcher *WebhookDispatcher,
+	uow repositories.UnitOfWork,
+	chainRepo repositories.ChainRepository,
+	headReader ChainHeadReader,
+) *WebhookUsecase {
+	u := NewWebhookUsecase(paymentRepo, paymentEventRepo, paymentRequestRepo, sessionRepo, merchantRepo, webhookLogRepo, dispatcher, uow)
+	u.chainRepo = chainRepo
+	u.headReader = headReader
+	return u
+}
+
 // Map indexer event types to backend status
 func mapStatus(indexerStatus string) entities.PaymentStatus {
 	switch indexerStatus {
@@ -71,10 +99,13 @@ func mapStatus(indexerStatus string) entities.PaymentStatus {
 
 // ProcessIndexerWebhook processes a webhook payload from the indexer
 func (u *WebhookUsecase) ProcessIndexerWebhook(ctx context.Context, eventType string, data json.RawMessage) error {
-	log.Printf("Processing indexer event: %s", eventType)
+	logger.Info(ctx, "Processing indexer event", zap.String("event_type", eventType))
 
-	switch eventType {
-	case "PAYMENT_CREATED", "PAYMENT_EXECUTED", "PAYMENT_COMPLETED", "PAYMENT_REFUNDED":
+	switch entities.PaymentEventType(eventType) {
+	case entities.PaymentEventTypeIndexerCreated,
+		entities.PaymentEventTypeIndexerExecuted,
+		entities.PaymentEventTypeIndexerCompleted,
+		entities.PaymentEventTypeIndexerRefunded:
 		var paymentData struct {
 			PaymentId    string `json:"paymentId"`
 			Status       string `json:"status"`
@@ -87,38 +118,82 @@ func (u *WebhookUsecase) ProcessIndexerWebhook(ctx context.Context, eventType st
 
 		paymentUUID, _ := uuid.Parse(paymentData.PaymentId)
 		newStatus := mapStatus(paymentData.Status)
+		payload := parseIndexerPayload(data)
+		confirmations := payload.confirmations()
+		transitioned := false
 
 		// Update payment status with locking to prevent race conditions
 		err := u.uow.Do(ctx, func(txCtx context.Context) error {
 			lockCtx := u.uow.WithLock(txCtx)
 
 			// 1. Get current Payment with Lock
-			_, err := u.paymentRepo.GetByID(lockCtx, paymentUUID)
+			payment, err := u.paymentRepo.GetByID(lockCtx, paymentUUID)
 			if err != nil {
 				return err
 			}
 
-			// 3. Update status
+			// 2. A payment awaiting approval was never handed calldata, so an event for it is
+			// recorded but moves nothing until an admin approves it
+			if payment.Status == entities.PaymentStatusPendingApproval {
+				logger.Warn(ctx, "Indexer event for a payment pending approval ignored",
+					zap.String("payment_id", paymentData.PaymentId),
+					zap.String("event_type", eventType),
+				)
+				newStatus = payment.Status
+			}
+
+			// 3. A completion that is not deep enough yet keeps the payment processing; the
+			// indexer reports the same event again as confirmations grow
+			if newStatus == entities.PaymentStatusCompleted && payment.Status != entities.PaymentStatusCompleted {
+				if required := u.requiredConfirmations(txCtx, payment); confirmations < required {
+					logger.Info(ctx, "Payment completion awaiting confirmations",
+						zap.String("payment_id", paymentData.PaymentId),
+						zap.Int("confirmations", confirmations),
+						zap.Int("required", required),
+					)
+					newStatus = entities.PaymentStatusProcessing
+				}
+			}
+			if payment.Status == entities.PaymentStatusCompleted && newStatus == entities.PaymentStatusProcessing {
+				newStatus = payment.Status
+			}
+			transitioned = payment.Status != newStatus
+
+			// 4. Update status
 			if err := u.paymentRepo.UpdateStatus(lockCtx, paymentUUID, newStatus); err != nil {
 				return err
 			}
 
-			// 4. Create event
+			// 5. Keep the bridge message ID once the indexer knows it
+			details := payload.eventDetails()
+			payment.Status = newStatus
+			if err := u.recordBridgeMessageID(lockCtx, payment, details.BridgeMessageID); err != nil {
+				return err
+			}
+
+			// 6. Create event
 			return u.paymentEventRepo.Create(lockCtx, &entities.PaymentEvent{
-				PaymentID: paymentUUID,
-				EventType: entities.PaymentEventType(eventType),
-				TxHash:    paymentData.SourceTxHash,
-				Metadata:  string(data),
+				PaymentID:     paymentUUID,
+				EventType:     entities.PaymentEventType(eventType),
+				TxHash:        paymentData.SourceTxHash,
+				BlockNumber:   details.BlockNumber,
+				Confirmations: confirmations,
+				Metadata:      string(data),
+				Details:       details,
 			})
 		})
 
 		if err != nil {
-			log.Printf("Error processing payment update: %v", err)
+			logger.Error(ctx, "Failed to process payment update",
+				zap.String("event_type", eventType),
+				zap.String("payment_id", paymentData.PaymentId),
+				zap.Error(err),
+			)
 			return err
 		}
 
-		// Trigger Webhook if terminal state
-		if newStatus == entities.PaymentStatusCompleted || newStatus == entities.PaymentStatusRefunded {
+		// Trigger Webhook once, when the payment reaches a terminal state
+		if transitioned && (newStatus == entities.PaymentStatusCompleted || newStatus == entities.PaymentStatusRefunded) {
 			_ = u.enqueueWebhookDelivery(ctx, paymentUUID, string(newStatus), data)
 
 			// Record Settlement Latency
@@ -130,7 +205,7 @@ func (u *WebhookUsecase) ProcessIndexerWebhook(ctx context.Context, eventType st
 			}
 		}
 
-	case "PAYMENT_FAILED":
+	case entities.PaymentEventTypeIndexerFailed:
 		var failureData struct {
 			PaymentId    string `json:"paymentId"`
 			Status       string `json:"status"`
@@ -162,40 +237,61 @@ func (u *WebhookUsecase) ProcessIndexerWebhook(ctx context.Context, eventType st
 			}
 		}
 
+		failed := true
 		err := u.uow.Do(ctx, func(txCtx context.Context) error {
 			lockCtx := u.uow.WithLock(txCtx)
 			payment, err := u.paymentRepo.GetByID(lockCtx, paymentUUID)
 			if err != nil {
 				return err
 			}
-			payment.Status = newStatus
-			payment.FailureReason.String = decodedReason
-			payment.FailureReason.Valid = decodedReason != ""
-			payment.RevertData.String = failureData.RevertData
-			payment.RevertData.Valid = failureData.RevertData != ""
-
-			if err := u.paymentRepo.Update(lockCtx, payment); err != nil {
-				return err
+			if payment.Status == entities.PaymentStatusPendingApproval {
+				logger.Warn(ctx, "Indexer failure for a payment pending approval ignored",
+					zap.String("payment_id", failureData.PaymentId),
+				)
+				failed = false
+			} else {
+				payment.Status = newStatus
+				payment.FailureReason.String = decodedReason
+				payment.FailureReason.Valid = decodedReason != ""
+				payment.RevertData.String = failureData.RevertData
+				payment.RevertData.Valid = failureData.RevertData != ""
+
+				if err := u.paymentRepo.Update(lockCtx, payment); err != nil {
+					return err
+				}
 			}
 
+			details := parseIndexerPayload(data).eventDetails()
+			details.RevertReason = decodedReason
+			details.RevertData = failureData.RevertData
 			return u.paymentEventRepo.Create(lockCtx, &entities.PaymentEvent{
-				PaymentID: paymentUUID,
-				EventType: entities.PaymentEventType(eventType),
-				TxHash:    failureData.SourceTxHash,
-				Metadata:  string(data),
+				PaymentID:   paymentUUID,
+				EventType:   entities.PaymentEventType(eventType),
+				TxHash:      failureData.SourceTxHash,
+				BlockNumber: details.BlockNumber,
+				Metadata:    string(data),
+				Details:     details,
 			})
 		})
 
 		if err != nil {
-			log.Printf("Error processing payment failure: %v", err)
+			logger.Error(ctx, "Failed to process payment failure",
+				zap.String("payment_id", failureData.PaymentId),
+				zap.Error(err),
+			)
 			return err
 		}
 
 		// Trigger Webhook for failure
-		_ = u.enqueueWebhookDelivery(ctx, paymentUUID, string(entities.PaymentStatusFailed), data)
+		if failed {
+			_ = u.enqueueWebhookDelivery(ctx, paymentUUID, string(entities.PaymentStatusFailed), data)
+		}
+
+	case entities.PaymentEventTypeBridgeMessageSent, entities.PaymentEventTypeBridgeMessageDelivered:
+		return u.processBridgeMessageEvent(ctx, entities.PaymentEventType(eventType), data)
 
 	case "PAYMENT_REQUEST_CREATED":
-		log.Printf("Payment request created on-chain: %s", data)
+		logger.Info(ctx, "Payment request created on-chain", zap.ByteString("data", data))
 
 	case "REQUEST_PAYMENT_RECEIVED":
 		var requestData struct {
@@ -208,25 +304,218 @@ func (u *WebhookUsecase) ProcessIndexerWebhook(ctx context.Context, eventType st
 		}
 
 		requestUUID, _ := uuid.Parse(requestData.Id)
-		err := u.paymentRequestRepo.MarkCompleted(ctx, requestUUID, requestData.TxHash)
+		if u.holdPaymentRequestCompletion(ctx, requestUUID, requestData.TxHash, parseIndexerPayload(data)) {
+			return nil
+		}
+		u.completePaymentRequest(ctx, requestUUID, requestData.TxHash)
+
+	default:
+		logger.Warn(ctx, "Unhandled indexer event type", zap.String("event_type", eventType))
+	}
+
+	return nil
+}
+
+// processBridgeMessageEvent records a cross-chain payment's bridge message being sent or
+// delivered, and stores its message ID on the payment. The status is left alone. The first
+// delivery is forwarded to the merchant as a BRIDGE_MESSAGE_DELIVERED webhook.
+func (u *WebhookUsecase) processBridgeMessageEvent(ctx context.Context, eventType entities.PaymentEventType, data json.RawMessage) error {
+	payload := parseIndexerPayload(data)
+	if payload == nil {
+		return fmt.Errorf("invalid %s payload", eventType)
+	}
+	paymentUUID, _ := uuid.Parse(payload.field("paymentId"))
+	details := payload.eventDetails()
+	firstDelivery := false
+
+	err := u.uow.Do(ctx, func(txCtx context.Context) error {
+		lockCtx := u.uow.WithLock(txCtx)
+		payment, err := u.paymentRepo.GetByID(lockCtx, paymentUUID)
 		if err != nil {
-			log.Printf("Error marking payment request as completed: %v", err)
+			return err
 		}
-		if u.sessionRepo != nil {
-			if session, sessionErr := u.sessionRepo.GetByPaymentRequestID(ctx, requestUUID); sessionErr == nil && session != nil {
-				if markErr := u.sessionRepo.MarkCompleted(ctx, session.ID, requestData.TxHash); markErr != nil {
-					log.Printf("Error marking partner payment session as completed: %v", markErr)
-				}
+
+		if eventType == entities.PaymentEventTypeBridgeMessageDelivered {
+			events, err := u.paymentEventRepo.GetByPaymentID(lockCtx, paymentUUID)
+			if err != nil {
+				return err
 			}
+			firstDelivery = !slices.ContainsFunc(events, func(event *entities.PaymentEvent) bool {
+				return event.EventType == entities.PaymentEventTypeBridgeMessageDelivered
+			})
 		}
 
-	default:
-		log.Printf("Unhandled event type: %s", eventType)
+		if err := u.recordBridgeMessageID(lockCtx, payment, details.BridgeMessageID); err != nil {
+			return err
+		}
+		return u.paymentEventRepo.Create(lockCtx, &entities.PaymentEvent{
+			PaymentID:     paymentUUID,
+			EventType:     eventType,
+			TxHash:        details.TxHash,
+			BlockNumber:   details.BlockNumber,
+			Confirmations: payload.confirmations(),
+			Metadata:      string(data),
+			Details:       details,
+		})
+	})
+	if err != nil {
+		logger.Error(ctx, "Failed to record bridge message event",
+			zap.String("event_type", string(eventType)),
+			zap.String("payment_id", paymentUUID.String()),
+			zap.Error(err),
+		)
+		return err
 	}
 
+	if firstDelivery {
+		_ = u.enqueueWebhookDelivery(ctx, paymentUUID, string(eventType), data)
+	}
 	return nil
 }
 
+// recordBridgeMessageID stores messageID (a CCIP message ID or Hyperbridge request commitment)
+// on the payment unless it is empty or already stored
+func (u *WebhookUsecase) recordBridgeMessageID(ctx context.Context, payment *entities.Payment, messageID string) error {
+	if messageID == "" || payment.CrossChainMessageID.String == messageID {
+		return nil
+	}
+	payment.CrossChainMessageID = null.StringFrom(messageID)
+	return u.paymentRepo.Update(ctx, payment)
+}
+
+// holdPaymentRequestCompletion keeps a paid request PROCESSING while its transaction is shallower
+// than the request chain's and merchant's thresholds. The indexer reporting the event again, or
+// RecheckHeldCompletions, completes it later. It reports whether the completion was held.
+func (u *WebhookUsecase) holdPaymentRequestCompletion(ctx context.Context, requestID uuid.UUID, txHash string, payload indexerPayload) bool {
+	if u.chainRepo == nil {
+		return false
+	}
+	request, err := u.paymentRequestRepo.GetByID(ctx, requestID)
+	if err != nil || request == nil {
+		return false
+	}
+	if request.Status == entities.PaymentRequestStatusCompleted {
+		return false
+	}
+	merchantID := request.MerchantID
+	confirmations := payload.confirmations()
+	required := u.confirmationThreshold(ctx, request.ChainID, &merchantID)
+	if confirmations >= required {
+		return false
+	}
+
+	blockNumber := payload.eventDetails().BlockNumber
+	if err := u.paymentRequestRepo.MarkProcessing(ctx, requestID, txHash, blockNumber); err != nil {
+		logger.Error(ctx, "Failed to mark payment request processing",
+			zap.String("payment_request_id", requestID.String()),
+			zap.Error(err),
+		)
+	}
+	logger.Info(ctx, "Payment request completion awaiting confirmations",
+		zap.String("payment_request_id", requestID.String()),
+		zap.Int("confirmations", confirmations),
+		zap.Int("required", required),
+	)
+	return true
+}
+
+// completePaymentRequest marks a paid request and its partner session completed
+func (u *WebhookUsecase) completePaymentRequest(ctx context.Context, requestID uuid.UUID, txHash string) {
+	if err := u.paymentRequestRepo.MarkCompleted(ctx, requestID, txHash); err != nil {
+		logger.Error(ctx, "Failed to mark payment request completed",
+			zap.String("payment_request_id", requestID.String()),
+			zap.Error(err),
+		)
+	}
+	if u.sessionRepo != nil {
+		if session, sessionErr := u.sessionRepo.GetByPaymentRequestID(ctx, requestID); sessionErr == nil && session != nil {
+			if markErr := u.sessionRepo.MarkCompleted(ctx, session.ID, txHash); markErr != nil {
+				logger.Error(ctx, "Failed to mark partner payment session completed",
+					zap.String("session_id", session.ID.String()),
+					zap.Error(markErr),
+				)
+			}
+		}
+	}
+}
+
+// requiredConfirmations is the deeper of the destination chain's and the merchant's thresholds.
+// Without a chain repository completions are never held back.
+func (u *WebhookUsecase) requiredConfirmations(ctx context.Context, payment *entities.Payment) int {
+	return u.confirmationThreshold(ctx, payment.DestChainID, payment.MerchantID)
+}
+
+// confirmationThreshold is the deeper of chainID's and the merchant's thresholds
+func (u *WebhookUsecase) confirmationThreshold(ctx context.Context, chainID uuid.UUID, merchantID *uuid.UUID) int {
+	if u.chainRepo == nil {
+		return 0
+	}
+	required := 0
+	if chain, err := u.chainRepo.GetByID(ctx, chainID); err == nil && chain != nil {
+		required = chain.MinConfirmations
+	} else if err != nil {
+		logger.Warn(ctx, "Failed to load chain confirmation threshold",
+			zap.String("chain_id", chainID.String()),
+			zap.Error(err),
+		)
+	}
+	if merchantID != nil && u.merchantRepo != nil {
+		if merchant, err := u.merchantRepo.GetByID(ctx, *merchantID); err == nil && merchant != nil {
+			required = max(required, merchant.MinConfirmations)
+		}
+	}
+	return required
+}
+
+// indexerPayload is an indexer webhook body. Fields may come as JSON strings or numbers, and
+// numbers in hex; anything unreadable reads as empty.
+type indexerPayload map[string]json.RawMessage
+
+func parseIndexerPayload(data json.RawMessage) indexerPayload {
+	var payload indexerPayload
+	_ = json.Unmarshal(data, &payload)
+	return payload
+}
+
+// field returns the first of names that is present, as text
+func (p indexerPayload) field(names ...string) string {
+	for _, name := range names {
+		raw := bytes.TrimSpace(p[name])
+		if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return strings.TrimSpace(s)
+		}
+		return string(raw)
+	}
+	return ""
+}
+
+// confirmations is the reported confirmation depth. An event without one counts the block that
+// included it.
+func (p indexerPayload) confirmations() int {
+	if confirmations, err := strconv.ParseInt(p.field("confirmations"), 0, 32); err == nil && confirmations > 0 {
+		return int(confirmations)
+	}
+	return 1
+}
+
+// eventDetails picks the typed event details out of the payload
+func (p indexerPayload) eventDetails() *entities.PaymentEventDetails {
+	details := &entities.PaymentEventDetails{
+		TxHash:          p.field("sourceTxHash", "txHash"),
+		DestTxHash:      p.field("destTxHash"),
+		ObservedAmount:  p.field("amount", "observedAmount"),
+		BridgeMessageID: p.field("bridgeMessageId", "messageId"),
+	}
+	if block, err := strconv.ParseInt(p.field("blockNumber"), 0, 64); err == nil && block > 0 {
+		details.BlockNumber = block
+	}
+	return details
+}
+
 func (u *WebhookUsecase) enqueueWebhookDelivery(ctx context.Context, paymentID uuid.UUID, eventType string, data json.RawMessage) error {
 	payment, err := u.paymentRepo.GetByID(ctx, paymentID)
 	if err != nil || payment.MerchantID == nil {
@@ -246,11 +535,19 @@ func (u *WebhookUsecase) enqueueWebhookDelivery(ctx context.Context, paymentID u
 	}
 
 	if err := u.webhookLogRepo.Create(ctx, delivery); err != nil {
-		log.Printf("[WebhookUsecase] Failed to create delivery log: %v", err)
+		logger.Error(ctx, "Failed to create webhook delivery",
+			zap.String("payment_id", paymentID.String()),
+			zap.String("merchant_id", delivery.MerchantID.String()),
+			zap.Error(err),
+		)
 		return err
 	}
 
-	log.Printf("[WebhookUsecase] Enqueued webhook delivery %s for merchant %s", delivery.ID, delivery.MerchantID)
+	logger.Info(ctx, "Enqueued webhook delivery",
+		zap.String("delivery_id", delivery.ID.String()),
+		zap.String("merchant_id", delivery.MerchantID.String()),
+		zap.String("payment_id", paymentID.String()),
+	)
 	return nil
 }
 
@@ -261,6 +558,35 @@ func (u *WebhookUsecase) ManualRetry(ctx context.Context, deliveryID uuid.UUID)
 		return fmt.Errorf("webhook delivery not found: %w", err)
 	}
 
-	log.Printf("[WebhookUsecase] Manually retrying webhook delivery %s", deliveryID)
+	logger.Info(ctx, "Manually retrying webhook delivery", zap.String("delivery_id", deliveryID.String()))
 	return u.dispatcher.Dispatch(ctx, delivery)
 }
+
+// SendTestWebhook fires a sample signed event at the caller's webhook endpoint. merchantID must be
+// the caller's own merchant; the webhook does not need to be active yet so it can be verified first.
+func (u *WebhookUsecase) SendTestWebhook(ctx context.Context, userID, merchantID uuid.UUID) (*WebhookTestResult, error) {
+	merchant, err := u.merchantRepo.GetByUserID(ctx, userID)
+	if err != nil || merchant == nil {
+		return nil, domainerrors.NotFound("merchant not found")
+	}
+	if merchant.ID != merchantID {
+		return nil, domainerrors.Forbidden("webhook does not belong to merchant")
+	}
+	if strings.TrimSpace(merchant.CallbackURL) == "" {
+		return nil, domainerrors.BadRequest("webhook callback URL is not configured")
+	}
+	if merchant.WebhookSecret == "" {
+		return nil, domainerrors.BadRequest("webhook secret is not configured")
+	}
+
+	result, err := u.dispatcher.SendTest(ctx, merchant)
+	if err != nil {
+		return nil, domainerrors.InternalError(err)
+	}
+	logger.Info(ctx, "Sent test webhook",
+		zap.String("merchant_id", merchant.ID.String()),
+		zap.Int("http_status", result.HttpStatus),
+		zap.Int64("latency_ms", result.LatencyMs),
+	)
+	return result, nil
+}
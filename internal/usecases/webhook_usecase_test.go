@@ -115,6 +115,194 @@ func TestWebhookUsecase_ProcessIndexerWebhook_PaymentFailed(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestWebhookUsecase_ProcessIndexerWebhook_RecordsEventDetails(t *testing.T) {
+	mockPaymentRepo := new(MockPaymentRepository)
+	mockEventRepo := new(MockPaymentEventRepository)
+	mockUOW := new(MockUnitOfWork)
+	uc := usecases.NewWebhookUsecase(
+		mockPaymentRepo,
+		mockEventRepo,
+		new(MockPaymentRequestRepository),
+		new(MockPartnerPaymentSessionRepository),
+		new(MockMerchantRepository),
+		new(MockWebhookLogRepository),
+		nil, // WebhookDispatcher
+		mockUOW,
+	)
+
+	paymentID := uuid.New()
+	ctx := context.Background()
+	mockUOW.On("Do", ctx, mock.Anything).Return(nil)
+	mockUOW.On("WithLock", ctx).Return(ctx)
+	mockPaymentRepo.On("GetByID", mock.Anything, paymentID).Return(&entities.Payment{ID: paymentID}, nil)
+	mockPaymentRepo.On("UpdateStatus", mock.Anything, paymentID, entities.PaymentStatusProcessing).Return(nil)
+	mockPaymentRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	var recorded []*entities.PaymentEvent
+	mockEventRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(1).(*entities.PaymentEvent))
+	}).Return(nil)
+
+	executed, _ := json.Marshal(map[string]any{
+		"paymentId":       paymentID.String(),
+		"status":          "processing",
+		"sourceTxHash":    "0xsource",
+		"blockNumber":     "0x2a",
+		"amount":          1000000,
+		"bridgeMessageId": "0xmsg",
+	})
+	assert.NoError(t, uc.ProcessIndexerWebhook(ctx, "PAYMENT_EXECUTED", executed))
+
+	failed, _ := json.Marshal(map[string]any{
+		"paymentId":    paymentID.String(),
+		"status":       "FAILED",
+		"reason":       "execution reverted",
+		"sourceTxHash": "0xfail",
+		"blockNumber":  43,
+	})
+	assert.NoError(t, uc.ProcessIndexerWebhook(ctx, "PAYMENT_FAILED", failed))
+
+	if assert.Len(t, recorded, 2) {
+		assert.Equal(t, int64(42), recorded[0].BlockNumber)
+		assert.Equal(t, &entities.PaymentEventDetails{
+			TxHash:          "0xsource",
+			BlockNumber:     42,
+			ObservedAmount:  "1000000",
+			BridgeMessageID: "0xmsg",
+		}, recorded[0].Details)
+		assert.Equal(t, &entities.PaymentEventDetails{
+			TxHash:       "0xfail",
+			BlockNumber:  43,
+			RevertReason: "execution reverted",
+		}, recorded[1].Details)
+	}
+}
+
+func TestWebhookUsecase_ProcessIndexerWebhook_WaitsForConfirmations(t *testing.T) {
+	mockPaymentRepo := new(MockPaymentRepository)
+	mockEventRepo := new(MockPaymentEventRepository)
+	mockMerchantRepo := new(MockMerchantRepository)
+	mockWebhookRepo := new(MockWebhookLogRepository)
+	mockChainRepo := new(MockChainRepository)
+	mockUOW := new(MockUnitOfWork)
+	uc := usecases.NewWebhookUsecaseWithConfirmations(
+		mockPaymentRepo,
+		mockEventRepo,
+		new(MockPaymentRequestRepository),
+		new(MockPartnerPaymentSessionRepository),
+		mockMerchantRepo,
+		mockWebhookRepo,
+		nil, // WebhookDispatcher
+		mockUOW,
+		mockChainRepo,
+		nil, // ChainHeadReader
+	)
+
+	ctx := context.Background()
+	merchantID := uuid.New()
+	payment := &entities.Payment{ID: uuid.New(), DestChainID: uuid.New(), MerchantID: &merchantID, Status: entities.PaymentStatusProcessing}
+	mockUOW.On("Do", ctx, mock.Anything).Return(nil)
+	mockUOW.On("WithLock", ctx).Return(ctx)
+	mockPaymentRepo.On("GetByID", mock.Anything, payment.ID).Return(payment, nil)
+	mockChainRepo.On("GetByID", mock.Anything, payment.DestChainID).Return(&entities.Chain{ID: payment.DestChainID, MinConfirmations: 2}, nil)
+	mockMerchantRepo.On("GetByID", mock.Anything, merchantID).Return(&entities.Merchant{ID: merchantID, MinConfirmations: 3}, nil)
+
+	var recorded []*entities.PaymentEvent
+	mockEventRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(1).(*entities.PaymentEvent))
+	}).Return(nil)
+
+	completed := func(confirmations int) json.RawMessage {
+		data, _ := json.Marshal(map[string]any{
+			"paymentId":     payment.ID.String(),
+			"status":        "completed",
+			"sourceTxHash":  "0xsource",
+			"confirmations": confirmations,
+		})
+		return data
+	}
+
+	// Below the merchant's threshold, which is stricter than the chain's: still processing
+	mockPaymentRepo.On("UpdateStatus", mock.Anything, payment.ID, entities.PaymentStatusProcessing).Return(nil).Once()
+	assert.NoError(t, uc.ProcessIndexerWebhook(ctx, "PAYMENT_COMPLETED", completed(2)))
+	mockWebhookRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	// Deep enough: completed, and the merchant is notified
+	mockPaymentRepo.On("UpdateStatus", mock.Anything, payment.ID, entities.PaymentStatusCompleted).Return(nil).Once()
+	mockWebhookRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	assert.NoError(t, uc.ProcessIndexerWebhook(ctx, "PAYMENT_COMPLETED", completed(3)))
+
+	mockPaymentRepo.AssertExpectations(t)
+	mockWebhookRepo.AssertExpectations(t)
+	if assert.Len(t, recorded, 2) {
+		assert.Equal(t, 2, recorded[0].Confirmations)
+		assert.Equal(t, 3, recorded[1].Confirmations)
+	}
+}
+
+func TestWebhookUsecase_ProcessIndexerWebhook_BridgeMessageEvents(t *testing.T) {
+	mockPaymentRepo := new(MockPaymentRepository)
+	mockEventRepo := new(MockPaymentEventRepository)
+	mockWebhookRepo := new(MockWebhookLogRepository)
+	mockUOW := new(MockUnitOfWork)
+	uc := usecases.NewWebhookUsecase(
+		mockPaymentRepo,
+		mockEventRepo,
+		new(MockPaymentRequestRepository),
+		new(MockPartnerPaymentSessionRepository),
+		new(MockMerchantRepository),
+		mockWebhookRepo,
+		nil, // WebhookDispatcher
+		mockUOW,
+	)
+
+	ctx := context.Background()
+	merchantID := uuid.New()
+	payment := &entities.Payment{ID: uuid.New(), MerchantID: &merchantID, Status: entities.PaymentStatusProcessing}
+	mockUOW.On("Do", ctx, mock.Anything).Return(nil)
+	mockUOW.On("WithLock", ctx).Return(ctx)
+	mockPaymentRepo.On("GetByID", mock.Anything, payment.ID).Return(payment, nil)
+
+	var recorded []*entities.PaymentEvent
+	mockEventRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, args.Get(1).(*entities.PaymentEvent))
+	}).Return(nil)
+
+	event := func(txHash string) json.RawMessage {
+		data, _ := json.Marshal(map[string]any{
+			"paymentId":       payment.ID.String(),
+			"txHash":          txHash,
+			"bridgeMessageId": "0xccip",
+		})
+		return data
+	}
+
+	// Sent: the message ID is stored on the payment, the status is untouched
+	mockPaymentRepo.On("Update", mock.Anything, payment).Return(nil).Once()
+	assert.NoError(t, uc.ProcessIndexerWebhook(ctx, "BRIDGE_MESSAGE_SENT", event("0xsource")))
+	assert.Equal(t, "0xccip", payment.CrossChainMessageID.String)
+	assert.Equal(t, entities.PaymentStatusProcessing, payment.Status)
+	mockWebhookRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	// The first delivery notifies the merchant; a redelivered event does not
+	mockWebhookRepo.On("Create", mock.Anything, mock.MatchedBy(func(delivery *entities.WebhookDelivery) bool {
+		return delivery.EventType == "BRIDGE_MESSAGE_DELIVERED" && delivery.MerchantID == merchantID
+	})).Return(nil).Once()
+	mockEventRepo.On("GetByPaymentID", mock.Anything, payment.ID).Return(recorded, nil).Once()
+	assert.NoError(t, uc.ProcessIndexerWebhook(ctx, "BRIDGE_MESSAGE_DELIVERED", event("0xdest")))
+	mockEventRepo.On("GetByPaymentID", mock.Anything, payment.ID).Return(recorded, nil).Once()
+	assert.NoError(t, uc.ProcessIndexerWebhook(ctx, "BRIDGE_MESSAGE_DELIVERED", event("0xdest")))
+
+	mockPaymentRepo.AssertExpectations(t)
+	mockWebhookRepo.AssertExpectations(t)
+	if assert.Len(t, recorded, 3) {
+		assert.Equal(t, entities.PaymentEventTypeBridgeMessageSent, recorded[0].EventType)
+		assert.Equal(t, "0xsource", recorded[0].TxHash)
+		assert.Equal(t, entities.PaymentEventTypeBridgeMessageDelivered, recorded[1].EventType)
+		assert.Equal(t, "0xccip", recorded[1].Details.BridgeMessageID)
+	}
+}
+
 func TestWebhookUsecase_ProcessIndexerWebhook_RequestPaymentReceived(t *testing.T) {
 	mockPaymentRepo := new(MockPaymentRepository)
 	mockEventRepo := new(MockPaymentEventRepository)
@@ -151,3 +339,40 @@ func TestWebhookUsecase_ProcessIndexerWebhook_RequestPaymentReceived(t *testing.
 	err := uc.ProcessIndexerWebhook(context.Background(), "REQUEST_PAYMENT_RECEIVED", raw)
 	assert.NoError(t, err)
 }
+
+func TestWebhookUsecase_ProcessIndexerWebhook_LeavesPendingApprovalAlone(t *testing.T) {
+	mockPaymentRepo := new(MockPaymentRepository)
+	mockEventRepo := new(MockPaymentEventRepository)
+	mockWebhookRepo := new(MockWebhookLogRepository)
+	mockUOW := new(MockUnitOfWork)
+	uc := usecases.NewWebhookUsecase(
+		mockPaymentRepo,
+		mockEventRepo,
+		new(MockPaymentRequestRepository),
+		new(MockPartnerPaymentSessionRepository),
+		new(MockMerchantRepository),
+		mockWebhookRepo,
+		nil, // WebhookDispatcher
+		mockUOW,
+	)
+
+	paymentID := uuid.New()
+	ctx := context.Background()
+	mockUOW.On("Do", ctx, mock.Anything).Return(nil)
+	mockUOW.On("WithLock", ctx).Return(ctx)
+	mockPaymentRepo.On("GetByID", mock.Anything, paymentID).Return(&entities.Payment{ID: paymentID, Status: entities.PaymentStatusPendingApproval}, nil)
+	mockPaymentRepo.On("UpdateStatus", mock.Anything, paymentID, entities.PaymentStatusPendingApproval).Return(nil).Once()
+	mockEventRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	// The events are kept for review, but the payment stays held and no webhook goes out
+	completed, _ := json.Marshal(map[string]any{"paymentId": paymentID.String(), "status": "completed", "sourceTxHash": "0x123", "confirmations": 100})
+	assert.NoError(t, uc.ProcessIndexerWebhook(ctx, "PAYMENT_COMPLETED", completed))
+	failed, _ := json.Marshal(map[string]any{"paymentId": paymentID.String(), "status": "FAILED", "reason": "execution reverted"})
+	assert.NoError(t, uc.ProcessIndexerWebhook(ctx, "PAYMENT_FAILED", failed))
+
+	mockPaymentRepo.AssertExpectations(t)
+	mockEventRepo.AssertExpectations(t)
+	mockPaymentRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, paymentID, entities.PaymentStatusCompleted)
+	mockPaymentRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockWebhookRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,5 @@
+ALTER TABLE merchants
+DROP COLUMN IF EXISTS fee_exempt;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS fee_exempt;
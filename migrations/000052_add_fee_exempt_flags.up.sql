@@ -0,0 +1,6 @@
+-- Internal/test accounts can be exempted from the platform fee by an admin.
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS fee_exempt BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE merchants
+ADD COLUMN IF NOT EXISTS fee_exempt BOOLEAN NOT NULL DEFAULT false;
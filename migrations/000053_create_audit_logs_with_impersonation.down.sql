@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_audit_logs_created_at;
+DROP INDEX IF EXISTS idx_audit_logs_impersonator_id;
+
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS action;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS impersonator_id;
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS user_id;
@@ -0,0 +1,20 @@
+-- Request audit log. Partner requests are keyed by merchant; requests made while an admin
+-- impersonates a user record both identities.
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY,
+    merchant_id UUID,
+    path TEXT NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    status_code INTEGER NOT NULL,
+    ip_address VARCHAR(64),
+    duration DOUBLE PRECISION,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE audit_logs ALTER COLUMN merchant_id DROP NOT NULL;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS user_id UUID;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS impersonator_id UUID;
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS action VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_impersonator_id ON audit_logs(impersonator_id) WHERE impersonator_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs(created_at DESC);
@@ -0,0 +1,13 @@
+-- Feature flags gate routes that ship dark. merchant_id NULL is the global value;
+-- a row with merchant_id set overrides it for that tenant.
+CREATE TABLE IF NOT EXISTS feature_flags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    name VARCHAR(64) NOT NULL,
+    merchant_id UUID REFERENCES merchants(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_feature_flags_global ON feature_flags(name) WHERE merchant_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_feature_flags_merchant ON feature_flags(name, merchant_id) WHERE merchant_id IS NOT NULL;
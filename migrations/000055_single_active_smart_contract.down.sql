@@ -0,0 +1 @@
+DROP INDEX IF EXISTS uq_smart_contracts_single_active;
@@ -0,0 +1,20 @@
+-- At most one active contract per (chain, type). Pools (and test mocks) are the exception:
+-- a chain can have many live DEX pools.
+-- Older rows may already violate this; keep the one GetActiveContract would have picked.
+UPDATE smart_contracts sc
+SET is_active = FALSE, updated_at = NOW()
+WHERE sc.is_active
+  AND sc.deleted_at IS NULL
+  AND sc.type NOT IN ('POOL', 'DEX_POOL', 'MOCK')
+  AND EXISTS (
+      SELECT 1 FROM smart_contracts newer
+      WHERE newer.chain_id = sc.chain_id
+        AND newer.type = sc.type
+        AND newer.is_active
+        AND newer.deleted_at IS NULL
+        AND (newer.updated_at, newer.created_at, newer.version, newer.id) > (sc.updated_at, sc.created_at, sc.version, sc.id)
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_smart_contracts_single_active
+    ON smart_contracts(chain_id, type)
+    WHERE is_active AND deleted_at IS NULL AND type NOT IN ('POOL', 'DEX_POOL', 'MOCK');
@@ -0,0 +1,2 @@
+-- ENS/SNS name the receiver address was resolved from at payment creation
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS receiver_name VARCHAR(255);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_payment_requests_merchant_created_id;
+DROP INDEX IF EXISTS idx_payments_merchant_created_id;
+DROP INDEX IF EXISTS idx_payments_sender_created_id;
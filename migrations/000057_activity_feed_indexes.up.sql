@@ -0,0 +1,4 @@
+-- Keyset indexes for the /activity feed, which pages each table by (created_at, id) per owner
+CREATE INDEX IF NOT EXISTS idx_payments_sender_created_id ON payments(sender_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payments_merchant_created_id ON payments(merchant_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_payment_requests_merchant_created_id ON payment_requests(merchant_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
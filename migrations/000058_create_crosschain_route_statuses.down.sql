@@ -0,0 +1 @@
+DROP TABLE IF EXISTS crosschain_route_statuses;
@@ -0,0 +1,17 @@
+-- Last recheck result per crosschain route, so the admin overview can be served without RPC
+-- calls and operators can see when a route last changed state. snapshot is the full status JSON.
+CREATE TABLE IF NOT EXISTS crosschain_route_statuses (
+    route_key VARCHAR(200) PRIMARY KEY,
+    source_chain_id VARCHAR(100) NOT NULL,
+    dest_chain_id VARCHAR(100) NOT NULL,
+    overall_status VARCHAR(20) NOT NULL,
+    previous_status VARCHAR(20) NOT NULL DEFAULT '',
+    snapshot JSONB NOT NULL,
+    checked_at TIMESTAMP NOT NULL,
+    status_changed_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_crosschain_route_statuses_source ON crosschain_route_statuses(source_chain_id);
+CREATE INDEX IF NOT EXISTS idx_crosschain_route_statuses_dest ON crosschain_route_statuses(dest_chain_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payments_merchant_external_ref;
+ALTER TABLE payments DROP COLUMN IF EXISTS external_ref;
@@ -0,0 +1,3 @@
+-- Merchant-supplied order id, so merchants can find a payment without storing our UUID
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS external_ref VARCHAR(128);
+CREATE INDEX IF NOT EXISTS idx_payments_merchant_external_ref ON payments(merchant_id, external_ref) WHERE external_ref IS NOT NULL AND deleted_at IS NULL;
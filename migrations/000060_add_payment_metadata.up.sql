@@ -0,0 +1,3 @@
+-- Integrator-supplied JSON (cart contents, customer id); stored and returned as-is
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS metadata JSONB;
+ALTER TABLE payment_requests ADD COLUMN IF NOT EXISTS metadata JSONB;
@@ -0,0 +1,12 @@
+-- Receiver addresses a merchant's payments and payment requests may pay out to. A merchant
+-- with no rows is unrestricted.
+CREATE TABLE IF NOT EXISTS merchant_allowed_receivers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    merchant_id UUID NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    address VARCHAR(255) NOT NULL,
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    created_by UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_merchant_allowed_receivers_address ON merchant_allowed_receivers(merchant_id, address);
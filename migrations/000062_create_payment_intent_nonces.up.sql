@@ -0,0 +1,11 @@
+-- Nonces of signed payment intents already used on /payment-app, so a signature cannot be replayed.
+-- Rows can be pruned once expires_at has passed: the intent deadline rejects them anyway.
+CREATE TABLE IF NOT EXISTS payment_intent_nonces (
+    payer VARCHAR(64) NOT NULL,
+    nonce VARCHAR(78) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (payer, nonce)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_intent_nonces_expires_at ON payment_intent_nonces(expires_at);
@@ -0,0 +1 @@
+ALTER TABLE payment_events DROP COLUMN IF EXISTS details;
@@ -0,0 +1,2 @@
+-- Typed, event-specific data (tx hash, block, observed amount, bridge message id, revert reason)
+ALTER TABLE payment_events ADD COLUMN IF NOT EXISTS details JSONB;
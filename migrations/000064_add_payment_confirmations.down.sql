@@ -0,0 +1,3 @@
+ALTER TABLE merchants DROP COLUMN IF EXISTS min_confirmations;
+ALTER TABLE chains DROP COLUMN IF EXISTS min_confirmations;
+ALTER TABLE payment_events DROP COLUMN IF EXISTS confirmations;
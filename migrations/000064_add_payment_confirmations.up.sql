@@ -0,0 +1,5 @@
+-- Confirmation depth reported with each payment event
+ALTER TABLE payment_events ADD COLUMN IF NOT EXISTS confirmations INTEGER NOT NULL DEFAULT 0;
+-- Blocks a completion must be buried under before the payment is completed; 0 completes on first sight
+ALTER TABLE chains ADD COLUMN IF NOT EXISTS min_confirmations INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE merchants ADD COLUMN IF NOT EXISTS min_confirmations INTEGER NOT NULL DEFAULT 0;
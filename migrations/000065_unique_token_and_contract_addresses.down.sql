@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS uq_smart_contracts_chain_address_type;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chain_address ON smart_contracts(chain_id, address);
+DROP INDEX IF EXISTS uq_tokens_chain_address;
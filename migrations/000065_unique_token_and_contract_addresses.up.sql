@@ -0,0 +1,37 @@
+-- One live token per (chain, address) and one live contract per (chain, address, type),
+-- compared case-insensitively. Native tokens have no address and are not constrained.
+-- Older rows may already violate this; keep the oldest and soft delete the rest.
+UPDATE tokens t
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE t.deleted_at IS NULL
+  AND COALESCE(t.address, '') <> ''
+  AND EXISTS (
+      SELECT 1 FROM tokens older
+      WHERE older.chain_id = t.chain_id
+        AND LOWER(older.address) = LOWER(t.address)
+        AND older.deleted_at IS NULL
+        AND (older.created_at, older.id) < (t.created_at, t.id)
+  );
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tokens_chain_address
+    ON tokens(chain_id, LOWER(address))
+    WHERE deleted_at IS NULL AND address IS NOT NULL AND address <> '';
+
+-- idx_chain_address also counted soft-deleted rows, so a deleted contract could never be
+-- registered again.
+UPDATE smart_contracts sc
+SET deleted_at = NOW(), updated_at = NOW()
+WHERE sc.deleted_at IS NULL
+  AND EXISTS (
+      SELECT 1 FROM smart_contracts other
+      WHERE other.chain_id = sc.chain_id
+        AND LOWER(other.address) = LOWER(sc.address)
+        AND other.type = sc.type
+        AND other.deleted_at IS NULL
+        AND (other.is_active, sc.created_at, sc.id) > (sc.is_active, other.created_at, other.id)
+  );
+
+DROP INDEX IF EXISTS idx_chain_address;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_smart_contracts_chain_address_type
+    ON smart_contracts(chain_id, LOWER(address), type)
+    WHERE deleted_at IS NULL;
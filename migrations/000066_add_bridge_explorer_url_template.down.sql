@@ -0,0 +1 @@
+ALTER TABLE payment_bridge DROP COLUMN IF EXISTS explorer_url_template;
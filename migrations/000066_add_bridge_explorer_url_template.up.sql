@@ -0,0 +1,9 @@
+-- Deep link to the bridge's own explorer; {messageId} and {sourceTxHash} are filled in per payment
+ALTER TABLE payment_bridge ADD COLUMN IF NOT EXISTS explorer_url_template VARCHAR(255);
+
+UPDATE payment_bridge SET explorer_url_template = 'https://ccip.chain.link/msg/{messageId}'
+WHERE UPPER(name) = 'CCIP' AND explorer_url_template IS NULL;
+UPDATE payment_bridge SET explorer_url_template = 'https://explorer.hyperbridge.network/messages/{messageId}'
+WHERE UPPER(name) IN ('HYPERBRIDGE', 'HYPERBRIDGETOKENGATEWAY', 'HYPERBRIDGE_TOKEN_GATEWAY') AND explorer_url_template IS NULL;
+UPDATE payment_bridge SET explorer_url_template = 'https://layerzeroscan.com/tx/{sourceTxHash}'
+WHERE UPPER(name) IN ('STARGATE', 'LAYERZERO') AND explorer_url_template IS NULL;
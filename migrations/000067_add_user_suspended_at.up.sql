@@ -0,0 +1,3 @@
+-- Admins can suspend an account (e.g. abuse) without deleting it; NULL means active.
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP;
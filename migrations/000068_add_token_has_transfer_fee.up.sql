@@ -0,0 +1,4 @@
+-- Fee-on-transfer and rebasing tokens deliver a different amount than was sent, which breaks
+-- bridging; flagged tokens are refused on cross-chain routes.
+ALTER TABLE tokens
+ADD COLUMN IF NOT EXISTS has_transfer_fee BOOLEAN NOT NULL DEFAULT FALSE;
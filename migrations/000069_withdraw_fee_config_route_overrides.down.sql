@@ -0,0 +1,2 @@
+-- Intentionally no-op: the withdrawn route override columns are not restored.
+SELECT 1;
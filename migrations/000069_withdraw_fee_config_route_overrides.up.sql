@@ -0,0 +1,10 @@
+-- Intentionally a no-op on fresh databases.
+-- This version once added fee_configs.dest_chain_id/dest_token_id for route-level fee overrides.
+-- The feature was withdrawn: the gateway charges its platform fee on-chain with no per-route rate,
+-- so an override could not change what a payment is charged. The number is kept so migration
+-- history stays contiguous, and databases that ran the old version lose the unused columns.
+DROP INDEX IF EXISTS idx_fee_configs_route;
+
+ALTER TABLE fee_configs
+DROP COLUMN IF EXISTS dest_token_id,
+DROP COLUMN IF EXISTS dest_chain_id;
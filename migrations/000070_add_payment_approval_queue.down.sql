@@ -0,0 +1,3 @@
+-- Postgres cannot drop an enum value; PENDING_APPROVAL stays in payment_status_enum.
+ALTER TABLE tokens
+DROP COLUMN IF EXISTS approval_threshold;
@@ -0,0 +1,6 @@
+-- Cross-chain payments above a token's approval threshold are held for admin review before
+-- the payer is given calldata. The queue is read through idx_payments_status.
+ALTER TYPE payment_status_enum ADD VALUE IF NOT EXISTS 'PENDING_APPROVAL';
+
+ALTER TABLE tokens
+ADD COLUMN IF NOT EXISTS approval_threshold DECIMAL(36, 18);
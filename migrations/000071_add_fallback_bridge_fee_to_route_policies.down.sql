@@ -0,0 +1,2 @@
+ALTER TABLE route_policies
+    DROP COLUMN IF EXISTS fallback_bridge_fee;
@@ -0,0 +1,2 @@
+ALTER TABLE route_policies
+    ADD COLUMN IF NOT EXISTS fallback_bridge_fee NUMERIC(78,0);
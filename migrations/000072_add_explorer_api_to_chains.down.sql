@@ -0,0 +1,2 @@
+ALTER TABLE chains DROP COLUMN IF EXISTS explorer_api_key;
+ALTER TABLE chains DROP COLUMN IF EXISTS explorer_api_url;
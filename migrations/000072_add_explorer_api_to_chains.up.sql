@@ -0,0 +1,2 @@
+ALTER TABLE chains ADD COLUMN IF NOT EXISTS explorer_api_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE chains ADD COLUMN IF NOT EXISTS explorer_api_key TEXT NOT NULL DEFAULT '';
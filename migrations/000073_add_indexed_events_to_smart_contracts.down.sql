@@ -0,0 +1 @@
+ALTER TABLE smart_contracts DROP COLUMN IF EXISTS indexed_events;
@@ -0,0 +1 @@
+ALTER TABLE smart_contracts ADD COLUMN IF NOT EXISTS indexed_events TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payments_mode;
+ALTER TABLE payments DROP COLUMN IF EXISTS mode;
@@ -0,0 +1,3 @@
+-- Payments created with a test-mode API key (pk_test_) are kept apart from live ones.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS mode VARCHAR(10) NOT NULL DEFAULT 'live';
+CREATE INDEX IF NOT EXISTS idx_payments_mode ON payments (mode);
@@ -0,0 +1 @@
+ALTER TABLE chains DROP COLUMN IF EXISTS is_testnet;
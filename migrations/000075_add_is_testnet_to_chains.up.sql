@@ -0,0 +1,7 @@
+-- Test-mode API keys may only pay on testnets and live keys only on mainnets.
+ALTER TABLE chains ADD COLUMN IF NOT EXISTS is_testnet BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Flag the well-known public EVM testnets; anything else is reviewed by an admin.
+UPDATE chains SET is_testnet = TRUE
+WHERE type = 'EVM'
+  AND chain_id IN ('11155111', '84532', '421614', '11155420', '80002', '97', '43113', '17000');
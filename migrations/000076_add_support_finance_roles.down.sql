@@ -0,0 +1 @@
+-- Postgres cannot drop an enum value; SUPPORT and FINANCE stay in user_role_enum.
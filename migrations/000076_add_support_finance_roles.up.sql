@@ -0,0 +1,3 @@
+-- Staff roles: SUPPORT reads the admin API, FINANCE also manages fee and settlement configuration.
+ALTER TYPE user_role_enum ADD VALUE IF NOT EXISTS 'SUPPORT';
+ALTER TYPE user_role_enum ADD VALUE IF NOT EXISTS 'FINANCE';
@@ -0,0 +1 @@
+-- Postgres cannot drop an enum value; SUBSTRATE and TRON stay in chain_type_enum.
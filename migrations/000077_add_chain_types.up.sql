@@ -0,0 +1,4 @@
+-- Chain types added in entities.ChainType after chain_type_enum was created. COSMOS is already
+-- in the enum.
+ALTER TYPE chain_type_enum ADD VALUE IF NOT EXISTS 'SUBSTRATE';
+ALTER TYPE chain_type_enum ADD VALUE IF NOT EXISTS 'TRON';
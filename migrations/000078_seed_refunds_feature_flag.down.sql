@@ -0,0 +1 @@
+DELETE FROM feature_flags WHERE name = 'refunds' AND merchant_id IS NULL;
@@ -0,0 +1,5 @@
+-- The privacy escrow refund route is gated by the "refunds" flag; keep it enabled for
+-- existing deployments. Merchant overrides can still turn it off per tenant.
+INSERT INTO feature_flags (name, enabled)
+SELECT 'refunds', true
+WHERE NOT EXISTS (SELECT 1 FROM feature_flags WHERE name = 'refunds' AND merchant_id IS NULL);
@@ -0,0 +1,2 @@
+ALTER TABLE payment_requests DROP COLUMN IF EXISTS block_number;
+-- Postgres cannot drop an enum value; PROCESSING stays in payment_request_status_enum.
@@ -0,0 +1,5 @@
+-- A paid request is PROCESSING until its transaction is as deep as the chain and merchant require;
+-- block_number is where the indexer saw the transaction, so the recheck job can measure depth.
+ALTER TYPE payment_request_status_enum ADD VALUE IF NOT EXISTS 'PROCESSING';
+
+ALTER TABLE payment_requests ADD COLUMN IF NOT EXISTS block_number BIGINT;
@@ -0,0 +1,25 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+)
+
+// constantTimeCompare is swapped in tests to assert that verification helpers go through it
+var constantTimeCompare = subtle.ConstantTimeCompare
+
+// ConstantTimeEqual compares two secrets (signatures, hashes, tokens) without leaking where they
+// differ. Both sides are digested first so the comparison does not leak their lengths either.
+func ConstantTimeEqual(a, b string) bool {
+	da := sha256.Sum256([]byte(a))
+	db := sha256.Sum256([]byte(b))
+	return constantTimeCompare(da[:], db[:]) == 1
+}
+
+// HashToken returns the SHA-256 hex digest under which a one-time token is stored, so the
+// database lookup never compares the raw token
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
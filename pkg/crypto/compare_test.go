@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstantTimeEqual(t *testing.T) {
+	assert.True(t, ConstantTimeEqual("abc", "abc"))
+	assert.False(t, ConstantTimeEqual("abc", "abd"))
+	assert.False(t, ConstantTimeEqual("abc", "abcd"))
+	assert.True(t, ConstantTimeEqual("", ""))
+}
+
+func TestHashToken(t *testing.T) {
+	assert.Len(t, HashToken("token"), 64)
+	assert.Equal(t, HashToken("token"), HashToken("token"))
+	assert.NotEqual(t, HashToken("token"), HashToken("token2"))
+}
+
+func TestVerifyHMAC_UsesConstantTimeCompare(t *testing.T) {
+	calls := 0
+	original := constantTimeCompare
+	constantTimeCompare = func(x, y []byte) int {
+		calls++
+		return original(x, y)
+	}
+	defer func() { constantTimeCompare = original }()
+
+	assert.True(t, VerifyHMAC("msg", "secret", GenerateHMAC("msg", "secret")))
+	assert.False(t, VerifyHMAC("msg", "secret", "bad"))
+	assert.Equal(t, 2, calls)
+}
+
+// secretOperand matches identifiers that hold signatures, secrets or credential hashes
+var secretOperand = regexp.MustCompile(`(?i)(signature|secret|keyhash|tokenhash|passwordhash|digest|hmac)`)
+
+// TestNoPlainEqualityOnSecrets audits the non-test sources for == / != between two values that
+// look like secrets. Those comparisons must go through ConstantTimeEqual.
+func TestNoPlainEqualityOnSecrets(t *testing.T) {
+	var findings []string
+	for _, root := range []string{"../../internal", "../../pkg", "../../cmd"} {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+			fset := token.NewFileSet()
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				return err
+			}
+			ast.Inspect(file, func(n ast.Node) bool {
+				bin, ok := n.(*ast.BinaryExpr)
+				if !ok || (bin.Op != token.EQL && bin.Op != token.NEQ) {
+					return true
+				}
+				if isSecretOperand(bin.X) && isSecretOperand(bin.Y) {
+					findings = append(findings, fset.Position(bin.Pos()).String())
+				}
+				return true
+			})
+			return nil
+		})
+		require.NoError(t, err)
+	}
+	assert.Empty(t, findings, "use crypto.ConstantTimeEqual for secret comparisons")
+}
+
+// isSecretOperand reports whether expr is a named value (not a literal or nil) whose name looks
+// like a secret. A call such as c.GetHeader("X-Signature") counts through its string argument.
+func isSecretOperand(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name != "nil" && secretOperand.MatchString(e.Name)
+	case *ast.SelectorExpr:
+		return secretOperand.MatchString(e.Sel.Name)
+	case *ast.CallExpr:
+		for _, arg := range e.Args {
+			if lit, ok := arg.(*ast.BasicLit); ok && secretOperand.MatchString(lit.Value) {
+				return true
+			}
+		}
+		return isSecretOperand(e.Fun)
+	case *ast.ParenExpr:
+		return isSecretOperand(e.X)
+	}
+	return false
+}
@@ -16,5 +16,5 @@ func GenerateHMAC(message, secret string) string {
 // VerifyHMAC verifies if the given signature matches the message and secret
 func VerifyHMAC(message, secret, signature string) bool {
 	validSignature := GenerateHMAC(message, secret)
-	return hmac.Equal([]byte(validSignature), []byte(signature))
+	return ConstantTimeEqual(validSignature, signature)
 }
@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"crypto"
 	"errors"
 	"time"
 
@@ -9,8 +10,17 @@ import (
 )
 
 var (
-	ErrInvalidToken = errors.New("invalid token")
-	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrExpiredToken         = errors.New("token has expired")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrInvalidKey           = errors.New("invalid signing key")
+)
+
+// Supported signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
 )
 
 // Claims represents JWT claims
@@ -18,9 +28,17 @@ type Claims struct {
 	UserID   uuid.UUID `json:"userId"`
 	Email    string    `json:"email"`
 	Role     string    `json:"role"`
+	// ImpersonatorID is set when an admin acts as UserID; such tokens are read-mostly
+	ImpersonatorID    *uuid.UUID `json:"impersonatorId,omitempty"`
+	ImpersonatorEmail string     `json:"impersonatorEmail,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// IsImpersonation reports whether the token was minted for an admin acting as another user
+func (c *Claims) IsImpersonation() bool {
+	return c.ImpersonatorID != nil
+}
+
 // TokenPair represents access and refresh tokens
 type TokenPair struct {
 	AccessToken  string `json:"accessToken"`
@@ -30,6 +48,10 @@ type TokenPair struct {
 // JWTService handles JWT operations
 type JWTService struct {
 	secret        []byte
+	method        jwt.SigningMethod
+	keyID         string
+	signingKey    crypto.Signer
+	verifyKeys    map[string]verificationKey
 	accessExpiry  time.Duration
 	refreshExpiry time.Duration
 }
@@ -42,13 +64,62 @@ var parseJWTWithClaims = func(tokenString string, claims jwt.Claims, keyFunc jwt
 	return jwt.ParseWithClaims(tokenString, claims, keyFunc)
 }
 
-// NewJWTService creates a new JWT service
+// NewJWTService creates a new JWT service signing with a shared HS256 secret
 func NewJWTService(secret string, accessExpiry, refreshExpiry time.Duration) *JWTService {
 	return &JWTService{
 		secret:        []byte(secret),
+		method:        jwt.SigningMethodHS256,
+		accessExpiry:  accessExpiry,
+		refreshExpiry: refreshExpiry,
+	}
+}
+
+// NewAsymmetricJWTService creates a JWT service signing with an RSA (RS256) or ECDSA P-256
+// (ES256) private key. Tokens carry keyID in the kid header, and the public half is published
+// through JWKS so other services can verify tokens without holding the signing key.
+func NewAsymmetricJWTService(algorithm, keyID string, privateKey crypto.Signer, accessExpiry, refreshExpiry time.Duration) (*JWTService, error) {
+	method, err := asymmetricMethod(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if keyID == "" || privateKey == nil {
+		return nil, ErrInvalidKey
+	}
+	if keyAlgorithm(privateKey.Public()) != method.Alg() {
+		return nil, ErrInvalidKey
+	}
+
+	s := &JWTService{
+		method:        method,
+		keyID:         keyID,
+		signingKey:    privateKey,
+		verifyKeys:    map[string]verificationKey{},
 		accessExpiry:  accessExpiry,
 		refreshExpiry: refreshExpiry,
 	}
+	if err := s.AddVerificationKey(keyID, privateKey.Public()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// AddVerificationKey registers an additional public key that tokens may be verified against,
+// so tokens signed by a previous key stay valid while it is being rotated out.
+func (s *JWTService) AddVerificationKey(keyID string, publicKey crypto.PublicKey) error {
+	if s.signingKey == nil {
+		return ErrUnsupportedAlgorithm
+	}
+	alg := keyAlgorithm(publicKey)
+	if keyID == "" || alg == "" {
+		return ErrInvalidKey
+	}
+	s.verifyKeys[keyID] = verificationKey{alg: alg, key: publicKey}
+	return nil
+}
+
+// Algorithm returns the algorithm used to sign new tokens
+func (s *JWTService) Algorithm() string {
+	return s.method.Alg()
 }
 
 // GenerateTokenPair generates access and refresh tokens
@@ -71,12 +142,7 @@ func (s *JWTService) GenerateTokenPair(userID uuid.UUID, email, role string) (*T
 
 // ValidateToken validates a JWT token and returns the claims
 func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := parseJWTWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, ErrInvalidToken
-		}
-		return s.secret, nil
-	})
+	token, err := parseJWTWithClaims(tokenString, &Claims{}, s.keyFunc)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -93,9 +159,22 @@ func (s *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// GenerateImpersonationToken mints a single short-lived access token for an admin acting as
+// another user. There is deliberately no refresh token: the session ends when it expires.
+func (s *JWTService) GenerateImpersonationToken(userID uuid.UUID, email, role string, impersonatorID uuid.UUID, impersonatorEmail string, expiry time.Duration) (string, error) {
+	claims := s.newClaims(userID, email, role, expiry)
+	claims.ImpersonatorID = &impersonatorID
+	claims.ImpersonatorEmail = impersonatorEmail
+	return s.sign(claims)
+}
+
 func (s *JWTService) generateToken(userID uuid.UUID, email, role string, expiry time.Duration) (string, error) {
+	return s.sign(s.newClaims(userID, email, role, expiry))
+}
+
+func (s *JWTService) newClaims(userID uuid.UUID, email, role string, expiry time.Duration) *Claims {
 	now := time.Now()
-	claims := &Claims{
+	return &Claims{
 		UserID: userID,
 		Email:  email,
 		Role:   role,
@@ -105,7 +184,31 @@ func (s *JWTService) generateToken(userID uuid.UUID, email, role string, expiry
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+func (s *JWTService) sign(claims *Claims) (string, error) {
+	token := jwt.NewWithClaims(s.method, claims)
+	if s.signingKey != nil {
+		token.Header["kid"] = s.keyID
+		return token.SignedString(s.signingKey)
+	}
 	return signJWTToken(token, s.secret)
 }
+
+// keyFunc resolves the verification key for a parsed token. HS256 services only accept HMAC
+// tokens; asymmetric services look the key up by kid and never fall back to a shared secret.
+func (s *JWTService) keyFunc(token *jwt.Token) (interface{}, error) {
+	if s.signingKey == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return s.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	vk, ok := s.verifyKeys[kid]
+	if !ok || token.Method.Alg() != vk.alg {
+		return nil, ErrInvalidToken
+	}
+	return vk.key, nil
+}
@@ -124,3 +124,26 @@ func TestJWTService_ValidateToken_ClaimsTypeAndValidityBranches(t *testing.T) {
 		assert.ErrorIs(t, err, ErrInvalidToken)
 	})
 }
+
+func TestJWTService_GenerateImpersonationToken(t *testing.T) {
+	svc := NewJWTService("secret", time.Minute, 2*time.Minute)
+	userID := uuid.New()
+	adminID := uuid.New()
+
+	token, err := svc.GenerateImpersonationToken(userID, "user@mail.com", "USER", adminID, "admin@mail.com", 5*time.Minute)
+	assert.NoError(t, err)
+
+	claims, err := svc.ValidateToken(token)
+	assert.NoError(t, err)
+	assert.True(t, claims.IsImpersonation())
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, adminID, *claims.ImpersonatorID)
+	assert.Equal(t, "admin@mail.com", claims.ImpersonatorEmail)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), claims.ExpiresAt.Time, 5*time.Second)
+
+	pair, err := svc.GenerateTokenPair(userID, "user@mail.com", "USER")
+	assert.NoError(t, err)
+	claims, err = svc.ValidateToken(pair.AccessToken)
+	assert.NoError(t, err)
+	assert.False(t, claims.IsImpersonation())
+}
@@ -0,0 +1,141 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type verificationKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+// JWK is a public key in JSON Web Key format
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public verification keys. It is empty for HS256 services, since the shared
+// secret must never be published.
+func (s *JWTService) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for kid, vk := range s.verifyKeys {
+		if jwk, ok := toJWK(kid, vk); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
+
+// ParsePrivateKeyPEM parses an RSA or ECDSA private key in PKCS#8, PKCS#1 or SEC 1 PEM form
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if signer, ok := key.(crypto.Signer); ok && keyAlgorithm(signer.Public()) != "" {
+			return signer, nil
+		}
+		return nil, ErrInvalidKey
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil && keyAlgorithm(&key.PublicKey) != "" {
+		return key, nil
+	}
+	return nil, ErrInvalidKey
+}
+
+// ParsePublicKeyPEM parses an RSA or ECDSA public key in PKIX or PKCS#1 PEM form
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidKey
+	}
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		if keyAlgorithm(key) == "" {
+			return nil, ErrInvalidKey
+		}
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, ErrInvalidKey
+}
+
+func asymmetricMethod(algorithm string) (jwt.SigningMethod, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		return jwt.SigningMethodRS256, nil
+	case AlgorithmES256:
+		return jwt.SigningMethodES256, nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+// keyAlgorithm maps a public key to the algorithm it verifies, or "" if unsupported
+func keyAlgorithm(key crypto.PublicKey) string {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return AlgorithmRS256
+	case *ecdsa.PublicKey:
+		if k.Curve == elliptic.P256() {
+			return AlgorithmES256
+		}
+	}
+	return ""
+}
+
+func toJWK(kid string, vk verificationKey) (JWK, bool) {
+	enc := base64.RawURLEncoding
+	switch k := vk.key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: vk.alg,
+			Kid: kid,
+			N:   enc.EncodeToString(k.N.Bytes()),
+			E:   enc.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, true
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		return JWK{
+			Kty: "EC",
+			Use: "sig",
+			Alg: vk.alg,
+			Kid: kid,
+			Crv: k.Curve.Params().Name,
+			X:   enc.EncodeToString(k.X.FillBytes(make([]byte, size))),
+			Y:   enc.EncodeToString(k.Y.FillBytes(make([]byte, size))),
+		}, true
+	}
+	return JWK{}, false
+}
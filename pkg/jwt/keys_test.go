@@ -0,0 +1,138 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsymmetricJWTService_SignAndValidate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		alg string
+		key crypto.Signer
+	}{
+		{AlgorithmRS256, rsaKey},
+		{AlgorithmES256, ecKey},
+	} {
+		t.Run(tc.alg, func(t *testing.T) {
+			svc, err := NewAsymmetricJWTService(tc.alg, "kid-1", tc.key, time.Minute, time.Hour)
+			require.NoError(t, err)
+			assert.Equal(t, tc.alg, svc.Algorithm())
+
+			userID := uuid.New()
+			pair, err := svc.GenerateTokenPair(userID, "a@b.c", "USER")
+			require.NoError(t, err)
+
+			claims, err := svc.ValidateToken(pair.AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, userID, claims.UserID)
+
+			set := svc.JWKS()
+			require.Len(t, set.Keys, 1)
+			assert.Equal(t, "kid-1", set.Keys[0].Kid)
+			assert.Equal(t, tc.alg, set.Keys[0].Alg)
+		})
+	}
+}
+
+func TestAsymmetricJWTService_Rotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	oldSvc, err := NewAsymmetricJWTService(AlgorithmRS256, "old", oldKey, time.Minute, time.Hour)
+	require.NoError(t, err)
+	oldPair, err := oldSvc.GenerateTokenPair(uuid.New(), "a@b.c", "USER")
+	require.NoError(t, err)
+
+	newSvc, err := NewAsymmetricJWTService(AlgorithmES256, "new", newKey, time.Minute, time.Hour)
+	require.NoError(t, err)
+	_, err = newSvc.ValidateToken(oldPair.AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	require.NoError(t, newSvc.AddVerificationKey("old", &oldKey.PublicKey))
+	_, err = newSvc.ValidateToken(oldPair.AccessToken)
+	assert.NoError(t, err)
+
+	set := newSvc.JWKS()
+	require.Len(t, set.Keys, 2)
+	assert.Equal(t, "new", set.Keys[0].Kid)
+	assert.Equal(t, "EC", set.Keys[0].Kty)
+	assert.Equal(t, "old", set.Keys[1].Kid)
+	assert.Equal(t, "RSA", set.Keys[1].Kty)
+}
+
+func TestAsymmetricJWTService_RejectsHMACAndMismatchedKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, err = NewAsymmetricJWTService(AlgorithmHS256, "kid", rsaKey, time.Minute, time.Hour)
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+	_, err = NewAsymmetricJWTService(AlgorithmES256, "kid", rsaKey, time.Minute, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = NewAsymmetricJWTService(AlgorithmRS256, "", rsaKey, time.Minute, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	svc, err := NewAsymmetricJWTService(AlgorithmRS256, "kid", rsaKey, time.Minute, time.Hour)
+	require.NoError(t, err)
+
+	// A token signed with a shared secret must not be accepted by an asymmetric service.
+	hsPair, err := NewJWTService("secret", time.Minute, time.Hour).GenerateTokenPair(uuid.New(), "a@b.c", "USER")
+	require.NoError(t, err)
+	_, err = svc.ValidateToken(hsPair.AccessToken)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	hs := NewJWTService("secret", time.Minute, time.Hour)
+	assert.ErrorIs(t, hs.AddVerificationKey("kid", &rsaKey.PublicKey), ErrUnsupportedAlgorithm)
+	assert.Empty(t, hs.JWKS().Keys)
+	assert.Equal(t, AlgorithmHS256, hs.Algorithm())
+}
+
+func TestParseKeyPEM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.NoError(t, err)
+	signer, err := ParsePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	require.NoError(t, err)
+	assert.IsType(t, &ecdsa.PrivateKey{}, signer)
+
+	signer, err = ParsePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}))
+	require.NoError(t, err)
+	assert.IsType(t, &rsa.PrivateKey{}, signer)
+
+	ecDER, err := x509.MarshalECPrivateKey(ecKey)
+	require.NoError(t, err)
+	_, err = ParsePrivateKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}))
+	require.NoError(t, err)
+
+	pkix, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	pub, err := ParsePublicKeyPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}))
+	require.NoError(t, err)
+	assert.IsType(t, &rsa.PublicKey{}, pub)
+
+	_, err = ParsePrivateKeyPEM([]byte("not pem"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = ParsePublicKeyPEM([]byte("not pem"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
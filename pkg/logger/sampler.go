@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultSampleInterval is how long WarnSampled suppresses repeats of the same key
+const DefaultSampleInterval = time.Minute
+
+// samplerMaxKeys bounds the sampler's memory; stale keys are pruned past this size
+const samplerMaxKeys = 1024
+
+var defaultSampler = NewSampler(DefaultSampleInterval)
+
+type sampleEntry struct {
+	lastLogged time.Time
+	suppressed int
+}
+
+// Sampler lets the first occurrence of a key through once per interval and counts the rest,
+// so a repeating warning (same chain, same constraint) cannot flood the logs during an incident.
+type Sampler struct {
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*sampleEntry
+}
+
+// NewSampler creates a sampler that allows one log per key per interval
+func NewSampler(interval time.Duration) *Sampler {
+	return &Sampler{
+		interval: interval,
+		now:      time.Now,
+		entries:  make(map[string]*sampleEntry),
+	}
+}
+
+// Allow reports whether key should be logged now and, if so, how many occurrences were
+// suppressed since it was last logged.
+func (s *Sampler) Allow(key string) (bool, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	entry, ok := s.entries[key]
+	if ok && now.Sub(entry.lastLogged) < s.interval {
+		entry.suppressed++
+		return false, 0
+	}
+	if !ok {
+		if len(s.entries) >= samplerMaxKeys {
+			s.prune(now)
+		}
+		entry = &sampleEntry{}
+		s.entries[key] = entry
+	}
+	suppressed := entry.suppressed
+	entry.lastLogged = now
+	entry.suppressed = 0
+	return true, suppressed
+}
+
+func (s *Sampler) prune(now time.Time) {
+	for key, entry := range s.entries {
+		if now.Sub(entry.lastLogged) >= s.interval {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// WarnSampled logs a warning at most once per DefaultSampleInterval for key. The number of
+// repeats dropped since the previous entry is attached as "suppressed".
+func WarnSampled(ctx context.Context, key, msg string, fields ...zap.Field) {
+	allowed, suppressed := defaultSampler.Allow(key)
+	if !allowed {
+		return
+	}
+	if suppressed > 0 {
+		fields = append(fields, zap.Int("suppressed", suppressed))
+	}
+	WithContext(ctx).Warn(msg, fields...)
+}
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSampler_ThrottlesPerKeyAndReportsSuppressed(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewSampler(time.Minute)
+	s.now = func() time.Time { return now }
+
+	if ok, _ := s.Allow("chain-a"); !ok {
+		t.Fatal("expected first occurrence to be allowed")
+	}
+	for i := 0; i < 3; i++ {
+		if ok, _ := s.Allow("chain-a"); ok {
+			t.Fatal("expected repeat within interval to be suppressed")
+		}
+	}
+	if ok, _ := s.Allow("chain-b"); !ok {
+		t.Fatal("expected a different key to be allowed")
+	}
+
+	now = now.Add(time.Minute)
+	ok, suppressed := s.Allow("chain-a")
+	if !ok || suppressed != 3 {
+		t.Fatalf("expected allowed with 3 suppressed, got %v %d", ok, suppressed)
+	}
+}
+
+func TestSampler_PrunesStaleKeys(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewSampler(time.Second)
+	s.now = func() time.Time { return now }
+
+	for i := 0; i < samplerMaxKeys; i++ {
+		s.Allow(time.Duration(i).String())
+	}
+	now = now.Add(time.Second)
+	s.Allow("fresh")
+	if len(s.entries) != 1 {
+		t.Fatalf("expected stale keys pruned, got %d entries", len(s.entries))
+	}
+}
+
+func TestWarnSampled(t *testing.T) {
+	Init("development")
+	WarnSampled(context.Background(), "test-key", "sampled warning")
+	WarnSampled(context.Background(), "test-key", "sampled warning")
+}
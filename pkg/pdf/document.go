@@ -0,0 +1,98 @@
+// Package pdf writes small text-only PDF documents (receipts, statements) in pure Go on top of
+// go-pdf/fpdf. Text is drawn in the DejaVu Sans fonts, which are embedded in the binary and
+// subset into every document, so accented Latin, Greek and Cyrillic names and references
+// render whatever fonts the reader has. Text is laid out left to right without shaping, and
+// DejaVu has no CJK or Thai glyphs; such characters may not draw as expected but are still
+// copied and searched as text.
+package pdf
+
+import (
+	"bytes"
+
+	"github.com/go-fonts/dejavu/dejavusans"
+	"github.com/go-fonts/dejavu/dejavusansbold"
+	"github.com/go-pdf/fpdf"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Font is one of the fonts a Document can draw with
+type Font int
+
+const (
+	Sans Font = iota
+	SansBold
+)
+
+const fontFamily = "DejaVuSans"
+
+var fontStyles = []string{"", "B"}
+
+// Document collects pages of text and lines. Coordinates are in points from the top-left corner
+// of the page.
+type Document struct {
+	pdf *fpdf.Fpdf
+}
+
+func New() *Document {
+	doc := fpdf.New("P", "pt", "A4", "")
+	doc.SetMargins(0, 0, 0)
+	doc.SetAutoPageBreak(false, 0)
+	doc.AddUTF8FontFromBytes(fontFamily, fontStyles[Sans], dejavusans.TTF)
+	doc.AddUTF8FontFromBytes(fontFamily, fontStyles[SansBold], dejavusansbold.TTF)
+	return &Document{pdf: doc}
+}
+
+// AddPage starts a new page; later drawing goes to it
+func (d *Document) AddPage() {
+	d.pdf.AddPage()
+}
+
+// PageCount is the number of pages added so far
+func (d *Document) PageCount() int {
+	return d.pdf.PageCount()
+}
+
+func (d *Document) page() {
+	if d.pdf.PageCount() == 0 {
+		d.AddPage()
+	}
+}
+
+func (d *Document) setFont(font Font, size float64) {
+	d.pdf.SetFont(fontFamily, fontStyles[font], size)
+}
+
+// Text draws s with its baseline at (x, y)
+func (d *Document) Text(x, y float64, font Font, size float64, s string) {
+	d.page()
+	d.setFont(font, size)
+	d.pdf.Text(x, y, s)
+}
+
+// Line draws a thin line from (x1, y1) to (x2, y2)
+func (d *Document) Line(x1, y1, x2, y2 float64) {
+	d.page()
+	d.pdf.SetLineWidth(0.5)
+	d.pdf.Line(x1, y1, x2, y2)
+}
+
+// TextWidth is how wide s is drawn in font at size, from the font's glyph metrics
+func (d *Document) TextWidth(font Font, size float64, s string) float64 {
+	d.setFont(font, size)
+	return d.pdf.GetStringWidth(s)
+}
+
+// Bytes renders the document. A document with no pages gets one blank page.
+func (d *Document) Bytes() ([]byte, error) {
+	d.page()
+	var out bytes.Buffer
+	if err := d.pdf.Output(&out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
@@ -0,0 +1,42 @@
+package pdf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"payment-kita.backend/pkg/pdf/pdftest"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := New()
+	doc.AddPage()
+	doc.Text(40, 60, SansBold, 18, "Receipt (copy)")
+	doc.Line(40, 70, 555, 70)
+	doc.AddPage()
+	doc.Text(40, 60, Sans, 10, `back\slash café Zürich Łódź Ωμέγα Жанна €`)
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-")))
+	require.Equal(t, "Receipt (copy)\nback\\slash café Zürich Łódź Ωμέγα Жанна €", pdftest.Text(t, out))
+	require.Contains(t, string(out), "/Count 2")
+	// Both fonts are embedded, so no text depends on the reader's fonts
+	require.Equal(t, 2, bytes.Count(out, []byte("/FontFile2")))
+	require.Contains(t, string(out), "/ToUnicode")
+}
+
+func TestDocument_TextWidth(t *testing.T) {
+	doc := New()
+	require.Greater(t, doc.TextWidth(Sans, 10, "WWW"), doc.TextWidth(Sans, 10, "iii"))
+	require.Greater(t, doc.TextWidth(SansBold, 10, "abc"), doc.TextWidth(Sans, 10, "abc"))
+	require.InDelta(t, 2*doc.TextWidth(Sans, 10, "Жанна"), doc.TextWidth(Sans, 20, "Жанна"), 0.01)
+}
+
+func TestDocument_EmptyHasOnePage(t *testing.T) {
+	doc := New()
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, 1, doc.PageCount())
+	require.Contains(t, string(out), "/Count 1")
+}
@@ -0,0 +1,69 @@
+// Package pdftest reads back the text of documents written by package pdf, so tests can check
+// what a PDF says without a full PDF parser.
+package pdftest
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+var (
+	streamPattern = regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`)
+	textPattern   = regexp.MustCompile(`(?s)\(((?:\\.|[^\\)])*)\) Tj`)
+)
+
+// Text is every string drawn in data, one per line in drawing order. Page content streams are
+// inflated and each string is decoded from the UTF-16 the embedded fonts are addressed in.
+func Text(tb testing.TB, data []byte) string {
+	tb.Helper()
+	var lines []string
+	for _, stream := range streamPattern.FindAllSubmatch(data, -1) {
+		content := stream[1]
+		if reader, err := zlib.NewReader(bytes.NewReader(content)); err == nil {
+			if inflated, err := io.ReadAll(reader); err == nil {
+				content = inflated
+			}
+		}
+		if !bytes.Contains(content, []byte(") Tj")) {
+			continue
+		}
+		for _, text := range textPattern.FindAllSubmatch(content, -1) {
+			lines = append(lines, decodeUTF16(unescape(text[1])))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// unescape undoes the backslash escapes of a PDF string literal
+func unescape(s []byte) []byte {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			switch s[i] {
+			case 'r':
+				out = append(out, '\r')
+			case 'n':
+				out = append(out, '\n')
+			default:
+				out = append(out, s[i])
+			}
+			continue
+		}
+		out = append(out, s[i])
+	}
+	return out
+}
+
+func decodeUTF16(b []byte) string {
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -9,6 +11,14 @@ import (
 
 var client *redis.Client
 
+// ErrUnavailable is returned by every command while Redis is marked unavailable, so callers
+// fail fast instead of waiting on a dial timeout
+var ErrUnavailable = errors.New("redis unavailable")
+
+// unavailable is set when the server runs without Redis (REDIS_REQUIRED=false) and Redis could
+// not be reached. The zero value means available.
+var unavailable atomic.Bool
+
 var pingClient = func(ctx context.Context, c *redis.Client) error {
 	return c.Ping(ctx).Err()
 }
@@ -33,9 +43,47 @@ func Init(url, password string) error {
 		return err
 	}
 
+	unavailable.Store(false)
 	return nil
 }
 
+// Available reports whether Redis is usable. Features that only need Redis for convenience
+// (idempotency, rate limiting, sessions) check it to degrade instead of failing.
+func Available() bool {
+	return !unavailable.Load()
+}
+
+// SetAvailable marks Redis usable or not (used by Monitor, at startup and in tests)
+func SetAvailable(ok bool) {
+	unavailable.Store(!ok)
+}
+
+// Monitor pings Redis every interval until ctx is done, updating Available. onChange, if set,
+// is called whenever availability flips.
+func Monitor(ctx context.Context, interval time.Duration, onChange func(available bool)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if client == nil {
+			continue
+		}
+		pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		ok := pingClient(pingCtx, client) == nil
+		cancel()
+		if ok != Available() {
+			SetAvailable(ok)
+			if onChange != nil {
+				onChange(ok)
+			}
+		}
+	}
+}
+
 // SetClient sets the Redis client (used for testing)
 func SetClient(c *redis.Client) {
 	client = c
@@ -48,35 +96,98 @@ func GetClient() *redis.Client {
 
 // Set stores a key-value pair with expiration
 func Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if !Available() {
+		return ErrUnavailable
+	}
 	return client.Set(ctx, key, value, expiration).Err()
 }
 
 // Get retrieves a value by key
 func Get(ctx context.Context, key string) (string, error) {
+	if !Available() {
+		return "", ErrUnavailable
+	}
 	return client.Get(ctx, key).Result()
 }
 
 // Del removes a key
 func Del(ctx context.Context, key string) error {
+	if !Available() {
+		return ErrUnavailable
+	}
 	return client.Del(ctx, key).Err()
 }
 
 // SetNX sets a key only if it does not exist
 func SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	if !Available() {
+		return false, ErrUnavailable
+	}
 	return client.SetNX(ctx, key, value, expiration).Result()
 }
 
 // SetEX sets a key with value and expiration (alias for Set)
 func SetEX(ctx context.Context, key string, value string, expiration time.Duration) error {
+	if !Available() {
+		return ErrUnavailable
+	}
 	return client.Set(ctx, key, value, expiration).Err()
 }
 
 // Incr increments a key
 func Incr(ctx context.Context, key string) (int64, error) {
+	if !Available() {
+		return 0, ErrUnavailable
+	}
 	return client.Incr(ctx, key).Result()
 }
 
 // Expire sets expiration for a key
 func Expire(ctx context.Context, key string, expiration time.Duration) (bool, error) {
+	if !Available() {
+		return false, ErrUnavailable
+	}
 	return client.Expire(ctx, key, expiration).Result()
 }
+
+// SAdd adds members to the set at key
+func SAdd(ctx context.Context, key string, members ...interface{}) error {
+	if !Available() {
+		return ErrUnavailable
+	}
+	return client.SAdd(ctx, key, members...).Err()
+}
+
+// SMembers returns every member of the set at key
+func SMembers(ctx context.Context, key string) ([]string, error) {
+	if !Available() {
+		return nil, ErrUnavailable
+	}
+	return client.SMembers(ctx, key).Result()
+}
+
+// delIfValueScript deletes KEYS[1] only while it holds ARGV[1]
+var delIfValueScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`)
+
+// DelIfValue removes key only while it still holds value, so a lock holder never releases a
+// lock that expired and was taken by someone else
+func DelIfValue(ctx context.Context, key, value string) (bool, error) {
+	if !Available() {
+		return false, ErrUnavailable
+	}
+	n, err := delIfValueScript.Run(ctx, client, []string{key}, value).Int()
+	return n == 1, err
+}
+
+// expireIfValueScript resets the TTL of KEYS[1] to ARGV[2] ms only while it holds ARGV[1]
+var expireIfValueScript = redis.NewScript(`if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("PEXPIRE", KEYS[1], ARGV[2]) end return 0`)
+
+// ExpireIfValue extends key by expiration only while it still holds value, so a lease holder
+// never renews a lease that expired and was taken by someone else
+func ExpireIfValue(ctx context.Context, key, value string, expiration time.Duration) (bool, error) {
+	if !Available() {
+		return false, ErrUnavailable
+	}
+	n, err := expireIfValueScript.Run(ctx, client, []string{key}, value, expiration.Milliseconds()).Int()
+	return n == 1, err
+}
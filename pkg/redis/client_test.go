@@ -83,4 +83,54 @@ func TestInitAndBasicOpsSuccessWithMiniRedis(t *testing.T) {
 	assert.NoError(t, Del(ctx, "k1"))
 	_, err = Get(ctx, "k1")
 	assert.Error(t, err)
+
+	extended, err := ExpireIfValue(ctx, "k2", "other", time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, extended)
+	extended, err = ExpireIfValue(ctx, "k2", "v2", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, extended)
+	assert.Equal(t, time.Hour, srv.TTL("k2"))
+
+	deleted, err := DelIfValue(ctx, "k2", "other")
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+	deleted, err = DelIfValue(ctx, "k2", "v2")
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+	_, err = Get(ctx, "k2")
+	assert.Error(t, err)
+}
+
+func TestUnavailableShortCircuitsAndMonitorRecovers(t *testing.T) {
+	srv, err := miniredis.Run()
+	if err != nil {
+		t.Skipf("skip: miniredis unavailable: %v", err)
+	}
+	defer srv.Close()
+	SetClient(goredis.NewClient(&goredis.Options{Addr: srv.Addr()}))
+	t.Cleanup(func() { SetAvailable(true) })
+
+	ctx := context.Background()
+	SetAvailable(false)
+	assert.False(t, Available())
+	assert.ErrorIs(t, Set(ctx, "k", "v", time.Second), ErrUnavailable)
+	_, err = Incr(ctx, "k")
+	assert.ErrorIs(t, err, ErrUnavailable)
+	_, err = SetNX(ctx, "k", "v", time.Second)
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	changes := make(chan bool, 1)
+	monitorCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go Monitor(monitorCtx, 10*time.Millisecond, func(available bool) { changes <- available })
+
+	select {
+	case available := <-changes:
+		assert.True(t, available)
+	case <-time.After(2 * time.Second):
+		t.Fatal("monitor did not report recovery")
+	}
+	assert.True(t, Available())
+	assert.NoError(t, Set(ctx, "k", "v", time.Second))
 }
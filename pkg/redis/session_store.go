@@ -12,10 +12,12 @@ import (
 	"time"
 )
 
-// SessionData holds the data stored in the session
+// SessionData holds the data stored in the session. UserID, when set, indexes the session
+// under its user so DeleteUserSessions can revoke it.
 type SessionData struct {
 	AccessToken  string `json:"accessToken"`
 	RefreshToken string `json:"refreshToken"`
+	UserID       string `json:"userId,omitempty"`
 }
 
 // SessionStore handles session storage in Redis with encryption
@@ -27,6 +29,9 @@ var (
 	setSessionValue = Set
 	getSessionValue = Get
 	delSessionValue = Del
+	addUserSession = SAdd
+	listUserSessions = SMembers
+	expireUserSessions = Expire
 	marshalSessionJSON = json.Marshal
 	sessionStoreRandReader = rand.Reader
 	newSessionStoreGCM = cipher.NewGCM
@@ -56,7 +61,18 @@ func (s *SessionStore) CreateSession(ctx context.Context, sessionID string, data
 		return err
 	}
 
-	return setSessionValue(ctx, "session:"+sessionID, encryptedData, expiration)
+	if err := setSessionValue(ctx, "session:"+sessionID, encryptedData, expiration); err != nil {
+		return err
+	}
+	if data.UserID == "" {
+		return nil
+	}
+	// The index lives as long as the newest session in it
+	if err := addUserSession(ctx, userSessionsKey(data.UserID), sessionID); err != nil {
+		return err
+	}
+	_, err = expireUserSessions(ctx, userSessionsKey(data.UserID), expiration)
+	return err
 }
 
 // GetSession retrieves and decrypts session data from Redis
@@ -84,6 +100,24 @@ func (s *SessionStore) DeleteSession(ctx context.Context, sessionID string) erro
 	return delSessionValue(ctx, "session:"+sessionID)
 }
 
+// DeleteUserSessions removes every indexed session of a user, e.g. when the account is suspended
+func (s *SessionStore) DeleteUserSessions(ctx context.Context, userID string) error {
+	sessionIDs, err := listUserSessions(ctx, userSessionsKey(userID))
+	if err != nil {
+		return err
+	}
+	for _, sessionID := range sessionIDs {
+		if err := delSessionValue(ctx, "session:"+sessionID); err != nil {
+			return err
+		}
+	}
+	return delSessionValue(ctx, userSessionsKey(userID))
+}
+
+func userSessionsKey(userID string) string {
+	return "user_sessions:" + userID
+}
+
 func (s *SessionStore) encrypt(plaintext []byte) (string, error) {
 	block, err := aes.NewCipher(s.encryptionKey)
 	if err != nil {
@@ -101,6 +101,41 @@ func TestSessionStoreCreateGetDeleteSuccess(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSessionStore_DeleteUserSessions(t *testing.T) {
+	srv, err := miniredis.Run()
+	if err != nil {
+		t.Skipf("skip: miniredis unavailable in this environment: %v", err)
+	}
+	defer srv.Close()
+
+	cli := goredis.NewClient(&goredis.Options{Addr: srv.Addr()})
+	SetClient(cli)
+	defer cli.Close()
+
+	store, err := NewSessionStore("0000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, store.CreateSession(ctx, "sid-u1-a", &SessionData{AccessToken: "a", UserID: "u1"}, time.Minute))
+	assert.NoError(t, store.CreateSession(ctx, "sid-u1-b", &SessionData{AccessToken: "b", UserID: "u1"}, time.Hour))
+	assert.NoError(t, store.CreateSession(ctx, "sid-u2", &SessionData{AccessToken: "c", UserID: "u2"}, time.Minute))
+	assert.Equal(t, time.Hour, srv.TTL("user_sessions:u1"))
+
+	assert.NoError(t, store.DeleteUserSessions(ctx, "u1"))
+	_, err = store.GetSession(ctx, "sid-u1-a")
+	assert.Error(t, err)
+	_, err = store.GetSession(ctx, "sid-u1-b")
+	assert.Error(t, err)
+	assert.False(t, srv.Exists("user_sessions:u1"))
+
+	data, err := store.GetSession(ctx, "sid-u2")
+	assert.NoError(t, err)
+	assert.Equal(t, "u2", data.UserID)
+
+	// A user without sessions has nothing to delete
+	assert.NoError(t, store.DeleteUserSessions(ctx, "u3"))
+}
+
 func TestSessionStore_GetSessionInvalidJSONPayload(t *testing.T) {
 	srv, err := miniredis.Run()
 	if err != nil {
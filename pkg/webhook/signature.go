@@ -0,0 +1,106 @@
+// Package webhook signs and verifies PaymentKita webhook deliveries. The dispatcher signs with
+// it, so it is the reference implementation of the scheme below. It only uses the standard
+// library.
+//
+// Each delivery carries X-Webhook-Timestamp, the Unix time in seconds it was signed at, and
+// X-Webhook-Signature, the lowercase hex HMAC-SHA256 of "<timestamp>.<raw body>" keyed with the
+// merchant's webhook secret. Verification recomputes the signature over the raw body, exactly as
+// received, compares it in constant time and rejects timestamps too far from the current time.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers set on every delivery
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery-Id"
+	// LegacySignatureHeader signs "<timestamp><raw body>" with no separator, for receivers
+	// written before the canonical scheme. New receivers should ignore it.
+	LegacySignatureHeader = "X-Webhook-Signature-Legacy"
+)
+
+// DefaultTolerance is how far a delivery's timestamp may be from the receiver's clock
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrMissingSignature = errors.New("webhook signature or timestamp missing")
+	ErrInvalidTimestamp = errors.New("webhook timestamp is not a unix time")
+	ErrTimestampExpired = errors.New("webhook timestamp outside tolerance")
+	ErrInvalidSignature = errors.New("webhook signature does not match")
+)
+
+// SignedPayload is the message a delivery's signature covers: "<timestamp>.<raw body>"
+func SignedPayload(timestamp string, payload []byte) string {
+	return timestamp + "." + string(payload)
+}
+
+// Sign returns the X-Webhook-Signature value for payload sent at timestamp (Unix seconds)
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(SignedPayload(timestamp, payload)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignLegacy returns the X-Webhook-Signature-Legacy value: the same HMAC over
+// "<timestamp><raw body>", with no separator
+func SignLegacy(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks signature and timestamp (the X-Webhook-Signature and X-Webhook-Timestamp
+// values) against the raw payload. A tolerance of zero uses DefaultTolerance.
+func Verify(secret string, payload []byte, timestamp, signature string, tolerance time.Duration) error {
+	return verifyAt(secret, payload, timestamp, signature, tolerance, time.Now())
+}
+
+// VerifyRequest verifies an incoming delivery and returns its body. The body is read in full
+// and put back, so the handler can decode it afterwards.
+func VerifyRequest(r *http.Request, secret string, tolerance time.Duration) ([]byte, error) {
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(payload))
+	if err := Verify(secret, payload, r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), tolerance); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func verifyAt(secret string, payload []byte, timestamp, signature string, tolerance time.Duration, now time.Time) error {
+	timestamp = strings.TrimSpace(timestamp)
+	signature = strings.ToLower(strings.TrimSpace(signature))
+	if timestamp == "" || signature == "" {
+		return ErrMissingSignature
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return ErrTimestampExpired
+	}
+	if !hmac.Equal([]byte(Sign(secret, timestamp, payload)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign_MatchesDocumentedScheme(t *testing.T) {
+	// HMAC-SHA256("whsec", "1700000000.{\"id\":\"pay_1\"}"), as documented for other languages
+	require.Equal(t,
+		"119ec37ab92e619cc2a90a832275d2028c02df38a7844b3fdaca87c4d454b594",
+		Sign("whsec", "1700000000", []byte(`{"id":"pay_1"}`)))
+	require.Equal(t, `1700000000.{"id":"pay_1"}`, SignedPayload("1700000000", []byte(`{"id":"pay_1"}`)))
+	require.Len(t, Sign("whsec", "1700000000", nil), 64)
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	payload := []byte(`{"event":"payment.completed"}`)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	sig := Sign("whsec", ts, payload)
+
+	require.NoError(t, verifyAt("whsec", payload, ts, sig, 0, now))
+	require.NoError(t, verifyAt("whsec", payload, ts, " "+string(bytes.ToUpper([]byte(sig)))+" ", 0, now.Add(4*time.Minute)))
+
+	require.ErrorIs(t, verifyAt("whsec", payload, "", sig, 0, now), ErrMissingSignature)
+	require.ErrorIs(t, verifyAt("whsec", payload, ts, "", 0, now), ErrMissingSignature)
+	require.ErrorIs(t, verifyAt("whsec", payload, "yesterday", sig, 0, now), ErrInvalidTimestamp)
+	require.ErrorIs(t, verifyAt("whsec", payload, ts, sig, 0, now.Add(6*time.Minute)), ErrTimestampExpired)
+	require.ErrorIs(t, verifyAt("whsec", payload, ts, sig, 0, now.Add(-6*time.Minute)), ErrTimestampExpired)
+	require.NoError(t, verifyAt("whsec", payload, ts, sig, time.Hour, now.Add(30*time.Minute)))
+
+	require.ErrorIs(t, verifyAt("other", payload, ts, sig, 0, now), ErrInvalidSignature)
+	require.ErrorIs(t, verifyAt("whsec", []byte(`{"event":"payment.failed"}`), ts, sig, 0, now), ErrInvalidSignature)
+	// Replaying the body under a fresh timestamp needs a new signature
+	fresh := strconv.FormatInt(now.Unix()+60, 10)
+	require.ErrorIs(t, verifyAt("whsec", payload, fresh, sig, 0, now), ErrInvalidSignature)
+	// The legacy signature is not accepted as the canonical one
+	legacy := "c1dbb38f8f0ae916bae770f09741bdd943192a9ca9b773bea9c37891f68c0d44"
+	require.Equal(t, legacy, SignLegacy("whsec", ts, payload))
+	require.ErrorIs(t, verifyAt("whsec", payload, ts, legacy, 0, now), ErrInvalidSignature)
+}
+
+func TestVerifyRequest(t *testing.T) {
+	payload := []byte(`{"event":"payment.completed"}`)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req := httptest.NewRequest("POST", "/webhooks/payment-kita", bytes.NewReader(payload))
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(SignatureHeader, Sign("whsec", ts, payload))
+	body, err := VerifyRequest(req, "whsec", 0)
+	require.NoError(t, err)
+	require.Equal(t, payload, body)
+	// The body is still readable by the handler
+	again, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, payload, again)
+
+	req = httptest.NewRequest("POST", "/webhooks/payment-kita", bytes.NewReader(payload))
+	req.Header.Set(TimestampHeader, ts)
+	req.Header.Set(SignatureHeader, Sign("wrong", ts, payload))
+	_, err = VerifyRequest(req, "whsec", 0)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+}